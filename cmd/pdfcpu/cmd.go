@@ -44,6 +44,16 @@ func (c command) String() string {
 	return fmt.Sprintf("cmd: <%s> <%s>\n", c.usageShort, c.usageLong)
 }
 
+// subCommand returns true if c is invoked with one of its optional sub commands.
+// Commands having a handler only support sub commands without completion.
+func (c command) subCommand() bool {
+	if c.cmdMap == nil || len(os.Args) < 3 {
+		return false
+	}
+	_, ok := c.cmdMap[os.Args[2]]
+	return ok
+}
+
 type commandMap map[string]*command
 
 func newCommandMap() commandMap {
@@ -59,10 +69,10 @@ func parseFlags(cmd *command) {
 	i := 2
 
 	// This command uses a subcommand and is therefore a special case => start flag processing after 3rd argument.
-	if cmd.handler == nil {
+	if cmd.handler == nil || cmd.subCommand() {
 		if len(os.Args) == 2 {
 			fmt.Fprintln(os.Stderr, cmd.usageShort)
-			os.Exit(1)
+			os.Exit(exitUsage)
 		}
 		i = 3
 	}
@@ -71,7 +81,7 @@ func parseFlags(cmd *command) {
 	if !flag.CommandLine.Parsed() {
 		err := flag.CommandLine.Parse(os.Args[i:])
 		if err != nil {
-			os.Exit(1)
+			os.Exit(exitUsage)
 		}
 		initLogging(verbose, veryVerbose)
 	}
@@ -86,6 +96,9 @@ func parseFlags(cmd *command) {
 		if f.Name == "optimize" || f.Name == "opt" {
 			optimizeSet = true
 		}
+		if f.Name == "perm" {
+			permSet = true
+		}
 	})
 }
 
@@ -95,14 +108,14 @@ func validateConfigDirFlag() {
 		if err != nil {
 			if os.IsNotExist(err) {
 				fmt.Fprintf(os.Stderr, "conf: %s does not exist\n\n", conf)
-				os.Exit(1)
+				os.Exit(exitIO)
 			}
 			fmt.Fprintf(os.Stderr, "conf: %s %v\n\n", conf, err)
-			os.Exit(1)
+			os.Exit(exitIO)
 		}
 		if !info.IsDir() {
 			fmt.Fprintf(os.Stderr, "conf: %s not a directory\n\n", conf)
-			os.Exit(1)
+			os.Exit(exitUsage)
 		}
 		model.ConfigPath = conf
 		return
@@ -155,7 +168,10 @@ func (m commandMap) process(cmdPrefix string, command string) (string, error) {
 		conf.Offline = offline
 	}
 
-	if m[cmdStr].handler != nil {
+	conf.DryRun = dryRun
+	backup.apply(conf)
+
+	if m[cmdStr].handler != nil && !m[cmdStr].subCommand() {
 
 		if conf.Version != model.VersionStr && cmdStr != "reset" {
 			model.CheckConfigVersion(conf.Version)
@@ -167,7 +183,7 @@ func (m commandMap) process(cmdPrefix string, command string) (string, error) {
 
 	if len(os.Args) == 2 {
 		fmt.Fprintln(os.Stderr, m[cmdStr].usageShort)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	return m[cmdStr].cmdMap.process(os.Args[2], cmdStr)
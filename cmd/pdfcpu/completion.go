@@ -0,0 +1,290 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bufio"
+	enc "encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+)
+
+// helpJSONFlag triggers a machine readable dump of all commands and flags.
+const helpJSONFlag = "--help-json"
+
+type cliCommand struct {
+	Name        string       `json:"name"`
+	Description string       `json:"description,omitempty"`
+	Usage       string       `json:"usage,omitempty"`
+	Help        string       `json:"help,omitempty"`
+	SubCommands []cliCommand `json:"subcommands,omitempty"`
+}
+
+type cliFlag struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Default string `json:"default,omitempty"`
+	Usage   string `json:"usage,omitempty"`
+}
+
+type cliHelp struct {
+	Version  string       `json:"version"`
+	Commands []cliCommand `json:"commands"`
+	Flags    []cliFlag    `json:"flags"`
+}
+
+// commandDescriptions returns the one line command descriptions of the usage text.
+func commandDescriptions() map[string]string {
+	m := map[string]string{}
+	s := bufio.NewScanner(strings.NewReader(usage))
+	for s.Scan() {
+		line := s.Text()
+		if !strings.HasPrefix(line, "   ") || strings.HasPrefix(line, "    ") {
+			continue
+		}
+		ss := strings.Fields(line)
+		if len(ss) < 2 {
+			continue
+		}
+		if _, ok := cmdMap[ss[0]]; ok {
+			m[ss[0]] = strings.Join(ss[1:], " ")
+		}
+	}
+	return m
+}
+
+// visible returns true if the command cmdStr shows up in completions and machine readable help.
+func (c command) visible(cmdStr string) bool {
+	return c.usageShort != "" || cmdStr == "help"
+}
+
+// sortedCommands returns the sorted names of all visible commands of m.
+func (m commandMap) sortedCommands() []string {
+	ss := []string{}
+	for k, c := range m {
+		if c.visible(k) {
+			ss = append(ss, k)
+		}
+	}
+	sort.Strings(ss)
+	return ss
+}
+
+func (m commandMap) cliCommands(descriptions map[string]string) []cliCommand {
+	cc := []cliCommand{}
+	for _, k := range m.sortedCommands() {
+		c := m[k]
+		cmd := cliCommand{Name: k, Description: descriptions[k], Usage: c.usageShort, Help: c.usageLong}
+		if c.cmdMap != nil {
+			cmd.SubCommands = c.cmdMap.cliCommands(nil)
+		}
+		cc = append(cc, cmd)
+	}
+	return cc
+}
+
+func flagType(f *flag.Flag) string {
+	if bf, ok := f.Value.(interface{ IsBoolFlag() bool }); ok && bf.IsBoolFlag() {
+		return "bool"
+	}
+	if g, ok := f.Value.(flag.Getter); ok {
+		switch g.Get().(type) {
+		case int:
+			return "int"
+		case float64:
+			return "float"
+		}
+	}
+	return "string"
+}
+
+func cliFlags() []cliFlag {
+	ff := []cliFlag{}
+	flag.VisitAll(func(f *flag.Flag) {
+		ff = append(ff, cliFlag{Name: f.Name, Type: flagType(f), Default: f.DefValue, Usage: f.Usage})
+	})
+	return ff
+}
+
+func flagNames() []string {
+	ss := []string{}
+	flag.VisitAll(func(f *flag.Flag) {
+		ss = append(ss, "-"+f.Name)
+	})
+	return ss
+}
+
+// printHelpJSON writes all commands including sub commands and all flags as JSON to w.
+func printHelpJSON(w io.Writer) error {
+	h := cliHelp{
+		Version:  version,
+		Commands: cmdMap.cliCommands(commandDescriptions()),
+		Flags:    cliFlags(),
+	}
+	e := enc.NewEncoder(w)
+	e.SetIndent("", "\t")
+	return e.Encode(h)
+}
+
+func subCommandNames(cmdStr string) []string {
+	c := cmdMap[cmdStr]
+	if c.cmdMap == nil {
+		return nil
+	}
+	return c.cmdMap.sortedCommands()
+}
+
+func bashCompletion(w io.Writer) {
+	fmt.Fprintln(w, "# bash completion for pdfcpu")
+	fmt.Fprintln(w, "# Load with: source <(pdfcpu completion bash)")
+	fmt.Fprintln(w, "_pdfcpu() {")
+	fmt.Fprintln(w, "    local cur=\"${COMP_WORDS[COMP_CWORD]}\"")
+	fmt.Fprintln(w, "    COMPREPLY=()")
+	fmt.Fprintln(w, "    if [[ $cur == -* ]]; then")
+	fmt.Fprintf(w, "        COMPREPLY=( $(compgen -W %q -- \"$cur\") )\n", strings.Join(flagNames(), " "))
+	fmt.Fprintln(w, "        return")
+	fmt.Fprintln(w, "    fi")
+	fmt.Fprintln(w, "    if [[ $COMP_CWORD -eq 1 ]]; then")
+	fmt.Fprintf(w, "        COMPREPLY=( $(compgen -W %q -- \"$cur\") )\n", strings.Join(cmdMap.sortedCommands(), " "))
+	fmt.Fprintln(w, "        return")
+	fmt.Fprintln(w, "    fi")
+	fmt.Fprintln(w, "    if [[ $COMP_CWORD -eq 2 ]]; then")
+	fmt.Fprintln(w, "        case \"${COMP_WORDS[1]}\" in")
+	for _, k := range cmdMap.sortedCommands() {
+		if ss := subCommandNames(k); len(ss) > 0 {
+			fmt.Fprintf(w, "            %s) COMPREPLY=( $(compgen -W %q -- \"$cur\") ) ;;\n", k, strings.Join(ss, " "))
+		}
+	}
+	fmt.Fprintln(w, "        esac")
+	fmt.Fprintln(w, "    fi")
+	fmt.Fprintln(w, "}")
+	fmt.Fprintln(w, "complete -o default -F _pdfcpu pdfcpu")
+}
+
+func zshCompletion(w io.Writer) {
+	descriptions := commandDescriptions()
+	fmt.Fprintln(w, "#compdef pdfcpu")
+	fmt.Fprintln(w, "# Load with: source <(pdfcpu completion zsh)")
+	fmt.Fprintln(w, "_pdfcpu() {")
+	fmt.Fprintln(w, "    local -a commands flags")
+	fmt.Fprintln(w, "    commands=(")
+	for _, k := range cmdMap.sortedCommands() {
+		desc := strings.ReplaceAll(descriptions[k], "'", "'\\''")
+		fmt.Fprintf(w, "        '%s:%s'\n", k, strings.ReplaceAll(desc, ":", "\\:"))
+	}
+	fmt.Fprintln(w, "    )")
+	fmt.Fprintf(w, "    flags=(%s)\n", strings.Join(flagNames(), " "))
+	fmt.Fprintln(w, "    if [[ $words[CURRENT] == -* ]]; then")
+	fmt.Fprintln(w, "        compadd -- $flags")
+	fmt.Fprintln(w, "        return")
+	fmt.Fprintln(w, "    fi")
+	fmt.Fprintln(w, "    if (( CURRENT == 2 )); then")
+	fmt.Fprintln(w, "        _describe 'command' commands")
+	fmt.Fprintln(w, "        return")
+	fmt.Fprintln(w, "    fi")
+	fmt.Fprintln(w, "    if (( CURRENT == 3 )); then")
+	fmt.Fprintln(w, "        case $words[2] in")
+	for _, k := range cmdMap.sortedCommands() {
+		if ss := subCommandNames(k); len(ss) > 0 {
+			fmt.Fprintf(w, "            %s) compadd -- %s; return ;;\n", k, strings.Join(ss, " "))
+		}
+	}
+	fmt.Fprintln(w, "        esac")
+	fmt.Fprintln(w, "    fi")
+	fmt.Fprintln(w, "    _files")
+	fmt.Fprintln(w, "}")
+	fmt.Fprintln(w, "compdef _pdfcpu pdfcpu")
+}
+
+func fishCompletion(w io.Writer) {
+	descriptions := commandDescriptions()
+	fmt.Fprintln(w, "# fish completion for pdfcpu")
+	fmt.Fprintln(w, "# Load with: pdfcpu completion fish | source")
+	for _, k := range cmdMap.sortedCommands() {
+		desc := strings.ReplaceAll(descriptions[k], "'", "\\'")
+		fmt.Fprintf(w, "complete -c pdfcpu -n __fish_use_subcommand -a %s -d '%s'\n", k, desc)
+		if ss := subCommandNames(k); len(ss) > 0 {
+			fmt.Fprintf(w, "complete -c pdfcpu -n '__fish_seen_subcommand_from %s' -a '%s'\n", k, strings.Join(ss, " "))
+		}
+	}
+	flag.VisitAll(func(f *flag.Flag) {
+		desc := strings.ReplaceAll(f.Usage, "'", "\\'")
+		fmt.Fprintf(w, "complete -c pdfcpu -o %s -d '%s'\n", f.Name, desc)
+	})
+}
+
+func quotedList(ss []string) string {
+	qq := make([]string, len(ss))
+	for i, s := range ss {
+		qq[i] = "'" + s + "'"
+	}
+	return strings.Join(qq, ", ")
+}
+
+func powershellCompletion(w io.Writer) {
+	fmt.Fprintln(w, "# PowerShell completion for pdfcpu")
+	fmt.Fprintln(w, "# Load with: pdfcpu completion powershell | Out-String | Invoke-Expression")
+	fmt.Fprintln(w, "Register-ArgumentCompleter -Native -CommandName pdfcpu -ScriptBlock {")
+	fmt.Fprintln(w, "    param($wordToComplete, $commandAst, $cursorPosition)")
+	fmt.Fprintf(w, "    $commands = @(%s)\n", quotedList(cmdMap.sortedCommands()))
+	fmt.Fprintln(w, "    $subCommands = @{")
+	for _, k := range cmdMap.sortedCommands() {
+		if ss := subCommandNames(k); len(ss) > 0 {
+			fmt.Fprintf(w, "        '%s' = @(%s)\n", k, quotedList(ss))
+		}
+	}
+	fmt.Fprintln(w, "    }")
+	fmt.Fprintf(w, "    $flags = @(%s)\n", quotedList(flagNames()))
+	fmt.Fprintln(w, "    $words = @($commandAst.CommandElements | ForEach-Object { $_.ToString() })")
+	fmt.Fprintln(w, "    $pos = $words.Count")
+	fmt.Fprintln(w, "    if ($wordToComplete -ne '') { $pos-- }")
+	fmt.Fprintln(w, "    if ($wordToComplete.StartsWith('-')) { $candidates = $flags }")
+	fmt.Fprintln(w, "    elseif ($pos -eq 1) { $candidates = $commands }")
+	fmt.Fprintln(w, "    elseif ($pos -eq 2 -and $subCommands.ContainsKey($words[1])) { $candidates = $subCommands[$words[1]] }")
+	fmt.Fprintln(w, "    else { return }")
+	fmt.Fprintln(w, "    $candidates | Where-Object { $_ -like \"$wordToComplete*\" } | ForEach-Object {")
+	fmt.Fprintln(w, "        [System.Management.Automation.CompletionResult]::new($_, $_, 'ParameterValue', $_)")
+	fmt.Fprintln(w, "    }")
+	fmt.Fprintln(w, "}")
+}
+
+func processCompletionCommand(conf *model.Configuration) {
+	if len(flag.Args()) != 1 {
+		fmt.Fprintf(os.Stderr, "%s\n\n", usageCompletion)
+		os.Exit(exitUsage)
+	}
+
+	switch flag.Arg(0) {
+	case "bash":
+		bashCompletion(os.Stdout)
+	case "zsh":
+		zshCompletion(os.Stdout)
+	case "fish":
+		fishCompletion(os.Stdout)
+	case "powershell", "pwsh":
+		powershellCompletion(os.Stdout)
+	default:
+		fmt.Fprintf(os.Stderr, "%s\n\n", usageCompletion)
+		os.Exit(exitUsage)
+	}
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+)
+
+// Exit codes for scripting.
+const (
+	exitOK               = 0
+	exitError            = 1 // Any other error.
+	exitUsage            = 2 // Unknown command, bad arguments or flags.
+	exitIO               = 3 // Missing, unreadable or unwritable file.
+	exitValidation       = 4 // Validation failure, also warnings with -strict.
+	exitPasswordRequired = 5 // Missing or wrong password for an encrypted file.
+	exitPermission       = 6 // Operation restricted by the permissions of an encrypted file.
+)
+
+// exitCode returns the exit code for a command failing with err.
+func exitCode(err error) int {
+	if err == nil {
+		return exitOK
+	}
+
+	if errors.Is(err, errUnknownCmd) || errors.Is(err, errAmbiguousCmd) {
+		return exitUsage
+	}
+
+	if errors.Is(err, pdfcpu.ErrWrongPassword) || errors.Is(err, pdfcpu.ErrOwnerPasswordRequired) {
+		return exitPasswordRequired
+	}
+
+	var permErr *pdfcpu.PermissionError
+	if errors.As(err, &permErr) {
+		return exitPermission
+	}
+
+	if errors.Is(err, api.ErrValidation) {
+		return exitValidation
+	}
+
+	var pathErr *fs.PathError
+	if errors.As(err, &pathErr) || errors.Is(err, fs.ErrNotExist) || errors.Is(err, fs.ErrPermission) {
+		return exitIO
+	}
+
+	return exitError
+}
+
+// exitOnWarnings terminates with exitValidation if warnings are treated as errors and any got reported.
+func exitOnWarnings() {
+	if !strict {
+		return
+	}
+	if n := model.WarningCount(); n > 0 {
+		fmt.Fprintf(os.Stderr, "pdfcpu: %d warning(s) treated as errors\n", n)
+		os.Exit(exitValidation)
+	}
+}
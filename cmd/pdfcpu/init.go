@@ -19,14 +19,19 @@ package main
 import (
 	"flag"
 
+	"github.com/pdfcpu/pdfcpu/pkg/api"
 	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
 )
 
 func initAnnotsCmdMap() commandMap {
 	m := newCommandMap()
 	for k, v := range map[string]command{
-		"list":   {processListAnnotationsCommand, nil, "", ""},
-		"remove": {processRemoveAnnotationsCommand, nil, "", ""},
+		"list":        {processListAnnotationsCommand, nil, "", ""},
+		"add":         {processAddAnnotationsCommand, nil, "", ""},
+		"remove":      {processRemoveAnnotationsCommand, nil, "", ""},
+		"export":      {processExportAnnotationsCommand, nil, "", ""},
+		"appearances": {processGenerateAnnotationAppearancesCommand, nil, "", ""},
 	} {
 		m.register(k, v)
 	}
@@ -40,6 +45,7 @@ func initAttachCmdMap() commandMap {
 		"add":     {processAddAttachmentsCommand, nil, "", ""},
 		"remove":  {processRemoveAttachmentsCommand, nil, "", ""},
 		"extract": {processExtractAttachmentsCommand, nil, "", ""},
+		"verify":  {processVerifyAttachmentsCommand, nil, "", ""},
 	} {
 		m.register(k, v)
 	}
@@ -49,10 +55,14 @@ func initAttachCmdMap() commandMap {
 func initBookmarksCmdMap() commandMap {
 	m := newCommandMap()
 	for k, v := range map[string]command{
-		"list":   {processListBookmarksCommand, nil, "", ""},
-		"import": {processImportBookmarksCommand, nil, "", ""},
-		"export": {processExportBookmarksCommand, nil, "", ""},
-		"remove": {processRemoveBookmarksCommand, nil, "", ""},
+		"list":     {processListBookmarksCommand, nil, "", ""},
+		"import":   {processImportBookmarksCommand, nil, "", ""},
+		"export":   {processExportBookmarksCommand, nil, "", ""},
+		"remove":   {processRemoveBookmarksCommand, nil, "", ""},
+		"sort":     {processSortBookmarksCommand, nil, "", ""},
+		"dedup":    {processDedupBookmarksCommand, nil, "", ""},
+		"collapse": {processCollapseBookmarksCommand, nil, "", ""},
+		"retarget": {processRetargetBookmarksCommand, nil, "", ""},
 	} {
 		m.register(k, v)
 	}
@@ -76,6 +86,7 @@ func initConfigCmdMap() commandMap {
 	for k, v := range map[string]command{
 		"list":  {printConfiguration, nil, "", ""},
 		"reset": {resetConfiguration, nil, "", ""},
+		"set":   {setConfiguration, nil, "", ""},
 	} {
 		m.register(k, v)
 	}
@@ -94,17 +105,32 @@ func initFontsCmdMap() commandMap {
 	return m
 }
 
+func initSignaturesCmdMap() commandMap {
+	m := newCommandMap()
+	for k, v := range map[string]command{
+		"ltv":                {processAddValidationInfoCommand, nil, "", ""},
+		"usagerights":        {processListUsageRightsCommand, nil, "", ""},
+		"remove-usagerights": {processRemoveUsageRightsCommand, nil, "", ""},
+	} {
+		m.register(k, v)
+	}
+	return m
+}
+
 func initFormCmdMap() commandMap {
 	m := newCommandMap()
 	for k, v := range map[string]command{
-		"list":      {processListFormFieldsCommand, nil, "", ""},
-		"remove":    {processRemoveFormFieldsCommand, nil, "", ""},
-		"lock":      {processLockFormCommand, nil, "", ""},
-		"unlock":    {processUnlockFormCommand, nil, "", ""},
-		"reset":     {processResetFormCommand, nil, "", ""},
-		"export":    {processExportFormCommand, nil, "", ""},
-		"fill":      {processFillFormCommand, nil, "", ""},
-		"multifill": {processMultiFillFormCommand, nil, "", ""},
+		"list":         {processListFormFieldsCommand, nil, "", ""},
+		"remove":       {processRemoveFormFieldsCommand, nil, "", ""},
+		"lock":         {processLockFormCommand, nil, "", ""},
+		"unlock":       {processUnlockFormCommand, nil, "", ""},
+		"reset":        {processResetFormCommand, nil, "", ""},
+		"export":       {processExportFormCommand, nil, "", ""},
+		"fill":         {processFillFormCommand, nil, "", ""},
+		"multifill":    {processMultiFillFormCommand, nil, "", ""},
+		"add-sigfield": {processAddSignatureFieldCommand, nil, "", ""},
+		"prepare-sig":  {processPrepareSignatureCommand, nil, "", ""},
+		"embed-sig":    {processEmbedSignatureCommand, nil, "", ""},
 	} {
 		m.register(k, v)
 	}
@@ -129,6 +155,8 @@ func initKeywordsCmdMap() commandMap {
 		"list":   {processListKeywordsCommand, nil, "", ""},
 		"add":    {processAddKeywordsCommand, nil, "", ""},
 		"remove": {processRemoveKeywordsCommand, nil, "", ""},
+		"import": {processImportKeywordsCommand, nil, "", ""},
+		"export": {processExportKeywordsCommand, nil, "", ""},
 	} {
 		m.register(k, v)
 	}
@@ -138,8 +166,9 @@ func initKeywordsCmdMap() commandMap {
 func initPagesCmdMap() commandMap {
 	m := newCommandMap()
 	for k, v := range map[string]command{
-		"insert": {processInsertPagesCommand, nil, "", ""},
-		"remove": {processRemovePagesCommand, nil, "", ""},
+		"insert":  {processInsertPagesCommand, nil, "", ""},
+		"remove":  {processRemovePagesCommand, nil, "", ""},
+		"flatten": {processFlattenPagesCommand, nil, "", ""},
 	} {
 		m.register(k, v)
 	}
@@ -157,6 +186,17 @@ func initPermissionsCmdMap() commandMap {
 	return m
 }
 
+func initEncryptCmdMap() commandMap {
+	m := newCommandMap()
+	for k, v := range map[string]command{
+		"info":   {processListEncryptionCommand, nil, "", ""},
+		"update": {processUpdateEncryptionCommand, nil, "", ""},
+	} {
+		m.register(k, v)
+	}
+	return m
+}
+
 func initPortfolioCmdMap() commandMap {
 	m := newCommandMap()
 	for k, v := range map[string]command{
@@ -176,6 +216,7 @@ func initPropertiesCmdMap() commandMap {
 		"list":   {processListPropertiesCommand, nil, "", ""},
 		"add":    {processAddPropertiesCommand, nil, "", ""},
 		"remove": {processRemovePropertiesCommand, nil, "", ""},
+		"export": {processExportPropertiesCommand, nil, "", ""},
 	} {
 		m.register(k, v)
 	}
@@ -186,6 +227,7 @@ func initStampCmdMap() commandMap {
 	m := newCommandMap()
 	for k, v := range map[string]command{
 		"add":    {processAddStampsCommand, nil, "", ""},
+		"apply":  {processApplyStampsCommand, nil, "", ""},
 		"remove": {processRemoveStampsCommand, nil, "", ""},
 		"update": {processUpdateStampsCommand, nil, "", ""},
 	} {
@@ -198,6 +240,7 @@ func initWatermarkCmdMap() commandMap {
 	m := newCommandMap()
 	for k, v := range map[string]command{
 		"add":    {processAddWatermarksCommand, nil, "", ""},
+		"apply":  {processApplyWatermarksCommand, nil, "", ""},
 		"remove": {processRemoveWatermarksCommand, nil, "", ""},
 		"update": {processUpdateWatermarksCommand, nil, "", ""},
 	} {
@@ -218,6 +261,108 @@ func initPageModeCmdMap() commandMap {
 	return m
 }
 
+func initOpenActionCmdMap() commandMap {
+	m := newCommandMap()
+	for k, v := range map[string]command{
+		"list":  {processListOpenActionCommand, nil, "", ""},
+		"set":   {processSetOpenActionCommand, nil, "", ""},
+		"reset": {processResetOpenActionCommand, nil, "", ""},
+	} {
+		m.register(k, v)
+	}
+	return m
+}
+
+func initTransitionsCmdMap() commandMap {
+	m := newCommandMap()
+	for k, v := range map[string]command{
+		"list":   {processListTransitionsCommand, nil, "", ""},
+		"set":    {processSetTransitionsCommand, nil, "", ""},
+		"remove": {processRemoveTransitionsCommand, nil, "", ""},
+	} {
+		m.register(k, v)
+	}
+	return m
+}
+
+func initGeoCmdMap() commandMap {
+	m := newCommandMap()
+	for k, v := range map[string]command{
+		"list":   {processListGeoCommand, nil, "", ""},
+		"add":    {processAddGeoCommand, nil, "", ""},
+		"remove": {processRemoveGeoCommand, nil, "", ""},
+	} {
+		m.register(k, v)
+	}
+	return m
+}
+
+func initMediaCmdMap() commandMap {
+	m := newCommandMap()
+	for k, v := range map[string]command{
+		"list":    {processListMediaCommand, nil, "", ""},
+		"extract": {processExtractMediaCommand, nil, "", ""},
+	} {
+		m.register(k, v)
+	}
+	return m
+}
+
+func initConvertCmdMap() commandMap {
+	m := newCommandMap()
+	for k, v := range map[string]command{
+		"pdfx": {processConvertPDFXCommand, nil, "", ""},
+	} {
+		m.register(k, v)
+	}
+	return m
+}
+
+func initOverprintCmdMap() commandMap {
+	m := newCommandMap()
+	for k, v := range map[string]command{
+		"list": {processListOverprintCommand, nil, "", ""},
+		"set":  {processSetOverprintCommand, nil, "", ""},
+	} {
+		m.register(k, v)
+	}
+	return m
+}
+
+func initContentCmdMap() commandMap {
+	m := newCommandMap()
+	for k, v := range map[string]command{
+		"extract":   {processExtractPageContentCommand, nil, "", ""},
+		"normalize": {processNormalizeContentCommand, nil, "", ""},
+		"replace":   {processReplaceContentCommand, nil, "", ""},
+	} {
+		m.register(k, v)
+	}
+	return m
+}
+
+func initTemplateCmdMap() commandMap {
+	m := newCommandMap()
+	for k, v := range map[string]command{
+		"add":  {processAddTemplateRegionCommand, nil, "", ""},
+		"fill": {processFillTemplateCommand, nil, "", ""},
+		"list": {processListTemplateRegionsCommand, nil, "", ""},
+	} {
+		m.register(k, v)
+	}
+	return m
+}
+
+func initOCRCmdMap() commandMap {
+	m := newCommandMap()
+	for k, v := range map[string]command{
+		"apply": {processApplyOCRCommand, nil, "", ""},
+	} {
+		m.register(k, v)
+	}
+	return m
+}
+
 func initPageLayoutCmdMap() commandMap {
 	m := newCommandMap()
 	for k, v := range map[string]command{
@@ -230,6 +375,30 @@ func initPageLayoutCmdMap() commandMap {
 	return m
 }
 
+func initLanguageCmdMap() commandMap {
+	m := newCommandMap()
+	for k, v := range map[string]command{
+		"list":  {processListLanguageCommand, nil, "", ""},
+		"set":   {processSetLanguageCommand, nil, "", ""},
+		"reset": {processResetLanguageCommand, nil, "", ""},
+	} {
+		m.register(k, v)
+	}
+	return m
+}
+
+func initOutputIntentCmdMap() commandMap {
+	m := newCommandMap()
+	for k, v := range map[string]command{
+		"list":   {processListOutputIntentsCommand, nil, "", ""},
+		"add":    {processAddOutputIntentCommand, nil, "", ""},
+		"remove": {processRemoveOutputIntentsCommand, nil, "", ""},
+	} {
+		m.register(k, v)
+	}
+	return m
+}
+
 func initViewerPreferencesCmdMap() commandMap {
 	m := newCommandMap()
 	for k, v := range map[string]command{
@@ -250,16 +419,29 @@ func initCommandMap() {
 	configCmdMap := initConfigCmdMap()
 	fontsCmdMap := initFontsCmdMap()
 	formCmdMap := initFormCmdMap()
+	signaturesCmdMap := initSignaturesCmdMap()
 	imagesCmdMap := initImagesCmdMap()
 	keywordsCmdMap := initKeywordsCmdMap()
 	pagesCmdMap := initPagesCmdMap()
 	permissionsCmdMap := initPermissionsCmdMap()
+	encryptCmdMap := initEncryptCmdMap()
 	portfolioCmdMap := initPortfolioCmdMap()
 	propertiesCmdMap := initPropertiesCmdMap()
 	stampCmdMap := initStampCmdMap()
 	watermarkCmdMap := initWatermarkCmdMap()
 	pageModeCmdMap := initPageModeCmdMap()
+	openActionCmdMap := initOpenActionCmdMap()
+	transitionsCmdMap := initTransitionsCmdMap()
+	geoCmdMap := initGeoCmdMap()
+	mediaCmdMap := initMediaCmdMap()
+	ocrCmdMap := initOCRCmdMap()
+	convertCmdMap := initConvertCmdMap()
+	overprintCmdMap := initOverprintCmdMap()
+	contentCmdMap := initContentCmdMap()
+	templateCmdMap := initTemplateCmdMap()
 	pageLayoutCmdMap := initPageLayoutCmdMap()
+	languageCmdMap := initLanguageCmdMap()
+	outputIntentCmdMap := initOutputIntentCmdMap()
 	viewerPrefsCmdMap := initViewerPreferencesCmdMap()
 
 	cmdMap = newCommandMap()
@@ -268,45 +450,65 @@ func initCommandMap() {
 		"annotations":   {nil, annotsCmdMap, usageAnnots, usageLongAnnots},
 		"attachments":   {nil, attachCmdMap, usageAttach, usageLongAttach},
 		"bookmarks":     {nil, bookmarksCmdMap, usageBookmarks, usageLongBookmarks},
+		"batch":         {processBatchCommand, nil, usageBatch, usageLongBatch},
 		"booklet":       {processBookletCommand, nil, usageBooklet, usageLongBooklet},
 		"boxes":         {nil, boxesCmdMap, usageBoxes, usageLongBoxes},
 		"changeopw":     {processChangeOwnerPasswordCommand, nil, usageChangeOwnerPW, usageLongChangeOwnerPW},
 		"changeupw":     {processChangeUserPasswordCommand, nil, usageChangeUserPW, usageLongChangeUserPW},
 		"collect":       {processCollectCommand, nil, usageCollect, usageLongCollect},
+		"completion":    {processCompletionCommand, nil, usageCompletion, usageLongCompletion},
 		"config":        {nil, configCmdMap, usageConfig, usageLongConfig},
+		"content":       {nil, contentCmdMap, usageContent, usageLongContent},
+		"convert":       {nil, convertCmdMap, usageConvert, usageLongConvert},
 		"create":        {processCreateCommand, nil, usageCreate, usageLongCreate},
 		"crop":          {processCropCommand, nil, usageCrop, usageLongCrop},
 		"cut":           {processCutCommand, nil, usageCut, usageLongCut},
 		"decrypt":       {processDecryptCommand, nil, usageDecrypt, usageLongDecrypt},
 		"dump":          {processDumpCommand, nil, "", ""},
-		"encrypt":       {processEncryptCommand, nil, usageEncrypt, usageLongEncrypt},
+		"encrypt":       {processEncryptCommand, encryptCmdMap, usageEncrypt, usageLongEncrypt},
 		"extract":       {processExtractCommand, nil, usageExtract, usageLongExtract},
 		"fonts":         {nil, fontsCmdMap, usageFonts, usageLongFonts},
 		"form":          {nil, formCmdMap, usageForm, usageLongForm},
+		"geo":           {nil, geoCmdMap, usageGeo, usageLongGeo},
 		"grid":          {processGridCommand, nil, usageGrid, usageLongGrid},
+		"hairlines":     {processFixHairlinesCommand, nil, usageHairlines, usageLongHairlines},
 		"help":          {printHelp, nil, "", ""},
 		"images":        {nil, imagesCmdMap, usageImages, usageLongImages},
 		"import":        {processImportImagesCommand, nil, usageImportImages, usageLongImportImages},
 		"info":          {processInfoCommand, nil, usageInfo, usageLongInfo},
 		"keywords":      {nil, keywordsCmdMap, usageKeywords, usageLongKeywords},
+		"language":      {nil, languageCmdMap, usageLanguage, usageLongLanguage},
+		"media":         {nil, mediaCmdMap, usageMedia, usageLongMedia},
 		"merge":         {processMergeCommand, nil, usageMerge, usageLongMerge},
 		"ndown":         {processNDownCommand, nil, usageNDown, usageLongNDown},
 		"nup":           {processNUpCommand, nil, usageNUp, usageLongNUp},
+		"ocr":           {nil, ocrCmdMap, usageOCR, usageLongOCR},
+		"openaction":    {nil, openActionCmdMap, usageOpenAction, usageLongOpenAction},
 		"optimize":      {processOptimizeCommand, nil, usageOptimize, usageLongOptimize},
+		"outputintent":  {nil, outputIntentCmdMap, usageOutputIntent, usageLongOutputIntent},
+		"overlay":       {processOverlayCommand, nil, usageOverlay, usageLongOverlay},
+		"overprint":     {nil, overprintCmdMap, usageOverprint, usageLongOverprint},
 		"pagelayout":    {nil, pageLayoutCmdMap, usagePageLayout, usageLongPageLayout},
 		"pagemode":      {nil, pageModeCmdMap, usagePageMode, usageLongPageMode},
+		"pagenumbers":   {processPageNumbersCommand, nil, usagePageNumbers, usageLongPageNumbers},
 		"pages":         {nil, pagesCmdMap, usagePages, usageLongPages},
 		"paper":         {printPaperSizes, nil, usagePaper, usageLongPaper},
 		"permissions":   {nil, permissionsCmdMap, usagePerm, usageLongPerm},
 		"portfolio":     {nil, portfolioCmdMap, usagePortfolio, usageLongPortfolio},
 		"poster":        {processPosterCommand, nil, usagePoster, usageLongPoster},
 		"properties":    {nil, propertiesCmdMap, usageProperties, usageLongProperties},
+		"reflow":        {processReflowCommand, nil, usageReflow, usageLongReflow},
 		"resize":        {processResizeCommand, nil, usageResize, usageLongResize},
 		"rotate":        {processRotateCommand, nil, usageRotate, usageLongRotate},
 		"selectedpages": {printSelectedPages, nil, usageSelectedPages, usageLongSelectedPages},
+		"signatures":    {nil, signaturesCmdMap, usageSignatures, usageLongSignatures},
 		"split":         {processSplitCommand, nil, usageSplit, usageLongSplit},
 		"stamp":         {nil, stampCmdMap, usageStamp, usageLongStamp},
+		"template":      {nil, templateCmdMap, usageTemplate, usageLongTemplate},
+		"transitions":   {nil, transitionsCmdMap, usageTransitions, usageLongTransitions},
+		"triage":        {processTriageCommand, nil, usageTriage, usageLongTriage},
 		"trim":          {processTrimCommand, nil, usageTrim, usageLongTrim},
+		"underlay":      {processUnderlayCommand, nil, usageUnderlay, usageLongUnderlay},
 		"validate":      {processValidateCommand, nil, usageValidate, usageLongValidate},
 		"watermark":     {nil, watermarkCmdMap, usageWatermark, usageLongWatermark},
 		"version":       {printVersion, nil, usageVersion, usageLongVersion},
@@ -321,6 +523,12 @@ func initFlags() {
 	flag.BoolVar(&all, "all", false, "")
 	flag.BoolVar(&all, "a", false, "")
 
+	attachmentsUsage := "encrypt: embedded files only"
+	flag.BoolVar(&attachmentsOnly, "attachments", false, attachmentsUsage)
+
+	authorUsage := "split: author of result files, may contain %basename%, %n%, %from%, %thru%, %bookmark%, %title%, %author%; annotations list: author filter"
+	flag.StringVar(&splitAuthor, "author", "", authorUsage)
+
 	bookmarksUsage := "create bookmarks while merging"
 	flag.BoolVar(&bookmarks, "bookmarks", false, bookmarksUsage)
 	flag.BoolVar(&bookmarks, "b", false, bookmarksUsage)
@@ -330,6 +538,12 @@ func initFlags() {
 	flag.StringVar(&conf, "conf", "", confUsage)
 	flag.StringVar(&conf, "c", "", confUsage)
 
+	conflictUsage := "merge: rename|skip|error colliding document-level names"
+	flag.StringVar(&mergeConflict, "conflict", "", conflictUsage)
+
+	dataUsage := "create: JSON or CSV file with data records for template placeholders"
+	flag.StringVar(&dataFile, "data", "", dataUsage)
+
 	dividerPageUsage := "create divider pages while merging"
 	flag.BoolVar(&dividerPage, "dividerPage", false, dividerPageUsage)
 	flag.BoolVar(&dividerPage, "d", false, dividerPageUsage)
@@ -338,6 +552,18 @@ func initFlags() {
 	flag.BoolVar(&fonts, "fonts", false, fontsUsage)
 	flag.BoolVar(&fonts, "f", false, fontsUsage)
 
+	formatUsage := "form export: json|csv"
+	flag.StringVar(&format, "format", "", formatUsage)
+
+	idUsage := "stamp, watermark: remove stamps/watermarks with matching id only"
+	flag.StringVar(&stampID, "id", "", idUsage)
+
+	identityUsage := "merge: keep and/or set file identifier and dates, eg. \"keep\" or \"id:0a1b2c3d, created:2024-01-31\""
+	flag.StringVar(&mergeIdentity, "identity", "", identityUsage)
+
+	fromUsage := "annotations list: modified on or after date"
+	flag.StringVar(&annotFrom, "from", "", fromUsage)
+
 	jsonUsage := "produce JSON output"
 	flag.BoolVar(&json, "json", false, jsonUsage)
 	flag.BoolVar(&json, "j", false, jsonUsage)
@@ -346,11 +572,17 @@ func initFlags() {
 	flag.StringVar(&key, "key", "256", keyUsage)
 	flag.StringVar(&key, "k", "256", keyUsage)
 
+	manifestUsage := "attachments, portfolio add: JSON manifest describing files and their metadata"
+	flag.StringVar(&manifest, "manifest", "", manifestUsage)
+
 	linksUsage := "check for broken links"
 	flag.BoolVar(&links, "links", false, linksUsage)
 	flag.BoolVar(&links, "l", false, linksUsage)
 
-	modeUsage := "validate: strict|relaxed; extract: image|font|content|page|meta; encrypt: rc4|aes; stamp:text|image/pdf"
+	metaUsage := "split: copy|strip document metadata of result files"
+	flag.StringVar(&splitMeta, "meta", "", metaUsage)
+
+	modeUsage := "validate: strict|relaxed; extract: image|font|content|page|meta|xobject; annotations export: json|csv|html; content normalize, replace: flate|plain; bookmarks sort: title|page; reflow: html|epub; encrypt: rc4|aes; stamp:text|image/pdf; overlay, underlay: repeat|cycle|once"
 	flag.StringVar(&mode, "mode", "", modeUsage)
 	flag.StringVar(&mode, "m", "", modeUsage)
 
@@ -362,6 +594,13 @@ func initFlags() {
 	flag.BoolVar(&optimize, "optimize", false, optimizeUsage)
 	flag.BoolVar(&optimize, "opt", false, optimizeUsage)
 
+	flag.IntVar(&openPage, "page", 0, "openaction set: page displayed on open")
+	flag.StringVar(&openPageLayout, "pagelayout", "", "openaction set: SinglePage|TwoColumnLeft|TwoColumnRight|TwoPageLeft|TwoPageRight")
+	flag.StringVar(&openPageMode, "pagemode", "", "openaction set: UseNone|UseOutlines|UseThumbs|FullScreen|UseOC|UseAttachments")
+
+	passesUsage := "optimize: a comma separated list of optimization passes: fonts, images, gc, recompress, metadata, pagetree, alternates, thumbnails, appstates, stdfonts, deadcode, inlineimg, all, default"
+	flag.StringVar(&optimizePasses, "passes", "", passesUsage)
+
 	selectedPagesUsage := "a comma separated list of pages or page ranges, see pdfcpu selectedpages"
 	flag.StringVar(&selectedPages, "pages", "", selectedPagesUsage)
 	flag.StringVar(&selectedPages, "p", "", selectedPagesUsage)
@@ -372,27 +611,98 @@ func initFlags() {
 	flag.BoolVar(&quiet, "quiet", false, "")
 	flag.BoolVar(&quiet, "q", false, "")
 
-	replaceUsage := "replace existing bookmarks"
+	strictUsage := "treat warnings as errors"
+	flag.BoolVar(&strict, "strict", false, strictUsage)
+	flag.BoolVar(&strict, "strict-warnings-as-errors", false, strictUsage)
+
+	flag.BoolVar(&dryRun, "dryrun", false, "report changes without writing output")
+
+	flag.Var(&backup, "backup", "back up files modified in place to inFile.bak or -backup=path")
+
+	replaceUsage := "replace existing bookmarks or keywords"
 	flag.BoolVar(&replaceBookmarks, "replace", false, replaceUsage)
 	flag.BoolVar(&replaceBookmarks, "r", false, replaceUsage)
 
+	recursiveUsage := "form export: include subdirectories"
+	flag.BoolVar(&recursive, "recursive", false, recursiveUsage)
+
+	standardUsage := "validate, convert pdfx: pdfx-1a|pdfx-4"
+	flag.StringVar(&standard, "standard", "", standardUsage)
+
+	conditionUsage := "convert pdfx, outputintent add: registered output condition identifier of an added output intent"
+	flag.StringVar(&outputCondition, "condition", "", conditionUsage)
+
+	subtypeUsage := "outputintent add, remove: GTS_PDFX|GTS_PDFA1|ISO_PDFE1"
+	flag.StringVar(&outputIntentSubtype, "subtype", "", subtypeUsage)
+
 	sortUsage := "sort files before merging"
 	flag.BoolVar(&sorted, "sort", false, sortUsage)
 	flag.BoolVar(&sorted, "s", false, sortUsage)
 
-	statsUsage := "optimize: create a csv file for stats"
+	statsUsage := "optimize: write a JSON report of the savings per optimization pass"
 	flag.StringVar(&fileStats, "stats", "", statsUsage)
 
+	typeUsage := "annotations list: comma separated annotation types"
+	flag.StringVar(&annotTypes, "type", "", typeUsage)
+
+	untilUsage := "annotations list: modified on or before date"
+	flag.StringVar(&annotUntil, "until", "", untilUsage)
+
+	titleUsage := "split: title of result files, may contain %basename%, %n%, %from%, %thru%, %bookmark%, %title%, %author%"
+	flag.StringVar(&splitTitle, "title", "", titleUsage)
+
 	unitUsage := "info: po|in|cm|mm"
 	flag.StringVar(&unit, "unit", "", unitUsage)
 	flag.StringVar(&unit, "u", "", unitUsage)
 
 	flag.StringVar(&upw, "upw", "", "user password")
 	flag.StringVar(&opw, "opw", "", "owner password")
+	flag.BoolVar(&noPrompt, "noprompt", false, "do not prompt for passwords of encrypted files")
 
 	flag.BoolVar(&verbose, "verbose", false, "")
 	flag.BoolVar(&verbose, "v", false, "")
 	flag.BoolVar(&veryVerbose, "vv", false, "")
+
+	flag.StringVar(&openZoom, "zoom", "", "openaction set: fit|fitwidth|fitheight|fitbox|percent")
+}
+
+// backupFlag holds the backup file name for -backup=path and "true" for -backup.
+type backupFlag string
+
+func (b *backupFlag) String() string {
+	return string(*b)
+}
+
+func (b *backupFlag) Set(s string) error {
+	if s == "false" {
+		s = ""
+	}
+	*b = backupFlag(s)
+	return nil
+}
+
+func (b *backupFlag) IsBoolFlag() bool {
+	return true
+}
+
+func (b backupFlag) apply(conf *model.Configuration) {
+	if b == "" {
+		return
+	}
+	conf.Backup = true
+	if b != "true" {
+		conf.BackupFile = string(b)
+	}
+}
+
+// usesStdIO returns true if any argument denotes stdin or stdout.
+func usesStdIO() bool {
+	for _, arg := range flag.Args() {
+		if arg == api.StdIO {
+			return true
+		}
+	}
+	return false
 }
 
 func initLogging(verbose, veryVerbose bool) {
@@ -402,7 +712,12 @@ func initLogging(verbose, veryVerbose bool) {
 		return
 	}
 
-	log.SetDefaultCLILogger()
+	if usesStdIO() {
+		// Keep stdout clean for piped PDF output.
+		log.SetStderrCLILogger()
+	} else {
+		log.SetDefaultCLILogger()
+	}
 
 	if verbose || veryVerbose {
 		log.SetDefaultDebugLogger()
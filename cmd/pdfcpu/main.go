@@ -28,13 +28,30 @@ var (
 	fileStats, mode, selectedPages           string
 	upw, opw, key, perm, unit, conf          string
 	verbose, veryVerbose                     bool
-	links, quiet, offline                    bool
-	replaceBookmarks                         bool // Import Bookmarks
-	all                                      bool // List Viewer Preferences
-	fonts                                    bool // Info
-	json                                     bool // List Viewer Preferences, Info
-	bookmarks, dividerPage, optimize, sorted bool // Merge
+	links, quiet, offline, strict, dryRun    bool
+	noPrompt                                 bool   // Encrypted files
+	replaceBookmarks                         bool   // Import Bookmarks, Keywords
+	all                                      bool   // List Viewer Preferences
+	attachmentsOnly                          bool   // Encrypt
+	manifest                                 string // Attachments, Portfolio
+	stampID                                  string // Stamp, Watermark
+	fonts                                    bool   // Info
+	json                                     bool   // List Viewer Preferences, Info
+	format                                   string // Export Form
+	dataFile                                 string // Create
+	recursive                                bool   // Export Form
+	openPage                                 int    // OpenAction
+	openZoom, openPageMode, openPageLayout   string // OpenAction
+	optimizePasses                           string // Optimize
+	bookmarks, dividerPage, optimize, sorted bool   // Merge
+	mergeConflict, mergeIdentity             string // Merge
+	splitTitle, splitAuthor, splitMeta       string // Split
+	annotTypes, annotFrom, annotUntil        string // List Annotations
+	standard, outputCondition                string // Validate, Convert PDF/X, Output Intents
+	outputIntentSubtype                      string // Output Intents
 	bookmarksSet, offlineSet, optimizeSet    bool
+	permSet                                  bool
+	backup                                   backupFlag
 	needStackTrace                           = true
 	cmdMap                                   commandMap
 )
@@ -60,6 +77,14 @@ func main() {
 	// The first argument is the pdfcpu command string.
 	cmdStr := os.Args[1]
 
+	if cmdStr == helpJSONFlag || cmdStr == helpJSONFlag[1:] {
+		if err := printHelpJSON(os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(exitError)
+		}
+		os.Exit(exitOK)
+	}
+
 	// Process command string for given configuration.
 	str, err := cmdMap.process(cmdStr, "")
 	if err != nil {
@@ -68,8 +93,10 @@ func main() {
 		}
 		fmt.Fprintf(os.Stderr, "%v \"%s\"\n", err, cmdStr)
 		fmt.Fprintln(os.Stderr, "Run 'pdfcpu help' for usage.")
-		os.Exit(1)
+		os.Exit(exitCode(err))
 	}
 
-	os.Exit(0)
+	exitOnWarnings()
+
+	os.Exit(exitOK)
 }
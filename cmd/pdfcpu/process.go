@@ -49,13 +49,13 @@ func abs(i int) int {
 }
 
 func hasPDFExtension(filename string) bool {
-	return strings.HasSuffix(strings.ToLower(filename), ".pdf")
+	return filename == api.StdIO || strings.HasSuffix(strings.ToLower(filename), ".pdf")
 }
 
 func ensurePDFExtension(filename string) {
 	if !hasPDFExtension(filename) {
 		fmt.Fprintf(os.Stderr, "%s needs extension \".pdf\".\n", filename)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 }
 
@@ -66,7 +66,7 @@ func hasJSONExtension(filename string) bool {
 func ensureJSONExtension(filename string) {
 	if !hasJSONExtension(filename) {
 		fmt.Fprintf(os.Stderr, "%s needs extension \".json\".\n", filename)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 }
 
@@ -77,7 +77,7 @@ func hasCSVExtension(filename string) bool {
 func ensureCSVExtension(filename string) {
 	if !hasCSVExtension(filename) {
 		fmt.Fprintf(os.Stderr, "%s needs extension \".csv\".\n", filename)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 }
 
@@ -105,14 +105,14 @@ func printConfiguration(conf *model.Configuration) {
 	f, err := os.Open(conf.Path)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "can't open %s", conf.Path)
-		os.Exit(1)
+		os.Exit(exitIO)
 	}
 	defer f.Close()
 
 	var buf bytes.Buffer
 	if _, err := io.Copy(&buf, f); err != nil {
 		fmt.Fprintf(os.Stderr, "can't read %s", conf.Path)
-		os.Exit(1)
+		os.Exit(exitIO)
 	}
 
 	fmt.Print(string(buf.String()))
@@ -149,7 +149,7 @@ func resetConfiguration(conf *model.Configuration) {
 			fmt.Println("resetting..")
 			if err := model.ResetConfig(); err != nil {
 				fmt.Fprintf(os.Stderr, "pdfcpu: config problem: %v\n", err)
-				os.Exit(1)
+				os.Exit(exitError)
 			}
 			fmt.Println("Ready - Don't forget to update config.yml with your modifications.")
 		} else {
@@ -160,6 +160,33 @@ func resetConfiguration(conf *model.Configuration) {
 	}
 }
 
+func setConfiguration(conf *model.Configuration) {
+	if len(flag.Args()) != 2 {
+		fmt.Fprintf(os.Stderr, "usage: %s\n\n", usageConfigSet)
+		os.Exit(exitUsage)
+	}
+
+	if conf.Path == "" {
+		fmt.Fprintln(os.Stderr, "pdfcpu: config dir disabled")
+		os.Exit(exitUsage)
+	}
+
+	c, err := api.LoadConfig(conf.Path)
+	if err == nil {
+		if err = c.Set(flag.Arg(0), flag.Arg(1)); err == nil {
+			err = api.SaveConfig(conf.Path, c)
+		}
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "pdfcpu: config problem: %v\n", err)
+		os.Exit(exitError)
+	}
+
+	if !quiet {
+		fmt.Fprintf(os.Stdout, "%s: %s\n", flag.Arg(0), flag.Arg(1))
+	}
+}
+
 func printPaperSizes(conf *model.Configuration) {
 	fmt.Fprintln(os.Stderr, paperSizes)
 }
@@ -171,7 +198,7 @@ func printSelectedPages(conf *model.Configuration) {
 func printVersion(conf *model.Configuration) {
 	if len(flag.Args()) != 0 {
 		fmt.Fprintf(os.Stderr, "%s\n\n", usageVersion)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	fmt.Fprintf(os.Stdout, "pdfcpu: %s\n", version)
@@ -198,14 +225,14 @@ func printVersion(conf *model.Configuration) {
 }
 
 func process(cmd *cli.Command) {
-	out, err := cli.Process(cmd)
+	out, err := processWithPasswordPrompt(cmd)
 	if err != nil {
 		if needStackTrace {
 			fmt.Fprintf(os.Stderr, "Fatal: %+v\n", err)
 		} else {
 			fmt.Fprintf(os.Stderr, "%v\n", err)
 		}
-		os.Exit(1)
+		os.Exit(exitCode(err))
 	}
 
 	if out != nil && !quiet {
@@ -328,7 +355,7 @@ func collectInFiles(conf *model.Configuration) []string {
 func processValidateCommand(conf *model.Configuration) {
 	if len(flag.Args()) == 0 || selectedPages != "" {
 		fmt.Fprintf(os.Stderr, "%s\n\n", usageValidate)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	inFiles := collectInFiles(conf)
@@ -341,13 +368,21 @@ func processValidateCommand(conf *model.Configuration) {
 	case "":
 	default:
 		fmt.Fprintf(os.Stderr, "%s\n\n", usageValidate)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	if links {
 		conf.ValidateLinks = true
 	}
 
+	if standard != "" {
+		if _, err := pdfcpu.ParsePDFXStandard(standard); err != nil {
+			fmt.Fprintf(os.Stderr, "%s\n\n", usageValidate)
+			os.Exit(exitUsage)
+		}
+		conf.ValidateStandard = standard
+	}
+
 	conf.Optimize = false
 	if optimizeSet {
 		conf.Optimize = optimize
@@ -356,10 +391,28 @@ func processValidateCommand(conf *model.Configuration) {
 	process(cli.ValidateCommand(inFiles, conf))
 }
 
+func processTriageCommand(conf *model.Configuration) {
+	if len(flag.Args()) != 1 || selectedPages != "" {
+		fmt.Fprintf(os.Stderr, "%s\n\n", usageTriage)
+		os.Exit(exitUsage)
+	}
+
+	inFile := flag.Arg(0)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+
+	if json {
+		log.SetCLILogger(nil)
+	}
+
+	process(cli.TriageCommand(inFile, json, conf))
+}
+
 func processOptimizeCommand(conf *model.Configuration) {
 	if len(flag.Args()) == 0 || len(flag.Args()) > 2 || selectedPages != "" {
 		fmt.Fprintf(os.Stderr, "%s\n\n", usageOptimize)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	inFile := flag.Arg(0)
@@ -373,18 +426,41 @@ func processOptimizeCommand(conf *model.Configuration) {
 		ensurePDFExtension(outFile)
 	}
 
+	if optimizePasses != "" {
+		passes, err := model.ParseOptimizePasses(optimizePasses)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(exitUsage)
+		}
+		conf.OptimizePasses = passes
+	}
+
 	conf.StatsFileName = fileStats
-	if len(fileStats) > 0 {
-		fmt.Fprintf(os.Stdout, "stats will be appended to %s\n", fileStats)
+	if len(fileStats) > 0 && log.CLIEnabled() {
+		log.CLI.Printf("optimization report will be written to %s\n", fileStats)
 	}
 
 	process(cli.OptimizeCommand(inFile, outFile, conf))
 }
 
 func processSplitByPageNumberCommand(inFile, outDir string, conf *model.Configuration) {
+	if selectedPages != "" {
+		if len(flag.Args()) > 2 {
+			fmt.Fprintln(os.Stderr, "split: either page numbers or -pages")
+			os.Exit(exitUsage)
+		}
+		pages, err := api.ParsePageSelection(selectedPages)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "problem with flag selectedPages: %v\n", err)
+			os.Exit(exitUsage)
+		}
+		process(cli.SplitByPageSelectionCommand(inFile, outDir, pages, conf))
+		return
+	}
+
 	if len(flag.Args()) == 2 {
 		fmt.Fprintln(os.Stderr, "split: missing page numbers")
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	ii := types.IntSet{}
@@ -392,7 +468,7 @@ func processSplitByPageNumberCommand(inFile, outDir string, conf *model.Configur
 		p, err := strconv.Atoi(flag.Arg(i))
 		if err != nil || p < 2 {
 			fmt.Fprintln(os.Stderr, "split: pageNr is a numeric value >= 2")
-			os.Exit(1)
+			os.Exit(exitUsage)
 		}
 		ii[p] = true
 	}
@@ -411,9 +487,9 @@ func processSplitCommand(conf *model.Configuration) {
 		mode = "span"
 	}
 	mode = modeCompletion(mode, []string{"span", "bookmark", "page"})
-	if mode == "" || len(flag.Args()) < 2 || selectedPages != "" {
+	if mode == "" || len(flag.Args()) < 2 || (selectedPages != "" && mode != "page") {
 		fmt.Fprintf(os.Stderr, "%s\n\n", usageSplit)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	inFile := flag.Arg(0)
@@ -423,11 +499,39 @@ func processSplitCommand(conf *model.Configuration) {
 
 	outDir := flag.Arg(1)
 
+	conf.SplitTitle, conf.SplitAuthor = splitTitle, splitAuthor
+
+	if splitMeta != "" {
+		switch modeCompletion(splitMeta, []string{"copy", "strip"}) {
+		case "copy":
+			conf.SplitCopyMetadata = true
+		case "strip":
+			conf.SplitCopyMetadata = false
+		default:
+			fmt.Fprintln(os.Stderr, "split: -meta copy|strip")
+			os.Exit(exitUsage)
+		}
+	}
+
 	if mode == "page" {
 		processSplitByPageNumberCommand(inFile, outDir, conf)
 		return
 	}
 
+	if mode == "bookmark" {
+		level := 1
+		if len(flag.Args()) == 3 {
+			var err error
+			level, err = strconv.Atoi(flag.Arg(2))
+			if err != nil || level < 1 {
+				fmt.Fprintln(os.Stderr, "split: level is a numeric value >= 1")
+				os.Exit(exitUsage)
+			}
+		}
+		process(cli.SplitByBookmarkCommand(inFile, outDir, level, conf))
+		return
+	}
+
 	span := 0
 
 	if mode == "span" {
@@ -437,7 +541,7 @@ func processSplitCommand(conf *model.Configuration) {
 			span, err = strconv.Atoi(flag.Arg(2))
 			if err != nil || span < 1 {
 				fmt.Fprintln(os.Stderr, "split: span is a numeric value >= 1")
-				os.Exit(1)
+				os.Exit(exitUsage)
 			}
 		}
 	}
@@ -471,18 +575,26 @@ func processArgsForMerge(conf *model.Configuration) ([]string, string) {
 	for i, arg := range flag.Args() {
 		if i == 0 {
 			ensurePDFExtension(arg)
+			if arg == api.StdIO && mode != "create" {
+				fmt.Fprintf(os.Stderr, "merge %s: writing to stdout not supported\n", mode)
+				os.Exit(exitUsage)
+			}
 			outFile = arg
 			continue
 		}
+		if arg == api.StdIO {
+			fmt.Fprintln(os.Stderr, "merge: reading from stdin not supported")
+			os.Exit(exitUsage)
+		}
 		if arg == outFile {
 			fmt.Fprintf(os.Stderr, "%s may appear as inFile or outFile only\n", outFile)
-			os.Exit(1)
+			os.Exit(exitUsage)
 		}
 		if mode != "zip" && strings.Contains(arg, "*") {
 			matches, err := filepath.Glob(arg)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "%s", err)
-				os.Exit(1)
+				os.Exit(exitUsage)
 			}
 			inFiles = append(inFiles, matches...)
 			continue
@@ -519,17 +631,17 @@ func processMergeCommand(conf *model.Configuration) {
 	mode = modeCompletion(mode, []string{"create", "append", "zip"})
 	if mode == "" {
 		fmt.Fprintf(os.Stderr, "%s\n\n", usageMerge)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	if len(flag.Args()) < 2 || selectedPages != "" {
 		fmt.Fprintf(os.Stderr, "%s\n\n", usageMerge)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	if mode == "zip" && len(flag.Args()) != 3 {
 		fmt.Fprintf(os.Stderr, "merge zip: expecting outFile inFile1 inFile2\n")
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	if mode == "zip" && dividerPage {
@@ -554,10 +666,28 @@ func processMergeCommand(conf *model.Configuration) {
 		conf.OptimizeBeforeWriting = optimize
 	}
 
+	if mergeConflict != "" {
+		p, err := model.ParseMergeConflictPolicy(mergeConflict)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(exitUsage)
+		}
+		conf.MergeConflicts = p
+	}
+
+	if mergeIdentity != "" {
+		di, err := model.ParseDocIdentity(mergeIdentity, conf.DateFormat)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(exitUsage)
+		}
+		conf.MergeIdentity = di
+	}
+
 	cmd := mergeCommandVariation(inFiles, outFile, dividerPage, conf)
 	if cmd == nil {
 		fmt.Fprintf(os.Stderr, "%s\n\n", usageMerge)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	process(cmd)
@@ -578,10 +708,10 @@ func modeCompletion(modePrefix string, modes []string) string {
 }
 
 func processExtractCommand(conf *model.Configuration) {
-	mode = modeCompletion(mode, []string{"image", "font", "page", "content", "meta"})
+	mode = modeCompletion(mode, []string{"image", "font", "page", "content", "meta", "xobject"})
 	if len(flag.Args()) != 2 || mode == "" {
 		fmt.Fprintf(os.Stderr, "%s\n\n", usageExtract)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	inFile := flag.Arg(0)
@@ -593,7 +723,7 @@ func processExtractCommand(conf *model.Configuration) {
 	pages, err := api.ParsePageSelection(selectedPages)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "problem with flag selectedPages: %v\n", err)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	var cmd *cli.Command
@@ -615,9 +745,12 @@ func processExtractCommand(conf *model.Configuration) {
 	case "meta":
 		cmd = cli.ExtractMetadataCommand(inFile, outDir, conf)
 
+	case "xobject":
+		cmd = cli.ExtractFormXObjectsCommand(inFile, outDir, pages, conf)
+
 	default:
 		fmt.Fprintf(os.Stderr, "unknown extract mode: %s\n", mode)
-		os.Exit(1)
+		os.Exit(exitUsage)
 
 	}
 
@@ -627,13 +760,13 @@ func processExtractCommand(conf *model.Configuration) {
 func processTrimCommand(conf *model.Configuration) {
 	if len(flag.Args()) == 0 || len(flag.Args()) > 2 || selectedPages == "" {
 		fmt.Fprintf(os.Stderr, "%s\n\n", usageTrim)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	pages, err := api.ParsePageSelection(selectedPages)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "problem with flag selectedPages: %v\n", err)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	inFile := flag.Arg(0)
@@ -653,7 +786,7 @@ func processTrimCommand(conf *model.Configuration) {
 func processListAttachmentsCommand(conf *model.Configuration) {
 	if len(flag.Args()) != 1 || selectedPages != "" {
 		fmt.Fprintf(os.Stderr, "usage: %s\n", usageAttachList)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	inFile := flag.Arg(0)
@@ -663,10 +796,42 @@ func processListAttachmentsCommand(conf *model.Configuration) {
 	process(cli.ListAttachmentsCommand(inFile, conf))
 }
 
+func processVerifyAttachmentsCommand(conf *model.Configuration) {
+	if len(flag.Args()) != 1 || selectedPages != "" {
+		fmt.Fprintf(os.Stderr, "usage: %s\n", usageAttachVerify)
+		os.Exit(exitUsage)
+	}
+
+	inFile := flag.Arg(0)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+	process(cli.VerifyAttachmentsCommand(inFile, conf))
+}
+
+func processAddAttachmentsManifest(conf *model.Configuration, coll bool, usage string) {
+	if len(flag.Args()) != 1 || selectedPages != "" {
+		fmt.Fprintf(os.Stderr, "usage: %s\n\n", usage)
+		os.Exit(exitUsage)
+	}
+
+	inFile := flag.Arg(0)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+
+	process(cli.AddAttachmentsJSONCommand(inFile, manifest, "", coll, conf))
+}
+
 func processAddAttachmentsCommand(conf *model.Configuration) {
+	if manifest != "" {
+		processAddAttachmentsManifest(conf, false, usageAttachAdd)
+		return
+	}
+
 	if len(flag.Args()) < 2 || selectedPages != "" {
 		fmt.Fprintf(os.Stderr, "usage: %s\n\n", usageAttachAdd)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	var inFile string
@@ -684,7 +849,7 @@ func processAddAttachmentsCommand(conf *model.Configuration) {
 			matches, err := filepath.Glob(arg)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "%s", err)
-				os.Exit(1)
+				os.Exit(exitUsage)
 			}
 			fileNames = append(fileNames, matches...)
 			continue
@@ -696,9 +861,14 @@ func processAddAttachmentsCommand(conf *model.Configuration) {
 }
 
 func processAddAttachmentsPortfolioCommand(conf *model.Configuration) {
+	if manifest != "" {
+		processAddAttachmentsManifest(conf, true, usagePortfolioAdd)
+		return
+	}
+
 	if len(flag.Args()) < 2 || selectedPages != "" {
 		fmt.Fprintf(os.Stderr, "usage: %s\n\n", usageAttachAdd)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	var inFile string
@@ -716,7 +886,7 @@ func processAddAttachmentsPortfolioCommand(conf *model.Configuration) {
 			matches, err := filepath.Glob(arg)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "%s", err)
-				os.Exit(1)
+				os.Exit(exitUsage)
 			}
 			fileNames = append(fileNames, matches...)
 			continue
@@ -730,7 +900,7 @@ func processAddAttachmentsPortfolioCommand(conf *model.Configuration) {
 func processRemoveAttachmentsCommand(conf *model.Configuration) {
 	if len(flag.Args()) < 1 || selectedPages != "" {
 		fmt.Fprintf(os.Stderr, "usage: %s\n\n", usageAttachRemove)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	var inFile string
@@ -753,7 +923,7 @@ func processRemoveAttachmentsCommand(conf *model.Configuration) {
 func processExtractAttachmentsCommand(conf *model.Configuration) {
 	if len(flag.Args()) < 2 || selectedPages != "" {
 		fmt.Fprintf(os.Stderr, "usage: %s\n\n", usageAttachExtract)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	var inFile string
@@ -775,13 +945,18 @@ func processExtractAttachmentsCommand(conf *model.Configuration) {
 		fileNames = append(fileNames, arg)
 	}
 
+	if outDir == "-" {
+		// Keep stdout clean for the attachment content.
+		log.DisableLoggers()
+	}
+
 	process(cli.ExtractAttachmentsCommand(inFile, outDir, fileNames, conf))
 }
 
 func processListPermissionsCommand(conf *model.Configuration) {
 	if len(flag.Args()) == 0 || selectedPages != "" {
 		fmt.Fprintf(os.Stderr, "usage: %s\n", usagePermList)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	inFiles := []string{}
@@ -790,7 +965,7 @@ func processListPermissionsCommand(conf *model.Configuration) {
 			matches, err := filepath.Glob(arg)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "%s", err)
-				os.Exit(1)
+				os.Exit(exitUsage)
 			}
 			inFiles = append(inFiles, matches...)
 			continue
@@ -856,11 +1031,11 @@ func processSetPermissionsCommand(conf *model.Configuration) {
 	}
 	if len(flag.Args()) != 1 || selectedPages != "" {
 		fmt.Fprintf(os.Stderr, "usage: %s\n\n", usagePermSet)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 	if perm != "" && perm != "none" && perm != "print" && perm != "all" && !isBinary(perm) && !isHex(perm) {
 		fmt.Fprintf(os.Stderr, "usage: %s\n\n", usagePermSet)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	inFile := flag.Arg(0)
@@ -876,7 +1051,7 @@ func processSetPermissionsCommand(conf *model.Configuration) {
 func processDecryptCommand(conf *model.Configuration) {
 	if len(flag.Args()) == 0 || len(flag.Args()) > 2 || selectedPages != "" {
 		fmt.Fprintf(os.Stderr, "%s\n\n", usageDecrypt)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	inFile := flag.Arg(0)
@@ -896,7 +1071,7 @@ func processDecryptCommand(conf *model.Configuration) {
 func validateEncryptModeFlag() {
 	if !types.MemberOf(mode, []string{"rc4", "aes", ""}) {
 		fmt.Fprintf(os.Stderr, "%s\n\n", "valid modes: rc4,aes default:aes")
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	// Default to AES encryption.
@@ -911,14 +1086,14 @@ func validateEncryptModeFlag() {
 	if mode == "rc4" {
 		if key != "40" && key != "128" && key != "" {
 			fmt.Fprintf(os.Stderr, "%s\n\n", "supported RC4 key lengths: 40,128 default:128")
-			os.Exit(1)
+			os.Exit(exitUsage)
 		}
 	}
 
 	if mode == "aes" {
 		if key != "40" && key != "128" && key != "256" && key != "" {
 			fmt.Fprintf(os.Stderr, "%s\n\n", "supported AES key lengths: 40,128,256 default:256")
-			os.Exit(1)
+			os.Exit(exitUsage)
 		}
 	}
 
@@ -928,42 +1103,46 @@ func validateEncryptFlags() {
 	validateEncryptModeFlag()
 	if perm != "none" && perm != "print" && perm != "all" && perm != "" {
 		fmt.Fprintf(os.Stderr, "%s\n\n", "supported permissions: none,print,all default:none (viewing always allowed!)")
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 }
 
-func processEncryptCommand(conf *model.Configuration) {
+// applyEncryptFlags applies the mode, key, perm and attachments flags to conf and returns inFile and outFile.
+func applyEncryptFlags(conf *model.Configuration, usage string) (string, string) {
 	if perm != "" {
 		perm = permCompletion(perm)
 	}
 	if len(flag.Args()) == 0 || len(flag.Args()) > 2 ||
 		!(perm == "none" || perm == "print" || perm == "all") {
-		fmt.Fprintf(os.Stderr, "%s\n\n", usageEncrypt)
-		os.Exit(1)
+		fmt.Fprintf(os.Stderr, "usage: %s\n\n", usage)
+		os.Exit(exitUsage)
 	}
 
 	if conf.OwnerPW == "" {
 		fmt.Fprintln(os.Stderr, "missing non-empty owner password!")
-		fmt.Fprintf(os.Stderr, "%s\n\n", usageEncrypt)
-		os.Exit(1)
+		fmt.Fprintf(os.Stderr, "usage: %s\n\n", usage)
+		os.Exit(exitUsage)
 	}
 
 	validateEncryptFlags()
-	if perm != "" {
-		perm = permCompletion(perm)
-	}
 
 	conf.EncryptUsingAES = mode != "rc4"
 
 	kl, _ := strconv.Atoi(key)
 	conf.EncryptKeyLength = kl
 
-	if perm == "all" {
-		conf.Permissions = model.PermissionsAll
-	}
+	conf.EncryptAttachmentsOnly = attachmentsOnly
 
-	if perm == "print" {
-		conf.Permissions = model.PermissionsPrint
+	if permSet {
+		switch perm {
+		case "all":
+			conf.Permissions = model.PermissionsAll
+		case "print":
+			conf.Permissions = model.PermissionsPrint
+		case "none":
+			conf.Permissions = model.PermissionsNone
+		}
+		conf.UpdatePermissions = true
 	}
 
 	inFile := flag.Arg(0)
@@ -977,13 +1156,49 @@ func processEncryptCommand(conf *model.Configuration) {
 		ensurePDFExtension(outFile)
 	}
 
+	return inFile, outFile
+}
+
+func processEncryptCommand(conf *model.Configuration) {
+	inFile, outFile := applyEncryptFlags(conf, usageEncrypt)
 	process(cli.EncryptCommand(inFile, outFile, conf))
 }
 
+func processListEncryptionCommand(conf *model.Configuration) {
+	if len(flag.Args()) == 0 || selectedPages != "" {
+		fmt.Fprintf(os.Stderr, "usage: %s\n", usageEncryptInfo)
+		os.Exit(exitUsage)
+	}
+
+	inFiles := []string{}
+	for _, arg := range flag.Args() {
+		if strings.Contains(arg, "*") {
+			matches, err := filepath.Glob(arg)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%s", err)
+				os.Exit(exitUsage)
+			}
+			inFiles = append(inFiles, matches...)
+			continue
+		}
+		if conf.CheckFileNameExt {
+			ensurePDFExtension(arg)
+		}
+		inFiles = append(inFiles, arg)
+	}
+
+	process(cli.ListEncryptionCommand(inFiles, json, conf))
+}
+
+func processUpdateEncryptionCommand(conf *model.Configuration) {
+	inFile, outFile := applyEncryptFlags(conf, usageEncryptUpdate)
+	process(cli.UpdateEncryptionCommand(inFile, outFile, conf))
+}
+
 func processChangeUserPasswordCommand(conf *model.Configuration) {
 	if len(flag.Args()) != 3 {
 		fmt.Fprintf(os.Stderr, "%s\n\n", usageChangeUserPW)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	inFile := flag.Arg(0)
@@ -1006,7 +1221,7 @@ func processChangeUserPasswordCommand(conf *model.Configuration) {
 func processChangeOwnerPasswordCommand(conf *model.Configuration) {
 	if len(flag.Args()) != 3 {
 		fmt.Fprintf(os.Stderr, "%s\n\n", usageChangeOwnerPW)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	inFile := flag.Arg(0)
@@ -1025,7 +1240,7 @@ func processChangeOwnerPasswordCommand(conf *model.Configuration) {
 	if pwNew == "" {
 		fmt.Fprintf(os.Stderr, "owner password cannot be empty")
 		fmt.Fprintf(os.Stderr, "%s\n\n", usageChangeOwnerPW)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	process(cli.ChangeOwnerPWCommand(inFile, outFile, &pwOld, &pwNew, conf))
@@ -1039,12 +1254,12 @@ func addWatermarks(conf *model.Configuration, onTop bool) {
 
 	if len(flag.Args()) < 3 || len(flag.Args()) > 4 {
 		fmt.Fprintf(os.Stderr, "usage: %s\n\n", u)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	if mode != "text" && mode != "image" && mode != "pdf" {
 		fmt.Fprintln(os.Stderr, "mode has to be one of: text, image or pdf")
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	processDisplayUnit(conf)
@@ -1069,13 +1284,13 @@ func addWatermarks(conf *model.Configuration, onTop bool) {
 
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "%v\n", err)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	selectedPages, err := api.ParsePageSelection(selectedPages)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "problem with flag selectedPages: %v", err)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	inFile := flag.Arg(2)
@@ -1108,12 +1323,12 @@ func updateWatermarks(conf *model.Configuration, onTop bool) {
 
 	if len(flag.Args()) < 3 || len(flag.Args()) > 4 {
 		fmt.Fprintf(os.Stderr, "%s\n\n", u)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	if mode != "text" && mode != "image" && mode != "pdf" {
 		fmt.Fprintf(os.Stderr, "%s\n\n", u)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	processDisplayUnit(conf)
@@ -1136,13 +1351,13 @@ func updateWatermarks(conf *model.Configuration, onTop bool) {
 
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "%v\n", err)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	selectedPages, err := api.ParsePageSelection(selectedPages)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "problem with flag selectedPages: %v", err)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	wm.Update = true
@@ -1161,6 +1376,43 @@ func updateWatermarks(conf *model.Configuration, onTop bool) {
 	process(cli.AddWatermarksCommand(inFile, outFile, selectedPages, wm, conf))
 }
 
+func applyWatermarks(conf *model.Configuration, onTop bool) {
+	if len(flag.Args()) < 2 || len(flag.Args()) > 3 {
+		s := usageWatermarkApply
+		if onTop {
+			s = usageStampApply
+		}
+		fmt.Fprintf(os.Stderr, "usage: %s\n\n", s)
+		os.Exit(exitUsage)
+	}
+
+	processDisplayUnit(conf)
+
+	inFileJSON := flag.Arg(0)
+	ensureJSONExtension(inFileJSON)
+
+	inFile := flag.Arg(1)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+
+	outFile := ""
+	if len(flag.Args()) == 3 {
+		outFile = flag.Arg(2)
+		ensurePDFExtension(outFile)
+	}
+
+	process(cli.AddWatermarksJSONCommand(inFile, inFileJSON, outFile, onTop, conf))
+}
+
+func processApplyStampsCommand(conf *model.Configuration) {
+	applyWatermarks(conf, true)
+}
+
+func processApplyWatermarksCommand(conf *model.Configuration) {
+	applyWatermarks(conf, false)
+}
+
 func processUpdateStampsCommand(conf *model.Configuration) {
 	updateWatermarks(conf, true)
 }
@@ -1176,13 +1428,13 @@ func removeWatermarks(conf *model.Configuration, onTop bool) {
 			s = usageStampRemove
 		}
 		fmt.Fprintf(os.Stderr, "%s\n\n", s)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	selectedPages, err := api.ParsePageSelection(selectedPages)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "problem with flag selectedPages: %v", err)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	inFile := flag.Arg(0)
@@ -1196,7 +1448,7 @@ func removeWatermarks(conf *model.Configuration, onTop bool) {
 		ensurePDFExtension(outFile)
 	}
 
-	process(cli.RemoveWatermarksCommand(inFile, outFile, selectedPages, conf))
+	process(cli.RemoveWatermarksByIDCommand(inFile, outFile, selectedPages, stampID, conf))
 }
 
 func processRemoveStampsCommand(conf *model.Configuration) {
@@ -1210,7 +1462,7 @@ func processRemoveWatermarksCommand(conf *model.Configuration) {
 func ensureImageExtension(filename string) {
 	if !model.ImageFileName(filename) {
 		fmt.Fprintf(os.Stderr, "%s needs an image extension (.jpg, .jpeg, .png, .tif, .tiff, .webp)\n", filename)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 }
 
@@ -1222,7 +1474,7 @@ func parseArgsForImageFileNames(startInd int) []string {
 			matches, err := filepath.Glob(arg)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "%v\n", err)
-				os.Exit(1)
+				os.Exit(exitUsage)
 			}
 			for _, fn := range matches {
 				ensureImageExtension(fn)
@@ -1239,7 +1491,7 @@ func parseArgsForImageFileNames(startInd int) []string {
 func processImportImagesCommand(conf *model.Configuration) {
 	if len(flag.Args()) < 2 || selectedPages != "" {
 		fmt.Fprintf(os.Stderr, "%s\n\n", usageImportImages)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	processDisplayUnit(conf)
@@ -1258,11 +1510,11 @@ func processImportImagesCommand(conf *model.Configuration) {
 	imp, err := pdfcpu.ParseImportDetails(flag.Arg(0), conf.Unit)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "%v\n", err)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 	if imp == nil {
 		fmt.Fprintf(os.Stderr, "missing import description\n")
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	outFile = flag.Arg(1)
@@ -1274,19 +1526,19 @@ func processImportImagesCommand(conf *model.Configuration) {
 func processInsertPagesCommand(conf *model.Configuration) {
 	if len(flag.Args()) == 0 || len(flag.Args()) > 3 {
 		fmt.Fprintf(os.Stderr, "usage: %s\n\n", usagePagesInsert)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	pages, err := api.ParsePageSelection(selectedPages)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "problem with flag selectedPages: %v\n", err)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	// Set default to insert pages before selected pages.
 	if mode != "" && mode != "before" && mode != "after" {
 		fmt.Fprintf(os.Stderr, "%s\n\n", usagePagesInsert)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	inFile := flag.Arg(0)
@@ -1309,11 +1561,11 @@ func processInsertPagesCommand(conf *model.Configuration) {
 	pageConf, err := pdfcpu.ParsePageConfiguration(flag.Arg(0), conf.Unit)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "%v\n", err)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 	if pageConf == nil {
 		fmt.Fprintf(os.Stderr, "missing page configuration\n")
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	inFile = flag.Arg(1)
@@ -1331,7 +1583,7 @@ func processInsertPagesCommand(conf *model.Configuration) {
 func processRemovePagesCommand(conf *model.Configuration) {
 	if len(flag.Args()) == 0 || len(flag.Args()) > 2 || selectedPages == "" {
 		fmt.Fprintf(os.Stderr, "usage: %s\n\n", usagePagesRemove)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	inFile := flag.Arg(0)
@@ -1347,20 +1599,39 @@ func processRemovePagesCommand(conf *model.Configuration) {
 	pages, err := api.ParsePageSelection(selectedPages)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "problem with flag selectedPages: %v\n", err)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 	if pages == nil {
 		fmt.Fprintf(os.Stderr, "missing page selection\n")
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	process(cli.RemovePagesCommand(inFile, outFile, pages, conf))
 }
 
+func processFlattenPagesCommand(conf *model.Configuration) {
+	if len(flag.Args()) == 0 || len(flag.Args()) > 2 || selectedPages != "" {
+		fmt.Fprintf(os.Stderr, "usage: %s\n\n", usagePagesFlatten)
+		os.Exit(exitUsage)
+	}
+
+	inFile := flag.Arg(0)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+	outFile := ""
+	if len(flag.Args()) == 2 {
+		outFile = flag.Arg(1)
+		ensurePDFExtension(outFile)
+	}
+
+	process(cli.FlattenPageAttrsCommand(inFile, outFile, conf))
+}
+
 func processRotateCommand(conf *model.Configuration) {
 	if len(flag.Args()) < 2 || len(flag.Args()) > 3 {
 		fmt.Fprintf(os.Stderr, "%s\n\n", usageRotate)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	inFile := flag.Arg(0)
@@ -1371,7 +1642,7 @@ func processRotateCommand(conf *model.Configuration) {
 	rotation, err := strconv.Atoi(flag.Arg(1))
 	if err != nil || abs(rotation)%90 > 0 {
 		fmt.Fprintf(os.Stderr, "rotation must be a multiple of 90: %s\n", flag.Arg(1))
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	outFile := ""
@@ -1383,26 +1654,60 @@ func processRotateCommand(conf *model.Configuration) {
 	selectedPages, err := api.ParsePageSelection(selectedPages)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "problem with flag selectedPages: %v\n", err)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	process(cli.RotateCommand(inFile, outFile, rotation, selectedPages, conf))
 }
 
+func processPageNumbersCommand(conf *model.Configuration) {
+	if len(flag.Args()) < 2 || len(flag.Args()) > 3 {
+		fmt.Fprintf(os.Stderr, "%s\n\n", usagePageNumbers)
+		os.Exit(exitUsage)
+	}
+
+	processDisplayUnit(conf)
+
+	pn, err := pdfcpu.ParsePageNumbersDetails(flag.Arg(0), conf.Unit)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(exitUsage)
+	}
+
+	selectedPages, err := api.ParsePageSelection(selectedPages)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "problem with flag selectedPages: %v\n", err)
+		os.Exit(exitUsage)
+	}
+
+	inFile := flag.Arg(1)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+
+	outFile := ""
+	if len(flag.Args()) == 3 {
+		outFile = flag.Arg(2)
+		ensurePDFExtension(outFile)
+	}
+
+	process(cli.AddPageNumbersCommand(inFile, outFile, selectedPages, pn, conf))
+}
+
 func parseForGrid(nup *model.NUp, argInd *int) {
 	cols, err := strconv.Atoi(flag.Arg(*argInd))
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "%s\n", err)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 	rows, err := strconv.Atoi(flag.Arg(*argInd + 1))
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "%s\n", err)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 	if err = pdfcpu.ParseNUpGridDefinition(cols, rows, nup); err != nil {
 		fmt.Fprintf(os.Stderr, "%s\n", err)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 	*argInd += 2
 }
@@ -1411,7 +1716,7 @@ func parseForNUp(nup *model.NUp, argInd *int, nUpValues []int) {
 	n, err := strconv.Atoi(flag.Arg(*argInd))
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "%s\n", err)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 	if !types.IntMemberOf(n, nUpValues) {
 		ss := make([]string, len(nUpValues))
@@ -1420,11 +1725,11 @@ func parseForNUp(nup *model.NUp, argInd *int, nUpValues []int) {
 		}
 		err := errors.Errorf("pdfcpu: n must be one of %s", strings.Join(ss, ", "))
 		fmt.Fprintf(os.Stderr, "%s\n", err)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 	if err = pdfcpu.ParseNUpValue(n, nup); err != nil {
 		fmt.Fprintf(os.Stderr, "%s\n", err)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 	*argInd++
 }
@@ -1439,7 +1744,7 @@ func parseAfterNUpDetails(nup *model.NUp, argInd int, nUpValues []int, filenameO
 	filenameIn := flag.Arg(argInd)
 	if !hasPDFExtension(filenameIn) && !model.ImageFileName(filenameIn) {
 		fmt.Fprintf(os.Stderr, "inFile has to be a PDF or one or a sequence of image files: %s\n", filenameIn)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	filenamesIn := []string{filenameIn}
@@ -1451,11 +1756,11 @@ func parseAfterNUpDetails(nup *model.NUp, argInd int, nUpValues []int, filenameO
 				usage = usageGrid
 			}
 			fmt.Fprintf(os.Stderr, "%s\n\n", usage)
-			os.Exit(1)
+			os.Exit(exitUsage)
 		}
 		if filenameIn == filenameOut {
 			fmt.Fprintln(os.Stderr, "inFile and outFile can't be the same.")
-			os.Exit(1)
+			os.Exit(exitUsage)
 		}
 	} else {
 		nup.ImgInputFile = true
@@ -1472,7 +1777,7 @@ func parseAfterNUpDetails(nup *model.NUp, argInd int, nUpValues []int, filenameO
 func processNUpCommand(conf *model.Configuration) {
 	if len(flag.Args()) < 3 {
 		fmt.Fprintf(os.Stderr, "%s\n\n", usageNUp)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	processDisplayUnit(conf)
@@ -1480,7 +1785,7 @@ func processNUpCommand(conf *model.Configuration) {
 	pages, err := api.ParsePageSelection(selectedPages)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "problem with flag selectedPages: %v\n", err)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	nup := model.DefaultNUpConfig()
@@ -1492,7 +1797,7 @@ func processNUpCommand(conf *model.Configuration) {
 		// pdfcpu nup description outFile n inFile|imageFiles...
 		if err = pdfcpu.ParseNUpDetails(flag.Arg(0), nup); err != nil {
 			fmt.Fprintf(os.Stderr, "%s\n", err)
-			os.Exit(1)
+			os.Exit(exitUsage)
 		}
 		outFile = flag.Arg(1)
 		ensurePDFExtension(outFile)
@@ -1509,7 +1814,7 @@ func processNUpCommand(conf *model.Configuration) {
 func processGridCommand(conf *model.Configuration) {
 	if len(flag.Args()) < 4 {
 		fmt.Fprintf(os.Stderr, "%s\n\n", usageGrid)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	processDisplayUnit(conf)
@@ -1517,7 +1822,7 @@ func processGridCommand(conf *model.Configuration) {
 	pages, err := api.ParsePageSelection(selectedPages)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "problem with flag selectedPages: %v\n", err)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	nup := model.DefaultNUpConfig()
@@ -1530,7 +1835,7 @@ func processGridCommand(conf *model.Configuration) {
 		// pdfcpu grid description outFile m n inFile|imageFiles...
 		if err = pdfcpu.ParseNUpDetails(flag.Arg(0), nup); err != nil {
 			fmt.Fprintf(os.Stderr, "%s\n", err)
-			os.Exit(1)
+			os.Exit(exitUsage)
 		}
 		outFile = flag.Arg(1)
 		ensurePDFExtension(outFile)
@@ -1547,7 +1852,7 @@ func processGridCommand(conf *model.Configuration) {
 func processBookletCommand(conf *model.Configuration) {
 	if len(flag.Args()) < 3 {
 		fmt.Fprintf(os.Stderr, "%s\n\n", usageBooklet)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	processDisplayUnit(conf)
@@ -1555,7 +1860,7 @@ func processBookletCommand(conf *model.Configuration) {
 	pages, err := api.ParsePageSelection(selectedPages)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "problem with flag selectedPages: %v\n", err)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	nup := pdfcpu.DefaultBookletConfig()
@@ -1568,7 +1873,7 @@ func processBookletCommand(conf *model.Configuration) {
 		// pdfcpu booklet description outFile n inFile|imageFiles...
 		if err = pdfcpu.ParseNUpDetails(flag.Arg(0), nup); err != nil {
 			fmt.Fprintf(os.Stderr, "%s\n", err)
-			os.Exit(1)
+			os.Exit(exitUsage)
 		}
 		outFile = flag.Arg(1)
 		ensurePDFExtension(outFile)
@@ -1585,7 +1890,7 @@ func processBookletCommand(conf *model.Configuration) {
 func processDisplayUnit(conf *model.Configuration) {
 	if !types.MemberOf(unit, []string{"", "points", "po", "inches", "in", "cm", "mm"}) {
 		fmt.Fprintf(os.Stderr, "%s\n\n", "supported units: (po)ints, (in)ches, cm, mm")
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	switch unit {
@@ -1603,7 +1908,7 @@ func processDisplayUnit(conf *model.Configuration) {
 func processInfoCommand(conf *model.Configuration) {
 	if len(flag.Args()) < 1 {
 		fmt.Fprintf(os.Stderr, "%s\n\n", usageInfo)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	inFiles := []string{}
@@ -1612,7 +1917,7 @@ func processInfoCommand(conf *model.Configuration) {
 			matches, err := filepath.Glob(arg)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "%s", err)
-				os.Exit(1)
+				os.Exit(exitUsage)
 			}
 			inFiles = append(inFiles, matches...)
 			continue
@@ -1626,7 +1931,7 @@ func processInfoCommand(conf *model.Configuration) {
 	selectedPages, err := api.ParsePageSelection(selectedPages)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "problem with flag selectedPages: %v\n", err)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	processDisplayUnit(conf)
@@ -1646,7 +1951,7 @@ func processInstallFontsCommand(conf *model.Configuration) {
 	fileNames := []string{}
 	if len(flag.Args()) == 0 {
 		fmt.Fprintf(os.Stderr, "%s\n\n", "expecting a list of TrueType filenames (.ttf, .ttc) for installation.")
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 	for _, arg := range flag.Args() {
 		if !types.MemberOf(filepath.Ext(arg), []string{".ttf", ".ttc"}) {
@@ -1656,7 +1961,7 @@ func processInstallFontsCommand(conf *model.Configuration) {
 	}
 	if len(fileNames) == 0 {
 		fmt.Fprintln(os.Stderr, "Please supply a *.ttf or *.tcc fontname!")
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 	process(cli.InstallFontsCommand(fileNames, conf))
 }
@@ -1672,7 +1977,7 @@ func processCreateCheatSheetFontsCommand(conf *model.Configuration) {
 func processListKeywordsCommand(conf *model.Configuration) {
 	if len(flag.Args()) != 1 || selectedPages != "" {
 		fmt.Fprintf(os.Stderr, "usage: %s\n", usageKeywordsList)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	inFile := flag.Arg(0)
@@ -1685,7 +1990,7 @@ func processListKeywordsCommand(conf *model.Configuration) {
 func processAddKeywordsCommand(conf *model.Configuration) {
 	if len(flag.Args()) < 2 || selectedPages != "" {
 		fmt.Fprintf(os.Stderr, "usage: %s\n\n", usageKeywordsAdd)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	var inFile string
@@ -1708,7 +2013,7 @@ func processAddKeywordsCommand(conf *model.Configuration) {
 func processRemoveKeywordsCommand(conf *model.Configuration) {
 	if len(flag.Args()) < 1 || selectedPages != "" {
 		fmt.Fprintf(os.Stderr, "usage: %s\n\n", usageKeywordsRemove)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	var inFile string
@@ -1728,78 +2033,171 @@ func processRemoveKeywordsCommand(conf *model.Configuration) {
 	process(cli.RemoveKeywordsCommand(inFile, "", keywords, conf))
 }
 
-func processListPropertiesCommand(conf *model.Configuration) {
-	if len(flag.Args()) != 1 || selectedPages != "" {
-		fmt.Fprintf(os.Stderr, "usage: %s\n", usagePropertiesList)
-		os.Exit(1)
+func processImportKeywordsCommand(conf *model.Configuration) {
+	if len(flag.Args()) < 2 || len(flag.Args()) > 3 || selectedPages != "" {
+		fmt.Fprintf(os.Stderr, "usage: %s\n\n", usageKeywordsImport)
+		os.Exit(exitUsage)
 	}
 
 	inFile := flag.Arg(0)
 	if conf.CheckFileNameExt {
 		ensurePDFExtension(inFile)
 	}
-	process(cli.ListPropertiesCommand(inFile, conf))
-}
 
-func processAddPropertiesCommand(conf *model.Configuration) {
-	if len(flag.Args()) < 2 || selectedPages != "" {
-		fmt.Fprintf(os.Stderr, "usage: %s\n\n", usagePropertiesAdd)
-		os.Exit(1)
+	inFileJSON := flag.Arg(1)
+	if ext := strings.ToLower(filepath.Ext(inFileJSON)); ext != ".txt" && ext != ".md" {
+		ensureJSONExtension(inFileJSON)
 	}
 
-	var inFile string
-	properties := map[string]string{}
-
-	for i, arg := range flag.Args() {
-		if i == 0 {
-			inFile = arg
-			if conf.CheckFileNameExt {
-				ensurePDFExtension(inFile)
-			}
-			continue
-		}
-		// Ensure key value pair.
-		ss := strings.SplitN(arg, "=", 2)
-		if len(ss) != 2 {
-			fmt.Fprintf(os.Stderr, "keyValuePair = 'key = value'\n")
-			fmt.Fprintf(os.Stderr, "usage: %s\n\n", usagePropertiesAdd)
-			os.Exit(1)
-		}
-		k := strings.TrimSpace(ss[0])
-		if !validate.DocumentProperty(k) {
-			fmt.Fprintf(os.Stderr, "property name \"%s\" not allowed!\n", k)
-			fmt.Fprintf(os.Stderr, "usage: %s\n\n", usagePropertiesAdd)
-			os.Exit(1)
-		}
-		v := strings.TrimSpace(ss[1])
-		properties[k] = v
+	outFile := ""
+	if len(flag.Args()) == 3 {
+		outFile = flag.Arg(2)
+		ensurePDFExtension(outFile)
 	}
 
-	process(cli.AddPropertiesCommand(inFile, "", properties, conf))
+	process(cli.ImportKeywordsCommand(inFile, inFileJSON, outFile, replaceBookmarks, conf))
 }
 
-func processRemovePropertiesCommand(conf *model.Configuration) {
-	if len(flag.Args()) < 1 || selectedPages != "" {
-		fmt.Fprintf(os.Stderr, "usage: %s\n\n", usagePropertiesRemove)
-		os.Exit(1)
+func processExportKeywordsCommand(conf *model.Configuration) {
+	if len(flag.Args()) == 0 || len(flag.Args()) > 2 || selectedPages != "" {
+		fmt.Fprintf(os.Stderr, "usage: %s\n\n", usageKeywordsExport)
+		os.Exit(exitUsage)
 	}
 
-	var inFile string
-	keys := []string{}
+	inFile := flag.Arg(0)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
 
-	for i, arg := range flag.Args() {
-		if i == 0 {
-			inFile = arg
-			if conf.CheckFileNameExt {
-				ensurePDFExtension(inFile)
-			}
+	outFileJSON := "out.json"
+	if len(flag.Args()) == 2 {
+		outFileJSON = flag.Arg(1)
+		ensureJSONExtension(outFileJSON)
+	}
+
+	process(cli.ExportKeywordsCommand(inFile, outFileJSON, conf))
+}
+
+func processListPropertiesCommand(conf *model.Configuration) {
+	if len(flag.Args()) != 1 || selectedPages != "" {
+		fmt.Fprintf(os.Stderr, "usage: %s\n", usagePropertiesList)
+		os.Exit(exitUsage)
+	}
+
+	inFile := flag.Arg(0)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+	process(cli.ListPropertiesCommand(inFile, conf))
+}
+
+func processAddPropertiesJSONCommand(conf *model.Configuration) {
+	if len(flag.Args()) < 2 || len(flag.Args()) > 3 || selectedPages != "" {
+		fmt.Fprintf(os.Stderr, "usage: %s\n\n", usagePropertiesAddJSON)
+		os.Exit(exitUsage)
+	}
+
+	inFile := flag.Arg(0)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+
+	inFileJSON := flag.Arg(1)
+	ensureJSONExtension(inFileJSON)
+
+	outFile := ""
+	if len(flag.Args()) == 3 {
+		outFile = flag.Arg(2)
+		ensurePDFExtension(outFile)
+	}
+
+	process(cli.AddPropertiesJSONCommand(inFile, inFileJSON, outFile, conf))
+}
+
+func processAddPropertiesCommand(conf *model.Configuration) {
+	if json {
+		processAddPropertiesJSONCommand(conf)
+		return
+	}
+
+	if len(flag.Args()) < 2 || selectedPages != "" {
+		fmt.Fprintf(os.Stderr, "usage: %s\n\n", usagePropertiesAdd)
+		os.Exit(exitUsage)
+	}
+
+	var inFile string
+	properties := map[string]string{}
+
+	for i, arg := range flag.Args() {
+		if i == 0 {
+			inFile = arg
+			if conf.CheckFileNameExt {
+				ensurePDFExtension(inFile)
+			}
+			continue
+		}
+		// Ensure key value pair.
+		ss := strings.SplitN(arg, "=", 2)
+		if len(ss) != 2 {
+			fmt.Fprintf(os.Stderr, "keyValuePair = 'key = value'\n")
+			fmt.Fprintf(os.Stderr, "usage: %s\n\n", usagePropertiesAdd)
+			os.Exit(exitUsage)
+		}
+		k := strings.TrimSpace(ss[0])
+		if !validate.DocumentProperty(k) {
+			fmt.Fprintf(os.Stderr, "property name \"%s\" not allowed!\n", k)
+			fmt.Fprintf(os.Stderr, "usage: %s\n\n", usagePropertiesAdd)
+			os.Exit(exitUsage)
+		}
+		v := strings.TrimSpace(ss[1])
+		properties[k] = v
+	}
+
+	process(cli.AddPropertiesCommand(inFile, "", properties, conf))
+}
+
+func processExportPropertiesCommand(conf *model.Configuration) {
+	if len(flag.Args()) == 0 || len(flag.Args()) > 2 || selectedPages != "" {
+		fmt.Fprintf(os.Stderr, "usage: %s\n\n", usagePropertiesExport)
+		os.Exit(exitUsage)
+	}
+
+	inFile := flag.Arg(0)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+
+	outFileJSON := "out.json"
+	if len(flag.Args()) == 2 {
+		outFileJSON = flag.Arg(1)
+		ensureJSONExtension(outFileJSON)
+	}
+
+	process(cli.ExportPropertiesCommand(inFile, outFileJSON, conf))
+}
+
+func processRemovePropertiesCommand(conf *model.Configuration) {
+	if len(flag.Args()) < 1 || selectedPages != "" {
+		fmt.Fprintf(os.Stderr, "usage: %s\n\n", usagePropertiesRemove)
+		os.Exit(exitUsage)
+	}
+
+	var inFile string
+	keys := []string{}
+
+	for i, arg := range flag.Args() {
+		if i == 0 {
+			inFile = arg
+			if conf.CheckFileNameExt {
+				ensurePDFExtension(inFile)
+			}
 			continue
 		}
 
 		if !validate.DocumentProperty(arg) {
 			fmt.Fprintf(os.Stderr, "property name \"%s\" not allowed!\n", arg)
 			fmt.Fprintf(os.Stderr, "usage: %s\n\n", usagePropertiesRemove)
-			os.Exit(1)
+			os.Exit(exitUsage)
 		}
 
 		keys = append(keys, arg)
@@ -1811,7 +2209,7 @@ func processRemovePropertiesCommand(conf *model.Configuration) {
 func processCollectCommand(conf *model.Configuration) {
 	if len(flag.Args()) < 1 || len(flag.Args()) > 2 || selectedPages == "" {
 		fmt.Fprintf(os.Stderr, "%s\n\n", usageCollect)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	inFile := flag.Arg(0)
@@ -1828,16 +2226,25 @@ func processCollectCommand(conf *model.Configuration) {
 	selectedPages, err := api.ParsePageSelection(selectedPages)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "problem with flag selectedPages: %v\n", err)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	process(cli.CollectCommand(inFile, outFile, selectedPages, conf))
 }
 
+func processBatchCommand(conf *model.Configuration) {
+	if len(flag.Args()) != 1 || selectedPages != "" {
+		fmt.Fprintf(os.Stderr, "%s\n\n", usageBatch)
+		os.Exit(exitUsage)
+	}
+
+	process(cli.BatchCommand(flag.Arg(0), conf))
+}
+
 func processListBoxesCommand(conf *model.Configuration) {
 	if len(flag.Args()) < 1 || len(flag.Args()) > 2 {
 		fmt.Fprintf(os.Stderr, "usage: %s\n", usageBoxesList)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	processDisplayUnit(conf)
@@ -1845,7 +2252,7 @@ func processListBoxesCommand(conf *model.Configuration) {
 	selectedPages, err := api.ParsePageSelection(selectedPages)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "problem with flag selectedPages: %v\n", err)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	if len(flag.Args()) == 1 {
@@ -1860,7 +2267,7 @@ func processListBoxesCommand(conf *model.Configuration) {
 	pb, err := api.PageBoundariesFromBoxList(flag.Arg(0))
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "problem parsing box list: %v\n", err)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	inFile := flag.Arg(1)
@@ -1874,7 +2281,7 @@ func processListBoxesCommand(conf *model.Configuration) {
 func processAddBoxesCommand(conf *model.Configuration) {
 	if len(flag.Args()) < 1 || len(flag.Args()) > 3 {
 		fmt.Fprintf(os.Stderr, "usage: %s\n", usageBoxesAdd)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	processDisplayUnit(conf)
@@ -1882,7 +2289,7 @@ func processAddBoxesCommand(conf *model.Configuration) {
 	pb, err := api.PageBoundaries(flag.Arg(0), conf.Unit)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "problem parsing page boundaries: %v\n", err)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	inFile := flag.Arg(1)
@@ -1899,7 +2306,7 @@ func processAddBoxesCommand(conf *model.Configuration) {
 	selectedPages, err := api.ParsePageSelection(selectedPages)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "problem with flag selectedPages: %v\n", err)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	process(cli.AddBoxesCommand(inFile, outFile, selectedPages, pb, conf))
@@ -1908,22 +2315,22 @@ func processAddBoxesCommand(conf *model.Configuration) {
 func processRemoveBoxesCommand(conf *model.Configuration) {
 	if len(flag.Args()) < 1 || len(flag.Args()) > 3 {
 		fmt.Fprintf(os.Stderr, "usage: %s\n", usageBoxesRemove)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	pb, err := api.PageBoundariesFromBoxList(flag.Arg(0))
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "problem parsing box list: %v\n", err)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 	if pb == nil {
 		fmt.Fprintln(os.Stderr, "please supply a list of box types to be removed")
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	if pb.Media != nil {
 		fmt.Fprintf(os.Stderr, "cannot remove media box\n")
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	inFile := flag.Arg(1)
@@ -1940,7 +2347,7 @@ func processRemoveBoxesCommand(conf *model.Configuration) {
 	selectedPages, err := api.ParsePageSelection(selectedPages)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "problem with flag selectedPages: %v\n", err)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	process(cli.RemoveBoxesCommand(inFile, outFile, selectedPages, pb, conf))
@@ -1949,7 +2356,7 @@ func processRemoveBoxesCommand(conf *model.Configuration) {
 func processCropCommand(conf *model.Configuration) {
 	if len(flag.Args()) < 1 || len(flag.Args()) > 3 {
 		fmt.Fprintf(os.Stderr, "%s\n", usageCrop)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	processDisplayUnit(conf)
@@ -1957,7 +2364,7 @@ func processCropCommand(conf *model.Configuration) {
 	box, err := api.Box(flag.Arg(0), conf.Unit)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "problem parsing box definition: %v\n", err)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	inFile := flag.Arg(1)
@@ -1974,7 +2381,7 @@ func processCropCommand(conf *model.Configuration) {
 	selectedPages, err := api.ParsePageSelection(selectedPages)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "problem with flag selectedPages: %v\n", err)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	process(cli.CropCommand(inFile, outFile, selectedPages, box, conf))
@@ -1983,7 +2390,34 @@ func processCropCommand(conf *model.Configuration) {
 func processListAnnotationsCommand(conf *model.Configuration) {
 	if len(flag.Args()) != 1 {
 		fmt.Fprintf(os.Stderr, "usage: %s\n", usageAnnotsList)
-		os.Exit(1)
+		os.Exit(exitUsage)
+	}
+
+	inFile := flag.Arg(0)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+
+	selectedPages, err := api.ParsePageSelection(selectedPages)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "problem with flag selectedPages: %v\n", err)
+		os.Exit(exitUsage)
+	}
+
+	filter, err := pdfcpu.ParseAnnotationFilter(annotTypes, splitAuthor, annotFrom, annotUntil, conf.DateFormat)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(exitUsage)
+	}
+
+	process(cli.ListFilteredAnnotationsCommand(inFile, selectedPages, filter, json, conf))
+}
+
+func processAddAnnotationsCommand(conf *model.Configuration) {
+	// pdfcpu annotations add inFile inFileJSON [outFile]
+	if len(flag.Args()) < 2 || len(flag.Args()) > 3 || selectedPages != "" {
+		fmt.Fprintf(os.Stderr, "usage: %s\n", usageAnnotsAdd)
+		os.Exit(exitUsage)
 	}
 
 	inFile := flag.Arg(0)
@@ -1991,25 +2425,53 @@ func processListAnnotationsCommand(conf *model.Configuration) {
 		ensurePDFExtension(inFile)
 	}
 
+	inFileJSON := flag.Arg(1)
+	ensureJSONExtension(inFileJSON)
+
+	outFile := ""
+	if len(flag.Args()) == 3 {
+		outFile = flag.Arg(2)
+		ensurePDFExtension(outFile)
+	}
+
+	process(cli.AddAnnotationsCommand(inFile, inFileJSON, outFile, conf))
+}
+
+func processGenerateAnnotationAppearancesCommand(conf *model.Configuration) {
+	if len(flag.Args()) == 0 || len(flag.Args()) > 2 {
+		fmt.Fprintf(os.Stderr, "usage: %s\n", usageAnnotsAppearances)
+		os.Exit(exitUsage)
+	}
+
 	selectedPages, err := api.ParsePageSelection(selectedPages)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "problem with flag selectedPages: %v\n", err)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
-	process(cli.ListAnnotationsCommand(inFile, selectedPages, conf))
+	inFile := flag.Arg(0)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+	outFile := ""
+	if len(flag.Args()) == 2 {
+		outFile = flag.Arg(1)
+		ensurePDFExtension(outFile)
+	}
+
+	process(cli.GenerateAnnotationAppearancesCommand(inFile, outFile, selectedPages, conf))
 }
 
 func processRemoveAnnotationsCommand(conf *model.Configuration) {
 	if len(flag.Args()) < 1 {
 		fmt.Fprintf(os.Stderr, "usage: %s\n", usageAnnotsRemove)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	selectedPages, err := api.ParsePageSelection(selectedPages)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "problem with flag selectedPages: %v\n", err)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	inFile, outFile := "", ""
@@ -2046,10 +2508,67 @@ func processRemoveAnnotationsCommand(conf *model.Configuration) {
 	process(cli.RemoveAnnotationsCommand(inFile, outFile, selectedPages, idsAndTypes, objNrs, conf))
 }
 
+func processExportAnnotationsCommand(conf *model.Configuration) {
+	if len(flag.Args()) == 0 || len(flag.Args()) > 2 {
+		fmt.Fprintf(os.Stderr, "usage: %s\n", usageAnnotsExport)
+		os.Exit(exitUsage)
+	}
+
+	inFile := flag.Arg(0)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+
+	if mode != "" && mode != "json" && mode != "csv" && mode != "html" {
+		fmt.Fprintf(os.Stderr, "%s\n", usageAnnotsExport)
+		os.Exit(exitUsage)
+	}
+
+	outFile := "out." + mode
+	if mode == "" {
+		outFile = "out.json"
+	}
+	if len(flag.Args()) == 2 {
+		outFile = flag.Arg(1)
+	}
+
+	selectedPages, err := api.ParsePageSelection(selectedPages)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "problem with flag selectedPages: %v\n", err)
+		os.Exit(exitUsage)
+	}
+
+	process(cli.ExportAnnotationsCommand(inFile, outFile, selectedPages, mode, conf))
+}
+
+func processReflowCommand(conf *model.Configuration) {
+	if len(flag.Args()) == 0 || len(flag.Args()) > 2 {
+		fmt.Fprintf(os.Stderr, "%s\n\n", usageReflow)
+		os.Exit(exitUsage)
+	}
+
+	inFile := flag.Arg(0)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+
+	if mode != "" && mode != "html" && mode != "epub" {
+		fmt.Fprintf(os.Stderr, "%s\n\n", usageReflow)
+		os.Exit(exitUsage)
+	}
+
+	outFile := ""
+	if len(flag.Args()) == 2 {
+		outFile = flag.Arg(1)
+	}
+
+	process(cli.ReflowCommand(inFile, outFile, mode, conf))
+}
+
 func processListImagesCommand(conf *model.Configuration) {
 	if len(flag.Args()) < 1 {
 		fmt.Fprintf(os.Stderr, "usage: %s\n", usageImagesList)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	inFiles := []string{}
@@ -2058,7 +2577,7 @@ func processListImagesCommand(conf *model.Configuration) {
 			matches, err := filepath.Glob(arg)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "%s", err)
-				os.Exit(1)
+				os.Exit(exitUsage)
 			}
 			inFiles = append(inFiles, matches...)
 			continue
@@ -2072,7 +2591,7 @@ func processListImagesCommand(conf *model.Configuration) {
 	selectedPages, err := api.ParsePageSelection(selectedPages)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "problem with flag selectedPages: %v\n", err)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	process(cli.ListImagesCommand(inFiles, selectedPages, conf))
@@ -2082,7 +2601,7 @@ func processExtractImagesCommand(conf *model.Configuration) {
 	// See also processExtractCommand
 	if len(flag.Args()) != 2 {
 		fmt.Fprintf(os.Stderr, "%s\n\n", usageImagesExtract)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	inFile := flag.Arg(0)
@@ -2094,7 +2613,7 @@ func processExtractImagesCommand(conf *model.Configuration) {
 	pages, err := api.ParsePageSelection(selectedPages)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "problem with flag selectedPages: %v\n", err)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	process(cli.ExtractImagesCommand(inFile, outDir, pages, conf))
@@ -2104,7 +2623,7 @@ func processUpdateImagesCommand(conf *model.Configuration) {
 	argCount := len(flag.Args())
 	if argCount < 2 || argCount > 5 {
 		fmt.Fprintf(os.Stderr, "%s\n\n", usageImagesUpdate)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	inFile := flag.Arg(0)
@@ -2129,11 +2648,11 @@ func processUpdateImagesCommand(conf *model.Configuration) {
 			i, err := strconv.Atoi(flag.Arg(c))
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "%v\n", err)
-				os.Exit(1)
+				os.Exit(exitUsage)
 			}
 			if i <= 0 {
 				fmt.Fprintln(os.Stderr, "objNr & pageNr must be > 0")
-				os.Exit(1)
+				os.Exit(exitUsage)
 			}
 			objNrOrPageNr = i
 			if argCount == c+2 {
@@ -2151,7 +2670,7 @@ func processDumpCommand(conf *model.Configuration) {
 	s := "No dump for you! - One year!\n\n"
 	if len(flag.Args()) != 3 {
 		fmt.Fprintln(os.Stderr, s)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	vals := []int{0, 0}
@@ -2168,7 +2687,7 @@ func processDumpCommand(conf *model.Configuration) {
 	objNr, err := strconv.Atoi(flag.Arg(1))
 	if err != nil {
 		fmt.Fprintln(os.Stderr, s)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 	vals[1] = objNr
 
@@ -2183,7 +2702,7 @@ func processDumpCommand(conf *model.Configuration) {
 func processCreateCommand(conf *model.Configuration) {
 	if len(flag.Args()) <= 1 || len(flag.Args()) > 3 || selectedPages != "" {
 		fmt.Fprintf(os.Stderr, "%s\n\n", usageCreate)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	inFileJSON := flag.Arg(0)
@@ -2200,13 +2719,18 @@ func processCreateCommand(conf *model.Configuration) {
 		ensurePDFExtension(outFile)
 	}
 
+	if dataFile != "" {
+		process(cli.CreateWithDataCommand(inFile, inFileJSON, dataFile, outFile, conf))
+		return
+	}
+
 	process(cli.CreateCommand(inFile, inFileJSON, outFile, conf))
 }
 
 func processListFormFieldsCommand(conf *model.Configuration) {
 	if len(flag.Args()) < 1 || selectedPages != "" {
 		fmt.Fprintf(os.Stderr, "usage: %s\n\n", usageFormListFields)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	inFiles := []string{}
@@ -2215,7 +2739,7 @@ func processListFormFieldsCommand(conf *model.Configuration) {
 			matches, err := filepath.Glob(arg)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "%s", err)
-				os.Exit(1)
+				os.Exit(exitUsage)
 			}
 			inFiles = append(inFiles, matches...)
 			continue
@@ -2226,13 +2750,17 @@ func processListFormFieldsCommand(conf *model.Configuration) {
 		inFiles = append(inFiles, arg)
 	}
 
-	process(cli.ListFormFieldsCommand(inFiles, conf))
+	if json {
+		log.SetCLILogger(nil)
+	}
+
+	process(cli.ListFormFieldsCommand(inFiles, json, conf))
 }
 
 func processRemoveFormFieldsCommand(conf *model.Configuration) {
 	if len(flag.Args()) < 2 {
 		fmt.Fprintf(os.Stderr, "usage: %s\n\n", usageFormRemoveFields)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	inFile := flag.Arg(0)
@@ -2247,7 +2775,7 @@ func processRemoveFormFieldsCommand(conf *model.Configuration) {
 		s := flag.Arg(1)
 		if hasPDFExtension(s) {
 			fmt.Fprintf(os.Stderr, "usage: %s\n\n", usageFormRemoveFields)
-			os.Exit(1)
+			os.Exit(exitUsage)
 		}
 		fieldIDs = append(fieldIDs, s)
 	} else {
@@ -2268,7 +2796,7 @@ func processRemoveFormFieldsCommand(conf *model.Configuration) {
 func processLockFormCommand(conf *model.Configuration) {
 	if len(flag.Args()) == 0 || selectedPages != "" {
 		fmt.Fprintf(os.Stderr, "usage: %s\n\n", usageFormLock)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	inFile := flag.Arg(0)
@@ -2300,7 +2828,7 @@ func processLockFormCommand(conf *model.Configuration) {
 func processUnlockFormCommand(conf *model.Configuration) {
 	if len(flag.Args()) == 0 || selectedPages != "" {
 		fmt.Fprintf(os.Stderr, "usage: %s\n\n", usageFormUnlock)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	inFile := flag.Arg(0)
@@ -2332,7 +2860,7 @@ func processUnlockFormCommand(conf *model.Configuration) {
 func processResetFormCommand(conf *model.Configuration) {
 	if len(flag.Args()) == 0 || selectedPages != "" {
 		fmt.Fprintf(os.Stderr, "usage: %s\n\n", usageFormReset)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	inFile := flag.Arg(0)
@@ -2361,10 +2889,76 @@ func processResetFormCommand(conf *model.Configuration) {
 	process(cli.ResetFormCommand(inFile, outFile, fieldIDs, conf))
 }
 
+func collectPDFFiles(dir string, recursive bool, inFiles *[]string) error {
+	return filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != dir && !recursive {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if hasPDFExtension(path) {
+			*inFiles = append(*inFiles, path)
+		}
+		return nil
+	})
+}
+
+func processExportFormCSVCommand(conf *model.Configuration) {
+	if len(flag.Args()) == 0 || selectedPages != "" {
+		fmt.Fprintf(os.Stderr, "usage: %s\n\n", usageFormExportCSV)
+		os.Exit(exitUsage)
+	}
+
+	args := flag.Args()
+
+	outFileCSV := "out.csv"
+	if len(args) > 1 && hasCSVExtension(args[len(args)-1]) {
+		outFileCSV = args[len(args)-1]
+		args = args[:len(args)-1]
+	}
+
+	inFiles := []string{}
+
+	for _, arg := range args {
+		dir, err := isDir(arg)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(exitUsage)
+		}
+		if !dir {
+			if conf.CheckFileNameExt {
+				ensurePDFExtension(arg)
+			}
+			inFiles = append(inFiles, arg)
+			continue
+		}
+		if err := collectPDFFiles(arg, recursive, &inFiles); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(exitUsage)
+		}
+	}
+
+	if len(inFiles) == 0 {
+		fmt.Fprintln(os.Stderr, "no PDF files found.")
+		os.Exit(exitUsage)
+	}
+
+	process(cli.ExportFormCSVCommand(inFiles, outFileCSV, conf))
+}
+
 func processExportFormCommand(conf *model.Configuration) {
-	if len(flag.Args()) == 0 || len(flag.Args()) > 2 || selectedPages != "" {
+	if format == "csv" {
+		processExportFormCSVCommand(conf)
+		return
+	}
+
+	if len(flag.Args()) == 0 || len(flag.Args()) > 2 || selectedPages != "" || recursive || (format != "" && format != "json") {
 		fmt.Fprintf(os.Stderr, "usage: %s\n\n", usageFormExport)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	inFile := flag.Arg(0)
@@ -2386,7 +2980,7 @@ func processExportFormCommand(conf *model.Configuration) {
 func processFillFormCommand(conf *model.Configuration) {
 	if len(flag.Args()) < 2 || len(flag.Args()) > 3 || selectedPages != "" {
 		fmt.Fprintf(os.Stderr, "usage: %s\n\n", usageFormFill)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	inFile := flag.Arg(0)
@@ -2413,12 +3007,12 @@ func processMultiFillFormCommand(conf *model.Configuration) {
 	mode = modeCompletion(mode, []string{"single", "merge"})
 	if mode == "" {
 		fmt.Fprintf(os.Stderr, "usage: %s\n\n", usageFormMultiFill)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	if len(flag.Args()) < 3 || selectedPages != "" {
 		fmt.Fprintf(os.Stderr, "usage: %s\n\n", usageFormMultiFill)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	inFile := flag.Arg(0)
@@ -2429,7 +3023,7 @@ func processMultiFillFormCommand(conf *model.Configuration) {
 	inFileData := flag.Arg(1)
 	if !hasJSONExtension(inFileData) && !hasCSVExtension(inFileData) {
 		fmt.Fprintf(os.Stderr, "%s needs extension \".json\" or \".csv\".\n", inFileData)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	outDir := flag.Arg(2)
@@ -2443,35 +3037,212 @@ func processMultiFillFormCommand(conf *model.Configuration) {
 	process(cli.MultiFillFormCommand(inFile, inFileData, outDir, outFile, mode == "merge", conf))
 }
 
-func processResizeCommand(conf *model.Configuration) {
-	if len(flag.Args()) < 2 || len(flag.Args()) > 3 {
-		fmt.Fprintf(os.Stderr, "%s\n", usageResize)
-		os.Exit(1)
+func processAddSignatureFieldCommand(conf *model.Configuration) {
+	// pdfcpu form add-sigfield [-p page] inFile [outFile] fieldName rect [label]
+	args := flag.Args()
+	if len(args) < 3 {
+		fmt.Fprintf(os.Stderr, "usage: %s\n\n", usageFormAddSigField)
+		os.Exit(exitUsage)
 	}
 
-	processDisplayUnit(conf)
-
-	rc, err := pdfcpu.ParseResizeConfig(flag.Arg(0), conf.Unit)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "%v\n", err)
-		os.Exit(1)
+	pageNr := 1
+	if selectedPages != "" {
+		pageNr = contentPageNr(usageFormAddSigField)
 	}
 
-	inFile := flag.Arg(1)
+	processDisplayUnit(conf)
+
+	inFile := args[0]
 	if conf.CheckFileNameExt {
 		ensurePDFExtension(inFile)
 	}
 
 	outFile := ""
-	if len(flag.Args()) == 3 {
-		outFile = flag.Arg(2)
-		ensurePDFExtension(outFile)
+	args = args[1:]
+	if hasPDFExtension(args[0]) {
+		outFile, args = args[0], args[1:]
 	}
 
-	selectedPages, err := api.ParsePageSelection(selectedPages)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "problem with flag selectedPages: %v\n", err)
-		os.Exit(1)
+	if len(args) < 2 || len(args) > 3 {
+		fmt.Fprintf(os.Stderr, "usage: %s\n\n", usageFormAddSigField)
+		os.Exit(exitUsage)
+	}
+
+	box, err := api.Box(args[1], conf.Unit)
+	if err != nil || box == nil || box.Rect == nil {
+		fmt.Fprintf(os.Stderr, "problem parsing rectangle, expected \"[llx lly urx ury]\": %s\n", args[1])
+		os.Exit(exitUsage)
+	}
+
+	label := ""
+	if len(args) == 3 {
+		label = args[2]
+	}
+
+	process(cli.AddSignatureFieldCommand(inFile, outFile, pageNr, box, args[0], label, conf))
+}
+
+func processPrepareSignatureCommand(conf *model.Configuration) {
+	// pdfcpu form prepare-sig inFile [outFile] fieldName [outFileContent]
+	args := flag.Args()
+	if len(args) < 2 || selectedPages != "" {
+		fmt.Fprintf(os.Stderr, "usage: %s\n\n", usageFormPrepareSig)
+		os.Exit(exitUsage)
+	}
+
+	inFile := args[0]
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+
+	outFile := ""
+	args = args[1:]
+	if hasPDFExtension(args[0]) {
+		outFile, args = args[0], args[1:]
+	}
+
+	if len(args) < 1 || len(args) > 2 {
+		fmt.Fprintf(os.Stderr, "usage: %s\n\n", usageFormPrepareSig)
+		os.Exit(exitUsage)
+	}
+
+	outFileContent := ""
+	if len(args) == 2 {
+		outFileContent = args[1]
+	}
+
+	process(cli.PrepareSignatureCommand(inFile, outFile, args[0], outFileContent, 0, conf))
+}
+
+func processEmbedSignatureCommand(conf *model.Configuration) {
+	// pdfcpu form embed-sig inFile inFileCMS [outFile]
+	if len(flag.Args()) < 2 || len(flag.Args()) > 3 || selectedPages != "" {
+		fmt.Fprintf(os.Stderr, "usage: %s\n\n", usageFormEmbedSig)
+		os.Exit(exitUsage)
+	}
+
+	inFile := flag.Arg(0)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+
+	outFile := ""
+	if len(flag.Args()) == 3 {
+		outFile = flag.Arg(2)
+		ensurePDFExtension(outFile)
+	}
+
+	process(cli.EmbedSignatureCommand(inFile, flag.Arg(1), outFile, conf))
+}
+
+func processAddValidationInfoCommand(conf *model.Configuration) {
+	// pdfcpu signatures ltv inFile [outFile] file...
+	args := flag.Args()
+	if len(args) < 2 || selectedPages != "" {
+		fmt.Fprintf(os.Stderr, "usage: %s\n\n", usageSignaturesLTV)
+		os.Exit(exitUsage)
+	}
+
+	inFile := args[0]
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+
+	outFile := ""
+	args = args[1:]
+	if hasPDFExtension(args[0]) {
+		outFile, args = args[0], args[1:]
+	}
+
+	if len(args) == 0 {
+		fmt.Fprintf(os.Stderr, "usage: %s\n\n", usageSignaturesLTV)
+		os.Exit(exitUsage)
+	}
+
+	process(cli.AddValidationInfoCommand(inFile, outFile, args, conf))
+}
+
+func processListUsageRightsCommand(conf *model.Configuration) {
+	// pdfcpu signatures usagerights [-j(son)] inFile...
+	if len(flag.Args()) < 1 || selectedPages != "" {
+		fmt.Fprintf(os.Stderr, "usage: %s\n\n", usageSignaturesUsageRights)
+		os.Exit(exitUsage)
+	}
+
+	inFiles := []string{}
+	for _, arg := range flag.Args() {
+		if strings.Contains(arg, "*") {
+			matches, err := filepath.Glob(arg)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%s", err)
+				os.Exit(exitUsage)
+			}
+			inFiles = append(inFiles, matches...)
+			continue
+		}
+		if conf.CheckFileNameExt {
+			ensurePDFExtension(arg)
+		}
+		inFiles = append(inFiles, arg)
+	}
+
+	if json {
+		log.SetCLILogger(nil)
+	}
+
+	process(cli.ListUsageRightsCommand(inFiles, json, conf))
+}
+
+func processRemoveUsageRightsCommand(conf *model.Configuration) {
+	// pdfcpu signatures remove-usagerights inFile [outFile]
+	if len(flag.Args()) < 1 || len(flag.Args()) > 2 || selectedPages != "" {
+		fmt.Fprintf(os.Stderr, "usage: %s\n\n", usageSignaturesRemoveUsageRights)
+		os.Exit(exitUsage)
+	}
+
+	inFile := flag.Arg(0)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+
+	outFile := ""
+	if len(flag.Args()) == 2 {
+		outFile = flag.Arg(1)
+		ensurePDFExtension(outFile)
+	}
+
+	process(cli.RemoveUsageRightsCommand(inFile, outFile, conf))
+}
+
+func processResizeCommand(conf *model.Configuration) {
+	if len(flag.Args()) < 2 || len(flag.Args()) > 3 {
+		fmt.Fprintf(os.Stderr, "%s\n", usageResize)
+		os.Exit(exitUsage)
+	}
+
+	processDisplayUnit(conf)
+
+	rc, err := pdfcpu.ParseResizeConfig(flag.Arg(0), conf.Unit)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(exitUsage)
+	}
+
+	inFile := flag.Arg(1)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+
+	outFile := ""
+	if len(flag.Args()) == 3 {
+		outFile = flag.Arg(2)
+		ensurePDFExtension(outFile)
+	}
+
+	selectedPages, err := api.ParsePageSelection(selectedPages)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "problem with flag selectedPages: %v\n", err)
+		os.Exit(exitUsage)
 	}
 
 	process(cli.ResizeCommand(inFile, outFile, selectedPages, rc, conf))
@@ -2480,7 +3251,7 @@ func processResizeCommand(conf *model.Configuration) {
 func processPosterCommand(conf *model.Configuration) {
 	if len(flag.Args()) < 3 || len(flag.Args()) > 4 {
 		fmt.Fprintf(os.Stderr, "%s\n", usagePoster)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	processDisplayUnit(conf)
@@ -2489,7 +3260,7 @@ func processPosterCommand(conf *model.Configuration) {
 	cut, err := pdfcpu.ParseCutConfigForPoster(flag.Arg(0), conf.Unit)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "%v\n", err)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	inFile := flag.Arg(1)
@@ -2502,7 +3273,7 @@ func processPosterCommand(conf *model.Configuration) {
 	selectedPages, err := api.ParsePageSelection(selectedPages)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "problem with flag selectedPages: %v\n", err)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	var outFile string
@@ -2516,7 +3287,7 @@ func processPosterCommand(conf *model.Configuration) {
 func processNDownCommand(conf *model.Configuration) {
 	if len(flag.Args()) < 3 || len(flag.Args()) > 5 {
 		fmt.Fprintf(os.Stderr, "%s\n", usageNDown)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	processDisplayUnit(conf)
@@ -2524,7 +3295,7 @@ func processNDownCommand(conf *model.Configuration) {
 	selectedPages, err := api.ParsePageSelection(selectedPages)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "problem with flag selectedPages: %v\n", err)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	var inFile, outDir string
@@ -2537,7 +3308,7 @@ func processNDownCommand(conf *model.Configuration) {
 		cut, err := pdfcpu.ParseCutConfigForN(n, "", conf.Unit)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "%v\n", err)
-			os.Exit(1)
+			os.Exit(exitUsage)
 		}
 		inFile = flag.Arg(1)
 		if conf.CheckFileNameExt {
@@ -2559,14 +3330,14 @@ func processNDownCommand(conf *model.Configuration) {
 	n, err = strconv.Atoi(flag.Arg(1))
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "%v\n", err)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	// Optionally: border, margin, bgcolor
 	cut, err := pdfcpu.ParseCutConfigForN(n, flag.Arg(0), conf.Unit)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "%v\n", err)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	inFile = flag.Arg(2)
@@ -2586,7 +3357,7 @@ func processNDownCommand(conf *model.Configuration) {
 func processCutCommand(conf *model.Configuration) {
 	if len(flag.Args()) < 3 || len(flag.Args()) > 4 {
 		fmt.Fprintf(os.Stderr, "%s\n", usageCut)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	processDisplayUnit(conf)
@@ -2596,7 +3367,7 @@ func processCutCommand(conf *model.Configuration) {
 	cut, err := pdfcpu.ParseCutConfig(flag.Arg(0), conf.Unit)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "%v\n", err)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	inFile := flag.Arg(1)
@@ -2609,7 +3380,7 @@ func processCutCommand(conf *model.Configuration) {
 	selectedPages, err := api.ParsePageSelection(selectedPages)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "problem with flag selectedPages: %v\n", err)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	var outFile string
@@ -2623,7 +3394,7 @@ func processCutCommand(conf *model.Configuration) {
 func processListBookmarksCommand(conf *model.Configuration) {
 	if len(flag.Args()) < 1 || selectedPages != "" {
 		fmt.Fprintf(os.Stderr, "usage: %s\n\n", usageBookmarksList)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	inFile := flag.Arg(0)
@@ -2637,7 +3408,7 @@ func processListBookmarksCommand(conf *model.Configuration) {
 func processExportBookmarksCommand(conf *model.Configuration) {
 	if len(flag.Args()) == 0 || len(flag.Args()) > 2 || selectedPages != "" {
 		fmt.Fprintf(os.Stderr, "usage: %s\n\n", usageBookmarksExport)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	inFile := flag.Arg(0)
@@ -2657,7 +3428,7 @@ func processExportBookmarksCommand(conf *model.Configuration) {
 func processImportBookmarksCommand(conf *model.Configuration) {
 	if len(flag.Args()) == 0 || len(flag.Args()) > 3 || selectedPages != "" {
 		fmt.Fprintf(os.Stderr, "usage: %s\n\n", usageBookmarksImport)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	inFile := flag.Arg(0)
@@ -2680,7 +3451,7 @@ func processImportBookmarksCommand(conf *model.Configuration) {
 func processRemoveBookmarksCommand(conf *model.Configuration) {
 	if len(flag.Args()) == 0 || len(flag.Args()) > 2 || selectedPages != "" {
 		fmt.Fprintf(os.Stderr, "usage: %s\n\n", usageBookmarksExport)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	inFile := flag.Arg(0)
@@ -2697,10 +3468,72 @@ func processRemoveBookmarksCommand(conf *model.Configuration) {
 	process(cli.RemoveBookmarksCommand(inFile, outFile, conf))
 }
 
+func bookmarksInOutFiles(conf *model.Configuration, usage string, nArgs int) (string, string) {
+	if len(flag.Args()) < nArgs || len(flag.Args()) > nArgs+1 || selectedPages != "" {
+		fmt.Fprintf(os.Stderr, "usage: %s\n\n", usage)
+		os.Exit(exitUsage)
+	}
+
+	inFile := flag.Arg(0)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+
+	outFile := ""
+	if len(flag.Args()) == nArgs+1 {
+		outFile = flag.Arg(nArgs)
+		ensurePDFExtension(outFile)
+	}
+
+	return inFile, outFile
+}
+
+func processSortBookmarksCommand(conf *model.Configuration) {
+	byPage := false
+	if mode != "" {
+		switch modeCompletion(mode, []string{"title", "page"}) {
+		case "title":
+		case "page":
+			byPage = true
+		default:
+			fmt.Fprintf(os.Stderr, "usage: %s\n\n", usageBookmarksSort)
+			os.Exit(exitUsage)
+		}
+	}
+
+	inFile, outFile := bookmarksInOutFiles(conf, usageBookmarksSort, 1)
+
+	process(cli.SortBookmarksCommand(inFile, outFile, byPage, conf))
+}
+
+func processDedupBookmarksCommand(conf *model.Configuration) {
+	inFile, outFile := bookmarksInOutFiles(conf, usageBookmarksDedup, 1)
+
+	process(cli.DedupBookmarksCommand(inFile, outFile, conf))
+}
+
+func processCollapseBookmarksCommand(conf *model.Configuration) {
+	inFile, outFile := bookmarksInOutFiles(conf, usageBookmarksCollapse, 2)
+
+	level, err := strconv.Atoi(flag.Arg(1))
+	if err != nil || level < 1 {
+		fmt.Fprintf(os.Stderr, "invalid level: %s\n", flag.Arg(1))
+		os.Exit(exitUsage)
+	}
+
+	process(cli.CollapseBookmarksCommand(inFile, outFile, level, conf))
+}
+
+func processRetargetBookmarksCommand(conf *model.Configuration) {
+	inFile, outFile := bookmarksInOutFiles(conf, usageBookmarksRetarget, 1)
+
+	process(cli.RetargetBookmarksCommand(inFile, outFile, conf))
+}
+
 func processListPageLayoutCommand(conf *model.Configuration) {
 	if len(flag.Args()) != 1 || selectedPages != "" {
 		fmt.Fprintf(os.Stderr, "usage: %s\n", usagePageLayoutList)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	inFile := flag.Arg(0)
@@ -2713,7 +3546,7 @@ func processListPageLayoutCommand(conf *model.Configuration) {
 func processSetPageLayoutCommand(conf *model.Configuration) {
 	if len(flag.Args()) != 2 || selectedPages != "" {
 		fmt.Fprintf(os.Stderr, "usage: %s\n", usagePageLayoutSet)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	inFile := flag.Arg(0)
@@ -2725,7 +3558,7 @@ func processSetPageLayoutCommand(conf *model.Configuration) {
 
 	if !validate.DocumentPageLayout(v) {
 		fmt.Fprintln(os.Stderr, "invalid page layout, use one of: SinglePage, TwoColumnLeft, TwoColumnRight, TwoPageLeft, TwoPageRight")
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	process(cli.SetPageLayoutCommand(inFile, "", v, conf))
@@ -2734,7 +3567,7 @@ func processSetPageLayoutCommand(conf *model.Configuration) {
 func processResetPageLayoutCommand(conf *model.Configuration) {
 	if len(flag.Args()) != 1 || selectedPages != "" {
 		fmt.Fprintf(os.Stderr, "usage: %s\n", usagePageLayoutReset)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	inFile := flag.Arg(0)
@@ -2744,10 +3577,70 @@ func processResetPageLayoutCommand(conf *model.Configuration) {
 	process(cli.ResetPageLayoutCommand(inFile, "", conf))
 }
 
+func processListLanguageCommand(conf *model.Configuration) {
+	if len(flag.Args()) != 1 || selectedPages != "" {
+		fmt.Fprintf(os.Stderr, "usage: %s\n", usageLanguageList)
+		os.Exit(exitUsage)
+	}
+
+	inFile := flag.Arg(0)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+	process(cli.ListLanguageCommand(inFile, conf))
+}
+
+func processSetLanguageCommand(conf *model.Configuration) {
+	if len(flag.Args()) < 2 || len(flag.Args()) > 3 || selectedPages != "" {
+		fmt.Fprintf(os.Stderr, "usage: %s\n", usageLanguageSet)
+		os.Exit(exitUsage)
+	}
+
+	inFile := flag.Arg(0)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+
+	lang := flag.Arg(1)
+
+	if !types.ValidLanguageTag(lang) {
+		fmt.Fprintln(os.Stderr, "invalid language, use a language identifier like: en, en-US, de-CH")
+		os.Exit(exitUsage)
+	}
+
+	outFile := ""
+	if len(flag.Args()) == 3 {
+		outFile = flag.Arg(2)
+		ensurePDFExtension(outFile)
+	}
+
+	process(cli.SetLanguageCommand(inFile, outFile, lang, conf))
+}
+
+func processResetLanguageCommand(conf *model.Configuration) {
+	if len(flag.Args()) < 1 || len(flag.Args()) > 2 || selectedPages != "" {
+		fmt.Fprintf(os.Stderr, "usage: %s\n", usageLanguageReset)
+		os.Exit(exitUsage)
+	}
+
+	inFile := flag.Arg(0)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+
+	outFile := ""
+	if len(flag.Args()) == 2 {
+		outFile = flag.Arg(1)
+		ensurePDFExtension(outFile)
+	}
+
+	process(cli.ResetLanguageCommand(inFile, outFile, conf))
+}
+
 func processListPageModeCommand(conf *model.Configuration) {
 	if len(flag.Args()) != 1 || selectedPages != "" {
 		fmt.Fprintf(os.Stderr, "usage: %s\n", usagePageModeList)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	inFile := flag.Arg(0)
@@ -2760,7 +3653,7 @@ func processListPageModeCommand(conf *model.Configuration) {
 func processSetPageModeCommand(conf *model.Configuration) {
 	if len(flag.Args()) != 2 || selectedPages != "" {
 		fmt.Fprintf(os.Stderr, "usage: %s\n", usagePageModeSet)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	inFile := flag.Arg(0)
@@ -2772,7 +3665,7 @@ func processSetPageModeCommand(conf *model.Configuration) {
 
 	if !validate.DocumentPageMode(v) {
 		fmt.Fprintln(os.Stderr, "invalid page mode, use one of: UseNone, UseOutlines, UseThumbs, FullScreen, UseOC, UseAttachments")
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	process(cli.SetPageModeCommand(inFile, "", v, conf))
@@ -2781,7 +3674,7 @@ func processSetPageModeCommand(conf *model.Configuration) {
 func processResetPageModeCommand(conf *model.Configuration) {
 	if len(flag.Args()) != 1 || selectedPages != "" {
 		fmt.Fprintf(os.Stderr, "usage: %s\n", usagePageModeReset)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	inFile := flag.Arg(0)
@@ -2791,10 +3684,69 @@ func processResetPageModeCommand(conf *model.Configuration) {
 	process(cli.ResetPageModeCommand(inFile, "", conf))
 }
 
+func processListOpenActionCommand(conf *model.Configuration) {
+	if len(flag.Args()) != 1 || selectedPages != "" {
+		fmt.Fprintf(os.Stderr, "usage: %s\n", usageOpenActionList)
+		os.Exit(exitUsage)
+	}
+
+	inFile := flag.Arg(0)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+	process(cli.ListOpenActionCommand(inFile, conf))
+}
+
+func processSetOpenActionCommand(conf *model.Configuration) {
+	if len(flag.Args()) == 0 || len(flag.Args()) > 2 || selectedPages != "" {
+		fmt.Fprintf(os.Stderr, "usage: %s\n", usageOpenActionSet)
+		os.Exit(exitUsage)
+	}
+
+	inFile := flag.Arg(0)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+
+	outFile := ""
+	if len(flag.Args()) == 2 {
+		outFile = flag.Arg(1)
+		ensurePDFExtension(outFile)
+	}
+
+	iv, err := pdfcpu.ParseInitialView(openPage, openZoom, openPageMode, openPageLayout)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(exitUsage)
+	}
+
+	process(cli.SetOpenActionCommand(inFile, outFile, iv, conf))
+}
+
+func processResetOpenActionCommand(conf *model.Configuration) {
+	if len(flag.Args()) == 0 || len(flag.Args()) > 2 || selectedPages != "" {
+		fmt.Fprintf(os.Stderr, "usage: %s\n", usageOpenActionReset)
+		os.Exit(exitUsage)
+	}
+
+	inFile := flag.Arg(0)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+
+	outFile := ""
+	if len(flag.Args()) == 2 {
+		outFile = flag.Arg(1)
+		ensurePDFExtension(outFile)
+	}
+
+	process(cli.ResetOpenActionCommand(inFile, outFile, conf))
+}
+
 func processListViewerPreferencesCommand(conf *model.Configuration) {
 	if len(flag.Args()) != 1 || selectedPages != "" {
 		fmt.Fprintf(os.Stderr, "usage: %s\n", usageViewerPreferencesList)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	inFile := flag.Arg(0)
@@ -2812,7 +3764,7 @@ func processListViewerPreferencesCommand(conf *model.Configuration) {
 func processSetViewerPreferencesCommand(conf *model.Configuration) {
 	if len(flag.Args()) != 2 || selectedPages != "" {
 		fmt.Fprintf(os.Stderr, "usage: %s\n", usageViewerPreferencesSet)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	inFile := flag.Arg(0)
@@ -2835,7 +3787,7 @@ func processSetViewerPreferencesCommand(conf *model.Configuration) {
 func processResetViewerPreferencesCommand(conf *model.Configuration) {
 	if len(flag.Args()) != 1 || selectedPages != "" {
 		fmt.Fprintf(os.Stderr, "usage: %s\n", usageViewerPreferencesReset)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	inFile := flag.Arg(0)
@@ -2848,7 +3800,7 @@ func processResetViewerPreferencesCommand(conf *model.Configuration) {
 func processZoomCommand(conf *model.Configuration) {
 	if len(flag.Args()) < 2 || len(flag.Args()) > 3 {
 		fmt.Fprintf(os.Stderr, "%s\n", usageZoom)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	processDisplayUnit(conf)
@@ -2856,7 +3808,7 @@ func processZoomCommand(conf *model.Configuration) {
 	zc, err := pdfcpu.ParseZoomConfig(flag.Arg(0), conf.Unit)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "%v\n", err)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	inFile := flag.Arg(1)
@@ -2873,8 +3825,617 @@ func processZoomCommand(conf *model.Configuration) {
 	selectedPages, err := api.ParsePageSelection(selectedPages)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "problem with flag selectedPages: %v\n", err)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	process(cli.ZoomCommand(inFile, outFile, selectedPages, zc, conf))
 }
+
+func processListTransitionsCommand(conf *model.Configuration) {
+	if len(flag.Args()) != 1 {
+		fmt.Fprintf(os.Stderr, "usage: %s\n", usageTransitionsList)
+		os.Exit(exitUsage)
+	}
+
+	inFile := flag.Arg(0)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+
+	selectedPages, err := api.ParsePageSelection(selectedPages)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "problem with flag selectedPages: %v\n", err)
+		os.Exit(exitUsage)
+	}
+
+	process(cli.ListTransitionsCommand(inFile, selectedPages, conf))
+}
+
+func processSetTransitionsCommand(conf *model.Configuration) {
+	if len(flag.Args()) < 2 || len(flag.Args()) > 3 {
+		fmt.Fprintf(os.Stderr, "usage: %s\n", usageTransitionsSet)
+		os.Exit(exitUsage)
+	}
+
+	t, err := pdfcpu.ParseTransitionConfig(flag.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(exitUsage)
+	}
+
+	inFile := flag.Arg(1)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+
+	outFile := ""
+	if len(flag.Args()) == 3 {
+		outFile = flag.Arg(2)
+		ensurePDFExtension(outFile)
+	}
+
+	selectedPages, err := api.ParsePageSelection(selectedPages)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "problem with flag selectedPages: %v\n", err)
+		os.Exit(exitUsage)
+	}
+
+	process(cli.SetTransitionsCommand(inFile, outFile, selectedPages, t, conf))
+}
+
+func processRemoveTransitionsCommand(conf *model.Configuration) {
+	if len(flag.Args()) == 0 || len(flag.Args()) > 2 {
+		fmt.Fprintf(os.Stderr, "usage: %s\n", usageTransitionsRemove)
+		os.Exit(exitUsage)
+	}
+
+	inFile := flag.Arg(0)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+
+	outFile := ""
+	if len(flag.Args()) == 2 {
+		outFile = flag.Arg(1)
+		ensurePDFExtension(outFile)
+	}
+
+	selectedPages, err := api.ParsePageSelection(selectedPages)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "problem with flag selectedPages: %v\n", err)
+		os.Exit(exitUsage)
+	}
+
+	process(cli.RemoveTransitionsCommand(inFile, outFile, selectedPages, conf))
+}
+
+func processListGeoCommand(conf *model.Configuration) {
+	if len(flag.Args()) != 1 {
+		fmt.Fprintf(os.Stderr, "usage: %s\n", usageGeoList)
+		os.Exit(exitUsage)
+	}
+
+	inFile := flag.Arg(0)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+
+	selectedPages, err := api.ParsePageSelection(selectedPages)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "problem with flag selectedPages: %v\n", err)
+		os.Exit(exitUsage)
+	}
+
+	process(cli.ListGeoCommand(inFile, selectedPages, conf))
+}
+
+func processAddGeoCommand(conf *model.Configuration) {
+	if len(flag.Args()) < 2 || len(flag.Args()) > 3 {
+		fmt.Fprintf(os.Stderr, "usage: %s\n", usageGeoAdd)
+		os.Exit(exitUsage)
+	}
+
+	inFile := flag.Arg(0)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+
+	inFileJSON := flag.Arg(1)
+	ensureJSONExtension(inFileJSON)
+
+	outFile := ""
+	if len(flag.Args()) == 3 {
+		outFile = flag.Arg(2)
+		ensurePDFExtension(outFile)
+	}
+
+	selectedPages, err := api.ParsePageSelection(selectedPages)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "problem with flag selectedPages: %v\n", err)
+		os.Exit(exitUsage)
+	}
+
+	process(cli.AddGeoCommand(inFile, inFileJSON, outFile, selectedPages, conf))
+}
+
+func processRemoveGeoCommand(conf *model.Configuration) {
+	if len(flag.Args()) == 0 || len(flag.Args()) > 2 {
+		fmt.Fprintf(os.Stderr, "usage: %s\n", usageGeoRemove)
+		os.Exit(exitUsage)
+	}
+
+	inFile := flag.Arg(0)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+
+	outFile := ""
+	if len(flag.Args()) == 2 {
+		outFile = flag.Arg(1)
+		ensurePDFExtension(outFile)
+	}
+
+	selectedPages, err := api.ParsePageSelection(selectedPages)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "problem with flag selectedPages: %v\n", err)
+		os.Exit(exitUsage)
+	}
+
+	process(cli.RemoveGeoCommand(inFile, outFile, selectedPages, conf))
+}
+
+func processListMediaCommand(conf *model.Configuration) {
+	if len(flag.Args()) != 1 {
+		fmt.Fprintf(os.Stderr, "usage: %s\n", usageMediaList)
+		os.Exit(exitUsage)
+	}
+
+	inFile := flag.Arg(0)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+
+	selectedPages, err := api.ParsePageSelection(selectedPages)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "problem with flag selectedPages: %v\n", err)
+		os.Exit(exitUsage)
+	}
+
+	process(cli.ListMediaCommand(inFile, selectedPages, conf))
+}
+
+func processExtractMediaCommand(conf *model.Configuration) {
+	if len(flag.Args()) != 2 {
+		fmt.Fprintf(os.Stderr, "usage: %s\n", usageMediaExtract)
+		os.Exit(exitUsage)
+	}
+
+	inFile := flag.Arg(0)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+	outDir := flag.Arg(1)
+
+	selectedPages, err := api.ParsePageSelection(selectedPages)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "problem with flag selectedPages: %v\n", err)
+		os.Exit(exitUsage)
+	}
+
+	process(cli.ExtractMediaCommand(inFile, outDir, selectedPages, conf))
+}
+
+func processApplyOCRCommand(conf *model.Configuration) {
+	if len(flag.Args()) < 2 || len(flag.Args()) > 3 {
+		fmt.Fprintf(os.Stderr, "usage: %s\n", usageOCRApply)
+		os.Exit(exitUsage)
+	}
+
+	inFile := flag.Arg(0)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+	ocrFile := flag.Arg(1)
+
+	outFile := ""
+	if len(flag.Args()) == 3 {
+		outFile = flag.Arg(2)
+		if conf.CheckFileNameExt {
+			ensurePDFExtension(outFile)
+		}
+	}
+
+	selectedPages, err := api.ParsePageSelection(selectedPages)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "problem with flag selectedPages: %v\n", err)
+		os.Exit(exitUsage)
+	}
+
+	process(cli.ApplyOCRCommand(inFile, ocrFile, outFile, selectedPages, conf))
+}
+
+func processOverlay(conf *model.Configuration, under bool, usage string) {
+	if len(flag.Args()) < 2 || len(flag.Args()) > 3 {
+		fmt.Fprintf(os.Stderr, "%s\n\n", usage)
+		os.Exit(exitUsage)
+	}
+
+	inFile := flag.Arg(0)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+
+	overlayFile := flag.Arg(1)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(overlayFile)
+	}
+
+	outFile := ""
+	if len(flag.Args()) == 3 {
+		outFile = flag.Arg(2)
+		ensurePDFExtension(outFile)
+	}
+
+	m, err := pdfcpu.ParseOverlayMode(mode)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(exitUsage)
+	}
+
+	selectedPages, err := api.ParsePageSelection(selectedPages)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "problem with flag selectedPages: %v\n", err)
+		os.Exit(exitUsage)
+	}
+
+	process(cli.OverlayCommand(inFile, overlayFile, outFile, selectedPages, under, m, conf))
+}
+
+func processOverlayCommand(conf *model.Configuration) {
+	processOverlay(conf, false, usageOverlay)
+}
+
+func processUnderlayCommand(conf *model.Configuration) {
+	processOverlay(conf, true, usageUnderlay)
+}
+
+func processConvertPDFXCommand(conf *model.Configuration) {
+	if len(flag.Args()) == 0 || len(flag.Args()) > 2 || selectedPages != "" {
+		fmt.Fprintf(os.Stderr, "usage: %s\n", usageConvertPDFX)
+		os.Exit(exitUsage)
+	}
+
+	std := pdfcpu.PDFX1a
+	if standard != "" {
+		var err error
+		if std, err = pdfcpu.ParsePDFXStandard(standard); err != nil {
+			fmt.Fprintf(os.Stderr, "usage: %s\n", usageConvertPDFX)
+			os.Exit(exitUsage)
+		}
+	}
+
+	inFile := flag.Arg(0)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+
+	outFile := ""
+	if len(flag.Args()) == 2 {
+		outFile = flag.Arg(1)
+		ensurePDFExtension(outFile)
+	}
+
+	process(cli.ConvertPDFXCommand(inFile, outFile, std, outputCondition, conf))
+}
+
+func processListOutputIntentsCommand(conf *model.Configuration) {
+	if len(flag.Args()) != 1 || selectedPages != "" {
+		fmt.Fprintf(os.Stderr, "usage: %s\n", usageOutputIntentList)
+		os.Exit(exitUsage)
+	}
+
+	inFile := flag.Arg(0)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+
+	process(cli.ListOutputIntentsCommand(inFile, conf))
+}
+
+func processAddOutputIntentCommand(conf *model.Configuration) {
+	if len(flag.Args()) < 2 || len(flag.Args()) > 3 || selectedPages != "" {
+		fmt.Fprintf(os.Stderr, "usage: %s\n", usageOutputIntentAdd)
+		os.Exit(exitUsage)
+	}
+
+	subtype := pdfcpu.OutputIntentPDFA1
+	if outputIntentSubtype != "" {
+		var err error
+		if subtype, err = pdfcpu.ParseOutputIntentSubtype(outputIntentSubtype); err != nil {
+			fmt.Fprintf(os.Stderr, "%s\n", err)
+			os.Exit(exitUsage)
+		}
+	}
+
+	inFile := flag.Arg(0)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+
+	iccFile := flag.Arg(1)
+
+	outFile := ""
+	if len(flag.Args()) == 3 {
+		outFile = flag.Arg(2)
+		ensurePDFExtension(outFile)
+	}
+
+	process(cli.AddOutputIntentCommand(inFile, outFile, iccFile, subtype, outputCondition, conf))
+}
+
+func processRemoveOutputIntentsCommand(conf *model.Configuration) {
+	if len(flag.Args()) < 1 || len(flag.Args()) > 2 || selectedPages != "" {
+		fmt.Fprintf(os.Stderr, "usage: %s\n", usageOutputIntentRemove)
+		os.Exit(exitUsage)
+	}
+
+	subtype := ""
+	if outputIntentSubtype != "" {
+		var err error
+		if subtype, err = pdfcpu.ParseOutputIntentSubtype(outputIntentSubtype); err != nil {
+			fmt.Fprintf(os.Stderr, "%s\n", err)
+			os.Exit(exitUsage)
+		}
+	}
+
+	inFile := flag.Arg(0)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+
+	outFile := ""
+	if len(flag.Args()) == 2 {
+		outFile = flag.Arg(1)
+		ensurePDFExtension(outFile)
+	}
+
+	process(cli.RemoveOutputIntentsCommand(inFile, outFile, subtype, conf))
+}
+
+func processListOverprintCommand(conf *model.Configuration) {
+	if len(flag.Args()) != 1 || selectedPages != "" {
+		fmt.Fprintf(os.Stderr, "usage: %s\n", usageOverprintList)
+		os.Exit(exitUsage)
+	}
+
+	inFile := flag.Arg(0)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+
+	process(cli.ListOverprintCommand(inFile, conf))
+}
+
+func processSetOverprintCommand(conf *model.Configuration) {
+	if len(flag.Args()) < 2 || len(flag.Args()) > 3 {
+		fmt.Fprintf(os.Stderr, "usage: %s\n", usageOverprintSet)
+		os.Exit(exitUsage)
+	}
+
+	oc, err := pdfcpu.ParseOverprintConfig(flag.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(exitUsage)
+	}
+
+	inFile := flag.Arg(1)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+
+	outFile := ""
+	if len(flag.Args()) == 3 {
+		outFile = flag.Arg(2)
+		ensurePDFExtension(outFile)
+	}
+
+	selectedPages, err := api.ParsePageSelection(selectedPages)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "problem with flag selectedPages: %v\n", err)
+		os.Exit(exitUsage)
+	}
+
+	process(cli.SetOverprintCommand(inFile, outFile, selectedPages, oc, conf))
+}
+
+func processFixHairlinesCommand(conf *model.Configuration) {
+	if len(flag.Args()) < 2 || len(flag.Args()) > 3 {
+		fmt.Fprintf(os.Stderr, "%s\n", usageHairlines)
+		os.Exit(exitUsage)
+	}
+
+	processDisplayUnit(conf)
+
+	hc, err := pdfcpu.ParseHairlineConfig(flag.Arg(0), conf.Unit)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(exitUsage)
+	}
+
+	inFile := flag.Arg(1)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+
+	outFile := ""
+	if len(flag.Args()) == 3 {
+		outFile = flag.Arg(2)
+		ensurePDFExtension(outFile)
+	}
+
+	selectedPages, err := api.ParsePageSelection(selectedPages)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "problem with flag selectedPages: %v\n", err)
+		os.Exit(exitUsage)
+	}
+
+	process(cli.FixHairlinesCommand(inFile, outFile, selectedPages, hc, conf))
+}
+
+func contentCompression(usage string) bool {
+	if mode == "" {
+		return true
+	}
+	switch modeCompletion(mode, []string{"flate", "plain"}) {
+	case "flate":
+		return true
+	case "plain":
+		return false
+	}
+	fmt.Fprintf(os.Stderr, "usage: %s\n", usage)
+	os.Exit(exitUsage)
+	return false
+}
+
+func contentPageNr(usage string) int {
+	pageNr, err := strconv.Atoi(selectedPages)
+	if err != nil || pageNr < 1 {
+		fmt.Fprintf(os.Stderr, "usage: %s\n", usage)
+		os.Exit(exitUsage)
+	}
+	return pageNr
+}
+
+func processNormalizeContentCommand(conf *model.Configuration) {
+	if len(flag.Args()) < 1 || len(flag.Args()) > 2 {
+		fmt.Fprintf(os.Stderr, "usage: %s\n", usageContentNormalize)
+		os.Exit(exitUsage)
+	}
+
+	compress := contentCompression(usageContentNormalize)
+
+	inFile := flag.Arg(0)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+
+	outFile := ""
+	if len(flag.Args()) == 2 {
+		outFile = flag.Arg(1)
+		ensurePDFExtension(outFile)
+	}
+
+	selectedPages, err := api.ParsePageSelection(selectedPages)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "problem with flag selectedPages: %v\n", err)
+		os.Exit(exitUsage)
+	}
+
+	process(cli.NormalizeContentCommand(inFile, outFile, selectedPages, compress, conf))
+}
+
+func processExtractPageContentCommand(conf *model.Configuration) {
+	if len(flag.Args()) != 2 {
+		fmt.Fprintf(os.Stderr, "usage: %s\n", usageContentExtract)
+		os.Exit(exitUsage)
+	}
+
+	pageNr := contentPageNr(usageContentExtract)
+
+	inFile := flag.Arg(0)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+
+	process(cli.ExtractPageContentCommand(inFile, flag.Arg(1), pageNr, conf))
+}
+
+func processReplaceContentCommand(conf *model.Configuration) {
+	if len(flag.Args()) < 2 || len(flag.Args()) > 3 {
+		fmt.Fprintf(os.Stderr, "usage: %s\n", usageContentReplace)
+		os.Exit(exitUsage)
+	}
+
+	pageNr := contentPageNr(usageContentReplace)
+	compress := contentCompression(usageContentReplace)
+
+	inFile := flag.Arg(0)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+
+	outFile := ""
+	if len(flag.Args()) == 3 {
+		outFile = flag.Arg(2)
+		ensurePDFExtension(outFile)
+	}
+
+	process(cli.ReplaceContentCommand(inFile, flag.Arg(1), outFile, pageNr, compress, conf))
+}
+
+func processListTemplateRegionsCommand(conf *model.Configuration) {
+	if len(flag.Args()) != 1 || selectedPages != "" {
+		fmt.Fprintf(os.Stderr, "usage: %s\n", usageTemplateList)
+		os.Exit(exitUsage)
+	}
+
+	inFile := flag.Arg(0)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+
+	process(cli.ListTemplateRegionsCommand(inFile, conf))
+}
+
+func processAddTemplateRegionCommand(conf *model.Configuration) {
+	if len(flag.Args()) < 2 || len(flag.Args()) > 3 {
+		fmt.Fprintf(os.Stderr, "usage: %s\n", usageTemplateAdd)
+		os.Exit(exitUsage)
+	}
+
+	pageNr := contentPageNr(usageTemplateAdd)
+
+	processDisplayUnit(conf)
+
+	r, err := pdfcpu.ParseTemplateRegion(flag.Arg(0), pageNr, conf.Unit)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(exitUsage)
+	}
+
+	inFile := flag.Arg(1)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+
+	outFile := ""
+	if len(flag.Args()) == 3 {
+		outFile = flag.Arg(2)
+		ensurePDFExtension(outFile)
+	}
+
+	process(cli.AddTemplateRegionCommand(inFile, outFile, r, conf))
+}
+
+func processFillTemplateCommand(conf *model.Configuration) {
+	if len(flag.Args()) < 2 || len(flag.Args()) > 3 || selectedPages != "" {
+		fmt.Fprintf(os.Stderr, "usage: %s\n", usageTemplateFill)
+		os.Exit(exitUsage)
+	}
+
+	inFile := flag.Arg(0)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+
+	inFileJSON := flag.Arg(1)
+	ensureJSONExtension(inFileJSON)
+
+	outFile := ""
+	if len(flag.Args()) == 3 {
+		outFile = flag.Arg(2)
+		ensurePDFExtension(outFile)
+	}
+
+	process(cli.FillTemplateCommand(inFile, inFileJSON, outFile, conf))
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/cli"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+)
+
+// maxPasswordAttempts limits interactive password prompts per command.
+const maxPasswordAttempts = 3
+
+var errPromptUnsupported = errors.New("pdfcpu: password prompt not supported")
+
+// interactive returns true if stdin is a terminal.
+func interactive() bool {
+	fi, err := os.Stdin.Stat()
+	if err != nil {
+		return false
+	}
+	return fi.Mode()&os.ModeCharDevice != 0
+}
+
+// readPassword prompts for a password on stderr and reads it from the terminal without echo.
+func readPassword(fileName string) (string, error) {
+	restore, err := echoOff()
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		restore()
+		fmt.Fprintln(os.Stderr)
+	}()
+
+	if fileName != "" {
+		fmt.Fprintf(os.Stderr, "Password for %s: ", fileName)
+	} else {
+		fmt.Fprint(os.Stderr, "Password: ")
+	}
+
+	s, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && s == "" {
+		return "", err
+	}
+
+	return strings.TrimRight(s, "\r\n"), nil
+}
+
+// canPrompt returns true if cmd failing with err may be retried using a password entered interactively.
+// Prompting is skipped for passwords supplied via -upw/-opw, -noprompt and non interactive sessions.
+func canPrompt(err error) bool {
+	return errors.Is(err, pdfcpu.ErrWrongPassword) && upw == "" && opw == "" && !noPrompt && interactive()
+}
+
+// processWithPasswordPrompt processes cmd and prompts for the document open password of an encrypted inFile if needed.
+func processWithPasswordPrompt(cmd *cli.Command) ([]string, error) {
+	out, err := cli.Process(cmd)
+
+	for i := 0; i < maxPasswordAttempts && canPrompt(err); i++ {
+		fileName := ""
+		if cmd.InFile != nil {
+			fileName = *cmd.InFile
+		}
+
+		pw, err1 := readPassword(fileName)
+		if err1 != nil {
+			return nil, err
+		}
+
+		// The entered password may be either the owner or the user password.
+		cmd.Conf.UserPW, cmd.Conf.OwnerPW = pw, pw
+
+		out, err = cli.Process(cmd)
+	}
+
+	return out, err
+}
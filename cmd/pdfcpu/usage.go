@@ -25,15 +25,19 @@ Usage:
    
 The commands are:
 
-   annotations   list, remove page annotations
-   attachments   list, add, remove, extract embedded file attachments
+   annotations   list, remove, export page annotations, generate missing appearances
+   attachments   list, add, remove, extract, verify embedded file attachments
+   batch         run a pipeline of operations over a set of files
    booklet       arrange pages onto larger sheets of paper to make a booklet or zine
-   bookmarks     list, import, export, remove bookmarks
+   bookmarks     list, import, export, remove, sort, dedup, collapse, retarget bookmarks
    boxes         list, add, remove page boundaries for selected pages
    changeopw     change owner password
    changeupw     change user password
    collect       create custom sequence of selected pages
+   completion    generate shell completion scripts for bash, zsh, fish or PowerShell
    config        list, reset configuration
+   content       normalize, extract, replace page content streams
+   convert       convert PDF to PDF/X-1a or PDF/X-4 (best effort)
    create        create PDF content including forms via JSON
    crop          set cropbox for selected pages
    cut           custom cut pages horizontally or vertically
@@ -42,29 +46,45 @@ The commands are:
    extract       extract images, fonts, content, pages or metadata
    fonts         install, list supported fonts, create cheat sheets
    form          list, remove fields, lock, unlock, reset, export, fill form via JSON or CSV
+   geo           list, add, remove georeferenced viewports
    grid          rearrange pages or images for enhanced browsing experience
+   hairlines     raise hairline stroke widths to a printable minimum
    images        list, extract, update images
    import        import/convert images to PDF
    info          print file info
-   keywords      list, add, remove keywords
+   keywords      list, add, remove, import, export keywords
+   language      list, set, reset the natural language of the document
+   media         list, extract 3D, RichMedia, sound and movie content
    merge         concatenate PDFs
    ndown         cut selected pages into n pages symmetrically
    nup           rearrange pages or images for reduced number of pages
+   ocr           apply hOCR or ALTO results as invisible text layer
+   openaction    list, set, reset page, zoom, page mode and page layout for opened document
    optimize      optimize PDF by getting rid of redundant page resources
+   outputintent  list, add, remove output intents with ICC profiles for PDF/A and PDF/X
+   overlay       composite pages of another PDF on top of selected pages
+   overprint     list, set overprint and trapping status for prepress
    pagelayout    list, set, reset page layout for opened document
    pagemode      list, set, reset page mode for opened document
-   pages         insert, remove selected pages
+   pagenumbers   stamp page numbers onto selected pages
+   pages         insert, remove selected pages, flatten inherited page attributes
    paper         print list of supported paper sizes
    permissions   list, set user access permissions
    portfolio     list, add, remove, extract portfolio entries with optional description
    poster        cut selected pages into poster by paper size or dimensions
-   properties    list, add, remove document properties
+   properties    list, add, remove, export document properties
+   reflow        export content as HTML or EPUB following the document structure
    resize        scale selected pages
    rotate        rotate selected pages
    selectedpages print definition of the -pages flag
+   signatures    embed validation material, list, remove usage rights
    split         split up a PDF by span or bookmark
    stamp         add, remove, update Unicode text, image or PDF stamps for selected pages
+   template      list, add, fill named placeholder regions of PDF templates
+   transitions   list, set, remove page transitions for presentations
+   triage        classify which processing stage and object a failing file breaks
    trim          create trimmed version of selected pages
+   underlay      composite pages of another PDF underneath selected pages
    validate      validate PDF against PDF 32000-1:2008 (PDF 1.7) + basic PDF 2.0 validation
    version       print version
    viewerpref    list, set, reset viewer preferences for opened document
@@ -73,62 +93,138 @@ The commands are:
 
    All instantly recognizable command prefixes are supported eg. val for validation
    One letter Unix style abbreviations supported for flags and command parameters.
+   Use - as inFile or outFile for reading stdin or writing stdout eg. pdfcpu optimize - - < in.pdf > out.pdf
+   (validate, optimize, encrypt, decrypt, stamp, watermark and other commands modifying a single file, merge create outFile)
 
-Use "pdfcpu help [command]" for more information about a command.`
+Use "pdfcpu help [command]" for more information about a command.
+Use "pdfcpu --help-json" for a machine readable description of all commands and flags.
+
+The exit codes are:
+
+   0 ... success
+   1 ... error
+   2 ... usage error
+   3 ... I/O error
+   4 ... validation failure or warnings with -strict
+   5 ... password required
+   6 ... operation restricted by permissions`
 
 	generalFlags = `
    
 common flags: -v(erbose)  ... turn on logging
               -vv         ... verbose logging
               -q(uiet)    ... disable output
+              -strict     ... treat warnings as errors
+              -dryrun     ... report changes without writing output
+              -backup     ... back up files modified in place to inFile.bak or -backup=path
               -o(ffline)  ... disable http traffic
               -c(onf)     ... set or disable config dir: $path|disable
               -opw        ... owner password
               -upw        ... user password
+              -noprompt   ... do not prompt for passwords of encrypted files
               -u(nit)     ... display unit: po(ints) ... points
                                             in(ches) ... inches
                                                   cm ... centimetres
                                                   mm ... millimetres`
 
-	usageValidate = "usage: pdfcpu validate [-m(ode) strict|relaxed] [-l(inks)] inFile..." + generalFlags
+	usageValidate = "usage: pdfcpu validate [-m(ode) strict|relaxed] [-l(inks)] [-standard pdfx-1a|pdfx-4] inFile..." + generalFlags
 
 	usageLongValidate = `Check inFile for specification compliance.
 
       mode ... validation mode
      links ... check for broken links
+  standard ... additionally preflight against a PDF/X standard
     inFile ... input PDF file
 		
 The validation modes are:
 
  strict ... validates against PDF 32000-1:2008 (PDF 1.7) and rudimentary against PDF 32000:2 (PDF 2.0)
-relaxed ... (default) like strict but doesn't complain about common seen spec violations.`
+relaxed ... (default) like strict but doesn't complain about common seen spec violations.
+            Known quirks of PDF producers like Quartz, Word or Ghostscript get reported as tolerated.
+
+The PDF/X preflight checks:
+
+ pdfx-1a ... PDF/X-1a:2003 (ISO 15930-4): output intent, GTS_PDFXVersion, Trapped,
+             TrimBox within BleedBox and MediaBox, embedded fonts, no RGB or device independent color
+  pdfx-4 ... PDF/X-4 (ISO 15930-7): like pdfx-1a but allowing RGB and ICC based color
+             and requiring an output intent with an embedded ICC profile
+
+All issues found are listed.`
+
+	usageTriage     = "usage: pdfcpu triage [-j(son)] inFile" + generalFlags
+	usageLongTriage = `Process inFile stage by stage and classify the first failure to help report actionable issues.
+
+      json ... produce JSON output
+    inFile ... input PDF file
+
+The processing stages are:
 
-	usageOptimize     = "usage: pdfcpu optimize [-stats csvFile] inFile [outFile]" + generalFlags
+      xref ... locating and parsing the cross reference sections
+   objects ... parsing objects incl. object streams and decryption
+  validate ... validation against the PDF specification
+  optimize ... optimization of the cross reference table
+     write ... serialization
+
+Reported are the failing stage, the object being processed and the cause, including crashes.
+
+Examples:
+
+   pdfcpu triage in.pdf
+
+   pdfcpu triage -j in.pdf > report.json`
+
+	usageOptimize     = "usage: pdfcpu optimize [-passes passes] [-stats jsonFile] inFile [outFile]" + generalFlags
 	usageLongOptimize = `Read inFile, remove redundant page resources like embedded fonts and images and write the result to outFile.
 
-     stats ... appends a stats line to a csv file with information about the usage of root and page entries.
-               useful for batch optimization and debugging PDFs.
+    passes ... a comma separated list of optimization passes (default: fonts,images,gc)
+     stats ... writes a JSON report of the savings per optimization pass.
     inFile ... input PDF file
-   outFile ... output PDF file`
+   outFile ... output PDF file
 
-	usageSplit     = "usage: pdfcpu split [-m(ode) span|bookmark|page] inFile outDir [span|pageNr...]" + generalFlags
+The optimization passes are:
+
+      fonts      ... remove duplicate embedded fonts
+      images     ... remove duplicate images
+      gc         ... drop unreferenced objects
+      recompress ... flate encode uncompressed streams
+      metadata   ... remove XMP metadata and page piece info (breaks PDF/A conformance)
+      pagetree   ... rebalance degenerate page trees
+      alternates ... remove alternate images
+      thumbnails ... remove page thumbnails
+      appstates  ... remove unused appearance states of markup annotations
+      stdfonts   ... unembed fonts metrically identical to the standard 14 fonts
+      deadcode   ... remove no-op sequences like empty q/Q pairs from content streams
+      inlineimg  ... convert large inline images into image XObjects
+      all        ... all of the above
+      default    ... fonts,images,gc
+
+The passes in effect unless -passes is given are configured in config.yml (optimizePasses).`
+
+	usageSplit     = "usage: pdfcpu split [-m(ode) span|bookmark|page] [-title pattern] [-author pattern] [-meta copy|strip] [-pages selectedPages] inFile outDir [span|level|pageNr...]" + generalFlags
 	usageLongSplit = `Generate a set of PDFs for the input file in outDir according to given span value or along bookmarks or page numbers.
 
       mode ... split mode (defaults to span)
     inFile ... input PDF file
     outDir ... output directory
       span ... split span in pages (default: 1) for mode "span"
+     level ... split along bookmarks up to level (default: 1) for mode "bookmark"
     pageNr ... split before a specific page number for mode "page"
+     pages ... split before each selected page for mode "page", eg. -pages matching:"Invoice No"
+     title ... title of the result files (optional)
+    author ... author of the result files (optional)
+      meta ... copy or strip (default) the document metadata of inFile
       
 The split modes are:
 
       span     ... Split into PDF files with span pages each (default).
                    span itself defaults to 1 resulting in single page PDF files.
   
-      bookmark ... Split into PDF files representing sections defined by existing bookmarks.
+      bookmark ... Split into PDF files representing sections defined by existing bookmarks up to level.
+                   Result files are named after section number and bookmark title.
+                   A JSON manifest mapping result files to page ranges is written to outDir.
                    Assumption: inFile contains an outline dictionary.
                    
-      page     ... Split before specific page numbers.
+      page     ... Split before specific page numbers or before each page selected by -pages.
       
 Eg. pdfcpu split test.pdf .      (= pdfcpu split -m span test.pdf . 1)
       generates:
@@ -142,20 +238,35 @@ Eg. pdfcpu split test.pdf .      (= pdfcpu split -m span test.pdf . 1)
          test_3-4.pdf
          etc.
 
-    pdfcpu split -m bookmark test.pdf .
+    pdfcpu split -m bookmark test.pdf . 2
       generates:
-         test_bm1Title_1-4.pdf
-         test_bm2Title.5-7-pdf
+         1_bm1Title.pdf
+         1.1_bm11Title.pdf
+         1.2_bm12Title.pdf
+         2_bm2Title.pdf
          etc.
+         test_manifest.json
 
     pdfcpu split -m page test.pdf . 2 4 10
       generates:
          test_1.pdf
          test_2-3.pdf
          test_4-9.pdf
-         test_10-20.pdf`
+         test_10-20.pdf
+
+Title and author patterns may contain the placeholders:
 
-	usageMerge     = "usage: pdfcpu merge [-m(ode) create|append|zip] [ -s(ort) -b(ookmarks) -d(ivider) -opt(imize)] outFile inFile..." + generalFlags
+    %basename% ... inFile without extension
+    %n%        ... part number
+    %from%     ... first page of part
+    %thru%     ... last page of part
+    %bookmark% ... bookmark title for mode "bookmark"
+    %title%    ... title of inFile
+    %author%   ... author of inFile
+
+Eg. pdfcpu split -title "%basename% part %n%" -meta copy test.pdf . 5`
+
+	usageMerge     = "usage: pdfcpu merge [-m(ode) create|append|zip] [ -s(ort) -b(ookmarks) -d(ivider) -opt(imize) -conflict rename|skip|error -identity description] outFile inFile..." + generalFlags
 	usageLongMerge = `Concatenate a sequence of PDFs/inFiles into outFile.
 
       mode ... merge mode (defaults to create)
@@ -163,6 +274,8 @@ Eg. pdfcpu split test.pdf .      (= pdfcpu split -m span test.pdf . 1)
  bookmarks ... create bookmarks
    divider ... insert blank page between merged documents
   optimize ... optimize before writing (default: true)
+  conflict ... policy for colliding document-level names (default: rename)
+  identity ... file identifier and dates of outFile (default: regenerate)
    outFile ... output PDF file
     inFile ... a list of PDF files subject to concatenation.
     
@@ -177,7 +290,25 @@ The merge modes are:
                
 Skip bookmark creation: -b(ookmarks)=false
 
-Skip optimization before writing: -opt(imize)=false`
+Skip optimization before writing: -opt(imize)=false
+
+Named destinations, JavaScript names, top-level form fields and optional content groups
+of merged in files may collide with those of the files merged before:
+
+    rename ... keep both and rename the merged in item (default).
+      skip ... keep the existing item and drop the merged in item.
+     error ... abort merging.
+
+Any actions taken are reported.
+
+By default outFile gets a fresh file identifier (ID) and new dates (CreationDate, ModDate).
+Downstream systems keying off the document ID may instead keep or set these:
+
+    -identity keep                                        ... preserve ID and dates of the first inFile.
+    -identity "id:0a1b2c3d, created:2024-01-31"           ... set ID (hex) and/or dates (created, modified).
+    -identity "keep, modified:2024-02-01"                 ... explicit values take precedence.
+
+Dates are expected in the date format of your configuration.`
 
 	usagePageSelection = `'-pages' selects pages for processing and is a comma separated list of expressions:
 
@@ -194,13 +325,20 @@ Skip optimization before writing: -opt(imize)=false`
     
    l-3- ... include last 3 pages         l-3 ... include page # last-3
   -l-3  ... include all, but last 3    2-l-1 ... pages 2 up to "last-1"
+l-5-l-2 ... pages "last-5" up to "last-2"
+
+ #-#:2  ... every 2nd page of a range   !even ... exclude even pages
+           (any range may take a step)   !odd ... exclude odd pages
+
+bm:title ... include the pages of the section introduced by the bookmark titled "title"
+matching:re ... include all pages whose text matches the regular expression re
 
 	n serves as an alternative for !, since ! needs to be escaped with single quotes on the cmd line.
 
-        e.g. -3,5,7- or 4-7,!6 or 1-,!5 or odd,n1`
+        e.g. -3,5,7- or 4-7,!6 or 1-,!5 or odd,n1 or 1-100:2 or "bm:Chapter 2" or matching:"Invoice No"`
 
-	usageExtract     = "usage: pdfcpu extract -m(ode) i(mage)|f(ont)|c(ontent)|p(age)|m(eta) [-p(ages) selectedPages] inFile outDir" + generalFlags
-	usageLongExtract = `Export inFile's images, fonts, content or pages into outDir.
+	usageExtract     = "usage: pdfcpu extract -m(ode) i(mage)|f(ont)|c(ontent)|p(age)|m(eta)|x(object) [-p(ages) selectedPages] inFile outDir" + generalFlags
+	usageLongExtract = `Export inFile's images, fonts, content, pages or form XObjects into outDir.
 
       mode ... extraction mode
      pages ... Please refer to "pdfcpu selectedpages"
@@ -214,6 +352,7 @@ Skip optimization before writing: -opt(imize)=false`
 content ... extract raw page content
    page ... extract single page PDFs
    meta ... extract all metadata (page selection does not apply)
+xobject ... extract form XObjects as single page PDFs
    
 `
 
@@ -227,26 +366,54 @@ content ... extract raw page content
 `
 
 	usageAttachList    = "pdfcpu attachments list    inFile"
-	usageAttachAdd     = "pdfcpu attachments add     inFile file..."
+	usageAttachAdd     = "pdfcpu attachments add     [-manifest manifest.json] inFile [file...]"
 	usageAttachRemove  = "pdfcpu attachments remove  inFile [file...]"
-	usageAttachExtract = "pdfcpu attachments extract inFile outDir [file...]"
+	usageAttachExtract = "pdfcpu attachments extract inFile outDir|- [file|glob...]"
+	usageAttachVerify  = "pdfcpu attachments verify  inFile"
 
 	usageAttach = "usage: " + usageAttachList +
 		"\n       " + usageAttachAdd +
 		"\n       " + usageAttachRemove +
-		"\n       " + usageAttachExtract + generalFlags
+		"\n       " + usageAttachExtract +
+		"\n       " + usageAttachVerify + generalFlags
 
 	usageLongAttach = `Manage embedded file attachments.
 
     inFile ... input PDF file
-      file ... attachment
-    outDir ... output directory
+      file ... attachment, for extract also a glob pattern like "*.xml"
+    outDir ... output directory, for extract "-" writes a single attachment to stdout
     
     Remove all attachments: pdfcpu attach remove test.pdf
+
+    Extract all XML attachments: pdfcpu attach extract test.pdf out "*.xml"
+
+    Extract a single attachment to stdout: pdfcpu attach extract test.pdf - invoice.xml > invoice.xml
+
+    Add attachments including metadata: pdfcpu attach add -manifest manifest.json test.pdf
+
+    Verify the checksums of all attachments: pdfcpu attach verify test.pdf
+
+    A manifest describes each file along with optional metadata:
+
+    {
+       "attachments": [
+          {
+             "file": "invoice.xml",
+             "desc": "Factur-X invoice data",
+             "mimeType": "text/xml",
+             "created": "2026-01-15T09:30:00Z",
+             "modified": "2026-01-16T10:00:00Z",
+             "afRelationship": "Alternative"
+          }
+       ]
+    }
+
+    Relative file names are resolved against the directory of the manifest.
+    afRelationship is one of Source, Data, Alternative, Supplement, EncryptedPayload, FormData, Schema, Unspecified.
     `
 
 	usagePortfolioList    = "pdfcpu portfolio list    inFile"
-	usagePortfolioAdd     = "pdfcpu portfolio add     inFile file[,desc]..."
+	usagePortfolioAdd     = "pdfcpu portfolio add     [-manifest manifest.json] inFile [file[,desc]...]"
 	usagePortfolioRemove  = "pdfcpu portfolio remove  inFile [file...]"
 	usagePortfolioExtract = "pdfcpu portfolio extract inFile outDir [file...]"
 
@@ -267,6 +434,10 @@ content ... extract raw page content
 
     Adding attachments to portfolio with description: 
            pdfcpu portfolio add test.pdf "test.mp3, Test sound file" "test.mkv, Test video file"
+
+    Extracting portfolio entries recreates the portfolio folder hierarchy within outDir
+    and preserves the modification dates of the embedded files:
+           pdfcpu portfolio extract test.pdf out
     `
 
 	usagePermList = "pdfcpu permissions list [-upw userpw] [-opw ownerpw] inFile..."
@@ -303,16 +474,28 @@ content ... extract raw page content
      11: Assemble document (security handlers >= rev.3)
      12: Print (security handlers >= rev.3)`
 
-	usageEncrypt     = "usage: pdfcpu encrypt [-m(ode) rc4|aes] [-key 40|128|256] [-perm none|print|all] [-upw userpw] -opw ownerpw inFile [outFile]" + generalFlags
-	usageLongEncrypt = `Setup password protection based on user and owner password.
-
-      mode ... algorithm (default=aes)
-       key ... key length in bits (default=256)
-      perm ... user access permissions
-    inFile ... input PDF file
-   outFile ... output PDF file
+	usageEncryptInfo   = "pdfcpu encrypt info [-upw userpw] [-opw ownerpw] [-j(son)] inFile..."
+	usageEncryptUpdate = "pdfcpu encrypt update [-m(ode) rc4|aes] [-key 40|128|256] [-perm none|print|all] [-attachments] [-upw userpw] -opw ownerpw inFile [outFile]"
+	usageEncrypt       = "usage: pdfcpu encrypt [-m(ode) rc4|aes] [-key 40|128|256] [-perm none|print|all] [-attachments] [-upw userpw] -opw ownerpw inFile [outFile]" +
+		"\n       " + usageEncryptInfo +
+		"\n       " + usageEncryptUpdate + generalFlags
+	usageLongEncrypt = `Setup password protection based on user and owner password,
+report the encryption details of a file w/o modifying it
+or change the encryption parameters of an encrypted file in one pass keeping its passwords.
+
+       mode ... algorithm (default=aes)
+        key ... key length in bits (default=256)
+       perm ... user access permissions
+attachments ... encrypt embedded files only, the document remains openable (key length 128 or 256)
+     inFile ... input PDF file
+    outFile ... output PDF file
    
-   PDF 2.0 files have to be encrypted using aes/256.`
+   PDF 2.0 files have to be encrypted using aes/256.
+
+   encrypt info reports the security handler revision, algorithm, key length, metadata encryption,
+   the decoded permission bits and whether the supplied user and owner passwords are valid.
+
+   encrypt update requires both current passwords and retains the current permissions unless -perm is set.`
 
 	usageDecrypt     = "usage: pdfcpu decrypt [-upw userpw] [-opw ownerpw] inFile [outFile]" + generalFlags
 	usageLongDecrypt = `Remove password protection and reset permissions.
@@ -364,6 +547,10 @@ content ... extract raw page content
          Customize your multistamp by starting with startPage#Src of a stamp PDF file.
          Apply repeatedly pages of the stamp file to inFile starting at startPage#Dest.
          Eg: pdfcpu stamp add -mode pdf -- "stamp.pdf:2:3" "" in.pdf out.pdf ... multistamp starting with page 2 of stamp.pdf onto page 3 of in.pdf
+
+      Once all pages of the stamp file are used up, the last page is repeated.
+      Use "multi:cycle" to start over with the first page or "multi:once" to leave the remaining pages untouched.
+      For mapping individual pages of a stamp file onto page ranges, each with its own description, use "pdfcpu stamp apply".
    `
 
 	usageWatermarkMode = `There are 3 different kinds of watermarks:
@@ -395,6 +582,10 @@ content ... extract raw page content
          Apply repeatedly pages of the watermark file to inFile starting at startPage#Dest.
          Eg: pdfcpu watermark add -mode pdf -- "watermark.pdf:2:3" "" in.pdf out.pdf ... multiwatermark starting with page 2 of watermark.pdf onto page 3 of in.pdf
 
+      Once all pages of the watermark file are used up, the last page is repeated.
+      Use "multi:cycle" to start over with the first page or "multi:once" to leave the remaining pages untouched.
+      For mapping individual pages of a watermark file onto page ranges, each with its own description, use "pdfcpu watermark apply".
+
    A watermark is the first content that gets rendered for a page.
    The visibility of the watermark depends on the transparency of all layers rendered on top.
 `
@@ -416,7 +607,7 @@ content ... extract raw page content
 
    points:           fontsize in points, in combination with absolute scaling only.
 
-   rtl:              render right to left (on/off, true/false, t/f)
+   rtl:              render right to left (on/off, true/false, t/f), implied by text starting with Arabic or Hebrew
 
    position:         one of the anchors:
 
@@ -447,6 +638,10 @@ content ... extract raw page content
    
    opacity:          where 0.0 <= x <= 1.0
 
+   blendmode:        blend mode used for compositing with the page content:
+                     Normal, Multiply, Screen, Overlay, Darken, Lighten, ColorDodge, ColorBurn,
+                     HardLight, SoftLight, Difference, Exclusion, Hue, Saturation, Color, Luminosity
+
    mode, rendermode: 0 ... fill (applies fill color)
                      1 ... stroke (applies stroke color)
                      2 ... fill & stroke (applies both fill and stroke colors)
@@ -468,6 +663,30 @@ content ... extract raw page content
 
    url:              Add link annotation for stamps only (omit https://)
 
+   id:               Identify stamps/watermarks for selective update and removal, eg. "id:draft"
+                     "update" replaces and "remove -id" removes matching stamps/watermarks only.
+
+   multi:            PDF multi stamps only: once all source pages are used up
+                     repeat ... repeat the last source page (default)
+                     cycle  ... start over with the first source page
+                     once   ... leave the remaining pages untouched
+
+   pagebox:          page box used for positioning and scaling: m(edia), c(rop) (default: crop)
+
+   keeprotation:     retain page rotation instead of rewriting the page content (on/off, true/false, t/f)
+                     The watermark is rendered upright relative to the displayed page either way.
+
+   tile:             repeat the watermark across the page at the given rotation (on/off, true/false, t/f)
+
+   spacing:          tiles only: (dx dy) or d gap between tiles in given display unit eg. '20 40'
+                     (default: tile height)
+
+   textpath:         text only: render the first line glyph by glyph along a path in given display unit
+                     arc r [a0 a1]                   ... arc of radius r from angle a0 to a1 in degrees
+                                                         (default: 180 0, the upper half circle as used for seals)
+                     curve x0 y0 x1 y1 x2 y2 x3 y3   ... cubic Bezier curve
+                     Relative scaling applies to the path length.
+
 A color value: 3 color intensities, where 0.0 < i < 1.0, eg 1.0, 
                or the hex RGB value: #RRGGBB, eg #FF0000 = red
 
@@ -476,16 +695,36 @@ All configuration string parameters support completion.
 e.g. "pos:bl, off: 20 5"   "rot:45"                 "op:0.5, scale:0.5 abs, rot:0"
      "d:2"                 "scale:.75 abs, points:48"  "rot:-90, scale:0.75 rel"
      "f:Courier, scale:0.75, str: 0.5 0.0 0.0, rot:20"
+     "tile:on, scale:.2, rot:45, op:.3, spacing:30 60"
+     "textpath:arc 100, scale:.9, rot:0"
+
+
+`
+
+	usageWMMap = `A JSON watermark map applies distinct watermarks to page selections in one pass:
+
+{
+   "watermarks": [
+      { "pages": "1-3", "text": "Chapter 1", "desc": "pos:tc, scale:.5" },
+      { "pages": "4-",  "image": "logo.png", "desc": "pos:br, scale:.1" },
+      { "pages": "5-8", "pdf": "stamp.pdf:2", "desc": "scale:.3, rot:45" },
+      { "pages": "9-", "pdf": "stamp.pdf:1:9", "desc": "multi:cycle" }
+   ]
+}
 
+Each entry provides one of text, image or pdf and an optional description.
+Omitting pages selects all pages.
 
 `
 
 	usageStampAdd    = "pdfcpu stamp add    [-p(ages) selectedPages] -m(ode) text|image|pdf -- string|file description inFile [outFile]"
 	usageStampUpdate = "pdfcpu stamp update [-p(ages) selectedPages] -m(ode) text|image|pdf -- string|file description inFile [outFile]"
-	usageStampRemove = "pdfcpu stamp remove [-p(ages) selectedPages] inFile [outFile]"
+	usageStampApply  = "pdfcpu stamp apply  inFileJSON inFile [outFile]"
+	usageStampRemove = "pdfcpu stamp remove [-p(ages) selectedPages] [-id id] inFile [outFile]"
 
 	usageStamp = "usage: " + usageStampAdd +
 		"\n       " + usageStampUpdate +
+		"\n       " + usageStampApply +
 		"\n       " + usageStampRemove + generalFlags
 
 	usageLongStamp = `Process stamping for selected pages. 
@@ -497,18 +736,22 @@ e.g. "pos:bl, off: 20 5"   "rot:45"                 "op:0.5, scale:0.5 abs, rot:
      string ... display string for text based watermarks
        file ... image or PDF file
 description ... fontname, points, position, offset, scalefactor, aligntext, rotation, 
-                diagonal, opacity, rendermode, strokecolor, fillcolor, bgcolor, margins, border
+                diagonal, opacity, blendmode, rendermode, strokecolor, fillcolor, bgcolor, margins, border,
+                pagebox, keeprotation, tile, spacing, textpath, id
+ inFileJSON ... JSON watermark map assigning stamps to page selections
      inFile ... input PDF file
     outFile ... output PDF file
 
-` + usageStampMode + usageWMDescription
+` + usageStampMode + usageWMDescription + usageWMMap
 
 	usageWatermarkAdd    = "pdfcpu watermark add    [-p(ages) selectedPages] -m(ode) text|image|pdf -- string|file description inFile [outFile]"
 	usageWatermarkUpdate = "pdfcpu watermark update [-p(ages) selectedPages] -m(ode) text|image|pdf -- string|file description inFile [outFile]"
-	usageWatermarkRemove = "pdfcpu watermark remove [-p(ages) selectedPages] inFile [outFile]"
+	usageWatermarkApply  = "pdfcpu watermark apply  inFileJSON inFile [outFile]"
+	usageWatermarkRemove = "pdfcpu watermark remove [-p(ages) selectedPages] [-id id] inFile [outFile]"
 
 	usageWatermark = "usage: " + usageWatermarkAdd +
 		"\n       " + usageWatermarkUpdate +
+		"\n       " + usageWatermarkApply +
 		"\n       " + usageWatermarkRemove + generalFlags
 
 	usageLongWatermark = `Process watermarking for selected pages. 
@@ -518,11 +761,13 @@ description ... fontname, points, position, offset, scalefactor, aligntext, rota
      string ... display string for text based watermarks
        file ... image or PDF file
 description ... fontname, points, position, offset, scalefactor, aligntext, rotation,
-                diagonal, opacity, rendermode, strokecolor, fillcolor, bgcolor, margins, border
+                diagonal, opacity, blendmode, rendermode, strokecolor, fillcolor, bgcolor, margins, border,
+                pagebox, keeprotation, tile, spacing, textpath, id
+ inFileJSON ... JSON watermark map assigning watermarks to page selections
      inFile ... input PDF file
     outFile ... output PDF file
 
-` + usageWatermarkMode + usageWMDescription
+` + usageWatermarkMode + usageWMDescription + usageWMMap
 
 	usageImportImages     = "usage: pdfcpu import -- [description] outFile imageFile..." + generalFlags
 	usageLongImportImages = `Turn image files into a PDF page sequence and write the result to outFile.
@@ -576,10 +821,12 @@ description ... dimensions, formsize, position, offset, scale factor, boxes
        "pos:full"                                   ... render the image to a page with corresponding dimensions.
        "f:A4, pos:c, dpi:300"                       ... render the image centered on A4 respecting a destination resolution of 300 dpi.`
 
-	usagePagesInsert = "pdfcpu pages insert [-p(ages) selectedPages] [-m(ode) before|after] [description] inFile [outFile]"
-	usagePagesRemove = "pdfcpu pages remove  -p(ages) selectedPages  inFile [outFile]"
-	usagePages       = "usage: " + usagePagesInsert +
-		"\n       " + usagePagesRemove + generalFlags
+	usagePagesInsert  = "pdfcpu pages insert [-p(ages) selectedPages] [-m(ode) before|after] [description] inFile [outFile]"
+	usagePagesRemove  = "pdfcpu pages remove  -p(ages) selectedPages  inFile [outFile]"
+	usagePagesFlatten = "pdfcpu pages flatten inFile [outFile]"
+	usagePages        = "usage: " + usagePagesInsert +
+		"\n       " + usagePagesRemove +
+		"\n       " + usagePagesFlatten + generalFlags
 
 	usageLongPages = `Manage pages.
 
@@ -617,6 +864,27 @@ description ... dimensions, formsize
                   pdfcpu pages remove -p odd in.pdf out.pdf
                   pdfcpu pages remove -pages=odd in.pdf out.pdf
                   Remove all odd pages.
+
+                  pdfcpu pages flatten in.pdf out.pdf
+                  Push inherited page attributes (Resources, MediaBox, CropBox, Rotate) down into each page
+                  and remove them from the page tree nodes for maximally portable pages.
+`
+
+	usageReflow     = "usage: pdfcpu reflow [-m(ode) html|epub] inFile [outFile]" + generalFlags
+	usageLongReflow = `Export the content of inFile as reflowable HTML or EPUB.
+
+       mode ... output format, derived from outFile's extension if missing:
+                   html ... single HTML document (default)
+                   epub ... EPUB 3 book with a navigation document listing all headings
+     inFile ... input PDF file
+    outFile ... output file, writes to stdout if missing or "-"
+
+Tagged files are rendered following their structure tree in logical reading order
+including headings, paragraphs, lists, tables, links and alternate descriptions.
+Untagged files are rendered as one section per page and one paragraph per line of text.
+
+Examples: pdfcpu reflow in.pdf out.html
+          pdfcpu reflow -mode epub in.pdf book.epub
 `
 
 	usageRotate     = "usage: pdfcpu rotate [-p(ages) selectedPages] inFile rotation [outFile]" + generalFlags
@@ -627,6 +895,49 @@ description ... dimensions, formsize
    rotation ... a multiple of 90 degrees for clockwise rotation
     outFile ... output PDF file
 
+`
+
+	usagePageNumbers     = "usage: pdfcpu pagenumbers [-p(ages) selectedPages] -- description inFile [outFile]" + generalFlags
+	usageLongPageNumbers = `Stamp page numbers onto selected pages.
+
+      pages ... Please refer to "pdfcpu selectedpages"
+description ... style, numerals, start, skip and any text stamp parameter, eg. fontname, points, position, offset
+     inFile ... input PDF file
+    outFile ... output PDF file
+
+   Parameters:
+
+   style       plain    ... 3 (default)
+               ofn      ... 3 of 10
+               page     ... Page 3
+               pageofn  ... Page 3 of 10
+               folio    ... 3 on the outer margin, right on odd and left on even pages
+               label    ... the page label, eg. chapter relative numbers like A-3
+
+   numerals    arabic (default), roman, ROMAN, letters, LETTERS
+
+   start       number of the first numbered page, defaults to 1
+
+   skip        page selection neither numbered nor counted, eg. cover pages: skip:1 (separate multiple selections by space)
+
+   Page numbers default to Helvetica 10pt at the bottom center.
+   Unless you set an offset positions keep a distance of 36/24 points to the side/top and bottom page edges.
+   Any text stamp parameter applies, please refer to "pdfcpu help stamp".
+
+   Remove page numbers using: pdfcpu stamp remove -id pagenumbers inFile
+
+Examples: pdfcpu pagenumbers -- "" in.pdf out.pdf
+             Number all pages at the bottom center.
+
+          pdfcpu pagenumbers -- "style:ofn, skip:1" in.pdf out.pdf
+             Number all pages but the cover page with 1 of N.
+
+          pdfcpu pagenumbers -- "style:folio, num:roman, pos:tc, points:12" in.pdf out.pdf
+             Number pages with lower case roman numerals on the outer top corners.
+
+          pdfcpu pagenumbers -p 5- -- "style:label, fontname:Times-Italic" in.pdf out.pdf
+             Stamp page labels starting with page 5.
+
 `
 
 	usageNUp     = "usage: pdfcpu nup [-p(ages) selectedPages] -- [description] outFile n inFile|imageFiles..." + generalFlags
@@ -937,6 +1248,26 @@ Examples: pdfcpu grid out.pdf 1 10 in.pdf
 	usageVersion     = "usage: pdfcpu version"
 	usageLongVersion = "Print the pdfcpu version & build info."
 
+	usageCompletion     = "usage: pdfcpu completion bash|zsh|fish|powershell"
+	usageLongCompletion = `Print a shell completion script for pdfcpu commands, sub commands and flags.
+
+      bash ... source <(pdfcpu completion bash)
+       zsh ... source <(pdfcpu completion zsh)
+      fish ... pdfcpu completion fish | source
+powershell ... pdfcpu completion powershell | Out-String | Invoke-Expression
+
+Use "pdfcpu --help-json" for a machine readable description of all commands and flags.
+
+The exit codes are:
+
+   0 ... success
+   1 ... error
+   2 ... usage error
+   3 ... I/O error
+   4 ... validation failure or warnings with -strict
+   5 ... password required
+   6 ... operation restricted by permissions`
+
 	usagePaper     = "usage: pdfcpu paper"
 	usageLongPaper = "Print a list of supported paper sizes."
 
@@ -965,39 +1296,56 @@ Create single page PDF cheat sheets in current dir.`
 	usageKeywordsList   = "pdfcpu keywords list    inFile"
 	usageKeywordsAdd    = "pdfcpu keywords add     inFile keyword..."
 	usageKeywordsRemove = "pdfcpu keywords remove  inFile [keyword...]"
+	usageKeywordsImport = "pdfcpu keywords import  [-r(eplace)] inFile inFileJSON [outFile]"
+	usageKeywordsExport = "pdfcpu keywords export  inFile [outFileJSON]"
 
 	usageKeywords = "usage: " + usageKeywordsList +
 		"\n       " + usageKeywordsAdd +
-		"\n       " + usageKeywordsRemove + generalFlags
+		"\n       " + usageKeywordsRemove +
+		"\n       " + usageKeywordsImport +
+		"\n       " + usageKeywordsExport + generalFlags
 
 	usageLongKeywords = `Manage keywords.
 
-    inFile ... input PDF file
-   keyword ... search keyword
+    replace ... replace existing keywords
+     inFile ... input PDF file
+    keyword ... search keyword
+ inFileJSON ... input JSON file: {"keywords": ["keyword", ...]}
+outFileJSON ... output JSON file, defaults to out.json
     
     Eg. adding two keywords: 
            pdfcpu keywords add test.pdf music 'virtual instruments'
 
         remove all keywords:
            pdfcpu keywords remove test.pdf
+
+        replace all keywords by the keywords of a JSON file:
+           pdfcpu keywords import -replace test.pdf keywords.json
     `
 
-	usagePropertiesList   = "pdfcpu properties list    inFile"
-	usagePropertiesAdd    = "pdfcpu properties add     inFile nameValuePair..."
-	usagePropertiesRemove = "pdfcpu properties remove  inFile [name...]"
+	usagePropertiesList    = "pdfcpu properties list    inFile"
+	usagePropertiesAdd     = "pdfcpu properties add     inFile nameValuePair..."
+	usagePropertiesAddJSON = "pdfcpu properties add     -j(son) inFile inFileJSON [outFile]"
+	usagePropertiesRemove  = "pdfcpu properties remove  inFile [name...]"
+	usagePropertiesExport  = "pdfcpu properties export  inFile [outFileJSON]"
 
 	usageProperties = "usage: " + usagePropertiesList +
 		"\n       " + usagePropertiesAdd +
-		"\n       " + usagePropertiesRemove + generalFlags
+		"\n       " + usagePropertiesAddJSON +
+		"\n       " + usagePropertiesRemove +
+		"\n       " + usagePropertiesExport + generalFlags
 
 	usageLongProperties = `Manage document properties.
 
        inFile ... input PDF file
 nameValuePair ... 'name = value'
          name ... property name
+   inFileJSON ... input JSON file: {"properties": {"name": "value", ...}}
+  outFileJSON ... output JSON file, defaults to out.json
      
      Eg. adding one property:   pdfcpu properties add test.pdf 'key = value'
          adding two properties: pdfcpu properties add test.pdf 'key1 = val1' 'key2 = val2'
+         adding many properties: pdfcpu properties add -json test.pdf props.json
 
          remove all properties: pdfcpu properties remove test.pdf
      `
@@ -1010,6 +1358,44 @@ nameValuePair ... 'name = value'
   
   `
 
+	usageBatch     = "usage: pdfcpu batch jobFile" + generalFlags
+	usageLongBatch = `Process the jobs of a JSON job file.
+
+      jobFile ... JSON job file
+
+A job applies a sequence of steps to a set of input files, directories or glob patterns.
+Each input file gets read and written once only regardless of the number of steps.
+Input files are processed concurrently and independently of each other.
+A failing file does not affect the processing of any other file.
+The result files are written to outDir. If outDir is omitted the input files get replaced.
+
+The supported steps are:
+
+   decrypt   ... upw, opw
+   encrypt   ... upw, opw, mode (aes|rc4), key (40|128|256), perm (none|print|all)
+   stamp     ... mode (text|image|pdf), text, desc, pages
+   watermark ... mode (text|image|pdf), text, desc, pages
+   rotate    ... rotation, pages
+   optimize
+   validate
+
+Eg. {
+      "jobs": [
+         {
+            "inputs": ["in", "archive/*.pdf"],
+            "outDir": "out",
+            "concurrency": 4,
+            "steps": [
+               {"op": "decrypt", "opw": "secret"},
+               {"op": "stamp", "text": "Confidential", "desc": "scale:.5, rot:0", "pages": "1"},
+               {"op": "optimize"},
+               {"op": "encrypt", "opw": "newSecret", "perm": "print"}
+            ]
+         }
+      ]
+    }
+`
+
 	usageBoxDescription = `
 box:
 
@@ -1100,20 +1486,54 @@ Examples:
      
 ` + usageBoxDescription
 
-	usageAnnotsList   = "pdfcpu annotations list   [-p(ages) selectedPages] inFile"
-	usageAnnotsRemove = "pdfcpu annotations remove [-p(ages) selectedPages] inFile [outFile] [objNr|annotId|annotType]..."
+	usageAnnotsList        = "pdfcpu annotations list        [-p(ages) selectedPages] [-type annotTypes] [-author author] [-from date] [-until date] [-j(son)] inFile"
+	usageAnnotsAdd         = "pdfcpu annotations add         inFile inFileJSON [outFile]"
+	usageAnnotsRemove      = "pdfcpu annotations remove      [-p(ages) selectedPages] inFile [outFile] [objNr|annotId|annotType]..."
+	usageAnnotsExport      = "pdfcpu annotations export      [-p(ages) selectedPages] [-m(ode) json|csv|html] inFile [outFile]"
+	usageAnnotsAppearances = "pdfcpu annotations appearances [-p(ages) selectedPages] inFile [outFile]"
 
 	usageAnnots = "usage: " + usageAnnotsList +
-		"\n       " + usageAnnotsRemove + generalFlags
+		"\n       " + usageAnnotsAdd +
+		"\n       " + usageAnnotsRemove +
+		"\n       " + usageAnnotsExport +
+		"\n       " + usageAnnotsAppearances + generalFlags
 
 	usageLongAnnots = `Manage annotations.
    
       pages ... Please refer to "pdfcpu selectedpages"
+       type ... list: comma separated list of annotTypes
+     author ... list: author of markup annotations (case insensitive)
+       from ... list: modified on or after date
+      until ... list: modified on or before date
+                dates are expected in the configured dateFormat, eg. 2006-01-02
+       json ... list: produce JSON output
+       mode ... export: summary format json, csv or html (default: derived from outFile's extension, else json)
      inFile ... input PDF file
+ inFileJSON ... add: JSON file describing annotations
+    outFile ... export: summary file (default: out.json), - for stdout
+                add, appearances: output PDF file (default: inFile)
       objNr ... obj# from "pdfcpu annotations list"
     annotId ... id from "pdfcpu annotations list"
   annotType ... Text, Link, FreeText, Line, Square, Circle, Polygon, PolyLine, HighLight, Underline, Squiggly, StrikeOut, Stamp,
                 Caret, Ink, Popup, FileAttachment, Sound, Movie, Widget, Screen, PrinterMark, TrapNet, Watermark, 3D, Redact
+
+   The JSON output of "annotations list" is stable and contains a header and an array "annotations" with:
+         page, objNr, id, type, rect, contents, author, subject, modified, created, irt (obj# of the annotation replied to)
+   Feed objNr into "annotations remove" to delete specific annotations.
+
+   "annotations export" summarizes all markup annotations (notes, highlights incl. the highlighted text, stamps, ..)
+   for review. Replies are nested below the annotation they respond to (/IRT).
+
+   "annotations add" creates annotations described by a JSON file like:
+         {"annotations": [
+            {"pages": "1-3", "type": "Text", "rect": [50, 700, 70, 720], "contents": "Check this", "col": "#FFFF00"},
+            {"pages": "1", "type": "Link", "rect": [50, 50, 250, 70], "uri": "https://pdfcpu.io"},
+            {"type": "Highlight", "rect": [100, 500, 300, 515], "author": "John"}]}
+   Supported types: Text, Link, FreeText, Line, Square, Circle, Polygon, PolyLine,
+   Highlight, Underline, Squiggly, StrikeOut, Ink. Omitting "pages" selects all pages.
+
+   "annotations appearances" generates missing appearance streams (/AP) for Square, Circle, Line, Highlight
+   and FreeText annotations so they get rendered, printed and flattened faithfully.
    
    Examples:
 
@@ -1123,6 +1543,21 @@ Examples:
       List annotation of first two pages:
          pdfcpu annot list -pages 1-2 in.pdf
 
+      List all Highlight and Text annotations by John modified in 2025 as JSON:
+         pdfcpu annot list -type Highlight,Text -author John -from 2025-01-01 -until 2025-12-31 -j in.pdf
+
+      Export a comment summary of pages 1-5 as HTML:
+         pdfcpu annot export -pages 1-5 in.pdf review.html
+
+      Export a comment summary as CSV to stdout:
+         pdfcpu annot export -mode csv in.pdf -
+
+      Add annotations described by annots.json and write to out.pdf:
+         pdfcpu annot add in.pdf annots.json out.pdf
+
+      Generate missing annotation appearance streams:
+         pdfcpu annot appearances in.pdf out.pdf
+
       Remove all page annotations and write to out.pdf:
          pdfcpu annot remove in.pdf out.pdf
       
@@ -1186,15 +1621,24 @@ Examples:
              pdfcpu images update gallery.pdf logo.jpg out.pdf 1 Im0
     `
 
-	usageCreate     = "usage: pdfcpu create inFileJSON [inFile] outFile" + generalFlags
+	usageCreate     = "usage: pdfcpu create [-data inFileData] inFileJSON [inFile] outFile" + generalFlags
 	usageLongCreate = `Create page content corresponding to declarations in inFileJSON.
 Append new page content to existing page content in inFile and write result to outFile.
 If inFile is absent outFile will be overwritten.
 
+         data ... JSON or CSV file with data records for {{placeholders}} in inFileJSON
    inFileJSON ... input json file
        inFile ... optional input PDF file 
       outFile ... output PDF file
 
+Any JSON object {"$include": "partial.json"} is replaced by the content of partial.json.
+Attributes next to "$include" override included attributes.
+
+Using -data inFileJSON is a template and each {{key}} within a string gets replaced by the corresponding data value.
+Nested JSON data may be addressed like {{customer.name}}.
+inFileData is a JSON object, a JSON array of objects or a CSV file whose first line holds the keys.
+For more than one data record the results are written to outFile_01.pdf, outFile_02.pdf etc.
+
 A minimalistic sample json:
 {
    "pages": {
@@ -1219,14 +1663,18 @@ For more info on json syntax & samples please refer to :
    pdfcpu/pkg/testdata/json/*
    pdfcpu/pkg/samples/create/*`
 
-	usageFormListFields   = "pdfcpu form list   inFile..."
+	usageFormListFields   = "pdfcpu form list   [-j(son)] inFile..."
 	usageFormRemoveFields = "pdfcpu form remove inFile [outFile] <fieldID|fieldName>..."
 	usageFormLock         = "pdfcpu form lock   inFile [outFile] [fieldID|fieldName]..."
 	usageFormUnlock       = "pdfcpu form unlock inFile [outFile] [fieldID|fieldName]..."
 	usageFormReset        = "pdfcpu form reset  inFile [outFile] [fieldID|fieldName]..."
 	usageFormExport       = "pdfcpu form export inFile [outFileJSON]"
+	usageFormExportCSV    = "pdfcpu form export -format csv [-recursive] inFile|inDir... [outFileCSV]"
 	usageFormFill         = "pdfcpu form fill inFile inFileJSON [outFile]"
 	usageFormMultiFill    = "pdfcpu form multifill [-m(ode) single|merge] inFile inFileData outDir [outName]"
+	usageFormAddSigField  = "pdfcpu form add-sigfield [-p(ages) page] [-u(nit) po|in|cm|mm] inFile [outFile] fieldName rect [label]"
+	usageFormPrepareSig   = "pdfcpu form prepare-sig inFile [outFile] fieldName [outFileContent]"
+	usageFormEmbedSig     = "pdfcpu form embed-sig   inFile inFileCMS [outFile]"
 
 	usageForm = "usage: " + usageFormListFields +
 		"\n       " + usageFormRemoveFields +
@@ -1234,21 +1682,35 @@ For more info on json syntax & samples please refer to :
 		"\n       " + usageFormUnlock +
 		"\n       " + usageFormReset +
 		"\n       " + usageFormExport +
+		"\n       " + usageFormExportCSV +
 		"\n\n       " + usageFormFill +
-		"\n       " + usageFormMultiFill + generalFlags
+		"\n       " + usageFormMultiFill +
+		"\n\n       " + usageFormAddSigField +
+		"\n       " + usageFormPrepareSig +
+		"\n       " + usageFormEmbedSig + generalFlags
 
 	usageLongForm = `Manage PDF forms.
 
+             json ... list: produce JSON output
            inFile ... input PDF file
        inFileData ... input CSV or JSON file
        inFileJSON ... input JSON file
+            inDir ... directory containing input PDF files
           outFile ... output PDF file
       outFileJSON ... output JSON file
+       outFileCSV ... output CSV file (default: out.csv)
+           format ... export: json (default) or csv
+        recursive ... export: include PDF files in subdirectories of inDir
              mode ... output mode (defaults to single)
            outDir ... output directory
           outName ... base output name
           fieldID ... as indicated by "pdfcpu form list"
         fieldName ... as indicated by "pdfcpu form list"
+             page ... page number of the signature field (defaults to 1)
+             rect ... signature field rectangle "[llx lly urx ury]" in given display unit
+            label ... signature field caption
+   outFileContent ... output file for the content to be signed
+        inFileCMS ... detached CMS signature (DER)
 
 The output modes are:
 
@@ -1262,6 +1724,9 @@ Supported usecases:
    1) Get a list of form fields:
          "pdfcpu form list in.pdf" returns a list of form fields of in.pdf.
          Each field is identified by its name and id.
+         "pdfcpu form list -j in.pdf" returns the complete field metadata as JSON:
+         type, flags, default appearance, options, current and default value,
+         parent hierarchy and the page and rectangle of each widget.
    
    2) Remove some form fields:
          "pdfcpu form remove in.pdf middleName birthPlace" removes the the two fields "middleName" and "birthPlace".
@@ -1284,6 +1749,8 @@ Supported usecases:
        
    6) Export all form fields as preparation for form filling:
          "pdfcpu form export in.pdf" exports field data into a JSON structure written to in.json.
+         "pdfcpu form export -format csv -recursive forms/ audit.csv" harvests the field values of all PDF files in forms/
+         into audit.csv. There is one row per file and one column per field name across all forms.
    
    7) Fill a form with data:
          a) Export your form into in.json and edit the field values.
@@ -1316,6 +1783,17 @@ Supported usecases:
             The first line identifies fields via id or name in in.json.
          c) "pdfcpu form multifill -m merge in.pdf in.csv outDir" creates a single output PDF in outDir.
 
+   10) Add an empty signature field:
+         "pdfcpu form add-sigfield -p 2 in.pdf out.pdf sig1 '[350 50 550 110]' 'Sign here'" adds the signature field "sig1" to page 2.
+
+   11) Sign using an external signer (deferred signing):
+         a) "pdfcpu form prepare-sig in.pdf out.pdf sig1 content.bin" reserves space for the signature of field "sig1"
+            and writes the content to be signed to content.bin.
+         b) Sign content.bin using your signing device or service producing a detached CMS signature, eg.
+            "openssl cms -sign -binary -outform DER -in content.bin -signer cert.pem -inkey key.pem -out sig.p7s"
+         c) "pdfcpu form embed-sig out.pdf sig.p7s" embeds the signature.
+         Any subsequent modification of out.pdf will break the signature.
+
 
    (For syntax and details please refer to pdfcpu/pkg/api/test/form_test.go)`
 
@@ -1508,22 +1986,54 @@ description ... scalefactor, dimensions, formsize, enforce, border, bgcolor
             
    See also the related commands: poster, ndown`
 
-	usageBookmarksList   = "pdfcpu bookmarks list   inFile"
-	usageBookmarksImport = "pdfcpu bookmarks import [-r(eplace)] inFile inFileJSON [outFile]"
-	usageBookmarksExport = "pdfcpu bookmarks export inFile [outFileJSON]"
-	usageBookmarksRemove = "pdfcpu bookmarks remove inFile [outFile]"
+	usageBookmarksList     = "pdfcpu bookmarks list     inFile"
+	usageBookmarksImport   = "pdfcpu bookmarks import   [-r(eplace)] inFile inFileJSON|inFileTxt [outFile]"
+	usageBookmarksExport   = "pdfcpu bookmarks export   inFile [outFileJSON]"
+	usageBookmarksRemove   = "pdfcpu bookmarks remove   inFile [outFile]"
+	usageBookmarksSort     = "pdfcpu bookmarks sort     [-m(ode) title|page] inFile [outFile]"
+	usageBookmarksDedup    = "pdfcpu bookmarks dedup    inFile [outFile]"
+	usageBookmarksCollapse = "pdfcpu bookmarks collapse inFile level [outFile]"
+	usageBookmarksRetarget = "pdfcpu bookmarks retarget inFile [outFile]"
 
 	usageBookmarks = "usage: " + usageBookmarksList +
 		"\n       " + usageBookmarksImport +
 		"\n       " + usageBookmarksExport +
-		"\n       " + usageBookmarksRemove + generalFlags
+		"\n       " + usageBookmarksRemove +
+		"\n       " + usageBookmarksSort +
+		"\n       " + usageBookmarksDedup +
+		"\n       " + usageBookmarksCollapse +
+		"\n       " + usageBookmarksRetarget + generalFlags
 
 	usageLongBookmarks = `Manage bookmarks.
 
            inFile ... input PDF file
        inFileJSON ... input JSON file
+        inFileTxt ... input text or Markdown file (.txt, .md)
           outFile ... output PDF file
       outFileJSON ... output PDF file
+             mode ... sort by title (default) or destination page
+            level ... deepest bookmark level to keep, 1 = top level
+
+    sort     ... sort sibling bookmarks alphabetically by title or by destination page.
+    dedup    ... remove bookmarks repeating title and destination page of a preceding sibling.
+                 Their kids are moved to the remaining bookmark.
+    collapse ... move bookmarks nested deeper than level up to level keeping their order.
+    retarget ... point bookmarks whose destination page has been removed
+                 to the destination page of the next bookmark or the last page.
+
+    Bookmarks may be imported from an indented text or Markdown list
+    with one "title | page" per line, nested by indentation or heading level:
+
+           - Chapter 1 | 1
+             - Section 1.1 | 2
+             - **Bold section** | 3
+           - *Italic chapter* | 5
+
+    Eg. sort bookmarks by page:
+           pdfcpu bookmarks sort -m page in.pdf out.pdf
+
+        flatten the bookmark tree:
+           pdfcpu bookmarks collapse in.pdf 1
 `
 
 	usagePageLayoutList  = "pdfcpu pagelayout list  inFile"
@@ -1552,6 +2062,30 @@ description ... scalefactor, dimensions, formsize, enforce, border, bgcolor
            pdfcpu pagelayout reset test.pdf
 `
 
+	usageLanguageList  = "pdfcpu language list  inFile"
+	usageLanguageSet   = "pdfcpu language set   inFile lang [outFile]"
+	usageLanguageReset = "pdfcpu language reset inFile [outFile]"
+
+	usageLanguage = "usage: " + usageLanguageList +
+		"\n       " + usageLanguageSet +
+		"\n       " + usageLanguageReset + generalFlags
+
+	usageLongLanguage = `Manage the natural language of the document as required for accessible documents:
+
+    inFile ... input PDF file
+      lang ... language identifier, eg. en, en-US, de-CH, zh-Hant
+   outFile ... output PDF file
+
+Screen readers use the language for pronunciation.
+Use "pdfcpu viewerpref set" to make PDF viewers display the document title (displayDocTitle).
+
+    Eg. set document language:
+           pdfcpu language set test.pdf en-US
+
+        reset document language:
+           pdfcpu language reset test.pdf
+`
+
 	usagePageModeList  = "pdfcpu pagemode list  inFile"
 	usagePageModeSet   = "pdfcpu pagemode set   inFile value"
 	usagePageModeReset = "pdfcpu pagemode reset inFile"
@@ -1579,6 +2113,39 @@ description ... scalefactor, dimensions, formsize, enforce, border, bgcolor
            pdfcpu pagemode reset test.pdf
     `
 
+	usageOpenActionList  = "pdfcpu openaction list  inFile"
+	usageOpenActionSet   = "pdfcpu openaction set   [-page pageNr] [-zoom zoom] [-pagemode mode] [-pagelayout layout] inFile [outFile]"
+	usageOpenActionReset = "pdfcpu openaction reset inFile [outFile]"
+
+	usageOpenAction = "usage: " + usageOpenActionList +
+		"\n       " + usageOpenActionSet +
+		"\n       " + usageOpenActionReset + generalFlags
+
+	usageLongOpenAction = `Manage the initial view of the document when opened:
+
+      page ... page to be displayed, defaults to 1 if only zoom is given
+      zoom ... one of:
+
+                  fit ... fit entire page within window
+             fitwidth ... fit page width within window
+            fitheight ... fit page height within window
+               fitbox ... fit page content bounding box within window
+              percent ... magnification eg. 150
+
+  pagemode ... one of: UseNone, UseOutlines, UseThumbs, FullScreen, UseOC, UseAttachments
+pagelayout ... one of: SinglePage, TwoColumnLeft, TwoColumnRight, TwoPageLeft, TwoPageRight
+    inFile ... input PDF file
+   outFile ... output PDF file
+
+    reset removes the open action, page mode and page layout.
+
+    Eg. open page 5 fit to window with bookmarks visible:
+           pdfcpu openaction set -page 5 -zoom fit -pagemode UseOutlines test.pdf
+
+        reset initial view:
+           pdfcpu openaction reset test.pdf
+    `
+
 	usageViewerPreferencesList  = "pdfcpu viewerpref list [-a(ll)] [-j(son)] inFile"
 	usageViewerPreferencesSet   = "pdfcpu viewerpref set                     inFile (inFileJSON | JSONstring)"
 	usageViewerPreferencesReset = "pdfcpu viewerpref reset                   inFile"
@@ -1709,11 +2276,389 @@ Examples:
    pdfcpu zoom -unit cm -- "vmargin: 1, border:true, bgcolor:lightgray" in.pdf out.pdf ... zoom out to vertical margin of 1 cm
 `
 
+	usageHairlines = "usage: pdfcpu hairlines [-p(ages) selectedPages] [-u(nit) po|in|cm|mm] -- description inFile [outFile]" + generalFlags
+
+	usageLongHairlines = `Raise stroke widths too thin for reliable printing on selected pages.
+
+      pages ... Please refer to "pdfcpu selectedpages"
+       unit ... display unit for threshold and width
+description ... threshold, width
+     inFile ... input PDF file
+    outFile ... output PDF file
+
+    parameter   values                                          default
+    threshold   stroke widths below threshold are raised        0.2 points
+    width       stroke width applied to hairlines               threshold
+
+Stroke widths are compared in default user space taking the transformation matrix into account.
+Zero width lines (the thinnest line a device can render) are raised as well.
+
+Examples:
+   pdfcpu hairlines -- "threshold: 0.2" in.pdf out.pdf
+   pdfcpu hairlines -pages 1-3 -- "threshold: 0.25, width: 0.3" in.pdf
+   pdfcpu hairlines -unit mm -- "threshold: 0.1" in.pdf out.pdf
+`
+
+	usageTemplateList = "pdfcpu template list inFile"
+	usageTemplateAdd  = "pdfcpu template add  -p(age) pageNr [-u(nit) po|in|cm|mm] -- description inFile [outFile]"
+	usageTemplateFill = "pdfcpu template fill inFile inFileJSON [outFile]"
+
+	usageTemplate = "usage: " + usageTemplateList +
+		"\n       " + usageTemplateAdd +
+		"\n       " + usageTemplateFill + generalFlags
+
+	usageLongTemplate = `Manage named placeholder regions of a template and fill them with text, images or tables.
+
+       pageNr ... a single page number
+         unit ... display unit for the region rectangle
+  description ... name, rect
+       inFile ... input PDF template
+   inFileJSON ... JSON file describing the content of regions
+      outFile ... output PDF file
+
+Template regions are defined either by form fields - the region being the rectangle of the field widget
+named by the fully qualified field name - or by regions recorded with "template add".
+
+    parameter   values
+    name        region name
+    rect        llx lly urx ury
+
+Note: "optimize" removes recorded regions, form field based regions are retained.
+
+fill replaces regions by the content described in inFileJSON, a JSON object mapping region names to:
+
+    text        a text block wrapped at the region width
+    image       an image file fitted into the region
+    table       a list of table rows
+    header      optional table header
+    font        font name (default Helvetica)
+    size        font size (default 12)
+    col         text color
+    align       text alignment: left, center, right
+    anchor      position within the region: tl, tc, tr, l, c, r, bl, bc, br
+
+Filled regions and form fields defining them are removed from the result.
+
+Examples:
+   pdfcpu template add -pages 1 -- "name:address, rect:50 650 300 750" invoice.pdf
+   pdfcpu template add -pages 1 -unit mm -- "name:logo, rect:150 250 200 280" invoice.pdf
+   pdfcpu template list invoice.pdf
+   pdfcpu template fill invoice.pdf invoice.json out.pdf
+
+   with invoice.json:
+   {
+      "address": {"text": "John Doe\nMain Street 1\n12345 Springfield"},
+      "logo":    {"image": "logo.png"},
+      "items":   {"header": ["Item", "Qty"], "table": [["Pen", "2"], ["Paper", "500"]], "size": 10}
+   }
+`
+
+	usageTransitionsList   = "pdfcpu transitions list   [-p(ages) selectedPages] inFile"
+	usageTransitionsSet    = "pdfcpu transitions set    [-p(ages) selectedPages] -- description inFile [outFile]"
+	usageTransitionsRemove = "pdfcpu transitions remove [-p(ages) selectedPages] inFile [outFile]"
+
+	usageTransitions = "usage: " + usageTransitionsList +
+		"\n       " + usageTransitionsSet +
+		"\n       " + usageTransitionsRemove + generalFlags
+
+	usageLongTransitions = `Manage page transitions and display durations for presentations.
+
+      pages ... Please refer to "pdfcpu selectedpages"
+description ... comma separated configuration string
+     inFile ... input PDF file
+    outFile ... output PDF file
+
+    parameter   values                                                       default
+    style       split, blinds, box, wipe, dissolve, glitter, replace,        replace
+                fly, push, cover, uncover, fade (since PDF 1.5)
+    duration    transition duration in seconds                               1
+    dimension   split, blinds: h(orizontal), v(ertical)                      h
+    motion      split, box, fly: i(nward), o(utward)                         i
+    angle       wipe, glitter, fly, cover, uncover, push:
+                0, 90, 180, 270, 315, none (fly only)                        0
+    display     max. page display duration in seconds before                 none
+                advancing to the next page
+
+Examples:
+   pdfcpu transitions set -- "style:dissolve, dur:1.5, display:5" in.pdf   ... kiosk mode: dissolve every 5 seconds
+   pdfcpu transitions set -p 2-4 -- "style:wipe, angle:90" in.pdf          ... wipe bottom to top on pages 2-4
+   pdfcpu transitions remove in.pdf                                        ... remove all transitions
+`
+
+	usageGeoList   = "pdfcpu geo list   [-p(ages) selectedPages] inFile"
+	usageGeoAdd    = "pdfcpu geo add    [-p(ages) selectedPages] inFile inFileJSON [outFile]"
+	usageGeoRemove = "pdfcpu geo remove [-p(ages) selectedPages] inFile [outFile]"
+
+	usageGeo = "usage: " + usageGeoList +
+		"\n       " + usageGeoAdd +
+		"\n       " + usageGeoRemove + generalFlags
+
+	usageLongGeo = `Manage georeferenced viewports (geospatial PDF).
+
+      pages ... Please refer to "pdfcpu selectedpages"
+     inFile ... input PDF file
+ inFileJSON ... input JSON file describing viewports with GEO measure dicts
+    outFile ... output PDF file
+
+list prints the bounding box, coordinate system and geographic extent of each georeferenced viewport.
+Viewports in inFileJSON carrying a "page" are only added to this page.
+
+Example JSON:
+{
+	"viewports": [
+		{
+			"name": "Map",
+			"bbox": [36, 36, 576, 756],
+			"gcs": {"type": "GEOGCS", "epsg": 4326},
+			"pdu": ["KM", "SQKM", "DEG"],
+			"gpts": [47.0, 8.0, 48.0, 8.0, 48.0, 9.0, 47.0, 9.0],
+			"lpts": [0, 0, 0, 1, 1, 1, 1, 0]
+		}
+	]
+}
+`
+
+	usageMediaList    = "pdfcpu media list    [-p(ages) selectedPages] inFile"
+	usageMediaExtract = "pdfcpu media extract [-p(ages) selectedPages] inFile outDir"
+
+	usageMedia = "usage: " + usageMediaList +
+		"\n       " + usageMediaExtract + generalFlags
+
+	usageLongMedia = `Manage 3D artwork, RichMedia, sound and movie content embedded into page annotations.
+
+      pages ... Please refer to "pdfcpu selectedpages"
+     inFile ... input PDF file
+     outDir ... output directory
+
+list prints the 3D streams (U3D, PRC) of 3D annotations, the assets of RichMedia annotations,
+the sound samples of Sound annotations, the embedded movie files of Movie annotations
+and the media clips played by Screen annotations along with their codec and size.
+extract writes them into outDir using the 3D format, file name or RAW (sound samples) as extension.
+External movies and media clips not embedded into the file are skipped.
+`
+
+	usageOCRApply = "pdfcpu ocr apply [-p(ages) selectedPages] inFile ocrFile [outFile]"
+
+	usageOCR = "usage: " + usageOCRApply + generalFlags
+
+	usageLongOCR = `Make scanned pages searchable using the results of an external OCR engine.
+
+      pages ... Please refer to "pdfcpu selectedpages"
+     inFile ... input PDF file
+    ocrFile ... hOCR or ALTO XML file, eg. produced by tesseract
+    outFile ... output PDF file
+
+apply adds the recognized words as invisible text positioned over the page content.
+The i-th page of ocrFile is applied to the i-th selected page.
+Word coordinates are scaled from the page image onto the visible region of the page
+taking the page rotation into account.
+
+Examples: tesseract scan.png scan hocr
+          pdfcpu ocr apply in.pdf scan.hocr out.pdf
+
+          tesseract scan.png scan alto
+          pdfcpu ocr apply -pages 3 in.pdf scan.xml out.pdf
+`
+
+	usageConvertPDFX = "pdfcpu convert pdfx [-standard pdfx-1a|pdfx-4] [-condition outputCondition] inFile [outFile]"
+
+	usageConvert = "usage: " + usageConvertPDFX + generalFlags
+
+	usageLongConvert = `Convert inFile to a PDF/X standard on a best effort basis.
+
+   standard ... pdfx-1a|pdfx-4 (default: pdfx-1a)
+  condition ... registered characterized printing condition (default: FOGRA39)
+     inFile ... input PDF file
+    outFile ... output PDF file
+
+pdfx fixes what can be fixed without touching page content:
+
+  - adds a GTS_PDFX output intent referring to condition unless present,
+    use "pdfcpu outputintent add -subtype GTS_PDFX" to embed the ICC profile required by pdfx-4
+  - sets GTS_PDFXVersion
+  - sets Trapped to False unless True or False
+  - adds a TrimBox derived from the CropBox to pages missing both TrimBox and ArtBox
+
+Remaining issues like unembedded fonts or RGB color are listed
+and need to be addressed by the application producing inFile.
+
+Examples: pdfcpu convert pdfx in.pdf out.pdf
+          pdfcpu convert pdfx -standard pdfx-4 -condition FOGRA51 in.pdf out.pdf
+          pdfcpu validate -standard pdfx-4 out.pdf
+`
+
+	usageOutputIntentList   = "pdfcpu outputintent list   inFile"
+	usageOutputIntentAdd    = "pdfcpu outputintent add    [-subtype GTS_PDFX|GTS_PDFA1|ISO_PDFE1] [-condition outputCondition] inFile iccFile [outFile]"
+	usageOutputIntentRemove = "pdfcpu outputintent remove [-subtype GTS_PDFX|GTS_PDFA1|ISO_PDFE1] inFile [outFile]"
+
+	usageOutputIntent = "usage: " + usageOutputIntentList +
+		"\n       " + usageOutputIntentAdd +
+		"\n       " + usageOutputIntentRemove + generalFlags
+
+	usageLongOutputIntent = `Manage the output intents describing the intended output device or production condition.
+
+    subtype ... GTS_PDFX (PDF/X), GTS_PDFA1 (PDF/A, default for add), ISO_PDFE1 (PDF/E)
+  condition ... registered characterized printing condition of the profile, eg. FOGRA39 (default: Custom)
+     inFile ... input PDF file
+    iccFile ... ICC profile (Gray, RGB or CMYK) embedded as destination output profile
+    outFile ... output PDF file
+
+add replaces an existing output intent of the same subtype.
+remove removes the output intents of subtype or all output intents.
+
+Examples: pdfcpu outputintent list in.pdf
+          pdfcpu outputintent add in.pdf sRGB.icc out.pdf
+          pdfcpu outputintent add -subtype GTS_PDFX -condition FOGRA39 in.pdf ISOcoated_v2.icc
+          pdfcpu outputintent remove -subtype GTS_PDFA1 in.pdf
+`
+
+	usageContentNormalize = "pdfcpu content normalize [-p(ages) selectedPages] [-m(ode) flate|plain] inFile [outFile]"
+	usageContentExtract   = "pdfcpu content extract   -p(age) pageNr inFile contentFile"
+	usageContentReplace   = "pdfcpu content replace   -p(age) pageNr [-m(ode) flate|plain] inFile contentFile [outFile]"
+
+	usageContent = "usage: " + usageContentNormalize +
+		"\n       " + usageContentExtract +
+		"\n       " + usageContentReplace + generalFlags
+
+	usageLongContent = `Normalize, extract and replace page content streams.
+
+         pages ... Please refer to "pdfcpu selectedpages"
+        pageNr ... a single page number
+          mode ... encoding of the resulting content streams:
+                      flate ... compressed (default)
+                      plain ... uncompressed for inspection with a text editor
+        inFile ... input PDF file
+   contentFile ... content stream file
+       outFile ... output PDF file
+
+normalize decodes the content streams of the selected pages, merges them into one
+and pretty-prints it using one operator per line indented by nesting level.
+
+extract writes the pretty-printed content of a page to contentFile.
+
+replace replaces the content of a page by contentFile.
+The page resources are left untouched.
+
+Examples: pdfcpu content normalize -mode plain in.pdf out.pdf
+          pdfcpu content extract -pages 2 in.pdf page2.txt
+          pdfcpu content replace -pages 2 in.pdf page2.txt out.pdf
+`
+
+	usageSignaturesLTV               = "pdfcpu signatures ltv inFile [outFile] file..."
+	usageSignaturesUsageRights       = "pdfcpu signatures usagerights [-j(son)] inFile..."
+	usageSignaturesRemoveUsageRights = "pdfcpu signatures remove-usagerights inFile [outFile]"
+
+	usageSignatures = "usage: " + usageSignaturesLTV +
+		"\n       " + usageSignaturesUsageRights +
+		"\n       " + usageSignaturesRemoveUsageRights + generalFlags
+
+	usageLongSignatures = `Manage digital signatures.
+
+      json ... output JSON
+    inFile ... input PDF file
+   outFile ... output PDF file
+      file ... validation material: certificate (.cer, .crt, .der), CRL (.crl), OCSP response (.ocsp, .ors) or PEM file
+
+ltv embeds certificates, CRLs and OCSP responses into the document security store (DSS)
+and relates them to all signatures of inFile enabling long term validation (LTV).
+The result gets written as an increment leaving existing signatures intact.
+
+usagerights reports the usage rights signature (UR3) enabling extended features like saving filled forms in Adobe Reader.
+
+remove-usagerights removes the usage rights signature.
+Usage rights get invalidated by most modifications causing Adobe Reader to warn and disable the extended features.
+Filling a signed form removes usage rights implicitly unless config.yml sets keepUsageRights: true.
+
+Examples: pdfcpu signatures ltv signed.pdf chain.pem ocsp.ors
+          pdfcpu signatures ltv signed.pdf signedLTV.pdf ca.cer ca.crl
+          pdfcpu signatures usagerights -j form.pdf
+          pdfcpu signatures remove-usagerights form.pdf formNoUR.pdf
+`
+
+	usageOverprintList = "pdfcpu overprint list inFile"
+	usageOverprintSet  = "pdfcpu overprint set [-p(ages) selectedPages] description inFile [outFile]"
+
+	usageOverprint = "usage: " + usageOverprintList +
+		"\n       " + usageOverprintSet + generalFlags
+
+	usageLongOverprint = `Manage overprint settings of graphics states and the trapping status of inFile.
+
+         pages ... Please refer to "pdfcpu selectedpages"
+   description ... comma separated configuration string (see below)
+        inFile ... input PDF file
+       outFile ... output PDF file
+
+list reports the trapping status, the pages using overprint
+and all graphics states with overprint settings.
+
+set applies overprint settings to all graphics states used by the selected pages
+including their form XObjects and patterns and sets the trapping status.
+
+<description> is a comma separated configuration string containing:
+
+    stroke:  on/off, true/false, t/f      overprint for stroking operations (OP)
+    fill:    on/off, true/false, t/f      overprint for all other painting operations (op)
+    mode:    0|1                          overprint mode (OPM)
+    trapped: true|false|unknown           trapping status in the document info dict
+
+Examples: pdfcpu overprint list in.pdf
+          pdfcpu overprint set "stroke:on, fill:on, mode:1" in.pdf out.pdf
+          pdfcpu overprint set -pages 2-3 "fill:off" in.pdf
+          pdfcpu overprint set "trapped:false" in.pdf
+`
+
+	usageOverlay  = "usage: pdfcpu overlay [-p(ages) selectedPages] [-m(ode) repeat|cycle|once] inFile overlayFile [outFile]" + generalFlags
+	usageUnderlay = "usage: pdfcpu underlay [-p(ages) selectedPages] [-m(ode) repeat|cycle|once] inFile underlayFile [outFile]" + generalFlags
+
+	usageLongOverlay = `Composite the pages of overlayFile on top of the corresponding selected pages of inFile.
+
+        pages ... Please refer to "pdfcpu selectedpages"
+         mode ... how to handle selected pages beyond the page count of overlayFile:
+                     repeat ... use the last page of overlayFile (default)
+                     cycle  ... start over with the first page of overlayFile
+                     once   ... leave remaining pages untouched
+       inFile ... input PDF file
+  overlayFile ... PDF file providing the overlay pages
+      outFile ... output PDF file
+
+The n-th selected page is combined with page n of overlayFile.
+The lower left corners of the visible regions of both pages are aligned.
+
+Examples: pdfcpu overlay in.pdf draft.pdf out.pdf
+          pdfcpu overlay -pages 2- -mode cycle in.pdf marks.pdf out.pdf`
+
+	usageLongUnderlay = `Composite the pages of underlayFile underneath the corresponding selected pages of inFile.
+
+        pages ... Please refer to "pdfcpu selectedpages"
+         mode ... how to handle selected pages beyond the page count of underlayFile:
+                     repeat ... use the last page of underlayFile (default)
+                     cycle  ... start over with the first page of underlayFile
+                     once   ... leave remaining pages untouched
+       inFile ... input PDF file
+ underlayFile ... PDF file providing the underlay pages
+      outFile ... output PDF file
+
+The n-th selected page is combined with page n of underlayFile.
+The lower left corners of the visible regions of both pages are aligned.
+
+Examples: pdfcpu underlay letter.pdf letterhead.pdf out.pdf                 ... letterhead on every page
+          pdfcpu underlay -mode once letter.pdf letterhead.pdf out.pdf      ... letterhead on the first page only`
+
 	usageConfigList  = "pdfcpu config list"
 	usageConfigReset = "pdfcpu config reset"
+	usageConfigSet   = "pdfcpu config set key value"
 
 	usageConfig = "usage: " + usageConfigList +
-		"\n       " + usageConfigReset + generalFlags
+		"\n       " + usageConfigReset +
+		"\n       " + usageConfigSet + generalFlags
+
+	usageLongConfig = `Manage your pdfcpu configuration.
+
+      key ... a config.yml entry, eg. timeout, offline, validationMode
+    value ... a valid value for key
+
+Config files written by older pdfcpu releases are migrated to the current schema on save.
 
-	usageLongConfig = `Manage your pdfcpu configuration.`
+Examples: pdfcpu config set timeout 10
+          pdfcpu config set validationMode ValidationStrict`
 )
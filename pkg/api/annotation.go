@@ -20,33 +20,58 @@ import (
 	"io"
 	"os"
 
+	"github.com/pdfcpu/pdfcpu/pkg/log"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
 	"github.com/pkg/errors"
 )
 
-// Annotations returns page annotations of rs for selected pages.
-func Annotations(rs io.ReadSeeker, selectedPages []string, conf *model.Configuration) (map[int]model.PgAnnots, error) {
+func annotations(rs io.ReadSeeker, selectedPages []string, f *pdfcpu.AnnotationFilter, conf *model.Configuration) (*model.Context, map[int]model.PgAnnots, error) {
 	if rs == nil {
-		return nil, errors.New("pdfcpu: Annotations: missing rs")
+		return nil, nil, errors.New("pdfcpu: Annotations: missing rs")
 	}
 
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
 	}
 	conf.Cmd = model.LISTANNOTATIONS
 
 	ctx, err := ReadValidateAndOptimize(rs, conf)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	pages, err := PagesForPageSelection(ctx.PageCount, selectedPages, true, true)
+	pages, err := PagesForPageSelectionWithContext(ctx, selectedPages, true, true)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return pdfcpu.AnnotationsForSelectedPages(ctx, pages), nil
+	annots := pdfcpu.AnnotationsForSelectedPages(ctx, pages)
+
+	return ctx, pdfcpu.FilterAnnotations(ctx, annots, f), nil
+}
+
+// Annotations returns page annotations of rs for selected pages.
+func Annotations(rs io.ReadSeeker, selectedPages []string, conf *model.Configuration) (map[int]model.PgAnnots, error) {
+	_, annots, err := annotations(rs, selectedPages, nil, conf)
+	return annots, err
+}
+
+// FilteredAnnotations returns page annotations of rs for selected pages matching f.
+func FilteredAnnotations(rs io.ReadSeeker, selectedPages []string, f *pdfcpu.AnnotationFilter, conf *model.Configuration) (map[int]model.PgAnnots, error) {
+	_, annots, err := annotations(rs, selectedPages, f, conf)
+	return annots, err
+}
+
+// AnnotationInfos returns descriptions of all page annotations of rs for selected pages matching f.
+func AnnotationInfos(rs io.ReadSeeker, selectedPages []string, f *pdfcpu.AnnotationFilter, conf *model.Configuration) ([]pdfcpu.AnnotationInfo, error) {
+	ctx, annots, err := annotations(rs, selectedPages, f, conf)
+	if err != nil {
+		return nil, err
+	}
+	return pdfcpu.AnnotationInfos(ctx, annots), nil
 }
 
 // AddAnnotations adds annotations for selected pages in rs and writes the result to w.
@@ -57,6 +82,8 @@ func AddAnnotations(rs io.ReadSeeker, w io.Writer, selectedPages []string, ann m
 
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
 	}
 	conf.Cmd = model.ADDANNOTATIONS
 
@@ -65,7 +92,7 @@ func AddAnnotations(rs io.ReadSeeker, w io.Writer, selectedPages []string, ann m
 		return err
 	}
 
-	pages, err := PagesForPageSelection(ctx.PageCount, selectedPages, true, true)
+	pages, err := PagesForPageSelectionWithContext(ctx, selectedPages, true, true)
 	if err != nil {
 		return err
 	}
@@ -89,6 +116,8 @@ func AddAnnotationsAsIncrement(rws io.ReadWriteSeeker, selectedPages []string, a
 
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
 	}
 	conf.Cmd = model.ADDANNOTATIONS
 
@@ -101,7 +130,7 @@ func AddAnnotationsAsIncrement(rws io.ReadWriteSeeker, selectedPages []string, a
 		return errors.New("Incremental writing not supported for PDF version < V1.4 (Hint: Use pdfcpu optimize then try again)")
 	}
 
-	pages, err := PagesForPageSelection(ctx.PageCount, selectedPages, true, true)
+	pages, err := PagesForPageSelectionWithContext(ctx, selectedPages, true, true)
 	if err != nil {
 		return err
 	}
@@ -119,9 +148,7 @@ func AddAnnotationsAsIncrement(rws io.ReadWriteSeeker, selectedPages []string, a
 
 // AddAnnotationsFile adds annotations for selected pages to a PDF context read from inFile and writes the result to outFile.
 func AddAnnotationsFile(inFile, outFile string, selectedPages []string, ar model.AnnotationRenderer, conf *model.Configuration, incr bool) (err error) {
-	tmpFile := inFile + ".tmp"
 	if outFile != "" && inFile != outFile {
-		tmpFile = outFile
 		logWritingTo(outFile)
 	} else {
 		logWritingTo(inFile)
@@ -135,36 +162,9 @@ func AddAnnotationsFile(inFile, outFile string, selectedPages []string, ar model
 		}
 	}
 
-	var f1, f2 *os.File
-
-	if f1, err = os.Open(inFile); err != nil {
-		return err
-	}
-
-	if f2, err = os.Create(tmpFile); err != nil {
-		f1.Close()
-		return err
-	}
-
-	defer func() {
-		if err != nil {
-			f2.Close()
-			f1.Close()
-			os.Remove(tmpFile)
-			return
-		}
-		if err = f2.Close(); err != nil {
-			return
-		}
-		if err = f1.Close(); err != nil {
-			return
-		}
-		if outFile == "" || inFile == outFile {
-			err = os.Rename(tmpFile, inFile)
-		}
-	}()
-
-	return AddAnnotations(f1, f2, selectedPages, ar, conf)
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return AddAnnotations(rs, w, selectedPages, ar, conf)
+	})
 }
 
 // AddAnnotationsMap adds annotations in m to corresponding pages of rs and writes the result to w.
@@ -175,6 +175,8 @@ func AddAnnotationsMap(rs io.ReadSeeker, w io.Writer, m map[int][]model.Annotati
 
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
 	}
 	conf.Cmd = model.ADDANNOTATIONS
 
@@ -202,6 +204,8 @@ func AddAnnotationsMapAsIncrement(rws io.ReadWriteSeeker, m map[int][]model.Anno
 
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
 	}
 	conf.Cmd = model.ADDANNOTATIONS
 
@@ -227,10 +231,7 @@ func AddAnnotationsMapAsIncrement(rws io.ReadWriteSeeker, m map[int][]model.Anno
 
 // AddAnnotationsMapFile adds annotations in m to corresponding pages of inFile and writes the result to outFile.
 func AddAnnotationsMapFile(inFile, outFile string, m map[int][]model.AnnotationRenderer, conf *model.Configuration, incr bool) (err error) {
-	tmpFile := inFile + ".tmp"
-
 	if outFile != "" && inFile != outFile {
-		tmpFile = outFile
 		logWritingTo(outFile)
 	} else {
 		logWritingTo(inFile)
@@ -244,36 +245,9 @@ func AddAnnotationsMapFile(inFile, outFile string, m map[int][]model.AnnotationR
 		}
 	}
 
-	var f1, f2 *os.File
-
-	if f1, err = os.Open(inFile); err != nil {
-		return err
-	}
-
-	if f2, err = os.Create(tmpFile); err != nil {
-		f1.Close()
-		return err
-	}
-
-	defer func() {
-		if err != nil {
-			f2.Close()
-			f1.Close()
-			os.Remove(tmpFile)
-			return
-		}
-		if err = f2.Close(); err != nil {
-			return
-		}
-		if err = f1.Close(); err != nil {
-			return
-		}
-		if outFile == "" || inFile == outFile {
-			err = os.Rename(tmpFile, inFile)
-		}
-	}()
-
-	return AddAnnotationsMap(f1, f2, m, conf)
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return AddAnnotationsMap(rs, w, m, conf)
+	})
 }
 
 // RemoveAnnotations removes annotations for selected pages by id and object number
@@ -285,6 +259,8 @@ func RemoveAnnotations(rs io.ReadSeeker, w io.Writer, selectedPages, idsAndTypes
 
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
 	}
 	conf.Cmd = model.REMOVEANNOTATIONS
 
@@ -293,7 +269,7 @@ func RemoveAnnotations(rs io.ReadSeeker, w io.Writer, selectedPages, idsAndTypes
 		return err
 	}
 
-	pages, err := PagesForPageSelection(ctx.PageCount, selectedPages, true, true)
+	pages, err := PagesForPageSelectionWithContext(ctx, selectedPages, true, true)
 	if err != nil {
 		return err
 	}
@@ -318,6 +294,8 @@ func RemoveAnnotationsAsIncrement(rws io.ReadWriteSeeker, selectedPages, idsAndT
 
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
 	}
 	conf.Cmd = model.REMOVEANNOTATIONS
 
@@ -330,7 +308,7 @@ func RemoveAnnotationsAsIncrement(rws io.ReadWriteSeeker, selectedPages, idsAndT
 		return errors.New("pdfcpu: Incremental writing unsupported for PDF version < V1.4 (Hint: Use pdfcpu optimize then try again)")
 	}
 
-	pages, err := PagesForPageSelection(ctx.PageCount, selectedPages, true, true)
+	pages, err := PagesForPageSelectionWithContext(ctx, selectedPages, true, true)
 	if err != nil {
 		return err
 	}
@@ -349,11 +327,9 @@ func RemoveAnnotationsAsIncrement(rws io.ReadWriteSeeker, selectedPages, idsAndT
 // RemoveAnnotationsFile removes annotations for selected pages by id and object number
 // from a PDF context read from inFile and writes the result to outFile.
 func RemoveAnnotationsFile(inFile, outFile string, selectedPages, idsAndTypes []string, objNrs []int, conf *model.Configuration, incr bool) (err error) {
-	var f1, f2 *os.File
+	var f1 *os.File
 
-	tmpFile := inFile + ".tmp"
 	if outFile != "" && inFile != outFile {
-		tmpFile = outFile
 		logWritingTo(outFile)
 	} else {
 		logWritingTo(inFile)
@@ -371,32 +347,143 @@ func RemoveAnnotationsFile(inFile, outFile string, selectedPages, idsAndTypes []
 		}
 	}
 
-	if f1, err = os.Open(inFile); err != nil {
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return RemoveAnnotations(rs, w, selectedPages, idsAndTypes, objNrs, conf)
+	})
+}
+
+// GenerateAnnotationAppearances synthesizes normal appearance streams for Square, Circle, Line, Highlight and FreeText
+// annotations of selected pages lacking one, so that flattening, printing and rendering is faithful.
+// The result is written to w.
+func GenerateAnnotationAppearances(rs io.ReadSeeker, w io.Writer, selectedPages []string, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: GenerateAnnotationAppearances: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
+	}
+	conf.Cmd = model.GENERATEANNOTAPPEARANCES
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	pages, err := PagesForPageSelectionWithContext(ctx, selectedPages, true, true)
+	if err != nil {
+		return err
+	}
+
+	n, err := pdfcpu.GenerateAnnotationAppearances(ctx, pages)
+	if err != nil {
+		return err
+	}
+
+	if log.CLIEnabled() {
+		log.CLI.Printf("%d appearance stream(s) generated\n", n)
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// GenerateAnnotationAppearancesFile synthesizes missing annotation appearance streams for selected pages of inFile
+// and writes the result to outFile.
+func GenerateAnnotationAppearancesFile(inFile, outFile string, selectedPages []string, conf *model.Configuration) error {
+	if outFile != "" && inFile != outFile {
+		logWritingTo(outFile)
+	} else {
+		logWritingTo(inFile)
+	}
+
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return GenerateAnnotationAppearances(rs, w, selectedPages, conf)
+	})
+}
+
+// AddAnnotationsJSON adds the annotations described by JSON read from rd to rs and writes the result to w.
+// See pdfcpu.AnnotationDescriptor for the supported annotation types and their attributes.
+func AddAnnotationsJSON(rs io.ReadSeeker, w io.Writer, rd io.Reader, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: AddAnnotationsJSON: missing rs")
+	}
+
+	if rd == nil {
+		return errors.New("pdfcpu: AddAnnotationsJSON: missing rd")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
+	}
+	conf.Cmd = model.ADDANNOTATIONS
+
+	ag, err := pdfcpu.ParseAnnotationGroup(rd)
+	if err != nil {
 		return err
 	}
 
-	if f2, err = os.Create(tmpFile); err != nil {
-		f1.Close()
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
 		return err
 	}
 
-	defer func() {
+	m := map[int][]model.AnnotationRenderer{}
+
+	for i, ad := range ag.Annotations {
+		ar, err := ad.Renderer()
 		if err != nil {
-			f2.Close()
-			f1.Close()
-			os.Remove(tmpFile)
-			return
+			return errors.Wrapf(err, "annotation #%d", i+1)
 		}
-		if err = f2.Close(); err != nil {
-			return
+
+		var selectedPages []string
+		if ad.Pages != "" {
+			if selectedPages, err = ParsePageSelection(ad.Pages); err != nil {
+				return errors.Wrapf(err, "annotation #%d", i+1)
+			}
 		}
-		if err = f1.Close(); err != nil {
-			return
+
+		pages, err := PagesForPageSelectionWithContext(ctx, selectedPages, true, false)
+		if err != nil {
+			return err
 		}
-		if outFile == "" || inFile == outFile {
-			err = os.Rename(tmpFile, inFile)
+
+		for pageNr, v := range pages {
+			if v {
+				m[pageNr] = append(m[pageNr], ar)
+			}
 		}
-	}()
+	}
+
+	ok, err := pdfcpu.AddAnnotationsMap(ctx, m, false)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New("pdfcpu: AddAnnotationsJSON: No annotations added")
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// AddAnnotationsJSONFile adds the annotations described by inFileJSON to inFile and writes the result to outFile.
+func AddAnnotationsJSONFile(inFile, inFileJSON, outFile string, conf *model.Configuration) (err error) {
+	f, err := os.Open(inFileJSON)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if outFile != "" && inFile != outFile {
+		logWritingTo(outFile)
+	} else {
+		logWritingTo(inFile)
+	}
 
-	return RemoveAnnotations(f1, f2, selectedPages, idsAndTypes, objNrs, conf)
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return AddAnnotationsJSON(rs, w, f, conf)
+	})
 }
@@ -0,0 +1,89 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+func addReply(rs io.ReadSeeker, w io.Writer, conf *model.Configuration, fn func(ctx *model.Context) (int, error)) (int, error) {
+	if rs == nil {
+		return 0, errors.New("pdfcpu: AddReply: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
+	}
+	conf.Cmd = model.ADDANNOTATIONS
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return 0, err
+	}
+
+	objNr, err := fn(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return objNr, Write(ctx, w, conf)
+}
+
+// AddReply adds a reply by author to the markup annotation objNr of rs, writes the result to w
+// and returns the object number of the reply.
+func AddReply(rs io.ReadSeeker, w io.Writer, objNr int, contents, author string, conf *model.Configuration) (int, error) {
+	return addReply(rs, w, conf, func(ctx *model.Context) (int, error) {
+		return pdfcpu.AddReply(ctx, objNr, contents, author, false)
+	})
+}
+
+// AddReplyFile adds a reply by author to the markup annotation objNr of inFile, writes the result to outFile
+// and returns the object number of the reply.
+func AddReplyFile(inFile, outFile string, objNr int, contents, author string, conf *model.Configuration) (replyObjNr int, err error) {
+	err = updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		var err1 error
+		replyObjNr, err1 = AddReply(rs, w, objNr, contents, author, conf)
+		return err1
+	})
+	return replyObjNr, err
+}
+
+// SetAnnotationState records state set by author for the markup annotation objNr of rs, writes the result to w
+// and returns the object number of the corresponding state annotation.
+// Use model.StateAccepted, model.StateRejected, .. for review workflows and model.StateMarked, model.StateUnmarked for check marks.
+func SetAnnotationState(rs io.ReadSeeker, w io.Writer, objNr int, state, author string, conf *model.Configuration) (int, error) {
+	return addReply(rs, w, conf, func(ctx *model.Context) (int, error) {
+		return pdfcpu.SetAnnotationState(ctx, objNr, state, author, false)
+	})
+}
+
+// SetAnnotationStateFile records state set by author for the markup annotation objNr of inFile, writes the result to outFile
+// and returns the object number of the corresponding state annotation.
+func SetAnnotationStateFile(inFile, outFile string, objNr int, state, author string, conf *model.Configuration) (stateObjNr int, err error) {
+	err = updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		var err1 error
+		stateObjNr, err1 = SetAnnotationState(rs, w, objNr, state, author, conf)
+		return err1
+	})
+	return stateObjNr, err
+}
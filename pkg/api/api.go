@@ -35,6 +35,7 @@ package api
 
 import (
 	"bufio"
+	"bytes"
 	"io"
 	"os"
 	"sync"
@@ -71,6 +72,10 @@ func ReadContext(rs io.ReadSeeker, conf *model.Configuration) (*model.Context, e
 	if rs == nil {
 		return nil, errors.New("pdfcpu: ReadContext: missing rs")
 	}
+	if conf != nil {
+		// The Context may modify its configuration (eg. passwords) which must not affect the caller.
+		conf = conf.Clone()
+	}
 	return pdfcpu.Read(rs, conf)
 }
 
@@ -91,11 +96,7 @@ func ReadContextFile(inFile string) (*model.Context, error) {
 		model.CheckConfigVersion(ctx.Conf.Version)
 	}
 
-	if ctx.XRefTable.Version() == model.V20 {
-		logDisclaimerPDF20()
-	}
-
-	if err = validate.XRefTable(ctx); err != nil {
+	if err = ValidateContext(ctx); err != nil {
 		return nil, err
 	}
 
@@ -107,7 +108,10 @@ func ValidateContext(ctx *model.Context) error {
 	if ctx.XRefTable.Version() == model.V20 {
 		logDisclaimerPDF20()
 	}
-	return validate.XRefTable(ctx)
+	if err := validate.XRefTable(ctx); err != nil {
+		return validationError{err}
+	}
+	return nil
 }
 
 // OptimizeContext optimizes ctx.
@@ -204,6 +208,141 @@ func logWritingTo(s string) {
 	}
 }
 
+// memoryOnly returns true if conf forbids writing intermediate files.
+func memoryOnly(conf *model.Configuration) bool {
+	return conf != nil && conf.MemoryOnly
+}
+
+func dryRun(conf *model.Configuration) bool {
+	return conf != nil && conf.DryRun
+}
+
+// discardOutput reads inFile and discards the output of fn.
+func discardOutput(inFile string, fn func(rs io.ReadSeeker, w io.Writer) error) (err error) {
+	rs, closeIn, err := openInFile(inFile)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err1 := closeIn(); err == nil {
+			err = err1
+		}
+	}()
+
+	return fn(rs, io.Discard)
+}
+
+// overwrite replaces the content of f with bb.
+//
+// NOTE: Unlike renaming a temporary file this is not atomic.
+// If writing gets interrupted (eg. disk full, crash) the content of f is lost.
+func overwrite(f *os.File, bb []byte) error {
+	if _, err := f.WriteAt(bb, 0); err != nil {
+		return err
+	}
+	if err := f.Truncate(int64(len(bb))); err != nil {
+		return err
+	}
+	return f.Sync()
+}
+
+// updateInMemory reads inFile and replaces its content with the output of fn buffered in memory.
+func updateInMemory(inFile string, conf *model.Configuration, fn func(rs io.ReadSeeker, w io.Writer) error) error {
+	f, err := os.OpenFile(inFile, os.O_RDWR, 0)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err = fn(f, &buf); err == nil {
+		err = verifyOutput(bytes.NewReader(buf.Bytes()), conf)
+	}
+	if err == nil {
+		// inFile remains untouched until the complete output is available.
+		err = overwrite(f, buf.Bytes())
+	}
+
+	if err1 := f.Close(); err == nil {
+		err = err1
+	}
+
+	return err
+}
+
+// updateFile reads inFile and writes the output of fn to outFile.
+// If outFile is empty or equals inFile, inFile gets replaced on success.
+// In this case the output is written to a temporary file next to inFile
+// unless conf.MemoryOnly is set, which buffers the output in memory instead.
+//
+// NOTE: With conf.MemoryOnly inFile gets overwritten in place which is not atomic.
+//
+// inFile and outFile may be StdIO for reading from stdin and writing to stdout.
+// With conf.DryRun set the output gets discarded.
+//
+// With conf.Backup set inFile gets copied to its backup file before being replaced.
+// With conf.Backup or conf.PostProcessValidate set inFile only gets replaced by valid output.
+func updateFile(inFile, outFile string, conf *model.Configuration, fn func(rs io.ReadSeeker, w io.Writer) error) (err error) {
+	if dryRun(conf) {
+		return discardOutput(inFile, fn)
+	}
+
+	if usesStdIO(inFile, outFile) {
+		return updateStdIO(inFile, outFile, fn)
+	}
+
+	inPlace := outFile == "" || inFile == outFile
+
+	if inPlace && backup(conf) {
+		if err := backupFile(inFile, conf); err != nil {
+			return err
+		}
+	}
+
+	if inPlace && memoryOnly(conf) {
+		return updateInMemory(inFile, conf, fn)
+	}
+
+	var f1, f2 *os.File
+
+	if f1, err = os.Open(inFile); err != nil {
+		return err
+	}
+
+	tmpFile := inFile + ".tmp"
+	if !inPlace {
+		tmpFile = outFile
+	}
+
+	if f2, err = os.Create(tmpFile); err != nil {
+		f1.Close()
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			f1.Close()
+			os.Remove(tmpFile)
+			return
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		if err = f1.Close(); err != nil {
+			return
+		}
+		if inPlace {
+			if err = verifyOutputFile(tmpFile, conf); err != nil {
+				os.Remove(tmpFile)
+				return
+			}
+			err = os.Rename(tmpFile, inFile)
+		}
+	}()
+
+	return fn(f1, f2)
+}
+
 func Write(ctx *model.Context, w io.Writer, conf *model.Configuration) error {
 	if log.StatsEnabled() {
 		log.Stats.Printf("XRefTable:\n%s\n", ctx)
@@ -269,3 +408,17 @@ func LoadConfiguration() *model.Configuration {
 	// and need to use user fonts for stamping or watermarking.
 	return model.NewDefaultConfiguration()
 }
+
+// LoadConfig loads and validates the configuration file at configPath.
+// Config files written by older releases are migrated to the current schema version.
+func LoadConfig(configPath string) (*model.Configuration, error) {
+	return model.ReadConfigFile(configPath)
+}
+
+// SaveConfig validates conf and writes it to configPath using the current schema version.
+func SaveConfig(configPath string, conf *model.Configuration) error {
+	if conf == nil {
+		return errors.New("pdfcpu: SaveConfig: missing conf")
+	}
+	return model.WriteConfigFile(configPath, conf)
+}
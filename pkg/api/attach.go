@@ -17,10 +17,13 @@
 package api
 
 import (
+	"encoding/json"
 	"io"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/pdfcpu/pdfcpu/pkg/log"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
@@ -35,6 +38,8 @@ func Attachments(rs io.ReadSeeker, conf *model.Configuration) ([]model.Attachmen
 
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
 	}
 	conf.Cmd = model.LISTATTACHMENTS
 
@@ -46,6 +51,38 @@ func Attachments(rs io.ReadSeeker, conf *model.Configuration) ([]model.Attachmen
 	return ctx.ListAttachments()
 }
 
+// VerifyAttachments recomputes the checksums of all embedded files of rs and compares them against their declared checksums.
+func VerifyAttachments(rs io.ReadSeeker, conf *model.Configuration) ([]model.AttachmentCheck, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: VerifyAttachments: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
+	}
+	conf.Cmd = model.VERIFYATTACHMENTS
+
+	ctx, err := ReadAndValidate(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return ctx.VerifyAttachments()
+}
+
+// VerifyAttachmentsFile recomputes the checksums of all embedded files of inFile and compares them against their declared checksums.
+func VerifyAttachmentsFile(inFile string, conf *model.Configuration) ([]model.AttachmentCheck, error) {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return VerifyAttachments(f, conf)
+}
+
 // AddAttachments embeds files into a PDF context read from rs and writes the result to w.
 // file is either a file name or a file name and a description separated by a comma.
 func AddAttachments(rs io.ReadSeeker, w io.Writer, files []string, coll bool, conf *model.Configuration) error {
@@ -59,6 +96,8 @@ func AddAttachments(rs io.ReadSeeker, w io.Writer, files []string, coll bool, co
 
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
 	}
 	conf.Cmd = model.ADDATTACHMENTS
 
@@ -112,40 +151,138 @@ func AddAttachments(rs io.ReadSeeker, w io.Writer, files []string, coll bool, co
 
 // AddAttachmentsFile embeds files into a PDF context read from inFile and writes the result to outFile.
 func AddAttachmentsFile(inFile, outFile string, files []string, coll bool, conf *model.Configuration) (err error) {
-	var f1, f2 *os.File
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return AddAttachments(rs, w, files, coll, conf)
+	})
+}
 
-	if f1, err = os.Open(inFile); err != nil {
-		return err
+// ParseAttachmentManifest parses a JSON attachment manifest read from rd.
+func ParseAttachmentManifest(rd io.Reader) (*model.AttachmentManifest, error) {
+	if rd == nil {
+		return nil, errors.New("pdfcpu: ParseAttachmentManifest: missing rd")
+	}
+
+	m := &model.AttachmentManifest{}
+	if err := json.NewDecoder(rd).Decode(m); err != nil {
+		return nil, errors.Wrap(err, "pdfcpu: invalid attachment manifest")
+	}
+
+	if len(m.Attachments) == 0 {
+		return nil, errors.New("pdfcpu: attachment manifest: missing attachments")
 	}
 
-	tmpFile := inFile + ".tmp"
-	if outFile != "" && inFile != outFile {
-		tmpFile = outFile
+	for i, e := range m.Attachments {
+		if e.File == "" {
+			return nil, errors.Errorf("pdfcpu: attachment manifest: entry %d: missing file", i+1)
+		}
+		if e.AFRelationship != "" {
+			if _, ok := model.AFRelationship(e.AFRelationship); !ok {
+				return nil, errors.Errorf("pdfcpu: attachment manifest: entry %d: invalid afRelationship %q", i+1, e.AFRelationship)
+			}
+		}
 	}
-	if f2, err = os.Create(tmpFile); err != nil {
-		f1.Close()
+
+	return m, nil
+}
+
+func addAttachmentsForManifest(rs io.ReadSeeker, w io.Writer, m *model.AttachmentManifest, baseDir string, coll bool, conf *model.Configuration) error {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
+	}
+	conf.Cmd = model.ADDATTACHMENTS
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
 		return err
 	}
 
-	defer func() {
+	for _, e := range m.Attachments {
+		fileName := e.File
+		if baseDir != "" && !filepath.IsAbs(fileName) {
+			fileName = filepath.Join(baseDir, fileName)
+		}
+
+		if log.CLIEnabled() {
+			log.CLI.Printf("adding %s\n", fileName)
+		}
+		f, err := os.Open(fileName)
 		if err != nil {
-			f2.Close()
-			f1.Close()
-			os.Remove(tmpFile)
-			return
+			return err
 		}
-		if err = f2.Close(); err != nil {
-			return
+		defer f.Close()
+
+		mt := e.Modified
+		if mt == nil {
+			fi, err := f.Stat()
+			if err != nil {
+				return err
+			}
+			t := fi.ModTime()
+			mt = &t
 		}
-		if err = f1.Close(); err != nil {
-			return
+
+		id := e.ID
+		if id == "" {
+			id = filepath.Base(fileName)
 		}
-		if outFile == "" || inFile == outFile {
-			err = os.Rename(tmpFile, inFile)
+
+		a := model.Attachment{
+			Reader:         f,
+			ID:             id,
+			FileName:       filepath.Base(fileName),
+			Desc:           e.Desc,
+			ModTime:        mt,
+			CreationTime:   e.Created,
+			MimeType:       e.MimeType,
+			AFRelationship: e.AFRelationship,
 		}
-	}()
+		if err = ctx.AddAttachment(a, coll); err != nil {
+			return err
+		}
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// AddAttachmentsJSON embeds the files described by the JSON manifest read from rd into a PDF context read from rs
+// and writes the result to w. Besides a description the manifest supports MIME type, creation and modification dates
+// and the AFRelationship for each file.
+func AddAttachmentsJSON(rs io.ReadSeeker, rd io.Reader, w io.Writer, coll bool, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: AddAttachmentsJSON: missing rs")
+	}
+
+	if w == nil {
+		return errors.New("pdfcpu: AddAttachmentsJSON: missing w")
+	}
+
+	m, err := ParseAttachmentManifest(rd)
+	if err != nil {
+		return err
+	}
+
+	return addAttachmentsForManifest(rs, w, m, "", coll, conf)
+}
+
+// AddAttachmentsJSONFile embeds the files described by the JSON manifest inFileJSON into a PDF context read from inFile
+// and writes the result to outFile. Relative file names are resolved against the directory of inFileJSON.
+func AddAttachmentsJSONFile(inFile, inFileJSON, outFile string, coll bool, conf *model.Configuration) (err error) {
+	f, err := os.Open(inFileJSON)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	m, err := ParseAttachmentManifest(f)
+	if err != nil {
+		return err
+	}
 
-	return AddAttachments(f1, f2, files, coll, conf)
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return addAttachmentsForManifest(rs, w, m, filepath.Dir(inFileJSON), coll, conf)
+	})
 }
 
 // RemoveAttachments deletes embedded files from a PDF context read from rs and writes the result to w.
@@ -160,6 +297,8 @@ func RemoveAttachments(rs io.ReadSeeker, w io.Writer, files []string, conf *mode
 
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
 	}
 	conf.Cmd = model.ADDATTACHMENTS
 
@@ -181,40 +320,9 @@ func RemoveAttachments(rs io.ReadSeeker, w io.Writer, files []string, conf *mode
 
 // RemoveAttachmentsFile deletes embedded files from a PDF context read from inFile and writes the result to outFile.
 func RemoveAttachmentsFile(inFile, outFile string, files []string, conf *model.Configuration) (err error) {
-	var f1, f2 *os.File
-
-	if f1, err = os.Open(inFile); err != nil {
-		return err
-	}
-
-	tmpFile := inFile + ".tmp"
-	if outFile != "" && inFile != outFile {
-		tmpFile = outFile
-	}
-	if f2, err = os.Create(tmpFile); err != nil {
-		f1.Close()
-		return err
-	}
-
-	defer func() {
-		if err != nil {
-			f2.Close()
-			f1.Close()
-			os.Remove(tmpFile)
-			return
-		}
-		if err = f2.Close(); err != nil {
-			return
-		}
-		if err = f1.Close(); err != nil {
-			return
-		}
-		if outFile == "" || inFile == outFile {
-			err = os.Rename(tmpFile, inFile)
-		}
-	}()
-
-	return RemoveAttachments(f1, f2, files, conf)
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return RemoveAttachments(rs, w, files, conf)
+	})
 }
 
 // ExtractAttachmentsRaw extracts embedded files from a PDF context read from rs.
@@ -225,6 +333,8 @@ func ExtractAttachmentsRaw(rs io.ReadSeeker, outDir string, fileNames []string,
 
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
 	}
 	conf.Cmd = model.EXTRACTATTACHMENTS
 
@@ -236,7 +346,17 @@ func ExtractAttachmentsRaw(rs io.ReadSeeker, outDir string, fileNames []string,
 	return ctx.ExtractAttachments(fileNames)
 }
 
+// attachmentFileName returns a's file name stripped of any path components.
+func attachmentFileName(a model.Attachment) string {
+	s := filepath.Base(filepath.FromSlash(strings.ReplaceAll(a.FileName, "\\", "/")))
+	if s == "." || s == ".." || s == string(filepath.Separator) {
+		s = "_"
+	}
+	return s
+}
+
 // ExtractAttachments extracts embedded files from a PDF context read from rs into outDir.
+// Portfolio folders are recreated as subdirectories of outDir.
 func ExtractAttachments(rs io.ReadSeeker, outDir string, fileNames []string, conf *model.Configuration) error {
 	aa, err := ExtractAttachmentsRaw(rs, outDir, fileNames, conf)
 	if err != nil {
@@ -244,10 +364,17 @@ func ExtractAttachments(rs io.ReadSeeker, outDir string, fileNames []string, con
 	}
 
 	for _, a := range aa {
-		fileName := filepath.Join(outDir, a.FileName)
+		dir := outDir
+		if a.Folder != "" {
+			dir = filepath.Join(outDir, filepath.FromSlash(a.Folder))
+			if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+				return err
+			}
+		}
+		fileName := filepath.Join(dir, attachmentFileName(a))
 		f, err := os.OpenFile(fileName, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, os.ModePerm)
 		if err != nil {
-			fileName = filepath.Base(a.FileName)
+			fileName = attachmentFileName(a)
 			f, err = os.OpenFile(fileName, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, os.ModePerm)
 			if err != nil {
 				return err
@@ -260,12 +387,62 @@ func ExtractAttachments(rs io.ReadSeeker, outDir string, fileNames []string, con
 		if err := f.Close(); err != nil {
 			return err
 		}
+		if a.ModTime != nil {
+			if err := os.Chtimes(fileName, *a.ModTime, *a.ModTime); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// ExtractAttachment extracts the embedded file fileName from a PDF context read from rs and writes its content to w.
+func ExtractAttachment(rs io.ReadSeeker, w io.Writer, fileName string, conf *model.Configuration) error {
+	if w == nil {
+		return errors.New("pdfcpu: ExtractAttachment: missing w")
+	}
+
+	aa, err := ExtractAttachmentsRaw(rs, "", []string{fileName}, conf)
+	if err != nil {
+		return err
+	}
+
+	if len(aa) == 0 {
+		return errors.Errorf("pdfcpu: ExtractAttachment: %s not found", fileName)
+	}
+
+	_, err = io.Copy(w, aa[0])
+	return err
+}
+
+// ExtractAttachmentsFunc extracts embedded files from a PDF context read from rs and passes each of them to fn.
+// fileNames may contain glob patterns. This is useful for streaming attachments to some external storage.
+func ExtractAttachmentsFunc(rs io.ReadSeeker, fileNames []string, conf *model.Configuration, fn func(name string, r io.Reader, modTime *time.Time) error) error {
+	if fn == nil {
+		return errors.New("pdfcpu: ExtractAttachmentsFunc: missing fn")
+	}
+
+	aa, err := ExtractAttachmentsRaw(rs, "", fileNames, conf)
+	if err != nil {
+		return err
+	}
+
+	for _, a := range aa {
+		name := attachmentFileName(a)
+		if a.Folder != "" {
+			name = path.Join(a.Folder, name)
+		}
+		if err := fn(name, a.Reader, a.ModTime); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
 // ExtractAttachmentsFile extracts embedded files from a PDF context read from inFile into outDir.
+// If outDir is "-" the single attachment selected by files gets written to stdout.
 func ExtractAttachmentsFile(inFile, outDir string, files []string, conf *model.Configuration) error {
 	f, err := os.Open(inFile)
 	if err != nil {
@@ -273,5 +450,23 @@ func ExtractAttachmentsFile(inFile, outDir string, files []string, conf *model.C
 	}
 	defer f.Close()
 
+	if outDir == "-" {
+		return extractAttachmentToStdout(f, files, conf)
+	}
+
 	return ExtractAttachments(f, outDir, files, conf)
 }
+
+func extractAttachmentToStdout(rs io.ReadSeeker, files []string, conf *model.Configuration) error {
+	aa, err := ExtractAttachmentsRaw(rs, "", files, conf)
+	if err != nil {
+		return err
+	}
+
+	if len(aa) != 1 {
+		return errors.Errorf("pdfcpu: extracting to stdout requires exactly one matching attachment, got %d", len(aa))
+	}
+
+	_, err = io.Copy(os.Stdout, aa[0])
+	return err
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+	"os"
+
+	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+// BackupFileName returns the name of the backup file for inFile.
+func BackupFileName(inFile string, conf *model.Configuration) string {
+	if conf != nil && conf.BackupFile != "" {
+		return conf.BackupFile
+	}
+	return inFile + ".bak"
+}
+
+func backup(conf *model.Configuration) bool {
+	return conf != nil && conf.Backup
+}
+
+// backupFile copies inFile to its backup file before getting modified in place.
+func backupFile(inFile string, conf *model.Configuration) (err error) {
+	bakFile := BackupFileName(inFile, conf)
+
+	if log.CLIEnabled() {
+		log.CLI.Printf("backing up %s to %s\n", inFile, bakFile)
+	}
+
+	f1, err := os.Open(inFile)
+	if err != nil {
+		return err
+	}
+	defer f1.Close()
+
+	f2, err := os.Create(bakFile)
+	if err != nil {
+		return err
+	}
+
+	if _, err = io.Copy(f2, f1); err != nil {
+		f2.Close()
+		os.Remove(bakFile)
+		return err
+	}
+
+	if err = f2.Sync(); err != nil {
+		f2.Close()
+		return err
+	}
+
+	return f2.Close()
+}
+
+// changesEncryption returns true for commands writing a file that can't be opened using the passwords of conf.
+func changesEncryption(cmd model.CommandMode) bool {
+	switch cmd {
+	case model.ENCRYPT, model.CHANGEUPW, model.CHANGEOPW, model.SETPERMISSIONS, model.UPDATEENCRYPTION:
+		return true
+	}
+	return false
+}
+
+// verifyOutput validates the output about to replace the original file.
+// Applies to in place updates with conf.Backup or conf.PostProcessValidate set.
+func verifyOutput(rs io.ReadSeeker, conf *model.Configuration) error {
+	if conf == nil || !(conf.Backup || conf.PostProcessValidate) || changesEncryption(conf.Cmd) {
+		return nil
+	}
+
+	c := *conf
+	c.Optimize = false
+	c.DryRun = false
+
+	if _, err := rs.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	if err := Validate(rs, &c); err != nil {
+		return errors.Wrap(err, "pdfcpu: refusing to overwrite original file")
+	}
+
+	return nil
+}
+
+// verifyOutputFile validates outFile about to replace the original file.
+func verifyOutputFile(outFile string, conf *model.Configuration) error {
+	if conf == nil || !(conf.Backup || conf.PostProcessValidate) || changesEncryption(conf.Cmd) {
+		return nil
+	}
+
+	f, err := os.Open(outFile)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return verifyOutput(f, conf)
+}
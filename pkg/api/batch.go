@@ -0,0 +1,449 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// JobStep is a single operation of a batch job.
+//
+// Supported operations and their parameters:
+//
+//	decrypt   ... upw, opw (passwords of the input files)
+//	encrypt   ... upw, opw, mode (aes|rc4), key (40|128|256), perm (none|print|all)
+//	stamp     ... mode (text|image|pdf), text, desc, pages
+//	watermark ... mode (text|image|pdf), text, desc, pages
+//	rotate    ... rotation, pages
+//	optimize
+//	validate
+type JobStep struct {
+	Op       string `json:"op"`
+	Pages    string `json:"pages,omitempty"`
+	Mode     string `json:"mode,omitempty"`
+	Text     string `json:"text,omitempty"` // text, image file or PDF file for stamps and watermarks
+	Desc     string `json:"desc,omitempty"`
+	Rotation int    `json:"rotation,omitempty"`
+	UserPW   string `json:"upw,omitempty"`
+	OwnerPW  string `json:"opw,omitempty"`
+	Key      int    `json:"key,omitempty"`
+	Perm     string `json:"perm,omitempty"`
+}
+
+// Job applies a sequence of steps to a set of input files.
+// Each input file is read and written once regardless of the number of steps.
+type Job struct {
+	Inputs      []string  `json:"inputs"`           // files, directories (*.pdf) or glob patterns
+	OutDir      string    `json:"outDir,omitempty"` // input files get replaced if empty
+	Concurrency int       `json:"concurrency,omitempty"`
+	Steps       []JobStep `json:"steps"`
+}
+
+// JobFile is the JSON representation of a sequence of batch jobs.
+type JobFile struct {
+	Jobs []Job `json:"jobs"`
+}
+
+// JobResult is the outcome of processing a single input file.
+type JobResult struct {
+	InFile   string
+	OutFile  string
+	Duration time.Duration
+	Err      error
+}
+
+// BatchReport summarizes the processing of batch jobs.
+type BatchReport struct {
+	Results []JobResult
+	OK      int
+	Failed  int
+}
+
+// String returns a human readable summary of r.
+func (r BatchReport) String() string {
+	var sb strings.Builder
+	for _, res := range r.Results {
+		if res.Err != nil {
+			fmt.Fprintf(&sb, "FAIL %s: %v\n", res.InFile, res.Err)
+			continue
+		}
+		fmt.Fprintf(&sb, "ok   %s -> %s (%.2fs)\n", res.InFile, res.OutFile, res.Duration.Seconds())
+	}
+	fmt.Fprintf(&sb, "%d files processed, %d ok, %d failed", len(r.Results), r.OK, r.Failed)
+	return sb.String()
+}
+
+func (s JobStep) validate() error {
+	switch s.Op {
+
+	case "decrypt", "optimize", "validate":
+
+	case "encrypt":
+		if s.OwnerPW == "" {
+			return errors.New("missing owner password")
+		}
+		if s.Mode != "" && s.Mode != "aes" && s.Mode != "rc4" {
+			return errors.Errorf("invalid mode: %s", s.Mode)
+		}
+		switch s.Key {
+		case 0, 40, 128, 256:
+		default:
+			return errors.Errorf("invalid key length: %d", s.Key)
+		}
+		switch s.Perm {
+		case "", "none", "print", "all":
+		default:
+			return errors.Errorf("invalid permissions: %s", s.Perm)
+		}
+
+	case "stamp", "watermark":
+		if s.Text == "" {
+			return errors.New("missing text")
+		}
+		switch s.Mode {
+		case "", "text", "image", "pdf":
+		default:
+			return errors.Errorf("invalid mode: %s", s.Mode)
+		}
+
+	case "rotate":
+		if s.Rotation%90 != 0 {
+			return errors.Errorf("rotation must be a multiple of 90, got: %d", s.Rotation)
+		}
+
+	default:
+		return errors.Errorf("unsupported operation: %s", s.Op)
+	}
+
+	if _, err := ParsePageSelection(s.Pages); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (j Job) validate() error {
+	if len(j.Inputs) == 0 {
+		return errors.New("pdfcpu: job: missing inputs")
+	}
+	if len(j.Steps) == 0 {
+		return errors.New("pdfcpu: job: missing steps")
+	}
+	for i, s := range j.Steps {
+		if err := s.validate(); err != nil {
+			return errors.Errorf("pdfcpu: job: step %d (%s): %v", i+1, s.Op, err)
+		}
+	}
+	return nil
+}
+
+func (s JobStep) watermark(u types.DisplayUnit) (*model.Watermark, error) {
+	onTop := s.Op == "stamp"
+	switch s.Mode {
+	case "image":
+		return ImageWatermark(s.Text, s.Desc, onTop, false, u)
+	case "pdf":
+		return PDFWatermark(s.Text, s.Desc, onTop, false, u)
+	}
+	return TextWatermark(s.Text, s.Desc, onTop, false, u)
+}
+
+func decryptContext(ctx *model.Context) error {
+	if ctx.Encrypt == nil {
+		return nil
+	}
+	if err := ctx.FreeObject(ctx.Encrypt.ObjectNumber.Value()); err != nil {
+		return err
+	}
+	ctx.Encrypt, ctx.EncKey = nil, nil
+	return nil
+}
+
+func encryptContext(ctx *model.Context, s JobStep) error {
+	if ctx.Encrypt != nil {
+		return errors.New("this file is already encrypted - add a decrypt step")
+	}
+
+	ctx.UserPW, ctx.OwnerPW = s.UserPW, s.OwnerPW
+	ctx.EncryptUsingAES = s.Mode != "rc4"
+	if s.Key > 0 {
+		ctx.EncryptKeyLength = s.Key
+	}
+
+	switch s.Perm {
+	case "none":
+		ctx.Permissions = model.PermissionsNone
+	case "all":
+		ctx.Permissions = model.PermissionsAll
+	default:
+		ctx.Permissions = model.PermissionsPrint
+	}
+
+	// Encryption takes place on write.
+	ctx.Cmd = model.ENCRYPT
+
+	return nil
+}
+
+func (s JobStep) apply(p *Pipeline) error {
+	switch s.Op {
+
+	case "decrypt":
+		return decryptContext(p.Context())
+
+	case "encrypt":
+		return encryptContext(p.Context(), s)
+
+	case "optimize":
+		return p.Optimize()
+
+	case "validate":
+		return p.Validate()
+	}
+
+	selectedPages, err := ParsePageSelection(s.Pages)
+	if err != nil {
+		return err
+	}
+
+	if s.Op == "rotate" {
+		return p.Rotate(s.Rotation, selectedPages)
+	}
+
+	wm, err := s.watermark(p.Context().Unit)
+	if err != nil {
+		return err
+	}
+
+	return p.AddWatermarks(selectedPages, wm)
+}
+
+func (j Job) decryptStep() *JobStep {
+	for i, s := range j.Steps {
+		if s.Op == "decrypt" {
+			return &j.Steps[i]
+		}
+	}
+	return nil
+}
+
+func (j Job) process(inFile, outFile string, conf *model.Configuration) error {
+	c := *conf
+	if s := j.decryptStep(); s != nil && (s.UserPW != "" || s.OwnerPW != "") {
+		c.UserPW, c.OwnerPW = s.UserPW, s.OwnerPW
+	}
+
+	return updateFile(inFile, outFile, &c, func(rs io.ReadSeeker, w io.Writer) error {
+		p, err := NewPipeline(rs, &c)
+		if err != nil {
+			return err
+		}
+
+		for _, s := range j.Steps {
+			if err := s.apply(p); err != nil {
+				return errors.Wrapf(err, "%s", s.Op)
+			}
+		}
+
+		return p.Write(w)
+	})
+}
+
+func batchInputFiles(inputs []string) ([]string, error) {
+	var ss []string
+
+	for _, in := range inputs {
+
+		if fi, err := os.Stat(in); err == nil && fi.IsDir() {
+			fileNames, err := filepath.Glob(filepath.Join(in, "*.pdf"))
+			if err != nil {
+				return nil, err
+			}
+			ss = append(ss, fileNames...)
+			continue
+		}
+
+		if strings.ContainsAny(in, "*?[") {
+			fileNames, err := filepath.Glob(in)
+			if err != nil {
+				return nil, err
+			}
+			ss = append(ss, fileNames...)
+			continue
+		}
+
+		ss = append(ss, in)
+	}
+
+	sort.Strings(ss)
+
+	// Remove duplicates.
+	var ss1 []string
+	for i, s := range ss {
+		if i == 0 || s != ss[i-1] {
+			ss1 = append(ss1, s)
+		}
+	}
+
+	return ss1, nil
+}
+
+func (j Job) run(conf *model.Configuration) ([]JobResult, error) {
+	inFiles, err := batchInputFiles(j.Inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	if j.OutDir != "" {
+		if err := os.MkdirAll(j.OutDir, os.ModePerm); err != nil {
+			return nil, err
+		}
+	}
+
+	results := make([]JobResult, len(inFiles))
+	outFiles := map[string]bool{}
+
+	for i, inFile := range inFiles {
+		outFile := inFile
+		if j.OutDir != "" {
+			outFile = filepath.Join(j.OutDir, filepath.Base(inFile))
+		}
+		results[i] = JobResult{InFile: inFile, OutFile: outFile}
+		if outFiles[outFile] {
+			results[i].Err = errors.Errorf("pdfcpu: duplicate output file: %s", outFile)
+		}
+		outFiles[outFile] = true
+	}
+
+	n := j.Concurrency
+	if n < 1 {
+		n = 1
+	}
+
+	ch := make(chan int)
+	var wg sync.WaitGroup
+
+	for w := 0; w < n; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range ch {
+				res := &results[i]
+				start := time.Now()
+				res.Err = j.process(res.InFile, res.OutFile, conf)
+				res.Duration = time.Since(start)
+			}
+		}()
+	}
+
+	for i := range results {
+		if results[i].Err == nil {
+			ch <- i
+		}
+	}
+	close(ch)
+
+	wg.Wait()
+
+	return results, nil
+}
+
+// RunJobs processes jobs one after the other.
+// The input files of a job are processed concurrently according to job.Concurrency.
+// Errors processing an input file do not affect the processing of other input files
+// and are recorded in the resulting report.
+func RunJobs(jobs []Job, conf *model.Configuration) (*BatchReport, error) {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
+	}
+	conf.Cmd = model.BATCH
+
+	for _, j := range jobs {
+		if err := j.validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	r := &BatchReport{}
+
+	for _, j := range jobs {
+		results, err := j.run(conf)
+		if err != nil {
+			return nil, err
+		}
+		for _, res := range results {
+			if res.Err != nil {
+				r.Failed++
+				if log.CLIEnabled() {
+					log.CLI.Printf("%s: %v\n", res.InFile, res.Err)
+				}
+			} else {
+				r.OK++
+			}
+		}
+		r.Results = append(r.Results, results...)
+	}
+
+	return r, nil
+}
+
+// ReadJobFile reads a JSON job file.
+func ReadJobFile(jobFile string) ([]Job, error) {
+	f, err := os.Open(jobFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	dec := json.NewDecoder(f)
+	dec.DisallowUnknownFields()
+
+	var jf JobFile
+	if err := dec.Decode(&jf); err != nil {
+		return nil, errors.Wrapf(err, "pdfcpu: invalid job file %s", jobFile)
+	}
+
+	if len(jf.Jobs) == 0 {
+		return nil, errors.Errorf("pdfcpu: no jobs in %s", jobFile)
+	}
+
+	return jf.Jobs, nil
+}
+
+// RunJobFile processes all jobs of jobFile.
+func RunJobFile(jobFile string, conf *model.Configuration) (*BatchReport, error) {
+	jobs, err := ReadJobFile(jobFile)
+	if err != nil {
+		return nil, err
+	}
+	return RunJobs(jobs, conf)
+}
@@ -63,6 +63,8 @@ func Booklet(rs io.ReadSeeker, w io.Writer, imgFiles, selectedPages []string, nu
 
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
 	}
 	conf.Cmd = model.BOOKLET
 
@@ -87,7 +89,7 @@ func Booklet(rs io.ReadSeeker, w io.Writer, imgFiles, selectedPages []string, nu
 			return err
 		}
 
-		pages, err := PagesForPageSelection(ctx.PageCount, selectedPages, true, true)
+		pages, err := PagesForPageSelectionWithContext(ctx, selectedPages, true, true)
 		if err != nil {
 			return err
 		}
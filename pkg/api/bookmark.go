@@ -20,6 +20,7 @@ import (
 	"io"
 	"os"
 
+	"github.com/pdfcpu/pdfcpu/pkg/log"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
 	"github.com/pkg/errors"
@@ -39,6 +40,7 @@ func Bookmarks(rs io.ReadSeeker, conf *model.Configuration) ([]pdfcpu.Bookmark,
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
 	} else {
+		conf = conf.Clone()
 		conf.ValidationMode = model.ValidationRelaxed
 	}
 	conf.Cmd = model.LISTBOOKMARKS
@@ -62,6 +64,8 @@ func ExportBookmarksJSON(rs io.ReadSeeker, w io.Writer, source string, conf *mod
 
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
 	}
 	conf.Cmd = model.EXPORTBOOKMARKS
 
@@ -113,6 +117,7 @@ func ExportBookmarksFile(inFilePDF, outFileJSON string, conf *model.Configuratio
 }
 
 // ImportBookmarks creates/replaces outlines in rs and writes the result to w.
+// rd provides the bookmarks either as JSON or as indented text or Markdown list like "- Title | 12".
 func ImportBookmarks(rs io.ReadSeeker, rd io.Reader, w io.Writer, replace bool, conf *model.Configuration) error {
 	if rs == nil {
 		return errors.New("pdfcpu: ImportBookmarks: missing rs")
@@ -125,6 +130,7 @@ func ImportBookmarks(rs io.ReadSeeker, rd io.Reader, w io.Writer, replace bool,
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
 	} else {
+		conf = conf.Clone()
 		conf.ValidationMode = model.ValidationRelaxed
 	}
 	conf.Cmd = model.IMPORTBOOKMARKS
@@ -146,45 +152,18 @@ func ImportBookmarks(rs io.ReadSeeker, rd io.Reader, w io.Writer, replace bool,
 }
 
 // ImportBookmarks creates/replaces outlines in inFilePDF and writes the result to outFilePDF.
+// inFileJSON may also be an indented text or Markdown file.
 func ImportBookmarksFile(inFilePDF, inFileJSON, outFilePDF string, replace bool, conf *model.Configuration) (err error) {
-	var f0, f1, f2 *os.File
-
-	if f0, err = os.Open(inFilePDF); err != nil {
-		return err
-	}
+	var f1 *os.File
 
 	if f1, err = os.Open(inFileJSON); err != nil {
 		return err
 	}
+	defer f1.Close()
 
-	tmpFile := inFilePDF + ".tmp"
-	if outFilePDF != "" && inFilePDF != outFilePDF {
-		tmpFile = outFilePDF
-	}
-	if f2, err = os.Create(tmpFile); err != nil {
-		f1.Close()
-		return err
-	}
-
-	defer func() {
-		if err != nil {
-			f2.Close()
-			f1.Close()
-			os.Remove(tmpFile)
-			return
-		}
-		if err = f2.Close(); err != nil {
-			return
-		}
-		if err = f1.Close(); err != nil {
-			return
-		}
-		if outFilePDF == "" || inFilePDF == outFilePDF {
-			err = os.Rename(tmpFile, inFilePDF)
-		}
-	}()
-
-	return ImportBookmarks(f0, f1, f2, replace, conf)
+	return updateFile(inFilePDF, outFilePDF, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return ImportBookmarks(rs, f1, w, replace, conf)
+	})
 }
 
 // AddBookmarks adds a single bookmark outline layer to the PDF context read from rs and writes the result to w.
@@ -196,6 +175,7 @@ func AddBookmarks(rs io.ReadSeeker, w io.Writer, bms []pdfcpu.Bookmark, replace
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
 	} else {
+		conf = conf.Clone()
 		conf.ValidationMode = model.ValidationRelaxed
 	}
 	conf.Cmd = model.ADDBOOKMARKS
@@ -218,40 +198,9 @@ func AddBookmarks(rs io.ReadSeeker, w io.Writer, bms []pdfcpu.Bookmark, replace
 
 // AddBookmarksFile adds outlines to the PDF context read from inFile and writes the result to outFile.
 func AddBookmarksFile(inFile, outFile string, bms []pdfcpu.Bookmark, replace bool, conf *model.Configuration) (err error) {
-	var f1, f2 *os.File
-
-	if f1, err = os.Open(inFile); err != nil {
-		return err
-	}
-
-	tmpFile := inFile + ".tmp"
-	if outFile != "" && inFile != outFile {
-		tmpFile = outFile
-	}
-	if f2, err = os.Create(tmpFile); err != nil {
-		f1.Close()
-		return err
-	}
-
-	defer func() {
-		if err != nil {
-			f2.Close()
-			f1.Close()
-			os.Remove(tmpFile)
-			return
-		}
-		if err = f2.Close(); err != nil {
-			return
-		}
-		if err = f1.Close(); err != nil {
-			return
-		}
-		if outFile == "" || inFile == outFile {
-			err = os.Rename(tmpFile, inFile)
-		}
-	}()
-
-	return AddBookmarks(f1, f2, bms, replace, conf)
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return AddBookmarks(rs, w, bms, replace, conf)
+	})
 }
 
 // RemoveBookmarks deletes outlines from rs and writes the result to w.
@@ -263,6 +212,7 @@ func RemoveBookmarks(rs io.ReadSeeker, w io.Writer, conf *model.Configuration) e
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
 	} else {
+		conf = conf.Clone()
 		conf.ValidationMode = model.ValidationRelaxed
 	}
 	conf.Cmd = model.REMOVEBOOKMARKS
@@ -285,38 +235,114 @@ func RemoveBookmarks(rs io.ReadSeeker, w io.Writer, conf *model.Configuration) e
 
 // RemoveBookmarksFile deletes outlines from inFile and writes the result to outFile.
 func RemoveBookmarksFile(inFile, outFile string, conf *model.Configuration) (err error) {
-	var f1, f2 *os.File
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return RemoveBookmarks(rs, w, conf)
+	})
+}
 
-	if f1, err = os.Open(inFile); err != nil {
-		return err
+func editBookmarks(rs io.ReadSeeker, w io.Writer, conf *model.Configuration, cmd model.CommandMode, f func(ctx *model.Context) error) error {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
+		conf.ValidationMode = model.ValidationRelaxed
 	}
+	conf.Cmd = cmd
 
-	tmpFile := inFile + ".tmp"
-	if outFile != "" && inFile != outFile {
-		tmpFile = outFile
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return err
 	}
-	if f2, err = os.Create(tmpFile); err != nil {
-		f1.Close()
+
+	if err := f(ctx); err != nil {
 		return err
 	}
 
-	defer func() {
-		if err != nil {
-			f2.Close()
-			f1.Close()
-			os.Remove(tmpFile)
-			return
-		}
-		if err = f2.Close(); err != nil {
-			return
+	return WriteContext(ctx, w)
+}
+
+// SortBookmarks sorts sibling bookmarks of rs alphabetically by title or by destination page and writes the result to w.
+func SortBookmarks(rs io.ReadSeeker, w io.Writer, byPage bool, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: SortBookmarks: missing rs")
+	}
+
+	return editBookmarks(rs, w, conf, model.SORTBOOKMARKS, func(ctx *model.Context) error {
+		return pdfcpu.SortBookmarks(ctx, byPage)
+	})
+}
+
+// SortBookmarksFile sorts sibling bookmarks of inFile alphabetically by title or by destination page and writes the result to outFile.
+func SortBookmarksFile(inFile, outFile string, byPage bool, conf *model.Configuration) (err error) {
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return SortBookmarks(rs, w, byPage, conf)
+	})
+}
+
+// DedupBookmarks removes bookmarks of rs duplicating title and destination page of a preceding sibling and writes the result to w.
+func DedupBookmarks(rs io.ReadSeeker, w io.Writer, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: DedupBookmarks: missing rs")
+	}
+
+	return editBookmarks(rs, w, conf, model.DEDUPBOOKMARKS, func(ctx *model.Context) error {
+		n, err := pdfcpu.DedupBookmarks(ctx)
+		if err == nil && log.CLIEnabled() {
+			log.CLI.Printf("%d duplicate bookmark(s) removed\n", n)
 		}
-		if err = f1.Close(); err != nil {
-			return
+		return err
+	})
+}
+
+// DedupBookmarksFile removes bookmarks of inFile duplicating title and destination page of a preceding sibling and writes the result to outFile.
+func DedupBookmarksFile(inFile, outFile string, conf *model.Configuration) (err error) {
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return DedupBookmarks(rs, w, conf)
+	})
+}
+
+// CollapseBookmarks moves bookmarks of rs nested deeper than maxLevel up to level maxLevel and writes the result to w.
+func CollapseBookmarks(rs io.ReadSeeker, w io.Writer, maxLevel int, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: CollapseBookmarks: missing rs")
+	}
+
+	return editBookmarks(rs, w, conf, model.COLLAPSEBOOKMARKS, func(ctx *model.Context) error {
+		n, err := pdfcpu.CollapseBookmarks(ctx, maxLevel)
+		if err == nil && log.CLIEnabled() {
+			log.CLI.Printf("%d bookmark(s) moved up to level %d\n", n, maxLevel)
 		}
-		if outFile == "" || inFile == outFile {
-			err = os.Rename(tmpFile, inFile)
+		return err
+	})
+}
+
+// CollapseBookmarksFile moves bookmarks of inFile nested deeper than maxLevel up to level maxLevel and writes the result to outFile.
+func CollapseBookmarksFile(inFile, outFile string, maxLevel int, conf *model.Configuration) (err error) {
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return CollapseBookmarks(rs, w, maxLevel, conf)
+	})
+}
+
+// RetargetBookmarks points bookmarks of rs whose destination page has been removed to the next remaining page
+// and writes the result to w.
+func RetargetBookmarks(rs io.ReadSeeker, w io.Writer, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: RetargetBookmarks: missing rs")
+	}
+
+	return editBookmarks(rs, w, conf, model.RETARGETBOOKMARKS, func(ctx *model.Context) error {
+		n, err := pdfcpu.RetargetBookmarks(ctx)
+		if err == nil && log.CLIEnabled() {
+			log.CLI.Printf("%d bookmark(s) retargeted\n", n)
 		}
-	}()
+		return err
+	})
+}
 
-	return RemoveBookmarks(f1, f2, conf)
+// RetargetBookmarksFile points bookmarks of inFile whose destination page has been removed to the next remaining page
+// and writes the result to outFile.
+func RetargetBookmarksFile(inFile, outFile string, conf *model.Configuration) (err error) {
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return RetargetBookmarks(rs, w, conf)
+	})
 }
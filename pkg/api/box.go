@@ -18,7 +18,6 @@ package api
 
 import (
 	"io"
-	"os"
 
 	"github.com/pdfcpu/pdfcpu/pkg/log"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
@@ -49,6 +48,8 @@ func Boxes(rs io.ReadSeeker, selectedPages []string, conf *model.Configuration)
 
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
 	}
 	conf.Cmd = model.LISTBOXES
 
@@ -57,7 +58,7 @@ func Boxes(rs io.ReadSeeker, selectedPages []string, conf *model.Configuration)
 		return nil, err
 	}
 
-	pages, err := PagesForPageSelection(ctx.PageCount, selectedPages, true, true)
+	pages, err := PagesForPageSelectionWithContext(ctx, selectedPages, true, true)
 	if err != nil {
 		return nil, err
 	}
@@ -73,6 +74,8 @@ func AddBoxes(rs io.ReadSeeker, w io.Writer, selectedPages []string, pb *model.P
 
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
 	}
 	conf.Cmd = model.ADDBOXES
 
@@ -81,7 +84,7 @@ func AddBoxes(rs io.ReadSeeker, w io.Writer, selectedPages []string, pb *model.P
 		return err
 	}
 
-	pages, err := PagesForPageSelection(ctx.PageCount, selectedPages, true, true)
+	pages, err := PagesForPageSelectionWithContext(ctx, selectedPages, true, true)
 	if err != nil {
 		return err
 	}
@@ -95,47 +98,19 @@ func AddBoxes(rs io.ReadSeeker, w io.Writer, selectedPages []string, pb *model.P
 
 // AddBoxesFile adds page boundaries for selected pages of inFile and writes result to outFile.
 func AddBoxesFile(inFile, outFile string, selectedPages []string, pb *model.PageBoundaries, conf *model.Configuration) (err error) {
-	var f1, f2 *os.File
 	if log.CLIEnabled() {
 		log.CLI.Printf("adding %s for %s\n", pb, inFile)
 	}
 
-	if f1, err = os.Open(inFile); err != nil {
-		return err
-	}
-
-	tmpFile := inFile + ".tmp"
 	if outFile != "" && inFile != outFile {
-		tmpFile = outFile
 		logWritingTo(outFile)
 	} else {
 		logWritingTo(inFile)
 	}
 
-	if f2, err = os.Create(tmpFile); err != nil {
-		f1.Close()
-		return err
-	}
-
-	defer func() {
-		if err != nil {
-			f2.Close()
-			f1.Close()
-			os.Remove(tmpFile)
-			return
-		}
-		if err = f2.Close(); err != nil {
-			return
-		}
-		if err = f1.Close(); err != nil {
-			return
-		}
-		if outFile == "" || inFile == outFile {
-			err = os.Rename(tmpFile, inFile)
-		}
-	}()
-
-	return AddBoxes(f1, f2, selectedPages, pb, conf)
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return AddBoxes(rs, w, selectedPages, pb, conf)
+	})
 }
 
 // RemoveBoxes removes page boundaries as specified in pb for selected pages of rs and writes result to w.
@@ -146,6 +121,8 @@ func RemoveBoxes(rs io.ReadSeeker, w io.Writer, selectedPages []string, pb *mode
 
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
 	}
 	conf.Cmd = model.REMOVEBOXES
 
@@ -154,7 +131,7 @@ func RemoveBoxes(rs io.ReadSeeker, w io.Writer, selectedPages []string, pb *mode
 		return err
 	}
 
-	pages, err := PagesForPageSelection(ctx.PageCount, selectedPages, true, true)
+	pages, err := PagesForPageSelectionWithContext(ctx, selectedPages, true, true)
 	if err != nil {
 		return err
 	}
@@ -168,48 +145,19 @@ func RemoveBoxes(rs io.ReadSeeker, w io.Writer, selectedPages []string, pb *mode
 
 // RemoveBoxesFile removes page boundaries as specified in pb for selected pages of inFile and writes result to outFile.
 func RemoveBoxesFile(inFile, outFile string, selectedPages []string, pb *model.PageBoundaries, conf *model.Configuration) (err error) {
-	var f1, f2 *os.File
-
 	if log.CLIEnabled() {
 		log.CLI.Printf("removing %s for %s\n", pb, inFile)
 	}
 
-	if f1, err = os.Open(inFile); err != nil {
-		return err
-	}
-
-	tmpFile := inFile + ".tmp"
 	if outFile != "" && inFile != outFile {
-		tmpFile = outFile
 		logWritingTo(outFile)
 	} else {
 		logWritingTo(inFile)
 	}
 
-	if f2, err = os.Create(tmpFile); err != nil {
-		f1.Close()
-		return err
-	}
-
-	defer func() {
-		if err != nil {
-			f2.Close()
-			f1.Close()
-			os.Remove(tmpFile)
-			return
-		}
-		if err = f2.Close(); err != nil {
-			return
-		}
-		if err = f1.Close(); err != nil {
-			return
-		}
-		if outFile == "" || inFile == outFile {
-			err = os.Rename(tmpFile, inFile)
-		}
-	}()
-
-	return RemoveBoxes(f1, f2, selectedPages, pb, conf)
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return RemoveBoxes(rs, w, selectedPages, pb, conf)
+	})
 }
 
 // Crop adds crop boxes for selected pages of rs and writes result to w.
@@ -220,6 +168,8 @@ func Crop(rs io.ReadSeeker, w io.Writer, selectedPages []string, b *model.Box, c
 
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
 	}
 	conf.Cmd = model.CROP
 
@@ -228,7 +178,7 @@ func Crop(rs io.ReadSeeker, w io.Writer, selectedPages []string, b *model.Box, c
 		return err
 	}
 
-	pages, err := PagesForPageSelection(ctx.PageCount, selectedPages, true, true)
+	pages, err := PagesForPageSelectionWithContext(ctx, selectedPages, true, true)
 	if err != nil {
 		return err
 	}
@@ -242,51 +192,24 @@ func Crop(rs io.ReadSeeker, w io.Writer, selectedPages []string, b *model.Box, c
 
 // CropFile adds crop boxes for selected pages of inFile and writes result to outFile.
 func CropFile(inFile, outFile string, selectedPages []string, b *model.Box, conf *model.Configuration) (err error) {
-	var f1, f2 *os.File
-
 	if log.CLIEnabled() {
 		log.CLI.Printf("cropping %s\n", inFile)
 	}
 
-	if f1, err = os.Open(inFile); err != nil {
-		return err
-	}
-
-	tmpFile := inFile + ".tmp"
 	if outFile != "" && inFile != outFile {
-		tmpFile = outFile
 		logWritingTo(outFile)
 	} else {
 		logWritingTo(inFile)
 	}
 
-	if f2, err = os.Create(tmpFile); err != nil {
-		f1.Close()
-		return err
-	}
-
-	defer func() {
-		if err != nil {
-			f2.Close()
-			f1.Close()
-			os.Remove(tmpFile)
-			return
-		}
-		if err = f2.Close(); err != nil {
-			return
-		}
-		if err = f1.Close(); err != nil {
-			return
-		}
-		if outFile == "" || inFile == outFile {
-			err = os.Rename(tmpFile, inFile)
-		}
-	}()
-
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
 	}
 	conf.Cmd = model.CROP
 
-	return Crop(f1, f2, selectedPages, b, conf)
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return Crop(rs, w, selectedPages, b, conf)
+	})
 }
@@ -18,7 +18,6 @@ package api
 
 import (
 	"io"
-	"os"
 
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
@@ -33,6 +32,8 @@ func Collect(rs io.ReadSeeker, w io.Writer, selectedPages []string, conf *model.
 
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
 	}
 	conf.Cmd = model.COLLECT
 
@@ -41,12 +42,16 @@ func Collect(rs io.ReadSeeker, w io.Writer, selectedPages []string, conf *model.
 		return err
 	}
 
+	if selectedPages, err = ResolvePageSelection(ctx, selectedPages); err != nil {
+		return err
+	}
+
 	pages, err := PagesForPageCollection(ctx.PageCount, selectedPages)
 	if err != nil {
 		return err
 	}
 
-	ctxDest, err := pdfcpu.ExtractPages(ctx, pages, false)
+	ctxDest, err := pdfcpu.CollectPages(ctx, pages)
 	if err != nil {
 		return err
 	}
@@ -56,42 +61,13 @@ func Collect(rs io.ReadSeeker, w io.Writer, selectedPages []string, conf *model.
 
 // CollectFile creates a custom PDF page sequence for inFile and writes the result to outFile.
 func CollectFile(inFile, outFile string, selectedPages []string, conf *model.Configuration) (err error) {
-	tmpFile := inFile + ".tmp"
 	if outFile != "" && inFile != outFile {
-		tmpFile = outFile
 		logWritingTo(outFile)
 	} else {
 		logWritingTo(inFile)
 	}
 
-	var f1, f2 *os.File
-
-	if f1, err = os.Open(inFile); err != nil {
-		return err
-	}
-
-	if f2, err = os.Create(tmpFile); err != nil {
-		f1.Close()
-		return err
-	}
-
-	defer func() {
-		if err != nil {
-			f2.Close()
-			f1.Close()
-			os.Remove(tmpFile)
-			return
-		}
-		if err = f2.Close(); err != nil {
-			return
-		}
-		if err = f1.Close(); err != nil {
-			return
-		}
-		if outFile == "" || inFile == outFile {
-			err = os.Rename(tmpFile, inFile)
-		}
-	}()
-
-	return Collect(f1, f2, selectedPages, conf)
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return Collect(rs, w, selectedPages, conf)
+	})
 }
@@ -0,0 +1,132 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+func commentSummary(rs io.ReadSeeker, selectedPages []string, conf *model.Configuration) (*model.Context, []*pdfcpu.Comment, error) {
+	if rs == nil {
+		return nil, nil, errors.New("pdfcpu: CommentSummary: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
+	}
+	conf.Cmd = model.EXPORTANNOTATIONS
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pages, err := PagesForPageSelectionWithContext(ctx, selectedPages, true, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cc, err := pdfcpu.CommentSummary(ctx, pages)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return ctx, cc, nil
+}
+
+// CommentSummary returns the markup annotations of selected pages of rs with replies nested via /IRT.
+func CommentSummary(rs io.ReadSeeker, selectedPages []string, conf *model.Configuration) ([]*pdfcpu.Comment, error) {
+	_, cc, err := commentSummary(rs, selectedPages, conf)
+	return cc, err
+}
+
+// CommentSummaryFormat returns the comment summary format for outFile's extension, defaulting to JSON.
+func CommentSummaryFormat(outFile string) string {
+	switch strings.ToLower(filepath.Ext(outFile)) {
+	case ".csv":
+		return pdfcpu.CommentSummaryCSV
+	case ".htm", ".html":
+		return pdfcpu.CommentSummaryHTML
+	}
+	return pdfcpu.CommentSummaryJSON
+}
+
+// ExportComments writes a summary of the markup annotations of selected pages of rs to w in format json, csv or html.
+func ExportComments(rs io.ReadSeeker, w io.Writer, source string, selectedPages []string, format string, conf *model.Configuration) error {
+	if w == nil {
+		return errors.New("pdfcpu: ExportComments: missing w")
+	}
+
+	ctx, cc, err := commentSummary(rs, selectedPages, conf)
+	if err != nil {
+		return err
+	}
+
+	return pdfcpu.WriteCommentSummary(w, pdfcpu.CommentSummaryHeader(ctx, source), cc, format)
+}
+
+// ExportCommentsFile writes a summary of the markup annotations of selected pages of inFile to outFile.
+// An empty format is derived from outFile's extension. If outFile is empty the summary goes to stdout.
+func ExportCommentsFile(inFile, outFile string, selectedPages []string, format string, conf *model.Configuration) (err error) {
+	if format == "" {
+		format = CommentSummaryFormat(outFile)
+	}
+
+	rs, closeIn, err := openInFile(inFile)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err1 := closeIn(); err == nil {
+			err = err1
+		}
+	}()
+
+	source := filepath.Base(inFile)
+	if inFile == StdIO {
+		source = ""
+	}
+
+	if outFile == "" || outFile == StdIO {
+		return writeStdout(func(w io.Writer) error {
+			return ExportComments(rs, w, source, selectedPages, format, conf)
+		})
+	}
+
+	f, err := os.Create(outFile)
+	if err != nil {
+		return err
+	}
+	logWritingTo(outFile)
+
+	if err = ExportComments(rs, f, source, selectedPages, format, conf); err != nil {
+		f.Close()
+		os.Remove(outFile)
+		return err
+	}
+
+	return f.Close()
+}
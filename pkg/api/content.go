@@ -0,0 +1,195 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+	"os"
+	"sort"
+
+	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+// NormalizeContent decodes and pretty-prints the content streams of selected pages of rs
+// using one operator per line and writes the result to w.
+// The resulting content streams are flate encoded if compress is true.
+func NormalizeContent(rs io.ReadSeeker, w io.Writer, selectedPages []string, compress bool, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: NormalizeContent: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
+	}
+	conf.Cmd = model.NORMALIZECONTENT
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	pages, err := PagesForPageSelectionWithContext(ctx, selectedPages, true, true)
+	if err != nil {
+		return err
+	}
+
+	pageNrs := []int{}
+	for i, v := range pages {
+		if v {
+			pageNrs = append(pageNrs, i)
+		}
+	}
+	sort.Ints(pageNrs)
+
+	if err := pdfcpu.NormalizeContent(ctx, pageNrs, compress); err != nil {
+		return err
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// NormalizeContentFile decodes and pretty-prints the content streams of selected pages of inFile
+// using one operator per line and writes the result to outFile.
+// The resulting content streams are flate encoded if compress is true.
+func NormalizeContentFile(inFile, outFile string, selectedPages []string, compress bool, conf *model.Configuration) error {
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return NormalizeContent(rs, w, selectedPages, compress, conf)
+	})
+}
+
+// ExtractPageContent writes the pretty-printed content of page pageNr of rs to w.
+func ExtractPageContent(rs io.ReadSeeker, w io.Writer, pageNr int, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: ExtractPageContent: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
+	}
+	conf.Cmd = model.EXTRACTPAGECONTENT
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	if pageNr < 1 || pageNr > ctx.PageCount {
+		return errors.Errorf("pdfcpu: invalid page number: %d", pageNr)
+	}
+
+	r, err := pdfcpu.ExtractPageContent(ctx, pageNr)
+	if err != nil {
+		return err
+	}
+	if r == nil {
+		return nil
+	}
+
+	bb, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(pdfcpu.FormatContent(bb))
+	return err
+}
+
+// ExtractPageContentFile writes the pretty-printed content of page pageNr of inFile to contentFile.
+func ExtractPageContentFile(inFile, contentFile string, pageNr int, conf *model.Configuration) (err error) {
+	f1, err := os.Open(inFile)
+	if err != nil {
+		return err
+	}
+	defer f1.Close()
+
+	logWritingTo(contentFile)
+	f2, err := os.Create(contentFile)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := f2.Close(); err == nil {
+			err = cerr
+		}
+	}()
+
+	return ExtractPageContent(f1, f2, pageNr, conf)
+}
+
+// ReplacePageContent replaces the content of page pageNr of rs by the content stream read from r
+// and writes the result to w. The new content stream is flate encoded if compress is true.
+func ReplacePageContent(rs io.ReadSeeker, r io.Reader, w io.Writer, pageNr int, compress bool, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: ReplacePageContent: missing rs")
+	}
+
+	if r == nil {
+		return errors.New("pdfcpu: ReplacePageContent: missing r")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
+	}
+	conf.Cmd = model.REPLACECONTENT
+
+	bb, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	if pageNr < 1 || pageNr > ctx.PageCount {
+		return errors.Errorf("pdfcpu: invalid page number: %d", pageNr)
+	}
+
+	if err := pdfcpu.ReplacePageContent(ctx, pageNr, bb, compress); err != nil {
+		return err
+	}
+
+	if log.CLIEnabled() {
+		log.CLI.Printf("replaced content of page %d\n", pageNr)
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// ReplacePageContentFile replaces the content of page pageNr of inFile by the content stream in contentFile
+// and writes the result to outFile. The new content stream is flate encoded if compress is true.
+func ReplacePageContentFile(inFile, contentFile, outFile string, pageNr int, compress bool, conf *model.Configuration) error {
+	f, err := os.Open(contentFile)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return ReplacePageContent(rs, f, w, pageNr, compress, conf)
+	})
+}
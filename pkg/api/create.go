@@ -19,6 +19,7 @@ package api
 import (
 	"io"
 	"os"
+	"strings"
 
 	"github.com/pdfcpu/pdfcpu/pkg/log"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
@@ -43,12 +44,21 @@ func CreatePDFFile(xRefTable *model.XRefTable, outFile string, conf *model.Confi
 // If rs is present, new PDF content will be appended including any empty pages needed.
 // rd is a JSON representation of PDF page content which may include form data.
 func Create(rs io.ReadSeeker, rd io.Reader, w io.Writer, conf *model.Configuration) error {
+	return CreateWithData(rs, rd, nil, w, conf)
+}
+
+// CreateWithData renders the PDF structure represented by rs into w.
+// If rs is present, new PDF content will be appended including any empty pages needed.
+// rd is a JSON template of PDF page content whose {{placeholders}} get bound to data.
+func CreateWithData(rs io.ReadSeeker, rd io.Reader, data map[string]interface{}, w io.Writer, conf *model.Configuration) error {
 	if rd == nil {
 		return errors.New("pdfcpu: Create: missing rd")
 	}
 
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
 	}
 	conf.Cmd = model.CREATE
 
@@ -66,7 +76,7 @@ func Create(rs io.ReadSeeker, rd io.Reader, w io.Writer, conf *model.Configurati
 		return err
 	}
 
-	if err := create.FromJSON(ctx, rd); err != nil {
+	if err := create.FromJSONWithData(ctx, rd, data); err != nil {
 		return err
 	}
 
@@ -79,9 +89,8 @@ func Create(rs io.ReadSeeker, rd io.Reader, w io.Writer, conf *model.Configurati
 	return WriteContext(ctx, w)
 }
 
-func handleOutFilePDF(inFilePDF, outFilePDF string, tmpFile *string) {
+func handleOutFilePDF(inFilePDF, outFilePDF string) {
 	if outFilePDF != "" && inFilePDF != outFilePDF {
-		*tmpFile = outFilePDF
 		logWritingTo(outFilePDF)
 	} else {
 		logWritingTo(inFilePDF)
@@ -92,54 +101,74 @@ func handleOutFilePDF(inFilePDF, outFilePDF string, tmpFile *string) {
 // If inFilePDF is present, new PDF content will be appended including any empty pages needed.
 // inFileJSON represents PDF page content which may include form data.
 func CreateFile(inFilePDF, inFileJSON, outFilePDF string, conf *model.Configuration) (err error) {
-	var f0, f1, f2 *os.File
+	return createFile(inFilePDF, inFileJSON, outFilePDF, nil, conf)
+}
+
+func createFile(inFilePDF, inFileJSON, outFilePDF string, data map[string]interface{}, conf *model.Configuration) (err error) {
+	var f0, f2 *os.File
 
 	if f0, err = os.Open(inFileJSON); err != nil {
 		return err
 	}
+	defer f0.Close()
+
+	handleOutFilePDF(inFilePDF, outFilePDF)
 
-	rs := io.ReadSeeker(nil)
-	f1 = nil
 	if fileExists(inFilePDF) {
-		if f1, err = os.Open(inFilePDF); err != nil {
-			return err
-		}
 		log.CLI.Printf("reading %s...\n", inFilePDF)
-		rs = f1
+		return updateFile(inFilePDF, outFilePDF, conf, func(rs io.ReadSeeker, w io.Writer) error {
+			return CreateWithData(rs, f0, data, w, conf)
+		})
 	}
 
-	tmpFile := inFilePDF + ".tmp"
-	handleOutFilePDF(inFilePDF, outFilePDF, &tmpFile)
+	// Nothing to append to, write straight to the result file.
+	outFile := inFilePDF
+	if outFilePDF != "" {
+		outFile = outFilePDF
+	}
 
-	if f2, err = os.Create(tmpFile); err != nil {
+	if f2, err = os.Create(outFile); err != nil {
 		return err
 	}
 
 	defer func() {
 		if err != nil {
 			f2.Close()
-			if f1 != nil {
-				f1.Close()
-			}
-			f0.Close()
-			os.Remove(tmpFile)
-			return
-		}
-		if err = f2.Close(); err != nil {
-			return
-		}
-		if f1 != nil {
-			if err = f1.Close(); err != nil {
-				return
-			}
-		}
-		if err = f0.Close(); err != nil {
+			os.Remove(outFile)
 			return
 		}
-		if outFilePDF == "" || inFilePDF == outFilePDF {
-			err = os.Rename(tmpFile, inFilePDF)
-		}
+		err = f2.Close()
 	}()
 
-	return Create(rs, f0, f2, conf)
+	return CreateWithData(nil, f0, data, f2, conf)
+}
+
+// CreateFilesWithData renders the JSON template inFileJSON once for each record of inFileData.
+// inFileData is either a JSON file containing an object or an array of objects or a CSV file with a header row.
+// If inFilePDF is present, new PDF content will be appended including any empty pages needed.
+// For more than one record the results are written to outFilePDF_01.pdf, outFilePDF_02.pdf etc.
+func CreateFilesWithData(inFilePDF, inFileJSON, inFileData, outFilePDF string, conf *model.Configuration) error {
+	f, err := os.Open(inFileData)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	recs, err := create.ReadData(f, strings.HasSuffix(strings.ToLower(inFileData), ".csv"))
+	if err != nil {
+		return err
+	}
+
+	outFile := inFilePDF
+	if outFilePDF != "" {
+		outFile = outFilePDF
+	}
+
+	for i, data := range recs {
+		if err := createFile(inFilePDF, inFileJSON, create.DataFileName(outFile, i, len(recs)), data, conf); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
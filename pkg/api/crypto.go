@@ -20,10 +20,39 @@ import (
 	"io"
 	"os"
 
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
 	"github.com/pkg/errors"
 )
 
+// EncryptionInfo returns details about the encryption of rs w/o modifying it.
+// Supplied passwords are checked against the security handler but not required.
+func EncryptionInfo(rs io.ReadSeeker, conf *model.Configuration) (*pdfcpu.EncryptionInfo, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: EncryptionInfo: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
+	}
+	conf.Cmd = model.LISTENCRYPTION
+
+	return pdfcpu.ReadEncryptionInfo(rs, conf)
+}
+
+// EncryptionInfoFile returns details about the encryption of inFile w/o modifying it.
+func EncryptionInfoFile(inFile string, conf *model.Configuration) (*pdfcpu.EncryptionInfo, error) {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return EncryptionInfo(f, conf)
+}
+
 // Encrypt reads a PDF stream from rs and writes the encrypted PDF stream to w.
 // A configuration containing at least the current passwords is required.
 func Encrypt(rs io.ReadSeeker, w io.Writer, conf *model.Configuration) error {
@@ -34,6 +63,7 @@ func Encrypt(rs io.ReadSeeker, w io.Writer, conf *model.Configuration) error {
 	if conf == nil {
 		return errors.New("pdfcpu: missing configuration for encryption")
 	}
+	conf = conf.Clone()
 	conf.Cmd = model.ENCRYPT
 
 	return Optimize(rs, w, conf)
@@ -45,46 +75,56 @@ func EncryptFile(inFile, outFile string, conf *model.Configuration) (err error)
 	if conf == nil {
 		return errors.New("pdfcpu: missing configuration for encryption")
 	}
+	conf = conf.Clone()
 	conf.Cmd = model.ENCRYPT
 
-	var f1, f2 *os.File
+	if outFile != "" && inFile != outFile {
+		logWritingTo(outFile)
+	} else {
+		logWritingTo(inFile)
+	}
+
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return Encrypt(rs, w, conf)
+	})
+}
+
+// UpdateEncryption reads an encrypted PDF stream from rs and writes it to w re-encrypted in one pass
+// using the algorithm and key length of conf while keeping the current passwords.
+// The current permissions are retained unless conf.UpdatePermissions is set.
+// A configuration containing both current passwords is required.
+func UpdateEncryption(rs io.ReadSeeker, w io.Writer, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: UpdateEncryption: missing rs")
+	}
 
-	if f1, err = os.Open(inFile); err != nil {
-		return err
+	if conf == nil {
+		return errors.New("pdfcpu: missing configuration for encryption update")
 	}
+	conf = conf.Clone()
+	conf.Cmd = model.UPDATEENCRYPTION
+
+	return Optimize(rs, w, conf)
+}
+
+// UpdateEncryptionFile re-encrypts inFile and writes the result to outFile.
+// A configuration containing both current passwords is required.
+func UpdateEncryptionFile(inFile, outFile string, conf *model.Configuration) (err error) {
+	if conf == nil {
+		return errors.New("pdfcpu: missing configuration for encryption update")
+	}
+	conf = conf.Clone()
+	conf.Cmd = model.UPDATEENCRYPTION
 
-	tmpFile := inFile + ".tmp"
 	if outFile != "" && inFile != outFile {
-		tmpFile = outFile
 		logWritingTo(outFile)
 	} else {
 		logWritingTo(inFile)
 	}
 
-	if f2, err = os.Create(tmpFile); err != nil {
-		f1.Close()
-		return err
-	}
-
-	defer func() {
-		if err != nil {
-			f2.Close()
-			f1.Close()
-			os.Remove(tmpFile)
-			return
-		}
-		if err = f2.Close(); err != nil {
-			return
-		}
-		if err = f1.Close(); err != nil {
-			return
-		}
-		if outFile == "" || inFile == outFile {
-			err = os.Rename(tmpFile, inFile)
-		}
-	}()
-
-	return Encrypt(f1, f2, conf)
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return UpdateEncryption(rs, w, conf)
+	})
 }
 
 // Decrypt reads a PDF stream from rs and writes the encrypted PDF stream to w.
@@ -97,6 +137,7 @@ func Decrypt(rs io.ReadSeeker, w io.Writer, conf *model.Configuration) error {
 	if conf == nil {
 		return errors.New("pdfcpu: missing configuration for decryption")
 	}
+	conf = conf.Clone()
 	conf.Cmd = model.DECRYPT
 
 	return Optimize(rs, w, conf)
@@ -108,46 +149,18 @@ func DecryptFile(inFile, outFile string, conf *model.Configuration) (err error)
 	if conf == nil {
 		return errors.New("pdfcpu: missing configuration for decryption")
 	}
+	conf = conf.Clone()
 	conf.Cmd = model.DECRYPT
 
-	var f1, f2 *os.File
-
-	if f1, err = os.Open(inFile); err != nil {
-		return err
-	}
-
-	tmpFile := inFile + ".tmp"
 	if outFile != "" && inFile != outFile {
-		tmpFile = outFile
 		logWritingTo(outFile)
 	} else {
 		logWritingTo(inFile)
 	}
 
-	if f2, err = os.Create(tmpFile); err != nil {
-		f1.Close()
-		return err
-	}
-
-	defer func() {
-		if err != nil {
-			f2.Close()
-			f1.Close()
-			os.Remove(tmpFile)
-			return
-		}
-		if err = f2.Close(); err != nil {
-			return
-		}
-		if err = f1.Close(); err != nil {
-			return
-		}
-		if outFile == "" || inFile == outFile {
-			err = os.Rename(tmpFile, inFile)
-		}
-	}()
-
-	return Decrypt(f1, f2, conf)
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return Decrypt(rs, w, conf)
+	})
 }
 
 // ChangeUserPassword reads a PDF stream from rs, changes the user password and writes the encrypted PDF stream to w.
@@ -160,6 +173,7 @@ func ChangeUserPassword(rs io.ReadSeeker, w io.Writer, pwOld, pwNew string, conf
 	if conf == nil {
 		return errors.New("pdfcpu: missing configuration for change user password")
 	}
+	conf = conf.Clone()
 
 	conf.Cmd = model.CHANGEUPW
 	conf.UserPW = pwOld
@@ -174,49 +188,21 @@ func ChangeUserPasswordFile(inFile, outFile string, pwOld, pwNew string, conf *m
 	if conf == nil {
 		return errors.New("pdfcpu: missing configuration for change user password")
 	}
+	conf = conf.Clone()
 
 	conf.Cmd = model.CHANGEUPW
 	conf.UserPW = pwOld
 	conf.UserPWNew = &pwNew
 
-	var f1, f2 *os.File
-
-	if f1, err = os.Open(inFile); err != nil {
-		return err
-	}
-
-	tmpFile := inFile + ".tmp"
 	if outFile != "" && inFile != outFile {
-		tmpFile = outFile
 		logWritingTo(outFile)
 	} else {
 		logWritingTo(inFile)
 	}
 
-	if f2, err = os.Create(tmpFile); err != nil {
-		f1.Close()
-		return err
-	}
-
-	defer func() {
-		if err != nil {
-			f2.Close()
-			f1.Close()
-			os.Remove(tmpFile)
-			return
-		}
-		if err = f2.Close(); err != nil {
-			return
-		}
-		if err = f1.Close(); err != nil {
-			return
-		}
-		if outFile == "" || inFile == outFile {
-			err = os.Rename(tmpFile, inFile)
-		}
-	}()
-
-	return ChangeUserPassword(f1, f2, pwOld, pwNew, conf)
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return ChangeUserPassword(rs, w, pwOld, pwNew, conf)
+	})
 }
 
 // ChangeOwnerPassword reads a PDF stream from rs, changes the owner password and writes the encrypted PDF stream to w.
@@ -229,6 +215,7 @@ func ChangeOwnerPassword(rs io.ReadSeeker, w io.Writer, pwOld, pwNew string, con
 	if conf == nil {
 		return errors.New("pdfcpu: missing configuration for change owner password")
 	}
+	conf = conf.Clone()
 
 	conf.Cmd = model.CHANGEOPW
 	conf.OwnerPW = pwOld
@@ -243,45 +230,18 @@ func ChangeOwnerPasswordFile(inFile, outFile string, pwOld, pwNew string, conf *
 	if conf == nil {
 		return errors.New("pdfcpu: missing configuration for change owner password")
 	}
+	conf = conf.Clone()
 	conf.Cmd = model.CHANGEOPW
 	conf.OwnerPW = pwOld
 	conf.OwnerPWNew = &pwNew
 
-	var f1, f2 *os.File
-
-	if f1, err = os.Open(inFile); err != nil {
-		return err
-	}
-
-	tmpFile := inFile + ".tmp"
 	if outFile != "" && inFile != outFile {
-		tmpFile = outFile
 		logWritingTo(outFile)
 	} else {
 		logWritingTo(inFile)
 	}
 
-	if f2, err = os.Create(tmpFile); err != nil {
-		return err
-	}
-
-	defer func() {
-		if err != nil {
-			f2.Close()
-			f1.Close()
-			os.Remove(tmpFile)
-			return
-		}
-		if err = f2.Close(); err != nil {
-			return
-		}
-		if err = f1.Close(); err != nil {
-			return
-		}
-		if outFile == "" || inFile == outFile {
-			err = os.Rename(tmpFile, inFile)
-		}
-	}()
-
-	return ChangeOwnerPassword(f1, f2, pwOld, pwNew, conf)
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return ChangeOwnerPassword(rs, w, pwOld, pwNew, conf)
+	})
 }
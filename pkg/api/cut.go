@@ -37,7 +37,7 @@ func prepareForCut(rs io.ReadSeeker, selectedPages []string, conf *model.Configu
 		return nil, nil, err
 	}
 
-	pages, err := PagesForPageSelection(ctx.PageCount, selectedPages, true, true)
+	pages, err := PagesForPageSelectionWithContext(ctx, selectedPages, true, true)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -65,6 +65,8 @@ func Poster(rs io.ReadSeeker, outDir, fileName string, selectedPages []string, c
 
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
 	}
 	conf.Cmd = model.POSTER
 
@@ -129,6 +131,8 @@ func NDown(rs io.ReadSeeker, outDir, fileName string, selectedPages []string, n
 
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
 	}
 	conf.Cmd = model.NDOWN
 
@@ -235,6 +239,8 @@ func Cut(rs io.ReadSeeker, outDir, fileName string, selectedPages []string, cut
 
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
 	}
 	conf.Cmd = model.CUT
 
@@ -42,6 +42,8 @@ func ExtractImagesRaw(rs io.ReadSeeker, selectedPages []string, conf *model.Conf
 
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
 	}
 	conf.Cmd = model.EXTRACTIMAGES
 
@@ -50,7 +52,7 @@ func ExtractImagesRaw(rs io.ReadSeeker, selectedPages []string, conf *model.Conf
 		return nil, err
 	}
 
-	pages, err := PagesForPageSelection(ctx.PageCount, selectedPages, true, true)
+	pages, err := PagesForPageSelectionWithContext(ctx, selectedPages, true, true)
 	if err != nil {
 		return nil, err
 	}
@@ -78,6 +80,8 @@ func ExtractImages(rs io.ReadSeeker, selectedPages []string, digestImage func(mo
 
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
 	}
 	conf.Cmd = model.EXTRACTIMAGES
 
@@ -86,7 +90,7 @@ func ExtractImages(rs io.ReadSeeker, selectedPages []string, digestImage func(mo
 		return err
 	}
 
-	pages, err := PagesForPageSelection(ctx.PageCount, selectedPages, true, true)
+	pages, err := PagesForPageSelectionWithContext(ctx, selectedPages, true, true)
 	if err != nil {
 		return err
 	}
@@ -161,6 +165,8 @@ func ExtractFonts(rs io.ReadSeeker, outDir, fileName string, selectedPages []str
 
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
 	}
 	conf.Cmd = model.EXTRACTFONTS
 
@@ -169,7 +175,7 @@ func ExtractFonts(rs io.ReadSeeker, outDir, fileName string, selectedPages []str
 		return err
 	}
 
-	pages, err := PagesForPageSelection(ctx.PageCount, selectedPages, true, true)
+	pages, err := PagesForPageSelectionWithContext(ctx, selectedPages, true, true)
 	if err != nil {
 		return err
 	}
@@ -251,6 +257,8 @@ func ExtractPages(rs io.ReadSeeker, outDir, fileName string, selectedPages []str
 
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
 	}
 	conf.Cmd = model.EXTRACTPAGES
 
@@ -259,7 +267,7 @@ func ExtractPages(rs io.ReadSeeker, outDir, fileName string, selectedPages []str
 		return err
 	}
 
-	pages, err := PagesForPageSelection(ctx.PageCount, selectedPages, true, true)
+	pages, err := PagesForPageSelectionWithContext(ctx, selectedPages, true, true)
 	if err != nil {
 		return err
 	}
@@ -309,6 +317,8 @@ func ExtractContent(rs io.ReadSeeker, outDir, fileName string, selectedPages []s
 
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
 	}
 	conf.Cmd = model.EXTRACTCONTENT
 
@@ -317,7 +327,7 @@ func ExtractContent(rs io.ReadSeeker, outDir, fileName string, selectedPages []s
 		return err
 	}
 
-	pages, err := PagesForPageSelection(ctx.PageCount, selectedPages, true, true)
+	pages, err := PagesForPageSelectionWithContext(ctx, selectedPages, true, true)
 	if err != nil {
 		return err
 	}
@@ -379,6 +389,8 @@ func ExtractMetadata(rs io.ReadSeeker, outDir, fileName string, conf *model.Conf
 
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
 	}
 	conf.Cmd = model.EXTRACTMETADATA
 
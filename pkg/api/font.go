@@ -19,6 +19,8 @@ package api
 import (
 	"bytes"
 	"fmt"
+	"io"
+	"io/fs"
 
 	"path/filepath"
 	"sort"
@@ -88,6 +90,59 @@ func InstallFonts(fileNames []string) error {
 	return font.LoadUserFonts()
 }
 
+// InstallFont installs the TrueType font read from r for embedding.
+// The font gets registered under its PostScript name, name is used for error reporting only.
+func InstallFont(r io.Reader, name string) error {
+	fontDir, err := font.WritableUserFontDir()
+	if err != nil {
+		return err
+	}
+
+	bb, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	if err := font.InstallFontFromBytes(fontDir, name, bb); err != nil {
+		return err
+	}
+
+	return font.LoadUserFonts()
+}
+
+// UninstallFont removes the installed user font fontName.
+func UninstallFont(fontName string) error {
+	fontDir, err := font.WritableUserFontDir()
+	if err != nil {
+		return err
+	}
+
+	if !font.IsUserFont(fontName) {
+		return errors.Errorf("pdfcpu: unknown user font: %s", fontName)
+	}
+
+	return font.UninstallFont(fontDir, fontName)
+}
+
+// ListUserFonts returns a sorted list of installed user fonts.
+func ListUserFonts() []string {
+	ss := font.UserFontNames()
+	sort.Strings(ss)
+	return ss
+}
+
+// SetUserFontDir points the user font cache to dir instead of the pdfcpu config dir.
+func SetUserFontDir(dir string) error {
+	return font.SetUserFontDir(dir)
+}
+
+// SetUserFontFS serves user fonts from fsys, eg. fonts bundled using go:embed.
+// fsys may contain fonts previously installed to a dir (.gob) as well as TTF or OTF font files.
+// fsys is read only, so installing and uninstalling fonts is not supported.
+func SetUserFontFS(fsys fs.FS) error {
+	return font.SetUserFontFS(fsys)
+}
+
 func rowLabel(xRefTable *model.XRefTable, i int, td model.TextDescriptor, baseFontName, baseFontKey string, buf *bytes.Buffer, mb *types.Rectangle, left bool) {
 	x := 39.
 	if !left {
@@ -50,6 +50,8 @@ func FormFields(rs io.ReadSeeker, conf *model.Configuration) ([]form.Field, erro
 
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
 	}
 	conf.Cmd = model.LISTFORMFIELDS
 
@@ -63,6 +65,103 @@ func FormFields(rs io.ReadSeeker, conf *model.Configuration) ([]form.Field, erro
 	return fields, err
 }
 
+// FormFieldDetails returns the complete metadata of all form fields of rs.
+func FormFieldDetails(rs io.ReadSeeker, conf *model.Configuration) ([]form.FieldDetail, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: FormFieldDetails: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
+	}
+	conf.Cmd = model.LISTFORMFIELDS
+
+	ctx, err := ReadAndValidate(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return form.FormFieldDetails(ctx)
+}
+
+// FormFieldDetailsFile returns the complete metadata of all form fields of inFile.
+func FormFieldDetailsFile(inFile string, conf *model.Configuration) ([]form.FieldDetail, error) {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return FormFieldDetails(f, conf)
+}
+
+// FormFieldValues returns the effective values of all form fields of rs
+// resolving checkbox and radio button states including "Off" as well as export and display values.
+func FormFieldValues(rs io.ReadSeeker, conf *model.Configuration) ([]form.FieldValue, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: FormFieldValues: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
+	}
+	conf.Cmd = model.LISTFORMFIELDS
+
+	ctx, err := ReadAndValidate(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return form.FormFieldValues(ctx)
+}
+
+// FormFieldValuesFile returns the effective values of all form fields of inFile.
+func FormFieldValuesFile(inFile string, conf *model.Configuration) ([]form.FieldValue, error) {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return FormFieldValues(f, conf)
+}
+
+// FormFieldValue returns the effective value of the form field of rs identified by id or fully qualified name.
+func FormFieldValue(rs io.ReadSeeker, idOrName string, conf *model.Configuration) (*form.FieldValue, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: FormFieldValue: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
+	}
+	conf.Cmd = model.LISTFORMFIELDS
+
+	ctx, err := ReadAndValidate(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return form.FormFieldValue(ctx, idOrName)
+}
+
+// FormFieldValueFile returns the effective value of the form field of inFile identified by id or fully qualified name.
+func FormFieldValueFile(inFile, idOrName string, conf *model.Configuration) (*form.FieldValue, error) {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return FormFieldValue(f, idOrName, conf)
+}
+
 // RemoveFormFields deletes form fields in rs and writes the result to w.
 func RemoveFormFields(rs io.ReadSeeker, w io.Writer, fieldIDsOrNames []string, conf *model.Configuration) error {
 	if rs == nil {
@@ -71,6 +170,8 @@ func RemoveFormFields(rs io.ReadSeeker, w io.Writer, fieldIDsOrNames []string, c
 
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
 	}
 	conf.Cmd = model.REMOVEFORMFIELDS
 
@@ -92,42 +193,11 @@ func RemoveFormFields(rs io.ReadSeeker, w io.Writer, fieldIDsOrNames []string, c
 
 // RemoveFormFieldsFile deletes form fields in inFile and writes the result to outFile.
 func RemoveFormFieldsFile(inFile, outFile string, fieldIDsOrNames []string, conf *model.Configuration) (err error) {
-	var f1, f2 *os.File
-
-	if f1, err = os.Open(inFile); err != nil {
-		return err
-	}
-
-	tmpFile := inFile + ".tmp"
-	if outFile != "" && inFile != outFile {
-		tmpFile = outFile
-	}
 	logWritingTo(outFile)
 
-	if f2, err = os.Create(tmpFile); err != nil {
-		f1.Close()
-		return err
-	}
-
-	defer func() {
-		if err != nil {
-			f2.Close()
-			f1.Close()
-			os.Remove(tmpFile)
-			return
-		}
-		if err = f2.Close(); err != nil {
-			return
-		}
-		if err = f1.Close(); err != nil {
-			return
-		}
-		if outFile == "" || inFile == outFile {
-			err = os.Rename(tmpFile, inFile)
-		}
-	}()
-
-	return RemoveFormFields(f1, f2, fieldIDsOrNames, conf)
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return RemoveFormFields(rs, w, fieldIDsOrNames, conf)
+	})
 }
 
 // LockFormFields turns form fields in rs into read-only and writes the result to w.
@@ -138,6 +208,8 @@ func LockFormFields(rs io.ReadSeeker, w io.Writer, fieldIDsOrNames []string, con
 
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
 	}
 	conf.Cmd = model.LOCKFORMFIELDS
 
@@ -159,42 +231,11 @@ func LockFormFields(rs io.ReadSeeker, w io.Writer, fieldIDsOrNames []string, con
 
 // LockFormFieldsFile turns form fields of inFile into read-only and writes the result to outFile.
 func LockFormFieldsFile(inFile, outFile string, fieldIDsOrNames []string, conf *model.Configuration) (err error) {
-	var f1, f2 *os.File
-
-	if f1, err = os.Open(inFile); err != nil {
-		return err
-	}
-
-	tmpFile := inFile + ".tmp"
-	if outFile != "" && inFile != outFile {
-		tmpFile = outFile
-	}
 	logWritingTo(outFile)
 
-	if f2, err = os.Create(tmpFile); err != nil {
-		f1.Close()
-		return err
-	}
-
-	defer func() {
-		if err != nil {
-			f2.Close()
-			f1.Close()
-			os.Remove(tmpFile)
-			return
-		}
-		if err = f2.Close(); err != nil {
-			return
-		}
-		if err = f1.Close(); err != nil {
-			return
-		}
-		if outFile == "" || inFile == outFile {
-			err = os.Rename(tmpFile, inFile)
-		}
-	}()
-
-	return LockFormFields(f1, f2, fieldIDsOrNames, conf)
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return LockFormFields(rs, w, fieldIDsOrNames, conf)
+	})
 }
 
 // UnlockFormFields makess form fields in rs writeable and writes the result to w.
@@ -205,6 +246,8 @@ func UnlockFormFields(rs io.ReadSeeker, w io.Writer, fieldIDsOrNames []string, c
 
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
 	}
 	conf.Cmd = model.UNLOCKFORMFIELDS
 
@@ -226,42 +269,11 @@ func UnlockFormFields(rs io.ReadSeeker, w io.Writer, fieldIDsOrNames []string, c
 
 // UnlockFormFieldsFile makes form fields of inFile writeable and writes the result to outFile.
 func UnlockFormFieldsFile(inFile, outFile string, fieldIDsOrNames []string, conf *model.Configuration) (err error) {
-	var f1, f2 *os.File
-
-	if f1, err = os.Open(inFile); err != nil {
-		return err
-	}
-
-	tmpFile := inFile + ".tmp"
-	if outFile != "" && inFile != outFile {
-		tmpFile = outFile
-	}
 	logWritingTo(outFile)
 
-	if f2, err = os.Create(tmpFile); err != nil {
-		f1.Close()
-		return err
-	}
-
-	defer func() {
-		if err != nil {
-			f2.Close()
-			f1.Close()
-			os.Remove(tmpFile)
-			return
-		}
-		if err = f2.Close(); err != nil {
-			return
-		}
-		if err = f1.Close(); err != nil {
-			return
-		}
-		if outFile == "" || inFile == outFile {
-			err = os.Rename(tmpFile, inFile)
-		}
-	}()
-
-	return UnlockFormFields(f1, f2, fieldIDsOrNames, conf)
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return UnlockFormFields(rs, w, fieldIDsOrNames, conf)
+	})
 }
 
 // ResetFormFields resets form fields of rs and writes the result to w.
@@ -272,6 +284,8 @@ func ResetFormFields(rs io.ReadSeeker, w io.Writer, fieldIDsOrNames []string, co
 
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
 	}
 	conf.Cmd = model.RESETFORMFIELDS
 
@@ -293,42 +307,11 @@ func ResetFormFields(rs io.ReadSeeker, w io.Writer, fieldIDsOrNames []string, co
 
 // ResetFormFieldsFile resets form fields of inFile and writes the result to outFile.
 func ResetFormFieldsFile(inFile, outFile string, fieldIDsOrNames []string, conf *model.Configuration) (err error) {
-	var f1, f2 *os.File
-
-	if f1, err = os.Open(inFile); err != nil {
-		return err
-	}
-
-	tmpFile := inFile + ".tmp"
-	if outFile != "" && inFile != outFile {
-		tmpFile = outFile
-	}
 	logWritingTo(outFile)
 
-	if f2, err = os.Create(tmpFile); err != nil {
-		f1.Close()
-		return err
-	}
-
-	defer func() {
-		if err != nil {
-			f2.Close()
-			f1.Close()
-			os.Remove(tmpFile)
-			return
-		}
-		if err = f2.Close(); err != nil {
-			return
-		}
-		if err = f1.Close(); err != nil {
-			return
-		}
-		if outFile == "" || inFile == outFile {
-			err = os.Rename(tmpFile, inFile)
-		}
-	}()
-
-	return ResetFormFields(f1, f2, fieldIDsOrNames, conf)
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return ResetFormFields(rs, w, fieldIDsOrNames, conf)
+	})
 }
 
 // ExportForm extracts form data originating from source from rs.
@@ -339,6 +322,8 @@ func ExportForm(rs io.ReadSeeker, source string, conf *model.Configuration) (*fo
 
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
 	}
 	conf.Cmd = model.EXPORTFORMFIELDS
 
@@ -370,6 +355,8 @@ func ExportFormJSON(rs io.ReadSeeker, w io.Writer, source string, conf *model.Co
 
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
 	}
 	conf.Cmd = model.EXPORTFORMFIELDS
 
@@ -420,6 +407,120 @@ func ExportFormFile(inFilePDF, outFileJSON string, conf *model.Configuration) (e
 	return ExportFormJSON(f1, f2, inFilePDF, conf)
 }
 
+func formFieldValuesForFile(inFile string, conf *model.Configuration) (map[string]string, []string, error) {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+
+	ctx, err := ReadAndValidate(f, conf)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	m := map[string]string{}
+
+	if ctx.Form == nil {
+		// Files without form contribute an empty row.
+		return m, nil, nil
+	}
+
+	fvs, err := form.FormFieldValues(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var keys []string
+	for _, fv := range fvs {
+		k := fv.Name
+		if k == "" {
+			k = fv.ID
+		}
+		if _, ok := m[k]; !ok {
+			keys = append(keys, k)
+		}
+		m[k] = fv.Value
+	}
+
+	return m, keys, nil
+}
+
+// ExportFormCSV harvests the effective form field values of inFiles and writes them to w as CSV.
+// Each file results in one row. The columns are the union of all fully qualified field names in order of appearance.
+func ExportFormCSV(inFiles []string, w io.Writer, conf *model.Configuration) error {
+	if w == nil {
+		return errors.New("pdfcpu: ExportFormCSV: missing w")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
+	}
+	conf.Cmd = model.EXPORTFORMFIELDSCSV
+
+	cols := []string{}
+	colSet := map[string]bool{}
+	rows := make([]map[string]string, len(inFiles))
+
+	for i, inFile := range inFiles {
+		if log.CLIEnabled() {
+			log.CLI.Printf("reading %s...\n", inFile)
+		}
+		m, keys, err := formFieldValuesForFile(inFile, conf)
+		if err != nil {
+			return errors.Wrapf(err, "%s", inFile)
+		}
+		for _, k := range keys {
+			if !colSet[k] {
+				colSet[k] = true
+				cols = append(cols, k)
+			}
+		}
+		rows[i] = m
+	}
+
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write(append([]string{"file"}, cols...)); err != nil {
+		return err
+	}
+
+	for i, m := range rows {
+		rec := make([]string, len(cols)+1)
+		rec[0] = inFiles[i]
+		for j, col := range cols {
+			rec[j+1] = m[col]
+		}
+		if err := cw.Write(rec); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+
+	return cw.Error()
+}
+
+// ExportFormCSVFile harvests the effective form field values of inFiles and writes them to outFileCSV.
+func ExportFormCSVFile(inFiles []string, outFileCSV string, conf *model.Configuration) (err error) {
+	var f *os.File
+
+	if f, err = os.Create(outFileCSV); err != nil {
+		return err
+	}
+	logWritingTo(outFileCSV)
+
+	defer func() {
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+	}()
+
+	return ExportFormCSV(inFiles, f, conf)
+}
+
 func validateComboBoxValues(f form.Form) error {
 	for _, cb := range f.ComboBoxes {
 		if cb.Value == "" || cb.Editable {
@@ -500,26 +601,8 @@ func fillPostProc(ctx *model.Context, pp []*model.Page) error {
 	return ValidateContext(ctx)
 }
 
-// FillForm populates the form rs with data from rd and writes the result to w.
-func FillForm(rs io.ReadSeeker, rd io.Reader, w io.Writer, conf *model.Configuration) error {
-	if rs == nil {
-		return errors.New("pdfcpu: FillForm: missing rs")
-	}
-
-	if rd == nil {
-		return errors.New("pdfcpu: FillForm: missing rd")
-	}
-
-	if conf == nil {
-		conf = model.NewDefaultConfiguration()
-	}
-	conf.Cmd = model.FILLFORMFIELDS
-
-	ctx, err := ReadValidateAndOptimize(rs, conf)
-	if err != nil {
-		return err
-	}
-
+// fillFormContext populates the form of ctx with JSON data from rd.
+func fillFormContext(ctx *model.Context, rd io.Reader) error {
 	ctx.RemoveSignature()
 
 	var buf bytes.Buffer
@@ -561,62 +644,52 @@ func FillForm(rs io.ReadSeeker, rd io.Reader, w io.Writer, conf *model.Configura
 		return ErrNoFormFieldsAffected
 	}
 
-	if err := fillPostProc(ctx, pp); err != nil {
-		return err
+	return fillPostProc(ctx, pp)
+}
+
+// FillForm populates the form rs with data from rd and writes the result to w.
+func FillForm(rs io.ReadSeeker, rd io.Reader, w io.Writer, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: FillForm: missing rs")
 	}
 
-	return Write(ctx, w, conf)
-}
+	if rd == nil {
+		return errors.New("pdfcpu: FillForm: missing rd")
+	}
 
-// FillFormFile populates the form inFilePDF with data from inFileJSON and writes the result to outFilePDF.
-func FillFormFile(inFilePDF, inFileJSON, outFilePDF string, conf *model.Configuration) (err error) {
-	var f0, f1, f2 *os.File
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
+	}
+	conf.Cmd = model.FILLFORMFIELDS
 
-	if f0, err = os.Open(inFileJSON); err != nil {
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
 		return err
 	}
 
-	if f1, err = os.Open(inFilePDF); err != nil {
-		f0.Close()
+	if err := fillFormContext(ctx, rd); err != nil {
 		return err
 	}
-	rs := f1
 
-	tmpFile := inFilePDF + ".tmp"
-	if outFilePDF != "" && inFilePDF != outFilePDF {
-		tmpFile = outFilePDF
-	}
-	logWritingTo(outFilePDF)
+	return Write(ctx, w, conf)
+}
 
-	if f2, err = os.Create(tmpFile); err != nil {
-		f1.Close()
-		f0.Close()
+// FillFormFile populates the form inFilePDF with data from inFileJSON and writes the result to outFilePDF.
+func FillFormFile(inFilePDF, inFileJSON, outFilePDF string, conf *model.Configuration) (err error) {
+	var f0 *os.File
+
+	if f0, err = os.Open(inFileJSON); err != nil {
 		return err
 	}
+	defer f0.Close()
 
-	defer func() {
-		if err != nil {
-			f2.Close()
-			f1.Close()
-			f0.Close()
-			os.Remove(tmpFile)
-			return
-		}
-		if err = f2.Close(); err != nil {
-			return
-		}
-		if err = f1.Close(); err != nil {
-			return
-		}
-		if err = f0.Close(); err != nil {
-			return
-		}
-		if outFilePDF == "" || inFilePDF == outFilePDF {
-			err = os.Rename(tmpFile, inFilePDF)
-		}
-	}()
+	logWritingTo(outFilePDF)
 
-	return FillForm(rs, f0, f2, conf)
+	return updateFile(inFilePDF, outFilePDF, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return FillForm(rs, f0, w, conf)
+	})
 }
 
 func parseFormGroup(rd io.Reader) (*form.FormGroup, error) {
@@ -815,6 +888,8 @@ func multiFillFormCSV(inFilePDF string, rd io.Reader, outDir, fileName string, m
 func MultiFillForm(inFilePDF string, rd io.Reader, outDir, fileName string, format form.DataFormat, merge bool, conf *model.Configuration) error {
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
 	}
 	conf.Cmd = model.MULTIFILLFORMFIELDS
 
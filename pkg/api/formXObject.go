@@ -0,0 +1,163 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+// ExtractFormXObjects writes the form XObjects used by selected pages of rs into outDir as single page PDF files.
+func ExtractFormXObjects(rs io.ReadSeeker, outDir, fileName string, selectedPages []string, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: ExtractFormXObjects: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
+	}
+	conf.Cmd = model.EXTRACTFORMXOBJECTS
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	pages, err := PagesForPageSelectionWithContext(ctx, selectedPages, true, true)
+	if err != nil {
+		return err
+	}
+
+	pageNrs := []int{}
+	for i, v := range pages {
+		if v {
+			pageNrs = append(pageNrs, i)
+		}
+	}
+	sort.Ints(pageNrs)
+
+	ff, err := pdfcpu.FormXObjects(ctx, pageNrs)
+	if err != nil {
+		return err
+	}
+
+	fileName = strings.TrimSuffix(filepath.Base(fileName), ".pdf")
+
+	for _, f := range ff {
+		ctxNew, err := pdfcpu.ExtractFormXObject(ctx, f.ObjNr)
+		if err != nil {
+			return err
+		}
+
+		outFile := filepath.Join(outDir, fmt.Sprintf("%s_form_%d_%s.pdf", fileName, f.ObjNr, f.Names[0]))
+		logWritingTo(outFile)
+		if err := WriteContextFile(ctxNew, outFile); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ExtractFormXObjectsFile writes the form XObjects used by selected pages of inFile into outDir as single page PDF files.
+func ExtractFormXObjectsFile(inFile, outDir string, selectedPages []string, conf *model.Configuration) error {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if log.CLIEnabled() {
+		log.CLI.Printf("extracting form XObjects from %s into %s/ ...\n", inFile, outDir)
+	}
+
+	return ExtractFormXObjects(f, outDir, filepath.Base(inFile), selectedPages, conf)
+}
+
+// PlacePage imports page pageNr of rsForm as a single form XObject and places it on pages of rs
+// according to pp (eg. a logo or a seal) without duplicating its content per placement.
+// The result is written to w.
+func PlacePage(rs, rsForm io.ReadSeeker, w io.Writer, pageNr int, pp []pdfcpu.FormPlacement, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: PlacePage: missing rs")
+	}
+
+	if rsForm == nil {
+		return errors.New("pdfcpu: PlacePage: missing rsForm")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
+	}
+	conf.Cmd = model.PLACEPAGE
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	otherCtx, err := ReadAndValidate(rsForm, model.NewDefaultConfiguration())
+	if err != nil {
+		return err
+	}
+
+	if err := otherCtx.EnsurePageCount(); err != nil {
+		return err
+	}
+
+	if pageNr < 1 || pageNr > otherCtx.PageCount {
+		return errors.Errorf("pdfcpu: invalid page number: %d", pageNr)
+	}
+
+	ir, r, err := pdfcpu.ImportPageAsForm(ctx, otherCtx, pageNr, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := pdfcpu.PlaceForm(ctx, *ir, r, pp); err != nil {
+		return err
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// PlacePageFile imports page pageNr of formFile as a single form XObject and places it on pages of inFile
+// according to pp. The result is written to outFile.
+func PlacePageFile(inFile, formFile, outFile string, pageNr int, pp []pdfcpu.FormPlacement, conf *model.Configuration) error {
+	f, err := os.Open(formFile)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return PlacePage(rs, f, w, pageNr, pp, conf)
+	})
+}
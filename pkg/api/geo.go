@@ -0,0 +1,193 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+	"os"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+// GeoViewports returns the georeferenced viewports of selected pages of rs.
+func GeoViewports(rs io.ReadSeeker, selectedPages []string, conf *model.Configuration) ([]pdfcpu.GeoViewport, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: GeoViewports: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
+		conf.ValidationMode = model.ValidationRelaxed
+	}
+	conf.Cmd = model.LISTGEO
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	pages, err := PagesForPageSelectionWithContext(ctx, selectedPages, true, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return pdfcpu.GeoViewportsForPages(ctx, pages)
+}
+
+// ListGeoViewports returns a list of the georeferenced viewports of selected pages of rs.
+func ListGeoViewports(rs io.ReadSeeker, selectedPages []string, conf *model.Configuration) ([]string, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: ListGeoViewports: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
+		conf.ValidationMode = model.ValidationRelaxed
+	}
+	conf.Cmd = model.LISTGEO
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	pages, err := PagesForPageSelectionWithContext(ctx, selectedPages, true, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return pdfcpu.ListGeoViewports(ctx, pages)
+}
+
+// ListGeoViewportsFile returns a list of the georeferenced viewports of selected pages of inFile.
+func ListGeoViewportsFile(inFile string, selectedPages []string, conf *model.Configuration) ([]string, error) {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ListGeoViewports(f, selectedPages, conf)
+}
+
+// AddGeoViewports adds georeferenced viewports to selected pages of rs and writes the result to w.
+func AddGeoViewports(rs io.ReadSeeker, w io.Writer, selectedPages []string, gvs []pdfcpu.GeoViewport, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: AddGeoViewports: missing rs")
+	}
+
+	if len(gvs) == 0 {
+		return errors.New("pdfcpu: AddGeoViewports: missing viewports")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
+	}
+	conf.Cmd = model.ADDGEO
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	pages, err := PagesForPageSelectionWithContext(ctx, selectedPages, true, true)
+	if err != nil {
+		return err
+	}
+
+	if err = pdfcpu.AddGeoViewports(ctx, pages, gvs); err != nil {
+		return err
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// AddGeoViewportsJSON adds the georeferenced viewports of a JSON document read from rd to selected pages of rs and writes the result to w.
+func AddGeoViewportsJSON(rs io.ReadSeeker, rd io.Reader, w io.Writer, selectedPages []string, conf *model.Configuration) error {
+	if rd == nil {
+		return errors.New("pdfcpu: AddGeoViewportsJSON: missing rd")
+	}
+
+	gvs, err := pdfcpu.ParseGeoViewportsJSON(rd)
+	if err != nil {
+		return err
+	}
+
+	return AddGeoViewports(rs, w, selectedPages, gvs, conf)
+}
+
+// AddGeoViewportsFile adds the georeferenced viewports of inFileJSON to selected pages of inFile and writes the result to outFile.
+func AddGeoViewportsFile(inFile, inFileJSON, outFile string, selectedPages []string, conf *model.Configuration) (err error) {
+	f, err := os.Open(inFileJSON)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return AddGeoViewportsJSON(rs, f, w, selectedPages, conf)
+	})
+}
+
+// RemoveGeoViewports removes the georeferenced viewports of selected pages of rs and writes the result to w.
+func RemoveGeoViewports(rs io.ReadSeeker, w io.Writer, selectedPages []string, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: RemoveGeoViewports: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
+	}
+	conf.Cmd = model.REMOVEGEO
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	pages, err := PagesForPageSelectionWithContext(ctx, selectedPages, true, true)
+	if err != nil {
+		return err
+	}
+
+	ok, err := pdfcpu.RemoveGeoViewports(ctx, pages)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New("pdfcpu: no georeferenced viewports removed")
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// RemoveGeoViewportsFile removes the georeferenced viewports of selected pages of inFile and writes the result to outFile.
+func RemoveGeoViewportsFile(inFile, outFile string, selectedPages []string, conf *model.Configuration) (err error) {
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return RemoveGeoViewports(rs, w, selectedPages, conf)
+	})
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+	"sort"
+
+	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+// FixHairlines raises stroke widths below hc.Threshold to hc.MinWidth on selected pages of rs
+// and writes the result to w.
+func FixHairlines(rs io.ReadSeeker, w io.Writer, selectedPages []string, hc *pdfcpu.HairlineConfig, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: FixHairlines: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
+	}
+	conf.Cmd = model.FIXHAIRLINES
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	pages, err := PagesForPageSelectionWithContext(ctx, selectedPages, true, true)
+	if err != nil {
+		return err
+	}
+
+	pageNrs := []int{}
+	for i, v := range pages {
+		if v {
+			pageNrs = append(pageNrs, i)
+		}
+	}
+	sort.Ints(pageNrs)
+
+	n, err := pdfcpu.FixHairlines(ctx, pageNrs, hc)
+	if err != nil {
+		return err
+	}
+
+	if log.CLIEnabled() {
+		log.CLI.Printf("%d hairline(s) fixed\n", n)
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// FixHairlinesFile raises stroke widths below hc.Threshold to hc.MinWidth on selected pages of inFile
+// and writes the result to outFile.
+func FixHairlinesFile(inFile, outFile string, selectedPages []string, hc *pdfcpu.HairlineConfig, conf *model.Configuration) error {
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return FixHairlines(rs, w, selectedPages, hc, conf)
+	})
+}
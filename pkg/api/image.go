@@ -36,6 +36,8 @@ func Images(rs io.ReadSeeker, selectedPages []string, conf *model.Configuration)
 
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
 	}
 	conf.Cmd = model.LISTIMAGES
 
@@ -44,7 +46,7 @@ func Images(rs io.ReadSeeker, selectedPages []string, conf *model.Configuration)
 		return nil, err
 	}
 
-	pages, err := PagesForPageSelection(ctx.PageCount, selectedPages, true, true)
+	pages, err := PagesForPageSelectionWithContext(ctx, selectedPages, true, true)
 	if err != nil {
 		return nil, err
 	}
@@ -63,6 +65,8 @@ func UpdateImages(rs io.ReadSeeker, rd io.Reader, w io.Writer, objNr, pageNr int
 
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
 	}
 	conf.Cmd = model.UPDATEIMAGES
 
@@ -125,49 +129,20 @@ func UpdateImagesFile(inFile, imageFile, outFile string, objNr, pageNr int, id s
 		}
 	}
 
-	var f0, f1, f2 *os.File
-
-	if f0, err = os.Open(inFile); err != nil {
-		return err
-	}
+	var f1 *os.File
 
 	if f1, err = os.Open(imageFile); err != nil {
 		return err
 	}
+	defer f1.Close()
 
-	tmpFile := inFile + ".tmp"
 	if outFile != "" && inFile != outFile {
-		tmpFile = outFile
 		logWritingTo(outFile)
 	} else {
 		logWritingTo(inFile)
 	}
-	if f2, err = os.Create(tmpFile); err != nil {
-		f1.Close()
-		return err
-	}
-
-	defer func() {
-		if err != nil {
-			f2.Close()
-			f1.Close()
-			f0.Close()
-			os.Remove(tmpFile)
-			return
-		}
-		if err = f2.Close(); err != nil {
-			return
-		}
-		if err = f1.Close(); err != nil {
-			return
-		}
-		if err = f0.Close(); err != nil {
-			return
-		}
-		if outFile == "" || inFile == outFile {
-			err = os.Rename(tmpFile, inFile)
-		}
-	}()
 
-	return UpdateImages(f0, f1, f2, objNr, pageNr, id, conf)
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return UpdateImages(rs, f1, w, objNr, pageNr, id, conf)
+	})
 }
@@ -37,6 +37,8 @@ func Import(s string, u types.DisplayUnit) (*pdfcpu.Import, error) {
 func ImportImages(rs io.ReadSeeker, w io.Writer, imgs []io.Reader, imp *pdfcpu.Import, conf *model.Configuration) error {
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
 	}
 	conf.Cmd = model.IMPORTIMAGES
 
@@ -101,15 +103,15 @@ func fileExists(filename string) bool {
 
 }
 
-func prepImgFiles(imgFiles []string, f1 *os.File) ([]io.ReadCloser, []io.Reader, error) {
+func prepImgFiles(imgFiles []string, conf *model.Configuration) ([]io.ReadCloser, []io.Reader, error) {
 	rc := make([]io.ReadCloser, len(imgFiles))
 	rr := make([]io.Reader, len(imgFiles))
 
 	for i, fn := range imgFiles {
-		f, err := os.Open(fn)
+		f, err := conf.OpenResource(fn)
 		if err != nil {
-			if f1 != nil {
-				f1.Close()
+			for _, f := range rc[:i] {
+				f.Close()
 			}
 			return nil, nil, err
 		}
@@ -128,63 +130,39 @@ func logImportImages(s, outFile string) {
 
 // ImportImagesFile appends PDF pages containing images to outFile which will be created if necessary.
 func ImportImagesFile(imgFiles []string, outFile string, imp *pdfcpu.Import, conf *model.Configuration) (err error) {
-	var f1, f2 *os.File
+	rc, rr, err := prepImgFiles(imgFiles, conf)
+	if err != nil {
+		return err
+	}
 
-	rs := io.ReadSeeker(nil)
-	f1 = nil
-	tmpFile := outFile
-	if fileExists(outFile) {
-		if f1, err = os.Open(outFile); err != nil {
-			return err
+	defer func() {
+		for _, f := range rc {
+			f.Close()
 		}
-		rs = f1
-		tmpFile += ".tmp"
+	}()
+
+	if fileExists(outFile) {
 		logImportImages("appending", outFile)
-	} else {
-		logImportImages("writing", outFile)
+		return updateFile(outFile, "", conf, func(rs io.ReadSeeker, w io.Writer) error {
+			return ImportImages(rs, w, rr, imp, conf)
+		})
 	}
 
-	rc, rr, err := prepImgFiles(imgFiles, f1)
-	if err != nil {
-		return err
-	}
+	logImportImages("writing", outFile)
 
-	if f2, err = os.Create(tmpFile); err != nil {
-		if f1 != nil {
-			f1.Close()
-		}
+	var f *os.File
+
+	if f, err = os.Create(outFile); err != nil {
 		return err
 	}
 
 	defer func() {
 		if err != nil {
-			f2.Close()
-			if f1 != nil {
-				f1.Close()
-				os.Remove(tmpFile)
-			}
-			for _, f := range rc {
-				f.Close()
-			}
-			return
-		}
-		if err = f2.Close(); err != nil {
+			f.Close()
 			return
 		}
-		if f1 != nil {
-			if err = f1.Close(); err != nil {
-				return
-			}
-			if err = os.Rename(tmpFile, outFile); err != nil {
-				return
-			}
-		}
-		for _, f := range rc {
-			if err := f.Close(); err != nil {
-				return
-			}
-		}
+		err = f.Close()
 	}()
 
-	return ImportImages(rs, f2, rr, imp, conf)
+	return ImportImages(nil, f, rr, imp, conf)
 }
@@ -33,6 +33,7 @@ func PDFInfo(rs io.ReadSeeker, fileName string, selectedPages []string, fonts bo
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
 	} else {
+		conf = conf.Clone()
 		conf.ValidationMode = model.ValidationRelaxed
 	}
 	conf.Cmd = model.LISTINFO
@@ -48,7 +49,7 @@ func PDFInfo(rs io.ReadSeeker, fileName string, selectedPages []string, fonts bo
 		}
 	}
 
-	pages, err := PagesForPageSelection(ctx.PageCount, selectedPages, false, true)
+	pages, err := PagesForPageSelectionWithContext(ctx, selectedPages, false, true)
 	if err != nil {
 		return nil, err
 	}
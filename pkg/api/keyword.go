@@ -34,6 +34,7 @@ func Keywords(rs io.ReadSeeker, conf *model.Configuration) ([]string, error) {
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
 	} else {
+		conf = conf.Clone()
 		conf.ValidationMode = model.ValidationRelaxed
 	}
 	conf.Cmd = model.LISTKEYWORDS
@@ -55,6 +56,7 @@ func AddKeywords(rs io.ReadSeeker, w io.Writer, files []string, conf *model.Conf
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
 	} else {
+		conf = conf.Clone()
 		conf.ValidationMode = model.ValidationRelaxed
 	}
 	conf.Cmd = model.ADDKEYWORDS
@@ -73,40 +75,9 @@ func AddKeywords(rs io.ReadSeeker, w io.Writer, files []string, conf *model.Conf
 
 // AddKeywordsFile adds keywords to inFile's infodict and writes the result to outFile.
 func AddKeywordsFile(inFile, outFile string, files []string, conf *model.Configuration) (err error) {
-	var f1, f2 *os.File
-
-	if f1, err = os.Open(inFile); err != nil {
-		return err
-	}
-
-	tmpFile := inFile + ".tmp"
-	if outFile != "" && inFile != outFile {
-		tmpFile = outFile
-	}
-	if f2, err = os.Create(tmpFile); err != nil {
-		f1.Close()
-		return err
-	}
-
-	defer func() {
-		if err != nil {
-			f2.Close()
-			f1.Close()
-			os.Remove(tmpFile)
-			return
-		}
-		if err = f2.Close(); err != nil {
-			return
-		}
-		if err = f1.Close(); err != nil {
-			return
-		}
-		if outFile == "" || inFile == outFile {
-			err = os.Rename(tmpFile, inFile)
-		}
-	}()
-
-	return AddKeywords(f1, f2, files, conf)
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return AddKeywords(rs, w, files, conf)
+	})
 }
 
 // RemoveKeywords deletes keywords from rs's infodict and writes the result to w.
@@ -118,6 +89,7 @@ func RemoveKeywords(rs io.ReadSeeker, w io.Writer, keywords []string, conf *mode
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
 	} else {
+		conf = conf.Clone()
 		conf.ValidationMode = model.ValidationRelaxed
 	}
 	conf.Cmd = model.REMOVEKEYWORDS
@@ -140,38 +112,110 @@ func RemoveKeywords(rs io.ReadSeeker, w io.Writer, keywords []string, conf *mode
 
 // RemoveKeywordsFile deletes keywords from inFile's infodict and writes the result to outFile.
 func RemoveKeywordsFile(inFile, outFile string, keywords []string, conf *model.Configuration) (err error) {
-	var f1, f2 *os.File
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return RemoveKeywords(rs, w, keywords, conf)
+	})
+}
+
+// ImportKeywords adds the keywords of a JSON document read from rd to rs's infodict and writes the result to w.
+// If replace is true existing keywords get dropped.
+func ImportKeywords(rs io.ReadSeeker, rd io.Reader, w io.Writer, replace bool, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: ImportKeywords: missing rs")
+	}
+
+	if rd == nil {
+		return errors.New("pdfcpu: ImportKeywords: missing rd")
+	}
+
+	keywords, err := pdfcpu.ParseKeywordsJSON(rd)
+	if err != nil {
+		return err
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
+		conf.ValidationMode = model.ValidationRelaxed
+	}
+	conf.Cmd = model.IMPORTKEYWORDS
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return err
+	}
 
-	if f1, err = os.Open(inFile); err != nil {
+	if replace {
+		err = pdfcpu.KeywordsReplace(ctx, keywords)
+	} else {
+		err = pdfcpu.KeywordsAdd(ctx, keywords)
+	}
+	if err != nil {
 		return err
 	}
 
-	tmpFile := inFile + ".tmp"
-	if outFile != "" && inFile != outFile {
-		tmpFile = outFile
+	return Write(ctx, w, conf)
+}
+
+// ImportKeywordsFile adds the keywords of inFileJSON to inFilePDF's infodict and writes the result to outFilePDF.
+func ImportKeywordsFile(inFilePDF, inFileJSON, outFilePDF string, replace bool, conf *model.Configuration) (err error) {
+	f, err := os.Open(inFileJSON)
+	if err != nil {
+		return err
 	}
-	if f2, err = os.Create(tmpFile); err != nil {
-		f1.Close()
+	defer f.Close()
+
+	return updateFile(inFilePDF, outFilePDF, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return ImportKeywords(rs, f, w, replace, conf)
+	})
+}
+
+// ExportKeywordsJSON writes rs's keywords (originating from source) as JSON to w.
+func ExportKeywordsJSON(rs io.ReadSeeker, w io.Writer, source string, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: ExportKeywordsJSON: missing rs")
+	}
+
+	if w == nil {
+		return errors.New("pdfcpu: ExportKeywordsJSON: missing w")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
+	}
+	conf.ValidationMode = model.ValidationRelaxed
+	conf.Cmd = model.EXPORTKEYWORDS
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	return pdfcpu.ExportKeywordsJSON(ctx, source, w)
+}
+
+// ExportKeywordsFile writes inFilePDF's keywords as JSON to outFileJSON.
+func ExportKeywordsFile(inFilePDF, outFileJSON string, conf *model.Configuration) (err error) {
+	var f1, f2 *os.File
+
+	if f1, err = os.Open(inFilePDF); err != nil {
 		return err
 	}
+	defer f1.Close()
+
+	if f2, err = os.Create(outFileJSON); err != nil {
+		return err
+	}
+	logWritingTo(outFileJSON)
 
 	defer func() {
-		if err != nil {
-			f2.Close()
-			f1.Close()
-			os.Remove(tmpFile)
-			return
-		}
-		if err = f2.Close(); err != nil {
-			return
-		}
-		if err = f1.Close(); err != nil {
-			return
-		}
-		if outFile == "" || inFile == outFile {
-			err = os.Rename(tmpFile, inFile)
+		if err1 := f2.Close(); err == nil {
+			err = err1
 		}
 	}()
 
-	return RemoveKeywords(f1, f2, keywords, conf)
+	return ExportKeywordsJSON(f1, f2, inFilePDF, conf)
 }
@@ -0,0 +1,150 @@
+/*
+	Copyright 2026 The pdfcpu Authors.
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+	"os"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+// Language returns rs's natural language.
+func Language(rs io.ReadSeeker, conf *model.Configuration) (string, error) {
+	if rs == nil {
+		return "", errors.New("pdfcpu: Language: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
+		conf.ValidationMode = model.ValidationRelaxed
+	}
+	conf.Cmd = model.LISTLANGUAGE
+
+	ctx, err := ReadAndValidate(rs, conf)
+	if err != nil {
+		return "", err
+	}
+
+	return pdfcpu.Language(ctx), nil
+}
+
+// LanguageFile returns inFile's natural language.
+func LanguageFile(inFile string, conf *model.Configuration) (string, error) {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	return Language(f, conf)
+}
+
+// ListLanguage lists rs's natural language.
+func ListLanguage(rs io.ReadSeeker, conf *model.Configuration) ([]string, error) {
+	lang, err := Language(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	if lang != "" {
+		return []string{lang}, nil
+	}
+
+	return []string{"No language set"}, nil
+}
+
+// ListLanguageFile lists inFile's natural language.
+func ListLanguageFile(inFile string, conf *model.Configuration) ([]string, error) {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ListLanguage(f, conf)
+}
+
+// SetLanguage sets rs's natural language and writes the result to w.
+func SetLanguage(rs io.ReadSeeker, w io.Writer, lang string, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: SetLanguage: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
+		conf.ValidationMode = model.ValidationRelaxed
+	}
+	conf.Cmd = model.SETLANGUAGE
+
+	ctx, err := ReadAndValidate(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	if err := pdfcpu.SetLanguage(ctx, lang); err != nil {
+		return err
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// SetLanguageFile sets inFile's natural language and writes the result to outFile.
+func SetLanguageFile(inFile, outFile, lang string, conf *model.Configuration) (err error) {
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return SetLanguage(rs, w, lang, conf)
+	})
+}
+
+// ResetLanguage removes rs's natural language and writes the result to w.
+func ResetLanguage(rs io.ReadSeeker, w io.Writer, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: ResetLanguage: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
+		conf.ValidationMode = model.ValidationRelaxed
+	}
+	conf.Cmd = model.RESETLANGUAGE
+
+	ctx, err := ReadAndValidate(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	if _, err := pdfcpu.ResetLanguage(ctx); err != nil {
+		return err
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// ResetLanguageFile removes inFile's natural language and writes the result to outFile.
+func ResetLanguageFile(inFile, outFile string, conf *model.Configuration) (err error) {
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return ResetLanguage(rs, w, conf)
+	})
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+// Media returns the 3D artwork, RichMedia assets, sounds and movies of selected pages of rs.
+func Media(rs io.ReadSeeker, selectedPages []string, conf *model.Configuration) ([]pdfcpu.Media, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: Media: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
+		conf.ValidationMode = model.ValidationRelaxed
+	}
+	conf.Cmd = model.EXTRACTMEDIA
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	pages, err := PagesForPageSelectionWithContext(ctx, selectedPages, true, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return pdfcpu.MediaForPages(ctx, pages)
+}
+
+// ListMedia returns a list of the 3D artwork, RichMedia assets, sounds and movies of selected pages of rs.
+func ListMedia(rs io.ReadSeeker, selectedPages []string, conf *model.Configuration) ([]string, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: ListMedia: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
+		conf.ValidationMode = model.ValidationRelaxed
+	}
+	conf.Cmd = model.LISTMEDIA
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	pages, err := PagesForPageSelectionWithContext(ctx, selectedPages, true, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return pdfcpu.ListMedia(ctx, pages)
+}
+
+// ListMediaFile returns a list of the 3D artwork, RichMedia assets, sounds and movies of selected pages of inFile.
+func ListMediaFile(inFile string, selectedPages []string, conf *model.Configuration) ([]string, error) {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ListMedia(f, selectedPages, conf)
+}
+
+func mediaFileName(fileName string, m pdfcpu.Media) string {
+	if m.Name != "" {
+		return fmt.Sprintf("%s_%s_page_%d_%d_%s", fileName, m.Annot, m.PageNr, m.ObjNr, filepath.Base(m.Name))
+	}
+	s := fmt.Sprintf("%s_%s_page_%d_%d", fileName, m.Annot, m.PageNr, m.ObjNr)
+	if m.Format != "" {
+		s += "." + strings.ToLower(m.Format)
+	}
+	return s
+}
+
+// ExtractMedia dumps the 3D artwork, RichMedia assets, sounds and movies of selected pages of rs into outDir.
+func ExtractMedia(rs io.ReadSeeker, outDir, fileName string, selectedPages []string, conf *model.Configuration) error {
+	mm, err := Media(rs, selectedPages, conf)
+	if err != nil {
+		return err
+	}
+
+	fileName = strings.TrimSuffix(filepath.Base(fileName), ".pdf")
+
+	for _, m := range mm {
+		outFile := filepath.Join(outDir, mediaFileName(fileName, m))
+		logWritingTo(outFile)
+		f, err := os.Create(outFile)
+		if err != nil {
+			return err
+		}
+		if _, err = io.Copy(f, m); err != nil {
+			f.Close()
+			return err
+		}
+		if err := f.Close(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ExtractMediaFile dumps the 3D artwork, RichMedia assets, sounds and movies of selected pages of inFile into outDir.
+func ExtractMediaFile(inFile, outDir string, selectedPages []string, conf *model.Configuration) error {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if log.CLIEnabled() {
+		log.CLI.Printf("extracting media content from %s into %s/ ...\n", inFile, outDir)
+	}
+
+	return ExtractMedia(f, outDir, inFile, selectedPages, conf)
+}
@@ -17,6 +17,7 @@
 package api
 
 import (
+	"bytes"
 	"io"
 	"os"
 	"path/filepath"
@@ -55,6 +56,8 @@ func MergeRaw(rsc []io.ReadSeeker, w io.Writer, dividerPage bool, conf *model.Co
 
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
 	}
 	conf.Cmd = model.MERGECREATE
 	conf.ValidationMode = model.ValidationRelaxed
@@ -73,6 +76,10 @@ func MergeRaw(rsc []io.ReadSeeker, w io.Writer, dividerPage bool, conf *model.Co
 		}
 	}
 
+	if err = pdfcpu.ApplyDocIdentity(ctxDest, conf.MergeIdentity); err != nil {
+		return err
+	}
+
 	if conf.OptimizeBeforeWriting {
 		if err = OptimizeContext(ctxDest); err != nil {
 			return err
@@ -101,29 +108,54 @@ func prepDestContext(destFile string, rs io.ReadSeeker, conf *model.Configuratio
 	return ctxDest, nil
 }
 
-func appendFile(fName string, ctxDest *model.Context, dividerPage bool) error {
+// maxOpenMergeFiles limits the number of merge sources kept open for copying through image streams.
+const maxOpenMergeFiles = 256
+
+// appendFile appends fName to ctxDest and returns the still open file
+// since unmodified image streams get copied through from there while writing ctxDest.
+func appendFile(fName string, ctxDest *model.Context, dividerPage bool) (*os.File, error) {
 	f, err := os.Open(fName)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	defer f.Close()
 
 	if log.CLIEnabled() {
 		log.CLI.Println(fName)
 	}
-	return appendTo(f, filepath.Base(fName), ctxDest, dividerPage)
+
+	if err := appendTo(f, filepath.Base(fName), ctxDest, dividerPage); err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	return f, nil
+}
+
+func closeFiles(ff []*os.File) {
+	for _, f := range ff {
+		f.Close()
+	}
 }
 
 // Merge concatenates inFiles.
 // if destFile is supplied it appends the result to destfile (=MERGEAPPEND)
 // if no destFile supplied it writes the result to the first entry of inFiles (=MERGECREATE).
 func Merge(destFile string, inFiles []string, w io.Writer, conf *model.Configuration, dividerPage bool) error {
+	_, err := MergeWithReport(destFile, inFiles, w, conf, dividerPage)
+	return err
+}
+
+// MergeWithReport concatenates inFiles like Merge and returns the actions taken resolving
+// colliding document-level names according to conf.MergeConflicts.
+func MergeWithReport(destFile string, inFiles []string, w io.Writer, conf *model.Configuration, dividerPage bool) ([]string, error) {
 	if w == nil {
-		return errors.New("pdfcpu: Merge: Please provide w")
+		return nil, errors.New("pdfcpu: Merge: Please provide w")
 	}
 
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
 	}
 	conf.Cmd = model.MERGECREATE
 	conf.ValidationMode = model.ValidationRelaxed
@@ -141,7 +173,7 @@ func Merge(destFile string, inFiles []string, w io.Writer, conf *model.Configura
 
 	f, err := os.Open(destFile)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer f.Close()
 
@@ -153,26 +185,60 @@ func Merge(destFile string, inFiles []string, w io.Writer, conf *model.Configura
 
 	ctxDest, err := prepDestContext(destFile, f, conf)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
+	var ff []*os.File
+	defer func() {
+		closeFiles(ff)
+	}()
+
 	for _, fName := range inFiles {
-		if err := appendFile(fName, ctxDest, dividerPage); err != nil {
-			return err
+		f, err := appendFile(fName, ctxDest, dividerPage)
+		if err != nil {
+			return nil, err
+		}
+		ff = append(ff, f)
+		if len(ff) == maxOpenMergeFiles {
+			if err := pdfcpu.LoadDeferredStreams(ctxDest); err != nil {
+				return nil, err
+			}
+			closeFiles(ff)
+			ff = nil
 		}
 	}
 
+	if log.CLIEnabled() {
+		for _, s := range ctxDest.MergeReport {
+			log.CLI.Println(s)
+		}
+	}
+
+	if err := pdfcpu.ApplyDocIdentity(ctxDest, conf.MergeIdentity); err != nil {
+		return nil, err
+	}
+
 	if conf.OptimizeBeforeWriting {
 		if err := OptimizeContext(ctxDest); err != nil {
-			return err
+			return nil, err
 		}
 	}
 
-	return WriteContext(ctxDest, w)
+	return ctxDest.MergeReport, WriteContext(ctxDest, w)
 }
 
-// MergeCreateFile merges inFiles and writes the result to outFile.
+// MergeCreateFile merges inFiles and writes the result to outFile or stdout if outFile is StdIO.
 func MergeCreateFile(inFiles []string, outFile string, dividerPage bool, conf *model.Configuration) (err error) {
+	if dryRun(conf) {
+		return Merge("", inFiles, io.Discard, conf, dividerPage)
+	}
+
+	if outFile == StdIO {
+		return writeStdout(func(w io.Writer) error {
+			return Merge("", inFiles, w, conf, dividerPage)
+		})
+	}
+
 	f, err := os.Create(outFile)
 	if err != nil {
 		return err
@@ -197,10 +263,18 @@ func MergeCreateFile(inFiles []string, outFile string, dividerPage bool, conf *m
 
 // MergeAppendFile appends inFiles to outFile.
 func MergeAppendFile(inFiles []string, outFile string, dividerPage bool, conf *model.Configuration) (err error) {
+	var f *os.File
 	tmpFile := outFile
 	overWrite := false
 	destFile := ""
 
+	if dryRun(conf) {
+		if fileExists(outFile) {
+			destFile = outFile
+		}
+		return Merge(destFile, inFiles, io.Discard, conf, dividerPage)
+	}
+
 	if fileExists(outFile) {
 		overWrite = true
 		destFile = outFile
@@ -208,12 +282,34 @@ func MergeAppendFile(inFiles []string, outFile string, dividerPage bool, conf *m
 		if log.CLIEnabled() {
 			log.CLI.Printf("appending to %s...\n", outFile)
 		}
+		if backup(conf) {
+			if err = backupFile(outFile, conf); err != nil {
+				return err
+			}
+		}
+		if memoryOnly(conf) {
+			// NOTE: Overwriting outFile in place is not atomic.
+			var buf bytes.Buffer
+			if err = Merge(destFile, inFiles, &buf, conf, dividerPage); err != nil {
+				return err
+			}
+			if err = verifyOutput(bytes.NewReader(buf.Bytes()), conf); err != nil {
+				return err
+			}
+			if f, err = os.OpenFile(outFile, os.O_WRONLY, 0); err != nil {
+				return err
+			}
+			err = overwrite(f, buf.Bytes())
+			if err1 := f.Close(); err == nil {
+				err = err1
+			}
+			return err
+		}
 	} else {
 		logWritingTo(outFile)
 	}
 
-	f, err := os.Create(tmpFile)
-	if err != nil {
+	if f, err = os.Create(tmpFile); err != nil {
 		return err
 	}
 
@@ -229,6 +325,10 @@ func MergeAppendFile(inFiles []string, outFile string, dividerPage bool, conf *m
 			return
 		}
 		if overWrite {
+			if err = verifyOutputFile(tmpFile, conf); err != nil {
+				os.Remove(tmpFile)
+				return
+			}
 			err = os.Rename(tmpFile, outFile)
 		}
 	}()
@@ -251,6 +351,8 @@ func MergeCreateZip(rs1, rs2 io.ReadSeeker, w io.Writer, conf *model.Configurati
 
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
 	}
 	conf.Cmd = model.MERGECREATEZIP
 	conf.ValidationMode = model.ValidationRelaxed
@@ -280,6 +382,10 @@ func MergeCreateZip(rs1, rs2 io.ReadSeeker, w io.Writer, conf *model.Configurati
 		return err
 	}
 
+	if err := pdfcpu.ApplyDocIdentity(ctxDest, conf.MergeIdentity); err != nil {
+		return err
+	}
+
 	if conf.OptimizeBeforeWriting {
 		if err := OptimizeContext(ctxDest); err != nil {
 			return err
@@ -94,6 +94,8 @@ func NUpFromImage(conf *model.Configuration, imageFileNames []string, nup *model
 func NUp(rs io.ReadSeeker, w io.Writer, imgFiles, selectedPages []string, nup *model.NUp, conf *model.Configuration) error {
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
 	}
 	conf.Cmd = model.NUP
 
@@ -118,7 +120,7 @@ func NUp(rs io.ReadSeeker, w io.Writer, imgFiles, selectedPages []string, nup *m
 			return err
 		}
 
-		pages, err := PagesForPageSelection(ctx.PageCount, selectedPages, true, true)
+		pages, err := PagesForPageSelectionWithContext(ctx, selectedPages, true, true)
 		if err != nil {
 			return err
 		}
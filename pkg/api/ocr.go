@@ -0,0 +1,96 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+	"os"
+	"sort"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+// ApplyOCR adds invisible text for the hOCR or ALTO results read from ocr to selected pages of rs
+// and writes the result to w. The i-th OCR page is applied to the i-th selected page.
+// Word coordinates are scaled from image space onto the visible region of each page.
+// Words with a confidence below minConfidence are skipped.
+func ApplyOCR(rs io.ReadSeeker, ocr io.Reader, w io.Writer, selectedPages []string, minConfidence float64, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: ApplyOCR: missing rs")
+	}
+
+	if ocr == nil {
+		return errors.New("pdfcpu: ApplyOCR: missing ocr")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
+	}
+	conf.Cmd = model.ADDTEXTLAYER
+
+	pp, err := pdfcpu.ParseOCR(ocr)
+	if err != nil {
+		return err
+	}
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	pages, err := PagesForPageSelectionWithContext(ctx, selectedPages, true, true)
+	if err != nil {
+		return err
+	}
+
+	pageNrs := []int{}
+	for i, v := range pages {
+		if v {
+			pageNrs = append(pageNrs, i)
+		}
+	}
+	sort.Ints(pageNrs)
+
+	layers, err := pdfcpu.OCRTextLayers(ctx, pp, pageNrs)
+	if err != nil {
+		return err
+	}
+
+	if err := pdfcpu.AddTextLayers(ctx, layers, minConfidence); err != nil {
+		return err
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// ApplyOCRFile adds invisible text for the hOCR or ALTO results of ocrFile to selected pages of inFile
+// and writes the result to outFile.
+func ApplyOCRFile(inFile, ocrFile, outFile string, selectedPages []string, minConfidence float64, conf *model.Configuration) error {
+	f, err := os.Open(ocrFile)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return ApplyOCR(rs, f, w, selectedPages, minConfidence, conf)
+	})
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+	"os"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+// ListOpenAction lists rs's open action, page mode and page layout.
+func ListOpenAction(rs io.ReadSeeker, conf *model.Configuration) ([]string, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: ListOpenAction: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
+		conf.ValidationMode = model.ValidationRelaxed
+	}
+	conf.Cmd = model.LISTOPENACTION
+
+	ctx, err := ReadAndValidate(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return pdfcpu.ListInitialView(ctx)
+}
+
+// ListOpenActionFile lists inFile's open action, page mode and page layout.
+func ListOpenActionFile(inFile string, conf *model.Configuration) ([]string, error) {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ListOpenAction(f, conf)
+}
+
+// SetOpenAction sets rs's open action, page mode and page layout according to iv and writes the result to w.
+func SetOpenAction(rs io.ReadSeeker, w io.Writer, iv pdfcpu.InitialView, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: SetOpenAction: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
+		conf.ValidationMode = model.ValidationRelaxed
+	}
+	conf.Cmd = model.SETOPENACTION
+
+	ctx, err := ReadAndValidate(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	if err := pdfcpu.SetInitialView(ctx, iv); err != nil {
+		return err
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// SetOpenActionFile sets inFile's open action, page mode and page layout according to iv and writes the result to outFile.
+func SetOpenActionFile(inFile, outFile string, iv pdfcpu.InitialView, conf *model.Configuration) (err error) {
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return SetOpenAction(rs, w, iv, conf)
+	})
+}
+
+// ResetOpenAction removes rs's open action, page mode and page layout and writes the result to w.
+func ResetOpenAction(rs io.ReadSeeker, w io.Writer, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: ResetOpenAction: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
+		conf.ValidationMode = model.ValidationRelaxed
+	}
+	conf.Cmd = model.RESETOPENACTION
+
+	ctx, err := ReadAndValidate(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	pdfcpu.ResetInitialView(ctx)
+
+	return Write(ctx, w, conf)
+}
+
+// ResetOpenActionFile removes inFile's open action, page mode and page layout and writes the result to outFile.
+func ResetOpenActionFile(inFile, outFile string, conf *model.Configuration) (err error) {
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return ResetOpenAction(rs, w, conf)
+	})
+}
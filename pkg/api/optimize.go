@@ -18,7 +18,6 @@ package api
 
 import (
 	"io"
-	"os"
 
 	"github.com/pdfcpu/pdfcpu/pkg/log"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
@@ -26,10 +25,9 @@ import (
 	"github.com/pkg/errors"
 )
 
-// Optimize reads a PDF stream from rs and writes the optimized PDF stream to w.
-func Optimize(rs io.ReadSeeker, w io.Writer, conf *model.Configuration) error {
+func optimize(rs io.ReadSeeker, w io.Writer, conf *model.Configuration) (*pdfcpu.OptimizeReport, error) {
 	if rs == nil {
-		return errors.New("pdfcpu: Optimize: missing rs")
+		return nil, errors.New("pdfcpu: Optimize: missing rs")
 	}
 
 	if conf == nil {
@@ -38,7 +36,7 @@ func Optimize(rs io.ReadSeeker, w io.Writer, conf *model.Configuration) error {
 
 	ctx, err := ReadValidateAndOptimize(rs, conf)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if log.StatsEnabled() {
@@ -46,64 +44,57 @@ func Optimize(rs io.ReadSeeker, w io.Writer, conf *model.Configuration) error {
 	}
 
 	if err = WriteContext(ctx, w); err != nil {
-		return err
+		return nil, err
 	}
 
 	// For Optimize only.
 	if ctx.StatsFileName != "" {
-		err = pdfcpu.AppendStatsFile(ctx)
-		if err != nil {
-			return errors.Wrap(err, "Write stats failed.")
+		if err = pdfcpu.WriteOptimizeReportFile(ctx); err != nil {
+			return nil, errors.Wrap(err, "Write stats failed.")
 		}
 	}
 
-	return nil
+	return pdfcpu.NewOptimizeReport(ctx), nil
+}
+
+// Optimize reads a PDF stream from rs and writes the optimized PDF stream to w.
+func Optimize(rs io.ReadSeeker, w io.Writer, conf *model.Configuration) error {
+	_, err := optimize(rs, w, conf)
+	return err
+}
+
+// OptimizeWithReport reads a PDF stream from rs and writes the optimized PDF stream to w.
+// It returns the savings of the optimization passes configured in conf.OptimizePasses.
+// If conf.StatsFileName is set the report is also written to this JSON file.
+func OptimizeWithReport(rs io.ReadSeeker, w io.Writer, conf *model.Configuration) (*pdfcpu.OptimizeReport, error) {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
+	}
+	conf.Cmd = model.OPTIMIZE
+
+	return optimize(rs, w, conf)
 }
 
 // OptimizeFile reads inFile and writes the optimized PDF to outFile.
 // If outFile is not provided then inFile gets overwritten
 // which leads to the same result as when inFile equals outFile.
 func OptimizeFile(inFile, outFile string, conf *model.Configuration) (err error) {
-	var f1, f2 *os.File
-
-	if f1, err = os.Open(inFile); err != nil {
-		return err
-	}
-
-	tmpFile := inFile + ".tmp"
 	if outFile != "" && inFile != outFile {
-		tmpFile = outFile
 		logWritingTo(outFile)
 	} else {
 		logWritingTo(inFile)
 	}
 
-	if f2, err = os.Create(tmpFile); err != nil {
-		return err
-	}
-
-	defer func() {
-		if err != nil {
-			f2.Close()
-			f1.Close()
-			os.Remove(tmpFile)
-			return
-		}
-		if err = f2.Close(); err != nil {
-			return
-		}
-		if err = f1.Close(); err != nil {
-			return
-		}
-		if outFile == "" || inFile == outFile {
-			err = os.Rename(tmpFile, inFile)
-		}
-	}()
-
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
 	}
 	conf.Cmd = model.OPTIMIZE
 
-	return Optimize(f1, f2, conf)
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return Optimize(rs, w, conf)
+	})
 }
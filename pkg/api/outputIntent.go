@@ -0,0 +1,193 @@
+/*
+	Copyright 2026 The pdfcpu Authors.
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+	"os"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+// OutputIntents returns rs's output intents.
+func OutputIntents(rs io.ReadSeeker, conf *model.Configuration) ([]pdfcpu.OutputIntent, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: OutputIntents: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
+		conf.ValidationMode = model.ValidationRelaxed
+	}
+	conf.Cmd = model.LISTOUTPUTINTENTS
+
+	ctx, err := ReadAndValidate(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return pdfcpu.OutputIntents(ctx)
+}
+
+// OutputIntentsFile returns inFile's output intents.
+func OutputIntentsFile(inFile string, conf *model.Configuration) ([]pdfcpu.OutputIntent, error) {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return OutputIntents(f, conf)
+}
+
+// ListOutputIntents lists rs's output intents.
+func ListOutputIntents(rs io.ReadSeeker, conf *model.Configuration) ([]string, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: ListOutputIntents: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
+		conf.ValidationMode = model.ValidationRelaxed
+	}
+	conf.Cmd = model.LISTOUTPUTINTENTS
+
+	ctx, err := ReadAndValidate(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return pdfcpu.ListOutputIntents(ctx)
+}
+
+// ListOutputIntentsFile lists inFile's output intents.
+func ListOutputIntentsFile(inFile string, conf *model.Configuration) ([]string, error) {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ListOutputIntents(f, conf)
+}
+
+// AddOutputIntent adds an output intent for subtype with the ICC profile read from profile to rs and writes the result to w.
+// An existing output intent for subtype gets replaced.
+// outputCondition is the registered characterized printing condition of the profile and defaults to "Custom".
+func AddOutputIntent(rs io.ReadSeeker, w io.Writer, profile io.Reader, subtype, outputCondition string, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: AddOutputIntent: missing rs")
+	}
+
+	if profile == nil {
+		return errors.New("pdfcpu: AddOutputIntent: missing profile")
+	}
+
+	subtype, err := pdfcpu.ParseOutputIntentSubtype(subtype)
+	if err != nil {
+		return err
+	}
+
+	bb, err := io.ReadAll(profile)
+	if err != nil {
+		return err
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
+		conf.ValidationMode = model.ValidationRelaxed
+	}
+	conf.Cmd = model.ADDOUTPUTINTENT
+
+	ctx, err := ReadAndValidate(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	if err := pdfcpu.AddOutputIntent(ctx, subtype, outputCondition, bb); err != nil {
+		return err
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// AddOutputIntentFile adds an output intent for subtype with the ICC profile iccFile to inFile and writes the result to outFile.
+func AddOutputIntentFile(inFile, outFile, iccFile, subtype, outputCondition string, conf *model.Configuration) (err error) {
+	f, err := os.Open(iccFile)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return AddOutputIntent(rs, w, f, subtype, outputCondition, conf)
+	})
+}
+
+// RemoveOutputIntents removes rs's output intents for subtype or all output intents for an empty subtype
+// and writes the result to w.
+func RemoveOutputIntents(rs io.ReadSeeker, w io.Writer, subtype string, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: RemoveOutputIntents: missing rs")
+	}
+
+	if subtype != "" {
+		var err error
+		if subtype, err = pdfcpu.ParseOutputIntentSubtype(subtype); err != nil {
+			return err
+		}
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
+		conf.ValidationMode = model.ValidationRelaxed
+	}
+	conf.Cmd = model.REMOVEOUTPUTINTENTS
+
+	ctx, err := ReadAndValidate(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	ok, err := pdfcpu.RemoveOutputIntents(ctx, subtype)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New("pdfcpu: no output intents removed")
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// RemoveOutputIntentsFile removes inFile's output intents for subtype or all output intents for an empty subtype
+// and writes the result to outFile.
+func RemoveOutputIntentsFile(inFile, outFile, subtype string, conf *model.Configuration) (err error) {
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return RemoveOutputIntents(rs, w, subtype, conf)
+	})
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+	"os"
+
+	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+// Overlay composites selected pages of rs with the corresponding pages of rsOverlay and writes the result to w.
+// The overlay pages are rendered on top of the page content or underneath if under is true (eg. a letterhead).
+// mode controls selected pages beyond the overlay page count: model.PdfMultiRepeat, model.PdfMultiCycle or model.PdfMultiOnce.
+func Overlay(rs, rsOverlay io.ReadSeeker, w io.Writer, selectedPages []string, under bool, mode int, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: Overlay: missing rs")
+	}
+
+	if rsOverlay == nil {
+		return errors.New("pdfcpu: Overlay: missing rsOverlay")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
+	}
+	conf.Cmd = model.OVERLAY
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	otherCtx, err := ReadAndValidate(rsOverlay, model.NewDefaultConfiguration())
+	if err != nil {
+		return err
+	}
+
+	pages, err := PagesForPageSelectionWithContext(ctx, selectedPages, true, true)
+	if err != nil {
+		return err
+	}
+
+	if err = pdfcpu.Overlay(ctx, otherCtx, pages, under, mode); err != nil {
+		return err
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// OverlayFile composites selected pages of inFile with the corresponding pages of overlayFile and writes the result to outFile.
+func OverlayFile(inFile, overlayFile, outFile string, selectedPages []string, under bool, mode int, conf *model.Configuration) (err error) {
+	if log.CLIEnabled() {
+		s := "overlaying"
+		if under {
+			s = "underlaying"
+		}
+		log.CLI.Printf("%s %s with %s\n", s, inFile, overlayFile)
+	}
+
+	if outFile != "" && inFile != outFile {
+		logWritingTo(outFile)
+	} else {
+		logWritingTo(inFile)
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
+	}
+	conf.Cmd = model.OVERLAY
+
+	f, err := os.Open(overlayFile)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return Overlay(rs, f, w, selectedPages, under, mode, conf)
+	})
+}
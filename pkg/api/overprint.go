@@ -0,0 +1,121 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+	"os"
+	"sort"
+
+	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+// Overprint returns the overprint settings and trapping status of rs along with the pages using overprint.
+func Overprint(rs io.ReadSeeker, conf *model.Configuration) (*pdfcpu.OverprintReport, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: Overprint: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
+	}
+	conf.Cmd = model.LISTOVERPRINT
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return pdfcpu.Overprint(ctx)
+}
+
+// ListOverprint returns a printable report of the overprint settings and trapping status of rs.
+func ListOverprint(rs io.ReadSeeker, conf *model.Configuration) ([]string, error) {
+	r, err := Overprint(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+	return pdfcpu.ListOverprint(r), nil
+}
+
+// ListOverprintFile returns a printable report of the overprint settings and trapping status of inFile.
+func ListOverprintFile(inFile string, conf *model.Configuration) ([]string, error) {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ListOverprint(f, conf)
+}
+
+// SetOverprint applies oc to the graphics states used by selected pages of rs and writes the result to w.
+func SetOverprint(rs io.ReadSeeker, w io.Writer, selectedPages []string, oc *pdfcpu.OverprintConfig, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: SetOverprint: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
+	}
+	conf.Cmd = model.SETOVERPRINT
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	var pageNrs []int
+
+	if len(selectedPages) > 0 {
+		pages, err := PagesForPageSelectionWithContext(ctx, selectedPages, true, true)
+		if err != nil {
+			return err
+		}
+		for i, v := range pages {
+			if v {
+				pageNrs = append(pageNrs, i)
+			}
+		}
+		sort.Ints(pageNrs)
+	}
+
+	n, err := pdfcpu.SetOverprint(ctx, pageNrs, oc)
+	if err != nil {
+		return err
+	}
+
+	if log.CLIEnabled() && (oc.Stroke != nil || oc.Fill != nil || oc.Mode != nil) {
+		log.CLI.Printf("%d graphics state(s) changed\n", n)
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// SetOverprintFile applies oc to the graphics states used by selected pages of inFile and writes the result to outFile.
+func SetOverprintFile(inFile, outFile string, selectedPages []string, oc *pdfcpu.OverprintConfig, conf *model.Configuration) error {
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return SetOverprint(rs, w, selectedPages, oc, conf)
+	})
+}
@@ -36,6 +36,8 @@ func InsertPages(rs io.ReadSeeker, w io.Writer, selectedPages []string, before b
 
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
 	}
 	conf.Cmd = model.INSERTPAGESAFTER
 	if before {
@@ -47,7 +49,7 @@ func InsertPages(rs io.ReadSeeker, w io.Writer, selectedPages []string, before b
 		return err
 	}
 
-	pages, err := PagesForPageSelection(ctx.PageCount, selectedPages, true, true)
+	pages, err := PagesForPageSelectionWithContext(ctx, selectedPages, true, true)
 	if err != nil {
 		return err
 	}
@@ -66,43 +68,15 @@ func InsertPages(rs io.ReadSeeker, w io.Writer, selectedPages []string, before b
 
 // InsertPagesFile inserts a blank page before or after every inFile page selected and writes the result to w.
 func InsertPagesFile(inFile, outFile string, selectedPages []string, before bool, pageConf *pdfcpu.PageConfiguration, conf *model.Configuration) (err error) {
-	var f1, f2 *os.File
-
-	if f1, err = os.Open(inFile); err != nil {
-		return err
-	}
-
-	tmpFile := inFile + ".tmp"
 	if outFile != "" && inFile != outFile {
-		tmpFile = outFile
 		logWritingTo(outFile)
 	} else {
 		logWritingTo(inFile)
 	}
-	if f2, err = os.Create(tmpFile); err != nil {
-		f1.Close()
-		return err
-	}
 
-	defer func() {
-		if err != nil {
-			f2.Close()
-			f1.Close()
-			os.Remove(tmpFile)
-			return
-		}
-		if err = f2.Close(); err != nil {
-			return
-		}
-		if err = f1.Close(); err != nil {
-			return
-		}
-		if outFile == "" || inFile == outFile {
-			err = os.Rename(tmpFile, inFile)
-		}
-	}()
-
-	return InsertPages(f1, f2, selectedPages, before, pageConf, conf)
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return InsertPages(rs, w, selectedPages, before, pageConf, conf)
+	})
 }
 
 // RemovePages removes selected pages from rs and writes the result to w.
@@ -113,6 +87,8 @@ func RemovePages(rs io.ReadSeeker, w io.Writer, selectedPages []string, conf *mo
 
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
 	}
 	conf.Cmd = model.REMOVEPAGES
 
@@ -121,6 +97,10 @@ func RemovePages(rs io.ReadSeeker, w io.Writer, selectedPages []string, conf *mo
 		return err
 	}
 
+	if selectedPages, err = ResolvePageSelection(ctx, selectedPages); err != nil {
+		return err
+	}
+
 	pages, err := RemainingPagesForPageRemoval(ctx.PageCount, selectedPages, true)
 	if err != nil {
 		return err
@@ -151,43 +131,59 @@ func RemovePages(rs io.ReadSeeker, w io.Writer, selectedPages []string, conf *mo
 
 // RemovePagesFile removes selected inFile pages and writes the result to outFile..
 func RemovePagesFile(inFile, outFile string, selectedPages []string, conf *model.Configuration) (err error) {
-	var f1, f2 *os.File
+	if outFile != "" && inFile != outFile {
+		logWritingTo(outFile)
+	} else {
+		logWritingTo(inFile)
+	}
+
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return RemovePages(rs, w, selectedPages, conf)
+	})
+}
 
-	if f1, err = os.Open(inFile); err != nil {
+// FlattenPageAttrs pushes inherited page attributes (Resources, MediaBox, CropBox, Rotate) of rs down into each page
+// and removes them from the intermediate nodes of the page tree. The result is written to w.
+func FlattenPageAttrs(rs io.ReadSeeker, w io.Writer, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: FlattenPageAttrs: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
+	}
+	conf.Cmd = model.FLATTENPAGEATTRS
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	n, err := pdfcpu.FlattenInheritedPageAttrs(ctx)
+	if err != nil {
 		return err
 	}
 
-	tmpFile := inFile + ".tmp"
+	if log.CLIEnabled() {
+		log.CLI.Printf("%d page(s) modified\n", n)
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// FlattenPageAttrsFile pushes inherited page attributes of inFile down into each page and writes the result to outFile.
+func FlattenPageAttrsFile(inFile, outFile string, conf *model.Configuration) (err error) {
 	if outFile != "" && inFile != outFile {
-		tmpFile = outFile
 		logWritingTo(outFile)
 	} else {
 		logWritingTo(inFile)
 	}
-	if f2, err = os.Create(tmpFile); err != nil {
-		f1.Close()
-		return err
-	}
-
-	defer func() {
-		if err != nil {
-			f2.Close()
-			f1.Close()
-			os.Remove(tmpFile)
-			return
-		}
-		if err = f2.Close(); err != nil {
-			return
-		}
-		if err = f1.Close(); err != nil {
-			return
-		}
-		if outFile == "" || inFile == outFile {
-			err = os.Rename(tmpFile, inFile)
-		}
-	}()
 
-	return RemovePages(f1, f2, selectedPages, conf)
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return FlattenPageAttrs(rs, w, conf)
+	})
 }
 
 // PageCount returns rs's page count.
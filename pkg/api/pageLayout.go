@@ -34,6 +34,7 @@ func PageLayout(rs io.ReadSeeker, conf *model.Configuration) (*model.PageLayout,
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
 	} else {
+		conf = conf.Clone()
 		conf.ValidationMode = model.ValidationRelaxed
 	}
 	conf.Cmd = model.LISTPAGELAYOUT
@@ -66,6 +67,7 @@ func ListPageLayout(rs io.ReadSeeker, conf *model.Configuration) ([]string, erro
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
 	} else {
+		conf = conf.Clone()
 		conf.ValidationMode = model.ValidationRelaxed
 	}
 	conf.Cmd = model.LISTPAGELAYOUT
@@ -102,6 +104,7 @@ func SetPageLayout(rs io.ReadSeeker, w io.Writer, val model.PageLayout, conf *mo
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
 	} else {
+		conf = conf.Clone()
 		conf.ValidationMode = model.ValidationRelaxed
 	}
 	conf.Cmd = model.SETPAGELAYOUT
@@ -118,40 +121,9 @@ func SetPageLayout(rs io.ReadSeeker, w io.Writer, val model.PageLayout, conf *mo
 
 // SetPageLayoutFile sets inFile's page layout and writes the result to outFile.
 func SetPageLayoutFile(inFile, outFile string, val model.PageLayout, conf *model.Configuration) (err error) {
-	var f1, f2 *os.File
-
-	if f1, err = os.Open(inFile); err != nil {
-		return err
-	}
-
-	tmpFile := inFile + ".tmp"
-	if outFile != "" && inFile != outFile {
-		tmpFile = outFile
-	}
-	if f2, err = os.Create(tmpFile); err != nil {
-		f1.Close()
-		return err
-	}
-
-	defer func() {
-		if err != nil {
-			f2.Close()
-			f1.Close()
-			os.Remove(tmpFile)
-			return
-		}
-		if err = f2.Close(); err != nil {
-			return
-		}
-		if err = f1.Close(); err != nil {
-			return
-		}
-		if outFile == "" || inFile == outFile {
-			err = os.Rename(tmpFile, inFile)
-		}
-	}()
-
-	return SetPageLayout(f1, f2, val, conf)
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return SetPageLayout(rs, w, val, conf)
+	})
 }
 
 // ResetPageLayout resets rs's page layout and writes the result to w.
@@ -163,6 +135,7 @@ func ResetPageLayout(rs io.ReadSeeker, w io.Writer, conf *model.Configuration) e
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
 	} else {
+		conf = conf.Clone()
 		conf.ValidationMode = model.ValidationRelaxed
 	}
 	conf.Cmd = model.RESETPAGELAYOUT
@@ -179,38 +152,7 @@ func ResetPageLayout(rs io.ReadSeeker, w io.Writer, conf *model.Configuration) e
 
 // ResetPageLayoutFile resets inFile's page layout and writes the result to outFile.
 func ResetPageLayoutFile(inFile, outFile string, conf *model.Configuration) (err error) {
-	var f1, f2 *os.File
-
-	if f1, err = os.Open(inFile); err != nil {
-		return err
-	}
-
-	tmpFile := inFile + ".tmp"
-	if outFile != "" && inFile != outFile {
-		tmpFile = outFile
-	}
-	if f2, err = os.Create(tmpFile); err != nil {
-		f1.Close()
-		return err
-	}
-
-	defer func() {
-		if err != nil {
-			f2.Close()
-			f1.Close()
-			os.Remove(tmpFile)
-			return
-		}
-		if err = f2.Close(); err != nil {
-			return
-		}
-		if err = f1.Close(); err != nil {
-			return
-		}
-		if outFile == "" || inFile == outFile {
-			err = os.Rename(tmpFile, inFile)
-		}
-	}()
-
-	return ResetPageLayout(f1, f2, conf)
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return ResetPageLayout(rs, w, conf)
+	})
 }
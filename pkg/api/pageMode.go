@@ -34,6 +34,7 @@ func PageMode(rs io.ReadSeeker, conf *model.Configuration) (*model.PageMode, err
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
 	} else {
+		conf = conf.Clone()
 		conf.ValidationMode = model.ValidationRelaxed
 	}
 	conf.Cmd = model.LISTPAGEMODE
@@ -66,6 +67,7 @@ func ListPageMode(rs io.ReadSeeker, conf *model.Configuration) ([]string, error)
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
 	} else {
+		conf = conf.Clone()
 		conf.ValidationMode = model.ValidationRelaxed
 	}
 	conf.Cmd = model.LISTPAGEMODE
@@ -102,6 +104,7 @@ func SetPageMode(rs io.ReadSeeker, w io.Writer, val model.PageMode, conf *model.
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
 	} else {
+		conf = conf.Clone()
 		conf.ValidationMode = model.ValidationRelaxed
 	}
 	conf.Cmd = model.SETPAGEMODE
@@ -118,40 +121,9 @@ func SetPageMode(rs io.ReadSeeker, w io.Writer, val model.PageMode, conf *model.
 
 // SetPageModeFile sets inFile's page mode and writes the result to outFile.
 func SetPageModeFile(inFile, outFile string, val model.PageMode, conf *model.Configuration) (err error) {
-	var f1, f2 *os.File
-
-	if f1, err = os.Open(inFile); err != nil {
-		return err
-	}
-
-	tmpFile := inFile + ".tmp"
-	if outFile != "" && inFile != outFile {
-		tmpFile = outFile
-	}
-	if f2, err = os.Create(tmpFile); err != nil {
-		f1.Close()
-		return err
-	}
-
-	defer func() {
-		if err != nil {
-			f2.Close()
-			f1.Close()
-			os.Remove(tmpFile)
-			return
-		}
-		if err = f2.Close(); err != nil {
-			return
-		}
-		if err = f1.Close(); err != nil {
-			return
-		}
-		if outFile == "" || inFile == outFile {
-			err = os.Rename(tmpFile, inFile)
-		}
-	}()
-
-	return SetPageMode(f1, f2, val, conf)
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return SetPageMode(rs, w, val, conf)
+	})
 }
 
 // ResetPageMode resets rs's page mode and writes the result to w.
@@ -163,6 +135,7 @@ func ResetPageMode(rs io.ReadSeeker, w io.Writer, conf *model.Configuration) err
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
 	} else {
+		conf = conf.Clone()
 		conf.ValidationMode = model.ValidationRelaxed
 	}
 	conf.Cmd = model.RESETPAGEMODE
@@ -179,38 +152,7 @@ func ResetPageMode(rs io.ReadSeeker, w io.Writer, conf *model.Configuration) err
 
 // ResetPageModeFile resets inFile's page mode and writes the result to outFile.
 func ResetPageModeFile(inFile, outFile string, conf *model.Configuration) (err error) {
-	var f1, f2 *os.File
-
-	if f1, err = os.Open(inFile); err != nil {
-		return err
-	}
-
-	tmpFile := inFile + ".tmp"
-	if outFile != "" && inFile != outFile {
-		tmpFile = outFile
-	}
-	if f2, err = os.Create(tmpFile); err != nil {
-		f1.Close()
-		return err
-	}
-
-	defer func() {
-		if err != nil {
-			f2.Close()
-			f1.Close()
-			os.Remove(tmpFile)
-			return
-		}
-		if err = f2.Close(); err != nil {
-			return
-		}
-		if err = f1.Close(); err != nil {
-			return
-		}
-		if outFile == "" || inFile == outFile {
-			err = os.Rename(tmpFile, inFile)
-		}
-	}()
-
-	return ResetPageMode(f1, f2, conf)
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return ResetPageMode(rs, w, conf)
+	})
 }
@@ -0,0 +1,79 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+// AddPageNumbers stamps page numbers onto all selected pages of rs and writes the result to w.
+// Pages selected by pn.Skip are neither numbered nor counted.
+func AddPageNumbers(rs io.ReadSeeker, w io.Writer, selectedPages []string, pn *model.PageNumbers, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: AddPageNumbers: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
+	}
+	conf.Cmd = model.ADDPAGENUMBERS
+	conf.OptimizeDuplicateContentStreams = false
+
+	if pn == nil {
+		return errors.New("pdfcpu: missing page numbers configuration")
+	}
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	pages, err := PagesForPageSelectionWithContext(ctx, selectedPages, true, true)
+	if err != nil {
+		return err
+	}
+
+	skipPages, err := PagesForPageSelection(ctx.PageCount, pn.Skip, false, false)
+	if err != nil {
+		return err
+	}
+
+	if err = pdfcpu.AddPageNumbers(ctx, pages, skipPages, pn); err != nil {
+		return err
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// AddPageNumbersFile stamps page numbers onto all selected pages of inFile and writes the result to outFile.
+func AddPageNumbersFile(inFile, outFile string, selectedPages []string, pn *model.PageNumbers, conf *model.Configuration) (err error) {
+	if outFile != "" && inFile != outFile {
+		logWritingTo(outFile)
+	} else {
+		logWritingTo(inFile)
+	}
+
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return AddPageNumbers(rs, w, selectedPages, pn, conf)
+	})
+}
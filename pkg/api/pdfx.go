@@ -0,0 +1,140 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+
+	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+func logPreflightIssues(standard string, issues []pdfcpu.PreflightIssue) {
+	if len(issues) == 0 || !log.CLIEnabled() {
+		return
+	}
+	log.CLI.Printf("%d %s issue(s):\n", len(issues), standard)
+	for _, pi := range issues {
+		log.CLI.Printf("  %s\n", pi)
+	}
+}
+
+// preflight runs the PDF/X checks for standard as part of validation.
+func preflight(ctx *model.Context, standard string) error {
+	standard, err := pdfcpu.ParsePDFXStandard(standard)
+	if err != nil {
+		return err
+	}
+
+	issues, err := pdfcpu.Preflight(ctx, standard)
+	if err != nil {
+		return err
+	}
+
+	if len(issues) == 0 {
+		return nil
+	}
+
+	logPreflightIssues(standard, issues)
+
+	return validationError{errors.Errorf("pdfcpu: %d %s issue(s), first: %s", len(issues), standard, issues[0])}
+}
+
+// Preflight checks rs for conformance with the PDF/X standard pdfx-1a or pdfx-4 and returns all violations found.
+func Preflight(rs io.ReadSeeker, standard string, conf *model.Configuration) ([]pdfcpu.PreflightIssue, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: Preflight: missing rs")
+	}
+
+	standard, err := pdfcpu.ParsePDFXStandard(standard)
+	if err != nil {
+		return nil, err
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
+	}
+	conf.Cmd = model.VALIDATE
+
+	ctx, err := ReadAndValidate(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return pdfcpu.Preflight(ctx, standard)
+}
+
+// ConvertPDFX fixes what can be fixed without touching page content for conformance with standard,
+// writes the result to w and returns the remaining violations.
+// A missing output intent refers to the registered characterized printing condition outputCondition,
+// an empty outputCondition defaults to FOGRA39.
+func ConvertPDFX(rs io.ReadSeeker, w io.Writer, standard, outputCondition string, conf *model.Configuration) ([]pdfcpu.PreflightIssue, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: ConvertPDFX: missing rs")
+	}
+
+	standard, err := pdfcpu.ParsePDFXStandard(standard)
+	if err != nil {
+		return nil, err
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
+	}
+	conf.Cmd = model.CONVERTPDFX
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	issues, err := pdfcpu.ConvertPDFX(ctx, standard, outputCondition)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := Write(ctx, w, conf); err != nil {
+		return nil, err
+	}
+
+	return issues, nil
+}
+
+// ConvertPDFXFile fixes inFile for conformance with standard, writes the result to outFile
+// and logs the remaining violations.
+func ConvertPDFXFile(inFile, outFile, standard, outputCondition string, conf *model.Configuration) error {
+	var issues []pdfcpu.PreflightIssue
+
+	err := updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		var err error
+		issues, err = ConvertPDFX(rs, w, standard, outputCondition, conf)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	logPreflightIssues(standard, issues)
+
+	return nil
+}
@@ -32,6 +32,8 @@ func Permissions(rs io.ReadSeeker, conf *model.Configuration) (int, error) {
 
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
 	}
 	conf.Cmd = model.LISTPERMISSIONS
 
@@ -59,6 +61,7 @@ func SetPermissions(rs io.ReadSeeker, w io.Writer, conf *model.Configuration) er
 	if conf == nil {
 		return errors.New("pdfcpu: missing configuration for setting permissions")
 	}
+	conf = conf.Clone()
 	conf.Cmd = model.SETPERMISSIONS
 
 	ctx, err := ReadValidateAndOptimize(rs, conf)
@@ -77,42 +80,15 @@ func SetPermissionsFile(inFile, outFile string, conf *model.Configuration) (err
 		return errors.New("pdfcpu: missing configuration for setting permissions")
 	}
 
-	var f1, f2 *os.File
-
-	if f1, err = os.Open(inFile); err != nil {
-		return err
-	}
-
-	tmpFile := inFile + ".tmp"
 	if outFile != "" && inFile != outFile {
-		tmpFile = outFile
 		logWritingTo(outFile)
 	} else {
 		logWritingTo(inFile)
 	}
-	if f2, err = os.Create(tmpFile); err != nil {
-		return err
-	}
 
-	defer func() {
-		if err != nil {
-			f2.Close()
-			f1.Close()
-			os.Remove(tmpFile)
-			return
-		}
-		if err = f2.Close(); err != nil {
-			return
-		}
-		if err = f1.Close(); err != nil {
-			return
-		}
-		if outFile == "" || inFile == outFile {
-			err = os.Rename(tmpFile, inFile)
-		}
-	}()
-
-	return SetPermissions(f1, f2, conf)
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return SetPermissions(rs, w, conf)
+	})
 }
 
 // GetPermissions returns the permissions for rs.
@@ -0,0 +1,225 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+	"os"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// Pipeline applies a sequence of operations to a PDF which gets read once and written once.
+//
+// Invariants:
+//   - The Context is read, validated and optimized once by NewPipeline.
+//   - Operations are applied in call order.
+//     Page selections are resolved against the state left by the preceding operations.
+//   - Page count and page tree are kept current by all operations.
+//   - The font and image caches of the optimization context reflect the state after reading.
+//     Operations adding resources (eg. watermarks) do not update them, call Optimize to rebuild them.
+//   - Form fonts are cached on read (see pdfcpu.CacheFormFonts) and kept current by FillForm.
+//   - FillForm removes any digital signature since it would be invalidated anyway.
+//   - After a failed operation the Context may be partially modified.
+//     Any further operation including writing returns the original error.
+//   - A Pipeline is not safe for concurrent use.
+type Pipeline struct {
+	ctx    *model.Context
+	err    error
+	inFile string // set if read from a file, see NewPipelineFile
+}
+
+// NewPipeline reads, validates and optimizes the PDF stream read from rs.
+func NewPipeline(rs io.ReadSeeker, conf *model.Configuration) (*Pipeline, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: NewPipeline: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
+	}
+	conf.Cmd = model.PIPELINE
+	conf.OptimizeDuplicateContentStreams = false
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Pipeline{ctx: ctx}, nil
+}
+
+// NewPipelineFile reads, validates and optimizes inFile.
+func NewPipelineFile(inFile string, conf *model.Configuration) (*Pipeline, error) {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	p, err := NewPipeline(f, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	p.inFile = inFile
+
+	return p, nil
+}
+
+// Context returns the Context processed by p.
+// Modifying the Context directly is subject to the invariants of Pipeline.
+func (p *Pipeline) Context() *model.Context {
+	return p.ctx
+}
+
+// Err returns the error of the first failed operation.
+func (p *Pipeline) Err() error {
+	return p.err
+}
+
+func (p *Pipeline) do(f func(ctx *model.Context) error) error {
+	if p.err != nil {
+		return p.err
+	}
+	p.err = f(p.ctx)
+	return p.err
+}
+
+func (p *Pipeline) pages(selectedPages []string) (types.IntSet, error) {
+	return PagesForPageSelectionWithContext(p.ctx, selectedPages, true, true)
+}
+
+// AddWatermarks adds watermarks to all selected pages.
+func (p *Pipeline) AddWatermarks(selectedPages []string, wm *model.Watermark) error {
+	return p.do(func(ctx *model.Context) error {
+		if wm == nil {
+			return errors.New("pdfcpu: missing watermark configuration")
+		}
+		pages, err := p.pages(selectedPages)
+		if err != nil {
+			return err
+		}
+		return pdfcpu.AddWatermarks(ctx, pages, wm)
+	})
+}
+
+// Rotate rotates all selected pages clockwise by rotation degrees.
+func (p *Pipeline) Rotate(rotation int, selectedPages []string) error {
+	return p.do(func(ctx *model.Context) error {
+		pages, err := p.pages(selectedPages)
+		if err != nil {
+			return err
+		}
+		return pdfcpu.RotatePages(ctx, pages, rotation)
+	})
+}
+
+// FillForm populates the form with JSON data from rd.
+func (p *Pipeline) FillForm(rd io.Reader) error {
+	return p.do(func(ctx *model.Context) error {
+		if rd == nil {
+			return errors.New("pdfcpu: FillForm: missing rd")
+		}
+		return fillFormContext(ctx, rd)
+	})
+}
+
+// AddBookmarks adds bms and replaces any existing bookmarks if replace is true.
+func (p *Pipeline) AddBookmarks(bms []pdfcpu.Bookmark, replace bool) error {
+	return p.do(func(ctx *model.Context) error {
+		if len(bms) == 0 {
+			return errors.New("pdfcpu: AddBookmarks: missing bms")
+		}
+		return pdfcpu.AddBookmarks(ctx, bms, replace)
+	})
+}
+
+// Optimize optimizes the Context and rebuilds the font and image caches.
+func (p *Pipeline) Optimize() error {
+	return p.do(OptimizeContext)
+}
+
+// Validate validates the Context.
+func (p *Pipeline) Validate() error {
+	return p.do(ValidateContext)
+}
+
+// Write writes the result to w.
+func (p *Pipeline) Write(w io.Writer) error {
+	return p.do(func(ctx *model.Context) error {
+		return Write(ctx, w, ctx.Configuration)
+	})
+}
+
+// WriteFile writes the result to outFile.
+// If outFile is empty or equals the file the Pipeline has been read from, this file gets replaced
+// subject to conf.MemoryOnly, conf.Backup and conf.PostProcessValidate (see updateFile).
+func (p *Pipeline) WriteFile(outFile string) error {
+	if p.err != nil {
+		return p.err
+	}
+
+	inFile := p.inFile
+	if inFile == "" {
+		// Read from a stream, there is no file to replace.
+		if outFile == "" {
+			return errors.New("pdfcpu: WriteFile: missing outFile")
+		}
+		return p.writeNewFile(outFile)
+	}
+
+	if outFile != "" && inFile != outFile {
+		logWritingTo(outFile)
+	} else {
+		logWritingTo(inFile)
+	}
+
+	// The Pipeline has already been read, fn only writes the result.
+	return updateFile(inFile, outFile, p.ctx.Configuration, func(_ io.ReadSeeker, w io.Writer) error {
+		return p.Write(w)
+	})
+}
+
+func (p *Pipeline) writeNewFile(outFile string) (err error) {
+	logWritingTo(outFile)
+
+	if dryRun(p.ctx.Configuration) {
+		return p.Write(io.Discard)
+	}
+
+	f, err := os.Create(outFile)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err1 := f.Close(); err == nil {
+			err = err1
+		}
+		if err != nil {
+			os.Remove(outFile)
+		}
+	}()
+
+	return p.Write(f)
+}
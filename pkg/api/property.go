@@ -22,6 +22,7 @@ import (
 
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/validate"
 	"github.com/pkg/errors"
 )
 
@@ -34,6 +35,8 @@ func Properties(rs io.ReadSeeker, conf *model.Configuration) (map[string]string,
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
 		conf.ValidationMode = model.ValidationRelaxed
+	} else {
+		conf = conf.Clone()
 	}
 	conf.Cmd = model.LISTPROPERTIES
 
@@ -54,6 +57,7 @@ func AddProperties(rs io.ReadSeeker, w io.Writer, properties map[string]string,
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
 	} else {
+		conf = conf.Clone()
 		conf.ValidationMode = model.ValidationRelaxed
 	}
 	conf.Cmd = model.ADDPROPERTIES
@@ -72,40 +76,9 @@ func AddProperties(rs io.ReadSeeker, w io.Writer, properties map[string]string,
 
 // AddPropertiesFile adds properties to inFile's infodict and writes the result to outFile.
 func AddPropertiesFile(inFile, outFile string, properties map[string]string, conf *model.Configuration) (err error) {
-	var f1, f2 *os.File
-
-	if f1, err = os.Open(inFile); err != nil {
-		return err
-	}
-
-	tmpFile := inFile + ".tmp"
-	if outFile != "" && inFile != outFile {
-		tmpFile = outFile
-	}
-	if f2, err = os.Create(tmpFile); err != nil {
-		f1.Close()
-		return err
-	}
-
-	defer func() {
-		if err != nil {
-			f2.Close()
-			f1.Close()
-			os.Remove(tmpFile)
-			return
-		}
-		if err = f2.Close(); err != nil {
-			return
-		}
-		if err = f1.Close(); err != nil {
-			return
-		}
-		if outFile == "" || inFile == outFile {
-			err = os.Rename(tmpFile, inFile)
-		}
-	}()
-
-	return AddProperties(f1, f2, properties, conf)
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return AddProperties(rs, w, properties, conf)
+	})
 }
 
 // RemoveProperties deletes properties from rs's infodict and writes the result to w.
@@ -117,6 +90,7 @@ func RemoveProperties(rs io.ReadSeeker, w io.Writer, properties []string, conf *
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
 	} else {
+		conf = conf.Clone()
 		conf.ValidationMode = model.ValidationRelaxed
 	}
 	conf.Cmd = model.REMOVEPROPERTIES
@@ -139,38 +113,89 @@ func RemoveProperties(rs io.ReadSeeker, w io.Writer, properties []string, conf *
 
 // RemovePropertiesFile deletes properties from inFile's infodict and writes the result to outFile.
 func RemovePropertiesFile(inFile, outFile string, properties []string, conf *model.Configuration) (err error) {
-	var f1, f2 *os.File
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return RemoveProperties(rs, w, properties, conf)
+	})
+}
 
-	if f1, err = os.Open(inFile); err != nil {
+// AddPropertiesJSON adds the properties of a JSON document read from rd to rs's infodict and writes the result to w.
+func AddPropertiesJSON(rs io.ReadSeeker, rd io.Reader, w io.Writer, conf *model.Configuration) error {
+	if rd == nil {
+		return errors.New("pdfcpu: AddPropertiesJSON: missing rd")
+	}
+
+	properties, err := pdfcpu.ParsePropertiesJSON(rd)
+	if err != nil {
 		return err
 	}
 
-	tmpFile := inFile + ".tmp"
-	if outFile != "" && inFile != outFile {
-		tmpFile = outFile
+	for k := range properties {
+		if !validate.DocumentProperty(k) {
+			return errors.Errorf("pdfcpu: property name \"%s\" not allowed", k)
+		}
 	}
-	if f2, err = os.Create(tmpFile); err != nil {
-		f1.Close()
+
+	return AddProperties(rs, w, properties, conf)
+}
+
+// AddPropertiesJSONFile adds the properties of inFileJSON to inFile's infodict and writes the result to outFile.
+func AddPropertiesJSONFile(inFile, inFileJSON, outFile string, conf *model.Configuration) (err error) {
+	f, err := os.Open(inFileJSON)
+	if err != nil {
 		return err
 	}
+	defer f.Close()
+
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return AddPropertiesJSON(rs, f, w, conf)
+	})
+}
+
+// ExportPropertiesJSON writes rs's properties (originating from source) as JSON to w.
+func ExportPropertiesJSON(rs io.ReadSeeker, w io.Writer, source string, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: ExportPropertiesJSON: missing rs")
+	}
+
+	if w == nil {
+		return errors.New("pdfcpu: ExportPropertiesJSON: missing w")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
+	}
+	conf.ValidationMode = model.ValidationRelaxed
+	conf.Cmd = model.EXPORTPROPERTIES
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	return pdfcpu.ExportPropertiesJSON(ctx, source, w)
+}
+
+// ExportPropertiesFile writes inFilePDF's properties as JSON to outFileJSON.
+func ExportPropertiesFile(inFilePDF, outFileJSON string, conf *model.Configuration) (err error) {
+	var f1, f2 *os.File
+
+	if f1, err = os.Open(inFilePDF); err != nil {
+		return err
+	}
+	defer f1.Close()
+
+	if f2, err = os.Create(outFileJSON); err != nil {
+		return err
+	}
+	logWritingTo(outFileJSON)
 
 	defer func() {
-		if err != nil {
-			f2.Close()
-			f1.Close()
-			os.Remove(tmpFile)
-			return
-		}
-		if err = f2.Close(); err != nil {
-			return
-		}
-		if err = f1.Close(); err != nil {
-			return
-		}
-		if outFile == "" || inFile == outFile {
-			err = os.Rename(tmpFile, inFile)
+		if err1 := f2.Close(); err == nil {
+			err = err1
 		}
 	}()
 
-	return RemoveProperties(f1, f2, properties, conf)
+	return ExportPropertiesJSON(f1, f2, inFilePDF, conf)
 }
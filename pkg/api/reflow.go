@@ -0,0 +1,127 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+// StructTree returns the logical structure tree of rs or nil if rs is not tagged.
+func StructTree(rs io.ReadSeeker, conf *model.Configuration) (*pdfcpu.StructElem, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: StructTree: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
+	}
+	conf.Cmd = model.REFLOW
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return pdfcpu.StructTree(ctx)
+}
+
+// ReflowFormat returns the reflow format for outFile's extension, defaulting to HTML.
+func ReflowFormat(outFile string) string {
+	if strings.ToLower(filepath.Ext(outFile)) == ".epub" {
+		return pdfcpu.ReflowEPUB
+	}
+	return pdfcpu.ReflowHTML
+}
+
+// Reflow writes the content of rs to w as HTML or EPUB.
+// Tagged files are rendered following their structure tree including headings, lists and tables,
+// for untagged files the text of each page is rendered line by line.
+func Reflow(rs io.ReadSeeker, w io.Writer, source, format string, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: Reflow: missing rs")
+	}
+
+	if w == nil {
+		return errors.New("pdfcpu: Reflow: missing w")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
+	}
+	conf.Cmd = model.REFLOW
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	return pdfcpu.Reflow(ctx, w, source, format)
+}
+
+// ReflowFile writes the content of inFile to outFile as HTML or EPUB.
+// An empty format is derived from outFile's extension. If outFile is empty the result goes to stdout.
+func ReflowFile(inFile, outFile, format string, conf *model.Configuration) (err error) {
+	if format == "" {
+		format = ReflowFormat(outFile)
+	}
+
+	rs, closeIn, err := openInFile(inFile)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err1 := closeIn(); err == nil {
+			err = err1
+		}
+	}()
+
+	source := filepath.Base(inFile)
+	if inFile == StdIO {
+		source = ""
+	}
+
+	if outFile == "" || outFile == StdIO {
+		return writeStdout(func(w io.Writer) error {
+			return Reflow(rs, w, source, format, conf)
+		})
+	}
+
+	f, err := os.Create(outFile)
+	if err != nil {
+		return err
+	}
+	logWritingTo(outFile)
+
+	if err = Reflow(rs, f, source, format, conf); err != nil {
+		f.Close()
+		os.Remove(outFile)
+		return err
+	}
+
+	return f.Close()
+}
@@ -18,7 +18,6 @@ package api
 
 import (
 	"io"
-	"os"
 
 	"github.com/pdfcpu/pdfcpu/pkg/log"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
@@ -34,6 +33,8 @@ func Resize(rs io.ReadSeeker, w io.Writer, selectedPages []string, resize *model
 
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
 	}
 	conf.Cmd = model.RESIZE
 
@@ -42,7 +43,7 @@ func Resize(rs io.ReadSeeker, w io.Writer, selectedPages []string, resize *model
 		return err
 	}
 
-	pages, err := PagesForPageSelection(ctx.PageCount, selectedPages, true, true)
+	pages, err := PagesForPageSelectionWithContext(ctx, selectedPages, true, true)
 	if err != nil {
 		return err
 	}
@@ -60,49 +61,20 @@ func ResizeFile(inFile, outFile string, selectedPages []string, resize *model.Re
 		log.CLI.Printf("resizing %s\n", inFile)
 	}
 
-	tmpFile := inFile + ".tmp"
 	if outFile != "" && inFile != outFile {
-		tmpFile = outFile
 		logWritingTo(outFile)
 	} else {
 		logWritingTo(inFile)
 	}
 
-	var (
-		f1, f2 *os.File
-	)
-
-	if f1, err = os.Open(inFile); err != nil {
-		return err
-	}
-
-	if f2, err = os.Create(tmpFile); err != nil {
-		f1.Close()
-		return err
-	}
-
-	defer func() {
-		if err != nil {
-			f2.Close()
-			f1.Close()
-			os.Remove(tmpFile)
-			return
-		}
-		if err = f2.Close(); err != nil {
-			return
-		}
-		if err = f1.Close(); err != nil {
-			return
-		}
-		if outFile == "" || inFile == outFile {
-			err = os.Rename(tmpFile, inFile)
-		}
-	}()
-
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
 	}
 	conf.Cmd = model.RESIZE
 
-	return Resize(f1, f2, selectedPages, resize, conf)
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return Resize(rs, w, selectedPages, resize, conf)
+	})
 }
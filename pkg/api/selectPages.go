@@ -24,19 +24,31 @@ import (
 	"strings"
 
 	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
 	"github.com/pkg/errors"
 )
 
+const (
+	// bookmarkPrefix introduces a page range named after a bookmark, eg. "bm:Chapter 2".
+	bookmarkPrefix = "bm:"
+
+	// matchingPrefix introduces a regular expression selecting all pages whose text matches, eg. "matching:Invoice No".
+	matchingPrefix = "matching:"
+)
+
 var (
 	selectedPagesRegExp *regexp.Regexp
 )
 
 func setupRegExpForPageSelection() *regexp.Regexp {
-	e := "(\\d+)?-l(-\\d+)?|l(-(\\d+)-?)?"
-	e = "[!n]?((-\\d+)|(\\d+(-(\\d+)?)?)|" + e + ")"
+	e := "(\\d+)?-l(-\\d+)?|l(-(\\d+)-?)?|l-\\d+-l(-\\d+)?"
+	e = "((-\\d+)|(\\d+(-(\\d+)?)?)|" + e + ")(:\\d+)?"
+	e = "[!n]?(\\Qeven\\E|\\Qodd\\E|" + bookmarkPrefix + "[^,]+|" + matchingPrefix + "[^,]+|" + e + ")"
 	e = "\\Qeven\\E|\\Qodd\\E|" + e
-	exp := "^" + e + "(," + e + ")*$"
+	e = "\\s*(?:" + e + ")"
+	exp := "^" + e + "(?:," + e + ")*$"
 	re, _ := regexp.Compile(exp)
 	return re
 }
@@ -63,6 +75,11 @@ func ParsePageSelection(s string) ([]string, error) {
 	// The pageSelection is evaluated strictly from left to right!
 	// e.g. "!3,1-5" extracts pages 1-5 whereas "1-5,!3" extracts pages 1,2,4,5
 	//
+	// Ranges may be followed by a step: "1-100:2" selects pages 1,3,5,...,99
+	// Both ends of a range may be relative to the last page: "l-5-l-2"
+	// A bookmark title selects the pages of the corresponding section: "bm:Chapter 2"
+	// A regular expression selects all pages whose text matches: "matching:Invoice No"
+	//
 
 	if !selectedPagesRegExp.MatchString(s) {
 		return nil, errors.Errorf("-pages \"%s\" => syntax error\n", s)
@@ -255,6 +272,77 @@ func parsePageRange(pr []string, pageCount int, negated bool, selectedPages type
 	return nil
 }
 
+// relativeRange returns the pages of a range relative to the last page: l-#-l l-#-l-#
+func relativeRange(v string, pageCount int) ([]int, error) {
+	pr := strings.SplitN(v[2:], "-", 2)
+
+	i, err := strconv.Atoi(pr[0])
+	if err != nil {
+		return nil, err
+	}
+	from := pageCount - i
+
+	thru := pageCount
+	if pr[1] != "l" {
+		j, err := strconv.Atoi(strings.TrimPrefix(pr[1], "l-"))
+		if err != nil {
+			return nil, err
+		}
+		thru -= j
+	}
+
+	if from < 1 {
+		from = 1
+	}
+
+	if thru < from {
+		return nil, nil
+	}
+
+	return PagesForPageRange(from, thru), nil
+}
+
+// extendedPageRange returns the pages for negated even/odd, ranges with step and ranges relative to the last page.
+func extendedPageRange(v string, pageCount int) ([]int, bool, error) {
+	if v == "even" || v == "odd" {
+		// Reached for negated even/odd only.
+		from := 2
+		if v == "odd" {
+			from = 1
+		}
+		pp := []int{}
+		for i := from; i <= pageCount; i += 2 {
+			pp = append(pp, i)
+		}
+		return pp, true, nil
+	}
+
+	if i := strings.IndexByte(v, ':'); i > 0 {
+		// range:step
+		step, err := strconv.Atoi(v[i+1:])
+		if err != nil || step < 1 {
+			return nil, false, errors.Errorf("pdfcpu: invalid page step: %s", v)
+		}
+		pp, err := calcPagesForPageCollection(pageCount, []string{v[:i]})
+		if err != nil {
+			return nil, false, err
+		}
+		pp1 := []int{}
+		for j := 0; j < len(pp); j += step {
+			pp1 = append(pp1, pp[j])
+		}
+		return pp1, true, nil
+	}
+
+	if strings.HasPrefix(v, "l-") && strings.Contains(v[2:], "-l") {
+		// l-#-l l-#-l-#
+		pp, err := relativeRange(v, pageCount)
+		return pp, err == nil, err
+	}
+
+	return nil, false, nil
+}
+
 func sortedPages(selectedPages types.IntSet) []int {
 	p := []int(nil)
 	for i, v := range selectedPages {
@@ -306,6 +394,22 @@ func calcSelPages(pageCount int, pageSelection []string, selectedPages types.Int
 			v = v[1:]
 		}
 
+		if unresolved(v) {
+			return errors.Errorf("pdfcpu: unresolved page selection: %s", v)
+		}
+
+		// !even !odd #-#:# l-#-l-#
+		pp, ok, err := extendedPageRange(v, pageCount)
+		if err != nil {
+			return err
+		}
+		if ok {
+			for _, p := range pp {
+				selectedPages[p] = !negated
+			}
+			continue
+		}
+
 		// -#
 		if v[0] == '-' {
 
@@ -391,6 +495,104 @@ func PagesForPageSelection(pageCount int, pageSelection []string, ensureAllforNo
 	return m, nil
 }
 
+// unresolved returns true if the page selection expression v refers to the content of a PDF.
+func unresolved(v string) bool {
+	return strings.HasPrefix(v, bookmarkPrefix) || strings.HasPrefix(v, matchingPrefix)
+}
+
+func resolveBookmark(ctx *model.Context, neg, v string) ([]string, error) {
+	title := strings.Trim(strings.TrimPrefix(v, bookmarkPrefix), "\"'")
+	from, thru, err := pdfcpu.BookmarkPageRange(ctx, title)
+	if err != nil {
+		return nil, err
+	}
+	return []string{fmt.Sprintf("%s%d-%d", neg, from, thru)}, nil
+}
+
+func resolveMatching(ctx *model.Context, neg, v string) ([]string, error) {
+	s := strings.Trim(strings.TrimPrefix(v, matchingPrefix), "\"'")
+	re, err := regexp.Compile(s)
+	if err != nil {
+		return nil, errors.Errorf("pdfcpu: invalid page selection %s: %v", v, err)
+	}
+
+	pageNrs, err := pdfcpu.PagesMatching(ctx, re)
+	if err != nil {
+		return nil, err
+	}
+
+	ss := make([]string, len(pageNrs))
+	for i, p := range pageNrs {
+		ss[i] = neg + strconv.Itoa(p)
+	}
+
+	return ss, nil
+}
+
+// ResolvePageSelection replaces any page selection expressions referring to the content of ctx
+// (bookmark titles, text patterns) by the corresponding page numbers.
+func ResolvePageSelection(ctx *model.Context, pageSelection []string) ([]string, error) {
+	var ss []string
+
+	for _, v := range pageSelection {
+		neg := ""
+		if negation(v[0]) && unresolved(v[1:]) {
+			neg, v = v[:1], v[1:]
+		}
+
+		var (
+			ss1 []string
+			err error
+		)
+
+		switch {
+		case strings.HasPrefix(v, bookmarkPrefix):
+			ss1, err = resolveBookmark(ctx, neg, v)
+		case strings.HasPrefix(v, matchingPrefix):
+			ss1, err = resolveMatching(ctx, neg, v)
+		default:
+			ss1 = []string{neg + v}
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		ss = append(ss, ss1...)
+	}
+
+	return ss, nil
+}
+
+// PagesForPageSelectionWithContext ensures a set of page numbers of ctx for an ascending page sequence
+// where each page number may appear only once.
+// In addition to PagesForPageSelection it resolves page ranges referring to the content of ctx.
+func PagesForPageSelectionWithContext(ctx *model.Context, pageSelection []string, ensureAllforNone bool, log bool) (types.IntSet, error) {
+	ss, err := ResolvePageSelection(ctx, pageSelection)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(pageSelection) > 0 && len(ss) == 0 {
+		// Nothing matched.
+		return types.IntSet{}, nil
+	}
+
+	m, err := PagesForPageSelection(ctx.PageCount, ss, ensureAllforNone, log)
+	if err != nil {
+		return nil, err
+	}
+
+	// Drop pages deselected by negated expressions.
+	for k, v := range m {
+		if !v {
+			delete(m, k)
+		}
+	}
+
+	return m, nil
+}
+
 func RemainingPagesForPageRemoval(pageCount int, pageSelection []string, log bool) (types.IntSet, error) {
 	pagesToRemove, err := selectedPages(pageCount, pageSelection, log)
 	if err != nil {
@@ -623,6 +825,22 @@ func calcPagesForPageCollection(pageCount int, pageSelection []string) ([]int, e
 			v = v[1:]
 		}
 
+		if unresolved(v) {
+			return nil, errors.Errorf("pdfcpu: unresolved page selection: %s", v)
+		}
+
+		// !even !odd #-#:# l-#-l-#
+		pp, ok, err := extendedPageRange(v, pageCount)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			for _, p := range pp {
+				processPageForCollection(&collectedPages, negated, p)
+			}
+			continue
+		}
+
 		// -#
 		if v[0] == '-' {
 
@@ -0,0 +1,411 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/pem"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/form"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// Deferred signing workflow:
+//
+//	1) AddSignatureField adds an empty signature field (optional if the form already provides one).
+//	2) PrepareSignature reserves space for the signature in this field.
+//	3) SignedContent returns the bytes to be signed by some external signer producing a detached CMS signature.
+//	4) EmbedSignature embeds this CMS signature.
+
+// AddSignatureField adds an empty signature field named fieldName with a visible placeholder appearance
+// to page pageNr of rs and writes the result to w.
+func AddSignatureField(rs io.ReadSeeker, w io.Writer, pageNr int, rect *types.Rectangle, fieldName, label string, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: AddSignatureField: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
+	}
+	conf.Cmd = model.ADDSIGNATUREFIELD
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	if _, err := form.AddSignatureField(ctx, pageNr, rect, fieldName, label); err != nil {
+		return err
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// AddSignatureFieldFile adds an empty signature field named fieldName with a visible placeholder appearance
+// to page pageNr of inFile and writes the result to outFile.
+func AddSignatureFieldFile(inFile, outFile string, pageNr int, rect *types.Rectangle, fieldName, label string, conf *model.Configuration) error {
+	if outFile != "" && inFile != outFile {
+		logWritingTo(outFile)
+	} else {
+		logWritingTo(inFile)
+	}
+
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return AddSignatureField(rs, w, pageNr, rect, fieldName, label, conf)
+	})
+}
+
+// PrepareSignature reserves size bytes for a detached CMS signature in the empty signature field fieldName of rs
+// and writes the result to w ready for external signing.
+// size defaults to form.DefaultSignatureSize.
+func PrepareSignature(rs io.ReadSeeker, w io.Writer, fieldName string, size int, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: PrepareSignature: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
+	}
+	conf.Cmd = model.PREPARESIGNATURE
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	if err := form.PrepareSignature(ctx, fieldName, size); err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := Write(ctx, &buf, conf); err != nil {
+		return err
+	}
+
+	bb := buf.Bytes()
+	if err := form.FinalizeByteRange(bb); err != nil {
+		return err
+	}
+
+	_, err = w.Write(bb)
+	return err
+}
+
+// PrepareSignatureFile reserves size bytes for a detached CMS signature in the empty signature field fieldName of inFile
+// and writes the result to outFile ready for external signing.
+func PrepareSignatureFile(inFile, outFile, fieldName string, size int, conf *model.Configuration) error {
+	if outFile != "" && inFile != outFile {
+		logWritingTo(outFile)
+	} else {
+		logWritingTo(inFile)
+	}
+
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return PrepareSignature(rs, w, fieldName, size, conf)
+	})
+}
+
+// SignedContent returns the content of rs prepared by PrepareSignature to be digested and signed by an external signer.
+func SignedContent(rs io.ReadSeeker) ([]byte, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: SignedContent: missing rs")
+	}
+
+	bb, err := io.ReadAll(rs)
+	if err != nil {
+		return nil, err
+	}
+
+	return form.SignedContent(bb)
+}
+
+// SignedContentFile writes the content of inFile prepared by PrepareSignature to be signed by an external signer to outFile.
+func SignedContentFile(inFile, outFile string) error {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	bb, err := SignedContent(f)
+	if err != nil {
+		return err
+	}
+
+	logWritingTo(outFile)
+
+	return os.WriteFile(outFile, bb, 0644)
+}
+
+// EmbedSignature embeds cms, a detached CMS signature created by an external signer,
+// into rs prepared by PrepareSignature and writes the result to w.
+func EmbedSignature(rs io.ReadSeeker, w io.Writer, cms []byte, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: EmbedSignature: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
+	}
+	conf.Cmd = model.EMBEDSIGNATURE
+
+	bb, err := io.ReadAll(rs)
+	if err != nil {
+		return err
+	}
+
+	if err := form.EmbedSignature(bb, cms); err != nil {
+		return err
+	}
+
+	if log.CLIEnabled() {
+		log.CLI.Printf("embedded signature: %d bytes\n", len(cms))
+	}
+
+	_, err = w.Write(bb)
+	return err
+}
+
+// EmbedSignatureFile embeds the detached CMS signature of inFileCMS into inFile prepared by PrepareSignature
+// and writes the result to outFile.
+func EmbedSignatureFile(inFile, inFileCMS, outFile string, conf *model.Configuration) error {
+	cms, err := os.ReadFile(inFileCMS)
+	if err != nil {
+		return err
+	}
+
+	if outFile != "" && inFile != outFile {
+		logWritingTo(outFile)
+	} else {
+		logWritingTo(inFile)
+	}
+
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return EmbedSignature(rs, w, cms, conf)
+	})
+}
+
+func addValidationItem(vm *form.ValidationMaterial, fileName, typ string, bb []byte) error {
+	switch typ {
+	case "CERTIFICATE":
+		if _, err := x509.ParseCertificate(bb); err != nil {
+			return errors.Wrapf(err, "%s", fileName)
+		}
+		vm.Certs = append(vm.Certs, bb)
+	case "X509 CRL":
+		if _, err := x509.ParseRevocationList(bb); err != nil {
+			return errors.Wrapf(err, "%s", fileName)
+		}
+		vm.CRLs = append(vm.CRLs, bb)
+	case "OCSP RESPONSE":
+		vm.OCSPs = append(vm.OCSPs, bb)
+	default:
+		return errors.Errorf("pdfcpu: %s: unsupported PEM block: %s", fileName, typ)
+	}
+	return nil
+}
+
+// ParseValidationMaterialFiles classifies and loads validation material for signatures.
+// Supported are DER encoded certificates (.cer, .crt, .der), CRLs (.crl) and OCSP responses (.ocsp, .ors)
+// as well as PEM files containing certificates and CRLs.
+func ParseValidationMaterialFiles(fileNames []string) (*form.ValidationMaterial, error) {
+	vm := &form.ValidationMaterial{}
+
+	for _, fn := range fileNames {
+		bb, err := os.ReadFile(fn)
+		if err != nil {
+			return nil, err
+		}
+
+		if bytes.HasPrefix(bytes.TrimSpace(bb), []byte("-----BEGIN")) {
+			for {
+				var block *pem.Block
+				block, bb = pem.Decode(bb)
+				if block == nil {
+					break
+				}
+				if err := addValidationItem(vm, fn, block.Type, block.Bytes); err != nil {
+					return nil, err
+				}
+			}
+			continue
+		}
+
+		typ := "CERTIFICATE"
+		switch strings.ToLower(filepath.Ext(fn)) {
+		case ".crl":
+			typ = "X509 CRL"
+		case ".ocsp", ".ors":
+			typ = "OCSP RESPONSE"
+		}
+
+		if err := addValidationItem(vm, fn, typ, bb); err != nil {
+			return nil, err
+		}
+	}
+
+	return vm, nil
+}
+
+// AddValidationMaterialAsIncrement embeds vm into the document security store of rws for all signatures
+// and writes out a PDF increment leaving existing signatures intact.
+func AddValidationMaterialAsIncrement(rws io.ReadWriteSeeker, vm form.ValidationMaterial, conf *model.Configuration) error {
+	if rws == nil {
+		return errors.New("pdfcpu: AddValidationMaterialAsIncrement: missing rws")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
+	}
+	conf.Cmd = model.ADDVALIDATIONINFO
+
+	ctx, err := ReadAndValidate(rws, conf)
+	if err != nil {
+		return err
+	}
+
+	if *ctx.HeaderVersion < model.V14 {
+		return errors.New("pdfcpu: incremental writing not supported for PDF version < V1.4")
+	}
+
+	if err := form.AddValidationMaterial(ctx, vm); err != nil {
+		return err
+	}
+
+	return WriteIncr(ctx, rws, conf)
+}
+
+// AddValidationMaterialFile embeds vm into the document security store of inFile for all signatures
+// making them LTV enabled and writes the result to outFile as an increment of inFile.
+func AddValidationMaterialFile(inFile, outFile string, vm form.ValidationMaterial, conf *model.Configuration) (err error) {
+	if outFile == "" || outFile == inFile {
+		outFile = inFile
+	} else {
+		bb, err := os.ReadFile(inFile)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(outFile, bb, 0644); err != nil {
+			return err
+		}
+	}
+
+	logWritingTo(outFile)
+
+	f, err := os.OpenFile(outFile, os.O_RDWR, 0644)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err1 := f.Close(); err == nil {
+			err = err1
+		}
+	}()
+
+	return AddValidationMaterialAsIncrement(f, vm, conf)
+}
+
+// UsageRights returns the usage rights signature (UR3) of rs or nil.
+func UsageRights(rs io.ReadSeeker, conf *model.Configuration) (*model.UsageRights, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: UsageRights: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
+	}
+	conf.Cmd = model.LISTUSAGERIGHTS
+
+	ctx, err := ReadAndValidate(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return ctx.UsageRights()
+}
+
+// UsageRightsFile returns the usage rights signature (UR3) of inFile or nil.
+func UsageRightsFile(inFile string, conf *model.Configuration) (*model.UsageRights, error) {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return UsageRights(f, conf)
+}
+
+// RemoveUsageRights removes the usage rights signature (UR3) of rs and writes the result to w.
+// Usage rights get invalidated by most modifications, in which case Adobe Reader disables the extended features and warns.
+func RemoveUsageRights(rs io.ReadSeeker, w io.Writer, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: RemoveUsageRights: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
+	}
+	conf.Cmd = model.REMOVEUSAGERIGHTS
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	ok, err := ctx.RemoveUsageRights()
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return model.ErrNoUsageRights
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// RemoveUsageRightsFile removes the usage rights signature (UR3) of inFile and writes the result to outFile.
+func RemoveUsageRightsFile(inFile, outFile string, conf *model.Configuration) error {
+	if outFile != "" && inFile != outFile {
+		logWritingTo(outFile)
+	} else {
+		logWritingTo(inFile)
+	}
+
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return RemoveUsageRights(rs, w, conf)
+	})
+}
@@ -18,6 +18,7 @@ package api
 
 import (
 	"bytes"
+	"encoding/json"
 	"io"
 	"os"
 	"path/filepath"
@@ -36,8 +37,21 @@ type PageSpan struct {
 	Reader io.Reader
 }
 
-func pageSpan(ctx *model.Context, from, thru int) (*PageSpan, error) {
-	ctxNew, err := pdfcpu.ExtractPages(ctx, PagesForPageRange(from, thru), false)
+func pageSpanContext(ctx *model.Context, p pdfcpu.SplitPart) (*model.Context, error) {
+	ctxNew, err := pdfcpu.ExtractPages(ctx, PagesForPageRange(p.From, p.Thru), false)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := pdfcpu.SetSplitPartInfo(ctx, ctxNew, p); err != nil {
+		return nil, err
+	}
+
+	return ctxNew, nil
+}
+
+func pageSpan(ctx *model.Context, p pdfcpu.SplitPart) (*PageSpan, error) {
+	ctxNew, err := pageSpanContext(ctx, p)
 	if err != nil {
 		return nil, err
 	}
@@ -47,7 +61,7 @@ func pageSpan(ctx *model.Context, from, thru int) (*PageSpan, error) {
 		return nil, err
 	}
 
-	return &PageSpan{From: from, Thru: thru, Reader: &b}, nil
+	return &PageSpan{From: p.From, Thru: p.Thru, Reader: &b}, nil
 }
 
 func spanFileName(fileName string, from, thru int) string {
@@ -60,26 +74,46 @@ func spanFileName(fileName string, from, thru int) string {
 	return fn + "-" + strconv.Itoa(thru) + ".pdf"
 }
 
-func splitOutPath(outDir, fileName string, forBookmark bool, from, thru int) string {
-	p := filepath.Join(outDir, fileName+".pdf")
-	if !forBookmark {
-		p = filepath.Join(outDir, spanFileName(fileName, from, thru))
-	}
-	return p
+func splitOutPath(outDir, fileName string, from, thru int) string {
+	return filepath.Join(outDir, spanFileName(fileName, from, thru))
 }
 
-func writePageSpan(ctx *model.Context, from, thru int, outPath string) error {
-	ps, err := pageSpan(ctx, from, thru)
+func splitBaseName(fileName string) string {
+	return strings.TrimSuffix(filepath.Base(fileName), ".pdf")
+}
+
+// writePageSpan writes a page span straight to outPath without buffering it in memory.
+// Unmodified image streams get copied through from the source file.
+func writePageSpan(ctx *model.Context, p pdfcpu.SplitPart, outPath string) error {
+	ctxNew, err := pageSpanContext(ctx, p)
 	if err != nil {
 		return err
 	}
+
+	if ctx.DryRun {
+		return WriteContext(ctxNew, io.Discard)
+	}
+
 	logWritingTo(outPath)
-	return pdfcpu.WriteReader(outPath, ps.Reader)
+
+	f, err := os.Create(outPath)
+	if err != nil {
+		return err
+	}
+
+	if err := WriteContext(ctxNew, f); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
 }
 
 func context(rs io.ReadSeeker, conf *model.Configuration) (*model.Context, error) {
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
 	}
 	conf.Cmd = model.SPLIT
 
@@ -94,14 +128,14 @@ func pageSpansSplitAlongBookmarks(ctx *model.Context) ([]*PageSpan, error) {
 		return nil, err
 	}
 
-	for _, bm := range bms {
+	for i, bm := range bms {
 
 		from, thru := bm.PageFrom, bm.PageThru
 		if thru == 0 {
 			thru = ctx.PageCount
 		}
 
-		ps, err := pageSpan(ctx, from, thru)
+		ps, err := pageSpan(ctx, pdfcpu.SplitPart{Nr: i + 1, From: from, Thru: thru, Bookmark: bm.Title})
 		if err != nil {
 			return nil, err
 		}
@@ -119,7 +153,7 @@ func pageSpans(ctx *model.Context, span int) ([]*PageSpan, error) {
 		start := i * span
 		from := start + 1
 		thru := start + span
-		ps, err := pageSpan(ctx, from, thru)
+		ps, err := pageSpan(ctx, pdfcpu.SplitPart{Nr: i + 1, From: from, Thru: thru})
 		if err != nil {
 			return nil, err
 		}
@@ -131,7 +165,7 @@ func pageSpans(ctx *model.Context, span int) ([]*PageSpan, error) {
 		start := (ctx.PageCount / span) * span
 		from := start + 1
 		thru := ctx.PageCount
-		ps, err := pageSpan(ctx, from, thru)
+		ps, err := pageSpan(ctx, pdfcpu.SplitPart{Nr: len(pss) + 1, From: from, Thru: thru})
 		if err != nil {
 			return nil, err
 		}
@@ -142,13 +176,14 @@ func pageSpans(ctx *model.Context, span int) ([]*PageSpan, error) {
 }
 
 func writePageSpans(ctx *model.Context, span int, outDir, fileName string) error {
-	forBookmark := false
+	baseName := splitBaseName(fileName)
 
 	for i := 0; i < ctx.PageCount/span; i++ {
 		start := i * span
 		from, thru := start+1, start+span
-		path := splitOutPath(outDir, fileName, forBookmark, from, thru)
-		if err := writePageSpan(ctx, from, thru, path); err != nil {
+		path := splitOutPath(outDir, fileName, from, thru)
+		p := pdfcpu.SplitPart{BaseName: baseName, Nr: i + 1, From: from, Thru: thru}
+		if err := writePageSpan(ctx, p, path); err != nil {
 			return err
 		}
 	}
@@ -157,8 +192,9 @@ func writePageSpans(ctx *model.Context, span int, outDir, fileName string) error
 	if ctx.PageCount%span > 0 {
 		start := (ctx.PageCount / span) * span
 		from, thru := start+1, ctx.PageCount
-		path := splitOutPath(outDir, fileName, forBookmark, from, thru)
-		if err := writePageSpan(ctx, from, thru, path); err != nil {
+		path := splitOutPath(outDir, fileName, from, thru)
+		p := pdfcpu.SplitPart{BaseName: baseName, Nr: ctx.PageCount/span + 1, From: from, Thru: thru}
+		if err := writePageSpan(ctx, p, path); err != nil {
 			return err
 		}
 	}
@@ -166,33 +202,44 @@ func writePageSpans(ctx *model.Context, span int, outDir, fileName string) error
 	return nil
 }
 
-func writePageSpansSplitAlongBookmarks(ctx *model.Context, outDir string) error {
-	forBookmark := true
+func writeSplitManifest(outDir, fileName string, level int, ss []pdfcpu.SplitSection) error {
+	m := pdfcpu.SplitManifest{Source: filepath.Base(fileName), Level: level, Sections: ss}
 
-	bms, err := pdfcpu.Bookmarks(ctx)
+	bb, err := json.MarshalIndent(m, "", "\t")
 	if err != nil {
 		return err
 	}
 
-	for _, bm := range bms {
-		fileName := strings.Replace(bm.Title, " ", "_", -1)
-		from, thru := bm.PageFrom, bm.PageThru
-		if thru == 0 {
-			thru = ctx.PageCount
-		}
-		path := splitOutPath(outDir, fileName, forBookmark, from, thru)
-		if err := writePageSpan(ctx, from, thru, path); err != nil {
+	outPath := filepath.Join(outDir, splitBaseName(fileName)+"_manifest.json")
+	logWritingTo(outPath)
+
+	return os.WriteFile(outPath, bb, 0644)
+}
+
+func writePageSpansSplitAlongBookmarks(ctx *model.Context, outDir, fileName string, level int) error {
+	baseName := splitBaseName(fileName)
+
+	ss, err := pdfcpu.BookmarkSections(ctx, level)
+	if err != nil {
+		return err
+	}
+
+	for i := range ss {
+		s := &ss[i]
+		s.File = s.FileName()
+		p := pdfcpu.SplitPart{BaseName: baseName, Nr: i + 1, From: s.From, Thru: s.Thru, Bookmark: s.Title}
+		if err := writePageSpan(ctx, p, filepath.Join(outDir, s.File)); err != nil {
 			return err
 		}
 	}
 
-	return nil
+	return writeSplitManifest(outDir, fileName, level, ss)
 }
 
 func writePageSpansSplitAlongPages(ctx *model.Context, pageNrs []int, outDir, fileName string) error {
 	// pageNumbers is a a sorted sequence of page numbers.
-	forBookmark := false
-	from, thru := 1, 0
+	baseName := splitBaseName(fileName)
+	from, thru, nr := 1, 0, 1
 
 	if len(pageNrs) < 1 {
 		return errors.New("pdfcpu: split along pageNrs - missing pageNrs")
@@ -207,16 +254,18 @@ func writePageSpansSplitAlongPages(ctx *model.Context, pageNrs []int, outDir, fi
 		if thru >= ctx.PageCount {
 			break
 		}
-		path := splitOutPath(outDir, fileName, forBookmark, from, thru)
-		if err := writePageSpan(ctx, from, thru, path); err != nil {
+		path := splitOutPath(outDir, fileName, from, thru)
+		p := pdfcpu.SplitPart{BaseName: baseName, Nr: nr, From: from, Thru: thru}
+		if err := writePageSpan(ctx, p, path); err != nil {
 			return err
 		}
-		from = thru + 1
+		from, nr = thru+1, nr+1
 	}
 
 	thru = ctx.PageCount
-	path := splitOutPath(outDir, fileName, forBookmark, from, thru)
-	return writePageSpan(ctx, from, thru, path)
+	path := splitOutPath(outDir, fileName, from, thru)
+	p := pdfcpu.SplitPart{BaseName: baseName, Nr: nr, From: from, Thru: thru}
+	return writePageSpan(ctx, p, path)
 }
 
 // SplitRaw returns page spans for the PDF stream read from rs obeying given split span.
@@ -243,6 +292,7 @@ func SplitRaw(rs io.ReadSeeker, span int, conf *model.Configuration) ([]*PageSpa
 // If span == 1 splitting results in single page PDFs.
 // If span == 0 we split along given bookmarks (level 1 only).
 // Default span: 1
+// Title and author of the result files may be set using conf.SplitTitle and conf.SplitAuthor (see pdfcpu.SplitPart.Expand).
 func Split(rs io.ReadSeeker, outDir, fileName string, span int, conf *model.Configuration) error {
 	if rs == nil {
 		return errors.New("pdfcpu: Split: missing rs")
@@ -254,7 +304,7 @@ func Split(rs io.ReadSeeker, outDir, fileName string, span int, conf *model.Conf
 	}
 
 	if span == 0 {
-		return writePageSpansSplitAlongBookmarks(ctx, outDir)
+		return writePageSpansSplitAlongBookmarks(ctx, outDir, fileName, 1)
 	}
 	return writePageSpans(ctx, span, outDir, fileName)
 }
@@ -317,3 +367,94 @@ func SplitByPageNrFile(inFile, outDir string, pageNrs []int, conf *model.Configu
 
 	return SplitByPageNr(f, outDir, filepath.Base(inFile), pageNrs, conf)
 }
+
+// SplitByPageSelection generates a sequence of PDF files in outDir for rs splitting before each selected page.
+// This way eg. "matching:Invoice No" splits rs into single invoices.
+func SplitByPageSelection(rs io.ReadSeeker, outDir, fileName string, selectedPages []string, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: SplitByPageSelection: missing rs")
+	}
+
+	ctx, err := context(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	pages, err := PagesForPageSelectionWithContext(ctx, selectedPages, false, true)
+	if err != nil {
+		return err
+	}
+
+	var pageNrs []int
+	for _, p := range sortedPages(pages) {
+		// Nothing to split before page 1.
+		if p > 1 {
+			pageNrs = append(pageNrs, p)
+		}
+	}
+
+	if len(pageNrs) == 0 {
+		p := pdfcpu.SplitPart{BaseName: splitBaseName(fileName), Nr: 1, From: 1, Thru: ctx.PageCount}
+		return writePageSpan(ctx, p, splitOutPath(outDir, fileName, 1, ctx.PageCount))
+	}
+
+	return writePageSpansSplitAlongPages(ctx, pageNrs, outDir, fileName)
+}
+
+// SplitByPageSelectionFile generates a sequence of PDF files in outDir for inFile splitting before each selected page.
+func SplitByPageSelectionFile(inFile, outDir string, selectedPages []string, conf *model.Configuration) error {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return err
+	}
+	if log.CLIEnabled() {
+		log.CLI.Printf("splitting %s to %s/...\n", inFile, outDir)
+	}
+
+	defer func() {
+		if err != nil {
+			f.Close()
+			return
+		}
+		err = f.Close()
+	}()
+
+	return SplitByPageSelection(f, outDir, filepath.Base(inFile), selectedPages, conf)
+}
+
+// SplitByBookmark generates a sequence of PDF files in outDir for rs splitting along bookmarks up to level.
+// Result files are named after their hierarchical section number and bookmark title.
+// A JSON manifest mapping result files to page ranges of rs is written to outDir.
+func SplitByBookmark(rs io.ReadSeeker, outDir, fileName string, level int, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: SplitByBookmark: missing rs")
+	}
+
+	ctx, err := context(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	return writePageSpansSplitAlongBookmarks(ctx, outDir, fileName, level)
+}
+
+// SplitByBookmarkFile generates a sequence of PDF files in outDir for inFile splitting along bookmarks up to level.
+func SplitByBookmarkFile(inFile, outDir string, level int, conf *model.Configuration) error {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return err
+	}
+	if log.CLIEnabled() {
+		log.CLI.Printf("splitting %s to %s/...\n", inFile, outDir)
+	}
+
+	defer func() {
+		if err != nil {
+			f.Close()
+			return
+		}
+		err = f.Close()
+	}()
+
+	return SplitByBookmark(f, outDir, filepath.Base(inFile), level, conf)
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+
+	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+// Colorants returns the Separation and DeviceN colorants of rs along with the pages using them.
+func Colorants(rs io.ReadSeeker, conf *model.Configuration) ([]pdfcpu.Colorant, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: Colorants: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
+	}
+	conf.Cmd = model.LISTCOLORANTS
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return pdfcpu.Colorants(ctx)
+}
+
+func editColorants(rs io.ReadSeeker, w io.Writer, conf *model.Configuration, fn func(ctx *model.Context) error) error {
+	if rs == nil {
+		return errors.New("pdfcpu: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
+	}
+	conf.Cmd = model.EDITCOLORANTS
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(ctx); err != nil {
+		return err
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// RenameColorant renames the spot color from to to in rs and writes the result to w.
+func RenameColorant(rs io.ReadSeeker, w io.Writer, from, to string, conf *model.Configuration) error {
+	return editColorants(rs, w, conf, func(ctx *model.Context) error {
+		_, err := pdfcpu.RenameColorant(ctx, from, to)
+		return err
+	})
+}
+
+// RenameColorantFile renames the spot color from to to in inFile and writes the result to outFile.
+func RenameColorantFile(inFile, outFile, from, to string, conf *model.Configuration) error {
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return RenameColorant(rs, w, from, to, conf)
+	})
+}
+
+// RemapColorant maps the spot color from of rs onto the existing spot color to and writes the result to w.
+func RemapColorant(rs io.ReadSeeker, w io.Writer, from, to string, conf *model.Configuration) error {
+	return editColorants(rs, w, conf, func(ctx *model.Context) error {
+		_, err := pdfcpu.RemapColorant(ctx, from, to)
+		return err
+	})
+}
+
+// RemapColorantFile maps the spot color from of inFile onto the existing spot color to and writes the result to outFile.
+func RemapColorantFile(inFile, outFile, from, to string, conf *model.Configuration) error {
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return RemapColorant(rs, w, from, to, conf)
+	})
+}
+
+// ConvertColorant replaces the spot color name of rs by the CMYK process build cmyk and writes the result to w.
+func ConvertColorant(rs io.ReadSeeker, w io.Writer, name string, cmyk []float64, conf *model.Configuration) error {
+	return editColorants(rs, w, conf, func(ctx *model.Context) error {
+		return pdfcpu.ConvertColorant(ctx, name, cmyk)
+	})
+}
+
+// ConvertColorantFile replaces the spot color name of inFile by the CMYK process build cmyk and writes the result to outFile.
+func ConvertColorantFile(inFile, outFile, name string, cmyk []float64, conf *model.Configuration) error {
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return ConvertColorant(rs, w, name, cmyk, conf)
+	})
+}
+
+// RemoveUnusedColorants removes Separation and DeviceN color space resources of rs not used by any content stream
+// and writes the result to w.
+func RemoveUnusedColorants(rs io.ReadSeeker, w io.Writer, conf *model.Configuration) error {
+	return editColorants(rs, w, conf, func(ctx *model.Context) error {
+		ss, err := pdfcpu.RemoveUnusedColorants(ctx)
+		if err != nil {
+			return err
+		}
+		if log.CLIEnabled() && len(ss) > 0 {
+			log.CLI.Printf("removed unused colorant definitions: %v\n", ss)
+		}
+		return nil
+	})
+}
+
+// RemoveUnusedColorantsFile removes unused Separation and DeviceN color space resources of inFile
+// and writes the result to outFile.
+func RemoveUnusedColorantsFile(inFile, outFile string, conf *model.Configuration) error {
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return RemoveUnusedColorants(rs, w, conf)
+	})
+}
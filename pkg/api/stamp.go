@@ -17,7 +17,10 @@
 package api
 
 import (
+	"bytes"
+	"encoding/json"
 	"io"
+	"io/fs"
 	"os"
 
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
@@ -39,6 +42,8 @@ func AddWatermarksMap(rs io.ReadSeeker, w io.Writer, m map[int]*model.Watermark,
 
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
 	}
 	conf.Cmd = model.ADDWATERMARKS
 
@@ -60,43 +65,15 @@ func AddWatermarksMap(rs io.ReadSeeker, w io.Writer, m map[int]*model.Watermark,
 
 // AddWatermarksMapFile adds watermarks to corresponding pages in m of inFile and writes the result to outFile.
 func AddWatermarksMapFile(inFile, outFile string, m map[int]*model.Watermark, conf *model.Configuration) (err error) {
-	var f1, f2 *os.File
-
-	if f1, err = os.Open(inFile); err != nil {
-		return err
-	}
-
-	tmpFile := inFile + ".tmp"
 	if outFile != "" && inFile != outFile {
-		tmpFile = outFile
 		logWritingTo(outFile)
 	} else {
 		logWritingTo(inFile)
 	}
-	if f2, err = os.Create(tmpFile); err != nil {
-		f1.Close()
-		return err
-	}
-
-	defer func() {
-		if err != nil {
-			f2.Close()
-			f1.Close()
-			os.Remove(tmpFile)
-			return
-		}
-		if err = f2.Close(); err != nil {
-			return
-		}
-		if err = f1.Close(); err != nil {
-			return
-		}
-		if outFile == "" || inFile == outFile {
-			err = os.Rename(tmpFile, inFile)
-		}
-	}()
 
-	return AddWatermarksMap(f1, f2, m, conf)
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return AddWatermarksMap(rs, w, m, conf)
+	})
 }
 
 // AddWatermarksSliceMap adds watermarks in m to corresponding pages in rs and writes the result to w.
@@ -107,6 +84,8 @@ func AddWatermarksSliceMap(rs io.ReadSeeker, w io.Writer, m map[int][]*model.Wat
 
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
 	}
 	conf.Cmd = model.ADDWATERMARKS
 
@@ -128,43 +107,15 @@ func AddWatermarksSliceMap(rs io.ReadSeeker, w io.Writer, m map[int][]*model.Wat
 
 // AddWatermarksSliceMapFile adds watermarks to corresponding pages in m of inFile and writes the result to outFile.
 func AddWatermarksSliceMapFile(inFile, outFile string, m map[int][]*model.Watermark, conf *model.Configuration) (err error) {
-	var f1, f2 *os.File
-
-	if f1, err = os.Open(inFile); err != nil {
-		return err
-	}
-
-	tmpFile := inFile + ".tmp"
 	if outFile != "" && inFile != outFile {
-		tmpFile = outFile
 		logWritingTo(outFile)
 	} else {
 		logWritingTo(inFile)
 	}
-	if f2, err = os.Create(tmpFile); err != nil {
-		f1.Close()
-		return err
-	}
-
-	defer func() {
-		if err != nil {
-			f2.Close()
-			f1.Close()
-			os.Remove(tmpFile)
-			return
-		}
-		if err = f2.Close(); err != nil {
-			return
-		}
-		if err = f1.Close(); err != nil {
-			return
-		}
-		if outFile == "" || inFile == outFile {
-			err = os.Rename(tmpFile, inFile)
-		}
-	}()
 
-	return AddWatermarksSliceMap(f1, f2, m, conf)
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return AddWatermarksSliceMap(rs, w, m, conf)
+	})
 }
 
 // AddWatermarks adds watermarks to all pages selected in rs and writes the result to w.
@@ -175,6 +126,8 @@ func AddWatermarks(rs io.ReadSeeker, w io.Writer, selectedPages []string, wm *mo
 
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
 	}
 	conf.Cmd = model.ADDWATERMARKS
 	conf.OptimizeDuplicateContentStreams = false
@@ -189,7 +142,7 @@ func AddWatermarks(rs io.ReadSeeker, w io.Writer, selectedPages []string, wm *mo
 	}
 
 	var pages types.IntSet
-	pages, err = PagesForPageSelection(ctx.PageCount, selectedPages, true, true)
+	pages, err = PagesForPageSelectionWithContext(ctx, selectedPages, true, true)
 	if err != nil {
 		return err
 	}
@@ -203,53 +156,33 @@ func AddWatermarks(rs io.ReadSeeker, w io.Writer, selectedPages []string, wm *mo
 
 // AddWatermarksFile adds watermarks to all selected pages of inFile and writes the result to outFile.
 func AddWatermarksFile(inFile, outFile string, selectedPages []string, wm *model.Watermark, conf *model.Configuration) (err error) {
-	var f1, f2 *os.File
-
-	if f1, err = os.Open(inFile); err != nil {
-		return err
-	}
-
-	tmpFile := inFile + ".tmp"
 	if outFile != "" && inFile != outFile {
-		tmpFile = outFile
 		logWritingTo(outFile)
 	} else {
 		logWritingTo(inFile)
 	}
-	if f2, err = os.Create(tmpFile); err != nil {
-		f1.Close()
-		return err
-	}
 
-	defer func() {
-		if err != nil {
-			f2.Close()
-			f1.Close()
-			os.Remove(tmpFile)
-			return
-		}
-		if err = f2.Close(); err != nil {
-			return
-		}
-		if err = f1.Close(); err != nil {
-			return
-		}
-		if outFile == "" || inFile == outFile {
-			err = os.Rename(tmpFile, inFile)
-		}
-	}()
-
-	return AddWatermarks(f1, f2, selectedPages, wm, conf)
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return AddWatermarks(rs, w, selectedPages, wm, conf)
+	})
 }
 
 // RemoveWatermarks removes watermarks from all pages selected in rs and writes the result to w.
 func RemoveWatermarks(rs io.ReadSeeker, w io.Writer, selectedPages []string, conf *model.Configuration) error {
+	return RemoveWatermarksByID(rs, w, selectedPages, "", conf)
+}
+
+// RemoveWatermarksByID removes watermarks identified by id from all pages selected in rs and writes the result to w.
+// If id is empty all watermarks are removed.
+func RemoveWatermarksByID(rs io.ReadSeeker, w io.Writer, selectedPages []string, id string, conf *model.Configuration) error {
 	if rs == nil {
 		return errors.New("pdfcpu: RemoveWatermarks: missing rs")
 	}
 
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
 	}
 	conf.Cmd = model.REMOVEWATERMARKS
 
@@ -258,12 +191,12 @@ func RemoveWatermarks(rs io.ReadSeeker, w io.Writer, selectedPages []string, con
 		return err
 	}
 
-	pages, err := PagesForPageSelection(ctx.PageCount, selectedPages, true, true)
+	pages, err := PagesForPageSelectionWithContext(ctx, selectedPages, true, true)
 	if err != nil {
 		return err
 	}
 
-	if err = pdfcpu.RemoveWatermarks(ctx, pages); err != nil {
+	if err = pdfcpu.RemoveWatermarksByID(ctx, pages, id); err != nil {
 		return err
 	}
 
@@ -272,43 +205,28 @@ func RemoveWatermarks(rs io.ReadSeeker, w io.Writer, selectedPages []string, con
 
 // RemoveWatermarksFile removes watermarks from all selected pages of inFile and writes the result to outFile.
 func RemoveWatermarksFile(inFile, outFile string, selectedPages []string, conf *model.Configuration) (err error) {
-	var f1, f2 *os.File
-
-	if f1, err = os.Open(inFile); err != nil {
-		return err
+	if outFile != "" && inFile != outFile {
+		logWritingTo(outFile)
+	} else {
+		logWritingTo(inFile)
 	}
 
-	tmpFile := inFile + ".tmp"
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return RemoveWatermarks(rs, w, selectedPages, conf)
+	})
+}
+
+// RemoveWatermarksByIDFile removes watermarks identified by id from all selected pages of inFile and writes the result to outFile.
+func RemoveWatermarksByIDFile(inFile, outFile string, selectedPages []string, id string, conf *model.Configuration) (err error) {
 	if outFile != "" && inFile != outFile {
-		tmpFile = outFile
 		logWritingTo(outFile)
 	} else {
 		logWritingTo(inFile)
 	}
-	if f2, err = os.Create(tmpFile); err != nil {
-		f1.Close()
-		return err
-	}
-
-	defer func() {
-		if err != nil {
-			f2.Close()
-			f1.Close()
-			os.Remove(tmpFile)
-			return
-		}
-		if err = f2.Close(); err != nil {
-			return
-		}
-		if err = f1.Close(); err != nil {
-			return
-		}
-		if outFile == "" || inFile == outFile {
-			err = os.Rename(tmpFile, inFile)
-		}
-	}()
 
-	return RemoveWatermarks(f1, f2, selectedPages, conf)
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return RemoveWatermarksByID(rs, w, selectedPages, id, conf)
+	})
 }
 
 // HasWatermarks checks rs for watermarks.
@@ -382,6 +300,27 @@ func ImageWatermarkForReader(r io.Reader, desc string, onTop, update bool, u typ
 	return wm, nil
 }
 
+// ImageWatermarkForFS returns an image watermark configuration for fileName read from fsys.
+func ImageWatermarkForFS(fsys fs.FS, fileName, desc string, onTop, update bool, u types.DisplayUnit) (*model.Watermark, error) {
+	if !model.ImageFileName(fileName) {
+		return nil, errors.New("imageFileName has to have one of these extensions: .jpg, .jpeg, .png, .tif, .tiff, .webp")
+	}
+
+	bb, err := fs.ReadFile(fsys, fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	wm, err := ImageWatermarkForReader(bytes.NewReader(bb), desc, onTop, update, u)
+	if err != nil {
+		return nil, err
+	}
+
+	wm.FileName = fileName
+
+	return wm, nil
+}
+
 // PDFWatermark returns a PDF watermark configuration.
 func PDFWatermark(fileName, desc string, onTop, update bool, u types.DisplayUnit) (*model.Watermark, error) {
 	wm, err := pdfcpu.ParsePDFWatermarkDetails(fileName, desc, onTop, u)
@@ -394,6 +333,24 @@ func PDFWatermark(fileName, desc string, onTop, update bool, u types.DisplayUnit
 	return wm, nil
 }
 
+// PDFWatermarkForFS returns a PDF watermark configuration for fileName read from fsys.
+// fileName supports the same page number suffixes as PDFWatermark.
+func PDFWatermarkForFS(fsys fs.FS, fileName, desc string, onTop, update bool, u types.DisplayUnit) (*model.Watermark, error) {
+	wm, err := PDFWatermark(fileName, desc, onTop, update, u)
+	if err != nil {
+		return nil, err
+	}
+
+	bb, err := fs.ReadFile(fsys, wm.FileName)
+	if err != nil {
+		return nil, err
+	}
+
+	wm.PDF = bytes.NewReader(bb)
+
+	return wm, nil
+}
+
 // PDFWatermarkForReadSeeker returns a PDF watermark configuration.
 // Apply watermark/stamp to destination file with pageNrSrc of rs for selected pages.
 // If pageNr == 0 apply a multi watermark/stamp applying all src pages in ascending manner to destination pages.
@@ -427,6 +384,26 @@ func PDFMultiWatermarkForReadSeeker(rs io.ReadSeeker, startPageNrSrc, startPageN
 	return wm, nil
 }
 
+func imageWatermark(fileName, desc string, onTop, update bool, conf *model.Configuration) (*model.Watermark, error) {
+	if conf == nil {
+		return ImageWatermark(fileName, desc, onTop, update, types.POINTS)
+	}
+	if conf.ResourceFS != nil {
+		return ImageWatermarkForFS(conf.ResourceFS, fileName, desc, onTop, update, conf.Unit)
+	}
+	return ImageWatermark(fileName, desc, onTop, update, conf.Unit)
+}
+
+func pdfWatermark(fileName, desc string, onTop, update bool, conf *model.Configuration) (*model.Watermark, error) {
+	if conf == nil {
+		return PDFWatermark(fileName, desc, onTop, update, types.POINTS)
+	}
+	if conf.ResourceFS != nil {
+		return PDFWatermarkForFS(conf.ResourceFS, fileName, desc, onTop, update, conf.Unit)
+	}
+	return PDFWatermark(fileName, desc, onTop, update, conf.Unit)
+}
+
 // AddTextWatermarksFile adds text stamps/watermarks to all selected pages of inFile and writes the result to outFile.
 func AddTextWatermarksFile(inFile, outFile string, selectedPages []string, onTop bool, text, desc string, conf *model.Configuration) error {
 	unit := types.POINTS
@@ -444,12 +421,7 @@ func AddTextWatermarksFile(inFile, outFile string, selectedPages []string, onTop
 
 // AddImageWatermarksFile adds image stamps/watermarks to all selected pages of inFile and writes the result to outFile.
 func AddImageWatermarksFile(inFile, outFile string, selectedPages []string, onTop bool, fileName, desc string, conf *model.Configuration) error {
-	unit := types.POINTS
-	if conf != nil {
-		unit = conf.Unit
-	}
-
-	wm, err := ImageWatermark(fileName, desc, onTop, false, unit)
+	wm, err := imageWatermark(fileName, desc, onTop, false, conf)
 	if err != nil {
 		return err
 	}
@@ -474,12 +446,7 @@ func AddImageWatermarksForReaderFile(inFile, outFile string, selectedPages []str
 
 // AddPDFWatermarksFile adds PDF stamps/watermarks to inFile and writes the result to outFile.
 func AddPDFWatermarksFile(inFile, outFile string, selectedPages []string, onTop bool, fileName, desc string, conf *model.Configuration) error {
-	unit := types.POINTS
-	if conf != nil {
-		unit = conf.Unit
-	}
-
-	wm, err := PDFWatermark(fileName, desc, onTop, false, unit)
+	wm, err := pdfWatermark(fileName, desc, onTop, false, conf)
 	if err != nil {
 		return err
 	}
@@ -504,11 +471,7 @@ func UpdateTextWatermarksFile(inFile, outFile string, selectedPages []string, on
 
 // UpdateImageWatermarksFile adds image stamps/watermarks to all selected pages of inFile and writes the result to outFile.
 func UpdateImageWatermarksFile(inFile, outFile string, selectedPages []string, onTop bool, fileName, desc string, conf *model.Configuration) error {
-	unit := types.POINTS
-	if conf != nil {
-		unit = conf.Unit
-	}
-	wm, err := ImageWatermark(fileName, desc, onTop, true, unit)
+	wm, err := imageWatermark(fileName, desc, onTop, true, conf)
 	if err != nil {
 		return err
 	}
@@ -517,15 +480,122 @@ func UpdateImageWatermarksFile(inFile, outFile string, selectedPages []string, o
 
 // UpdatePDFWatermarksFile adds PDF stamps/watermarks to all selected pages of inFile and writes the result to outFile.
 func UpdatePDFWatermarksFile(inFile, outFile string, selectedPages []string, onTop bool, fileName, desc string, conf *model.Configuration) error {
-	unit := types.POINTS
-	if conf != nil {
-		unit = conf.Unit
+	wm, err := pdfWatermark(fileName, desc, onTop, true, conf)
+	if err != nil {
+		return err
+	}
+
+	return AddWatermarksFile(inFile, outFile, selectedPages, wm, conf)
+}
+
+func watermarkForMapEntry(e model.WatermarkMapEntry, onTop bool, conf *model.Configuration) (*model.Watermark, error) {
+	n := 0
+	for _, s := range []string{e.Text, e.Image, e.PDF} {
+		if s != "" {
+			n++
+		}
+	}
+	if n != 1 {
+		return nil, errors.New("please provide exactly one of: text, image, pdf")
+	}
+
+	if e.Image != "" {
+		return imageWatermark(e.Image, e.Desc, onTop, false, conf)
+	}
+
+	if e.PDF != "" {
+		return pdfWatermark(e.PDF, e.Desc, onTop, false, conf)
+	}
+
+	return TextWatermark(e.Text, e.Desc, onTop, false, conf.Unit)
+}
+
+// ParseWatermarkMap parses a JSON watermark map from rd.
+func ParseWatermarkMap(rd io.Reader) (*model.WatermarkMap, error) {
+	if rd == nil {
+		return nil, errors.New("pdfcpu: ParseWatermarkMap: missing rd")
+	}
+
+	var wmm model.WatermarkMap
+	if err := json.NewDecoder(rd).Decode(&wmm); err != nil {
+		return nil, errors.Errorf("pdfcpu: invalid watermark map: %v", err)
+	}
+
+	if len(wmm.Watermarks) == 0 {
+		return nil, errors.New("pdfcpu: missing watermarks")
 	}
 
-	wm, err := PDFWatermark(fileName, desc, onTop, true, unit)
+	return &wmm, nil
+}
+
+// AddWatermarksJSON adds the watermarks of the JSON watermark map read from rd to the corresponding pages of rs
+// and writes the result to w. All watermarks are applied in one pass.
+func AddWatermarksJSON(rs io.ReadSeeker, rd io.Reader, w io.Writer, onTop bool, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: AddWatermarksJSON: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
+	}
+	conf.Cmd = model.ADDWATERMARKS
+	conf.OptimizeDuplicateContentStreams = false
+
+	wmm, err := ParseWatermarkMap(rd)
 	if err != nil {
 		return err
 	}
 
-	return AddWatermarksFile(inFile, outFile, selectedPages, wm, conf)
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	for i, e := range wmm.Watermarks {
+
+		wm, err := watermarkForMapEntry(e, onTop, conf)
+		if err != nil {
+			return errors.Errorf("pdfcpu: watermark map entry %d: %v", i+1, err)
+		}
+
+		var selectedPages []string
+		if e.Pages != "" {
+			if selectedPages, err = ParsePageSelection(e.Pages); err != nil {
+				return errors.Errorf("pdfcpu: watermark map entry %d: %v", i+1, err)
+			}
+		}
+
+		pages, err := PagesForPageSelectionWithContext(ctx, selectedPages, true, true)
+		if err != nil {
+			return err
+		}
+
+		if err = pdfcpu.AddWatermarks(ctx, pages, wm); err != nil {
+			return err
+		}
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// AddWatermarksJSONFile adds the watermarks of the JSON watermark map inFileJSON to the corresponding pages of inFile
+// and writes the result to outFile.
+func AddWatermarksJSONFile(inFile, inFileJSON, outFile string, onTop bool, conf *model.Configuration) (err error) {
+	f, err := os.Open(inFileJSON)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if outFile != "" && inFile != outFile {
+		logWritingTo(outFile)
+	} else {
+		logWritingTo(inFile)
+	}
+
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return AddWatermarksJSON(rs, f, w, onTop, conf)
+	})
 }
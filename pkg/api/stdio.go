@@ -0,0 +1,104 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+// StdIO as inFile denotes stdin, as outFile stdout.
+const StdIO = "-"
+
+// Stdin and Stdout may be replaced for testing.
+var (
+	Stdin  io.Reader = os.Stdin
+	Stdout io.Writer = os.Stdout
+)
+
+// usesStdIO returns true if inFile or outFile denotes stdin or stdout.
+func usesStdIO(inFile, outFile string) bool {
+	return inFile == StdIO || outFile == StdIO
+}
+
+// openInFile returns a ReadSeeker for inFile.
+// Stdin is not seekable and gets buffered in memory.
+func openInFile(inFile string) (io.ReadSeeker, func() error, error) {
+	if inFile != StdIO {
+		f, err := os.Open(inFile)
+		if err != nil {
+			return nil, nil, err
+		}
+		return f, f.Close, nil
+	}
+
+	bb, err := io.ReadAll(Stdin)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(bb) == 0 {
+		return nil, nil, errors.New("pdfcpu: no input on stdin")
+	}
+
+	return bytes.NewReader(bb), func() error { return nil }, nil
+}
+
+// writeStdout writes the output of fn to stdout.
+func writeStdout(fn func(w io.Writer) error) error {
+	w := bufio.NewWriter(Stdout)
+	if err := fn(w); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+// updateStdIO reads inFile and writes the output of fn to outFile where either may be StdIO.
+// If outFile is empty the output goes to stdout.
+func updateStdIO(inFile, outFile string, fn func(rs io.ReadSeeker, w io.Writer) error) (err error) {
+	rs, closeIn, err := openInFile(inFile)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err1 := closeIn(); err == nil {
+			err = err1
+		}
+	}()
+
+	if outFile == "" || outFile == StdIO {
+		return writeStdout(func(w io.Writer) error {
+			return fn(rs, w)
+		})
+	}
+
+	f, err := os.Create(outFile)
+	if err != nil {
+		return err
+	}
+
+	if err = fn(rs, f); err != nil {
+		f.Close()
+		os.Remove(outFile)
+		return err
+	}
+
+	return f.Close()
+}
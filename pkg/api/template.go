@@ -0,0 +1,244 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/create"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/form"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// Template regions live in page-piece dicts which get stripped during optimization,
+// hence all template related commands skip the optimization step.
+
+// TemplateRegions returns the named placeholder regions of the template rs.
+func TemplateRegions(rs io.ReadSeeker, conf *model.Configuration) ([]pdfcpu.TemplateRegion, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: TemplateRegions: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
+	}
+	conf.Cmd = model.LISTTEMPLATEREGIONS
+
+	ctx, err := ReadAndValidate(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return pdfcpu.TemplateRegions(ctx)
+}
+
+// ListTemplateRegions returns a list of the named placeholder regions of the template rs.
+func ListTemplateRegions(rs io.ReadSeeker, conf *model.Configuration) ([]string, error) {
+	rr, err := TemplateRegions(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rr) == 0 {
+		return []string{"no template regions available"}, nil
+	}
+
+	ss := []string{"Template regions:"}
+	for _, r := range rr {
+		ss = append(ss, r.String())
+	}
+
+	return ss, nil
+}
+
+// ListTemplateRegionsFile returns a list of the named placeholder regions of the template inFile.
+func ListTemplateRegionsFile(inFile string, conf *model.Configuration) ([]string, error) {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ListTemplateRegions(f, conf)
+}
+
+// AddTemplateRegions records the named placeholder regions rr in rs and writes the result to w.
+func AddTemplateRegions(rs io.ReadSeeker, w io.Writer, rr []pdfcpu.TemplateRegion, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: AddTemplateRegions: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
+	}
+	conf.Cmd = model.ADDTEMPLATEREGIONS
+
+	ctx, err := ReadAndValidate(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	if err := pdfcpu.AddTemplateRegions(ctx, rr); err != nil {
+		return err
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// AddTemplateRegionsFile records the named placeholder regions rr in inFile and writes the result to outFile.
+func AddTemplateRegionsFile(inFile, outFile string, rr []pdfcpu.TemplateRegion, conf *model.Configuration) error {
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return AddTemplateRegions(rs, w, rr, conf)
+	})
+}
+
+// ParseTemplateFillsJSON parses a JSON object mapping template region names to their content.
+func ParseTemplateFillsJSON(r io.Reader) (map[string]pdfcpu.TemplateFill, error) {
+	fills := map[string]pdfcpu.TemplateFill{}
+	if err := json.NewDecoder(r).Decode(&fills); err != nil {
+		return nil, errors.Wrap(err, "pdfcpu: invalid template JSON")
+	}
+	return fills, nil
+}
+
+// fillTemplateRegion renders f into a form the size of region r and places it on top of r.
+func fillTemplateRegion(ctx *model.Context, r pdfcpu.TemplateRegion, f pdfcpu.TemplateFill) error {
+	bb, err := pdfcpu.TemplateFillJSON(f, r.Rect)
+	if err != nil {
+		return errors.Wrapf(err, "template region %s", r.Name)
+	}
+
+	ctxFill, err := pdfcpu.CreateContextWithXRefTable(model.NewDefaultConfiguration(), types.PaperSize["A4"])
+	if err != nil {
+		return err
+	}
+
+	if err := create.FromJSON(ctxFill, bytes.NewReader(bb)); err != nil {
+		return errors.Wrapf(err, "template region %s", r.Name)
+	}
+
+	ir, box, err := pdfcpu.ImportPageAsForm(ctx, ctxFill, 1, nil)
+	if err != nil {
+		return err
+	}
+
+	return pdfcpu.PlaceForm(ctx, *ir, box, []pdfcpu.FormPlacement{{PageNr: r.PageNr, X: r.Rect.LL.X, Y: r.Rect.LL.Y}})
+}
+
+// FillTemplate replaces the named placeholder regions of the template rs with text blocks, images or tables
+// and writes the result to w. Filled regions are removed from the result, as are form fields defining them.
+func FillTemplate(rs io.ReadSeeker, w io.Writer, fills map[string]pdfcpu.TemplateFill, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: FillTemplate: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
+	}
+	conf.Cmd = model.FILLTEMPLATE
+
+	ctx, err := ReadAndValidate(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	rr, err := pdfcpu.TemplateRegions(ctx)
+	if err != nil {
+		return err
+	}
+
+	filled := map[string]bool{}
+	fieldNames := []string{}
+
+	for _, r := range rr {
+		f, ok := fills[r.Name]
+		if !ok {
+			continue
+		}
+
+		if err := fillTemplateRegion(ctx, r, f); err != nil {
+			return err
+		}
+
+		if r.Field {
+			if !filled[r.Name] {
+				fieldNames = append(fieldNames, r.Name)
+			}
+		} else if err := pdfcpu.RemoveTemplateRegion(ctx, r); err != nil {
+			return err
+		}
+
+		filled[r.Name] = true
+	}
+
+	var missing []string
+	for name := range fills {
+		if !filled[name] {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return errors.Errorf("pdfcpu: unknown template regions: %s", strings.Join(missing, ", "))
+	}
+
+	if len(fieldNames) > 0 {
+		if _, err := form.RemoveFormFields(ctx, fieldNames); err != nil {
+			return err
+		}
+	}
+
+	if log.CLIEnabled() {
+		log.CLI.Printf("%d template region(s) filled\n", len(filled))
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// FillTemplateFile replaces the named placeholder regions of the template inFile with the content described by inFileJSON
+// and writes the result to outFile.
+func FillTemplateFile(inFile, inFileJSON, outFile string, conf *model.Configuration) error {
+	f, err := os.Open(inFileJSON)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	fills, err := ParseTemplateFillsJSON(f)
+	if err != nil {
+		return err
+	}
+
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return FillTemplate(rs, w, fills, conf)
+	})
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package test
 
 import (
+	"bytes"
 	"os"
 	"path/filepath"
 	"testing"
@@ -278,6 +279,48 @@ func TestAddRemoveAnnotationsByAnnotType(t *testing.T) {
 	}
 }
 
+func TestListAnnotationsFiltered(t *testing.T) {
+	msg := "TestListAnnotationsFiltered"
+
+	fn := "test.pdf"
+	copyFile(t, filepath.Join(inDir, fn), filepath.Join(outDir, fn))
+	inFile := filepath.Join(outDir, fn)
+
+	add2Annotations(t, msg, inFile, false)
+
+	f, err := os.Open(inFile)
+	if err != nil {
+		t.Fatalf("%s open: %v\n", msg, err)
+	}
+
+	filter, err := pdfcpu.ParseAnnotationFilter("link", "", "", "", conf.DateFormat)
+	if err != nil {
+		t.Fatalf("%s filter: %v\n", msg, err)
+	}
+
+	aa, err := api.AnnotationInfos(f, nil, filter, conf)
+	f.Close()
+	if err != nil {
+		t.Fatalf("%s infos: %v\n", msg, err)
+	}
+	if len(aa) != 1 || aa[0].Type != "Link" || aa[0].Page != 1 || aa[0].ObjNr == 0 {
+		t.Fatalf("%s: unexpected annotations: %v\n", msg, aa)
+	}
+
+	// Remove the selected annotation by obj#.
+	if err := api.RemoveAnnotationsFile(inFile, "", nil, nil, []int{aa[0].ObjNr}, nil, false); err != nil {
+		t.Fatalf("%s remove: %v\n", msg, err)
+	}
+
+	if i := annotationCount(t, inFile); i != 1 {
+		t.Fatalf("%s count: got %d want 1\n", msg, i)
+	}
+
+	if _, err := pdfcpu.ParseAnnotationFilter("Bogus", "", "", "", conf.DateFormat); err == nil {
+		t.Fatalf("%s: expected error for unknown annotation type\n", msg)
+	}
+}
+
 func TestAddRemoveAnnotationsById(t *testing.T) {
 	msg := "TestAddRemoveAnnotationsById"
 
@@ -1075,3 +1118,248 @@ func TestCaretAnnotation(t *testing.T) {
 		t.Fatalf("%s add: %v\n", msg, err)
 	}
 }
+
+func TestExportComments(t *testing.T) {
+	msg := "TestExportComments"
+
+	inFile := filepath.Join(inDir, "testWithText.pdf")
+	outFile := filepath.Join(outDir, "Comments.pdf")
+
+	r := types.NewRectangle(205, 624.16, 400, 645.88)
+
+	ql := types.NewQuadLiteralForRect(r)
+
+	highlightAnn := model.NewHighlightAnnotation(
+		*r,                    // rect
+		"Please rephrase",     // contents
+		"IDHighlight",         // id
+		"",                    // modDate
+		0,                     // f
+		&color.Yellow,         // col
+		0,                     // borderRadX
+		0,                     // borderRadY
+		2,                     // borderWidth
+		"Reviewer",            // title
+		nil,                   // popupIndRef
+		nil,                   // ca
+		"",                    // rc
+		"",                    // subject
+		types.QuadPoints{*ql}, // quad points
+	)
+
+	if err := api.AddAnnotationsFile(inFile, outFile, nil, highlightAnn, nil, false); err != nil {
+		t.Fatalf("%s add: %v\n", msg, err)
+	}
+
+	// Links are no markup annotations and don't show up in the summary.
+	if err := api.AddAnnotationsFile(outFile, "", nil, linkAnn, nil, false); err != nil {
+		t.Fatalf("%s add: %v\n", msg, err)
+	}
+
+	f, err := os.Open(outFile)
+	if err != nil {
+		t.Fatalf("%s open: %v\n", msg, err)
+	}
+
+	cc, err := api.CommentSummary(f, nil, conf)
+	f.Close()
+	if err != nil {
+		t.Fatalf("%s summary: %v\n", msg, err)
+	}
+
+	// testWithText.pdf already comes with a FreeText comment.
+	if len(cc) != 2 {
+		t.Fatalf("%s: got %d comments, want 2\n", msg, len(cc))
+	}
+
+	c := cc[1]
+	if cc[0].Type != "FreeText" {
+		t.Fatalf("%s: unexpected comment: %+v\n", msg, *cc[0])
+	}
+	if c.Type != "Highlight" || c.Author != "Reviewer" || c.Contents != "Please rephrase" || c.Page != 1 || c.ObjNr == 0 {
+		t.Fatalf("%s: unexpected comment: %+v\n", msg, *c)
+	}
+
+	for _, fn := range []string{"comments.json", "comments.csv", "comments.html"} {
+		if err := api.ExportCommentsFile(outFile, filepath.Join(outDir, fn), nil, "", conf); err != nil {
+			t.Fatalf("%s export %s: %v\n", msg, fn, err)
+		}
+	}
+}
+
+func TestAnnotationReplies(t *testing.T) {
+	msg := "TestAnnotationReplies"
+
+	fn := "test.pdf"
+	copyFile(t, filepath.Join(inDir, fn), filepath.Join(outDir, fn))
+	inFile := filepath.Join(outDir, fn)
+
+	if err := api.AddAnnotationsFile(inFile, "", []string{"1"}, textAnn, nil, false); err != nil {
+		t.Fatalf("%s add: %v\n", msg, err)
+	}
+
+	f, err := os.Open(inFile)
+	if err != nil {
+		t.Fatalf("%s open: %v\n", msg, err)
+	}
+	cc, err := api.CommentSummary(f, nil, conf)
+	f.Close()
+	if err != nil {
+		t.Fatalf("%s summary: %v\n", msg, err)
+	}
+	if len(cc) != 1 {
+		t.Fatalf("%s: got %d comments, want 1\n", msg, len(cc))
+	}
+	objNr := cc[0].ObjNr
+
+	replyObjNr, err := api.AddReplyFile(inFile, "", objNr, "Agreed.", "Jane", conf)
+	if err != nil {
+		t.Fatalf("%s reply: %v\n", msg, err)
+	}
+
+	if _, err := api.SetAnnotationStateFile(inFile, "", replyObjNr, model.StateAccepted, "John", conf); err != nil {
+		t.Fatalf("%s state: %v\n", msg, err)
+	}
+
+	if _, err := api.SetAnnotationStateFile(inFile, "", objNr, "Approved", "John", conf); err == nil {
+		t.Fatalf("%s: expected error for invalid state\n", msg)
+	}
+
+	f, err = os.Open(inFile)
+	if err != nil {
+		t.Fatalf("%s open: %v\n", msg, err)
+	}
+	cc, err = api.CommentSummary(f, nil, conf)
+	f.Close()
+	if err != nil {
+		t.Fatalf("%s summary: %v\n", msg, err)
+	}
+
+	// The reply is nested below the text annotation, the state below the reply.
+	if len(cc) != 1 || len(cc[0].Replies) != 1 {
+		t.Fatalf("%s: unexpected thread: %+v\n", msg, cc)
+	}
+	reply := cc[0].Replies[0]
+	if reply.ObjNr != replyObjNr || reply.Author != "Jane" || reply.Contents != "Agreed." || len(reply.Replies) != 1 {
+		t.Fatalf("%s: unexpected reply: %+v\n", msg, *reply)
+	}
+	if state := reply.Replies[0]; state.State != model.StateAccepted || state.Author != "John" {
+		t.Fatalf("%s: unexpected state: %+v\n", msg, *state)
+	}
+}
+
+func TestGenerateAnnotationAppearances(t *testing.T) {
+	msg := "TestGenerateAnnotationAppearances"
+
+	fn := "test.pdf"
+	inFile := filepath.Join(outDir, "annotAppearances.pdf")
+	copyFile(t, filepath.Join(inDir, fn), inFile)
+
+	m := map[int][]model.AnnotationRenderer{1: {squareAnn, circleAnn, freeTextAnn, linkAnn}}
+	if err := api.AddAnnotationsMapFile(inFile, "", m, nil, false); err != nil {
+		t.Fatalf("%s add: %v\n", msg, err)
+	}
+
+	outFile := filepath.Join(outDir, "annotAppearancesOut.pdf")
+	if err := api.GenerateAnnotationAppearancesFile(inFile, outFile, nil, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	ctx, err := api.ReadContextFile(outFile)
+	if err != nil {
+		t.Fatalf("%s read: %v\n", msg, err)
+	}
+
+	pageDict, _, _, err := ctx.PageDict(1, false)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	annots, err := ctx.DereferenceArray(pageDict["Annots"])
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	for _, o := range annots {
+		d, err := ctx.DereferenceDict(o)
+		if err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+
+		st := *d.Subtype()
+
+		ap, err := ctx.DereferenceDict(d["AP"])
+		if err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+
+		if st == "Link" {
+			// Unsupported annotation types remain untouched.
+			if ap != nil {
+				t.Fatalf("%s: unexpected appearance for %s", msg, st)
+			}
+			continue
+		}
+
+		if ap == nil {
+			t.Fatalf("%s: missing appearance for %s", msg, st)
+		}
+
+		sd, _, err := ctx.DereferenceStreamDict(ap["N"])
+		if err != nil || sd == nil {
+			t.Fatalf("%s: missing normal appearance stream for %s: %v", msg, st, err)
+		}
+
+		if err := sd.Decode(); err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+
+		if len(sd.Content) == 0 {
+			t.Fatalf("%s: empty appearance stream for %s", msg, st)
+		}
+
+		if st == "FreeText" && !bytes.Contains(sd.Content, []byte("(line 2) Tj")) {
+			t.Fatalf("%s: missing FreeText contents", msg)
+		}
+	}
+}
+
+func TestAddAnnotationsJSON(t *testing.T) {
+	msg := "TestAddAnnotationsJSON"
+
+	fn := "test.pdf"
+	inFile := filepath.Join(outDir, "annotJSON.pdf")
+	copyFile(t, filepath.Join(inDir, fn), inFile)
+
+	inFileJSON := filepath.Join(outDir, "annots.json")
+	json := `{"annotations": [
+		{"pages": "1", "type": "Text", "rect": [50, 700, 70, 720], "contents": "Check this", "col": "#FFFF00", "author": "John"},
+		{"pages": "1", "type": "Link", "rect": [50, 50, 250, 70], "uri": "https://pdfcpu.io"},
+		{"pages": "1", "type": "Square", "rect": [100, 100, 200, 200], "col": "Red", "fillCol": "#E0E0E0", "borderWidth": 2},
+		{"pages": "1", "type": "Line", "line": [100, 300, 300, 350], "lineEndings": ["None", "OpenArrow"]},
+		{"pages": "1", "type": "Highlight", "rect": [100, 500, 300, 515]},
+		{"pages": "1", "type": "Ink", "ink": [[10, 10, 20, 30, 40, 15]]}]}`
+	if err := os.WriteFile(inFileJSON, []byte(json), 0644); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if err := api.AddAnnotationsJSONFile(inFile, inFileJSON, "", nil); err != nil {
+		t.Fatalf("%s add: %v\n", msg, err)
+	}
+
+	if i := annotationCount(t, inFile); i != 6 {
+		t.Fatalf("%s count: got %d want 6\n", msg, i)
+	}
+
+	// Line annotations w/o rect derive it from their mandatory end points.
+	var buf bytes.Buffer
+	bad := `{"annotations": [{"type": "Line"}]}`
+	f, err := os.Open(inFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	defer f.Close()
+	if err := api.AddAnnotationsJSON(f, &buf, bytes.NewReader([]byte(bad)), nil); err == nil {
+		t.Fatalf("%s: missing error for Line annotation w/o points\n", msg)
+	}
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package test
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -194,6 +195,39 @@ func TestValidate(t *testing.T) {
 	}
 }
 
+func TestValidationError(t *testing.T) {
+	msg := "TestValidationError"
+	inFile := filepath.Join(inDir, "Acroforms2.pdf")
+
+	f, err := os.Open(inFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	defer f.Close()
+
+	ctx, err := api.ReadContext(f, model.NewDefaultConfiguration())
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	rootDict, err := ctx.Catalog()
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	rootDict.Delete("Pages")
+
+	err = api.ValidateContext(ctx)
+	if err == nil {
+		t.Fatalf("%s: missing validation error\n", msg)
+	}
+	if !errors.Is(err, api.ErrValidation) {
+		t.Fatalf("%s: want ErrValidation, got: %v\n", msg, err)
+	}
+	if errors.Is(errors.New("pdfcpu: no validation error"), api.ErrValidation) {
+		t.Fatalf("%s: unexpected ErrValidation\n", msg)
+	}
+}
+
 func TestManipulateContext(t *testing.T) {
 	msg := "TestManipulateContext"
 	inFile := filepath.Join(inDir, "5116.DCT_Filter.pdf")
@@ -26,6 +26,7 @@ import (
 
 	"github.com/pdfcpu/pdfcpu/pkg/api"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
 )
 
 func prepareForAttachmentTest(t *testing.T) error {
@@ -95,6 +96,25 @@ func TestAttachments(t *testing.T) {
 		t.Fatalf("%s extract one attachment: %v\n", msg, err)
 	}
 
+	// Stream all attachments matching a glob pattern.
+	f, err := os.Open(fileName)
+	if err != nil {
+		t.Fatalf("%s open: %v\n", msg, err)
+	}
+	var names []string
+	err = api.ExtractAttachmentsFunc(f, []string{"*.pdf"}, nil, func(name string, r io.Reader, modTime *time.Time) error {
+		names = append(names, name)
+		_, err := io.Copy(io.Discard, r)
+		return err
+	})
+	f.Close()
+	if err != nil {
+		t.Fatalf("%s extract attachments by glob: %v\n", msg, err)
+	}
+	if len(names) != 3 {
+		t.Fatalf("%s extract attachments by glob: want 3 got %d: %v\n", msg, len(names), names)
+	}
+
 	// Remove 1 attachment.
 	if err := api.RemoveAttachmentsFile(fileName, "", []string{"golang.pdf"}, nil); err != nil {
 		t.Fatalf("%s remove one attachment: %v\n", msg, err)
@@ -260,3 +280,96 @@ func TestAttachmentsLowLevel(t *testing.T) {
 
 	removeAttachment(t, msg, outFile, a, ctx)
 }
+
+func TestAddAttachmentsViaManifest(t *testing.T) {
+	msg := "TestAddAttachmentsViaManifest"
+
+	inFile := filepath.Join(inDir, "go.pdf")
+	inFileJSON := filepath.Join(inDir, "json", "attach", "manifest.json")
+	outFile := filepath.Join(outDir, "goWithManifestAttachments.pdf")
+
+	if err := api.AddAttachmentsJSONFile(inFile, inFileJSON, outFile, false, nil); err != nil {
+		t.Fatalf("%s add attachments: %v\n", msg, err)
+	}
+
+	listAttachments(t, msg, outFile, 2)
+
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: validate: %v\n", msg, err)
+	}
+
+	ctx, err := api.ReadContextFile(outFile)
+	if err != nil {
+		t.Fatalf("%s: read context: %v\n", msg, err)
+	}
+
+	af, err := ctx.DereferenceArray(ctx.RootDict["AF"])
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(af) != 2 {
+		t.Fatalf("%s: want 2 associated files, got %d\n", msg, len(af))
+	}
+
+	d, err := ctx.DereferenceDict(af[0])
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if rel := d.NameEntry("AFRelationship"); rel == nil || *rel != "Supplement" {
+		t.Fatalf("%s: want AFRelationship Supplement, got %v\n", msg, rel)
+	}
+
+	// Invalid relationships are rejected.
+	if _, err := api.ParseAttachmentManifest(strings.NewReader(`{"attachments":[{"file":"a.xml","afRelationship":"Foo"}]}`)); err == nil {
+		t.Fatalf("%s: expected error for invalid afRelationship\n", msg)
+	}
+}
+
+func TestVerifyAttachments(t *testing.T) {
+	msg := "TestVerifyAttachments"
+
+	fileName := filepath.Join(outDir, "attachVerify.pdf")
+	if err := copyFile(t, filepath.Join(inDir, "test.pdf"), fileName); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	attFile := filepath.Join(outDir, "checksum.txt")
+	if err := os.WriteFile(attFile, []byte("Checksum me!"), os.ModePerm); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if err := api.AddAttachmentsFile(fileName, "", []string{attFile}, false, nil); err != nil {
+		t.Fatalf("%s add attachments: %v\n", msg, err)
+	}
+
+	acs, err := api.VerifyAttachmentsFile(fileName, nil)
+	if err != nil {
+		t.Fatalf("%s verify: %v\n", msg, err)
+	}
+	if len(acs) != 1 || !acs[0].CheckSum || !acs[0].Valid {
+		t.Fatalf("%s: unexpected result: %v\n", msg, acs)
+	}
+
+	// Corrupt the declared checksum.
+	ctx, err := api.ReadContextFile(fileName)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	for _, entry := range ctx.Table {
+		sd, ok := entry.Object.(types.StreamDict)
+		if !ok || sd.Type() == nil || *sd.Type() != "EmbeddedFile" {
+			continue
+		}
+		sd.DictEntry("Params")["CheckSum"] = types.HexLiteral("00112233445566778899aabbccddeeff")
+	}
+	if err := api.WriteContextFile(ctx, fileName); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if acs, err = api.VerifyAttachmentsFile(fileName, nil); err != nil {
+		t.Fatalf("%s verify: %v\n", msg, err)
+	}
+	if len(acs) != 1 || !acs[0].CheckSum || acs[0].Valid {
+		t.Fatalf("%s: checksum mismatch not detected: %v\n", msg, acs)
+	}
+}
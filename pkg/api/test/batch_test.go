@@ -0,0 +1,89 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+)
+
+func TestBatch(t *testing.T) {
+	msg := "TestBatch"
+
+	batchInDir := filepath.Join(outDir, "batchIn")
+	batchOutDir := filepath.Join(outDir, "batchOut")
+	if err := os.MkdirAll(batchInDir, os.ModePerm); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	for _, fn := range []string{"5116.DCT_Filter.pdf", "Acroforms2.pdf", "CenterOfWhy.pdf"} {
+		if err := copyFile(t, filepath.Join(inDir, fn), filepath.Join(batchInDir, fn)); err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+	}
+
+	// Provoke a failure for a single file.
+	corrupt := filepath.Join(batchInDir, "corrupt.pdf")
+	if err := os.WriteFile(corrupt, []byte("%PDF-1.7\nno PDF"), os.ModePerm); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	jobs := []api.Job{{
+		Inputs:      []string{batchInDir},
+		OutDir:      batchOutDir,
+		Concurrency: 2,
+		Steps: []api.JobStep{
+			{Op: "stamp", Text: "Confidential", Desc: "scale:.5, rot:0", Pages: "1"},
+			{Op: "rotate", Rotation: 90, Pages: "even"},
+			{Op: "optimize"},
+			{Op: "encrypt", OwnerPW: "opw", Perm: "print"},
+		},
+	}}
+
+	r, err := api.RunJobs(jobs, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if r.OK != 3 || r.Failed != 1 {
+		t.Fatalf("%s: want 3 ok, 1 failed, got:\n%s\n", msg, r)
+	}
+
+	for _, res := range r.Results {
+		if res.Err != nil {
+			if res.InFile != corrupt {
+				t.Fatalf("%s: %s: %v\n", msg, res.InFile, res.Err)
+			}
+			continue
+		}
+		conf := model.NewDefaultConfiguration()
+		conf.OwnerPW = "opw"
+		if err := api.ValidateFile(res.OutFile, conf); err != nil {
+			t.Fatalf("%s: %s: %v\n", msg, res.OutFile, err)
+		}
+	}
+
+	// Invalid jobs are rejected before processing any file.
+	jobs[0].Steps = append(jobs[0].Steps, api.JobStep{Op: "unknown"})
+	if _, err := api.RunJobs(jobs, nil); err == nil {
+		t.Fatalf("%s: expected error for unsupported operation\n", msg)
+	}
+}
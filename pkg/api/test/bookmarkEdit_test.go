@@ -0,0 +1,183 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+func bookmarkTitles(bms []pdfcpu.Bookmark) string {
+	ss := []string{}
+	for _, bm := range bms {
+		s := bm.Title
+		if len(bm.Kids) > 0 {
+			s += "(" + bookmarkTitles(bm.Kids) + ")"
+		}
+		ss = append(ss, s)
+	}
+	return strings.Join(ss, " ")
+}
+
+func bookmarkTitlesFile(t *testing.T, msg, inFile string) string {
+	t.Helper()
+
+	ctx, err := api.ReadContextFile(inFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	bms, err := pdfcpu.Bookmarks(ctx)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	return bookmarkTitles(bms)
+}
+
+func writeBookmarkEditPDF(t *testing.T, msg, outFile string) {
+	t.Helper()
+
+	bms := []pdfcpu.Bookmark{
+		{PageFrom: 1, Title: "Zeta", Kids: []pdfcpu.Bookmark{
+			{PageFrom: 2, Title: "Gamma", Kids: []pdfcpu.Bookmark{
+				{PageFrom: 2, Title: "Deep"},
+			}},
+			{PageFrom: 3, Title: "Beta"},
+		}},
+		{PageFrom: 4, Title: "alpha"},
+		{PageFrom: 5, Title: "Mid"},
+	}
+
+	if err := api.AddBookmarksFile(filepath.Join(inDir, "CenterOfWhy.pdf"), outFile, bms, true, nil); err != nil {
+		t.Fatalf("%s addBookmarks: %v\n", msg, err)
+	}
+}
+
+func TestSortAndCollapseBookmarks(t *testing.T) {
+	msg := "TestSortAndCollapseBookmarks"
+	outFile := filepath.Join(outDir, "bookmarkEdit.pdf")
+
+	writeBookmarkEditPDF(t, msg, outFile)
+
+	if err := api.SortBookmarksFile(outFile, "", false, nil); err != nil {
+		t.Fatalf("%s sort: %v\n", msg, err)
+	}
+	if s, want := bookmarkTitlesFile(t, msg, outFile), "alpha Mid Zeta(Beta Gamma(Deep))"; s != want {
+		t.Fatalf("%s sort by title: want %q, got %q\n", msg, want, s)
+	}
+
+	if err := api.SortBookmarksFile(outFile, "", true, nil); err != nil {
+		t.Fatalf("%s sort: %v\n", msg, err)
+	}
+	if s, want := bookmarkTitlesFile(t, msg, outFile), "Zeta(Gamma(Deep) Beta) alpha Mid"; s != want {
+		t.Fatalf("%s sort by page: want %q, got %q\n", msg, want, s)
+	}
+
+	if err := api.CollapseBookmarksFile(outFile, "", 1, nil); err != nil {
+		t.Fatalf("%s collapse: %v\n", msg, err)
+	}
+	if s, want := bookmarkTitlesFile(t, msg, outFile), "Zeta Gamma Deep Beta alpha Mid"; s != want {
+		t.Fatalf("%s collapse: want %q, got %q\n", msg, want, s)
+	}
+
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+}
+
+// outlineItemDict returns the outline item dict of the nth top level bookmark of ctx.
+func outlineItemDict(t *testing.T, msg string, ctx *model.Context, n int) types.Dict {
+	t.Helper()
+
+	rootDict, err := ctx.Catalog()
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	d, err := ctx.DereferenceDict(rootDict["Outlines"])
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	ir := d.IndirectRefEntry("First")
+	for i := 0; ; i++ {
+		if d, err = ctx.DereferenceDict(*ir); err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		if i == n {
+			return d
+		}
+		ir = d.IndirectRefEntry("Next")
+	}
+}
+
+func TestDedupAndRetargetBookmarks(t *testing.T) {
+	msg := "TestDedupAndRetargetBookmarks"
+	outFile := filepath.Join(outDir, "bookmarkEdit.pdf")
+
+	writeBookmarkEditPDF(t, msg, outFile)
+
+	ctx, err := api.ReadContextFile(outFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// Turn "alpha" into a duplicate of "Zeta".
+	d := outlineItemDict(t, msg, ctx, 1)
+	d["Title"] = types.StringLiteral("Zeta")
+	d["Dest"] = outlineItemDict(t, msg, ctx, 0)["Dest"]
+
+	// Let "Mid" point to a removed page.
+	ir, err := ctx.IndRefForNewObject(types.Dict{"Type": types.Name("Page")})
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	outlineItemDict(t, msg, ctx, 2)["Dest"] = types.Array{*ir, types.Name("Fit")}
+
+	n, err := pdfcpu.DedupBookmarks(ctx)
+	if err != nil {
+		t.Fatalf("%s dedup: %v\n", msg, err)
+	}
+	if n != 1 {
+		t.Fatalf("%s dedup: want 1 removed bookmark, got %d\n", msg, n)
+	}
+
+	if n, err = pdfcpu.RetargetBookmarks(ctx); err != nil {
+		t.Fatalf("%s retarget: %v\n", msg, err)
+	}
+	if n != 1 {
+		t.Fatalf("%s retarget: want 1 retargeted bookmark, got %d\n", msg, n)
+	}
+
+	bms, err := pdfcpu.Bookmarks(ctx)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if s, want := bookmarkTitles(bms), "Zeta(Gamma(Deep) Beta) Mid"; s != want {
+		t.Fatalf("%s: want %q, got %q\n", msg, want, s)
+	}
+	if bms[1].PageFrom != ctx.PageCount {
+		t.Fatalf("%s: want retargeted page %d, got %d\n", msg, ctx.PageCount, bms[1].PageFrom)
+	}
+}
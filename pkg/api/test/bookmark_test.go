@@ -185,3 +185,48 @@ func TestImportBookmarks(t *testing.T) {
 		t.Fatalf("%s: %v\n", msg, err)
 	}
 }
+
+func TestImportBookmarksFromText(t *testing.T) {
+	msg := "TestImportBookmarksFromText"
+	inFile := filepath.Join(inDir, "CenterOfWhy.pdf")
+	outFile := filepath.Join(outDir, "bookmarkText.pdf")
+	inFileMD := filepath.Join(outDir, "bookmarks.md")
+
+	md := "- Chapter 1 | 1\n" +
+		"  - Section 1.1 | 2\n" +
+		"    1. Section 1.1.1 | 2\n" +
+		"  - **Section 1.2** | 3\n" +
+		"- *Chapter 2* | 5\n"
+
+	if err := os.WriteFile(inFileMD, []byte(md), os.ModePerm); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if err := api.ImportBookmarksFile(inFile, inFileMD, outFile, true, nil); err != nil {
+		t.Fatalf("%s importBookmarks: %v\n", msg, err)
+	}
+
+	ctx, err := api.ReadContextFile(outFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	bms, err := pdfcpu.Bookmarks(ctx)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if s, want := bookmarkTitles(bms), "Chapter 1(Section 1.1(Section 1.1.1) Section 1.2) Chapter 2"; s != want {
+		t.Fatalf("%s: want %q, got %q\n", msg, want, s)
+	}
+	if !bms[0].Kids[1].Bold || !bms[1].Italic || bms[1].PageFrom != 5 {
+		t.Fatalf("%s: unexpected bookmarks: %v\n", msg, bms)
+	}
+
+	if err := os.WriteFile(inFileMD, []byte("- Chapter 1\n"), os.ModePerm); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.ImportBookmarksFile(inFile, inFileMD, outFile, true, nil); err == nil {
+		t.Fatalf("%s: missing error for missing page number\n", msg)
+	}
+}
@@ -17,6 +17,10 @@ limitations under the License.
 package test
 
 import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
 	"sync"
 	"testing"
 
@@ -47,3 +51,78 @@ func TestDisableConfigDir_Parallel(t *testing.T) {
 	wg.Wait()
 	t.Log("DisableConfigDir passed")
 }
+
+func TestConfigurationPerCallOverride(t *testing.T) {
+	msg := "TestConfigurationPerCallOverride"
+	inFile := filepath.Join(inDir, "Acroforms2.pdf")
+
+	shared := model.NewConfiguration(model.WithValidationStrict(), model.WithOffline(true))
+	cmd := shared.Cmd
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := api.ValidateFile(inFile, shared.With(model.WithValidationRelaxed())); err != nil {
+				t.Errorf("%s: %v\n", msg, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if shared.ValidationMode != model.ValidationStrict || shared.Cmd != cmd {
+		t.Fatalf("%s: shared configuration modified\n", msg)
+	}
+}
+
+func TestConfigurationShared(t *testing.T) {
+	msg := "TestConfigurationShared"
+	inFile := filepath.Join(inDir, "5116.DCT_Filter.pdf")
+
+	bb, err := os.ReadFile(inFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// One configuration reused by concurrent calls of different commands.
+	shared := model.NewConfiguration(model.WithOwnerPW("opw"), model.WithAES(256))
+	shared.OptimizePasses = []string{model.OptimizePassGC, model.OptimizePassRecompress}
+	want := *shared
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			if err := api.Validate(bytes.NewReader(bb), shared); err != nil {
+				t.Errorf("%s: %v\n", msg, err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			if err := api.Optimize(bytes.NewReader(bb), io.Discard, shared); err != nil {
+				t.Errorf("%s: %v\n", msg, err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			if err := api.Encrypt(bytes.NewReader(bb), io.Discard, shared); err != nil {
+				t.Errorf("%s: %v\n", msg, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if shared.Cmd != want.Cmd || shared.ValidationMode != want.ValidationMode ||
+		shared.UserPW != want.UserPW || shared.OwnerPW != want.OwnerPW || len(shared.OptimizePasses) != 2 {
+		t.Fatalf("%s: shared configuration modified\n", msg)
+	}
+
+	// With does not share reference fields.
+	c := shared.With(model.WithOptimize(false))
+	c.OptimizePasses[0] = model.OptimizePassStdFonts
+	if shared.OptimizePasses[0] != model.OptimizePassGC {
+		t.Fatalf("%s: optimization passes shared\n", msg)
+	}
+}
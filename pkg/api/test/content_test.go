@@ -0,0 +1,90 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+)
+
+func TestNormalizeContent(t *testing.T) {
+	msg := "TestNormalizeContent"
+
+	inFile := filepath.Join(outDir, "ContentMessy.pdf")
+	outFile := filepath.Join(outDir, "ContentNormalized.pdf")
+
+	writePageContentPDF(t, msg, inFile, "q 1 0 0 1 10 10 cm BT/F1 12 Tf(Hello)Tj ET Q 0 0 m 10 10 l S")
+
+	if err := api.NormalizeContentFile(inFile, outFile, nil, false, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	want := "q\n" +
+		"  1 0 0 1 10 10 cm\n" +
+		"  BT\n" +
+		"    /F1 12 Tf\n" +
+		"    (Hello) Tj\n" +
+		"  ET\n" +
+		"Q\n" +
+		"0 0 m\n" +
+		"10 10 l\n" +
+		"S\n"
+
+	if s := pageContent(t, msg, outFile, 1); s != want {
+		t.Fatalf("%s: want:\n%s\ngot:\n%s\n", msg, want, s)
+	}
+}
+
+func TestExtractReplacePageContent(t *testing.T) {
+	msg := "TestExtractReplacePageContent"
+
+	inFile := filepath.Join(outDir, "ContentRoundTrip.pdf")
+	contentFile := filepath.Join(outDir, "ContentRoundTrip.txt")
+	outFile := filepath.Join(outDir, "ContentReplaced.pdf")
+
+	writePageContentPDF(t, msg, inFile, "0 0 1 rg 10 10 100 100 re f")
+
+	if err := api.ExtractPageContentFile(inFile, contentFile, 1, nil); err != nil {
+		t.Fatalf("%s extract: %v\n", msg, err)
+	}
+
+	bb, err := os.ReadFile(contentFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	s := strings.Replace(string(bb), "0 0 1 rg", "1 0 0 rg", 1)
+	if err := os.WriteFile(contentFile, []byte(s), os.ModePerm); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if err := api.ReplacePageContentFile(inFile, contentFile, outFile, 1, true, nil); err != nil {
+		t.Fatalf("%s replace: %v\n", msg, err)
+	}
+
+	if got := pageContent(t, msg, outFile, 1); got != s {
+		t.Fatalf("%s: want:\n%s\ngot:\n%s\n", msg, s, got)
+	}
+
+	if err := api.ReplacePageContentFile(inFile, contentFile, outFile, 1000, true, nil); err == nil {
+		t.Fatalf("%s: missing error for invalid page number\n", msg)
+	}
+}
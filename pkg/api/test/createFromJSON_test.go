@@ -17,7 +17,10 @@ limitations under the License.
 package test
 
 import (
+	"fmt"
+	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/pdfcpu/pdfcpu/pkg/api"
@@ -83,6 +86,10 @@ func TestCreateContentPrimitivesViaJson(t *testing.T) {
 		{"TestBoxesAndMargin", "boxesAndMargin.json", "boxesAndMargin.pdf"},
 		{"TestBoxesAndRotation", "boxesAndRotation.json", "boxesAndRotation.pdf"},
 
+		// Gradients, patterns and transparency
+		{"TestGradientsAndPatterns", "gradientsAndPatterns.json", "gradientsAndPatterns.pdf"},
+		{"TestTransparency", "transparency.json", "transparency.pdf"},
+
 		// Table
 		{"TestTable", "table.json", "table.pdf"},
 		{"TestTableRTL", "tableRTL.json", "tableRTL.pdf"},
@@ -91,6 +98,28 @@ func TestCreateContentPrimitivesViaJson(t *testing.T) {
 		// Content Region
 		{"TestRegions", "regions.json", "regions.pdf"},
 		{"TestRegionsMarginBorderPadding", "regionsMargBordPadd.json", "regionsMarginBorderPadding.pdf"},
+		{"TestClip", "clip.json", "clip.pdf"},
+
+		// Styles
+		{"TestStyles", "styles.json", "styles.pdf"},
+
+		// Automatic page breaking
+		{"TestFlow", "flow.json", "flow.pdf"},
+
+		// Page masters
+		{"TestMasters", "masters.json", "masters.pdf"},
+
+		// Footnotes
+		{"TestFootnotes", "footnotes.json", "footnotes.pdf"},
+
+		// Text flowing around images
+		{"TestFloats", "floats.json", "floats.pdf"},
+
+		// Bookmarks and internal links
+		{"TestNavigation", "navigation.json", "navigation.pdf"},
+
+		// Document language, title and text language overrides
+		{"TestAccessibility", "accessibility.json", "accessibility.pdf"},
 	} {
 		inFileJSON := filepath.Join(inDir, tt.inFileJSON)
 		outFile := filepath.Join(outDir, tt.outFile)
@@ -143,6 +172,9 @@ func TestCreateFormPrimitivesViaJson(t *testing.T) {
 		// Listbox
 		{"TestListbox", "listbox.json", "listbox.pdf"},
 		{"TestListboxGroup", "listboxGroup.json", "listboxGroup.pdf"},
+
+		// Tab order, tooltips, border styles and field flags
+		{"TestFieldAppearance", "appearance.json", "appearance.pdf"},
 	} {
 		inFileJSON := filepath.Join(inDirForm, tt.inFileJSON)
 		outFile := filepath.Join(outDirForm, tt.outFile)
@@ -354,3 +386,76 @@ func TestReadFormAndUpdateFormViaJson(t *testing.T) {
 	outFile = filepath.Join(outDir, "readFormAndUpdateFormCJK.pdf")
 	createPDF(t, "pass1", inFile, inFileJSON, outFile, conf)
 }
+
+func TestCreateImagesForFSViaJson(t *testing.T) {
+	msg := "TestCreateImagesForFSViaJson"
+	outFile := filepath.Join(samplesDir, "create", "primitives", "imagesFromFS.pdf")
+
+	// Image sources are resolved relative to a file system, eg. assets bundled using go:embed.
+	conf := model.NewDefaultConfiguration()
+	conf.ResourceFS = os.DirFS(resDir)
+
+	json := `{
+		"paper": "A4P",
+		"pages": {
+			"1": {
+				"content": {
+					"image": [
+						{
+							"src": "logoSmall.png",
+							"pos": [100, 100],
+							"width": 200
+						}
+					]
+				}
+			}
+		}
+	}`
+
+	f, err := os.Create(outFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.Create(nil, strings.NewReader(json), f, conf); err != nil {
+		f.Close()
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+}
+
+func TestCreateFromTemplateWithData(t *testing.T) {
+	msg := "TestCreateFromTemplateWithData"
+
+	// Bind a JSON template including partials to JSON and CSV data records.
+	jsonDir := filepath.Join(inDir, "json", "create", "template")
+	outDir := filepath.Join(samplesDir, "create", "flow")
+
+	for _, tt := range []struct {
+		inFileJSON, inFileData, outFile string
+		n                               int
+	}{
+		{"letter.json", "letterData.json", "letter.pdf", 2},
+		{"greeting.json", "greetingData.csv", "greeting.pdf", 3},
+	} {
+		inFileJSON := filepath.Join(jsonDir, tt.inFileJSON)
+		inFileData := filepath.Join(jsonDir, tt.inFileData)
+		outFile := filepath.Join(outDir, tt.outFile)
+
+		if err := api.CreateFilesWithData("", inFileJSON, inFileData, outFile, conf); err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+
+		for i := 1; i <= tt.n; i++ {
+			outFile := strings.TrimSuffix(outFile, ".pdf") + fmt.Sprintf("_%02d.pdf", i)
+			if err := api.ValidateFile(outFile, nil); err != nil {
+				t.Fatalf("%s: %v\n", msg, err)
+			}
+		}
+	}
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package test
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
@@ -24,6 +25,7 @@ import (
 	"github.com/pdfcpu/pdfcpu/pkg/api"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
 )
 
 func listPermissions(t *testing.T, fileName string) ([]string, error) {
@@ -230,3 +232,235 @@ func TestSetPermissions(t *testing.T) {
 		t.Fatalf("%s: got: %d want: %d", msg, uint16(*p), uint16(permNew))
 	}
 }
+
+func TestPermissionError(t *testing.T) {
+	msg := "TestPermissionError"
+	inFile := filepath.Join(inDir, "test.pdf")
+	encFile := filepath.Join(outDir, "restricted.pdf")
+	outFile := filepath.Join(outDir, "out.pdf")
+
+	conf := confForAlgorithm(true, 256, "upw", "opw")
+	conf.Permissions = model.PermissionsNone
+	if err := api.EncryptFile(inFile, encFile, conf); err != nil {
+		t.Fatalf("%s: encrypt %s: %v\n", msg, encFile, err)
+	}
+
+	// Rotating pages requires permission to assemble the document (bit 11).
+	conf = confForAlgorithm(true, 256, "upw", "")
+	err := api.RotateFile(encFile, outFile, 90, nil, conf)
+
+	var permErr *pdfcpu.PermissionError
+	if !errors.As(err, &permErr) {
+		t.Fatalf("%s: want PermissionError, got: %v\n", msg, err)
+	}
+	if permErr.Bit != 11 || permErr.Cmd != model.ROTATE {
+		t.Fatalf("%s: unexpected PermissionError: %+v\n", msg, permErr)
+	}
+
+	// The owner password lifts all restrictions.
+	conf = confForAlgorithm(true, 256, "", "opw")
+	if err := api.RotateFile(encFile, outFile, 90, nil, conf); err != nil {
+		t.Fatalf("%s: rotate using opw: %v\n", msg, err)
+	}
+}
+
+func TestEncryptAttachmentsOnly(t *testing.T) {
+	msg := "TestEncryptAttachmentsOnly"
+
+	if err := prepareForAttachmentTest(t); err != nil {
+		t.Fatalf("%s prepare for attachments: %v\n", msg, err)
+	}
+
+	inFile := filepath.Join(outDir, "go.pdf")
+	outFile := filepath.Join(outDir, "goEFF.pdf")
+
+	if err := api.AddAttachmentsFile(inFile, outFile, []string{filepath.Join(outDir, "test.wav")}, true, nil); err != nil {
+		t.Fatalf("%s add attachment: %v\n", msg, err)
+	}
+
+	for _, keyLength := range []int{128, 256} {
+
+		conf := confForAlgorithm(true, keyLength, "upw", "opw")
+		conf.EncryptAttachmentsOnly = true
+		if err := api.EncryptFile(outFile, outFile, conf); err != nil {
+			t.Fatalf("%s: encrypt %s: %v\n", msg, outFile, err)
+		}
+
+		// The document remains openable w/o password.
+		if err := api.ValidateFile(outFile, nil); err != nil {
+			t.Fatalf("%s: validate w/o password: %v\n", msg, err)
+		}
+		if n, err := api.PageCountFile(outFile); err != nil || n == 0 {
+			t.Fatalf("%s: page count w/o password: %d %v\n", msg, n, err)
+		}
+
+		dir := filepath.Join(outDir, "eff")
+		if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+
+		// Accessing embedded files requires the password.
+		if err := api.ExtractAttachmentsFile(outFile, dir, nil, nil); err == nil {
+			t.Fatalf("%s: extract attachments w/o password should fail\n", msg)
+		}
+
+		conf = confForAlgorithm(true, keyLength, "upw", "opw")
+		f, err := os.Open(outFile)
+		if err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		ctx, err := api.ReadAndValidate(f, conf)
+		f.Close()
+		if err != nil {
+			t.Fatalf("%s: read %s: %v\n", msg, outFile, err)
+		}
+		if ctx.EncStrings || ctx.EncStreams || !ctx.EncEmbeddedStreams {
+			t.Fatalf("%s: expected embedded file encryption only\n", msg)
+		}
+
+		// Embedded file streams are identified by reference, their Type entry is optional.
+		if len(ctx.EmbeddedFileStreams) == 0 {
+			t.Fatalf("%s: missing embedded file streams\n", msg)
+		}
+		for objNr := range ctx.EmbeddedFileStreams {
+			if sd, ok := ctx.Table[objNr].Object.(types.StreamDict); ok {
+				sd.Delete("Type")
+			}
+		}
+		outFileNoType := filepath.Join(outDir, "goEFFNoType.pdf")
+		if err := api.WriteContextFile(ctx, outFileNoType); err != nil {
+			t.Fatalf("%s: write %s: %v\n", msg, outFileNoType, err)
+		}
+
+		for _, fn := range []string{outFile, outFileNoType} {
+			conf = confForAlgorithm(true, keyLength, "upw", "opw")
+			if err := api.ExtractAttachmentsFile(fn, dir, nil, conf); err != nil {
+				t.Fatalf("%s: extract attachments from %s: %v\n", msg, fn, err)
+			}
+			bb1, err := os.ReadFile(filepath.Join(dir, "test.wav"))
+			if err != nil {
+				t.Fatalf("%s: %v\n", msg, err)
+			}
+			bb2, err := os.ReadFile(filepath.Join(outDir, "test.wav"))
+			if err != nil {
+				t.Fatalf("%s: %v\n", msg, err)
+			}
+			if string(bb1) != string(bb2) {
+				t.Fatalf("%s: extracted attachment of %s corrupted\n", msg, fn)
+			}
+		}
+
+		conf = confForAlgorithm(true, keyLength, "upw", "opw")
+		if err := api.DecryptFile(outFile, outFile, conf); err != nil {
+			t.Fatalf("%s: decrypt %s: %v\n", msg, outFile, err)
+		}
+	}
+}
+
+func TestEncryptionInfo(t *testing.T) {
+	msg := "TestEncryptionInfo"
+	inFile := filepath.Join(inDir, "5116.DCT_Filter.pdf")
+	outFile := filepath.Join(outDir, "encInfo.pdf")
+
+	info, err := api.EncryptionInfoFile(inFile, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if info.Encrypted {
+		t.Fatalf("%s: %s is not encrypted\n", msg, inFile)
+	}
+
+	conf := confForAlgorithm(true, 128, "upw", "opw")
+	if err := api.EncryptFile(inFile, outFile, conf); err != nil {
+		t.Fatalf("%s: encrypt %s: %v\n", msg, outFile, err)
+	}
+
+	// No password supplied.
+	info, err = api.EncryptionInfoFile(outFile, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if !info.Encrypted || info.R != 4 || info.KeyLength != 128 || info.Algorithm != "AES" {
+		t.Fatalf("%s: unexpected encryption info: %+v\n", msg, info)
+	}
+	if !info.UserPWRequired || info.UserPWValid || info.OwnerPWValid {
+		t.Fatalf("%s: unexpected password status: %+v\n", msg, info)
+	}
+
+	// Owner password supplied.
+	info, err = api.EncryptionInfoFile(outFile, model.NewAESConfiguration("", "opw", 128))
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if !info.OwnerPWValid || info.UserPWValid {
+		t.Fatalf("%s: unexpected password status: %+v\n", msg, info)
+	}
+
+	// User password supplied.
+	info, err = api.EncryptionInfoFile(outFile, model.NewAESConfiguration("upw", "", 128))
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if info.OwnerPWValid || !info.UserPWValid {
+		t.Fatalf("%s: unexpected password status: %+v\n", msg, info)
+	}
+}
+
+func TestUpdateEncryption(t *testing.T) {
+	msg := "TestUpdateEncryption"
+	inFile := filepath.Join(inDir, "5116.DCT_Filter.pdf")
+	outFile := filepath.Join(outDir, "encUpdate.pdf")
+
+	conf := confForAlgorithm(false, 128, "upw", "opw")
+	if err := api.EncryptFile(inFile, outFile, conf); err != nil {
+		t.Fatalf("%s: encrypt %s: %v\n", msg, outFile, err)
+	}
+
+	// Updating requires both current passwords.
+	conf = confForAlgorithm(true, 256, "", "opw")
+	if err := api.UpdateEncryptionFile(outFile, "", conf); err == nil {
+		t.Fatalf("%s: update encryption w/o user password should fail\n", msg)
+	}
+
+	conf = confForAlgorithm(true, 256, "upw", "opw")
+	conf.Permissions = model.PermissionsAll
+	conf.UpdatePermissions = true
+	if err := api.UpdateEncryptionFile(outFile, "", conf); err != nil {
+		t.Fatalf("%s: update encryption %s: %v\n", msg, outFile, err)
+	}
+
+	info, err := api.EncryptionInfoFile(outFile, model.NewAESConfiguration("upw", "opw", 256))
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if info.Algorithm != "AES" || info.KeyLength != 256 {
+		t.Fatalf("%s: unexpected algorithm: %s-%d\n", msg, info.Algorithm, info.KeyLength)
+	}
+	if !info.UserPWValid || !info.OwnerPWValid {
+		t.Fatalf("%s: passwords not retained: %+v\n", msg, info)
+	}
+	if uint16(info.Permissions) != uint16(model.PermissionsAll) {
+		t.Fatalf("%s: permissions got: %d want: %d\n", msg, uint16(info.Permissions), uint16(model.PermissionsAll))
+	}
+
+	if err := api.ValidateFile(outFile, model.NewAESConfiguration("upw", "opw", 256)); err != nil {
+		t.Fatalf("%s: validate %s: %v\n", msg, outFile, err)
+	}
+
+	// Permissions are retained unless explicitly updated.
+	conf = confForAlgorithm(true, 128, "upw", "opw")
+	if err := api.UpdateEncryptionFile(outFile, "", conf); err != nil {
+		t.Fatalf("%s: update encryption %s: %v\n", msg, outFile, err)
+	}
+
+	info, err = api.EncryptionInfoFile(outFile, model.NewAESConfiguration("upw", "opw", 128))
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if info.KeyLength != 128 {
+		t.Fatalf("%s: unexpected key length: %d\n", msg, info.KeyLength)
+	}
+	if uint16(info.Permissions) != uint16(model.PermissionsAll) {
+		t.Fatalf("%s: permissions not retained, got: %d want: %d\n", msg, uint16(info.Permissions), uint16(model.PermissionsAll))
+	}
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+func writeWithExtensions(t *testing.T, inFile, outFile string, extensions types.Dict, requirements ...string) {
+	t.Helper()
+
+	ctx, err := api.ReadContextFile(inFile)
+	if err != nil {
+		t.Fatalf("read %s: %v\n", inFile, err)
+	}
+
+	ctx.RootDict["Extensions"] = extensions
+
+	arr := types.Array{}
+	for _, s := range requirements {
+		arr = append(arr, types.Dict{"Type": types.Name("Requirement"), "S": types.Name(s)})
+	}
+	ctx.RootDict["Requirements"] = arr
+
+	if err := api.WriteContextFile(ctx, outFile); err != nil {
+		t.Fatalf("write %s: %v\n", outFile, err)
+	}
+}
+
+func developerExtension(baseVersion string, level int) types.Dict {
+	return types.Dict{
+		"Type":           types.Name("DeveloperExtensions"),
+		"BaseVersion":    types.Name(baseVersion),
+		"ExtensionLevel": types.Integer(level),
+	}
+}
+
+func TestMergeExtensions(t *testing.T) {
+	msg := "TestMergeExtensions"
+
+	inFile := filepath.Join(inDir, "test.pdf")
+	inFile1 := filepath.Join(outDir, "extensions1.pdf")
+	inFile2 := filepath.Join(outDir, "extensions2.pdf")
+	outFile := filepath.Join(outDir, "extensionsMerged.pdf")
+
+	writeWithExtensions(t, inFile, inFile1,
+		types.Dict{"ADBE": developerExtension("1.7", 3)},
+		"EnableJavaScripts")
+
+	writeWithExtensions(t, inFile, inFile2,
+		types.Dict{"ADBE": developerExtension("1.7", 8), "GLGR": developerExtension("1.7", 1002)},
+		"EnableJavaScripts", "OCInteract")
+
+	if err := api.MergeCreateFile([]string{inFile1, inFile2}, outFile, false, nil); err != nil {
+		t.Fatalf("%s: merge: %v\n", msg, err)
+	}
+
+	ctx, err := api.ReadContextFile(outFile)
+	if err != nil {
+		t.Fatalf("%s: read %s: %v\n", msg, outFile, err)
+	}
+
+	ee, err := pdfcpu.Extensions(ctx.XRefTable)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	want := []pdfcpu.DeveloperExtension{
+		{Prefix: "ADBE", BaseVersion: "1.7", ExtensionLevel: 8},
+		{Prefix: "GLGR", BaseVersion: "1.7", ExtensionLevel: 1002},
+	}
+	if len(ee) != len(want) || ee[0] != want[0] || ee[1] != want[1] {
+		t.Fatalf("%s: extensions want:%v got:%v\n", msg, want, ee)
+	}
+
+	rr, err := pdfcpu.Requirements(ctx.XRefTable)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(rr) != 2 || rr[0] != "EnableJavaScripts" || rr[1] != "OCInteract" {
+		t.Fatalf("%s: requirements got:%v\n", msg, rr)
+	}
+
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: validate %s: %v\n", msg, outFile, err)
+	}
+}
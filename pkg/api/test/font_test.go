@@ -17,10 +17,12 @@ limitations under the License.
 package test
 
 import (
+	"bytes"
 	"fmt"
-
+	"os"
 	"path/filepath"
 	"testing"
+	"testing/fstest"
 
 	"github.com/pdfcpu/pdfcpu/pkg/api"
 	"github.com/pdfcpu/pdfcpu/pkg/font"
@@ -128,3 +130,101 @@ func TestUserFontDemoPDF(t *testing.T) {
 		}
 	}
 }
+
+func TestUserFontManagement(t *testing.T) {
+	msg := "TestUserFontManagement"
+
+	defer api.SetUserFontDir(font.UserFontDir)
+
+	dir := filepath.Join(outDir, "userfonts")
+	if err := api.SetUserFontDir(dir); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(api.ListUserFonts()) != 0 {
+		t.Fatalf("%s: expected no user fonts in %s\n", msg, dir)
+	}
+
+	f, err := os.Open(filepath.Join(inDir, "fonts", "Roboto-Regular.ttf"))
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	defer f.Close()
+
+	if err := api.InstallFont(f, "Roboto-Regular.ttf"); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if ss := api.ListUserFonts(); len(ss) != 1 || ss[0] != "Roboto-Regular" {
+		t.Fatalf("%s: unexpected user fonts: %v\n", msg, ss)
+	}
+
+	// Serve the installed fonts read only.
+	if err := api.SetUserFontFS(os.DirFS(dir)); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if !font.IsUserFont("Roboto-Regular") {
+		t.Fatalf("%s: missing user font Roboto-Regular\n", msg)
+	}
+	if err := api.UninstallFont("Roboto-Regular"); err == nil {
+		t.Fatalf("%s: uninstalled font from read only fs\n", msg)
+	}
+
+	if err := api.SetUserFontDir(dir); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.UninstallFont("Roboto-Regular"); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(api.ListUserFonts()) != 0 {
+		t.Fatalf("%s: expected no user fonts in %s\n", msg, dir)
+	}
+
+	// Serve a plain TrueType font file read only, eg. bundled using go:embed.
+	bb, err := os.ReadFile(filepath.Join(inDir, "fonts", "Roboto-Regular.ttf"))
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.SetUserFontFS(fstest.MapFS{"Roboto-Regular.ttf": {Data: bb}}); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if ss := api.ListUserFonts(); len(ss) != 1 || ss[0] != "Roboto-Regular" {
+		t.Fatalf("%s: unexpected user fonts: %v\n", msg, ss)
+	}
+	if bb, err := font.Read("Roboto-Regular"); err != nil || len(bb) == 0 {
+		t.Fatalf("%s: missing font file for Roboto-Regular: %v\n", msg, err)
+	}
+}
+
+func TestUserFontCache(t *testing.T) {
+	msg := "TestUserFontCache"
+
+	fontName := "Roboto-Regular"
+	if !font.IsUserFont(fontName) {
+		t.Skipf("%s: missing user font %s\n", msg, fontName)
+	}
+
+	usedGIDs := map[uint16]bool{36: true, 37: true, 38: true}
+
+	bb1, err := font.Subset(fontName, usedGIDs)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// Subsetting again is served from cached font tables which must not be modified by subsetting.
+	bb2, err := font.Subset(fontName, usedGIDs)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if !bytes.Equal(bb1, bb2) {
+		t.Fatalf("%s: subsets of cached font differ\n", msg)
+	}
+
+	font.ClearCache()
+
+	bb3, err := font.Subset(fontName, usedGIDs)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if !bytes.Equal(bb1, bb3) {
+		t.Fatalf("%s: subsets differ after clearing the cache\n", msg)
+	}
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+)
+
+func TestPlacePageAndExtractFormXObjects(t *testing.T) {
+	msg := "TestPlacePageAndExtractFormXObjects"
+
+	inFile := filepath.Join(inDir, "test.pdf")
+	formFile := filepath.Join(inDir, "Wonderwall.pdf")
+	outFile := filepath.Join(outDir, "PlacedPage.pdf")
+
+	// Place a logo twice on page 1.
+	pp := []pdfcpu.FormPlacement{
+		{PageNr: 1, X: 20, Y: 20, Scale: 0.1},
+		{PageNr: 1, X: 400, Y: 20, Scale: 0.1, Rotation: 90},
+	}
+
+	if err := api.PlacePageFile(inFile, formFile, outFile, 1, pp, nil); err != nil {
+		t.Fatalf("%s place: %v\n", msg, err)
+	}
+
+	ctx, err := api.ReadContextFile(outFile)
+	if err != nil {
+		t.Fatalf("%s read: %v\n", msg, err)
+	}
+
+	ff, err := pdfcpu.FormXObjects(ctx, []int{1})
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// Both placements share a single form XObject.
+	s := pageContent(t, msg, outFile, 1)
+	shared := false
+	for _, f := range ff {
+		if strings.Count(s, "/"+f.Names[0]+" Do") == 2 {
+			shared = true
+		}
+	}
+	if !shared {
+		t.Fatalf("%s: missing shared form XObject: %v\n", msg, ff)
+	}
+
+	dir := filepath.Join(outDir, "forms")
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if err := api.ExtractFormXObjectsFile(outFile, dir, []string{"1"}, nil); err != nil {
+		t.Fatalf("%s extract: %v\n", msg, err)
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "PlacedPage_form_*.pdf"))
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(files) == 0 {
+		t.Fatalf("%s: no form XObjects extracted\n", msg)
+	}
+
+	for _, f := range files {
+		if err := api.ValidateFile(f, nil); err != nil {
+			t.Fatalf("%s validate %s: %v\n", msg, f, err)
+		}
+	}
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package test
 
 import (
+	"encoding/csv"
 	"os"
 	"path/filepath"
 	"testing"
@@ -24,6 +25,7 @@ import (
 	"github.com/pdfcpu/pdfcpu/pkg/api"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/form"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
 )
 
 /**************************************************************
@@ -69,6 +71,87 @@ func TestListFormFields(t *testing.T) {
 	}
 }
 
+func TestFormFieldDetails(t *testing.T) {
+
+	msg := "TestFormFieldDetails"
+	inFile := filepath.Join(samplesDir, "form", "demo", "english.pdf")
+
+	fds, err := api.FormFieldDetailsFile(inFile, conf)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	m := map[string]form.FieldDetail{}
+	for _, fd := range fds {
+		m[fd.Name] = fd
+	}
+
+	for name, typ := range map[string]string{"firstName1": form.DetailText, "dob1": form.DetailDate} {
+		fd, ok := m[name]
+		if !ok {
+			t.Fatalf("%s: missing field %s\n", msg, name)
+		}
+		if fd.Type != typ {
+			t.Fatalf("%s: %s: want type %s, got %s\n", msg, name, typ, fd.Type)
+		}
+		if len(fd.Widgets) == 0 || fd.Widgets[0].Page != 1 || len(fd.Widgets[0].Rect) != 4 {
+			t.Fatalf("%s: %s: invalid widgets: %v\n", msg, name, fd.Widgets)
+		}
+	}
+}
+
+func TestFormFieldValues(t *testing.T) {
+
+	msg := "TestFormFieldValues"
+	inFile := filepath.Join(samplesDir, "form", "demoSinglePage", "english.pdf")
+
+	fvs, err := api.FormFieldValuesFile(inFile, conf)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	m := map[string]form.FieldValue{}
+	for _, fv := range fvs {
+		m[fv.Name] = fv
+	}
+
+	for name, want := range map[string]string{
+		"firstName1": "Jackie",
+		"gender1":    "non-binary",
+		"cb11":       "Yes",
+		"cb12":       "Off",
+		"city12":     "Sidney",
+	} {
+		fv, ok := m[name]
+		if !ok {
+			t.Fatalf("%s: missing field %s\n", msg, name)
+		}
+		if fv.Value != want {
+			t.Fatalf("%s: %s: want %s, got %s\n", msg, name, want, fv.Value)
+		}
+	}
+
+	if fv := m["cb12"]; fv.Export != "" {
+		t.Fatalf("%s: cb12: unexpected export value %s\n", msg, fv.Export)
+	}
+
+	if fv := m["city11"]; len(fv.Values) != 2 {
+		t.Fatalf("%s: city11: want 2 values, got %v\n", msg, fv.Values)
+	}
+
+	fv, err := api.FormFieldValueFile(inFile, "lastName1", conf)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if fv.Value != "Doe" {
+		t.Fatalf("%s: lastName1: want Doe, got %s\n", msg, fv.Value)
+	}
+
+	if _, err := api.FormFieldValueFile(inFile, "missing", conf); err != form.ErrNoFormField {
+		t.Fatalf("%s: want %v, got %v\n", msg, form.ErrNoFormField, err)
+	}
+}
+
 func TestRemoveFormFields(t *testing.T) {
 
 	msg := "TestRemoveFormFields"
@@ -184,6 +267,52 @@ func TestExportForm(t *testing.T) {
 	}
 }
 
+func TestExportFormCSV(t *testing.T) {
+
+	msg := "TestExportFormCSV"
+	formDir := filepath.Join(samplesDir, "form", "demoSinglePage")
+	outFile := filepath.Join(samplesDir, "form", "export", "forms.csv")
+
+	inFiles := []string{
+		filepath.Join(formDir, "english.pdf"),
+		filepath.Join(formDir, "person.pdf"),
+		filepath.Join(inDir, "test.pdf"), // No form
+	}
+
+	if err := api.ExportFormCSVFile(inFiles, outFile, conf); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	f, err := os.Open(outFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	defer f.Close()
+
+	recs, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if len(recs) != len(inFiles)+1 {
+		t.Fatalf("%s: want %d records, got %d\n", msg, len(inFiles)+1, len(recs))
+	}
+
+	col := -1
+	for i, s := range recs[0] {
+		if s == "firstName1" {
+			col = i
+		}
+	}
+	if col < 0 {
+		t.Fatalf("%s: missing column firstName1: %v\n", msg, recs[0])
+	}
+
+	if recs[1][col] != "Jackie" || recs[3][col] != "" {
+		t.Fatalf("%s: unexpected values for firstName1: %s, %s\n", msg, recs[1][col], recs[3][col])
+	}
+}
+
 func TestFillForm(t *testing.T) {
 
 	inDir := filepath.Join(samplesDir, "form", "demoSinglePage")
@@ -211,6 +340,76 @@ func TestFillForm(t *testing.T) {
 	}
 }
 
+func TestFillFormSharedFonts(t *testing.T) {
+	msg := "TestFillFormSharedFonts"
+
+	inFile := filepath.Join(samplesDir, "form", "demoSinglePage", "english.pdf")
+	inFileJSON := filepath.Join(samplesDir, "form", "fill", "english.json")
+	outFile := filepath.Join(outDir, "englishSharedFonts.pdf")
+
+	if err := api.FillFormFile(inFile, inFileJSON, outFile, conf); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	ctx, err := api.ReadContextFile(outFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	fonts := map[string]types.IndirectRef{}
+
+	for i := 1; i <= ctx.PageCount; i++ {
+		d, _, _, err := ctx.PageDict(i, false)
+		if err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		annots, err := ctx.DereferenceArray(d["Annots"])
+		if err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		for _, o := range annots {
+			d, err := ctx.DereferenceDict(o)
+			if err != nil {
+				t.Fatalf("%s: %v\n", msg, err)
+			}
+			ap := d.DictEntry("AP")
+			if ap == nil {
+				continue
+			}
+			sd, _, err := ctx.DereferenceStreamDict(ap["N"])
+			if err != nil || sd == nil {
+				continue
+			}
+			o, found := sd.Find("Resources")
+			if !found {
+				continue
+			}
+			if _, ok := o.(types.IndirectRef); !ok {
+				t.Fatalf("%s: want shared resources, got: %s\n", msg, o)
+			}
+			res, err := ctx.DereferenceDict(o)
+			if err != nil {
+				t.Fatalf("%s: %v\n", msg, err)
+			}
+			fd, err := ctx.DereferenceDict(res["Font"])
+			if err != nil {
+				t.Fatalf("%s: %v\n", msg, err)
+			}
+			for _, o := range fd {
+				ir := o.(types.IndirectRef)
+				f, err := ctx.DereferenceDict(ir)
+				if err != nil {
+					t.Fatalf("%s: %v\n", msg, err)
+				}
+				if ir1, ok := fonts[f.PDFString()]; ok && ir1 != ir {
+					t.Fatalf("%s: duplicate font dicts: %s %s\n", msg, ir1, ir)
+				}
+				fonts[f.PDFString()] = ir
+			}
+		}
+	}
+}
+
 func TestMultiFillFormJSON(t *testing.T) {
 
 	inDir := filepath.Join(samplesDir, "form", "demoSinglePage")
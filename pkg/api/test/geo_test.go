@@ -0,0 +1,77 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+)
+
+func TestGeoViewports(t *testing.T) {
+	msg := "TestGeoViewports"
+
+	inFile := filepath.Join(outDir, "geo.pdf")
+	copyFile(t, filepath.Join(inDir, "test.pdf"), inFile)
+	inFileJSON := filepath.Join(inDir, "json", "geo", "viewports.json")
+
+	if err := api.AddGeoViewportsFile(inFile, inFileJSON, "", []string{"1"}, nil); err != nil {
+		t.Fatalf("%s %s: add viewports: %v\n", msg, inFile, err)
+	}
+
+	f, err := os.Open(inFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	defer f.Close()
+
+	gvs, err := api.GeoViewports(f, nil, nil)
+	if err != nil {
+		t.Fatalf("%s %s: viewports: %v\n", msg, inFile, err)
+	}
+	if len(gvs) != 1 {
+		t.Fatalf("%s %s: want 1 viewport, got %d\n", msg, inFile, len(gvs))
+	}
+	gv := gvs[0]
+	if gv.PageNr != 1 || gv.Name != "Zürich" || gv.GCS.EPSG != 4326 || len(gv.GPTS) != 8 {
+		t.Fatalf("%s %s: unexpected viewport: %+v\n", msg, inFile, gv)
+	}
+
+	want := []string{
+		"Georeferenced viewports:",
+		`page 1 "Zürich": bbox [36 36 576 756]`,
+	}
+	got, err := api.ListGeoViewportsFile(inFile, nil, nil)
+	if err != nil {
+		t.Fatalf("%s %s: list viewports: %v\n", msg, inFile, err)
+	}
+	if len(got) != 4 || got[0] != want[0] || got[1] != want[1] {
+		t.Fatalf("%s %s: list viewports, want:%v, got:%v\n", msg, inFile, want, got)
+	}
+
+	if err := api.RemoveGeoViewportsFile(inFile, "", nil, nil); err != nil {
+		t.Fatalf("%s %s: remove viewports: %v\n", msg, inFile, err)
+	}
+	if got, err = api.ListGeoViewportsFile(inFile, nil, nil); err != nil {
+		t.Fatalf("%s %s: list viewports: %v\n", msg, inFile, err)
+	}
+	if len(got) != 1 || got[0] != "no georeferenced viewports available" {
+		t.Fatalf("%s %s: list viewports after remove, got:%v\n", msg, inFile, got)
+	}
+}
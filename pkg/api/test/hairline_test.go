@@ -0,0 +1,115 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// writePageContentPDF writes a copy of test.pdf with content bb on page 1.
+func writePageContentPDF(t *testing.T, msg, outFile, content string) {
+	t.Helper()
+
+	ctx, err := api.ReadContextFile(filepath.Join(inDir, "test.pdf"))
+	if err != nil {
+		t.Fatalf("%s read: %v\n", msg, err)
+	}
+
+	d, _, _, err := ctx.PageDict(1, false)
+	if err != nil {
+		t.Fatalf("%s page: %v\n", msg, err)
+	}
+
+	sd, err := ctx.NewStreamDictForBuf([]byte(content))
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := sd.Encode(); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	ir, err := ctx.IndRefForNewObject(*sd)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	d.Update("Contents", *ir)
+	d.Update("Resources", types.Dict{})
+
+	if err := api.WriteContextFile(ctx, outFile); err != nil {
+		t.Fatalf("%s write: %v\n", msg, err)
+	}
+}
+
+func pageContent(t *testing.T, msg, inFile string, pageNr int) string {
+	t.Helper()
+
+	ctx, err := api.ReadContextFile(inFile)
+	if err != nil {
+		t.Fatalf("%s read: %v\n", msg, err)
+	}
+
+	d, _, _, err := ctx.PageDict(pageNr, false)
+	if err != nil {
+		t.Fatalf("%s page: %v\n", msg, err)
+	}
+
+	bb, err := ctx.PageContent(d)
+	if err != nil {
+		t.Fatalf("%s content: %v\n", msg, err)
+	}
+
+	return string(bb)
+}
+
+func TestFixHairlines(t *testing.T) {
+	msg := "TestFixHairlines"
+
+	inFile := filepath.Join(outDir, "Hairlines.pdf")
+	outFile := filepath.Join(outDir, "HairlinesFixed.pdf")
+
+	writePageContentPDF(t, msg, inFile,
+		"q 0.05 w 10 10 m 100 100 l S Q "+
+			"q 1 w 10 10 m 100 10 l S Q "+
+			"q 0.1 0 0 0.1 0 0 cm 1 w 0 0 m 100 0 l S Q")
+
+	hc, err := pdfcpu.ParseHairlineConfig("threshold: 0.2", types.POINTS)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if err := api.FixHairlinesFile(inFile, outFile, nil, hc, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	s := pageContent(t, msg, outFile, 1)
+
+	for _, want := range []string{
+		"0.2 w 10 10 m 100 100 l S 0.05 w",
+		"1 w 10 10 m 100 10 l S Q",
+		"2 w 0 0 m 100 0 l S 1 w",
+	} {
+		if !strings.Contains(s, want) {
+			t.Fatalf("%s: missing %q in:\n%s\n", msg, want, s)
+		}
+	}
+}
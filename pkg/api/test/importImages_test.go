@@ -26,6 +26,7 @@ import (
 
 	"github.com/pdfcpu/pdfcpu/pkg/api"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
 )
 
@@ -119,3 +120,25 @@ func TestMemBasedWriterPanic(t *testing.T) {
 	}
 
 }
+
+func TestImportImagesForFS(t *testing.T) {
+	msg := "TestImportImagesForFS"
+	outFile := filepath.Join(samplesDir, "import", "ImportFromFS.pdf")
+	os.Remove(outFile)
+
+	// Resolve image file names relative to a file system, eg. assets bundled using go:embed.
+	conf := model.NewDefaultConfiguration()
+	conf.ResourceFS = os.DirFS(resDir)
+
+	if err := api.ImportImagesFile([]string{"logoSmall.png"}, outFile, pdfcpu.DefaultImportConfig(), conf); err != nil {
+		t.Fatalf("%s %s: %v\n", msg, outFile, err)
+	}
+
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if err := api.ImportImagesFile([]string{"missing.png"}, outFile, nil, conf); err == nil {
+		t.Fatalf("%s: expected error for missing image\n", msg)
+	}
+}
@@ -96,3 +96,39 @@ func TestKeywords(t *testing.T) {
 	// # of keywords must be 0
 	listKeywords(t, msg, fileName, nil)
 }
+
+func TestKeywordsJSON(t *testing.T) {
+	msg := "TestKeywordsJSON"
+
+	fileName := filepath.Join(outDir, "go.pdf")
+	if err := copyFile(t, filepath.Join(inDir, "go.pdf"), fileName); err != nil {
+		t.Fatalf("%s: copyFile: %v\n", msg, err)
+	}
+
+	if err := api.AddKeywordsFile(fileName, "", []string{"draft"}, nil); err != nil {
+		t.Fatalf("%s add keywords: %v\n", msg, err)
+	}
+
+	inFileJSON := filepath.Join(inDir, "json", "keywords", "keywords.json")
+	if err := api.ImportKeywordsFile(fileName, inFileJSON, "", false, nil); err != nil {
+		t.Fatalf("%s import keywords: %v\n", msg, err)
+	}
+	listKeywords(t, msg, fileName, []string{"draft", "invoice", "Überweisung", "请求"})
+
+	outFileJSON := filepath.Join(outDir, "keywords.json")
+	if err := api.ExportKeywordsFile(fileName, outFileJSON, nil); err != nil {
+		t.Fatalf("%s export keywords: %v\n", msg, err)
+	}
+
+	// Replace all keywords.
+	if err := api.ImportKeywordsFile(fileName, inFileJSON, "", true, nil); err != nil {
+		t.Fatalf("%s import keywords (replace): %v\n", msg, err)
+	}
+	listKeywords(t, msg, fileName, []string{"invoice", "Überweisung", "请求"})
+
+	// Restore the exported keywords.
+	if err := api.ImportKeywordsFile(fileName, outFileJSON, "", true, nil); err != nil {
+		t.Fatalf("%s import exported keywords: %v\n", msg, err)
+	}
+	listKeywords(t, msg, fileName, []string{"draft", "invoice", "Überweisung", "请求"})
+}
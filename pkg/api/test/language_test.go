@@ -0,0 +1,74 @@
+/*
+Copyright 2026 The pdf Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+)
+
+func TestLanguage(t *testing.T) {
+	msg := "testLanguage"
+
+	fileName := "test.pdf"
+	inFile := filepath.Join(outDir, "language.pdf")
+	copyFile(t, filepath.Join(inDir, fileName), inFile)
+
+	lang := "en-US"
+
+	if err := api.ResetLanguageFile(inFile, "", nil); err != nil {
+		t.Fatalf("%s %s: reset language: %v\n", msg, inFile, err)
+	}
+
+	s, err := api.LanguageFile(inFile, nil)
+	if err != nil {
+		t.Fatalf("%s %s: list language: %v\n", msg, inFile, err)
+	}
+	if s != "" {
+		t.Fatalf("%s %s: list language, unexpected: %s\n", msg, inFile, s)
+	}
+
+	if err := api.SetLanguageFile(inFile, "", "en US", nil); err == nil {
+		t.Fatalf("%s %s: set language: expected error for invalid language\n", msg, inFile)
+	}
+
+	if err := api.SetLanguageFile(inFile, "", lang, nil); err != nil {
+		t.Fatalf("%s %s: set language: %v\n", msg, inFile, err)
+	}
+
+	s, err = api.LanguageFile(inFile, nil)
+	if err != nil {
+		t.Fatalf("%s %s: list language: %v\n", msg, inFile, err)
+	}
+	if s != lang {
+		t.Fatalf("%s %s: list language, want:%s, got:%s\n", msg, inFile, lang, s)
+	}
+
+	if err := api.ResetLanguageFile(inFile, "", nil); err != nil {
+		t.Fatalf("%s %s: reset language: %v\n", msg, inFile, err)
+	}
+
+	s, err = api.LanguageFile(inFile, nil)
+	if err != nil {
+		t.Fatalf("%s %s: list language: %v\n", msg, inFile, err)
+	}
+	if s != "" {
+		t.Fatalf("%s %s: list language, unexpected: %s\n", msg, inFile, s)
+	}
+}
@@ -0,0 +1,274 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+var u3d = []byte("U3D\x00 pdfcpu test artwork")
+
+// writeMediaPDF writes a copy of inFile with a 3D and a RichMedia annotation on page 1 to outFile.
+func writeMediaPDF(t *testing.T, inFile, outFile string) {
+	t.Helper()
+
+	ctx, err := api.ReadContextFile(inFile)
+	if err != nil {
+		t.Fatalf("read %s: %v\n", inFile, err)
+	}
+
+	sd, _ := ctx.NewStreamDictForBuf(u3d)
+	sd.InsertName("Type", "3D")
+	sd.InsertName("Subtype", "U3D")
+	if err := sd.Encode(); err != nil {
+		t.Fatalf("encode 3D stream: %v\n", err)
+	}
+	ir3D, err := ctx.IndRefForNewObject(*sd)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	irEmb, err := ctx.NewEmbeddedStreamDict(bytes.NewReader(u3d), time.Now())
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	fs, err := ctx.NewFileSpecDict("model.u3d", "model.u3d", "", *irEmb)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	irFS, err := ctx.IndRefForNewObject(fs)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	annots := types.Array{}
+	for _, d := range []types.Dict{
+		{
+			"Type":    types.Name("Annot"),
+			"Subtype": types.Name("3D"),
+			"Rect":    types.NewNumberArray(100, 100, 300, 300),
+			"3DD":     *ir3D,
+		},
+		{
+			"Type":    types.Name("Annot"),
+			"Subtype": types.Name("RichMedia"),
+			"Rect":    types.NewNumberArray(300, 300, 500, 500),
+			"RichMediaContent": types.Dict{
+				"Type":   types.Name("RichMediaContent"),
+				"Assets": types.Dict{"Names": types.Array{types.StringLiteral("model.u3d"), *irFS}},
+			},
+		},
+	} {
+		ir, err := ctx.IndRefForNewObject(d)
+		if err != nil {
+			t.Fatalf("%v\n", err)
+		}
+		annots = append(annots, *ir)
+	}
+
+	d, _, _, err := ctx.PageDict(1, false)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	d["Annots"] = annots
+
+	if err := api.WriteContextFile(ctx, outFile); err != nil {
+		t.Fatalf("write %s: %v\n", outFile, err)
+	}
+}
+
+func TestMedia(t *testing.T) {
+	msg := "TestMedia"
+
+	inFile := filepath.Join(outDir, "media.pdf")
+	writeMediaPDF(t, filepath.Join(inDir, "test.pdf"), inFile)
+
+	want := []string{
+		"media content:",
+		"page 1: 3D (obj#", // object numbers depend on the input file
+		"page 1: RichMedia (obj#",
+	}
+
+	checkList := func(inFile string, n int) {
+		t.Helper()
+		ss, err := api.ListMediaFile(inFile, nil, nil)
+		if err != nil {
+			t.Fatalf("%s %s: list media: %v\n", msg, inFile, err)
+		}
+		if len(ss) != 1+n || ss[0] != want[0] {
+			t.Fatalf("%s %s: list media, got:%v\n", msg, inFile, ss)
+		}
+		for i := 0; i < 2 && i < n; i++ {
+			if len(ss[i+1]) < len(want[i+1]) || ss[i+1][:len(want[i+1])] != want[i+1] {
+				t.Fatalf("%s %s: list media, want:%v, got:%v\n", msg, inFile, want, ss)
+			}
+		}
+	}
+
+	checkList(inFile, 2)
+
+	f, err := os.Open(inFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	defer f.Close()
+
+	mm, err := api.Media(f, nil, nil)
+	if err != nil {
+		t.Fatalf("%s %s: media: %v\n", msg, inFile, err)
+	}
+	if len(mm) != 2 || mm[0].Format != "U3D" || mm[1].Name != "model.u3d" || mm[1].Size != len(u3d) {
+		t.Fatalf("%s %s: unexpected media: %v\n", msg, inFile, mm)
+	}
+
+	// 3D content survives optimize, merge and trim.
+	outFile := filepath.Join(outDir, "mediaOptimized.pdf")
+	if err := api.OptimizeFile(inFile, outFile, nil); err != nil {
+		t.Fatalf("%s %s: optimize: %v\n", msg, inFile, err)
+	}
+	checkList(outFile, 2)
+
+	outFile = filepath.Join(outDir, "mediaMerged.pdf")
+	if err := api.MergeCreateFile([]string{inFile, inFile}, outFile, false, nil); err != nil {
+		t.Fatalf("%s %s: merge: %v\n", msg, inFile, err)
+	}
+	checkList(outFile, 4)
+
+	outFile = filepath.Join(outDir, "mediaTrimmed.pdf")
+	if err := api.TrimFile(inFile, outFile, []string{"1"}, nil); err != nil {
+		t.Fatalf("%s %s: trim: %v\n", msg, inFile, err)
+	}
+	checkList(outFile, 2)
+
+	if err := api.ExtractMediaFile(inFile, outDir, nil, nil); err != nil {
+		t.Fatalf("%s %s: extract media: %v\n", msg, inFile, err)
+	}
+	ff, err := filepath.Glob(filepath.Join(outDir, "media_*_page_1_*"))
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(ff) != 2 {
+		t.Fatalf("%s %s: extract media, got files:%v\n", msg, inFile, ff)
+	}
+	for _, fn := range ff {
+		bb, err := os.ReadFile(fn)
+		if err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		if !bytes.Equal(bb, u3d) {
+			t.Fatalf("%s %s: extracted content mismatch\n", msg, fn)
+		}
+	}
+}
+
+func TestSoundAndMovieMedia(t *testing.T) {
+	msg := "TestSoundAndMovieMedia"
+
+	inFile := filepath.Join(inDir, "test.pdf")
+	outFile := filepath.Join(outDir, "soundAndMovie.pdf")
+
+	ctx, err := api.ReadContextFile(inFile)
+	if err != nil {
+		t.Fatalf("%s read %s: %v\n", msg, inFile, err)
+	}
+
+	samples := []byte{0x80, 0x90, 0xA0, 0x90, 0x80, 0x70, 0x60, 0x70}
+	sd, _ := ctx.NewStreamDictForBuf(samples)
+	sd.InsertName("Type", "Sound")
+	sd.InsertInt("R", 8000)
+	sd.InsertInt("C", 1)
+	sd.InsertInt("B", 8)
+	sd.InsertName("E", "Raw")
+	if err := sd.Encode(); err != nil {
+		t.Fatalf("%s encode sound: %v\n", msg, err)
+	}
+	irSound, err := ctx.IndRefForNewObject(*sd)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	movie := []byte("pdfcpu test movie")
+	irEmb, err := ctx.NewEmbeddedStreamDict(bytes.NewReader(movie), time.Now())
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	fs, err := ctx.NewFileSpecDict("clip.mp4", "clip.mp4", "", *irEmb)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	annots := types.Array{}
+	for _, d := range []types.Dict{
+		{
+			"Type":    types.Name("Annot"),
+			"Subtype": types.Name("Sound"),
+			"Rect":    types.NewNumberArray(100, 100, 120, 120),
+			"Sound":   *irSound,
+		},
+		{
+			"Type":    types.Name("Annot"),
+			"Subtype": types.Name("Movie"),
+			"Rect":    types.NewNumberArray(200, 200, 400, 300),
+			"Movie":   types.Dict{"F": fs},
+		},
+	} {
+		ir, err := ctx.IndRefForNewObject(d)
+		if err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		annots = append(annots, *ir)
+	}
+
+	d, _, _, err := ctx.PageDict(1, false)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	d["Annots"] = annots
+
+	if err := api.WriteContextFile(ctx, outFile); err != nil {
+		t.Fatalf("%s write %s: %v\n", msg, outFile, err)
+	}
+
+	f, err := os.Open(outFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	defer f.Close()
+
+	mm, err := api.Media(f, nil, nil)
+	if err != nil {
+		t.Fatalf("%s %s: media: %v\n", msg, outFile, err)
+	}
+	if len(mm) != 2 {
+		t.Fatalf("%s %s: got %d media, want 2\n", msg, outFile, len(mm))
+	}
+
+	if m := mm[0]; m.Annot != "Sound" || m.Format != "RAW" || m.Codec != "Raw 8 bit 1 ch 8000 Hz" || m.Size != len(samples) {
+		t.Fatalf("%s: unexpected sound: %v\n", msg, m)
+	}
+
+	if m := mm[1]; m.Annot != "Movie" || m.Format != "MP4" || m.Name != "clip.mp4" || m.Size != len(movie) {
+		t.Fatalf("%s: unexpected movie: %v\n", msg, m)
+	}
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+func mergeWithPolicy(t *testing.T, msg, inFile string, p model.MergeConflictPolicy) ([]string, error) {
+	t.Helper()
+
+	// Merge inFile with a copy of itself resulting in colliding names.
+	inFile2 := filepath.Join(outDir, "mergeConflictCopy.pdf")
+	if err := copyFile(t, inFile, inFile2); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	conf := model.NewDefaultConfiguration()
+	conf.MergeConflicts = p
+
+	var buf bytes.Buffer
+	report, err := api.MergeWithReport("", []string{inFile, inFile2}, &buf, conf, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := api.Validate(bytes.NewReader(buf.Bytes()), nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	return report, nil
+}
+
+func TestMergeConflictPolicies(t *testing.T) {
+	msg := "TestMergeConflictPolicies"
+
+	for _, inFile := range []string{
+		filepath.Join(samplesDir, "form", "demoSinglePage", "english.pdf"), // form fields
+		filepath.Join(inDir, "adobe_errata.pdf"),                           // named destinations
+	} {
+
+		for _, p := range []model.MergeConflictPolicy{model.MergeConflictRename, model.MergeConflictSkip} {
+			report, err := mergeWithPolicy(t, msg, inFile, p)
+			if err != nil {
+				t.Fatalf("%s %s %s: %v\n", msg, inFile, p, err)
+			}
+			if len(report) == 0 {
+				t.Fatalf("%s %s %s: missing merge report\n", msg, inFile, p)
+			}
+		}
+
+		if _, err := mergeWithPolicy(t, msg, inFile, model.MergeConflictError); err == nil {
+			t.Fatalf("%s %s: expected merge conflict error\n", msg, inFile)
+		}
+	}
+}
+
+func TestParseMergeConflictPolicy(t *testing.T) {
+	msg := "TestParseMergeConflictPolicy"
+
+	for s, want := range map[string]model.MergeConflictPolicy{
+		"rename": model.MergeConflictRename,
+		"skip":   model.MergeConflictSkip,
+		"e":      model.MergeConflictError,
+	} {
+		p, err := model.ParseMergeConflictPolicy(s)
+		if err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		if p != want {
+			t.Fatalf("%s: %s: want %s, got %s\n", msg, s, want, p)
+		}
+	}
+
+	if _, err := model.ParseMergeConflictPolicy("overwrite"); err == nil {
+		t.Fatalf("%s: expected error for invalid policy\n", msg)
+	}
+}
+
+func mergedIdentity(t *testing.T, msg string, di *model.DocIdentity) (types.Array, types.Dict) {
+	t.Helper()
+
+	inFile := filepath.Join(inDir, "test.pdf")
+	inFile2 := filepath.Join(outDir, "mergeIdentityCopy.pdf")
+	if err := copyFile(t, inFile, inFile2); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	conf := model.NewDefaultConfiguration()
+	conf.MergeIdentity = di
+
+	var buf bytes.Buffer
+	if err := api.Merge("", []string{inFile, inFile2}, &buf, conf, false); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	ctx, err := api.ReadContext(bytes.NewReader(buf.Bytes()), model.NewDefaultConfiguration())
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	d, err := ctx.DereferenceDict(*ctx.Info)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	return ctx.ID, d
+}
+
+func TestMergeIdentity(t *testing.T) {
+	msg := "TestMergeIdentity"
+
+	ctx, err := api.ReadContextFile(filepath.Join(inDir, "test.pdf"))
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	d, err := ctx.DereferenceDict(*ctx.Info)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// Preserve ID and dates of the first input.
+	id, d1 := mergedIdentity(t, msg, &model.DocIdentity{Keep: true})
+	if id.PDFString() != ctx.ID.PDFString() {
+		t.Fatalf("%s: want ID %s, got %s\n", msg, ctx.ID, id)
+	}
+	if d1["CreationDate"].String() != d["CreationDate"].String() || d1["ModDate"].String() != d["ModDate"].String() {
+		t.Fatalf("%s: dates not preserved: %s\n", msg, d1)
+	}
+
+	// Explicit values take precedence.
+	di, err := model.ParseDocIdentity("keep, id:0a1b2c3d, modified:2024-02-01", "2006-01-02")
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	id, d1 = mergedIdentity(t, msg, di)
+	if want := types.HexLiteral("0A1B2C3D"); len(id) != 2 || id[0] != want || id[1] != want {
+		t.Fatalf("%s: unexpected ID %s\n", msg, id)
+	}
+	if d1["CreationDate"].String() != d["CreationDate"].String() || !strings.HasPrefix(d1["ModDate"].String(), "(D:20240201") {
+		t.Fatalf("%s: unexpected dates: %s\n", msg, d1)
+	}
+
+	// By default the ID gets updated.
+	if id, _ = mergedIdentity(t, msg, nil); id.PDFString() == ctx.ID.PDFString() {
+		t.Fatalf("%s: ID not updated\n", msg)
+	}
+
+	if _, err := model.ParseDocIdentity("id:xyz", "2006-01-02"); err == nil {
+		t.Fatalf("%s: missing error for invalid ID\n", msg)
+	}
+}
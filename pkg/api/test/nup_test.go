@@ -17,10 +17,12 @@ limitations under the License.
 package test
 
 import (
+	"os"
 	"path/filepath"
 	"testing"
 
 	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
 )
 
@@ -109,3 +111,57 @@ func TestNUp(t *testing.T) {
 		testNUp(t, tt.msg, tt.inFiles, tt.outFile, tt.selectedPages, tt.desc, tt.n, tt.isImg, conf)
 	}
 }
+
+func checkBookmarkPages(t *testing.T, msg, fileName string, bms []pdfcpu.Bookmark, pageCount int) {
+	t.Helper()
+	for _, bm := range bms {
+		if bm.PageFrom < 1 || bm.PageFrom > pageCount {
+			t.Fatalf("%s %s: bookmark %q points to page %d of %d\n", msg, fileName, bm.Title, bm.PageFrom, pageCount)
+		}
+		checkBookmarkPages(t, msg, fileName, bm.Kids, pageCount)
+	}
+}
+
+func TestNUpBookletRemapBookmarks(t *testing.T) {
+	msg := "TestNUpBookletRemapBookmarks"
+
+	inFile := filepath.Join(inDir, "CenterOfWhy.pdf")
+
+	nup, err := api.PDFNUpConfig(4, "", nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	outFileNUp := filepath.Join(outDir, "CenterOfWhyNUp4.pdf")
+	if err := api.NUpFile([]string{inFile}, outFileNUp, nil, nup, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	booklet, err := api.PDFBookletConfig(2, "", nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	outFileBooklet := filepath.Join(outDir, "CenterOfWhyBooklet.pdf")
+	if err := api.BookletFile([]string{inFile}, outFileBooklet, []string{"1-4"}, booklet, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	for _, fileName := range []string{outFileNUp, outFileBooklet} {
+		if err := api.ValidateFile(fileName, nil); err != nil {
+			t.Fatalf("%s %s: %v\n", msg, fileName, err)
+		}
+		pageCount, err := api.PageCountFile(fileName)
+		if err != nil {
+			t.Fatalf("%s %s: %v\n", msg, fileName, err)
+		}
+		f, err := os.Open(fileName)
+		if err != nil {
+			t.Fatalf("%s %s: %v\n", msg, fileName, err)
+		}
+		bms, err := api.Bookmarks(f, nil)
+		f.Close()
+		if err != nil {
+			t.Fatalf("%s %s: %v\n", msg, fileName, err)
+		}
+		checkBookmarkPages(t, msg, fileName, bms, pageCount)
+	}
+}
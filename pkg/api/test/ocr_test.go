@@ -0,0 +1,136 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+)
+
+func pageWords(t *testing.T, msg, inFile string) *pdfcpu.PageWords {
+	t.Helper()
+
+	f, err := os.Open(inFile)
+	if err != nil {
+		t.Fatalf("%s open: %v\n", msg, err)
+	}
+	defer f.Close()
+
+	pww, err := api.Words(f, []string{"1"}, conf)
+	if err != nil {
+		t.Fatalf("%s words: %v\n", msg, err)
+	}
+
+	return pww[0]
+}
+
+func testApplyOCR(t *testing.T, msg, ocr string) {
+	t.Helper()
+
+	inFile := filepath.Join(inDir, "test.pdf")
+	outFile := filepath.Join(outDir, msg+".pdf")
+
+	pw := pageWords(t, msg, inFile)
+	if pw.Rotate != 0 {
+		t.Fatalf("%s: unexpected page rotation: %d\n", msg, pw.Rotate)
+	}
+	vp := pw.CropBox
+
+	// The page image is twice the size of the page.
+	ocr = strings.NewReplacer("$W", fmt.Sprintf("%.0f", 2*vp.Width()), "$H", fmt.Sprintf("%.0f", 2*vp.Height())).Replace(ocr)
+
+	f, err := os.Open(inFile)
+	if err != nil {
+		t.Fatalf("%s open: %v\n", msg, err)
+	}
+	defer f.Close()
+
+	var buf bytes.Buffer
+	if err := api.ApplyOCR(f, strings.NewReader(ocr), &buf, []string{"1"}, 50, conf); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if err := os.WriteFile(outFile, buf.Bytes(), os.ModePerm); err != nil {
+		t.Fatalf("%s write: %v\n", msg, err)
+	}
+
+	var found bool
+	for _, w := range pageWords(t, msg, outFile).Words {
+		if w.Text == "lowconf" {
+			t.Fatalf("%s: word below minimum confidence added\n", msg)
+		}
+		if w.Text != "Searchable" {
+			continue
+		}
+		found = true
+		if math.Abs(w.Rect.LL.X-(vp.LL.X+100)) > 1 || math.Abs(w.Rect.UR.X-(vp.LL.X+200)) > 1 ||
+			math.Abs(w.Rect.LL.Y-(vp.UR.Y-70)) > 1 || math.Abs(w.Rect.UR.Y-(vp.UR.Y-50)) > 1 {
+			t.Fatalf("%s: unexpected position: %v\n", msg, w.Rect)
+		}
+	}
+
+	if !found {
+		t.Fatalf("%s: missing word\n", msg)
+	}
+}
+
+func TestApplyHOCR(t *testing.T) {
+	hocr := `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE html PUBLIC "-//W3C//DTD XHTML 1.0 Transitional//EN" "http://www.w3.org/TR/xhtml1/DTD/xhtml1-transitional.dtd">
+<html xmlns="http://www.w3.org/1999/xhtml">
+<head><meta name="ocr-system" content="tesseract"/></head>
+<body>
+<div class="ocr_page" id="page_1" title="image &quot;scan.png&quot;; bbox 0 0 $W $H; ppageno 0">
+<span class="ocr_line" title="bbox 200 100 800 140">
+<span class="ocrx_word" title="bbox 200 100 400 140; x_wconf 95"><strong>Searchable</strong></span>
+<span class="ocrx_word" title="bbox 500 100 800 140; x_wconf 10">lowconf</span>
+</span>
+</div>
+</body>
+</html>`
+
+	testApplyOCR(t, "TestApplyHOCR", hocr)
+}
+
+func TestApplyALTO(t *testing.T) {
+	alto := `<?xml version="1.0" encoding="UTF-8"?>
+<alto xmlns="http://www.loc.gov/standards/alto/ns-v3#">
+<Layout>
+<Page ID="page_0" WIDTH="$W" HEIGHT="$H" PHYSICAL_IMG_NR="0">
+<PrintSpace>
+<TextBlock>
+<TextLine>
+<String CONTENT="Searchable" HPOS="200" VPOS="100" WIDTH="200" HEIGHT="40" WC="0.95"/>
+<SP/>
+<String CONTENT="lowconf" HPOS="500" VPOS="100" WIDTH="300" HEIGHT="40" WC="0.10"/>
+</TextLine>
+</TextBlock>
+</PrintSpace>
+</Page>
+</Layout>
+</alto>`
+
+	testApplyOCR(t, "TestApplyALTO", alto)
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+)
+
+func TestOpenAction(t *testing.T) {
+	msg := "TestOpenAction"
+
+	fileName := "CenterOfWhy.pdf"
+	inFile := filepath.Join(outDir, "openAction.pdf")
+	copyFile(t, filepath.Join(inDir, fileName), inFile)
+
+	iv, err := pdfcpu.ParseInitialView(2, "fit", "UseOutlines", "TwoPageLeft")
+	if err != nil {
+		t.Fatalf("%s: parse initial view: %v\n", msg, err)
+	}
+
+	if err := api.SetOpenActionFile(inFile, "", *iv, nil); err != nil {
+		t.Fatalf("%s %s: set open action: %v\n", msg, inFile, err)
+	}
+
+	want := []string{"OpenAction: page 2, zoom: fit", "PageMode: UseOutlines", "PageLayout: TwoPageLeft"}
+	got, err := api.ListOpenActionFile(inFile, nil)
+	if err != nil {
+		t.Fatalf("%s %s: list open action: %v\n", msg, inFile, err)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("%s %s: list open action, want:%v, got:%v\n", msg, inFile, want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("%s %s: list open action, want:%v, got:%v\n", msg, inFile, want, got)
+		}
+	}
+
+	// Zoom only changes the open action.
+	if iv, err = pdfcpu.ParseInitialView(0, "150", "", ""); err != nil {
+		t.Fatalf("%s: parse initial view: %v\n", msg, err)
+	}
+	if err := api.SetOpenActionFile(inFile, "", *iv, nil); err != nil {
+		t.Fatalf("%s %s: set open action: %v\n", msg, inFile, err)
+	}
+	if got, err = api.ListOpenActionFile(inFile, nil); err != nil {
+		t.Fatalf("%s %s: list open action: %v\n", msg, inFile, err)
+	}
+	if got[0] != "OpenAction: page 1, zoom: 150%" {
+		t.Fatalf("%s %s: list open action, unexpected: %v\n", msg, inFile, got)
+	}
+
+	if err := api.ResetOpenActionFile(inFile, "", nil); err != nil {
+		t.Fatalf("%s %s: reset open action: %v\n", msg, inFile, err)
+	}
+
+	if got, err = api.ListOpenActionFile(inFile, nil); err != nil {
+		t.Fatalf("%s %s: list open action: %v\n", msg, inFile, err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("%s %s: list open action after reset, unexpected: %v\n", msg, inFile, got)
+	}
+
+	if _, err := pdfcpu.ParseInitialView(1, "huge", "", ""); err == nil {
+		t.Fatalf("%s: parse initial view: expected error for invalid zoom\n", msg)
+	}
+}
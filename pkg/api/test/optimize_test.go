@@ -17,10 +17,16 @@ limitations under the License.
 package test
 
 import (
+	"bytes"
+	"encoding/json"
+	"os"
 	"path/filepath"
 	"testing"
 
 	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
 )
 
 func TestOptimize(t *testing.T) {
@@ -41,3 +47,520 @@ func TestOptimize(t *testing.T) {
 		t.Fatalf("%s: %v\n", msg, err)
 	}
 }
+
+func TestOptimizeMemoryOnly(t *testing.T) {
+	msg := "TestOptimizeMemoryOnly"
+	fileName := "Acroforms2.pdf"
+	inFile := filepath.Join(outDir, fileName)
+	copyFile(t, filepath.Join(inDir, fileName), inFile)
+
+	// Optimize inFile in place without writing any intermediate file.
+	conf := model.NewDefaultConfiguration()
+	conf.MemoryOnly = true
+	if err := api.OptimizeFile(inFile, "", conf); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if _, err := os.Stat(inFile + ".tmp"); !os.IsNotExist(err) {
+		t.Fatalf("%s: unexpected intermediate file\n", msg)
+	}
+
+	if err := api.ValidateFile(inFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+}
+
+func TestOptimizePasses(t *testing.T) {
+	msg := "TestOptimizePasses"
+	inFile := filepath.Join(inDir, "Acroforms2.pdf")
+	outFile := filepath.Join(outDir, "optimizePasses.pdf")
+	reportFile := filepath.Join(outDir, "optimizePasses.json")
+
+	passes, err := model.ParseOptimizePasses("gc, recompress,metadata")
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	conf := model.NewDefaultConfiguration()
+	conf.OptimizePasses = passes
+	conf.StatsFileName = reportFile
+
+	f, err := os.Open(inFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	defer f.Close()
+
+	w, err := os.Create(outFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	r, err := api.OptimizeWithReport(f, w, conf)
+	if err != nil {
+		w.Close()
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if len(r.Passes) != 3 || r.Passes[0].Pass != model.OptimizePassGC {
+		t.Fatalf("%s: unexpected passes: %v\n", msg, r.Passes)
+	}
+
+	if r.SizeBefore == 0 || r.SizeAfter == 0 {
+		t.Fatalf("%s: missing file sizes: %+v\n", msg, r)
+	}
+
+	bb, err := os.ReadFile(reportFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	var r1 pdfcpu.OptimizeReport
+	if err := json.Unmarshal(bb, &r1); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(r1.Passes) != len(r.Passes) || r1.SizeAfter != r.SizeAfter {
+		t.Fatalf("%s: report file does not match: %+v\n", msg, r1)
+	}
+
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if _, err := model.ParseOptimizePasses("fonts,nopass"); err == nil {
+		t.Fatalf("%s: expected error for invalid optimization pass\n", msg)
+	}
+}
+
+func TestOptimizeStdIO(t *testing.T) {
+	msg := "TestOptimizeStdIO"
+	inFile := filepath.Join(inDir, "Acroforms2.pdf")
+
+	bb, err := os.ReadFile(inFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	stdin, stdout := api.Stdin, api.Stdout
+	defer func() {
+		api.Stdin, api.Stdout = stdin, stdout
+	}()
+
+	var buf bytes.Buffer
+	api.Stdin, api.Stdout = bytes.NewReader(bb), &buf
+
+	// Read from stdin and write to stdout.
+	if err := api.OptimizeFile(api.StdIO, api.StdIO, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if buf.Len() == 0 {
+		t.Fatalf("%s: missing output on stdout\n", msg)
+	}
+	if err := api.Validate(bytes.NewReader(buf.Bytes()), nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// Read from stdin and write to outFile.
+	outFile := filepath.Join(outDir, "stdin.pdf")
+	api.Stdin = bytes.NewReader(bb)
+	if err := api.OptimizeFile(api.StdIO, outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// Validate stdin.
+	api.Stdin = bytes.NewReader(bb)
+	if err := api.ValidateFile(api.StdIO, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+}
+
+func TestPreserveObjectNumbers(t *testing.T) {
+	msg := "TestPreserveObjectNumbers"
+	inFile := filepath.Join(inDir, "test.pdf")
+	outFile := filepath.Join(outDir, "testPreserveObjectNumbers.pdf")
+
+	ctx, err := api.ReadContextFile(inFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	ctx.PreserveObjectNumbers = true
+
+	ir, err := ctx.IndRefForNewObject(types.Dict{})
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	objNr := ir.ObjectNumber.Value()
+
+	if err := ctx.FreeObject(objNr); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// The freed object number must not be recycled.
+	ir, err = ctx.IndRefForNewObject(types.Dict{})
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if ir.ObjectNumber.Value() <= objNr {
+		t.Fatalf("%s: obj #%d recycled as #%d\n", msg, objNr, ir.ObjectNumber.Value())
+	}
+
+	if err := api.WriteContextFile(ctx, outFile); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+}
+
+// writeFlatPageTreePDF writes a PDF with pageCount empty pages below a single intermediate node defining the media box.
+func writeFlatPageTreePDF(t *testing.T, msg, outFile string, pageCount int) {
+	t.Helper()
+
+	ctx, err := pdfcpu.CreateContextWithXRefTable(nil, types.PaperSize["A4"])
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	rootIndRef, err := ctx.Pages()
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	root, err := ctx.DereferenceDict(*rootIndRef)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	node := types.Dict{
+		"Type":     types.Name("Pages"),
+		"Parent":   *rootIndRef,
+		"MediaBox": types.NewNumberArray(0, 0, 200, 300),
+		"Count":    types.Integer(pageCount),
+	}
+	nodeIndRef, err := ctx.IndRefForNewObject(node)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	kids := types.Array{}
+	for i := 0; i < pageCount; i++ {
+		ir, err := ctx.IndRefForNewObject(types.Dict{"Type": types.Name("Page"), "Parent": *nodeIndRef})
+		if err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		kids = append(kids, *ir)
+	}
+	node["Kids"] = kids
+
+	root["Kids"] = types.Array{*nodeIndRef}
+	root["Count"] = types.Integer(pageCount)
+	ctx.PageCount = pageCount
+
+	if err := api.WriteContextFile(ctx, outFile); err != nil {
+		t.Fatalf("%s write: %v\n", msg, err)
+	}
+}
+
+func TestOptimizePageTree(t *testing.T) {
+	msg := "TestOptimizePageTree"
+	inFile := filepath.Join(outDir, "flatPageTree.pdf")
+	outFile := filepath.Join(outDir, "balancedPageTree.pdf")
+
+	writeFlatPageTreePDF(t, msg, inFile, 100)
+
+	conf := model.NewDefaultConfiguration()
+	passes, err := model.ParseOptimizePasses("default,pagetree")
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	conf.OptimizePasses = passes
+
+	if err := api.OptimizeFile(inFile, outFile, conf); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	ctx, err := api.ReadContextFile(outFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if ctx.PageCount != 100 {
+		t.Fatalf("%s: want 100 pages, got %d\n", msg, ctx.PageCount)
+	}
+
+	rootIndRef, err := ctx.Pages()
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	root, err := ctx.DereferenceDict(*rootIndRef)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// 100 pages need 4 intermediate nodes of at most 32 kids.
+	kids, err := ctx.DereferenceArray(root["Kids"])
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(kids) != 4 {
+		t.Fatalf("%s: want 4 kids of the page tree root, got %d\n", msg, len(kids))
+	}
+
+	// The media box of the replaced node has been pushed down into the pages.
+	d, _, _, err := ctx.PageDict(100, false)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	r, err := ctx.RectForArray(d.ArrayEntry("MediaBox"))
+	if err != nil || r == nil || r.Width() != 200 || r.Height() != 300 {
+		t.Fatalf("%s: missing media box of page 100\n", msg)
+	}
+}
+
+func newTestStream(t *testing.T, ctx *model.Context, d types.Dict, content []byte) *types.IndirectRef {
+	t.Helper()
+
+	sd, err := ctx.NewStreamDictForBuf(content)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	for k, v := range d {
+		sd.Insert(k, v)
+	}
+	if err := sd.Encode(); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	ir, err := ctx.IndRefForNewObject(*sd)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	return ir
+}
+
+func TestOptimizeThumbnailsAndAppStates(t *testing.T) {
+	msg := "TestOptimizeThumbnailsAndAppStates"
+	inFile := filepath.Join(inDir, "test.pdf")
+	thumbFile := filepath.Join(outDir, "thumbsAndStates.pdf")
+	outFile := filepath.Join(outDir, "thumbsAndStatesOptimized.pdf")
+
+	ctx, err := api.ReadContextFile(inFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	pageDict, _, _, err := ctx.PageDict(1, false)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	pageDict["Thumb"] = *newTestStream(t, ctx, types.Dict{
+		"Type":             types.Name("XObject"),
+		"Subtype":          types.Name("Image"),
+		"Width":            types.Integer(1),
+		"Height":           types.Integer(1),
+		"ColorSpace":       types.Name("DeviceGray"),
+		"BitsPerComponent": types.Integer(8),
+	}, []byte{0})
+
+	form := types.Dict{
+		"Type":    types.Name("XObject"),
+		"Subtype": types.Name("Form"),
+		"BBox":    types.NewNumberArray(0, 0, 10, 10),
+	}
+	annot := types.Dict{
+		"Type":    types.Name("Annot"),
+		"Subtype": types.Name("Square"),
+		"Rect":    types.NewNumberArray(100, 100, 110, 110),
+		"AS":      types.Name("On"),
+		"AP": types.Dict{"N": types.Dict{
+			"On":  *newTestStream(t, ctx, form.Clone().(types.Dict), []byte("0 0 10 10 re f")),
+			"Off": *newTestStream(t, ctx, form.Clone().(types.Dict), []byte("0 0 10 10 re S")),
+		}},
+	}
+	ir, err := ctx.IndRefForNewObject(annot)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	annots, err := ctx.DereferenceArray(pageDict["Annots"])
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	pageDict["Annots"] = append(annots, *ir)
+
+	if err := api.WriteContextFile(ctx, thumbFile); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	passes, err := model.ParseOptimizePasses("default,thumbnails,appstates")
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	conf := model.NewDefaultConfiguration()
+	conf.OptimizePasses = passes
+
+	f, err := os.Open(thumbFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	defer f.Close()
+
+	var buf bytes.Buffer
+	r, err := api.OptimizeWithReport(f, &buf, conf)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	n := 0
+	for _, pr := range r.Passes {
+		if pr.Pass != model.OptimizePassThumbnails && pr.Pass != model.OptimizePassAppStates {
+			continue
+		}
+		if pr.Objects != 1 || pr.BytesSaved == 0 {
+			t.Fatalf("%s: %s: unexpected savings: %+v\n", msg, pr.Pass, pr)
+		}
+		n++
+	}
+	if n != 2 {
+		t.Fatalf("%s: missing pass reports: %v\n", msg, r.Passes)
+	}
+
+	if err := os.WriteFile(outFile, buf.Bytes(), 0644); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if ctx, err = api.ReadContextFile(outFile); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if pageDict, _, _, err = ctx.PageDict(1, false); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if _, found := pageDict.Find("Thumb"); found {
+		t.Fatalf("%s: thumbnail not removed\n", msg)
+	}
+}
+
+func TestOptimizeStdFonts(t *testing.T) {
+	msg := "TestOptimizeStdFonts"
+	inFile := filepath.Join(inDir, "test.pdf")
+	arialFile := filepath.Join(outDir, "embeddedArial.pdf")
+
+	ctx, err := api.ReadContextFile(inFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// A fake font program will do since it never gets parsed.
+	fontFile := newTestStream(t, ctx, types.Dict{"Length1": types.Integer(1024)}, bytes.Repeat([]byte{0x00}, 1024))
+
+	fd := types.Dict{
+		"Type":        types.Name("FontDescriptor"),
+		"FontName":    types.Name("ArialMT"),
+		"Flags":       types.Integer(32),
+		"FontBBox":    types.NewNumberArray(-665, -325, 2000, 1040),
+		"ItalicAngle": types.Integer(0),
+		"Ascent":      types.Integer(905),
+		"Descent":     types.Integer(-212),
+		"CapHeight":   types.Integer(716),
+		"StemV":       types.Integer(80),
+		"FontFile2":   *fontFile,
+	}
+	fdIndRef, err := ctx.IndRefForNewObject(fd)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	fontDict := types.Dict{
+		"Type":           types.Name("Font"),
+		"Subtype":        types.Name("TrueType"),
+		"BaseFont":       types.Name("ArialMT"),
+		"Encoding":       types.Name("WinAnsiEncoding"),
+		"FirstChar":      types.Integer(32),
+		"LastChar":       types.Integer(32),
+		"Widths":         types.NewIntegerArray(278),
+		"FontDescriptor": *fdIndRef,
+	}
+	fontIndRef, err := ctx.IndRefForNewObject(fontDict)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	pageDict, _, _, err := ctx.PageDict(1, false)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	resDict, err := ctx.DereferenceDict(pageDict["Resources"])
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if resDict == nil {
+		// Page 1 of test.pdf comes without resources.
+		resDict = types.Dict{}
+		pageDict["Resources"] = resDict
+	}
+	fonts, err := ctx.DereferenceDict(resDict["Font"])
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if fonts == nil {
+		fonts = types.Dict{}
+		resDict["Font"] = fonts
+	}
+	fonts["FArial"] = *fontIndRef
+
+	if err := api.WriteContextFile(ctx, arialFile); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	conf := model.NewDefaultConfiguration()
+	conf.OptimizePasses = []string{model.OptimizePassStdFonts}
+
+	f, err := os.Open(arialFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	defer f.Close()
+
+	var buf bytes.Buffer
+	r, err := api.OptimizeWithReport(f, &buf, conf)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if len(r.Passes) != 1 || r.Passes[0].Objects != 1 || r.Passes[0].BytesSaved == 0 {
+		t.Fatalf("%s: unexpected report: %+v\n", msg, r.Passes)
+	}
+
+	if ctx, err = api.ReadAndValidate(bytes.NewReader(buf.Bytes()), model.NewDefaultConfiguration()); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if pageDict, _, _, err = ctx.PageDict(1, false); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if resDict, err = ctx.DereferenceDict(pageDict["Resources"]); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if fonts, err = ctx.DereferenceDict(resDict["Font"]); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if fontDict, err = ctx.DereferenceDict(fonts["FArial"]); err != nil || fontDict == nil {
+		t.Fatalf("%s: missing font: %v\n", msg, err)
+	}
+	if bf := fontDict.NameEntry("BaseFont"); bf == nil || *bf != "Helvetica" {
+		t.Fatalf("%s: font not unembedded: %s\n", msg, fontDict)
+	}
+	if _, found := fontDict.Find("FontDescriptor"); found {
+		t.Fatalf("%s: unexpected font descriptor\n", msg)
+	}
+}
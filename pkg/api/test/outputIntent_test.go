@@ -0,0 +1,92 @@
+/*
+Copyright 2026 The pdf Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+)
+
+func TestOutputIntents(t *testing.T) {
+	msg := "testOutputIntents"
+
+	inFile := filepath.Join(inDir, "test.pdf")
+	outFile := filepath.Join(outDir, "outputIntents.pdf")
+	iccFile := filepath.Join(resDir, "sRGB.icc")
+
+	ois, err := api.OutputIntentsFile(inFile, nil)
+	if err != nil {
+		t.Fatalf("%s %s: list output intents: %v\n", msg, inFile, err)
+	}
+	if len(ois) > 0 {
+		t.Fatalf("%s %s: list output intents, unexpected: %v\n", msg, inFile, ois)
+	}
+
+	if err := api.AddOutputIntentFile(inFile, outFile, iccFile, pdfcpu.OutputIntentPDFA1, "", nil); err != nil {
+		t.Fatalf("%s %s: add output intent: %v\n", msg, outFile, err)
+	}
+
+	// Replace the PDF/A output intent and add one for PDF/X.
+	if err := api.AddOutputIntentFile(outFile, "", iccFile, pdfcpu.OutputIntentPDFA1, "", nil); err != nil {
+		t.Fatalf("%s %s: add output intent: %v\n", msg, outFile, err)
+	}
+	if err := api.AddOutputIntentFile(outFile, "", iccFile, pdfcpu.OutputIntentPDFX, "sRGB", nil); err != nil {
+		t.Fatalf("%s %s: add output intent: %v\n", msg, outFile, err)
+	}
+
+	if err := api.AddOutputIntentFile(outFile, "", filepath.Join(resDir, "mountain.jpg"), pdfcpu.OutputIntentPDFA1, "", nil); err == nil {
+		t.Fatalf("%s %s: add output intent: expected error for invalid ICC profile\n", msg, outFile)
+	}
+
+	ois, err = api.OutputIntentsFile(outFile, nil)
+	if err != nil {
+		t.Fatalf("%s %s: list output intents: %v\n", msg, outFile, err)
+	}
+	if len(ois) != 2 {
+		t.Fatalf("%s %s: list output intents, want:2, got:%d\n", msg, outFile, len(ois))
+	}
+	oi := ois[0]
+	if oi.Subtype != pdfcpu.OutputIntentPDFA1 || oi.OutputConditionIdentifier != "Custom" || oi.OutputCondition != "sRGB IEC61966-2.1" {
+		t.Fatalf("%s %s: list output intents, unexpected: %v\n", msg, outFile, oi)
+	}
+	if oi.Profile == "" {
+		t.Fatalf("%s %s: list output intents, missing profile\n", msg, outFile)
+	}
+
+	if err := api.RemoveOutputIntentsFile(outFile, "", pdfcpu.OutputIntentPDFA1, nil); err != nil {
+		t.Fatalf("%s %s: remove output intents: %v\n", msg, outFile, err)
+	}
+
+	ois, err = api.OutputIntentsFile(outFile, nil)
+	if err != nil {
+		t.Fatalf("%s %s: list output intents: %v\n", msg, outFile, err)
+	}
+	if len(ois) != 1 || ois[0].Subtype != pdfcpu.OutputIntentPDFX {
+		t.Fatalf("%s %s: list output intents, unexpected: %v\n", msg, outFile, ois)
+	}
+
+	if err := api.RemoveOutputIntentsFile(outFile, "", "", nil); err != nil {
+		t.Fatalf("%s %s: remove output intents: %v\n", msg, outFile, err)
+	}
+
+	if err := api.RemoveOutputIntentsFile(outFile, "", "", nil); err == nil {
+		t.Fatalf("%s %s: remove output intents: expected error for missing output intents\n", msg, outFile)
+	}
+}
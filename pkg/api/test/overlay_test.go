@@ -0,0 +1,59 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+)
+
+func TestOverlayAndUnderlay(t *testing.T) {
+	msg := "TestOverlayAndUnderlay"
+
+	inFile := filepath.Join(inDir, "Walden.pdf")
+	overlayFile := filepath.Join(inDir, "testWithText.pdf")
+
+	for _, tt := range []struct {
+		outFile string
+		pages   []string
+		under   bool
+		mode    string
+	}{
+		{"overlayRepeat.pdf", nil, false, "repeat"},
+		{"overlayCycle.pdf", []string{"2-"}, false, "cycle"},
+		{"underlayOnce.pdf", nil, true, "once"},
+	} {
+		mode, err := pdfcpu.ParseOverlayMode(tt.mode)
+		if err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		outFile := filepath.Join(outDir, tt.outFile)
+		if err := api.OverlayFile(inFile, overlayFile, outFile, tt.pages, tt.under, mode, nil); err != nil {
+			t.Fatalf("%s %s: %v\n", msg, tt.outFile, err)
+		}
+		if err := api.ValidateFile(outFile, nil); err != nil {
+			t.Fatalf("%s %s: %v\n", msg, tt.outFile, err)
+		}
+	}
+
+	if _, err := pdfcpu.ParseOverlayMode("twice"); err == nil {
+		t.Fatalf("%s: expected error for invalid mode\n", msg)
+	}
+}
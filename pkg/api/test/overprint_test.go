@@ -0,0 +1,123 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// writeOverprintPDF writes a copy of test.pdf whose first page fills a rectangle using overprint.
+func writeOverprintPDF(t *testing.T, msg, outFile string) {
+	t.Helper()
+
+	ctx, err := api.ReadContextFile(filepath.Join(inDir, "test.pdf"))
+	if err != nil {
+		t.Fatalf("%s read: %v\n", msg, err)
+	}
+
+	d, _, _, err := ctx.PageDict(1, false)
+	if err != nil {
+		t.Fatalf("%s page: %v\n", msg, err)
+	}
+
+	sd, err := ctx.NewStreamDictForBuf([]byte("q /GS0 gs 0 0 0 1 k 100 100 200 200 re f Q"))
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := sd.Encode(); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	ir, err := ctx.IndRefForNewObject(*sd)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	d.Update("Contents", *ir)
+	d.Update("Resources", types.Dict{
+		"ExtGState": types.Dict{
+			"GS0": types.Dict{
+				"Type": types.Name("ExtGState"),
+				"OP":   types.Boolean(true),
+				"op":   types.Boolean(true),
+				"OPM":  types.Integer(1),
+			},
+		},
+	})
+
+	if err := api.WriteContextFile(ctx, outFile); err != nil {
+		t.Fatalf("%s write: %v\n", msg, err)
+	}
+}
+
+func overprintReport(t *testing.T, msg, inFile string) *pdfcpu.OverprintReport {
+	t.Helper()
+
+	f, err := os.Open(inFile)
+	if err != nil {
+		t.Fatalf("%s open: %v\n", msg, err)
+	}
+	defer f.Close()
+
+	r, err := api.Overprint(f, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	return r
+}
+
+func TestOverprint(t *testing.T) {
+	msg := "TestOverprint"
+
+	inFile := filepath.Join(outDir, "Overprint.pdf")
+	outFile := filepath.Join(outDir, "OverprintSet.pdf")
+	writeOverprintPDF(t, msg, inFile)
+
+	r := overprintReport(t, msg, inFile)
+	if len(r.Pages) != 1 || r.Pages[0] != 1 {
+		t.Fatalf("%s: want overprint on page 1, got %v\n", msg, r.Pages)
+	}
+	if len(r.ExtGStates) != 1 || !r.ExtGStates[0].Stroke || !r.ExtGStates[0].Fill || r.ExtGStates[0].Mode != 1 {
+		t.Fatalf("%s: unexpected graphics states: %+v\n", msg, r.ExtGStates)
+	}
+
+	oc, err := pdfcpu.ParseOverprintConfig("stroke:off, fill:off, trapped:true")
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if err := api.SetOverprintFile(inFile, outFile, nil, oc, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	r = overprintReport(t, msg, outFile)
+	if len(r.Pages) != 0 {
+		t.Fatalf("%s: want no pages using overprint, got %v\n", msg, r.Pages)
+	}
+	if r.Trapped != "True" {
+		t.Fatalf("%s: want Trapped True, got %q\n", msg, r.Trapped)
+	}
+
+	if _, err := pdfcpu.ParseOverprintConfig("mode:2"); err == nil {
+		t.Fatalf("%s: want error for invalid mode\n", msg)
+	}
+}
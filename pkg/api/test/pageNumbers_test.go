@@ -0,0 +1,85 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+func TestAddPageNumbers(t *testing.T) {
+	msg := "TestAddPageNumbers"
+
+	for _, tt := range []struct {
+		inFile, outFile string
+		selectedPages   []string
+		desc            string
+	}{
+		{"Walden.pdf", "pageNumbersDefaults.pdf", nil, ""},
+		{"Walden.pdf", "pageNumbersOfN.pdf", nil, "style:ofn, skip:1"},
+		{"Walden.pdf", "pageNumbersFolio.pdf", nil, "style:folio, numerals:roman, pos:tc, points:12"},
+		{"Walden.pdf", "pageNumbersPageOfN.pdf", []string{"2-"}, "style:pageofn, numerals:ROMAN, start:3, fontname:Times-Italic, fillcolor:#0000ff"},
+		{"CenterOfWhy.pdf", "pageNumbersLabels.pdf", nil, "style:label, pos:br"},
+	} {
+		inFile := filepath.Join(inDir, tt.inFile)
+		outFile := filepath.Join(outDir, tt.outFile)
+
+		pn, err := pdfcpu.ParsePageNumbersDetails(tt.desc, types.POINTS)
+		if err != nil {
+			t.Fatalf("%s %s: %v\n", msg, tt.outFile, err)
+		}
+		if err := api.AddPageNumbersFile(inFile, outFile, tt.selectedPages, pn, nil); err != nil {
+			t.Fatalf("%s %s: %v\n", msg, tt.outFile, err)
+		}
+		if err := api.ValidateFile(outFile, nil); err != nil {
+			t.Fatalf("%s %s: %v\n", msg, tt.outFile, err)
+		}
+	}
+
+	// Page numbers may be removed like any other stamp.
+	outFile := filepath.Join(outDir, "pageNumbersOfN.pdf")
+	if err := api.RemoveWatermarksByIDFile(outFile, "", []string{"2-"}, "pagenumbers", nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	ok, err := api.HasWatermarksFile(outFile, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if ok {
+		t.Fatalf("%s: page numbers not removed\n", msg)
+	}
+}
+
+func TestParsePageNumbersDetails(t *testing.T) {
+	msg := "TestParsePageNumbersDetails"
+
+	for _, desc := range []string{
+		"style:unknown",
+		"numerals:Roman",
+		"start:0",
+		"s:ofn", // ambiguous: style, skip, scalefactor, ...
+		"foo:bar",
+	} {
+		if _, err := pdfcpu.ParsePageNumbersDetails(desc, types.POINTS); err == nil {
+			t.Fatalf("%s: expected error for %q\n", msg, desc)
+		}
+	}
+}
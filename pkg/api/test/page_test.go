@@ -98,3 +98,43 @@ func TestInsertCustomBlankPage(t *testing.T) {
 	}
 
 }
+
+func TestFlattenPageAttrs(t *testing.T) {
+	msg := "TestFlattenPageAttrs"
+	inFile := filepath.Join(outDir, "inheritedPageAttrs.pdf")
+	outFile := filepath.Join(outDir, "flattenedPageAttrs.pdf")
+
+	writeFlatPageTreePDF(t, msg, inFile, 10)
+
+	if err := api.FlattenPageAttrsFile(inFile, outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	ctx, err := api.ReadContextFile(outFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	rootIndRef, err := ctx.Pages()
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	root, err := ctx.DereferenceDict(*rootIndRef)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if _, found := root.Find("MediaBox"); found {
+		t.Fatalf("%s: page tree root still defines a media box\n", msg)
+	}
+
+	for i := 1; i <= ctx.PageCount; i++ {
+		d, _, _, err := ctx.PageDict(i, false)
+		if err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		r, err := ctx.RectForArray(d.ArrayEntry("MediaBox"))
+		if err != nil || r == nil || r.Width() != 200 || r.Height() != 300 {
+			t.Fatalf("%s: page %d: missing media box\n", msg, i)
+		}
+	}
+}
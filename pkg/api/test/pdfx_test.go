@@ -0,0 +1,94 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+)
+
+func preflightFile(t *testing.T, msg, fileName, standard string) []pdfcpu.PreflightIssue {
+	t.Helper()
+
+	f, err := os.Open(fileName)
+	if err != nil {
+		t.Fatalf("%s open: %v\n", msg, err)
+	}
+	defer f.Close()
+
+	issues, err := api.Preflight(f, standard, nil)
+	if err != nil {
+		t.Fatalf("%s preflight: %v\n", msg, err)
+	}
+
+	return issues
+}
+
+func hasIssue(issues []pdfcpu.PreflightIssue, s string) bool {
+	for _, pi := range issues {
+		if strings.Contains(pi.Message, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestPreflightPDFX(t *testing.T) {
+	msg := "TestPreflightPDFX"
+
+	inFile := filepath.Join(inDir, "test.pdf")
+
+	issues := preflightFile(t, msg, inFile, pdfcpu.PDFX1a)
+
+	for _, s := range []string{"output intent", "GTS_PDFXVersion", "Trapped", "TrimBox"} {
+		if !hasIssue(issues, s) {
+			t.Fatalf("%s: missing issue %q in %v\n", msg, s, issues)
+		}
+	}
+
+	conf := model.NewDefaultConfiguration()
+	conf.ValidateStandard = pdfcpu.PDFX1a
+	if err := api.ValidateFile(inFile, conf); !errors.Is(err, api.ErrValidation) {
+		t.Fatalf("%s: want validation error, got %v\n", msg, err)
+	}
+}
+
+func TestConvertPDFX(t *testing.T) {
+	msg := "TestConvertPDFX"
+
+	inFile := filepath.Join(inDir, "test.pdf")
+	outFile := filepath.Join(outDir, "PDFX.pdf")
+
+	if err := api.ConvertPDFXFile(inFile, outFile, pdfcpu.PDFX1a, "", nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	issues := preflightFile(t, msg, outFile, pdfcpu.PDFX1a)
+
+	for _, s := range []string{"output intent", "GTS_PDFXVersion", "Trapped", "TrimBox"} {
+		if hasIssue(issues, s) {
+			t.Fatalf("%s: unexpected issue %q in %v\n", msg, s, issues)
+		}
+	}
+}
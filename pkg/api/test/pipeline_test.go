@@ -0,0 +1,166 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+func TestPipeline(t *testing.T) {
+	msg := "TestPipeline"
+	inFile := filepath.Join(inDir, "5116.DCT_Filter.pdf")
+	outFile := filepath.Join(outDir, "pipeline.pdf")
+
+	p, err := api.NewPipelineFile(inFile, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	wm, err := api.TextWatermark("Draft", "scale:.5, rot:0", true, false, types.POINTS)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if err := p.AddWatermarks([]string{"1-3"}, wm); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if err := p.Rotate(90, []string{"even"}); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	bms := []pdfcpu.Bookmark{{PageFrom: 1, Title: "Start"}, {PageFrom: 2, Title: "Rest"}}
+	if err := p.AddBookmarks(bms, true); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if err := p.Optimize(); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if err := p.WriteFile(outFile); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	ctx, err := api.ReadContextFile(outFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if bms, err := pdfcpu.Bookmarks(ctx); err != nil || len(bms) != 2 {
+		t.Fatalf("%s: want 2 bookmarks, got %d: %v\n", msg, len(bms), err)
+	}
+
+	d, _, _, err := ctx.PageDict(2, false)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if r := d.IntEntry("Rotate"); r == nil || *r%360 == 0 {
+		t.Fatalf("%s: page 2 not rotated\n", msg)
+	}
+}
+
+func TestPipelineFailedOperation(t *testing.T) {
+	msg := "TestPipelineFailedOperation"
+	inFile := filepath.Join(inDir, "5116.DCT_Filter.pdf")
+
+	p, err := api.NewPipelineFile(inFile, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if err := p.AddBookmarks(nil, false); err == nil {
+		t.Fatalf("%s: expected error for missing bookmarks\n", msg)
+	}
+
+	// A failed operation prevents writing a partially modified result.
+	var buf bytes.Buffer
+	if err := p.Write(&buf); err == nil || err != p.Err() {
+		t.Fatalf("%s: expected error of failed operation, got: %v\n", msg, err)
+	}
+}
+
+func TestPipelineWriteFileInPlace(t *testing.T) {
+	msg := "TestPipelineWriteFileInPlace"
+	inFile := filepath.Join(outDir, "pipelineInPlace.pdf")
+	bakFile := inFile + ".bak"
+
+	if err := copyFile(t, filepath.Join(inDir, "5116.DCT_Filter.pdf"), inFile); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	os.Remove(bakFile)
+
+	bb, err := os.ReadFile(inFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	conf := model.NewDefaultConfiguration()
+	conf.Backup = true
+	conf.MemoryOnly = true
+
+	p, err := api.NewPipelineFile(inFile, conf)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if err := p.Rotate(90, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// Replace inFile in memory without any intermediate file.
+	if err := p.WriteFile(""); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if _, err := os.Stat(inFile + ".tmp"); !os.IsNotExist(err) {
+		t.Fatalf("%s: unexpected temporary file\n", msg)
+	}
+
+	bak, err := os.ReadFile(bakFile)
+	if err != nil {
+		t.Fatalf("%s: missing backup: %v\n", msg, err)
+	}
+	if !bytes.Equal(bak, bb) {
+		t.Fatalf("%s: backup differs from original\n", msg)
+	}
+
+	ctx, err := api.ReadContextFile(inFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	d, _, _, err := ctx.PageDict(1, false)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if r := d.IntEntry("Rotate"); r == nil || *r%360 == 0 {
+		t.Fatalf("%s: page 1 not rotated\n", msg)
+	}
+}
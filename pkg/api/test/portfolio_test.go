@@ -17,10 +17,16 @@ limitations under the License.
 package test
 
 import (
+	"bytes"
+	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
 )
 
 func TestPortfolio(t *testing.T) {
@@ -76,3 +82,145 @@ func TestPortfolio(t *testing.T) {
 		t.Fatalf("%s: validate: %v\n", msg, err)
 	}
 }
+
+func TestPortfolioFolders(t *testing.T) {
+	msg := "testPortfolioFolders"
+
+	if err := prepareForAttachmentTest(t); err != nil {
+		t.Fatalf("%s prepare for portfolio: %v\n", msg, err)
+	}
+
+	ctx, err := api.ReadContextFile(filepath.Join(outDir, "go.pdf"))
+	if err != nil {
+		t.Fatalf("%s read context: %v\n", msg, err)
+	}
+
+	modTime := time.Date(2020, time.March, 1, 12, 0, 0, 0, time.UTC)
+	a := model.Attachment{Reader: strings.NewReader("Hello Folder"), ID: "<2>readme.txt", FileName: "readme.txt", ModTime: &modTime}
+	if err := ctx.AddAttachment(a, true); err != nil {
+		t.Fatalf("%s add attachment: %v\n", msg, err)
+	}
+
+	// Root folder (id 1) with one child folder "docs" (id 2).
+	child := types.Dict(map[string]types.Object{
+		"Type": types.Name("Folder"),
+		"ID":   types.Integer(2),
+		"Name": types.StringLiteral("docs"),
+	})
+	ir, err := ctx.IndRefForNewObject(child)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	root := types.Dict(map[string]types.Object{
+		"Type":  types.Name("Folder"),
+		"ID":    types.Integer(1),
+		"Name":  types.StringLiteral("root"),
+		"Child": *ir,
+	})
+	rootDict, err := ctx.Catalog()
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	collDict, err := ctx.DereferenceDict(rootDict["Collection"])
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	collDict["Folders"] = root
+
+	fileName := filepath.Join(outDir, "portfolioFolders.pdf")
+	if err := api.WriteContextFile(ctx, fileName); err != nil {
+		t.Fatalf("%s write context: %v\n", msg, err)
+	}
+
+	dir := filepath.Join(outDir, "portfolioFolders")
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.ExtractAttachmentsFile(fileName, dir, nil, nil); err != nil {
+		t.Fatalf("%s extract portfolio entries: %v\n", msg, err)
+	}
+
+	fi, err := os.Stat(filepath.Join(dir, "docs", "readme.txt"))
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if !fi.ModTime().Equal(modTime) {
+		t.Fatalf("%s: modTime want %v got %v\n", msg, modTime, fi.ModTime())
+	}
+
+	// Extract a single entry to a writer.
+	f, err := os.Open(fileName)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	defer f.Close()
+
+	var buf bytes.Buffer
+	if err := api.ExtractAttachment(f, &buf, "readme.txt", nil); err != nil {
+		t.Fatalf("%s extract single entry: %v\n", msg, err)
+	}
+	if buf.String() != "Hello Folder" {
+		t.Fatalf("%s: unexpected content: %s\n", msg, buf.String())
+	}
+}
+
+func TestAttachmentFileNames(t *testing.T) {
+	msg := "testAttachmentFileNames"
+
+	if err := prepareForAttachmentTest(t); err != nil {
+		t.Fatalf("%s prepare for portfolio: %v\n", msg, err)
+	}
+
+	ctx, err := api.ReadContextFile(filepath.Join(outDir, "go.pdf"))
+	if err != nil {
+		t.Fatalf("%s read context: %v\n", msg, err)
+	}
+
+	// UF/F default to the attachment id.
+	a := model.Attachment{Reader: strings.NewReader("id"), ID: "id.txt"}
+	if err := ctx.AddAttachment(a, true); err != nil {
+		t.Fatalf("%s add attachment: %v\n", msg, err)
+	}
+
+	// UF/F are taken from FileName if present.
+	a = model.Attachment{Reader: strings.NewReader("name"), ID: "key", FileName: "name.txt"}
+	if err := ctx.AddAttachment(a, true); err != nil {
+		t.Fatalf("%s add attachment: %v\n", msg, err)
+	}
+
+	// Path components of file names must not escape outDir.
+	a = model.Attachment{Reader: strings.NewReader("escape"), ID: "escape", FileName: "../../escape.txt"}
+	if err := ctx.AddAttachment(a, true); err != nil {
+		t.Fatalf("%s add attachment: %v\n", msg, err)
+	}
+
+	aa, err := ctx.ListAttachments()
+	if err != nil {
+		t.Fatalf("%s list attachments: %v\n", msg, err)
+	}
+	want := map[string]string{"id.txt": "id.txt", "key": "name.txt", "escape": "../../escape.txt"}
+	for _, a := range aa {
+		if want[a.ID] != a.FileName {
+			t.Fatalf("%s: %s: want fileName %s got %s\n", msg, a.ID, want[a.ID], a.FileName)
+		}
+	}
+
+	fileName := filepath.Join(outDir, "attachmentFileNames.pdf")
+	if err := api.WriteContextFile(ctx, fileName); err != nil {
+		t.Fatalf("%s write context: %v\n", msg, err)
+	}
+
+	dir := filepath.Join(outDir, "attachmentFileNames", "sub")
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.ExtractAttachmentsFile(fileName, dir, nil, nil); err != nil {
+		t.Fatalf("%s extract attachments: %v\n", msg, err)
+	}
+
+	for _, fn := range []string{"id.txt", "name.txt", "escape.txt"} {
+		if _, err := os.Stat(filepath.Join(dir, fn)); err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+	}
+}
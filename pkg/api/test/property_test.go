@@ -17,6 +17,7 @@ limitations under the License.
 package test
 
 import (
+	"bytes"
 	"os"
 	"path/filepath"
 	"testing"
@@ -103,3 +104,119 @@ func TestProperties(t *testing.T) {
 	// # of properties must be 0
 	listProperties(t, msg, fileName, nil)
 }
+
+func TestPropertiesJSON(t *testing.T) {
+	msg := "TestPropertiesJSON"
+
+	fileName := filepath.Join(outDir, "go.pdf")
+	if err := copyFile(t, filepath.Join(inDir, "go.pdf"), fileName); err != nil {
+		t.Fatalf("%s: copyFile: %v\n", msg, err)
+	}
+
+	inFileJSON := filepath.Join(inDir, "json", "properties", "properties.json")
+	if err := api.AddPropertiesJSONFile(fileName, inFileJSON, "", nil); err != nil {
+		t.Fatalf("%s add properties: %v\n", msg, err)
+	}
+
+	want := []string{"Project = Apollo", "Region = 東京", "Reviewer = Zoë Ångström"}
+	listProperties(t, msg, fileName, want)
+
+	// Export and import into a fresh copy.
+	outFileJSON := filepath.Join(outDir, "properties.json")
+	if err := api.ExportPropertiesFile(fileName, outFileJSON, nil); err != nil {
+		t.Fatalf("%s export properties: %v\n", msg, err)
+	}
+
+	fileName2 := filepath.Join(outDir, "goProperties.pdf")
+	if err := copyFile(t, filepath.Join(inDir, "go.pdf"), fileName2); err != nil {
+		t.Fatalf("%s: copyFile: %v\n", msg, err)
+	}
+
+	if err := api.AddPropertiesJSONFile(fileName2, outFileJSON, "", nil); err != nil {
+		t.Fatalf("%s import exported properties: %v\n", msg, err)
+	}
+
+	listProperties(t, msg, fileName2, want)
+}
+
+func TestPropertiesCopyThrough(t *testing.T) {
+	msg := "TestPropertiesCopyThrough"
+
+	inFile := filepath.Join(inDir, "go.pdf")
+	outFile := filepath.Join(outDir, "goCopyThrough.pdf")
+
+	conf := model.NewDefaultConfiguration()
+	conf.CopyThrough = true
+
+	properties := map[string]string{"name1": "value1"}
+	if err := api.AddPropertiesFile(inFile, outFile, properties, conf); err != nil {
+		t.Fatalf("%s add properties: %v\n", msg, err)
+	}
+
+	bbIn, err := os.ReadFile(inFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	bbOut, err := os.ReadFile(outFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// The input file is copied through and followed by an incremental update.
+	if len(bbOut) <= len(bbIn) || !bytes.HasPrefix(bbOut, bbIn) {
+		t.Fatalf("%s: %s is no incremental update of %s\n", msg, outFile, inFile)
+	}
+
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	listProperties(t, msg, outFile, []string{"name1 = value1"})
+}
+
+func TestPropertiesCompressIncrement(t *testing.T) {
+	msg := "TestPropertiesCompressIncrement"
+
+	// pdfcpu writes cross reference streams by default.
+	inFile := filepath.Join(outDir, "goXRefStream.pdf")
+	if err := api.OptimizeFile(filepath.Join(inDir, "go.pdf"), inFile, nil); err != nil {
+		t.Fatalf("%s optimize: %v\n", msg, err)
+	}
+
+	outFile := filepath.Join(outDir, "goCompressIncrement.pdf")
+
+	conf := model.NewDefaultConfiguration()
+	conf.CopyThrough = true
+	conf.CompressIncrement = true
+
+	properties := map[string]string{"name1": "value1"}
+	if err := api.AddPropertiesFile(inFile, outFile, properties, conf); err != nil {
+		t.Fatalf("%s add properties: %v\n", msg, err)
+	}
+
+	bbIn, err := os.ReadFile(inFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	bbOut, err := os.ReadFile(outFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if len(bbOut) <= len(bbIn) || !bytes.HasPrefix(bbOut, bbIn) {
+		t.Fatalf("%s: %s is no incremental update of %s\n", msg, outFile, inFile)
+	}
+
+	// The modified info dict is part of an object stream.
+	if !bytes.Contains(bbOut[len(bbIn):], []byte("/ObjStm")) {
+		t.Fatalf("%s: increment without object stream\n", msg)
+	}
+
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	listProperties(t, msg, outFile, []string{"name1 = value1"})
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"archive/zip"
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+)
+
+func TestReflowUntagged(t *testing.T) {
+	msg := "TestReflowUntagged"
+
+	inFile := filepath.Join(inDir, "Acroforms2.pdf")
+
+	f, err := os.Open(inFile)
+	if err != nil {
+		t.Fatalf("%s open: %v\n", msg, err)
+	}
+	defer f.Close()
+
+	var buf bytes.Buffer
+	if err := api.Reflow(f, &buf, "Acroforms2.pdf", pdfcpu.ReflowHTML, conf); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	s := buf.String()
+	if !strings.HasPrefix(s, "<!DOCTYPE html>") || !strings.Contains(s, `<section id="page1">`) || !strings.Contains(s, "<p>") {
+		t.Fatalf("%s: unexpected html:\n%s\n", msg, s)
+	}
+}
+
+func TestReflowTagged(t *testing.T) {
+	msg := "TestReflowTagged"
+
+	inFile := filepath.Join(inDir, "go.pdf")
+
+	f, err := os.Open(inFile)
+	if err != nil {
+		t.Fatalf("%s open: %v\n", msg, err)
+	}
+	defer f.Close()
+
+	root, err := api.StructTree(f, conf)
+	if err != nil {
+		t.Fatalf("%s struct tree: %v\n", msg, err)
+	}
+	if root == nil || len(root.Kids) == 0 {
+		t.Fatalf("%s: missing structure tree\n", msg)
+	}
+
+	outFile := filepath.Join(outDir, "go.epub")
+	if err := api.ReflowFile(inFile, outFile, "", conf); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	zr, err := zip.OpenReader(outFile)
+	if err != nil {
+		t.Fatalf("%s open epub: %v\n", msg, err)
+	}
+	defer zr.Close()
+
+	want := []string{"mimetype", "META-INF/container.xml", "OEBPS/content.opf", "OEBPS/nav.xhtml", "OEBPS/content.xhtml"}
+	if len(zr.File) != len(want) {
+		t.Fatalf("%s: want %d entries, got %d\n", msg, len(want), len(zr.File))
+	}
+	for i, zf := range zr.File {
+		if zf.Name != want[i] {
+			t.Fatalf("%s: entry %d: want %s, got %s\n", msg, i, want[i], zf.Name)
+		}
+	}
+	if zr.File[0].Method != zip.Store {
+		t.Fatalf("%s: mimetype must be stored\n", msg)
+	}
+}
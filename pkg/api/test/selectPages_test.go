@@ -18,11 +18,13 @@ package test
 
 import (
 	"fmt"
+	"path/filepath"
 	"testing"
 
 	"strings"
 
 	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
 )
 
@@ -46,13 +48,14 @@ func testPageSelectionSyntaxFail(t *testing.T, s string) {
 // This is used to select specific pages for extraction and trimming.
 func TestPageSelectionSyntax(t *testing.T) {
 	psOk := []string{"1", "!1", "n1", "1-", "!1-", "n1-", "-5", "!-5", "n-5", "3-5", "!3-5", "n3-5",
-		"1,2,3", "!-5,10-15,30-", "1-,n4", "odd", "even", " 1"}
+		"1,2,3", "!-5,10-15,30-", "1-,n4", "odd", "even", " 1",
+		"!even", "n1-l:3", "1-100:2", "l-5-l-2", "l-5-l", "bm:Chapter 2", "nbm:Appendix", "matching:Invoice No", "!matching:\\d{4}"}
 
 	for _, s := range psOk {
 		testPageSelectionSyntaxOk(t, s)
 	}
 
-	psFail := []string{"1,", "1 ", "-", " -", " !"}
+	psFail := []string{"1,", "1 ", "-", " -", " !", "1-5:", "1-5:x", "even:2"}
 
 	for _, s := range psFail {
 		testPageSelectionSyntaxFail(t, s)
@@ -145,6 +148,16 @@ func TestSelectedPages(t *testing.T) {
 
 	testSelectedPages("1-l,!2-l-1", pageCount, "10001", t)
 	testSelectedPages("1-l,!2-l-1", pageCount, "10001", t)
+
+	testSelectedPages("1-5:2", pageCount, "10101", t)
+	testSelectedPages("-l:2", pageCount, "10101", t)
+	testSelectedPages("2-:2", pageCount, "01010", t)
+	testSelectedPages("1-l,n1-l:2", pageCount, "01010", t)
+	testSelectedPages("1-l,!even", pageCount, "10101", t)
+	testSelectedPages("1-l,nodd", pageCount, "01010", t)
+	testSelectedPages("l-3-l-1", pageCount, "01110", t)
+	testSelectedPages("l-3-l", pageCount, "01111", t)
+	testSelectedPages("l-9-l-3", pageCount, "11000", t)
 }
 
 func collectedPagesString(cp []int) string {
@@ -213,6 +226,10 @@ func TestCollectedPages(t *testing.T) {
 	testCollectedPages("!l,odd", pageCount, "[1 3 5]", t)
 	testCollectedPages("l,even", pageCount, "[5 2 4]", t)
 
+	testCollectedPages("1-5:2,l", pageCount, "[1 3 5 5]", t)
+	testCollectedPages("l-3-l-1,1", pageCount, "[2 3 4 1]", t)
+	testCollectedPages("-l,!even", pageCount, "[1 3 5]", t)
+
 	testCollectedPages("1-3,2,1,l", pageCount, "[1 2 3 2 1 5]", t)
 	testCollectedPages("1,1,1,l,l,l", pageCount, "[1 1 1 5 5 5]", t)
 	testCollectedPages("1-3,2-4,3-", pageCount, "[1 2 3 2 3 4 3 4 5]", t)
@@ -221,3 +238,74 @@ func TestCollectedPages(t *testing.T) {
 	testCollectedPages("1-,!l", pageCount, "[1 2 3 4]", t)
 	testCollectedPages("1-,nl", pageCount, "[1 2 3 4]", t)
 }
+
+func TestSelectedPagesForBookmark(t *testing.T) {
+	msg := "TestSelectedPagesForBookmark"
+	inFile := filepath.Join(inDir, "5116.DCT_Filter.pdf")
+
+	ctx, err := api.ReadContextFile(inFile)
+	if err != nil {
+		t.Fatalf("%s readContext: %v\n", msg, err)
+	}
+
+	bms, err := pdfcpu.Bookmarks(ctx)
+	if err != nil || len(bms) < 2 {
+		t.Fatalf("%s: missing bookmarks: %v\n", msg, err)
+	}
+
+	// Select the pages of the section introduced by the second bookmark.
+	bm := bms[1]
+	selectedPages, err := api.PagesForPageSelectionWithContext(ctx, []string{"bm:" + bm.Title}, false, false)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	from, thru := bm.PageFrom, bm.PageThru
+	if thru == 0 {
+		thru = ctx.PageCount
+	}
+
+	if len(selectedPages) != thru-from+1 || !selectedPages[from] || !selectedPages[thru] {
+		t.Fatalf("%s: want pages %d-%d, got %v\n", msg, from, thru, selectedPages)
+	}
+
+	if _, err := api.PagesForPageSelectionWithContext(ctx, []string{"bm:missing bookmark"}, false, false); err == nil {
+		t.Fatalf("%s: expected error for unknown bookmark\n", msg)
+	}
+}
+
+func TestSelectedPagesMatching(t *testing.T) {
+	msg := "TestSelectedPagesMatching"
+	inFile := filepath.Join(inDir, "5116.DCT_Filter.pdf")
+	outFile := filepath.Join(outDir, "selectedPagesMatching.pdf")
+
+	// Mark pages 2 and 4 with some distinct text.
+	if err := api.AddTextWatermarksFile(inFile, outFile, []string{"2", "4"}, true, "pdfcpu marker 4711", "fo:Helvetica, points:12, scale:1 abs", nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	ctx, err := api.ReadContextFile(outFile)
+	if err != nil {
+		t.Fatalf("%s readContext: %v\n", msg, err)
+	}
+
+	selectedPages, err := api.PagesForPageSelectionWithContext(ctx, []string{`matching:"marker \d+"`}, false, false)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(selectedPages) != 2 || !selectedPages[2] || !selectedPages[4] {
+		t.Fatalf("%s: want pages 2,4, got %v\n", msg, selectedPages)
+	}
+
+	selectedPages, err = api.PagesForPageSelectionWithContext(ctx, []string{"1-", "!matching:pdfcpu marker"}, false, false)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(selectedPages) != ctx.PageCount-2 || selectedPages[2] || selectedPages[4] {
+		t.Fatalf("%s: want all pages but 2,4, got %v\n", msg, selectedPages)
+	}
+
+	if _, err := api.PagesForPageSelectionWithContext(ctx, []string{"matching:(unbalanced"}, false, false); err == nil {
+		t.Fatalf("%s: expected error for invalid regular expression\n", msg)
+	}
+}
@@ -0,0 +1,279 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/form"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+func TestDeferredSigning(t *testing.T) {
+	msg := "TestDeferredSigning"
+
+	inFile := filepath.Join(inDir, "test.pdf")
+	outFile := filepath.Join(outDir, "sigField.pdf")
+
+	rect := types.NewRectangle(350, 50, 550, 110)
+	if err := api.AddSignatureFieldFile(inFile, outFile, 1, rect, "sig1", "Sign here", nil); err != nil {
+		t.Fatalf("%s add: %v\n", msg, err)
+	}
+
+	fds, err := api.FormFieldDetailsFile(outFile, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(fds) != 1 || fds[0].Type != form.DetailSignature || fds[0].Widgets[0].Page != 1 {
+		t.Fatalf("%s: unexpected fields: %+v\n", msg, fds)
+	}
+
+	if err := api.AddSignatureFieldFile(outFile, outFile, 1, rect, "sig1", "", nil); err == nil {
+		t.Fatalf("%s: missing error for duplicate field\n", msg)
+	}
+
+	size := 1024
+	var buf bytes.Buffer
+	f, err := os.Open(outFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	defer f.Close()
+	if err := api.PrepareSignature(f, &buf, "sig1", size, nil); err != nil {
+		t.Fatalf("%s prepare: %v\n", msg, err)
+	}
+	prepared := buf.Bytes()
+
+	content, err := api.SignedContent(bytes.NewReader(prepared))
+	if err != nil {
+		t.Fatalf("%s content: %v\n", msg, err)
+	}
+	if want := len(prepared) - 2*size - 2; len(content) != want {
+		t.Fatalf("%s: signed content: want %d bytes, got %d\n", msg, want, len(content))
+	}
+
+	// Any CMS signature will do as long as it fits.
+	cms := bytes.Repeat([]byte{0x30}, 100)
+
+	buf.Reset()
+	if err := api.EmbedSignature(bytes.NewReader(prepared), &buf, cms, nil); err != nil {
+		t.Fatalf("%s embed: %v\n", msg, err)
+	}
+	signed := buf.Bytes()
+
+	if len(signed) != len(prepared) {
+		t.Fatalf("%s: embedding changed file size\n", msg)
+	}
+
+	content1, err := api.SignedContent(bytes.NewReader(signed))
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if !bytes.Equal(content, content1) {
+		t.Fatalf("%s: embedding changed signed content\n", msg)
+	}
+
+	if err := api.EmbedSignature(bytes.NewReader(signed), &buf, cms, nil); err == nil {
+		t.Fatalf("%s: missing error for repeated embedding\n", msg)
+	}
+
+	if err := api.EmbedSignature(bytes.NewReader(prepared), &buf, make([]byte, size+1), nil); err == nil {
+		t.Fatalf("%s: missing error for oversized signature\n", msg)
+	}
+}
+
+func selfSignedCert(t *testing.T) []byte {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "pdfcpu test"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	return der
+}
+
+func TestAddValidationMaterial(t *testing.T) {
+	msg := "TestAddValidationMaterial"
+
+	inFile := filepath.Join(inDir, "test.pdf")
+	sigFieldFile := filepath.Join(outDir, "sigFieldLTV.pdf")
+	outFile := filepath.Join(outDir, "signedLTV.pdf")
+
+	rect := types.NewRectangle(350, 50, 550, 110)
+	if err := api.AddSignatureFieldFile(inFile, sigFieldFile, 1, rect, "sig1", "", nil); err != nil {
+		t.Fatalf("%s add: %v\n", msg, err)
+	}
+
+	if err := api.PrepareSignatureFile(sigFieldFile, outFile, "sig1", 1024, nil); err != nil {
+		t.Fatalf("%s prepare: %v\n", msg, err)
+	}
+
+	cmsFile := filepath.Join(outDir, "sig.p7s")
+	if err := os.WriteFile(cmsFile, bytes.Repeat([]byte{0x30}, 100), 0644); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if err := api.EmbedSignatureFile(outFile, cmsFile, "", nil); err != nil {
+		t.Fatalf("%s embed: %v\n", msg, err)
+	}
+
+	signed, err := os.ReadFile(outFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	certFile := filepath.Join(outDir, "test.cer")
+	if err := os.WriteFile(certFile, selfSignedCert(t), 0644); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	vm, err := api.ParseValidationMaterialFiles([]string{certFile})
+	if err != nil || len(vm.Certs) != 1 {
+		t.Fatalf("%s parse: %v\n", msg, err)
+	}
+
+	if err := api.AddValidationMaterialFile(outFile, "", *vm, nil); err != nil {
+		t.Fatalf("%s ltv: %v\n", msg, err)
+	}
+
+	bb, err := os.ReadFile(outFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// The signed revision must be preserved.
+	if !bytes.HasPrefix(bb, signed) || len(bb) == len(signed) {
+		t.Fatalf("%s: missing increment\n", msg)
+	}
+
+	ctx, err := api.ReadContextFile(outFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	d, err := ctx.DereferenceDict(ctx.RootDict["DSS"])
+	if err != nil || d == nil {
+		t.Fatalf("%s: missing DSS: %v\n", msg, err)
+	}
+	if len(d.ArrayEntry("Certs")) != 1 || len(d.DictEntry("VRI")) != 1 {
+		t.Fatalf("%s: corrupt DSS: %s\n", msg, d)
+	}
+
+	// Adding the same material again must not duplicate it.
+	if err := api.AddValidationMaterialFile(outFile, "", *vm, model.NewDefaultConfiguration()); err != nil {
+		t.Fatalf("%s ltv: %v\n", msg, err)
+	}
+
+	if ctx, err = api.ReadContextFile(outFile); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if d, err = ctx.DereferenceDict(ctx.RootDict["DSS"]); err != nil || len(d.ArrayEntry("Certs")) != 1 {
+		t.Fatalf("%s: duplicate DSS entries: %v\n", msg, err)
+	}
+}
+
+func TestUsageRights(t *testing.T) {
+	msg := "TestUsageRights"
+
+	inFile := filepath.Join(inDir, "test.pdf")
+	urFile := filepath.Join(outDir, "usageRights.pdf")
+	outFile := filepath.Join(outDir, "usageRightsRemoved.pdf")
+
+	ur, err := api.UsageRightsFile(inFile, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if ur != nil {
+		t.Fatalf("%s: unexpected usage rights\n", msg)
+	}
+
+	// Simulate a usage rights signature as produced by Adobe.
+	ctx, err := api.ReadContextFile(inFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	params := types.Dict{
+		"Type":     types.Name("TransformParams"),
+		"V":        types.Name("2.2"),
+		"Document": types.Array{types.Name("FullSave")},
+		"Form":     types.Array{types.Name("FillIn"), types.Name("Import")},
+		"Msg":      types.StringLiteral("Extended features enabled"),
+	}
+	sigDict := types.Dict{
+		"Type":      types.Name("Sig"),
+		"Filter":    types.Name("Adobe.PPKLite"),
+		"Name":      types.StringLiteral("ARE Acrobat Product v8.0 P23 0002337"),
+		"Reference": types.Array{types.Dict{"Type": types.Name("SigRef"), "TransformMethod": types.Name("UR3"), "TransformParams": params}},
+	}
+	ir, err := ctx.IndRefForNewObject(sigDict)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	ctx.RootDict["Perms"] = types.Dict{"UR3": *ir}
+
+	if err := api.WriteContextFile(ctx, urFile); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if ur, err = api.UsageRightsFile(urFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if ur == nil || ur.Legacy || len(ur.Document) != 1 || len(ur.Form) != 2 || ur.Msg != "Extended features enabled" {
+		t.Fatalf("%s: unexpected usage rights: %+v\n", msg, ur)
+	}
+
+	if err := api.RemoveUsageRightsFile(urFile, outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if ur, err = api.UsageRightsFile(outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if ur != nil {
+		t.Fatalf("%s: usage rights not removed\n", msg)
+	}
+
+	if err := api.RemoveUsageRightsFile(outFile, "", nil); err != model.ErrNoUsageRights {
+		t.Fatalf("%s: want %v, got %v\n", msg, model.ErrNoUsageRights, err)
+	}
+}
@@ -17,11 +17,17 @@ limitations under the License.
 package test
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
 	"path/filepath"
 	"testing"
 
 	"github.com/pdfcpu/pdfcpu/pkg/api"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
 )
 
 func TestSplitSpan1(t *testing.T) {
@@ -60,6 +66,60 @@ func TestSplitByBookmark(t *testing.T) {
 	}
 }
 
+func TestSplitByBookmarkLevel(t *testing.T) {
+	msg := "TestSplitByBookmarkLevel"
+	fileName := "5116.DCT_Filter.pdf"
+	inFile := filepath.Join(inDir, fileName)
+
+	dir := filepath.Join(outDir, "splitByBookmark")
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// Split along bookmarks of level 1 and 2.
+	if err := api.SplitByBookmarkFile(inFile, dir, 2, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	bb, err := os.ReadFile(filepath.Join(dir, "5116.DCT_Filter_manifest.json"))
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	var m pdfcpu.SplitManifest
+	if err := json.Unmarshal(bb, &m); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if m.Source != fileName || m.Level != 2 || len(m.Sections) == 0 {
+		t.Fatalf("%s: invalid manifest: %s\n", msg, bb)
+	}
+
+	for _, s := range m.Sections {
+		if s.From > s.Thru {
+			t.Fatalf("%s: invalid page range for %s: %d-%d\n", msg, s.File, s.From, s.Thru)
+		}
+		if _, err := os.Stat(filepath.Join(dir, s.File)); err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+	}
+}
+
+func TestSanitizedFileName(t *testing.T) {
+	msg := "TestSanitizedFileName"
+
+	for s, want := range map[string]string{
+		"Chapter 1: Introduction": "Chapter_1_Introduction",
+		" ../etc/passwd ":         "etc_passwd",
+		"Über Größe":              "Über_Größe",
+		"?!":                      "",
+	} {
+		if got := pdfcpu.SanitizedFileName(s); got != want {
+			t.Fatalf("%s: %q: want %q, got %q\n", msg, s, want, got)
+		}
+	}
+}
+
 func TestSplitByPageNr(t *testing.T) {
 	msg := "TestSplitByPageNr"
 	fileName := "5116.DCT_Filter.pdf"
@@ -102,3 +162,118 @@ func TestSplitLowLevel(t *testing.T) {
 		t.Fatalf("%s write: %v\n", msg, err)
 	}
 }
+
+func TestSplitMetadata(t *testing.T) {
+	msg := "TestSplitMetadata"
+	inFile := filepath.Join(inDir, "Acroforms2.pdf")
+
+	f, err := os.Open(inFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	defer f.Close()
+
+	// Split into dual page files titled "Acroforms part 1", "Acroforms part 2" etc.
+	conf := model.NewDefaultConfiguration()
+	conf.SplitTitle = "Acroforms part %n%"
+	conf.SplitAuthor = "pdfcpu"
+	conf.SplitCopyMetadata = true
+
+	pss, err := api.SplitRaw(f, 2, conf)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	for i, ps := range pss {
+		bb, err := io.ReadAll(ps.Reader)
+		if err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		info, err := api.PDFInfo(bytes.NewReader(bb), "", nil, false, nil)
+		if err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		if want := fmt.Sprintf("Acroforms part %d", i+1); info.Title != want {
+			t.Fatalf("%s: title want: %q, got: %q\n", msg, want, info.Title)
+		}
+		if info.Author != "pdfcpu" {
+			t.Fatalf("%s: author want: %q, got: %q\n", msg, "pdfcpu", info.Author)
+		}
+	}
+}
+
+func pageImageBytes(t *testing.T, fileName string, pageNr int) [][]byte {
+	t.Helper()
+
+	f, err := os.Open(fileName)
+	if err != nil {
+		t.Fatalf("%s: %v\n", fileName, err)
+	}
+	defer f.Close()
+
+	mm, err := api.ExtractImagesRaw(f, []string{fmt.Sprintf("%d", pageNr)}, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", fileName, err)
+	}
+
+	bb := [][]byte{}
+	for _, m := range mm {
+		for _, img := range m {
+			b, err := io.ReadAll(img)
+			if err != nil {
+				t.Fatalf("%s: %v\n", fileName, err)
+			}
+			bb = append(bb, b)
+		}
+	}
+
+	return bb
+}
+
+func TestSplitCopyThroughImages(t *testing.T) {
+	msg := "TestSplitCopyThroughImages"
+	fileName := "RA_CI.pdf"
+	inFile := filepath.Join(inDir, fileName)
+
+	dir := filepath.Join(outDir, "splitCopyThrough")
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// Image streams get copied through from inFile.
+	if err := api.SplitFile(inFile, dir, 1, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	pageCount, err := api.PageCountFile(inFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	size := func(bb [][]byte) int {
+		n := 0
+		for _, b := range bb {
+			n += len(b)
+		}
+		return n
+	}
+
+	var images int
+
+	for i := 1; i <= pageCount; i++ {
+		outFile := filepath.Join(dir, fmt.Sprintf("RA_CI_%d.pdf", i))
+		if err := api.ValidateFile(outFile, nil); err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+
+		want, got := pageImageBytes(t, inFile, i), pageImageBytes(t, outFile, 1)
+		if len(got) != len(want) || size(got) != size(want) {
+			t.Fatalf("%s: page %d: want %d images (%d bytes), got %d images (%d bytes)\n", msg, i, len(want), size(want), len(got), size(got))
+		}
+		images += len(got)
+	}
+
+	if images == 0 {
+		t.Fatalf("%s: no images found\n", msg)
+	}
+}
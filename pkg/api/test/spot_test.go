@@ -0,0 +1,197 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// writeSpotColorPDF writes a copy of test.pdf whose first page fills a rectangle using the spot color Pantone185C
+// and additionally defines the unused spot color Unused.
+func writeSpotColorPDF(t *testing.T, msg, outFile string) {
+	t.Helper()
+
+	ctx, err := api.ReadContextFile(filepath.Join(inDir, "test.pdf"))
+	if err != nil {
+		t.Fatalf("%s read: %v\n", msg, err)
+	}
+
+	sep := func(name string, c1 ...float64) types.Array {
+		f := types.Dict{
+			"FunctionType": types.Integer(2),
+			"Domain":       types.NewNumberArray(0, 1),
+			"C0":           types.NewNumberArray(0, 0, 0, 0),
+			"C1":           types.NewNumberArray(c1...),
+			"N":            types.Integer(1),
+		}
+		return types.Array{types.Name("Separation"), types.Name(name), types.Name("DeviceCMYK"), f}
+	}
+
+	d, _, _, err := ctx.PageDict(1, false)
+	if err != nil {
+		t.Fatalf("%s page: %v\n", msg, err)
+	}
+
+	sd, err := ctx.NewStreamDictForBuf([]byte("q /CS0 cs 0.5 scn 100 100 200 200 re f Q"))
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := sd.Encode(); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	ir, err := ctx.IndRefForNewObject(*sd)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	d.Update("Contents", *ir)
+	d.Update("Resources", types.Dict{
+		"ColorSpace": types.Dict{
+			"CS0": sep("Pantone185C", 0, 0.8, 0.9, 0),
+			"CS1": sep("Unused", 0.5, 0, 0, 0),
+		},
+	})
+
+	if err := api.WriteContextFile(ctx, outFile); err != nil {
+		t.Fatalf("%s write: %v\n", msg, err)
+	}
+}
+
+func colorants(t *testing.T, msg, inFile string) map[string]pdfcpu.Colorant {
+	t.Helper()
+
+	f, err := os.Open(inFile)
+	if err != nil {
+		t.Fatalf("%s open: %v\n", msg, err)
+	}
+	defer f.Close()
+
+	cc, err := api.Colorants(f, nil)
+	if err != nil {
+		t.Fatalf("%s colorants: %v\n", msg, err)
+	}
+
+	m := map[string]pdfcpu.Colorant{}
+	for _, c := range cc {
+		m[c.Name] = c
+	}
+	return m
+}
+
+func TestColorants(t *testing.T) {
+	msg := "TestColorants"
+
+	inFile := filepath.Join(outDir, "SpotColor.pdf")
+	writeSpotColorPDF(t, msg, inFile)
+
+	m := colorants(t, msg, inFile)
+
+	c, ok := m["Pantone185C"]
+	if !ok || !c.Separation || c.Alternate != "DeviceCMYK" || len(c.Pages) != 1 || c.Pages[0] != 1 {
+		t.Fatalf("%s: unexpected Pantone185C: %+v\n", msg, c)
+	}
+
+	if c, ok := m["Unused"]; !ok || len(c.Pages) != 0 {
+		t.Fatalf("%s: unexpected Unused: %+v\n", msg, c)
+	}
+}
+
+func TestRenameColorant(t *testing.T) {
+	msg := "TestRenameColorant"
+
+	inFile := filepath.Join(outDir, "SpotColor.pdf")
+	outFile := filepath.Join(outDir, "SpotColorRenamed.pdf")
+	writeSpotColorPDF(t, msg, inFile)
+
+	if err := api.RenameColorantFile(inFile, outFile, "Pantone185C", "Red", nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	m := colorants(t, msg, outFile)
+	if _, ok := m["Pantone185C"]; ok {
+		t.Fatalf("%s: Pantone185C not renamed\n", msg)
+	}
+	if c := m["Red"]; len(c.Pages) != 1 {
+		t.Fatalf("%s: unexpected Red: %+v\n", msg, c)
+	}
+
+	if err := api.RenameColorantFile(inFile, outFile, "Missing", "Red", nil); err == nil {
+		t.Fatalf("%s: want error for unknown colorant\n", msg)
+	}
+}
+
+func TestRemapColorant(t *testing.T) {
+	msg := "TestRemapColorant"
+
+	inFile := filepath.Join(outDir, "SpotColor.pdf")
+	outFile := filepath.Join(outDir, "SpotColorRemapped.pdf")
+	writeSpotColorPDF(t, msg, inFile)
+
+	if err := api.RemapColorantFile(inFile, outFile, "Pantone185C", "Unused", nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	m := colorants(t, msg, outFile)
+	if _, ok := m["Pantone185C"]; ok {
+		t.Fatalf("%s: Pantone185C not remapped\n", msg)
+	}
+	if c := m["Unused"]; len(c.Pages) != 1 {
+		t.Fatalf("%s: unexpected Unused: %+v\n", msg, c)
+	}
+}
+
+func TestConvertColorant(t *testing.T) {
+	msg := "TestConvertColorant"
+
+	inFile := filepath.Join(outDir, "SpotColor.pdf")
+	outFile := filepath.Join(outDir, "SpotColorConverted.pdf")
+	writeSpotColorPDF(t, msg, inFile)
+
+	if err := api.ConvertColorantFile(inFile, outFile, "Pantone185C", []float64{0, 0.8, 0.9, 0}, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if c := colorants(t, msg, outFile)["Pantone185C"]; len(c.Pages) != 0 {
+		t.Fatalf("%s: Pantone185C still in use: %+v\n", msg, c)
+	}
+}
+
+func TestRemoveUnusedColorants(t *testing.T) {
+	msg := "TestRemoveUnusedColorants"
+
+	inFile := filepath.Join(outDir, "SpotColor.pdf")
+	outFile := filepath.Join(outDir, "SpotColorCleaned.pdf")
+	writeSpotColorPDF(t, msg, inFile)
+
+	if err := api.RemoveUnusedColorantsFile(inFile, outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	m := colorants(t, msg, outFile)
+	if _, ok := m["Unused"]; ok {
+		t.Fatalf("%s: Unused not removed\n", msg)
+	}
+	if _, ok := m["Pantone185C"]; !ok {
+		t.Fatalf("%s: Pantone185C removed\n", msg)
+	}
+}
@@ -390,3 +390,68 @@ func TestPdfMultiStampVariations(t *testing.T) {
 		}
 	}
 }
+
+func TestBlendModesAndOpacityViaWatermarkSliceMap(t *testing.T) {
+	msg := "TestBlendModesAndOpacityViaWatermarkSliceMap"
+	inFile := filepath.Join(inDir, "mountain.pdf")
+	outFile := filepath.Join(samplesDir, "stamp", "mixed", "BlendModesAndOpacityViaWatermarkSliceMap.pdf")
+
+	// Each watermark uses its own opacity and blend mode.
+	wm1, err := api.ImageWatermark(filepath.Join(resDir, "logoSmall.png"), "pos:tl, scale:.3, op:.4, blend:multiply, rot:0", true, false, types.POINTS)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	wm2, err := api.TextWatermark("Scanned", "pos:c, scale:.8, op:.8, blend:screen, fillc:#FFFFFF, rot:0", true, false, types.POINTS)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	wm3, err := api.TextWatermark("Copy", "pos:br, scale:.3, blend:overlay, rot:0", true, false, types.POINTS)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	m := map[int][]*model.Watermark{1: {wm1, wm2, wm3}}
+
+	if err := api.AddWatermarksSliceMapFile(inFile, outFile, m, nil); err != nil {
+		t.Fatalf("%s %s: %v\n", msg, outFile, err)
+	}
+
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if _, err := api.TextWatermark("Copy", "blend:mix", true, false, types.POINTS); err == nil {
+		t.Fatalf("%s: expected error for invalid blend mode\n", msg)
+	}
+}
+
+func TestPdfMultiStampModes(t *testing.T) {
+	msg := "TestPdfMultiStampModes"
+	inFile := filepath.Join(inDir, "WaldenFull.pdf")
+	stampFile := filepath.Join(inDir, "zineTest.pdf")
+
+	for _, tt := range []struct {
+		outFile, desc string
+	}{
+		// Once all pages of stampFile are used repeat the last one.
+		{"PdfMultiStampRepeat.pdf", "scale:.2, pos:tr, rot:0, multi:repeat"},
+		// Once all pages of stampFile are used start over with the first one.
+		{"PdfMultiStampCycle.pdf", "scale:.2, pos:tr, rot:0, multi:cycle"},
+		// Once all pages of stampFile are used stop stamping.
+		{"PdfMultiStampOnce.pdf", "scale:.2, pos:tr, rot:0, multi:once"},
+	} {
+		wm, err := api.PDFWatermark(stampFile, tt.desc, true, false, types.POINTS)
+		if err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		outFile := filepath.Join(samplesDir, "stamp", "mixed", tt.outFile)
+		if err = api.AddWatermarksFile(inFile, outFile, nil, wm, nil); err != nil {
+			t.Fatalf("%s %s: %v\n", msg, outFile, err)
+		}
+		if err := api.ValidateFile(outFile, nil); err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+	}
+}
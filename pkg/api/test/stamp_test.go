@@ -18,11 +18,15 @@ package test
 
 import (
 	"fmt"
+	"io"
+	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/pdfcpu/pdfcpu/pkg/api"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
 )
 
@@ -433,6 +437,42 @@ func TestAddWatermarks(t *testing.T) {
 		// Add a PDF multistamp to all pages of inFile.
 		// Start by stamping page 3 with page 1.
 		// You may filter stamping by defining selected Pages.
+		// Repeat a text watermark across all pages at 45 degrees.
+		{"TestWatermarkText",
+			"Walden.pdf",
+			"TextTiled.pdf",
+			[]string{"1-"},
+			"text",
+			"CONFIDENTIAL",
+			"tile:on, scale:.25, rot:45, op:.3, spacing:30 60"},
+
+		// Render text along the upper half of a circle like a round seal.
+		{"TestWatermarkText",
+			"Walden.pdf",
+			"TextPathSeal.pdf",
+			[]string{"1-"},
+			"text",
+			"CERTIFIED COPY OF THE ORIGINAL",
+			"textpath:arc 100, scale:.9, rot:0, fillcol:#0000cc"},
+
+		// Render text along a Bezier curve.
+		{"TestWatermarkText",
+			"Walden.pdf",
+			"TextPathCurve.pdf",
+			[]string{"1-"},
+			"text",
+			"Following a curved baseline",
+			"textpath:curve 0 0 100 150 250 -150 350 0, points:24, scale:1 abs, rot:0, pos:bc, off:0 50"},
+
+		// Repeat an image watermark across all pages.
+		{"TestWatermarkImage",
+			"Walden.pdf",
+			"ImageTiled.pdf",
+			[]string{"1-"},
+			"image",
+			filepath.Join(resDir, "logoSmall.png"),
+			"tile:on, scale:.1, rot:0, op:.5"},
+
 		{"TestWatermarkPDF",
 			"zineTest.pdf",
 			"PdfMultistamp13.pdf",
@@ -637,3 +677,263 @@ func TestRecycleWM(t *testing.T) {
 		t.Fatalf("%s %s: %v\n", msg, outFile, err)
 	}
 }
+
+func TestAddWatermarksForFS(t *testing.T) {
+	msg := "TestAddWatermarksForFS"
+	inFile := filepath.Join(inDir, "test.pdf")
+	outFile := filepath.Join(outDir, "ImageStampFromFS.pdf")
+
+	// Resolve image file names relative to a file system, eg. assets bundled using go:embed.
+	conf := model.NewDefaultConfiguration()
+	conf.ResourceFS = os.DirFS(resDir)
+
+	if err := api.AddImageWatermarksFile(inFile, outFile, nil, true, "logoSmall.png", "scale:.25 abs, rot:0", conf); err != nil {
+		t.Fatalf("%s %s: %v\n", msg, outFile, err)
+	}
+
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if _, err := api.ImageWatermarkForFS(os.DirFS(resDir), "missing.png", "", true, false, types.POINTS); err == nil {
+		t.Fatalf("%s: expected error for missing image\n", msg)
+	}
+}
+
+func pageRotation(t *testing.T, msg, fileName string, pageNr int) int {
+	t.Helper()
+	ctx, err := api.ReadContextFile(fileName)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	_, _, inhPAttrs, err := ctx.PageDict(pageNr, false)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	return inhPAttrs.Rotate
+}
+
+func TestStampRotatedCroppedPage(t *testing.T) {
+	msg := "TestStampRotatedCroppedPage"
+	inFile := filepath.Join(inDir, "test.pdf")
+	rotFile := filepath.Join(outDir, "testRotatedCropped.pdf")
+
+	// Prepare a page with an offset crop box and a page rotation.
+	b, err := api.Box("[50 50 450 650]", types.POINTS)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.CropFile(inFile, rotFile, []string{"1"}, b, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.RotateFile(rotFile, "", 90, []string{"1"}, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	for _, tt := range []struct {
+		outFile string
+		desc    string
+		rot     int
+	}{
+		// Internalize page rotation.
+		{"stampRotatedCropInternalized.pdf", "pos:tl, scale:.5, rot:0", 0},
+		// Retain page rotation and render upright relative to the displayed crop box.
+		{"stampRotatedCropKeep.pdf", "pos:tl, scale:.5, rot:0, keeprot:on", 90},
+		// Retain page rotation and render upright relative to the displayed media box.
+		{"stampRotatedMediaKeep.pdf", "pos:tl, scale:.5, rot:0, keeprot:on, pagebox:media", 90},
+	} {
+		outFile := filepath.Join(outDir, tt.outFile)
+		if err := api.AddTextWatermarksFile(rotFile, outFile, []string{"1"}, true, "Draft", tt.desc, nil); err != nil {
+			t.Fatalf("%s %s: %v\n", msg, outFile, err)
+		}
+		if err := api.ValidateFile(outFile, nil); err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		if rot := pageRotation(t, msg, outFile, 1); rot != tt.rot {
+			t.Fatalf("%s %s: want page rotation %d, got %d\n", msg, outFile, tt.rot, rot)
+		}
+	}
+
+	if _, err := api.TextWatermark("Draft", "pagebox:trim", true, false, types.POINTS); err == nil {
+		t.Fatalf("%s: expected error for invalid page box\n", msg)
+	}
+}
+
+func TestAddWatermarksJSON(t *testing.T) {
+	msg := "TestAddWatermarksJSON"
+	inFile := filepath.Join(inDir, "Walden.pdf")
+	inFileJSON := filepath.Join(inDir, "json", "stamp", "watermarkMap.json")
+	outFile := filepath.Join(outDir, "stampMap.pdf")
+
+	// Apply distinct stamps to page selections in one pass.
+	if err := api.AddWatermarksJSONFile(inFile, inFileJSON, outFile, true, nil); err != nil {
+		t.Fatalf("%s %s: %v\n", msg, outFile, err)
+	}
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	ok, err := api.HasWatermarksFile(outFile, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if !ok {
+		t.Fatalf("%s: missing stamps\n", msg)
+	}
+
+	for _, s := range []string{
+		`{"watermarks": []}`,
+		`{"watermarks": [{"pages": "1", "text": "a", "image": "b.png"}]}`,
+		`{"watermarks": [{"pages": "1"}]}`,
+	} {
+		f, err := os.Open(inFile)
+		if err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		err = api.AddWatermarksJSON(f, strings.NewReader(s), io.Discard, true, nil)
+		f.Close()
+		if err == nil {
+			t.Fatalf("%s: expected error for %s\n", msg, s)
+		}
+	}
+}
+
+func TestRemoveStampsByID(t *testing.T) {
+	msg := "TestRemoveStampsByID"
+	inFile := filepath.Join(inDir, "test.pdf")
+	outFile := filepath.Join(outDir, "stampsByID.pdf")
+
+	if err := api.AddTextWatermarksFile(inFile, outFile, nil, true, "Draft", "id:draft, pos:tl, scale:.3, rot:0", nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.AddTextWatermarksFile(outFile, "", nil, true, "Final", "id:final, pos:br, scale:.3, rot:0", nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// Replace the draft stamp only.
+	if err := api.UpdateTextWatermarksFile(outFile, "", nil, true, "Draft 2", "id:draft, pos:tl, scale:.3, rot:0", nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// Remove the draft stamp only.
+	if err := api.RemoveWatermarksByIDFile(outFile, "", nil, "draft", nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	ok, err := api.HasWatermarksFile(outFile, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if !ok {
+		t.Fatalf("%s: missing final stamp\n", msg)
+	}
+
+	// No more draft stamps to remove.
+	if err := api.RemoveWatermarksByIDFile(outFile, "", nil, "draft", nil); err == nil {
+		t.Fatalf("%s: expected error removing draft stamp twice\n", msg)
+	}
+
+	if err := api.RemoveWatermarksByIDFile(outFile, "", nil, "final", nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+}
+
+func TestAddWatermarksDryRun(t *testing.T) {
+	msg := "TestAddWatermarksDryRun"
+	inFile := filepath.Join(inDir, "Acroforms2.pdf")
+	outFile := filepath.Join(outDir, "dryRun.pdf")
+
+	os.Remove(outFile)
+
+	conf := model.NewDefaultConfiguration()
+	conf.DryRun = true
+
+	wm, err := api.TextWatermark("Draft", "", true, false, types.POINTS)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if err := api.AddWatermarksFile(inFile, outFile, []string{"1"}, wm, conf); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if _, err := os.Stat(outFile); !os.IsNotExist(err) {
+		t.Fatalf("%s: dry run wrote %s\n", msg, outFile)
+	}
+
+	f, err := os.Open(inFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	defer f.Close()
+
+	// Skip optimization which may touch objects of other pages.
+	conf.Optimize = false
+
+	ctx, err := api.ReadValidateAndOptimize(f, conf)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if err := pdfcpu.AddWatermarks(ctx, types.IntSet{1: true}, wm); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if err := api.WriteContext(ctx, io.Discard); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	r := ctx.DryRunReport
+	if r == nil {
+		t.Fatalf("%s: missing dry run report\n", msg)
+	}
+	if len(r.AffectedPages) != 1 || r.AffectedPages[0] != 1 {
+		t.Fatalf("%s: want affected pages [1], got: %v\n", msg, r.AffectedPages)
+	}
+	if r.Added == 0 || r.OutputSize == 0 {
+		t.Fatalf("%s: unexpected report: %s\n", msg, r)
+	}
+}
+
+func TestAddWatermarksBackup(t *testing.T) {
+	msg := "TestAddWatermarksBackup"
+	inFile := filepath.Join(outDir, "backup.pdf")
+	bakFile := inFile + ".bak"
+
+	if err := copyFile(t, filepath.Join(inDir, "Acroforms2.pdf"), inFile); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	os.Remove(bakFile)
+
+	bb, err := os.ReadFile(inFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	conf := model.NewDefaultConfiguration()
+	conf.Backup = true
+
+	wm, err := api.TextWatermark("Draft", "", true, false, types.POINTS)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// Stamp inFile in place.
+	if err := api.AddWatermarksFile(inFile, "", nil, wm, conf); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	bak, err := os.ReadFile(bakFile)
+	if err != nil {
+		t.Fatalf("%s: missing backup: %v\n", msg, err)
+	}
+	if string(bak) != string(bb) {
+		t.Fatalf("%s: backup differs from original\n", msg)
+	}
+
+	if !hasWatermarks(inFile, t) {
+		t.Fatalf("%s: watermarks missing\n", msg)
+	}
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+func templateRegions(t *testing.T, msg, inFile string) []pdfcpu.TemplateRegion {
+	t.Helper()
+
+	f, err := os.Open(inFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	defer f.Close()
+
+	rr, err := api.TemplateRegions(f, nil)
+	if err != nil {
+		t.Fatalf("%s regions: %v\n", msg, err)
+	}
+
+	return rr
+}
+
+func TestFillTemplate(t *testing.T) {
+	msg := "TestFillTemplate"
+
+	inFile := filepath.Join(outDir, "Template.pdf")
+	outFile := filepath.Join(outDir, "TemplateFilled.pdf")
+	if err := copyFile(t, filepath.Join(inDir, "test.pdf"), inFile); err != nil {
+		t.Fatalf("%s copyFile: %v\n", msg, err)
+	}
+
+	r1, err := pdfcpu.ParseTemplateRegion("name:address, rect:50 650 300 750", 1, types.POINTS)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	r2 := pdfcpu.TemplateRegion{Name: "items", PageNr: 1, Rect: types.NewRectangle(50, 300, 500, 600)}
+
+	if err := api.AddTemplateRegionsFile(inFile, "", []pdfcpu.TemplateRegion{*r1, r2}, nil); err != nil {
+		t.Fatalf("%s add: %v\n", msg, err)
+	}
+
+	if rr := templateRegions(t, msg, inFile); len(rr) != 2 || rr[0].Name != "address" || rr[1].Name != "items" {
+		t.Fatalf("%s: unexpected regions: %v\n", msg, rr)
+	}
+
+	fills, err := api.ParseTemplateFillsJSON(strings.NewReader(`{
+		"address": {"text": "John Doe\nMain Street 1"},
+		"items":   {"header": ["Item", "Qty"], "table": [["Pen", "2"], ["Paper", "500"]], "size": 10}
+	}`))
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	f, err := os.Open(inFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	defer f.Close()
+
+	w, err := os.Create(outFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if err := api.FillTemplate(f, w, fills, nil); err != nil {
+		t.Fatalf("%s fill: %v\n", msg, err)
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if rr := templateRegions(t, msg, outFile); len(rr) != 0 {
+		t.Fatalf("%s: regions not removed: %v\n", msg, rr)
+	}
+
+	if s := pageContent(t, msg, outFile, 1); strings.Count(s, " Do Q") < 2 {
+		t.Fatalf("%s: missing filled regions\n", msg)
+	}
+
+	if _, err := f.Seek(0, 0); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	fills = map[string]pdfcpu.TemplateFill{"unknown": {Text: "x"}}
+	if err := api.FillTemplate(f, io.Discard, fills, nil); err == nil {
+		t.Fatalf("%s: missing error for unknown region\n", msg)
+	}
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+func TestAddTextLayer(t *testing.T) {
+	msg := "TestAddTextLayer"
+
+	inFile := filepath.Join(inDir, "test.pdf")
+	outFile := filepath.Join(outDir, "TextLayer.pdf")
+
+	ww := []pdfcpu.OCRWord{
+		{Text: "Invisible", Rect: *types.NewRectangle(100, 500, 190, 520), Confidence: 96},
+		{Text: "Straße", Rect: *types.NewRectangle(200, 500, 260, 520), Confidence: 91},
+		{Text: "noise", Rect: *types.NewRectangle(300, 500, 340, 520), Confidence: 12},
+	}
+
+	if err := api.AddTextLayersFile(inFile, outFile, map[int][]pdfcpu.OCRWord{1: ww}, 50, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	f, err := os.Open(outFile)
+	if err != nil {
+		t.Fatalf("%s open: %v\n", msg, err)
+	}
+	defer f.Close()
+
+	pww, err := api.Words(f, []string{"1"}, conf)
+	if err != nil {
+		t.Fatalf("%s words: %v\n", msg, err)
+	}
+
+	found := map[string]types.Rectangle{}
+	for _, w := range pww[0].Words {
+		found[w.Text] = w.Rect
+	}
+
+	if _, ok := found["noise"]; ok {
+		t.Fatalf("%s: word below minimum confidence added\n", msg)
+	}
+
+	for _, w := range ww[:2] {
+		r, ok := found[w.Text]
+		if !ok {
+			t.Fatalf("%s: missing word %q\n", msg, w.Text)
+		}
+		if math.Abs(r.LL.X-w.Rect.LL.X) > 1 || math.Abs(r.UR.X-w.Rect.UR.X) > 1 ||
+			math.Abs(r.LL.Y-w.Rect.LL.Y) > 1 || math.Abs(r.UR.Y-w.Rect.UR.Y) > 1 {
+			t.Fatalf("%s: %q: want %v, got %v\n", msg, w.Text, w.Rect, r)
+		}
+	}
+}
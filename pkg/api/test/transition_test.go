@@ -0,0 +1,75 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+)
+
+func TestTransitions(t *testing.T) {
+	msg := "TestTransitions"
+
+	inFile := filepath.Join(outDir, "transitions.pdf")
+	copyFile(t, filepath.Join(inDir, "CenterOfWhy.pdf"), inFile)
+
+	tr, err := pdfcpu.ParseTransitionConfig("style:split, dur:1.5, dim:v, motion:o, display:5")
+	if err != nil {
+		t.Fatalf("%s: parse transition: %v\n", msg, err)
+	}
+
+	if err := api.SetTransitionsFile(inFile, "", []string{"1-2"}, tr, nil); err != nil {
+		t.Fatalf("%s %s: set transitions: %v\n", msg, inFile, err)
+	}
+
+	want := []string{
+		"Transitions:",
+		"page 1: style:Split, duration:1.50, dimension:V, motion:O, display:5.00",
+		"page 2: style:Split, duration:1.50, dimension:V, motion:O, display:5.00",
+	}
+	got, err := api.ListTransitionsFile(inFile, nil, nil)
+	if err != nil {
+		t.Fatalf("%s %s: list transitions: %v\n", msg, inFile, err)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("%s %s: list transitions, want:%v, got:%v\n", msg, inFile, want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("%s %s: list transitions, want:%v, got:%v\n", msg, inFile, want, got)
+		}
+	}
+
+	if err := api.RemoveTransitionsFile(inFile, "", []string{"1"}, nil); err != nil {
+		t.Fatalf("%s %s: remove transitions: %v\n", msg, inFile, err)
+	}
+	if got, err = api.ListTransitionsFile(inFile, nil, nil); err != nil {
+		t.Fatalf("%s %s: list transitions: %v\n", msg, inFile, err)
+	}
+	if len(got) != 2 || got[1] != want[2] {
+		t.Fatalf("%s %s: list transitions after remove, got:%v\n", msg, inFile, got)
+	}
+
+	for _, s := range []string{"style:zoom", "style:wipe, dim:h", "style:box, angle:none"} {
+		if _, err := pdfcpu.ParseTransitionConfig(s); err == nil {
+			t.Fatalf("%s: parse transition %q: expected error\n", msg, s)
+		}
+	}
+}
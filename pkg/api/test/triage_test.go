@@ -0,0 +1,52 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+)
+
+func TestTriage(t *testing.T) {
+	msg := "TestTriage"
+
+	r, err := api.TriageFile(filepath.Join(inDir, "test.pdf"), nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if !r.OK() || r.PageCount == 0 {
+		t.Fatalf("%s: unexpected failure: %+v\n", msg, r)
+	}
+
+	// Truncate a valid file right in the middle.
+	bb, err := os.ReadFile(filepath.Join(inDir, "test.pdf"))
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	r, err = api.Triage(strings.NewReader(string(bb[:100])), nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if r.OK() || r.Error == "" {
+		t.Fatalf("%s: missing failure for truncated file: %+v\n", msg, r)
+	}
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+)
+
+func TestWords(t *testing.T) {
+	msg := "TestWords"
+
+	f, err := os.Open(filepath.Join(inDir, "Acroforms2.pdf"))
+	if err != nil {
+		t.Fatalf("%s open: %v\n", msg, err)
+	}
+	defer f.Close()
+
+	pww, err := api.Words(f, []string{"1"}, conf)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if len(pww) != 1 || pww[0].Page != 1 || pww[0].CropBox == nil {
+		t.Fatalf("%s: unexpected pages: %v\n", msg, pww)
+	}
+
+	ww := pww[0].Words
+	if len(ww) == 0 {
+		t.Fatalf("%s: no words found\n", msg)
+	}
+
+	for _, w := range ww {
+		if w.Text == "" || strings.ContainsAny(w.Text, " \t\n") || len(w.Glyphs) == 0 || w.FontSize <= 0 {
+			t.Fatalf("%s: invalid word: %+v\n", msg, w)
+		}
+		for _, g := range w.Glyphs {
+			r := g.Rect
+			if r.LL.X < w.Rect.LL.X || r.LL.Y < w.Rect.LL.Y || r.UR.X > w.Rect.UR.X || r.UR.Y > w.Rect.UR.Y {
+				t.Fatalf("%s: glyph %q outside of word %q\n", msg, g.Text, w.Text)
+			}
+		}
+	}
+}
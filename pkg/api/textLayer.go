@@ -0,0 +1,69 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+// AddTextLayers adds invisible text for OCR results to pages of rs and writes the result to w.
+// layers maps page numbers to the words recognized on the page in user space coordinates.
+// Words with a confidence below minConfidence are skipped.
+func AddTextLayers(rs io.ReadSeeker, w io.Writer, layers map[int][]pdfcpu.OCRWord, minConfidence float64, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: AddTextLayers: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
+	}
+	conf.Cmd = model.ADDTEXTLAYER
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	if err := pdfcpu.AddTextLayers(ctx, layers, minConfidence); err != nil {
+		return err
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// AddTextLayer adds invisible text for the OCR results ww to page pageNr of rs and writes the result to w.
+func AddTextLayer(rs io.ReadSeeker, w io.Writer, pageNr int, ww []pdfcpu.OCRWord, conf *model.Configuration) error {
+	return AddTextLayers(rs, w, map[int][]pdfcpu.OCRWord{pageNr: ww}, 0, conf)
+}
+
+// AddTextLayersFile adds invisible text for OCR results to pages of inFile and writes the result to outFile.
+func AddTextLayersFile(inFile, outFile string, layers map[int][]pdfcpu.OCRWord, minConfidence float64, conf *model.Configuration) error {
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return AddTextLayers(rs, w, layers, minConfidence, conf)
+	})
+}
+
+// AddTextLayerFile adds invisible text for the OCR results ww to page pageNr of inFile and writes the result to outFile.
+func AddTextLayerFile(inFile, outFile string, pageNr int, ww []pdfcpu.OCRWord, conf *model.Configuration) error {
+	return AddTextLayersFile(inFile, outFile, map[int][]pdfcpu.OCRWord{pageNr: ww}, 0, conf)
+}
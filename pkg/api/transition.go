@@ -0,0 +1,157 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+	"os"
+
+	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+// ListTransitions returns the page transitions and display durations of selected pages of rs.
+func ListTransitions(rs io.ReadSeeker, selectedPages []string, conf *model.Configuration) ([]string, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: ListTransitions: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
+		conf.ValidationMode = model.ValidationRelaxed
+	}
+	conf.Cmd = model.LISTTRANSITIONS
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	pages, err := PagesForPageSelectionWithContext(ctx, selectedPages, true, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return pdfcpu.ListTransitions(ctx, pages)
+}
+
+// ListTransitionsFile returns the page transitions and display durations of selected pages of inFile.
+func ListTransitionsFile(inFile string, selectedPages []string, conf *model.Configuration) ([]string, error) {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ListTransitions(f, selectedPages, conf)
+}
+
+// SetTransitions applies the page transition t to selected pages of rs and writes the result to w.
+func SetTransitions(rs io.ReadSeeker, w io.Writer, selectedPages []string, t *model.Transition, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: SetTransitions: missing rs")
+	}
+
+	if t == nil {
+		return errors.New("pdfcpu: SetTransitions: missing transition")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
+	}
+	conf.Cmd = model.SETTRANSITIONS
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	pages, err := PagesForPageSelectionWithContext(ctx, selectedPages, true, true)
+	if err != nil {
+		return err
+	}
+
+	if err = pdfcpu.SetTransitions(ctx, pages, *t); err != nil {
+		return err
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// SetTransitionsFile applies the page transition t to selected pages of inFile and writes the result to outFile.
+func SetTransitionsFile(inFile, outFile string, selectedPages []string, t *model.Transition, conf *model.Configuration) (err error) {
+	if log.CLIEnabled() {
+		log.CLI.Printf("setting transitions for %s\n", inFile)
+	}
+
+	if outFile != "" && inFile != outFile {
+		logWritingTo(outFile)
+	} else {
+		logWritingTo(inFile)
+	}
+
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return SetTransitions(rs, w, selectedPages, t, conf)
+	})
+}
+
+// RemoveTransitions removes page transitions and display durations from selected pages of rs and writes the result to w.
+func RemoveTransitions(rs io.ReadSeeker, w io.Writer, selectedPages []string, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: RemoveTransitions: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
+	}
+	conf.Cmd = model.REMOVETRANSITIONS
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	pages, err := PagesForPageSelectionWithContext(ctx, selectedPages, true, true)
+	if err != nil {
+		return err
+	}
+
+	ok, err := pdfcpu.RemoveTransitions(ctx, pages)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New("pdfcpu: no transitions removed")
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// RemoveTransitionsFile removes page transitions and display durations from selected pages of inFile and writes the result to outFile.
+func RemoveTransitionsFile(inFile, outFile string, selectedPages []string, conf *model.Configuration) (err error) {
+	return updateFile(inFile, outFile, conf, func(rs io.ReadSeeker, w io.Writer) error {
+		return RemoveTransitions(rs, w, selectedPages, conf)
+	})
+}
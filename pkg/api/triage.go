@@ -0,0 +1,74 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+	"os"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+// Triage processes rs stage by stage (xref, objects, validate, optimize, write) recovering from crashes
+// and classifies the first failing stage along with the object involved.
+// The result helps users to report actionable issues.
+func Triage(rs io.ReadSeeker, conf *model.Configuration) (*pdfcpu.TriageReport, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: Triage: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
+	}
+	conf.Cmd = model.TRIAGE
+
+	r := &pdfcpu.TriageReport{}
+
+	ctx := pdfcpu.TriageRead(rs, conf, r)
+	if ctx == nil {
+		return r, nil
+	}
+
+	if !r.Run(ctx, pdfcpu.TriageStageValidate, func() error { return ValidateContext(ctx) }) {
+		return r, nil
+	}
+
+	r.PageCount = ctx.PageCount
+
+	if !r.Run(ctx, pdfcpu.TriageStageOptimize, func() error { return pdfcpu.OptimizeXRefTable(ctx) }) {
+		return r, nil
+	}
+
+	r.Run(ctx, pdfcpu.TriageStageWrite, func() error { return WriteContext(ctx, io.Discard) })
+
+	return r, nil
+}
+
+// TriageFile processes inFile stage by stage and classifies the first failing stage along with the object involved.
+func TriageFile(inFile string, conf *model.Configuration) (*pdfcpu.TriageReport, error) {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return Triage(f, conf)
+}
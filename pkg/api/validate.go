@@ -28,6 +28,23 @@ import (
 	"github.com/pkg/errors"
 )
 
+// ErrValidation matches all errors caused by a document failing validation.
+//
+//	if errors.Is(err, api.ErrValidation) { ... }
+var ErrValidation = errors.New("pdfcpu: validation failed")
+
+type validationError struct {
+	error
+}
+
+func (e validationError) Unwrap() error {
+	return e.error
+}
+
+func (e validationError) Is(target error) bool {
+	return target == ErrValidation
+}
+
 // Validate validates a PDF stream read from rs.
 func Validate(rs io.ReadSeeker, conf *model.Configuration) error {
 	if rs == nil {
@@ -36,6 +53,8 @@ func Validate(rs io.ReadSeeker, conf *model.Configuration) error {
 
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
 	}
 	conf.Cmd = model.VALIDATE
 
@@ -57,6 +76,10 @@ func Validate(rs io.ReadSeeker, conf *model.Configuration) error {
 		err = errors.Wrap(err, fmt.Sprintf("validation error (obj#:%d)%s", ctx.CurObj, s))
 	}
 
+	if err == nil && conf.ValidateStandard != "" {
+		err = preflight(ctx, conf.ValidateStandard)
+	}
+
 	if err == nil {
 		if log.StatsEnabled() || conf.Optimize {
 			if log.CLIEnabled() {
@@ -83,7 +106,7 @@ func Validate(rs io.ReadSeeker, conf *model.Configuration) error {
 	return err
 }
 
-// ValidateFile validates inFile.
+// ValidateFile validates inFile or stdin if inFile is StdIO.
 func ValidateFile(inFile string, conf *model.Configuration) error {
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
@@ -91,14 +114,14 @@ func ValidateFile(inFile string, conf *model.Configuration) error {
 
 	log.CLI.Printf("validating(mode=%s) %s ...\n", conf.ValidationModeString(), inFile)
 
-	f, err := os.Open(inFile)
+	rs, closeIn, err := openInFile(inFile)
 	if err != nil {
 		return err
 	}
 
-	defer f.Close()
+	defer closeIn()
 
-	if err = Validate(f, conf); err != nil {
+	if err = Validate(rs, conf); err != nil {
 		return err
 	}
 
@@ -113,6 +136,7 @@ func ValidateFiles(inFiles []string, conf *model.Configuration) error {
 		conf = model.NewDefaultConfiguration()
 	}
 
+	failed := 0
 	for i, fn := range inFiles {
 		if i > 0 {
 			log.CLI.Println()
@@ -122,9 +146,14 @@ func ValidateFiles(inFiles []string, conf *model.Configuration) error {
 				return err
 			}
 			fmt.Fprintf(os.Stderr, "%s: %v\n", fn, err)
+			failed++
 		}
 	}
 
+	if failed > 0 {
+		return validationError{errors.Errorf("pdfcpu: %d of %d files failed validation", failed, len(inFiles))}
+	}
+
 	return nil
 }
 
@@ -136,6 +165,8 @@ func DumpObject(rs io.ReadSeeker, mode, objNr int, conf *model.Configuration) er
 
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
 	}
 	conf.Cmd = model.DUMP
 
@@ -0,0 +1,71 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+	"sort"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+// Words returns the words of selected pages of rs including glyph bounding boxes, baselines and advances.
+// Use this for aligning external OCR output with the text of a page.
+func Words(rs io.ReadSeeker, selectedPages []string, conf *model.Configuration) ([]*pdfcpu.PageWords, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: Words: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf = conf.Clone()
+	}
+	conf.Cmd = model.EXTRACTCONTENT
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	pages, err := PagesForPageSelectionWithContext(ctx, selectedPages, true, true)
+	if err != nil {
+		return nil, err
+	}
+
+	pageNrs := make([]int, 0, len(pages))
+	for i, v := range pages {
+		if v {
+			pageNrs = append(pageNrs, i)
+		}
+	}
+	sort.Ints(pageNrs)
+
+	pww := make([]*pdfcpu.PageWords, 0, len(pageNrs))
+
+	for _, i := range pageNrs {
+		pw, err := pdfcpu.Words(ctx, i)
+		if err != nil {
+			return nil, err
+		}
+		pww = append(pww, pw)
+	}
+
+	return pww, nil
+}
@@ -18,8 +18,13 @@ limitations under the License.
 package cli
 
 import (
+	"encoding/json"
+	"strings"
+
 	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
 )
 
 // Validate inFile against ISO-32000-1:2008.
@@ -27,6 +32,24 @@ func Validate(cmd *Command) ([]string, error) {
 	return nil, api.ValidateFiles(cmd.InFiles, cmd.Conf)
 }
 
+// Triage processes inFile stage by stage and reports the first failing stage along with the object involved.
+func Triage(cmd *Command) ([]string, error) {
+	r, err := api.TriageFile(*cmd.InFile, cmd.Conf)
+	if err != nil {
+		return nil, err
+	}
+
+	if cmd.BoolVal1 {
+		bb, err := json.MarshalIndent(r, "", "\t")
+		if err != nil {
+			return nil, err
+		}
+		return []string{string(bb)}, nil
+	}
+
+	return pdfcpu.ListTriageReport(r), nil
+}
+
 // Optimize inFile and write result to outFile.
 func Optimize(cmd *Command) ([]string, error) {
 	return nil, api.OptimizeFile(*cmd.InFile, *cmd.OutFile, cmd.Conf)
@@ -52,6 +75,16 @@ func ChangeOwnerPassword(cmd *Command) ([]string, error) {
 	return nil, api.ChangeOwnerPasswordFile(*cmd.InFile, *cmd.OutFile, *cmd.PWOld, *cmd.PWNew, cmd.Conf)
 }
 
+// UpdateEncryption re-encrypts inFile and writes the result to outFile.
+func UpdateEncryption(cmd *Command) ([]string, error) {
+	return nil, api.UpdateEncryptionFile(*cmd.InFile, *cmd.OutFile, cmd.Conf)
+}
+
+// ListEncryption returns encryption details of inFiles.
+func ListEncryption(cmd *Command) ([]string, error) {
+	return ListEncryptionFiles(cmd.InFiles, cmd.BoolVal1, cmd.Conf)
+}
+
 // ListPermissions of inFile.
 func ListPermissions(cmd *Command) ([]string, error) {
 	return ListPermissionsFile(cmd.InFiles, cmd.Conf)
@@ -69,9 +102,17 @@ func Split(cmd *Command) ([]string, error) {
 
 // Split inFile along pages and write result files to outDir.
 func SplitByPageNr(cmd *Command) ([]string, error) {
+	if len(cmd.PageSelection) > 0 {
+		return nil, api.SplitByPageSelectionFile(*cmd.InFile, *cmd.OutDir, cmd.PageSelection, cmd.Conf)
+	}
 	return nil, api.SplitByPageNrFile(*cmd.InFile, *cmd.OutDir, cmd.IntVals, cmd.Conf)
 }
 
+// Split inFile along bookmarks and write result files and manifest to outDir.
+func SplitByBookmark(cmd *Command) ([]string, error) {
+	return nil, api.SplitByBookmarkFile(*cmd.InFile, *cmd.OutDir, cmd.IntVal, cmd.Conf)
+}
+
 // Trim inFile and write result to outFile.
 func Trim(cmd *Command) ([]string, error) {
 	return nil, api.TrimFile(*cmd.InFile, *cmd.OutFile, cmd.PageSelection, cmd.Conf)
@@ -84,11 +125,22 @@ func Rotate(cmd *Command) ([]string, error) {
 
 // AddWatermarks adds watermarks or stamps to selected pages of inFile and writes the result to outFile.
 func AddWatermarks(cmd *Command) ([]string, error) {
+	if cmd.InFileJSON != nil {
+		return nil, api.AddWatermarksJSONFile(*cmd.InFile, *cmd.InFileJSON, *cmd.OutFile, cmd.BoolVal1, cmd.Conf)
+	}
 	return nil, api.AddWatermarksFile(*cmd.InFile, *cmd.OutFile, cmd.PageSelection, cmd.Watermark, cmd.Conf)
 }
 
+// AddPageNumbers stamps page numbers onto selected pages of inFile and writes the result to outFile.
+func AddPageNumbers(cmd *Command) ([]string, error) {
+	return nil, api.AddPageNumbersFile(*cmd.InFile, *cmd.OutFile, cmd.PageSelection, cmd.PageNumbers, cmd.Conf)
+}
+
 // RemoveWatermarks remove watermarks or stamps from selected pages of inFile and writes the result to outFile.
 func RemoveWatermarks(cmd *Command) ([]string, error) {
+	if cmd.StringVal != "" {
+		return nil, api.RemoveWatermarksByIDFile(*cmd.InFile, *cmd.OutFile, cmd.PageSelection, cmd.StringVal, cmd.Conf)
+	}
 	return nil, api.RemoveWatermarksFile(*cmd.InFile, *cmd.OutFile, cmd.PageSelection, cmd.Conf)
 }
 
@@ -123,6 +175,11 @@ func RemovePages(cmd *Command) ([]string, error) {
 	return nil, api.RemovePagesFile(*cmd.InFile, *cmd.OutFile, cmd.PageSelection, cmd.Conf)
 }
 
+// FlattenPageAttrs pushes inherited page attributes down into each page.
+func FlattenPageAttrs(cmd *Command) ([]string, error) {
+	return nil, api.FlattenPageAttrsFile(*cmd.InFile, *cmd.OutFile, cmd.Conf)
+}
+
 // MergeCreate merges inFiles in the order specified and writes the result to outFile.
 func MergeCreate(cmd *Command) ([]string, error) {
 	return nil, api.MergeCreateFile(cmd.InFiles, *cmd.OutFile, cmd.BoolVal1, cmd.Conf)
@@ -158,6 +215,11 @@ func ExtractContent(cmd *Command) ([]string, error) {
 	return nil, api.ExtractContentFile(*cmd.InFile, *cmd.OutDir, cmd.PageSelection, cmd.Conf)
 }
 
+// ExtractFormXObjects dumps form XObjects for selected pages of inFile into outDir as single page PDFs.
+func ExtractFormXObjects(cmd *Command) ([]string, error) {
+	return nil, api.ExtractFormXObjectsFile(*cmd.InFile, *cmd.OutDir, cmd.PageSelection, cmd.Conf)
+}
+
 // ExtractMetadata dumps all metadata dict entries for inFile into outDir.
 func ExtractMetadata(cmd *Command) ([]string, error) {
 	return nil, api.ExtractMetadataFile(*cmd.InFile, *cmd.OutDir, cmd.Conf)
@@ -168,9 +230,39 @@ func ListAttachments(cmd *Command) ([]string, error) {
 	return ListAttachmentsFile(*cmd.InFile, cmd.Conf)
 }
 
+// VerifyAttachments verifies the checksums of embedded file attachments of inFile.
+func VerifyAttachments(cmd *Command) ([]string, error) {
+	acs, err := api.VerifyAttachmentsFile(*cmd.InFile, cmd.Conf)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(acs) == 0 {
+		return []string{"no attachments available"}, nil
+	}
+
+	ss, failed := []string{}, []string{}
+	for _, ac := range acs {
+		ss = append(ss, ac.String())
+		if ac.CheckSum && !ac.Valid {
+			failed = append(failed, ac.FileName)
+		}
+	}
+
+	if len(failed) > 0 {
+		return ss, errors.Errorf("pdfcpu: checksum mismatch: %s", strings.Join(failed, ", "))
+	}
+
+	return ss, nil
+}
+
 // AddAttachments embeds inFiles into a PDF context read from inFile and writes the result to outFile.
 func AddAttachments(cmd *Command) ([]string, error) {
-	return nil, api.AddAttachmentsFile(*cmd.InFile, *cmd.OutFile, cmd.InFiles, cmd.Mode == model.ADDATTACHMENTSPORTFOLIO, cmd.Conf)
+	coll := cmd.Mode == model.ADDATTACHMENTSPORTFOLIO
+	if cmd.InFileJSON != nil && *cmd.InFileJSON != "" {
+		return nil, api.AddAttachmentsJSONFile(*cmd.InFile, *cmd.InFileJSON, *cmd.OutFile, coll, cmd.Conf)
+	}
+	return nil, api.AddAttachmentsFile(*cmd.InFile, *cmd.OutFile, cmd.InFiles, coll, cmd.Conf)
 }
 
 // RemoveAttachments deletes inFiles from a PDF context read from inFile and writes the result to outFile.
@@ -218,6 +310,16 @@ func RemoveKeywords(cmd *Command) ([]string, error) {
 	return nil, api.RemoveKeywordsFile(*cmd.InFile, *cmd.OutFile, cmd.StringVals, cmd.Conf)
 }
 
+// ImportKeywords adds the keywords of inFileJSON to inFile's document info dict and writes the result to outFile.
+func ImportKeywords(cmd *Command) ([]string, error) {
+	return nil, api.ImportKeywordsFile(*cmd.InFile, *cmd.InFileJSON, *cmd.OutFile, cmd.BoolVal1, cmd.Conf)
+}
+
+// ExportKeywords writes inFile's keywords to outFileJSON.
+func ExportKeywords(cmd *Command) ([]string, error) {
+	return nil, api.ExportKeywordsFile(*cmd.InFile, *cmd.OutFileJSON, cmd.Conf)
+}
+
 // ListProperties returns inFile's properties.
 func ListProperties(cmd *Command) ([]string, error) {
 	return ListPropertiesFile(*cmd.InFile, cmd.Conf)
@@ -225,9 +327,17 @@ func ListProperties(cmd *Command) ([]string, error) {
 
 // AddProperties adds properties to inFile's document info dict and writes the result to outFile.
 func AddProperties(cmd *Command) ([]string, error) {
+	if cmd.InFileJSON != nil && *cmd.InFileJSON != "" {
+		return nil, api.AddPropertiesJSONFile(*cmd.InFile, *cmd.InFileJSON, *cmd.OutFile, cmd.Conf)
+	}
 	return nil, api.AddPropertiesFile(*cmd.InFile, *cmd.OutFile, cmd.StringMap, cmd.Conf)
 }
 
+// ExportProperties writes inFile's properties to outFileJSON.
+func ExportProperties(cmd *Command) ([]string, error) {
+	return nil, api.ExportPropertiesFile(*cmd.InFile, *cmd.OutFileJSON, cmd.Conf)
+}
+
 // RemoveProperties deletes properties from inFile's document info dict and writes the result to outFile.
 func RemoveProperties(cmd *Command) ([]string, error) {
 	return nil, api.RemovePropertiesFile(*cmd.InFile, *cmd.OutFile, cmd.StringVals, cmd.Conf)
@@ -260,16 +370,31 @@ func Crop(cmd *Command) ([]string, error) {
 
 // ListAnnotations returns inFile's page annotations.
 func ListAnnotations(cmd *Command) ([]string, error) {
-	_, ss, err := ListAnnotationsFile(*cmd.InFile, cmd.PageSelection, cmd.Conf)
+	_, ss, err := ListFilteredAnnotationsFile(*cmd.InFile, cmd.PageSelection, cmd.AnnotFilter, cmd.BoolVal1, cmd.Conf)
 	return ss, err
 }
 
+// AddAnnotations adds the annotations described by inFileJSON to inFile and writes the result to outFile.
+func AddAnnotations(cmd *Command) ([]string, error) {
+	return nil, api.AddAnnotationsJSONFile(*cmd.InFile, *cmd.InFileJSON, *cmd.OutFile, cmd.Conf)
+}
+
+// ExportAnnotations writes a summary of inFile's markup annotations including replies to outFile.
+func ExportAnnotations(cmd *Command) ([]string, error) {
+	return nil, api.ExportCommentsFile(*cmd.InFile, *cmd.OutFile, cmd.PageSelection, cmd.StringVal, cmd.Conf)
+}
+
 // RemoveAnnotations deletes annotations from inFile's page tree and writes the result to outFile.
 func RemoveAnnotations(cmd *Command) ([]string, error) {
 	incr := false // No incremental writing on cli.
 	return nil, api.RemoveAnnotationsFile(*cmd.InFile, *cmd.OutFile, cmd.PageSelection, cmd.StringVals, cmd.IntVals, cmd.Conf, incr)
 }
 
+// GenerateAnnotationAppearances synthesizes missing appearance streams of inFile's annotations and writes the result to outFile.
+func GenerateAnnotationAppearances(cmd *Command) ([]string, error) {
+	return nil, api.GenerateAnnotationAppearancesFile(*cmd.InFile, *cmd.OutFile, cmd.PageSelection, cmd.Conf)
+}
+
 // ListImages returns inFiles embedded images.
 func ListImages(cmd *Command) ([]string, error) {
 	return ListImagesFile(cmd.InFiles, cmd.PageSelection, cmd.Conf)
@@ -303,11 +428,17 @@ func Dump(cmd *Command) ([]string, error) {
 // Create renders page content corresponding to declarations found in inFileJSON and writes the result to outFile.
 // If inFile is present, page content will be appended,
 func Create(cmd *Command) ([]string, error) {
+	if cmd.StringVal != "" {
+		return nil, api.CreateFilesWithData(*cmd.InFile, *cmd.InFileJSON, cmd.StringVal, *cmd.OutFile, cmd.Conf)
+	}
 	return nil, api.CreateFile(*cmd.InFile, *cmd.InFileJSON, *cmd.OutFile, cmd.Conf)
 }
 
-// ListFormFields returns inFile's form field ids.
+// ListFormFields returns inFile's form field ids or their complete metadata as JSON.
 func ListFormFields(cmd *Command) ([]string, error) {
+	if cmd.BoolVal1 {
+		return ListFormFieldDetailsFiles(cmd.InFiles, cmd.Conf)
+	}
 	return ListFormFieldsFile(cmd.InFiles, cmd.Conf)
 }
 
@@ -336,11 +467,63 @@ func ExportFormFields(cmd *Command) ([]string, error) {
 	return nil, api.ExportFormFile(*cmd.InFile, *cmd.OutFileJSON, cmd.Conf)
 }
 
+// ExportFormFieldsCSV exports the form field values of inFiles into a single CSV file.
+func ExportFormFieldsCSV(cmd *Command) ([]string, error) {
+	return nil, api.ExportFormCSVFile(cmd.InFiles, *cmd.OutFile, cmd.Conf)
+}
+
 // FillFormFields fills out inFile's form using data represented by inFileJSON.
 func FillFormFields(cmd *Command) ([]string, error) {
 	return nil, api.FillFormFile(*cmd.InFile, *cmd.InFileJSON, *cmd.OutFile, cmd.Conf)
 }
 
+// AddSignatureField adds an empty signature field to inFile.
+func AddSignatureField(cmd *Command) ([]string, error) {
+	return nil, api.AddSignatureFieldFile(*cmd.InFile, *cmd.OutFile, cmd.IntVal, cmd.Box.Rect, cmd.StringVals[0], cmd.StringVals[1], cmd.Conf)
+}
+
+// PrepareSignature reserves space for a signature in a signature field of inFile for external signing.
+func PrepareSignature(cmd *Command) ([]string, error) {
+	if err := api.PrepareSignatureFile(*cmd.InFile, *cmd.OutFile, cmd.StringVals[0], cmd.IntVal, cmd.Conf); err != nil {
+		return nil, err
+	}
+
+	if cmd.StringVals[1] == "" {
+		return nil, nil
+	}
+
+	outFile := *cmd.OutFile
+	if outFile == "" {
+		outFile = *cmd.InFile
+	}
+
+	return nil, api.SignedContentFile(outFile, cmd.StringVals[1])
+}
+
+// EmbedSignature embeds an externally created CMS signature into inFile.
+func EmbedSignature(cmd *Command) ([]string, error) {
+	return nil, api.EmbedSignatureFile(*cmd.InFile, cmd.StringVal, *cmd.OutFile, cmd.Conf)
+}
+
+// AddValidationInfo embeds validation material into inFile making its signatures LTV enabled.
+func AddValidationInfo(cmd *Command) ([]string, error) {
+	vm, err := api.ParseValidationMaterialFiles(cmd.InFiles)
+	if err != nil {
+		return nil, err
+	}
+	return nil, api.AddValidationMaterialFile(*cmd.InFile, *cmd.OutFile, *vm, cmd.Conf)
+}
+
+// ListUsageRights returns the usage rights signatures of inFiles.
+func ListUsageRights(cmd *Command) ([]string, error) {
+	return ListUsageRightsFiles(cmd.InFiles, cmd.BoolVal1, cmd.Conf)
+}
+
+// RemoveUsageRights removes the usage rights signature of inFile.
+func RemoveUsageRights(cmd *Command) ([]string, error) {
+	return nil, api.RemoveUsageRightsFile(*cmd.InFile, *cmd.OutFile, cmd.Conf)
+}
+
 // MultiFillFormFields fills out multiple instances of inFile's form using JSON or CSV data.
 func MultiFillFormFields(cmd *Command) ([]string, error) {
 	return nil, api.MultiFillFormFile(*cmd.InFile, *cmd.InFileJSON, *cmd.OutDir, *cmd.OutFile, cmd.BoolVal1, cmd.Conf)
@@ -386,6 +569,26 @@ func RemoveBookmarks(cmd *Command) ([]string, error) {
 	return nil, api.RemoveBookmarksFile(*cmd.InFile, *cmd.OutFile, cmd.Conf)
 }
 
+// SortBookmarks sorts sibling bookmarks of inFile by title or destination page.
+func SortBookmarks(cmd *Command) ([]string, error) {
+	return nil, api.SortBookmarksFile(*cmd.InFile, *cmd.OutFile, cmd.BoolVal1, cmd.Conf)
+}
+
+// DedupBookmarks removes duplicate bookmarks of inFile.
+func DedupBookmarks(cmd *Command) ([]string, error) {
+	return nil, api.DedupBookmarksFile(*cmd.InFile, *cmd.OutFile, cmd.Conf)
+}
+
+// CollapseBookmarks moves bookmarks of inFile nested too deep up to a maximum level.
+func CollapseBookmarks(cmd *Command) ([]string, error) {
+	return nil, api.CollapseBookmarksFile(*cmd.InFile, *cmd.OutFile, cmd.IntVal, cmd.Conf)
+}
+
+// RetargetBookmarks points bookmarks of inFile to remaining pages.
+func RetargetBookmarks(cmd *Command) ([]string, error) {
+	return nil, api.RetargetBookmarksFile(*cmd.InFile, *cmd.OutFile, cmd.Conf)
+}
+
 // ListPageLayout returns inFile's page layout.
 func ListPageLayout(cmd *Command) ([]string, error) {
 	return api.ListPageLayoutFile(*cmd.InFile, cmd.Conf)
@@ -402,6 +605,36 @@ func ResetPageLayout(cmd *Command) ([]string, error) {
 	return nil, api.ResetPageLayoutFile(*cmd.InFile, *cmd.OutFile, cmd.Conf)
 }
 
+// ListLanguage returns inFile's natural language.
+func ListLanguage(cmd *Command) ([]string, error) {
+	return api.ListLanguageFile(*cmd.InFile, cmd.Conf)
+}
+
+// SetLanguage sets inFile's natural language.
+func SetLanguage(cmd *Command) ([]string, error) {
+	return nil, api.SetLanguageFile(*cmd.InFile, *cmd.OutFile, cmd.StringVal, cmd.Conf)
+}
+
+// ResetLanguage removes inFile's natural language.
+func ResetLanguage(cmd *Command) ([]string, error) {
+	return nil, api.ResetLanguageFile(*cmd.InFile, *cmd.OutFile, cmd.Conf)
+}
+
+// ListOutputIntents returns inFile's output intents.
+func ListOutputIntents(cmd *Command) ([]string, error) {
+	return api.ListOutputIntentsFile(*cmd.InFile, cmd.Conf)
+}
+
+// AddOutputIntent adds an output intent to inFile.
+func AddOutputIntent(cmd *Command) ([]string, error) {
+	return nil, api.AddOutputIntentFile(*cmd.InFile, *cmd.OutFile, cmd.StringVal, cmd.StringVals[0], cmd.StringVals[1], cmd.Conf)
+}
+
+// RemoveOutputIntents removes output intents from inFile.
+func RemoveOutputIntents(cmd *Command) ([]string, error) {
+	return nil, api.RemoveOutputIntentsFile(*cmd.InFile, *cmd.OutFile, cmd.StringVal, cmd.Conf)
+}
+
 // ListPageMode returns inFile's page mode.
 func ListPageMode(cmd *Command) ([]string, error) {
 	return api.ListPageModeFile(*cmd.InFile, cmd.Conf)
@@ -418,6 +651,61 @@ func ResetPageMode(cmd *Command) ([]string, error) {
 	return nil, api.ResetPageModeFile(*cmd.InFile, *cmd.OutFile, cmd.Conf)
 }
 
+// ListOpenAction returns inFile's open action, page mode and page layout.
+func ListOpenAction(cmd *Command) ([]string, error) {
+	return api.ListOpenActionFile(*cmd.InFile, cmd.Conf)
+}
+
+// SetOpenAction sets inFile's open action, page mode and page layout.
+func SetOpenAction(cmd *Command) ([]string, error) {
+	return nil, api.SetOpenActionFile(*cmd.InFile, *cmd.OutFile, *cmd.InitialView, cmd.Conf)
+}
+
+// ResetOpenAction removes inFile's open action, page mode and page layout.
+func ResetOpenAction(cmd *Command) ([]string, error) {
+	return nil, api.ResetOpenActionFile(*cmd.InFile, *cmd.OutFile, cmd.Conf)
+}
+
+// ListTransitions returns the page transitions of selected pages of inFile.
+func ListTransitions(cmd *Command) ([]string, error) {
+	return api.ListTransitionsFile(*cmd.InFile, cmd.PageSelection, cmd.Conf)
+}
+
+// SetTransitions applies a page transition to selected pages of inFile and writes the result to outFile.
+func SetTransitions(cmd *Command) ([]string, error) {
+	return nil, api.SetTransitionsFile(*cmd.InFile, *cmd.OutFile, cmd.PageSelection, cmd.Transition, cmd.Conf)
+}
+
+// RemoveTransitions removes page transitions from selected pages of inFile and writes the result to outFile.
+func RemoveTransitions(cmd *Command) ([]string, error) {
+	return nil, api.RemoveTransitionsFile(*cmd.InFile, *cmd.OutFile, cmd.PageSelection, cmd.Conf)
+}
+
+// ListGeo returns the georeferenced viewports of selected pages of inFile.
+func ListGeo(cmd *Command) ([]string, error) {
+	return api.ListGeoViewportsFile(*cmd.InFile, cmd.PageSelection, cmd.Conf)
+}
+
+// AddGeo adds the georeferenced viewports of inFileJSON to selected pages of inFile and writes the result to outFile.
+func AddGeo(cmd *Command) ([]string, error) {
+	return nil, api.AddGeoViewportsFile(*cmd.InFile, *cmd.InFileJSON, *cmd.OutFile, cmd.PageSelection, cmd.Conf)
+}
+
+// RemoveGeo removes the georeferenced viewports of selected pages of inFile and writes the result to outFile.
+func RemoveGeo(cmd *Command) ([]string, error) {
+	return nil, api.RemoveGeoViewportsFile(*cmd.InFile, *cmd.OutFile, cmd.PageSelection, cmd.Conf)
+}
+
+// ListMedia returns the 3D artwork, RichMedia assets, sounds and movies of selected pages of inFile.
+func ListMedia(cmd *Command) ([]string, error) {
+	return api.ListMediaFile(*cmd.InFile, cmd.PageSelection, cmd.Conf)
+}
+
+// ExtractMedia dumps the 3D artwork, RichMedia assets, sounds and movies of selected pages of inFile into outDir.
+func ExtractMedia(cmd *Command) ([]string, error) {
+	return nil, api.ExtractMediaFile(*cmd.InFile, *cmd.OutDir, cmd.PageSelection, cmd.Conf)
+}
+
 // ListViewerPreferences returns inFile's viewer preferences.
 func ListViewerPreferences(cmd *Command) ([]string, error) {
 	return api.ListViewerPreferencesFile(*cmd.InFile, cmd.BoolVal1, cmd.BoolVal2, cmd.Conf)
@@ -440,3 +728,80 @@ func ResetViewerPreferences(cmd *Command) ([]string, error) {
 func Zoom(cmd *Command) ([]string, error) {
 	return nil, api.ZoomFile(*cmd.InFile, *cmd.OutFile, cmd.PageSelection, cmd.Zoom, cmd.Conf)
 }
+
+// Overlay composites selected pages of inFile with the corresponding pages of an overlay file.
+func Overlay(cmd *Command) ([]string, error) {
+	return nil, api.OverlayFile(*cmd.InFile, cmd.InFiles[0], *cmd.OutFile, cmd.PageSelection, cmd.BoolVal1, cmd.IntVal, cmd.Conf)
+}
+
+// Batch processes the jobs of a JSON job file.
+func Batch(cmd *Command) ([]string, error) {
+	r, err := api.RunJobFile(*cmd.InFile, cmd.Conf)
+	if err != nil {
+		return nil, err
+	}
+	if r.Failed > 0 {
+		return nil, errors.Errorf("%s", r)
+	}
+	return strings.Split(r.String(), "\n"), nil
+}
+
+// Reflow exports the content of inFile as HTML or EPUB following its logical structure.
+func Reflow(cmd *Command) ([]string, error) {
+	return nil, api.ReflowFile(*cmd.InFile, *cmd.OutFile, cmd.StringVal, cmd.Conf)
+}
+
+// ApplyOCR adds the hOCR or ALTO results of an OCR file as invisible text to selected pages of inFile.
+func ApplyOCR(cmd *Command) ([]string, error) {
+	return nil, api.ApplyOCRFile(*cmd.InFile, cmd.InFiles[0], *cmd.OutFile, cmd.PageSelection, 0, cmd.Conf)
+}
+
+// ConvertPDFX fixes inFile for conformance with a PDF/X standard and logs the remaining issues.
+func ConvertPDFX(cmd *Command) ([]string, error) {
+	return nil, api.ConvertPDFXFile(*cmd.InFile, *cmd.OutFile, cmd.StringVals[0], cmd.StringVals[1], cmd.Conf)
+}
+
+// ListOverprint returns the overprint settings and trapping status of inFile.
+func ListOverprint(cmd *Command) ([]string, error) {
+	return api.ListOverprintFile(*cmd.InFile, cmd.Conf)
+}
+
+// SetOverprint sets overprint in the graphics states used by selected pages of inFile and its trapping status.
+func SetOverprint(cmd *Command) ([]string, error) {
+	return nil, api.SetOverprintFile(*cmd.InFile, *cmd.OutFile, cmd.PageSelection, cmd.Overprint, cmd.Conf)
+}
+
+// FixHairlines raises stroke widths below a threshold on selected pages of inFile.
+func FixHairlines(cmd *Command) ([]string, error) {
+	return nil, api.FixHairlinesFile(*cmd.InFile, *cmd.OutFile, cmd.PageSelection, cmd.Hairline, cmd.Conf)
+}
+
+// NormalizeContent pretty-prints the content of selected pages of inFile.
+func NormalizeContent(cmd *Command) ([]string, error) {
+	return nil, api.NormalizeContentFile(*cmd.InFile, *cmd.OutFile, cmd.PageSelection, cmd.BoolVal1, cmd.Conf)
+}
+
+// ExtractPageContent writes the pretty-printed content of a page of inFile to outFile.
+func ExtractPageContent(cmd *Command) ([]string, error) {
+	return nil, api.ExtractPageContentFile(*cmd.InFile, *cmd.OutFile, cmd.IntVal, cmd.Conf)
+}
+
+// ReplaceContent replaces the content of a page of inFile.
+func ReplaceContent(cmd *Command) ([]string, error) {
+	return nil, api.ReplacePageContentFile(*cmd.InFile, cmd.StringVal, *cmd.OutFile, cmd.IntVal, cmd.BoolVal1, cmd.Conf)
+}
+
+// ListTemplateRegions returns the placeholder regions of the template inFile.
+func ListTemplateRegions(cmd *Command) ([]string, error) {
+	return api.ListTemplateRegionsFile(*cmd.InFile, cmd.Conf)
+}
+
+// AddTemplateRegions adds a placeholder region to the template inFile.
+func AddTemplateRegions(cmd *Command) ([]string, error) {
+	return nil, api.AddTemplateRegionsFile(*cmd.InFile, *cmd.OutFile, []pdfcpu.TemplateRegion{*cmd.TemplateRegion}, cmd.Conf)
+}
+
+// FillTemplate fills the placeholder regions of the template inFile as described by inFileJSON.
+func FillTemplate(cmd *Command) ([]string, error) {
+	return nil, api.FillTemplateFile(*cmd.InFile, *cmd.InFileJSON, *cmd.OutFile, cmd.Conf)
+}
@@ -54,92 +54,155 @@ type Command struct {
 	Resize            *model.Resize
 	Zoom              *model.Zoom
 	Watermark         *model.Watermark
+	PageNumbers       *model.PageNumbers
 	ViewerPreferences *model.ViewerPreferences
+	InitialView       *pdfcpu.InitialView
+	Transition        *model.Transition
 	PageConf          *pdfcpu.PageConfiguration
+	AnnotFilter       *pdfcpu.AnnotationFilter
+	Overprint         *pdfcpu.OverprintConfig
+	Hairline          *pdfcpu.HairlineConfig
+	TemplateRegion    *pdfcpu.TemplateRegion
 	Conf              *model.Configuration
 }
 
 var cmdMap = map[model.CommandMode]func(cmd *Command) ([]string, error){
-	model.VALIDATE:                Validate,
-	model.OPTIMIZE:                Optimize,
-	model.SPLIT:                   Split,
-	model.SPLITBYPAGENR:           SplitByPageNr,
-	model.MERGECREATE:             MergeCreate,
-	model.MERGECREATEZIP:          MergeCreateZip,
-	model.MERGEAPPEND:             MergeAppend,
-	model.EXTRACTIMAGES:           ExtractImages,
-	model.EXTRACTFONTS:            ExtractFonts,
-	model.EXTRACTPAGES:            ExtractPages,
-	model.EXTRACTCONTENT:          ExtractContent,
-	model.EXTRACTMETADATA:         ExtractMetadata,
-	model.TRIM:                    Trim,
-	model.ADDWATERMARKS:           AddWatermarks,
-	model.REMOVEWATERMARKS:        RemoveWatermarks,
-	model.LISTATTACHMENTS:         processAttachments,
-	model.ADDATTACHMENTS:          processAttachments,
-	model.ADDATTACHMENTSPORTFOLIO: processAttachments,
-	model.REMOVEATTACHMENTS:       processAttachments,
-	model.EXTRACTATTACHMENTS:      processAttachments,
-	model.ENCRYPT:                 processEncryption,
-	model.DECRYPT:                 processEncryption,
-	model.CHANGEUPW:               processEncryption,
-	model.CHANGEOPW:               processEncryption,
-	model.LISTPERMISSIONS:         processPermissions,
-	model.SETPERMISSIONS:          processPermissions,
-	model.IMPORTIMAGES:            ImportImages,
-	model.INSERTPAGESBEFORE:       processPages,
-	model.INSERTPAGESAFTER:        processPages,
-	model.REMOVEPAGES:             processPages,
-	model.ROTATE:                  Rotate,
-	model.NUP:                     NUp,
-	model.BOOKLET:                 Booklet,
-	model.LISTINFO:                ListInfo,
-	model.CHEATSHEETSFONTS:        CreateCheatSheetsFonts,
-	model.INSTALLFONTS:            InstallFonts,
-	model.LISTFONTS:               ListFonts,
-	model.LISTKEYWORDS:            processKeywords,
-	model.ADDKEYWORDS:             processKeywords,
-	model.REMOVEKEYWORDS:          processKeywords,
-	model.LISTPROPERTIES:          processProperties,
-	model.ADDPROPERTIES:           processProperties,
-	model.REMOVEPROPERTIES:        processProperties,
-	model.COLLECT:                 Collect,
-	model.LISTBOXES:               processPageBoundaries,
-	model.ADDBOXES:                processPageBoundaries,
-	model.REMOVEBOXES:             processPageBoundaries,
-	model.CROP:                    processPageBoundaries,
-	model.LISTANNOTATIONS:         processPageAnnotations,
-	model.REMOVEANNOTATIONS:       processPageAnnotations,
-	model.LISTIMAGES:              processImages,
-	model.UPDATEIMAGES:            processImages,
-	model.DUMP:                    Dump,
-	model.CREATE:                  Create,
-	model.LISTFORMFIELDS:          processForm,
-	model.REMOVEFORMFIELDS:        processForm,
-	model.LOCKFORMFIELDS:          processForm,
-	model.UNLOCKFORMFIELDS:        processForm,
-	model.RESETFORMFIELDS:         processForm,
-	model.EXPORTFORMFIELDS:        processForm,
-	model.FILLFORMFIELDS:          processForm,
-	model.MULTIFILLFORMFIELDS:     processForm,
-	model.RESIZE:                  Resize,
-	model.POSTER:                  Poster,
-	model.NDOWN:                   NDown,
-	model.CUT:                     Cut,
-	model.LISTBOOKMARKS:           processBookmarks,
-	model.EXPORTBOOKMARKS:         processBookmarks,
-	model.IMPORTBOOKMARKS:         processBookmarks,
-	model.REMOVEBOOKMARKS:         processBookmarks,
-	model.LISTPAGEMODE:            processPageMode,
-	model.SETPAGEMODE:             processPageMode,
-	model.RESETPAGEMODE:           processPageMode,
-	model.LISTPAGELAYOUT:          processPageLayout,
-	model.SETPAGELAYOUT:           processPageLayout,
-	model.RESETPAGELAYOUT:         processPageLayout,
-	model.LISTVIEWERPREFERENCES:   processViewerPreferences,
-	model.SETVIEWERPREFERENCES:    processViewerPreferences,
-	model.RESETVIEWERPREFERENCES:  processViewerPreferences,
-	model.ZOOM:                    Zoom,
+	model.VALIDATE:                 Validate,
+	model.OPTIMIZE:                 Optimize,
+	model.TRIAGE:                   Triage,
+	model.SPLIT:                    Split,
+	model.SPLITBYPAGENR:            SplitByPageNr,
+	model.SPLITBYBOOKMARK:          SplitByBookmark,
+	model.BATCH:                    Batch,
+	model.MERGECREATE:              MergeCreate,
+	model.MERGECREATEZIP:           MergeCreateZip,
+	model.MERGEAPPEND:              MergeAppend,
+	model.EXTRACTIMAGES:            ExtractImages,
+	model.EXTRACTFONTS:             ExtractFonts,
+	model.EXTRACTPAGES:             ExtractPages,
+	model.EXTRACTCONTENT:           ExtractContent,
+	model.EXTRACTMETADATA:          ExtractMetadata,
+	model.TRIM:                     Trim,
+	model.ADDWATERMARKS:            AddWatermarks,
+	model.ADDPAGENUMBERS:           AddPageNumbers,
+	model.REMOVEWATERMARKS:         RemoveWatermarks,
+	model.LISTATTACHMENTS:          processAttachments,
+	model.ADDATTACHMENTS:           processAttachments,
+	model.ADDATTACHMENTSPORTFOLIO:  processAttachments,
+	model.REMOVEATTACHMENTS:        processAttachments,
+	model.EXTRACTATTACHMENTS:       processAttachments,
+	model.VERIFYATTACHMENTS:        processAttachments,
+	model.FLATTENPAGEATTRS:         processPages,
+	model.ENCRYPT:                  processEncryption,
+	model.DECRYPT:                  processEncryption,
+	model.CHANGEUPW:                processEncryption,
+	model.CHANGEOPW:                processEncryption,
+	model.LISTENCRYPTION:           processEncryption,
+	model.UPDATEENCRYPTION:         processEncryption,
+	model.LISTPERMISSIONS:          processPermissions,
+	model.SETPERMISSIONS:           processPermissions,
+	model.IMPORTIMAGES:             ImportImages,
+	model.INSERTPAGESBEFORE:        processPages,
+	model.INSERTPAGESAFTER:         processPages,
+	model.REMOVEPAGES:              processPages,
+	model.ROTATE:                   Rotate,
+	model.NUP:                      NUp,
+	model.BOOKLET:                  Booklet,
+	model.LISTINFO:                 ListInfo,
+	model.CHEATSHEETSFONTS:         CreateCheatSheetsFonts,
+	model.INSTALLFONTS:             InstallFonts,
+	model.LISTFONTS:                ListFonts,
+	model.LISTKEYWORDS:             processKeywords,
+	model.ADDKEYWORDS:              processKeywords,
+	model.REMOVEKEYWORDS:           processKeywords,
+	model.IMPORTKEYWORDS:           processKeywords,
+	model.EXPORTKEYWORDS:           processKeywords,
+	model.LISTPROPERTIES:           processProperties,
+	model.ADDPROPERTIES:            processProperties,
+	model.REMOVEPROPERTIES:         processProperties,
+	model.EXPORTPROPERTIES:         processProperties,
+	model.COLLECT:                  Collect,
+	model.LISTBOXES:                processPageBoundaries,
+	model.ADDBOXES:                 processPageBoundaries,
+	model.REMOVEBOXES:              processPageBoundaries,
+	model.CROP:                     processPageBoundaries,
+	model.LISTANNOTATIONS:          processPageAnnotations,
+	model.ADDANNOTATIONS:           processPageAnnotations,
+	model.REMOVEANNOTATIONS:        processPageAnnotations,
+	model.EXPORTANNOTATIONS:        processPageAnnotations,
+	model.GENERATEANNOTAPPEARANCES: processPageAnnotations,
+	model.LISTIMAGES:               processImages,
+	model.UPDATEIMAGES:             processImages,
+	model.DUMP:                     Dump,
+	model.CREATE:                   Create,
+	model.LISTFORMFIELDS:           processForm,
+	model.REMOVEFORMFIELDS:         processForm,
+	model.LOCKFORMFIELDS:           processForm,
+	model.UNLOCKFORMFIELDS:         processForm,
+	model.RESETFORMFIELDS:          processForm,
+	model.EXPORTFORMFIELDS:         processForm,
+	model.EXPORTFORMFIELDSCSV:      processForm,
+	model.FILLFORMFIELDS:           processForm,
+	model.MULTIFILLFORMFIELDS:      processForm,
+	model.ADDSIGNATUREFIELD:        processForm,
+	model.PREPARESIGNATURE:         processForm,
+	model.EMBEDSIGNATURE:           processForm,
+	model.ADDVALIDATIONINFO:        processSignatures,
+	model.LISTUSAGERIGHTS:          processSignatures,
+	model.REMOVEUSAGERIGHTS:        processSignatures,
+	model.RESIZE:                   Resize,
+	model.POSTER:                   Poster,
+	model.NDOWN:                    NDown,
+	model.CUT:                      Cut,
+	model.LISTBOOKMARKS:            processBookmarks,
+	model.EXPORTBOOKMARKS:          processBookmarks,
+	model.IMPORTBOOKMARKS:          processBookmarks,
+	model.REMOVEBOOKMARKS:          processBookmarks,
+	model.SORTBOOKMARKS:            processBookmarks,
+	model.DEDUPBOOKMARKS:           processBookmarks,
+	model.COLLAPSEBOOKMARKS:        processBookmarks,
+	model.RETARGETBOOKMARKS:        processBookmarks,
+	model.LISTPAGEMODE:             processPageMode,
+	model.SETPAGEMODE:              processPageMode,
+	model.RESETPAGEMODE:            processPageMode,
+	model.LISTOPENACTION:           processOpenAction,
+	model.SETOPENACTION:            processOpenAction,
+	model.RESETOPENACTION:          processOpenAction,
+	model.LISTTRANSITIONS:          processTransitions,
+	model.SETTRANSITIONS:           processTransitions,
+	model.REMOVETRANSITIONS:        processTransitions,
+	model.LISTGEO:                  processGeo,
+	model.ADDGEO:                   processGeo,
+	model.REMOVEGEO:                processGeo,
+	model.LISTMEDIA:                processMedia,
+	model.EXTRACTMEDIA:             processMedia,
+	model.LISTPAGELAYOUT:           processPageLayout,
+	model.SETPAGELAYOUT:            processPageLayout,
+	model.RESETPAGELAYOUT:          processPageLayout,
+	model.LISTLANGUAGE:             processLanguage,
+	model.SETLANGUAGE:              processLanguage,
+	model.RESETLANGUAGE:            processLanguage,
+	model.LISTOUTPUTINTENTS:        processOutputIntents,
+	model.ADDOUTPUTINTENT:          processOutputIntents,
+	model.REMOVEOUTPUTINTENTS:      processOutputIntents,
+	model.LISTVIEWERPREFERENCES:    processViewerPreferences,
+	model.SETVIEWERPREFERENCES:     processViewerPreferences,
+	model.RESETVIEWERPREFERENCES:   processViewerPreferences,
+	model.ZOOM:                     Zoom,
+	model.OVERLAY:                  Overlay,
+	model.REFLOW:                   Reflow,
+	model.ADDTEXTLAYER:             ApplyOCR,
+	model.CONVERTPDFX:              ConvertPDFX,
+	model.LISTOVERPRINT:            ListOverprint,
+	model.SETOVERPRINT:             SetOverprint,
+	model.FIXHAIRLINES:             FixHairlines,
+	model.NORMALIZECONTENT:         NormalizeContent,
+	model.EXTRACTPAGECONTENT:       ExtractPageContent,
+	model.REPLACECONTENT:           ReplaceContent,
+	model.EXTRACTFORMXOBJECTS:      ExtractFormXObjects,
+	model.LISTTEMPLATEREGIONS:      ListTemplateRegions,
+	model.ADDTEMPLATEREGIONS:       AddTemplateRegions,
+	model.FILLTEMPLATE:             FillTemplate,
 }
 
 // ValidateCommand creates a new command to validate a file.
@@ -154,6 +217,19 @@ func ValidateCommand(inFiles []string, conf *model.Configuration) *Command {
 		Conf:    conf}
 }
 
+// TriageCommand creates a new command to classify the processing failure of a file.
+func TriageCommand(inFile string, json bool, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.TRIAGE
+	return &Command{
+		Mode:     model.TRIAGE,
+		InFile:   &inFile,
+		BoolVal1: json,
+		Conf:     conf}
+}
+
 // OptimizeCommand creates a new command to optimize a file.
 func OptimizeCommand(inFile, outFile string, conf *model.Configuration) *Command {
 	if conf == nil {
@@ -195,6 +271,34 @@ func SplitByPageNrCommand(inFile, dirNameOut string, pageNrs []int, conf *model.
 		Conf:    conf}
 }
 
+// SplitByPageSelectionCommand creates a new command to split a file into files before each selected page.
+func SplitByPageSelectionCommand(inFile, dirNameOut string, pageSelection []string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.SPLITBYPAGENR
+	return &Command{
+		Mode:          model.SPLITBYPAGENR,
+		InFile:        &inFile,
+		OutDir:        &dirNameOut,
+		PageSelection: pageSelection,
+		Conf:          conf}
+}
+
+// SplitByBookmarkCommand creates a new command to split a file into files along bookmarks up to level.
+func SplitByBookmarkCommand(inFile, dirNameOut string, level int, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.SPLITBYBOOKMARK
+	return &Command{
+		Mode:   model.SPLITBYBOOKMARK,
+		InFile: &inFile,
+		OutDir: &dirNameOut,
+		IntVal: level,
+		Conf:   conf}
+}
+
 // MergeCreateCommand creates a new command to merge files.
 // Outfile will be created. An existing outFile will be overwritten.
 func MergeCreateCommand(inFiles []string, outFile string, dividerPage bool, conf *model.Configuration) *Command {
@@ -297,6 +401,20 @@ func ExtractContentCommand(inFile string, outDir string, pageSelection []string,
 		Conf:          conf}
 }
 
+// ExtractFormXObjectsCommand creates a new command to extract form XObjects as single page PDFs.
+func ExtractFormXObjectsCommand(inFile string, outDir string, pageSelection []string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.EXTRACTFORMXOBJECTS
+	return &Command{
+		Mode:          model.EXTRACTFORMXOBJECTS,
+		InFile:        &inFile,
+		OutDir:        &outDir,
+		PageSelection: pageSelection,
+		Conf:          conf}
+}
+
 // ExtractMetadataCommand creates a new command to extract metadata streams.
 func ExtractMetadataCommand(inFile string, outDir string, conf *model.Configuration) *Command {
 	if conf == nil {
@@ -336,6 +454,18 @@ func ListAttachmentsCommand(inFile string, conf *model.Configuration) *Command {
 		Conf:   conf}
 }
 
+// VerifyAttachmentsCommand creates a new command to verify attachment checksums.
+func VerifyAttachmentsCommand(inFile string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.VERIFYATTACHMENTS
+	return &Command{
+		Mode:   model.VERIFYATTACHMENTS,
+		InFile: &inFile,
+		Conf:   conf}
+}
+
 // AddAttachmentsCommand creates a new command to add attachments.
 func AddAttachmentsCommand(inFile, outFile string, fileNames []string, conf *model.Configuration) *Command {
 	if conf == nil {
@@ -350,6 +480,24 @@ func AddAttachmentsCommand(inFile, outFile string, fileNames []string, conf *mod
 		Conf:    conf}
 }
 
+// AddAttachmentsJSONCommand creates a new command to add attachments described by a JSON manifest.
+func AddAttachmentsJSONCommand(inFile, inFileJSON, outFile string, coll bool, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	mode := model.ADDATTACHMENTS
+	if coll {
+		mode = model.ADDATTACHMENTSPORTFOLIO
+	}
+	conf.Cmd = mode
+	return &Command{
+		Mode:       mode,
+		InFile:     &inFile,
+		InFileJSON: &inFileJSON,
+		OutFile:    &outFile,
+		Conf:       conf}
+}
+
 // AddAttachmentsPortfolioCommand creates a new command to add attachments to a portfolio.
 func AddAttachmentsPortfolioCommand(inFile, outFile string, fileNames []string, conf *model.Configuration) *Command {
 	if conf == nil {
@@ -405,6 +553,32 @@ func EncryptCommand(inFile, outFile string, conf *model.Configuration) *Command
 		Conf:    conf}
 }
 
+// ListEncryptionCommand creates a new command to list encryption details.
+func ListEncryptionCommand(inFiles []string, json bool, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.LISTENCRYPTION
+	return &Command{
+		Mode:     model.LISTENCRYPTION,
+		InFiles:  inFiles,
+		BoolVal1: json,
+		Conf:     conf}
+}
+
+// UpdateEncryptionCommand creates a new command to re-encrypt a file using new encryption parameters.
+func UpdateEncryptionCommand(inFile, outFile string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.UPDATEENCRYPTION
+	return &Command{
+		Mode:    model.UPDATEENCRYPTION,
+		InFile:  &inFile,
+		OutFile: &outFile,
+		Conf:    conf}
+}
+
 // DecryptCommand creates a new command to decrypt a file.
 func DecryptCommand(inFile, outFile string, conf *model.Configuration) *Command {
 	if conf == nil {
@@ -488,6 +662,36 @@ func AddWatermarksCommand(inFile, outFile string, pageSelection []string, wm *mo
 		Conf:          conf}
 }
 
+// AddWatermarksJSONCommand creates a new command to add the watermarks of a JSON watermark map to a file.
+func AddWatermarksJSONCommand(inFile, inFileJSON, outFile string, onTop bool, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.ADDWATERMARKS
+	return &Command{
+		Mode:       model.ADDWATERMARKS,
+		InFile:     &inFile,
+		InFileJSON: &inFileJSON,
+		OutFile:    &outFile,
+		BoolVal1:   onTop,
+		Conf:       conf}
+}
+
+// AddPageNumbersCommand creates a new command to stamp page numbers onto a file.
+func AddPageNumbersCommand(inFile, outFile string, pageSelection []string, pn *model.PageNumbers, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.ADDPAGENUMBERS
+	return &Command{
+		Mode:          model.ADDPAGENUMBERS,
+		InFile:        &inFile,
+		OutFile:       &outFile,
+		PageSelection: pageSelection,
+		PageNumbers:   pn,
+		Conf:          conf}
+}
+
 // RemoveWatermarksCommand creates a new command to remove Watermarks from a file.
 func RemoveWatermarksCommand(inFile, outFile string, pageSelection []string, conf *model.Configuration) *Command {
 	if conf == nil {
@@ -502,6 +706,13 @@ func RemoveWatermarksCommand(inFile, outFile string, pageSelection []string, con
 		Conf:          conf}
 }
 
+// RemoveWatermarksByIDCommand creates a new command to remove Watermarks identified by id from a file.
+func RemoveWatermarksByIDCommand(inFile, outFile string, pageSelection []string, id string, conf *model.Configuration) *Command {
+	cmd := RemoveWatermarksCommand(inFile, outFile, pageSelection, conf)
+	cmd.StringVal = id
+	return cmd
+}
+
 // ImportImagesCommand creates a new command to import images.
 func ImportImagesCommand(imageFiles []string, outFile string, imp *pdfcpu.Import, conf *model.Configuration) *Command {
 	if conf == nil {
@@ -549,6 +760,19 @@ func RemovePagesCommand(inFile, outFile string, pageSelection []string, conf *mo
 		Conf:          conf}
 }
 
+// FlattenPageAttrsCommand creates a new command to push inherited page attributes down into each page.
+func FlattenPageAttrsCommand(inFile, outFile string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.FLATTENPAGEATTRS
+	return &Command{
+		Mode:    model.FLATTENPAGEATTRS,
+		InFile:  &inFile,
+		OutFile: &outFile,
+		Conf:    conf}
+}
+
 // RotateCommand creates a new command to rotate pages.
 func RotateCommand(inFile, outFile string, rotation int, pageSelection []string, conf *model.Configuration) *Command {
 	if conf == nil {
@@ -684,6 +908,34 @@ func RemoveKeywordsCommand(inFile, outFile string, keywords []string, conf *mode
 		Conf:       conf}
 }
 
+// ImportKeywordsCommand creates a new command to add the keywords of inFileJSON.
+func ImportKeywordsCommand(inFile, inFileJSON, outFile string, replace bool, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.IMPORTKEYWORDS
+	return &Command{
+		Mode:       model.IMPORTKEYWORDS,
+		InFile:     &inFile,
+		InFileJSON: &inFileJSON,
+		OutFile:    &outFile,
+		BoolVal1:   replace,
+		Conf:       conf}
+}
+
+// ExportKeywordsCommand creates a new command to export keywords as JSON.
+func ExportKeywordsCommand(inFile, outFileJSON string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.EXPORTKEYWORDS
+	return &Command{
+		Mode:        model.EXPORTKEYWORDS,
+		InFile:      &inFile,
+		OutFileJSON: &outFileJSON,
+		Conf:        conf}
+}
+
 // ListPropertiesCommand creates a new command to list document properties.
 func ListPropertiesCommand(inFile string, conf *model.Configuration) *Command {
 	if conf == nil {
@@ -710,6 +962,33 @@ func AddPropertiesCommand(inFile, outFile string, properties map[string]string,
 		Conf:      conf}
 }
 
+// AddPropertiesJSONCommand creates a new command to add the document properties of inFileJSON.
+func AddPropertiesJSONCommand(inFile, inFileJSON, outFile string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.ADDPROPERTIES
+	return &Command{
+		Mode:       model.ADDPROPERTIES,
+		InFile:     &inFile,
+		InFileJSON: &inFileJSON,
+		OutFile:    &outFile,
+		Conf:       conf}
+}
+
+// ExportPropertiesCommand creates a new command to export document properties as JSON.
+func ExportPropertiesCommand(inFile, outFileJSON string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.EXPORTPROPERTIES
+	return &Command{
+		Mode:        model.EXPORTPROPERTIES,
+		InFile:      &inFile,
+		OutFileJSON: &outFileJSON,
+		Conf:        conf}
+}
+
 // RemovePropertiesCommand creates a new command to remove document properties.
 func RemovePropertiesCommand(inFile, outFile string, propKeys []string, conf *model.Configuration) *Command {
 	if conf == nil {
@@ -799,6 +1078,11 @@ func CropCommand(inFile, outFile string, pageSelection []string, box *model.Box,
 
 // ListAnnotationsCommand creates a new command to list annotations for selected pages.
 func ListAnnotationsCommand(inFile string, pageSelection []string, conf *model.Configuration) *Command {
+	return ListFilteredAnnotationsCommand(inFile, pageSelection, nil, false, conf)
+}
+
+// ListFilteredAnnotationsCommand creates a new command to list annotations for selected pages matching filter.
+func ListFilteredAnnotationsCommand(inFile string, pageSelection []string, filter *pdfcpu.AnnotationFilter, json bool, conf *model.Configuration) *Command {
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
 	}
@@ -807,6 +1091,37 @@ func ListAnnotationsCommand(inFile string, pageSelection []string, conf *model.C
 		Mode:          model.LISTANNOTATIONS,
 		InFile:        &inFile,
 		PageSelection: pageSelection,
+		AnnotFilter:   filter,
+		BoolVal1:      json,
+		Conf:          conf}
+}
+
+// AddAnnotationsCommand creates a new command to add annotations described by JSON.
+func AddAnnotationsCommand(inFile, inFileJSON, outFile string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.ADDANNOTATIONS
+	return &Command{
+		Mode:       model.ADDANNOTATIONS,
+		InFile:     &inFile,
+		InFileJSON: &inFileJSON,
+		OutFile:    &outFile,
+		Conf:       conf}
+}
+
+// ExportAnnotationsCommand creates a new command to export a summary of markup annotations for selected pages.
+func ExportAnnotationsCommand(inFile, outFile string, pageSelection []string, format string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.EXPORTANNOTATIONS
+	return &Command{
+		Mode:          model.EXPORTANNOTATIONS,
+		InFile:        &inFile,
+		OutFile:       &outFile,
+		PageSelection: pageSelection,
+		StringVal:     format,
 		Conf:          conf}
 }
 
@@ -826,6 +1141,20 @@ func RemoveAnnotationsCommand(inFile, outFile string, pageSelection []string, id
 		Conf:          conf}
 }
 
+// GenerateAnnotationAppearancesCommand creates a new command to generate missing annotation appearance streams for selected pages.
+func GenerateAnnotationAppearancesCommand(inFile, outFile string, pageSelection []string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.GENERATEANNOTAPPEARANCES
+	return &Command{
+		Mode:          model.GENERATEANNOTAPPEARANCES,
+		InFile:        &inFile,
+		OutFile:       &outFile,
+		PageSelection: pageSelection,
+		Conf:          conf}
+}
+
 // ListImagesCommand creates a new command to list annotations for selected pages.
 func ListImagesCommand(inFiles []string, pageSelection []string, conf *model.Configuration) *Command {
 	if conf == nil {
@@ -882,16 +1211,32 @@ func CreateCommand(inFilePDF, inFileJSON, outFilePDF string, conf *model.Configu
 		Conf:       conf}
 }
 
-// ListFormFieldsCommand creates a new command to list the field ids from a PDF form.
-func ListFormFieldsCommand(inFiles []string, conf *model.Configuration) *Command {
+// CreateWithDataCommand creates a new command to create PDF content from a JSON template for each data record of inFileData.
+func CreateWithDataCommand(inFilePDF, inFileJSON, inFileData, outFilePDF string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.CREATE
+	return &Command{
+		Mode:       model.CREATE,
+		InFile:     &inFilePDF,
+		InFileJSON: &inFileJSON,
+		OutFile:    &outFilePDF,
+		StringVal:  inFileData,
+		Conf:       conf}
+}
+
+// ListFormFieldsCommand creates a new command to list the fields of a PDF form, optionally with full metadata as JSON.
+func ListFormFieldsCommand(inFiles []string, json bool, conf *model.Configuration) *Command {
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
 	}
 	conf.Cmd = model.LISTFORMFIELDS
 	return &Command{
-		Mode:    model.LISTFORMFIELDS,
-		InFiles: inFiles,
-		Conf:    conf}
+		Mode:     model.LISTFORMFIELDS,
+		InFiles:  inFiles,
+		BoolVal1: json,
+		Conf:     conf}
 }
 
 // RemoveFormFieldsCommand creates a new command to remove fields from a PDF form.
@@ -963,6 +1308,19 @@ func ExportFormCommand(inFilePDF, outFileJSON string, conf *model.Configuration)
 		Conf:        conf}
 }
 
+// ExportFormCSVCommand creates a new command to export the form field values of inFiles as CSV.
+func ExportFormCSVCommand(inFiles []string, outFileCSV string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.EXPORTFORMFIELDSCSV
+	return &Command{
+		Mode:    model.EXPORTFORMFIELDSCSV,
+		InFiles: inFiles,
+		OutFile: &outFileCSV,
+		Conf:    conf}
+}
+
 // FillFormCommand creates a new command to fill a PDF form with data.
 func FillFormCommand(inFilePDF, inFileJSON, outFilePDF string, conf *model.Configuration) *Command {
 	if conf == nil {
@@ -977,6 +1335,92 @@ func FillFormCommand(inFilePDF, inFileJSON, outFilePDF string, conf *model.Confi
 		Conf:       conf}
 }
 
+// AddSignatureFieldCommand creates a new command to add an empty signature field to a page.
+func AddSignatureFieldCommand(inFile, outFile string, pageNr int, box *model.Box, fieldName, label string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.ADDSIGNATUREFIELD
+	return &Command{
+		Mode:       model.ADDSIGNATUREFIELD,
+		InFile:     &inFile,
+		OutFile:    &outFile,
+		IntVal:     pageNr,
+		Box:        box,
+		StringVals: []string{fieldName, label},
+		Conf:       conf}
+}
+
+// PrepareSignatureCommand creates a new command to reserve space for a signature in a signature field
+// and to optionally write the content to be signed to outFileContent.
+func PrepareSignatureCommand(inFile, outFile, fieldName, outFileContent string, size int, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.PREPARESIGNATURE
+	return &Command{
+		Mode:       model.PREPARESIGNATURE,
+		InFile:     &inFile,
+		OutFile:    &outFile,
+		IntVal:     size,
+		StringVals: []string{fieldName, outFileContent},
+		Conf:       conf}
+}
+
+// EmbedSignatureCommand creates a new command to embed an externally created CMS signature.
+func EmbedSignatureCommand(inFile, inFileCMS, outFile string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.EMBEDSIGNATURE
+	return &Command{
+		Mode:      model.EMBEDSIGNATURE,
+		InFile:    &inFile,
+		OutFile:   &outFile,
+		StringVal: inFileCMS,
+		Conf:      conf}
+}
+
+// AddValidationInfoCommand creates a new command to embed validation material for signatures.
+func AddValidationInfoCommand(inFile, outFile string, files []string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.ADDVALIDATIONINFO
+	return &Command{
+		Mode:    model.ADDVALIDATIONINFO,
+		InFile:  &inFile,
+		OutFile: &outFile,
+		InFiles: files,
+		Conf:    conf}
+}
+
+// ListUsageRightsCommand creates a new command to list usage rights signatures.
+func ListUsageRightsCommand(inFiles []string, json bool, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.LISTUSAGERIGHTS
+	return &Command{
+		Mode:     model.LISTUSAGERIGHTS,
+		InFiles:  inFiles,
+		BoolVal1: json,
+		Conf:     conf}
+}
+
+// RemoveUsageRightsCommand creates a new command to remove a usage rights signature.
+func RemoveUsageRightsCommand(inFile, outFile string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.REMOVEUSAGERIGHTS
+	return &Command{
+		Mode:    model.REMOVEUSAGERIGHTS,
+		InFile:  &inFile,
+		OutFile: &outFile,
+		Conf:    conf}
+}
+
 // MultiFillFormCommand creates a new command to fill multiple PDF forms with JSON or CSV data.
 func MultiFillFormCommand(inFilePDF, inFileData, outDir, outFilePDF string, merge bool, conf *model.Configuration) *Command {
 	if conf == nil {
@@ -1110,8 +1554,62 @@ func RemoveBookmarksCommand(inFile, outFile string, conf *model.Configuration) *
 		Conf:    conf}
 }
 
-// ListPageLayoutCommand creates a new command to list the document page layout.
-func ListPageLayoutCommand(inFile string, conf *model.Configuration) *Command {
+// SortBookmarksCommand creates a new command to sort sibling bookmarks by title or destination page.
+func SortBookmarksCommand(inFile, outFile string, byPage bool, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.SORTBOOKMARKS
+	return &Command{
+		Mode:     model.SORTBOOKMARKS,
+		InFile:   &inFile,
+		OutFile:  &outFile,
+		BoolVal1: byPage,
+		Conf:     conf}
+}
+
+// DedupBookmarksCommand creates a new command to remove duplicate bookmarks.
+func DedupBookmarksCommand(inFile, outFile string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.DEDUPBOOKMARKS
+	return &Command{
+		Mode:    model.DEDUPBOOKMARKS,
+		InFile:  &inFile,
+		OutFile: &outFile,
+		Conf:    conf}
+}
+
+// CollapseBookmarksCommand creates a new command to move bookmarks nested deeper than maxLevel up to maxLevel.
+func CollapseBookmarksCommand(inFile, outFile string, maxLevel int, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.COLLAPSEBOOKMARKS
+	return &Command{
+		Mode:    model.COLLAPSEBOOKMARKS,
+		InFile:  &inFile,
+		OutFile: &outFile,
+		IntVal:  maxLevel,
+		Conf:    conf}
+}
+
+// RetargetBookmarksCommand creates a new command to retarget bookmarks pointing to removed pages.
+func RetargetBookmarksCommand(inFile, outFile string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.RETARGETBOOKMARKS
+	return &Command{
+		Mode:    model.RETARGETBOOKMARKS,
+		InFile:  &inFile,
+		OutFile: &outFile,
+		Conf:    conf}
+}
+
+// ListPageLayoutCommand creates a new command to list the document page layout.
+func ListPageLayoutCommand(inFile string, conf *model.Configuration) *Command {
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
 	}
@@ -1149,6 +1647,86 @@ func ResetPageLayoutCommand(inFile, outFile string, conf *model.Configuration) *
 		Conf:    conf}
 }
 
+// ListLanguageCommand creates a new command to list the document language.
+func ListLanguageCommand(inFile string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.LISTLANGUAGE
+	return &Command{
+		Mode:   model.LISTLANGUAGE,
+		InFile: &inFile,
+		Conf:   conf}
+}
+
+// SetLanguageCommand creates a new command to set the document language.
+func SetLanguageCommand(inFile, outFile, lang string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.SETLANGUAGE
+	return &Command{
+		Mode:      model.SETLANGUAGE,
+		InFile:    &inFile,
+		OutFile:   &outFile,
+		StringVal: lang,
+		Conf:      conf}
+}
+
+// ResetLanguageCommand creates a new command to reset the document language.
+func ResetLanguageCommand(inFile, outFile string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.RESETLANGUAGE
+	return &Command{
+		Mode:    model.RESETLANGUAGE,
+		InFile:  &inFile,
+		OutFile: &outFile,
+		Conf:    conf}
+}
+
+// ListOutputIntentsCommand creates a new command to list the output intents of a document.
+func ListOutputIntentsCommand(inFile string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.LISTOUTPUTINTENTS
+	return &Command{
+		Mode:   model.LISTOUTPUTINTENTS,
+		InFile: &inFile,
+		Conf:   conf}
+}
+
+// AddOutputIntentCommand creates a new command to add an output intent using an ICC profile.
+func AddOutputIntentCommand(inFile, outFile, iccFile, subtype, outputCondition string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.ADDOUTPUTINTENT
+	return &Command{
+		Mode:       model.ADDOUTPUTINTENT,
+		InFile:     &inFile,
+		OutFile:    &outFile,
+		StringVal:  iccFile,
+		StringVals: []string{subtype, outputCondition},
+		Conf:       conf}
+}
+
+// RemoveOutputIntentsCommand creates a new command to remove output intents of a document.
+func RemoveOutputIntentsCommand(inFile, outFile, subtype string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.REMOVEOUTPUTINTENTS
+	return &Command{
+		Mode:      model.REMOVEOUTPUTINTENTS,
+		InFile:    &inFile,
+		OutFile:   &outFile,
+		StringVal: subtype,
+		Conf:      conf}
+}
+
 // ListPageModeCommand creates a new command to list the document page mode.
 func ListPageModeCommand(inFile string, conf *model.Configuration) *Command {
 	if conf == nil {
@@ -1188,6 +1766,156 @@ func ResetPageModeCommand(inFile, outFile string, conf *model.Configuration) *Co
 		Conf:    conf}
 }
 
+// ListOpenActionCommand creates a new command to list the document open action.
+func ListOpenActionCommand(inFile string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.LISTOPENACTION
+	return &Command{
+		Mode:   model.LISTOPENACTION,
+		InFile: &inFile,
+		Conf:   conf}
+}
+
+// SetOpenActionCommand creates a new command to set the document open action, page mode and page layout.
+func SetOpenActionCommand(inFile, outFile string, iv *pdfcpu.InitialView, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.SETOPENACTION
+	return &Command{
+		Mode:        model.SETOPENACTION,
+		InFile:      &inFile,
+		OutFile:     &outFile,
+		InitialView: iv,
+		Conf:        conf}
+}
+
+// ResetOpenActionCommand creates a new command to reset the document open action, page mode and page layout.
+func ResetOpenActionCommand(inFile, outFile string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.RESETOPENACTION
+	return &Command{
+		Mode:    model.RESETOPENACTION,
+		InFile:  &inFile,
+		OutFile: &outFile,
+		Conf:    conf}
+}
+
+// ListTransitionsCommand creates a new command to list page transitions.
+func ListTransitionsCommand(inFile string, pageSelection []string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.LISTTRANSITIONS
+	return &Command{
+		Mode:          model.LISTTRANSITIONS,
+		InFile:        &inFile,
+		PageSelection: pageSelection,
+		Conf:          conf}
+}
+
+// SetTransitionsCommand creates a new command to set page transitions.
+func SetTransitionsCommand(inFile, outFile string, pageSelection []string, t *model.Transition, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.SETTRANSITIONS
+	return &Command{
+		Mode:          model.SETTRANSITIONS,
+		InFile:        &inFile,
+		OutFile:       &outFile,
+		PageSelection: pageSelection,
+		Transition:    t,
+		Conf:          conf}
+}
+
+// RemoveTransitionsCommand creates a new command to remove page transitions.
+func RemoveTransitionsCommand(inFile, outFile string, pageSelection []string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.REMOVETRANSITIONS
+	return &Command{
+		Mode:          model.REMOVETRANSITIONS,
+		InFile:        &inFile,
+		OutFile:       &outFile,
+		PageSelection: pageSelection,
+		Conf:          conf}
+}
+
+// ListGeoCommand creates a new command to list georeferenced viewports.
+func ListGeoCommand(inFile string, pageSelection []string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.LISTGEO
+	return &Command{
+		Mode:          model.LISTGEO,
+		InFile:        &inFile,
+		PageSelection: pageSelection,
+		Conf:          conf}
+}
+
+// AddGeoCommand creates a new command to add the georeferenced viewports of inFileJSON.
+func AddGeoCommand(inFile, inFileJSON, outFile string, pageSelection []string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.ADDGEO
+	return &Command{
+		Mode:          model.ADDGEO,
+		InFile:        &inFile,
+		InFileJSON:    &inFileJSON,
+		OutFile:       &outFile,
+		PageSelection: pageSelection,
+		Conf:          conf}
+}
+
+// RemoveGeoCommand creates a new command to remove georeferenced viewports.
+func RemoveGeoCommand(inFile, outFile string, pageSelection []string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.REMOVEGEO
+	return &Command{
+		Mode:          model.REMOVEGEO,
+		InFile:        &inFile,
+		OutFile:       &outFile,
+		PageSelection: pageSelection,
+		Conf:          conf}
+}
+
+// ListMediaCommand creates a new command to list 3D artwork, RichMedia assets, sounds and movies.
+func ListMediaCommand(inFile string, pageSelection []string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.LISTMEDIA
+	return &Command{
+		Mode:          model.LISTMEDIA,
+		InFile:        &inFile,
+		PageSelection: pageSelection,
+		Conf:          conf}
+}
+
+// ExtractMediaCommand creates a new command to extract 3D artwork, RichMedia assets, sounds and movies.
+func ExtractMediaCommand(inFile string, outDir string, pageSelection []string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.EXTRACTMEDIA
+	return &Command{
+		Mode:          model.EXTRACTMEDIA,
+		InFile:        &inFile,
+		OutDir:        &outDir,
+		PageSelection: pageSelection,
+		Conf:          conf}
+}
+
 // ListViewerPreferencesCommand creates a new command to list the viewer preferences.
 func ListViewerPreferencesCommand(inFile string, all, json bool, conf *model.Configuration) *Command {
 
@@ -1246,3 +1974,202 @@ func ZoomCommand(inFile, outFile string, pageSelection []string, zoom *model.Zoo
 		Zoom:          zoom,
 		Conf:          conf}
 }
+
+// OverlayCommand creates a new command to composite selected pages with the pages of overlayFile.
+func OverlayCommand(inFile, overlayFile, outFile string, pageSelection []string, under bool, mode int, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.OVERLAY
+	return &Command{
+		Mode:          model.OVERLAY,
+		InFile:        &inFile,
+		InFiles:       []string{overlayFile},
+		OutFile:       &outFile,
+		PageSelection: pageSelection,
+		BoolVal1:      under,
+		IntVal:        mode,
+		Conf:          conf}
+}
+
+// BatchCommand creates a new command to process the jobs of a JSON job file.
+func BatchCommand(jobFile string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.BATCH
+	return &Command{
+		Mode:   model.BATCH,
+		InFile: &jobFile,
+		Conf:   conf}
+}
+
+// ReflowCommand creates a new command to export the content of a file as HTML or EPUB.
+func ReflowCommand(inFile, outFile, format string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.REFLOW
+	return &Command{
+		Mode:      model.REFLOW,
+		InFile:    &inFile,
+		OutFile:   &outFile,
+		StringVal: format,
+		Conf:      conf}
+}
+
+// ApplyOCRCommand creates a new command to add the hOCR or ALTO results of ocrFile as invisible text to selected pages.
+func ApplyOCRCommand(inFile, ocrFile, outFile string, pageSelection []string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.ADDTEXTLAYER
+	return &Command{
+		Mode:          model.ADDTEXTLAYER,
+		InFile:        &inFile,
+		InFiles:       []string{ocrFile},
+		OutFile:       &outFile,
+		PageSelection: pageSelection,
+		Conf:          conf}
+}
+
+// ConvertPDFXCommand creates a new command to fix inFile for conformance with a PDF/X standard.
+func ConvertPDFXCommand(inFile, outFile, standard, outputCondition string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.CONVERTPDFX
+	return &Command{
+		Mode:       model.CONVERTPDFX,
+		InFile:     &inFile,
+		OutFile:    &outFile,
+		StringVals: []string{standard, outputCondition},
+		Conf:       conf}
+}
+
+// ListOverprintCommand creates a new command to list overprint settings and the trapping status.
+func ListOverprintCommand(inFile string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.LISTOVERPRINT
+	return &Command{
+		Mode:   model.LISTOVERPRINT,
+		InFile: &inFile,
+		Conf:   conf}
+}
+
+// SetOverprintCommand creates a new command to set overprint settings and the trapping status.
+func SetOverprintCommand(inFile, outFile string, pageSelection []string, oc *pdfcpu.OverprintConfig, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.SETOVERPRINT
+	return &Command{
+		Mode:          model.SETOVERPRINT,
+		InFile:        &inFile,
+		OutFile:       &outFile,
+		PageSelection: pageSelection,
+		Overprint:     oc,
+		Conf:          conf}
+}
+
+// FixHairlinesCommand creates a new command to raise stroke widths below a threshold on selected pages.
+func FixHairlinesCommand(inFile, outFile string, pageSelection []string, hc *pdfcpu.HairlineConfig, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.FIXHAIRLINES
+	return &Command{
+		Mode:          model.FIXHAIRLINES,
+		InFile:        &inFile,
+		OutFile:       &outFile,
+		PageSelection: pageSelection,
+		Hairline:      hc,
+		Conf:          conf}
+}
+
+// NormalizeContentCommand creates a new command to pretty-print the content of selected pages.
+func NormalizeContentCommand(inFile, outFile string, pageSelection []string, compress bool, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.NORMALIZECONTENT
+	return &Command{
+		Mode:          model.NORMALIZECONTENT,
+		InFile:        &inFile,
+		OutFile:       &outFile,
+		PageSelection: pageSelection,
+		BoolVal1:      compress,
+		Conf:          conf}
+}
+
+// ExtractPageContentCommand creates a new command to extract the pretty-printed content of a page.
+func ExtractPageContentCommand(inFile, outFile string, pageNr int, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.EXTRACTPAGECONTENT
+	return &Command{
+		Mode:    model.EXTRACTPAGECONTENT,
+		InFile:  &inFile,
+		OutFile: &outFile,
+		IntVal:  pageNr,
+		Conf:    conf}
+}
+
+// ReplaceContentCommand creates a new command to replace the content of a page.
+func ReplaceContentCommand(inFile, contentFile, outFile string, pageNr int, compress bool, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.REPLACECONTENT
+	return &Command{
+		Mode:      model.REPLACECONTENT,
+		InFile:    &inFile,
+		OutFile:   &outFile,
+		StringVal: contentFile,
+		IntVal:    pageNr,
+		BoolVal1:  compress,
+		Conf:      conf}
+}
+
+// ListTemplateRegionsCommand creates a new command to list the placeholder regions of a template.
+func ListTemplateRegionsCommand(inFile string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.LISTTEMPLATEREGIONS
+	return &Command{
+		Mode:   model.LISTTEMPLATEREGIONS,
+		InFile: &inFile,
+		Conf:   conf}
+}
+
+// AddTemplateRegionCommand creates a new command to add a placeholder region to a template.
+func AddTemplateRegionCommand(inFile, outFile string, r *pdfcpu.TemplateRegion, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.ADDTEMPLATEREGIONS
+	return &Command{
+		Mode:           model.ADDTEMPLATEREGIONS,
+		InFile:         &inFile,
+		OutFile:        &outFile,
+		TemplateRegion: r,
+		Conf:           conf}
+}
+
+// FillTemplateCommand creates a new command to fill the placeholder regions of a template.
+func FillTemplateCommand(inFile, inFileJSON, outFile string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.FILLTEMPLATE
+	return &Command{
+		Mode:       model.FILLTEMPLATE,
+		InFile:     &inFile,
+		InFileJSON: &inFileJSON,
+		OutFile:    &outFile,
+		Conf:       conf}
+}
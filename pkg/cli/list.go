@@ -93,8 +93,8 @@ func ListAttachmentsCompactFile(inFile string, conf *model.Configuration) ([]str
 	return listAttachments(f, conf, false, false)
 }
 
-func listAnnotations(rs io.ReadSeeker, selectedPages []string, conf *model.Configuration) (int, []string, error) {
-	annots, err := api.Annotations(rs, selectedPages, conf)
+func listAnnotations(rs io.ReadSeeker, selectedPages []string, filter *pdfcpu.AnnotationFilter, conf *model.Configuration) (int, []string, error) {
+	annots, err := api.FilteredAnnotations(rs, selectedPages, filter, conf)
 	if err != nil {
 		return 0, nil, err
 	}
@@ -102,15 +102,46 @@ func listAnnotations(rs io.ReadSeeker, selectedPages []string, conf *model.Confi
 	return pdfcpu.ListAnnotations(annots)
 }
 
+func listAnnotationsJSON(rs io.ReadSeeker, selectedPages []string, filter *pdfcpu.AnnotationFilter, conf *model.Configuration) (int, []string, error) {
+	aa, err := api.AnnotationInfos(rs, selectedPages, filter, conf)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	s := struct {
+		Header      pdfcpu.Header           `json:"header"`
+		Annotations []pdfcpu.AnnotationInfo `json:"annotations"`
+	}{
+		Header:      pdfcpu.Header{Version: "pdfcpu " + model.VersionStr, Creation: time.Now().Format("2006-01-02 15:04:05 MST")},
+		Annotations: aa,
+	}
+
+	bb, err := json.MarshalIndent(s, "", "\t")
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return len(aa), []string{string(bb)}, nil
+}
+
 // ListAnnotationsFile returns a list of page annotations of inFile.
 func ListAnnotationsFile(inFile string, selectedPages []string, conf *model.Configuration) (int, []string, error) {
+	return ListFilteredAnnotationsFile(inFile, selectedPages, nil, false, conf)
+}
+
+// ListFilteredAnnotationsFile returns a list of page annotations of inFile matching filter, optionally as JSON.
+func ListFilteredAnnotationsFile(inFile string, selectedPages []string, filter *pdfcpu.AnnotationFilter, json bool, conf *model.Configuration) (int, []string, error) {
 	f, err := os.Open(inFile)
 	if err != nil {
 		return 0, nil, err
 	}
 	defer f.Close()
 
-	return listAnnotations(f, selectedPages, conf)
+	if json {
+		return listAnnotationsJSON(f, selectedPages, filter, conf)
+	}
+
+	return listAnnotations(f, selectedPages, filter, conf)
 }
 
 func listBoxes(rs io.ReadSeeker, selectedPages []string, pb *model.PageBoundaries, conf *model.Configuration) ([]string, error) {
@@ -128,7 +159,7 @@ func listBoxes(rs io.ReadSeeker, selectedPages []string, pb *model.PageBoundarie
 		return nil, err
 	}
 
-	pages, err := api.PagesForPageSelection(ctx.PageCount, selectedPages, true, true)
+	pages, err := api.PagesForPageSelectionWithContext(ctx, selectedPages, true, true)
 	if err != nil {
 		return nil, err
 	}
@@ -201,6 +232,93 @@ func ListFormFieldsFile(inFiles []string, conf *model.Configuration) ([]string,
 	return ss, nil
 }
 
+// ListFormFieldDetailsFiles returns the complete form field metadata of inFiles as JSON.
+func ListFormFieldDetailsFiles(inFiles []string, conf *model.Configuration) ([]string, error) {
+	type fileFields struct {
+		File   string             `json:"file"`
+		Fields []form.FieldDetail `json:"fields"`
+	}
+
+	forms := []fileFields{}
+
+	for _, fn := range inFiles {
+		fds, err := api.FormFieldDetailsFile(fn, conf)
+		if err != nil {
+			return nil, errors.Wrap(err, fn)
+		}
+		forms = append(forms, fileFields{File: fn, Fields: fds})
+	}
+
+	s := struct {
+		Header pdfcpu.Header `json:"header"`
+		Forms  []fileFields  `json:"forms"`
+	}{
+		Header: pdfcpu.Header{Version: "pdfcpu " + model.VersionStr, Creation: time.Now().Format("2006-01-02 15:04:05 MST")},
+		Forms:  forms,
+	}
+
+	bb, err := json.MarshalIndent(s, "", "\t")
+	if err != nil {
+		return nil, err
+	}
+
+	return []string{string(bb)}, nil
+}
+
+// ListUsageRightsFiles returns the usage rights signatures of inFiles.
+func ListUsageRightsFiles(inFiles []string, asJSON bool, conf *model.Configuration) ([]string, error) {
+	type fileUsageRights struct {
+		File        string             `json:"file"`
+		UsageRights *model.UsageRights `json:"usageRights"`
+	}
+
+	var (
+		ss  []string
+		urs []fileUsageRights
+	)
+
+	for i, fn := range inFiles {
+		ur, err := api.UsageRightsFile(fn, conf)
+		if err != nil {
+			return nil, errors.Wrap(err, fn)
+		}
+		if asJSON {
+			urs = append(urs, fileUsageRights{File: fn, UsageRights: ur})
+			continue
+		}
+		if i > 0 {
+			ss = append(ss, "")
+		}
+		if len(inFiles) > 1 {
+			ss = append(ss, fn+":")
+		}
+		if ur == nil {
+			ss = append(ss, "no usage rights")
+			continue
+		}
+		ss = append(ss, ur.String())
+	}
+
+	if !asJSON {
+		return ss, nil
+	}
+
+	s := struct {
+		Header pdfcpu.Header     `json:"header"`
+		Files  []fileUsageRights `json:"files"`
+	}{
+		Header: pdfcpu.Header{Version: "pdfcpu " + model.VersionStr, Creation: time.Now().Format("2006-01-02 15:04:05 MST")},
+		Files:  urs,
+	}
+
+	bb, err := json.MarshalIndent(s, "", "\t")
+	if err != nil {
+		return nil, err
+	}
+
+	return []string{string(bb)}, nil
+}
+
 func listImages(rs io.ReadSeeker, selectedPages []string, conf *model.Configuration) ([]string, error) {
 	if rs == nil {
 		return nil, errors.New("pdfcpu: listImages: Please provide rs")
@@ -216,7 +334,7 @@ func listImages(rs io.ReadSeeker, selectedPages []string, conf *model.Configurat
 		return nil, err
 	}
 
-	pages, err := api.PagesForPageSelection(ctx.PageCount, selectedPages, true, true)
+	pages, err := api.PagesForPageSelectionWithContext(ctx, selectedPages, true, true)
 	if err != nil {
 		return nil, err
 	}
@@ -449,6 +567,65 @@ func listPermissions(rs io.ReadSeeker, conf *model.Configuration) ([]string, err
 	return pdfcpu.Permissions(ctx), nil
 }
 
+func listEncryptionFilesJSON(inFiles []string, conf *model.Configuration) ([]string, error) {
+	type fileInfo struct {
+		File string `json:"file"`
+		*pdfcpu.EncryptionInfo
+	}
+
+	var infos []fileInfo
+
+	for _, fn := range inFiles {
+		info, err := api.EncryptionInfoFile(fn, conf)
+		if err != nil {
+			return nil, err
+		}
+		infos = append(infos, fileInfo{File: fn, EncryptionInfo: info})
+	}
+
+	s := struct {
+		Header pdfcpu.Header `json:"header"`
+		Infos  []fileInfo    `json:"infos"`
+	}{
+		Header: pdfcpu.Header{Version: "pdfcpu " + model.VersionStr, Creation: time.Now().Format("2006-01-02 15:04:05 MST")},
+		Infos:  infos,
+	}
+
+	bb, err := json.MarshalIndent(s, "", "\t")
+	if err != nil {
+		return nil, err
+	}
+
+	return []string{string(bb)}, nil
+}
+
+// ListEncryptionFiles returns formatted encryption details for inFiles.
+func ListEncryptionFiles(inFiles []string, json bool, conf *model.Configuration) ([]string, error) {
+	if json {
+		return listEncryptionFilesJSON(inFiles, conf)
+	}
+
+	var ss []string
+
+	for i, fn := range inFiles {
+		if i > 0 {
+			ss = append(ss, "")
+		}
+		info, err := api.EncryptionInfoFile(fn, conf)
+		if err != nil {
+			if len(inFiles) == 1 {
+				return nil, err
+			}
+			fmt.Fprintf(os.Stderr, "%s: %v\n", fn, err)
+			continue
+		}
+		ss = append(ss, fn+":")
+		ss = append(ss, pdfcpu.ListEncryptionInfo(info)...)
+	}
+
+	return ss, nil
+}
+
 // ListPermissionsFile returns a list of user access permissions for inFile.
 func ListPermissionsFile(inFiles []string, conf *model.Configuration) ([]string, error) {
 	log.SetCLILogger(nil)
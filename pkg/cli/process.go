@@ -52,6 +52,9 @@ func processAttachments(cmd *Command) (out []string, err error) {
 
 	case model.EXTRACTATTACHMENTS:
 		out, err = ExtractAttachments(cmd)
+
+	case model.VERIFYATTACHMENTS:
+		out, err = VerifyAttachments(cmd)
 	}
 
 	return out, err
@@ -71,6 +74,18 @@ func processBookmarks(cmd *Command) (out []string, err error) {
 
 	case model.REMOVEBOOKMARKS:
 		return RemoveBookmarks(cmd)
+
+	case model.SORTBOOKMARKS:
+		return SortBookmarks(cmd)
+
+	case model.DEDUPBOOKMARKS:
+		return DedupBookmarks(cmd)
+
+	case model.COLLAPSEBOOKMARKS:
+		return CollapseBookmarks(cmd)
+
+	case model.RETARGETBOOKMARKS:
+		return RetargetBookmarks(cmd)
 	}
 
 	return nil, nil
@@ -90,6 +105,28 @@ func processEncryption(cmd *Command) (out []string, err error) {
 
 	case model.CHANGEOPW:
 		return ChangeOwnerPassword(cmd)
+
+	case model.LISTENCRYPTION:
+		return ListEncryption(cmd)
+
+	case model.UPDATEENCRYPTION:
+		return UpdateEncryption(cmd)
+	}
+
+	return nil, nil
+}
+
+func processSignatures(cmd *Command) (out []string, err error) {
+	switch cmd.Mode {
+
+	case model.ADDVALIDATIONINFO:
+		return AddValidationInfo(cmd)
+
+	case model.LISTUSAGERIGHTS:
+		return ListUsageRights(cmd)
+
+	case model.REMOVEUSAGERIGHTS:
+		return RemoveUsageRights(cmd)
 	}
 
 	return nil, nil
@@ -116,11 +153,23 @@ func processForm(cmd *Command) (out []string, err error) {
 	case model.EXPORTFORMFIELDS:
 		return ExportFormFields(cmd)
 
+	case model.EXPORTFORMFIELDSCSV:
+		return ExportFormFieldsCSV(cmd)
+
 	case model.FILLFORMFIELDS:
 		return FillFormFields(cmd)
 
 	case model.MULTIFILLFORMFIELDS:
 		return MultiFillFormFields(cmd)
+
+	case model.ADDSIGNATUREFIELD:
+		return AddSignatureField(cmd)
+
+	case model.PREPARESIGNATURE:
+		return PrepareSignature(cmd)
+
+	case model.EMBEDSIGNATURE:
+		return EmbedSignature(cmd)
 	}
 
 	return nil, nil
@@ -151,6 +200,12 @@ func processKeywords(cmd *Command) (out []string, err error) {
 	case model.REMOVEKEYWORDS:
 		out, err = RemoveKeywords(cmd)
 
+	case model.IMPORTKEYWORDS:
+		out, err = ImportKeywords(cmd)
+
+	case model.EXPORTKEYWORDS:
+		out, err = ExportKeywords(cmd)
+
 	}
 
 	return out, err
@@ -162,8 +217,17 @@ func processPageAnnotations(cmd *Command) (out []string, err error) {
 	case model.LISTANNOTATIONS:
 		out, err = ListAnnotations(cmd)
 
+	case model.ADDANNOTATIONS:
+		out, err = AddAnnotations(cmd)
+
 	case model.REMOVEANNOTATIONS:
 		out, err = RemoveAnnotations(cmd)
+
+	case model.EXPORTANNOTATIONS:
+		out, err = ExportAnnotations(cmd)
+
+	case model.GENERATEANNOTAPPEARANCES:
+		out, err = GenerateAnnotationAppearances(cmd)
 	}
 
 	return out, err
@@ -204,6 +268,38 @@ func processPageLayout(cmd *Command) (out []string, err error) {
 	return nil, nil
 }
 
+func processLanguage(cmd *Command) (out []string, err error) {
+	switch cmd.Mode {
+
+	case model.LISTLANGUAGE:
+		return ListLanguage(cmd)
+
+	case model.SETLANGUAGE:
+		return SetLanguage(cmd)
+
+	case model.RESETLANGUAGE:
+		return ResetLanguage(cmd)
+	}
+
+	return nil, nil
+}
+
+func processOutputIntents(cmd *Command) (out []string, err error) {
+	switch cmd.Mode {
+
+	case model.LISTOUTPUTINTENTS:
+		return ListOutputIntents(cmd)
+
+	case model.ADDOUTPUTINTENT:
+		return AddOutputIntent(cmd)
+
+	case model.REMOVEOUTPUTINTENTS:
+		return RemoveOutputIntents(cmd)
+	}
+
+	return nil, nil
+}
+
 func processPageMode(cmd *Command) (out []string, err error) {
 	switch cmd.Mode {
 
@@ -220,6 +316,67 @@ func processPageMode(cmd *Command) (out []string, err error) {
 	return nil, nil
 }
 
+func processOpenAction(cmd *Command) (out []string, err error) {
+	switch cmd.Mode {
+
+	case model.LISTOPENACTION:
+		return ListOpenAction(cmd)
+
+	case model.SETOPENACTION:
+		return SetOpenAction(cmd)
+
+	case model.RESETOPENACTION:
+		return ResetOpenAction(cmd)
+	}
+
+	return nil, nil
+}
+
+func processTransitions(cmd *Command) (out []string, err error) {
+	switch cmd.Mode {
+
+	case model.LISTTRANSITIONS:
+		return ListTransitions(cmd)
+
+	case model.SETTRANSITIONS:
+		return SetTransitions(cmd)
+
+	case model.REMOVETRANSITIONS:
+		return RemoveTransitions(cmd)
+	}
+
+	return nil, nil
+}
+
+func processGeo(cmd *Command) (out []string, err error) {
+	switch cmd.Mode {
+
+	case model.LISTGEO:
+		return ListGeo(cmd)
+
+	case model.ADDGEO:
+		return AddGeo(cmd)
+
+	case model.REMOVEGEO:
+		return RemoveGeo(cmd)
+	}
+
+	return nil, nil
+}
+
+func processMedia(cmd *Command) (out []string, err error) {
+	switch cmd.Mode {
+
+	case model.LISTMEDIA:
+		return ListMedia(cmd)
+
+	case model.EXTRACTMEDIA:
+		return ExtractMedia(cmd)
+	}
+
+	return nil, nil
+}
+
 func processPages(cmd *Command) (out []string, err error) {
 	switch cmd.Mode {
 
@@ -228,6 +385,9 @@ func processPages(cmd *Command) (out []string, err error) {
 
 	case model.REMOVEPAGES:
 		return RemovePages(cmd)
+
+	case model.FLATTENPAGEATTRS:
+		return FlattenPageAttrs(cmd)
 	}
 
 	return nil, nil
@@ -258,6 +418,9 @@ func processProperties(cmd *Command) (out []string, err error) {
 	case model.REMOVEPROPERTIES:
 		out, err = RemoveProperties(cmd)
 
+	case model.EXPORTPROPERTIES:
+		out, err = ExportProperties(cmd)
+
 	}
 
 	return out, err
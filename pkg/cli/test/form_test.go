@@ -32,7 +32,7 @@ func TestListFormFields(t *testing.T) {
 	msg := "TestListFormFields"
 	inFile := filepath.Join(samplesDir, "form", "demo", "english.pdf")
 
-	cmd := cli.ListFormFieldsCommand([]string{inFile}, conf)
+	cmd := cli.ListFormFieldsCommand([]string{inFile}, false, conf)
 	if _, err := cli.Process(cmd); err != nil {
 		t.Fatalf("%s %s: %v\n", msg, inFile, err)
 	}
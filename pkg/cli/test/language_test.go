@@ -0,0 +1,63 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/cli"
+)
+
+func TestLanguage(t *testing.T) {
+	msg := "testLanguage"
+
+	lang := "de-CH"
+
+	inFile := filepath.Join(inDir, "test.pdf")
+	outFile := filepath.Join(outDir, "language.pdf")
+
+	cmd := cli.SetLanguageCommand(inFile, outFile, lang, nil)
+	if _, err := cli.Process(cmd); err != nil {
+		t.Fatalf("%s %s: set language: %v\n", msg, outFile, err)
+	}
+
+	cmd = cli.ListLanguageCommand(outFile, conf)
+	ss, err := cli.Process(cmd)
+	if err != nil {
+		t.Fatalf("%s %s: list language: %v\n", msg, outFile, err)
+	}
+	if len(ss) == 0 {
+		t.Fatalf("%s %s: list language, missing language\n", msg, outFile)
+	}
+	if ss[0] != lang {
+		t.Fatalf("%s %s: list language, want:%s, got:%s\n", msg, outFile, lang, ss[0])
+	}
+
+	cmd = cli.ResetLanguageCommand(outFile, "", nil)
+	if _, err = cli.Process(cmd); err != nil {
+		t.Fatalf("%s %s: reset language: %v\n", msg, outFile, err)
+	}
+
+	cmd = cli.ListLanguageCommand(outFile, conf)
+	if ss, err = cli.Process(cmd); err != nil {
+		t.Fatalf("%s %s: list language: %v\n", msg, outFile, err)
+	}
+	if len(ss) == 0 || ss[0] != "No language set" {
+		t.Fatalf("%s %s: list language, unexpected: %v\n", msg, outFile, ss)
+	}
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/cli"
+)
+
+func TestOutputIntents(t *testing.T) {
+	msg := "testOutputIntents"
+
+	inFile := filepath.Join(inDir, "test.pdf")
+	outFile := filepath.Join(outDir, "outputIntents.pdf")
+	iccFile := filepath.Join(resDir, "sRGB.icc")
+
+	cmd := cli.AddOutputIntentCommand(inFile, outFile, iccFile, "GTS_PDFA1", "", nil)
+	if _, err := cli.Process(cmd); err != nil {
+		t.Fatalf("%s %s: add output intent: %v\n", msg, outFile, err)
+	}
+
+	cmd = cli.ListOutputIntentsCommand(outFile, conf)
+	ss, err := cli.Process(cmd)
+	if err != nil {
+		t.Fatalf("%s %s: list output intents: %v\n", msg, outFile, err)
+	}
+	if len(ss) != 1 || !strings.HasPrefix(ss[0], "GTS_PDFA1: Custom") {
+		t.Fatalf("%s %s: list output intents, unexpected: %v\n", msg, outFile, ss)
+	}
+
+	cmd = cli.RemoveOutputIntentsCommand(outFile, "", "", nil)
+	if _, err = cli.Process(cmd); err != nil {
+		t.Fatalf("%s %s: remove output intents: %v\n", msg, outFile, err)
+	}
+
+	cmd = cli.ListOutputIntentsCommand(outFile, conf)
+	if ss, err = cli.Process(cmd); err != nil {
+		t.Fatalf("%s %s: list output intents: %v\n", msg, outFile, err)
+	}
+	if len(ss) != 1 || ss[0] != "no output intents" {
+		t.Fatalf("%s %s: list output intents, unexpected: %v\n", msg, outFile, ss)
+	}
+}
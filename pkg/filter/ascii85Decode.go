@@ -61,7 +61,7 @@ func (f ascii85Decode) DecodeLength(r io.Reader, maxLen int64) (io.Reader, error
 	// fmt.Printf("dump:\n%s", hex.Dump(bb))
 
 	l := len(bb)
-	if bb[l-1] == 0x0A || bb[l-1] == 0x0D {
+	if l > 0 && (bb[l-1] == 0x0A || bb[l-1] == 0x0D) {
 		bb = bb[:l-1]
 	}
 
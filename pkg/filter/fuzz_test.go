@@ -0,0 +1,61 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filter_test
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/filter"
+)
+
+// Run with: go test -run=^$ -fuzz=FuzzDecode ./pkg/filter
+
+// maxFuzzDecodeLength protects against decompression bombs.
+const maxFuzzDecodeLength = 1 << 20
+
+func FuzzDecode(f *testing.F) {
+	for _, filterName := range filter.List() {
+		fi, err := filter.NewFilter(filterName, nil)
+		if err != nil {
+			f.Fatalf("%s: %v\n", filterName, err)
+		}
+		r, err := fi.Encode(strings.NewReader("Hello, Gopher! Hello, Gopher! Hello, Gopher!"))
+		if err != nil {
+			f.Fatalf("%s: %v\n", filterName, err)
+		}
+		bb, err := io.ReadAll(r)
+		if err != nil {
+			f.Fatalf("%s: %v\n", filterName, err)
+		}
+		f.Add(filterName, bb)
+	}
+
+	f.Fuzz(func(t *testing.T, filterName string, data []byte) {
+		fi, err := filter.NewFilter(filterName, nil)
+		if err != nil {
+			return
+		}
+		r, err := fi.DecodeLength(bytes.NewReader(data), maxFuzzDecodeLength)
+		if err != nil {
+			return
+		}
+		io.Copy(io.Discard, io.LimitReader(r, maxFuzzDecodeLength))
+	})
+}
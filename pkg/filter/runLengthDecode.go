@@ -37,7 +37,7 @@ func (f runLengthDecode) decode(w io.ByteWriter, src []byte, maxLen int64) {
 		i++
 		if b < 0x80 {
 			c := int(b) + 1
-			for j := 0; j < c; j++ {
+			for j := 0; j < c && i < len(src); j++ {
 				if maxLen >= 0 && maxLen == written {
 					break
 				}
@@ -48,6 +48,10 @@ func (f runLengthDecode) decode(w io.ByteWriter, src []byte, maxLen int64) {
 			}
 			continue
 		}
+		if i == len(src) {
+			// Truncated run.
+			break
+		}
 		c := 257 - int(b)
 		for j := 0; j < c; j++ {
 			if maxLen >= 0 && maxLen == written {
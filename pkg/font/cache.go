@@ -0,0 +1,199 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package font
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"hash/crc32"
+	"io/fs"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// The font cache holds parsed font data for the lifetime of the process keyed by font checksum.
+// Batch jobs creating lots of files with embedded user fonts parse each font file only once,
+// even across resets of the user font dir. Core font metrics are compiled in and need no caching.
+var (
+	fontCacheLock = &sync.Mutex{}
+
+	// Font metrics by checksum of the installed (.gob) or loaded (.ttf, .otf) font file.
+	cachedMetrics = map[uint32]TTFLight{}
+
+	// Font programs by checksum of the installed (.gob) font file.
+	cachedPrograms = map[uint32][]byte{}
+
+	// Font program tables by checksum of the font program.
+	cachedTables = map[uint32]*fontTables{}
+
+	// Checksums of installed font files by font name as recorded by LoadUserFonts.
+	fontChecksums = map[string]uint32{}
+)
+
+type fontTables struct {
+	header []byte
+	tables map[string]*table
+}
+
+// clone returns a copy of ft's tables safe for modification.
+// Table data is shared since it is only ever replaced.
+func (ft fontTables) clone() (header []byte, tables map[string]*table) {
+	tables = make(map[string]*table, len(ft.tables))
+	for tag, t := range ft.tables {
+		t1 := *t
+		tables[tag] = &t1
+	}
+	return append([]byte(nil), ft.header...), tables
+}
+
+func checksum(bb []byte) uint32 {
+	return crc32.ChecksumIEEE(bb)
+}
+
+// ClearCache releases all font data cached by this process.
+func ClearCache() {
+	fontCacheLock.Lock()
+	defer fontCacheLock.Unlock()
+	cachedMetrics = map[uint32]TTFLight{}
+	cachedPrograms = map[uint32][]byte{}
+	cachedTables = map[uint32]*fontTables{}
+	fontChecksums = map[string]uint32{}
+}
+
+// cachedLoad returns the metrics of the installed font fileName contained in fsys.
+func cachedLoad(fsys fs.FS, fontName, fileName string) (TTFLight, error) {
+	bb, err := fs.ReadFile(fsys, fileName)
+	if err != nil {
+		return TTFLight{}, err
+	}
+
+	crc := checksum(bb)
+
+	fontCacheLock.Lock()
+	fontChecksums[fontName] = crc
+	fd, ok := cachedMetrics[crc]
+	fontCacheLock.Unlock()
+	if ok {
+		return fd, nil
+	}
+
+	if err := gob.NewDecoder(bytes.NewReader(bb)).Decode(&fd); err != nil {
+		return TTFLight{}, err
+	}
+
+	fontCacheLock.Lock()
+	cachedMetrics[crc] = fd
+	fontCacheLock.Unlock()
+
+	return fd, nil
+}
+
+// cachedRead returns the font program of the installed font fontName contained in fsys.
+func cachedRead(fsys fs.FS, fontName string) ([]byte, error) {
+	fontCacheLock.Lock()
+	crc, ok := fontChecksums[fontName]
+	var bb []byte
+	if ok {
+		bb, ok = cachedPrograms[crc]
+	}
+	fontCacheLock.Unlock()
+	if ok {
+		return bb, nil
+	}
+
+	bb, err := fs.ReadFile(fsys, fontName+".gob")
+	if err != nil {
+		return nil, err
+	}
+
+	ff := &struct{ FontFile []byte }{}
+	if err := gob.NewDecoder(bytes.NewReader(bb)).Decode(ff); err != nil {
+		return nil, err
+	}
+
+	crc = checksum(bb)
+
+	fontCacheLock.Lock()
+	fontChecksums[fontName] = crc
+	cachedPrograms[crc] = ff.FontFile
+	fontCacheLock.Unlock()
+
+	return ff.FontFile, nil
+}
+
+// cachedParse parses the TTF or OTF font file bb.
+func cachedParse(fileName string, bb []byte) (TTFLight, []byte, error) {
+	crc := checksum(bb)
+
+	fontCacheLock.Lock()
+	fd, ok1 := cachedMetrics[crc]
+	ff, ok2 := cachedPrograms[crc]
+	fontCacheLock.Unlock()
+	if ok1 && ok2 {
+		return fd, ff, nil
+	}
+
+	ttf, err := parseFont(fileName, bb)
+	if err != nil {
+		return TTFLight{}, nil, err
+	}
+
+	fd = ttf.light()
+
+	fontCacheLock.Lock()
+	cachedMetrics[crc] = fd
+	cachedPrograms[crc] = ttf.FontFile
+	fontCacheLock.Unlock()
+
+	return fd, ttf.FontFile, nil
+}
+
+// cachedTTFTables returns the header and a modifiable copy of the tables of font program bb.
+func cachedTTFTables(bb []byte) ([]byte, map[string]*table, error) {
+	if len(bb) < 12 {
+		return nil, nil, errors.New("pdfcpu: corrupt font program")
+	}
+
+	crc := checksum(bb)
+
+	fontCacheLock.Lock()
+	ft, ok := cachedTables[crc]
+	fontCacheLock.Unlock()
+	if ok {
+		header, tables := ft.clone()
+		return header, tables, nil
+	}
+
+	header := bb[:12]
+	tableCount := int(binary.BigEndian.Uint16(header[4:]))
+	tables, err := ttfTables(tableCount, bb)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ft = &fontTables{header: append([]byte(nil), header...), tables: tables}
+
+	fontCacheLock.Lock()
+	cachedTables[crc] = ft
+	fontCacheLock.Unlock()
+
+	header, tables = ft.clone()
+
+	return header, tables, nil
+}
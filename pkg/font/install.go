@@ -648,21 +648,67 @@ func readGob(fileName string, fd *ttf) error {
 	return dec.Decode(fd)
 }
 
-func installTrueTypeRep(fontDir, fontName string, header []byte, tables map[string]*table) error {
+func (fd ttf) light() TTFLight {
+	return TTFLight{
+		PostscriptName:  fd.PostscriptName,
+		Protected:       fd.Protected,
+		UnitsPerEm:      fd.UnitsPerEm,
+		Ascent:          fd.Ascent,
+		Descent:         fd.Descent,
+		CapHeight:       fd.CapHeight,
+		FirstChar:       fd.FirstChar,
+		LastChar:        fd.LastChar,
+		UnicodeRange:    fd.UnicodeRange,
+		LLx:             fd.LLx,
+		LLy:             fd.LLy,
+		URx:             fd.URx,
+		URy:             fd.URy,
+		ItalicAngle:     fd.ItalicAngle,
+		FixedPitch:      fd.FixedPitch,
+		Bold:            fd.Bold,
+		HorMetricsCount: fd.HorMetricsCount,
+		GlyphCount:      fd.GlyphCount,
+		GlyphWidths:     fd.GlyphWidths,
+		Chars:           fd.Chars,
+		ToUnicode:       fd.ToUnicode,
+		Planes:          fd.Planes,
+	}
+}
+
+func newTTF(header []byte, tables map[string]*table) (*ttf, error) {
 	fd := ttf{}
-	//fmt.Println(fontName)
 	for _, v := range []string{"head", "OS/2", "post", "name", "hhea", "maxp", "hmtx", "cmap"} {
 		if err := parse(tables, v, &fd); err != nil {
-			return err
+			return nil, err
 		}
 	}
 
 	bb, err := createTTF(header, tables)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	fd.FontFile = bb
 
+	return &fd, nil
+}
+
+// parseFont returns the internal representation of the TrueType font contained in bb.
+func parseFont(fontName string, bb []byte) (*ttf, error) {
+	header, tables, err := headerAndTables(fontName, bytes.NewReader(bb), 0)
+	if err != nil {
+		return nil, err
+	}
+	return newTTF(header, tables)
+}
+
+func installTrueTypeRep(fontDir, fontName string, header []byte, tables map[string]*table) error {
+	//fmt.Println(fontName)
+	p, err := newTTF(header, tables)
+	if err != nil {
+		return err
+	}
+	fd := *p
+
 	if log.CLIEnabled() {
 		log.CLI.Println(fd.PostscriptName)
 	}
@@ -760,6 +806,17 @@ func InstallFontFromBytes(fontDir, fontName string, bb []byte) error {
 	return installTrueTypeRep(fontDir, fontName, header, tables)
 }
 
+// UninstallFont removes the internal representation of fontName from fontDir.
+func UninstallFont(fontDir, fontName string) error {
+	if err := os.Remove(filepath.Join(fontDir, fontName+".gob")); err != nil {
+		return err
+	}
+	UserFontMetricsLock.Lock()
+	delete(UserFontMetrics, fontName)
+	UserFontMetricsLock.Unlock()
+	return nil
+}
+
 func ttfTables(tableCount int, bb []byte) (map[string]*table, error) {
 	tables := map[string]*table{}
 	b := bb[12:]
@@ -1025,9 +1082,7 @@ func Subset(fontName string, usedGIDs map[uint16]bool) ([]byte, error) {
 		return nil, err
 	}
 
-	header := bb[:12]
-	tableCount := int(binary.BigEndian.Uint16(header[4:]))
-	tables, err := ttfTables(tableCount, bb)
+	header, tables, err := cachedTTFTables(bb)
 	if err != nil {
 		return nil, err
 	}
@@ -17,12 +17,11 @@ limitations under the License.
 package font
 
 import (
-	"encoding/gob"
 	"fmt"
+	"io/fs"
 	"math"
 	"os"
 	"path"
-	"path/filepath"
 	"runtime/debug"
 	"strconv"
 	"strings"
@@ -195,56 +194,109 @@ func (fd TTFLight) SupportsScript(id string) (bool, error) {
 // UserFontDir is the location for installed TTF or OTF font files.
 var UserFontDir string
 
+// UserFontFS optionally supersedes UserFontDir as read only source for installed font files,
+// eg. for fonts bundled using go:embed.
+// Besides installed fonts (.gob) UserFontFS may contain TTF or OTF font files which get parsed into memory.
+var UserFontFS fs.FS
+
 // UserFontMetrics represents font metrics for TTF or OTF font files installed into UserFontDir.
 var UserFontMetrics = map[string]TTFLight{}
 var UserFontMetricsLock = &sync.RWMutex{}
 
-func load(fileName string, fd *TTFLight) error {
-	//fmt.Printf("reading gob from: %s\n", fileName)
-	f, err := os.Open(fileName)
-	if err != nil {
-		return err
+// userFontFiles holds the font files of TTF or OTF files loaded from UserFontFS.
+var userFontFiles = map[string][]byte{}
+
+// userFonts returns the source of installed fonts and true if it is UserFontFS.
+func userFonts() (fs.FS, bool, error) {
+	UserFontMetricsLock.RLock()
+	defer UserFontMetricsLock.RUnlock()
+	if UserFontFS != nil {
+		return UserFontFS, true, nil
+	}
+	if UserFontDir == "" {
+		return nil, false, errors.New("pdfcpu: missing user font dir")
+	}
+	return os.DirFS(UserFontDir), false, nil
+}
+
+// WritableUserFontDir returns UserFontDir unless fonts are served read only from UserFontFS.
+func WritableUserFontDir() (string, error) {
+	UserFontMetricsLock.RLock()
+	defer UserFontMetricsLock.RUnlock()
+	if UserFontFS != nil {
+		return "", errors.New("pdfcpu: user fonts are read only")
 	}
-	defer f.Close()
-	dec := gob.NewDecoder(f)
-	return dec.Decode(fd)
+	return UserFontDir, nil
 }
 
 // Read reads in the font file bytes from gob
 func Read(fileName string) ([]byte, error) {
-	fn := filepath.Join(UserFontDir, fileName+".gob")
-	f, err := os.Open(fn)
+	UserFontMetricsLock.RLock()
+	bb, ok := userFontFiles[fileName]
+	UserFontMetricsLock.RUnlock()
+	if ok {
+		return bb, nil
+	}
+
+	fsys, _, err := userFonts()
 	if err != nil {
 		return nil, err
 	}
-	defer f.Close()
-	dec := gob.NewDecoder(f)
-	ff := &struct{ FontFile []byte }{}
-	err = dec.Decode(ff)
-	return ff.FontFile, err
+	return cachedRead(fsys, fileName)
 }
 
 func isSupportedFontFile(filename string) bool {
 	return strings.HasSuffix(strings.ToLower(filename), ".gob")
 }
 
+func isFontProgramFile(filename string) bool {
+	ext := strings.ToLower(path.Ext(filename))
+	return ext == ".ttf" || ext == ".otf"
+}
+
+// loadFontProgram parses the TTF or OTF font file fileName contained in fsys into memory.
+func loadFontProgram(fsys fs.FS, fileName string) error {
+	bb, err := fs.ReadFile(fsys, fileName)
+	if err != nil {
+		return err
+	}
+	fd, ff, err := cachedParse(fileName, bb)
+	if err != nil {
+		return err
+	}
+	UserFontMetricsLock.Lock()
+	UserFontMetrics[fd.PostscriptName] = fd
+	userFontFiles[fd.PostscriptName] = ff
+	UserFontMetricsLock.Unlock()
+	return nil
+}
+
 // LoadUserFonts loads any installed TTF or OTF font files.
 func LoadUserFonts() error {
 	//fmt.Printf("loading userFonts from %s\n", UserFontDir)
-	files, err := os.ReadDir(UserFontDir)
+	fsys, readOnly, err := userFonts()
+	if err != nil {
+		return err
+	}
+	files, err := fs.ReadDir(fsys, ".")
 	if err != nil {
 		return err
 	}
 	for _, f := range files {
+		if readOnly && isFontProgramFile(f.Name()) {
+			if err := loadFontProgram(fsys, f.Name()); err != nil {
+				return err
+			}
+			continue
+		}
 		if !isSupportedFontFile(f.Name()) {
 			continue
 		}
-		ttf := TTFLight{}
-		fn := filepath.Join(UserFontDir, f.Name())
-		if err := load(fn, &ttf); err != nil {
+		fn := strings.TrimSuffix(f.Name(), path.Ext(f.Name()))
+		ttf, err := cachedLoad(fsys, fn, f.Name())
+		if err != nil {
 			return err
 		}
-		fn = strings.TrimSuffix(f.Name(), path.Ext(f.Name()))
 		//fmt.Printf("loading %s.ttf...\n", fn)
 		//fmt.Printf("Loaded %s:\n%s", fn, ttf)
 		UserFontMetricsLock.Lock()
@@ -254,6 +306,32 @@ func LoadUserFonts() error {
 	return nil
 }
 
+func resetUserFonts(dir string, fsys fs.FS) error {
+	UserFontMetricsLock.Lock()
+	UserFontDir, UserFontFS = dir, fsys
+	UserFontMetrics = map[string]TTFLight{}
+	userFontFiles = map[string][]byte{}
+	UserFontMetricsLock.Unlock()
+	return LoadUserFonts()
+}
+
+// SetUserFontDir points the user font cache to dir and loads all fonts installed there.
+func SetUserFontDir(dir string) error {
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return err
+	}
+	return resetUserFonts(dir, nil)
+}
+
+// SetUserFontFS makes fsys the read only source of user fonts and loads all fonts contained.
+// fsys may contain installed fonts (.gob) as well as TTF or OTF font files.
+func SetUserFontFS(fsys fs.FS) error {
+	if fsys == nil {
+		return errors.New("pdfcpu: missing fsys")
+	}
+	return resetUserFonts("", fsys)
+}
+
 // BoundingBox returns the font bounding box for a given font as specified in the corresponding AFM file.
 func BoundingBox(fontName string) *types.Rectangle {
 	if IsCoreFont(fontName) {
@@ -160,6 +160,11 @@ func SetDefaultCLILogger() {
 	SetCLILogger(log.New(os.Stdout, "", 0))
 }
 
+// SetStderrCLILogger sets a cli logger writing to stderr keeping stdout free for command output.
+func SetStderrCLILogger() {
+	SetCLILogger(log.New(os.Stderr, "", 0))
+}
+
 // SetDefaultLoggers sets all loggers to their default logger.
 func SetDefaultLoggers() {
 	SetDefaultDebugLogger()
@@ -0,0 +1,537 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/font"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"golang.org/x/text/encoding/charmap"
+)
+
+// Many PDF producers omit the appearance streams of annotations and rely on viewers to render them.
+// Flattening, printing and most non interactive renderers need /AP, so we synthesize the normal appearance
+// for the most common annotation types based on their attributes.
+
+// bezierCircle is the control point distance for approximating a quarter circle by a cubic Bézier curve.
+const bezierCircle = 0.5523
+
+// annotAppearanceFont is the standard font used for FreeText appearances.
+const annotAppearanceFont = "Helvetica"
+
+// annotAppearance collects the content and resources of an appearance stream for an annotation dict.
+type annotAppearance struct {
+	ctx     *model.Context
+	d       types.Dict
+	rect    *types.Rectangle
+	buf     bytes.Buffer
+	res     types.Dict
+	fontRef *types.IndirectRef
+}
+
+// annotAppearanceGenerators maps supported annotation subtypes to their appearance generators.
+// A generator returns false if the annotation has nothing to render.
+var annotAppearanceGenerators = map[string]func(*annotAppearance) (bool, error){
+	"Square":    (*annotAppearance).square,
+	"Circle":    (*annotAppearance).circle,
+	"Line":      (*annotAppearance).line,
+	"Highlight": (*annotAppearance).highlight,
+	"FreeText":  (*annotAppearance).freeText,
+}
+
+// colorOp returns the color operator for a color array of 1 (gray), 3 (RGB) or 4 (CMYK) components.
+func colorOp(ff []float64, stroke bool) string {
+	var op string
+	switch len(ff) {
+	case 1:
+		op = "g"
+	case 3:
+		op = "rg"
+	case 4:
+		op = "k"
+	default:
+		return ""
+	}
+	if stroke {
+		op = strings.ToUpper(op)
+	}
+	ss := make([]string, len(ff))
+	for i, f := range ff {
+		ss[i] = strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	return strings.Join(ss, " ") + " " + op
+}
+
+func (a *annotAppearance) color(key string, stroke bool) string {
+	ff, err := floats(a.ctx, a.d[key])
+	if err != nil {
+		return ""
+	}
+	return colorOp(ff, stroke)
+}
+
+// borderWidth returns the border width defined by /BS or /Border, 1 by default.
+func (a *annotAppearance) borderWidth() float64 {
+	if bs, err := a.ctx.DereferenceDict(a.d["BS"]); err == nil && bs != nil {
+		if o, found := bs.Find("W"); found {
+			if w, err := a.ctx.DereferenceNumber(o); err == nil {
+				return w
+			}
+		}
+		return 1
+	}
+	if ff, err := floats(a.ctx, a.d["Border"]); err == nil && len(ff) >= 3 {
+		return ff[2]
+	}
+	return 1
+}
+
+// dashPattern returns the dash operator for dashed borders.
+func (a *annotAppearance) dashPattern() string {
+	bs, err := a.ctx.DereferenceDict(a.d["BS"])
+	if err != nil || bs == nil {
+		return ""
+	}
+	if s := bs.NameEntry("S"); s == nil || *s != "D" {
+		return ""
+	}
+	ff, err := floats(a.ctx, bs["D"])
+	if err != nil || len(ff) == 0 {
+		ff = []float64{3}
+	}
+	ss := make([]string, len(ff))
+	for i, f := range ff {
+		ss[i] = strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	return "[" + strings.Join(ss, " ") + "] 0 d"
+}
+
+// graphicsState sets up an extended graphics state for the annotation's opacity /CA and blend mode.
+func (a *annotAppearance) graphicsState(blendMode string) {
+	gs := types.Dict{"Type": types.Name("ExtGState")}
+	if o, found := a.d.Find("CA"); found {
+		if ca, err := a.ctx.DereferenceNumber(o); err == nil && ca < 1 {
+			gs["CA"] = types.Float(ca)
+			gs["ca"] = types.Float(ca)
+		}
+	}
+	if blendMode != "" {
+		gs["BM"] = types.Name(blendMode)
+	}
+	if len(gs) == 1 {
+		return
+	}
+	a.res["ExtGState"] = types.Dict{"GS0": gs}
+	fmt.Fprint(&a.buf, "/GS0 gs\n")
+}
+
+// innerRect returns the annotation rectangle reduced by the rectangle differences /RD.
+func (a *annotAppearance) innerRect() *types.Rectangle {
+	r := a.rect
+	ff, err := floats(a.ctx, a.d["RD"])
+	if err != nil || len(ff) != 4 {
+		return r
+	}
+	r1 := types.NewRectangle(r.LL.X+ff[0], r.LL.Y+ff[1], r.UR.X-ff[2], r.UR.Y-ff[3])
+	if r1.Width() <= 0 || r1.Height() <= 0 {
+		return r
+	}
+	return r1
+}
+
+// shape writes the stroke and fill settings for Square and Circle annotations along with path
+// for the rectangle inset by half the border width and returns false if there is nothing to render.
+func (a *annotAppearance) shape(path func(r *types.Rectangle) string) bool {
+	stroke, fill := a.color("C", true), a.color("IC", false)
+
+	w := a.borderWidth()
+	if stroke == "" || w <= 0 {
+		stroke, w = "", 0
+	}
+	if stroke == "" && fill == "" {
+		return false
+	}
+
+	r := a.innerRect()
+	r = types.NewRectangle(r.LL.X+w/2, r.LL.Y+w/2, r.UR.X-w/2, r.UR.Y-w/2)
+
+	a.graphicsState("")
+
+	op := "f"
+	if stroke != "" {
+		fmt.Fprintf(&a.buf, "%s %.2f w\n", stroke, w)
+		if dash := a.dashPattern(); dash != "" {
+			fmt.Fprintf(&a.buf, "%s\n", dash)
+		}
+		op = "S"
+	}
+	if fill != "" {
+		fmt.Fprintf(&a.buf, "%s\n", fill)
+		if stroke != "" {
+			op = "B"
+		}
+	}
+
+	fmt.Fprintf(&a.buf, "%s %s\n", path(r), op)
+	return true
+}
+
+func (a *annotAppearance) square() (bool, error) {
+	return a.shape(func(r *types.Rectangle) string {
+		return fmt.Sprintf("%.2f %.2f %.2f %.2f re", r.LL.X, r.LL.Y, r.Width(), r.Height())
+	}), nil
+}
+
+func (a *annotAppearance) circle() (bool, error) {
+	return a.shape(func(r *types.Rectangle) string {
+		rx, ry := r.Width()/2, r.Height()/2
+		cx, cy := r.LL.X+rx, r.LL.Y+ry
+		kx, ky := rx*bezierCircle, ry*bezierCircle
+		var sb strings.Builder
+		fmt.Fprintf(&sb, "%.2f %.2f m ", cx+rx, cy)
+		fmt.Fprintf(&sb, "%.2f %.2f %.2f %.2f %.2f %.2f c ", cx+rx, cy+ky, cx+kx, cy+ry, cx, cy+ry)
+		fmt.Fprintf(&sb, "%.2f %.2f %.2f %.2f %.2f %.2f c ", cx-kx, cy+ry, cx-rx, cy+ky, cx-rx, cy)
+		fmt.Fprintf(&sb, "%.2f %.2f %.2f %.2f %.2f %.2f c ", cx-rx, cy-ky, cx-kx, cy-ry, cx, cy-ry)
+		fmt.Fprintf(&sb, "%.2f %.2f %.2f %.2f %.2f %.2f c h", cx+kx, cy-ry, cx+rx, cy-ky, cx+rx, cy)
+		return sb.String()
+	}), nil
+}
+
+func (a *annotAppearance) line() (bool, error) {
+	ff, err := floats(a.ctx, a.d["L"])
+	if err != nil {
+		return false, err
+	}
+	if len(ff) != 4 {
+		return false, nil
+	}
+
+	stroke, w := a.color("C", true), a.borderWidth()
+	if stroke == "" || w <= 0 {
+		return false, nil
+	}
+
+	a.graphicsState("")
+
+	fmt.Fprintf(&a.buf, "%s %.2f w\n", stroke, w)
+	if dash := a.dashPattern(); dash != "" {
+		fmt.Fprintf(&a.buf, "%s\n", dash)
+	}
+	fmt.Fprintf(&a.buf, "%.2f %.2f m %.2f %.2f l S\n", ff[0], ff[1], ff[2], ff[3])
+	return true, nil
+}
+
+func (a *annotAppearance) highlight() (bool, error) {
+	ff, err := floats(a.ctx, a.d["QuadPoints"])
+	if err != nil {
+		return false, err
+	}
+	if len(ff) < 8 {
+		r := a.rect
+		ff = []float64{r.LL.X, r.UR.Y, r.UR.X, r.UR.Y, r.LL.X, r.LL.Y, r.UR.X, r.LL.Y}
+	}
+
+	fill := a.color("C", false)
+	if fill == "" {
+		// Highlighter yellow.
+		fill = "1 1 0 rg"
+	}
+
+	a.graphicsState("Multiply")
+
+	fmt.Fprintf(&a.buf, "%s\n", fill)
+
+	// Quadrilaterals are given as upper left, upper right, lower left, lower right.
+	for i := 0; i+8 <= len(ff); i += 8 {
+		q := ff[i : i+8]
+		fmt.Fprintf(&a.buf, "%.2f %.2f m %.2f %.2f l %.2f %.2f l %.2f %.2f l h f\n", q[0], q[1], q[2], q[3], q[6], q[7], q[4], q[5])
+	}
+	return true, nil
+}
+
+// parseDefaultAppearance returns the font size and the fill color operator of a default appearance string.
+func parseDefaultAppearance(da string) (float64, string) {
+	var (
+		fontSize float64
+		col      string
+	)
+
+	ss := strings.Fields(da)
+
+	for i, s := range ss {
+		n := 0
+		switch s {
+		case "Tf":
+			if i > 0 {
+				fontSize, _ = strconv.ParseFloat(ss[i-1], 64)
+			}
+			continue
+		case "g":
+			n = 1
+		case "rg":
+			n = 3
+		case "k":
+			n = 4
+		default:
+			continue
+		}
+		if i >= n {
+			col = strings.Join(ss[i-n:i+1], " ")
+		}
+	}
+
+	return fontSize, col
+}
+
+// winAnsiString returns s encoded in WinAnsiEncoding, replacing unsupported characters by '?'.
+func winAnsiString(s string) string {
+	bb := make([]byte, 0, len(s))
+	for _, r := range s {
+		b, ok := charmap.Windows1252.EncodeRune(r)
+		if !ok {
+			b = '?'
+		}
+		bb = append(bb, b)
+	}
+	return string(bb)
+}
+
+// wrapText breaks s into lines not exceeding width when rendered using fontName at fontSize.
+func wrapText(s, fontName string, fontSize, width float64) []string {
+	textWidth := func(s string) float64 {
+		return font.TextWidth(s, fontName, 1000) * fontSize / 1000
+	}
+
+	var lines []string
+
+	for _, para := range strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n") {
+		line := ""
+		for _, word := range strings.Fields(strings.ReplaceAll(para, "\r", " ")) {
+			s1 := word
+			if line != "" {
+				s1 = line + " " + word
+			}
+			if line != "" && textWidth(s1) > width {
+				lines = append(lines, line)
+				s1 = word
+			}
+			line = s1
+		}
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
+func (a *annotAppearance) freeText() (bool, error) {
+	s := ""
+	if o, found := a.d.Find("Contents"); found {
+		var err error
+		if s, err = a.ctx.DereferenceText(o); err != nil {
+			return false, err
+		}
+	}
+
+	da := ""
+	if o, found := a.d.Find("DA"); found {
+		da, _ = a.ctx.DereferenceText(o)
+	}
+
+	fontSize, col := parseDefaultAppearance(da)
+	if fontSize <= 0 {
+		fontSize = 12
+	}
+	if col == "" {
+		col = "0 g"
+	}
+
+	r := a.rect
+
+	a.graphicsState("")
+
+	if bg := a.color("C", false); bg != "" {
+		fmt.Fprintf(&a.buf, "%s %.2f %.2f %.2f %.2f re f\n", bg, r.LL.X, r.LL.Y, r.Width(), r.Height())
+	}
+
+	w := a.borderWidth()
+	if w > 0 {
+		// The border uses the text color.
+		i := strings.LastIndex(col, " ")
+		fmt.Fprintf(&a.buf, "%s %s %.2f w\n", col[:i], strings.ToUpper(col[i+1:]), w)
+		fmt.Fprintf(&a.buf, "%.2f %.2f %.2f %.2f re S\n", r.LL.X+w/2, r.LL.Y+w/2, r.Width()-w, r.Height()-w)
+	}
+
+	if strings.TrimSpace(s) == "" {
+		return w > 0, nil
+	}
+
+	// Text goes inside the margins /RD.
+	r = a.innerRect()
+	pad := w + 2
+	lines := wrapText(s, annotAppearanceFont, fontSize, r.Width()-2*pad)
+
+	q := 0
+	if i := a.d.IntEntry("Q"); i != nil {
+		q = *i
+	}
+
+	if a.fontRef == nil {
+		fd := types.Dict{
+			"Type":     types.Name("Font"),
+			"Subtype":  types.Name("Type1"),
+			"BaseFont": types.Name(annotAppearanceFont),
+			"Encoding": types.Name("WinAnsiEncoding"),
+		}
+		ir, err := a.ctx.IndRefForNewObject(fd)
+		if err != nil {
+			return false, err
+		}
+		a.fontRef = ir
+	}
+	a.res["Font"] = types.Dict{"Helv": *a.fontRef}
+
+	fmt.Fprintf(&a.buf, "%.2f %.2f %.2f %.2f re W n\n", r.LL.X+pad, r.LL.Y+pad, r.Width()-2*pad, r.Height()-2*pad)
+	fmt.Fprintf(&a.buf, "BT /Helv %.2f Tf %s\n", fontSize, col)
+
+	y := r.UR.Y - pad - fontSize
+	for _, line := range lines {
+		x := r.LL.X + pad
+		if q > 0 {
+			lw := font.TextWidth(line, annotAppearanceFont, 1000) * fontSize / 1000
+			d := r.Width() - 2*pad - lw
+			if q == 1 {
+				d /= 2
+			}
+			x += d
+		}
+		s, err := types.Escape(winAnsiString(line))
+		if err != nil {
+			return false, err
+		}
+		fmt.Fprintf(&a.buf, "1 0 0 1 %.2f %.2f Tm (%s) Tj\n", x, y, *s)
+		y -= fontSize * 1.2
+	}
+
+	fmt.Fprint(&a.buf, "ET\n")
+	return true, nil
+}
+
+// formXObject wraps the collected content and resources into a form XObject.
+func (a *annotAppearance) formXObject() (*types.IndirectRef, error) {
+	sd, _ := a.ctx.NewStreamDictForBuf(a.buf.Bytes())
+	sd.InsertName("Type", "XObject")
+	sd.InsertName("Subtype", "Form")
+	sd.InsertInt("FormType", 1)
+	sd.Insert("BBox", a.rect.Array())
+	sd.Insert("Matrix", types.NewIntegerArray(1, 0, 0, 1, 0, 0))
+	if len(a.res) > 0 {
+		sd.Insert("Resources", a.res)
+	}
+
+	if err := sd.Encode(); err != nil {
+		return nil, err
+	}
+
+	return a.ctx.IndRefForNewObject(*sd)
+}
+
+// GenerateAnnotationAppearances synthesizes normal appearance streams for Square, Circle, Line, Highlight and FreeText
+// annotations of selectedPages lacking /AP and returns the number of appearance streams generated.
+func GenerateAnnotationAppearances(ctx *model.Context, selectedPages types.IntSet) (int, error) {
+	var fontRef *types.IndirectRef
+
+	n := 0
+
+	for pageNr := 1; pageNr <= ctx.PageCount; pageNr++ {
+		if selectedPages != nil {
+			if _, found := selectedPages[pageNr]; !found {
+				continue
+			}
+		}
+
+		pageDict, _, _, err := ctx.PageDict(pageNr, false)
+		if err != nil {
+			return 0, err
+		}
+
+		annots, err := ctx.DereferenceArray(pageDict["Annots"])
+		if err != nil {
+			return 0, err
+		}
+
+		for _, o := range annots {
+			d, err := ctx.DereferenceDict(o)
+			if err != nil {
+				return 0, err
+			}
+			if d == nil {
+				continue
+			}
+
+			if _, found := d.Find("AP"); found {
+				continue
+			}
+
+			st := d.Subtype()
+			if st == nil {
+				continue
+			}
+
+			gen, ok := annotAppearanceGenerators[*st]
+			if !ok {
+				continue
+			}
+
+			arr, err := ctx.DereferenceArray(d["Rect"])
+			if err != nil || len(arr) != 4 {
+				continue
+			}
+
+			rect, err := ctx.RectForArray(arr)
+			if err != nil {
+				return 0, err
+			}
+			rect = types.NewRectangle(min(rect.LL.X, rect.UR.X), min(rect.LL.Y, rect.UR.Y), max(rect.LL.X, rect.UR.X), max(rect.LL.Y, rect.UR.Y))
+
+			a := &annotAppearance{ctx: ctx, d: d, rect: rect, res: types.Dict{}, fontRef: fontRef}
+
+			ok, err = gen(a)
+			if err != nil {
+				return 0, err
+			}
+			if !ok {
+				continue
+			}
+			fontRef = a.fontRef
+
+			ir, err := a.formXObject()
+			if err != nil {
+				return 0, err
+			}
+
+			d["AP"] = types.Dict{"N": *ir}
+			n++
+		}
+	}
+
+	return n, nil
+}
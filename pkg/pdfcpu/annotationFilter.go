@@ -0,0 +1,249 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// AnnotationFilter selects annotations by type, author and modification date.
+// Zero values match all annotations.
+type AnnotationFilter struct {
+	Types  []model.AnnotationType
+	Author string    // Case insensitive match of the markup annotation's /T entry.
+	From   time.Time // Modified at or after From.
+	Until  time.Time // Modified before the end of the day Until.
+}
+
+// AnnotationInfo describes a page annotation.
+// The JSON representation is considered stable.
+type AnnotationInfo struct {
+	Page     int    `json:"page"`
+	ObjNr    int    `json:"objNr"`
+	ID       string `json:"id,omitempty"`
+	Type     string `json:"type"`
+	Rect     string `json:"rect"`
+	Contents string `json:"contents,omitempty"`
+	Author   string `json:"author,omitempty"`
+	Subject  string `json:"subject,omitempty"`
+	Modified string `json:"modified,omitempty"`
+	Created  string `json:"created,omitempty"`
+	IRT      int    `json:"irt,omitempty"`
+	mod      time.Time
+}
+
+// ParseAnnotationFilter returns an annotation filter for a comma separated list of annotation types,
+// an author and a date range in dateFormat.
+func ParseAnnotationFilter(annotTypes, author, from, until, dateFormat string) (*AnnotationFilter, error) {
+	f := &AnnotationFilter{Author: author}
+
+	if annotTypes != "" {
+		for _, s := range strings.Split(annotTypes, ",") {
+			s = strings.TrimSpace(s)
+			t, ok := annotationType(s)
+			if !ok {
+				return nil, errors.Errorf("pdfcpu: unknown annotation type: %s", s)
+			}
+			f.Types = append(f.Types, t)
+		}
+	}
+
+	var err error
+
+	if from != "" {
+		if f.From, err = time.ParseInLocation(dateFormat, from, time.Local); err != nil {
+			return nil, errors.Errorf("pdfcpu: invalid date: %s, expected format: %s", from, dateFormat)
+		}
+	}
+
+	if until != "" {
+		if f.Until, err = time.ParseInLocation(dateFormat, until, time.Local); err != nil {
+			return nil, errors.Errorf("pdfcpu: invalid date: %s, expected format: %s", until, dateFormat)
+		}
+	}
+
+	return f, nil
+}
+
+func annotationType(s string) (model.AnnotationType, bool) {
+	for k, v := range model.AnnotTypes {
+		if strings.EqualFold(k, s) {
+			return v, true
+		}
+	}
+	return 0, false
+}
+
+func (f AnnotationFilter) empty() bool {
+	return len(f.Types) == 0 && f.Author == "" && f.From.IsZero() && f.Until.IsZero()
+}
+
+func (f AnnotationFilter) matchesType(t model.AnnotationType) bool {
+	if len(f.Types) == 0 {
+		return true
+	}
+	for _, t1 := range f.Types {
+		if t1 == t {
+			return true
+		}
+	}
+	return false
+}
+
+func (f AnnotationFilter) matches(ai AnnotationInfo) bool {
+	if f.Author != "" && !strings.EqualFold(f.Author, ai.Author) {
+		return false
+	}
+
+	if f.From.IsZero() && f.Until.IsZero() {
+		return true
+	}
+
+	if ai.mod.IsZero() {
+		return false
+	}
+
+	if !f.From.IsZero() && ai.mod.Before(f.From) {
+		return false
+	}
+
+	return f.Until.IsZero() || ai.mod.Before(f.Until.AddDate(0, 0, 1))
+}
+
+func annotationDate(ctx *model.Context, d types.Dict, key string) (string, time.Time) {
+	o, found := d.Find(key)
+	if !found {
+		return "", time.Time{}
+	}
+
+	s, err := ctx.DereferenceStringOrHexLiteral(o, model.V10, nil)
+	if err != nil {
+		return "", time.Time{}
+	}
+
+	t, ok := types.DateTime(s, true)
+	if !ok {
+		return s, time.Time{}
+	}
+
+	return t.Format(time.RFC3339), t
+}
+
+func annotationText(ctx *model.Context, d types.Dict, key string) string {
+	o, found := d.Find(key)
+	if !found {
+		return ""
+	}
+	s, err := ctx.DereferenceText(o)
+	if err != nil {
+		return ""
+	}
+	return s
+}
+
+func annotationInfo(ctx *model.Context, pageNr, objNr int, ann model.AnnotationRenderer) AnnotationInfo {
+	ai := AnnotationInfo{
+		Page:     pageNr,
+		ObjNr:    objNr,
+		ID:       ann.ID(),
+		Type:     model.AnnotTypeStrings[ann.Type()],
+		Rect:     ann.RectString(),
+		Contents: ann.ContentString(),
+	}
+
+	// Direct annotation dicts are cached using made up object numbers.
+	entry, found := ctx.FindTableEntryLight(objNr)
+	if !found || entry.Free {
+		return ai
+	}
+
+	d, ok := entry.Object.(types.Dict)
+	if !ok {
+		return ai
+	}
+
+	ai.Author = annotationText(ctx, d, "T")
+	ai.Subject = annotationText(ctx, d, "Subj")
+	ai.Modified, ai.mod = annotationDate(ctx, d, "M")
+	ai.Created, _ = annotationDate(ctx, d, "CreationDate")
+
+	if ir := d.IndirectRefEntry("IRT"); ir != nil {
+		ai.IRT = ir.ObjectNumber.Value()
+	}
+
+	return ai
+}
+
+// FilterAnnotations returns the annotations of annots matching f.
+func FilterAnnotations(ctx *model.Context, annots map[int]model.PgAnnots, f *AnnotationFilter) map[int]model.PgAnnots {
+	if f == nil || f.empty() {
+		return annots
+	}
+
+	m := map[int]model.PgAnnots{}
+
+	for pageNr, pgAnnots := range annots {
+		pgAnnots1 := model.PgAnnots{}
+		for annType, annot := range pgAnnots {
+			if !f.matchesType(annType) {
+				continue
+			}
+			annot1 := model.Annot{IndRefs: annot.IndRefs, Map: model.AnnotMap{}}
+			for objNr, ann := range annot.Map {
+				if f.matches(annotationInfo(ctx, pageNr, objNr, ann)) {
+					annot1.Map[objNr] = ann
+				}
+			}
+			if len(annot1.Map) > 0 {
+				pgAnnots1[annType] = annot1
+			}
+		}
+		if len(pgAnnots1) > 0 {
+			m[pageNr] = pgAnnots1
+		}
+	}
+
+	return m
+}
+
+// AnnotationInfos returns descriptions of annots sorted by page and object number.
+func AnnotationInfos(ctx *model.Context, annots map[int]model.PgAnnots) []AnnotationInfo {
+	aa := []AnnotationInfo{}
+
+	for pageNr, pgAnnots := range annots {
+		for _, annot := range pgAnnots {
+			for objNr, ann := range annot.Map {
+				aa = append(aa, annotationInfo(ctx, pageNr, objNr, ann))
+			}
+		}
+	}
+
+	sort.Slice(aa, func(i, j int) bool {
+		if aa[i].Page != aa[j].Page {
+			return aa[i].Page < aa[j].Page
+		}
+		return aa[i].ObjNr < aa[j].ObjNr
+	})
+
+	return aa
+}
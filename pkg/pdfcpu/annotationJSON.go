@@ -0,0 +1,397 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/color"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// AnnotationDescriptor describes an annotation to be added to a set of pages.
+// All coordinates are in default user space units.
+type AnnotationDescriptor struct {
+	Pages    string     `json:"pages"` // Page selection, eg. "1-3,even", defaults to all pages.
+	Type     string     `json:"type"`  // Text, Link, FreeText, Line, Square, Circle, Polygon, PolyLine, Highlight, Underline, Squiggly, StrikeOut, Ink
+	Rect     [4]float64 `json:"rect"`  // llx, lly, urx, ury - optional for types defined by points.
+	Contents string     `json:"contents"`
+	ID       string     `json:"id"`
+	Color    string     `json:"col"` // #RRGGBB, "r g b" or a color name.
+	Author   string     `json:"author"`
+	Subject  string     `json:"subject"`
+	Opacity  *float64   `json:"opacity"`
+
+	// Text
+	Icon string `json:"icon"` // Comment, Key, Note, Help, NewParagraph, Paragraph, Insert
+	Open bool   `json:"open"`
+
+	// Link
+	URI  string `json:"uri"`
+	Dest int    `json:"dest"` // Destination page number.
+
+	// FreeText
+	Text      string `json:"text"`
+	FontName  string `json:"fontName"`
+	FontSize  int    `json:"fontSize"`
+	FontColor string `json:"fontCol"`
+	Alignment string `json:"align"` // left, center, right
+
+	// Line, Square, Circle, Polygon, PolyLine, Ink
+	FillColor   string      `json:"fillCol"`
+	BorderWidth float64     `json:"borderWidth"`
+	BorderStyle string      `json:"borderStyle"` // solid, dashed, beveled, inset, underline
+	Line        []float64   `json:"line"`        // Line: x1, y1, x2, y2
+	LineEndings []string    `json:"lineEndings"` // Line, PolyLine: begin, end eg. None, OpenArrow, ClosedArrow, Circle, Square
+	Vertices    []float64   `json:"vertices"`    // Polygon, PolyLine: x1, y1, x2, y2...
+	QuadPoints  []float64   `json:"quadPoints"`  // Highlight, Underline, Squiggly, StrikeOut: 8 numbers per quadrilateral, defaults to rect.
+	Ink         [][]float64 `json:"ink"`         // Ink: paths of x, y pairs
+}
+
+// AnnotationGroup represents the JSON input for adding annotations.
+type AnnotationGroup struct {
+	Annotations []AnnotationDescriptor `json:"annotations"`
+}
+
+// ParseAnnotationGroup parses annotation descriptors from JSON input read from rd.
+func ParseAnnotationGroup(rd io.Reader) (*AnnotationGroup, error) {
+	bb, err := io.ReadAll(rd)
+	if err != nil {
+		return nil, err
+	}
+
+	if !json.Valid(bb) {
+		return nil, errors.New("pdfcpu: invalid JSON encoding detected")
+	}
+
+	ag := &AnnotationGroup{}
+	if err := json.Unmarshal(bb, ag); err != nil {
+		return nil, err
+	}
+
+	if len(ag.Annotations) == 0 {
+		return nil, errors.New("pdfcpu: missing annotations")
+	}
+
+	return ag, nil
+}
+
+func optionalColor(s string) (*color.SimpleColor, error) {
+	if s == "" {
+		return nil, nil
+	}
+	c, err := color.ParseColor(s)
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func parseBorderStyle(s string) (model.BorderStyle, error) {
+	switch strings.ToLower(s) {
+	case "", "solid":
+		return model.BSSolid, nil
+	case "dashed":
+		return model.BSDashed, nil
+	case "beveled":
+		return model.BSBeveled, nil
+	case "inset":
+		return model.BSInset, nil
+	case "underline":
+		return model.BSUnderline, nil
+	}
+	return 0, errors.Errorf("pdfcpu: unknown border style (solid, dashed, beveled, inset, underline): %s", s)
+}
+
+func parseLineEndingStyle(s string) (*model.LineEndingStyle, error) {
+	if s == "" {
+		return nil, nil
+	}
+	for les := model.LESquare; les <= model.LESlash; les++ {
+		if strings.EqualFold(model.LineEndingStyleName(les), s) {
+			return &les, nil
+		}
+	}
+	return nil, errors.Errorf("pdfcpu: unknown line ending style: %s", s)
+}
+
+func (ad AnnotationDescriptor) lineEndings() (*model.LineEndingStyle, *model.LineEndingStyle, error) {
+	if len(ad.LineEndings) > 2 {
+		return nil, nil, errors.New("pdfcpu: \"lineEndings\": expected begin and end style")
+	}
+	var ss [2]string
+	copy(ss[:], ad.LineEndings)
+	begin, err := parseLineEndingStyle(ss[0])
+	if err != nil {
+		return nil, nil, err
+	}
+	end, err := parseLineEndingStyle(ss[1])
+	if err != nil {
+		return nil, nil, err
+	}
+	return begin, end, nil
+}
+
+// boundingRect returns the rectangle enclosing the points of all pp extended by d.
+func boundingRect(d float64, pp ...[]float64) *types.Rectangle {
+	var r *types.Rectangle
+	for _, p := range pp {
+		for i := 0; i+1 < len(p); i += 2 {
+			x, y := p[i], p[i+1]
+			if r == nil {
+				r = types.NewRectangle(x, y, x, y)
+				continue
+			}
+			r.LL.X, r.LL.Y = min(r.LL.X, x), min(r.LL.Y, y)
+			r.UR.X, r.UR.Y = max(r.UR.X, x), max(r.UR.Y, y)
+		}
+	}
+	if r == nil {
+		return nil
+	}
+	return types.NewRectangle(r.LL.X-d, r.LL.Y-d, r.UR.X+d, r.UR.Y+d)
+}
+
+// rect returns the annotation rectangle falling back to the bounding box of pp.
+func (ad AnnotationDescriptor) rect(pp ...[]float64) (types.Rectangle, error) {
+	r := types.NewRectangle(ad.Rect[0], ad.Rect[1], ad.Rect[2], ad.Rect[3])
+	if r.Width() == 0 || r.Height() == 0 {
+		if r = boundingRect(max(ad.BorderWidth, 1), pp...); r == nil {
+			return types.Rectangle{}, errors.Errorf("pdfcpu: %s annotation: missing \"rect\"", ad.Type)
+		}
+	}
+	return *r, nil
+}
+
+func (ad AnnotationDescriptor) quadPoints(r types.Rectangle) (types.QuadPoints, error) {
+	qp := types.QuadPoints{}
+	if len(ad.QuadPoints) == 0 {
+		qp.AddQuadLiteral(*types.NewQuadLiteralForRect(&r))
+		return qp, nil
+	}
+	if len(ad.QuadPoints)%8 > 0 {
+		return nil, errors.New("pdfcpu: \"quadPoints\": expected 8 numbers per quadrilateral")
+	}
+	for i := 0; i < len(ad.QuadPoints); i += 8 {
+		q := ad.QuadPoints[i : i+8]
+		qp.AddQuadLiteral(types.QuadLiteral{
+			P1: types.Point{X: q[0], Y: q[1]},
+			P2: types.Point{X: q[2], Y: q[3]},
+			P3: types.Point{X: q[4], Y: q[5]},
+			P4: types.Point{X: q[6], Y: q[7]},
+		})
+	}
+	return qp, nil
+}
+
+func (ad AnnotationDescriptor) textMarkupAnnotation(typ model.AnnotationType, r types.Rectangle, col *color.SimpleColor) (model.AnnotationRenderer, error) {
+	qp, err := ad.quadPoints(r)
+	if err != nil {
+		return nil, err
+	}
+
+	switch typ {
+	case model.AnnHighLight:
+		return model.NewHighlightAnnotation(r, ad.Contents, ad.ID, "", model.AnnPrint, col, 0, 0, 0, ad.Author, nil, ad.Opacity, "", ad.Subject, qp), nil
+	case model.AnnUnderline:
+		return model.NewUnderlineAnnotation(r, ad.Contents, ad.ID, "", model.AnnPrint, col, 0, 0, 0, ad.Author, nil, ad.Opacity, "", ad.Subject, qp), nil
+	case model.AnnSquiggly:
+		return model.NewSquigglyAnnotation(r, ad.Contents, ad.ID, "", model.AnnPrint, col, 0, 0, 0, ad.Author, nil, ad.Opacity, "", ad.Subject, qp), nil
+	}
+	return model.NewStrikeOutAnnotation(r, ad.Contents, ad.ID, "", model.AnnPrint, col, 0, 0, 0, ad.Author, nil, ad.Opacity, "", ad.Subject, qp), nil
+}
+
+func (ad AnnotationDescriptor) freeTextAnnotation(r types.Rectangle, col *color.SimpleColor) (model.AnnotationRenderer, error) {
+	fontCol, err := optionalColor(ad.FontColor)
+	if err != nil {
+		return nil, err
+	}
+
+	hAlign := types.AlignLeft
+	if ad.Alignment != "" {
+		if hAlign, err = types.ParseHorAlignment(ad.Alignment); err != nil {
+			return nil, err
+		}
+	}
+
+	bs, err := parseBorderStyle(ad.BorderStyle)
+	if err != nil {
+		return nil, err
+	}
+
+	fontName, fontSize := ad.FontName, ad.FontSize
+	if fontName == "" {
+		fontName = "Helvetica"
+	}
+	if fontSize <= 0 {
+		fontSize = 12
+	}
+
+	text := ad.Text
+	if text == "" {
+		text = ad.Contents
+	}
+
+	return model.NewFreeTextAnnotation(r, ad.Contents, ad.ID, "", model.AnnPrint, col, ad.Author, nil, ad.Opacity, "", ad.Subject,
+		text, hAlign, fontName, fontSize, fontCol, "", nil, nil, nil, 0, 0, 0, 0, ad.BorderWidth, bs, false, 0), nil
+}
+
+func (ad AnnotationDescriptor) shapeAnnotation(typ model.AnnotationType, col *color.SimpleColor) (model.AnnotationRenderer, error) {
+	fillCol, err := optionalColor(ad.FillColor)
+	if err != nil {
+		return nil, err
+	}
+
+	bs, err := parseBorderStyle(ad.BorderStyle)
+	if err != nil {
+		return nil, err
+	}
+
+	switch typ {
+
+	case model.AnnSquare, model.AnnCircle:
+		r, err := ad.rect()
+		if err != nil {
+			return nil, err
+		}
+		if typ == model.AnnSquare {
+			return model.NewSquareAnnotation(r, ad.Contents, ad.ID, "", model.AnnPrint, col, ad.Author, nil, ad.Opacity, "", ad.Subject,
+				fillCol, 0, 0, 0, 0, ad.BorderWidth, bs, false, 0), nil
+		}
+		return model.NewCircleAnnotation(r, ad.Contents, ad.ID, "", model.AnnPrint, col, ad.Author, nil, ad.Opacity, "", ad.Subject,
+			fillCol, 0, 0, 0, 0, ad.BorderWidth, bs, false, 0), nil
+
+	case model.AnnLine:
+		if len(ad.Line) != 4 {
+			return nil, errors.New("pdfcpu: Line annotation: \"line\": expected x1, y1, x2, y2")
+		}
+		r, err := ad.rect(ad.Line)
+		if err != nil {
+			return nil, err
+		}
+		begin, end, err := ad.lineEndings()
+		if err != nil {
+			return nil, err
+		}
+		p1, p2 := types.Point{X: ad.Line[0], Y: ad.Line[1]}, types.Point{X: ad.Line[2], Y: ad.Line[3]}
+		return model.NewLineAnnotation(r, ad.Contents, ad.ID, "", model.AnnPrint, col, ad.Author, nil, ad.Opacity, "", ad.Subject,
+			p1, p2, begin, end, 0, 0, 0, nil, nil, false, false, 0, 0, fillCol, ad.BorderWidth, bs), nil
+
+	case model.AnnPolygon, model.AnnPolyLine:
+		if len(ad.Vertices) < 4 || len(ad.Vertices)%2 > 0 {
+			return nil, errors.Errorf("pdfcpu: %s annotation: \"vertices\": expected at least 2 points", ad.Type)
+		}
+		r, err := ad.rect(ad.Vertices)
+		if err != nil {
+			return nil, err
+		}
+		vertices := types.NewNumberArray(ad.Vertices...)
+		if typ == model.AnnPolygon {
+			return model.NewPolygonAnnotation(r, ad.Contents, ad.ID, "", model.AnnPrint, col, ad.Author, nil, ad.Opacity, "", ad.Subject,
+				vertices, nil, nil, nil, fillCol, ad.BorderWidth, bs, false, 0), nil
+		}
+		begin, end, err := ad.lineEndings()
+		if err != nil {
+			return nil, err
+		}
+		return model.NewPolyLineAnnotation(r, ad.Contents, ad.ID, "", model.AnnPrint, col, ad.Author, nil, ad.Opacity, "", ad.Subject,
+			vertices, nil, nil, nil, fillCol, ad.BorderWidth, bs, begin, end), nil
+	}
+
+	// Ink
+	if len(ad.Ink) == 0 {
+		return nil, errors.New("pdfcpu: Ink annotation: missing \"ink\"")
+	}
+	ink := make([]model.InkPath, len(ad.Ink))
+	for i, p := range ad.Ink {
+		if len(p) < 4 || len(p)%2 > 0 {
+			return nil, errors.New("pdfcpu: Ink annotation: \"ink\": expected paths of at least 2 points")
+		}
+		ink[i] = model.InkPath(p)
+	}
+	r, err := ad.rect(ad.Ink...)
+	if err != nil {
+		return nil, err
+	}
+	return model.NewInkAnnotation(r, ad.Contents, ad.ID, "", model.AnnPrint, col, ad.Author, nil, ad.Opacity, "", ad.Subject,
+		ink, ad.BorderWidth, bs), nil
+}
+
+// Renderer returns the annotation described by ad.
+func (ad AnnotationDescriptor) Renderer() (model.AnnotationRenderer, error) {
+	typ, ok := annotationType(ad.Type)
+	if !ok {
+		return nil, errors.Errorf("pdfcpu: unknown annotation type: %s", ad.Type)
+	}
+
+	col, err := optionalColor(ad.Color)
+	if err != nil {
+		return nil, err
+	}
+
+	switch typ {
+
+	case model.AnnText:
+		r, err := ad.rect()
+		if err != nil {
+			return nil, err
+		}
+		icon := ad.Icon
+		if icon == "" {
+			icon = "Note"
+		}
+		return model.NewTextAnnotation(r, ad.Contents, ad.ID, "", model.AnnPrint|model.AnnNoZoom|model.AnnNoRotate, col, ad.Author, nil, ad.Opacity, "", ad.Subject,
+			0, 0, 0, ad.Open, icon), nil
+
+	case model.AnnLink:
+		r, err := ad.rect()
+		if err != nil {
+			return nil, err
+		}
+		var dest *model.Destination
+		if ad.Dest > 0 {
+			dest = &model.Destination{Typ: model.DestFit, PageNr: ad.Dest}
+		}
+		if dest == nil && ad.URI == "" {
+			return nil, errors.New("pdfcpu: Link annotation: missing \"uri\" or \"dest\"")
+		}
+		return model.NewLinkAnnotation(r, ad.Contents, ad.ID, "", model.AnnPrint, col, dest, ad.URI, nil, col != nil, ad.BorderWidth, model.BSSolid), nil
+
+	case model.AnnFreeText:
+		r, err := ad.rect()
+		if err != nil {
+			return nil, err
+		}
+		return ad.freeTextAnnotation(r, col)
+
+	case model.AnnHighLight, model.AnnUnderline, model.AnnSquiggly, model.AnnStrikeOut:
+		r, err := ad.rect(ad.QuadPoints)
+		if err != nil {
+			return nil, err
+		}
+		return ad.textMarkupAnnotation(typ, r, col)
+
+	case model.AnnSquare, model.AnnCircle, model.AnnLine, model.AnnPolygon, model.AnnPolyLine, model.AnnInk:
+		return ad.shapeAnnotation(typ, col)
+	}
+
+	return nil, errors.Errorf("pdfcpu: unsupported annotation type: %s", ad.Type)
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// markupAnnotation returns the page number, indirect reference and rectangle of the markup annotation objNr.
+func markupAnnotation(ctx *model.Context, objNr int) (int, types.IndirectRef, *types.Rectangle, error) {
+	var ir types.IndirectRef
+
+	for pageNr := 1; pageNr <= ctx.PageCount; pageNr++ {
+		d, _, _, err := ctx.PageDict(pageNr, false)
+		if err != nil {
+			return 0, ir, nil, err
+		}
+		if d == nil {
+			continue
+		}
+
+		annots, err := ctx.DereferenceArray(d["Annots"])
+		if err != nil {
+			return 0, ir, nil, err
+		}
+
+		i, err := findAnnotByObjNr(objNr, annots)
+		if err != nil {
+			return 0, ir, nil, err
+		}
+		if i < 0 {
+			continue
+		}
+
+		d1, err := ctx.DereferenceDict(annots[i])
+		if err != nil || d1 == nil {
+			return 0, ir, nil, errors.Errorf("pdfcpu: invalid annotation obj#%d", objNr)
+		}
+
+		if st := d1.Subtype(); st == nil || !markupAnnotTypes[*st] {
+			return 0, ir, nil, errors.Errorf("pdfcpu: obj#%d is no markup annotation", objNr)
+		}
+
+		arr, err := ctx.DereferenceArray(d1["Rect"])
+		if err != nil {
+			return 0, ir, nil, err
+		}
+
+		r, err := ctx.RectForArray(arr)
+		if err != nil {
+			return 0, ir, nil, err
+		}
+
+		return pageNr, annots[i].(types.IndirectRef), r, nil
+	}
+
+	return 0, ir, nil, errors.Errorf("pdfcpu: no page annotation with obj#%d", objNr)
+}
+
+func addReply(ctx *model.Context, objNr int, newReply func(r types.Rectangle, irt types.IndirectRef) (model.AnnotationRenderer, error), incr bool) (int, error) {
+	pageNr, irt, r, err := markupAnnotation(ctx, objNr)
+	if err != nil {
+		return 0, err
+	}
+
+	ar, err := newReply(*r, irt)
+	if err != nil {
+		return 0, err
+	}
+
+	if incr {
+		ctx.Write.Increment = true
+		ctx.Write.Offset = ctx.Read.FileSize
+	}
+
+	ir, _, err := AddAnnotationToPage(ctx, pageNr, ar, incr)
+	if err != nil {
+		return 0, err
+	}
+
+	return ir.ObjectNumber.Value(), nil
+}
+
+// AddReply adds a reply by author to the markup annotation objNr and returns the object number of the reply (see 12.5.6.2).
+func AddReply(ctx *model.Context, objNr int, contents, author string, incr bool) (int, error) {
+	return addReply(ctx, objNr, func(r types.Rectangle, irt types.IndirectRef) (model.AnnotationRenderer, error) {
+		return model.NewReplyAnnotation(r, irt, contents, author), nil
+	}, incr)
+}
+
+// SetAnnotationState records state set by author for the markup annotation objNr
+// and returns the object number of the corresponding state annotation (see 12.5.6.3).
+func SetAnnotationState(ctx *model.Context, objNr int, state, author string, incr bool) (int, error) {
+	return addReply(ctx, objNr, func(r types.Rectangle, irt types.IndirectRef) (model.AnnotationRenderer, error) {
+		return model.NewStateAnnotation(r, irt, state, author)
+	}, incr)
+}
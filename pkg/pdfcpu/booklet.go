@@ -594,6 +594,11 @@ func BookletFromPDF(ctx *model.Context, selectedPages types.IntSet, nup *model.N
 
 	nup.PageDim = &types.Dim{Width: mb.Width(), Height: mb.Height()}
 
+	objNrs, err := pageObjNrs(ctx)
+	if err != nil {
+		return err
+	}
+
 	if err = bookletPages(ctx, selectedPages, nup, pagesDict, pagesIndRef); err != nil {
 		return err
 	}
@@ -605,5 +610,13 @@ func BookletFromPDF(ctx *model.Context, selectedPages types.IntSet, nup *model.N
 	}
 
 	rootDict.Update("Pages", *pagesIndRef)
-	return nil
+
+	// Point bookmarks and named destinations to the sheets holding their pages.
+	var pageNrs []int
+	for _, bp := range GetBookletOrdering(selectedPages, nup) {
+		pageNrs = append(pageNrs, bp.Number)
+	}
+	m := pageRemapForSheets(objNrs, pagesDict.ArrayEntry("Kids"), pageNrs, nup.N())
+
+	return remapDestinations(ctx, m)
 }
@@ -20,7 +20,9 @@ import (
 	"bytes"
 	"encoding/json"
 	"io"
+	"math"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -306,6 +308,46 @@ func BookmarkList(ctx *model.Context) ([]string, error) {
 	return bookmarkList(bms, 0)
 }
 
+// BookmarkPageRange returns the page range of the section introduced by the first bookmark titled title.
+// The section reaches until before the next bookmark of the same or a higher level.
+func BookmarkPageRange(ctx *model.Context, title string) (int, int, error) {
+	bms, err := Bookmarks(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var ss []SplitSection
+	flattenBookmarks(bms, "", 1, math.MaxInt32, &ss)
+
+	i := -1
+	for j, s := range ss {
+		if s.Title == title {
+			i = j
+			break
+		}
+		if i < 0 && strings.EqualFold(s.Title, title) {
+			i = j
+		}
+	}
+
+	if i < 0 {
+		return 0, 0, errors.Errorf("pdfcpu: no bookmark titled %q", title)
+	}
+
+	from, thru := ss[i].From, ctx.PageCount
+	for _, s := range ss[i+1:] {
+		if s.Level <= ss[i].Level {
+			thru = from
+			if s.From > from {
+				thru = s.From - 1
+			}
+			break
+		}
+	}
+
+	return from, thru, nil
+}
+
 func ExportBookmarks(ctx *model.Context, source string) (*BookmarkTree, error) {
 	bms, err := Bookmarks(ctx)
 	if err != nil {
@@ -630,7 +672,122 @@ func parseBookmarksFromJSON(bb []byte) (*BookmarkTree, error) {
 	return bmTree, nil
 }
 
-// ImportBookmarks creates/replaces outlines in ctx as provided by rd.
+type bookmarkNode struct {
+	bm     Bookmark
+	indent int
+	kids   []*bookmarkNode
+}
+
+func (n *bookmarkNode) bookmarks() []Bookmark {
+	bms := []Bookmark{}
+	for _, kid := range n.kids {
+		bm := kid.bm
+		if len(kid.kids) > 0 {
+			bm.Kids = kid.bookmarks()
+		}
+		bms = append(bms, bm)
+	}
+	return bms
+}
+
+// bookmarkLine parses the indentation, title, style and page number out of a line like "  - **Title** | 12".
+func bookmarkLine(s string) (int, Bookmark, error) {
+	bm := Bookmark{}
+
+	indent := 0
+	for ; len(s) > 0; s = s[1:] {
+		if s[0] == ' ' {
+			indent++
+		} else if s[0] == '\t' {
+			indent += 4
+		} else {
+			break
+		}
+	}
+
+	if strings.HasPrefix(s, "#") {
+		// Markdown heading, the heading level takes precedence over indentation.
+		indent = len(s) - len(strings.TrimLeft(s, "#"))
+		s = s[indent:]
+	} else if i := strings.IndexAny(s, " \t"); i > 0 {
+		// List item marker
+		m := s[:i]
+		if m == "-" || m == "*" || m == "+" || strings.Trim(m, "0123456789") == "." || strings.Trim(m, "0123456789") == ")" {
+			s = s[i:]
+		}
+	}
+
+	i := strings.LastIndex(s, "|")
+	if i < 0 {
+		return 0, bm, errors.New("missing \"| page\"")
+	}
+
+	pageNr, err := strconv.Atoi(strings.TrimSpace(s[i+1:]))
+	if err != nil || pageNr < 1 {
+		return 0, bm, errors.Errorf("invalid page number: %s", strings.TrimSpace(s[i+1:]))
+	}
+	bm.PageFrom = pageNr
+
+	title := strings.TrimSpace(s[:i])
+	for _, m := range []string{"***", "**", "*", "___", "__", "_"} {
+		if len(title) > 2*len(m) && strings.HasPrefix(title, m) && strings.HasSuffix(title, m) {
+			title = title[len(m) : len(title)-len(m)]
+			bm.Bold = len(m) > 1
+			bm.Italic = len(m) != 2
+			break
+		}
+	}
+	if title == "" {
+		return 0, bm, errors.New("missing title")
+	}
+	bm.Title = title
+
+	return indent, bm, nil
+}
+
+// parseBookmarksFromText parses bookmarks from an indented text like:
+//
+//	Chapter 1 | 1
+//	    Section 1.1 | 2
+//	**Chapter 2** | 5
+//
+// Lines may start with a Markdown list marker (-, *, +, 1.) or be Markdown headings.
+// Nesting is defined by indentation or the level of Markdown headings.
+// Titles wrapped in ** or * (__ or _) are bold or italic.
+func parseBookmarksFromText(bb []byte) ([]Bookmark, error) {
+	root := &bookmarkNode{indent: -1}
+	stack := []*bookmarkNode{root}
+
+	for i, line := range strings.Split(string(bb), "\n") {
+		line = strings.TrimRight(line, " \t\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		indent, bm, err := bookmarkLine(line)
+		if err != nil {
+			return nil, errors.Errorf("pdfcpu: bookmarks line %d: %v", i+1, err)
+		}
+
+		for stack[len(stack)-1].indent >= indent {
+			stack = stack[:len(stack)-1]
+		}
+
+		n := &bookmarkNode{bm: bm, indent: indent}
+		parent := stack[len(stack)-1]
+		parent.kids = append(parent.kids, n)
+		stack = append(stack, n)
+	}
+
+	if len(root.kids) == 0 {
+		return nil, errors.New("pdfcpu: no bookmarks found")
+	}
+
+	return root.bookmarks(), nil
+}
+
+// ImportBookmarks creates/replaces outlines in ctx as provided by rd
+// either in JSON or as indented text or Markdown list.
 func ImportBookmarks(ctx *model.Context, rd io.Reader, replace bool) (bool, error) {
 
 	var buf bytes.Buffer
@@ -638,7 +795,19 @@ func ImportBookmarks(ctx *model.Context, rd io.Reader, replace bool) (bool, erro
 		return false, err
 	}
 
-	bmTree, err := parseBookmarksFromJSON(buf.Bytes())
+	bb := bytes.TrimSpace(buf.Bytes())
+
+	var (
+		bmTree *BookmarkTree
+		err    error
+	)
+
+	if len(bb) > 0 && bb[0] == '{' {
+		bmTree, err = parseBookmarksFromJSON(bb)
+	} else {
+		bmTree = &BookmarkTree{}
+		bmTree.Bookmarks, err = parseBookmarksFromText(buf.Bytes())
+	}
 	if err != nil {
 		return false, err
 	}
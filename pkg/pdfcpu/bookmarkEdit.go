@@ -0,0 +1,359 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// The following operations edit the outline tree in place
+// and therefore preserve all outline item attributes like destinations, actions, colors and styles.
+
+// outlineItem is an outline item dict along with its kids.
+type outlineItem struct {
+	ir       types.IndirectRef
+	d        types.Dict
+	title    string
+	pageNr   int  // Destination page, 0 if there is none.
+	dangling bool // Destination page has been removed.
+	open     bool
+	kids     []*outlineItem
+}
+
+// outlineItemPageNr returns the destination page of d and whether this destination is dangling.
+func outlineItemPageNr(ctx *model.Context, d types.Dict) (int, bool) {
+	dest, found := d["Dest"]
+	if !found {
+		act, err := ctx.DereferenceDict(d["A"])
+		if err != nil || act == nil {
+			return 0, false
+		}
+		if s := act.NameEntry("S"); s == nil || *s != "GoTo" {
+			return 0, false
+		}
+		dest = act["D"]
+	}
+
+	o, err := ctx.Dereference(dest)
+	if err != nil || o == nil {
+		return 0, true
+	}
+
+	pageNr, err := PageNrFromDestination(ctx, o)
+	if err != nil || pageNr < 1 || pageNr > ctx.PageCount {
+		return 0, true
+	}
+
+	return pageNr, false
+}
+
+func outlineItems(ctx *model.Context, first *types.IndirectRef, visited map[int]bool) ([]*outlineItem, error) {
+	var (
+		items []*outlineItem
+		d     types.Dict
+		err   error
+	)
+
+	for ir := first; ir != nil; ir = d.IndirectRefEntry("Next") {
+
+		if visited[ir.ObjectNumber.Value()] {
+			return nil, errCorruptedBookmarks
+		}
+		visited[ir.ObjectNumber.Value()] = true
+
+		if d, err = ctx.DereferenceDict(*ir); err != nil {
+			return nil, err
+		}
+		if d == nil {
+			return nil, errCorruptedBookmarks
+		}
+
+		title, err := title(ctx, d)
+		if err != nil {
+			return nil, err
+		}
+
+		item := &outlineItem{ir: *ir, d: d, title: title}
+		item.pageNr, item.dangling = outlineItemPageNr(ctx, d)
+		if c := d.IntEntry("Count"); c != nil && *c > 0 {
+			item.open = true
+		}
+
+		if first := d.IndirectRefEntry("First"); first != nil {
+			if item.kids, err = outlineItems(ctx, first, visited); err != nil {
+				return nil, err
+			}
+		}
+
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// linkOutlineItems links items as kids of parent and returns the number of visible descendants of parent when open.
+func linkOutlineItems(parent types.Dict, parentIndRef types.IndirectRef, items []*outlineItem) int {
+	if len(items) == 0 {
+		parent.Delete("First")
+		parent.Delete("Last")
+		parent.Delete("Count")
+		return 0
+	}
+
+	parent["First"] = items[0].ir
+	parent["Last"] = items[len(items)-1].ir
+
+	visible := 0
+
+	for i, item := range items {
+		d := item.d
+		d["Parent"] = parentIndRef
+
+		d.Delete("Prev")
+		if i > 0 {
+			d["Prev"] = items[i-1].ir
+		}
+
+		d.Delete("Next")
+		if i < len(items)-1 {
+			d["Next"] = items[i+1].ir
+		}
+
+		visible++
+
+		c := linkOutlineItems(d, item.ir, item.kids)
+		if len(item.kids) == 0 {
+			continue
+		}
+
+		if item.open {
+			d["Count"] = types.Integer(c)
+			visible += c
+		} else {
+			d["Count"] = types.Integer(-c)
+		}
+	}
+
+	return visible
+}
+
+// editBookmarks applies f to the top level outline items of ctx and relinks the resulting outline tree.
+func editBookmarks(ctx *model.Context, f func([]*outlineItem) ([]*outlineItem, int)) (int, error) {
+	if err := ctx.LocateNameTree("Dests", false); err != nil {
+		return 0, err
+	}
+
+	rootDict, err := ctx.Catalog()
+	if err != nil {
+		return 0, err
+	}
+
+	ir := rootDict.IndirectRefEntry("Outlines")
+	if ir == nil {
+		return 0, errNoBookmarks
+	}
+
+	d, err := ctx.DereferenceDict(*ir)
+	if err != nil {
+		return 0, err
+	}
+	if d == nil || d.IndirectRefEntry("First") == nil {
+		return 0, errNoBookmarks
+	}
+
+	items, err := outlineItems(ctx, d.IndirectRefEntry("First"), map[int]bool{})
+	if err != nil {
+		return 0, err
+	}
+
+	items, n := f(items)
+
+	if c := linkOutlineItems(d, *ir, items); c > 0 {
+		d["Count"] = types.Integer(c)
+	}
+
+	return n, nil
+}
+
+// sortPageNr returns the page number used for sorting item by page,
+// falling back to the first page of its kids for items without a destination page.
+func (item *outlineItem) sortPageNr() int {
+	if item.pageNr > 0 {
+		return item.pageNr
+	}
+	pageNr := 0
+	for _, kid := range item.kids {
+		if p := kid.sortPageNr(); p > 0 && (pageNr == 0 || p < pageNr) {
+			pageNr = p
+		}
+	}
+	return pageNr
+}
+
+func sortOutlineItems(items []*outlineItem, byPage bool) {
+	sort.SliceStable(items, func(i, j int) bool {
+		if byPage {
+			p1, p2 := items[i].sortPageNr(), items[j].sortPageNr()
+			if p1 == 0 || p2 == 0 {
+				// Items without destination page go last.
+				return p2 == 0 && p1 > 0
+			}
+			return p1 < p2
+		}
+		return strings.ToLower(items[i].title) < strings.ToLower(items[j].title)
+	})
+
+	for _, item := range items {
+		sortOutlineItems(item.kids, byPage)
+	}
+}
+
+// SortBookmarks sorts sibling bookmarks of ctx alphabetically by title or by destination page.
+func SortBookmarks(ctx *model.Context, byPage bool) error {
+	_, err := editBookmarks(ctx, func(items []*outlineItem) ([]*outlineItem, int) {
+		sortOutlineItems(items, byPage)
+		return items, 0
+	})
+	return err
+}
+
+func dedupOutlineItems(items []*outlineItem) ([]*outlineItem, int) {
+	type key struct {
+		title  string
+		pageNr int
+	}
+
+	m := map[key]*outlineItem{}
+	kept := []*outlineItem{}
+	removed := 0
+
+	for _, item := range items {
+		k := key{item.title, item.pageNr}
+		if item1, ok := m[k]; ok {
+			// Adopt the kids of the duplicate.
+			item1.kids = append(item1.kids, item.kids...)
+			item1.open = item1.open || item.open
+			removed++
+			continue
+		}
+		m[k] = item
+		kept = append(kept, item)
+	}
+
+	for _, item := range kept {
+		var n int
+		item.kids, n = dedupOutlineItems(item.kids)
+		removed += n
+	}
+
+	return kept, removed
+}
+
+// DedupBookmarks removes bookmarks duplicating the title and destination page of a preceding sibling
+// and returns the number of removed bookmarks. Kids of removed bookmarks are moved to the remaining one.
+func DedupBookmarks(ctx *model.Context) (int, error) {
+	return editBookmarks(ctx, dedupOutlineItems)
+}
+
+// documentOrder returns items and their descendants in document order.
+func documentOrder(items []*outlineItem) []*outlineItem {
+	ii := []*outlineItem{}
+	for _, item := range items {
+		ii = append(ii, item)
+		ii = append(ii, documentOrder(item.kids)...)
+	}
+	return ii
+}
+
+func collapseOutlineItems(items []*outlineItem, level, maxLevel int) ([]*outlineItem, int) {
+	if level < maxLevel {
+		n := 0
+		for _, item := range items {
+			var c int
+			item.kids, c = collapseOutlineItems(item.kids, level+1, maxLevel)
+			n += c
+		}
+		return items, n
+	}
+
+	ii := documentOrder(items)
+	for _, item := range ii {
+		item.kids = nil
+	}
+
+	return ii, len(ii) - len(items)
+}
+
+// CollapseBookmarks moves bookmarks nested deeper than maxLevel up to level maxLevel keeping their order
+// and returns the number of moved bookmarks. Top level bookmarks are on level 1.
+func CollapseBookmarks(ctx *model.Context, maxLevel int) (int, error) {
+	if maxLevel < 1 {
+		return 0, errors.Errorf("pdfcpu: invalid bookmark level: %d", maxLevel)
+	}
+	return editBookmarks(ctx, func(items []*outlineItem) ([]*outlineItem, int) {
+		return collapseOutlineItems(items, 1, maxLevel)
+	})
+}
+
+// RetargetBookmarks points bookmarks whose destination page has been removed
+// to the destination page of the next bookmark in document order, or the last page,
+// and returns the number of retargeted bookmarks.
+func RetargetBookmarks(ctx *model.Context) (int, error) {
+	var ferr error
+
+	n, err := editBookmarks(ctx, func(items []*outlineItem) ([]*outlineItem, int) {
+		// Walk in reverse document order keeping track of the next valid destination page.
+		all := documentOrder(items)
+		pageNr := ctx.PageCount
+		n := 0
+
+		for i := len(all) - 1; i >= 0; i-- {
+			item := all[i]
+			if item.pageNr > 0 {
+				pageNr = item.pageNr
+				continue
+			}
+			if !item.dangling {
+				continue
+			}
+
+			_, pageIndRef, _, err := ctx.PageDict(pageNr, false)
+			if err != nil {
+				ferr = err
+				return items, 0
+			}
+
+			item.d["Dest"] = types.Array{*pageIndRef, types.Name("Fit")}
+			item.d.Delete("A")
+			item.pageNr, item.dangling = pageNr, false
+			n++
+		}
+
+		return items, n
+	})
+
+	if err == nil {
+		err = ferr
+	}
+
+	return n, err
+}
@@ -0,0 +1,342 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"html/template"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// Supported comment summary formats.
+const (
+	CommentSummaryJSON = "json"
+	CommentSummaryCSV  = "csv"
+	CommentSummaryHTML = "html"
+)
+
+// Markup annotations (see 12.5.6.2).
+var markupAnnotTypes = map[string]bool{
+	"Text": true, "FreeText": true, "Line": true, "Square": true, "Circle": true, "Polygon": true,
+	"PolyLine": true, "Highlight": true, "Underline": true, "Squiggly": true, "StrikeOut": true,
+	"Stamp": true, "Caret": true, "Ink": true, "FileAttachment": true, "Sound": true, "Redact": true,
+}
+
+// Text markup annotations (see 12.5.6.10).
+var textMarkupAnnotTypes = map[string]bool{
+	"Highlight": true, "Underline": true, "Squiggly": true, "StrikeOut": true,
+}
+
+// Comment is a markup annotation together with its replies.
+// The JSON representation is considered stable.
+type Comment struct {
+	Page      int        `json:"page"`
+	ObjNr     int        `json:"objNr"`
+	Type      string     `json:"type"`
+	Author    string     `json:"author,omitempty"`
+	Subject   string     `json:"subject,omitempty"`
+	Contents  string     `json:"contents,omitempty"`
+	Text      string     `json:"text,omitempty"` // Marked up page text of text markup annotations.
+	Created   string     `json:"created,omitempty"`
+	Modified  string     `json:"modified,omitempty"`
+	InReplyTo int        `json:"inReplyTo,omitempty"`
+	State     string     `json:"state,omitempty"` // State set for InReplyTo, eg. Accepted or Rejected.
+	Replies   []*Comment `json:"replies,omitempty"`
+}
+
+// markedUpRects returns the areas covered by a text markup annotation.
+func markedUpRects(ctx *model.Context, d types.Dict) []types.Rectangle {
+	var rr []types.Rectangle
+
+	if arr, err := ctx.DereferenceArray(d["QuadPoints"]); err == nil && len(arr) >= 8 {
+		for i := 0; i+8 <= len(arr); i += 8 {
+			var r *types.Rectangle
+			for j := i; j < i+8; j += 2 {
+				x, err1 := ctx.DereferenceNumber(arr[j])
+				y, err2 := ctx.DereferenceNumber(arr[j+1])
+				if err1 != nil || err2 != nil {
+					continue
+				}
+				if r == nil {
+					r = types.NewRectangle(x, y, x, y)
+					continue
+				}
+				r.LL.X, r.LL.Y = min(r.LL.X, x), min(r.LL.Y, y)
+				r.UR.X, r.UR.Y = max(r.UR.X, x), max(r.UR.Y, y)
+			}
+			if r != nil {
+				rr = append(rr, *r)
+			}
+		}
+		return rr
+	}
+
+	if arr, err := ctx.DereferenceArray(d["Rect"]); err == nil {
+		if r, err := ctx.RectForArray(arr); err == nil && r != nil {
+			rr = append(rr, *r)
+		}
+	}
+
+	return rr
+}
+
+func pageComments(ctx *model.Context, pageNr int) ([]*Comment, error) {
+	d, _, _, err := ctx.PageDict(pageNr, false)
+	if err != nil || d == nil {
+		return nil, err
+	}
+
+	arr, err := ctx.DereferenceArray(d["Annots"])
+	if err != nil || len(arr) == 0 {
+		return nil, err
+	}
+
+	var (
+		cc     []*Comment
+		glyphs []textGlyph
+		parsed bool
+	)
+
+	for _, o := range arr {
+		objNr := 0
+		if ir, ok := o.(types.IndirectRef); ok {
+			objNr = ir.ObjectNumber.Value()
+		}
+
+		d1, err := ctx.DereferenceDict(o)
+		if err != nil || d1 == nil {
+			continue
+		}
+
+		st := d1.Subtype()
+		if st == nil || !markupAnnotTypes[*st] {
+			continue
+		}
+
+		c := &Comment{
+			Page:     pageNr,
+			ObjNr:    objNr,
+			Type:     *st,
+			Author:   annotationText(ctx, d1, "T"),
+			Subject:  annotationText(ctx, d1, "Subj"),
+			Contents: annotationText(ctx, d1, "Contents"),
+		}
+		c.Created, _ = annotationDate(ctx, d1, "CreationDate")
+		c.Modified, _ = annotationDate(ctx, d1, "M")
+
+		if ir := d1.IndirectRefEntry("IRT"); ir != nil {
+			c.InReplyTo = ir.ObjectNumber.Value()
+			c.State = annotationText(ctx, d1, "State")
+		}
+
+		if textMarkupAnnotTypes[*st] {
+			if !parsed {
+				if glyphs, err = pageGlyphs(ctx, pageNr); err != nil {
+					return nil, err
+				}
+				parsed = true
+			}
+			c.Text = textInRects(glyphs, markedUpRects(ctx, d1))
+		}
+
+		cc = append(cc, c)
+	}
+
+	return cc, nil
+}
+
+// threadComments nests replies under the comments they respond to via /IRT (see 12.5.6.2).
+// Replies whose parent is not part of cc remain at top level.
+func threadComments(cc []*Comment) []*Comment {
+	m := map[int]*Comment{}
+	for _, c := range cc {
+		if c.ObjNr > 0 {
+			m[c.ObjNr] = c
+		}
+	}
+
+	// cyclic returns true if following /IRT starting at c leads back to c.
+	cyclic := func(c *Comment) bool {
+		for p, n := m[c.InReplyTo], 0; p != nil && n < len(m); p, n = m[p.InReplyTo], n+1 {
+			if p == c {
+				return true
+			}
+		}
+		return false
+	}
+
+	var top []*Comment
+
+	for _, c := range cc {
+		p := m[c.InReplyTo]
+		if p == nil || p == c || cyclic(c) {
+			top = append(top, c)
+			continue
+		}
+		p.Replies = append(p.Replies, c)
+	}
+
+	return top
+}
+
+// CommentSummary returns the markup annotations of selected pages of ctx with replies threaded via /IRT.
+func CommentSummary(ctx *model.Context, selectedPages types.IntSet) ([]*Comment, error) {
+	var cc []*Comment
+
+	for i := 1; i <= ctx.PageCount; i++ {
+		if selectedPages != nil && !selectedPages[i] {
+			continue
+		}
+		cc1, err := pageComments(ctx, i)
+		if err != nil {
+			return nil, err
+		}
+		cc = append(cc, cc1...)
+	}
+
+	return threadComments(cc), nil
+}
+
+// CommentSummaryHeader returns the header of a comment summary for ctx read from source.
+func CommentSummaryHeader(ctx *model.Context, source string) Header {
+	return Header{
+		Source:   source,
+		Version:  "pdfcpu " + model.VersionStr,
+		Creation: time.Now().Format("2006-01-02 15:04:05 MST"),
+		Title:    ctx.Title,
+	}
+}
+
+// WriteCommentSummary writes cc to w in format json, csv or html.
+func WriteCommentSummary(w io.Writer, h Header, cc []*Comment, format string) error {
+	switch format {
+	case CommentSummaryJSON:
+		return writeCommentSummaryJSON(w, h, cc)
+	case CommentSummaryCSV:
+		return writeCommentSummaryCSV(w, cc)
+	case CommentSummaryHTML:
+		return writeCommentSummaryHTML(w, h, cc)
+	}
+	return errors.Errorf("pdfcpu: unsupported comment summary format: %s", format)
+}
+
+func writeCommentSummaryJSON(w io.Writer, h Header, cc []*Comment) error {
+	s := struct {
+		Header   Header     `json:"header"`
+		Comments []*Comment `json:"comments"`
+	}{
+		Header:   h,
+		Comments: cc,
+	}
+
+	if s.Comments == nil {
+		s.Comments = []*Comment{}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "\t")
+	return enc.Encode(s)
+}
+
+func writeCommentsCSV(w *csv.Writer, cc []*Comment) error {
+	for _, c := range cc {
+		irt := ""
+		if c.InReplyTo > 0 {
+			irt = strconv.Itoa(c.InReplyTo)
+		}
+		rec := []string{
+			strconv.Itoa(c.Page), strconv.Itoa(c.ObjNr), irt, c.Type, c.Author, c.Subject,
+			c.Created, c.Modified, c.Text, c.Contents, c.State,
+		}
+		if err := w.Write(rec); err != nil {
+			return err
+		}
+		if err := writeCommentsCSV(w, c.Replies); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeCommentSummaryCSV writes one record per comment, replies follow the comment they respond to.
+func writeCommentSummaryCSV(w io.Writer, cc []*Comment) error {
+	cw := csv.NewWriter(w)
+
+	header := []string{"page", "objNr", "inReplyTo", "type", "author", "subject", "created", "modified", "text", "contents", "state"}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	if err := writeCommentsCSV(cw, cc); err != nil {
+		return err
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+var commentSummaryHTML = template.Must(template.New("summary").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Comment summary{{with .Header.Title}}: {{.}}{{end}}</title>
+<style>
+body { font-family: sans-serif; }
+.comment { border-left: 3px solid #ccc; margin: 0.5em 0; padding-left: 0.8em; }
+.meta { color: #666; font-size: 0.85em; }
+.text { background: #ffff99; font-style: italic; }
+</style>
+</head>
+<body>
+<h1>Comment summary{{with .Header.Title}}: {{.}}{{end}}</h1>
+<p class="meta">{{with .Header.Source}}{{.}} &middot; {{end}}{{.Header.Version}} &middot; {{.Header.Creation}}</p>
+{{- define "comment"}}
+<div class="comment">
+<div class="meta">Page {{.Page}} &middot; {{.Type}}{{if .Author}} &middot; {{.Author}}{{end}}{{if .Modified}} &middot; {{.Modified}}{{end}} &middot; obj# {{.ObjNr}}{{with .State}} &middot; <strong>{{.}}</strong>{{end}}</div>
+{{- if .Subject}}
+<div><strong>{{.Subject}}</strong></div>
+{{- end}}
+{{- if .Text}}
+<div class="text">{{.Text}}</div>
+{{- end}}
+{{- if .Contents}}
+<div>{{.Contents}}</div>
+{{- end}}
+{{- range .Replies}}{{template "comment" .}}{{end}}
+</div>
+{{- end}}
+{{- range .Comments}}{{template "comment" .}}{{end}}
+</body>
+</html>
+`))
+
+func writeCommentSummaryHTML(w io.Writer, h Header, cc []*Comment) error {
+	return commentSummaryHTML.Execute(w, struct {
+		Header   Header
+		Comments []*Comment
+	}{
+		Header:   h,
+		Comments: cc,
+	})
+}
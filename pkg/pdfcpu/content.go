@@ -0,0 +1,240 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"bytes"
+	"math"
+	"strconv"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// contentNum formats f as a content stream number operand.
+func contentNum(f float64) string {
+	f = math.Round(f*10000) / 10000
+	if f == 0 {
+		return "0"
+	}
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// editContentOps rewrites content stream bb replacing each operator along with its operands
+// by the string returned by fn, if any. raw are the bytes of the operator along with its operands.
+// All other bytes are kept as is.
+func editContentOps(bb []byte, fn func(op contentOp, oo []interface{}, raw []byte) (string, bool)) ([]byte, bool) {
+	l := &contentLexer{bb: bb}
+
+	var (
+		buf     bytes.Buffer
+		oo      []interface{}
+		last    int // End of the bytes already copied to buf.
+		start   = -1
+		changed bool
+	)
+
+	for {
+		l.skipWhitespaceAndComments()
+		if start < 0 {
+			start = l.pos
+		}
+
+		o, ok := l.next()
+		if !ok {
+			break
+		}
+
+		op, ok := o.(contentOp)
+		if !ok {
+			oo = append(oo, o)
+			continue
+		}
+
+		if s, ok := fn(op, oo, bb[start:l.pos]); ok {
+			buf.Write(bb[last:start])
+			buf.WriteString(s)
+			last, changed = l.pos, true
+		}
+
+		if op == "ID" {
+			l.skipInlineImage()
+		}
+
+		oo, start = oo[:0], -1
+	}
+
+	if !changed {
+		return bb, false
+	}
+
+	buf.Write(bb[last:])
+
+	return buf.Bytes(), true
+}
+
+// contentEditor returns the edited content bb of a page, form XObject or tiling pattern using resources res.
+type contentEditor func(pageNr int, res types.Dict, bb []byte) ([]byte, bool, error)
+
+// contentWalker applies a contentEditor to the page content of selected pages
+// and to the content of form XObjects and tiling patterns used by them.
+type contentWalker struct {
+	ctx     *model.Context
+	edit    contentEditor
+	perPage bool // Revisit form XObjects and patterns for each page using them.
+	visited map[int]bool
+}
+
+func newContentWalker(ctx *model.Context, edit contentEditor, perPage bool) *contentWalker {
+	return &contentWalker{ctx: ctx, edit: edit, perPage: perPage, visited: map[int]bool{}}
+}
+
+// updateStreamContent replaces the content of the stream dict ir refers to by bb.
+func updateStreamContent(ctx *model.Context, ir types.IndirectRef, sd *types.StreamDict, bb []byte) error {
+	entry, found := ctx.FindTableEntry(ir.ObjectNumber.Value(), ir.GenerationNumber.Value())
+	if !found {
+		return errors.Errorf("pdfcpu: missing obj#%d", ir.ObjectNumber.Value())
+	}
+	sd.Content = bb
+	if err := sd.Encode(); err != nil {
+		return err
+	}
+	entry.Object = *sd
+	return nil
+}
+
+func (cw *contentWalker) stream(pageNr int, o types.Object, parentRes types.Dict, depth int) error {
+	ir, ok := o.(types.IndirectRef)
+	if !ok || depth > maxFormDepth {
+		return nil
+	}
+
+	objNr := ir.ObjectNumber.Value()
+	if cw.visited[objNr] {
+		return nil
+	}
+	cw.visited[objNr] = true
+
+	sd, _, err := cw.ctx.DereferenceStreamDict(ir)
+	if err != nil || sd == nil {
+		return err
+	}
+
+	res := parentRes
+	if o, found := sd.Dict.Find("Resources"); found {
+		if res, err = cw.ctx.DereferenceDict(o); err != nil {
+			return err
+		}
+	}
+
+	if err := sd.Decode(); err != nil {
+		return err
+	}
+
+	bb, changed, err := cw.edit(pageNr, res, sd.Content)
+	if err != nil {
+		return err
+	}
+
+	if changed {
+		if err := updateStreamContent(cw.ctx, ir, sd, bb); err != nil {
+			return err
+		}
+	}
+
+	return cw.resources(pageNr, res, depth+1)
+}
+
+func (cw *contentWalker) resources(pageNr int, res types.Dict, depth int) error {
+	if res == nil {
+		return nil
+	}
+
+	for _, key := range []string{"XObject", "Pattern"} {
+		d, err := cw.ctx.DereferenceDict(res[key])
+		if err != nil {
+			return err
+		}
+		for _, o := range d {
+			sd, _, err := cw.ctx.DereferenceStreamDict(o)
+			if err != nil || sd == nil {
+				// Skip shading patterns.
+				continue
+			}
+			if key == "XObject" {
+				if st := sd.Subtype(); st == nil || *st != "Form" {
+					continue
+				}
+			}
+			if err := cw.stream(pageNr, o, res, depth); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (cw *contentWalker) page(pageNr int) error {
+	d, _, inhPAttrs, err := cw.ctx.PageDict(pageNr, false)
+	if err != nil || d == nil {
+		return err
+	}
+
+	if cw.perPage {
+		cw.visited = map[int]bool{}
+	}
+
+	bb, err := cw.ctx.PageContent(d)
+	if err != nil && err != model.ErrNoContent {
+		return err
+	}
+
+	if err == nil {
+		bb, changed, err := cw.edit(pageNr, inhPAttrs.Resources, bb)
+		if err != nil {
+			return err
+		}
+		if changed {
+			ir, err := newContentStreamRef(cw.ctx, string(bb))
+			if err != nil {
+				return err
+			}
+			d.Update("Contents", *ir)
+		}
+	}
+
+	return cw.resources(pageNr, inhPAttrs.Resources, 0)
+}
+
+// walk processes pageNrs or all pages if pageNrs is nil.
+func (cw *contentWalker) walk(pageNrs []int) error {
+	if pageNrs == nil {
+		for i := 1; i <= cw.ctx.PageCount; i++ {
+			pageNrs = append(pageNrs, i)
+		}
+	}
+
+	for _, pageNr := range pageNrs {
+		if err := cw.page(pageNr); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,232 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// formatString returns b as literal string or as hex string if b contains mostly non printable bytes.
+func formatString(b []byte) string {
+	np := 0
+	for _, c := range b {
+		if c < 0x20 || c > 0x7E {
+			np++
+		}
+	}
+
+	if np > len(b)/4 {
+		return fmt.Sprintf("<%X>", b)
+	}
+
+	var sb strings.Builder
+	sb.WriteByte('(')
+	for _, c := range b {
+		switch {
+		case c == '(' || c == ')' || c == '\\':
+			sb.WriteByte('\\')
+			sb.WriteByte(c)
+		case c == '\n':
+			sb.WriteString(`\n`)
+		case c == '\r':
+			sb.WriteString(`\r`)
+		case c < 0x20 || c > 0x7E:
+			fmt.Fprintf(&sb, "\\%03o", c)
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	sb.WriteByte(')')
+
+	return sb.String()
+}
+
+// formatOperand returns the content stream representation of an operand.
+func formatOperand(o interface{}) string {
+	switch o := o.(type) {
+
+	case float64:
+		return strconv.FormatFloat(o, 'f', -1, 64)
+
+	case contentName:
+		return "/" + types.EncodeName(string(o))
+
+	case contentOp:
+		// true, false, null
+		return string(o)
+
+	case []byte:
+		return formatString(o)
+
+	case []interface{}:
+		ss := make([]string, len(o))
+		for i, o := range o {
+			ss[i] = formatOperand(o)
+		}
+		return "[" + strings.Join(ss, " ") + "]"
+
+	case contentDict:
+		keys := make([]string, 0, len(o))
+		for k := range o {
+			keys = append(keys, string(k))
+		}
+		sort.Strings(keys)
+		ss := make([]string, len(keys))
+		for i, k := range keys {
+			ss[i] = "/" + types.EncodeName(k) + " " + formatOperand(o[contentName(k)])
+		}
+		return "<<" + strings.Join(ss, " ") + ">>"
+	}
+
+	return ""
+}
+
+// FormatContent pretty-prints content stream bb using one line per operator
+// indented by the nesting of graphics states, text objects and marked content.
+func FormatContent(bb []byte) []byte {
+	l := &contentLexer{bb: bb}
+
+	var (
+		buf   bytes.Buffer
+		oo    []string
+		depth int
+	)
+
+	for {
+		o, ok := l.next()
+		if !ok {
+			break
+		}
+
+		op, ok := o.(contentOp)
+		if !ok || op == "true" || op == "false" || op == "null" {
+			oo = append(oo, formatOperand(o))
+			continue
+		}
+
+		switch op {
+		case "Q", "ET", "EMC":
+			if depth > 0 {
+				depth--
+			}
+		}
+
+		buf.WriteString(strings.Repeat("  ", depth))
+		for _, s := range oo {
+			buf.WriteString(s)
+			buf.WriteByte(' ')
+		}
+		buf.WriteString(string(op))
+
+		switch op {
+		case "q", "BT", "BMC", "BDC":
+			depth++
+		case "ID":
+			// Copy the inline image data up to and including EI.
+			start := l.pos
+			l.skipInlineImage()
+			buf.Write(bb[start:l.pos])
+		}
+
+		buf.WriteByte('\n')
+		oo = oo[:0]
+	}
+
+	if len(oo) > 0 {
+		// Dangling operands.
+		buf.WriteString(strings.Join(oo, " "))
+		buf.WriteByte('\n')
+	}
+
+	return buf.Bytes()
+}
+
+func newContentStream(ctx *model.Context, bb []byte, compress bool) (*types.IndirectRef, error) {
+	sd := &types.StreamDict{Dict: types.NewDict(), Content: bb}
+	if compress {
+		var err error
+		if sd, err = ctx.NewStreamDictForBuf(bb); err != nil {
+			return nil, err
+		}
+	}
+	if err := sd.Encode(); err != nil {
+		return nil, err
+	}
+	return ctx.IndRefForNewObject(*sd)
+}
+
+// ReplacePageContent replaces the content of page pageNr by a single content stream containing bb.
+func ReplacePageContent(ctx *model.Context, pageNr int, bb []byte, compress bool) error {
+	d, _, _, err := ctx.PageDict(pageNr, false)
+	if err != nil {
+		return err
+	}
+	if d == nil {
+		return errors.Errorf("pdfcpu: unknown page number: %d\n", pageNr)
+	}
+
+	ir, err := newContentStream(ctx, bb, compress)
+	if err != nil {
+		return err
+	}
+
+	d.Update("Contents", *ir)
+
+	return nil
+}
+
+// NormalizeContent decodes and pretty-prints the content of pages pageNrs (all pages if nil)
+// merging multiple content streams of a page into one, which is flate encoded if compress is true.
+func NormalizeContent(ctx *model.Context, pageNrs []int, compress bool) error {
+	if pageNrs == nil {
+		for i := 1; i <= ctx.PageCount; i++ {
+			pageNrs = append(pageNrs, i)
+		}
+	}
+
+	for _, pageNr := range pageNrs {
+		d, _, _, err := ctx.PageDict(pageNr, false)
+		if err != nil {
+			return err
+		}
+		if d == nil {
+			return errors.Errorf("pdfcpu: unknown page number: %d\n", pageNr)
+		}
+
+		bb, err := ctx.PageContent(d)
+		if err == model.ErrNoContent {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := ReplacePageContent(ctx, pageNr, FormatContent(bb), compress); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
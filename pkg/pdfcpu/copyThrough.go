@@ -0,0 +1,242 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"sort"
+
+	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// lazyDigest marks objects of object streams that could not be decoded at read time.
+const lazyDigest = 0
+
+func objectDigest(o types.Object) uint64 {
+	h := fnv.New64a()
+
+	if sd, ok := o.(types.StreamDict); ok {
+		io.WriteString(h, sd.Dict.PDFString())
+		if sd.Deferred() {
+			fmt.Fprintf(h, "@%d", sd.StreamOffset)
+		} else {
+			h.Write(sd.Raw)
+		}
+		return h.Sum64()
+	}
+
+	io.WriteString(h, o.PDFString())
+
+	return h.Sum64()
+}
+
+func skipDigest(o types.Object) bool {
+	switch o.(type) {
+	case types.ObjectStreamDict, types.XRefStreamDict, *types.XRefStreamDict:
+		return true
+	}
+	return false
+}
+
+// recordObjectDigests fingerprints all objects right after reading
+// in order to detect the objects modified by the command in progress.
+// Objects of object streams are fingerprinted in their decoded form,
+// so that decoding them later on does not count as a modification.
+func recordObjectDigests(c context.Context, ctx *model.Context) {
+	m := map[int]uint64{}
+
+	for objNr, e := range ctx.Table {
+		if objNr == 0 || e.Free || e.Object == nil || skipDigest(e.Object) {
+			continue
+		}
+		if l, ok := e.Object.(types.LazyObjectStreamObject); ok {
+			o, err := l.DecodedObject(c)
+			if err != nil || o == nil {
+				m[objNr] = lazyDigest
+				continue
+			}
+			m[objNr] = objectDigest(o)
+			continue
+		}
+		m[objNr] = objectDigest(e.Object)
+	}
+
+	ctx.Read.ObjectDigests = m
+}
+
+func objectChanged(e *model.XRefTableEntry, digest uint64, recorded bool) bool {
+	if e.Free {
+		return recorded
+	}
+
+	if e.Object == nil || skipDigest(e.Object) {
+		return false
+	}
+
+	if !recorded {
+		return true
+	}
+
+	if _, ok := e.Object.(types.LazyObjectStreamObject); ok {
+		return false
+	}
+
+	// Objects of object streams not decodable at read time are considered modified.
+	return digest == lazyDigest || objectDigest(e.Object) != digest
+}
+
+// changedObjects returns the sorted numbers of all objects added, modified or freed since reading.
+func changedObjects(ctx *model.Context) []int {
+	objNrs := []int{}
+
+	for objNr, e := range ctx.Table {
+		if objNr == 0 {
+			continue
+		}
+		digest, recorded := ctx.Read.ObjectDigests[objNr]
+		if objectChanged(e, digest, recorded) {
+			objNrs = append(objNrs, objNr)
+		}
+	}
+
+	sort.Ints(objNrs)
+
+	return objNrs
+}
+
+func copyThroughApplicable(ctx *model.Context) bool {
+	if !ctx.CopyThrough || ctx.Read == nil || ctx.Read.RS == nil || ctx.Read.ObjectDigests == nil {
+		return false
+	}
+
+	if ctx.Write.Increment || len(ctx.Write.SelectedPages) > 0 || ctx.Write.OffsetPrevXRef == nil {
+		return false
+	}
+
+	if ctx.Read.RepairedXRef || ctx.Encrypt != nil || ctx.EncKey != nil {
+		return false
+	}
+
+	if ctx.HeaderVersion == nil || *ctx.HeaderVersion < model.V14 {
+		return false
+	}
+
+	switch ctx.Cmd {
+	case model.OPTIMIZE, model.ENCRYPT, model.DECRYPT, model.CHANGEUPW, model.CHANGEOPW, model.SETPERMISSIONS:
+		return false
+	}
+
+	return true
+}
+
+func copyInput(ctx *model.Context) error {
+	rs := ctx.Read.RS
+
+	if _, err := rs.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	n, err := io.Copy(ctx.Write.Writer, rs)
+	if err != nil {
+		return err
+	}
+
+	ctx.Write.Offset = n
+
+	if n == 0 {
+		return nil
+	}
+
+	// Ensure the increment starts on a new line.
+	b := make([]byte, 1)
+	if _, err := rs.Seek(-1, io.SeekEnd); err != nil {
+		return err
+	}
+	if _, err := io.ReadFull(rs, b); err != nil {
+		return err
+	}
+	if b[0] == 0x0A || b[0] == 0x0D {
+		return nil
+	}
+
+	if err := ctx.Write.WriteEol(); err != nil {
+		return err
+	}
+	ctx.Write.Offset += int64(len(ctx.Write.Eol))
+
+	return nil
+}
+
+// writeCopyThrough copies the input file through unmodified
+// and appends all objects changed since reading as incremental update.
+// Falls back to a full write if most objects have changed.
+func writeCopyThrough(ctx *model.Context) error {
+	if err := ensureInfoDictAndFileID(ctx); err != nil {
+		return err
+	}
+
+	objNrs := changedObjects(ctx)
+
+	if 2*len(objNrs) > len(ctx.Read.ObjectDigests) {
+		if log.WriteEnabled() {
+			log.Write.Printf("writeCopyThrough: %d of %d objects changed, falling back to full write\n", len(objNrs), len(ctx.Read.ObjectDigests))
+		}
+		ctx.Read.ObjectDigests = nil
+		return write(ctx)
+	}
+
+	if log.WriteEnabled() {
+		log.Write.Printf("writeCopyThrough: copying through, %d objects changed\n", len(objNrs))
+	}
+
+	if err := copyInput(ctx); err != nil {
+		return err
+	}
+
+	if len(objNrs) == 0 {
+		return setFileSizeOfWrittenFile(ctx.Write)
+	}
+
+	ctx.Write.Increment = true
+	ctx.Write.ObjNrs = objNrs
+
+	// Stick to the cross reference format of the input file unless offsets overflow the xref table.
+	if err := writeIncrementObjects(ctx, objNrs, ctx.Read.UsingXRefStreams); err != nil {
+		return err
+	}
+
+	var err error
+	if ctx.Read.UsingXRefStreams || xRefTableOverflow(ctx.Write) {
+		err = writeXRefStream(ctx)
+	} else {
+		err = writeXRefTable(ctx)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := writeTrailer(ctx.Write); err != nil {
+		return err
+	}
+
+	return setFileSizeOfWrittenFile(ctx.Write)
+}
@@ -24,6 +24,7 @@ import (
 	"strings"
 
 	"github.com/pdfcpu/pdfcpu/pkg/font"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
 	pdffont "github.com/pdfcpu/pdfcpu/pkg/pdfcpu/font"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/primitives"
@@ -82,8 +83,11 @@ func addPageResources(xRefTable *model.XRefTable, d types.Dict, p model.Page, fo
 	for _, img := range p.Im {
 		imgRes[img.Res.ID] = *img.Res.IndRef
 	}
+	for _, f := range p.Forms {
+		imgRes[f.Res.ID] = *f.Res.IndRef
+	}
 
-	if len(fontRes) > 0 || len(imgRes) > 0 {
+	if len(fontRes) > 0 || len(imgRes) > 0 || len(p.Pm) > 0 || len(p.Gm) > 0 {
 		resDict := types.Dict{}
 		if len(fontRes) > 0 {
 			resDict["Font"] = fontRes
@@ -91,6 +95,15 @@ func addPageResources(xRefTable *model.XRefTable, d types.Dict, p model.Page, fo
 		if len(imgRes) > 0 {
 			resDict["XObject"] = imgRes
 		}
+		if len(p.Pm) > 0 {
+			resDict["Pattern"] = p.Pm
+		}
+		if len(p.Gm) > 0 {
+			resDict["ExtGState"] = p.Gm
+		}
+		for _, f := range p.Forms {
+			f.Dict["Resources"] = resDict
+		}
 		d["Resources"] = resDict
 	}
 
@@ -117,7 +130,7 @@ func updatePageResources(xRefTable *model.XRefTable, d, resDict types.Dict, p mo
 		resDict["Font"] = fontRes
 	}
 
-	if len(p.Im) > 0 {
+	if len(p.Im) > 0 || len(p.Forms) > 0 {
 		imgRes, ok := resDict["XObject"].(types.Dict)
 		if !ok {
 			imgRes = types.Dict{}
@@ -125,10 +138,39 @@ func updatePageResources(xRefTable *model.XRefTable, d, resDict types.Dict, p mo
 		for _, img := range p.Im {
 			imgRes[img.Res.ID] = *img.Res.IndRef
 		}
+		for _, f := range p.Forms {
+			imgRes[f.Res.ID] = *f.Res.IndRef
+		}
 		resDict["XObject"] = imgRes
 	}
 
-	if len(p.Fm) > 0 || len(p.Im) > 0 {
+	if len(p.Pm) > 0 {
+		patRes, ok := resDict["Pattern"].(types.Dict)
+		if !ok {
+			patRes = types.Dict{}
+		}
+		for id, o := range p.Pm {
+			patRes[id] = o
+		}
+		resDict["Pattern"] = patRes
+	}
+
+	if len(p.Gm) > 0 {
+		gsRes, ok := resDict["ExtGState"].(types.Dict)
+		if !ok {
+			gsRes = types.Dict{}
+		}
+		for id, o := range p.Gm {
+			gsRes[id] = o
+		}
+		resDict["ExtGState"] = gsRes
+	}
+
+	for _, f := range p.Forms {
+		f.Dict["Resources"] = resDict
+	}
+
+	if len(p.Fm) > 0 || len(p.Im) > 0 || len(p.Pm) > 0 || len(p.Gm) > 0 || len(p.Forms) > 0 {
 		d["Resources"] = resDict
 	}
 
@@ -313,6 +355,10 @@ func CreatePage(
 	}
 	pageDict.Insert("Contents", *ir)
 
+	if p.Tabs != "" {
+		pageDict["Tabs"] = types.Name(p.Tabs)
+	}
+
 	pageDictIndRef, err := xRefTable.IndRefForNewObject(pageDict)
 	if err != nil {
 		return nil, nil, err
@@ -363,6 +409,10 @@ func UpdatePage(xRefTable *model.XRefTable, dIndRef types.IndirectRef, d, res ty
 		return err
 	}
 
+	if p.Tabs != "" {
+		d["Tabs"] = types.Name(p.Tabs)
+	}
+
 	if len(p.AnnotTabs) == 0 && len(p.Annots) == 0 && len(p.LinkAnnots) == 0 {
 		return nil
 	}
@@ -454,6 +504,11 @@ func parseFromJSON(ctx *model.Context, bb []byte) (*primitives.PDF, error) {
 		return nil, errors.Errorf("pdfcpu: invalid JSON encoding detected.")
 	}
 
+	bb, err := primitives.ApplyStyles(bb)
+	if err != nil {
+		return nil, err
+	}
+
 	pdf := &primitives.PDF{
 		FieldIDs:      types.StringSet{},
 		Fields:        types.Array{},
@@ -728,15 +783,53 @@ func handleForm(
 	return nil
 }
 
+// handleDocumentLanguageAndTitle sets the document language and title as needed for accessible documents.
+func handleDocumentLanguageAndTitle(ctx *model.Context, pdf *primitives.PDF) error {
+	if pdf.Lang != "" {
+		if err := pdfcpu.SetLanguage(ctx, pdf.Lang); err != nil {
+			return err
+		}
+	}
+
+	if pdf.Title == "" && !pdf.DisplayDocTitle {
+		return nil
+	}
+
+	return pdfcpu.SetTitle(ctx, pdf.Title, pdf.DisplayDocTitle)
+}
+
 // FromJSON generates PDF content into ctx as provided by rd.
 func FromJSON(ctx *model.Context, rd io.Reader) error {
+	return FromJSONWithData(ctx, rd, nil)
+}
+
+// FromJSONWithData generates PDF content into ctx as provided by rd
+// after resolving any includes and binding all placeholders to data.
+func FromJSONWithData(ctx *model.Context, rd io.Reader, data map[string]interface{}) error {
 
 	var buf bytes.Buffer
 	if _, err := io.Copy(&buf, rd); err != nil {
 		return err
 	}
 
-	pdf, err := parseFromJSON(ctx, buf.Bytes())
+	bb := buf.Bytes()
+
+	if !json.Valid(bb) {
+		return errors.Errorf("pdfcpu: invalid JSON encoding detected.")
+	}
+
+	bb, err := ResolveIncludes(bb, ".")
+	if err != nil {
+		return err
+	}
+
+	if data != nil {
+		if bb, err = BindData(bb, data); err != nil {
+			return err
+		}
+	}
+
+	pdf, err := parseFromJSON(ctx, bb)
 	if err != nil {
 		return err
 	}
@@ -757,5 +850,9 @@ func FromJSON(ctx *model.Context, rd io.Reader) error {
 		}
 	}
 
-	return nil
+	if err := handleNavigation(ctx, pdf); err != nil {
+		return err
+	}
+
+	return handleDocumentLanguageAndTitle(ctx, pdf)
 }
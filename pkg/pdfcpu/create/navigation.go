@@ -0,0 +1,128 @@
+/*
+	Copyright 2026 The pdfcpu Authors.
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+package create
+
+import (
+	"sort"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/primitives"
+	"github.com/pkg/errors"
+)
+
+type outlineNode struct {
+	bm   pdfcpu.Bookmark
+	kids []*outlineNode
+}
+
+func (n *outlineNode) bookmarks() []pdfcpu.Bookmark {
+	bms := []pdfcpu.Bookmark{}
+	for _, kid := range n.kids {
+		bm := kid.bm
+		if len(kid.kids) > 0 {
+			bm.Kids = kid.bookmarks()
+		}
+		bms = append(bms, bm)
+	}
+	return bms
+}
+
+// bookmarks nests outline items by level in reading order.
+func bookmarks(items []*primitives.OutlineItem) ([]pdfcpu.Bookmark, error) {
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].PageNr != items[j].PageNr {
+			return items[i].PageNr < items[j].PageNr
+		}
+		return items[i].Top > items[j].Top
+	})
+
+	root := &outlineNode{}
+	parents := []*outlineNode{root}
+
+	for _, item := range items {
+		if item.Level > len(parents) {
+			return nil, errors.Errorf("pdfcpu: bookmark \"%s\" on page %d: level %d without parent at level %d", item.Title, item.PageNr, item.Level, item.Level-1)
+		}
+		parents = parents[:item.Level]
+		n := &outlineNode{bm: pdfcpu.Bookmark{
+			Title:    item.Title,
+			PageFrom: item.PageNr,
+			Bold:     item.Bold,
+			Italic:   item.Italic,
+			Color:    item.Color,
+		}}
+		parent := parents[len(parents)-1]
+		parent.kids = append(parent.kids, n)
+		parents = append(parents, n)
+	}
+
+	return root.bookmarks(), nil
+}
+
+// addTargets adds targets to the Dests name tree of ctx.
+func addTargets(ctx *model.Context, targets map[string]*primitives.Target) error {
+	if err := ctx.LocateNameTree("Dests", true); err != nil {
+		return err
+	}
+
+	var names []string
+	for name := range targets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		t := targets[name]
+
+		_, pageIndRef, _, err := ctx.PageDict(t.PageNr, false)
+		if err != nil {
+			return err
+		}
+
+		dest := model.Destination{Typ: model.DestXYZ, Left: int(t.Left), Top: int(t.Top)}
+		ir, err := ctx.IndRefForNewObject(dest.Array(*pageIndRef))
+		if err != nil {
+			return err
+		}
+
+		if err := ctx.Names["Dests"].Add(ctx.XRefTable, name, *ir, nil, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func handleNavigation(ctx *model.Context, pdf *primitives.PDF) error {
+	if len(pdf.Targets) > 0 {
+		if err := addTargets(ctx, pdf.Targets); err != nil {
+			return err
+		}
+	}
+
+	if len(pdf.Outline) == 0 {
+		return nil
+	}
+
+	bms, err := bookmarks(pdf.Outline)
+	if err != nil {
+		return err
+	}
+
+	return pdfcpu.AddBookmarks(ctx, bms, false)
+}
@@ -0,0 +1,344 @@
+/*
+	Copyright 2026 The pdfcpu Authors.
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+package create
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// IncludeKey is the key of JSON objects getting replaced by the content of a partial JSON file.
+const IncludeKey = "$include"
+
+const maxIncludeDepth = 16
+
+var placeholder = regexp.MustCompile(`{{\s*([^{}\s]+)\s*}}`)
+
+func decodeJSON(rd io.Reader) (interface{}, error) {
+	d := json.NewDecoder(rd)
+	d.UseNumber()
+	var o interface{}
+	if err := d.Decode(&o); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// mergeJSON adds all attributes of m0 missing in m, nested objects are merged recursively.
+func mergeJSON(m, m0 map[string]interface{}) {
+	for k, v := range m0 {
+		v1, ok := m[k]
+		if !ok {
+			m[k] = v
+			continue
+		}
+		m1, ok1 := v1.(map[string]interface{})
+		m2, ok2 := v.(map[string]interface{})
+		if ok1 && ok2 {
+			mergeJSON(m1, m2)
+		}
+	}
+}
+
+func includeFile(fileName, dir string, depth int) (interface{}, error) {
+	if depth >= maxIncludeDepth {
+		return nil, errors.Errorf("pdfcpu: %s: includes nested too deeply", fileName)
+	}
+
+	if !filepath.IsAbs(fileName) {
+		fileName = filepath.Join(dir, fileName)
+	}
+
+	f, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	o, err := decodeJSON(f)
+	if err != nil {
+		return nil, errors.Wrapf(err, "pdfcpu: %s", fileName)
+	}
+
+	// Nested includes are relative to the including file.
+	return resolveIncludes(o, filepath.Dir(fileName), depth+1)
+}
+
+func resolveIncludes(o interface{}, dir string, depth int) (interface{}, error) {
+	switch o := o.(type) {
+
+	case map[string]interface{}:
+		for k, v := range o {
+			if k == IncludeKey {
+				continue
+			}
+			v1, err := resolveIncludes(v, dir, depth)
+			if err != nil {
+				return nil, err
+			}
+			o[k] = v1
+		}
+
+		v, ok := o[IncludeKey]
+		if !ok {
+			return o, nil
+		}
+		delete(o, IncludeKey)
+
+		var fileNames []string
+		switch v := v.(type) {
+		case string:
+			fileNames = []string{v}
+		case []interface{}:
+			for _, v := range v {
+				s, ok := v.(string)
+				if !ok {
+					return nil, errors.Errorf("pdfcpu: invalid %s: %v", IncludeKey, v)
+				}
+				fileNames = append(fileNames, s)
+			}
+		default:
+			return nil, errors.Errorf("pdfcpu: invalid %s: %v", IncludeKey, v)
+		}
+
+		for _, fileName := range fileNames {
+			o1, err := includeFile(fileName, dir, depth)
+			if err != nil {
+				return nil, err
+			}
+			m, ok := o1.(map[string]interface{})
+			if !ok {
+				if len(o) > 0 || len(fileNames) > 1 {
+					return nil, errors.Errorf("pdfcpu: %s: can't merge non object", fileName)
+				}
+				return o1, nil
+			}
+			// Attributes next to $include and from earlier includes take precedence.
+			mergeJSON(o, m)
+		}
+
+		return o, nil
+
+	case []interface{}:
+		for i, v := range o {
+			v1, err := resolveIncludes(v, dir, depth)
+			if err != nil {
+				return nil, err
+			}
+			o[i] = v1
+		}
+	}
+
+	return o, nil
+}
+
+// ResolveIncludes replaces JSON objects of the form {"$include": "partial.json"} by the content of the partial JSON file.
+// Any further attributes of such an object override the corresponding included attributes.
+// Multiple files may be included using an array of file names.
+// Relative file names are resolved against dir for bb and against the including file for nested includes.
+func ResolveIncludes(bb []byte, dir string) ([]byte, error) {
+	if !bytes.Contains(bb, []byte(IncludeKey)) {
+		return bb, nil
+	}
+
+	o, err := decodeJSON(bytes.NewReader(bb))
+	if err != nil {
+		return nil, err
+	}
+
+	if o, err = resolveIncludes(o, dir, 0); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(o)
+}
+
+// lookup returns the value for a dot separated key path like "customer.name".
+func lookup(data map[string]interface{}, key string) (interface{}, bool) {
+	var o interface{} = data
+	for _, k := range strings.Split(key, ".") {
+		m, ok := o.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if o, ok = m[k]; !ok {
+			return nil, false
+		}
+	}
+	return o, true
+}
+
+func bindString(s string, data map[string]interface{}) (interface{}, error) {
+	// A string consisting of a single placeholder takes over the data type, eg. table values.
+	if m := placeholder.FindStringSubmatch(s); m != nil && m[0] == s {
+		v, ok := lookup(data, m[1])
+		if !ok {
+			return nil, errors.Errorf("pdfcpu: missing data for placeholder: %s", m[1])
+		}
+		return v, nil
+	}
+
+	var err error
+	s = placeholder.ReplaceAllStringFunc(s, func(s1 string) string {
+		key := placeholder.FindStringSubmatch(s1)[1]
+		v, ok := lookup(data, key)
+		if !ok {
+			if err == nil {
+				err = errors.Errorf("pdfcpu: missing data for placeholder: %s", key)
+			}
+			return s1
+		}
+		switch v := v.(type) {
+		case string:
+			return v
+		case json.Number:
+			return v.String()
+		case nil:
+			return ""
+		}
+		bb, _ := json.Marshal(v)
+		return string(bb)
+	})
+
+	return s, err
+}
+
+func bindData(o interface{}, data map[string]interface{}) (interface{}, error) {
+	switch o := o.(type) {
+
+	case string:
+		return bindString(o, data)
+
+	case map[string]interface{}:
+		for k, v := range o {
+			v1, err := bindData(v, data)
+			if err != nil {
+				return nil, err
+			}
+			o[k] = v1
+		}
+
+	case []interface{}:
+		for i, v := range o {
+			v1, err := bindData(v, data)
+			if err != nil {
+				return nil, err
+			}
+			o[i] = v1
+		}
+	}
+
+	return o, nil
+}
+
+// BindData substitutes all {{placeholders}} within JSON string values of bb by the corresponding data values.
+// Placeholders may address nested data using dot separated keys.
+// A string value consisting of a placeholder only is replaced by the data value including its type,
+// so for example table values may be bound to an array of rows.
+func BindData(bb []byte, data map[string]interface{}) ([]byte, error) {
+	o, err := decodeJSON(bytes.NewReader(bb))
+	if err != nil {
+		return nil, err
+	}
+
+	if o, err = bindData(o, data); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(o)
+}
+
+// ReadData reads data records for binding from rd.
+// JSON data is either a single object or an array of objects.
+// CSV data starts with a header row holding the keys followed by one row per record.
+func ReadData(rd io.Reader, isCSV bool) ([]map[string]interface{}, error) {
+	if isCSV {
+		return readCSVData(rd)
+	}
+
+	o, err := decodeJSON(rd)
+	if err != nil {
+		return nil, err
+	}
+
+	switch o := o.(type) {
+
+	case map[string]interface{}:
+		return []map[string]interface{}{o}, nil
+
+	case []interface{}:
+		var recs []map[string]interface{}
+		for i, v := range o {
+			m, ok := v.(map[string]interface{})
+			if !ok {
+				return nil, errors.Errorf("pdfcpu: data record %d is not a JSON object", i+1)
+			}
+			recs = append(recs, m)
+		}
+		if len(recs) == 0 {
+			return nil, errors.New("pdfcpu: missing data records")
+		}
+		return recs, nil
+	}
+
+	return nil, errors.New("pdfcpu: data must be a JSON object or an array of JSON objects")
+}
+
+func readCSVData(rd io.Reader) ([]map[string]interface{}, error) {
+	lines, err := csv.NewReader(rd).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(lines) < 2 || len(lines[0]) == 0 {
+		return nil, errors.New("pdfcpu: CSV data needs a header row and at least one record")
+	}
+
+	keys := lines[0]
+
+	var recs []map[string]interface{}
+	for i, line := range lines[1:] {
+		if len(line) != len(keys) {
+			return nil, errors.Errorf("pdfcpu: CSV record %d: expected %d values", i+1, len(keys))
+		}
+		m := map[string]interface{}{}
+		for j, k := range keys {
+			m[strings.TrimSpace(k)] = line[j]
+		}
+		recs = append(recs, m)
+	}
+
+	return recs, nil
+}
+
+// DataFileName returns the file name for the result of data record i (0-based) out of n.
+func DataFileName(fileName string, i, n int) string {
+	if n == 1 {
+		return fileName
+	}
+	ext := filepath.Ext(fileName)
+	return fmt.Sprintf("%s_%02d%s", strings.TrimSuffix(fileName, ext), i+1, ext)
+}
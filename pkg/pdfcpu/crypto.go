@@ -54,83 +54,141 @@ var (
 
 	// Needed permission bits for pdfcpu commands.
 	perm = map[model.CommandMode]struct{ extract, modify int }{
-		model.VALIDATE:                {0, 0},
-		model.LISTINFO:                {0, 0},
-		model.OPTIMIZE:                {0, 0},
-		model.SPLIT:                   {1, 0},
-		model.SPLITBYPAGENR:           {1, 0},
-		model.MERGECREATE:             {0, 0},
-		model.MERGECREATEZIP:          {0, 0},
-		model.MERGEAPPEND:             {0, 0},
-		model.EXTRACTIMAGES:           {1, 0},
-		model.EXTRACTFONTS:            {1, 0},
-		model.EXTRACTPAGES:            {1, 0},
-		model.EXTRACTCONTENT:          {1, 0},
-		model.EXTRACTMETADATA:         {1, 0},
-		model.TRIM:                    {0, 1},
-		model.LISTATTACHMENTS:         {0, 0},
-		model.EXTRACTATTACHMENTS:      {1, 0},
-		model.ADDATTACHMENTS:          {0, 1},
-		model.ADDATTACHMENTSPORTFOLIO: {0, 1},
-		model.REMOVEATTACHMENTS:       {0, 1},
-		model.LISTPERMISSIONS:         {0, 0},
-		model.SETPERMISSIONS:          {0, 0},
-		model.ADDWATERMARKS:           {0, 1},
-		model.REMOVEWATERMARKS:        {0, 1},
-		model.IMPORTIMAGES:            {0, 1},
-		model.INSERTPAGESBEFORE:       {0, 1},
-		model.INSERTPAGESAFTER:        {0, 1},
-		model.REMOVEPAGES:             {0, 1},
-		model.LISTKEYWORDS:            {0, 0},
-		model.ADDKEYWORDS:             {0, 1},
-		model.REMOVEKEYWORDS:          {0, 1},
-		model.LISTPROPERTIES:          {0, 0},
-		model.ADDPROPERTIES:           {0, 1},
-		model.REMOVEPROPERTIES:        {0, 1},
-		model.COLLECT:                 {1, 0},
-		model.CROP:                    {0, 1},
-		model.LISTBOXES:               {0, 0},
-		model.ADDBOXES:                {0, 1},
-		model.REMOVEBOXES:             {0, 1},
-		model.LISTANNOTATIONS:         {0, 1},
-		model.ADDANNOTATIONS:          {0, 1},
-		model.REMOVEANNOTATIONS:       {0, 1},
-		model.ROTATE:                  {0, 1},
-		model.NUP:                     {0, 1},
-		model.BOOKLET:                 {0, 1},
-		model.LISTBOOKMARKS:           {0, 0},
-		model.ADDBOOKMARKS:            {0, 1},
-		model.REMOVEBOOKMARKS:         {0, 1},
-		model.IMPORTBOOKMARKS:         {0, 1},
-		model.EXPORTBOOKMARKS:         {0, 1},
-		model.LISTIMAGES:              {0, 1},
-		model.UPDATEIMAGES:            {0, 1},
-		model.CREATE:                  {0, 0},
-		model.DUMP:                    {0, 1},
-		model.LISTFORMFIELDS:          {0, 0},
-		model.REMOVEFORMFIELDS:        {0, 1},
-		model.LOCKFORMFIELDS:          {0, 1},
-		model.UNLOCKFORMFIELDS:        {0, 1},
-		model.RESETFORMFIELDS:         {0, 1},
-		model.EXPORTFORMFIELDS:        {0, 1},
-		model.FILLFORMFIELDS:          {0, 1},
-		model.LISTPAGELAYOUT:          {0, 1},
-		model.SETPAGELAYOUT:           {0, 1},
-		model.RESETPAGELAYOUT:         {0, 1},
-		model.LISTPAGEMODE:            {0, 1},
-		model.SETPAGEMODE:             {0, 1},
-		model.RESETPAGEMODE:           {0, 1},
-		model.LISTVIEWERPREFERENCES:   {0, 1},
-		model.SETVIEWERPREFERENCES:    {0, 1},
-		model.RESETVIEWERPREFERENCES:  {0, 1},
-		model.ZOOM:                    {0, 1},
+		model.VALIDATE:                 {0, 0},
+		model.LISTINFO:                 {0, 0},
+		model.OPTIMIZE:                 {0, 0},
+		model.SPLIT:                    {1, 0},
+		model.SPLITBYPAGENR:            {1, 0},
+		model.MERGECREATE:              {0, 0},
+		model.MERGECREATEZIP:           {0, 0},
+		model.MERGEAPPEND:              {0, 0},
+		model.EXTRACTIMAGES:            {1, 0},
+		model.EXTRACTFONTS:             {1, 0},
+		model.EXTRACTPAGES:             {1, 0},
+		model.EXTRACTCONTENT:           {1, 0},
+		model.EXTRACTMETADATA:          {1, 0},
+		model.TRIM:                     {0, 1},
+		model.LISTATTACHMENTS:          {0, 0},
+		model.EXTRACTATTACHMENTS:       {1, 0},
+		model.ADDATTACHMENTS:           {0, 1},
+		model.ADDATTACHMENTSPORTFOLIO:  {0, 1},
+		model.REMOVEATTACHMENTS:        {0, 1},
+		model.LISTPERMISSIONS:          {0, 0},
+		model.SETPERMISSIONS:           {0, 0},
+		model.ADDWATERMARKS:            {0, 1},
+		model.ADDPAGENUMBERS:           {0, 1},
+		model.REMOVEWATERMARKS:         {0, 1},
+		model.IMPORTIMAGES:             {0, 1},
+		model.INSERTPAGESBEFORE:        {0, 1},
+		model.INSERTPAGESAFTER:         {0, 1},
+		model.REMOVEPAGES:              {0, 1},
+		model.LISTKEYWORDS:             {0, 0},
+		model.ADDKEYWORDS:              {0, 1},
+		model.REMOVEKEYWORDS:           {0, 1},
+		model.LISTPROPERTIES:           {0, 0},
+		model.ADDPROPERTIES:            {0, 1},
+		model.REMOVEPROPERTIES:         {0, 1},
+		model.COLLECT:                  {1, 0},
+		model.CROP:                     {0, 1},
+		model.LISTBOXES:                {0, 0},
+		model.ADDBOXES:                 {0, 1},
+		model.REMOVEBOXES:              {0, 1},
+		model.LISTANNOTATIONS:          {0, 1},
+		model.ADDANNOTATIONS:           {0, 1},
+		model.REMOVEANNOTATIONS:        {0, 1},
+		model.ROTATE:                   {0, 1},
+		model.NUP:                      {0, 1},
+		model.BOOKLET:                  {0, 1},
+		model.LISTBOOKMARKS:            {0, 0},
+		model.ADDBOOKMARKS:             {0, 1},
+		model.REMOVEBOOKMARKS:          {0, 1},
+		model.IMPORTBOOKMARKS:          {0, 1},
+		model.EXPORTBOOKMARKS:          {0, 1},
+		model.LISTIMAGES:               {0, 1},
+		model.UPDATEIMAGES:             {0, 1},
+		model.CREATE:                   {0, 0},
+		model.DUMP:                     {0, 1},
+		model.LISTFORMFIELDS:           {0, 0},
+		model.REMOVEFORMFIELDS:         {0, 1},
+		model.LOCKFORMFIELDS:           {0, 1},
+		model.UNLOCKFORMFIELDS:         {0, 1},
+		model.RESETFORMFIELDS:          {0, 1},
+		model.EXPORTFORMFIELDS:         {0, 1},
+		model.FILLFORMFIELDS:           {0, 1},
+		model.LISTPAGELAYOUT:           {0, 1},
+		model.SETPAGELAYOUT:            {0, 1},
+		model.RESETPAGELAYOUT:          {0, 1},
+		model.LISTLANGUAGE:             {0, 0},
+		model.SETLANGUAGE:              {0, 1},
+		model.RESETLANGUAGE:            {0, 1},
+		model.LISTOUTPUTINTENTS:        {0, 0},
+		model.ADDOUTPUTINTENT:          {0, 1},
+		model.REMOVEOUTPUTINTENTS:      {0, 1},
+		model.LISTPAGEMODE:             {0, 1},
+		model.SETPAGEMODE:              {0, 1},
+		model.RESETPAGEMODE:            {0, 1},
+		model.LISTVIEWERPREFERENCES:    {0, 1},
+		model.SETVIEWERPREFERENCES:     {0, 1},
+		model.RESETVIEWERPREFERENCES:   {0, 1},
+		model.ZOOM:                     {0, 1},
+		model.OVERLAY:                  {0, 1},
+		model.IMPORTKEYWORDS:           {0, 1},
+		model.EXPORTKEYWORDS:           {0, 0},
+		model.EXPORTPROPERTIES:         {0, 0},
+		model.LISTOPENACTION:           {0, 1},
+		model.SETOPENACTION:            {0, 1},
+		model.RESETOPENACTION:          {0, 1},
+		model.LISTTRANSITIONS:          {0, 0},
+		model.SETTRANSITIONS:           {0, 1},
+		model.REMOVETRANSITIONS:        {0, 1},
+		model.LISTGEO:                  {0, 0},
+		model.ADDGEO:                   {0, 1},
+		model.REMOVEGEO:                {0, 1},
+		model.LISTMEDIA:                {0, 0},
+		model.EXTRACTMEDIA:             {1, 0},
+		model.SPLITBYBOOKMARK:          {1, 0},
+		model.BATCH:                    {0, 1},
+		model.PIPELINE:                 {0, 1},
+		model.EXPORTANNOTATIONS:        {0, 1},
+		model.REFLOW:                   {1, 0},
+		model.ADDTEXTLAYER:             {0, 1},
+		model.CONVERTPDFX:              {0, 1},
+		model.LISTCOLORANTS:            {0, 0},
+		model.EDITCOLORANTS:            {0, 1},
+		model.LISTOVERPRINT:            {0, 0},
+		model.SETOVERPRINT:             {0, 1},
+		model.FIXHAIRLINES:             {0, 1},
+		model.NORMALIZECONTENT:         {0, 1},
+		model.EXTRACTPAGECONTENT:       {1, 0},
+		model.REPLACECONTENT:           {0, 1},
+		model.EXTRACTFORMXOBJECTS:      {1, 0},
+		model.PLACEPAGE:                {0, 1},
+		model.LISTTEMPLATEREGIONS:      {0, 0},
+		model.ADDTEMPLATEREGIONS:       {0, 1},
+		model.FILLTEMPLATE:             {0, 1},
+		model.SORTBOOKMARKS:            {0, 1},
+		model.DEDUPBOOKMARKS:           {0, 1},
+		model.COLLAPSEBOOKMARKS:        {0, 1},
+		model.RETARGETBOOKMARKS:        {0, 1},
+		model.VERIFYATTACHMENTS:        {1, 0},
+		model.FLATTENPAGEATTRS:         {0, 1},
+		model.GENERATEANNOTAPPEARANCES: {0, 1},
+		model.TRIAGE:                   {0, 0},
+		model.ADDSIGNATUREFIELD:        {0, 1},
+		model.PREPARESIGNATURE:         {0, 1},
+		model.EMBEDSIGNATURE:           {0, 0},
+		model.ADDVALIDATIONINFO:        {0, 1},
+		model.LISTUSAGERIGHTS:          {0, 0},
+		model.REMOVEUSAGERIGHTS:        {0, 1},
+		model.EXPORTFORMFIELDSCSV:      {0, 1},
 	}
 
 	ErrUnknownEncryption = errors.New("pdfcpu: unknown encryption")
 )
 
 // NewEncryptDict creates a new EncryptDict using the standard security handler.
-func newEncryptDict(v model.Version, needAES bool, keyLength int, permissions int16) types.Dict {
+// If attachmentsOnly is set only embedded file streams get encrypted.
+func newEncryptDict(v model.Version, needAES bool, keyLength int, permissions int16, attachmentsOnly bool) types.Dict {
 	d := types.NewDict()
 
 	d.Insert("Filter", types.Name("Standard"))
@@ -154,11 +212,18 @@ func newEncryptDict(v model.Version, needAES bool, keyLength int, permissions in
 	// Set user access permission flags.
 	d.Insert("P", types.Integer(permissions))
 
-	d.Insert("StmF", types.Name("StdCF"))
-	d.Insert("StrF", types.Name("StdCF"))
-
 	d1 := types.NewDict()
-	d1.Insert("AuthEvent", types.Name("DocOpen"))
+
+	if attachmentsOnly {
+		d.Insert("StmF", types.Name("Identity"))
+		d.Insert("StrF", types.Name("Identity"))
+		d.Insert("EFF", types.Name("StdCF"))
+		d1.Insert("AuthEvent", types.Name("EFOpen"))
+	} else {
+		d.Insert("StmF", types.Name("StdCF"))
+		d.Insert("StrF", types.Name("StdCF"))
+		d1.Insert("AuthEvent", types.Name("DocOpen"))
+	}
 
 	if needAES {
 		n := "AESV2"
@@ -741,7 +806,7 @@ func supportedCFEntry(d types.Dict) (bool, error) {
 	}
 
 	ae := d.NameEntry("AuthEvent")
-	if ae != nil && *ae != "DocOpen" {
+	if ae != nil && *ae != "DocOpen" && *ae != "EFOpen" {
 		return false, errors.New("pdfcpu: supportedCFEntry: invalid entry \"AuthEvent\"")
 	}
 
@@ -887,8 +952,30 @@ func maskModify(mode model.CommandMode, secHandlerRev int) int {
 	return 0x0008 // need bit 4
 }
 
-// HasNeededPermissions returns true if permissions for pdfcpu processing are present.
-func hasNeededPermissions(mode model.CommandMode, enc *model.Enc) bool {
+// PermissionError reports an operation restricted by the user access permissions of an encrypted file (see Table 22).
+// Supplying the owner password lifts all restrictions.
+type PermissionError struct {
+	Cmd       model.CommandMode // The command in progress.
+	Bit       int               // The permission bit required but not set.
+	Operation string            // The operation controlled by Bit.
+}
+
+func (e *PermissionError) Error() string {
+	return fmt.Sprintf("pdfcpu: operation restricted: %s requires permission bit %d which is not set - please provide the owner password with -opw", e.Operation, e.Bit)
+}
+
+// bitNr returns the 1-based number of the bit set in mask.
+func bitNr(mask int) int {
+	i := 1
+	for mask > 1 {
+		mask >>= 1
+		i++
+	}
+	return i
+}
+
+// neededPermissions returns a *PermissionError if permissions for pdfcpu processing are missing.
+func neededPermissions(mode model.CommandMode, enc *model.Enc) error {
 	// see 7.6.3.2
 
 	logP(enc)
@@ -896,18 +983,22 @@ func hasNeededPermissions(mode model.CommandMode, enc *model.Enc) bool {
 	m := maskExtract(mode, enc.R)
 	if m > 0 {
 		if enc.P&m == 0 {
-			return false
+			return &PermissionError{Cmd: mode, Bit: bitNr(m), Operation: "extracting text and graphics"}
 		}
 	}
 
 	m = maskModify(mode, enc.R)
 	if m > 0 {
 		if enc.P&m == 0 {
-			return false
+			op := "modifying content"
+			if enc.R >= 3 {
+				op = "assembling the document"
+			}
+			return &PermissionError{Cmd: mode, Bit: bitNr(m), Operation: op}
 		}
 	}
 
-	return true
+	return nil
 }
 
 func getV(ctx *model.Context, d types.Dict, l int) (*int, error) {
@@ -929,7 +1020,12 @@ func getV(ctx *model.Context, d types.Dict, l int) (*int, error) {
 	return v, nil
 }
 func checkStmf(ctx *model.Context, stmf *string, cfDict types.Dict) error {
-	if stmf != nil && *stmf != "Identity" {
+	if stmf != nil && *stmf == "Identity" {
+		ctx.EncStreams = false
+		return nil
+	}
+
+	if stmf != nil {
 
 		d := cfDict.DictEntry(*stmf)
 		if d == nil {
@@ -946,12 +1042,41 @@ func checkStmf(ctx *model.Context, stmf *string, cfDict types.Dict) error {
 	return nil
 }
 
+func checkEFF(ctx *model.Context, eff *string, cfDict types.Dict) error {
+	if eff == nil {
+		// Embedded file streams default to StmF.
+		ctx.AES4EmbeddedStreams = ctx.AES4Streams
+		ctx.EncEmbeddedStreams = ctx.EncStreams
+		return nil
+	}
+
+	if *eff == "Identity" {
+		ctx.EncEmbeddedStreams = false
+		return nil
+	}
+
+	d := cfDict.DictEntry(*eff)
+	if d == nil {
+		return errors.Errorf("pdfcpu: checkV: entry \"%s\" missing in \"CF\"", *eff)
+	}
+
+	aes, err := supportedCFEntry(d)
+	if err != nil {
+		return errors.Wrapf(err, "checkV: unsupported \"%s\" entry in \"CF\"", *eff)
+	}
+	ctx.AES4EmbeddedStreams = aes
+
+	return nil
+}
+
 func checkV(ctx *model.Context, d types.Dict, l int) (*int, error) {
 	v, err := getV(ctx, d, l)
 	if err != nil {
 		return nil, err
 	}
 
+	ctx.EncStrings, ctx.EncStreams, ctx.EncEmbeddedStreams = true, true, true
+
 	// v == 2 implies RC4
 	if *v != 4 && *v != 5 {
 		return v, nil
@@ -972,7 +1097,9 @@ func checkV(ctx *model.Context, d types.Dict, l int) (*int, error) {
 
 	// StrF
 	strf := d.NameEntry("StrF")
-	if strf != nil && *strf != "Identity" {
+	if strf != nil && *strf == "Identity" {
+		ctx.EncStrings = false
+	} else if strf != nil {
 		d1 := cfDict.DictEntry(*strf)
 		if d1 == nil {
 			return nil, errors.Errorf("pdfcpu: checkV: entry \"%s\" missing in \"CF\"", *strf)
@@ -985,17 +1112,8 @@ func checkV(ctx *model.Context, d types.Dict, l int) (*int, error) {
 	}
 
 	// EFF
-	eff := d.NameEntry("EFF")
-	if eff != nil && *eff != "Identity" {
-		d := cfDict.DictEntry(*eff)
-		if d == nil {
-			return nil, errors.Errorf("pdfcpu: checkV: entry \"%s\" missing in \"CF\"", *eff)
-		}
-		aes, err := supportedCFEntry(d)
-		if err != nil {
-			return nil, errors.Wrapf(err, "checkV: unsupported \"%s\" entry in \"CF\"", *eff)
-		}
-		ctx.AES4EmbeddedStreams = aes
+	if err := checkEFF(ctx, d.NameEntry("EFF"), cfDict); err != nil {
+		return nil, err
 	}
 
 	return v, nil
@@ -1515,6 +1633,21 @@ func encryptStream(buf []byte, objNr, genNr int, encKey []byte, needAES bool, r
 	return applyRC4Bytes(buf, k)
 }
 
+// streamEncryption returns true if stream objNr is subject to encryption and whether AES applies.
+func streamEncryption(ctx *model.Context, objNr int) (enc, aes bool) {
+	if ctx.EmbeddedFileStreams[objNr] {
+		return ctx.EncEmbeddedStreams && !ctx.EmbeddedFilesLocked, ctx.AES4EmbeddedStreams
+	}
+	return ctx.EncStreams, ctx.AES4Streams
+}
+
+// embeddedFileCryptDiffers returns true if embedded file streams need a crypt filter other than StmF.
+func embeddedFileCryptDiffers(ctx *model.Context) bool {
+	return ctx.EncEmbeddedStreams != ctx.EncStreams ||
+		ctx.AES4EmbeddedStreams != ctx.AES4Streams ||
+		ctx.EmbeddedFilesLocked
+}
+
 // decryptStream decrypts a stream buffer using RC4 or AES.
 func decryptStream(buf []byte, objNr, genNr int, encKey []byte, needAES bool, r int) ([]byte, error) {
 	k := encKey
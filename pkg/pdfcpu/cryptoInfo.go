@@ -0,0 +1,178 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+// EncryptionInfo represents the setup of the standard security handler of an encrypted PDF file.
+type EncryptionInfo struct {
+	Encrypted       bool     `json:"encrypted"`
+	V               int      `json:"v,omitempty"`
+	R               int      `json:"revision,omitempty"`
+	KeyLength       int      `json:"keyLength,omitempty"`
+	Algorithm       string   `json:"algorithm,omitempty"`
+	EncryptMetadata bool     `json:"encryptMetadata"`
+	AttachmentsOnly bool     `json:"attachmentsOnly"`
+	Permissions     int      `json:"permissions"`
+	PermissionList  []string `json:"permissionList,omitempty"`
+	UserPWRequired  bool     `json:"userPasswordRequired"`
+	UserPWValid     bool     `json:"userPasswordValid"`
+	OwnerPWValid    bool     `json:"ownerPasswordValid"`
+	Resistance      string   `json:"bruteForceResistance,omitempty"`
+}
+
+func encryptionAlgorithm(ctx *model.Context) string {
+	if ctx.E.V < 4 || !ctx.AES4Streams && !ctx.AES4EmbeddedStreams {
+		return "RC4"
+	}
+	return "AES"
+}
+
+// bruteForceResistance classifies the security handler's resistance against password brute forcing.
+func bruteForceResistance(e *model.Enc) string {
+	switch e.R {
+	case 2:
+		return "weak (40 bit RC4)"
+	case 3, 4:
+		if e.L < 128 {
+			return "weak (RC4 key length < 128 bits)"
+		}
+		return "moderate (MD5 based key derivation)"
+	case 5:
+		return "weak (deprecated single pass SHA-256 password validation)"
+	case 6:
+		return "strong (iterated hash password validation)"
+	}
+	return "unknown"
+}
+
+// passwordValid checks pw against the user or owner password entries of the encrypt dict w/o altering ctx.
+func passwordValid(ctx *model.Context, pw string, owner bool) (bool, error) {
+	upw, opw, key := ctx.UserPW, ctx.OwnerPW, ctx.EncKey
+	defer func() {
+		ctx.UserPW, ctx.OwnerPW, ctx.EncKey = upw, opw, key
+	}()
+
+	if owner {
+		if pw == "" {
+			return false, nil
+		}
+		ctx.OwnerPW = pw
+		return validateOwnerPassword(ctx)
+	}
+
+	ctx.UserPW = pw
+	return validateUserPassword(ctx)
+}
+
+// ReadEncryptionInfo returns information about the encryption of rs.
+// Only the cross reference table and the encrypt dict get read, rs does not need to be decrypted.
+func ReadEncryptionInfo(rs io.ReadSeeker, conf *model.Configuration) (*EncryptionInfo, error) {
+	c := context.Background()
+
+	ctx, err := model.NewContext(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	if ctx.Read.FileSize == 0 {
+		return nil, errors.New("The file could not be opened because it is empty.")
+	}
+
+	if err = readXRefTable(c, ctx); err != nil {
+		return nil, errors.Wrap(err, "ReadEncryptionInfo: xRefTable failed")
+	}
+
+	info := &EncryptionInfo{}
+	if ctx.Encrypt == nil {
+		return info, nil
+	}
+	info.Encrypted = true
+
+	d, err := dereferencedDict(c, ctx, ctx.Encrypt.ObjectNumber.Value())
+	if err != nil {
+		return nil, err
+	}
+
+	if ctx.E, err = supportedEncryption(ctx, d); err != nil {
+		return nil, err
+	}
+
+	if ctx.E.ID, err = ctx.IDFirstElement(); err != nil {
+		return nil, err
+	}
+
+	info.V = ctx.E.V
+	info.R = ctx.E.R
+	info.KeyLength = ctx.E.L
+	info.Algorithm = encryptionAlgorithm(ctx)
+	info.EncryptMetadata = ctx.E.Emd
+	info.AttachmentsOnly = !ctx.EncStrings && !ctx.EncStreams && ctx.EncEmbeddedStreams
+	info.Permissions = ctx.E.P
+	info.PermissionList = PermissionsList(ctx.E.P)
+	info.Resistance = bruteForceResistance(ctx.E)
+
+	ok, err := passwordValid(ctx, "", false)
+	if err != nil {
+		return nil, err
+	}
+	info.UserPWRequired = !ok
+
+	if info.UserPWValid, err = passwordValid(ctx, ctx.UserPW, false); err != nil {
+		return nil, err
+	}
+
+	if info.OwnerPWValid, err = passwordValid(ctx, ctx.OwnerPW, true); err != nil {
+		return nil, err
+	}
+
+	return info, nil
+}
+
+// ListEncryptionInfo returns a formatted list of encryption details.
+func ListEncryptionInfo(info *EncryptionInfo) []string {
+	if !info.Encrypted {
+		return []string{"not encrypted"}
+	}
+
+	ss := []string{
+		fmt.Sprintf("%22s: Standard", "Security handler"),
+		fmt.Sprintf("%22s: %d", "Revision", info.R),
+		fmt.Sprintf("%22s: %d", "Version", info.V),
+		fmt.Sprintf("%22s: %s-%d", "Algorithm", info.Algorithm, info.KeyLength),
+		fmt.Sprintf("%22s: %t", "Encrypted metadata", info.EncryptMetadata),
+		fmt.Sprintf("%22s: %t", "Attachments only", info.AttachmentsOnly),
+		fmt.Sprintf("%22s: %t", "User password required", info.UserPWRequired),
+		fmt.Sprintf("%22s: %t", "User password valid", info.UserPWValid),
+		fmt.Sprintf("%22s: %t", "Owner password valid", info.OwnerPWValid),
+		fmt.Sprintf("%22s: %s", "Brute force resistance", info.Resistance),
+		fmt.Sprintf("%22s:", "Permissions"),
+	}
+
+	for _, s := range info.PermissionList {
+		ss = append(ss, "    "+s)
+	}
+
+	return ss
+}
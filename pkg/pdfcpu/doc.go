@@ -4,10 +4,10 @@ It provides an API and a command line interface. Supported are all versions up t
 
 The commands are:
 
-	annotations   list, remove page annotations
-	attachments   list, add, remove, extract embedded file attachments
+	annotations   list, remove, export page annotations, generate missing appearances
+	attachments   list, add, remove, extract, verify embedded file attachments
 	booklet       arrange pages onto larger sheets of paper to make a booklet or zine
-	bookmarks     list, import, export, remove bookmarks
+	bookmarks     list, import, export, remove, sort, dedup, collapse, retarget bookmarks
 	boxes         list, add, remove page boundaries for selected pages
 	changeopw     change owner password
 	changeupw     change user password
@@ -26,13 +26,15 @@ The commands are:
 	import        import/convert images to PDF
 	info          print file info
 	keywords      list, add, remove keywords
+	language      list, set, reset the natural language of the document
 	merge         concatenate PDFs
 	ndown         cut selected pages into n pages symmetrically
 	nup           rearrange pages or images for reduced number of pages
 	optimize      optimize PDF by getting rid of redundant page resources
+	outputintent  list, add, remove output intents with ICC profiles for PDF/A and PDF/X
 	pagelayout    list, set, reset page layout for opened document
 	pagemode      list, set, reset page mode for opened document
-	pages         insert, remove selected pages
+	pages         insert, remove selected pages, flatten inherited page attributes
 	paper         print list of supported paper sizes
 	permissions   list, set user access permissions
 	portfolio     list, add, remove, extract portfolio entries with optional description
@@ -41,8 +43,10 @@ The commands are:
 	resize        scale selected pages
 	rotate        rotate selected pages
 	selectedpages print definition of the -pages flag
+	signatures    embed validation material, list, remove usage rights
 	split         split up a PDF by span or bookmark
 	stamp         add, remove, update Unicode text, image or PDF stamps for selected pages
+	triage        classify which processing stage and object a failing file breaks
 	trim          create trimmed version of selected pages
 	validate      validate PDF against PDF 32000-1:2008 (PDF 1.7) + basic PDF 2.0 validation
 	version       print version
@@ -19,6 +19,7 @@ package draw
 import (
 	"fmt"
 	"io"
+	"math"
 	"strings"
 
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/color"
@@ -55,6 +56,21 @@ func SetFillColor(w io.Writer, c color.SimpleColor) {
 	fmt.Fprintf(w, "%.2f %.2f %.2f rg ", c.R, c.G, c.B)
 }
 
+// SetExtGState sets the graphics state parameters of the ExtGState resource id.
+func SetExtGState(w io.Writer, id string) {
+	fmt.Fprintf(w, "/%s gs ", id)
+}
+
+// SetFillPattern sets the fill color to the pattern resource id.
+func SetFillPattern(w io.Writer, id string) {
+	fmt.Fprintf(w, "/Pattern cs /%s scn ", id)
+}
+
+// SetStrokePattern sets the stroke color to the pattern resource id.
+func SetStrokePattern(w io.Writer, id string) {
+	fmt.Fprintf(w, "/Pattern CS /%s SCN ", id)
+}
+
 // DrawLineSimple draws the path from P to Q.
 func DrawLineSimple(w io.Writer, xp, yp, xq, yq float64) {
 	fmt.Fprintf(w, "%.2f %.2f m %.2f %.2f l s ", xp, yp, xq, yq)
@@ -110,6 +126,65 @@ func FillRect(w io.Writer, r *types.Rectangle, lineWidth float64, strokeCol *col
 	fmt.Fprintf(w, "Q ")
 }
 
+// FillRectWithPattern fills a rectangular path for r using the pattern resource id.
+func FillRectWithPattern(w io.Writer, r *types.Rectangle, id string) {
+	fmt.Fprintf(w, "q ")
+	SetFillPattern(w, id)
+	fmt.Fprintf(w, "%.2f %.2f %.2f %.2f re f ", r.LL.X, r.LL.Y, r.Width(), r.Height())
+	fmt.Fprintf(w, "Q ")
+}
+
+// DrawRectWithPattern strokes a rectangular path for r using lineWidth, the pattern resource id and style.
+func DrawRectWithPattern(w io.Writer, r *types.Rectangle, lineWidth float64, id string, style *types.LineJoinStyle) {
+	fmt.Fprintf(w, "q ")
+	SetLineWidth(w, lineWidth)
+	SetStrokePattern(w, id)
+	if style != nil {
+		SetLineJoinStyle(w, *style)
+	}
+	DrawRectSimple(w, r)
+	fmt.Fprintf(w, "Q ")
+}
+
+// RoundedRectPath appends a closed rectangular path for r with corners rounded using radius rad.
+func RoundedRectPath(w io.Writer, r *types.Rectangle, rad float64) {
+	rad = math.Max(0, math.Min(rad, math.Min(r.Width(), r.Height())/2))
+	k := .5523 * rad
+	x0, y0, x1, y1 := r.LL.X, r.LL.Y, r.UR.X, r.UR.Y
+	fmt.Fprintf(w, "%.2f %.2f m %.2f %.2f l ", x0+rad, y0, x1-rad, y0)
+	fmt.Fprintf(w, "%.2f %.2f %.2f %.2f %.2f %.2f c ", x1-rad+k, y0, x1, y0+rad-k, x1, y0+rad)
+	fmt.Fprintf(w, "%.2f %.2f l ", x1, y1-rad)
+	fmt.Fprintf(w, "%.2f %.2f %.2f %.2f %.2f %.2f c ", x1, y1-rad+k, x1-rad+k, y1, x1-rad, y1)
+	fmt.Fprintf(w, "%.2f %.2f l ", x0+rad, y1)
+	fmt.Fprintf(w, "%.2f %.2f %.2f %.2f %.2f %.2f c ", x0+rad-k, y1, x0, y1-rad+k, x0, y1-rad)
+	fmt.Fprintf(w, "%.2f %.2f l ", x0, y0+rad)
+	fmt.Fprintf(w, "%.2f %.2f %.2f %.2f %.2f %.2f c h ", x0, y0+rad-k, x0+rad-k, y0, x0+rad, y0)
+}
+
+// EllipsePath appends a closed elliptic path inscribed into r.
+func EllipsePath(w io.Writer, r *types.Rectangle) {
+	c := r.Center()
+	rx, ry := r.Width()/2, r.Height()/2
+	kx, ky := .5523*rx, .5523*ry
+	fmt.Fprintf(w, "%.2f %.2f m ", c.X+rx, c.Y)
+	fmt.Fprintf(w, "%.2f %.2f %.2f %.2f %.2f %.2f c ", c.X+rx, c.Y+ky, c.X+kx, c.Y+ry, c.X, c.Y+ry)
+	fmt.Fprintf(w, "%.2f %.2f %.2f %.2f %.2f %.2f c ", c.X-kx, c.Y+ry, c.X-rx, c.Y+ky, c.X-rx, c.Y)
+	fmt.Fprintf(w, "%.2f %.2f %.2f %.2f %.2f %.2f c ", c.X-rx, c.Y-ky, c.X-kx, c.Y-ry, c.X, c.Y-ry)
+	fmt.Fprintf(w, "%.2f %.2f %.2f %.2f %.2f %.2f c h ", c.X+kx, c.Y-ry, c.X+rx, c.Y-ky, c.X+rx, c.Y)
+}
+
+// PolygonPath appends a closed path connecting pp.
+func PolygonPath(w io.Writer, pp []types.Point) {
+	for i, p := range pp {
+		op := "l"
+		if i == 0 {
+			op = "m"
+		}
+		fmt.Fprintf(w, "%.2f %.2f %s ", p.X, p.Y, op)
+	}
+	fmt.Fprint(w, "h ")
+}
+
 // DrawCircle strokes a circle with optional filling.
 func DrawCircle(w io.Writer, x, y, r float64, strokeCol color.SimpleColor, fillCol *color.SimpleColor) {
 	f := .5523
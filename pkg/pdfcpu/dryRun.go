@@ -0,0 +1,124 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"bufio"
+	"io"
+
+	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// changedSet returns the numbers of added and modified objects
+// and counts added, modified and removed objects since reading.
+func changedSet(ctx *model.Context, r *model.DryRunReport) map[int]bool {
+	m := map[int]bool{}
+
+	for _, objNr := range changedObjects(ctx) {
+		e := ctx.Table[objNr]
+		_, recorded := ctx.Read.ObjectDigests[objNr]
+		switch {
+		case e.Free:
+			r.Removed++
+		case recorded:
+			r.Modified++
+			m[objNr] = true
+		default:
+			r.Added++
+			m[objNr] = true
+		}
+	}
+
+	// Objects dropped from the cross reference table altogether.
+	for objNr := range ctx.Read.ObjectDigests {
+		if _, ok := ctx.Table[objNr]; !ok {
+			r.Removed++
+		}
+	}
+
+	return m
+}
+
+func pageAffected(d types.Dict, pageObjNr int, changed map[int]bool) bool {
+	if changed[pageObjNr] {
+		return true
+	}
+
+	switch o := d["Contents"].(type) {
+	case types.IndirectRef:
+		return changed[o.ObjectNumber.Value()]
+	case types.Array:
+		for _, o1 := range o {
+			if ir, ok := o1.(types.IndirectRef); ok && changed[ir.ObjectNumber.Value()] {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func affectedPages(ctx *model.Context, changed map[int]bool) ([]int, error) {
+	pp := []int{}
+
+	for i := 1; i <= ctx.PageCount; i++ {
+		d, ir, _, err := ctx.PageDict(i, false)
+		if err != nil {
+			return nil, err
+		}
+		if d == nil || ir == nil {
+			continue
+		}
+		if pageAffected(d, ir.ObjectNumber.Value(), changed) {
+			pp = append(pp, i)
+		}
+	}
+
+	return pp, nil
+}
+
+// writeDryRun performs a write into the void and reports what would change.
+func writeDryRun(ctx *model.Context) error {
+	r := model.DryRunReport{PageCount: ctx.PageCount, InputSize: ctx.Read.FileSize}
+
+	if ctx.Read.ObjectDigests != nil {
+		changed := changedSet(ctx, &r)
+		pp, err := affectedPages(ctx, changed)
+		if err != nil {
+			return err
+		}
+		r.AffectedPages = pp
+	}
+
+	ctx.Write.Writer = bufio.NewWriter(io.Discard)
+	ctx.Write.Fp = nil
+
+	if err := write(ctx); err != nil {
+		return err
+	}
+
+	r.OutputSize = ctx.Write.Offset
+	ctx.DryRunReport = &r
+
+	if log.CLIEnabled() {
+		log.CLI.Println(r)
+	}
+
+	return nil
+}
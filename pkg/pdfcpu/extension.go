@@ -0,0 +1,324 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// DeveloperExtension represents an entry of the document's extensions dict (see 7.12).
+type DeveloperExtension struct {
+	Prefix         string `json:"prefix"`
+	BaseVersion    string `json:"baseVersion"`
+	ExtensionLevel int    `json:"extensionLevel"`
+	URL            string `json:"url,omitempty"`
+}
+
+func (e DeveloperExtension) String() string {
+	s := fmt.Sprintf("%s %s level %d", e.Prefix, e.BaseVersion, e.ExtensionLevel)
+	if e.URL != "" {
+		s += " " + e.URL
+	}
+	return s
+}
+
+// supersedes returns true if e declares a higher base version or extension level than e1.
+func (e DeveloperExtension) supersedes(e1 DeveloperExtension) bool {
+	v, err := model.PDFVersion(e.BaseVersion)
+	if err != nil {
+		return false
+	}
+	v1, err := model.PDFVersion(e1.BaseVersion)
+	if err != nil {
+		return true
+	}
+	if v != v1 {
+		return v > v1
+	}
+	return e.ExtensionLevel > e1.ExtensionLevel
+}
+
+func developerExtension(xRefTable *model.XRefTable, prefix string, o types.Object) (*DeveloperExtension, error) {
+	d, err := xRefTable.DereferenceDict(o)
+	if err != nil || d == nil {
+		return nil, err
+	}
+
+	e := &DeveloperExtension{Prefix: prefix}
+
+	if bv := d.NameEntry("BaseVersion"); bv != nil {
+		e.BaseVersion = *bv
+	}
+
+	if i := d.IntEntry("ExtensionLevel"); i != nil {
+		e.ExtensionLevel = *i
+	}
+
+	if o, found := d.Find("URL"); found {
+		if e.URL, err = xRefTable.DereferenceStringOrHexLiteral(o, model.V10, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return e, nil
+}
+
+func developerExtensions(xRefTable *model.XRefTable, prefix string, o types.Object) ([]DeveloperExtension, error) {
+	o, err := xRefTable.Dereference(o)
+	if err != nil || o == nil {
+		return nil, err
+	}
+
+	arr, ok := o.(types.Array)
+	if !ok {
+		arr = types.Array{o}
+	}
+
+	ee := []DeveloperExtension{}
+	for _, o := range arr {
+		e, err := developerExtension(xRefTable, prefix, o)
+		if err != nil {
+			return nil, err
+		}
+		if e != nil {
+			ee = append(ee, *e)
+		}
+	}
+
+	return ee, nil
+}
+
+// Extensions returns the developer extensions declared by the catalog of xRefTable sorted by prefix.
+func Extensions(xRefTable *model.XRefTable) ([]DeveloperExtension, error) {
+	rootDict, err := xRefTable.Catalog()
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := xRefTable.DereferenceDict(rootDict["Extensions"])
+	if err != nil || d == nil {
+		return nil, err
+	}
+
+	var prefixes []string
+	for k := range d {
+		if k != "Type" {
+			prefixes = append(prefixes, k)
+		}
+	}
+	sort.Strings(prefixes)
+
+	ee := []DeveloperExtension{}
+	for _, prefix := range prefixes {
+		ee1, err := developerExtensions(xRefTable, prefix, d[prefix])
+		if err != nil {
+			return nil, err
+		}
+		ee = append(ee, ee1...)
+	}
+
+	return ee, nil
+}
+
+// Requirements returns the requirement types declared by the catalog of xRefTable.
+func Requirements(xRefTable *model.XRefTable) ([]string, error) {
+	rootDict, err := xRefTable.Catalog()
+	if err != nil {
+		return nil, err
+	}
+
+	arr, err := xRefTable.DereferenceArray(rootDict["Requirements"])
+	if err != nil {
+		return nil, err
+	}
+
+	ss := []string{}
+	for _, o := range arr {
+		d, err := xRefTable.DereferenceDict(o)
+		if err != nil {
+			return nil, err
+		}
+		if d == nil {
+			continue
+		}
+		if s := d.NameEntry("S"); s != nil {
+			ss = append(ss, *s)
+		}
+	}
+
+	return ss, nil
+}
+
+func containsDeveloperExtension(ee []DeveloperExtension, e DeveloperExtension) bool {
+	for _, e1 := range ee {
+		if e1 == e {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeExtensionsEntry merges the extensions declared for prefix by oSrc into the extensions dict dDest.
+func mergeExtensionsEntry(ctxSrc, ctxDest *model.Context, dDest types.Dict, prefix string, oSrc types.Object) error {
+	oDest, found := dDest.Find(prefix)
+	if !found {
+		dDest[prefix] = oSrc
+		return nil
+	}
+
+	eeSrc, err := developerExtensions(ctxSrc.XRefTable, prefix, oSrc)
+	if err != nil {
+		return err
+	}
+
+	eeDest, err := developerExtensions(ctxDest.XRefTable, prefix, oDest)
+	if err != nil {
+		return err
+	}
+
+	oSrc, _ = ctxSrc.Dereference(oSrc)
+	oDest, _ = ctxDest.Dereference(oDest)
+	_, arrSrc := oSrc.(types.Array)
+	_, arrDest := oDest.(types.Array)
+
+	if !arrSrc && !arrDest {
+		// A single extension per prefix: the higher level includes the lower one.
+		if len(eeSrc) == 1 && (len(eeDest) == 0 || eeSrc[0].supersedes(eeDest[0])) {
+			dDest[prefix] = oSrc
+		}
+		return nil
+	}
+
+	// PDF 2.0 allows for multiple independent extensions per prefix.
+	arr := types.Array{}
+	for _, o := range []types.Object{oDest, oSrc} {
+		if a, ok := o.(types.Array); ok {
+			arr = append(arr, a...)
+			continue
+		}
+		arr = append(arr, o)
+	}
+
+	var ee []DeveloperExtension
+	arr1 := types.Array{}
+	for _, o := range arr {
+		e, err := developerExtension(ctxDest.XRefTable, prefix, o)
+		if err != nil {
+			return err
+		}
+		if e == nil || containsDeveloperExtension(ee, *e) {
+			continue
+		}
+		ee = append(ee, *e)
+		arr1 = append(arr1, o)
+	}
+	dDest[prefix] = arr1
+
+	return nil
+}
+
+// mergeExtensions declares the superset of the developer extensions of ctxSrc and ctxDest in ctxDest.
+func mergeExtensions(ctxSrc, ctxDest *model.Context) error {
+	rootDictSrc, rootDictDest, err := rootDicts(ctxSrc, ctxDest)
+	if err != nil {
+		return err
+	}
+
+	o, found := rootDictSrc.Find("Extensions")
+	if !found {
+		return nil
+	}
+
+	dSrc, err := ctxSrc.DereferenceDict(o)
+	if err != nil || len(dSrc) == 0 {
+		return err
+	}
+
+	o, found = rootDictDest.Find("Extensions")
+	if !found {
+		rootDictDest["Extensions"] = dSrc
+		return nil
+	}
+
+	dDest, err := ctxDest.DereferenceDict(o)
+	if err != nil {
+		return err
+	}
+
+	if dDest == nil {
+		rootDictDest["Extensions"] = dSrc
+		return nil
+	}
+
+	for k, v := range dSrc {
+		if k == "Type" {
+			continue
+		}
+		if err := mergeExtensionsEntry(ctxSrc, ctxDest, dDest, k, v); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// mergeRequirements declares the union of the requirements of ctxSrc and ctxDest in ctxDest.
+func mergeRequirements(ctxSrc, ctxDest *model.Context) error {
+	rootDictSrc, rootDictDest, err := rootDicts(ctxSrc, ctxDest)
+	if err != nil {
+		return err
+	}
+
+	arrSrc, err := ctxSrc.DereferenceArray(rootDictSrc["Requirements"])
+	if err != nil || len(arrSrc) == 0 {
+		return err
+	}
+
+	arrDest, err := ctxDest.DereferenceArray(rootDictDest["Requirements"])
+	if err != nil {
+		return err
+	}
+
+	ss, err := Requirements(ctxDest.XRefTable)
+	if err != nil {
+		return err
+	}
+
+	for _, o := range arrSrc {
+		d, err := ctxSrc.DereferenceDict(o)
+		if err != nil {
+			return err
+		}
+		if d == nil {
+			continue
+		}
+		s := d.NameEntry("S")
+		if s == nil || types.MemberOf(*s, ss) {
+			continue
+		}
+		ss = append(ss, *s)
+		arrDest = append(arrDest, o)
+	}
+
+	rootDictDest["Requirements"] = arrDest
+
+	return nil
+}
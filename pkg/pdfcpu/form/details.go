@@ -0,0 +1,482 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package form
+
+import (
+	"math"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/primitives"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// Field detail types.
+const (
+	DetailText             = "text"
+	DetailDate             = "date"
+	DetailCheckBox         = "checkbox"
+	DetailRadioButtonGroup = "radiobuttongroup"
+	DetailPushButton       = "pushbutton"
+	DetailComboBox         = "combobox"
+	DetailListBox          = "listbox"
+	DetailSignature        = "signature"
+	DetailNode             = "node" // non-terminal field
+)
+
+var fieldFlagNames = []struct {
+	f    primitives.FieldFlags
+	name string
+}{
+	{primitives.FieldReadOnly, "ReadOnly"},
+	{primitives.FieldRequired, "Required"},
+	{primitives.FieldNoExport, "NoExport"},
+	{primitives.FieldMultiline, "Multiline"},
+	{primitives.FieldPassword, "Password"},
+	{primitives.FieldNoToggleToOff, "NoToggleToOff"},
+	{primitives.FieldRadio, "Radio"},
+	{primitives.FieldPushbutton, "Pushbutton"},
+	{primitives.FieldCombo, "Combo"},
+	{primitives.FieldEdit, "Edit"},
+	{primitives.FieldSort, "Sort"},
+	{primitives.FieldFileSelect, "FileSelect"},
+	{primitives.FieldMultiselect, "MultiSelect"},
+	{primitives.FieldDoNotSpellCheck, "DoNotSpellCheck"},
+	{primitives.FieldDoNotScroll, "DoNotScroll"},
+	{primitives.FieldComb, "Comb"},
+	{primitives.FieldRichTextAndRadiosInUnison, "RichText|RadiosInUnison"},
+	{primitives.FieldCommitOnSelChange, "CommitOnSelChange"},
+}
+
+// FieldWidget represents a widget annotation of a form field.
+type FieldWidget struct {
+	ObjNr   int       `json:"objNr"`
+	Page    int       `json:"page,omitempty"`    // 0 if not referenced by any page.
+	Rect    []float64 `json:"rect,omitempty"`    // llx, lly, urx, ury
+	OnState string    `json:"onState,omitempty"` // Checkboxes and radio buttons only.
+}
+
+// FieldDetail represents the complete metadata of a form field.
+//
+// Value and Default hold:
+//
+//	a string for text, date, combobox, radiobuttongroup and single selection listbox fields,
+//	a bool for checkbox fields,
+//	a []string for multi selection listbox fields.
+type FieldDetail struct {
+	ID        string        `json:"id"`                // As indicated by "pdfcpu form list".
+	Name      string        `json:"name,omitempty"`    // Fully qualified field name.
+	Partial   string        `json:"partial,omitempty"` // Partial field name "T".
+	Parent    string        `json:"parent,omitempty"`  // Parent field id.
+	Kids      []string      `json:"kids,omitempty"`    // Child field ids of non-terminal fields.
+	Type      string        `json:"type"`
+	FT        string        `json:"ft,omitempty"`
+	Flags     int           `json:"flags"`
+	FlagNames []string      `json:"flagNames,omitempty"`
+	Locked    bool          `json:"locked"`
+	DA        string        `json:"da,omitempty"`
+	MaxLen    int           `json:"maxLen,omitempty"`
+	Options   []string      `json:"options,omitempty"`
+	Value     interface{}   `json:"value,omitempty"`
+	Default   interface{}   `json:"default,omitempty"`
+	Widgets   []FieldWidget `json:"widgets,omitempty"`
+}
+
+// inherited holds the inheritable field attributes, see 12.7.4.1
+type inherited struct {
+	ft     string
+	ff     int
+	da     string
+	maxLen int
+	v, dv  types.Object
+}
+
+func (inh inherited) merge(d types.Dict) inherited {
+	if ft := d.NameEntry("FT"); ft != nil {
+		inh.ft = *ft
+	}
+	if ff := d.IntEntry("Ff"); ff != nil {
+		inh.ff = *ff
+	}
+	if da := d.StringEntry("DA"); da != nil {
+		inh.da = *da
+	}
+	if ml := d.IntEntry("MaxLen"); ml != nil {
+		inh.maxLen = *ml
+	}
+	if o, found := d.Find("V"); found {
+		inh.v = o
+	}
+	if o, found := d.Find("DV"); found {
+		inh.dv = o
+	}
+	return inh
+}
+
+func widgetPages(xRefTable *model.XRefTable) map[int]int {
+	m := map[int]int{}
+	for p := 1; p <= xRefTable.PageCount; p++ {
+		wAnnots, ok := xRefTable.PageAnnots[p][model.AnnWidget]
+		if !ok || wAnnots.IndRefs == nil {
+			continue
+		}
+		for _, ir := range *wAnnots.IndRefs {
+			m[ir.ObjectNumber.Value()] = p
+		}
+	}
+	return m
+}
+
+func flagNames(ff int) []string {
+	var ss []string
+	for _, fn := range fieldFlagNames {
+		if primitives.FieldFlags(ff)&fn.f > 0 {
+			ss = append(ss, fn.name)
+		}
+	}
+	return ss
+}
+
+func roundRect(r *types.Rectangle) []float64 {
+	round := func(f float64) float64 { return math.Round(f*100) / 100 }
+	return []float64{round(r.LL.X), round(r.LL.Y), round(r.UR.X), round(r.UR.Y)}
+}
+
+func onState(xRefTable *model.XRefTable, d types.Dict) (string, error) {
+	apDict, err := xRefTable.DereferenceDict(d["AP"])
+	if err != nil || apDict == nil {
+		return "", err
+	}
+	nDict, err := xRefTable.DereferenceDict(apDict["N"])
+	if err != nil || nDict == nil {
+		return "", err
+	}
+	for k := range nDict {
+		if k != "Off" {
+			return types.DecodeName(k)
+		}
+	}
+	return "", nil
+}
+
+func fieldWidget(xRefTable *model.XRefTable, objNr int, d types.Dict, pages map[int]int, btn bool) (*FieldWidget, error) {
+	w := &FieldWidget{ObjNr: objNr, Page: pages[objNr]}
+
+	if a := d.ArrayEntry("Rect"); a != nil {
+		r, err := xRefTable.RectForArray(a)
+		if err != nil {
+			return nil, err
+		}
+		if r != nil {
+			w.Rect = roundRect(r)
+		}
+	}
+
+	if btn {
+		s, err := onState(xRefTable, d)
+		if err != nil {
+			return nil, err
+		}
+		w.OnState = s
+	}
+
+	return w, nil
+}
+
+func detailType(fd *FieldDetail, d types.Dict) (string, error) {
+	ff := primitives.FieldFlags(fd.Flags)
+
+	switch fd.FT {
+
+	case "Btn":
+		if ff&primitives.FieldPushbutton > 0 {
+			return DetailPushButton, nil
+		}
+		if ff&primitives.FieldRadio > 0 || len(fd.Widgets) > 1 {
+			return DetailRadioButtonGroup, nil
+		}
+		return DetailCheckBox, nil
+
+	case "Ch":
+		if ff&primitives.FieldCombo > 0 {
+			return DetailComboBox, nil
+		}
+		return DetailListBox, nil
+
+	case "Tx":
+		df, err := extractDateFormat(d)
+		if err != nil {
+			return "", err
+		}
+		if df != nil {
+			return DetailDate, nil
+		}
+		return DetailText, nil
+
+	case "Sig":
+		return DetailSignature, nil
+	}
+
+	return "", errors.Errorf("pdfcpu: corrupt form field %s: missing entry \"FT\"", fd.ID)
+}
+
+func textValue(xRefTable *model.XRefTable, o types.Object) (interface{}, error) {
+	o, err := xRefTable.Dereference(o)
+	if err != nil || o == nil {
+		return nil, err
+	}
+
+	if a, ok := o.(types.Array); ok {
+		ss := []string{}
+		for _, o := range a {
+			s, err := xRefTable.DereferenceText(o)
+			if err != nil {
+				return nil, err
+			}
+			ss = append(ss, s)
+		}
+		return ss, nil
+	}
+
+	if _, ok := o.(types.StreamDict); ok {
+		// Rich text value.
+		return nil, nil
+	}
+
+	return xRefTable.DereferenceText(o)
+}
+
+func fieldValue(xRefTable *model.XRefTable, typ string, multi bool, o types.Object) (interface{}, error) {
+	if o == nil {
+		return nil, nil
+	}
+
+	switch typ {
+
+	case DetailCheckBox:
+		n, err := xRefTable.DereferenceName(o, model.V10, nil)
+		if err != nil {
+			return nil, err
+		}
+		return n.Value() != "Off", nil
+
+	case DetailRadioButtonGroup:
+		n, err := xRefTable.DereferenceName(o, model.V10, nil)
+		if err != nil || n.Value() == "Off" {
+			return nil, err
+		}
+		return types.DecodeName(n.Value())
+
+	case DetailPushButton, DetailSignature:
+		return nil, nil
+	}
+
+	v, err := textValue(xRefTable, o)
+	if err != nil {
+		return nil, err
+	}
+
+	if ss, ok := v.([]string); ok && !multi && len(ss) > 0 {
+		return ss[0], nil
+	}
+
+	if s, ok := v.(string); ok && multi {
+		return []string{s}, nil
+	}
+
+	return v, nil
+}
+
+// isWidget returns true for pure widget annotation kids carrying no field attributes.
+func isWidget(d types.Dict) bool {
+	_, found := d.Find("T")
+	return !found && d.Subtype() != nil && *d.Subtype() == "Widget"
+}
+
+func completeTerminalField(xRefTable *model.XRefTable, fd *FieldDetail, d types.Dict, inh inherited) error {
+	var err error
+
+	if fd.Type, err = detailType(fd, d); err != nil {
+		return err
+	}
+
+	fd.DA = inh.da
+	if fd.Type == DetailText {
+		fd.MaxLen = inh.maxLen
+	}
+
+	switch fd.Type {
+	case DetailComboBox, DetailListBox:
+		if fd.Options, err = parseOptions(xRefTable, d, OPTIONAL); err != nil {
+			return err
+		}
+	case DetailRadioButtonGroup:
+		for _, w := range fd.Widgets {
+			if w.OnState != "" {
+				fd.Options = append(fd.Options, w.OnState)
+			}
+		}
+	}
+
+	multi := fd.Type == DetailListBox && primitives.FieldFlags(fd.Flags)&primitives.FieldMultiselect > 0
+
+	if fd.Value, err = fieldValue(xRefTable, fd.Type, multi, inh.v); err != nil {
+		return err
+	}
+
+	fd.Default, err = fieldValue(xRefTable, fd.Type, multi, inh.dv)
+
+	return err
+}
+
+func collectFieldDetails(
+	xRefTable *model.XRefTable,
+	indRef types.IndirectRef,
+	parent *FieldDetail,
+	inh inherited,
+	pages map[int]int,
+	visited map[int]bool,
+	fds *[]FieldDetail) error {
+
+	objNr := indRef.ObjectNumber.Value()
+	if visited[objNr] {
+		return nil
+	}
+	visited[objNr] = true
+
+	d, err := xRefTable.DereferenceDict(indRef)
+	if err != nil || d == nil {
+		return err
+	}
+
+	inh = inh.merge(d)
+
+	fd := FieldDetail{ID: indRef.ObjectNumber.String(), FT: inh.ft, Flags: inh.ff}
+
+	s, err := d.StringOrHexLiteralEntry("T")
+	if err != nil {
+		return err
+	}
+	if s != nil {
+		fd.Partial = *s
+	}
+	fd.Name = fd.Partial
+
+	if parent != nil {
+		fd.ID = parent.ID + "." + fd.ID
+		fd.Parent = parent.ID
+		if parent.Name != "" && fd.Partial != "" {
+			fd.Name = parent.Name + "." + fd.Partial
+		} else if fd.Partial == "" {
+			fd.Name = parent.Name
+		}
+	}
+
+	fd.FlagNames = flagNames(fd.Flags)
+	fd.Locked = primitives.FieldFlags(fd.Flags)&primitives.FieldReadOnly > 0
+
+	btn := fd.FT == "Btn"
+
+	var kids []types.IndirectRef
+
+	for _, o := range d.ArrayEntry("Kids") {
+		ir, ok := o.(types.IndirectRef)
+		if !ok {
+			continue
+		}
+		dk, err := xRefTable.DereferenceDict(ir)
+		if err != nil {
+			return err
+		}
+		if dk == nil {
+			continue
+		}
+		if isWidget(dk) {
+			w, err := fieldWidget(xRefTable, ir.ObjectNumber.Value(), dk, pages, btn)
+			if err != nil {
+				return err
+			}
+			fd.Widgets = append(fd.Widgets, *w)
+			continue
+		}
+		kids = append(kids, ir)
+	}
+
+	if len(d.ArrayEntry("Kids")) == 0 {
+		// Field and widget annotation merged into one dict.
+		w, err := fieldWidget(xRefTable, objNr, d, pages, btn)
+		if err != nil {
+			return err
+		}
+		fd.Widgets = append(fd.Widgets, *w)
+	}
+
+	if len(kids) > 0 && len(fd.Widgets) == 0 {
+		fd.Type = DetailNode
+		for _, ir := range kids {
+			fd.Kids = append(fd.Kids, fd.ID+"."+ir.ObjectNumber.String())
+		}
+		*fds = append(*fds, fd)
+		for _, ir := range kids {
+			if err := collectFieldDetails(xRefTable, ir, &fd, inh, pages, visited, fds); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := completeTerminalField(xRefTable, &fd, d, inh); err != nil {
+		return err
+	}
+
+	*fds = append(*fds, fd)
+
+	return nil
+}
+
+// FormFieldDetails returns the complete metadata of all form fields of ctx in field hierarchy order.
+// This serves as a stable contract for form driven frontends.
+func FormFieldDetails(ctx *model.Context) ([]FieldDetail, error) {
+	xRefTable := ctx.XRefTable
+
+	fields, err := fields(xRefTable)
+	if err != nil {
+		return nil, err
+	}
+
+	inh := inherited{}
+	if da := xRefTable.Form.StringEntry("DA"); da != nil {
+		inh.da = *da
+	}
+
+	pages := widgetPages(xRefTable)
+	visited := map[int]bool{}
+
+	fds := []FieldDetail{}
+
+	for _, o := range fields {
+		ir, ok := o.(types.IndirectRef)
+		if !ok {
+			continue
+		}
+		if err := collectFieldDetails(xRefTable, ir, nil, inh, pages, visited, &fds); err != nil {
+			return nil, err
+		}
+	}
+
+	return fds, nil
+}
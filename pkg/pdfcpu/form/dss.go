@@ -0,0 +1,373 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package form
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// ValidationMaterial represents DER encoded validation related information for signatures
+// to be embedded into the document security store (DSS), see 12.8.4.3 (PDF 2.0).
+type ValidationMaterial struct {
+	Certs [][]byte // X.509 certificates
+	OCSPs [][]byte // OCSP responses
+	CRLs  [][]byte // Certificate revocation lists
+}
+
+// Empty returns true if vm holds no validation material.
+func (vm ValidationMaterial) Empty() bool {
+	return len(vm.Certs) == 0 && len(vm.OCSPs) == 0 && len(vm.CRLs) == 0
+}
+
+// dss is used to add validation material to the document security store of a context.
+// Any object touched is marked for incremental writing in order to preserve existing signatures.
+type dss struct {
+	ctx    *model.Context
+	d      types.Dict
+	hashes map[string]types.IndirectRef // Existing streams by SHA-1 of their content.
+}
+
+func (s *dss) touch(ir types.IndirectRef) {
+	s.ctx.Write.IncrementWithObjNr(ir.ObjectNumber.Value())
+}
+
+// array returns a copy of the array for key of d, inlining indirect arrays.
+func (s *dss) array(d types.Dict, key string) (types.Array, error) {
+	o, found := d.Find(key)
+	if !found {
+		return types.Array{}, nil
+	}
+
+	if ir, ok := o.(types.IndirectRef); ok {
+		// Inline the array in order to avoid touching another object.
+		a, err := s.ctx.DereferenceArray(ir)
+		if err != nil {
+			return nil, err
+		}
+		return append(types.Array{}, a...), nil
+	}
+
+	a, ok := o.(types.Array)
+	if !ok {
+		return nil, errors.Errorf("pdfcpu: corrupt DSS entry \"%s\"", key)
+	}
+
+	return a, nil
+}
+
+func (s *dss) hashExisting() error {
+	s.hashes = map[string]types.IndirectRef{}
+
+	for _, key := range []string{"Certs", "OCSPs", "CRLs"} {
+		a, err := s.array(s.d, key)
+		if err != nil {
+			return err
+		}
+		for _, o := range a {
+			ir, ok := o.(types.IndirectRef)
+			if !ok {
+				continue
+			}
+			sd, _, err := s.ctx.DereferenceStreamDict(ir)
+			if err != nil || sd == nil {
+				continue
+			}
+			if err := sd.Load(); err != nil {
+				continue
+			}
+			if err := sd.Decode(); err != nil {
+				continue
+			}
+			s.hashes[sha1Hex(sd.Content)] = ir
+		}
+	}
+
+	return nil
+}
+
+func containsIndRef(a types.Array, o types.Object) bool {
+	for _, o1 := range a {
+		if o1 == o {
+			return true
+		}
+	}
+	return false
+}
+
+func sha1Hex(bb []byte) string {
+	h := sha1.Sum(bb)
+	return strings.ToUpper(hex.EncodeToString(h[:]))
+}
+
+// add embeds each item of bbs as a stream unless already present and returns the corresponding indirect references.
+func (s *dss) add(key string, bbs [][]byte) (types.Array, error) {
+	a, err := s.array(s.d, key)
+	if err != nil {
+		return nil, err
+	}
+
+	refs := types.Array{}
+
+	for _, bb := range bbs {
+		h := sha1Hex(bb)
+		if ir, ok := s.hashes[h]; ok {
+			refs = append(refs, ir)
+			continue
+		}
+
+		sd, err := s.ctx.NewStreamDictForBuf(bb)
+		if err != nil {
+			return nil, err
+		}
+		if err := sd.Encode(); err != nil {
+			return nil, err
+		}
+
+		ir, err := s.ctx.IndRefForNewObject(*sd)
+		if err != nil {
+			return nil, err
+		}
+		s.touch(*ir)
+
+		s.hashes[h] = *ir
+		a = append(a, *ir)
+		refs = append(refs, *ir)
+	}
+
+	if len(a) > 0 {
+		s.d[key] = a
+	}
+
+	return refs, nil
+}
+
+func (s *dss) addVRI(sigKeys []string, certs, ocsps, crls types.Array) error {
+	vri := types.Dict{}
+
+	if o, found := s.d.Find("VRI"); found {
+		d, err := s.ctx.DereferenceDict(o)
+		if err != nil {
+			return err
+		}
+		for k, v := range d {
+			vri[k] = v
+		}
+	}
+
+	for _, k := range sigKeys {
+		d := types.Dict{}
+		if o, found := vri.Find(k); found {
+			d1, err := s.ctx.DereferenceDict(o)
+			if err != nil {
+				return err
+			}
+			for k, v := range d1 {
+				d[k] = v
+			}
+		}
+		for _, e := range []struct {
+			key  string
+			refs types.Array
+		}{{"Cert", certs}, {"OCSP", ocsps}, {"CRL", crls}} {
+			if len(e.refs) == 0 {
+				continue
+			}
+			a, err := s.array(d, e.key)
+			if err != nil {
+				return err
+			}
+			for _, ir := range e.refs {
+				if !containsIndRef(a, ir) {
+					a = append(a, ir)
+				}
+			}
+			d[e.key] = a
+		}
+		d["TU"] = types.StringLiteral(types.DateString(time.Now()))
+		vri[k] = d
+	}
+
+	s.d["VRI"] = vri
+
+	return nil
+}
+
+// signatureKeys returns the VRI keys of all signatures of ctx,
+// the upper case hex encoded SHA-1 digest of their signature contents.
+func signatureKeys(ctx *model.Context) ([]string, error) {
+	if ctx.Form == nil {
+		return nil, nil
+	}
+
+	fds, err := FormFieldDetails(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []string
+
+	for _, fd := range fds {
+		if fd.Type != DetailSignature {
+			continue
+		}
+
+		d, err := fieldDict(ctx, fd)
+		if err != nil || d == nil {
+			return nil, err
+		}
+
+		sigDict, err := ctx.DereferenceDict(d["V"])
+		if err != nil || sigDict == nil {
+			// Unsigned
+			continue
+		}
+
+		o, err := ctx.Dereference(sigDict["Contents"])
+		if err != nil {
+			return nil, err
+		}
+
+		var bb []byte
+
+		switch o := o.(type) {
+		case types.HexLiteral:
+			bb, err = o.Bytes()
+		case types.StringLiteral:
+			bb, err = types.Unescape(o.Value())
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, sha1Hex(bb))
+	}
+
+	return keys, nil
+}
+
+// AddValidationMaterial embeds vm into the document security store of ctx
+// and relates it to all signatures of ctx in order to enable long term validation (LTV).
+// All affected objects are marked for incremental writing which is mandatory for preserving signatures.
+func AddValidationMaterial(ctx *model.Context, vm ValidationMaterial) error {
+	if vm.Empty() {
+		return errors.New("pdfcpu: missing validation material")
+	}
+
+	if ctx.Root == nil {
+		return errors.New("pdfcpu: missing root object")
+	}
+
+	sigKeys, err := signatureKeys(ctx)
+	if err != nil {
+		return err
+	}
+	if len(sigKeys) == 0 {
+		return errors.New("pdfcpu: no signatures available")
+	}
+
+	ctx.Write.Increment = true
+	ctx.Write.Offset = ctx.Read.FileSize
+
+	s := &dss{ctx: ctx, d: types.Dict{"Type": types.Name("DSS")}}
+
+	if o, found := ctx.RootDict.Find("DSS"); found {
+		d, err := ctx.DereferenceDict(o)
+		if err != nil {
+			return err
+		}
+		for k, v := range d {
+			s.d[k] = v
+		}
+	}
+
+	if err := s.hashExisting(); err != nil {
+		return err
+	}
+
+	certs, err := s.add("Certs", vm.Certs)
+	if err != nil {
+		return err
+	}
+
+	ocsps, err := s.add("OCSPs", vm.OCSPs)
+	if err != nil {
+		return err
+	}
+
+	crls, err := s.add("CRLs", vm.CRLs)
+	if err != nil {
+		return err
+	}
+
+	if err := s.addVRI(sigKeys, certs, ocsps, crls); err != nil {
+		return err
+	}
+
+	ir, err := ctx.IndRefForNewObject(s.d)
+	if err != nil {
+		return err
+	}
+	s.touch(*ir)
+
+	ctx.RootDict["DSS"] = *ir
+	s.touch(*ctx.Root)
+
+	if ctx.XRefTable.Version() < model.V20 {
+		// DSS is part of PDF 2.0 and ISO 32000-1 extension level 5 (PAdES).
+		return s.addExtension("ESIC", "1.7", 5)
+	}
+
+	return nil
+}
+
+func (s *dss) addExtension(prefix, baseVersion string, level int) error {
+	rootDict := s.ctx.RootDict
+
+	d := types.Dict{}
+
+	if o, found := rootDict.Find("Extensions"); found {
+		d1, err := s.ctx.DereferenceDict(o)
+		if err != nil {
+			return err
+		}
+		if d1 != nil {
+			d = d1
+		}
+		if ir, ok := o.(types.IndirectRef); ok {
+			s.touch(ir)
+		}
+	}
+
+	if _, found := d.Find(prefix); !found {
+		d[prefix] = types.Dict{"BaseVersion": types.Name(baseVersion), "ExtensionLevel": types.Integer(level)}
+	}
+
+	if _, found := rootDict.Find("Extensions"); !found {
+		rootDict["Extensions"] = d
+	}
+
+	return nil
+}
@@ -984,6 +984,11 @@ func fillTextField(
 	}
 	d["V"] = types.StringLiteral(*s)
 
+	if primitives.Quadding(ctx, d) == nil && types.RightToLeft(vNew) {
+		// Let viewers regenerating the appearance align right to left values right too.
+		d["Q"] = types.Integer(types.AlignRight)
+	}
+
 	multiLine := ff != nil && uint(primitives.FieldFlags(*ff))&uint(primitives.FieldMultiline) > 0
 
 	comb := ff != nil && primitives.FieldFlags(*ff)&primitives.FieldComb > 0
@@ -1204,6 +1209,11 @@ func FillForm(
 		}
 	}
 
+	// Share font dicts and font resources among the appearance streams created.
+	if err := ConsolidateFonts(ctx); err != nil {
+		return false, nil, err
+	}
+
 	var pages []*model.Page
 
 	if len(imgs) > 0 {
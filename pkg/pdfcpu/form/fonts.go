@@ -0,0 +1,269 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package form
+
+import (
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// fontConsolidator makes annotation appearance streams share font dicts and font resources.
+type fontConsolidator struct {
+	xRefTable *model.XRefTable
+	fonts     map[string]types.IndirectRef // Font dicts by content.
+	resources map[string]types.IndirectRef // Resource dicts by content.
+	visited   map[int]bool
+	formFonts types.Dict // The font resources of the AcroForm dict.
+}
+
+// fontRef returns the font dict equal to the font dict ir refers to which has been encountered first.
+func (fc *fontConsolidator) fontRef(ir types.IndirectRef) (types.IndirectRef, error) {
+	d, err := fc.xRefTable.DereferenceDict(ir)
+	if err != nil || d == nil {
+		return ir, err
+	}
+
+	key := d.PDFString()
+	if ir1, ok := fc.fonts[key]; ok {
+		return ir1, nil
+	}
+
+	fc.fonts[key] = ir
+
+	return ir, nil
+}
+
+func (fc *fontConsolidator) ensureFormFonts() (types.Dict, error) {
+	if fc.formFonts != nil {
+		return fc.formFonts, nil
+	}
+
+	d := fc.xRefTable.Form
+
+	dr, err := fc.xRefTable.DereferenceDict(d["DR"])
+	if err != nil {
+		return nil, err
+	}
+	if dr == nil {
+		dr = types.NewDict()
+		d.Insert("DR", dr)
+	}
+
+	fd, err := fc.xRefTable.DereferenceDict(dr["Font"])
+	if err != nil {
+		return nil, err
+	}
+	if fd == nil {
+		fd = types.NewDict()
+		dr.Insert("Font", fd)
+	}
+
+	fc.formFonts = fd
+
+	return fd, nil
+}
+
+// registerFormFont adds the font dict ir refers to as id to the default resources of the AcroForm dict unless id is taken.
+func (fc *fontConsolidator) registerFormFont(id string, ir types.IndirectRef) error {
+	if fc.xRefTable.Form == nil {
+		return nil
+	}
+
+	fd, err := fc.ensureFormFonts()
+	if err != nil {
+		return err
+	}
+
+	if _, found := fd.Find(id); !found {
+		fd.Insert(id, ir)
+	}
+
+	return nil
+}
+
+func (fc *fontConsolidator) fontResources(res types.Dict, widget bool) error {
+	fd, err := fc.xRefTable.DereferenceDict(res["Font"])
+	if err != nil || fd == nil {
+		return err
+	}
+
+	for id, o := range fd {
+		ir, ok := o.(types.IndirectRef)
+		if !ok {
+			continue
+		}
+		if ir, err = fc.fontRef(ir); err != nil {
+			return err
+		}
+		fd[id] = ir
+		if widget {
+			if err := fc.registerFormFont(id, ir); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (fc *fontConsolidator) appearanceStream(o types.Object, widget bool) error {
+	ir, ok := o.(types.IndirectRef)
+	if !ok || fc.visited[ir.ObjectNumber.Value()] {
+		return nil
+	}
+	fc.visited[ir.ObjectNumber.Value()] = true
+
+	sd, _, err := fc.xRefTable.DereferenceStreamDict(ir)
+	if err != nil || sd == nil {
+		return err
+	}
+
+	o, found := sd.Find("Resources")
+	if !found {
+		return nil
+	}
+
+	if ir, ok := o.(types.IndirectRef); ok {
+		if fc.visited[ir.ObjectNumber.Value()] {
+			return nil
+		}
+		fc.visited[ir.ObjectNumber.Value()] = true
+		res, err := fc.xRefTable.DereferenceDict(ir)
+		if err != nil || res == nil {
+			return err
+		}
+		return fc.fontResources(res, widget)
+	}
+
+	res, ok := o.(types.Dict)
+	if !ok {
+		return nil
+	}
+
+	if err := fc.fontResources(res, widget); err != nil {
+		return err
+	}
+
+	// Replace the direct resource dict by a shared one.
+	key := res.PDFString()
+	irRes, ok := fc.resources[key]
+	if !ok {
+		ir, err := fc.xRefTable.IndRefForNewObject(res)
+		if err != nil {
+			return err
+		}
+		irRes = *ir
+		fc.resources[key] = irRes
+		fc.visited[irRes.ObjectNumber.Value()] = true
+	}
+
+	sd.Update("Resources", irRes)
+
+	return nil
+}
+
+func (fc *fontConsolidator) annotation(o types.Object) error {
+	d, err := fc.xRefTable.DereferenceDict(o)
+	if err != nil || d == nil {
+		return err
+	}
+
+	ap, err := fc.xRefTable.DereferenceDict(d["AP"])
+	if err != nil || ap == nil {
+		return err
+	}
+
+	widget := false
+	if st := d.NameEntry("Subtype"); st != nil && *st == "Widget" {
+		widget = true
+	}
+
+	for _, k := range []string{"N", "R", "D"} {
+		o, found := ap.Find(k)
+		if !found {
+			continue
+		}
+		o1, err := fc.xRefTable.Dereference(o)
+		if err != nil {
+			return err
+		}
+		switch o1 := o1.(type) {
+		case types.StreamDict:
+			err = fc.appearanceStream(o, widget)
+		case types.Dict:
+			// Appearance states
+			for _, o := range o1 {
+				if err = fc.appearanceStream(o, widget); err != nil {
+					break
+				}
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ConsolidateFonts makes the appearance streams of all annotations of ctx share equal font dicts and font resource dicts.
+// Fonts used by widgets become part of the default resources of the AcroForm dict.
+// Form filling renders appearance streams with font resources of their own which adds up for forms with thousands of fields.
+func ConsolidateFonts(ctx *model.Context) error {
+	xRefTable := ctx.XRefTable
+
+	fc := &fontConsolidator{
+		xRefTable: xRefTable,
+		fonts:     map[string]types.IndirectRef{},
+		resources: map[string]types.IndirectRef{},
+		visited:   map[int]bool{},
+	}
+
+	// Fonts of the AcroForm dict take precedence.
+	if xRefTable.Form != nil {
+		res, err := xRefTable.DereferenceDict(xRefTable.Form["DR"])
+		if err != nil {
+			return err
+		}
+		if res != nil {
+			if err := fc.fontResources(res, false); err != nil {
+				return err
+			}
+		}
+	}
+
+	for i := 1; i <= xRefTable.PageCount; i++ {
+		d, _, _, err := xRefTable.PageDict(i, false)
+		if err != nil {
+			return err
+		}
+		if d == nil {
+			continue
+		}
+		annots, err := xRefTable.DereferenceArray(d["Annots"])
+		if err != nil {
+			return err
+		}
+		for _, o := range annots {
+			if err := fc.annotation(o); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
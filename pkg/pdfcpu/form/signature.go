@@ -0,0 +1,424 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package form
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pdfcpu/pdfcpu/pkg/font"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// DefaultSignatureSize is the default number of bytes reserved for an embedded CMS signature.
+const DefaultSignatureSize = 16384
+
+const (
+	sigFlagsSignaturesExist = 1
+	sigFlagsAppendOnly      = 2
+
+	sigFont = "Helvetica"
+
+	// A ByteRange placeholder wide enough for files up to 10GB.
+	byteRangePlaceholder = "/ByteRange[0 9999999999 9999999999 9999999999]"
+)
+
+var (
+	ErrNoSignatureField = errors.New("pdfcpu: no such signature field")
+	ErrNoByteRange      = errors.New("pdfcpu: missing signature ByteRange")
+)
+
+func appendToArrayEntry(xRefTable *model.XRefTable, d types.Dict, key string, o types.Object) error {
+	v, found := d.Find(key)
+	if !found {
+		d.Insert(key, types.Array{o})
+		return nil
+	}
+
+	ir, ok := v.(types.IndirectRef)
+	if !ok {
+		a, ok := v.(types.Array)
+		if !ok {
+			return errors.Errorf("pdfcpu: corrupt entry \"%s\"", key)
+		}
+		d.Update(key, append(a, o))
+		return nil
+	}
+
+	a, err := xRefTable.DereferenceArray(ir)
+	if err != nil {
+		return err
+	}
+
+	entry, ok := xRefTable.FindTableEntryForIndRef(&ir)
+	if !ok {
+		return errors.Errorf("pdfcpu: can't dereference \"%s\" (obj#:%d)", key, ir.ObjectNumber)
+	}
+	entry.Object = append(a, o)
+
+	return nil
+}
+
+func ensureForm(xRefTable *model.XRefTable) types.Dict {
+	if xRefTable.Form == nil {
+		xRefTable.Form = types.Dict{"Fields": types.Array{}}
+		xRefTable.RootDict.Insert("AcroForm", xRefTable.Form)
+	}
+	return xRefTable.Form
+}
+
+func setSigFlags(xRefTable *model.XRefTable, flags int) {
+	d := ensureForm(xRefTable)
+	f := flags
+	if i := d.IntEntry("SigFlags"); i != nil {
+		f |= *i
+	}
+	d["SigFlags"] = types.Integer(f)
+	xRefTable.SignatureExist = f&sigFlagsSignaturesExist > 0
+	xRefTable.AppendOnly = f&sigFlagsAppendOnly > 0
+}
+
+func signatureFieldAppearance(xRefTable *model.XRefTable, r *types.Rectangle, label string) (*types.IndirectRef, error) {
+	w, h := r.Width(), r.Height()
+
+	var buf bytes.Buffer
+	fmt.Fprint(&buf, "q 0.95 g ")
+	fmt.Fprintf(&buf, "0 0 %.2f %.2f re f ", w, h)
+	fmt.Fprint(&buf, "0.5 G 1 w ")
+	fmt.Fprintf(&buf, "0.5 0.5 %.2f %.2f re S ", w-1, h-1)
+	fmt.Fprintf(&buf, "4 %.2f m %.2f %.2f l S Q\n", h/4, w-4, h/4)
+
+	res := types.Dict{}
+
+	if label != "" {
+		fontSize := min(h/4, 10.)
+		s, err := types.Escape(label)
+		if err != nil {
+			return nil, err
+		}
+		fd := types.Dict{
+			"Type":     types.Name("Font"),
+			"Subtype":  types.Name("Type1"),
+			"BaseFont": types.Name(sigFont),
+			"Encoding": types.Name("WinAnsiEncoding"),
+		}
+		fontIndRef, err := xRefTable.IndRefForNewObject(fd)
+		if err != nil {
+			return nil, err
+		}
+		res["Font"] = types.Dict{"Helv": *fontIndRef}
+		x := max(4, (w-font.TextWidth(label, sigFont, 1000)*fontSize/1000)/2)
+		fmt.Fprintf(&buf, "BT /Helv %.2f Tf 0.4 g %.2f %.2f Td (%s) Tj ET\n", fontSize, x, h/4-fontSize-1, *s)
+	}
+
+	sd, err := xRefTable.NewStreamDictForBuf(buf.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	sd.InsertName("Type", "XObject")
+	sd.InsertName("Subtype", "Form")
+	sd.Insert("BBox", types.RectForDim(w, h).Array())
+	if len(res) > 0 {
+		sd.Insert("Resources", res)
+	}
+
+	if err := sd.Encode(); err != nil {
+		return nil, err
+	}
+
+	return xRefTable.IndRefForNewObject(*sd)
+}
+
+// AddSignatureField adds an empty signature field named fieldName to page pageNr of ctx
+// with a visible placeholder appearance at rect labelled with label.
+func AddSignatureField(ctx *model.Context, pageNr int, rect *types.Rectangle, fieldName, label string) (*types.IndirectRef, error) {
+	if fieldName == "" || strings.Contains(fieldName, ".") {
+		return nil, errors.Errorf("pdfcpu: invalid signature field name: \"%s\"", fieldName)
+	}
+
+	if rect == nil || rect.Width() <= 0 || rect.Height() <= 0 {
+		return nil, errors.New("pdfcpu: invalid signature field rectangle")
+	}
+
+	if ctx.Form != nil {
+		if _, found := ctx.Form.Find("Fields"); found {
+			fds, err := FormFieldDetails(ctx)
+			if err != nil {
+				return nil, err
+			}
+			for _, fd := range fds {
+				if fd.Name == fieldName {
+					return nil, errors.Errorf("pdfcpu: duplicate form field: \"%s\"", fieldName)
+				}
+			}
+		}
+	}
+
+	pageDict, pageIndRef, _, err := ctx.PageDict(pageNr, false)
+	if err != nil {
+		return nil, err
+	}
+	if pageDict == nil {
+		return nil, errors.Errorf("pdfcpu: invalid page number: %d", pageNr)
+	}
+
+	apIndRef, err := signatureFieldAppearance(ctx.XRefTable, rect, label)
+	if err != nil {
+		return nil, err
+	}
+
+	// Field dict merged with its widget annotation.
+	d := types.Dict{
+		"Type":    types.Name("Annot"),
+		"Subtype": types.Name("Widget"),
+		"FT":      types.Name("Sig"),
+		"T":       types.StringLiteral(fieldName),
+		"Rect":    rect.Array(),
+		"F":       types.Integer(model.AnnPrint),
+		"P":       *pageIndRef,
+		"AP":      types.Dict{"N": *apIndRef},
+	}
+
+	indRef, err := ctx.IndRefForNewObject(d)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := appendToArrayEntry(ctx.XRefTable, pageDict, "Annots", *indRef); err != nil {
+		return nil, err
+	}
+
+	if err := appendToArrayEntry(ctx.XRefTable, ensureForm(ctx.XRefTable), "Fields", *indRef); err != nil {
+		return nil, err
+	}
+
+	setSigFlags(ctx.XRefTable, sigFlagsSignaturesExist)
+
+	ctx.EnsureVersionForWriting()
+
+	return indRef, nil
+}
+
+// fieldDict returns the field dict for fd.
+func fieldDict(ctx *model.Context, fd FieldDetail) (types.Dict, error) {
+	ids := strings.Split(fd.ID, ".")
+	objNr, err := strconv.Atoi(ids[len(ids)-1])
+	if err != nil {
+		return nil, err
+	}
+	return ctx.DereferenceDict(*types.NewIndirectRef(objNr, 0))
+}
+
+func signatureFieldDict(ctx *model.Context, fieldName string) (types.Dict, error) {
+	if ctx.Form == nil {
+		return nil, ErrNoSignatureField
+	}
+
+	fds, err := FormFieldDetails(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var sigDict types.Dict
+
+	for _, fd := range fds {
+		if fd.Type != DetailSignature {
+			continue
+		}
+		d, err := fieldDict(ctx, fd)
+		if err != nil {
+			return nil, err
+		}
+		_, signed := d.Find("V")
+		if fd.Name == fieldName || fd.ID == fieldName {
+			if signed {
+				return nil, errors.Errorf("pdfcpu: signature field \"%s\" already signed", fieldName)
+			}
+			sigDict = d
+			continue
+		}
+		if signed {
+			// Rewriting the file would break this signature.
+			return nil, errors.Errorf("pdfcpu: signature field \"%s\" already signed, incremental signing is not supported", fd.Name)
+		}
+	}
+
+	if sigDict == nil {
+		return nil, ErrNoSignatureField
+	}
+
+	return sigDict, nil
+}
+
+// PrepareSignature reserves space for a detached CMS signature of size bytes
+// in the empty signature field fieldName for deferred external signing.
+//
+// ctx needs to be written without object streams and the result patched using FinalizeByteRange.
+func PrepareSignature(ctx *model.Context, fieldName string, size int) error {
+	if ctx.Encrypt != nil {
+		return errors.New("pdfcpu: signing encrypted files is not supported")
+	}
+
+	if size <= 0 {
+		size = DefaultSignatureSize
+	}
+
+	d, err := signatureFieldDict(ctx, fieldName)
+	if err != nil {
+		return err
+	}
+
+	sd := types.Dict{
+		"Type":      types.Name("Sig"),
+		"Filter":    types.Name("Adobe.PPKLite"),
+		"SubFilter": types.Name("adbe.pkcs7.detached"),
+		"ByteRange": types.NewIntegerArray(0, 9999999999, 9999999999, 9999999999),
+		"Contents":  types.HexLiteral(strings.Repeat("0", 2*size)),
+		"M":         types.StringLiteral(types.DateString(time.Now())),
+	}
+
+	indRef, err := ctx.IndRefForNewObject(sd)
+	if err != nil {
+		return err
+	}
+
+	d["V"] = *indRef
+
+	setSigFlags(ctx.XRefTable, sigFlagsSignaturesExist|sigFlagsAppendOnly)
+
+	// Object streams are compressed.
+	ctx.WriteObjectStream = false
+
+	return nil
+}
+
+// FinalizeByteRange replaces the ByteRange placeholder in bb, the serialized prepared context, by the actual byte ranges
+// excluding the hex encoded signature contents.
+func FinalizeByteRange(bb []byte) error {
+	i := bytes.Index(bb, []byte(byteRangePlaceholder))
+	if i < 0 {
+		return ErrNoByteRange
+	}
+
+	j := bytes.Index(bb[i:], []byte("/Contents<"))
+	if j < 0 {
+		return ErrNoByteRange
+	}
+	from := i + j + len("/Contents")
+
+	k := bytes.IndexByte(bb[from:], '>')
+	if k < 0 {
+		return ErrNoByteRange
+	}
+	to := from + k + 1
+
+	br := fmt.Sprintf("/ByteRange[0 %d %d %d", from, to, len(bb)-to)
+	br += strings.Repeat(" ", len(byteRangePlaceholder)-len(br)-1) + "]"
+	if len(br) != len(byteRangePlaceholder) {
+		return errors.New("pdfcpu: file too large for signing")
+	}
+
+	copy(bb[i:], br)
+
+	return nil
+}
+
+// signatureByteRange returns the last ByteRange of bb along with the position of the signature contents.
+func signatureByteRange(bb []byte) ([4]int, error) {
+	var br [4]int
+
+	i := bytes.LastIndex(bb, []byte("/ByteRange"))
+	if i < 0 {
+		return br, ErrNoByteRange
+	}
+
+	s := bb[i+len("/ByteRange"):]
+	j := bytes.IndexByte(s, ']')
+	if j < 0 {
+		return br, ErrNoByteRange
+	}
+
+	ss := strings.Fields(strings.Trim(strings.TrimSpace(string(s[:j])), "["))
+	if len(ss) != 4 {
+		return br, ErrNoByteRange
+	}
+
+	for k, s := range ss {
+		v, err := strconv.Atoi(s)
+		if err != nil {
+			return br, ErrNoByteRange
+		}
+		br[k] = v
+	}
+
+	if br[0] != 0 || br[1] <= 0 || br[2] <= br[1] || br[2]+br[3] != len(bb) {
+		return br, errors.New("pdfcpu: invalid signature ByteRange")
+	}
+
+	if bb[br[1]] != '<' || bb[br[2]-1] != '>' {
+		return br, errors.New("pdfcpu: invalid signature contents")
+	}
+
+	return br, nil
+}
+
+// SignedContent returns the content of bb covered by the signature ByteRange to be digested by an external signer.
+func SignedContent(bb []byte) ([]byte, error) {
+	br, err := signatureByteRange(bb)
+	if err != nil {
+		return nil, err
+	}
+
+	content := make([]byte, 0, br[1]+br[3])
+	content = append(content, bb[br[0]:br[0]+br[1]]...)
+	content = append(content, bb[br[2]:br[2]+br[3]]...)
+
+	return content, nil
+}
+
+// EmbedSignature embeds cms, an externally created detached CMS signature, into the reserved space of bb.
+func EmbedSignature(bb, cms []byte) error {
+	if len(cms) == 0 {
+		return errors.New("pdfcpu: missing signature")
+	}
+
+	br, err := signatureByteRange(bb)
+	if err != nil {
+		return err
+	}
+
+	from, to := br[1]+1, br[2]-1
+
+	if strings.Trim(string(bb[from:to]), "0") != "" {
+		return errors.New("pdfcpu: signature already embedded")
+	}
+
+	s := strings.ToUpper(hex.EncodeToString(cms))
+	if len(s) > to-from {
+		return errors.Errorf("pdfcpu: signature too large: %d bytes, reserved: %d bytes", len(cms), (to-from)/2)
+	}
+
+	copy(bb[from:], s)
+
+	return nil
+}
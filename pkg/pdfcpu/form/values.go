@@ -0,0 +1,259 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package form
+
+import (
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// ErrNoFormField indicates a form field id or name without match.
+var ErrNoFormField = errors.New("pdfcpu: no such form field")
+
+// FieldValue represents the effective value of a terminal form field.
+//
+// For checkboxes and radio button groups Value is the selected appearance state or "Off".
+// Export and Display are empty for unset fields.
+type FieldValue struct {
+	ID      string   `json:"id"`
+	Name    string   `json:"name,omitempty"`
+	Type    string   `json:"type"`
+	Value   string   `json:"value"`
+	Values  []string `json:"values,omitempty"`  // Multi selection listbox fields only.
+	Export  string   `json:"export,omitempty"`  // Value as exported, eg. mapped via "Opt".
+	Display string   `json:"display,omitempty"` // Value as displayed by a viewer.
+}
+
+// inheritedEntry returns the value of key for d taking field inheritance into account.
+func inheritedEntry(xRefTable *model.XRefTable, d types.Dict, key string) (types.Object, error) {
+	for i := 0; d != nil && i < 32; i++ {
+		if o, found := d.Find(key); found {
+			return xRefTable.Dereference(o)
+		}
+		var err error
+		if d, err = xRefTable.DereferenceDict(d["Parent"]); err != nil {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+// buttonState returns the selected appearance state of a checkbox or radio button group.
+// A missing "V" is resolved via the appearance states of the widgets.
+func buttonState(xRefTable *model.XRefTable, fd FieldDetail, d types.Dict) (string, error) {
+	o, err := inheritedEntry(xRefTable, d, "V")
+	if err != nil {
+		return "", err
+	}
+	if n, ok := o.(types.Name); ok {
+		return types.DecodeName(n.Value())
+	}
+
+	for _, w := range fd.Widgets {
+		wd, err := xRefTable.DereferenceDict(*types.NewIndirectRef(w.ObjNr, 0))
+		if err != nil || wd == nil {
+			return "", err
+		}
+		if as := wd.NameEntry("AS"); as != nil && *as != "Off" {
+			return types.DecodeName(*as)
+		}
+	}
+
+	return "Off", nil
+}
+
+func resolveButtonValue(xRefTable *model.XRefTable, fd FieldDetail, d types.Dict, fv *FieldValue) error {
+	s, err := buttonState(xRefTable, fd, d)
+	if err != nil {
+		return err
+	}
+
+	fv.Value = s
+	if s == "Off" {
+		return nil
+	}
+
+	fv.Export = s
+
+	// Since PDF 1.4 "Opt" maps widgets to export values.
+	o, err := inheritedEntry(xRefTable, d, "Opt")
+	if err != nil {
+		return err
+	}
+	if a, ok := o.(types.Array); ok {
+		for i, w := range fd.Widgets {
+			if w.OnState != s || i >= len(a) {
+				continue
+			}
+			if fv.Export, err = xRefTable.DereferenceText(a[i]); err != nil {
+				return err
+			}
+			break
+		}
+	}
+
+	fv.Display = fv.Export
+
+	return nil
+}
+
+// choiceDisplayValues maps the export values of a choice field to their display values.
+func choiceDisplayValues(xRefTable *model.XRefTable, d types.Dict) (map[string]string, error) {
+	m := map[string]string{}
+
+	o, err := inheritedEntry(xRefTable, d, "Opt")
+	if err != nil {
+		return nil, err
+	}
+	a, ok := o.(types.Array)
+	if !ok {
+		return m, nil
+	}
+
+	for _, o := range a {
+		o, err := xRefTable.Dereference(o)
+		if err != nil {
+			return nil, err
+		}
+		pair, ok := o.(types.Array)
+		if !ok || len(pair) != 2 {
+			continue
+		}
+		exp, err := xRefTable.DereferenceText(pair[0])
+		if err != nil {
+			return nil, err
+		}
+		disp, err := xRefTable.DereferenceText(pair[1])
+		if err != nil {
+			return nil, err
+		}
+		m[exp] = disp
+	}
+
+	return m, nil
+}
+
+func resolveChoiceValue(xRefTable *model.XRefTable, fd FieldDetail, d types.Dict, fv *FieldValue) error {
+	m, err := choiceDisplayValues(xRefTable, d)
+	if err != nil {
+		return err
+	}
+
+	display := func(s string) string {
+		if disp, ok := m[s]; ok {
+			return disp
+		}
+		return s
+	}
+
+	switch v := fd.Value.(type) {
+	case string:
+		fv.Value, fv.Export, fv.Display = v, v, display(v)
+	case []string:
+		ss := make([]string, len(v))
+		for i, s := range v {
+			ss[i] = display(s)
+		}
+		fv.Values = v
+		fv.Value = strings.Join(v, ", ")
+		fv.Export = fv.Value
+		fv.Display = strings.Join(ss, ", ")
+	}
+
+	return nil
+}
+
+func fieldValueFor(ctx *model.Context, fd FieldDetail) (*FieldValue, error) {
+	xRefTable := ctx.XRefTable
+	fv := &FieldValue{ID: fd.ID, Name: fd.Name, Type: fd.Type}
+
+	d, err := fieldDict(ctx, fd)
+	if err != nil {
+		return nil, err
+	}
+
+	switch fd.Type {
+
+	case DetailCheckBox, DetailRadioButtonGroup:
+		err = resolveButtonValue(xRefTable, fd, d, fv)
+
+	case DetailComboBox, DetailListBox:
+		err = resolveChoiceValue(xRefTable, fd, d, fv)
+
+	default:
+		if s, ok := fd.Value.(string); ok {
+			fv.Value, fv.Export, fv.Display = s, s, s
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return fv, nil
+}
+
+func valueField(fd FieldDetail) bool {
+	switch fd.Type {
+	case DetailNode, DetailPushButton, DetailSignature:
+		return false
+	}
+	return true
+}
+
+// FormFieldValues returns the effective values of all terminal form fields of ctx
+// except push buttons and signature fields in field hierarchy order.
+func FormFieldValues(ctx *model.Context) ([]FieldValue, error) {
+	fds, err := FormFieldDetails(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	fvs := []FieldValue{}
+
+	for _, fd := range fds {
+		if !valueField(fd) {
+			continue
+		}
+		fv, err := fieldValueFor(ctx, fd)
+		if err != nil {
+			return nil, err
+		}
+		fvs = append(fvs, *fv)
+	}
+
+	return fvs, nil
+}
+
+// FormFieldValue returns the effective value of the form field of ctx identified by id or fully qualified name.
+func FormFieldValue(ctx *model.Context, idOrName string) (*FieldValue, error) {
+	fds, err := FormFieldDetails(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, fd := range fds {
+		if (fd.ID == idOrName || fd.Name == idOrName) && valueField(fd) {
+			return fieldValueFor(ctx, fd)
+		}
+	}
+
+	return nil, ErrNoFormField
+}
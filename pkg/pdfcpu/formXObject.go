@@ -0,0 +1,345 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/matrix"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// FormXObject describes a form XObject used by some pages.
+type FormXObject struct {
+	ObjNr   int
+	Names   []string // Resource names
+	PageNrs []int    // Pages using this form directly or via other forms
+}
+
+// FormPlacement positions an imported form on a page.
+type FormPlacement struct {
+	PageNr   int
+	X, Y     float64 // Lower left corner of the form in user space.
+	Scale    float64 // Defaults to 1.
+	Rotation float64 // Counterclockwise rotation in degrees around the lower left corner.
+}
+
+type formUsage struct {
+	names   map[string]bool
+	pageNrs map[int]bool
+}
+
+type formCollector struct {
+	ctx     *model.Context
+	forms   map[int]*formUsage
+	visited map[int]bool
+}
+
+func (fc *formCollector) resources(pageNr int, res types.Dict, depth int) error {
+	if res == nil || depth > maxFormDepth {
+		return nil
+	}
+
+	d, err := fc.ctx.DereferenceDict(res["XObject"])
+	if err != nil || d == nil {
+		return err
+	}
+
+	for name, o := range d {
+		ir, ok := o.(types.IndirectRef)
+		if !ok {
+			continue
+		}
+
+		sd, _, err := fc.ctx.DereferenceStreamDict(ir)
+		if err != nil {
+			return err
+		}
+		if sd == nil {
+			continue
+		}
+		if st := sd.Subtype(); st == nil || *st != "Form" {
+			continue
+		}
+
+		objNr := ir.ObjectNumber.Value()
+		fu, ok := fc.forms[objNr]
+		if !ok {
+			fu = &formUsage{names: map[string]bool{}, pageNrs: map[int]bool{}}
+			fc.forms[objNr] = fu
+		}
+		fu.names[name] = true
+		fu.pageNrs[pageNr] = true
+
+		if fc.visited[objNr] {
+			continue
+		}
+		fc.visited[objNr] = true
+
+		formRes, err := fc.ctx.DereferenceDict(sd.Dict["Resources"])
+		if err != nil {
+			return err
+		}
+		if err := fc.resources(pageNr, formRes, depth+1); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// FormXObjects returns the form XObjects used by pages pageNrs (all pages if nil) including nested forms.
+func FormXObjects(ctx *model.Context, pageNrs []int) ([]FormXObject, error) {
+	if pageNrs == nil {
+		for i := 1; i <= ctx.PageCount; i++ {
+			pageNrs = append(pageNrs, i)
+		}
+	}
+
+	fc := &formCollector{ctx: ctx, forms: map[int]*formUsage{}}
+
+	for _, pageNr := range pageNrs {
+		_, _, inhPAttrs, err := ctx.PageDict(pageNr, false)
+		if err != nil {
+			return nil, err
+		}
+		if inhPAttrs == nil {
+			continue
+		}
+		fc.visited = map[int]bool{}
+		if err := fc.resources(pageNr, inhPAttrs.Resources, 0); err != nil {
+			return nil, err
+		}
+	}
+
+	ff := make([]FormXObject, 0, len(fc.forms))
+	for objNr, fu := range fc.forms {
+		f := FormXObject{ObjNr: objNr}
+		for name := range fu.names {
+			f.Names = append(f.Names, name)
+		}
+		for pageNr := range fu.pageNrs {
+			f.PageNrs = append(f.PageNrs, pageNr)
+		}
+		sort.Strings(f.Names)
+		sort.Ints(f.PageNrs)
+		ff = append(ff, f)
+	}
+
+	sort.Slice(ff, func(i, j int) bool { return ff[i].ObjNr < ff[j].ObjNr })
+
+	return ff, nil
+}
+
+// formBox returns the bounding box of form sd in the coordinate system of its parent.
+func formBox(ctx *model.Context, sd *types.StreamDict) (*types.Rectangle, error) {
+	a, err := ctx.DereferenceArray(sd.Dict["BBox"])
+	if err != nil {
+		return nil, err
+	}
+	if len(a) != 4 {
+		return nil, errors.New("pdfcpu: form XObject: invalid BBox")
+	}
+	r, err := ctx.RectForArray(a)
+	if err != nil {
+		return nil, err
+	}
+
+	a, err = ctx.DereferenceArray(sd.Dict["Matrix"])
+	if err != nil || len(a) != 6 {
+		return r, nil
+	}
+
+	ff := make([]float64, 6)
+	for i, o := range a {
+		f, ok := numberValue(o)
+		if !ok {
+			return r, nil
+		}
+		ff[i] = f
+	}
+	m := matrixFor(ff)
+
+	llx, lly, urx, ury := math.MaxFloat64, math.MaxFloat64, -math.MaxFloat64, -math.MaxFloat64
+	for _, p := range []types.Point{r.LL, r.UR, {X: r.LL.X, Y: r.UR.Y}, {X: r.UR.X, Y: r.LL.Y}} {
+		p = m.Transform(p)
+		llx, lly = math.Min(llx, p.X), math.Min(lly, p.Y)
+		urx, ury = math.Max(urx, p.X), math.Max(ury, p.Y)
+	}
+
+	return types.NewRectangle(llx, lly, urx, ury), nil
+}
+
+// ExtractFormXObject returns a new single page context displaying form XObject objNr of ctx.
+// The page boundaries match the bounding box of the form.
+func ExtractFormXObject(ctx *model.Context, objNr int) (*model.Context, error) {
+	ir := *types.NewIndirectRef(objNr, 0)
+
+	sd, _, err := ctx.DereferenceStreamDict(ir)
+	if err != nil {
+		return nil, err
+	}
+	if sd == nil {
+		return nil, errors.Errorf("pdfcpu: missing obj#%d", objNr)
+	}
+	if st := sd.Subtype(); st == nil || *st != "Form" {
+		return nil, errors.Errorf("pdfcpu: obj#%d is not a form XObject", objNr)
+	}
+
+	r, err := formBox(ctx, sd)
+	if err != nil {
+		return nil, err
+	}
+
+	ctxDest, err := CreateContextWithXRefTable(nil, &types.Dim{Width: r.Width(), Height: r.Height()})
+	if err != nil {
+		return nil, err
+	}
+
+	o, err := migrateObject(ir, ctx, ctxDest, map[int]int{})
+	if err != nil {
+		return nil, err
+	}
+
+	pagesIndRef, err := ctxDest.Pages()
+	if err != nil {
+		return nil, err
+	}
+
+	pagesDict, err := ctxDest.DereferenceDict(*pagesIndRef)
+	if err != nil {
+		return nil, err
+	}
+
+	pageIndRef, err := ctxDest.EmptyPage(pagesIndRef, r)
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := ctxDest.DereferenceDict(*pageIndRef)
+	if err != nil {
+		return nil, err
+	}
+
+	contents, err := newContentStreamRef(ctxDest, "/Fm0 Do")
+	if err != nil {
+		return nil, err
+	}
+
+	d.Update("Contents", *contents)
+	d.Update("Resources", types.Dict{"XObject": types.Dict{"Fm0": o}})
+
+	if err := model.AppendPageTree(pageIndRef, 1, pagesDict); err != nil {
+		return nil, err
+	}
+
+	ctxDest.PageCount = 1
+
+	return ctxDest, nil
+}
+
+// ImportPageAsForm imports page pageNr of otherCtx into ctx as form XObject
+// and returns it along with its bounding box, the visible region of the imported page.
+// Objects of otherCtx already imported into ctx are tracked in migrated.
+func ImportPageAsForm(ctx, otherCtx *model.Context, pageNr int, migrated map[int]int) (*types.IndirectRef, *types.Rectangle, error) {
+	if migrated == nil {
+		migrated = map[int]int{}
+	}
+	return overlayForm(ctx, otherCtx, pageNr, migrated)
+}
+
+// formResource returns the name of form in the XObject resources of page dict d, adding it if necessary.
+func formResource(ctx *model.Context, d, resDict types.Dict, form types.IndirectRef) (string, error) {
+	if resDict != nil {
+		xoDict, err := ctx.DereferenceDict(resDict["XObject"])
+		if err != nil {
+			return "", err
+		}
+		for id, o := range xoDict {
+			if ir, ok := o.(types.IndirectRef); ok && ir.ObjectNumber == form.ObjectNumber {
+				d.Update("Resources", resDict)
+				return id, nil
+			}
+		}
+	}
+	return addOverlayResource(ctx, d, resDict, form)
+}
+
+// PlaceForm places form with bounding box r on pages according to pp.
+// All placements share the same form XObject.
+func PlaceForm(ctx *model.Context, form types.IndirectRef, r *types.Rectangle, pp []FormPlacement) error {
+	// Placement content by page.
+	m := map[int]string{}
+	pageNrs := []int{}
+
+	for _, p := range pp {
+		if p.PageNr < 1 || p.PageNr > ctx.PageCount {
+			return errors.Errorf("pdfcpu: unknown page number: %d\n", p.PageNr)
+		}
+		if _, ok := m[p.PageNr]; !ok {
+			pageNrs = append(pageNrs, p.PageNr)
+		}
+		m[p.PageNr] += placementContent(p, r)
+	}
+
+	sort.Ints(pageNrs)
+
+	for _, pageNr := range pageNrs {
+		d, _, inhPAttrs, err := ctx.PageDict(pageNr, false)
+		if err != nil {
+			return err
+		}
+		if d == nil {
+			return errors.Errorf("pdfcpu: unknown page number: %d\n", pageNr)
+		}
+
+		id, err := formResource(ctx, d, inhPAttrs.Resources, form)
+		if err != nil {
+			return err
+		}
+
+		if err := wrapPageContent(ctx, d, "q ", " Q "+fmt.Sprintf(m[pageNr], id)); err != nil {
+			return err
+		}
+	}
+
+	ctx.EnsureVersionForWriting()
+
+	return nil
+}
+
+// placementContent returns the content for placing a form with bounding box r
+// using a format verb for the resource name.
+func placementContent(p FormPlacement, r *types.Rectangle) string {
+	scale := p.Scale
+	if scale <= 0 {
+		scale = 1
+	}
+
+	// Move the lower left corner of the form to the origin, then scale, rotate and translate.
+	m := matrix.IdentMatrix
+	m[2][0], m[2][1] = -r.LL.X, -r.LL.Y
+	sin, cos := math.Sincos(p.Rotation * float64(matrix.DegToRad))
+	m = m.Multiply(matrix.CalcTransformMatrix(scale, scale, sin, cos, p.X, p.Y))
+
+	return fmt.Sprintf("q %s %s %s %s %s %s cm /%%[1]s Do Q ",
+		contentNum(m[0][0]), contentNum(m[0][1]), contentNum(m[1][0]), contentNum(m[1][1]), contentNum(m[2][0]), contentNum(m[2][1]))
+}
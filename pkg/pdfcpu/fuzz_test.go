@@ -0,0 +1,65 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+)
+
+// Run with: go test -run=^$ -fuzz=FuzzRead ./pkg/pdfcpu
+
+// minimalPDF returns a single page PDF using a cross reference table.
+func minimalPDF() []byte {
+	objs := []string{
+		"<</Type/Catalog/Pages 2 0 R>>",
+		"<</Type/Pages/Kids[3 0 R]/Count 1>>",
+		"<</Type/Page/Parent 2 0 R/MediaBox[0 0 595 842]>>",
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.7\n")
+
+	offs := []int{}
+	for i, o := range objs {
+		offs = append(offs, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, o)
+	}
+
+	xRefOffset := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f\r\n", len(objs)+1)
+	for _, off := range offs {
+		fmt.Fprintf(&buf, "%010d 00000 n\r\n", off)
+	}
+	fmt.Fprintf(&buf, "trailer\n<</Size %d/Root 1 0 R>>\nstartxref\n%d\n%%%%EOF\n", len(objs)+1, xRefOffset)
+
+	return buf.Bytes()
+}
+
+func FuzzRead(f *testing.F) {
+	bb := minimalPDF()
+	f.Add(bb)
+	f.Add(bb[:len(bb)/2])
+	f.Add(bytes.Replace(bb, []byte("xref"), []byte("xraf"), 1))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		Read(bytes.NewReader(data), model.NewDefaultConfiguration())
+	})
+}
@@ -0,0 +1,447 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// GeoCoordSys represents a geographic (GEOGCS) or projected (PROJCS) coordinate system.
+type GeoCoordSys struct {
+	Type string `json:"type"`
+	EPSG int    `json:"epsg,omitempty"`
+	WKT  string `json:"wkt,omitempty"`
+}
+
+// GeoViewport represents a georeferenced page region (a viewport with a GEO measure dict).
+type GeoViewport struct {
+	PageNr int          `json:"page,omitempty"`
+	Name   string       `json:"name,omitempty"`
+	BBox   []float64    `json:"bbox"`
+	Bounds []float64    `json:"bounds,omitempty"`
+	GCS    GeoCoordSys  `json:"gcs"`
+	DCS    *GeoCoordSys `json:"dcs,omitempty"`
+	PDU    []string     `json:"pdu,omitempty"`
+	GPTS   []float64    `json:"gpts"`
+	LPTS   []float64    `json:"lpts,omitempty"`
+}
+
+// GeoViewports represents a list of georeferenced viewports for JSON export and import.
+type GeoViewports struct {
+	Viewports []GeoViewport `json:"viewports"`
+}
+
+// Validate checks gv for consistency.
+func (gv GeoViewport) Validate() error {
+	if len(gv.BBox) != 4 {
+		return errors.New("pdfcpu: geo viewport: \"bbox\" requires 4 values")
+	}
+	if gv.GCS.Type != "GEOGCS" && gv.GCS.Type != "PROJCS" {
+		return errors.Errorf("pdfcpu: geo viewport: invalid gcs type %q, please use GEOGCS or PROJCS", gv.GCS.Type)
+	}
+	if gv.GCS.EPSG == 0 && gv.GCS.WKT == "" {
+		return errors.New("pdfcpu: geo viewport: gcs requires \"epsg\" or \"wkt\"")
+	}
+	if len(gv.GPTS) == 0 || len(gv.GPTS)%2 != 0 {
+		return errors.New("pdfcpu: geo viewport: \"gpts\" requires latitude/longitude pairs")
+	}
+	if len(gv.LPTS) > 0 && len(gv.LPTS) != len(gv.GPTS) {
+		return errors.New("pdfcpu: geo viewport: \"lpts\" must match \"gpts\"")
+	}
+	if len(gv.Bounds)%2 != 0 {
+		return errors.New("pdfcpu: geo viewport: \"bounds\" requires coordinate pairs")
+	}
+	if len(gv.PDU) > 0 && len(gv.PDU) != 3 {
+		return errors.New("pdfcpu: geo viewport: \"pdu\" requires linear, area and angular units")
+	}
+	return nil
+}
+
+// LatLonBounds returns the min/max latitude and longitude covered by the geographic points of gv.
+func (gv GeoViewport) LatLonBounds() (minLat, minLon, maxLat, maxLon float64) {
+	minLat, minLon = math.MaxFloat64, math.MaxFloat64
+	maxLat, maxLon = -math.MaxFloat64, -math.MaxFloat64
+	for i := 0; i+1 < len(gv.GPTS); i += 2 {
+		minLat = math.Min(minLat, gv.GPTS[i])
+		maxLat = math.Max(maxLat, gv.GPTS[i])
+		minLon = math.Min(minLon, gv.GPTS[i+1])
+		maxLon = math.Max(maxLon, gv.GPTS[i+1])
+	}
+	return
+}
+
+func numberArray(ff []float64) types.Array {
+	arr := make(types.Array, len(ff))
+	for i, f := range ff {
+		arr[i] = types.Float(f)
+	}
+	return arr
+}
+
+func floats(ctx *model.Context, o types.Object) ([]float64, error) {
+	arr, err := ctx.DereferenceArray(o)
+	if err != nil || arr == nil {
+		return nil, err
+	}
+	ff := make([]float64, len(arr))
+	for i, o := range arr {
+		if ff[i], err = ctx.DereferenceNumber(o); err != nil {
+			return nil, err
+		}
+	}
+	return ff, nil
+}
+
+func (cs GeoCoordSys) dict() (types.Dict, error) {
+	d := types.Dict(map[string]types.Object{"Type": types.Name(cs.Type)})
+	if cs.EPSG > 0 {
+		d["EPSG"] = types.Integer(cs.EPSG)
+	}
+	if cs.WKT != "" {
+		s, err := types.Escape(cs.WKT)
+		if err != nil {
+			return nil, err
+		}
+		d["WKT"] = types.StringLiteral(*s)
+	}
+	return d, nil
+}
+
+func (gv GeoViewport) dict() (types.Dict, error) {
+	gcs, err := gv.GCS.dict()
+	if err != nil {
+		return nil, err
+	}
+
+	md := types.Dict(map[string]types.Object{
+		"Type":    types.Name("Measure"),
+		"Subtype": types.Name("GEO"),
+		"GCS":     gcs,
+		"GPTS":    numberArray(gv.GPTS),
+	})
+	if len(gv.Bounds) > 0 {
+		md["Bounds"] = numberArray(gv.Bounds)
+	}
+	if gv.DCS != nil {
+		dcs, err := gv.DCS.dict()
+		if err != nil {
+			return nil, err
+		}
+		md["DCS"] = dcs
+	}
+	if len(gv.PDU) > 0 {
+		arr := types.Array{}
+		for _, s := range gv.PDU {
+			arr = append(arr, types.Name(s))
+		}
+		md["PDU"] = arr
+	}
+	if len(gv.LPTS) > 0 {
+		md["LPTS"] = numberArray(gv.LPTS)
+	}
+
+	d := types.Dict(map[string]types.Object{
+		"Type":    types.Name("Viewport"),
+		"BBox":    numberArray(gv.BBox),
+		"Measure": md,
+	})
+	if gv.Name != "" {
+		s, err := types.EscapedUTF16String(gv.Name)
+		if err != nil {
+			return nil, err
+		}
+		d["Name"] = types.StringLiteral(*s)
+	}
+	return d, nil
+}
+
+func geoCoordSys(ctx *model.Context, o types.Object) (*GeoCoordSys, error) {
+	d, err := ctx.DereferenceDict(o)
+	if err != nil || d == nil {
+		return nil, err
+	}
+	cs := &GeoCoordSys{}
+	if t := d.NameEntry("Type"); t != nil {
+		cs.Type = *t
+	}
+	if i := d.IntEntry("EPSG"); i != nil {
+		cs.EPSG = *i
+	}
+	if o, found := d.Find("WKT"); found {
+		if cs.WKT, err = ctx.DereferenceStringOrHexLiteral(o, model.V10, nil); err != nil {
+			return nil, err
+		}
+	}
+	return cs, nil
+}
+
+// geoViewport returns the georeferenced viewport represented by the viewport dict d or nil.
+func geoViewport(ctx *model.Context, d types.Dict, pageNr int) (*GeoViewport, error) {
+	md, err := ctx.DereferenceDict(d["Measure"])
+	if err != nil || md == nil {
+		return nil, err
+	}
+	if st := md.NameEntry("Subtype"); st == nil || *st != "GEO" {
+		return nil, nil
+	}
+
+	gv := &GeoViewport{PageNr: pageNr}
+
+	if o, found := d.Find("Name"); found {
+		if gv.Name, err = ctx.DereferenceStringOrHexLiteral(o, model.V10, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	if gv.BBox, err = floats(ctx, d["BBox"]); err != nil {
+		return nil, err
+	}
+
+	if gv.Bounds, err = floats(ctx, md["Bounds"]); err != nil {
+		return nil, err
+	}
+
+	gcs, err := geoCoordSys(ctx, md["GCS"])
+	if err != nil {
+		return nil, err
+	}
+	if gcs != nil {
+		gv.GCS = *gcs
+	}
+
+	if gv.DCS, err = geoCoordSys(ctx, md["DCS"]); err != nil {
+		return nil, err
+	}
+
+	arr, err := ctx.DereferenceArray(md["PDU"])
+	if err != nil {
+		return nil, err
+	}
+	for _, o := range arr {
+		if n, ok := o.(types.Name); ok {
+			gv.PDU = append(gv.PDU, n.Value())
+		}
+	}
+
+	if gv.GPTS, err = floats(ctx, md["GPTS"]); err != nil {
+		return nil, err
+	}
+
+	if gv.LPTS, err = floats(ctx, md["LPTS"]); err != nil {
+		return nil, err
+	}
+
+	return gv, nil
+}
+
+// GeoViewportsForPages returns the georeferenced viewports of selected pages of ctx.
+func GeoViewportsForPages(ctx *model.Context, selectedPages types.IntSet) ([]GeoViewport, error) {
+	gvs := []GeoViewport{}
+
+	for _, pageNr := range selectedPageNrs(ctx, selectedPages) {
+		d, _, _, err := ctx.PageDict(pageNr, false)
+		if err != nil {
+			return nil, err
+		}
+		if d == nil {
+			continue
+		}
+		arr, err := ctx.DereferenceArray(d["VP"])
+		if err != nil {
+			return nil, err
+		}
+		for _, o := range arr {
+			vpd, err := ctx.DereferenceDict(o)
+			if err != nil {
+				return nil, err
+			}
+			if vpd == nil {
+				continue
+			}
+			gv, err := geoViewport(ctx, vpd, pageNr)
+			if err != nil {
+				return nil, err
+			}
+			if gv != nil {
+				gvs = append(gvs, *gv)
+			}
+		}
+	}
+
+	return gvs, nil
+}
+
+func (cs GeoCoordSys) String() string {
+	var ss []string
+	if cs.EPSG > 0 {
+		ss = append(ss, fmt.Sprintf("EPSG:%d", cs.EPSG))
+	}
+	if cs.WKT != "" {
+		wkt := cs.WKT
+		if len(wkt) > 40 {
+			wkt = wkt[:40] + "..."
+		}
+		ss = append(ss, wkt)
+	}
+	return cs.Type + " " + strings.Join(ss, " ")
+}
+
+// ListGeoViewports returns a list of georeferenced viewports for selected pages of ctx.
+func ListGeoViewports(ctx *model.Context, selectedPages types.IntSet) ([]string, error) {
+	gvs, err := GeoViewportsForPages(ctx, selectedPages)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(gvs) == 0 {
+		return []string{"no georeferenced viewports available"}, nil
+	}
+
+	ss := []string{"Georeferenced viewports:"}
+	for _, gv := range gvs {
+		name := ""
+		if gv.Name != "" {
+			name = fmt.Sprintf(" %q", gv.Name)
+		}
+		ss = append(ss, fmt.Sprintf("page %d%s: bbox %v", gv.PageNr, name, gv.BBox))
+		ss = append(ss, "    coordinate system: "+gv.GCS.String())
+		minLat, minLon, maxLat, maxLon := gv.LatLonBounds()
+		ss = append(ss, fmt.Sprintf("    lat: %.6f .. %.6f, lon: %.6f .. %.6f", minLat, maxLat, minLon, maxLon))
+	}
+
+	return ss, nil
+}
+
+// ParseGeoViewportsJSON parses a JSON document containing georeferenced viewports.
+func ParseGeoViewportsJSON(rd io.Reader) ([]GeoViewport, error) {
+	gvs := GeoViewports{}
+	if err := json.NewDecoder(rd).Decode(&gvs); err != nil {
+		return nil, errors.Wrap(err, "pdfcpu: invalid geo viewports JSON")
+	}
+	if len(gvs.Viewports) == 0 {
+		return nil, errors.New("pdfcpu: geo viewports JSON: missing \"viewports\"")
+	}
+	for _, gv := range gvs.Viewports {
+		if err := gv.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	return gvs.Viewports, nil
+}
+
+// AddGeoViewports adds gvs to the viewports of selected pages of ctx.
+// Viewports carrying a page number are added to this page only.
+func AddGeoViewports(ctx *model.Context, selectedPages types.IntSet, gvs []GeoViewport) error {
+	for _, pageNr := range selectedPageNrs(ctx, selectedPages) {
+		d, _, _, err := ctx.PageDict(pageNr, false)
+		if err != nil {
+			return err
+		}
+		if d == nil {
+			return errors.Errorf("pdfcpu: unknown page number: %d\n", pageNr)
+		}
+
+		arr, err := ctx.DereferenceArray(d["VP"])
+		if err != nil {
+			return err
+		}
+
+		n := len(arr)
+		for _, gv := range gvs {
+			if gv.PageNr > 0 && gv.PageNr != pageNr {
+				continue
+			}
+			if err := gv.Validate(); err != nil {
+				return err
+			}
+			vpd, err := gv.dict()
+			if err != nil {
+				return err
+			}
+			arr = append(arr, vpd)
+		}
+
+		if len(arr) > n {
+			d.Update("VP", arr)
+		}
+	}
+
+	if ctx.XRefTable.Version() < model.V16 {
+		ctx.EnsureVersionForWriting()
+	}
+
+	return nil
+}
+
+// RemoveGeoViewports removes all georeferenced viewports from selected pages of ctx.
+// Returns true if at least one viewport was removed.
+func RemoveGeoViewports(ctx *model.Context, selectedPages types.IntSet) (bool, error) {
+	var removed bool
+
+	for _, pageNr := range selectedPageNrs(ctx, selectedPages) {
+		d, _, _, err := ctx.PageDict(pageNr, false)
+		if err != nil {
+			return false, err
+		}
+		if d == nil {
+			continue
+		}
+
+		arr, err := ctx.DereferenceArray(d["VP"])
+		if err != nil || arr == nil {
+			if err != nil {
+				return false, err
+			}
+			continue
+		}
+
+		arr1 := types.Array{}
+		for _, o := range arr {
+			vpd, err := ctx.DereferenceDict(o)
+			if err != nil {
+				return false, err
+			}
+			if vpd != nil {
+				gv, err := geoViewport(ctx, vpd, pageNr)
+				if err != nil {
+					return false, err
+				}
+				if gv != nil {
+					removed = true
+					continue
+				}
+			}
+			arr1 = append(arr1, o)
+		}
+
+		if len(arr1) == 0 {
+			d.Delete("VP")
+		} else {
+			d.Update("VP", arr1)
+		}
+	}
+
+	return removed, nil
+}
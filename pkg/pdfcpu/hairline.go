@@ -0,0 +1,209 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/matrix"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// DefaultHairlineThreshold is the stroke width in points below which lines are considered hairlines.
+const DefaultHairlineThreshold = 0.2
+
+// HairlineConfig describes how to fix hairlines.
+type HairlineConfig struct {
+	Threshold float64 // Stroke widths below Threshold (in points) are raised.
+	MinWidth  float64 // Stroke width (in points) applied to hairlines, defaults to Threshold.
+}
+
+// ParseHairlineConfig parses a hairline configuration string like "threshold:0.2, width:0.25" given in unit.
+func ParseHairlineConfig(s string, unit types.DisplayUnit) (*HairlineConfig, error) {
+	hc := &HairlineConfig{Threshold: DefaultHairlineThreshold}
+
+	if strings.TrimSpace(s) == "" {
+		return hc, nil
+	}
+
+	for _, s := range strings.Split(s, ",") {
+
+		ss := strings.Split(s, ":")
+		if len(ss) != 2 {
+			return nil, errors.New("pdfcpu: Invalid hairline configuration string. Please consult pdfcpu help hairlines")
+		}
+
+		k, v := strings.ToLower(strings.TrimSpace(ss[0])), strings.TrimSpace(ss[1])
+
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil || f <= 0 {
+			return nil, errors.Errorf("pdfcpu: invalid hairline %s: %s", k, v)
+		}
+		f = types.ToUserSpace(f, unit)
+
+		switch k {
+		case "threshold", "t":
+			hc.Threshold = f
+		case "width", "w":
+			hc.MinWidth = f
+		default:
+			return nil, errors.Errorf("pdfcpu: unknown hairline parameter: %s", k)
+		}
+	}
+
+	return hc, nil
+}
+
+// hairlineState is the part of the graphics state relevant for stroke widths.
+type hairlineState struct {
+	ctm   matrix.Matrix
+	width float64
+}
+
+// hairlineFixer raises stroke widths below a threshold in content streams.
+type hairlineFixer struct {
+	ctx      *model.Context
+	cfg      HairlineConfig
+	gs       hairlineState
+	stack    []hairlineState
+	inPath   bool
+	restore  string // Line width to restore after the current path.
+	nrFixed  int
+	gsWidths map[string]*float64 // Line widths of graphics state parameter dicts by resource name.
+}
+
+// matrixScale returns the factor mapping lengths from user space to default user space.
+func matrixScale(m matrix.Matrix) float64 {
+	return math.Sqrt(math.Abs(m[0][0]*m[1][1] - m[0][1]*m[1][0]))
+}
+
+func (hf *hairlineFixer) extGStateWidth(res types.Dict, name string) *float64 {
+	if w, ok := hf.gsWidths[name]; ok {
+		return w
+	}
+	var w *float64
+	if d, err := hf.ctx.DereferenceDict(extGStates(hf.ctx, res)[name]); err == nil && d != nil {
+		if o, err := hf.ctx.Dereference(d["LW"]); err == nil {
+			if f, ok := numberValue(o); ok {
+				w = &f
+			}
+		}
+	}
+	hf.gsWidths[name] = w
+	return w
+}
+
+// pathStart returns the operators to prepend to a new path in order to fix a hairline.
+func (hf *hairlineFixer) pathStart(raw []byte) (string, bool) {
+	s := matrixScale(hf.gs.ctm)
+	if s == 0 || hf.gs.width*s >= hf.cfg.Threshold {
+		return "", false
+	}
+
+	hf.restore = contentNum(hf.gs.width)
+
+	return contentNum(hf.cfg.MinWidth/s) + " w " + string(raw), true
+}
+
+func (hf *hairlineFixer) edit(pageNr int, res types.Dict, bb []byte) ([]byte, bool, error) {
+	hf.gs, hf.stack, hf.inPath, hf.restore = hairlineState{ctm: matrix.IdentMatrix, width: 1}, nil, false, ""
+	hf.gsWidths = map[string]*float64{}
+
+	bb, changed := editContentOps(bb, func(op contentOp, oo []interface{}, raw []byte) (string, bool) {
+		switch op {
+
+		case "q":
+			hf.stack = append(hf.stack, hf.gs)
+
+		case "Q":
+			if l := len(hf.stack); l > 0 {
+				hf.gs, hf.stack = hf.stack[l-1], hf.stack[:l-1]
+			}
+
+		case "cm":
+			if ff, ok := numbers(oo, 6); ok {
+				hf.gs.ctm = matrixFor(ff).Multiply(hf.gs.ctm)
+			}
+
+		case "w":
+			if ff, ok := numbers(oo, 1); ok {
+				hf.gs.width = ff[0]
+			}
+
+		case "gs":
+			if len(oo) > 0 {
+				if n, ok := oo[len(oo)-1].(contentName); ok {
+					if w := hf.extGStateWidth(res, string(n)); w != nil {
+						hf.gs.width = *w
+					}
+				}
+			}
+
+		case "m", "re":
+			if !hf.inPath {
+				hf.inPath = true
+				return hf.pathStart(raw)
+			}
+
+		case "S", "s", "f", "F", "f*", "B", "B*", "b", "b*", "n":
+			hf.inPath = false
+			if hf.restore != "" {
+				if op != "f" && op != "F" && op != "f*" && op != "n" {
+					hf.nrFixed++
+				}
+				s := string(raw) + " " + hf.restore + " w"
+				hf.restore = ""
+				return s, true
+			}
+		}
+
+		return "", false
+	})
+
+	return bb, changed, nil
+}
+
+// FixHairlines raises the width of strokes thinner than hc.Threshold in default user space to hc.MinWidth
+// for pages pageNrs (all pages if nil) including their form XObjects and tiling patterns.
+// Form XObjects and patterns are evaluated in their own coordinate system.
+// It returns the number of strokes fixed.
+func FixHairlines(ctx *model.Context, pageNrs []int, hc *HairlineConfig) (int, error) {
+	if hc == nil {
+		hc = &HairlineConfig{Threshold: DefaultHairlineThreshold}
+	}
+
+	if hc.Threshold <= 0 {
+		return 0, errors.New("pdfcpu: hairline threshold must be positive")
+	}
+
+	cfg := *hc
+	if cfg.MinWidth <= 0 {
+		cfg.MinWidth = cfg.Threshold
+	}
+
+	hf := &hairlineFixer{ctx: ctx, cfg: cfg}
+
+	if err := newContentWalker(ctx, hf.edit, false).walk(pageNrs); err != nil {
+		return 0, err
+	}
+
+	return hf.nrFixed, nil
+}
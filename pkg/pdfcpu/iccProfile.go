@@ -20,6 +20,8 @@ import (
 	"encoding/binary"
 	"encoding/hex"
 	"fmt"
+	"strings"
+	"unicode/utf16"
 
 	"github.com/pkg/errors"
 )
@@ -309,3 +311,72 @@ func (p iccProfile) String() string {
 
 	return s
 }
+
+// newICCProfile returns the ICC profile for bb after checking its header and tag table.
+func newICCProfile(bb []byte) (*iccProfile, error) {
+
+	p := &iccProfile{b: bb}
+
+	if len(bb) < 132 || p.fileSig() != "acsp" {
+		return nil, errors.New("pdfcpu: invalid ICC profile")
+	}
+
+	if int(p.size()) > len(bb) || 132+12*p.tagCount() > len(bb) {
+		return nil, errors.New("pdfcpu: corrupt ICC profile")
+	}
+
+	return p, nil
+}
+
+// components returns the number of color components of the profile's data color space.
+func (p iccProfile) components() (int, error) {
+	switch p.dataColorSpace() {
+	case "GRAY":
+		return 1, nil
+	case "RGB ":
+		return 3, nil
+	case "CMYK":
+		return 4, nil
+	}
+	return 0, errors.Errorf("pdfcpu: unsupported ICC profile color space: %s", strings.TrimSpace(p.dataColorSpace()))
+}
+
+// description returns the profile description of the profileDescriptionTag.
+func (p iccProfile) description() string {
+
+	off, size, err := p.tag("desc")
+	if err != nil || size < 12 || off+size > len(p.b) {
+		return ""
+	}
+
+	bb := p.b[off : off+size]
+
+	switch string(bb[:4]) {
+
+	case "desc":
+		// textDescriptionType (ICC v2): ASCII count followed by ASCII description.
+		n := int(binary.BigEndian.Uint32(bb[8:]))
+		if n == 0 || 12+n > len(bb) {
+			return ""
+		}
+		return strings.TrimRight(string(bb[12:12+n]), "\x00")
+
+	case "mluc":
+		// multiLocalizedUnicodeType (ICC v4): use the first record.
+		if len(bb) < 28 {
+			return ""
+		}
+		l := int(binary.BigEndian.Uint32(bb[20:]))
+		o := int(binary.BigEndian.Uint32(bb[24:]))
+		if o+l > len(bb) {
+			return ""
+		}
+		u := make([]uint16, l/2)
+		for i := range u {
+			u[i] = binary.BigEndian.Uint16(bb[o+2*i:])
+		}
+		return strings.TrimRight(string(utf16.Decode(u)), "\x00")
+	}
+
+	return ""
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package pdfcpu
 
 import (
+	"encoding/hex"
 	"fmt"
 	"sort"
 	"strings"
@@ -26,6 +27,7 @@ import (
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/draw"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
 )
 
 func extractAuthor(ctx *model.Context, obj types.Object) (err error) {
@@ -146,13 +148,83 @@ func ensureInfoDict(ctx *model.Context) error {
 		return err
 	}
 
-	d.Update("CreationDate", types.StringLiteral(now))
-	d.Update("ModDate", types.StringLiteral(now))
+	if !ctx.KeepDates {
+		d.Update("CreationDate", types.StringLiteral(now))
+		d.Update("ModDate", types.StringLiteral(now))
+	}
 	d.Update("Producer", types.StringLiteral(v))
 
 	return nil
 }
 
+// ApplyDocIdentity sets the file identifier and the document dates of ctx according to di
+// and makes sure they survive writing.
+func ApplyDocIdentity(ctx *model.Context, di *model.DocIdentity) error {
+	if di == nil {
+		return nil
+	}
+
+	if di.ID != "" {
+		bb, err := hex.DecodeString(di.ID)
+		if err != nil {
+			return errors.Errorf("pdfcpu: invalid file identifier: %s", di.ID)
+		}
+		// Use the upper case form the parser produces.
+		id := types.HexLiteral(strings.ToUpper(hex.EncodeToString(bb)))
+		ctx.ID = types.Array{id, id}
+		ctx.KeepID = true
+	} else if di.Keep && len(ctx.ID) == 2 {
+		ctx.KeepID = true
+	}
+
+	if (!di.Keep && di.Created == nil && di.Modified == nil) || ctx.XRefTable.Version() >= model.V20 {
+		// There are no document dates without an info dict.
+		return nil
+	}
+
+	var created, modified types.Object
+
+	if di.Keep && ctx.Info != nil {
+		d, err := ctx.DereferenceDict(*ctx.Info)
+		if err != nil {
+			return err
+		}
+		if created, err = ctx.Dereference(d["CreationDate"]); err != nil {
+			return err
+		}
+		if modified, err = ctx.Dereference(d["ModDate"]); err != nil {
+			return err
+		}
+	}
+
+	if err := ensureInfoDict(ctx); err != nil {
+		return err
+	}
+
+	d, err := ctx.DereferenceDict(*ctx.Info)
+	if err != nil {
+		return err
+	}
+
+	if di.Created != nil {
+		created = types.StringLiteral(types.DateString(*di.Created))
+	}
+	if di.Modified != nil {
+		modified = types.StringLiteral(types.DateString(*di.Modified))
+	}
+
+	if created != nil {
+		d.Update("CreationDate", created)
+	}
+	if modified != nil {
+		d.Update("ModDate", modified)
+	}
+
+	ctx.KeepDates = true
+
+	return nil
+}
+
 // Write the document info object for this PDF file.
 func writeDocumentInfoDict(ctx *model.Context) error {
 	if log.WriteEnabled() {
@@ -312,6 +384,8 @@ func pageInfo(info *PDFInfo, selectedPages types.IntSet) ([]string, error) {
 type PDFInfo struct {
 	FileName           string                          `json:"source,omitempty"`
 	Version            string                          `json:"version"`
+	Extensions         []DeveloperExtension            `json:"extensions,omitempty"`
+	Requirements       []string                        `json:"requirements,omitempty"`
 	PageCount          int                             `json:"pageCount"`
 	PageBoundaries     []model.PageBoundaries          `json:"-"`
 	Boundaries         map[string]model.PageBoundaries `json:"pageBoundaries,omitempty"`
@@ -339,6 +413,7 @@ type PDFInfo struct {
 	Form               bool                            `json:"form"`
 	Signatures         bool                            `json:"signatures"`
 	AppendOnly         bool                            `json:"appendOnly"`
+	UsageRights        bool                            `json:"usageRights"`
 	Outlines           bool                            `json:"bookmarks"`
 	Names              bool                            `json:"names"`
 	Encrypted          bool                            `json:"encrypted"`
@@ -435,6 +510,9 @@ func (info PDFInfo) renderFlagsPart2(ss *[]string, separator string) {
 			*ss = append(*ss, fmt.Sprintf("          AppendOnly: %s", s))
 		}
 	}
+	if info.UsageRights {
+		*ss = append(*ss, "         UsageRights: Yes")
+	}
 
 	s = "No"
 	if info.Outlines {
@@ -522,6 +600,18 @@ func Info(ctx *model.Context, fileName string, selectedPages types.IntSet, fonts
 	}
 	info.Version = (*v).String()
 
+	ee, err := Extensions(ctx.XRefTable)
+	if err != nil {
+		return nil, err
+	}
+	info.Extensions = ee
+
+	rr, err := Requirements(ctx.XRefTable)
+	if err != nil {
+		return nil, err
+	}
+	info.Requirements = rr
+
 	info.PageCount = ctx.PageCount
 
 	// PageBoundaries for selected pages.
@@ -582,6 +672,13 @@ func Info(ctx *model.Context, fileName string, selectedPages types.IntSet, fonts
 
 	info.Signatures = ctx.SignatureExist
 	info.AppendOnly = ctx.AppendOnly
+
+	ur, err := ctx.UsageRights()
+	if err != nil {
+		return nil, err
+	}
+	info.UsageRights = ur != nil
+
 	info.Encrypted = ctx.Encrypt != nil
 
 	if ctx.E != nil {
@@ -634,6 +731,16 @@ func ListInfo(info *PDFInfo, selectedPages types.IntSet, fonts bool) ([]string,
 		ss = append(ss, fmt.Sprintf("%20s: %s", "Source", info.FileName))
 	}
 	ss = append(ss, fmt.Sprintf("%20s: %s", "PDF version", info.Version))
+	for i, e := range info.Extensions {
+		s := ""
+		if i == 0 {
+			s = "Extensions:"
+		}
+		ss = append(ss, fmt.Sprintf("%21s %s", s, e))
+	}
+	if len(info.Requirements) > 0 {
+		ss = append(ss, fmt.Sprintf("%20s: %s", "Requirements", strings.Join(info.Requirements, ", ")))
+	}
 	ss = append(ss, fmt.Sprintf("%20s: %d", "Page count", info.PageCount))
 
 	pi, err := pageInfo(info, selectedPages)
@@ -17,10 +17,14 @@ limitations under the License.
 package pdfcpu
 
 import (
+	"encoding/json"
+	"io"
+	"sort"
 	"strings"
 
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
 )
 
 // KeywordsList returns a list of keywords as recorded in the document info dict.
@@ -141,3 +145,63 @@ func KeywordsRemove(ctx *model.Context, keywords []string) (bool, error) {
 
 	return removed, err
 }
+
+// KeywordsJSON represents the keywords of a PDF file for JSON export and import.
+type KeywordsJSON struct {
+	Header   *Header  `json:"header,omitempty"`
+	Keywords []string `json:"keywords"`
+}
+
+// ExportKeywordsJSON writes the keywords of ctx (originating from source) as JSON to w.
+func ExportKeywordsJSON(ctx *model.Context, source string, w io.Writer) error {
+	ss, err := KeywordsList(ctx)
+	if err != nil {
+		return err
+	}
+	if ss == nil {
+		ss = []string{}
+	}
+	sort.Strings(ss)
+
+	h := header(ctx.XRefTable, source)
+	bb, err := json.MarshalIndent(KeywordsJSON{Header: &h, Keywords: ss}, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(bb)
+	return err
+}
+
+// ParseKeywordsJSON parses a JSON document containing keywords.
+func ParseKeywordsJSON(rd io.Reader) ([]string, error) {
+	kj := KeywordsJSON{}
+	if err := json.NewDecoder(rd).Decode(&kj); err != nil {
+		return nil, errors.Wrap(err, "pdfcpu: invalid keywords JSON")
+	}
+
+	var ss []string
+	for _, s := range kj.Keywords {
+		if s = strings.TrimSpace(s); s != "" {
+			ss = append(ss, s)
+		}
+	}
+	if len(ss) == 0 {
+		return nil, errors.New("pdfcpu: keywords JSON: missing \"keywords\"")
+	}
+
+	return ss, nil
+}
+
+// KeywordsReplace replaces all keywords of the document info dict.
+func KeywordsReplace(ctx *model.Context, keywords []string) error {
+	if err := ensureInfoDictAndFileID(ctx); err != nil {
+		return err
+	}
+
+	for k := range ctx.KeywordList {
+		ctx.KeywordList[k] = false
+	}
+
+	return KeywordsAdd(ctx, keywords)
+}
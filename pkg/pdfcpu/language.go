@@ -0,0 +1,109 @@
+/*
+	Copyright 2026 The pdfcpu Authors.
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// Language returns the natural language of ctx as recorded in the catalog.
+func Language(ctx *model.Context) string {
+	rootDict, err := ctx.Catalog()
+	if err != nil {
+		return ""
+	}
+	o, found := rootDict.Find("Lang")
+	if !found {
+		return ""
+	}
+	s, err := ctx.DereferenceText(o)
+	if err != nil {
+		return ""
+	}
+	return s
+}
+
+// SetLanguage sets the natural language of ctx, eg. "en-US".
+func SetLanguage(ctx *model.Context, lang string) error {
+	if !types.ValidLanguageTag(lang) {
+		return errors.Errorf("pdfcpu: invalid language: %s (eg. \"en\", \"en-US\")", lang)
+	}
+
+	rootDict, err := ctx.Catalog()
+	if err != nil {
+		return err
+	}
+
+	rootDict["Lang"] = types.StringLiteral(lang)
+
+	return nil
+}
+
+// ResetLanguage removes the natural language of ctx.
+// Returns true if a language was set.
+func ResetLanguage(ctx *model.Context) (bool, error) {
+	rootDict, err := ctx.Catalog()
+	if err != nil {
+		return false, err
+	}
+
+	if _, found := rootDict.Find("Lang"); !found {
+		return false, nil
+	}
+
+	delete(rootDict, "Lang")
+
+	return true, nil
+}
+
+// SetTitle sets the document title of ctx.
+// displayDocTitle makes PDF viewers display the title instead of the file name in the window title bar.
+func SetTitle(ctx *model.Context, title string, displayDocTitle bool) error {
+	if title != "" {
+		if err := ensureInfoDictAndFileID(ctx); err != nil {
+			return err
+		}
+
+		if ctx.Info != nil {
+			d, err := ctx.DereferenceDict(*ctx.Info)
+			if err != nil || d == nil {
+				return err
+			}
+			s, err := types.EscapedUTF16String(title)
+			if err != nil {
+				return err
+			}
+			d["Title"] = types.StringLiteral(*s)
+		}
+
+		ctx.Title = title
+	}
+
+	if !displayDocTitle {
+		return nil
+	}
+
+	if ctx.ViewerPref == nil {
+		ctx.ViewerPref = &model.ViewerPreferences{}
+	}
+	ctx.ViewerPref.SetDisplayDocTitle(true)
+	ctx.XRefTable.BindViewerPreferences()
+
+	return nil
+}
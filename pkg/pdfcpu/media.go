@@ -0,0 +1,468 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/filter"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// Media represents 3D artwork, a RichMedia asset, a sound or a movie embedded into a page annotation.
+type Media struct {
+	io.Reader
+	PageNr int
+	ObjNr  int    // annotation object number
+	Annot  string // 3D, RichMedia, Sound, Movie or Screen
+	Format string // U3D, PRC, RAW for sound samples or the file extension of an asset, movie or media clip
+	Codec  string // sample format of sounds, MIME type of movies and media clips
+	Name   string // file name of an asset, movie or media clip
+	Size   int
+}
+
+func (m Media) String() string {
+	s := fmt.Sprintf("page %d: %s (obj#%d)", m.PageNr, m.Annot, m.ObjNr)
+	if m.Name != "" {
+		s += fmt.Sprintf(" asset %q", m.Name)
+	}
+	if m.Format != "" {
+		s += " " + m.Format
+	}
+	if m.Codec != "" {
+		s += " " + m.Codec
+	}
+	return s + fmt.Sprintf(", %d bytes", m.Size)
+}
+
+func decodedMediaStream(sd *types.StreamDict) (io.Reader, int, error) {
+	if err := sd.Decode(); err != nil {
+		if err == filter.ErrUnsupportedFilter {
+			return nil, 0, nil
+		}
+		return nil, 0, err
+	}
+	return bytes.NewReader(sd.Content), len(sd.Content), nil
+}
+
+// media3D returns the 3D stream of the 3D annotation d.
+func media3D(ctx *model.Context, d types.Dict) (*Media, error) {
+	o, err := ctx.Dereference(d["3DD"])
+	if err != nil || o == nil {
+		return nil, err
+	}
+
+	if d1, ok := o.(types.Dict); ok {
+		// 3D reference dict
+		if o, err = ctx.Dereference(d1["3DD"]); err != nil || o == nil {
+			return nil, err
+		}
+	}
+
+	sd, ok := o.(types.StreamDict)
+	if !ok {
+		return nil, nil
+	}
+
+	r, size, err := decodedMediaStream(&sd)
+	if err != nil || r == nil {
+		return nil, err
+	}
+
+	m := &Media{Reader: r, Annot: "3D", Size: size}
+	if st := sd.Subtype(); st != nil {
+		m.Format = *st
+	}
+
+	return m, nil
+}
+
+func nameTreeValues(ctx *model.Context, d types.Dict, fn func(k string, v types.Object) error) error {
+	names, err := ctx.DereferenceArray(d["Names"])
+	if err != nil {
+		return err
+	}
+	for i := 0; i+1 < len(names); i += 2 {
+		k, err := ctx.DereferenceStringOrHexLiteral(names[i], model.V10, nil)
+		if err != nil {
+			return err
+		}
+		if err := fn(k, names[i+1]); err != nil {
+			return err
+		}
+	}
+
+	kids, err := ctx.DereferenceArray(d["Kids"])
+	if err != nil {
+		return err
+	}
+	for _, o := range kids {
+		d1, err := ctx.DereferenceDict(o)
+		if err != nil {
+			return err
+		}
+		if d1 == nil {
+			continue
+		}
+		if err := nameTreeValues(ctx, d1, fn); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// mediaRichMedia returns the assets of the RichMedia annotation d.
+func mediaRichMedia(ctx *model.Context, d types.Dict) ([]Media, error) {
+	content, err := ctx.DereferenceDict(d["RichMediaContent"])
+	if err != nil || content == nil {
+		return nil, err
+	}
+
+	assets, err := ctx.DereferenceDict(content["Assets"])
+	if err != nil || assets == nil {
+		return nil, err
+	}
+
+	mm := []Media{}
+
+	err = nameTreeValues(ctx, assets, func(k string, v types.Object) error {
+		sd, _, err := embeddedFile(ctx, v)
+		if err != nil || sd == nil {
+			return err
+		}
+		r, size, err := decodedMediaStream(sd)
+		if err != nil || r == nil {
+			return err
+		}
+		mm = append(mm, Media{
+			Reader: r,
+			Annot:  "RichMedia",
+			Format: fileFormat(k),
+			Name:   k,
+			Size:   size,
+		})
+		return nil
+	})
+
+	return mm, err
+}
+
+// embeddedFile returns the embedded file stream and the file name of the file specification o (see 7.11.4).
+func embeddedFile(ctx *model.Context, o types.Object) (*types.StreamDict, string, error) {
+	fs, err := ctx.DereferenceDict(o)
+	if err != nil || fs == nil {
+		// External file given by a file specification string.
+		return nil, "", nil
+	}
+
+	var name string
+	for _, k := range []string{"UF", "F"} {
+		if o, ok := fs[k]; ok {
+			if s, err := ctx.DereferenceStringOrHexLiteral(o, model.V10, nil); err == nil && s != "" {
+				name = s
+				break
+			}
+		}
+	}
+
+	ef, err := ctx.DereferenceDict(fs["EF"])
+	if err != nil || ef == nil {
+		return nil, name, err
+	}
+
+	o, ok := ef["UF"]
+	if !ok {
+		o = ef["F"]
+	}
+
+	sd, _, err := ctx.DereferenceStreamDict(o)
+	return sd, name, err
+}
+
+func fileFormat(fileName string) string {
+	return strings.ToUpper(strings.TrimPrefix(filepath.Ext(fileName), "."))
+}
+
+// mediaSound returns the sound object of the sound annotation d (see 13.3).
+func mediaSound(ctx *model.Context, d types.Dict) (*Media, error) {
+	sd, _, err := ctx.DereferenceStreamDict(d["Sound"])
+	if err != nil || sd == nil {
+		return nil, err
+	}
+
+	rate, err := ctx.DereferenceNumber(sd.Dict["R"])
+	if err != nil {
+		return nil, err
+	}
+
+	channels, bits, enc := 1, 8, "Raw"
+	if i := sd.IntEntry("C"); i != nil {
+		channels = *i
+	}
+	if i := sd.IntEntry("B"); i != nil {
+		bits = *i
+	}
+	if n := sd.NameEntry("E"); n != nil {
+		enc = *n
+	}
+
+	codec := fmt.Sprintf("%s %d bit %d ch %g Hz", enc, bits, channels, rate)
+	if n := sd.NameEntry("CO"); n != nil {
+		codec += " " + *n
+	}
+
+	r, size, err := decodedMediaStream(sd)
+	if err != nil || r == nil {
+		return nil, err
+	}
+
+	return &Media{Reader: r, Annot: "Sound", Format: "RAW", Codec: codec, Size: size}, nil
+}
+
+func mediaFile(ctx *model.Context, o types.Object, annot string) (*Media, error) {
+	sd, name, err := embeddedFile(ctx, o)
+	if err != nil || sd == nil {
+		return nil, err
+	}
+
+	r, size, err := decodedMediaStream(sd)
+	if err != nil || r == nil {
+		return nil, err
+	}
+
+	m := &Media{Reader: r, Annot: annot, Format: fileFormat(name), Name: name, Size: size}
+	if st := sd.Subtype(); st != nil {
+		m.Codec = *st
+	}
+
+	return m, nil
+}
+
+// mediaMovie returns the embedded movie file of the movie annotation d (see 13.4).
+func mediaMovie(ctx *model.Context, d types.Dict) (*Media, error) {
+	movie, err := ctx.DereferenceDict(d["Movie"])
+	if err != nil || movie == nil {
+		return nil, err
+	}
+
+	return mediaFile(ctx, movie["F"], "Movie")
+}
+
+// mediaClip returns the media clip data of the media rendition d (see 13.2.4.2).
+func mediaClip(ctx *model.Context, d types.Dict) (*Media, error) {
+	clip, err := ctx.DereferenceDict(d["C"])
+	if err != nil || clip == nil {
+		return nil, err
+	}
+
+	if st := clip.NameEntry("S"); st == nil || *st != "MCD" {
+		return nil, nil
+	}
+
+	var m *Media
+
+	o, err := ctx.Dereference(clip["D"])
+	if err != nil || o == nil {
+		return nil, err
+	}
+
+	if sd, ok := o.(types.StreamDict); ok {
+		r, size, err := decodedMediaStream(&sd)
+		if err != nil || r == nil {
+			return nil, err
+		}
+		m = &Media{Reader: r, Annot: "Screen", Size: size}
+	} else if m, err = mediaFile(ctx, o, "Screen"); err != nil || m == nil {
+		return nil, err
+	}
+
+	if ct, err := ctx.DereferenceStringOrHexLiteral(clip["CT"], model.V10, nil); err == nil && ct != "" {
+		m.Codec = ct
+	}
+
+	if m.Name == "" {
+		if n, err := ctx.DereferenceStringOrHexLiteral(clip["N"], model.V10, nil); err == nil {
+			m.Name = n
+			m.Format = fileFormat(n)
+		}
+	}
+
+	return m, nil
+}
+
+// renditionMedia returns the media clips of rendition d including the renditions of selector renditions (see 13.2.3).
+func renditionMedia(ctx *model.Context, d types.Dict, depth int) ([]Media, error) {
+	if d == nil || depth > maxFormDepth {
+		return nil, nil
+	}
+
+	st := d.NameEntry("S")
+	if st == nil {
+		return nil, nil
+	}
+
+	switch *st {
+
+	case "MR":
+		m, err := mediaClip(ctx, d)
+		if err != nil || m == nil {
+			return nil, err
+		}
+		return []Media{*m}, nil
+
+	case "SR":
+		arr, err := ctx.DereferenceArray(d["R"])
+		if err != nil {
+			return nil, err
+		}
+		var mm []Media
+		for _, o := range arr {
+			d1, err := ctx.DereferenceDict(o)
+			if err != nil {
+				return nil, err
+			}
+			mm1, err := renditionMedia(ctx, d1, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			mm = append(mm, mm1...)
+		}
+		return mm, nil
+	}
+
+	return nil, nil
+}
+
+// mediaScreen returns the media clips played by the rendition action of the screen annotation d (see 12.5.6.18).
+func mediaScreen(ctx *model.Context, d types.Dict) ([]Media, error) {
+	a, err := ctx.DereferenceDict(d["A"])
+	if err != nil || a == nil {
+		return nil, err
+	}
+
+	if s := a.NameEntry("S"); s == nil || *s != "Rendition" {
+		return nil, nil
+	}
+
+	r, err := ctx.DereferenceDict(a["R"])
+	if err != nil {
+		return nil, err
+	}
+
+	return renditionMedia(ctx, r, 0)
+}
+
+// MediaForPages returns the 3D artwork, RichMedia assets, sounds and movies of selected pages of ctx.
+func MediaForPages(ctx *model.Context, selectedPages types.IntSet) ([]Media, error) {
+	mm := []Media{}
+
+	for _, pageNr := range selectedPageNrs(ctx, selectedPages) {
+		d, _, _, err := ctx.PageDict(pageNr, false)
+		if err != nil {
+			return nil, err
+		}
+
+		annots, err := ctx.DereferenceArray(d["Annots"])
+		if err != nil {
+			return nil, err
+		}
+
+		for _, o := range annots {
+			d1, err := ctx.DereferenceDict(o)
+			if err != nil {
+				return nil, err
+			}
+			st := d1.Subtype()
+			if st == nil {
+				continue
+			}
+
+			var objNr int
+			if ir, ok := o.(types.IndirectRef); ok {
+				objNr = ir.ObjectNumber.Value()
+			}
+
+			switch *st {
+
+			case "3D":
+				m, err := media3D(ctx, d1)
+				if err != nil {
+					return nil, err
+				}
+				if m != nil {
+					m.PageNr, m.ObjNr = pageNr, objNr
+					mm = append(mm, *m)
+				}
+
+			case "Sound", "Movie":
+				f := mediaSound
+				if *st == "Movie" {
+					f = mediaMovie
+				}
+				m, err := f(ctx, d1)
+				if err != nil {
+					return nil, err
+				}
+				if m != nil {
+					m.PageNr, m.ObjNr = pageNr, objNr
+					mm = append(mm, *m)
+				}
+
+			case "RichMedia", "Screen":
+				f := mediaRichMedia
+				if *st == "Screen" {
+					f = mediaScreen
+				}
+				mm1, err := f(ctx, d1)
+				if err != nil {
+					return nil, err
+				}
+				for _, m := range mm1 {
+					m.PageNr, m.ObjNr = pageNr, objNr
+					mm = append(mm, m)
+				}
+			}
+		}
+	}
+
+	return mm, nil
+}
+
+// ListMedia returns a list of the 3D artwork, RichMedia assets, sounds and movies of selected pages of ctx.
+func ListMedia(ctx *model.Context, selectedPages types.IntSet) ([]string, error) {
+	mm, err := MediaForPages(ctx, selectedPages)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(mm) == 0 {
+		return []string{"no media content available"}, nil
+	}
+
+	ss := []string{"media content:"}
+	for _, m := range mm {
+		ss = append(ss, m.String())
+	}
+
+	return ss, nil
+}
@@ -374,7 +374,7 @@ func mergeInFields(ctxDest *model.Context, arrFieldsSrc, arrFieldsDest types.Arr
 	return nil
 }
 
-func mergeDests(ctxSource, ctxDest *model.Context) error {
+func mergeDests(fName string, ctxSource, ctxDest *model.Context) error {
 	rootDictSource, rootDictDest, err := rootDicts(ctxSource, ctxDest)
 	if err != nil {
 		return err
@@ -401,15 +401,10 @@ func mergeDests(ctxSource, ctxDest *model.Context) error {
 		return err
 	}
 
-	// Note: We ignore duplicate keys
-	for k, v := range destsSrc {
-		destsDest[k] = v
-	}
-
-	return nil
+	return mergeDestsDict(fName, ctxSource, ctxDest, destsSrc, destsDest)
 }
 
-func mergeNames(ctxSrc, ctxDest *model.Context) error {
+func mergeNames(fName string, ctxSrc, ctxDest *model.Context) error {
 
 	rootDictSrc, rootDictDest, err := rootDicts(ctxSrc, ctxDest)
 	if err != nil {
@@ -432,7 +427,7 @@ func mergeNames(ctxSrc, ctxDest *model.Context) error {
 	for id, namesSrc := range ctxSrc.Names {
 		if namesDest, ok := ctxDest.Names[id]; ok {
 			// Merge src tree into dest tree including collision detection.
-			if err := namesDest.AddTree(ctxDest.XRefTable, namesSrc, ctxSrc.NameRefs[id], []string{"D", "Dest"}); err != nil {
+			if err := mergeNameTree(fName, id, ctxSrc, ctxDest, namesSrc, namesDest); err != nil {
 				return err
 			}
 			continue
@@ -445,7 +440,7 @@ func mergeNames(ctxSrc, ctxDest *model.Context) error {
 	return nil
 }
 
-func mergeForms(ctxSrc, ctxDest *model.Context) error {
+func mergeForms(fName string, ctxSrc, ctxDest *model.Context) error {
 
 	rootDictSource, rootDictDest, err := rootDicts(ctxSrc, ctxDest)
 	if err != nil {
@@ -508,7 +503,7 @@ func mergeForms(ctxSrc, ctxDest *model.Context) error {
 		return nil
 	}
 
-	if err := mergeInFields(ctxDest, arrFieldsSrc, arrFieldsDest, dDest); err != nil {
+	if arrFieldsSrc, err = mergeFields(fName, ctxDest, dSrc, dDest, arrFieldsSrc, arrFieldsDest); err != nil {
 		return err
 	}
 
@@ -951,15 +946,27 @@ func MergeXRefTables(fName string, ctxSrc, ctxDest *model.Context, zip, dividerP
 		return nil
 	}
 
-	if err = mergeForms(ctxSrc, ctxDest); err != nil {
+	if err = mergeForms(fName, ctxSrc, ctxDest); err != nil {
+		return err
+	}
+
+	if err = mergeDests(fName, ctxSrc, ctxDest); err != nil {
+		return err
+	}
+
+	if err = mergeNames(fName, ctxSrc, ctxDest); err != nil {
+		return err
+	}
+
+	if err = mergeOCProperties(fName, ctxSrc, ctxDest); err != nil {
 		return err
 	}
 
-	if err = mergeDests(ctxSrc, ctxDest); err != nil {
+	if err = mergeExtensions(ctxSrc, ctxDest); err != nil {
 		return err
 	}
 
-	if err = mergeNames(ctxSrc, ctxDest); err != nil {
+	if err = mergeRequirements(ctxSrc, ctxDest); err != nil {
 		return err
 	}
 
@@ -0,0 +1,463 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"fmt"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// nameRefDictKeys returns the keys of dicts referring to entries of the name tree id.
+var nameRefDictKeys = map[string][]string{
+	"Dests":         {"D", "Dest"},
+	"EmbeddedFiles": {"F", "UF"},
+}
+
+func reportMerge(ctx *model.Context, fName, format string, a ...interface{}) {
+	ctx.MergeReport = append(ctx.MergeReport, fName+": "+fmt.Sprintf(format, a...))
+}
+
+func errMergeConflict(fName, item, name string) error {
+	return errors.Errorf("pdfcpu: merge %s: duplicate %s: %q", fName, item, name)
+}
+
+// uniqueName returns the first name of the form k_i not taken.
+func uniqueName(k string, taken map[string]bool) string {
+	for i := 1; ; i++ {
+		s := fmt.Sprintf("%s_%d", k, i)
+		if !taken[s] {
+			return s
+		}
+	}
+}
+
+// updateNameRefs patches all dicts in dd referring to name kOld via keys.
+func updateNameRefs(dd []types.Dict, keys []string, kOld, kNew string) {
+	for _, d := range dd {
+		for _, key := range keys {
+			switch o := d[key].(type) {
+			case types.Name:
+				if o.Value() == kOld {
+					d[key] = types.Name(kNew)
+				}
+			case types.StringLiteral, types.HexLiteral:
+				if s, err := types.StringOrHexLiteral(o); err == nil && s != nil && *s == kOld {
+					d[key] = types.NewHexLiteral([]byte(kNew))
+				}
+			}
+		}
+	}
+}
+
+type nameTreeEntry struct {
+	k string
+	v types.Object
+}
+
+func nameTreeEntries(n *model.Node) ([]nameTreeEntry, error) {
+	var ee []nameTreeEntry
+	err := n.Process(nil, func(_ *model.XRefTable, k string, v *types.Object) error {
+		ee = append(ee, nameTreeEntry{k, *v})
+		return nil
+	})
+	return ee, err
+}
+
+// mergeNameTree adds all entries of namesSrc to namesDest resolving colliding names according to the merge conflict policy.
+func mergeNameTree(fName, id string, ctxSrc, ctxDest *model.Context, namesSrc, namesDest *model.Node) error {
+	eeDest, err := nameTreeEntries(namesDest)
+	if err != nil {
+		return err
+	}
+
+	taken := map[string]bool{}
+	for _, e := range eeDest {
+		taken[e.k] = true
+	}
+
+	eeSrc, err := nameTreeEntries(namesSrc)
+	if err != nil {
+		return err
+	}
+
+	m, keys := ctxSrc.NameRefs[id], nameRefDictKeys[id]
+
+	for _, e := range eeSrc {
+		k := e.k
+		if taken[k] {
+			switch ctxDest.MergeConflicts {
+			case model.MergeConflictError:
+				return errMergeConflict(fName, id+" name", k)
+			case model.MergeConflictSkip:
+				reportMerge(ctxDest, fName, "skipped %s name %q", id, k)
+				continue
+			default:
+				k = uniqueName(e.k, taken)
+				updateNameRefs(m[e.k], keys, e.k, k)
+				reportMerge(ctxDest, fName, "renamed %s name %q to %q", id, e.k, k)
+			}
+		}
+		taken[k] = true
+		if err := namesDest.Add(ctxDest.XRefTable, k, e.v, nil, keys); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// mergeDestsDict merges the named destinations of destsSrc into destsDest resolving colliding names according to the merge conflict policy.
+func mergeDestsDict(fName string, ctxSrc, ctxDest *model.Context, destsSrc, destsDest types.Dict) error {
+	taken := map[string]bool{}
+	for k := range destsDest {
+		taken[k] = true
+	}
+
+	for k, v := range destsSrc {
+		if taken[k] {
+			switch ctxDest.MergeConflicts {
+			case model.MergeConflictError:
+				return errMergeConflict(fName, "named destination", k)
+			case model.MergeConflictSkip:
+				reportMerge(ctxDest, fName, "skipped named destination %q", k)
+				continue
+			default:
+				k1 := uniqueName(k, taken)
+				updateNameRefs(ctxSrc.NameRefs["Dests"][k], nameRefDictKeys["Dests"], k, k1)
+				reportMerge(ctxDest, fName, "renamed named destination %q to %q", k, k1)
+				k = k1
+			}
+		}
+		taken[k] = true
+		destsDest[k] = v
+	}
+
+	return nil
+}
+
+func fieldName(ctx *model.Context, o types.Object) (string, error) {
+	d, err := ctx.DereferenceDict(o)
+	if err != nil || d == nil {
+		return "", err
+	}
+	o, found := d.Find("T")
+	if !found {
+		return "", nil
+	}
+	return ctx.DereferenceStringOrHexLiteral(o, model.V10, nil)
+}
+
+// collidingFields returns the indices of the fields of arrFieldsSrc named like a field of arrFieldsDest.
+func collidingFields(ctxDest *model.Context, arrFieldsSrc, arrFieldsDest types.Array) ([]int, []string, error) {
+	taken := map[string]bool{}
+	for _, o := range arrFieldsDest {
+		s, err := fieldName(ctxDest, o)
+		if err != nil {
+			return nil, nil, err
+		}
+		taken[s] = true
+	}
+
+	var (
+		ii []int
+		ss []string
+	)
+
+	for i, o := range arrFieldsSrc {
+		s, err := fieldName(ctxDest, o)
+		if err != nil {
+			return nil, nil, err
+		}
+		if s != "" && taken[s] {
+			ii = append(ii, i)
+			ss = append(ss, s)
+		}
+	}
+
+	return ii, ss, nil
+}
+
+func collectWidgets(ctx *model.Context, o types.Object, objNrs types.IntSet) error {
+	ir, ok := o.(types.IndirectRef)
+	if !ok {
+		return nil
+	}
+	d, err := ctx.DereferenceDict(ir)
+	if err != nil || d == nil {
+		return err
+	}
+	objNrs[ir.ObjectNumber.Value()] = true
+	kids, err := ctx.DereferenceArray(d["Kids"])
+	if err != nil {
+		return err
+	}
+	for _, kid := range kids {
+		if err := collectWidgets(ctx, kid, objNrs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func withoutObjNrs(arr types.Array, objNrs types.IntSet) types.Array {
+	arr1 := types.Array{}
+	for _, o := range arr {
+		if ir, ok := o.(types.IndirectRef); ok && objNrs[ir.ObjectNumber.Value()] {
+			continue
+		}
+		arr1 = append(arr1, o)
+	}
+	return arr1
+}
+
+// removeAnnots removes the annotations objNrs from all pages of ctx.
+func removeAnnots(ctx *model.Context, objNrs types.IntSet) error {
+	irs, err := pageIndRefs(ctx)
+	if err != nil {
+		return err
+	}
+	for _, ir := range irs {
+		d, err := ctx.DereferenceDict(ir)
+		if err != nil {
+			return err
+		}
+		annots, err := ctx.DereferenceArray(d["Annots"])
+		if err != nil || len(annots) == 0 {
+			continue
+		}
+		annots1 := withoutObjNrs(annots, objNrs)
+		if len(annots1) == len(annots) {
+			continue
+		}
+		if len(annots1) == 0 {
+			d.Delete("Annots")
+			continue
+		}
+		d["Annots"] = annots1
+	}
+	return nil
+}
+
+// skipFields drops the fields ii of arrFieldsSrc including their widgets and returns the remaining fields.
+func skipFields(ctxDest *model.Context, dSrc types.Dict, arrFieldsSrc types.Array, ii []int) (types.Array, error) {
+	objNrs := types.IntSet{}
+	for _, i := range ii {
+		if err := collectWidgets(ctxDest, arrFieldsSrc[i], objNrs); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := removeAnnots(ctxDest, objNrs); err != nil {
+		return nil, err
+	}
+
+	if co, err := ctxDest.DereferenceArray(dSrc["CO"]); err == nil && len(co) > 0 {
+		dSrc["CO"] = withoutObjNrs(co, objNrs)
+	}
+
+	return withoutObjNrs(arrFieldsSrc, objNrs), nil
+}
+
+// mergeFields merges the top-level fields of arrFieldsSrc into the form dDest resolving colliding field names according to the merge conflict policy.
+func mergeFields(fName string, ctxDest *model.Context, dSrc, dDest types.Dict, arrFieldsSrc, arrFieldsDest types.Array) (types.Array, error) {
+	ii, names, err := collidingFields(ctxDest, arrFieldsSrc, arrFieldsDest)
+	if err != nil {
+		return nil, err
+	}
+
+	switch ctxDest.MergeConflicts {
+
+	case model.MergeConflictError:
+		if len(names) > 0 {
+			return nil, errMergeConflict(fName, "form field", names[0])
+		}
+
+	case model.MergeConflictSkip:
+		for _, s := range names {
+			reportMerge(ctxDest, fName, "skipped form field %q", s)
+		}
+		if arrFieldsSrc, err = skipFields(ctxDest, dSrc, arrFieldsSrc, ii); err != nil {
+			return nil, err
+		}
+
+	default:
+		// Qualify all merged in fields by nesting them under a new parent field.
+		for _, s := range names {
+			reportMerge(ctxDest, fName, "renamed form field %q to \"%d.%s\"", s, len(arrFieldsDest), s)
+		}
+		return arrFieldsSrc, mergeInFields(ctxDest, arrFieldsSrc, arrFieldsDest, dDest)
+	}
+
+	dDest["Fields"] = append(arrFieldsDest, arrFieldsSrc...)
+
+	return arrFieldsSrc, nil
+}
+
+func ocgName(ctx *model.Context, o types.Object) (string, error) {
+	d, err := ctx.DereferenceDict(o)
+	if err != nil || d == nil {
+		return "", err
+	}
+	o, found := d.Find("Name")
+	if !found {
+		return "", nil
+	}
+	return ctx.DereferenceStringOrHexLiteral(o, model.V10, nil)
+}
+
+// withoutOCGs returns a copy of the optional content order arr without objNrs.
+func withoutOCGs(ctx *model.Context, arr types.Array, objNrs types.IntSet) types.Array {
+	arr1 := types.Array{}
+	for _, o := range arr {
+		if ir, ok := o.(types.IndirectRef); ok && objNrs[ir.ObjectNumber.Value()] {
+			continue
+		}
+		if a, ok := o.(types.Array); ok {
+			o = withoutOCGs(ctx, a, objNrs)
+		}
+		arr1 = append(arr1, o)
+	}
+	return arr1
+}
+
+func mergeOCConfigArrays(ctx *model.Context, dSrc, dDest types.Dict, objNrs types.IntSet) error {
+	for _, k := range []string{"ON", "OFF", "Order", "Locked", "RBGroups"} {
+		arrSrc, err := ctx.DereferenceArray(dSrc[k])
+		if err != nil {
+			return err
+		}
+		if len(arrSrc) == 0 {
+			continue
+		}
+		arrDest, err := ctx.DereferenceArray(dDest[k])
+		if err != nil {
+			return err
+		}
+		dDest[k] = append(arrDest, withoutOCGs(ctx, arrSrc, objNrs)...)
+	}
+	return nil
+}
+
+// mergeOCProperties merges the optional content groups of ctxSrc into ctxDest resolving colliding group names according to the merge conflict policy.
+func mergeOCProperties(fName string, ctxSrc, ctxDest *model.Context) error {
+	rootDictSrc, rootDictDest, err := rootDicts(ctxSrc, ctxDest)
+	if err != nil {
+		return err
+	}
+
+	dSrc, err := ctxDest.DereferenceDict(rootDictSrc["OCProperties"])
+	if err != nil || dSrc == nil {
+		return err
+	}
+
+	dDest, err := ctxDest.DereferenceDict(rootDictDest["OCProperties"])
+	if err != nil {
+		return err
+	}
+	if dDest == nil {
+		rootDictDest["OCProperties"] = dSrc
+		return nil
+	}
+
+	ocgsDest, err := ctxDest.DereferenceArray(dDest["OCGs"])
+	if err != nil {
+		return err
+	}
+
+	taken := map[string]bool{}
+	for _, o := range ocgsDest {
+		s, err := ocgName(ctxDest, o)
+		if err != nil {
+			return err
+		}
+		taken[s] = true
+	}
+
+	ocgsSrc, err := ctxDest.DereferenceArray(dSrc["OCGs"])
+	if err != nil {
+		return err
+	}
+
+	skipped := types.IntSet{}
+
+	for _, o := range ocgsSrc {
+		s, err := ocgName(ctxDest, o)
+		if err != nil {
+			return err
+		}
+		if taken[s] {
+			switch ctxDest.MergeConflicts {
+			case model.MergeConflictError:
+				return errMergeConflict(fName, "optional content group", s)
+			case model.MergeConflictSkip:
+				if ir, ok := o.(types.IndirectRef); ok {
+					skipped[ir.ObjectNumber.Value()] = true
+				}
+				reportMerge(ctxDest, fName, "skipped optional content group %q", s)
+				continue
+			default:
+				s1 := uniqueName(s, taken)
+				d, err := ctxDest.DereferenceDict(o)
+				if err != nil {
+					return err
+				}
+				sl, err := types.EscapedUTF16String(s1)
+				if err != nil {
+					return err
+				}
+				d["Name"] = types.StringLiteral(*sl)
+				reportMerge(ctxDest, fName, "renamed optional content group %q to %q", s, s1)
+				s = s1
+			}
+		}
+		taken[s] = true
+		ocgsDest = append(ocgsDest, o)
+	}
+
+	dDest["OCGs"] = ocgsDest
+
+	dSrcD, err := ctxDest.DereferenceDict(dSrc["D"])
+	if err != nil || dSrcD == nil {
+		return err
+	}
+
+	dDestD, err := ctxDest.DereferenceDict(dDest["D"])
+	if err != nil {
+		return err
+	}
+	if dDestD == nil {
+		dDest["D"] = dSrcD
+		return nil
+	}
+
+	if err := mergeOCConfigArrays(ctxDest, dSrcD, dDestD, skipped); err != nil {
+		return err
+	}
+
+	if configs, err := ctxDest.DereferenceArray(dSrc["Configs"]); err == nil && len(configs) > 0 {
+		configsDest, err := ctxDest.DereferenceArray(dDest["Configs"])
+		if err != nil {
+			return err
+		}
+		dDest["Configs"] = append(configsDest, configs...)
+	}
+
+	return nil
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+var errMmapUnsupported = errors.New("pdfcpu: memory mapped read not supported")
+
+// mmapReader is an io.ReadSeeker backed by a read only memory mapping of a file.
+// The OS pages in file data on demand which avoids a syscall per buffer refill.
+type mmapReader struct {
+	data []byte
+	off  int64
+}
+
+// newMmapReader maps f into memory.
+// The file must not be modified until the returned reader gets closed.
+func newMmapReader(f *os.File) (*mmapReader, error) {
+	// Address space on 32-bit platforms is too small for large files.
+	if strconv.IntSize < 64 {
+		return nil, errMmapUnsupported
+	}
+
+	fi, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	if fi.Size() == 0 {
+		// Empty files cannot be mapped.
+		return &mmapReader{}, nil
+	}
+
+	data, err := mmap(f, fi.Size())
+	if err != nil {
+		return nil, err
+	}
+
+	return &mmapReader{data: data}, nil
+}
+
+// Read implements io.Reader.
+func (r *mmapReader) Read(p []byte) (int, error) {
+	if r.off >= int64(len(r.data)) {
+		return 0, io.EOF
+	}
+	n := copy(p, r.data[r.off:])
+	r.off += int64(n)
+	return n, nil
+}
+
+// ReadAt implements io.ReaderAt.
+func (r *mmapReader) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, errors.New("pdfcpu: mmapReader.ReadAt: negative offset")
+	}
+	if off >= int64(len(r.data)) {
+		return 0, io.EOF
+	}
+	n := copy(p, r.data[off:])
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// Seek implements io.Seeker.
+func (r *mmapReader) Seek(offset int64, whence int) (int64, error) {
+	var off int64
+	switch whence {
+	case io.SeekStart:
+		off = offset
+	case io.SeekCurrent:
+		off = r.off + offset
+	case io.SeekEnd:
+		off = int64(len(r.data)) + offset
+	default:
+		return 0, errors.New("pdfcpu: mmapReader.Seek: invalid whence")
+	}
+	if off < 0 {
+		return 0, errors.New("pdfcpu: mmapReader.Seek: negative position")
+	}
+	r.off = off
+	return off, nil
+}
+
+// Close releases the memory mapping.
+func (r *mmapReader) Close() error {
+	if r.data == nil {
+		return nil
+	}
+	err := munmap(r.data)
+	r.data = nil
+	return err
+}
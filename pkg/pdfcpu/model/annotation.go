@@ -71,6 +71,7 @@ const (
 	AnnWatermark
 	Ann3D
 	AnnRedact
+	AnnRichMedia
 )
 
 var AnnotTypes = map[string]AnnotationType{
@@ -100,6 +101,7 @@ var AnnotTypes = map[string]AnnotationType{
 	"Watermark":      AnnWatermark,
 	"3D":             Ann3D,
 	"Redact":         AnnRedact,
+	"RichMedia":      AnnRichMedia,
 }
 
 // AnnotTypeStrings manages string representations for annotation types.
@@ -130,6 +132,7 @@ var AnnotTypeStrings = map[AnnotationType]string{
 	AnnWatermark:      "Watermark",
 	Ann3D:             "3D",
 	AnnRedact:         "Redact",
+	AnnRichMedia:      "RichMedia",
 }
 
 // BorderStyle (see table 168)
@@ -426,6 +429,7 @@ func (ann PopupAnnotation) RenderDict(xRefTable *XRefTable, pageIndRef *types.In
 type LinkAnnotation struct {
 	Annotation
 	Dest        *Destination     // internal link
+	DestName    string           // internal link via named destination, takes precedence over Dest
 	URI         string           // external link
 	Quad        types.QuadPoints // shall be ignored if any coordinate lies outside the region specified by Rect.
 	Border      bool             // render border using borderColor.
@@ -466,6 +470,9 @@ func (ann LinkAnnotation) ContentString() string {
 	if len(ann.URI) > 0 {
 		return ann.URI
 	}
+	if ann.DestName != "" {
+		return "#" + ann.DestName
+	}
 	if ann.Dest != nil {
 		// eg. page /XYZ left top zoom
 		return fmt.Sprintf("Page %d %s", ann.Dest.PageNr, ann.Dest)
@@ -480,7 +487,10 @@ func (ann LinkAnnotation) RenderDict(xRefTable *XRefTable, pageIndRef *types.Ind
 		return nil, err
 	}
 
-	if ann.Dest != nil {
+	if ann.DestName != "" {
+		// Resolved via the Dests name tree, allowing links to pages not yet created.
+		d["Dest"] = types.NewHexLiteral([]byte(ann.DestName))
+	} else if ann.Dest != nil {
 		dest := ann.Dest
 		if dest.Zoom == 0 {
 			dest.Zoom = 1
@@ -534,6 +544,8 @@ type MarkupAnnotation struct {
 	RC           string             // A rich text string that shall be displayed in the pop-up window when the annotation is opened.
 	CreationDate string             // The date and time when the annotation was created.
 	Subj         string             // Text representing a short description of the subject being addressed by the annotation.
+	IRT          *types.IndirectRef // A reference to the annotation that this annotation is “in reply to”.
+	RT           string             // (Default: R) Specifies the relationship between this annotation and IRT: R, Group
 }
 
 // NewMarkupAnnotation returns a new markup annotation.
@@ -614,14 +626,23 @@ func (ann MarkupAnnotation) RenderDict(xRefTable *XRefTable, pageIndRef *types.I
 		d.InsertString("Subj", *s)
 	}
 
+	if ann.IRT != nil {
+		d.Insert("IRT", *ann.IRT)
+		if ann.RT != "" {
+			d.InsertName("RT", ann.RT)
+		}
+	}
+
 	return d, nil
 }
 
 // TextAnnotation represents a PDF text annotation aka "Sticky Note".
 type TextAnnotation struct {
 	MarkupAnnotation
-	Open bool   // A flag specifying whether the annotation shall initially be displayed open.
-	Name string // The name of an icon that shall be used in displaying the annotation. Comment, Key, (Note), Help, NewParagraph, Paragraph, Insert
+	Open       bool   // A flag specifying whether the annotation shall initially be displayed open.
+	Name       string // The name of an icon that shall be used in displaying the annotation. Comment, Key, (Note), Help, NewParagraph, Paragraph, Insert
+	State      string // The state to which the annotation referred to by IRT shall be set.
+	StateModel string // The state model corresponding to State: Marked, Review
 }
 
 // NewTextAnnotation returns a new text annotation.
@@ -664,6 +685,11 @@ func (ann TextAnnotation) RenderDict(xRefTable *XRefTable, pageIndRef *types.Ind
 		d.InsertName("Name", ann.Name)
 	}
 
+	if ann.State != "" {
+		d.InsertString("State", ann.State)
+		d.InsertString("StateModel", ann.StateModel)
+	}
+
 	return d, nil
 }
 
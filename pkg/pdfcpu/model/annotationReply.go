@@ -0,0 +1,78 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"time"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// Annotation state models (see 12.5.6.3).
+const (
+	StateModelMarked = "Marked"
+	StateModelReview = "Review"
+)
+
+// Annotation states (see 12.5.6.3).
+const (
+	StateMarked    = "Marked"
+	StateUnmarked  = "Unmarked"
+	StateAccepted  = "Accepted"
+	StateRejected  = "Rejected"
+	StateCancelled = "Cancelled"
+	StateCompleted = "Completed"
+	StateNone      = "None"
+)
+
+// StateModelFor returns the state model for state.
+func StateModelFor(state string) (string, error) {
+	switch state {
+	case StateMarked, StateUnmarked:
+		return StateModelMarked, nil
+	case StateAccepted, StateRejected, StateCancelled, StateCompleted, StateNone:
+		return StateModelReview, nil
+	}
+	return "", errors.Errorf("pdfcpu: invalid annotation state: %s", state)
+}
+
+// NewReplyAnnotation returns a text annotation by author replying to the annotation irt located at rect.
+func NewReplyAnnotation(rect types.Rectangle, irt types.IndirectRef, contents, author string) TextAnnotation {
+	ann := NewTextAnnotation(rect, contents, "", types.DateString(time.Now()), AnnNoZoom|AnnNoRotate, nil, author, nil, nil, "", "", 0, 0, 0, false, "Comment")
+	ann.IRT = &irt
+	return ann
+}
+
+// NewStateAnnotation returns a hidden text annotation by author setting the state of the annotation irt located at rect.
+func NewStateAnnotation(rect types.Rectangle, irt types.IndirectRef, state, author string) (TextAnnotation, error) {
+	stateModel, err := StateModelFor(state)
+	if err != nil {
+		return TextAnnotation{}, err
+	}
+
+	contents := state + " set by " + author
+	if author == "" {
+		contents = state
+	}
+
+	ann := NewReplyAnnotation(rect, irt, contents, author)
+	ann.F = AnnHidden | AnnPrint | AnnNoZoom | AnnNoRotate
+	ann.State, ann.StateModel = state, stateModel
+
+	return ann, nil
+}
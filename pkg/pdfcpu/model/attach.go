@@ -18,9 +18,13 @@ package model
 
 import (
 	"bytes"
+	"crypto/md5"
 	"fmt"
 	"io"
+	"path"
 	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/pdfcpu/pdfcpu/pkg/log"
@@ -30,11 +34,44 @@ import (
 
 // Attachment is a Reader representing a PDF attachment.
 type Attachment struct {
-	io.Reader            // attachment data
-	ID        string     // id
-	FileName  string     // filename
-	Desc      string     // description
-	ModTime   *time.Time // time of last modification (optional)
+	io.Reader                 // attachment data
+	ID             string     // id
+	FileName       string     // filename
+	Desc           string     // description
+	ModTime        *time.Time // time of last modification (optional)
+	CreationTime   *time.Time // time of creation (optional)
+	MimeType       string     // MIME type eg. text/xml (optional)
+	AFRelationship string     // relationship to the PDF eg. Source, Data, Alternative (optional, PDF/A-3)
+	Folder         string     // portfolio folder path (optional)
+}
+
+// AttachmentManifestEntry describes a file to be attached including its metadata.
+type AttachmentManifestEntry struct {
+	File           string     `json:"file"`
+	ID             string     `json:"id,omitempty"`
+	Desc           string     `json:"desc,omitempty"`
+	MimeType       string     `json:"mimeType,omitempty"`
+	Created        *time.Time `json:"created,omitempty"`
+	Modified       *time.Time `json:"modified,omitempty"`
+	AFRelationship string     `json:"afRelationship,omitempty"`
+}
+
+// AttachmentManifest is a JSON description of files to be attached.
+type AttachmentManifest struct {
+	Attachments []AttachmentManifestEntry `json:"attachments"`
+}
+
+// AFRelationships are the valid values for the AFRelationship entry of a file specification.
+var AFRelationships = []string{"Source", "Data", "Alternative", "Supplement", "EncryptedPayload", "FormData", "Schema", "Unspecified"}
+
+// AFRelationship returns the AFRelationship value matching s case-insensitively.
+func AFRelationship(s string) (string, bool) {
+	for _, v := range AFRelationships {
+		if strings.EqualFold(v, s) {
+			return v, true
+		}
+	}
+	return "", false
 }
 
 func (a Attachment) String() string {
@@ -89,6 +126,8 @@ func fileSpecStreamDict(xRefTable *XRefTable, d types.Dict) (*types.StreamDict,
 }
 
 // NewFileSpectDictForAttachment returns a fileSpecDict for a.
+// UF and F are set to a.FileName falling back to a.ID,
+// which allows name tree keys (eg. portfolio folder prefixes) differing from the file name.
 func (xRefTable *XRefTable) NewFileSpecDictForAttachment(a Attachment) (types.Dict, error) {
 	modTime := time.Now()
 	if a.ModTime != nil {
@@ -101,7 +140,77 @@ func (xRefTable *XRefTable) NewFileSpecDictForAttachment(a Attachment) (types.Di
 
 	// TODO insert (escaped) reverse solidus before solidus between file name components.
 
-	return xRefTable.NewFileSpecDict(a.ID, a.ID, a.Desc, *sd)
+	if a.MimeType != "" || a.CreationTime != nil {
+		entry, _ := xRefTable.FindTableEntryForIndRef(sd)
+		esd := entry.Object.(types.StreamDict)
+		if a.MimeType != "" {
+			esd.InsertName("Subtype", a.MimeType)
+		}
+		if a.CreationTime != nil {
+			if params := esd.DictEntry("Params"); params != nil {
+				params.Insert("CreationDate", types.StringLiteral(types.DateString(*a.CreationTime)))
+			}
+		}
+		entry.Object = esd
+	}
+
+	fileName := a.ID
+	if a.FileName != "" {
+		fileName = a.FileName
+	}
+
+	d, err := xRefTable.NewFileSpecDict(fileName, fileName, a.Desc, *sd)
+	if err != nil {
+		return nil, err
+	}
+
+	if a.AFRelationship != "" {
+		rel, ok := AFRelationship(a.AFRelationship)
+		if !ok {
+			return nil, errors.Errorf("pdfcpu: invalid AFRelationship %q, please provide one of: %s", a.AFRelationship, strings.Join(AFRelationships, ", "))
+		}
+		d.InsertName("AFRelationship", rel)
+	}
+
+	return d, nil
+}
+
+func (xRefTable *XRefTable) collectEmbeddedFileStreams(o types.Object, objNrs types.IntSet) {
+	switch o := o.(type) {
+
+	case types.Dict:
+		if d, err := xRefTable.DereferenceDict(o["EF"]); err == nil {
+			for _, v := range d {
+				if ir, ok := v.(types.IndirectRef); ok {
+					objNrs[ir.ObjectNumber.Value()] = true
+				}
+			}
+		}
+		for _, v := range o {
+			xRefTable.collectEmbeddedFileStreams(v, objNrs)
+		}
+
+	case types.StreamDict:
+		xRefTable.collectEmbeddedFileStreams(o.Dict, objNrs)
+
+	case types.Array:
+		for _, v := range o {
+			xRefTable.collectEmbeddedFileStreams(v, objNrs)
+		}
+	}
+}
+
+// EmbeddedFileStreamObjNrs returns the object numbers of all streams referenced by a file specification's EF entry.
+// Embedded file streams are identified by reference since their Type entry is optional.
+func (xRefTable *XRefTable) EmbeddedFileStreamObjNrs() types.IntSet {
+	objNrs := types.IntSet{}
+	for _, entry := range xRefTable.Table {
+		if entry == nil || entry.Free || entry.Object == nil {
+			continue
+		}
+		xRefTable.collectEmbeddedFileStreams(entry.Object, objNrs)
+	}
+	return objNrs
 }
 
 func fileSpecStreamDictInfo(xRefTable *XRefTable, id string, o types.Object, decode bool) (*types.StreamDict, string, string, *time.Time, error) {
@@ -131,20 +240,127 @@ func fileSpecStreamDictInfo(xRefTable *XRefTable, id string, o types.Object, dec
 
 	var modDate *time.Time
 	if d = sd.DictEntry("Params"); d != nil {
+		relaxed := xRefTable.ValidationMode == ValidationRelaxed
 		if s := d.StringEntry("ModDate"); s != nil {
-			dt, ok := types.DateTime(*s, xRefTable.ValidationMode == ValidationRelaxed)
+			dt, ok := types.DateTime(*s, relaxed)
 			if !ok {
 				return nil, desc, "", nil, errors.New("pdfcpu: invalid date ModDate")
 			}
 			modDate = &dt
+		} else if s := d.StringEntry("CreationDate"); s != nil {
+			// Optional fallback, ignore if unparsable.
+			if dt, ok := types.DateTime(*s, relaxed); ok {
+				modDate = &dt
+			}
 		}
 	}
 
-	err = decodeFileSpecStreamDict(sd)
+	if decode {
+		err = decodeFileSpecStreamDict(sd)
+	}
 
 	return sd, desc, fileName, modDate, err
 }
 
+// collectionFolders maps folder ids to folder paths relative to the portfolio root.
+type collectionFolders map[int]string
+
+// path returns the folder path for a name tree key of the form "<folderID>fileName".
+func (cf collectionFolders) path(key string) string {
+	if len(cf) == 0 || !strings.HasPrefix(key, "<") {
+		return ""
+	}
+	i := strings.Index(key, ">")
+	if i < 0 {
+		return ""
+	}
+	id, err := strconv.Atoi(key[1:i])
+	if err != nil {
+		return ""
+	}
+	return cf[id]
+}
+
+func folderPathComponent(s string) string {
+	s = strings.NewReplacer("/", "_", "\\", "_").Replace(s)
+	if s == "" || s == "." || s == ".." {
+		s = "_"
+	}
+	return s
+}
+
+func (xRefTable *XRefTable) processCollectionFolder(o types.Object, parent string, root bool, cf collectionFolders, visited types.IntSet) error {
+	for o != nil {
+		if ir, ok := o.(types.IndirectRef); ok {
+			if visited[ir.ObjectNumber.Value()] {
+				return errors.New("pdfcpu: collection folder cycle detected")
+			}
+			visited[ir.ObjectNumber.Value()] = true
+		}
+
+		d, err := xRefTable.DereferenceDict(o)
+		if err != nil || d == nil {
+			return err
+		}
+
+		p := parent
+		if !root {
+			name := ""
+			if o, found := d.Find("Name"); found {
+				if name, err = xRefTable.DereferenceStringOrHexLiteral(o, V10, nil); err != nil {
+					return err
+				}
+			}
+			p = path.Join(parent, folderPathComponent(name))
+		}
+
+		if id := d.IntEntry("ID"); id != nil {
+			cf[*id] = p
+		}
+
+		if o, found := d.Find("Child"); found {
+			if err := xRefTable.processCollectionFolder(o, p, false, cf, visited); err != nil {
+				return err
+			}
+		}
+
+		if root {
+			// The root folder has no siblings.
+			return nil
+		}
+
+		o, _ = d.Find("Next")
+	}
+
+	return nil
+}
+
+// collectionFolders returns the folder hierarchy of a portable collection (PDF 2.0 /Folders).
+func (xRefTable *XRefTable) collectionFolders() (collectionFolders, error) {
+	cf := collectionFolders{}
+
+	rootDict, err := xRefTable.Catalog()
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := xRefTable.DereferenceDict(rootDict["Collection"])
+	if err != nil || d == nil {
+		return cf, err
+	}
+
+	o, found := d.Find("Folders")
+	if !found {
+		return cf, nil
+	}
+
+	if err := xRefTable.processCollectionFolder(o, "", true, cf, types.IntSet{}); err != nil {
+		return nil, err
+	}
+
+	return cf, nil
+}
+
 // ListAttachments returns a slice of attachment stubs (attachment w/o data).
 func (ctx *Context) ListAttachments() ([]Attachment, error) {
 	xRefTable := ctx.XRefTable
@@ -157,6 +373,11 @@ func (ctx *Context) ListAttachments() ([]Attachment, error) {
 		return nil, nil
 	}
 
+	folders, err := xRefTable.collectionFolders()
+	if err != nil {
+		return nil, err
+	}
+
 	aa := []Attachment{}
 
 	createAttachmentStub := func(xRefTable *XRefTable, id string, o *types.Object) error {
@@ -165,7 +386,7 @@ func (ctx *Context) ListAttachments() ([]Attachment, error) {
 		if err != nil {
 			return err
 		}
-		aa = append(aa, Attachment{nil, id, fileName, desc, modTime})
+		aa = append(aa, Attachment{ID: id, FileName: fileName, Desc: desc, ModTime: modTime, Folder: folders.path(id)})
 		return nil
 	}
 
@@ -201,6 +422,15 @@ func (ctx *Context) AddAttachment(a Attachment, useCollection bool) error {
 		return err
 	}
 
+	if a.AFRelationship != "" {
+		// Associated files also need to be referenced by the catalog (PDF/A-3).
+		arr, err := xRefTable.DereferenceArray(xRefTable.RootDict["AF"])
+		if err != nil {
+			return err
+		}
+		xRefTable.RootDict["AF"] = append(arr, *ir)
+	}
+
 	m := NameMap{a.ID: []types.Dict{d}}
 
 	return xRefTable.Names["EmbeddedFiles"].Add(xRefTable, a.ID, *ir, m, []string{"F", "UF"})
@@ -326,8 +556,12 @@ func (ctx *Context) RemoveAttachment(a Attachment) (bool, error) {
 }
 
 // ExtractAttachments extracts attachments with id.
+// ids containing any of *?[ are glob patterns matched against attachment ids and file names.
 func (ctx *Context) ExtractAttachments(ids []string) ([]Attachment, error) {
 	xRefTable := ctx.XRefTable
+	if xRefTable.EmbeddedFilesLocked {
+		return nil, errors.New("pdfcpu: please provide the user password to access embedded files")
+	}
 	if !xRefTable.Valid {
 		if err := xRefTable.LocateNameTree("EmbeddedFiles", false); err != nil {
 			return nil, err
@@ -337,6 +571,11 @@ func (ctx *Context) ExtractAttachments(ids []string) ([]Attachment, error) {
 		return nil, errors.Errorf("no attachments available.")
 	}
 
+	folders, err := xRefTable.collectionFolders()
+	if err != nil {
+		return nil, err
+	}
+
 	aa := []Attachment{}
 
 	createAttachment := func(xRefTable *XRefTable, id string, o *types.Object) error {
@@ -345,14 +584,43 @@ func (ctx *Context) ExtractAttachments(ids []string) ([]Attachment, error) {
 		if err != nil {
 			return err
 		}
-		a := Attachment{Reader: bytes.NewReader(sd.Content), ID: id, FileName: fileName, Desc: desc, ModTime: modTime}
+		a := Attachment{Reader: bytes.NewReader(sd.Content), ID: id, FileName: fileName, Desc: desc, ModTime: modTime, Folder: folders.path(id)}
 		aa = append(aa, a)
 		return nil
 	}
 
+	var patterns []string
+	ids, patterns = splitAttachmentPatterns(ids)
+
+	if len(patterns) > 0 {
+		// Collect all attachments matching any of the glob patterns.
+		seen := map[string]bool{}
+		matchAttachment := func(xRefTable *XRefTable, id string, o *types.Object) error {
+			if seen[id] {
+				return nil
+			}
+			_, _, fileName, _, err := fileSpecStreamDictInfo(xRefTable, id, *o, false)
+			if err != nil {
+				return err
+			}
+			if !matchesAnyPattern(patterns, id, fileName) {
+				return nil
+			}
+			seen[id] = true
+			return createAttachment(xRefTable, id, o)
+		}
+		if err := ctx.Names["EmbeddedFiles"].Process(ctx.XRefTable, matchAttachment); err != nil {
+			return nil, err
+		}
+		if len(ids) == 0 {
+			return aa, nil
+		}
+	}
+
 	// Search with UF,F,Desc
 	if len(ids) > 0 {
 		for _, id := range ids {
+			key := id
 			v, ok := ctx.Names["EmbeddedFiles"].Value(id)
 			if !ok {
 				// Try to identify name tree node by content.
@@ -369,9 +637,9 @@ func (ctx *Context) ExtractAttachments(ids []string) ([]Attachment, error) {
 					}
 					continue
 				}
-				v = o
+				key, v = *k, o
 			}
-			if err := createAttachment(ctx.XRefTable, id, &v); err != nil {
+			if err := createAttachment(ctx.XRefTable, key, &v); err != nil {
 				return nil, err
 			}
 		}
@@ -386,6 +654,29 @@ func (ctx *Context) ExtractAttachments(ids []string) ([]Attachment, error) {
 	return aa, nil
 }
 
+// splitAttachmentPatterns separates glob patterns from plain attachment ids.
+func splitAttachmentPatterns(ss []string) (ids, patterns []string) {
+	for _, s := range ss {
+		if strings.ContainsAny(s, "*?[") {
+			patterns = append(patterns, s)
+			continue
+		}
+		ids = append(ids, s)
+	}
+	return ids, patterns
+}
+
+func matchesAnyPattern(patterns []string, ss ...string) bool {
+	for _, p := range patterns {
+		for _, s := range ss {
+			if ok, _ := path.Match(p, s); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // ExtractAttachment extracts a fully populated attachment.
 func (ctx *Context) ExtractAttachment(a Attachment) (*Attachment, error) {
 	aa, err := ctx.ExtractAttachments([]string{a.ID})
@@ -427,3 +718,87 @@ func (ctx *Context) AddAttachmentsToInfoDigest(ss *[]string) error {
 
 	return nil
 }
+
+// AttachmentCheck is the result of verifying the checksum of an embedded file.
+type AttachmentCheck struct {
+	ID       string
+	FileName string
+	CheckSum bool // The embedded file declares a checksum.
+	Valid    bool // The declared checksum matches the embedded file data.
+}
+
+func (ac AttachmentCheck) String() string {
+	status := "ok"
+	if !ac.CheckSum {
+		status = "no checksum"
+	} else if !ac.Valid {
+		status = "checksum mismatch"
+	}
+	return fmt.Sprintf("%s: %s", ac.FileName, status)
+}
+
+// embeddedFileCheckSum returns the checksum declared by the embedded file stream sd.
+func embeddedFileCheckSum(xRefTable *XRefTable, sd *types.StreamDict) ([]byte, error) {
+	d, err := xRefTable.DereferenceDict(sd.Dict["Params"])
+	if err != nil || d == nil {
+		return nil, err
+	}
+
+	o, err := xRefTable.Dereference(d["CheckSum"])
+	if err != nil {
+		return nil, err
+	}
+
+	switch o := o.(type) {
+	case types.StringLiteral:
+		return types.Unescape(o.Value())
+	case types.HexLiteral:
+		return o.Bytes()
+	}
+
+	return nil, nil
+}
+
+// VerifyAttachments recomputes the MD5 checksums of all embedded files and compares them against their declared checksums.
+func (ctx *Context) VerifyAttachments() ([]AttachmentCheck, error) {
+	xRefTable := ctx.XRefTable
+	if err := xRefTable.LocateNameTree("EmbeddedFiles", false); err != nil {
+		return nil, err
+	}
+	if xRefTable.Names["EmbeddedFiles"] == nil {
+		return nil, nil
+	}
+
+	acs := []AttachmentCheck{}
+
+	verifyAttachment := func(xRefTable *XRefTable, id string, o *types.Object) error {
+		decode := true
+		sd, _, fileName, _, err := fileSpecStreamDictInfo(xRefTable, id, *o, decode)
+		if err != nil {
+			return err
+		}
+
+		ac := AttachmentCheck{ID: id, FileName: fileName}
+
+		if sd != nil {
+			bb, err := embeddedFileCheckSum(xRefTable, sd)
+			if err != nil {
+				return err
+			}
+			if bb != nil {
+				sum := md5.Sum(sd.Content)
+				ac.CheckSum = true
+				ac.Valid = bytes.Equal(bb, sum[:])
+			}
+		}
+
+		acs = append(acs, ac)
+		return nil
+	}
+
+	if err := ctx.Names["EmbeddedFiles"].Process(xRefTable, verifyAttachment); err != nil {
+		return nil, err
+	}
+
+	return acs, nil
+}
@@ -0,0 +1,232 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ConfigSchemaVersion is the version of the config.yml layout written by this release.
+// Config files without a schemaVersion entry are considered to be of version 0.
+//
+//	1: introduces schemaVersion, memoryOnly
+//	2: introduces optimizePasses
+//	3: introduces copyThrough
+//	4: introduces compressIncrement
+//	5: introduces preserveObjectNumbers
+//	6: introduces keepUsageRights
+const ConfigSchemaVersion = 6
+
+// configEntry represents a key of the embedded config.yml including its documentation.
+type configEntry struct {
+	key   string
+	lines []string
+}
+
+func configKey(line string) (string, bool) {
+	if len(line) == 0 || line[0] == '#' {
+		return "", false
+	}
+	i := strings.Index(line, ":")
+	if i <= 0 {
+		return "", false
+	}
+	return strings.TrimSpace(line[:i]), true
+}
+
+// configTemplate returns the entries of the embedded config.yml in order.
+func configTemplate() []configEntry {
+	var (
+		entries []configEntry
+		lines   []string
+	)
+	s := bufio.NewScanner(bytes.NewReader(configFileBytes))
+	for s.Scan() {
+		line := strings.TrimRight(s.Text(), " ")
+		if line == "" {
+			lines = nil
+			continue
+		}
+		lines = append(lines, line)
+		if k, ok := configKey(line); ok {
+			entries = append(entries, configEntry{key: k, lines: lines})
+			lines = nil
+		}
+	}
+	return entries
+}
+
+func isConfigKey(key string) bool {
+	for _, e := range configTemplate() {
+		if e.key == key {
+			return true
+		}
+	}
+	return false
+}
+
+func configHeader(created string) string {
+	return fmt.Sprintf(`
+#############################
+#   Default configuration   #
+#############################
+
+# Creation date
+created: %s 
+
+# version (Do not edit!)
+version: %s 
+
+# config schema version (Do not edit!)
+schemaVersion: %d
+
+`,
+		created,
+		VersionStr,
+		ConfigSchemaVersion)
+}
+
+// configFileKeys returns the keys present in config file content bb and the schema version in effect.
+func configFileKeys(bb []byte) (map[string]bool, int) {
+	keys := map[string]bool{}
+	version := 0
+	s := bufio.NewScanner(bytes.NewReader(bb))
+	for s.Scan() {
+		line := s.Text()
+		k, ok := configKey(line)
+		if !ok {
+			continue
+		}
+		keys[k] = true
+		if k == "schemaVersion" {
+			v := strings.TrimSpace(line[strings.Index(line, ":")+1:])
+			if i, err := strconv.Atoi(v); err == nil {
+				version = i
+			}
+		}
+	}
+	return keys, version
+}
+
+// migrateConfig upgrades config file content bb written using an older schema version.
+// Missing entries are added using their documented defaults.
+// It returns true if bb got modified.
+func migrateConfig(bb []byte) ([]byte, bool) {
+	keys, version := configFileKeys(bb)
+	if version >= ConfigSchemaVersion {
+		return bb, false
+	}
+
+	lines := strings.Split(strings.TrimRight(string(bb), "\n"), "\n")
+
+	for i, line := range lines {
+		k, ok := configKey(line)
+		if !ok {
+			continue
+		}
+		switch k {
+		case "units":
+			// v0.3.8 renamed "units" to "unit".
+			if !keys["unit"] {
+				lines[i] = "unit" + strings.TrimPrefix(line, "units")
+				keys["unit"] = true
+			}
+		case "schemaVersion":
+			lines[i] = fmt.Sprintf("schemaVersion: %d", ConfigSchemaVersion)
+		}
+	}
+
+	if !keys["schemaVersion"] {
+		lines = append(lines, "", "# config schema version (Do not edit!)", fmt.Sprintf("schemaVersion: %d", ConfigSchemaVersion))
+	}
+
+	for _, e := range configTemplate() {
+		if !keys[e.key] {
+			lines = append(lines, "")
+			lines = append(lines, e.lines...)
+		}
+	}
+
+	return []byte(strings.Join(lines, "\n") + "\n"), true
+}
+
+func readConfig(r io.Reader, configPath string) (*Configuration, error) {
+	var buf bytes.Buffer
+	if _, err := io.Copy(&buf, r); err != nil {
+		return nil, err
+	}
+	bb, _ := migrateConfig(buf.Bytes())
+	return parseConfig(bb, configPath)
+}
+
+func parseConfigFile(r io.Reader, configPath string) error {
+	c, err := readConfig(r, configPath)
+	if err != nil {
+		return err
+	}
+	loadedDefaultConfig = c
+	return nil
+}
+
+// configBytes renders c using the layout of the embedded config.yml.
+func configBytes(c *Configuration) []byte {
+	created := c.CreationDate
+	if created == "" {
+		created = time.Now().Format("2006-01-02 15:04")
+	}
+
+	var sb strings.Builder
+	sb.WriteString(configHeader(created))
+
+	s := bufio.NewScanner(bytes.NewReader(configFileBytes))
+	for s.Scan() {
+		line := s.Text()
+		if k, ok := configKey(line); ok {
+			line = fmt.Sprintf("%s: %s", k, c.configValue(k))
+		}
+		sb.WriteString(line + "\n")
+	}
+
+	return []byte(sb.String())
+}
+
+// ReadConfigFile loads and validates the configuration at configPath.
+// Config files using an older schema version are migrated on the fly.
+func ReadConfigFile(configPath string) (*Configuration, error) {
+	f, err := os.Open(configPath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return readConfig(f, configPath)
+}
+
+// WriteConfigFile validates c and writes it to configPath using the current schema version.
+func WriteConfigFile(configPath string, c *Configuration) error {
+	bb := configBytes(c)
+	if _, err := parseConfig(bb, configPath); err != nil {
+		return err
+	}
+	return os.WriteFile(configPath, bb, os.ModePerm)
+}
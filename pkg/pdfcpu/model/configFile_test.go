@@ -0,0 +1,85 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+func TestMigrateConfig(t *testing.T) {
+	legacy := []byte("version: v0.3.7\nunits: cm\noffline: true\n")
+
+	bb, ok := migrateConfig(legacy)
+	if !ok {
+		t.Fatal("expected migration")
+	}
+
+	if _, v := configFileKeys(bb); v != ConfigSchemaVersion {
+		t.Fatalf("schemaVersion: got %d, want %d", v, ConfigSchemaVersion)
+	}
+
+	if _, ok := migrateConfig(bb); ok {
+		t.Fatal("unexpected repeated migration")
+	}
+
+	c, err := parseConfig(bb, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.Unit != types.CENTIMETRES || !c.Offline || c.Timeout != 5 || c.EncryptKeyLength != 256 {
+		t.Fatalf("unexpected migrated config:\n%s", c)
+	}
+}
+
+func TestConfigRoundTrip(t *testing.T) {
+	c := newDefaultConfiguration()
+
+	if err := c.Set("timeout", "10"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Set("validationMode", "ValidationStrict"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Set("timeout", "ten"); err == nil {
+		t.Fatal("expected error for invalid value")
+	}
+	if err := c.Set("noSuchKey", "true"); err == nil {
+		t.Fatal("expected error for unknown key")
+	}
+
+	c1, err := parseConfig(configBytes(c), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c1.Timeout != 10 || c1.ValidationMode != ValidationStrict || c1.Permissions != c.Permissions {
+		t.Fatalf("unexpected config after round trip:\n%s", c1)
+	}
+}
+
+func TestConfigNewerSchema(t *testing.T) {
+	s := fmt.Sprintf("schemaVersion: %d", ConfigSchemaVersion)
+	bb := bytes.Replace(configBytes(newDefaultConfiguration()), []byte(s), []byte("schemaVersion: 99"), 1)
+	if _, err := parseConfig(bb, ""); err == nil {
+		t.Fatal("expected error for unsupported schemaVersion")
+	}
+}
@@ -0,0 +1,406 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+func handleCreationDate(v string, c *Configuration) error {
+	c.CreationDate = v
+	return nil
+}
+
+func handleVersion(v string, c *Configuration) error {
+	c.Version = v
+	return nil
+}
+
+func handleSchemaVersion(v string) error {
+	i, err := strconv.Atoi(v)
+	if err != nil {
+		return errors.Errorf("schemaVersion is numeric, got: %s", v)
+	}
+	if i > ConfigSchemaVersion {
+		return errors.Errorf("unsupported schemaVersion: %d (max %d)", i, ConfigSchemaVersion)
+	}
+	return nil
+}
+
+func handleCheckFileNameExt(k, v string, c *Configuration) error {
+	v = strings.ToLower(v)
+	if v != "true" && v != "false" {
+		return errors.Errorf("config key %s is boolean", k)
+	}
+	c.CheckFileNameExt = v == "true"
+	return nil
+}
+
+func handleConfReader15(k, v string, c *Configuration) error {
+	v = strings.ToLower(v)
+	if v != "true" && v != "false" {
+		return errors.Errorf("config key %s is boolean", k)
+	}
+	c.Reader15 = v == "true"
+	return nil
+}
+
+func handleConfDecodeAllStreams(k, v string, c *Configuration) error {
+	v = strings.ToLower(v)
+	if v != "true" && v != "false" {
+		return errors.Errorf("config key %s is boolean", k)
+	}
+	c.DecodeAllStreams = v == "true"
+	return nil
+}
+
+func handleConfPostProcessValidate(k, v string, c *Configuration) error {
+	v = strings.ToLower(v)
+	if v != "true" && v != "false" {
+		return errors.Errorf("config key %s is boolean", k)
+	}
+	c.PostProcessValidate = v == "true"
+	return nil
+}
+
+func handleConfValidationMode(v string, c *Configuration) error {
+	v1 := strings.ToLower(v)
+	switch v1 {
+	case "validationstrict":
+		c.ValidationMode = ValidationStrict
+	case "validationrelaxed":
+		c.ValidationMode = ValidationRelaxed
+	default:
+		return errors.Errorf("invalid validationMode: %s", v)
+	}
+	return nil
+}
+
+func handleConfEol(v string, c *Configuration) error {
+	v1 := strings.ToLower(v)
+	switch v1 {
+	case "eollf":
+		c.Eol = types.EolLF
+	case "eolcr":
+		c.Eol = types.EolCR
+	case "eolcrlf":
+		c.Eol = types.EolCRLF
+	default:
+		return errors.Errorf("invalid eol: %s", v)
+	}
+	return nil
+}
+
+func handleConfWriteObjectStream(k, v string, c *Configuration) error {
+	v = strings.ToLower(v)
+	if v != "true" && v != "false" {
+		return errors.Errorf("config key %s is boolean", k)
+	}
+	c.WriteObjectStream = v == "true"
+	return nil
+}
+
+func handleConfWriteXRefStream(k, v string, c *Configuration) error {
+	v = strings.ToLower(v)
+	if v != "true" && v != "false" {
+		return errors.Errorf("config key %s is boolean", k)
+	}
+	c.WriteXRefStream = v == "true"
+	return nil
+}
+
+func handleConfEncryptUsingAES(k, v string, c *Configuration) error {
+	v = strings.ToLower(v)
+	if v != "true" && v != "false" {
+		return errors.Errorf("config key %s is boolean", k)
+	}
+	c.EncryptUsingAES = v == "true"
+	return nil
+}
+
+func handleConfEncryptKeyLength(v string, c *Configuration) error {
+	i, err := strconv.Atoi(v)
+	if err != nil {
+		return errors.Errorf("encryptKeyLength is numeric, got: %s", v)
+	}
+	if !types.IntMemberOf(i, []int{40, 128, 256}) {
+		return errors.Errorf("encryptKeyLength possible values: 40, 128, 256, got: %s", v)
+	}
+	c.EncryptKeyLength = i
+	return nil
+}
+
+func handleTimeout(v string, c *Configuration) error {
+	i, err := strconv.Atoi(v)
+	if err != nil {
+		return errors.Errorf("timeout is numeric > 0, got: %s", v)
+	}
+	c.Timeout = i
+	return nil
+}
+
+func handleConfPermissions(v string, c *Configuration) error {
+	i, err := strconv.ParseInt(v, 0, 32)
+	if err != nil {
+		return errors.Errorf("permissions is numeric, got: %s", v)
+	}
+	c.Permissions = PermissionFlags(i)
+	return nil
+}
+
+func handleConfUnit(v string, c *Configuration) error {
+	v1 := v
+	switch v1 {
+	case "points":
+		c.Unit = types.POINTS
+	case "inches":
+		c.Unit = types.INCHES
+	case "cm":
+		c.Unit = types.CENTIMETRES
+	case "mm":
+		c.Unit = types.MILLIMETRES
+	default:
+		return errors.Errorf("invalid unit: %s", v)
+	}
+	return nil
+}
+
+func handleTimestampFormat(v string, c *Configuration) error {
+	c.TimestampFormat = v
+	return nil
+}
+
+func handleDateFormat(v string, c *Configuration) error {
+	c.DateFormat = v
+	return nil
+}
+
+func boolean(k, v string) (bool, error) {
+	v = strings.ToLower(v)
+	if v != "true" && v != "false" {
+		return false, errors.Errorf("config key %s is boolean", k)
+	}
+	return v == "true", nil
+}
+
+func parseKeysPart1(k, v string, c *Configuration) (bool, error) {
+	switch k {
+
+	case "created":
+		return true, handleCreationDate(v, c)
+
+	case "version":
+		return true, handleVersion(v, c)
+
+	case "schemaVersion":
+		return true, handleSchemaVersion(v)
+
+	case "checkFileNameExt":
+		return true, handleCheckFileNameExt(k, v, c)
+
+	case "reader15":
+		return true, handleConfReader15(k, v, c)
+
+	case "decodeAllStreams":
+		return true, handleConfDecodeAllStreams(k, v, c)
+
+	case "validationMode":
+		return true, handleConfValidationMode(v, c)
+
+	case "postProcessValidate":
+		return true, handleConfPostProcessValidate(k, v, c)
+
+	case "eol":
+		return true, handleConfEol(v, c)
+
+	case "writeObjectStream":
+		return true, handleConfWriteObjectStream(k, v, c)
+
+	case "writeXRefStream":
+		return true, handleConfWriteXRefStream(k, v, c)
+	}
+
+	return false, nil
+}
+
+func parseKeysPart2(k, v string, c *Configuration) (err error) {
+	switch k {
+
+	case "encryptUsingAES":
+		err = handleConfEncryptUsingAES(k, v, c)
+
+	case "encryptKeyLength":
+		err = handleConfEncryptKeyLength(v, c)
+
+	case "permissions":
+		err = handleConfPermissions(v, c)
+
+	case "unit", "units":
+		err = handleConfUnit(v, c)
+
+	case "timestampFormat":
+		err = handleTimestampFormat(v, c)
+
+	case "dateFormat":
+		err = handleDateFormat(v, c)
+
+	case "optimize":
+		c.Optimize, err = boolean(k, v)
+
+	case "optimizeResourceDicts":
+		c.OptimizeResourceDicts, err = boolean(k, v)
+
+	case "optimizeDuplicateContentStreams":
+		c.OptimizeDuplicateContentStreams, err = boolean(k, v)
+
+	case "createBookmarks":
+		c.CreateBookmarks, err = boolean(k, v)
+
+	case "needAppearances":
+		c.NeedAppearances, err = boolean(k, v)
+
+	case "offline":
+		c.Offline, err = boolean(k, v)
+
+	case "timeout":
+		err = handleTimeout(v, c)
+
+	case "memoryOnly":
+		c.MemoryOnly, err = boolean(k, v)
+
+	case "optimizePasses":
+		c.OptimizePasses, err = ParseOptimizePasses(v)
+
+	case "copyThrough":
+		c.CopyThrough, err = boolean(k, v)
+
+	case "compressIncrement":
+		c.CompressIncrement, err = boolean(k, v)
+
+	case "preserveObjectNumbers":
+		c.PreserveObjectNumbers, err = boolean(k, v)
+
+	case "keepUsageRights":
+		c.KeepUsageRights, err = boolean(k, v)
+	}
+
+	return err
+}
+
+func parseKeyValue(k, v string, c *Configuration) error {
+	ok, err := parseKeysPart1(k, v, c)
+	if err != nil {
+		return err
+	}
+	if ok {
+		return nil
+	}
+	return parseKeysPart2(k, v, c)
+}
+
+func unitConfigValue(u types.DisplayUnit) string {
+	switch u {
+	case types.INCHES:
+		return "inches"
+	case types.CENTIMETRES:
+		return "cm"
+	case types.MILLIMETRES:
+		return "mm"
+	}
+	return "points"
+}
+
+// configValue returns the config.yml representation of the value for key.
+func (c *Configuration) configValue(key string) string {
+	switch key {
+	case "checkFileNameExt":
+		return strconv.FormatBool(c.CheckFileNameExt)
+	case "reader15":
+		return strconv.FormatBool(c.Reader15)
+	case "decodeAllStreams":
+		return strconv.FormatBool(c.DecodeAllStreams)
+	case "validationMode":
+		if c.ValidationMode == ValidationStrict {
+			return "ValidationStrict"
+		}
+		return "ValidationRelaxed"
+	case "postProcessValidate":
+		return strconv.FormatBool(c.PostProcessValidate)
+	case "eol":
+		return c.EolString()
+	case "writeObjectStream":
+		return strconv.FormatBool(c.WriteObjectStream)
+	case "writeXRefStream":
+		return strconv.FormatBool(c.WriteXRefStream)
+	case "encryptUsingAES":
+		return strconv.FormatBool(c.EncryptUsingAES)
+	case "encryptKeyLength":
+		return strconv.Itoa(c.EncryptKeyLength)
+	case "permissions":
+		return fmt.Sprintf("0x%04X", uint16(c.Permissions))
+	case "unit":
+		return unitConfigValue(c.Unit)
+	case "timestampFormat":
+		return c.TimestampFormat
+	case "dateFormat":
+		return c.DateFormat
+	case "optimize":
+		return strconv.FormatBool(c.Optimize)
+	case "optimizeResourceDicts":
+		return strconv.FormatBool(c.OptimizeResourceDicts)
+	case "optimizeDuplicateContentStreams":
+		return strconv.FormatBool(c.OptimizeDuplicateContentStreams)
+	case "createBookmarks":
+		return strconv.FormatBool(c.CreateBookmarks)
+	case "needAppearances":
+		return strconv.FormatBool(c.NeedAppearances)
+	case "offline":
+		return strconv.FormatBool(c.Offline)
+	case "timeout":
+		return strconv.Itoa(c.Timeout)
+	case "memoryOnly":
+		return strconv.FormatBool(c.MemoryOnly)
+	case "optimizePasses":
+		return c.OptimizePassesString()
+	case "copyThrough":
+		return strconv.FormatBool(c.CopyThrough)
+	case "compressIncrement":
+		return strconv.FormatBool(c.CompressIncrement)
+	case "preserveObjectNumbers":
+		return strconv.FormatBool(c.PreserveObjectNumbers)
+	case "keepUsageRights":
+		return strconv.FormatBool(c.KeepUsageRights)
+	}
+	return ""
+}
+
+// Set validates and assigns value to the config.yml entry key.
+func (c *Configuration) Set(key, value string) error {
+	if !isConfigKey(key) {
+		return errors.Errorf("pdfcpu: unknown config key: %s", key)
+	}
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return errors.Errorf("pdfcpu: missing value for config key: %s", key)
+	}
+	return parseKeyValue(key, value, c)
+}
@@ -19,7 +19,10 @@ package model
 import (
 	_ "embed"
 	"fmt"
+	"io"
+	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
 	"time"
 
@@ -63,7 +66,7 @@ const (
 const (
 
 	// StatsFileNameDefault is the standard stats filename.
-	StatsFileNameDefault = "stats.csv"
+	StatsFileNameDefault = "stats.json"
 )
 
 // CommandMode specifies the operation being executed.
@@ -153,6 +156,65 @@ const (
 	SETVIEWERPREFERENCES
 	RESETVIEWERPREFERENCES
 	ZOOM
+	LISTENCRYPTION
+	UPDATEENCRYPTION
+	OVERLAY
+	IMPORTKEYWORDS
+	EXPORTKEYWORDS
+	EXPORTPROPERTIES
+	LISTOPENACTION
+	SETOPENACTION
+	RESETOPENACTION
+	LISTTRANSITIONS
+	SETTRANSITIONS
+	REMOVETRANSITIONS
+	LISTGEO
+	ADDGEO
+	REMOVEGEO
+	LISTMEDIA
+	EXTRACTMEDIA
+	SPLITBYBOOKMARK
+	BATCH
+	PIPELINE
+	EXPORTANNOTATIONS
+	REFLOW
+	ADDTEXTLAYER
+	CONVERTPDFX
+	LISTCOLORANTS
+	EDITCOLORANTS
+	LISTOVERPRINT
+	SETOVERPRINT
+	FIXHAIRLINES
+	NORMALIZECONTENT
+	EXTRACTPAGECONTENT
+	REPLACECONTENT
+	EXTRACTFORMXOBJECTS
+	PLACEPAGE
+	LISTTEMPLATEREGIONS
+	ADDTEMPLATEREGIONS
+	FILLTEMPLATE
+	SORTBOOKMARKS
+	DEDUPBOOKMARKS
+	COLLAPSEBOOKMARKS
+	RETARGETBOOKMARKS
+	VERIFYATTACHMENTS
+	FLATTENPAGEATTRS
+	GENERATEANNOTAPPEARANCES
+	TRIAGE
+	ADDSIGNATUREFIELD
+	PREPARESIGNATURE
+	EMBEDSIGNATURE
+	ADDVALIDATIONINFO
+	LISTUSAGERIGHTS
+	REMOVEUSAGERIGHTS
+	EXPORTFORMFIELDSCSV
+	ADDPAGENUMBERS
+	LISTLANGUAGE
+	SETLANGUAGE
+	RESETLANGUAGE
+	LISTOUTPUTINTENTS
+	ADDOUTPUTINTENT
+	REMOVEOUTPUTINTENTS
 )
 
 // Configuration of a Context.
@@ -182,6 +244,9 @@ type Configuration struct {
 	// Check for broken links in LinkedAnnotations/URIActions.
 	ValidateLinks bool
 
+	// Preflight against a PDF/X standard (pdfx-1a, pdfx-4) during validation.
+	ValidateStandard string
+
 	// End of line char sequence for writing.
 	Eol string
 
@@ -198,7 +263,7 @@ type Configuration struct {
 	// TODO Decision - unused.
 	CollectStats bool
 
-	// A CSV-filename holding the statistics.
+	// A JSON-filename for the optimization report.
 	StatsFileName string
 
 	// Supplied user password.
@@ -217,9 +282,16 @@ type Configuration struct {
 	// AES:40,128,256 RC4:40,128
 	EncryptKeyLength int
 
+	// EncryptAttachmentsOnly restricts encryption to embedded file streams (EFF).
+	// Requires a key length of at least 128 bits.
+	EncryptAttachmentsOnly bool
+
 	// Supplied user access permissions, see Table 22.
 	Permissions PermissionFlags // int16
 
+	// Apply Permissions when updating encryption, otherwise the current permissions are retained.
+	UpdatePermissions bool
+
 	// Command being executed.
 	Cmd CommandMode
 
@@ -248,6 +320,21 @@ type Configuration struct {
 	// Merge creates bookmarks.
 	CreateBookmarks bool
 
+	// Merge policy for colliding document-level names.
+	MergeConflicts MergeConflictPolicy
+
+	// Merge file identifier and document dates, regenerated if nil.
+	MergeIdentity *DocIdentity
+
+	// Split title pattern for result files, eg. "%basename% part %n%".
+	SplitTitle string
+
+	// Split author pattern for result files.
+	SplitAuthor string
+
+	// Split copies the document information dict into result files.
+	SplitCopyMetadata bool
+
 	// PDF Viewer is expected to supply appearance streams for form fields.
 	NeedAppearances bool
 
@@ -256,6 +343,56 @@ type Configuration struct {
 
 	// HTTP timeout in seconds.
 	Timeout int
+
+	// Guarantees no intermediate files get written.
+	// Files processed in place are buffered in memory instead of using a temporary file.
+	// NOTE: The final overwrite of the original file is not atomic.
+	// An interrupted write (eg. disk full, crash) leaves the original file corrupted.
+	MemoryOnly bool
+
+	// Read input files via a read only memory mapping on 64-bit unix platforms.
+	// Reduces syscall overhead for very large files and lets the OS page in stream data.
+	// NOTE: The input file must not be modified or truncated while being read.
+	MemoryMappedRead bool
+
+	// Optimization passes in effect, see AllOptimizePasses.
+	// nil means DefaultOptimizePasses.
+	OptimizePasses []string
+
+	// Copy the input file through unmodified and append an incremental update
+	// holding the objects changed by a command (eg. stamping a single page, setting properties).
+	// Preserves the original compression and avoids rewriting huge files.
+	// Falls back to a full write if not applicable, eg. for encrypted files.
+	CopyThrough bool
+
+	// Pack non-stream objects of incremental updates (eg. added annotations, filled form fields, stamps)
+	// into object streams in order to keep incremental growth small.
+	// Applies only to increments using a cross reference stream.
+	CompressIncrement bool
+
+	// Keep object numbers stable: free object numbers do not get recycled for new objects,
+	// so any object number of the output refers to the same object as in the input.
+	// New objects are numbered beyond the size of the input cross reference table.
+	PreserveObjectNumbers bool
+
+	// Keep usage rights signatures (UR3) when removing signatures of modified forms.
+	// Usage rights get invalidated by most modifications and cause warnings in Adobe Reader.
+	KeepUsageRights bool
+
+	// Perform all processing including validation but instead of writing
+	// report the pages affected, objects added, modified or removed and the estimated output size.
+	DryRun bool
+
+	// Copy files to their backup file before getting modified in place
+	// and only replace them by output passing validation.
+	Backup bool
+
+	// Backup file name, defaults to the input file name extended by ".bak".
+	BackupFile string
+
+	// Optional file system for resolving resource files like stamp images, JSON image references and import lists.
+	// Allows bundling assets using go:embed.
+	ResourceFS fs.FS
 }
 
 // ConfigPath defines the location of pdfcpu's configuration directory.
@@ -282,20 +419,7 @@ func ensureConfigFileAt(path string, override bool) error {
 	if err != nil || override {
 		f.Close()
 
-		s := fmt.Sprintf(`
-#############################
-#   Default configuration   #
-#############################
-
-# Creation date
-created: %s 
-
-# version (Do not edit!)
-version: %s 
-
-`,
-			time.Now().Format("2006-01-02 15:04"),
-			VersionStr)
+		s := configHeader(time.Now().Format("2006-01-02 15:04"))
 
 		bb := append([]byte(s), configFileBytes...)
 		if err := os.WriteFile(path, bb, os.ModePerm); err != nil {
@@ -375,6 +499,8 @@ func newDefaultConfiguration() *Configuration {
 		NeedAppearances:                 false,
 		Offline:                         false,
 		Timeout:                         5,
+		MemoryOnly:                      false,
+		OptimizePasses:                  append([]string(nil), DefaultOptimizePasses...),
 	}
 }
 
@@ -428,6 +554,153 @@ func NewRC4Configuration(userPW, ownerPW string, keyLength int) *Configuration {
 	return c
 }
 
+// Option modifies a Configuration.
+type Option func(*Configuration)
+
+// NewConfiguration returns the default configuration with opts applied.
+func NewConfiguration(opts ...Option) *Configuration {
+	return NewDefaultConfiguration().With(opts...)
+}
+
+// Clone returns a deep copy of c.
+// ResourceFS is shared since file systems are read only.
+func (c *Configuration) Clone() *Configuration {
+	c1 := *c
+
+	if c.UserPWNew != nil {
+		pw := *c.UserPWNew
+		c1.UserPWNew = &pw
+	}
+
+	if c.OwnerPWNew != nil {
+		pw := *c.OwnerPWNew
+		c1.OwnerPWNew = &pw
+	}
+
+	if c.MergeIdentity != nil {
+		c1.MergeIdentity = c.MergeIdentity.clone()
+	}
+
+	if c.OptimizePasses != nil {
+		c1.OptimizePasses = append([]string{}, c.OptimizePasses...)
+	}
+
+	return &c1
+}
+
+// With returns a deep copy of c with opts applied leaving c untouched.
+// Use this for per call overrides of a shared configuration.
+func (c *Configuration) With(opts ...Option) *Configuration {
+	c1 := c.Clone()
+	for _, opt := range opts {
+		opt(c1)
+	}
+	return c1
+}
+
+// WithValidationStrict enforces 100% compliance with the spec.
+func WithValidationStrict() Option {
+	return func(c *Configuration) {
+		c.ValidationMode = ValidationStrict
+	}
+}
+
+// WithValidationRelaxed tolerates frequently encountered validation errors.
+func WithValidationRelaxed() Option {
+	return func(c *Configuration) {
+		c.ValidationMode = ValidationRelaxed
+	}
+}
+
+// WithUserPW sets the user password.
+func WithUserPW(pw string) Option {
+	return func(c *Configuration) {
+		c.UserPW = pw
+	}
+}
+
+// WithOwnerPW sets the owner password.
+func WithOwnerPW(pw string) Option {
+	return func(c *Configuration) {
+		c.OwnerPW = pw
+	}
+}
+
+// WithAES sets AES encryption using keyLength.
+func WithAES(keyLength int) Option {
+	return func(c *Configuration) {
+		c.EncryptUsingAES = true
+		c.EncryptKeyLength = keyLength
+	}
+}
+
+// WithRC4 sets RC4 encryption using keyLength.
+func WithRC4(keyLength int) Option {
+	return func(c *Configuration) {
+		c.EncryptUsingAES = false
+		c.EncryptKeyLength = keyLength
+	}
+}
+
+// WithPermissions sets the user access permissions.
+// Also applies to encryption updates.
+func WithPermissions(p PermissionFlags) Option {
+	return func(c *Configuration) {
+		c.Permissions = p
+		c.UpdatePermissions = true
+	}
+}
+
+// WithUnit sets the display unit.
+func WithUnit(u types.DisplayUnit) Option {
+	return func(c *Configuration) {
+		c.Unit = u
+	}
+}
+
+// WithOptimize toggles optimization after reading and validating.
+func WithOptimize(b bool) Option {
+	return func(c *Configuration) {
+		c.Optimize = b
+	}
+}
+
+// WithOffline toggles internet availability.
+func WithOffline(b bool) Option {
+	return func(c *Configuration) {
+		c.Offline = b
+	}
+}
+
+// WithTimeout sets the HTTP timeout in seconds.
+func WithTimeout(secs int) Option {
+	return func(c *Configuration) {
+		c.Timeout = secs
+	}
+}
+
+// WithMemoryOnly guarantees no intermediate files get written.
+func WithMemoryOnly() Option {
+	return func(c *Configuration) {
+		c.MemoryOnly = true
+	}
+}
+
+// WithDryRun reports the changes of a command instead of writing the result.
+func WithDryRun() Option {
+	return func(c *Configuration) {
+		c.DryRun = true
+	}
+}
+
+// WithBackup backs up files modified in place to bakFile or if empty inFile.bak.
+func WithBackup(bakFile string) Option {
+	return func(c *Configuration) {
+		c.Backup = true
+		c.BackupFile = bakFile
+	}
+}
+
 func (c Configuration) String() string {
 	path := "default"
 	if len(c.Path) > 0 {
@@ -459,7 +732,13 @@ func (c Configuration) String() string {
 		"CreateBookmarks %t\n"+
 		"NeedAppearances %t\n"+
 		"Offline %t\n"+
-		"Timeout %d\n",
+		"Timeout %d\n"+
+		"MemoryOnly %t\n"+
+		"OptimizePasses %s\n"+
+		"CopyThrough %t\n"+
+		"CompressIncrement %t\n"+
+		"PreserveObjectNumbers %t\n"+
+		"KeepUsageRights %t\n",
 		path,
 		c.CreationDate,
 		c.Version,
@@ -486,9 +765,23 @@ func (c Configuration) String() string {
 		c.NeedAppearances,
 		c.Offline,
 		c.Timeout,
+		c.MemoryOnly,
+		c.OptimizePassesString(),
+		c.CopyThrough,
+		c.CompressIncrement,
+		c.PreserveObjectNumbers,
+		c.KeepUsageRights,
 	)
 }
 
+// OpenResource opens the resource file name using ResourceFS if set.
+func (c *Configuration) OpenResource(name string) (io.ReadCloser, error) {
+	if c != nil && c.ResourceFS != nil {
+		return c.ResourceFS.Open(path.Clean(filepath.ToSlash(name)))
+	}
+	return os.Open(name)
+}
+
 // EolString returns a string rep for the eol in effect.
 func (c *Configuration) EolString() string {
 	var s string
@@ -35,8 +35,10 @@ type Context struct {
 	Read         *ReadContext
 	Optimize     *OptimizationContext
 	Write        *WriteContext
-	WritingPages bool // true, when writing page dicts.
-	Dest         bool // true when writing a destination within a page.
+	WritingPages bool          // true, when writing page dicts.
+	Dest         bool          // true when writing a destination within a page.
+	MergeReport  []string      // Actions taken resolving colliding names during merge.
+	DryRunReport *DryRunReport // Changes a write would apply, see Configuration.DryRun.
 }
 
 // NewContext initializes a new Context.
@@ -59,6 +61,8 @@ func NewContext(rs io.ReadSeeker, conf *Configuration) (*Context, error) {
 		NewWriteContext(conf.Eol),
 		false,
 		false,
+		nil,
+		nil,
 	}
 
 	return ctx, nil
@@ -179,21 +183,23 @@ func (ctx *Context) ConvertToUnit(d types.Dim) types.Dim {
 
 // ReadContext represents the context for reading a PDF file.
 type ReadContext struct {
-	FileName            string        // Input PDF-File.
-	FileSize            int64         // Input file size.
-	RS                  io.ReadSeeker // Input read seeker.
-	EolCount            int           // 1 or 2 characters used for eol.
-	BinaryTotalSize     int64         // total stream data
-	BinaryImageSize     int64         // total image stream data
-	BinaryFontSize      int64         // total font stream data (fontfiles)
-	BinaryImageDuplSize int64         // total obsolet image stream data after optimization
-	BinaryFontDuplSize  int64         // total obsolet font stream data after optimization
-	Linearized          bool          // File is linearized.
-	Hybrid              bool          // File is a hybrid PDF file.
-	UsingObjectStreams  bool          // File is using object streams.
-	ObjectStreams       types.IntSet  // All object numbers of any object streams found which need to be decoded.
-	UsingXRefStreams    bool          // File is using xref streams.
-	XRefStreams         types.IntSet  // All object numbers of any xref streams found.
+	FileName            string         // Input PDF-File.
+	FileSize            int64          // Input file size.
+	RS                  io.ReadSeeker  // Input read seeker.
+	EolCount            int            // 1 or 2 characters used for eol.
+	BinaryTotalSize     int64          // total stream data
+	BinaryImageSize     int64          // total image stream data
+	BinaryFontSize      int64          // total font stream data (fontfiles)
+	BinaryImageDuplSize int64          // total obsolet image stream data after optimization
+	BinaryFontDuplSize  int64          // total obsolet font stream data after optimization
+	Linearized          bool           // File is linearized.
+	Hybrid              bool           // File is a hybrid PDF file.
+	UsingObjectStreams  bool           // File is using object streams.
+	ObjectStreams       types.IntSet   // All object numbers of any object streams found which need to be decoded.
+	UsingXRefStreams    bool           // File is using xref streams.
+	XRefStreams         types.IntSet   // All object numbers of any xref streams found.
+	RepairedXRef        bool           // The xref table got rebuilt by scanning the file.
+	ObjectDigests       map[int]uint64 // Object fingerprints taken right after reading, see Configuration.CopyThrough.
 }
 
 func newReadContext(rs io.ReadSeeker) (*ReadContext, error) {
@@ -301,6 +307,8 @@ type OptimizationContext struct {
 	DuplicateInfoObjects types.IntSet // Possible result of manual info dict modification.
 	NonReferencedObjs    []int        // Objects that are not referenced.
 
+	PassStats map[string]*OptimizePassStats // Savings of the optimization passes not covered by the font, image and gc statistics.
+
 	Cache     map[int]bool // For visited objects during optimization.
 	NullObjNr *int         // objNr of a regular null object, to be used for fixing references to free objects.
 }
@@ -318,6 +326,7 @@ func newOptimizationContext() *OptimizationContext {
 		DuplicateInfoObjects: types.IntSet{},
 		ContentStreamCache:   map[int]*types.StreamDict{},
 		FormStreamCache:      map[int]*types.StreamDict{},
+		PassStats:            map[string]*OptimizePassStats{},
 		Cache:                map[int]bool{},
 	}
 }
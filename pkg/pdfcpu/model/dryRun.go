@@ -0,0 +1,52 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"strconv"
+	"strings"
+)
+
+// DryRunReport summarizes the changes a command would apply to its input file.
+type DryRunReport struct {
+	PageCount     int
+	AffectedPages []int // Added or modified pages.
+	Added         int   // Number of added objects.
+	Modified      int   // Number of modified objects.
+	Removed       int   // Number of removed objects.
+	InputSize     int64
+	OutputSize    int64 // Estimated size of the output file.
+}
+
+func (r DryRunReport) String() string {
+	ss := make([]string, len(r.AffectedPages))
+	for i, p := range r.AffectedPages {
+		ss[i] = strconv.Itoa(p)
+	}
+	affected := strings.Join(ss, ",")
+	if affected == "" {
+		affected = "none"
+	}
+
+	var sb strings.Builder
+	sb.WriteString("dry run, no output written:\n")
+	sb.WriteString("   pages:   " + strconv.Itoa(r.PageCount) + " (affected: " + affected + ")\n")
+	sb.WriteString("   objects: " + strconv.Itoa(r.Added) + " added, " + strconv.Itoa(r.Modified) + " modified, " + strconv.Itoa(r.Removed) + " removed\n")
+	sb.WriteString("   size:    " + strconv.FormatInt(r.InputSize, 10) + " -> " + strconv.FormatInt(r.OutputSize, 10) + " bytes (estimated)")
+
+	return sb.String()
+}
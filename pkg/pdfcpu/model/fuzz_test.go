@@ -0,0 +1,54 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import "testing"
+
+// Run with: go test -run=^$ -fuzz=FuzzParseObject ./pkg/pdfcpu/model
+
+func FuzzParseObject(f *testing.F) {
+	for _, s := range []string{
+		"<</Type/Page/Parent 2 0 R/MediaBox[0 0 595 842]>>",
+		"[1 2.5 -3 (string) <414243> /Name true null 4 0 R]",
+		"(nested (parens) and \\) escapes \\101)",
+		"<</Length 10/Filter[/FlateDecode]/DecodeParms[<</Predictor 12/Columns 4>>]>>",
+		"/Name#20With#20Spaces",
+		"<<>>",
+	} {
+		f.Add(s)
+	}
+
+	f.Fuzz(func(t *testing.T, s string) {
+		ParseObject(&s)
+	})
+}
+
+func FuzzParseContent(f *testing.F) {
+	for _, s := range []string{
+		"q 1 0 0 1 0 0 cm /Im0 Do Q",
+		"BT /F1 12 Tf 72 712 Td (Hello) Tj [(W) 120 (orld)] TJ ET",
+		"/GS0 gs /CS0 cs /P0 scn 0 0 100 100 re f /Sh0 sh",
+		"BI /W 1 /H 1 /BPC 8 /CS /G ID \x00 EI",
+		"/OC /MC0 BDC 0 g EMC",
+	} {
+		f.Add(s)
+	}
+
+	f.Fuzz(func(t *testing.T, s string) {
+		parseContent(s)
+	})
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// MergeConflictPolicy controls how merging handles document-level items colliding by name:
+// named destinations, name tree entries like JavaScript, top-level form fields and optional content groups.
+type MergeConflictPolicy int
+
+const (
+	// MergeConflictRename keeps both items by renaming the merged in item.
+	// Form fields get qualified by nesting them under a new parent field.
+	MergeConflictRename MergeConflictPolicy = iota
+
+	// MergeConflictSkip keeps the item of the destination and drops the merged in item.
+	MergeConflictSkip
+
+	// MergeConflictError aborts merging.
+	MergeConflictError
+)
+
+func (p MergeConflictPolicy) String() string {
+	switch p {
+	case MergeConflictSkip:
+		return "skip"
+	case MergeConflictError:
+		return "error"
+	}
+	return "rename"
+}
+
+// ParseMergeConflictPolicy returns the merge conflict policy for s, which may be abbreviated.
+func ParseMergeConflictPolicy(s string) (MergeConflictPolicy, error) {
+	s = strings.ToLower(s)
+	for _, p := range []MergeConflictPolicy{MergeConflictRename, MergeConflictSkip, MergeConflictError} {
+		if s != "" && strings.HasPrefix(p.String(), s) {
+			return p, nil
+		}
+	}
+	return MergeConflictRename, errors.Errorf("pdfcpu: invalid merge conflict policy %q, use one of: rename, skip, error", s)
+}
+
+// DocIdentity controls the file identifier and the document dates of a merge result.
+// By default both get regenerated. Explicit values take precedence over Keep.
+type DocIdentity struct {
+	Keep     bool       // Preserve ID, CreationDate and ModDate of the first input.
+	ID       string     // File identifier as hex string.
+	Created  *time.Time // CreationDate
+	Modified *time.Time // ModDate
+}
+
+func (di *DocIdentity) clone() *DocIdentity {
+	di1 := *di
+	if di.Created != nil {
+		t := *di.Created
+		di1.Created = &t
+	}
+	if di.Modified != nil {
+		t := *di.Modified
+		di1.Modified = &t
+	}
+	return &di1
+}
+
+// ParseDocIdentity parses a document identity description eg. "keep" or "id:0a1b2c3d, created:2024-01-31".
+// Dates are expected in dateFormat.
+func ParseDocIdentity(s, dateFormat string) (*DocIdentity, error) {
+	di := &DocIdentity{}
+
+	for _, s := range strings.Split(s, ",") {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+
+		if strings.ToLower(s) == "keep" {
+			di.Keep = true
+			continue
+		}
+
+		k, v, ok := strings.Cut(s, ":")
+		if !ok {
+			return nil, errors.Errorf("pdfcpu: invalid document identity: %s", s)
+		}
+		k, v = strings.ToLower(strings.TrimSpace(k)), strings.TrimSpace(v)
+
+		switch k {
+
+		case "id":
+			if _, err := hex.DecodeString(v); err != nil || v == "" {
+				return nil, errors.Errorf("pdfcpu: invalid file identifier: %s", v)
+			}
+			di.ID = v
+
+		case "created", "modified":
+			t, err := time.ParseInLocation(dateFormat, v, time.Local)
+			if err != nil {
+				return nil, errors.Errorf("pdfcpu: invalid date: %s, expected format: %s", v, dateFormat)
+			}
+			if k == "created" {
+				di.Created = &t
+			} else {
+				di.Modified = &t
+			}
+
+		default:
+			return nil, errors.Errorf("pdfcpu: invalid document identity parameter: %s, use one of: keep, id, created, modified", k)
+		}
+	}
+
+	return di, nil
+}
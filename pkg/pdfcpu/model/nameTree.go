@@ -18,6 +18,7 @@ package model
 
 import (
 	"fmt"
+	"reflect"
 	"strings"
 
 	"github.com/pdfcpu/pdfcpu/pkg/log"
@@ -447,9 +448,40 @@ func (n *Node) removeFromLeaf(xRefTable *XRefTable, k string) (empty, ok bool, e
 	return false, ok, nil
 }
 
+// freeKidObject frees the object of kid referenced by n.D.
+// Binding the name tree creates new objects for all kids.
+func (n *Node) freeKidObject(xRefTable *XRefTable, kid *Node) error {
+	if n.D == nil || kid.D == nil {
+		return nil
+	}
+
+	kids, ok := n.D["Kids"].(types.Array)
+	if !ok {
+		return nil
+	}
+
+	for _, o := range kids {
+		ir, ok := o.(types.IndirectRef)
+		if !ok {
+			continue
+		}
+		entry, found := xRefTable.FindTableEntryForIndRef(&ir)
+		if !found || entry.Free {
+			continue
+		}
+		// Only free the object actually holding the dict of kid.
+		if d, ok := entry.Object.(types.Dict); ok && reflect.ValueOf(d).Pointer() == reflect.ValueOf(kid.D).Pointer() {
+			return xRefTable.FreeObject(ir.ObjectNumber.Value())
+		}
+	}
+
+	return nil
+}
+
 func (n *Node) removeKid(xRefTable *XRefTable, kid *Node, i int) (bool, error) {
 	if xRefTable != nil {
-		if err := xRefTable.DeleteObject(kid.D); err != nil {
+		// The values of kid have already been deleted.
+		if err := n.freeKidObject(xRefTable, kid); err != nil {
 			return false, err
 		}
 	}
@@ -483,7 +515,8 @@ func (n *Node) removeKid(xRefTable *XRefTable, kid *Node, i int) (bool, error) {
 		}
 
 		if xRefTable != nil {
-			if err := xRefTable.DeleteObject(n.D); err != nil {
+			// The remaining kid takes over the dict of n.
+			if err := n.freeKidObject(xRefTable, n.Kids[0]); err != nil {
 				return false, err
 			}
 		}
@@ -500,7 +533,7 @@ func (n *Node) removeKid(xRefTable *XRefTable, kid *Node, i int) (bool, error) {
 	return false, nil
 }
 
-func (n *Node) removeFromKids(xRefTable *XRefTable, k string) (ok bool, err error) {
+func (n *Node) removeFromKids(xRefTable *XRefTable, k string) (empty, ok bool, err error) {
 	// Locate the kid to recurse into, then remove k from that subtree.
 	for i, kid := range n.Kids {
 
@@ -510,22 +543,37 @@ func (n *Node) removeFromKids(xRefTable *XRefTable, k string) (ok bool, err erro
 
 		empty, ok, err := kid.Remove(xRefTable, k)
 		if err != nil {
-			return false, err
+			return false, false, err
 		}
 		if !ok {
-			return false, nil
+			return false, false, nil
 		}
 
 		if empty {
 
 			// This kid is now empty and needs to be removed.
 
+			if len(n.Kids) == 1 {
+				// The sole kid is gone, n is an empty leaf now.
+				if xRefTable != nil {
+					if err := n.freeKidObject(xRefTable, kid); err != nil {
+						return false, false, err
+					}
+				}
+				if n.D != nil {
+					n.D.Delete("Kids")
+				}
+				n.Kids, n.Names = nil, nil
+				n.Kmin, n.Kmax = "", ""
+				return true, true, nil
+			}
+
 			noKids, err := n.removeKid(xRefTable, kid, i)
 			if err != nil {
-				return false, err
+				return false, false, err
 			}
 			if noKids {
-				return true, nil
+				return false, true, nil
 			}
 
 		}
@@ -534,10 +582,10 @@ func (n *Node) removeFromKids(xRefTable *XRefTable, k string) (ok bool, err erro
 		n.Kmin = n.Kids[0].Kmin
 		n.Kmax = n.Kids[len(n.Kids)-1].Kmax
 
-		return true, nil
+		return false, true, nil
 	}
 
-	return false, nil
+	return false, false, nil
 }
 
 // Remove removes an entry from a name tree.
@@ -548,12 +596,7 @@ func (n *Node) Remove(xRefTable *XRefTable, k string) (empty, ok bool, err error
 		return n.removeFromLeaf(xRefTable, k)
 	}
 
-	if ok, err = n.removeFromKids(xRefTable, k); err != nil {
-		return false, false, err
-	}
-
-	return false, ok, nil
-
+	return n.removeFromKids(xRefTable, k)
 }
 
 // Process traverses the nametree applying a handler to each entry (key-value pair).
@@ -200,3 +200,22 @@ func TestNameTree(t *testing.T) {
 	buildNameTree(t, r)
 	destroyNameTree(t, r)
 }
+
+func TestNameTreeRemoveSoleKid(t *testing.T) {
+	kid := &Node{Kmin: "a", Kmax: "a", Names: []entry{{"a", types.StringLiteral("av")}}}
+	r := &Node{Kmin: "a", Kmax: "a", Kids: []*Node{kid}}
+
+	empty, ok, err := r.Remove(nil, "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Fatal("could not Remove a")
+	}
+	if !empty {
+		t.Fatal("r should be empty after removing its sole entry")
+	}
+	if !r.leaf() {
+		t.Fatal("empty root node should be a leaf node")
+	}
+}
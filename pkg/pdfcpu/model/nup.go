@@ -292,11 +292,17 @@ func translationForPageRotation(pageRot int, w, h float64) (float64, float64) {
 	return dx, dy
 }
 
-// ContentBytesForPageRotation returns content bytes compensating for rot.
-func ContentBytesForPageRotation(rot int, w, h float64) []byte {
+// PageRotationMatrix returns the transform matrix compensating for rot
+// where w and h are the dimensions of the rotated page.
+func PageRotationMatrix(rot int, w, h float64) matrix.Matrix {
 	dx, dy := translationForPageRotation(rot, w, h)
 	// Note: PDF rotation is clockwise!
-	m := matrix.CalcRotateAndTranslateTransformMatrix(float64(-rot), dx, dy)
+	return matrix.CalcRotateAndTranslateTransformMatrix(float64(-rot), dx, dy)
+}
+
+// ContentBytesForPageRotation returns content bytes compensating for rot.
+func ContentBytesForPageRotation(rot int, w, h float64) []byte {
+	m := PageRotationMatrix(rot, w, h)
 	var b bytes.Buffer
 	fmt.Fprintf(&b, "%.5f %.5f %.5f %.5f %.5f %.5f cm ", m[0][0], m[0][1], m[1][0], m[1][1], m[2][0], m[2][1])
 	return b.Bytes()
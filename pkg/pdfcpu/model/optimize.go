@@ -0,0 +1,121 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// Optimization passes.
+const (
+	OptimizePassFonts        = "fonts"      // Remove duplicate embedded fonts.
+	OptimizePassImages       = "images"     // Remove duplicate images.
+	OptimizePassGC           = "gc"         // Drop unreferenced objects.
+	OptimizePassRecompress   = "recompress" // Flate encode uncompressed streams.
+	OptimizePassMetadata     = "metadata"   // Remove XMP metadata and page piece info.
+	OptimizePassPageTree     = "pagetree"   // Rebalance degenerate page trees.
+	OptimizePassAlternates   = "alternates" // Remove alternate images.
+	OptimizePassThumbnails   = "thumbnails" // Remove page thumbnails.
+	OptimizePassAppStates    = "appstates"  // Remove unused appearance states of markup annotations.
+	OptimizePassStdFonts     = "stdfonts"   // Unembed fonts metrically compatible with the standard 14 fonts.
+	OptimizePassDeadCode     = "deadcode"   // Remove no-op sequences from content streams.
+	OptimizePassInlineImages = "inlineimg"  // Convert large inline images into image XObjects.
+)
+
+// AllOptimizePasses lists all optimization passes in order of execution.
+var AllOptimizePasses = []string{
+	OptimizePassFonts,
+	OptimizePassImages,
+	OptimizePassGC,
+	OptimizePassRecompress,
+	OptimizePassMetadata,
+	OptimizePassPageTree,
+	OptimizePassAlternates,
+	OptimizePassThumbnails,
+	OptimizePassAppStates,
+	OptimizePassStdFonts,
+	OptimizePassDeadCode,
+	OptimizePassInlineImages,
+}
+
+// DefaultOptimizePasses lists the optimization passes in effect unless configured otherwise.
+var DefaultOptimizePasses = []string{
+	OptimizePassFonts,
+	OptimizePassImages,
+	OptimizePassGC,
+}
+
+// ParseOptimizePasses parses a comma separated list of optimization passes.
+// "all" and "default" may be used as shortcuts.
+func ParseOptimizePasses(s string) ([]string, error) {
+	var passes []string
+
+	add := func(pp ...string) {
+		for _, p := range pp {
+			if !types.MemberOf(p, passes) {
+				passes = append(passes, p)
+			}
+		}
+	}
+
+	for _, p := range strings.Split(s, ",") {
+		p = strings.ToLower(strings.TrimSpace(p))
+		switch {
+		case p == "":
+		case p == "all":
+			add(AllOptimizePasses...)
+		case p == "default":
+			add(DefaultOptimizePasses...)
+		case types.MemberOf(p, AllOptimizePasses):
+			add(p)
+		default:
+			return nil, errors.Errorf("pdfcpu: invalid optimization pass: %s (possible values: %s)", p, strings.Join(AllOptimizePasses, ", "))
+		}
+	}
+
+	if len(passes) == 0 {
+		return nil, errors.New("pdfcpu: missing optimization passes")
+	}
+
+	return passes, nil
+}
+
+// OptimizePass returns true if the optimization pass p is enabled.
+func (c *Configuration) OptimizePass(p string) bool {
+	passes := c.OptimizePasses
+	if passes == nil {
+		passes = DefaultOptimizePasses
+	}
+	return types.MemberOf(p, passes)
+}
+
+// OptimizePassesString returns a string rep for the optimization passes in effect.
+func (c *Configuration) OptimizePassesString() string {
+	if c.OptimizePasses == nil {
+		return strings.Join(DefaultOptimizePasses, ",")
+	}
+	return strings.Join(c.OptimizePasses, ",")
+}
+
+// OptimizePassStats records the savings of an optimization pass.
+type OptimizePassStats struct {
+	Objects int   // Number of objects removed or rewritten.
+	Bytes   int64 // Number of bytes saved.
+}
@@ -80,11 +80,25 @@ type Page struct {
 	CropBox    *types.Rectangle
 	Fm         FontMap
 	Im         ImageMap
+	Pm         types.Dict // Pattern resources
+	Gm         types.Dict // ExtGState resources
+	Forms      []FormResource
 	Annots     []FieldAnnotation
 	AnnotTabs  map[int]FieldAnnotation
 	LinkAnnots []LinkAnnotation
 	Buf        *bytes.Buffer
 	Fields     types.Array
+	Tabs       string // tab order of annotations: "R", "C" or "S"
+}
+
+// AddPattern registers the pattern indRef as page resource and returns its resource id.
+func (p *Page) AddPattern(indRef types.IndirectRef) string {
+	if p.Pm == nil {
+		p.Pm = types.Dict{}
+	}
+	id := "Pat" + strconv.Itoa(len(p.Pm))
+	p.Pm[id] = indRef
+	return id
 }
 
 // NewPage creates a page for given mediaBox and cropBox.
@@ -0,0 +1,147 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/color"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// PageNumberStyle defines how page numbers read.
+type PageNumberStyle int
+
+// Page numbering styles.
+const (
+	PageNumberPlain   PageNumberStyle = iota // 3
+	PageNumberOfN                            // 3 of 10
+	PageNumberPage                           // Page 3
+	PageNumberPageOfN                        // Page 3 of 10
+	PageNumberFolio                          // 3 on the outer margin alternating between odd and even pages.
+	PageNumberLabel                          // The page label, eg. chapter relative like A-3
+)
+
+// NumeralStyle defines how numbers get rendered.
+type NumeralStyle int
+
+// Numeral styles (see 12.4.2 Table 161).
+const (
+	NumeralArabic       NumeralStyle = iota // 1, 2, 3
+	NumeralRomanLower                       // i, ii, iii
+	NumeralRomanUpper                       // I, II, III
+	NumeralLettersLower                     // a..z, aa..zz
+	NumeralLettersUpper                     // A..Z, AA..ZZ
+)
+
+// PageNumbersID identifies page number stamps for selective removal.
+const PageNumbersID = "pagenumbers"
+
+// PageNumbers represents the configuration for the command "pagenumbers".
+type PageNumbers struct {
+	Style   PageNumberStyle
+	Numeral NumeralStyle
+	Start   int        // Number of the first numbered page.
+	Skip    []string   // Page selection excluded from numbering and counting, eg. cover pages.
+	Wm      *Watermark // Text stamp configuration for font, position, offset and colors.
+}
+
+// DefaultPageNumbersConfig returns the default configuration: plain arabic numbers in Helvetica 10pt at the bottom center.
+func DefaultPageNumbersConfig() *PageNumbers {
+	wm := DefaultWatermarkConfig()
+	wm.OnTop = true
+	wm.ID = PageNumbersID
+	wm.FontSize = 10
+	wm.Scale = 1
+	wm.ScaleAbs = true
+	wm.Pos = types.BottomCenter
+	wm.Diagonal = NoDiagonal
+	wm.Color = color.Black
+	wm.FillColor = color.Black
+	wm.StrokeColor = color.Black
+
+	return &PageNumbers{Start: 1, Wm: wm}
+}
+
+func romanNumeral(n int) string {
+	if n <= 0 || n >= 4000 {
+		return strconv.Itoa(n)
+	}
+
+	var sb strings.Builder
+	for _, v := range []struct {
+		i int
+		s string
+	}{
+		{1000, "M"}, {900, "CM"}, {500, "D"}, {400, "CD"}, {100, "C"}, {90, "XC"},
+		{50, "L"}, {40, "XL"}, {10, "X"}, {9, "IX"}, {5, "V"}, {4, "IV"}, {1, "I"},
+	} {
+		for n >= v.i {
+			sb.WriteString(v.s)
+			n -= v.i
+		}
+	}
+
+	return sb.String()
+}
+
+// letters returns A..Z for 1..26, AA..ZZ for 27..52 and so on.
+func letters(n int) string {
+	if n <= 0 {
+		return strconv.Itoa(n)
+	}
+	c := string(rune('A' + (n-1)%26))
+	return strings.Repeat(c, (n-1)/26+1)
+}
+
+// FormatNumeral returns n rendered in numeral style s.
+func FormatNumeral(n int, s NumeralStyle) string {
+	switch s {
+	case NumeralRomanLower:
+		return strings.ToLower(romanNumeral(n))
+	case NumeralRomanUpper:
+		return romanNumeral(n)
+	case NumeralLettersLower:
+		return strings.ToLower(letters(n))
+	case NumeralLettersUpper:
+		return letters(n)
+	}
+	return strconv.Itoa(n)
+}
+
+// Text returns the page number for number i out of n numbers.
+// label is the page label and used for PageNumberLabel only.
+func (pn PageNumbers) Text(i, n int, label string) string {
+	s := FormatNumeral(i, pn.Numeral)
+
+	switch pn.Style {
+	case PageNumberOfN:
+		return fmt.Sprintf("%s of %s", s, FormatNumeral(n, pn.Numeral))
+	case PageNumberPage:
+		return "Page " + s
+	case PageNumberPageOfN:
+		return fmt.Sprintf("Page %s of %s", s, FormatNumeral(n, pn.Numeral))
+	case PageNumberLabel:
+		if label != "" {
+			return label
+		}
+	}
+
+	return s
+}
@@ -20,9 +20,6 @@ limitations under the License.
 package model
 
 import (
-	"bytes"
-	"io"
-
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
 	"github.com/pkg/errors"
 	"gopkg.in/yaml.v2"
@@ -31,6 +28,7 @@ import (
 type configuration struct {
 	CreationDate                    string `yaml:"created"`
 	Version                         string `yaml:"version"`
+	SchemaVersion                   int    `yaml:"schemaVersion"`
 	CheckFileNameExt                bool   `yaml:"checkFileNameExt"`
 	Reader15                        bool   `yaml:"reader15"`
 	DecodeAllStreams                bool   `yaml:"decodeAllStreams"`
@@ -48,12 +46,18 @@ type configuration struct {
 	DateFormat                      string `yaml:"dateFormat"`
 	Optimize                        bool   `yaml:"optimize"`
 	OptimizeBeforeWriting           bool
-	OptimizeResourceDicts           bool `yaml:"optimizeResourceDicts"`
-	OptimizeDuplicateContentStreams bool `yaml:"optimizeDuplicateContentStreams"`
-	CreateBookmarks                 bool `yaml:"createBookmarks"`
-	NeedAppearances                 bool `yaml:"needAppearances"`
-	Offline                         bool `yaml:"offline"`
-	Timeout                         int  `yaml:"timeout"`
+	OptimizeResourceDicts           bool   `yaml:"optimizeResourceDicts"`
+	OptimizeDuplicateContentStreams bool   `yaml:"optimizeDuplicateContentStreams"`
+	CreateBookmarks                 bool   `yaml:"createBookmarks"`
+	NeedAppearances                 bool   `yaml:"needAppearances"`
+	Offline                         bool   `yaml:"offline"`
+	Timeout                         int    `yaml:"timeout"`
+	MemoryOnly                      bool   `yaml:"memoryOnly"`
+	OptimizePasses                  string `yaml:"optimizePasses"`
+	CopyThrough                     bool   `yaml:"copyThrough"`
+	CompressIncrement               bool   `yaml:"compressIncrement"`
+	PreserveObjectNumbers           bool   `yaml:"preserveObjectNumbers"`
+	KeepUsageRights                 bool   `yaml:"keepUsageRights"`
 }
 
 func loadedConfig(c configuration, configPath string) *Configuration {
@@ -113,30 +117,33 @@ func loadedConfig(c configuration, configPath string) *Configuration {
 	conf.NeedAppearances = c.NeedAppearances
 	conf.Offline = c.Offline
 	conf.Timeout = c.Timeout
+	conf.MemoryOnly = c.MemoryOnly
+	conf.CopyThrough = c.CopyThrough
+	conf.CompressIncrement = c.CompressIncrement
+	conf.PreserveObjectNumbers = c.PreserveObjectNumbers
+	conf.KeepUsageRights = c.KeepUsageRights
 
 	return &conf
 }
 
-func parseConfigFile(r io.Reader, configPath string) error {
+func parseConfig(bb []byte, configPath string) (*Configuration, error) {
 	var c configuration
 
 	// Enforce default for old config files.
 	c.CheckFileNameExt = true
 
-	var buf bytes.Buffer
-	if _, err := io.Copy(&buf, r); err != nil {
-		return err
+	if err := yaml.Unmarshal(bb, &c); err != nil {
+		return nil, err
 	}
 
-	if err := yaml.Unmarshal(buf.Bytes(), &c); err != nil {
-		return err
+	if c.SchemaVersion > ConfigSchemaVersion {
+		return nil, errors.Errorf("unsupported schemaVersion: %d (max %d)", c.SchemaVersion, ConfigSchemaVersion)
 	}
-
 	if !types.MemberOf(c.ValidationMode, []string{"ValidationStrict", "ValidationRelaxed"}) {
-		return errors.Errorf("invalid validationMode: %s", c.ValidationMode)
+		return nil, errors.Errorf("invalid validationMode: %s", c.ValidationMode)
 	}
 	if !types.MemberOf(c.Eol, []string{"EolLF", "EolCR", "EolCRLF"}) {
-		return errors.Errorf("invalid eol: %s", c.Eol)
+		return nil, errors.Errorf("invalid eol: %s", c.Eol)
 	}
 	if c.Unit == "" {
 		// v0.3.8 modifies "units" to "unit".
@@ -145,14 +152,22 @@ func parseConfigFile(r io.Reader, configPath string) error {
 		}
 	}
 	if !types.MemberOf(c.Unit, []string{"points", "inches", "cm", "mm"}) {
-		return errors.Errorf("invalid unit: %s", c.Unit)
+		return nil, errors.Errorf("invalid unit: %s", c.Unit)
 	}
 
 	if !types.IntMemberOf(c.EncryptKeyLength, []int{40, 128, 256}) {
-		return errors.Errorf("encryptKeyLength possible values: 40, 128, 256, got: %s", c.Unit)
+		return nil, errors.Errorf("encryptKeyLength possible values: 40, 128, 256, got: %d", c.EncryptKeyLength)
 	}
 
-	loadedDefaultConfig = loadedConfig(c, configPath)
+	conf := loadedConfig(c, configPath)
+
+	if c.OptimizePasses != "" {
+		passes, err := ParseOptimizePasses(c.OptimizePasses)
+		if err != nil {
+			return nil, err
+		}
+		conf.OptimizePasses = passes
+	}
 
-	return nil
+	return conf, nil
 }
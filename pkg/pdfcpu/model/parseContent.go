@@ -112,6 +112,9 @@ func skipTJ(l *string) error {
 	s := *l
 	for {
 		s = strings.TrimLeftFunc(s, whitespaceOrEOL)
+		if len(s) == 0 {
+			return errTJExpressionCorrupt
+		}
 		if s[0] == ']' {
 			s = s[1:]
 			break
@@ -121,7 +124,7 @@ func skipTJ(l *string) error {
 				return err
 			}
 		}
-		if s[0] == '<' {
+		if len(s) > 0 && s[0] == '<' {
 			if err := skipHexStringLiteral(&s); err != nil {
 				return err
 			}
@@ -140,11 +143,13 @@ func skipBI(l *string, prn PageResourceNames) error {
 	s := *l
 	for {
 		s = strings.TrimLeftFunc(s, whitespaceOrEOL)
-		if strings.HasPrefix(s, "EI") && whitespaceOrEOL(rune(s[2])) {
+		if strings.HasPrefix(s, "EI") && (len(s) == 2 || whitespaceOrEOL(rune(s[2]))) {
 			s = s[2:]
 			break
 		}
-		// TODO Check len(s) > 0
+		if len(s) == 0 {
+			return errBIExpressionCorrupt
+		}
 		if s[0] == '/' {
 			s = s[1:]
 			i, _ := positionToNextWhitespaceOrChar(s, "/")
@@ -155,6 +160,9 @@ func skipBI(l *string, prn PageResourceNames) error {
 			if token == "CS" || token == "ColorSpace" {
 				s = s[i:]
 				s, _ = trimLeftSpace(s, false)
+				if len(s) == 0 {
+					return errBIExpressionCorrupt
+				}
 				s = s[1:]
 				i, _ = positionToNextWhitespaceOrChar(s, "/")
 				if i < 0 {
@@ -215,7 +223,7 @@ func positionToNextContentToken(line *string, prn PageResourceNames) (bool, erro
 			}
 			continue
 		}
-		if strings.HasPrefix(l, "BI") && (l[2] == '/' || whitespaceOrEOL(rune(l[2]))) {
+		if strings.HasPrefix(l, "BI") && len(l) > 2 && (l[2] == '/' || whitespaceOrEOL(rune(l[2]))) {
 			// Handle inline image
 			l = l[2:]
 			if err := skipBI(&l, prn); err != nil {
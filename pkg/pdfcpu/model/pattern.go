@@ -0,0 +1,221 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/color"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/matrix"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// Gradient represents an axial or radial color gradient spanning the unit square.
+type Gradient struct {
+	Radial bool
+	Colors []color.SimpleColor // At least 2 colors evenly spread across the gradient.
+	Angle  float64             // Direction of axial gradients in degrees, counterclockwise with 0 pointing to the right.
+}
+
+// TilingStyle represents the cell content of a tiling pattern.
+type TilingStyle int
+
+// Tiling styles
+const (
+	TilingStripes TilingStyle = iota
+	TilingGrid
+	TilingDots
+	TilingChecker
+)
+
+// TilingPattern represents a colored tiling pattern.
+type TilingPattern struct {
+	Style    TilingStyle
+	Color    color.SimpleColor
+	BgColor  *color.SimpleColor
+	Size     float64 // Cell width and height.
+	Width    float64 // Line width or dot diameter.
+	Rotation float64 // In degrees.
+}
+
+func rgb(c color.SimpleColor) types.Array {
+	return types.NewNumberArray(float64(c.R), float64(c.G), float64(c.B))
+}
+
+func matrixArray(m matrix.Matrix) types.Array {
+	return types.NewNumberArray(m[0][0], m[0][1], m[1][0], m[1][1], m[2][0], m[2][1])
+}
+
+func exponentialFunction(c0, c1 color.SimpleColor) types.Dict {
+	return types.Dict(
+		map[string]types.Object{
+			"FunctionType": types.Integer(2),
+			"Domain":       types.NewNumberArray(0, 1),
+			"C0":           rgb(c0),
+			"C1":           rgb(c1),
+			"N":            types.Float(1),
+		},
+	)
+}
+
+// function returns an interpolation function for g (see 7.10.3 and 7.10.4).
+func (g Gradient) function() types.Dict {
+	cc := g.Colors
+	if len(cc) == 2 {
+		return exponentialFunction(cc[0], cc[1])
+	}
+
+	// Stitch one exponential function for each pair of adjacent colors.
+	n := len(cc) - 1
+	ff, bounds, encode := types.Array{}, types.Array{}, types.Array{}
+	for i := 0; i < n; i++ {
+		ff = append(ff, exponentialFunction(cc[i], cc[i+1]))
+		encode = append(encode, types.Float(0), types.Float(1))
+		if i > 0 {
+			bounds = append(bounds, types.Float(float64(i)/float64(n)))
+		}
+	}
+
+	return types.Dict(
+		map[string]types.Object{
+			"FunctionType": types.Integer(3),
+			"Domain":       types.NewNumberArray(0, 1),
+			"Functions":    ff,
+			"Bounds":       bounds,
+			"Encode":       encode,
+		},
+	)
+}
+
+// shadingDict returns an axial or radial shading covering the unit square.
+func (g Gradient) shadingDict() types.Dict {
+	d := types.Dict(
+		map[string]types.Object{
+			"ColorSpace": types.Name("DeviceRGB"),
+			"Function":   g.function(),
+			"Extend":     types.Array{types.Boolean(true), types.Boolean(true)},
+		},
+	)
+
+	if g.Radial {
+		d["ShadingType"] = types.Integer(3)
+		d["Coords"] = types.NewNumberArray(.5, .5, 0, .5, .5, math.Sqrt2/2)
+		return d
+	}
+
+	a := g.Angle * math.Pi / 180
+	dx, dy := math.Cos(a), math.Sin(a)
+
+	// Span the unit square along the gradient direction.
+	l := (math.Abs(dx) + math.Abs(dy)) / 2
+
+	d["ShadingType"] = types.Integer(2)
+	d["Coords"] = types.NewNumberArray(.5-l*dx, .5-l*dy, .5+l*dx, .5+l*dy)
+
+	return d
+}
+
+// NewShadingPattern creates a shading pattern for g stretched over r.
+// m is the transformation in effect when painting r.
+func NewShadingPattern(xRefTable *XRefTable, g Gradient, r *types.Rectangle, m matrix.Matrix) (*types.IndirectRef, error) {
+	if len(g.Colors) < 2 {
+		return nil, errors.New("pdfcpu: gradient needs at least 2 colors")
+	}
+
+	// Map the unit square onto r.
+	m1 := matrix.CalcTransformMatrix(r.Width(), r.Height(), 0, 1, r.LL.X, r.LL.Y).Multiply(m)
+
+	d := types.Dict(
+		map[string]types.Object{
+			"Type":        types.Name("Pattern"),
+			"PatternType": types.Integer(2),
+			"Shading":     g.shadingDict(),
+			"Matrix":      matrixArray(m1),
+		},
+	)
+
+	return xRefTable.IndRefForNewObject(d)
+}
+
+func fillCircle(w *bytes.Buffer, x, y, r float64) {
+	f := .5523 * r
+	fmt.Fprintf(w, "%.2f %.2f m ", x+r, y)
+	fmt.Fprintf(w, "%.2f %.2f %.2f %.2f %.2f %.2f c ", x+r, y+f, x+f, y+r, x, y+r)
+	fmt.Fprintf(w, "%.2f %.2f %.2f %.2f %.2f %.2f c ", x-f, y+r, x-r, y+f, x-r, y)
+	fmt.Fprintf(w, "%.2f %.2f %.2f %.2f %.2f %.2f c ", x-r, y-f, x-f, y-r, x, y-r)
+	fmt.Fprintf(w, "%.2f %.2f %.2f %.2f %.2f %.2f c f ", x+f, y-r, x+r, y-f, x+r, y)
+}
+
+// content returns the pattern cell.
+func (tp TilingPattern) content() []byte {
+	var b bytes.Buffer
+	s, w := tp.Size, tp.Width
+
+	if c := tp.BgColor; c != nil {
+		fmt.Fprintf(&b, "%.2f %.2f %.2f rg 0 0 %.2f %.2f re f ", c.R, c.G, c.B, s, s)
+	}
+
+	c := tp.Color
+	fmt.Fprintf(&b, "%.2f %.2f %.2f rg ", c.R, c.G, c.B)
+
+	switch tp.Style {
+	case TilingStripes:
+		fmt.Fprintf(&b, "0 0 %.2f %.2f re f ", w, s)
+	case TilingGrid:
+		fmt.Fprintf(&b, "0 0 %.2f %.2f re 0 0 %.2f %.2f re f ", w, s, s, w)
+	case TilingDots:
+		fillCircle(&b, s/2, s/2, w/2)
+	case TilingChecker:
+		fmt.Fprintf(&b, "0 0 %.2f %.2f re %.2f %.2f %.2f %.2f re f ", s/2, s/2, s/2, s/2, s/2, s/2)
+	}
+
+	return b.Bytes()
+}
+
+// NewTilingPattern creates a tiling pattern aligned to the lower left corner of r.
+// m is the transformation in effect when painting r.
+func NewTilingPattern(xRefTable *XRefTable, tp TilingPattern, r *types.Rectangle, m matrix.Matrix) (*types.IndirectRef, error) {
+	if tp.Size <= 0 {
+		return nil, errors.New("pdfcpu: tiling pattern size must be positive")
+	}
+
+	sd, err := xRefTable.NewStreamDictForBuf(tp.content())
+	if err != nil {
+		return nil, err
+	}
+
+	m1 := matrix.CalcRotateAndTranslateTransformMatrix(tp.Rotation, r.LL.X, r.LL.Y).Multiply(m)
+
+	sd.InsertName("Type", "Pattern")
+	sd.InsertInt("PatternType", 1)
+	sd.InsertInt("PaintType", 1)
+	sd.InsertInt("TilingType", 1)
+	sd.Insert("BBox", types.NewNumberArray(0, 0, tp.Size, tp.Size))
+	sd.InsertFloat("XStep", float32(tp.Size))
+	sd.InsertFloat("YStep", float32(tp.Size))
+	sd.Insert("Resources", types.Dict{})
+	sd.Insert("Matrix", matrixArray(m1))
+
+	if err := sd.Encode(); err != nil {
+		return nil, err
+	}
+
+	return xRefTable.IndRefForNewObject(*sd)
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"math"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/color"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+func floats(a types.Array) []float64 {
+	ff := make([]float64, len(a))
+	for i, o := range a {
+		ff[i] = float64(o.(types.Float))
+	}
+	return ff
+}
+
+func TestGradientShading(t *testing.T) {
+	for _, tt := range []struct {
+		angle float64
+		want  []float64
+	}{
+		{0, []float64{0, .5, 1, .5}},
+		{90, []float64{.5, 0, .5, 1}},
+		{45, []float64{0, 0, 1, 1}},
+		{180, []float64{1, .5, 0, .5}},
+	} {
+		g := Gradient{Colors: []color.SimpleColor{color.White, color.Black}, Angle: tt.angle}
+		d := g.shadingDict()
+		if st := d.IntEntry("ShadingType"); st == nil || *st != 2 {
+			t.Fatalf("angle %.0f: expected axial shading", tt.angle)
+		}
+		got := floats(d.ArrayEntry("Coords"))
+		for i := range got {
+			if math.Abs(got[i]-tt.want[i]) > 1e-9 {
+				t.Errorf("angle %.0f: expected coords %v, got %v", tt.angle, tt.want, got)
+				break
+			}
+		}
+		if ft := d.DictEntry("Function").IntEntry("FunctionType"); ft == nil || *ft != 2 {
+			t.Errorf("angle %.0f: expected exponential function", tt.angle)
+		}
+	}
+
+	g := Gradient{Radial: true, Colors: []color.SimpleColor{color.White, color.Red, color.Black}}
+	d := g.shadingDict()
+	if st := d.IntEntry("ShadingType"); st == nil || *st != 3 {
+		t.Fatal("expected radial shading")
+	}
+	f := d.DictEntry("Function")
+	if ft := f.IntEntry("FunctionType"); ft == nil || *ft != 3 {
+		t.Fatal("expected stitching function")
+	}
+	if n := len(f.ArrayEntry("Functions")); n != 2 {
+		t.Errorf("expected 2 functions, got %d", n)
+	}
+	if bb := floats(f.ArrayEntry("Bounds")); len(bb) != 1 || bb[0] != .5 {
+		t.Errorf("expected bounds [0.5], got %v", bb)
+	}
+}
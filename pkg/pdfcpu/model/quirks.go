@@ -0,0 +1,134 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// Quirk describes a deviation from the PDF specification written by certain PDF producers
+// which gets tolerated in relaxed validation mode.
+type Quirk struct {
+	Producers []string // Case insensitive substrings of the Producer or Creator of known culprits.
+	Generic   bool     // Tolerate for any producer, not just for known culprits.
+	Desc      string   // What gets written.
+}
+
+// Quirk ids referenced by validation.
+const (
+	QuirkNewerFeatures = "newerFeatures"
+	QuirkInfoDate      = "infoDate"
+	QuirkTrapped       = "trapped"
+	QuirkActionType    = "actionType"
+)
+
+// Quirks is the database of tolerated producer quirks keyed by quirk id.
+// New tolerances are added here and checked for using XRefTable.Tolerate.
+var Quirks = map[string]Quirk{
+	QuirkNewerFeatures: {
+		Producers: []string{"Quartz PDFContext"},
+		Desc:      "features newer than the PDF version of its header",
+	},
+	QuirkInfoDate: {
+		Producers: []string{"Quartz PDFContext", "Microsoft® Word"},
+		Generic:   true,
+		Desc:      "malformed info dict dates",
+	},
+	QuirkTrapped: {
+		Producers: []string{"Ghostscript"},
+		Generic:   true,
+		Desc:      "lower case or boolean info dict \"Trapped\" values",
+	},
+	QuirkActionType: {
+		Generic: true,
+		Desc:    "action dicts of type \"A\"",
+	},
+}
+
+// producerInfo returns the Producer and Creator of the document info dict.
+// Validation consults quirks before the info dict gets validated, hence this gets resolved on demand.
+func (xRefTable *XRefTable) producerInfo() string {
+	if xRefTable.producer != nil {
+		return *xRefTable.producer
+	}
+
+	ss := []string{}
+
+	if xRefTable.Info != nil {
+		if d, err := xRefTable.DereferenceDict(*xRefTable.Info); err == nil && d != nil {
+			for _, k := range []string{"Producer", "Creator"} {
+				if o, found := d.Find(k); found {
+					if s, err := xRefTable.DereferenceText(o); err == nil {
+						ss = append(ss, s)
+					}
+				}
+			}
+		}
+	}
+
+	s := strings.Join(ss, " ")
+	xRefTable.producer = &s
+
+	return s
+}
+
+// culprit returns the known culprit of q matching producer.
+func (q Quirk) culprit(producer string) string {
+	producer = strings.ToLower(producer)
+	for _, p := range q.Producers {
+		if strings.Contains(producer, strings.ToLower(p)) {
+			return p
+		}
+	}
+	return ""
+}
+
+// Tolerate returns true if the spec violation identified by quirk id gets tolerated.
+// This is the case in relaxed validation mode for generic quirks and for documents written by a known culprit.
+// Tolerated quirks are reported once per document.
+func (xRefTable *XRefTable) Tolerate(id string) bool {
+	if xRefTable.ValidationMode != ValidationRelaxed {
+		return false
+	}
+
+	q, ok := Quirks[id]
+	if !ok {
+		return false
+	}
+
+	culprit := q.culprit(xRefTable.producerInfo())
+	if culprit == "" && !q.Generic {
+		return false
+	}
+
+	if xRefTable.Tolerated == nil {
+		xRefTable.Tolerated = types.StringSet{}
+	}
+
+	if !xRefTable.Tolerated[id] {
+		xRefTable.Tolerated[id] = true
+		msg := q.Desc
+		if culprit != "" {
+			msg = culprit + " writes " + msg
+		}
+		ShowTolerated(msg)
+	}
+
+	return true
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+func xRefTableWithProducer(t *testing.T, producer string, validationMode int) *XRefTable {
+	t.Helper()
+
+	conf := NewDefaultConfiguration()
+	conf.ValidationMode = validationMode
+
+	xRefTable := newXRefTable(conf)
+	xRefTable.Table[0] = NewFreeHeadXRefTableEntry()
+	one := 1
+	xRefTable.Size = &one
+	v := V13
+	xRefTable.HeaderVersion = &v
+
+	ir, err := xRefTable.IndRefForNewObject(types.Dict{"Producer": types.StringLiteral(producer)})
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	xRefTable.Info = ir
+
+	return xRefTable
+}
+
+func TestQuirks(t *testing.T) {
+	for _, tt := range []struct {
+		producer       string
+		validationMode int
+		id             string
+		want           bool
+	}{
+		{"Mac OS X 10.15 Quartz PDFContext", ValidationRelaxed, QuirkNewerFeatures, true},
+		{"Mac OS X 10.15 Quartz PDFContext", ValidationStrict, QuirkNewerFeatures, false},
+		{"GPL Ghostscript 9.50", ValidationRelaxed, QuirkNewerFeatures, false},
+		{"GPL Ghostscript 9.50", ValidationRelaxed, QuirkTrapped, true},
+		{"pdfcpu", ValidationRelaxed, QuirkInfoDate, true},
+		{"pdfcpu", ValidationRelaxed, "unknown", false},
+	} {
+		xRefTable := xRefTableWithProducer(t, tt.producer, tt.validationMode)
+		if got := xRefTable.Tolerate(tt.id); got != tt.want {
+			t.Errorf("%s/%s: got %t want %t\n", tt.producer, tt.id, got, tt.want)
+		}
+		if tt.want && !xRefTable.Tolerated[tt.id] {
+			t.Errorf("%s/%s: missing tolerated quirk\n", tt.producer, tt.id)
+		}
+	}
+}
+
+func TestValidateVersionQuirk(t *testing.T) {
+	xRefTable := xRefTableWithProducer(t, "macOS Version 13.0 Quartz PDFContext", ValidationRelaxed)
+	if err := xRefTable.ValidateVersion("SMask", V14); err != nil {
+		t.Fatalf("Quartz: %v\n", err)
+	}
+
+	xRefTable = xRefTableWithProducer(t, "pdfcpu", ValidationRelaxed)
+	if err := xRefTable.ValidateVersion("SMask", V14); err == nil {
+		t.Fatalf("pdfcpu: missing version error\n")
+	}
+}
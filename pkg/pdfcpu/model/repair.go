@@ -18,12 +18,27 @@ package model
 
 import (
 	"fmt"
+	"sync/atomic"
 
 	"github.com/pdfcpu/pdfcpu/pkg/log"
 )
 
+// warningCount is the number of spec violations, repairs and other warnings reported by this process.
+var warningCount atomic.Int64
+
+// WarningCount returns the number of warnings reported since the last call of ResetWarnings.
+func WarningCount() int64 {
+	return warningCount.Load()
+}
+
+// ResetWarnings resets the warning counter.
+func ResetWarnings() {
+	warningCount.Store(0)
+}
+
 func ReportSpecViolation(xRefTable *XRefTable, err error) {
 	// TODO Apply across code base.
+	warningCount.Add(1)
 	pre := fmt.Sprintf("digesting spec violation around obj#(%d)", xRefTable.CurObj)
 	if log.DebugEnabled() {
 		log.Debug.Printf("%s: %v\n", pre, err)
@@ -40,6 +55,7 @@ func ReportSpecViolation(xRefTable *XRefTable, err error) {
 }
 
 func ShowRepaired(msg string) {
+	warningCount.Add(1)
 	msg = "repaired: " + msg
 	if log.DebugEnabled() {
 		log.Debug.Println("pdfcpu " + msg)
@@ -54,3 +70,29 @@ func ShowRepaired(msg string) {
 		log.CLI.Println(msg)
 	}
 }
+
+// ShowTolerated reports a tolerated spec violation.
+func ShowTolerated(msg string) {
+	warningCount.Add(1)
+	msg = "tolerated: " + msg
+	if log.DebugEnabled() {
+		log.Debug.Println("pdfcpu " + msg)
+	}
+	if log.ValidateEnabled() {
+		log.Validate.Println("pdfcpu " + msg)
+	}
+	if log.CLIEnabled() {
+		log.CLI.Println(msg)
+	}
+}
+
+// ShowWarning reports a non fatal problem encountered while processing.
+func ShowWarning(msg string) {
+	warningCount.Add(1)
+	if log.DebugEnabled() {
+		log.Debug.Println("pdfcpu warning: " + msg)
+	}
+	if log.CLIEnabled() {
+		log.CLI.Println("warning: " + msg)
+	}
+}
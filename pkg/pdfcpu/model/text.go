@@ -64,6 +64,36 @@ type TextDescriptor struct {
 	ShowMargins    bool                // Render margins in light gray.
 	ShowPosition   bool                // Highlight position.
 	HairCross      bool                // Draw haircross at X,Y
+	Exclusions     []Exclusion         // Areas kept free of justified text.
+}
+
+// Exclusion is a rectangular area within a justified text column the text flows around.
+// Left and Top are relative to the upper left corner of the column's text.
+type Exclusion struct {
+	Left, Top, Width, Height float64
+}
+
+// LineSpan returns the horizontal offset and width available for line i of a column of width w
+// with line height lh flowing around ee.
+func LineSpan(ee []Exclusion, lh, w float64, i int) (float64, float64) {
+	top, bot := float64(i)*lh, float64(i+1)*lh
+	x0, x1 := 0., w
+	for _, e := range ee {
+		if e.Top >= bot || e.Top+e.Height <= top {
+			continue
+		}
+		// Keep the wider side.
+		l, r := math.Min(x1, e.Left), math.Max(x0, e.Left+e.Width)
+		if l-x0 >= x1-r {
+			x1 = l
+		} else {
+			x0 = r
+		}
+	}
+	if x1 < x0 {
+		x1 = x0
+	}
+	return x0, x1 - x0
 }
 
 func deltaAlignMiddle(fontName string, fontSize, lines int, mTop, mBot float64) float64 {
@@ -175,9 +205,8 @@ func calcBoundingBoxForLines(lines []string, x, y float64, fontName string, font
 
 func PrepBytes(xRefTable *XRefTable, s, fontName string, embed, rtl, fillFont bool) string {
 	if font.IsUserFont(fontName) && !fillFont {
-		if rtl {
-			s = types.Reverse(s)
-		}
+		// Glyphs are shown left to right.
+		s = types.BidiVisual(s, rtl)
 		bb := []byte{}
 		if !embed {
 			for _, r := range s {
@@ -271,10 +300,18 @@ func prepJustifiedLine(xRefTable *XRefTable, lines *[]string, strbuf []string, s
 	*lines = append(*lines, sb.String())
 }
 
+// shiftLastLine moves the last prerendered line horizontally by dx.
+func shiftLastLine(lines []string, dx float64) {
+	if dx > 0 && len(lines) > 0 {
+		lines[len(lines)-1] = fmt.Sprintf("%.2f 0 Td %s", dx, lines[len(lines)-1])
+	}
+}
+
 func newPrepJustifiedString(
 	xRefTable *XRefTable,
 	fontName string,
-	fontSize int) func(lines *[]string, s string, w float64, fontName string, fontSize *int, lastline, parIndent, cjk, rtl bool) int {
+	fontSize int,
+	exclusions []Exclusion) func(lines *[]string, s string, w float64, fontName string, fontSize *int, lastline, parIndent, cjk, rtl bool) int {
 
 	// Not yet rendered content.
 	strbuf := []string{}
@@ -290,18 +327,30 @@ func newPrepJustifiedString(
 
 	blankWidth := font.TextWidth(" ", fontName, fontSize)
 
+	lh := font.LineHeight(fontName, fontSize)
+
 	return func(lines *[]string, s string, w float64, fontName string, fontSize *int, lastline, parIndent, embed, rtl bool) int {
 
+		// Offset and width of the current line.
+		lineSpan := func() (float64, float64) {
+			if len(exclusions) == 0 {
+				return 0, w
+			}
+			return LineSpan(exclusions, lh, w, len(*lines))
+		}
+
 		if len(s) == 0 {
 			if len(strbuf) > 0 {
+				x0, w1 := lineSpan()
 				s1 := PrepBytes(xRefTable, strings.Join(strbuf, " "), fontName, embed, rtl, false)
 				if rtl {
-					dx := font.GlyphSpaceUnits(w-strWidth, *fontSize)
+					dx := font.GlyphSpaceUnits(w1-strWidth, *fontSize)
 					s = fmt.Sprintf("[ %d (%s) ] TJ ", -int(dx), s1)
 				} else {
 					s = fmt.Sprintf("(%s) Tj", s1)
 				}
 				*lines = append(*lines, s)
+				shiftLastLine(*lines, x0)
 				strbuf = []string{}
 				strWidth = 0
 			}
@@ -321,30 +370,39 @@ func newPrepJustifiedString(
 			ss[0] = identPrefix + ss[0]
 		}
 
-		for _, s1 := range ss {
+		for i := 0; i < len(ss); i++ {
+			s1 := ss[i]
 			s1Width := font.TextWidth(s1, fontName, *fontSize)
 			bw := 0.
 			if len(strbuf) > 0 {
 				bw = blankWidth
 			}
-			if w-strWidth-(s1Width+bw) > 0 {
+			x0, w1 := lineSpan()
+			if w1-strWidth-(s1Width+bw) > 0 {
 				strWidth += s1Width + bw
 				strbuf = append(strbuf, s1)
 				continue
 			}
+			if len(strbuf) == 0 && w1 < w && s1Width < w {
+				// Skip a line narrowed by exclusions.
+				*lines = append(*lines, "")
+				i--
+				continue
+			}
 			// Ensure s1 fits into w.
 			fs := font.Size(s1, fontName, w)
 			if fs < *fontSize {
 				*fontSize = fs
 			}
 			if len(strbuf) == 0 {
-				prepJustifiedLine(xRefTable, lines, []string{s1}, s1Width, w, *fontSize, fontName, embed, rtl)
+				prepJustifiedLine(xRefTable, lines, []string{s1}, s1Width, w1, *fontSize, fontName, embed, rtl)
 			} else {
 				// Note: Previous lines have whitespace based on bigger font size.
-				prepJustifiedLine(xRefTable, lines, strbuf, strWidth, w, *fontSize, fontName, embed, rtl)
+				prepJustifiedLine(xRefTable, lines, strbuf, strWidth, w1, *fontSize, fontName, embed, rtl)
 				strbuf = []string{s1}
 				strWidth = s1Width
 			}
+			shiftLastLine(*lines, x0)
 			linefeeds++
 			indent = false
 		}
@@ -374,7 +432,7 @@ func preRenderJustifiedText(
 		}
 	}
 	ww -= mLeft + mRight + 2*borderWidth
-	prepJustifiedString := newPrepJustifiedString(xRefTable, td.FontName, *fontSize)
+	prepJustifiedString := newPrepJustifiedString(xRefTable, td.FontName, *fontSize, td.Exclusions)
 	l := []string{}
 	for i, s := range *lines {
 		linefeeds := prepJustifiedString(&l, s, ww, td.FontName, fontSize, false, td.ParIndent, td.Embed, td.RTL)
@@ -631,6 +689,17 @@ func SplitMultilineStr(s string) []string {
 // Enforce a desired column width by supplying a width > 0 (especially useful for justified text).
 // It returns the bounding box of this column.
 func WriteColumn(xRefTable *XRefTable, w io.Writer, mediaBox, region *types.Rectangle, td TextDescriptor, width float64) *types.Rectangle {
+	colBB, _ := writeColumn(xRefTable, w, mediaBox, region, td, width)
+	return colBB
+}
+
+// WriteColumnQuad writes a text column like WriteColumn
+// and additionally returns the area covered on the page taking any rotation into account.
+func WriteColumnQuad(xRefTable *XRefTable, w io.Writer, mediaBox, region *types.Rectangle, td TextDescriptor, width float64) (*types.Rectangle, types.QuadLiteral) {
+	return writeColumn(xRefTable, w, mediaBox, region, td, width)
+}
+
+func writeColumn(xRefTable *XRefTable, w io.Writer, mediaBox, region *types.Rectangle, td TextDescriptor, width float64) (*types.Rectangle, types.QuadLiteral) {
 	x, y, dx, dy := td.X, td.Y, td.Dx, td.Dy
 	mTop, mBot, mLeft, mRight := td.MTop, td.MBot, td.MLeft, td.MRight
 	s, fontSize, borderWidth := td.Text, td.FontSize, td.BorderWidth
@@ -695,6 +764,14 @@ func WriteColumn(xRefTable *XRefTable, w io.Writer, mediaBox, region *types.Rect
 	m := matrix.CalcRotateTransformMatrix(td.Rotation, colBB)
 	fmt.Fprintf(w, "%.5f %.5f %.5f %.5f %.5f %.5f cm ", m[0][0], m[0][1], m[1][0], m[1][1], m[2][0], m[2][1])
 
+	bbw, bbh := colBB.Width(), colBB.Height()
+	ql := types.QuadLiteral{
+		P1: m.Transform(types.Point{X: 0, Y: 0}),
+		P2: m.Transform(types.Point{X: bbw, Y: 0}),
+		P3: m.Transform(types.Point{X: bbw, Y: bbh}),
+		P4: m.Transform(types.Point{X: 0, Y: bbh}),
+	}
+
 	x -= colBB.LL.X
 	y -= colBB.LL.Y
 	colBB.Translate(-colBB.LL.X, -colBB.LL.Y)
@@ -722,7 +799,7 @@ func WriteColumn(xRefTable *XRefTable, w io.Writer, mediaBox, region *types.Rect
 		draw.DrawCircle(w, x0, y0, 5, color.Black, &color.Red)
 	}
 
-	return colBB
+	return colBB, ql
 }
 
 // WriteMultiLine writes s at position x/y using a certain font, fontsize and a desired horizontal and vertical alignment.
@@ -0,0 +1,274 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pdfcpu/pdfcpu/pkg/font"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// TextPath is a baseline for text rendered glyph by glyph,
+// either an arc around the origin or a cubic Bezier curve.
+type TextPath struct {
+	Arc        bool
+	Radius     float64        // Arc radius.
+	Start, End float64        // Arc start and end angle in degrees, counterclockwise with 0 pointing to the right.
+	Curve      [4]types.Point // Bezier curve start point, control points and end point.
+}
+
+// ParseTextPath parses a text path given by "arc radius [startAngle endAngle]" or "curve x0 y0 x1 y1 x2 y2 x3 y3".
+// Arcs default to the upper half circle running clockwise as used for round seals.
+func ParseTextPath(s string, u types.DisplayUnit) (*TextPath, error) {
+	ss := strings.Fields(s)
+	if len(ss) == 0 {
+		return nil, errors.New("pdfcpu: missing text path")
+	}
+
+	ff := make([]float64, len(ss)-1)
+	for i, s := range ss[1:] {
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, errors.Errorf("pdfcpu: invalid text path value: %s", s)
+		}
+		ff[i] = f
+	}
+
+	switch strings.ToLower(ss[0]) {
+
+	case "arc":
+		if len(ff) != 1 && len(ff) != 3 {
+			return nil, errors.New("pdfcpu: text path arc: need radius and optional start and end angle")
+		}
+		if ff[0] <= 0 {
+			return nil, errors.New("pdfcpu: text path arc: radius must be positive")
+		}
+		tp := &TextPath{Arc: true, Radius: types.ToUserSpace(ff[0], u), Start: 180, End: 0}
+		if len(ff) == 3 {
+			tp.Start, tp.End = ff[1], ff[2]
+			if tp.Start == tp.End {
+				return nil, errors.New("pdfcpu: text path arc: start and end angle must differ")
+			}
+		}
+		return tp, nil
+
+	case "curve":
+		if len(ff) != 8 {
+			return nil, errors.New("pdfcpu: text path curve: need 4 points")
+		}
+		tp := &TextPath{}
+		for i := 0; i < 4; i++ {
+			tp.Curve[i] = types.Point{X: types.ToUserSpace(ff[2*i], u), Y: types.ToUserSpace(ff[2*i+1], u)}
+		}
+		return tp, nil
+	}
+
+	return nil, errors.Errorf("pdfcpu: unknown text path: %s (arc, curve)", ss[0])
+}
+
+// polyline approximates tp.
+func (tp TextPath) polyline() []types.Point {
+	const n = 256
+	pp := make([]types.Point, n+1)
+
+	if tp.Arc {
+		a0, a1 := tp.Start*math.Pi/180, tp.End*math.Pi/180
+		for i := 0; i <= n; i++ {
+			a := a0 + (a1-a0)*float64(i)/n
+			pp[i] = types.Point{X: tp.Radius * math.Cos(a), Y: tp.Radius * math.Sin(a)}
+		}
+		return pp
+	}
+
+	c := tp.Curve
+	for i := 0; i <= n; i++ {
+		t := float64(i) / n
+		b0, b1, b2, b3 := (1-t)*(1-t)*(1-t), 3*t*(1-t)*(1-t), 3*t*t*(1-t), t*t*t
+		pp[i] = types.Point{
+			X: b0*c[0].X + b1*c[1].X + b2*c[2].X + b3*c[3].X,
+			Y: b0*c[0].Y + b1*c[1].Y + b2*c[2].Y + b3*c[3].Y,
+		}
+	}
+	return pp
+}
+
+// pathWalker locates points on a polyline by distance from its start.
+type pathWalker struct {
+	pp  []types.Point
+	acc []float64 // Accumulated segment lengths.
+}
+
+func newPathWalker(pp []types.Point) *pathWalker {
+	acc := make([]float64, len(pp))
+	for i := 1; i < len(pp); i++ {
+		acc[i] = acc[i-1] + math.Hypot(pp[i].X-pp[i-1].X, pp[i].Y-pp[i-1].Y)
+	}
+	return &pathWalker{pp: pp, acc: acc}
+}
+
+func (pw *pathWalker) length() float64 {
+	return pw.acc[len(pw.acc)-1]
+}
+
+// at returns the point at distance d and the angle of the tangent.
+// Distances outside the path extend its first or last segment.
+func (pw *pathWalker) at(d float64) (types.Point, float64) {
+	i := 1
+	for i < len(pw.acc)-1 && pw.acc[i] < d {
+		i++
+	}
+	p0, p1 := pw.pp[i-1], pw.pp[i]
+	l := pw.acc[i] - pw.acc[i-1]
+	a := math.Atan2(p1.Y-p0.Y, p1.X-p0.X)
+	if l == 0 {
+		return p0, a
+	}
+	t := (d - pw.acc[i-1]) / l
+	return types.Point{X: p0.X + t*(p1.X-p0.X), Y: p0.Y + t*(p1.Y-p0.Y)}, a
+}
+
+type pathGlyph struct {
+	s              string
+	x, y, cos, sin float64
+}
+
+// textPathGlyphs returns the glyphs of the first line of td.Text placed along tp, the font size and their bounding box.
+func textPathGlyphs(td TextDescriptor, tp TextPath) ([]pathGlyph, int, *types.Rectangle) {
+	var s string
+	if lines := SplitMultilineStr(td.Text); len(lines) > 0 {
+		s = lines[0]
+	}
+
+	var gg []string
+	if font.IsCoreFont(td.FontName) {
+		if utf8.ValidString(s) {
+			s = DecodeUTF8ToByte(s)
+		}
+		for i := 0; i < len(s); i++ {
+			gg = append(gg, s[i:i+1])
+		}
+	} else {
+		// Glyphs are shown left to right.
+		for _, r := range types.BidiVisual(s, td.RTL) {
+			gg = append(gg, string(r))
+		}
+	}
+
+	pw := newPathWalker(tp.polyline())
+
+	fontSize := td.FontSize
+	if td.ScaleAbs {
+		fontSize = int(float64(fontSize) * td.Scale)
+	} else if w := font.TextWidth(s, td.FontName, fontSize); w > 0 {
+		scale := td.Scale
+		if scale > 1 {
+			scale = 1
+		}
+		fontSize = int(pw.length() * scale * float64(fontSize) / w)
+	}
+
+	ww := make([]float64, len(gg))
+	var w float64
+	for i, g := range gg {
+		ww[i] = font.TextWidth(g, td.FontName, fontSize)
+		w += ww[i]
+	}
+
+	// Align text along the path.
+	var d float64
+	switch td.HAlign {
+	case types.AlignCenter, types.AlignJustify:
+		d = (pw.length() - w) / 2
+	case types.AlignRight:
+		d = pw.length() - w
+	}
+
+	asc, desc := font.Ascent(td.FontName, fontSize), font.Descent(td.FontName, fontSize)
+
+	var bb *types.Rectangle
+	glyphs := make([]pathGlyph, len(gg))
+
+	for i, g := range gg {
+		p, a := pw.at(d + ww[i]/2)
+		cos, sin := math.Cos(a), math.Sin(a)
+
+		// Glyph origin
+		x, y := p.X-ww[i]/2*cos, p.Y-ww[i]/2*sin
+
+		glyphs[i] = pathGlyph{s: g, x: x, y: y, cos: cos, sin: sin}
+
+		for _, c := range [][2]float64{{0, -desc}, {ww[i], -desc}, {ww[i], asc}, {0, asc}} {
+			px, py := x+c[0]*cos-c[1]*sin, y+c[0]*sin+c[1]*cos
+			if bb == nil {
+				bb = types.NewRectangle(px, py, px, py)
+				continue
+			}
+			bb.LL.X, bb.LL.Y = math.Min(bb.LL.X, px), math.Min(bb.LL.Y, py)
+			bb.UR.X, bb.UR.Y = math.Max(bb.UR.X, px), math.Max(bb.UR.Y, py)
+		}
+
+		d += ww[i]
+	}
+
+	if bb == nil {
+		bb = types.NewRectangle(0, 0, 0, 0)
+	}
+
+	return glyphs, fontSize, bb
+}
+
+// TextPathBoundingBox returns the bounding box of the first line of td.Text rendered along tp relative to the origin of tp.
+func TextPathBoundingBox(td TextDescriptor, tp TextPath) *types.Rectangle {
+	_, _, bb := textPathGlyphs(td, tp)
+	return bb
+}
+
+// WriteTextOnPath renders the first line of td.Text glyph by glyph along tp with the origin of tp at td.X, td.Y.
+// The font size is implied by td.Scale relative to the path length unless td.ScaleAbs is set.
+// It returns the bounding box of the rendered text.
+func WriteTextOnPath(xRefTable *XRefTable, w io.Writer, td TextDescriptor, tp TextPath) *types.Rectangle {
+	glyphs, fontSize, bb := textPathGlyphs(td, tp)
+	bb.Translate(td.X, td.Y)
+
+	fmt.Fprint(w, "q ")
+
+	if td.ShowTextBB {
+		renderBackgroundAndBorder(w, td, td.BorderWidth, bb)
+	}
+
+	setFont(w, td.FontKey, float32(fontSize))
+
+	fmt.Fprintf(w, "1 0 0 1 %.2f %.2f cm %.2f %.2f %.2f RG %.2f %.2f %.2f rg ",
+		td.X, td.Y, td.StrokeCol.R, td.StrokeCol.G, td.StrokeCol.B, td.FillCol.R, td.FillCol.G, td.FillCol.B)
+
+	for _, g := range glyphs {
+		s := PrepBytes(xRefTable, g.s, td.FontName, td.Embed, false, false)
+		fmt.Fprintf(w, "BT %d Tr %.5f %.5f %.5f %.5f %.2f %.2f Tm (%s) Tj ET ",
+			td.RMode, g.cos, g.sin, -g.sin, g.cos, g.x, g.y, s)
+	}
+
+	fmt.Fprint(w, "Q ")
+
+	return bb
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"math"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+func TestParseTextPath(t *testing.T) {
+	for _, s := range []string{"", "arc", "arc 0", "arc 10 90", "arc 10 90 90", "curve 0 0 1 1", "line 0 0 1 1", "arc x"} {
+		if _, err := ParseTextPath(s, types.POINTS); err == nil {
+			t.Errorf("ParseTextPath(%q): expected error", s)
+		}
+	}
+
+	tp, err := ParseTextPath("arc 1 0 90", types.INCHES)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !tp.Arc || tp.Radius != 72 || tp.Start != 0 || tp.End != 90 {
+		t.Errorf("ParseTextPath: unexpected arc %v", tp)
+	}
+
+	tp, err = ParseTextPath("curve 0 0 10 10 20 10 30 0", types.POINTS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tp.Arc || tp.Curve[3] != (types.Point{X: 30, Y: 0}) {
+		t.Errorf("ParseTextPath: unexpected curve %v", tp)
+	}
+}
+
+func TestTextPathWalker(t *testing.T) {
+	tp := TextPath{Arc: true, Radius: 100, Start: 180, End: 0}
+	pw := newPathWalker(tp.polyline())
+
+	if l := pw.length(); math.Abs(l-100*math.Pi) > .1 {
+		t.Errorf("arc length: expected %.2f, got %.2f", 100*math.Pi, l)
+	}
+
+	// The top of the upper half circle running clockwise points to the right.
+	p, a := pw.at(pw.length() / 2)
+	if math.Abs(p.X) > .1 || math.Abs(p.Y-100) > .1 || math.Abs(a) > .02 {
+		t.Errorf("arc center: unexpected point %v angle %.2f", p, a)
+	}
+}
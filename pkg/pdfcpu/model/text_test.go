@@ -0,0 +1,43 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import "testing"
+
+func TestLineSpan(t *testing.T) {
+	ee := []Exclusion{
+		{Left: 0, Top: 0, Width: 100, Height: 25},    // floats left
+		{Left: 250, Top: 30, Width: 50, Height: 10},  // floats right
+		{Left: 120, Top: 100, Width: 20, Height: 10}, // centered, text keeps the wider side
+	}
+
+	for _, tt := range []struct {
+		line  int
+		x0, w float64
+	}{
+		{0, 100, 200},
+		{2, 100, 200},
+		{3, 0, 250},
+		{4, 0, 300},
+		{10, 140, 160},
+	} {
+		x0, w := LineSpan(ee, 10, 300, tt.line)
+		if x0 != tt.x0 || w != tt.w {
+			t.Errorf("line %d: got %.0f/%.0f, want %.0f/%.0f", tt.line, x0, w, tt.x0, tt.w)
+		}
+	}
+}
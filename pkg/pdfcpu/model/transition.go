@@ -0,0 +1,146 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// Transition represents the transition effect used when moving to a page during presentations
+// along with the maximum display duration of that page.
+type Transition struct {
+	Style     string  // Split, Blinds, Box, Wipe, Dissolve, Glitter, R, Fly, Push, Cover, Uncover, Fade
+	Duration  float64 // transition duration in seconds
+	Dimension string  // Split, Blinds: H|V
+	Motion    string  // Split, Box, Fly: I|O
+	Direction int     // Wipe, Glitter, Fly, Cover, Uncover, Push: 0, 90, 180, 270, 315, -1 = none (Fly)
+	Display   float64 // max page display duration in seconds (/Dur), 0 = no automatic advance
+}
+
+// TransitionStyles lists the supported transition styles.
+var TransitionStyles = []string{"Split", "Blinds", "Box", "Wipe", "Dissolve", "Glitter", "R", "Fly", "Push", "Cover", "Uncover", "Fade"}
+
+// NeedsV15 returns true if the transition style got introduced with PDF 1.5.
+func (t Transition) NeedsV15() bool {
+	return types.MemberOf(t.Style, []string{"Fly", "Push", "Cover", "Uncover", "Fade"})
+}
+
+func parseTransitionStyle(s string, t *Transition) error {
+	if strings.ToLower(s) == "replace" {
+		s = "R"
+	}
+	for _, style := range TransitionStyles {
+		if strings.EqualFold(s, style) {
+			t.Style = style
+			return nil
+		}
+	}
+	return errors.Errorf("pdfcpu: invalid transition style: %s, please provide one of: %s", s, strings.Join(TransitionStyles, ", "))
+}
+
+func parseTransitionDuration(s string, t *Transition) error {
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || f <= 0 {
+		return errors.Errorf("pdfcpu: transition \"duration\" must be a positive numeric value, got %s\n", s)
+	}
+	t.Duration = f
+	return nil
+}
+
+func parseTransitionDimension(s string, t *Transition) error {
+	switch strings.ToLower(s) {
+	case "h", "horizontal":
+		t.Dimension = "H"
+	case "v", "vertical":
+		t.Dimension = "V"
+	default:
+		return errors.New("pdfcpu: transition dimension, please provide one of: h(orizontal) v(ertical)")
+	}
+	return nil
+}
+
+func parseTransitionMotion(s string, t *Transition) error {
+	switch strings.ToLower(s) {
+	case "i", "inward":
+		t.Motion = "I"
+	case "o", "outward":
+		t.Motion = "O"
+	default:
+		return errors.New("pdfcpu: transition motion, please provide one of: i(nward) o(utward)")
+	}
+	return nil
+}
+
+func parseTransitionDirection(s string, t *Transition) error {
+	if strings.ToLower(s) == "none" {
+		t.Direction = -1
+		return nil
+	}
+	i, err := strconv.Atoi(s)
+	if err != nil || !types.IntMemberOf(i, []int{0, 90, 180, 270, 315}) {
+		return errors.New("pdfcpu: transition direction, please provide one of: 0, 90, 180, 270, 315, none")
+	}
+	t.Direction = i
+	return nil
+}
+
+func parseTransitionDisplay(s string, t *Transition) error {
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || f < 0 {
+		return errors.Errorf("pdfcpu: transition \"display\" must be a non negative numeric value, got %s\n", s)
+	}
+	t.Display = f
+	return nil
+}
+
+type transitionParameterMap map[string]func(string, *Transition) error
+
+// TransitionParamMap maps transition parameter prefixes to their parse functions.
+var TransitionParamMap = transitionParameterMap{
+	"style":     parseTransitionStyle,
+	"duration":  parseTransitionDuration,
+	"dimension": parseTransitionDimension,
+	"motion":    parseTransitionMotion,
+	"angle":     parseTransitionDirection,
+	"display":   parseTransitionDisplay,
+}
+
+// Handle applies parameter completion and on success parse parameter values into t.
+func (m transitionParameterMap) Handle(paramPrefix, paramValueStr string, t *Transition) error {
+	var param string
+
+	// Completion support
+	for k := range m {
+		if !strings.HasPrefix(k, strings.ToLower(paramPrefix)) {
+			continue
+		}
+		if len(param) > 0 {
+			return errors.Errorf("pdfcpu: ambiguous parameter prefix \"%s\"", paramPrefix)
+		}
+		param = k
+	}
+
+	if param == "" {
+		return errors.Errorf("pdfcpu: unknown parameter prefix \"%s\"", paramPrefix)
+	}
+
+	return m[param](paramValueStr, t)
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"strconv"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// FormResource represents a form XObject painted by page content.
+type FormResource struct {
+	Res  Resource
+	Dict types.Dict // Shares the page resources once the page gets created.
+}
+
+// ExtGState registers a graphics state parameter dict for fill alpha ca and stroke alpha CA
+// as page resource and returns its resource id.
+func (p *Page) ExtGState(ca, CA float64) string {
+	for id, o := range p.Gm {
+		d := o.(types.Dict)
+		if f, ok := d["ca"].(types.Float); ok && f.Value() == ca {
+			if f, ok := d["CA"].(types.Float); ok && f.Value() == CA {
+				return id
+			}
+		}
+	}
+
+	if p.Gm == nil {
+		p.Gm = types.Dict{}
+	}
+
+	id := "GS" + strconv.Itoa(len(p.Gm))
+	p.Gm[id] = types.Dict(
+		map[string]types.Object{
+			"Type": types.Name("ExtGState"),
+			"ca":   types.Float(ca),
+			"CA":   types.Float(CA),
+		},
+	)
+
+	return id
+}
+
+// AddTransparencyGroup registers content as isolated transparency group (see 11.6.6)
+// covering bb as page resource and returns its resource id.
+// The group shares the page resources.
+func (p *Page) AddTransparencyGroup(xRefTable *XRefTable, bb *types.Rectangle, content []byte) (string, error) {
+	sd, err := xRefTable.NewStreamDictForBuf(content)
+	if err != nil {
+		return "", err
+	}
+
+	sd.InsertName("Type", "XObject")
+	sd.InsertName("Subtype", "Form")
+	sd.Insert("BBox", bb.Array())
+	sd.Insert("Group", types.Dict(
+		map[string]types.Object{
+			"Type": types.Name("Group"),
+			"S":    types.Name("Transparency"),
+			"I":    types.Boolean(true),
+		},
+	))
+
+	if err := sd.Encode(); err != nil {
+		return "", err
+	}
+
+	indRef, err := xRefTable.IndRefForNewObject(*sd)
+	if err != nil {
+		return "", err
+	}
+
+	id := "Fm" + strconv.Itoa(len(p.Forms))
+	p.Forms = append(p.Forms, FormResource{Res: Resource{ID: id, IndRef: indRef}, Dict: sd.Dict})
+
+	return id, nil
+}
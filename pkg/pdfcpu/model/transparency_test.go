@@ -0,0 +1,34 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import "testing"
+
+func TestPageExtGState(t *testing.T) {
+	var p Page
+
+	id := p.ExtGState(.5, 1)
+	if id1 := p.ExtGState(.5, 1); id1 != id {
+		t.Errorf("expected reuse of %s, got %s", id, id1)
+	}
+	if id1 := p.ExtGState(1, .5); id1 == id {
+		t.Errorf("expected new ExtGState for different alpha, got %s", id1)
+	}
+	if len(p.Gm) != 2 {
+		t.Errorf("expected 2 ExtGStates, got %d", len(p.Gm))
+	}
+}
@@ -0,0 +1,225 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// ErrNoUsageRights indicates a document without usage rights signature.
+var ErrNoUsageRights = errors.New("pdfcpu: no usage rights available")
+
+// UsageRights represents a usage rights signature (UR3) enabling additional features in Adobe Reader,
+// see 12.8.2.3 UR Transform Method (PDF 1.7).
+// Any modification of the document not covered by the granted rights invalidates the signature.
+type UsageRights struct {
+	Legacy     bool     `json:"legacy"`              // UR instead of UR3 (deprecated)
+	Signer     string   `json:"signer,omitempty"`    // Name of the signer
+	Date       string   `json:"date,omitempty"`      // Time of signing
+	Document   []string `json:"document,omitempty"`  // eg. FullSave
+	Form       []string `json:"form,omitempty"`      // eg. Add, Delete, FillIn, Import, Export, SubmitStandalone, SpawnTemplate, BarcodePlaintext, Online
+	Annots     []string `json:"annots,omitempty"`    // eg. Create, Delete, Modify, Copy, Import, Export, Online, SummaryView
+	Signature  []string `json:"signature,omitempty"` // eg. Modify
+	EF         []string `json:"ef,omitempty"`        // eg. Create, Delete, Modify, Import
+	Msg        string   `json:"msg,omitempty"`       // Message displayed by the viewer
+	Restricted bool     `json:"restricted"`          // P: true if granted permissions are more restrictive.
+}
+
+// String returns a string representation of ur.
+func (ur UsageRights) String() string {
+	var sb strings.Builder
+	typ := "UR3"
+	if ur.Legacy {
+		typ = "UR"
+	}
+	fmt.Fprintf(&sb, "Usage rights (%s):\n", typ)
+	for _, e := range []struct{ k, v string }{
+		{"Signer", ur.Signer},
+		{"Date", ur.Date},
+		{"Document", strings.Join(ur.Document, ", ")},
+		{"Form", strings.Join(ur.Form, ", ")},
+		{"Annots", strings.Join(ur.Annots, ", ")},
+		{"Signature", strings.Join(ur.Signature, ", ")},
+		{"EF", strings.Join(ur.EF, ", ")},
+		{"Msg", ur.Msg},
+	} {
+		if e.v != "" {
+			fmt.Fprintf(&sb, "%12s: %s\n", e.k, e.v)
+		}
+	}
+	fmt.Fprintf(&sb, "%12s: %t", "Restricted", ur.Restricted)
+	return sb.String()
+}
+
+func (xRefTable *XRefTable) permsDict() (types.Dict, error) {
+	if xRefTable.RootDict == nil {
+		return nil, nil
+	}
+	o, found := xRefTable.RootDict.Find("Perms")
+	if !found {
+		return nil, nil
+	}
+	return xRefTable.DereferenceDict(o)
+}
+
+func (xRefTable *XRefTable) nameArrayEntry(d types.Dict, key string) ([]string, error) {
+	o, found := d.Find(key)
+	if !found {
+		return nil, nil
+	}
+	a, err := xRefTable.DereferenceArray(o)
+	if err != nil {
+		return nil, err
+	}
+	var ss []string
+	for _, o := range a {
+		n, err := xRefTable.DereferenceName(o, V10, nil)
+		if err != nil {
+			return nil, err
+		}
+		ss = append(ss, n.Value())
+	}
+	return ss, nil
+}
+
+func (xRefTable *XRefTable) usageRightsTransformParams(sigDict types.Dict, ur *UsageRights) error {
+	o, found := sigDict.Find("Reference")
+	if !found {
+		return nil
+	}
+
+	a, err := xRefTable.DereferenceArray(o)
+	if err != nil {
+		return err
+	}
+
+	for _, o := range a {
+		d, err := xRefTable.DereferenceDict(o)
+		if err != nil || d == nil {
+			return err
+		}
+
+		tm := d.NameEntry("TransformMethod")
+		if tm == nil || (*tm != "UR3" && *tm != "UR") {
+			continue
+		}
+
+		params, err := xRefTable.DereferenceDict(d["TransformParams"])
+		if err != nil || params == nil {
+			return err
+		}
+
+		for _, e := range []struct {
+			key string
+			ss  *[]string
+		}{
+			{"Document", &ur.Document},
+			{"Form", &ur.Form},
+			{"Annots", &ur.Annots},
+			{"Signature", &ur.Signature},
+			{"EF", &ur.EF},
+		} {
+			if *e.ss, err = xRefTable.nameArrayEntry(params, e.key); err != nil {
+				return err
+			}
+		}
+
+		if ur.Msg, err = xRefTable.DereferenceStringOrHexLiteral(params["Msg"], V10, nil); err != nil {
+			return err
+		}
+
+		if b := params.BooleanEntry("P"); b != nil {
+			ur.Restricted = *b
+		}
+
+		return nil
+	}
+
+	return nil
+}
+
+// UsageRights returns the usage rights signature of xRefTable or nil.
+func (xRefTable *XRefTable) UsageRights() (*UsageRights, error) {
+	d, err := xRefTable.permsDict()
+	if err != nil || d == nil {
+		return nil, err
+	}
+
+	ur := &UsageRights{}
+
+	o, found := d.Find("UR3")
+	if !found {
+		if o, found = d.Find("UR"); !found {
+			return nil, nil
+		}
+		ur.Legacy = true
+	}
+
+	sigDict, err := xRefTable.DereferenceDict(o)
+	if err != nil || sigDict == nil {
+		return nil, err
+	}
+
+	if ur.Signer, err = xRefTable.DereferenceStringOrHexLiteral(sigDict["Name"], V10, nil); err != nil {
+		return nil, err
+	}
+
+	if ur.Date, err = xRefTable.DereferenceStringOrHexLiteral(sigDict["M"], V10, nil); err != nil {
+		return nil, err
+	}
+	if t, ok := types.DateTime(ur.Date, true); ok {
+		ur.Date = t.Format("2006-01-02 15:04:05 MST")
+	}
+
+	if err := xRefTable.usageRightsTransformParams(sigDict, ur); err != nil {
+		return nil, err
+	}
+
+	return ur, nil
+}
+
+// RemoveUsageRights removes the usage rights signature of xRefTable and returns true if there was one.
+func (xRefTable *XRefTable) RemoveUsageRights() (bool, error) {
+	d, err := xRefTable.permsDict()
+	if err != nil || d == nil {
+		return false, err
+	}
+
+	_, ur3 := d.Find("UR3")
+	_, ur := d.Find("UR")
+	if !ur3 && !ur {
+		return false, nil
+	}
+
+	if log.CLIEnabled() {
+		log.CLI.Println("removing usage rights...")
+	}
+
+	delete(d, "UR3")
+	delete(d, "UR")
+
+	if len(d) == 0 {
+		delete(xRefTable.RootDict, "Perms")
+	}
+
+	return true, nil
+}
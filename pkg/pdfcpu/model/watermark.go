@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"strings"
 
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/color"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/draw"
@@ -46,8 +47,23 @@ const (
 	WMPDF
 )
 
+// PDF multi stamp modes defining how to proceed once all source pages have been used.
+const (
+	PdfMultiRepeat = iota // repeat the last source page.
+	PdfMultiCycle         // start over with the first source page.
+	PdfMultiOnce          // leave remaining pages untouched.
+)
+
 type formCache map[types.Rectangle]*types.IndirectRef
 
+// tileKey identifies a tiled form by the form being repeated and the view port covered.
+type tileKey struct {
+	form types.IndirectRef
+	vp   types.Rectangle
+}
+
+type tileCache map[tileKey]*types.IndirectRef
+
 type PdfResources struct {
 	Content []byte
 	ResDict *types.IndirectRef
@@ -57,6 +73,7 @@ type PdfResources struct {
 // Watermark represents the basic structure and command details for the commands "Stamp" and "Watermark".
 type Watermark struct {
 	OnTop                     bool                // if true STAMP else WATERMARK.
+	ID                        string              // optional identifier for selective updates and removal.
 	Mode                      int                 // WMText, WMImage or WMPDF
 	FileName                  string              // image or PDF file name
 	Image                     io.Reader           // image reader
@@ -73,6 +90,7 @@ type Watermark struct {
 	ScaledFontSize            int                 // font scaling factor for a specific page
 	ScriptName                string              // ISO 15924: Hans, Hant, Hira, Kana, Jpan, Hang, Kore: if set, font will not be embedded.
 	RTL                       bool                // if true, render text from right to left
+	TextPath                  *TextPath           // if set, render the first line of text glyph by glyph along this path.
 	Color                     color.SimpleColor   // text fill color(=non stroking color) for backwards compatibility.
 	FillColor                 color.SimpleColor   // text fill color(=non stroking color).
 	StrokeColor               color.SimpleColor   // text stroking color
@@ -86,11 +104,16 @@ type Watermark struct {
 	Diagonal                  int                 // paint along the diagonal.
 	UserRotOrDiagonal         bool                // true if one of rotation or diagonal provided overriding the default.
 	Opacity                   float64             // opacity of the watermark. 0 <= x <= 1
+	BlendMode                 string              // blend mode used for compositing with the page, eg. Multiply.
 	RenderMode                draw.RenderMode     // fill=0, stroke=1 fill&stroke=2
 	Scale                     float64             // relative scale factor: 0 <= x <= 1, absolute scale factor: 0 <= x
 	ScaleEff                  float64             // effective scale factor
 	ScaleAbs                  bool                // true for absolute scaling.
 	Update                    bool                // true for updating instead of adding a page watermark.
+	PageBox                   string              // page box used for positioning and scaling: media or crop.
+	KeepPageRot               bool                // if true, retain page rotation and render upright instead of internalizing it.
+	Tile                      bool                // if true, repeat the watermark across the page.
+	TileDx, TileDy            float64             // horizontal and vertical gap between tiles, defaults to the tile height.
 	Ocg, ExtGState, Font, Img *types.IndirectRef  // resources
 	Width, Height             int                 // image or page dimensions
 
@@ -100,17 +123,52 @@ type Watermark struct {
 	PdfPageNrSrc            int                  // page number of the source PDF file serving as stamp provider, 0 for multi stamping
 	PdfMultiStartPageNrSrc  int                  // start page number of the source PDF file serving as stamp provider.
 	PdfMultiStartPageNrDest int                  // start page number of the destination PDF file.
+	PdfMultiMode            int                  // PdfMultiRepeat, PdfMultiCycle or PdfMultiOnce
 
 	// page specific
-	Bb      *types.Rectangle   // bounding box of the form representing this watermark.
-	BbTrans types.QuadLiteral  // Transformed bounding box.
-	Vp      *types.Rectangle   // view port, page dimensions.
-	PageRot int                // page rotation in effect.
-	Form    *types.IndirectRef // form dependent on given page dimensions.
+	Bb       *types.Rectangle   // bounding box of the form representing this watermark.
+	BbTrans  types.QuadLiteral  // Transformed bounding box.
+	Vp       *types.Rectangle   // view port, page dimensions.
+	PageRot  int                // page rotation to be internalized.
+	PageRotM matrix.Matrix      // internalizes page rotation into the page content.
+	PageM    *matrix.Matrix     // maps the upright view port into user space of a page with retained rotation.
+	Form     *types.IndirectRef // form dependent on given page dimensions.
 
 	// house keeping
 	Objs   types.IntSet // objects for which wm has been applied already.
 	FCache formCache    // form cache.
+	TCache tileCache    // tiled form cache.
+}
+
+// blendModes are the standard separable and non-separable blend modes.
+var blendModes = []string{
+	"Normal", "Multiply", "Screen", "Overlay", "Darken", "Lighten", "ColorDodge", "ColorBurn",
+	"HardLight", "SoftLight", "Difference", "Exclusion", "Hue", "Saturation", "Color", "Luminosity",
+}
+
+// BlendMode returns the canonical name of blend mode s.
+func BlendMode(s string) (string, bool) {
+	for _, bm := range blendModes {
+		if strings.EqualFold(bm, s) {
+			return bm, true
+		}
+	}
+	return "", false
+}
+
+// WatermarkMapEntry describes the watermark for a page selection within a JSON watermark map.
+// Exactly one of Text, Image or PDF is expected.
+type WatermarkMapEntry struct {
+	Pages string `json:"pages"`           // page selection, all pages if empty.
+	Text  string `json:"text,omitempty"`  // text watermark.
+	Image string `json:"image,omitempty"` // image file name.
+	PDF   string `json:"pdf,omitempty"`   // PDF file name incl. optional page numbers.
+	Desc  string `json:"desc,omitempty"`  // watermark description.
+}
+
+// WatermarkMap represents a JSON watermark map assigning watermarks to page selections.
+type WatermarkMap struct {
+	Watermarks []WatermarkMapEntry `json:"watermarks"`
 }
 
 // DefaultWatermarkConfig returns the default configuration.
@@ -131,9 +189,11 @@ func DefaultWatermarkConfig() *Watermark {
 		Diagonal:                DiagonalLLToUR,
 		Opacity:                 1.0,
 		RenderMode:              draw.RMFill,
+		PageBox:                 "crop",
 		PdfRes:                  map[int]PdfResources{},
 		Objs:                    types.IntSet{},
 		FCache:                  formCache{},
+		TCache:                  tileCache{},
 		TextLines:               []string{},
 	}
 }
@@ -142,6 +202,17 @@ func DefaultWatermarkConfig() *Watermark {
 func (wm *Watermark) Recycle() {
 	wm.Objs = types.IntSet{}
 	wm.FCache = formCache{}
+	wm.TCache = tileCache{}
+}
+
+// TiledForm returns the cached form repeating wm.Form across wm.Vp.
+func (wm Watermark) TiledForm() *types.IndirectRef {
+	return wm.TCache[tileKey{form: *wm.Form, vp: *wm.Vp}]
+}
+
+// CacheTiledForm caches ir as the form repeating wm.Form across wm.Vp.
+func (wm *Watermark) CacheTiledForm(ir *types.IndirectRef) {
+	wm.TCache[tileKey{form: *wm.Form, vp: *wm.Vp}] = ir
 }
 
 // IsText returns true if the watermark content is text.
@@ -197,6 +268,7 @@ func (wm Watermark) String() string {
 	}
 
 	return fmt.Sprintf("Watermark: <%s> is %son top, typ:%s\n"+
+		"id: %s\n"+
 		"%s %d points\n"+
 		"PDFpage#: %d\n"+
 		"scaling: %.1f %s\n"+
@@ -204,11 +276,15 @@ func (wm Watermark) String() string {
 		"rotation: %.1f\n"+
 		"diagonal: %d\n"+
 		"opacity: %.1f\n"+
+		"blendMode: %s\n"+
 		"renderMode: %d\n"+
+		"pageBox: %s\n"+
+		"keepPageRotation: %t\n"+
 		"bbox:%s\n"+
 		"vp:%s\n"+
 		"pageRotation: %d\n",
 		t, s, wm.Typ(),
+		wm.ID,
 		wm.FontName, wm.FontSize,
 		wm.PdfPageNrSrc,
 		wm.Scale, sc,
@@ -216,7 +292,10 @@ func (wm Watermark) String() string {
 		wm.Rotation,
 		wm.Diagonal,
 		wm.Opacity,
+		wm.BlendMode,
 		wm.RenderMode,
+		wm.PageBox,
+		wm.KeepPageRot,
 		bbox,
 		vp,
 		wm.PageRot,
@@ -448,14 +527,58 @@ func (wm *Watermark) CalcTransformMatrix() matrix.Matrix {
 	return matrix.CalcTransformMatrix(1, 1, sin, cos, dx, dy)
 }
 
+// UprightPageMatrix returns the matrix mapping the upright view port of r into user space for page rotation rot.
+func UprightPageMatrix(rot int, r *types.Rectangle) matrix.Matrix {
+	m := matrix.IdentMatrix
+	w, h := r.Width(), r.Height()
+
+	switch rot {
+	case 90:
+		m[0][0], m[0][1], m[1][0], m[1][1] = 0, 1, -1, 0
+		m[2][0], m[2][1] = r.LL.X+w, r.LL.Y
+	case 180:
+		m[0][0], m[1][1] = -1, -1
+		m[2][0], m[2][1] = r.LL.X+w, r.LL.Y+h
+	case 270:
+		m[0][0], m[0][1], m[1][0], m[1][1] = 0, -1, 1, 0
+		m[2][0], m[2][1] = r.LL.X, r.LL.Y+h
+	default:
+		m[2][0], m[2][1] = r.LL.X, r.LL.Y
+	}
+
+	return m
+}
+
+// UprightViewPort returns the view port of r as displayed for page rotation rot.
+func UprightViewPort(rot int, r *types.Rectangle) *types.Rectangle {
+	if rot == 90 || rot == 270 {
+		return types.RectForDim(r.Height(), r.Width())
+	}
+	return types.RectForDim(r.Width(), r.Height())
+}
+
+// MaxStampPageNr returns the last destination page number covered by the source pages of a multi stamp.
+func (wm *Watermark) MaxStampPageNr() int {
+	return wm.PdfMultiStartPageNrDest + len(wm.PdfRes) - 1
+}
+
+// PdfResIndex returns the index into PdfRes to be used for pageNr.
 func (wm *Watermark) PdfResIndex(pageNr int) int {
 	if !wm.MultiStamp() {
 		return wm.PdfPageNrSrc
 	}
-	maxStampPageNr := wm.PdfMultiStartPageNrDest + len(wm.PdfRes) - 1
+	maxStampPageNr := wm.MaxStampPageNr()
 	i := pageNr
 	if pageNr > maxStampPageNr {
 		i = maxStampPageNr
+		if wm.PdfMultiMode == PdfMultiCycle && len(wm.PdfRes) > 0 {
+			i = wm.PdfMultiStartPageNrDest + (pageNr-wm.PdfMultiStartPageNrDest)%len(wm.PdfRes)
+		}
 	}
 	return i
 }
+
+// SkipPage returns true if a multi stamp has run out of source pages for pageNr.
+func (wm *Watermark) SkipPage(pageNr int) bool {
+	return wm.IsPDF() && wm.MultiStamp() && wm.PdfMultiMode == PdfMultiOnce && pageNr > wm.MaxStampPageNr()
+}
@@ -19,6 +19,7 @@ package model
 import (
 	"bufio"
 	"bytes"
+	"crypto/md5"
 	"encoding/hex"
 	"fmt"
 	"io"
@@ -114,6 +115,11 @@ type XRefTable struct {
 	AES4Strings         bool
 	AES4Streams         bool
 	AES4EmbeddedStreams bool
+	EncStrings          bool         // false if StrF is Identity.
+	EncStreams          bool         // false if StmF is Identity.
+	EncEmbeddedStreams  bool         // false if EFF is Identity.
+	EmbeddedFileStreams types.IntSet // Object numbers of streams referenced by a file specification's EF entry.
+	EmbeddedFilesLocked bool         // EFOpen w/o password: embedded file streams remain encrypted.
 
 	// PDF Version
 	HeaderVersion *Version // The PDF version the source is claiming to us as per its header.
@@ -133,6 +139,8 @@ type XRefTable struct {
 	KeywordList    types.StringSet
 	Properties     map[string]string
 	CatalogXMPMeta *XMPMeta
+	KeepID         bool // Writing preserves ID instead of updating it.
+	KeepDates      bool // Writing preserves CreationDate and ModDate instead of updating them.
 
 	PageLayout *PageLayout
 	PageMode   *PageMode
@@ -160,12 +168,14 @@ type XRefTable struct {
 
 	// Validation
 	CurPage        int                       // current page during validation
-	CurObj         int                       // current object during validation, the last dereferenced object
+	CurObj         int                       // current object during reading and validation, the last dereferenced object
 	Conf           *Configuration            // current command being executed
 	ValidationMode int                       // see Configuration
 	ValidateLinks  bool                      // check for broken links in LinkAnnotations/URIDicts.
 	Valid          bool                      // true means successful validated against ISO 32000.
 	URIs           map[int]map[string]string // URIs for link checking
+	Tolerated      types.StringSet           // Ids of producer quirks tolerated during validation.
+	producer       *string                   // Producer and Creator for matching quirks.
 
 	Optimized      bool
 	Watermarked    bool
@@ -231,7 +241,7 @@ func (xRefTable *XRefTable) ParseRootVersion() (v *string, err error) {
 
 // ValidateVersion validates against the xRefTable's version.
 func (xRefTable *XRefTable) ValidateVersion(element string, sinceVersion Version) error {
-	if xRefTable.Version() < sinceVersion {
+	if xRefTable.Version() < sinceVersion && !xRefTable.Tolerate(QuirkNewerFeatures) {
 		return errors.Errorf("%s: unsupported in version %s\n", element, xRefTable.VersionString())
 	}
 
@@ -385,8 +395,8 @@ func (xRefTable *XRefTable) InsertAndUseRecycled(xRefTableEntry XRefTableEntry)
 		return 0, err
 	}
 
-	// If none available, add new object & return.
-	if *freeListHeadEntry.Offset == 0 {
+	// If none available or object numbers are to be preserved, add new object & return.
+	if *freeListHeadEntry.Offset == 0 || xRefTable.Conf != nil && xRefTable.Conf.PreserveObjectNumbers {
 		xRefTableEntry.RefCount = 1
 		objNr = xRefTable.InsertNew(xRefTableEntry)
 		if log.WriteEnabled() {
@@ -478,6 +488,8 @@ func (xRefTable *XRefTable) NewEmbeddedStreamDict(r io.Reader, modDate time.Time
 	d := types.NewDict()
 	d.InsertInt("Size", len(bb))
 	d.Insert("ModDate", types.StringLiteral(types.DateString(modDate)))
+	sum := md5.Sum(bb)
+	d.Insert("CheckSum", types.NewHexLiteral(sum[:]))
 	sd.Insert("Params", d)
 	if err = sd.Encode(); err != nil {
 		return nil, err
@@ -2773,6 +2785,10 @@ func (xRefTable *XRefTable) NameRef(nameType string) NameMap {
 	return nm
 }
 
+// RemoveSignature removes all signature related state invalidated by modifying a signed form:
+// SigFlags, XFA, extensions and permissions including the usage rights signature (UR3),
+// unless Configuration.KeepUsageRights is set.
+// Use RemoveUsageRights for removing usage rights of any document explicitly.
 func (xRefTable *XRefTable) RemoveSignature() {
 	if xRefTable.SignatureExist || xRefTable.AppendOnly {
 		// TODO enable incremental writing
@@ -2781,7 +2797,13 @@ func (xRefTable *XRefTable) RemoveSignature() {
 		}
 		// root -> Perms -> UR3 -> = Sig dict
 		d1 := xRefTable.RootDict
-		delete(d1, "Perms")
+		if xRefTable.Conf != nil && xRefTable.Conf.KeepUsageRights {
+			if d, err := xRefTable.permsDict(); err == nil && d != nil {
+				delete(d, "DocMDP")
+			}
+		} else {
+			delete(d1, "Perms")
+		}
 		d2 := xRefTable.Form
 		delete(d2, "SigFlags")
 		delete(d2, "XFA")
@@ -817,6 +817,11 @@ func NUpFromPDF(ctx *model.Context, selectedPages types.IntSet, nup *model.NUp)
 
 	nup.PageDim = &types.Dim{Width: mb.Width(), Height: mb.Height()}
 
+	objNrs, err := pageObjNrs(ctx)
+	if err != nil {
+		return err
+	}
+
 	if err = nupPages(ctx, selectedPages, nup, pagesDict, pagesIndRef); err != nil {
 		return err
 	}
@@ -829,5 +834,8 @@ func NUpFromPDF(ctx *model.Context, selectedPages types.IntSet, nup *model.NUp)
 
 	rootDict.Update("Pages", *pagesIndRef)
 
-	return nil
+	// Point bookmarks and named destinations to the sheets holding their pages.
+	m := pageRemapForSheets(objNrs, pagesDict.ArrayEntry("Kids"), sortSelectedPages(selectedPages), nup.N())
+
+	return remapDestinations(ctx, m)
 }
@@ -0,0 +1,280 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// OCRPage is the OCR result for a page image.
+// Word rectangles are given in image space with the origin in the upper left corner.
+type OCRPage struct {
+	Width, Height float64
+	Words         []OCRWord
+}
+
+// hOCRTitle parses the properties of an hOCR title attribute, eg. "bbox 10 20 30 40; x_wconf 93".
+func hOCRTitle(s string) map[string][]string {
+	m := map[string][]string{}
+	for _, prop := range strings.Split(s, ";") {
+		ff := strings.Fields(prop)
+		if len(ff) > 0 {
+			m[ff[0]] = ff[1:]
+		}
+	}
+	return m
+}
+
+func hOCRBBox(m map[string][]string) (*types.Rectangle, bool) {
+	ss := m["bbox"]
+	if len(ss) != 4 {
+		return nil, false
+	}
+	ff := make([]float64, 4)
+	for i, s := range ss {
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, false
+		}
+		ff[i] = f
+	}
+	return types.NewRectangle(ff[0], ff[1], ff[2], ff[3]), true
+}
+
+func xmlAttr(se xml.StartElement, name string) string {
+	for _, a := range se.Attr {
+		if a.Name.Local == name {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+func xmlFloatAttr(se xml.StartElement, name string) float64 {
+	f, _ := strconv.ParseFloat(xmlAttr(se, name), 64)
+	return f
+}
+
+func hasClass(se xml.StartElement, class string) bool {
+	for _, s := range strings.Fields(xmlAttr(se, "class")) {
+		if s == class {
+			return true
+		}
+	}
+	return false
+}
+
+// ocrParser collects pages and words of hOCR and ALTO documents.
+type ocrParser struct {
+	pages []OCRPage
+	word  *OCRWord        // hOCR word being read.
+	depth int             // Element depth within the hOCR word being read.
+	sb    strings.Builder // Text of the hOCR word being read.
+}
+
+func (p *ocrParser) page() *OCRPage {
+	if len(p.pages) == 0 {
+		p.pages = append(p.pages, OCRPage{})
+	}
+	return &p.pages[len(p.pages)-1]
+}
+
+// hOCRStart handles the start of an hOCR element (see http://kba.github.io/hocr-spec/1.2/).
+func (p *ocrParser) hOCRStart(se xml.StartElement) {
+	if p.word != nil {
+		p.depth++
+		return
+	}
+
+	switch {
+	case hasClass(se, "ocr_page"):
+		pg := OCRPage{}
+		if r, ok := hOCRBBox(hOCRTitle(xmlAttr(se, "title"))); ok {
+			pg.Width, pg.Height = r.Width(), r.Height()
+		}
+		p.pages = append(p.pages, pg)
+
+	case hasClass(se, "ocrx_word"):
+		m := hOCRTitle(xmlAttr(se, "title"))
+		r, ok := hOCRBBox(m)
+		if !ok {
+			return
+		}
+		w := OCRWord{Rect: *r, Confidence: 100}
+		if ss := m["x_wconf"]; len(ss) > 0 {
+			if f, err := strconv.ParseFloat(ss[0], 64); err == nil {
+				w.Confidence = f
+			}
+		}
+		p.word, p.depth = &w, 0
+		p.sb.Reset()
+	}
+}
+
+func (p *ocrParser) hOCREnd() {
+	if p.word == nil {
+		return
+	}
+	if p.depth > 0 {
+		p.depth--
+		return
+	}
+	p.word.Text = strings.TrimSpace(p.sb.String())
+	pg := p.page()
+	pg.Words = append(pg.Words, *p.word)
+	p.word = nil
+}
+
+// altoStart handles the start of an ALTO element (see https://www.loc.gov/standards/alto/).
+func (p *ocrParser) altoStart(se xml.StartElement) {
+	switch se.Name.Local {
+
+	case "Page":
+		p.pages = append(p.pages, OCRPage{Width: xmlFloatAttr(se, "WIDTH"), Height: xmlFloatAttr(se, "HEIGHT")})
+
+	case "String":
+		x, y := xmlFloatAttr(se, "HPOS"), xmlFloatAttr(se, "VPOS")
+		w := OCRWord{
+			Text:       xmlAttr(se, "CONTENT"),
+			Rect:       *types.NewRectangle(x, y, x+xmlFloatAttr(se, "WIDTH"), y+xmlFloatAttr(se, "HEIGHT")),
+			Confidence: 100,
+		}
+		if s := xmlAttr(se, "WC"); s != "" {
+			if f, err := strconv.ParseFloat(s, 64); err == nil {
+				w.Confidence = f * 100
+			}
+		}
+		pg := p.page()
+		pg.Words = append(pg.Words, w)
+	}
+}
+
+// ParseOCR parses hOCR or ALTO XML as produced by OCR engines like Tesseract.
+func ParseOCR(r io.Reader) ([]OCRPage, error) {
+	dec := xml.NewDecoder(r)
+	dec.Strict = false
+	dec.AutoClose = xml.HTMLAutoClose
+	dec.Entity = xml.HTMLEntity
+
+	p := &ocrParser{}
+	alto, root := false, true
+
+	for {
+		t, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "pdfcpu: invalid OCR file")
+		}
+
+		switch t := t.(type) {
+
+		case xml.StartElement:
+			if root {
+				alto, root = strings.EqualFold(t.Name.Local, "alto"), false
+			}
+			if alto {
+				p.altoStart(t)
+				continue
+			}
+			p.hOCRStart(t)
+
+		case xml.EndElement:
+			if !alto {
+				p.hOCREnd()
+			}
+
+		case xml.CharData:
+			if p.word != nil {
+				p.sb.Write(t)
+			}
+		}
+	}
+
+	if root {
+		return nil, errors.New("pdfcpu: invalid OCR file: missing root element")
+	}
+
+	return p.pages, nil
+}
+
+// ocrToUserSpace maps r given in the image space of a page image sized w x h
+// onto the visible region vp of a page rotated by rot degrees.
+func ocrToUserSpace(r types.Rectangle, w, h float64, vp *types.Rectangle, rot int) types.Rectangle {
+	pt := func(x, y float64) types.Point {
+		u, v := x/w, y/h
+		switch (rot%360 + 360) % 360 {
+		case 90:
+			return types.Point{X: vp.LL.X + v*vp.Width(), Y: vp.LL.Y + u*vp.Height()}
+		case 180:
+			return types.Point{X: vp.UR.X - u*vp.Width(), Y: vp.LL.Y + v*vp.Height()}
+		case 270:
+			return types.Point{X: vp.UR.X - v*vp.Width(), Y: vp.UR.Y - u*vp.Height()}
+		}
+		return types.Point{X: vp.LL.X + u*vp.Width(), Y: vp.UR.Y - v*vp.Height()}
+	}
+
+	p1, p2 := pt(r.LL.X, r.LL.Y), pt(r.UR.X, r.UR.Y)
+
+	return *types.NewRectangle(min(p1.X, p2.X), min(p1.Y, p2.Y), max(p1.X, p2.X), max(p1.Y, p2.Y))
+}
+
+// OCRTextLayers maps the OCR results pp onto pages of ctx scaling from image space to user space.
+// The i-th OCR page is applied to the i-th page of pageNrs.
+func OCRTextLayers(ctx *model.Context, pp []OCRPage, pageNrs []int) (map[int][]OCRWord, error) {
+	if len(pp) > len(pageNrs) {
+		return nil, errors.Errorf("pdfcpu: OCR results for %d pages, but only %d pages selected", len(pp), len(pageNrs))
+	}
+
+	layers := map[int][]OCRWord{}
+
+	for i, pg := range pp {
+		pageNr := pageNrs[i]
+
+		d, _, inhPAttrs, err := ctx.PageDict(pageNr, false)
+		if err != nil {
+			return nil, err
+		}
+		if d == nil {
+			return nil, errors.Errorf("pdfcpu: unknown page number: %d\n", pageNr)
+		}
+
+		if pg.Width <= 0 || pg.Height <= 0 {
+			return nil, errors.Errorf("pdfcpu: OCR page %d: missing image dimensions", i+1)
+		}
+
+		vp := viewPort(inhPAttrs)
+
+		ww := make([]OCRWord, len(pg.Words))
+		for j, w := range pg.Words {
+			ww[j] = w
+			ww[j].Rect = ocrToUserSpace(w.Rect, pg.Width, pg.Height, vp, inhPAttrs.Rotate)
+		}
+
+		layers[pageNr] = ww
+	}
+
+	return layers, nil
+}
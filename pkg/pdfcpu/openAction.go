@@ -0,0 +1,253 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// InitialView represents the view of a document when opened in a PDF viewer.
+type InitialView struct {
+	PageNr     int    // Page displayed on open, 0 leaves an existing /OpenAction untouched.
+	Zoom       string // fit, fitwidth, fitheight, fitbox or a magnification in percent eg. 150
+	PageMode   *model.PageMode
+	PageLayout *model.PageLayout
+}
+
+// ParseInitialView validates and returns the initial view described by the given values.
+func ParseInitialView(pageNr int, zoom, pageMode, pageLayout string) (*InitialView, error) {
+	if pageNr < 0 {
+		return nil, errors.Errorf("pdfcpu: invalid open action page: %d", pageNr)
+	}
+
+	if zoom != "" {
+		if pageNr == 0 {
+			pageNr = 1
+		}
+		if _, err := openActionZoom(zoom); err != nil {
+			return nil, err
+		}
+	}
+
+	iv := &InitialView{PageNr: pageNr, Zoom: zoom}
+
+	if pageMode != "" {
+		if iv.PageMode = model.PageModeFor(pageMode); iv.PageMode == nil {
+			return nil, errors.Errorf("pdfcpu: invalid page mode %q, use one of: UseNone, UseOutlines, UseThumbs, FullScreen, UseOC, UseAttachments", pageMode)
+		}
+	}
+
+	if pageLayout != "" {
+		if iv.PageLayout = model.PageLayoutFor(pageLayout); iv.PageLayout == nil {
+			return nil, errors.Errorf("pdfcpu: invalid page layout %q, use one of: SinglePage, TwoColumnLeft, TwoColumnRight, TwoPageLeft, TwoPageRight", pageLayout)
+		}
+	}
+
+	if iv.PageNr == 0 && iv.PageMode == nil && iv.PageLayout == nil {
+		return nil, errors.New("pdfcpu: open action: please provide page, zoom, page mode or page layout")
+	}
+
+	return iv, nil
+}
+
+// openActionZoom returns the destination parameters following the page reference for zoom.
+func openActionZoom(zoom string) (types.Array, error) {
+	switch strings.ToLower(zoom) {
+	case "":
+		// Retain the current magnification.
+		return types.Array{types.Name("XYZ"), nil, nil, nil}, nil
+	case "fit":
+		return types.Array{types.Name("Fit")}, nil
+	case "fitwidth", "fith":
+		return types.Array{types.Name("FitH"), nil}, nil
+	case "fitheight", "fitv":
+		return types.Array{types.Name("FitV"), nil}, nil
+	case "fitbox", "fitb":
+		return types.Array{types.Name("FitB")}, nil
+	}
+
+	f, err := strconv.ParseFloat(strings.TrimSuffix(zoom, "%"), 64)
+	if err != nil || f <= 0 {
+		return nil, errors.Errorf("pdfcpu: invalid zoom %q, use one of: fit, fitwidth, fitheight, fitbox or a percentage", zoom)
+	}
+
+	return types.Array{types.Name("XYZ"), nil, nil, types.Float(f / 100)}, nil
+}
+
+// SetInitialView writes the open action, page mode and page layout of iv into the root dict of ctx.
+func SetInitialView(ctx *model.Context, iv InitialView) error {
+	if iv.PageNr > 0 {
+		if err := ctx.EnsurePageCount(); err != nil {
+			return err
+		}
+		if iv.PageNr > ctx.PageCount {
+			return errors.Errorf("pdfcpu: open action: page %d out of range (%d pages)", iv.PageNr, ctx.PageCount)
+		}
+
+		_, ir, _, err := ctx.PageDict(iv.PageNr, false)
+		if err != nil {
+			return err
+		}
+
+		arr, err := openActionZoom(iv.Zoom)
+		if err != nil {
+			return err
+		}
+
+		ctx.RootDict["OpenAction"] = append(types.Array{*ir}, arr...)
+	}
+
+	if iv.PageMode != nil {
+		ctx.RootDict["PageMode"] = types.Name(iv.PageMode.String())
+	}
+
+	if iv.PageLayout != nil {
+		ctx.RootDict["PageLayout"] = types.Name(iv.PageLayout.String())
+	}
+
+	return nil
+}
+
+// ResetInitialView removes the open action, page mode and page layout from the root dict of ctx.
+// Returns true if anything was removed.
+func ResetInitialView(ctx *model.Context) bool {
+	var ok bool
+	for _, k := range []string{"OpenAction", "PageMode", "PageLayout"} {
+		if _, found := ctx.RootDict.Find(k); found {
+			delete(ctx.RootDict, k)
+			ok = true
+		}
+	}
+	return ok
+}
+
+func describeZoom(arr types.Array) string {
+	if len(arr) < 2 {
+		return "?"
+	}
+	n, ok := arr[1].(types.Name)
+	if !ok {
+		return "?"
+	}
+	switch n {
+	case "Fit":
+		return "fit"
+	case "FitH", "FitBH":
+		return "fitwidth"
+	case "FitV", "FitBV":
+		return "fitheight"
+	case "FitB":
+		return "fitbox"
+	case "XYZ":
+		if len(arr) > 4 {
+			if f, ok := arr[4].(types.Float); ok && f > 0 {
+				return fmt.Sprintf("%.0f%%", f.Value()*100)
+			}
+			if i, ok := arr[4].(types.Integer); ok && i > 0 {
+				return fmt.Sprintf("%d%%", i.Value()*100)
+			}
+		}
+		return "unchanged"
+	}
+	return n.String()
+}
+
+func describeDestArray(ctx *model.Context, arr types.Array) (string, error) {
+	if len(arr) == 0 {
+		return "", nil
+	}
+	pageNr := 0
+	switch p := arr[0].(type) {
+	case types.IndirectRef:
+		objNrs, err := pageObjNrs(ctx)
+		if err != nil {
+			return "", err
+		}
+		for i, objNr := range objNrs {
+			if objNr == p.ObjectNumber.Value() {
+				pageNr = i + 1
+				break
+			}
+		}
+	case types.Integer:
+		// Remote destination
+		pageNr = p.Value() + 1
+	}
+	return fmt.Sprintf("page %d, zoom: %s", pageNr, describeZoom(arr)), nil
+}
+
+func describeOpenAction(ctx *model.Context, o types.Object) (string, error) {
+	o, err := ctx.Dereference(o)
+	if err != nil {
+		return "", err
+	}
+
+	switch o := o.(type) {
+	case types.Array:
+		return describeDestArray(ctx, o)
+	case types.Dict:
+		s := o.NameEntry("S")
+		if s == nil {
+			return "action: ?", nil
+		}
+		if *s != "GoTo" {
+			return "action: " + *s, nil
+		}
+		arr, err := ctx.DereferenceArray(o["D"])
+		if err != nil {
+			return "", err
+		}
+		if arr == nil {
+			// Named destination
+			return "action: GoTo", nil
+		}
+		return describeDestArray(ctx, arr)
+	}
+
+	return "?", nil
+}
+
+// ListInitialView returns a description of the open action, page mode and page layout of ctx.
+func ListInitialView(ctx *model.Context) ([]string, error) {
+	ss := []string{}
+
+	if o, found := ctx.RootDict.Find("OpenAction"); found {
+		s, err := describeOpenAction(ctx, o)
+		if err != nil {
+			return nil, err
+		}
+		ss = append(ss, "OpenAction: "+s)
+	} else {
+		ss = append(ss, "OpenAction: none, PDF viewers will display page 1")
+	}
+
+	if pm := ctx.RootDict.NameEntry("PageMode"); pm != nil {
+		ss = append(ss, "PageMode: "+*pm)
+	}
+
+	if pl := ctx.RootDict.NameEntry("PageLayout"); pl != nil {
+		ss = append(ss, "PageLayout: "+*pl)
+	}
+
+	return ss, nil
+}
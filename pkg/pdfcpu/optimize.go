@@ -243,6 +243,10 @@ func resourcesDictForPageDict(xRefTable *model.XRefTable, pageDict types.Dict, p
 
 // handleDuplicateFontObject returns nil or the object number of the registered font if it matches this font.
 func handleDuplicateFontObject(ctx *model.Context, fontDict types.Dict, fName, rName string, objNr, pageNr int) (*int, error) {
+	if !ctx.OptimizePass(model.OptimizePassFonts) {
+		return nil, nil
+	}
+
 	// Get a slice of all font object numbers for font name.
 	fontObjNrs, found := ctx.Optimize.Fonts[fName]
 	if !found {
@@ -423,8 +427,29 @@ func optimizeFontResourcesDict(ctx *model.Context, rDict types.Dict, pageNr, pag
 	return nil
 }
 
+// deletePieceInfo removes the page-piece dict of d except for pdfcpu's own private data, eg. stamp ids.
+func deletePieceInfo(ctx *model.Context, d types.Dict) error {
+	pi, err := ctx.DereferenceDict(d["PieceInfo"])
+	if err != nil || pi == nil {
+		return err
+	}
+
+	o, found := pi.Find("pdfcpu")
+	if !found {
+		return ctx.DeleteDictEntry(d, "PieceInfo")
+	}
+
+	d.Update("PieceInfo", types.Dict(map[string]types.Object{"pdfcpu": o}))
+
+	return nil
+}
+
 // handleDuplicateImageObject returns nil or the object number of the registered image if it matches this image.
 func handleDuplicateImageObject(ctx *model.Context, imageDict *types.StreamDict, resourceName string, objNr, pageNr int) (*int, error) {
+	if !ctx.OptimizePass(model.OptimizePassImages) {
+		return nil, nil
+	}
+
 	// Get the set of image object numbers for pageNr.
 	pageImages := ctx.Optimize.PageImages[pageNr]
 
@@ -662,7 +687,7 @@ func optimizeXObjectResourcesDict(ctx *model.Context, rDict types.Dict, pageNr,
 			continue
 		}
 
-		if err := ctx.DeleteDictEntry(sd.Dict, "PieceInfo"); err != nil {
+		if err := deletePieceInfo(ctx, sd.Dict); err != nil {
 			return err
 		}
 
@@ -843,7 +868,7 @@ func parsePagesDict(ctx *model.Context, pagesDict types.Dict, pageNr int) (int,
 			return 0, err
 		}
 
-		if err := ctx.DeleteDictEntry(d, "PieceInfo"); err != nil {
+		if err := deletePieceInfo(ctx, d); err != nil {
 			return 0, err
 		}
 
@@ -1452,6 +1477,13 @@ func OptimizeXRefTable(ctx *model.Context) error {
 		}
 	}
 
+	if ctx.OptimizePass(model.OptimizePassInlineImages) {
+		// Precedes font and image optimization for the resulting image XObjects to be deduplicated.
+		if err := convertInlineImages(ctx); err != nil {
+			return err
+		}
+	}
+
 	// Get rid of duplicate embedded fonts and images.
 	if err := optimizeFontAndImages(ctx); err != nil {
 		return err
@@ -1462,8 +1494,57 @@ func OptimizeXRefTable(ctx *model.Context) error {
 		return err
 	}
 
+	if ctx.OptimizePass(model.OptimizePassMetadata) {
+		if err := removeMetadata(ctx); err != nil {
+			return err
+		}
+	}
+
+	if ctx.OptimizePass(model.OptimizePassPageTree) {
+		if err := rebalancePageTree(ctx); err != nil {
+			return err
+		}
+	}
+
+	if ctx.OptimizePass(model.OptimizePassAlternates) {
+		if err := removeAlternateImages(ctx); err != nil {
+			return err
+		}
+	}
+
+	if ctx.OptimizePass(model.OptimizePassThumbnails) {
+		if err := removeThumbnails(ctx); err != nil {
+			return err
+		}
+	}
+
+	if ctx.OptimizePass(model.OptimizePassAppStates) {
+		if err := removeAppearanceStates(ctx); err != nil {
+			return err
+		}
+	}
+
+	if ctx.OptimizePass(model.OptimizePassStdFonts) {
+		if err := unembedStdFonts(ctx); err != nil {
+			return err
+		}
+	}
+
+	if ctx.OptimizePass(model.OptimizePassDeadCode) {
+		if err := eliminateContentDeadCode(ctx); err != nil {
+			return err
+		}
+	}
+
+	if ctx.OptimizePass(model.OptimizePassRecompress) {
+		if err := recompressStreams(ctx); err != nil {
+			return err
+		}
+	}
+
 	// Calculate memory usage of binary content for stats.
-	if log.StatsEnabled() {
+	// Optimize relies on these for its report.
+	if log.StatsEnabled() || ctx.Cmd == model.OPTIMIZE {
 		if err := calcBinarySizes(ctx); err != nil {
 			return err
 		}
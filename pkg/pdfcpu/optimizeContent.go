@@ -0,0 +1,283 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// contentToken is a content stream operator along with its operands.
+type contentToken struct {
+	op  contentOp
+	oo  []interface{}
+	raw []byte // The operands and the operator as found in the content stream incl. inline image data.
+}
+
+var (
+	// Operators without any effect on the page unless followed by a painting operator.
+	noPaintOps = map[contentOp]bool{
+		"m": true, "l": true, "c": true, "v": true, "y": true, "h": true, "re": true, "n": true, "W": true, "W*": true,
+		"cm": true, "w": true, "J": true, "j": true, "M": true, "d": true, "ri": true, "i": true, "gs": true,
+		"CS": true, "cs": true, "SC": true, "SCN": true, "sc": true, "scn": true, "G": true, "g": true, "RG": true, "rg": true, "K": true, "k": true,
+		"Tc": true, "Tw": true, "Tz": true, "TL": true, "Tf": true, "Tr": true, "Ts": true,
+	}
+
+	// Operators producing marks on the page.
+	paintOps = map[contentOp]bool{
+		"S": true, "s": true, "f": true, "F": true, "f*": true, "B": true, "B*": true, "b": true, "b*": true,
+		"sh": true, "Do": true, "Tj": true, "TJ": true, "'": true, "\"": true, "ID": true,
+	}
+
+	pathOps = map[contentOp]bool{"m": true, "l": true, "c": true, "v": true, "y": true, "h": true, "re": true}
+
+	strokeColorOps = map[contentOp]bool{"G": true, "RG": true, "K": true, "SC": true, "SCN": true}
+	fillColorOps   = map[contentOp]bool{"g": true, "rg": true, "k": true, "sc": true, "scn": true}
+)
+
+// tokenizeContent splits bb into operators along with their operands.
+// It returns false for content streams with dangling operands.
+func tokenizeContent(bb []byte) ([]contentToken, bool) {
+	l := &contentLexer{bb: bb}
+
+	var (
+		tt    []contentToken
+		oo    []interface{}
+		start = -1
+	)
+
+	for {
+		l.skipWhitespaceAndComments()
+		if start < 0 {
+			start = l.pos
+		}
+
+		o, ok := l.next()
+		if !ok {
+			break
+		}
+
+		op, ok := o.(contentOp)
+		if !ok {
+			oo = append(oo, o)
+			continue
+		}
+
+		if op == "ID" {
+			l.skipInlineImage()
+		}
+
+		tt = append(tt, contentToken{op: op, oo: oo, raw: bb[start:l.pos]})
+		oo, start = nil, -1
+	}
+
+	return tt, len(oo) == 0
+}
+
+func (t contentToken) key() string {
+	ss := make([]string, len(t.oo)+1)
+	for i, o := range t.oo {
+		ss[i] = formatOperand(o)
+	}
+	ss[len(t.oo)] = string(t.op)
+	return strings.Join(ss, " ")
+}
+
+func (t contentToken) identityCM() bool {
+	if t.op != "cm" || len(t.oo) != 6 {
+		return false
+	}
+	for i, want := range []float64{1, 0, 0, 1, 0, 0} {
+		if f, ok := t.oo[i].(float64); !ok || f != want {
+			return false
+		}
+	}
+	return true
+}
+
+func (t contentToken) zeroAreaRect() bool {
+	if t.op != "re" || len(t.oo) != 4 {
+		return false
+	}
+	w, ok1 := t.oo[2].(float64)
+	h, ok2 := t.oo[3].(float64)
+	return ok1 && ok2 && (w == 0 || h == 0)
+}
+
+func fillOp(op contentOp) bool {
+	return op == "f" || op == "F" || op == "f*"
+}
+
+func noPaintGroup(tt []contentToken) bool {
+	for _, t := range tt {
+		if !noPaintOps[t.op] {
+			return false
+		}
+	}
+	return true
+}
+
+func paintOpCount(tt []contentToken) int {
+	c := 0
+	for _, t := range tt {
+		if paintOps[t.op] {
+			c++
+		}
+	}
+	return c
+}
+
+// eliminateDeadCode removes no-op sequences from content stream bb:
+//
+//	q/Q pairs enclosing state changes and paths not painted,
+//	identity transformations,
+//	filled rectangles without area,
+//	color settings repeating the color in effect.
+//
+// Content streams using compatibility sections or unbalanced q/Q pairs are left alone.
+func eliminateDeadCode(bb []byte) ([]byte, bool) {
+	tt, ok := tokenizeContent(bb)
+	if !ok {
+		return bb, false
+	}
+
+	var (
+		out               []contentToken
+		stack             []int
+		stroke, fill      string // Color settings in effect.
+		removed, zeroArea int
+	)
+
+	for i := 0; i < len(tt); i++ {
+		t := tt[i]
+
+		switch {
+
+		case t.op == "BX" || t.op == "EX":
+			return bb, false
+
+		case t.op == "q":
+			stack = append(stack, len(out))
+			out = append(out, t)
+			stroke, fill = "", ""
+
+		case t.op == "Q":
+			if len(stack) == 0 {
+				return bb, false
+			}
+			j := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			stroke, fill = "", ""
+			if noPaintGroup(out[j+1:]) {
+				removed += len(out) - j + 1
+				out = out[:j]
+				continue
+			}
+			out = append(out, t)
+
+		case t.identityCM():
+			removed++
+
+		case t.zeroAreaRect() && i+1 < len(tt) && fillOp(tt[i+1].op) && (len(out) == 0 || !pathOps[out[len(out)-1].op]):
+			removed += 2
+			zeroArea++
+			i++
+
+		case t.op == "CS":
+			stroke = ""
+			out = append(out, t)
+
+		case t.op == "cs":
+			fill = ""
+			out = append(out, t)
+
+		case strokeColorOps[t.op]:
+			if k := t.key(); k == stroke {
+				removed++
+			} else {
+				stroke = k
+				out = append(out, t)
+			}
+
+		case fillColorOps[t.op]:
+			if k := t.key(); k == fill {
+				removed++
+			} else {
+				fill = k
+				out = append(out, t)
+			}
+
+		default:
+			out = append(out, t)
+		}
+	}
+
+	if removed == 0 || len(stack) > 0 {
+		return bb, false
+	}
+
+	// Verify no marks got lost other than fills without area.
+	if paintOpCount(out) != paintOpCount(tt)-zeroArea {
+		return bb, false
+	}
+
+	var buf bytes.Buffer
+	for i, t := range out {
+		if i > 0 {
+			buf.WriteByte('\n')
+		}
+		buf.Write(t.raw)
+	}
+
+	if buf.Len() >= len(bb) {
+		return bb, false
+	}
+
+	return buf.Bytes(), true
+}
+
+// eliminateContentDeadCode removes no-op sequences from the content of all pages, form XObjects and tiling patterns.
+func eliminateContentDeadCode(ctx *model.Context) error {
+	if log.OptimizeEnabled() {
+		log.Optimize.Println("eliminateContentDeadCode begin")
+	}
+
+	st := passStats(ctx, model.OptimizePassDeadCode)
+
+	cw := newContentWalker(ctx, func(pageNr int, res types.Dict, bb []byte) ([]byte, bool, error) {
+		bb1, ok := eliminateDeadCode(bb)
+		if ok {
+			st.Objects++
+			st.Bytes += int64(len(bb) - len(bb1))
+		}
+		return bb1, ok, nil
+	}, false)
+
+	if err := cw.walk(nil); err != nil {
+		return err
+	}
+
+	if log.OptimizeEnabled() {
+		log.Optimize.Println("eliminateContentDeadCode end")
+	}
+
+	return nil
+}
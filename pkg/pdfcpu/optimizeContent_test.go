@@ -0,0 +1,51 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import "testing"
+
+func TestEliminateDeadCode(t *testing.T) {
+	for _, tt := range []struct {
+		id, in, want string
+		changed      bool
+	}{
+		{"emptyGroup", "q 1 0 0 RG 2 w Q 0 0 10 10 re f", "0 0 10 10 re\nf", true},
+		{"nestedGroups", "q q 1 g Q 0 0 10 10 re W n Q BT /F1 12 Tf (a) Tj ET", "BT\n/F1 12 Tf\n(a) Tj\nET", true},
+		{"identityCM", "1 0 0 1 0 0 cm 0 0 10 10 re S", "0 0 10 10 re\nS", true},
+		{"zeroAreaFill", "0 0 0 10 re f 0 0 10 10 re S", "0 0 10 10 re\nS", true},
+		{"zeroAreaStroke", "0 0 0 10 re S", "", false},
+		{"repeatedColor", "1 0 0 rg 0 0 10 10 re f 1 0 0 rg 10 10 10 10 re f", "1 0 0 rg\n0 0 10 10 re\nf\n10 10 10 10 re\nf", true},
+		{"colorAfterGroup", "1 g q 0 g 0 0 1 1 re f Q 1 g 0 0 1 1 re f", "", false},
+		{"paintingGroup", "q 0 0 10 10 re f Q", "", false},
+		{"unbalanced", "q 1 g Q Q", "", false},
+		{"compatibility", "BX q Q EX", "", false},
+	} {
+		bb, changed := eliminateDeadCode([]byte(tt.in))
+		if changed != tt.changed {
+			t.Fatalf("%s: want changed=%t, got %t\n", tt.id, tt.changed, changed)
+		}
+		if !changed {
+			if string(bb) != tt.in {
+				t.Fatalf("%s: content modified: %s\n", tt.id, bb)
+			}
+			continue
+		}
+		if string(bb) != tt.want {
+			t.Fatalf("%s:\nwant: %q\ngot:  %q\n", tt.id, tt.want, bb)
+		}
+	}
+}
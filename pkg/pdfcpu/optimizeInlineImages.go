@@ -0,0 +1,506 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"bytes"
+	"crypto/md5"
+	"fmt"
+	"math"
+
+	"github.com/pdfcpu/pdfcpu/pkg/filter"
+	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// minInlineImageSize is the size of inline image data in bytes starting at which inline images get converted into image XObjects.
+// Inline images should not exceed 4 KB (see 8.9.7).
+const minInlineImageSize = 4096
+
+var (
+	inlineImageKeys = map[string]string{
+		"BPC": "BitsPerComponent",
+		"CS":  "ColorSpace",
+		"D":   "Decode",
+		"DP":  "DecodeParms",
+		"F":   "Filter",
+		"H":   "Height",
+		"IM":  "ImageMask",
+		"I":   "Interpolate",
+		"W":   "Width",
+	}
+
+	inlineImageColorSpaces = map[string]string{
+		"G":    model.DeviceGrayCS,
+		"RGB":  model.DeviceRGBCS,
+		"CMYK": model.DeviceCMYKCS,
+		"I":    model.IndexedCS,
+	}
+
+	inlineImageFilters = map[string]string{
+		"AHx": filter.ASCIIHex,
+		"A85": filter.ASCII85,
+		"LZW": filter.LZW,
+		"Fl":  filter.Flate,
+		"RL":  filter.RunLength,
+		"CCF": filter.CCITTFax,
+		"DCT": filter.DCT,
+	}
+)
+
+// contentObject returns the object for content stream operand o.
+func contentObject(o interface{}) types.Object {
+	switch o := o.(type) {
+
+	case float64:
+		if o == math.Trunc(o) {
+			return types.Integer(int(o))
+		}
+		return types.Float(o)
+
+	case contentName:
+		return types.Name(o)
+
+	case []byte:
+		return types.NewHexLiteral(o)
+
+	case []interface{}:
+		a := types.Array{}
+		for _, o := range o {
+			o1 := contentObject(o)
+			if o1 == nil {
+				return nil
+			}
+			a = append(a, o1)
+		}
+		return a
+
+	case contentDict:
+		d := types.NewDict()
+		for k, v := range o {
+			o1 := contentObject(v)
+			if o1 == nil {
+				return nil
+			}
+			d.Insert(string(k), o1)
+		}
+		return d
+
+	case contentOp:
+		switch o {
+		case "true":
+			return types.Boolean(true)
+		case "false":
+			return types.Boolean(false)
+		}
+	}
+
+	return nil
+}
+
+func expandInlineImageNames(m map[string]string, o types.Object) types.Object {
+	switch o := o.(type) {
+	case types.Name:
+		if s, ok := m[o.Value()]; ok {
+			return types.Name(s)
+		}
+	case types.Array:
+		for i := range o {
+			o[i] = expandInlineImageNames(m, o[i])
+		}
+	}
+	return o
+}
+
+// inlineImageColorSpace expands abbreviations of inline image color space o and resolves named color spaces using res.
+func inlineImageColorSpace(xRefTable *model.XRefTable, res types.Dict, o types.Object) types.Object {
+	switch o := o.(type) {
+
+	case types.Name:
+		if s, ok := inlineImageColorSpaces[o.Value()]; ok {
+			return types.Name(s)
+		}
+		switch o.Value() {
+		case model.DeviceGrayCS, model.DeviceRGBCS, model.DeviceCMYKCS:
+			return o
+		}
+		d, err := xRefTable.DereferenceDict(res["ColorSpace"])
+		if err != nil || d == nil {
+			return nil
+		}
+		cs, found := d.Find(o.Value())
+		if !found {
+			return nil
+		}
+		return cs
+
+	case types.Array:
+		// Indexed color space
+		if len(o) != 4 {
+			return nil
+		}
+		if o[0] = expandInlineImageNames(inlineImageColorSpaces, o[0]); o[0] != types.Name(model.IndexedCS) {
+			return nil
+		}
+		if o[1] = inlineImageColorSpace(xRefTable, res, o[1]); o[1] == nil {
+			return nil
+		}
+		return o
+	}
+
+	return nil
+}
+
+// inlineImageDict returns the image XObject dict corresponding to the inline image dict entries oo.
+func inlineImageDict(xRefTable *model.XRefTable, res types.Dict, oo []interface{}) (types.Dict, bool) {
+	if len(oo)%2 != 0 {
+		return nil, false
+	}
+
+	d := types.NewDict()
+	d.InsertName("Type", "XObject")
+	d.InsertName("Subtype", "Image")
+
+	for i := 0; i < len(oo); i += 2 {
+		n, ok := oo[i].(contentName)
+		if !ok {
+			return nil, false
+		}
+
+		k := string(n)
+		if s, ok := inlineImageKeys[k]; ok {
+			k = s
+		}
+
+		o := contentObject(oo[i+1])
+		if o == nil {
+			return nil, false
+		}
+
+		switch k {
+		case "ColorSpace":
+			if o = inlineImageColorSpace(xRefTable, res, o); o == nil {
+				return nil, false
+			}
+		case "Filter":
+			o = expandInlineImageNames(inlineImageFilters, o)
+		case "L", "Length":
+			continue
+		}
+
+		d.Insert(k, o)
+	}
+
+	return d, true
+}
+
+// inlineImageSize returns the size of the unfiltered image data described by d or 0 if unknown.
+func inlineImageSize(xRefTable *model.XRefTable, d types.Dict) int {
+	w, h := d.IntEntry("Width"), d.IntEntry("Height")
+	if w == nil || h == nil {
+		return 0
+	}
+
+	bpc, comps := 1, 1
+
+	if im := d.BooleanEntry("ImageMask"); im == nil || !*im {
+		i := d.IntEntry("BitsPerComponent")
+		if i == nil {
+			return 0
+		}
+		bpc = *i
+
+		o, err := xRefTable.Dereference(d["ColorSpace"])
+		if err != nil {
+			return 0
+		}
+		a, ok := o.(types.Array)
+		if ok && len(a) == 0 {
+			return 0
+		}
+		if !ok || a[0] != types.Name(model.IndexedCS) {
+			if comps, err = ColorSpaceComponents(xRefTable, &types.StreamDict{Dict: d}); err != nil {
+				return 0
+			}
+		}
+	}
+
+	return (*w*comps*bpc + 7) / 8 * *h
+}
+
+// inlineImageData returns the inline image data following an ID operator at pos
+// along with the position after the terminating EI operator.
+// size is the expected size of unfiltered image data or 0.
+func inlineImageData(bb []byte, pos, size int) ([]byte, int) {
+	// ID is followed by a single white-space character.
+	start := pos + 1
+	if start > len(bb) {
+		start = len(bb)
+	}
+
+	if size > 0 && start+size <= len(bb) {
+		i := start + size
+		for i < len(bb) && contentWhitespace(bb[i]) {
+			i++
+		}
+		if i+1 < len(bb) && bb[i] == 'E' && bb[i+1] == 'I' && (i+2 == len(bb) || contentWhitespace(bb[i+2])) {
+			return bb[start : start+size], i + 2
+		}
+	}
+
+	i := inlineImageEI(bb, pos)
+	if i < 0 {
+		return bb[start:], len(bb)
+	}
+
+	// Strip the white-space preceding EI.
+	end := i - 1
+	if end < start {
+		end = start
+	}
+
+	return bb[start:end], i + 2
+}
+
+func inlineImageFilterPipeline(d types.Dict) []types.PDFFilter {
+	var ff, pp types.Array
+
+	switch o := d["Filter"].(type) {
+	case types.Name:
+		ff = types.Array{o}
+	case types.Array:
+		ff = o
+	}
+
+	switch o := d["DecodeParms"].(type) {
+	case types.Dict:
+		pp = types.Array{o}
+	case types.Array:
+		pp = o
+	}
+
+	fpl := make([]types.PDFFilter, len(ff))
+	for i, f := range ff {
+		if n, ok := f.(types.Name); ok {
+			fpl[i].Name = n.Value()
+		}
+		if i < len(pp) {
+			fpl[i].DecodeParms, _ = pp[i].(types.Dict)
+		}
+	}
+
+	return fpl
+}
+
+// inlineImageConverter converts large inline images into image XObjects.
+type inlineImageConverter struct {
+	ctx    *model.Context
+	st     *model.OptimizePassStats
+	images map[[md5.Size]byte]types.IndirectRef // Image XObjects created so far by fingerprint.
+}
+
+// imageRef returns an indirect reference to an image XObject for d and data.
+func (c *inlineImageConverter) imageRef(d types.Dict, data []byte) (*types.IndirectRef, error) {
+	key := md5.Sum(append([]byte(d.PDFString()), data...))
+	if ir, ok := c.images[key]; ok {
+		c.st.Bytes += int64(len(data))
+		return &ir, nil
+	}
+
+	sd := &types.StreamDict{Dict: d, Content: append([]byte(nil), data...)}
+
+	if _, filtered := d.Find("Filter"); filtered {
+		// Keep the encoded image data.
+		if err := sd.Encode(); err != nil {
+			return nil, err
+		}
+		sd.Content = nil
+		sd.FilterPipeline = inlineImageFilterPipeline(d)
+	} else {
+		sd.InsertName("Filter", filter.Flate)
+		sd.FilterPipeline = []types.PDFFilter{{Name: filter.Flate, DecodeParms: nil}}
+		if err := sd.Encode(); err != nil {
+			return nil, err
+		}
+		c.st.Bytes += int64(len(data) - len(sd.Raw))
+	}
+
+	ir, err := c.ctx.IndRefForNewObject(*sd)
+	if err != nil {
+		return nil, err
+	}
+
+	c.images[key] = *ir
+
+	return ir, nil
+}
+
+// resourceName returns the name of the image XObject ir refers to in res.
+func (c *inlineImageConverter) resourceName(res types.Dict, ir types.IndirectRef) (string, error) {
+	d, err := c.ctx.DereferenceDict(res["XObject"])
+	if err != nil {
+		return "", err
+	}
+
+	if d == nil {
+		d = types.NewDict()
+		res.Insert("XObject", d)
+	}
+
+	for k, o := range d {
+		if ir1, ok := o.(types.IndirectRef); ok && ir1 == ir {
+			return k, nil
+		}
+	}
+
+	for i := len(d) + 1; ; i++ {
+		k := fmt.Sprintf("Im%d", i)
+		if _, found := d.Find(k); !found {
+			d.Insert(k, ir)
+			return k, nil
+		}
+	}
+}
+
+// convert returns the replacement for the inline image with dict entries oo whose data follows an ID operator at l.pos
+// and positions l after the terminating EI operator.
+func (c *inlineImageConverter) convert(res types.Dict, oo []interface{}, l *contentLexer) (string, error) {
+	d, ok := inlineImageDict(c.ctx.XRefTable, res, oo)
+
+	size := 0
+	if ok {
+		size = inlineImageSize(c.ctx.XRefTable, d)
+	}
+
+	data, pos := inlineImageData(l.bb, l.pos, size)
+	l.pos = pos
+
+	if !ok || res == nil || len(data) < minInlineImageSize {
+		return "", nil
+	}
+
+	if _, filtered := d.Find("Filter"); !filtered && len(data) != size {
+		// Unable to reliably determine the end of the image data.
+		return "", nil
+	}
+
+	ir, err := c.imageRef(d, data)
+	if err != nil {
+		return "", err
+	}
+
+	name, err := c.resourceName(res, *ir)
+	if err != nil {
+		return "", err
+	}
+
+	c.st.Objects++
+
+	return "/" + name + " Do", nil
+}
+
+// content replaces large inline images of content bb using resources res by image XObjects.
+func (c *inlineImageConverter) content(res types.Dict, bb []byte) ([]byte, bool, error) {
+	l := &contentLexer{bb: bb}
+
+	var (
+		buf     bytes.Buffer
+		oo      []interface{}
+		last    int // End of the bytes already copied to buf.
+		start   = -1
+		bi      = -1 // Start of the inline image in progress.
+		changed bool
+	)
+
+	for {
+		l.skipWhitespaceAndComments()
+		if start < 0 {
+			start = l.pos
+		}
+
+		o, ok := l.next()
+		if !ok {
+			break
+		}
+
+		op, ok := o.(contentOp)
+		if !ok {
+			oo = append(oo, o)
+			continue
+		}
+
+		switch op {
+
+		case "BI":
+			bi = start
+
+		case "ID":
+			s, err := c.convert(res, oo, l)
+			if err != nil {
+				return nil, false, err
+			}
+			if s != "" && bi >= 0 {
+				buf.Write(bb[last:bi])
+				buf.WriteString(s)
+				last, changed = l.pos, true
+			}
+			bi = -1
+
+		default:
+			bi = -1
+		}
+
+		oo, start = oo[:0], -1
+	}
+
+	if !changed {
+		return bb, false, nil
+	}
+
+	buf.Write(bb[last:])
+
+	return buf.Bytes(), true, nil
+}
+
+// convertInlineImages replaces large inline images in the content of all pages, form XObjects and tiling patterns
+// by image XObjects which compress better and are subject to deduplication.
+func convertInlineImages(ctx *model.Context) error {
+	if log.OptimizeEnabled() {
+		log.Optimize.Println("convertInlineImages begin")
+	}
+
+	c := &inlineImageConverter{
+		ctx:    ctx,
+		st:     passStats(ctx, model.OptimizePassInlineImages),
+		images: map[[md5.Size]byte]types.IndirectRef{},
+	}
+
+	cw := newContentWalker(ctx, func(pageNr int, res types.Dict, bb []byte) ([]byte, bool, error) {
+		return c.content(res, bb)
+	}, false)
+
+	if err := cw.walk(nil); err != nil {
+		return err
+	}
+
+	if log.OptimizeEnabled() {
+		log.Optimize.Println("convertInlineImages end")
+	}
+
+	return nil
+}
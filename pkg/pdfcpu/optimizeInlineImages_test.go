@@ -0,0 +1,54 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import "testing"
+
+func TestInlineImageData(t *testing.T) {
+	for _, tt := range []struct {
+		id, in string
+		size   int
+		data   string
+		rest   string
+	}{
+		{"plain", "BI /W 2 /H 1 /BPC 8 /CS /G ID ab EI Q", 0, "ab", " Q"},
+		{"binaryEI", "BI /F /Fl ID a EI \x80\x81 EI Q", 0, "a EI \x80\x81", " Q"},
+		{"textualEI", "BI /F /Fl ID a EI xyz EI Q", 0, "a EI xyz", " Q"},
+		{"exactSize", "BI /W 4 /H 1 /BPC 8 /CS /G ID  EI \nEI Q", 4, " EI ", " Q"},
+		{"eof", "BI /F /Fl ID abc EI", 0, "abc", ""},
+		{"missingEI", "BI /F /Fl ID abc", 0, "abc", ""},
+	} {
+		l := &contentLexer{bb: []byte(tt.in)}
+		for {
+			o, ok := l.next()
+			if !ok {
+				t.Fatalf("%s: missing ID\n", tt.id)
+			}
+			if op, ok := o.(contentOp); ok && op == "ID" {
+				break
+			}
+		}
+
+		data, pos := inlineImageData(l.bb, l.pos, tt.size)
+		if string(data) != tt.data {
+			t.Fatalf("%s: want data %q, got %q\n", tt.id, tt.data, data)
+		}
+		if rest := tt.in[pos:]; rest != tt.rest {
+			t.Fatalf("%s: want rest %q, got %q\n", tt.id, tt.rest, rest)
+		}
+	}
+}
@@ -0,0 +1,470 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"fmt"
+
+	"github.com/pdfcpu/pdfcpu/pkg/filter"
+	"github.com/pdfcpu/pdfcpu/pkg/font"
+	"github.com/pdfcpu/pdfcpu/pkg/log"
+	pdffont "github.com/pdfcpu/pdfcpu/pkg/pdfcpu/font"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+func passStats(ctx *model.Context, pass string) *model.OptimizePassStats {
+	st, ok := ctx.Optimize.PassStats[pass]
+	if !ok {
+		st = &model.OptimizePassStats{}
+		ctx.Optimize.PassStats[pass] = st
+	}
+	return st
+}
+
+func recompressable(ctx *model.Context, objNr int, sd types.StreamDict) bool {
+	if sd.FilterPipeline != nil || sd.Raw == nil || len(sd.Raw) == 0 {
+		return false
+	}
+
+	if _, found := sd.Find("Filter"); found {
+		return false
+	}
+
+	if ctx.Read.IsObjectStreamObject(objNr) || ctx.Read.XRefStreams[objNr] || ctx.IsLinearizationObject(objNr) {
+		return false
+	}
+
+	// Keep XMP metadata readable by non PDF aware tools.
+	if t := sd.Type(); t != nil && *t == "Metadata" {
+		return false
+	}
+
+	return true
+}
+
+// recompressStreams flate encodes all uncompressed streams if this reduces their size.
+func recompressStreams(ctx *model.Context) error {
+	if log.OptimizeEnabled() {
+		log.Optimize.Println("recompressStreams begin")
+	}
+
+	st := passStats(ctx, model.OptimizePassRecompress)
+
+	for objNr, entry := range ctx.Table {
+		if entry.Free || entry.Object == nil {
+			continue
+		}
+
+		sd, ok := entry.Object.(types.StreamDict)
+		if !ok || !recompressable(ctx, objNr, sd) {
+			continue
+		}
+
+		raw := sd.Raw
+		sd1 := types.NewStreamDict(sd.Dict.Clone().(types.Dict), 0, nil, nil, []types.PDFFilter{{Name: filter.Flate}})
+		sd1.Content = raw
+		sd1.InsertName("Filter", filter.Flate)
+		sd1.Delete("DecodeParms")
+
+		if err := sd1.Encode(); err != nil {
+			return err
+		}
+
+		if len(sd1.Raw) >= len(raw) {
+			continue
+		}
+
+		if log.OptimizeEnabled() {
+			log.Optimize.Printf("recompressStreams: obj#%d %d -> %d bytes\n", objNr, len(raw), len(sd1.Raw))
+		}
+
+		st.Objects++
+		st.Bytes += int64(len(raw) - len(sd1.Raw))
+
+		entry.Object = sd1
+	}
+
+	if log.OptimizeEnabled() {
+		log.Optimize.Println("recompressStreams end")
+	}
+
+	return nil
+}
+
+// streamLength returns the encoded length of the stream referenced by o.
+func streamLength(ctx *model.Context, o types.Object) int64 {
+	if sd, _, err := ctx.DereferenceStreamDict(o); err == nil && sd != nil && sd.StreamLength != nil {
+		return *sd.StreamLength
+	}
+	return 0
+}
+
+func removeStreamEntry(ctx *model.Context, d types.Dict, key string, st *model.OptimizePassStats) error {
+	o, found := d.Find(key)
+	if !found {
+		return nil
+	}
+
+	st.Bytes += streamLength(ctx, o)
+
+	if err := ctx.DeleteDictEntry(d, key); err != nil {
+		return err
+	}
+
+	st.Objects++
+
+	return nil
+}
+
+// removeMetadata removes XMP metadata of the document and its pages as well as page piece info.
+// This breaks PDF/A conformance.
+func removeMetadata(ctx *model.Context) error {
+	if log.OptimizeEnabled() {
+		log.Optimize.Println("removeMetadata begin")
+	}
+
+	st := passStats(ctx, model.OptimizePassMetadata)
+
+	if err := removeStreamEntry(ctx, ctx.RootDict, "Metadata", st); err != nil {
+		return err
+	}
+
+	for i := 1; i <= ctx.PageCount; i++ {
+		d, _, _, err := ctx.PageDict(i, false)
+		if err != nil {
+			return err
+		}
+		if d == nil {
+			continue
+		}
+		for _, key := range []string{"Metadata", "PieceInfo"} {
+			if err := removeStreamEntry(ctx, d, key, st); err != nil {
+				return err
+			}
+		}
+	}
+
+	if log.OptimizeEnabled() {
+		log.Optimize.Println("removeMetadata end")
+	}
+
+	return nil
+}
+
+// removeAlternateImages removes alternate image representations (eg. for printing) of all images.
+func removeAlternateImages(ctx *model.Context) error {
+	if log.OptimizeEnabled() {
+		log.Optimize.Println("removeAlternateImages begin")
+	}
+
+	st := passStats(ctx, model.OptimizePassAlternates)
+
+	for objNr, entry := range ctx.Table {
+		if entry.Free || entry.Object == nil {
+			continue
+		}
+
+		sd, ok := entry.Object.(types.StreamDict)
+		if !ok {
+			continue
+		}
+
+		if t := sd.Subtype(); t == nil || *t != "Image" {
+			continue
+		}
+
+		o, found := sd.Find("Alternates")
+		if !found {
+			continue
+		}
+
+		a, err := ctx.DereferenceArray(o)
+		if err != nil {
+			return err
+		}
+
+		for _, o := range a {
+			d, err := ctx.DereferenceDict(o)
+			if err != nil {
+				return err
+			}
+			if d != nil {
+				st.Bytes += streamLength(ctx, d["Image"])
+				st.Objects++
+			}
+		}
+
+		if log.OptimizeEnabled() {
+			log.Optimize.Printf("removeAlternateImages: obj#%d: %d alternates\n", objNr, len(a))
+		}
+
+		sd.Delete("Alternates")
+		entry.Object = sd
+	}
+
+	if log.OptimizeEnabled() {
+		log.Optimize.Println("removeAlternateImages end")
+	}
+
+	return nil
+}
+
+// removeThumbnails removes all page thumbnail images.
+func removeThumbnails(ctx *model.Context) error {
+	if log.OptimizeEnabled() {
+		log.Optimize.Println("removeThumbnails begin")
+	}
+
+	st := passStats(ctx, model.OptimizePassThumbnails)
+
+	for i := 1; i <= ctx.PageCount; i++ {
+		d, _, _, err := ctx.PageDict(i, false)
+		if err != nil {
+			return err
+		}
+		if d == nil {
+			continue
+		}
+		if err := removeStreamEntry(ctx, d, "Thumb", st); err != nil {
+			return err
+		}
+		delete(ctx.PageThumbs, i)
+	}
+
+	if log.OptimizeEnabled() {
+		log.Optimize.Println("removeThumbnails end")
+	}
+
+	return nil
+}
+
+// removeUnusedAppearanceStates removes all appearance states of an annotation except the one selected by "AS".
+// Widget annotations are skipped since form fields need all their states for toggling.
+func removeUnusedAppearanceStates(ctx *model.Context, d types.Dict, st *model.OptimizePassStats) error {
+	if t := d.Subtype(); t == nil || *t == "Widget" {
+		return nil
+	}
+
+	as := d.NameEntry("AS")
+	if as == nil {
+		return nil
+	}
+
+	apDict, err := ctx.DereferenceDict(d["AP"])
+	if err != nil || apDict == nil {
+		return err
+	}
+
+	for _, k := range []string{"N", "R", "D"} {
+		o, err := ctx.Dereference(apDict[k])
+		if err != nil {
+			return err
+		}
+		// Appearance streams without states are left alone.
+		states, ok := o.(types.Dict)
+		if !ok {
+			continue
+		}
+		for state, o := range states {
+			if state == *as {
+				continue
+			}
+			st.Bytes += streamLength(ctx, o)
+			st.Objects++
+			delete(states, state)
+		}
+	}
+
+	return nil
+}
+
+// removeAppearanceStates removes unused appearance states of all markup annotations.
+func removeAppearanceStates(ctx *model.Context) error {
+	if log.OptimizeEnabled() {
+		log.Optimize.Println("removeAppearanceStates begin")
+	}
+
+	st := passStats(ctx, model.OptimizePassAppStates)
+
+	for i := 1; i <= ctx.PageCount; i++ {
+		d, _, _, err := ctx.PageDict(i, false)
+		if err != nil {
+			return err
+		}
+		if d == nil {
+			continue
+		}
+
+		a, err := ctx.DereferenceArray(d["Annots"])
+		if err != nil {
+			return err
+		}
+
+		for _, o := range a {
+			ad, err := ctx.DereferenceDict(o)
+			if err != nil {
+				return err
+			}
+			if ad == nil {
+				continue
+			}
+			if err := removeUnusedAppearanceStates(ctx, ad, st); err != nil {
+				return err
+			}
+		}
+	}
+
+	if log.OptimizeEnabled() {
+		log.Optimize.Println("removeAppearanceStates end")
+	}
+
+	return nil
+}
+
+// stdFontSubstitutes maps the PostScript names of common fonts to their metrically identical standard font.
+var stdFontSubstitutes = map[string]string{
+	"Arial":                        "Helvetica",
+	"ArialMT":                      "Helvetica",
+	"Arial,Bold":                   "Helvetica-Bold",
+	"Arial-BoldMT":                 "Helvetica-Bold",
+	"Arial,Italic":                 "Helvetica-Oblique",
+	"Arial-ItalicMT":               "Helvetica-Oblique",
+	"Arial,BoldItalic":             "Helvetica-BoldOblique",
+	"Arial-BoldItalicMT":           "Helvetica-BoldOblique",
+	"TimesNewRoman":                "Times-Roman",
+	"TimesNewRomanPSMT":            "Times-Roman",
+	"TimesNewRoman,Bold":           "Times-Bold",
+	"TimesNewRomanPS-BoldMT":       "Times-Bold",
+	"TimesNewRoman,Italic":         "Times-Italic",
+	"TimesNewRomanPS-ItalicMT":     "Times-Italic",
+	"TimesNewRoman,BoldItalic":     "Times-BoldItalic",
+	"TimesNewRomanPS-BoldItalicMT": "Times-BoldItalic",
+	"CourierNew":                   "Courier",
+	"CourierNewPSMT":               "Courier",
+	"CourierNew,Bold":              "Courier-Bold",
+	"CourierNewPS-BoldMT":          "Courier-Bold",
+	"CourierNew,Italic":            "Courier-Oblique",
+	"CourierNewPS-ItalicMT":        "Courier-Oblique",
+	"CourierNew,BoldItalic":        "Courier-BoldOblique",
+	"CourierNewPS-BoldItalicMT":    "Courier-BoldOblique",
+}
+
+func stdFontSubstitute(fontName string) (string, bool) {
+	if font.IsCoreFont(fontName) {
+		return fontName, true
+	}
+	s, ok := stdFontSubstitutes[fontName]
+	return s, ok
+}
+
+// unembeddable returns true if the embedded font program of a simple font may be replaced by the standard font stdName.
+// Glyph selection has to be based on glyph names which excludes symbolic TrueType fonts relying on their cmap.
+func unembeddable(ctx *model.Context, fontDict, fd types.Dict, stdName string) bool {
+	if fontDict.Subtype() == nil || (*fontDict.Subtype() != "Type1" && *fontDict.Subtype() != "TrueType") {
+		return false
+	}
+
+	if stdName == "Symbol" || stdName == "ZapfDingbats" {
+		return *fontDict.Subtype() == "Type1"
+	}
+
+	if flags := fd.IntEntry("Flags"); flags != nil && *flags&0x04 > 0 {
+		// Symbolic
+		return false
+	}
+
+	if *fontDict.Subtype() == "TrueType" {
+		o, err := ctx.Dereference(fontDict["Encoding"])
+		if err != nil || o == nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+// unembedStdFont replaces the embedded font program of fontDict by a metrically identical standard font.
+func unembedStdFont(ctx *model.Context, objNr int, fontDict types.Dict, st *model.OptimizePassStats) error {
+	_, fontName, err := pdffont.Name(ctx.XRefTable, fontDict, objNr)
+	if err != nil {
+		return err
+	}
+
+	stdName, ok := stdFontSubstitute(fontName)
+	if !ok {
+		return nil
+	}
+
+	fd, err := ctx.DereferenceDict(fontDict["FontDescriptor"])
+	if err != nil || fd == nil {
+		return err
+	}
+
+	var fontFile types.Object
+	for _, k := range []string{"FontFile", "FontFile2", "FontFile3"} {
+		if o, found := fd.Find(k); found {
+			fontFile = o
+			break
+		}
+	}
+	if fontFile == nil || !unembeddable(ctx, fontDict, fd, stdName) {
+		return nil
+	}
+
+	model.ShowWarning(fmt.Sprintf("obj#%d: unembedding font %s in favor of standard font %s", objNr, fontName, stdName))
+
+	st.Bytes += streamLength(ctx, fontFile)
+	st.Objects++
+
+	fontDict["Subtype"] = types.Name("Type1")
+	fontDict["BaseFont"] = types.Name(stdName)
+	delete(fontDict, "FontDescriptor")
+
+	return nil
+}
+
+// unembedStdFonts replaces embedded fonts metrically identical to one of the standard 14 fonts by the latter.
+// Any viewer is required to supply the standard fonts.
+func unembedStdFonts(ctx *model.Context) error {
+	if log.OptimizeEnabled() {
+		log.Optimize.Println("unembedStdFonts begin")
+	}
+
+	st := passStats(ctx, model.OptimizePassStdFonts)
+
+	for objNr, entry := range ctx.Table {
+		if entry.Free || entry.Object == nil {
+			continue
+		}
+
+		d, ok := entry.Object.(types.Dict)
+		if !ok || d.Type() == nil || *d.Type() != "Font" || d.Subtype() == nil {
+			continue
+		}
+
+		if err := unembedStdFont(ctx, objNr, d, st); err != nil {
+			return err
+		}
+	}
+
+	if log.OptimizeEnabled() {
+		log.Optimize.Println("unembedStdFonts end")
+	}
+
+	return nil
+}
@@ -0,0 +1,343 @@
+/*
+	Copyright 2026 The pdfcpu Authors.
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// Output intent subtypes (see 14.11.5).
+const (
+	OutputIntentPDFX  = "GTS_PDFX"
+	OutputIntentPDFA1 = "GTS_PDFA1"
+	OutputIntentPDFE1 = "ISO_PDFE1"
+)
+
+// customOutputCondition identifies a characterized printing condition not found in the ICC registry.
+const customOutputCondition = "Custom"
+
+// OutputIntent describes an output intent of the catalog.
+type OutputIntent struct {
+	Subtype                   string `json:"subtype"`
+	OutputCondition           string `json:"outputCondition,omitempty"`
+	OutputConditionIdentifier string `json:"outputConditionIdentifier"`
+	RegistryName              string `json:"registryName,omitempty"`
+	Info                      string `json:"info,omitempty"`
+	Profile                   string `json:"profile,omitempty"` // data color space, description and size of the destination output profile
+}
+
+func (oi OutputIntent) String() string {
+	ss := []string{fmt.Sprintf("%s: %s", oi.Subtype, oi.OutputConditionIdentifier)}
+	if oi.OutputCondition != "" {
+		ss = append(ss, fmt.Sprintf("  condition: %s", oi.OutputCondition))
+	}
+	if oi.RegistryName != "" {
+		ss = append(ss, fmt.Sprintf("   registry: %s", oi.RegistryName))
+	}
+	if oi.Info != "" {
+		ss = append(ss, fmt.Sprintf("       info: %s", oi.Info))
+	}
+	if oi.Profile != "" {
+		ss = append(ss, fmt.Sprintf("    profile: %s", oi.Profile))
+	}
+	return strings.Join(ss, "\n")
+}
+
+// ParseOutputIntentSubtype returns the output intent subtype for s.
+func ParseOutputIntentSubtype(s string) (string, error) {
+	for _, st := range []string{OutputIntentPDFX, OutputIntentPDFA1, OutputIntentPDFE1} {
+		if strings.EqualFold(s, st) {
+			return st, nil
+		}
+	}
+	return "", errors.Errorf("pdfcpu: unsupported output intent subtype: %s (should be %s, %s or %s)", s, OutputIntentPDFX, OutputIntentPDFA1, OutputIntentPDFE1)
+}
+
+func outputIntentDicts(ctx *model.Context) (types.Array, error) {
+	rootDict, err := ctx.Catalog()
+	if err != nil {
+		return nil, err
+	}
+	return ctx.DereferenceArray(rootDict["OutputIntents"])
+}
+
+// outputIntent returns the output intent of the catalog for subtype.
+func outputIntent(ctx *model.Context, subtype string) (types.Dict, error) {
+	arr, err := outputIntentDicts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, o := range arr {
+		d, err := ctx.DereferenceDict(o)
+		if err != nil || d == nil {
+			continue
+		}
+		if s := d.NameEntry("S"); s != nil && *s == subtype {
+			return d, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// appendOutputIntent adds the output intent d to the catalog.
+func appendOutputIntent(ctx *model.Context, d types.Dict) error {
+	ir, err := ctx.IndRefForNewObject(d)
+	if err != nil {
+		return err
+	}
+
+	rootDict, err := ctx.Catalog()
+	if err != nil {
+		return err
+	}
+
+	arr, err := ctx.DereferenceArray(rootDict["OutputIntents"])
+	if err != nil {
+		return err
+	}
+
+	rootDict.Update("OutputIntents", append(arr, *ir))
+
+	return nil
+}
+
+// newOutputIntentDict returns an output intent dict for subtype referring to the characterized printing condition identified by outputCondition.
+func newOutputIntentDict(subtype, outputCondition, info string) (types.Dict, error) {
+	d := types.Dict{
+		"Type": types.Name("OutputIntent"),
+		"S":    types.Name(subtype),
+	}
+
+	s, err := types.EscapedUTF16String(outputCondition)
+	if err != nil {
+		return nil, err
+	}
+	d["OutputConditionIdentifier"] = types.StringLiteral(*s)
+
+	if outputCondition != customOutputCondition {
+		d["RegistryName"] = types.StringLiteral("http://www.color.org")
+	}
+
+	if info != "" {
+		s, err := types.EscapedUTF16String(info)
+		if err != nil {
+			return nil, err
+		}
+		d["Info"] = types.StringLiteral(*s)
+	}
+
+	return d, nil
+}
+
+func textEntry(ctx *model.Context, d types.Dict, key string) string {
+	o, found := d.Find(key)
+	if !found {
+		return ""
+	}
+	s, err := ctx.DereferenceText(o)
+	if err != nil {
+		return ""
+	}
+	return s
+}
+
+func outputProfile(ctx *model.Context, o types.Object) string {
+	sd, _, err := ctx.DereferenceStreamDict(o)
+	if err != nil || sd == nil {
+		return ""
+	}
+
+	if err := sd.Decode(); err != nil {
+		return "corrupt"
+	}
+
+	p, err := newICCProfile(sd.Content)
+	if err != nil {
+		return "invalid"
+	}
+
+	ss := []string{strings.TrimSpace(p.dataColorSpace())}
+	if s := p.description(); s != "" {
+		ss = append(ss, s)
+	}
+	ss = append(ss, fmt.Sprintf("%d bytes", len(sd.Content)))
+
+	return strings.Join(ss, ", ")
+}
+
+// OutputIntents returns the output intents of the catalog.
+func OutputIntents(ctx *model.Context) ([]OutputIntent, error) {
+	arr, err := outputIntentDicts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var ois []OutputIntent
+
+	for _, o := range arr {
+		d, err := ctx.DereferenceDict(o)
+		if err != nil || d == nil {
+			continue
+		}
+
+		oi := OutputIntent{
+			OutputCondition:           textEntry(ctx, d, "OutputCondition"),
+			OutputConditionIdentifier: textEntry(ctx, d, "OutputConditionIdentifier"),
+			RegistryName:              textEntry(ctx, d, "RegistryName"),
+			Info:                      textEntry(ctx, d, "Info"),
+		}
+
+		if s := d.NameEntry("S"); s != nil {
+			oi.Subtype = *s
+		}
+
+		if o, found := d.Find("DestOutputProfile"); found {
+			oi.Profile = outputProfile(ctx, o)
+		}
+
+		ois = append(ois, oi)
+	}
+
+	return ois, nil
+}
+
+// ListOutputIntents returns a list of the output intents of the catalog.
+func ListOutputIntents(ctx *model.Context) ([]string, error) {
+	ois, err := OutputIntents(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ois) == 0 {
+		return []string{"no output intents"}, nil
+	}
+
+	var ss []string
+	for _, oi := range ois {
+		ss = append(ss, oi.String())
+	}
+
+	return ss, nil
+}
+
+// AddOutputIntent adds an output intent for subtype using the ICC profile bb as destination output profile.
+// An existing output intent for subtype gets replaced.
+// outputCondition is the registered characterized printing condition of the profile, eg. "FOGRA39", and defaults to "Custom".
+func AddOutputIntent(ctx *model.Context, subtype, outputCondition string, bb []byte) error {
+	p, err := newICCProfile(bb)
+	if err != nil {
+		return err
+	}
+
+	n, err := p.components()
+	if err != nil {
+		return err
+	}
+
+	if outputCondition == "" {
+		outputCondition = customOutputCondition
+	}
+
+	desc := p.description()
+
+	d, err := newOutputIntentDict(subtype, outputCondition, desc)
+	if err != nil {
+		return err
+	}
+
+	if desc != "" {
+		s, err := types.EscapedUTF16String(desc)
+		if err != nil {
+			return err
+		}
+		d["OutputCondition"] = types.StringLiteral(*s)
+	}
+
+	sd, err := ctx.NewStreamDictForBuf(bb)
+	if err != nil {
+		return err
+	}
+	sd.InsertInt("N", n)
+	if err := sd.Encode(); err != nil {
+		return err
+	}
+
+	ir, err := ctx.IndRefForNewObject(*sd)
+	if err != nil {
+		return err
+	}
+	d["DestOutputProfile"] = *ir
+
+	if _, err := RemoveOutputIntents(ctx, subtype); err != nil {
+		return err
+	}
+
+	return appendOutputIntent(ctx, d)
+}
+
+// RemoveOutputIntents removes the output intents for subtype or all output intents for an empty subtype.
+// Returns true if at least one output intent was removed.
+func RemoveOutputIntents(ctx *model.Context, subtype string) (bool, error) {
+	rootDict, err := ctx.Catalog()
+	if err != nil {
+		return false, err
+	}
+
+	arr, err := ctx.DereferenceArray(rootDict["OutputIntents"])
+	if err != nil || len(arr) == 0 {
+		return false, err
+	}
+
+	if subtype == "" {
+		delete(rootDict, "OutputIntents")
+		return true, nil
+	}
+
+	var arr1 types.Array
+	for _, o := range arr {
+		d, err := ctx.DereferenceDict(o)
+		if err != nil {
+			return false, err
+		}
+		if d != nil {
+			if s := d.NameEntry("S"); s != nil && *s == subtype {
+				continue
+			}
+		}
+		arr1 = append(arr1, o)
+	}
+
+	if len(arr1) == len(arr) {
+		return false, nil
+	}
+
+	if len(arr1) == 0 {
+		delete(rootDict, "OutputIntents")
+		return true, nil
+	}
+
+	rootDict["OutputIntents"] = arr1
+
+	return true, nil
+}
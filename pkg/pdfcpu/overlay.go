@@ -0,0 +1,270 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// ParseOverlayMode parses the strategy for base pages lacking a corresponding overlay page.
+func ParseOverlayMode(s string) (int, error) {
+	switch strings.ToLower(s) {
+	case "", "repeat":
+		return model.PdfMultiRepeat, nil
+	case "cycle":
+		return model.PdfMultiCycle, nil
+	case "once":
+		return model.PdfMultiOnce, nil
+	}
+	return 0, errors.Errorf("pdfcpu: invalid overlay mode %q, please provide one of: repeat, cycle, once", s)
+}
+
+// overlayPageNr returns the overlay page to be composited with the i-th selected page or 0.
+func overlayPageNr(i, pageCount, mode int) int {
+	if i <= pageCount {
+		return i
+	}
+	switch mode {
+	case model.PdfMultiCycle:
+		return (i-1)%pageCount + 1
+	case model.PdfMultiOnce:
+		return 0
+	}
+	return pageCount
+}
+
+// overlayForm returns a form XObject wrapping page pageNr of otherCtx migrated into ctx
+// along with the visible region of this page.
+func overlayForm(ctx, otherCtx *model.Context, pageNr int, migrated map[int]int) (*types.IndirectRef, *types.Rectangle, error) {
+	d, _, inhPAttrs, err := otherCtx.PageDict(pageNr, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	if d == nil {
+		return nil, nil, errors.Errorf("pdfcpu: unknown page number: %d\n", pageNr)
+	}
+
+	bb, err := otherCtx.PageContent(d)
+	if err != nil && err != model.ErrNoContent {
+		return nil, nil, err
+	}
+
+	if _, err = migrateObject(inhPAttrs.Resources, otherCtx, ctx, migrated); err != nil {
+		return nil, nil, err
+	}
+
+	r := viewPort(inhPAttrs)
+
+	sd, _ := ctx.NewStreamDictForBuf(bb)
+	sd.InsertName("Type", "XObject")
+	sd.InsertName("Subtype", "Form")
+	sd.Insert("BBox", r.Array())
+	if inhPAttrs.Resources != nil {
+		sd.Insert("Resources", inhPAttrs.Resources)
+	}
+
+	if err := sd.Encode(); err != nil {
+		return nil, nil, err
+	}
+
+	ir, err := ctx.IndRefForNewObject(*sd)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return ir, r, nil
+}
+
+func addOverlayResource(ctx *model.Context, d types.Dict, resDict types.Dict, form types.IndirectRef) (string, error) {
+	if resDict == nil {
+		resDict = types.Dict{}
+	}
+
+	xoDict := types.Dict{}
+	if o, found := resDict.Find("XObject"); found {
+		d1, err := ctx.DereferenceDict(o)
+		if err != nil {
+			return "", err
+		}
+		if d1 != nil {
+			xoDict = d1
+		}
+	}
+
+	id := "Ov0"
+	for i := 1; ; i++ {
+		if _, found := xoDict.Find(id); !found {
+			break
+		}
+		id = "Ov" + strconv.Itoa(i)
+	}
+
+	xoDict.Insert(id, form)
+	resDict.Update("XObject", xoDict)
+	d.Update("Resources", resDict)
+
+	return id, nil
+}
+
+func newContentStreamRef(ctx *model.Context, s string) (*types.IndirectRef, error) {
+	sd, _ := ctx.NewStreamDictForBuf([]byte(s))
+	if err := sd.Encode(); err != nil {
+		return nil, err
+	}
+	return ctx.IndRefForNewObject(*sd)
+}
+
+// wrapPageContent wraps the page content of d between pre and post leaving the existing content streams untouched.
+func wrapPageContent(ctx *model.Context, d types.Dict, pre, post string) error {
+	var contents types.Array
+
+	if o, found := d.Find("Contents"); found {
+		o1, err := ctx.Dereference(o)
+		if err != nil {
+			return err
+		}
+		switch o1 := o1.(type) {
+		case types.StreamDict:
+			contents = types.Array{o}
+		case types.Array:
+			contents = o1
+		case nil:
+		default:
+			return errors.New("pdfcpu: corrupt page \"Contents\"")
+		}
+	}
+
+	irPre, err := newContentStreamRef(ctx, pre)
+	if err != nil {
+		return err
+	}
+
+	irPost, err := newContentStreamRef(ctx, post)
+	if err != nil {
+		return err
+	}
+
+	arr := types.Array{*irPre}
+	arr = append(arr, contents...)
+	arr = append(arr, *irPost)
+
+	d.Update("Contents", arr)
+
+	return nil
+}
+
+func overlayPage(ctx *model.Context, pageNr int, form types.IndirectRef, r *types.Rectangle, under bool) error {
+	d, _, inhPAttrs, err := ctx.PageDict(pageNr, false)
+	if err != nil {
+		return err
+	}
+	if d == nil {
+		return errors.Errorf("pdfcpu: unknown page number: %d\n", pageNr)
+	}
+
+	id, err := addOverlayResource(ctx, d, inhPAttrs.Resources, form)
+	if err != nil {
+		return err
+	}
+
+	// Align the lower left corners of both visible regions.
+	vp := viewPort(inhPAttrs)
+	dx, dy := vp.LL.X-r.LL.X, vp.LL.Y-r.LL.Y
+	ov := fmt.Sprintf("q 1 0 0 1 %.5f %.5f cm /%s Do Q ", dx, dy, id)
+
+	if under {
+		return wrapPageContent(ctx, d, ov+"q ", " Q")
+	}
+	return wrapPageContent(ctx, d, "q ", " Q "+ov)
+}
+
+// Overlay composites the pages of otherCtx with selected pages of ctx.
+// The i-th selected page gets combined with the i-th page of otherCtx which is rendered
+// on top of the existing page content or underneath if under is true.
+// mode controls pages beyond the page count of otherCtx: model.PdfMultiRepeat, model.PdfMultiCycle or model.PdfMultiOnce.
+func Overlay(ctx, otherCtx *model.Context, selectedPages types.IntSet, under bool, mode int) error {
+	if otherCtx.XRefTable.Version() == model.V20 {
+		return ErrUnsupportedVersion
+	}
+
+	if err := otherCtx.EnsurePageCount(); err != nil {
+		return err
+	}
+
+	if otherCtx.PageCount == 0 {
+		return errors.New("pdfcpu: overlay: missing overlay pages")
+	}
+
+	var pageNrs []int
+	if len(selectedPages) == 0 {
+		for i := 1; i <= ctx.PageCount; i++ {
+			pageNrs = append(pageNrs, i)
+		}
+	} else {
+		for k, v := range selectedPages {
+			if v {
+				pageNrs = append(pageNrs, k)
+			}
+		}
+		sort.Ints(pageNrs)
+	}
+
+	type form struct {
+		ir *types.IndirectRef
+		r  *types.Rectangle
+	}
+
+	forms := map[int]form{}
+	migrated := map[int]int{}
+
+	for i, pageNr := range pageNrs {
+		j := overlayPageNr(i+1, otherCtx.PageCount, mode)
+		if j == 0 {
+			break
+		}
+
+		f, ok := forms[j]
+		if !ok {
+			ir, r, err := overlayForm(ctx, otherCtx, j, migrated)
+			if err != nil {
+				return err
+			}
+			f = form{ir, r}
+			forms[j] = f
+		}
+
+		if log.DebugEnabled() {
+			log.Debug.Printf("Overlay: page %d <- overlay page %d\n", pageNr, j)
+		}
+
+		if err := overlayPage(ctx, pageNr, *f.ir, f.r, under); err != nil {
+			return err
+		}
+	}
+
+	ctx.EnsureVersionForWriting()
+
+	return nil
+}
@@ -0,0 +1,349 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// OverprintConfig describes overprint settings for graphics state parameter dicts (see 8.4.5 and 8.6.7)
+// and the trapping status of a document (see 14.3.3).
+type OverprintConfig struct {
+	Stroke  *bool  // OP
+	Fill    *bool  // op
+	Mode    *int   // OPM
+	Trapped string // True, False or Unknown
+}
+
+// ExtGStateOverprint are the overprint settings of a graphics state parameter dict.
+type ExtGStateOverprint struct {
+	Name   string `json:"name"`            // Resource name of the first use.
+	ObjNr  int    `json:"objNr,omitempty"` // 0 for direct objects.
+	Stroke bool   `json:"stroke"`
+	Fill   bool   `json:"fill"`
+	Mode   int    `json:"mode"`
+	Pages  []int  `json:"pages"` // Pages using the graphics state.
+}
+
+// OverprintReport lists the overprint settings of a document.
+type OverprintReport struct {
+	Trapped    string               `json:"trapped,omitempty"`
+	Pages      []int                `json:"pages"`      // Pages using overprint.
+	ExtGStates []ExtGStateOverprint `json:"extGStates"` // Graphics states with overprint settings.
+}
+
+func parseOnOff(s string) (bool, error) {
+	switch strings.ToLower(s) {
+	case "on", "true", "t":
+		return true, nil
+	case "off", "false", "f":
+		return false, nil
+	}
+	return false, errors.Errorf("pdfcpu: invalid value %q, please use on or off", s)
+}
+
+// ParseOverprintConfig parses an overprint configuration string like "stroke:on, fill:on, mode:1, trapped:false".
+func ParseOverprintConfig(s string) (*OverprintConfig, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, errors.New("pdfcpu: missing overprint configuration string")
+	}
+
+	oc := &OverprintConfig{}
+
+	for _, s := range strings.Split(s, ",") {
+
+		ss := strings.Split(s, ":")
+		if len(ss) != 2 {
+			return nil, errors.New("pdfcpu: Invalid overprint configuration string. Please consult pdfcpu help overprint")
+		}
+
+		k, v := strings.ToLower(strings.TrimSpace(ss[0])), strings.TrimSpace(ss[1])
+
+		switch k {
+
+		case "stroke", "op":
+			b, err := parseOnOff(v)
+			if err != nil {
+				return nil, err
+			}
+			oc.Stroke = &b
+
+		case "fill":
+			b, err := parseOnOff(v)
+			if err != nil {
+				return nil, err
+			}
+			oc.Fill = &b
+
+		case "mode", "opm":
+			i, err := strconv.Atoi(v)
+			if err != nil || (i != 0 && i != 1) {
+				return nil, errors.Errorf("pdfcpu: invalid overprint mode %q, please use 0 or 1", v)
+			}
+			oc.Mode = &i
+
+		case "trapped":
+			t, err := parseTrapped(v)
+			if err != nil {
+				return nil, err
+			}
+			oc.Trapped = t
+
+		default:
+			return nil, errors.Errorf("pdfcpu: unknown overprint parameter: %s", k)
+		}
+	}
+
+	return oc, nil
+}
+
+func parseTrapped(s string) (string, error) {
+	switch strings.ToLower(s) {
+	case "true", "on", "t":
+		return "True", nil
+	case "false", "off", "f":
+		return "False", nil
+	case "unknown", "u":
+		return "Unknown", nil
+	}
+	return "", errors.Errorf("pdfcpu: invalid trapped value %q, please use true, false or unknown", s)
+}
+
+// extGStates returns the graphics state parameter dicts of res.
+func extGStates(ctx *model.Context, res types.Dict) types.Dict {
+	if res == nil {
+		return nil
+	}
+	d, err := ctx.DereferenceDict(res["ExtGState"])
+	if err != nil {
+		return nil
+	}
+	return d
+}
+
+// overprint returns the overprint settings of a graphics state parameter dict and whether any are present.
+func overprint(d types.Dict) (stroke, fill bool, mode int, ok bool) {
+	if b := d.BooleanEntry("OP"); b != nil {
+		stroke, ok = *b, true
+	}
+	fill = stroke
+	if b := d.BooleanEntry("op"); b != nil {
+		fill, ok = *b, true
+	}
+	if i := d.IntEntry("OPM"); i != nil {
+		mode, ok = *i, true
+	}
+	return stroke, fill, mode, ok
+}
+
+// usedExtGStates returns the names of graphics state parameter dicts set by content bb.
+func usedExtGStates(bb []byte) map[string]bool {
+	m := map[string]bool{}
+	forEachContentOp(bb, func(op contentOp, oo []interface{}) {
+		if op == "gs" && len(oo) > 0 {
+			if n, ok := oo[len(oo)-1].(contentName); ok {
+				m[string(n)] = true
+			}
+		}
+	})
+	return m
+}
+
+// Overprint returns the overprint settings of ctx along with the pages using overprint.
+func Overprint(ctx *model.Context) (*OverprintReport, error) {
+	r := &OverprintReport{Pages: []int{}, ExtGStates: []ExtGStateOverprint{}}
+
+	if o, found := infoEntry(ctx, "Trapped"); found {
+		switch o := o.(type) {
+		case types.Name:
+			r.Trapped = o.Value()
+		case types.Boolean:
+			// Some writers use a boolean.
+			r.Trapped = "False"
+			if o.Value() {
+				r.Trapped = "True"
+			}
+		}
+	}
+
+	type entry struct {
+		ExtGStateOverprint
+		pages map[int]bool
+	}
+
+	m := map[uintptr]*entry{}
+	var order []uintptr
+	pages := map[int]bool{}
+
+	cw := newContentWalker(ctx, func(pageNr int, res types.Dict, bb []byte) ([]byte, bool, error) {
+		gsRes := extGStates(ctx, res)
+		if gsRes == nil {
+			return nil, false, nil
+		}
+		used := usedExtGStates(bb)
+		for name, o := range gsRes {
+			d, err := ctx.DereferenceDict(o)
+			if err != nil || d == nil {
+				continue
+			}
+			stroke, fill, mode, ok := overprint(d)
+			if !ok {
+				continue
+			}
+			k := dictPtr(d)
+			e, found := m[k]
+			if !found {
+				e = &entry{ExtGStateOverprint: ExtGStateOverprint{Name: name, Stroke: stroke, Fill: fill, Mode: mode}, pages: map[int]bool{}}
+				if ir, ok := o.(types.IndirectRef); ok {
+					e.ObjNr = ir.ObjectNumber.Value()
+				}
+				m[k] = e
+				order = append(order, k)
+			}
+			if used[name] {
+				e.pages[pageNr] = true
+				if stroke || fill {
+					pages[pageNr] = true
+				}
+			}
+		}
+		return nil, false, nil
+	}, true)
+
+	if err := cw.walk(nil); err != nil {
+		return nil, err
+	}
+
+	for _, k := range order {
+		e := m[k]
+		e.Pages = []int{}
+		for pageNr := range e.pages {
+			e.Pages = append(e.Pages, pageNr)
+		}
+		sort.Ints(e.Pages)
+		r.ExtGStates = append(r.ExtGStates, e.ExtGStateOverprint)
+	}
+
+	for pageNr := range pages {
+		r.Pages = append(r.Pages, pageNr)
+	}
+	sort.Ints(r.Pages)
+
+	return r, nil
+}
+
+func pageList(pp []int) string {
+	ss := make([]string, len(pp))
+	for i, p := range pp {
+		ss[i] = strconv.Itoa(p)
+	}
+	return strings.Join(ss, ",")
+}
+
+// ListOverprint returns a printable representation of r.
+func ListOverprint(r *OverprintReport) []string {
+	trapped := r.Trapped
+	if trapped == "" {
+		trapped = "-"
+	}
+
+	ss := []string{"Trapped: " + trapped}
+
+	if len(r.Pages) == 0 {
+		ss = append(ss, "No pages using overprint.")
+	} else {
+		ss = append(ss, "Pages using overprint: "+pageList(r.Pages))
+	}
+
+	if len(r.ExtGStates) == 0 {
+		return ss
+	}
+
+	ss = append(ss, "", "ExtGStates:", "     obj# name       OP   op   OPM pages")
+	for _, e := range r.ExtGStates {
+		onOff := func(b bool) string {
+			if b {
+				return "on"
+			}
+			return "off"
+		}
+		ss = append(ss, fmt.Sprintf("%9s %-10s %-4s %-4s %3d %s", objNrString(e.ObjNr), e.Name, onOff(e.Stroke), onOff(e.Fill), e.Mode, pageList(e.Pages)))
+	}
+
+	return ss
+}
+
+func objNrString(objNr int) string {
+	if objNr == 0 {
+		return "-"
+	}
+	return strconv.Itoa(objNr)
+}
+
+// SetOverprint applies oc to the graphics state parameter dicts used by pages pageNrs (all pages if nil)
+// including their form XObjects and patterns and sets the trapping status.
+// It returns the number of graphics state parameter dicts changed.
+func SetOverprint(ctx *model.Context, pageNrs []int, oc *OverprintConfig) (int, error) {
+	if oc == nil {
+		return 0, errors.New("pdfcpu: missing overprint configuration")
+	}
+
+	if oc.Trapped != "" {
+		if err := setInfoEntry(ctx, "Trapped", types.Name(oc.Trapped)); err != nil {
+			return 0, err
+		}
+	}
+
+	if oc.Stroke == nil && oc.Fill == nil && oc.Mode == nil {
+		return 0, nil
+	}
+
+	done := map[uintptr]bool{}
+
+	cw := newContentWalker(ctx, func(pageNr int, res types.Dict, bb []byte) ([]byte, bool, error) {
+		for _, o := range extGStates(ctx, res) {
+			d, err := ctx.DereferenceDict(o)
+			if err != nil || d == nil || done[dictPtr(d)] {
+				continue
+			}
+			done[dictPtr(d)] = true
+			if oc.Stroke != nil {
+				d.Update("OP", types.Boolean(*oc.Stroke))
+			}
+			if oc.Fill != nil {
+				d.Update("op", types.Boolean(*oc.Fill))
+			}
+			if oc.Mode != nil {
+				d.Update("OPM", types.Integer(*oc.Mode))
+			}
+		}
+		return nil, false, nil
+	}, false)
+
+	if err := cw.walk(pageNrs); err != nil {
+		return 0, err
+	}
+
+	return len(done), nil
+}
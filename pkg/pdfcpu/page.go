@@ -133,7 +133,8 @@ func addPages(
 	pagesIndRef types.IndirectRef,
 	pagesDict types.Dict,
 	fieldsSrc, fieldsDest *types.Array,
-	migrated map[int]int) error {
+	migrated map[int]int,
+	copies map[int]int) error {
 
 	// Used by collect, extractPages, split
 
@@ -158,6 +159,11 @@ func addPages(
 			return errors.Errorf("pdfcpu: unknown page number: %d\n", i)
 		}
 
+		if objNr := migrated[pageIndRef.ObjectNumber.Value()]; objNr > 0 {
+			// This page has already been migrated as the target of a link.
+			copies[objNr] = pageIndRef.ObjectNumber.Value()
+		}
+
 		obj, err := migrateIndRef(pageIndRef, ctxSrc, ctxDest, migrated)
 		if err != nil {
 			return err
@@ -193,38 +199,83 @@ func addPages(
 	return nil
 }
 
-func migrateNamedDests(ctxSrc *model.Context, n *model.Node, migrated map[int]int) error {
-	patchValues := func(xRefTable *model.XRefTable, k string, v *types.Object) error {
-		arr, err := xRefTable.DereferenceArray(*v)
-		if err == nil {
-			arr[0] = patchObject(arr[0], migrated)
-			*v = arr
-			return nil
-		}
-		d, err := xRefTable.DereferenceDict(*v)
+// migrateNamedDests returns a new name tree containing the named destinations of n pointing to migrated pages.
+// n is left untouched since it may get migrated repeatedly eg. when splitting.
+func migrateNamedDests(ctxSrc, ctxDest *model.Context, n *model.Node, migrated map[int]int) (*model.Node, error) {
+	n1 := &model.Node{}
+
+	migrateValue := func(xRefTable *model.XRefTable, k string, v *types.Object) error {
+		o, err := xRefTable.Dereference(*v)
 		if err != nil {
 			return err
 		}
-		arr = d.ArrayEntry("D")
-		arr[0] = patchObject(arr[0], migrated)
-		*v = d
-		return nil
+
+		var arr types.Array
+
+		switch o := o.(type) {
+		case types.Array:
+			arr = o
+		case types.Dict:
+			if arr, err = xRefTable.DereferenceArray(o["D"]); err != nil {
+				return err
+			}
+		}
+
+		if len(arr) == 0 {
+			return nil
+		}
+
+		ir, ok := arr[0].(types.IndirectRef)
+		if !ok {
+			return nil
+		}
+
+		objNr, ok := migrated[ir.ObjectNumber.Value()]
+		if !ok {
+			// Skip destinations into pages not migrated.
+			return nil
+		}
+
+		arr1 := arr.Clone().(types.Array)
+		arr1[0] = *types.NewIndirectRef(objNr, 0)
+
+		var v1 types.Object = arr1
+		if _, ok := o.(types.Dict); ok {
+			v1 = types.Dict(map[string]types.Object{"D": arr1})
+		}
+
+		return n1.Add(ctxDest.XRefTable, k, v1, nil, nil)
+	}
+
+	if err := n.Process(ctxSrc.XRefTable, migrateValue); err != nil {
+		return nil, err
 	}
 
-	return n.Process(ctxSrc.XRefTable, patchValues)
+	if len(n1.Names) == 0 && len(n1.Kids) == 0 {
+		return nil, nil
+	}
+
+	return n1, nil
 }
 
 // AddPages adds pages and corresponding resources from ctxSrc to ctxDest.
 func AddPages(ctxSrc, ctxDest *model.Context, pageNrs []int, usePgCache bool) error {
+	_, _, err := addPagesAndResources(ctxSrc, ctxDest, pageNrs, usePgCache)
+	return err
+}
+
+// addPagesAndResources adds pages and corresponding resources from ctxSrc to ctxDest
+// and returns the object numbers of all migrated objects and pages migrated as link targets.
+func addPagesAndResources(ctxSrc, ctxDest *model.Context, pageNrs []int, usePgCache bool) (map[int]int, map[int]int, error) {
 
 	pagesIndRef, err := ctxDest.Pages()
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
 	pagesDict, err := ctxDest.DereferenceDict(*pagesIndRef)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
 	fieldsSrc, fieldsDest := types.Array{}, types.Array{}
@@ -233,31 +284,59 @@ func AddPages(ctxSrc, ctxDest *model.Context, pageNrs []int, usePgCache bool) er
 		o, _ := ctxSrc.Form.Find("Fields")
 		fieldsSrc, err = ctxSrc.DereferenceArray(o)
 		if err != nil {
-			return err
+			return nil, nil, err
 		}
 	}
 
-	migrated := map[int]int{}
+	migrated, copies := map[int]int{}, map[int]int{}
 
-	if err := addPages(ctxSrc, ctxDest, pageNrs, usePgCache, *pagesIndRef, pagesDict, &fieldsSrc, &fieldsDest, migrated); err != nil {
-		return err
+	if err := addPages(ctxSrc, ctxDest, pageNrs, usePgCache, *pagesIndRef, pagesDict, &fieldsSrc, &fieldsDest, migrated, copies); err != nil {
+		return nil, nil, err
 	}
 
 	if ctxSrc.Form != nil && len(fieldsDest) > 0 {
 		d := ctxSrc.Form.Clone().(types.Dict)
 		if err := migrateFormDict(d, fieldsDest, ctxSrc, ctxDest, migrated); err != nil {
-			return err
+			return nil, nil, err
 		}
 		ctxDest.RootDict["AcroForm"] = d
 	}
 
 	if n, ok := ctxSrc.Names["Dests"]; ok {
 		// Carry over used named destinations.
-		if err := migrateNamedDests(ctxSrc, n, migrated); err != nil {
-			return err
+		n1, err := migrateNamedDests(ctxSrc, ctxDest, n, migrated)
+		if err != nil {
+			return nil, nil, err
+		}
+		if n1 != nil {
+			ctxDest.Names = map[string]*model.Node{"Dests": n1}
 		}
-		ctxDest.Names = map[string]*model.Node{"Dests": n}
 	}
 
-	return nil
+	return migrated, copies, nil
+}
+
+// CollectPages returns a new context containing pageNrs of ctx in the given order.
+// Bookmarks, named destinations and links get remapped to the collected pages.
+func CollectPages(ctx *model.Context, pageNrs []int) (*model.Context, error) {
+	ctxDest, err := CreateContextWithXRefTable(nil, types.PaperSize["A4"])
+	if err != nil {
+		return nil, err
+	}
+
+	migrated, copies, err := addPagesAndResources(ctx, ctxDest, pageNrs, false)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := pageRemapForAddedPages(ctx, pageNrs, migrated, copies)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := remapDestinations(ctxDest, m); err != nil {
+		return nil, err
+	}
+
+	return ctxDest, nil
 }
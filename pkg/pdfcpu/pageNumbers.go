@@ -0,0 +1,390 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+type pageNumbersParamMap map[string]func(string, *model.PageNumbers) error
+
+var pnParamMap = pageNumbersParamMap{
+	"numerals": parsePageNumbersNumerals,
+	"skip":     parsePageNumbersSkip,
+	"start":    parsePageNumbersStart,
+	"style":    parsePageNumbersStyle,
+}
+
+func parsePageNumbersStyle(s string, pn *model.PageNumbers) error {
+	switch strings.ToLower(s) {
+	case "plain":
+		pn.Style = model.PageNumberPlain
+	case "ofn":
+		pn.Style = model.PageNumberOfN
+	case "page":
+		pn.Style = model.PageNumberPage
+	case "pageofn":
+		pn.Style = model.PageNumberPageOfN
+	case "folio":
+		pn.Style = model.PageNumberFolio
+	case "label":
+		pn.Style = model.PageNumberLabel
+	default:
+		return errors.Errorf("pdfcpu: unknown page number style (plain, ofn, page, pageofn, folio, label): %s", s)
+	}
+	return nil
+}
+
+func parsePageNumbersNumerals(s string, pn *model.PageNumbers) error {
+	// Case matters.
+	switch s {
+	case "arabic":
+		pn.Numeral = model.NumeralArabic
+	case "roman":
+		pn.Numeral = model.NumeralRomanLower
+	case "ROMAN":
+		pn.Numeral = model.NumeralRomanUpper
+	case "letters":
+		pn.Numeral = model.NumeralLettersLower
+	case "LETTERS":
+		pn.Numeral = model.NumeralLettersUpper
+	default:
+		return errors.Errorf("pdfcpu: unknown numerals (arabic, roman, ROMAN, letters, LETTERS): %s", s)
+	}
+	return nil
+}
+
+func parsePageNumbersStart(s string, pn *model.PageNumbers) error {
+	i, err := strconv.Atoi(s)
+	if err != nil || i < 1 {
+		return errors.Errorf("pdfcpu: start must be a positive integer: %s", s)
+	}
+	pn.Start = i
+	return nil
+}
+
+func parsePageNumbersSkip(s string, pn *model.PageNumbers) error {
+	pn.Skip = strings.Fields(s)
+	if len(pn.Skip) == 0 {
+		return errors.New("pdfcpu: skip: missing page selection")
+	}
+	return nil
+}
+
+// resolvePageNumbersParam applies parameter completion across page number and text stamp parameters.
+func resolvePageNumbersParam(paramPrefix string) (string, bool, error) {
+	prefix := strings.ToLower(paramPrefix)
+
+	var params []string
+	for k := range pnParamMap {
+		if k == prefix {
+			return k, true, nil
+		}
+		if strings.HasPrefix(k, prefix) {
+			params = append(params, k)
+		}
+	}
+	var wmParams []string
+	for k := range wmParamMap {
+		if k == prefix {
+			return k, false, nil
+		}
+		if strings.HasPrefix(k, prefix) {
+			wmParams = append(wmParams, k)
+		}
+	}
+
+	switch {
+	case len(params)+len(wmParams) == 0:
+		return "", false, errors.Errorf("pdfcpu: unknown parameter prefix \"%s\"", paramPrefix)
+	case len(params)+len(wmParams) > 1:
+		return "", false, errors.Errorf("pdfcpu: ambiguous parameter prefix \"%s\"", paramPrefix)
+	case len(params) == 1:
+		return params[0], true, nil
+	}
+
+	return wmParams[0], false, nil
+}
+
+// ParsePageNumbersDetails parses a page numbers command string into an internal structure.
+// Besides style, numerals, start and skip all text stamp parameters are supported.
+func ParsePageNumbersDetails(desc string, u types.DisplayUnit) (*model.PageNumbers, error) {
+	pn := model.DefaultPageNumbersConfig()
+	pn.Wm.InpUnit = u
+
+	if len(strings.TrimSpace(desc)) == 0 {
+		return pn, nil
+	}
+
+	for _, s := range strings.Split(desc, ",") {
+		ss := strings.Split(s, ":")
+		if len(ss) != 2 {
+			return nil, errors.New("pdfcpu: Invalid page numbers configuration string. Please consult pdfcpu help pagenumbers.")
+		}
+
+		paramPrefix := strings.TrimSpace(ss[0])
+		paramValueStr := strings.TrimSpace(ss[1])
+
+		param, isPN, err := resolvePageNumbersParam(paramPrefix)
+		if err != nil {
+			return nil, err
+		}
+
+		if isPN {
+			err = pnParamMap[param](paramValueStr, pn)
+		} else {
+			err = wmParamMap[param](paramValueStr, pn.Wm)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return pn, nil
+}
+
+func pageLabelNumeral(s string) (model.NumeralStyle, bool) {
+	switch s {
+	case "D":
+		return model.NumeralArabic, true
+	case "R":
+		return model.NumeralRomanUpper, true
+	case "r":
+		return model.NumeralRomanLower, true
+	case "A":
+		return model.NumeralLettersUpper, true
+	case "a":
+		return model.NumeralLettersLower, true
+	}
+	return model.NumeralArabic, false
+}
+
+type pageLabelRange struct {
+	start int // 0 based page index
+	d     types.Dict
+}
+
+func collectPageLabelRanges(xRefTable *model.XRefTable, o types.Object, rr []pageLabelRange) ([]pageLabelRange, error) {
+	d, err := xRefTable.DereferenceDict(o)
+	if err != nil || d == nil {
+		return rr, err
+	}
+
+	kids, err := xRefTable.DereferenceArray(d["Kids"])
+	if err != nil {
+		return nil, err
+	}
+	for _, o := range kids {
+		if rr, err = collectPageLabelRanges(xRefTable, o, rr); err != nil {
+			return nil, err
+		}
+	}
+
+	nums, err := xRefTable.DereferenceArray(d["Nums"])
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i+1 < len(nums); i += 2 {
+		o, err := xRefTable.Dereference(nums[i])
+		if err != nil {
+			return nil, err
+		}
+		start, ok := o.(types.Integer)
+		if !ok {
+			continue
+		}
+		d, err := xRefTable.DereferenceDict(nums[i+1])
+		if err != nil {
+			return nil, err
+		}
+		if d != nil {
+			rr = append(rr, pageLabelRange{start: start.Value(), d: d})
+		}
+	}
+
+	return rr, nil
+}
+
+// PageLabels returns the page labels of all pages of ctx (see 12.4.2 Page Labels) or nil if there are none.
+func PageLabels(ctx *model.Context) ([]string, error) {
+	xRefTable := ctx.XRefTable
+
+	rootDict, err := xRefTable.Catalog()
+	if err != nil {
+		return nil, err
+	}
+
+	o, found := rootDict.Find("PageLabels")
+	if !found {
+		return nil, nil
+	}
+
+	rr, err := collectPageLabelRanges(xRefTable, o, nil)
+	if err != nil || len(rr) == 0 {
+		return nil, err
+	}
+
+	sort.Slice(rr, func(i, j int) bool { return rr[i].start < rr[j].start })
+
+	labels := make([]string, xRefTable.PageCount)
+
+	for i, r := range rr {
+		end := xRefTable.PageCount
+		if i+1 < len(rr) && rr[i+1].start < end {
+			end = rr[i+1].start
+		}
+
+		var prefix string
+		if o, found := r.d.Find("P"); found {
+			if prefix, err = xRefTable.DereferenceStringOrHexLiteral(o, model.V10, nil); err != nil {
+				return nil, err
+			}
+		}
+
+		st := 1
+		if i := r.d.IntEntry("St"); i != nil && *i > 0 {
+			st = *i
+		}
+
+		s := r.d.NameEntry("S")
+
+		for j := r.start; j < end; j++ {
+			if j < 0 {
+				continue
+			}
+			labels[j] = prefix
+			if s == nil {
+				continue
+			}
+			if ns, ok := pageLabelNumeral(*s); ok {
+				labels[j] += model.FormatNumeral(st+j-r.start, ns)
+			}
+		}
+	}
+
+	return labels, nil
+}
+
+// folioAnchor moves anchor a to the outer margin of page nr keeping its vertical position.
+func folioAnchor(a types.Anchor, nr int) types.Anchor {
+	odd := nr%2 == 1
+	switch a {
+	case types.TopLeft, types.TopCenter, types.TopRight:
+		if odd {
+			return types.TopRight
+		}
+		return types.TopLeft
+	case types.Left, types.Center, types.Right:
+		if odd {
+			return types.Right
+		}
+		return types.Left
+	}
+	if odd {
+		return types.BottomRight
+	}
+	return types.BottomLeft
+}
+
+// presetOffset returns an offset keeping page numbers anchored at a away from the page edges.
+func presetOffset(a types.Anchor) (float64, float64) {
+	var dx, dy float64
+	switch a {
+	case types.TopLeft, types.Left, types.BottomLeft:
+		dx = 36
+	case types.TopRight, types.Right, types.BottomRight:
+		dx = -36
+	}
+	switch a {
+	case types.TopLeft, types.TopCenter, types.TopRight:
+		dy = -24
+	case types.BottomLeft, types.BottomCenter, types.BottomRight:
+		dy = 24
+	}
+	return dx, dy
+}
+
+// AddPageNumbers stamps page numbers configured by pn onto selectedPages of ctx.
+// Pages in skipPages are neither numbered nor counted.
+func AddPageNumbers(ctx *model.Context, selectedPages, skipPages types.IntSet, pn *model.PageNumbers) error {
+	if pn == nil || pn.Wm == nil {
+		return errors.New("pdfcpu: missing page numbers configuration")
+	}
+
+	var pages []int
+	for i := 1; i <= ctx.PageCount; i++ {
+		if selectedPages != nil && !selectedPages[i] {
+			continue
+		}
+		if skipPages[i] {
+			continue
+		}
+		pages = append(pages, i)
+	}
+
+	if len(pages) == 0 {
+		return errors.New("pdfcpu: no pages left to number")
+	}
+
+	var labels []string
+	if pn.Style == model.PageNumberLabel {
+		var err error
+		if labels, err = PageLabels(ctx); err != nil {
+			return err
+		}
+	}
+
+	start := pn.Start
+	if start < 1 {
+		start = 1
+	}
+	n := start + len(pages) - 1
+
+	m := map[int]*model.Watermark{}
+
+	for i, pageNr := range pages {
+		wm := *pn.Wm
+		wm.Recycle()
+		wm.TextLines = nil
+
+		if pn.Style == model.PageNumberFolio {
+			wm.Pos = folioAnchor(wm.Pos, start+i)
+		}
+		if wm.Dx == 0 && wm.Dy == 0 {
+			wm.Dx, wm.Dy = presetOffset(wm.Pos)
+		}
+
+		var label string
+		if labels != nil {
+			label = labels[pageNr-1]
+		}
+
+		if err := setWatermarkType(model.WMText, pn.Text(start+i, n, label), &wm); err != nil {
+			return err
+		}
+
+		m[pageNr] = &wm
+	}
+
+	return AddWatermarksMap(ctx, m)
+}
@@ -0,0 +1,312 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// pageTreeFanout is the maximum number of kids of a node of a rebuilt page tree.
+const pageTreeFanout = 32
+
+var errCorruptPageTree = errors.New("pdfcpu: corrupt page tree")
+
+// inheritablePageAttrs are the page attributes a page may inherit from its ancestors.
+var inheritablePageAttrs = []string{"Resources", "MediaBox", "CropBox", "Rotate"}
+
+// pageTreeCollector gathers the pages of a page tree in order.
+type pageTreeCollector struct {
+	ctx      *model.Context
+	pages    []types.IndirectRef
+	inh      []types.Dict // Attributes inherited by pages from intermediate nodes
+	nodes    []int        // Intermediate nodes
+	nodeDs   []types.Dict // Intermediate node dicts
+	maxKids  int
+	maxDepth int
+	visited  map[int]bool
+}
+
+// collect walks the page tree node d at depth collecting the pages
+// along with the attributes inh they inherit from intermediate nodes.
+func (c *pageTreeCollector) collect(d, inh types.Dict, depth int) error {
+	kids, err := c.ctx.DereferenceArray(d["Kids"])
+	if err != nil {
+		return err
+	}
+
+	if len(kids) > c.maxKids {
+		c.maxKids = len(kids)
+	}
+
+	if depth > c.maxDepth {
+		c.maxDepth = depth
+	}
+
+	for _, o := range kids {
+		ir, ok := o.(types.IndirectRef)
+		if !ok {
+			return errCorruptPageTree
+		}
+
+		objNr := ir.ObjectNumber.Value()
+		if c.visited[objNr] {
+			return errCorruptPageTree
+		}
+		c.visited[objNr] = true
+
+		kid, err := c.ctx.DereferenceDict(ir)
+		if err != nil {
+			return err
+		}
+		if kid == nil {
+			continue
+		}
+
+		if _, found := kid.Find("Kids"); found {
+			inh1 := inh.Clone().(types.Dict)
+			for _, k := range inheritablePageAttrs {
+				if v, found := kid.Find(k); found {
+					inh1[k] = v
+				}
+			}
+			c.nodes = append(c.nodes, objNr)
+			c.nodeDs = append(c.nodeDs, kid)
+			if err := c.collect(kid, inh1, depth+1); err != nil {
+				return err
+			}
+			continue
+		}
+
+		c.pages = append(c.pages, ir)
+		c.inh = append(c.inh, inh)
+	}
+
+	return nil
+}
+
+// pushDownInheritedAttrs adds the collected inherited attributes to the pages unless overridden
+// and returns the number of pages modified.
+func (c *pageTreeCollector) pushDownInheritedAttrs() (int, error) {
+	n := 0
+	for i, ir := range c.pages {
+		d, err := c.ctx.DereferenceDict(ir)
+		if err != nil {
+			return 0, err
+		}
+		modified := false
+		for k, v := range c.inh[i] {
+			if _, found := d.Find(k); !found {
+				d[k] = v.Clone()
+				modified = true
+			}
+		}
+		if modified {
+			n++
+		}
+	}
+	return n, nil
+}
+
+// balancedPageTreeDepth returns the depth of a balanced page tree for pageCount pages.
+func balancedPageTreeDepth(pageCount int) int {
+	depth := 1
+	for n := pageTreeFanout; n < pageCount; n *= pageTreeFanout {
+		depth++
+	}
+	return depth
+}
+
+// degenerate returns true if the collected page tree has nodes with excessive kids or is too deep.
+func (c *pageTreeCollector) degenerate() bool {
+	return c.maxKids > 2*pageTreeFanout || c.maxDepth > balancedPageTreeDepth(len(c.pages))+1
+}
+
+// buildPageTree links kids below the page tree root dict d using intermediate nodes of at most pageTreeFanout kids
+// and returns the number of intermediate nodes created.
+func buildPageTree(ctx *model.Context, ir types.IndirectRef, d types.Dict, kids []types.IndirectRef) (int, error) {
+	counts := make([]int, len(kids))
+	for i := range counts {
+		counts[i] = 1
+	}
+
+	nodes := 0
+
+	for len(kids) > pageTreeFanout {
+		var (
+			kids1   []types.IndirectRef
+			counts1 []int
+		)
+
+		for i := 0; i < len(kids); i += pageTreeFanout {
+			j := min(i+pageTreeFanout, len(kids))
+
+			a := types.Array{}
+			count := 0
+			for k := i; k < j; k++ {
+				a = append(a, kids[k])
+				count += counts[k]
+			}
+
+			node := types.Dict{"Type": types.Name("Pages"), "Kids": a, "Count": types.Integer(count)}
+			nodeIndRef, err := ctx.IndRefForNewObject(node)
+			if err != nil {
+				return 0, err
+			}
+
+			if err := setPageTreeParent(ctx, kids[i:j], *nodeIndRef); err != nil {
+				return 0, err
+			}
+
+			kids1 = append(kids1, *nodeIndRef)
+			counts1 = append(counts1, count)
+			nodes++
+		}
+
+		kids, counts = kids1, counts1
+	}
+
+	a := types.Array{}
+	count := 0
+	for i, kid := range kids {
+		a = append(a, kid)
+		count += counts[i]
+	}
+
+	d["Kids"] = a
+	d["Count"] = types.Integer(count)
+
+	return nodes, setPageTreeParent(ctx, kids, ir)
+}
+
+func setPageTreeParent(ctx *model.Context, kids []types.IndirectRef, parent types.IndirectRef) error {
+	for _, ir := range kids {
+		d, err := ctx.DereferenceDict(ir)
+		if err != nil {
+			return err
+		}
+		if d == nil {
+			return errCorruptPageTree
+		}
+		d["Parent"] = parent
+	}
+	return nil
+}
+
+// pageTreeRoot returns the page tree root dict of ctx along with its indirect reference.
+func pageTreeRoot(ctx *model.Context) (*types.IndirectRef, types.Dict, error) {
+	ir, err := ctx.Pages()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	d, err := ctx.DereferenceDict(*ir)
+	if err != nil {
+		return nil, nil, err
+	}
+	if d == nil {
+		return nil, nil, errCorruptPageTree
+	}
+
+	return ir, d, nil
+}
+
+// rebalancePageTree rebuilds degenerate page trees with thousands of kids below a single node or deep chains of nodes
+// as balanced page trees. Attributes inherited from replaced intermediate nodes get pushed down into the pages.
+func rebalancePageTree(ctx *model.Context) error {
+	if log.OptimizeEnabled() {
+		log.Optimize.Println("rebalancePageTree begin")
+	}
+
+	ir, d, err := pageTreeRoot(ctx)
+	if err != nil {
+		return err
+	}
+
+	c := &pageTreeCollector{ctx: ctx, visited: map[int]bool{}}
+	if err := c.collect(d, types.Dict{}, 1); err != nil {
+		return err
+	}
+
+	if !c.degenerate() {
+		if log.OptimizeEnabled() {
+			log.Optimize.Println("rebalancePageTree end: page tree is balanced")
+		}
+		return nil
+	}
+
+	if _, err := c.pushDownInheritedAttrs(); err != nil {
+		return err
+	}
+
+	for _, objNr := range c.nodes {
+		if err := ctx.FreeObject(objNr); err != nil {
+			return err
+		}
+	}
+
+	nodes, err := buildPageTree(ctx, *ir, d, c.pages)
+	if err != nil {
+		return err
+	}
+
+	st := passStats(ctx, model.OptimizePassPageTree)
+	st.Objects += len(c.nodes) + nodes
+
+	if log.OptimizeEnabled() {
+		log.Optimize.Printf("rebalancePageTree end: replaced %d intermediate nodes by %d\n", len(c.nodes), nodes)
+	}
+
+	return nil
+}
+
+// FlattenInheritedPageAttrs pushes the inherited page attributes Resources, MediaBox, CropBox and Rotate
+// down into each page dict and removes them from the nodes of the page tree.
+// Returns the number of pages modified.
+func FlattenInheritedPageAttrs(ctx *model.Context) (int, error) {
+	_, d, err := pageTreeRoot(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	inh := types.Dict{}
+	for _, k := range inheritablePageAttrs {
+		if v, found := d.Find(k); found {
+			inh[k] = v
+		}
+	}
+
+	c := &pageTreeCollector{ctx: ctx, visited: map[int]bool{}}
+	if err := c.collect(d, inh, 1); err != nil {
+		return 0, err
+	}
+
+	n, err := c.pushDownInheritedAttrs()
+	if err != nil {
+		return 0, err
+	}
+
+	for _, d := range append(c.nodeDs, d) {
+		for _, k := range inheritablePageAttrs {
+			d.Delete(k)
+		}
+	}
+
+	return n, nil
+}
@@ -0,0 +1,597 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	pdffont "github.com/pdfcpu/pdfcpu/pkg/pdfcpu/font"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// Supported PDF/X standards.
+const (
+	PDFX1a = "pdfx-1a" // ISO 15930-4:2003
+	PDFX4  = "pdfx-4"  // ISO 15930-7:2010
+)
+
+// Default characterized printing condition used by ConvertPDFX (see http://www.color.org/registry).
+const (
+	DefaultOutputCondition     = "FOGRA39"
+	defaultOutputConditionInfo = "Coated FOGRA39 (ISO 12647-2:2004)"
+)
+
+var pdfxVersions = map[string]string{
+	PDFX1a: "PDF/X-1a:2003",
+	PDFX4:  "PDF/X-4",
+}
+
+// PreflightIssue is a violation of a PDF/X standard.
+type PreflightIssue struct {
+	Page    int    `json:"page,omitempty"` // 0 for document level issues.
+	Message string `json:"message"`
+}
+
+func (pi PreflightIssue) String() string {
+	if pi.Page == 0 {
+		return pi.Message
+	}
+	return fmt.Sprintf("page %d: %s", pi.Page, pi.Message)
+}
+
+// ParsePDFXStandard returns the PDF/X standard for s.
+func ParsePDFXStandard(s string) (string, error) {
+	switch strings.ToLower(s) {
+	case "pdfx-1a", "x-1a", "x1a":
+		return PDFX1a, nil
+	case "pdfx-4", "x-4", "x4":
+		return PDFX4, nil
+	}
+	return "", errors.Errorf("pdfcpu: unsupported PDF/X standard: %s", s)
+}
+
+// preflight collects the PDF/X violations of a document.
+type preflight struct {
+	ctx      *model.Context
+	standard string
+	issues   []PreflightIssue
+	seen     map[string]bool
+	visited  map[int]bool // objNrs of processed resource dicts and streams
+	pageNr   int
+}
+
+func (pf *preflight) report(format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	k := fmt.Sprintf("%d %s", pf.pageNr, msg)
+	if pf.seen[k] {
+		return
+	}
+	pf.seen[k] = true
+	pf.issues = append(pf.issues, PreflightIssue{Page: pf.pageNr, Message: msg})
+}
+
+// firstVisit returns true if o is no indirect reference or has not been processed yet.
+func (pf *preflight) firstVisit(o types.Object) bool {
+	ir, ok := o.(types.IndirectRef)
+	if !ok {
+		return true
+	}
+	objNr := ir.ObjectNumber.Value()
+	if pf.visited[objNr] {
+		return false
+	}
+	pf.visited[objNr] = true
+	return true
+}
+
+func infoEntry(ctx *model.Context, key string) (types.Object, bool) {
+	if ctx.Info == nil {
+		return nil, false
+	}
+	d, err := ctx.DereferenceDict(*ctx.Info)
+	if err != nil || d == nil {
+		return nil, false
+	}
+	o, found := d.Find(key)
+	if !found {
+		return nil, false
+	}
+	o, err = ctx.Dereference(o)
+	return o, err == nil && o != nil
+}
+
+func (pf *preflight) document() error {
+	ctx := pf.ctx
+
+	if ctx.Encrypt != nil {
+		pf.report("encryption is not allowed")
+	}
+
+	oi, err := outputIntent(ctx, OutputIntentPDFX)
+	if err != nil {
+		return err
+	}
+
+	if oi == nil {
+		pf.report("missing GTS_PDFX output intent")
+	} else {
+		if _, found := oi.Find("OutputConditionIdentifier"); !found {
+			pf.report("output intent: missing OutputConditionIdentifier")
+		}
+		if _, found := oi.Find("DestOutputProfile"); !found && pf.standard == PDFX4 {
+			pf.report("output intent: missing DestOutputProfile")
+		}
+	}
+
+	if o, found := infoEntry(ctx, "Trapped"); !found {
+		pf.report("missing Trapped")
+	} else if n, ok := o.(types.Name); !ok || (n.Value() != "True" && n.Value() != "False") {
+		pf.report("Trapped must be True or False")
+	}
+
+	want := pdfxVersions[pf.standard]
+	version := ""
+	if o, found := infoEntry(ctx, "GTS_PDFXVersion"); found {
+		version, _ = ctx.DereferenceText(o)
+	}
+
+	if pf.standard == PDFX4 && !strings.HasPrefix(version, want) {
+		// PDF/X-4 identifies itself via XMP metadata.
+		if bb, err := catalogMetadata(ctx); err == nil && strings.Contains(string(bb), want) {
+			version = want
+		}
+	}
+
+	if !strings.HasPrefix(version, want) {
+		pf.report("missing GTS_PDFXVersion %s", want)
+	}
+
+	return nil
+}
+
+func catalogMetadata(ctx *model.Context) ([]byte, error) {
+	rootDict, err := ctx.Catalog()
+	if err != nil {
+		return nil, err
+	}
+	sd, _, err := ctx.DereferenceStreamDict(rootDict["Metadata"])
+	if err != nil || sd == nil {
+		return nil, err
+	}
+	if err := sd.Decode(); err != nil {
+		return nil, err
+	}
+	return sd.Content, nil
+}
+
+func (pf *preflight) boxes(d types.Dict, inhPAttrs *model.InheritedPageAttrs) error {
+	box := func(key string) (*types.Rectangle, error) {
+		arr, err := pf.ctx.DereferenceArray(d[key])
+		if err != nil || arr == nil {
+			return nil, err
+		}
+		return pf.ctx.RectForArray(arr)
+	}
+
+	tb, err := box("TrimBox")
+	if err != nil {
+		return err
+	}
+
+	ab, err := box("ArtBox")
+	if err != nil {
+		return err
+	}
+
+	if tb == nil && ab == nil {
+		pf.report("missing TrimBox")
+		return nil
+	}
+
+	if tb == nil {
+		tb = ab
+	}
+
+	within := func(r, outer *types.Rectangle) bool {
+		return r.LL.X >= outer.LL.X && r.LL.Y >= outer.LL.Y && r.UR.X <= outer.UR.X && r.UR.Y <= outer.UR.Y
+	}
+
+	bb, err := box("BleedBox")
+	if err != nil {
+		return err
+	}
+
+	if bb != nil && !within(tb, bb) {
+		pf.report("TrimBox exceeds BleedBox")
+	}
+
+	if mb := inhPAttrs.MediaBox; mb != nil && !within(tb, mb) {
+		pf.report("TrimBox exceeds MediaBox")
+	}
+
+	return nil
+}
+
+// colorSpace reports color spaces not allowed by PDF/X-1a.
+func (pf *preflight) colorSpace(o types.Object, depth int) {
+	if pf.standard != PDFX1a || depth > 4 {
+		return
+	}
+
+	o, err := pf.ctx.Dereference(o)
+	if err != nil || o == nil {
+		return
+	}
+
+	switch o := o.(type) {
+
+	case types.Name:
+		if o == "DeviceRGB" || o == "RGB" {
+			pf.report("RGB color space used")
+		}
+
+	case types.Array:
+		if len(o) == 0 {
+			return
+		}
+		n, ok := o[0].(types.Name)
+		if !ok {
+			return
+		}
+		switch n {
+		case "CalRGB", "Lab":
+			pf.report("device independent color space %s used", n)
+		case "ICCBased":
+			pf.report("ICCBased color space used")
+		case "Indexed", "I":
+			if len(o) > 1 {
+				pf.colorSpace(o[1], depth+1)
+			}
+		case "Separation":
+			if len(o) > 2 {
+				pf.colorSpace(o[2], depth+1)
+			}
+		case "DeviceN":
+			if len(o) > 2 {
+				pf.colorSpace(o[2], depth+1)
+			}
+		case "Pattern":
+			if len(o) > 1 {
+				pf.colorSpace(o[1], depth+1)
+			}
+		}
+	}
+}
+
+func (pf *preflight) content(bb []byte) {
+	if pf.standard != PDFX1a {
+		return
+	}
+
+	forEachContentOp(bb, func(op contentOp, oo []interface{}) {
+		switch op {
+		case "rg", "RG":
+			pf.report("RGB color used")
+		case "cs", "CS":
+			if len(oo) > 0 {
+				if n, ok := oo[len(oo)-1].(contentName); ok && n == "DeviceRGB" {
+					pf.report("RGB color used")
+				}
+			}
+		case "ID":
+			for i := 0; i+1 < len(oo); i++ {
+				if k, ok := oo[i].(contentName); ok && (k == "CS" || k == "ColorSpace") {
+					if v, ok := oo[i+1].(contentName); ok && (v == "RGB" || v == "DeviceRGB") {
+						pf.report("RGB inline image used")
+					}
+				}
+			}
+		}
+	})
+}
+
+func (pf *preflight) font(o types.Object) error {
+	if !pf.firstVisit(o) {
+		return nil
+	}
+
+	d, err := pf.ctx.DereferenceDict(o)
+	if err != nil || d == nil {
+		return err
+	}
+
+	st := d.Subtype()
+	if st != nil && *st == "Type3" {
+		return nil
+	}
+
+	objNr := 0
+	if ir, ok := o.(types.IndirectRef); ok {
+		objNr = ir.ObjectNumber.Value()
+	}
+
+	embedded, err := pdffont.Embedded(pf.ctx.XRefTable, d, objNr)
+	if err != nil {
+		return err
+	}
+
+	if !embedded {
+		name := "unknown"
+		if bf := d.NameEntry("BaseFont"); bf != nil {
+			name = *bf
+		}
+		pf.report("font %s not embedded", name)
+	}
+
+	return nil
+}
+
+func (pf *preflight) xObject(o types.Object, depth int) error {
+	if !pf.firstVisit(o) {
+		return nil
+	}
+
+	sd, _, err := pf.ctx.DereferenceStreamDict(o)
+	if err != nil || sd == nil {
+		return err
+	}
+
+	st := sd.Subtype()
+	if st == nil {
+		return nil
+	}
+
+	switch *st {
+
+	case "Image":
+		if mask := sd.BooleanEntry("ImageMask"); mask == nil || !*mask {
+			pf.colorSpace(sd.Dict["ColorSpace"], 0)
+		}
+
+	case "Form":
+		if err := sd.Decode(); err != nil {
+			return err
+		}
+		pf.content(sd.Content)
+		return pf.resources(sd.Dict["Resources"], depth+1)
+	}
+
+	return nil
+}
+
+func (pf *preflight) shading(o types.Object) {
+	o, err := pf.ctx.Dereference(o)
+	if err != nil || o == nil {
+		return
+	}
+	var d types.Dict
+	switch o := o.(type) {
+	case types.Dict:
+		d = o
+	case types.StreamDict:
+		d = o.Dict
+	}
+	if d != nil {
+		pf.colorSpace(d["ColorSpace"], 0)
+	}
+}
+
+func (pf *preflight) pattern(o types.Object, depth int) error {
+	if !pf.firstVisit(o) {
+		return nil
+	}
+
+	o1, err := pf.ctx.Dereference(o)
+	if err != nil || o1 == nil {
+		return err
+	}
+
+	switch o1 := o1.(type) {
+	case types.Dict:
+		// Shading pattern.
+		pf.shading(o1["Shading"])
+	case types.StreamDict:
+		// Tiling pattern.
+		if err := o1.Decode(); err != nil {
+			return err
+		}
+		pf.content(o1.Content)
+		return pf.resources(o1.Dict["Resources"], depth+1)
+	}
+
+	return nil
+}
+
+func (pf *preflight) resources(o types.Object, depth int) error {
+	if depth > maxFormDepth || !pf.firstVisit(o) {
+		return nil
+	}
+
+	d, err := pf.ctx.DereferenceDict(o)
+	if err != nil || d == nil {
+		return err
+	}
+
+	sub := func(key string) types.Dict {
+		d1, err := pf.ctx.DereferenceDict(d[key])
+		if err != nil {
+			return nil
+		}
+		return d1
+	}
+
+	for _, o := range sub("ColorSpace") {
+		pf.colorSpace(o, 0)
+	}
+
+	for _, o := range sub("Shading") {
+		pf.shading(o)
+	}
+
+	for _, o := range sub("Font") {
+		if err := pf.font(o); err != nil {
+			return err
+		}
+	}
+
+	for _, o := range sub("Pattern") {
+		if err := pf.pattern(o, depth); err != nil {
+			return err
+		}
+	}
+
+	for _, o := range sub("XObject") {
+		if err := pf.xObject(o, depth); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (pf *preflight) page(pageNr int) error {
+	pf.pageNr = pageNr
+	defer func() { pf.pageNr = 0 }()
+
+	d, _, inhPAttrs, err := pf.ctx.PageDict(pageNr, false)
+	if err != nil || d == nil {
+		return err
+	}
+
+	if err := pf.boxes(d, inhPAttrs); err != nil {
+		return err
+	}
+
+	bb, err := pf.ctx.PageContent(d)
+	if err != nil && err != model.ErrNoContent {
+		return err
+	}
+	pf.content(bb)
+
+	// Resources shared by several pages are reported for the first page using them.
+	return pf.resources(inhPAttrs.Resources, 0)
+}
+
+// Preflight checks ctx for conformance with the PDF/X standard pdfx-1a or pdfx-4 and returns all violations found.
+// Covered are the output intent, document identification, trapping, page boxes, font embedding
+// and for PDF/X-1a the use of RGB and device independent color.
+func Preflight(ctx *model.Context, standard string) ([]PreflightIssue, error) {
+	if _, ok := pdfxVersions[standard]; !ok {
+		return nil, errors.Errorf("pdfcpu: unsupported PDF/X standard: %s", standard)
+	}
+
+	pf := &preflight{
+		ctx:      ctx,
+		standard: standard,
+		seen:     map[string]bool{},
+		visited:  map[int]bool{},
+	}
+
+	if err := pf.document(); err != nil {
+		return nil, err
+	}
+
+	for i := 1; i <= ctx.PageCount; i++ {
+		if err := pf.page(i); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.SliceStable(pf.issues, func(i, j int) bool { return pf.issues[i].Page < pf.issues[j].Page })
+
+	return pf.issues, nil
+}
+
+func setInfoEntry(ctx *model.Context, key string, o types.Object) error {
+	if err := ensureInfoDictAndFileID(ctx); err != nil {
+		return err
+	}
+	if ctx.Info == nil {
+		return errors.New("pdfcpu: missing info dict")
+	}
+	d, err := ctx.DereferenceDict(*ctx.Info)
+	if err != nil || d == nil {
+		return errors.New("pdfcpu: corrupt info dict")
+	}
+	d.Update(key, o)
+	return nil
+}
+
+// ConvertPDFX applies the fixes possible without touching page content in order to conform to standard:
+// a GTS_PDFX output intent referring to the registered printing condition outputCondition,
+// the PDF/X version, the Trapped key and missing trim boxes derived from the crop box.
+// It returns the violations remaining.
+func ConvertPDFX(ctx *model.Context, standard, outputCondition string) ([]PreflightIssue, error) {
+	version, ok := pdfxVersions[standard]
+	if !ok {
+		return nil, errors.Errorf("pdfcpu: unsupported PDF/X standard: %s", standard)
+	}
+
+	oi, err := outputIntent(ctx, OutputIntentPDFX)
+	if err != nil {
+		return nil, err
+	}
+
+	if oi == nil {
+		info := outputCondition
+		if outputCondition == "" || outputCondition == DefaultOutputCondition {
+			outputCondition, info = DefaultOutputCondition, defaultOutputConditionInfo
+		}
+		d, err := newOutputIntentDict(OutputIntentPDFX, outputCondition, info)
+		if err != nil {
+			return nil, err
+		}
+		if err := appendOutputIntent(ctx, d); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := setInfoEntry(ctx, "GTS_PDFXVersion", types.StringLiteral(version)); err != nil {
+		return nil, err
+	}
+
+	if o, found := infoEntry(ctx, "Trapped"); !found || (o != types.Name("True") && o != types.Name("False")) {
+		if err := setInfoEntry(ctx, "Trapped", types.Name("False")); err != nil {
+			return nil, err
+		}
+	}
+
+	for i := 1; i <= ctx.PageCount; i++ {
+		d, _, inhPAttrs, err := ctx.PageDict(i, false)
+		if err != nil {
+			return nil, err
+		}
+		if d == nil {
+			continue
+		}
+		if _, found := d.Find("TrimBox"); found {
+			continue
+		}
+		if _, found := d.Find("ArtBox"); found {
+			continue
+		}
+		if vp := viewPort(inhPAttrs); vp != nil {
+			d.Update("TrimBox", vp.Array())
+		}
+	}
+
+	return Preflight(ctx, standard)
+}
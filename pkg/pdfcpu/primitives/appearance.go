@@ -0,0 +1,129 @@
+/*
+	Copyright 2026 The pdfcpu Authors.
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+package primitives
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/color"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// tabOrders maps the supported tab orders of a page to their PDF names.
+// See table 30.
+var tabOrders = map[string]string{
+	"row":       "R",
+	"column":    "C",
+	"structure": "S",
+}
+
+// fieldBorderStyles maps the supported border styles of form fields.
+// See table 168.
+var fieldBorderStyles = map[string]model.BorderStyle{
+	"solid":     model.BSSolid,
+	"dashed":    model.BSDashed,
+	"beveled":   model.BSBeveled,
+	"inset":     model.BSInset,
+	"underline": model.BSUnderline,
+}
+
+func parseTabOrder(s string) (string, error) {
+	if s == "" {
+		return "", nil
+	}
+	tabs, ok := tabOrders[strings.ToLower(s)]
+	if !ok {
+		return "", errors.Errorf("pdfcpu: invalid tab order: %s (should be \"row\", \"column\" or \"structure\")", s)
+	}
+	return tabs, nil
+}
+
+func parseFieldBorderStyle(s string) (model.BorderStyle, error) {
+	if s == "" {
+		return model.BSSolid, nil
+	}
+	bs, ok := fieldBorderStyles[strings.ToLower(s)]
+	if !ok {
+		return 0, errors.Errorf("pdfcpu: invalid field border style: %s (should be \"solid\", \"dashed\", \"beveled\", \"inset\" or \"underline\")", s)
+	}
+	return bs, nil
+}
+
+// fieldBorderStyleDict returns the border style dict of a form field.
+func fieldBorderStyleDict(boWidth float64, bs model.BorderStyle) types.Dict {
+	s := "S"
+	switch bs {
+	case model.BSDashed:
+		s = "D"
+	case model.BSBeveled:
+		s = "B"
+	case model.BSInset:
+		s = "I"
+	case model.BSUnderline:
+		s = "U"
+	}
+
+	d := types.Dict(map[string]types.Object{
+		"W": types.Float(boWidth),
+		"S": types.Name(s),
+	})
+
+	if bs == model.BSDashed {
+		d["D"] = types.NewIntegerArray(3)
+	}
+
+	return d
+}
+
+// renderFieldBorder renders a border of style bs for a form field appearance of given width and height.
+func renderFieldBorder(w io.Writer, bs model.BorderStyle, boCol *color.SimpleColor, boWidth, width, height float64) {
+	fmt.Fprintf(w, "q %.2f %.2f %.2f RG %.2f w ", boCol.R, boCol.G, boCol.B, boWidth)
+
+	bw := boWidth / 2
+
+	switch bs {
+
+	case model.BSDashed:
+		fmt.Fprintf(w, "[3] 0 d %.2f %.2f %.2f %.2f re s ", bw, bw, width-boWidth, height-boWidth)
+
+	case model.BSUnderline:
+		fmt.Fprintf(w, "0 %.2f m %.2f %.2f l S ", bw, width, bw)
+
+	case model.BSBeveled, model.BSInset:
+		fmt.Fprintf(w, "%.2f %.2f %.2f %.2f re s ", bw, bw, width-boWidth, height-boWidth)
+
+		// Shade the upper left and lower right inner edges.
+		upperLeft, lowerRight := 1., .5
+		if bs == model.BSInset {
+			upperLeft, lowerRight = .5, .75
+		}
+		b1, b2 := boWidth, 2*boWidth
+		fmt.Fprintf(w, "%.2f g %.2f %.2f m %.2f %.2f l %.2f %.2f l %.2f %.2f l %.2f %.2f l %.2f %.2f l f ",
+			upperLeft, b1, b1, b1, height-b1, width-b1, height-b1, width-b2, height-b2, b2, height-b2, b2, b2)
+		fmt.Fprintf(w, "%.2f g %.2f %.2f m %.2f %.2f l %.2f %.2f l %.2f %.2f l %.2f %.2f l %.2f %.2f l f ",
+			lowerRight, width-b1, height-b1, width-b1, b1, b1, b1, b2, b2, width-b2, b2, width-b2, height-b2)
+
+	default:
+		fmt.Fprintf(w, "%.2f %.2f %.2f %.2f re s ", bw, bw, width-boWidth, height-boWidth)
+	}
+
+	fmt.Fprint(w, "Q ")
+}
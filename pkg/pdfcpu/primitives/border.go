@@ -28,6 +28,7 @@ type Border struct {
 	Width int
 	Color string `json:"col"`
 	col   *color.SimpleColor
+	paint paint
 	Style string
 	style types.LineJoinStyle
 }
@@ -39,11 +40,11 @@ func (b *Border) validate() error {
 	}
 
 	if b.Color != "" {
-		sc, err := b.pdf.parseColor(b.Color)
+		sc, pt, err := b.pdf.parsePaint(b.Color)
 		if err != nil {
 			return err
 		}
-		b.col = sc
+		b.col, b.paint = sc, pt
 	}
 
 	b.style = types.LJMiter
@@ -67,8 +68,8 @@ func (b *Border) mergeIn(b0 *Border) {
 	if b.Width == 0 {
 		b.Width = b0.Width
 	}
-	if b.col == nil {
-		b.col = b0.col
+	if b.col == nil && b.paint == nil {
+		b.col, b.paint = b0.col, b0.paint
 	}
 	if b.style == types.LJMiter {
 		b.style = b0.style
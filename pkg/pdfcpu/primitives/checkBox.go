@@ -46,6 +46,7 @@ type CheckBox struct {
 	bgCol           *color.SimpleColor
 	Tab             int
 	Locked          bool
+	Required        bool
 	Debug           bool
 	Hide            bool
 }
@@ -610,8 +611,15 @@ func (cb *CheckBox) prepareDict(fonts model.FontMap) (types.Dict, error) {
 		d["MK"] = appCharDict
 	}
 
+	ff := FieldFlags(0)
 	if cb.Locked {
-		d["Ff"] = types.Integer(FieldReadOnly)
+		ff += FieldReadOnly
+	}
+	if cb.Required {
+		ff += FieldRequired
+	}
+	if ff > 0 {
+		d["Ff"] = types.Integer(ff)
 	}
 
 	return d, nil
@@ -0,0 +1,116 @@
+/*
+	Copyright 2026 The pdfcpu Authors.
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+package primitives
+
+import (
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/draw"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+type clipShape int
+
+const (
+	clipRect clipShape = iota
+	clipRoundedRect
+	clipEllipse
+	clipPolygon
+)
+
+// clip is a shape content gets clipped to.
+type clip struct {
+	shape  clipShape
+	radius float64       // corner radius of rounded rects
+	pp     []types.Point // polygon corners relative to the clipped box
+}
+
+// parseClip parses "rect", "round radius", "ellipse" or "path x0 y0 x1 y1 x2 y2 ...".
+func parseClip(s string) (*clip, error) {
+	ss := strings.Fields(s)
+	if len(ss) == 0 {
+		return nil, errors.New("pdfcpu: missing clip shape")
+	}
+
+	ff := make([]float64, len(ss)-1)
+	for i, s := range ss[1:] {
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, errors.Errorf("pdfcpu: invalid clip value: %s", s)
+		}
+		ff[i] = f
+	}
+
+	switch strings.ToLower(ss[0]) {
+
+	case "rect":
+		if len(ff) > 0 {
+			return nil, errors.New("pdfcpu: clip rect: unexpected values")
+		}
+		return &clip{shape: clipRect}, nil
+
+	case "round":
+		if len(ff) != 1 || ff[0] <= 0 {
+			return nil, errors.New("pdfcpu: clip round: need positive corner radius")
+		}
+		return &clip{shape: clipRoundedRect, radius: ff[0]}, nil
+
+	case "ellipse", "circle":
+		if len(ff) > 0 {
+			return nil, errors.New("pdfcpu: clip ellipse: unexpected values")
+		}
+		return &clip{shape: clipEllipse}, nil
+
+	case "path":
+		if len(ff) < 6 || len(ff)%2 > 0 {
+			return nil, errors.New("pdfcpu: clip path: need at least 3 points")
+		}
+		cl := &clip{shape: clipPolygon}
+		for i := 0; i < len(ff); i += 2 {
+			if ff[i] < 0 || ff[i+1] < 0 {
+				return nil, errors.New("pdfcpu: clip path: coordinates must not be negative")
+			}
+			cl.pp = append(cl.pp, types.Point{X: ff[i], Y: ff[i+1]})
+		}
+		return cl, nil
+	}
+
+	return nil, errors.Errorf("pdfcpu: unknown clip shape: %s (rect, round, ellipse, path)", ss[0])
+}
+
+// path writes the clip path for r.
+// Polygon corners are relative to r using origin.
+func (cl clip) path(w io.Writer, r *types.Rectangle, origin types.Corner) {
+	switch cl.shape {
+	case clipRoundedRect:
+		draw.RoundedRectPath(w, r, cl.radius)
+	case clipEllipse:
+		draw.EllipsePath(w, r)
+	case clipPolygon:
+		pp := make([]types.Point, len(cl.pp))
+		for i, p := range cl.pp {
+			x, y := types.NormalizeCoord(p.X, p.Y, r, origin, true)
+			pp[i] = types.Point{X: x, Y: y}
+		}
+		draw.PolygonPath(w, pp)
+	default:
+		draw.PolygonPath(w, []types.Point{r.LL, {X: r.UR.X, Y: r.LL.Y}, r.UR, {X: r.LL.X, Y: r.UR.Y}})
+	}
+}
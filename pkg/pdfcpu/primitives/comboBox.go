@@ -49,6 +49,8 @@ type ComboBox struct {
 	fontID          string `json:"-"`
 	Margin          *Margin
 	Border          *Border
+	BorderStyle     string `json:"borderStyle"` // "solid", "dashed", "beveled", "inset", "underline"
+	borderStyle     model.BorderStyle
 	BackgroundColor string             `json:"bgCol"`
 	BgCol           *color.SimpleColor `json:"-"`
 	Alignment       string             `json:"align"` // "Left", "Center", "Right"
@@ -56,6 +58,7 @@ type ComboBox struct {
 	RTL             bool
 	Tab             int
 	Locked          bool
+	Required        bool
 	Debug           bool
 	Hide            bool
 }
@@ -132,6 +135,11 @@ func (cb *ComboBox) validateBorder() error {
 			return err
 		}
 	}
+	bs, err := parseFieldBorderStyle(cb.BorderStyle)
+	if err != nil {
+		return err
+	}
+	cb.borderStyle = bs
 	return nil
 }
 
@@ -367,7 +375,7 @@ func (cb *ComboBox) renderN(xRefTable *model.XRefTable) ([]byte, error) {
 		if bgCol != nil {
 			fmt.Fprintf(buf, "%.2f %.2f %.2f rg 0 0 %.2f %.2f re f ", bgCol.R, bgCol.G, bgCol.B, w, h)
 		}
-		if boCol != nil {
+		if boCol != nil && cb.borderStyle == model.BSSolid {
 			fmt.Fprintf(buf, "%.2f %.2f %.2f RG %.2f w %.2f %.2f %.2f %.2f re s ",
 				boCol.R, boCol.G, boCol.B, boWidth, boWidth/2, boWidth/2, w-boWidth, h-boWidth)
 		}
@@ -411,8 +419,12 @@ func (cb *ComboBox) renderN(xRefTable *model.XRefTable) ([]byte, error) {
 	fmt.Fprint(buf, "Q EMC ")
 
 	if boCol != nil && boWidth > 0 {
-		fmt.Fprintf(buf, "q %.2f %.2f %.2f RG %.2f w %.2f %.2f %.2f %.2f re s Q ",
-			boCol.R, boCol.G, boCol.B, boWidth-1, boWidth/2, boWidth/2, w-boWidth, h-boWidth)
+		if cb.borderStyle != model.BSSolid {
+			renderFieldBorder(buf, cb.borderStyle, boCol, boWidth, w, h)
+		} else {
+			fmt.Fprintf(buf, "q %.2f %.2f %.2f RG %.2f w %.2f %.2f %.2f %.2f re s Q ",
+				boCol.R, boCol.G, boCol.B, boWidth-1, boWidth/2, boWidth/2, w-boWidth, h-boWidth)
+		}
 	}
 
 	return buf.Bytes(), nil
@@ -436,6 +448,9 @@ func (cb *ComboBox) prepareFF() FieldFlags {
 		// Note: unsupported in Mac Preview
 		ff += FieldReadOnly
 	}
+	if cb.Required {
+		ff += FieldRequired
+	}
 	return ff
 }
 
@@ -464,6 +479,9 @@ func (cb *ComboBox) handleBorderAndMK(d types.Dict) {
 
 	if boWidth > 0 {
 		d["Border"] = types.NewNumberArray(0, 0, boWidth)
+		if cb.borderStyle != model.BSSolid {
+			d["BS"] = fieldBorderStyleDict(boWidth, cb.borderStyle)
+		}
 	}
 }
 
@@ -739,10 +757,7 @@ func NewComboBox(
 		return nil, nil, err
 	}
 
-	cb.HorAlign = types.AlignLeft
-	if q := d.IntEntry("Q"); q != nil {
-		cb.HorAlign = types.HAlignment(*q)
-	}
+	cb.HorAlign = horAlign(ctx, d, v)
 
 	bgCol, boCol, err := calcColsFromMK(ctx, d)
 	if err != nil {
@@ -17,6 +17,8 @@
 package primitives
 
 import (
+	"fmt"
+
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/color"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/draw"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
@@ -37,6 +39,8 @@ type Content struct {
 	Margin          *Margin              // content margin
 	Border          *Border              // content border
 	Padding         *Padding             // content padding
+	Clip            string               // "rect", "round radius", "ellipse" or "path x0 y0 x1 y1 x2 y2 ..."
+	clip            *clip
 	Regions         *Regions
 	mediaBox        *types.Rectangle
 	borderRect      *types.Rectangle
@@ -422,6 +426,14 @@ func (c *Content) validate() error {
 		return err
 	}
 
+	if c.Clip != "" {
+		cl, err := parseClip(c.Clip)
+		if err != nil {
+			return err
+		}
+		c.clip = cl
+	}
+
 	for _, g := range c.Guides {
 		g.validate()
 	}
@@ -1059,14 +1071,14 @@ func (c *Content) renderSimpleBoxes(p *model.Page) error {
 			}
 			sb.mergeIn(sb0)
 		}
-		if err := sb.render(p); err != nil {
+		if err := c.page.pdf.renderGroup(p, sb.Opacity, func() error { return sb.render(p) }); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func (c *Content) renderTextBoxes(p *model.Page, pageNr int, fonts model.FontMap) error {
+func (c *Content) renderTextBoxes(p *model.Page, pageNr int, fonts model.FontMap, images model.ImageMap) error {
 	for _, tb := range c.TextBoxes {
 		if tb.Hide {
 			continue
@@ -1080,7 +1092,7 @@ func (c *Content) renderTextBoxes(p *model.Page, pageNr int, fonts model.FontMap
 			}
 			tb.mergeIn(tb0)
 		}
-		if err := tb.render(p, pageNr, fonts); err != nil {
+		if err := c.page.pdf.renderGroup(p, tb.Opacity, func() error { return tb.render(p, pageNr, fonts, images) }); err != nil {
 			return err
 		}
 	}
@@ -1101,7 +1113,7 @@ func (c *Content) renderImageBoxes(p *model.Page, pageNr int, images model.Image
 			}
 			ib.mergeIn(ib0)
 		}
-		if err := ib.render(p, pageNr, images); err != nil {
+		if err := c.page.pdf.renderGroup(p, ib.Opacity, func() error { return ib.render(p, pageNr, images) }); err != nil {
 			return err
 		}
 	}
@@ -1122,7 +1134,7 @@ func (c *Content) renderTables(p *model.Page, pageNr int, fonts model.FontMap) e
 			}
 			t.mergeIn(t0)
 		}
-		if err := t.render(p, pageNr, fonts); err != nil {
+		if err := c.page.pdf.renderGroup(p, t.Opacity, func() error { return t.render(p, pageNr, fonts) }); err != nil {
 			return err
 		}
 	}
@@ -1248,7 +1260,7 @@ func (c *Content) renderPrimitives(p *model.Page, pageNr int, fonts model.FontMa
 		return err
 	}
 
-	if err := c.renderTextBoxes(p, pageNr, fonts); err != nil {
+	if err := c.renderTextBoxes(p, pageNr, fonts, images); err != nil {
 		return err
 	}
 
@@ -1295,6 +1307,10 @@ func (c *Content) render(p *model.Page, pageNr int, fonts model.FontMap, images
 		return c.Regions.render(p, pageNr, fonts, images)
 	}
 
+	if c.clip != nil {
+		return c.renderClipped(p, pageNr, fonts, images)
+	}
+
 	// Render background
 	if c.bgCol != nil {
 		draw.FillRectNoBorder(p.Buf, c.BorderRect(), *c.bgCol)
@@ -1318,3 +1334,41 @@ func (c *Content) render(p *model.Page, pageNr int, fonts model.FontMap, images
 
 	return nil
 }
+
+// renderClipped renders background and primitives clipped to c.clip and strokes the border along the clip path.
+// Form fields are not subject to clipping.
+func (c *Content) renderClipped(p *model.Page, pageNr int, fonts model.FontMap, images model.ImageMap) error {
+	r, origin := c.BorderRect(), c.page.pdf.origin
+
+	fmt.Fprint(p.Buf, "q ")
+	c.clip.path(p.Buf, r, origin)
+	fmt.Fprint(p.Buf, "W n ")
+
+	if c.bgCol != nil {
+		draw.FillRectNoBorder(p.Buf, r, *c.bgCol)
+	}
+
+	if err := c.renderPrimitives(p, pageNr, fonts, images); err != nil {
+		return err
+	}
+
+	fmt.Fprint(p.Buf, "Q ")
+
+	b := c.border()
+	if b != nil && b.col != nil && b.Width >= 0 {
+		fmt.Fprint(p.Buf, "q ")
+		draw.SetLineWidth(p.Buf, float64(b.Width))
+		draw.SetStrokeColor(p.Buf, *b.col)
+		draw.SetLineJoinStyle(p.Buf, b.style)
+		c.clip.path(p.Buf, r, origin)
+		fmt.Fprint(p.Buf, "S Q ")
+	}
+
+	if err := c.renderFormPrimitives(p, pageNr, fonts); err != nil {
+		return err
+	}
+
+	c.renderBoxesAndGuides(p)
+
+	return nil
+}
@@ -51,12 +51,15 @@ type DateField struct {
 	fontID          string
 	Margin          *Margin // applied to content box
 	Border          *Border
+	BorderStyle     string `json:"borderStyle"` // "solid", "dashed", "beveled", "inset", "underline"
+	borderStyle     model.BorderStyle
 	BackgroundColor string             `json:"bgCol"`
 	BgCol           *color.SimpleColor `json:"-"`
 	Alignment       string             `json:"align"` // "Left", "Center", "Right"
 	HorAlign        types.HAlignment   `json:"-"`
 	Tab             int
 	Locked          bool
+	Required        bool
 	Debug           bool
 	Hide            bool
 }
@@ -117,6 +120,11 @@ func (df *DateField) validateBorder() error {
 			return err
 		}
 	}
+	bs, err := parseFieldBorderStyle(df.BorderStyle)
+	if err != nil {
+		return err
+	}
+	df.borderStyle = bs
 	return nil
 }
 
@@ -434,7 +442,11 @@ func (df *DateField) renderN(xRefTable *model.XRefTable) ([]byte, error) {
 	boWidth, boCol := df.calcBorder()
 	buf := new(bytes.Buffer)
 
-	df.renderBackground(buf, bgCol, boCol, boWidth, w, h)
+	if df.borderStyle == model.BSSolid {
+		df.renderBackground(buf, bgCol, boCol, boWidth, w, h)
+	} else {
+		df.renderBackground(buf, bgCol, nil, 0, w, h)
+	}
 
 	fmt.Fprint(buf, "/Tx BMC q ")
 	fmt.Fprintf(buf, "1 1 %.1f %.1f re W n ", w-2, h-2)
@@ -478,8 +490,12 @@ func (df *DateField) renderN(xRefTable *model.XRefTable) ([]byte, error) {
 	fmt.Fprint(buf, "Q EMC ")
 
 	if boCol != nil && boWidth > 0 {
-		fmt.Fprintf(buf, "q %.2f %.2f %.2f RG %.2f w %.2f %.2f %.2f %.2f re s Q ",
-			boCol.R, boCol.G, boCol.B, boWidth-1, boWidth/2, boWidth/2, w-boWidth, h-boWidth)
+		if df.borderStyle != model.BSSolid {
+			renderFieldBorder(buf, df.borderStyle, boCol, boWidth, w, h)
+		} else {
+			fmt.Fprintf(buf, "q %.2f %.2f %.2f RG %.2f w %.2f %.2f %.2f %.2f re s Q ",
+				boCol.R, boCol.G, boCol.B, boWidth-1, boWidth/2, boWidth/2, w-boWidth, h-boWidth)
+		}
 	}
 
 	return buf.Bytes(), nil
@@ -568,6 +584,9 @@ func (df *DateField) prepareFF() FieldFlags {
 	if df.Locked {
 		ff += FieldReadOnly
 	}
+	if df.Required {
+		ff += FieldRequired
+	}
 	return ff
 }
 
@@ -596,6 +615,9 @@ func (df *DateField) handleBorderAndMK(d types.Dict) {
 
 	if boWidth > 0 {
 		d["Border"] = types.NewNumberArray(0, 0, boWidth)
+		if df.borderStyle != model.BSSolid {
+			d["BS"] = fieldBorderStyleDict(boWidth, df.borderStyle)
+		}
 	}
 }
 
@@ -877,10 +899,7 @@ func NewDateField(
 		return nil, nil, err
 	}
 
-	df.HorAlign = types.AlignLeft
-	if q := d.IntEntry("Q"); q != nil {
-		df.HorAlign = types.HAlignment(*q)
-	}
+	df.HorAlign = horAlign(ctx, d, "")
 
 	bgCol, boCol, err := calcColsFromMK(ctx, d)
 	if err != nil {
@@ -0,0 +1,134 @@
+/*
+	Copyright 2026 The pdfcpu Authors.
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+package primitives
+
+import (
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// TextFloat is an image floating at the left or right edge of a justified text column.
+// The text flows around the image keeping a gap.
+type TextFloat struct {
+	Src    string `json:"src"`   // path of image file name
+	Align  string `json:"align"` // "left" (default), "right"
+	right  bool
+	Top    float64 // offset from the top of the column text
+	Width  float64
+	Height float64
+	Gap    float64 // space between image and text, defaults to 5
+	ib     *ImageBox
+}
+
+func (tf *TextFloat) validate(tb *TextBox) error {
+	if tf.Src == "" {
+		return errors.New("pdfcpu: text float: missing \"src\"")
+	}
+
+	if tf.Width <= 0 || tf.Height <= 0 {
+		return errors.New("pdfcpu: text float: please supply \"width\" and \"height\"")
+	}
+
+	switch strings.ToLower(tf.Align) {
+	case "", "left":
+	case "right":
+		tf.right = true
+	default:
+		return errors.Errorf("pdfcpu: text float: invalid align: %s", tf.Align)
+	}
+
+	if tf.Top < 0 || tf.Gap < 0 {
+		return errors.New("pdfcpu: text float: \"top\" and \"gap\" must not be negative")
+	}
+	if tf.Gap == 0 {
+		tf.Gap = 5
+	}
+
+	tf.ib = &ImageBox{
+		pdf:     tb.pdf,
+		content: tb.content,
+		Src:     tf.Src,
+		Width:   tf.Width,
+		Height:  tf.Height,
+	}
+
+	if err := tf.ib.validate(); err != nil {
+		return err
+	}
+
+	// Floats get rendered into the area left free by the text.
+	tf.ib.content = nil
+
+	return nil
+}
+
+func (tb *TextBox) validateFloats() error {
+	if len(tb.Floats) == 0 {
+		return nil
+	}
+	if tb.anchored || tb.path != nil {
+		return errors.New("pdfcpu: Please supply \"pos\" for text with floats")
+	}
+	if tb.Width <= 0 {
+		return errors.New("pdfcpu: Please supply \"width\" for text with floats")
+	}
+	if tb.Rotation != 0 {
+		return errors.New("pdfcpu: text with floats can't be rotated")
+	}
+	if tb.horAlign != types.AlignJustify {
+		return errors.New("pdfcpu: text with floats must be justified")
+	}
+	for _, tf := range tb.Floats {
+		if err := tf.validate(tb); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// exclusions returns the areas of floats within the column text of width w.
+func (tb *TextBox) exclusions(w float64) []model.Exclusion {
+	var ee []model.Exclusion
+	for _, tf := range tb.Floats {
+		e := model.Exclusion{Top: tf.Top, Width: tf.Width + tf.Gap, Height: tf.Height + tf.Gap}
+		if tf.right {
+			e.Left = w - e.Width
+		}
+		ee = append(ee, e)
+	}
+	return ee
+}
+
+// renderFloats renders the floats of tb into the column text of width w starting at the upper left corner x,y.
+func (tb *TextBox) renderFloats(p *model.Page, x, y, w float64, pageNr int, images model.ImageMap) error {
+	for _, tf := range tb.Floats {
+		x1 := x
+		if tf.right {
+			x1 += w - tf.Width
+		}
+		ib := tf.ib
+		ib.dest = types.RectForWidthAndHeight(x1, y-tf.Top-tf.Height, tf.Width, tf.Height)
+		ib.anchor, ib.anchored = types.Center, true
+		if err := ib.render(p, pageNr, images); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,618 @@
+/*
+	Copyright 2026 The pdfcpu Authors.
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+package primitives
+
+import (
+	"math"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pdfcpu/pdfcpu/pkg/font"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// flowable is content able to continue on the next page.
+type flowable interface {
+	// split reduces the content to what fits onto its page and returns the rest.
+	split() (flowable, error)
+	// moveTo adds the content to c.
+	moveTo(c *Content)
+}
+
+// newContinuationPage returns an empty page numbered pageNr for content overflowing page.
+func (page *PDFPage) newContinuationPage(pageNr int) *PDFPage {
+	pdf := page.pdf
+
+	p := &PDFPage{
+		pdf:            pdf,
+		mediaBox:       page.mediaBox,
+		cropBox:        page.cropBox,
+		bgCol:          page.bgCol,
+		bgPaint:        page.bgPaint,
+		Fonts:          page.Fonts,
+		Margins:        page.Margins,
+		Borders:        page.Borders,
+		Paddings:       page.Paddings,
+		SimpleBoxPool:  page.SimpleBoxPool,
+		TextBoxPool:    page.TextBoxPool,
+		ImageBoxPool:   page.ImageBoxPool,
+		TablePool:      page.TablePool,
+		FieldGroupPool: page.FieldGroupPool,
+		FileNames:      page.FileNames,
+	}
+
+	// Continuation pages use the overflow master or else the master of their page range.
+	m := pdf.Overflow
+	if m == nil {
+		m = pdf.master(pageNr)
+	}
+	p.master = m
+
+	// Named resources and content layout default to the overflowing page.
+	c := &Content{parent: page.Content, page: p, bgCol: page.Content.bgCol}
+
+	if m != nil {
+		mp := m.page
+		if m.Paper != "" || m.Crop != "" {
+			p.mediaBox, p.cropBox = mp.mediaBox, mp.cropBox
+		}
+		if mp.bgCol != nil || mp.bgPaint != nil {
+			p.bgCol, p.bgPaint = mp.bgCol, mp.bgPaint
+		}
+		mc := mp.Content
+		c.Margins, c.Borders, c.Paddings = mc.Margins, mc.Borders, mc.Paddings
+		if mc.bgCol != nil {
+			c.bgCol = mc.bgCol
+		}
+	}
+
+	p.Content = c
+	c.mediaBox = pdf.contentRect(p.cropBox, p.master)
+
+	return p
+}
+
+func (c *Content) flowables() ([]flowable, error) {
+	var ff []flowable
+
+	for _, tb := range c.TextBoxes {
+		if tb.Hide {
+			continue
+		}
+		if tb.Name != "" && tb.Name[0] == '$' {
+			// Use named textbox
+			tbName := tb.Name[1:]
+			tb0 := c.namedTextBox(tbName)
+			if tb0 == nil {
+				return nil, errors.Errorf("pdfcpu: unknown named text %s", tbName)
+			}
+			tb.mergeIn(tb0)
+			tb.Name = ""
+		}
+		if tb.Flow && !tb.Hide {
+			ff = append(ff, tb)
+		}
+	}
+
+	for _, t := range c.Tables {
+		if t.Hide {
+			continue
+		}
+		if t.Name != "" && t.Name[0] == '$' {
+			// Use named table
+			tName := t.Name[1:]
+			t0 := c.namedTable(tName)
+			if t0 == nil {
+				return nil, errors.Errorf("pdfcpu: unknown named table %s", tName)
+			}
+			t.mergeIn(t0)
+			t.Name = ""
+		}
+		if t.Flow && !t.Hide {
+			ff = append(ff, t)
+		}
+	}
+
+	return ff, nil
+}
+
+// snapshot returns a func restoring ff to their current state.
+func snapshot(ff []flowable) func() {
+	var undo []func()
+	for _, f := range ff {
+		switch f := f.(type) {
+		case *TextBox:
+			tb := *f
+			undo = append(undo, func() { *f = tb })
+		case *Table:
+			t := *f
+			undo = append(undo, func() { *f = t })
+		}
+	}
+	return func() {
+		for _, f := range undo {
+			f()
+		}
+	}
+}
+
+// layout splits flowing content of page reserving space for footnotes in followed by the footnotes referenced on page.
+// It returns the flowing content and the footnotes continuing on the next page.
+func (page *PDFPage) layout(in []*footnote) (flowable, []*footnote, error) {
+	c := page.Content
+	fns := page.pdf.Footnotes
+
+	r := page.pdf.contentRect(page.cropBox, page.master)
+
+	ff, err := c.flowables()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fixed := append([]*footnote{}, in...)
+	for _, tb := range c.TextBoxes {
+		if !tb.Flow && !tb.Hide {
+			fixed = append(fixed, tb.footnotes...)
+		}
+	}
+
+	var (
+		rest   flowable
+		nn     []*footnote
+		w      = page.footnoteRect(r).Width()
+		maxH   = fns.MaxHeight * r.Height()
+		h      float64
+		undo   = snapshot(ff)
+		height = fns.height(fixed, w, maxH)
+	)
+
+	// Footnotes referenced by flowing text depend on the space left by the footnotes.
+	for i := 0; i < 3; i++ {
+		undo()
+
+		h = height
+		c.mediaBox, c.borderRect, c.box = r.CroppedCopy(0), nil, nil
+		c.mediaBox.LL.Y += h
+
+		rest = nil
+		for _, f := range ff {
+			f1, err := f.split()
+			if err != nil {
+				return nil, nil, err
+			}
+			if f1 == nil {
+				continue
+			}
+			if rest != nil {
+				return nil, nil, errors.Errorf("pdfcpu: page %d: only one flowing element may overflow", page.number)
+			}
+			rest = f1
+		}
+
+		nn = fixed
+		for _, tb := range c.TextBoxes {
+			if tb.Flow && !tb.Hide {
+				nn = append(nn, tb.footnotes...)
+			}
+		}
+
+		if height = fns.height(nn, w, maxH); height <= h {
+			break
+		}
+	}
+
+	page.footnotes, nn = fns.fit(nn, w, h)
+	page.footnoteHeight = 0
+	if len(page.footnotes) > 0 {
+		page.footnoteHeight = h
+	}
+
+	return rest, nn, nil
+}
+
+func (page *PDFPage) takesFootnotes() bool {
+	return page != nil && page.Content != nil && page.Content.Regions == nil
+}
+
+// overflow lays out page numbered pageNr starting with footnotes in continued from the previous page.
+// It returns the continuation pages needed for flowing content and the footnotes continuing on the next page.
+func (page *PDFPage) overflow(pageNr int, nr *int, in []*footnote) ([]*PDFPage, []*footnote, error) {
+	if !page.takesFootnotes() {
+		return nil, in, nil
+	}
+
+	page.Content.collectFootnotes(nr)
+
+	rest, out, err := page.layout(in)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var pp []*PDFPage
+	for rest != nil {
+		p := page.newContinuationPage(pageNr + len(pp) + 1)
+		rest.moveTo(p.Content)
+		pp = append(pp, p)
+		if rest, out, err = p.layout(out); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return pp, out, nil
+}
+
+// footnotePages returns the continuation pages of page numbered pageNr needed for footnotes nn.
+func (page *PDFPage) footnotePages(pageNr int, nn []*footnote) ([]*PDFPage, error) {
+	var pp []*PDFPage
+	for len(nn) > 0 {
+		p := page.newContinuationPage(pageNr + len(pp) + 1)
+		n := len(nn)
+		_, out, err := p.layout(nn)
+		if err != nil {
+			return nil, err
+		}
+		if len(out) == n && out[0] == nn[0] {
+			return nil, errors.Errorf("pdfcpu: page %d: footnotes do not fit", pageNr)
+		}
+		pp, nn = append(pp, p), out
+	}
+	return pp, nil
+}
+
+// paginate inserts continuation pages for flowing text and tables right after their overflowing page.
+// Any following pages move back accordingly.
+// Page masters get applied along the way based on the resulting page numbers.
+// Footnotes not fitting onto their page continue on the next page taking footnotes.
+func (pdf *PDF) paginate() error {
+	if err := pdf.calcFont(pdf.Footnotes.Font); err != nil {
+		return err
+	}
+
+	var (
+		pp   []*PDFPage
+		last *PDFPage // the last page taking footnotes
+		nn   []*footnote
+		nr   int
+	)
+
+	insert := func(more []*PDFPage) error {
+		if len(more) > 0 && pdf.Update() && len(pp) < pdf.XRefTable.PageCount {
+			return errors.Errorf("pdfcpu: page %d: flowing content must not overflow onto existing pages", len(pp))
+		}
+		pp = append(pp, more...)
+		return nil
+	}
+
+	for _, page := range pdf.pages {
+		if len(nn) > 0 && !page.takesFootnotes() {
+			more, err := last.footnotePages(len(pp), nn)
+			if err != nil {
+				return err
+			}
+			if err := insert(more); err != nil {
+				return err
+			}
+			nn = nil
+		}
+
+		pp = append(pp, page)
+		if page == nil {
+			continue
+		}
+
+		page.applyMaster(pdf.master(len(pp)))
+
+		more, out, err := page.overflow(len(pp), &nr, nn)
+		if err != nil {
+			return err
+		}
+		nn = out
+		if page.takesFootnotes() {
+			last = page
+		}
+
+		if err := insert(more); err != nil {
+			return err
+		}
+	}
+
+	if len(nn) > 0 {
+		more, err := last.footnotePages(len(pp), nn)
+		if err != nil {
+			return err
+		}
+		if err := insert(more); err != nil {
+			return err
+		}
+	}
+
+	pdf.pages = pp
+
+	return nil
+}
+
+// flowLine is a line of words resulting from breaking text into a column.
+type flowLine struct {
+	words  []string
+	parEnd bool // last line of a paragraph
+}
+
+// wrapLines breaks the paragraphs of s into lines not wider than w flowing around ee.
+// Words are measured and broken the same way justified columns get rendered.
+func wrapLines(s, fontName string, fontSize int, w float64, ee []model.Exclusion) []flowLine {
+	coreFont := font.IsCoreFont(fontName)
+
+	textWidth := func(s string) float64 {
+		if coreFont && utf8.ValidString(s) {
+			s = model.DecodeUTF8ToByte(s)
+		}
+		return font.TextWidth(s, fontName, fontSize)
+	}
+
+	blankWidth := textWidth(" ")
+	lh := font.LineHeight(fontName, fontSize)
+
+	var ll []flowLine
+
+	for _, par := range model.SplitMultilineStr(s) {
+		var l flowLine
+		var lw float64
+		words := strings.Fields(par)
+		for i := 0; i < len(words); i++ {
+			word := words[i]
+			ww := textWidth(word)
+			bw := 0.
+			if len(l.words) > 0 {
+				bw = blankWidth
+			}
+			w1 := w
+			if len(ee) > 0 {
+				_, w1 = model.LineSpan(ee, lh, w, len(ll))
+			}
+			if len(l.words) == 0 && w1 < w && w1-ww <= 0 && ww < w {
+				// Skip a line narrowed by exclusions.
+				ll = append(ll, flowLine{})
+				i--
+				continue
+			}
+			if len(l.words) == 0 || w1-lw-(ww+bw) > 0 {
+				l.words = append(l.words, word)
+				lw += ww + bw
+				continue
+			}
+			ll = append(ll, l)
+			l = flowLine{words: []string{word}}
+			lw = ww
+		}
+		l.parEnd = true
+		ll = append(ll, l)
+	}
+
+	return ll
+}
+
+// flowText joins ll into text.
+// Justified text keeps its paragraphs and gets broken into lines again during rendering.
+func flowText(ll []flowLine, justify bool) string {
+	var sb strings.Builder
+	sep := ""
+	for _, l := range ll {
+		if len(l.words) == 0 && !l.parEnd {
+			// Line skipped next to a float.
+			continue
+		}
+		sb.WriteString(sep)
+		sb.WriteString(strings.Join(l.words, " "))
+		sep = " "
+		if l.parEnd || !justify {
+			sep = "\n"
+		}
+	}
+	return sb.String()
+}
+
+func (tb *TextBox) moveTo(c *Content) {
+	tb.content = c
+	c.TextBoxes = append(c.TextBoxes, tb)
+}
+
+// flowTop returns the top of flowing text relative to r, the content box reduced by margins.
+func (tb *TextBox) flowTop(r *types.Rectangle, mBottom float64) float64 {
+	if tb.cont {
+		return r.Height()
+	}
+	_, y := types.NormalizeCoord(tb.x, tb.y, tb.content.Box(), tb.pdf.origin, false)
+	if y < 0 {
+		return r.Height()
+	}
+	y -= mBottom
+	return math.Max(0, math.Min(y, r.Height()))
+}
+
+// insets returns border width and vertical and horizontal padding.
+func (tb *TextBox) insets() (float64, float64, float64, error) {
+	var bWidth, pVert, pHor float64
+
+	if b := tb.Border; b != nil {
+		if b.Name != "" && b.Name[0] == '$' {
+			// Use named border
+			bName := b.Name[1:]
+			b0 := tb.border(bName)
+			if b0 == nil {
+				return 0, 0, 0, errors.Errorf("pdfcpu: unknown named border %s", bName)
+			}
+			b.mergeIn(b0)
+		}
+		if b.Width >= 0 {
+			bWidth = float64(b.Width)
+		}
+	}
+
+	if p := tb.Padding; p != nil {
+		if p.Name != "" && p.Name[0] == '$' {
+			// Use named padding
+			pName := p.Name[1:]
+			p0 := tb.padding(pName)
+			if p0 == nil {
+				return 0, 0, 0, errors.Errorf("pdfcpu: unknown named padding %s", pName)
+			}
+			p.mergeIn(p0)
+		}
+		var td model.TextDescriptor
+		tdMargin(p, &td)
+		pVert, pHor = td.MTop+td.MBot, td.MLeft+td.MRight
+	}
+
+	return bWidth, pVert, pHor, nil
+}
+
+func (tb *TextBox) split() (flowable, error) {
+	if err := tb.calcFont(); err != nil {
+		return nil, err
+	}
+
+	mTop, mRight, mBottom, mLeft, err := tb.calcMargin()
+	if err != nil {
+		return nil, err
+	}
+
+	bWidth, pVert, pHor, err := tb.insets()
+	if err != nil {
+		return nil, err
+	}
+
+	r := tb.content.Box().CroppedCopy(0)
+	r.LL.X += mLeft
+	r.LL.Y += mBottom
+	r.UR.X -= mRight
+	r.UR.Y -= mTop
+
+	_, dy := types.NormalizeOffset(tb.Dx, tb.Dy, tb.pdf.origin)
+	h := math.Min(tb.flowTop(r, mBottom)+dy, r.Height())
+
+	f := tb.Font
+	lh := font.LineHeight(f.Name, f.Size)
+	n := int(math.Max(0, math.Floor((h-pVert-2*bWidth)/lh)))
+
+	w := tb.Width - pHor - 2*bWidth
+	ll := wrapLines(tb.Value, f.Name, f.Size, w, tb.exclusions(w))
+	justify := tb.horAlign == types.AlignJustify
+
+	if len(ll) <= n {
+		tb.Value = flowText(ll, justify)
+		return nil, nil
+	}
+
+	if n == 0 && tb.cont {
+		return nil, errors.New("pdfcpu: flowing text does not fit onto continuation page")
+	}
+
+	tb1 := *tb
+	tb1.Value, tb1.cont, tb1.Dy = flowText(ll[n:], true), true, 0
+
+	// Floats stay on the first page.
+	tb1.Floats = nil
+
+	if n == 0 {
+		// Start on the next page.
+		tb.Hide = true
+		tb.footnotes = nil
+		tb.Bookmark, tb.Target = nil, ""
+	} else {
+		tb.Value = flowText(ll[:n], justify)
+		tb.footnotes, tb1.footnotes = splitFootnotes(tb.footnotes, tb.Value)
+		// Bookmark and target stay with the start of the text.
+		tb1.Bookmark, tb1.Target = nil, ""
+	}
+
+	return &tb1, nil
+}
+
+func (t *Table) moveTo(c *Content) {
+	t.content = c
+	c.Tables = append(c.Tables, t)
+}
+
+// flowTop returns the top of a flowing table relative to r, the content box reduced by margins.
+func (t *Table) flowTop(r *types.Rectangle, mBottom float64) float64 {
+	if t.cont {
+		return r.Height()
+	}
+	_, y := types.NormalizeCoord(t.x, t.y, r, t.pdf.origin, false)
+	if y < 0 {
+		return r.Height()
+	}
+	y -= mBottom
+	return math.Max(0, math.Min(y, r.Height()))
+}
+
+func (t *Table) split() (flowable, error) {
+	bWidth, _, _, err := t.calcBorder()
+	if err != nil {
+		return nil, err
+	}
+
+	mTop, mRight, mBottom, mLeft, err := t.calcMargin()
+	if err != nil {
+		return nil, err
+	}
+
+	r := t.content.Box().CroppedCopy(0)
+	r.LL.X += mLeft
+	r.LL.Y += mBottom
+	r.UR.X -= mRight
+	r.UR.Y -= mTop
+
+	h := math.Min(t.flowTop(r, mBottom)+t.Dy, r.Height()) - 2*bWidth
+	if t.Header != nil {
+		h -= float64(t.LineHeight)
+	}
+	n := int(math.Max(0, math.Floor(h/float64(t.LineHeight))))
+
+	rows := t.Rows
+	if len(t.Values) > rows {
+		rows = len(t.Values)
+	}
+
+	if rows <= n {
+		t.Rows = rows
+		return nil, nil
+	}
+
+	if n == 0 && t.cont {
+		return nil, errors.New("pdfcpu: flowing table does not fit onto continuation page")
+	}
+
+	t1 := *t
+	t1.Rows, t1.row0, t1.cont, t1.Dy = rows-n, t.row0+n, true, 0
+	t1.Values = nil
+	if len(t.Values) > n {
+		t1.Values = t.Values[n:]
+		t.Values = t.Values[:n]
+	}
+
+	if n == 0 {
+		// Start on the next page.
+		t.Hide = true
+	} else {
+		t.Rows = n
+	}
+
+	return &t1, nil
+}
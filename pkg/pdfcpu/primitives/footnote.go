@@ -0,0 +1,233 @@
+/*
+	Copyright 2026 The pdfcpu Authors.
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+package primitives
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/font"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/color"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/draw"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// footnoteRef matches a footnote within text like: "Some statement[^The footnote text.]"
+var footnoteRef = regexp.MustCompile(`\[\^([^\[\]]+)\]`)
+
+// footnoteGap is the space above footnotes holding the separator line.
+const footnoteGap = 8.
+
+// Footnotes configures the rendering of footnotes.
+// Footnotes are defined within the text of page content using [^footnote text].
+// They get numbered throughout the document and are rendered at the bottom of the page holding their reference.
+// Footnotes exceeding MaxHeight continue on the following page.
+type Footnotes struct {
+	pdf       *PDF
+	Font      *FormFont
+	Marker    string  // format of reference and footnote number, defaults to "[%d]"
+	Continued string  `json:"contd"` // prefix for footnotes continued from the previous page, defaults to "(cont'd)"
+	MaxHeight float64 // max share of the content height, defaults to 0.5
+}
+
+// footnote is a numbered footnote or its part continued on the next page.
+type footnote struct {
+	nr    int
+	ref   string // the reference within text
+	text  string
+	contd bool
+}
+
+func (fns *Footnotes) validate() error {
+	if fns.Font == nil {
+		fns.Font = &FormFont{}
+	}
+	if fns.Font.Name == "" {
+		fns.Font.Name = "Helvetica"
+	}
+	if fns.Font.Size == 0 && fns.Font.Name[0] != '$' {
+		fns.Font.Size = 9
+	}
+	fns.Font.pdf = fns.pdf
+	if err := fns.Font.validate(); err != nil {
+		return err
+	}
+
+	if fns.Marker == "" {
+		fns.Marker = "[%d]"
+	}
+	if strings.Count(fns.Marker, "%d") != 1 || strings.Count(fns.Marker, "%") != 1 {
+		return errors.Errorf("pdfcpu: invalid footnote marker: %s", fns.Marker)
+	}
+
+	if fns.Continued == "" {
+		fns.Continued = "(cont'd)"
+	}
+
+	if fns.MaxHeight == 0 {
+		fns.MaxHeight = .5
+	}
+	if fns.MaxHeight < 0 || fns.MaxHeight > 1 {
+		return errors.Errorf("pdfcpu: footnote maxHeight must be in (0,1]: %.2f", fns.MaxHeight)
+	}
+
+	return nil
+}
+
+func (fn *footnote) value(fns *Footnotes) string {
+	if fn.contd {
+		return fns.Continued + " " + fn.text
+	}
+	return fn.ref + " " + fn.text
+}
+
+// fit breaks nn into lines of width w and returns the lines fitting into height h
+// along with the footnotes continuing on the next page.
+func (fns *Footnotes) fit(nn []*footnote, w, h float64) ([]string, []*footnote) {
+	f := fns.Font
+	n := int(math.Max(0, math.Floor((h-footnoteGap)/font.LineHeight(f.Name, f.Size))))
+
+	var lines []string
+
+	for i, fn := range nn {
+		ll := wrapLines(fn.value(fns), f.Name, f.Size, w, nil)
+		if len(lines)+len(ll) <= n {
+			for _, l := range ll {
+				lines = append(lines, strings.Join(l.words, " "))
+			}
+			continue
+		}
+
+		k := n - len(lines)
+		if k <= 0 {
+			return lines, nn[i:]
+		}
+
+		for _, l := range ll[:k] {
+			lines = append(lines, strings.Join(l.words, " "))
+		}
+
+		rest := &footnote{nr: fn.nr, ref: fn.ref, text: flowText(ll[k:], true), contd: true}
+
+		return lines, append([]*footnote{rest}, nn[i+1:]...)
+	}
+
+	return lines, nil
+}
+
+// height returns the height needed for nn limited to maxH.
+func (fns *Footnotes) height(nn []*footnote, w, maxH float64) float64 {
+	if len(nn) == 0 {
+		return 0
+	}
+	f := fns.Font
+	lh := font.LineHeight(f.Name, f.Size)
+	n := 0
+	for _, fn := range nn {
+		n += len(wrapLines(fn.value(fns), f.Name, f.Size, w, nil))
+	}
+	return math.Min(footnoteGap+float64(n)*lh, maxH)
+}
+
+// collectFootnotes replaces footnotes within text of c by numbered references starting after *nr.
+func (c *Content) collectFootnotes(nr *int) {
+	fns := c.page.pdf.Footnotes
+	for _, tb := range c.TextBoxes {
+		if tb.Hide || !strings.Contains(tb.Value, "[^") {
+			continue
+		}
+		tb.Value = footnoteRef.ReplaceAllStringFunc(tb.Value, func(s string) string {
+			*nr++
+			fn := &footnote{
+				nr:   *nr,
+				ref:  fmt.Sprintf(fns.Marker, *nr),
+				text: strings.TrimSpace(footnoteRef.FindStringSubmatch(s)[1]),
+			}
+			tb.footnotes = append(tb.footnotes, fn)
+			return fn.ref
+		})
+	}
+}
+
+// splitFootnotes returns the footnotes referenced within s and the remaining ones.
+func splitFootnotes(nn []*footnote, s string) ([]*footnote, []*footnote) {
+	for i, fn := range nn {
+		j := strings.Index(s, fn.ref)
+		if j < 0 {
+			return nn[:i], nn[i:]
+		}
+		s = s[j+len(fn.ref):]
+	}
+	return nn, nil
+}
+
+// footnoteRect returns the area for footnotes of page within r, the content area between header and footer.
+func (page *PDFPage) footnoteRect(r *types.Rectangle) *types.Rectangle {
+	llx, urx := r.LL.X, r.UR.X
+	if m := page.Content.margin(); m != nil {
+		llx += m.Left
+		urx -= m.Right
+	}
+	return types.NewRectangle(llx, r.LL.Y, urx, r.LL.Y+page.footnoteHeight)
+}
+
+func (page *PDFPage) renderFootnotes(p *model.Page, r *types.Rectangle, pageNr int, fonts model.FontMap) error {
+	if len(page.footnotes) == 0 {
+		return nil
+	}
+
+	pdf := page.pdf
+	f := pdf.Footnotes.Font
+	r = page.footnoteRect(r)
+
+	col := color.Black
+	if f.col != nil {
+		col = *f.col
+	}
+
+	// Separator
+	y := r.UR.Y - footnoteGap/2
+	draw.DrawLine(p.Buf, r.LL.X, y, r.LL.X+r.Width()/3, y, .5, &col, nil)
+
+	id, err := pdf.idForFontName(f.Name, f.Lang, p.Fm, fonts, pageNr)
+	if err != nil {
+		return err
+	}
+
+	td := model.TextDescriptor{
+		Text:      strings.Join(page.footnotes, "\n"),
+		Y:         r.Height() - footnoteGap,
+		HAlign:    types.AlignLeft,
+		VAlign:    types.AlignTop,
+		FontName:  f.Name,
+		Embed:     true,
+		FontKey:   id,
+		FontSize:  f.Size,
+		Scale:     1.,
+		ScaleAbs:  true,
+		StrokeCol: col,
+		FillCol:   col,
+	}
+
+	model.WriteMultiLine(pdf.XRefTable, p.Buf, r, nil, td)
+
+	return nil
+}
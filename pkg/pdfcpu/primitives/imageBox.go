@@ -22,7 +22,6 @@ import (
 	"math"
 	"net"
 	"net/http"
-	"os"
 	"strconv"
 	"strings"
 	"time"
@@ -65,8 +64,11 @@ type ImageBox struct {
 	BackgroundColor string `json:"bgCol"`
 	bgCol           *color.SimpleColor
 	Rotation        float64 `json:"rot"`
-	Url             string
+	Url             string  // external link or "#target" for an internal link
+	Opacity         float64 // 0 < opacity < 1 renders the image box as isolated transparency group
 	Hide            bool
+	Bookmark        *Bookmark
+	Target          string
 	PageNr          string `json:"-"`
 }
 
@@ -93,6 +95,10 @@ func (ib *ImageBox) parseAnchor() (types.Anchor, error) {
 
 func (ib *ImageBox) validate() error {
 
+	if err := validateOpacity(ib.Opacity); err != nil {
+		return err
+	}
+
 	ib.x = ib.Position[0]
 	ib.y = ib.Position[1]
 
@@ -146,7 +152,7 @@ func (ib *ImageBox) validate() error {
 		ib.bgCol = sc
 	}
 
-	return nil
+	return validateNavigation(ib.pdf, ib.Bookmark, ib.Target)
 }
 
 func (ib *ImageBox) margin(name string) *Margin {
@@ -167,6 +173,10 @@ func (ib *ImageBox) missingPosition() bool {
 
 func (ib *ImageBox) mergeIn(ib0 *ImageBox) {
 
+	if ib.Opacity == 0 {
+		ib.Opacity = ib0.Opacity
+	}
+
 	if !ib.anchored && ib.missingPosition() {
 		ib.x = ib0.x
 		ib.y = ib0.y
@@ -300,7 +310,7 @@ func (ib *ImageBox) resource() (io.ReadCloser, error) {
 		f = resp.Body
 	} else {
 		var err error
-		f, err = os.Open(ib.Src)
+		f, err = pdf.Conf.OpenResource(ib.Src)
 		if err != nil {
 			return nil, err
 		}
@@ -396,34 +406,6 @@ func (ib *ImageBox) image(pageImages, images model.ImageMap, pageNr int) (int, i
 	return imgRes.Width, imgRes.Height, imgRes.Res.ID, nil
 }
 
-func (ib *ImageBox) createLink(p *model.Page, pageNr int, r *types.Rectangle, m matrix.Matrix) {
-
-	p1 := m.Transform(types.Point{X: r.LL.X, Y: r.LL.Y})
-	p2 := m.Transform(types.Point{X: r.UR.X, Y: r.LL.X})
-	p3 := m.Transform(types.Point{X: r.UR.X, Y: r.UR.Y})
-	p4 := m.Transform(types.Point{X: r.LL.X, Y: r.UR.Y})
-
-	ql := types.QuadLiteral{P1: p1, P2: p2, P3: p3, P4: p4}
-
-	id := fmt.Sprintf("l%d%d", pageNr, len(p.LinkAnnots))
-	ann := model.NewLinkAnnotation(
-		*ql.EnclosingRectangle(5.0), // rect
-		"",                          // contents
-		id,                          // id
-		"",                          // modDate
-		0,                           // f
-		&color.Red,                  // borderCol
-		nil,                         // dest
-		ib.Url,                      // uri
-		types.QuadPoints{ql},        // quad
-		false,                       // border
-		0,                           // borderWidth
-		model.BSSolid,               // borderStyle
-	)
-
-	p.LinkAnnots = append(p.LinkAnnots, ann)
-}
-
 func (ib *ImageBox) prepareMargin() (float64, float64, float64, float64, error) {
 
 	mTop, mRight, mBot, mLeft := 0., 0., 0., 0.
@@ -642,8 +624,14 @@ func (ib *ImageBox) render(p *model.Page, pageNr int, images model.ImageMap) err
 
 	fmt.Fprintf(p.Buf, "q %.5f %.5f %.5f %.5f %.5f %.5f cm ", m[0][0], m[0][1], m[1][0], m[1][1], m[2][0], m[2][1])
 
-	if ib.Url != "" {
-		ib.createLink(p, pageNr, r, m)
+	if ib.Url != "" || ib.Bookmark != nil || ib.Target != "" {
+		ql := quad(r, m)
+		if ib.Url != "" {
+			ib.pdf.createLink(p, pageNr, ql, ib.Url)
+		}
+		if err := ib.pdf.recordNavigation(pageNr, ql, ib.Bookmark, "", ib.Target); err != nil {
+			return err
+		}
 	}
 
 	// Render border
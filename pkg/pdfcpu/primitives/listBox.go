@@ -54,6 +54,8 @@ type ListBox struct {
 	fontID          string
 	Margin          *Margin
 	Border          *Border
+	BorderStyle     string `json:"borderStyle"` // "solid", "dashed", "beveled", "inset", "underline"
+	borderStyle     model.BorderStyle
 	BackgroundColor string             `json:"bgCol"`
 	BgCol           *color.SimpleColor `json:"-"`
 	Alignment       string             `json:"align"` // "Left", "Center", "Right"
@@ -61,6 +63,7 @@ type ListBox struct {
 	RTL             bool
 	Tab             int
 	Locked          bool
+	Required        bool
 	Debug           bool
 	Hide            bool
 }
@@ -194,6 +197,11 @@ func (lb *ListBox) validateBorder() error {
 			return err
 		}
 	}
+	bs, err := parseFieldBorderStyle(lb.BorderStyle)
+	if err != nil {
+		return err
+	}
+	lb.borderStyle = bs
 	return nil
 }
 
@@ -453,7 +461,7 @@ func (lb *ListBox) renderN(xRefTable *model.XRefTable) ([]byte, error) {
 		if bgCol != nil {
 			fmt.Fprintf(buf, "%.2f %.2f %.2f rg 0 0 %.2f %.2f re f ", bgCol.R, bgCol.G, bgCol.B, w, h)
 		}
-		if boCol != nil {
+		if boCol != nil && lb.borderStyle == model.BSSolid {
 			fmt.Fprintf(buf, "%.2f %.2f %.2f RG %.2f w %.2f %.2f %.2f %.2f re s ",
 				boCol.R, boCol.G, boCol.B, boWidth, boWidth/2, boWidth/2, w-boWidth, h-boWidth)
 		}
@@ -507,7 +515,9 @@ func (lb *ListBox) renderN(xRefTable *model.XRefTable) ([]byte, error) {
 
 	fmt.Fprint(buf, "Q EMC ")
 
-	if boCol != nil {
+	if boCol != nil && boWidth > 0 && lb.borderStyle != model.BSSolid {
+		renderFieldBorder(buf, lb.borderStyle, boCol, boWidth, w, h)
+	} else if boCol != nil {
 		fmt.Fprintf(buf, "q %.2f %.2f %.2f RG %.2f w %.2f %.2f %.2f %.2f re s Q ",
 			boCol.R, boCol.G, boCol.B, boWidth, boWidth/2, boWidth/2, w-boWidth, h-boWidth)
 	}
@@ -574,6 +584,9 @@ func (lb *ListBox) prepareFF() FieldFlags {
 	if lb.Locked {
 		ff += FieldReadOnly
 	}
+	if lb.Required {
+		ff += FieldRequired
+	}
 	return ff
 }
 
@@ -602,6 +615,9 @@ func (lb *ListBox) handleBorderAndMK(d types.Dict) {
 
 	if boWidth > 0 {
 		d["Border"] = types.NewNumberArray(0, 0, boWidth)
+		if lb.borderStyle != model.BSSolid {
+			d["BS"] = fieldBorderStyleDict(boWidth, lb.borderStyle)
+		}
 	}
 }
 
@@ -890,10 +906,7 @@ func NewListBox(
 		return nil, nil, err
 	}
 
-	lb.HorAlign = types.AlignLeft
-	if q := d.IntEntry("Q"); q != nil {
-		lb.HorAlign = types.HAlignment(*q)
-	}
+	lb.HorAlign = horAlign(ctx, d, "")
 
 	bgCol, boCol, err := calcColsFromMK(ctx, d)
 	if err != nil {
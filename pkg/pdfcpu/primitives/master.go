@@ -0,0 +1,196 @@
+/*
+	Copyright 2026 The pdfcpu Authors.
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+package primitives
+
+import (
+	"math"
+	"sort"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// PageMaster describes the common layout of a range of pages.
+// Paper, crop box and background color apply to pages not defining their own.
+// Header and footer replace the global header and footer.
+// The content margin, border, padding and background color serve as defaults for page content
+// and any content elements get rendered on each page underneath the page content.
+type PageMaster struct {
+	pdf             *PDF
+	Paper           string
+	Crop            string
+	BackgroundColor string `json:"bgCol"`
+	Header          *HorizontalBand
+	Footer          *HorizontalBand
+	Content         *Content
+	From            int // first page, defaults to 1
+	Thru            int // last page, defaults to the last page
+	page            *PDFPage
+}
+
+func (m *PageMaster) validate() error {
+	pdf := m.pdf
+
+	if m.From < 0 || m.Thru < 0 || (m.Thru > 0 && m.Thru < m.From) {
+		return errors.Errorf("pdfcpu: invalid page master range: %d-%d", m.From, m.Thru)
+	}
+
+	if m.Header != nil {
+		m.Header.pdf = pdf
+		if err := m.Header.validate(); err != nil {
+			return err
+		}
+		m.Header.position = types.TopCenter
+	}
+
+	if m.Footer != nil {
+		m.Footer.pdf = pdf
+		if err := m.Footer.validate(); err != nil {
+			return err
+		}
+		m.Footer.position = types.BottomCenter
+	}
+
+	c := m.Content
+	if c == nil {
+		c = &Content{}
+	}
+
+	m.page = &PDFPage{
+		pdf:             pdf,
+		Paper:           m.Paper,
+		Crop:            m.Crop,
+		BackgroundColor: m.BackgroundColor,
+		Content:         c,
+	}
+
+	return m.page.validate()
+}
+
+func (m *PageMaster) pageRange() (int, int) {
+	from, thru := m.From, m.Thru
+	if from == 0 {
+		from = 1
+	}
+	if thru == 0 {
+		thru = math.MaxInt32
+	}
+	return from, thru
+}
+
+func (m *PageMaster) applies(pageNr int) bool {
+	from, thru := m.pageRange()
+	return pageNr >= from && pageNr <= thru
+}
+
+// render renders the content elements of m into r.
+func (m *PageMaster) render(p *model.Page, r *types.Rectangle, pageNr int, fonts model.FontMap, images model.ImageMap) error {
+	c := m.page.Content
+	c.mediaBox, c.borderRect, c.box = r, nil, nil
+	return c.renderPrimitives(p, pageNr, fonts, images)
+}
+
+func (pdf *PDF) validateMasters() error {
+	var names []string
+	for name, m := range pdf.Masters {
+		m.pdf = pdf
+		if err := m.validate(); err != nil {
+			return err
+		}
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	// Page ranges must not overlap.
+	for i, n1 := range names {
+		from1, thru1 := pdf.Masters[n1].pageRange()
+		for _, n2 := range names[i+1:] {
+			from2, thru2 := pdf.Masters[n2].pageRange()
+			if from1 <= thru2 && from2 <= thru1 {
+				return errors.Errorf("pdfcpu: overlapping page masters: %s, %s", n1, n2)
+			}
+		}
+	}
+
+	if pdf.Overflow != nil {
+		pdf.Overflow.pdf = pdf
+		return pdf.Overflow.validate()
+	}
+
+	return nil
+}
+
+// master returns the page master for page pageNr.
+func (pdf *PDF) master(pageNr int) *PageMaster {
+	for _, m := range pdf.Masters {
+		if m.applies(pageNr) {
+			return m
+		}
+	}
+	return nil
+}
+
+// applyMaster makes m the page master of page.
+func (page *PDFPage) applyMaster(m *PageMaster) {
+	if m == nil {
+		return
+	}
+
+	page.master = m
+	mp := m.page
+
+	if page.Paper == "" && page.Crop == "" && (m.Paper != "" || m.Crop != "") {
+		page.mediaBox, page.cropBox = mp.mediaBox, mp.cropBox
+	}
+
+	if page.bgCol == nil && page.bgPaint == nil {
+		page.bgCol, page.bgPaint = mp.bgCol, mp.bgPaint
+	}
+
+	if page.Content.bgCol == nil {
+		page.Content.bgCol = mp.Content.bgCol
+	}
+}
+
+// bands returns header and footer for pages using m.
+func (pdf *PDF) bands(m *PageMaster) (*HorizontalBand, *HorizontalBand) {
+	header, footer := pdf.Header, pdf.Footer
+	if m != nil {
+		if m.Header != nil {
+			header = m.Header
+		}
+		if m.Footer != nil {
+			footer = m.Footer
+		}
+	}
+	return header, footer
+}
+
+// contentRect returns the area of cropBox between header and footer.
+func (pdf *PDF) contentRect(cropBox *types.Rectangle, m *PageMaster) *types.Rectangle {
+	header, footer := pdf.bands(m)
+	r := cropBox.CroppedCopy(0)
+	if footer != nil {
+		r.LL.Y += footer.Height + float64(footer.Dy)
+	}
+	if header != nil {
+		r.UR.Y -= header.Height + float64(header.Dy)
+	}
+	return r
+}
@@ -0,0 +1,185 @@
+/*
+	Copyright 2026 The pdfcpu Authors.
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+package primitives
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/color"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/matrix"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// Bookmark declares an outline entry for the element it belongs to.
+type Bookmark struct {
+	Title  string // defaults to the text of a text box
+	Level  int    // nesting level starting at 1, defaults to 1
+	Bold   bool
+	Italic bool
+	Color  string `json:"col"`
+	col    *color.SimpleColor
+}
+
+// OutlineItem is a bookmark rendered on page PageNr with its element starting at Top.
+type OutlineItem struct {
+	Title  string
+	Level  int
+	Bold   bool
+	Italic bool
+	Color  *color.SimpleColor
+	PageNr int
+	Top    float64
+}
+
+// Target is a named destination at the upper left corner of a rendered element.
+// Internal links refer to targets using urls like "#name".
+type Target struct {
+	PageNr int
+	Left   float64
+	Top    float64
+}
+
+func (bm *Bookmark) validate(pdf *PDF) error {
+	if bm.Level == 0 {
+		bm.Level = 1
+	}
+	if bm.Level < 0 {
+		return errors.Errorf("pdfcpu: invalid bookmark level: %d", bm.Level)
+	}
+	if bm.Color != "" {
+		sc, err := pdf.parseColor(bm.Color)
+		if err != nil {
+			return err
+		}
+		bm.col = sc
+	}
+	return nil
+}
+
+func validateNavigation(pdf *PDF, bm *Bookmark, target string) error {
+	if bm != nil {
+		if err := bm.validate(pdf); err != nil {
+			return err
+		}
+	}
+	if strings.HasPrefix(target, "#") {
+		return errors.Errorf("pdfcpu: target name must not start with '#': %s", target)
+	}
+	return nil
+}
+
+// internalLink returns the target name of an internal link like "#chapter1".
+func internalLink(url string) (string, bool) {
+	if len(url) > 1 && url[0] == '#' {
+		return url[1:], true
+	}
+	return "", false
+}
+
+// quad returns the area covered by r transformed by m.
+func quad(r *types.Rectangle, m matrix.Matrix) types.QuadLiteral {
+	return types.QuadLiteral{
+		P1: m.Transform(types.Point{X: r.LL.X, Y: r.LL.Y}),
+		P2: m.Transform(types.Point{X: r.UR.X, Y: r.LL.Y}),
+		P3: m.Transform(types.Point{X: r.UR.X, Y: r.UR.Y}),
+		P4: m.Transform(types.Point{X: r.LL.X, Y: r.UR.Y}),
+	}
+}
+
+// createLink adds a link annotation for ql pointing to url or to a named target for urls like "#name".
+func (pdf *PDF) createLink(p *model.Page, pageNr int, ql types.QuadLiteral, url string) {
+	id := fmt.Sprintf("l%d%d", pageNr, len(p.LinkAnnots))
+	ann := model.NewLinkAnnotation(
+		*ql.EnclosingRectangle(5.0), // rect
+		"",                          // contents
+		id,                          // id
+		"",                          // modDate
+		0,                           // f
+		&color.Red,                  // borderCol
+		nil,                         // dest
+		url,                         // uri
+		types.QuadPoints{ql},        // quad
+		false,                       // border
+		0,                           // borderWidth
+		model.BSSolid,               // borderStyle
+	)
+
+	if name, ok := internalLink(url); ok {
+		ann.URI, ann.DestName = "", name
+		if pdf.links == nil {
+			pdf.links = types.StringSet{}
+		}
+		pdf.links[name] = true
+	}
+
+	p.LinkAnnots = append(p.LinkAnnots, ann)
+}
+
+// recordNavigation records the outline item and target of an element covering ql on page pageNr.
+func (pdf *PDF) recordNavigation(pageNr int, ql types.QuadLiteral, bm *Bookmark, title, target string) error {
+	r := ql.EnclosingRectangle(0)
+
+	if bm != nil {
+		if bm.Title != "" {
+			title = bm.Title
+		}
+		title = strings.Join(strings.Fields(title), " ")
+		if title == "" {
+			return errors.New("pdfcpu: bookmark: missing \"title\"")
+		}
+		pdf.Outline = append(pdf.Outline, &OutlineItem{
+			Title:  title,
+			Level:  bm.Level,
+			Bold:   bm.Bold,
+			Italic: bm.Italic,
+			Color:  bm.col,
+			PageNr: pageNr,
+			Top:    r.UR.Y,
+		})
+	}
+
+	if target != "" {
+		if pdf.Targets == nil {
+			pdf.Targets = map[string]*Target{}
+		}
+		// The first occurrence wins, eg. for elements repeated by page masters.
+		if _, ok := pdf.Targets[target]; !ok {
+			pdf.Targets[target] = &Target{PageNr: pageNr, Left: r.LL.X, Top: r.UR.Y}
+		}
+	}
+
+	return nil
+}
+
+// checkLinks ensures all internal links point to a rendered target.
+func (pdf *PDF) checkLinks() error {
+	var names []string
+	for name := range pdf.links {
+		if _, ok := pdf.Targets[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	if len(names) > 0 {
+		sort.Strings(names)
+		return errors.Errorf("pdfcpu: unknown link target: %s", strings.Join(names, ", "))
+	}
+	return nil
+}
@@ -36,6 +36,7 @@ type PDFPage struct {
 	cropBox         *types.Rectangle     // page crop box
 	BackgroundColor string               `json:"bgCol"`
 	bgCol           *color.SimpleColor   // page background color
+	bgPaint         paint                // page background gradient or pattern
 	Fonts           map[string]*FormFont // default fonts
 	DA              types.Object
 	Guides          []*Guide               // hor/vert guidelines for layout
@@ -52,7 +53,12 @@ type PDFPage struct {
 	FieldGroupPool  map[string]*FieldGroup `json:"fieldgroups"`
 	FileNames       map[string]string      `json:"files"`
 	Tabs            types.IntSet           `json:"-"`
+	TabOrder        string                 `json:"tabs"` // tab order of form fields: "row", "column", "structure"
+	tabs            string
 	Content         *Content
+	master          *PageMaster // layout applied to this page
+	footnotes       []string    // footnote lines rendered at the bottom of the page
+	footnoteHeight  float64
 }
 
 func (page *PDFPage) resolveFileName(s string) (string, error) {
@@ -108,11 +114,11 @@ func (page *PDFPage) validatePageBoundaries() error {
 func (page *PDFPage) validateBackgroundColor() error {
 	// Default background color
 	if page.BackgroundColor != "" {
-		sc, err := page.pdf.parseColor(page.BackgroundColor)
+		sc, pt, err := page.pdf.parsePaint(page.BackgroundColor)
 		if err != nil {
 			return err
 		}
-		page.bgCol = sc
+		page.bgCol, page.bgPaint = sc, pt
 	}
 	return nil
 }
@@ -292,6 +298,12 @@ func (page *PDFPage) validate() error {
 		return err
 	}
 
+	tabs, err := parseTabOrder(page.TabOrder)
+	if err != nil {
+		return err
+	}
+	page.tabs = tabs
+
 	if page.Content == nil {
 		return errors.New("pdfcpu: Please supply page \"content\"")
 	}
@@ -314,6 +326,12 @@ func (page *PDFPage) namedMargin(id string) *Margin {
 	if m != nil {
 		return m
 	}
+	if page.master != nil {
+		// Page masters provide default content layout.
+		if m := page.master.page.Content.Margins[id]; m != nil {
+			return m
+		}
+	}
 	return page.pdf.Margins[id]
 }
 
@@ -322,6 +340,12 @@ func (page *PDFPage) namedBorder(id string) *Border {
 	if b != nil {
 		return b
 	}
+	if page.master != nil {
+		// Page masters provide default content layout.
+		if b := page.master.page.Content.Borders[id]; b != nil {
+			return b
+		}
+	}
 	return page.pdf.Borders[id]
 }
 
@@ -330,6 +354,12 @@ func (page *PDFPage) namedPadding(id string) *Padding {
 	if p != nil {
 		return p
 	}
+	if page.master != nil {
+		// Page masters provide default content layout.
+		if p := page.master.page.Content.Paddings[id]; p != nil {
+			return p
+		}
+	}
 	return page.pdf.Paddings[id]
 }
 
@@ -0,0 +1,209 @@
+/*
+	Copyright 2026 The pdfcpu Authors.
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+package primitives
+
+import (
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/color"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/draw"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/matrix"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// paint is a gradient or pattern referenced by "$name" wherever a color is expected.
+type paint interface {
+	pattern(xRefTable *model.XRefTable, r *types.Rectangle, m matrix.Matrix) (*types.IndirectRef, error)
+}
+
+// Gradient is a named axial or radial color gradient.
+type Gradient struct {
+	pdf    *PDF
+	Type   string   // axial (default), radial
+	Colors []string `json:"cols"`
+	Angle  float64  // direction of axial gradients, 0 = left to right, 90 = bottom to top
+	g      model.Gradient
+}
+
+func (g *Gradient) validate() error {
+	switch strings.ToLower(g.Type) {
+	case "", "axial":
+	case "radial":
+		g.g.Radial = true
+	default:
+		return errors.Errorf("pdfcpu: invalid gradient type: %s (should be \"axial\" or \"radial\")", g.Type)
+	}
+
+	if len(g.Colors) < 2 {
+		return errors.New("pdfcpu: gradient needs at least 2 colors")
+	}
+
+	for _, s := range g.Colors {
+		sc, err := g.pdf.parseColor(s)
+		if err != nil {
+			return err
+		}
+		g.g.Colors = append(g.g.Colors, *sc)
+	}
+
+	g.g.Angle = g.Angle
+
+	return nil
+}
+
+func (g *Gradient) pattern(xRefTable *model.XRefTable, r *types.Rectangle, m matrix.Matrix) (*types.IndirectRef, error) {
+	return model.NewShadingPattern(xRefTable, g.g, r, m)
+}
+
+// Pattern is a named tiling pattern.
+type Pattern struct {
+	pdf             *PDF
+	Type            string // stripes, grid, dots, checker
+	Color           string `json:"col"`
+	BackgroundColor string `json:"bgCol"`
+	Size            float64
+	Width           float64 // line width or dot diameter
+	Rotation        float64 `json:"rot"`
+	tp              model.TilingPattern
+}
+
+func (pa *Pattern) validate() error {
+	switch strings.ToLower(pa.Type) {
+	case "stripes":
+		pa.tp.Style = model.TilingStripes
+	case "grid":
+		pa.tp.Style = model.TilingGrid
+	case "dots":
+		pa.tp.Style = model.TilingDots
+	case "checker":
+		pa.tp.Style = model.TilingChecker
+	default:
+		return errors.Errorf("pdfcpu: invalid pattern type: %s (should be \"stripes\", \"grid\", \"dots\" or \"checker\")", pa.Type)
+	}
+
+	pa.tp.Color = color.Black
+	if pa.Color != "" {
+		sc, err := pa.pdf.parseColor(pa.Color)
+		if err != nil {
+			return err
+		}
+		pa.tp.Color = *sc
+	}
+
+	if pa.BackgroundColor != "" {
+		sc, err := pa.pdf.parseColor(pa.BackgroundColor)
+		if err != nil {
+			return err
+		}
+		pa.tp.BgColor = sc
+	}
+
+	if pa.Size < 0 || pa.Width < 0 {
+		return errors.New("pdfcpu: pattern size and width must not be negative")
+	}
+
+	pa.tp.Size = pa.Size
+	if pa.tp.Size == 0 {
+		pa.tp.Size = 10
+	}
+
+	pa.tp.Width = pa.Width
+	if pa.tp.Width == 0 {
+		pa.tp.Width = pa.tp.Size / 4
+	}
+
+	if pa.tp.Width > pa.tp.Size {
+		return errors.New("pdfcpu: pattern width must not exceed pattern size")
+	}
+
+	pa.tp.Rotation = pa.Rotation
+
+	return nil
+}
+
+func (pa *Pattern) pattern(xRefTable *model.XRefTable, r *types.Rectangle, m matrix.Matrix) (*types.IndirectRef, error) {
+	return model.NewTilingPattern(xRefTable, pa.tp, r, m)
+}
+
+func (pdf *PDF) validatePaints() error {
+	pdf.paints = map[string]paint{}
+
+	for n, g := range pdf.Gradients {
+		g.pdf = pdf
+		if err := g.validate(); err != nil {
+			return err
+		}
+		pdf.paints[strings.ToLower(n)] = g
+	}
+
+	for n, pa := range pdf.Patterns {
+		pa.pdf = pdf
+		if err := pa.validate(); err != nil {
+			return err
+		}
+		if _, ok := pdf.paints[strings.ToLower(n)]; ok {
+			return errors.Errorf("pdfcpu: duplicate gradient/pattern name: %s", n)
+		}
+		pdf.paints[strings.ToLower(n)] = pa
+	}
+
+	return nil
+}
+
+// parsePaint resolves s to a named gradient or pattern, or else to a color.
+func (pdf *PDF) parsePaint(s string) (*color.SimpleColor, paint, error) {
+	if len(s) > 1 && s[0] == '$' {
+		if pt, ok := pdf.paints[strings.ToLower(s[1:])]; ok {
+			return nil, pt, nil
+		}
+	}
+	sc, err := pdf.parseColor(s)
+	return sc, nil, err
+}
+
+// patternID creates a pattern for pt covering r painted under transformation m and returns its page resource id.
+func (pdf *PDF) patternID(p *model.Page, pt paint, r *types.Rectangle, m matrix.Matrix) (string, error) {
+	indRef, err := pt.pattern(pdf.XRefTable, r, m)
+	if err != nil {
+		return "", err
+	}
+	return p.AddPattern(*indRef), nil
+}
+
+// fillRect fills r using pt.
+func (pdf *PDF) fillRect(p *model.Page, r *types.Rectangle, m matrix.Matrix, pt paint) error {
+	id, err := pdf.patternID(p, pt, r, m)
+	if err != nil {
+		return err
+	}
+	draw.FillRectWithPattern(p.Buf, r, id)
+	return nil
+}
+
+// drawRect strokes r using lineWidth, pt and style.
+func (pdf *PDF) drawRect(p *model.Page, r *types.Rectangle, m matrix.Matrix, lineWidth float64, pt paint, style *types.LineJoinStyle) error {
+	// Cover the stroke.
+	r1 := r.CroppedCopy(-lineWidth / 2)
+	id, err := pdf.patternID(p, pt, r1, m)
+	if err != nil {
+		return err
+	}
+	draw.DrawRectWithPattern(p.Buf, r, lineWidth, id, style)
+	return nil
+}
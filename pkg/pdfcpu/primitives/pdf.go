@@ -28,6 +28,7 @@ import (
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/color"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/draw"
 	pdffont "github.com/pdfcpu/pdfcpu/pkg/pdfcpu/font"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/matrix"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
 	"github.com/pkg/errors"
@@ -80,13 +81,22 @@ type PDF struct {
 	Debug           bool                 // highlight element positions
 	BackgroundColor string               `json:"bgCol"`
 	bgCol           *color.SimpleColor   // default background color
+	bgPaint         paint                // default background gradient or pattern
 	Fonts           map[string]*FormFont // global fonts
 	FormFonts       map[string]*FormFont
 	FieldIDs        types.StringSet
 	Fields          types.Array
 	InheritedDA     string
+	TabOrder        string `json:"tabs"` // default tab order of form fields: "row", "column", "structure"
+	tabs            string
+	Lang            string // natural language of the document, eg. "en-US"
+	Title           string // document title
+	DisplayDocTitle bool   // PDF viewers display the title instead of the file name
 	Header          *HorizontalBand
 	Footer          *HorizontalBand
+	Masters         map[string]*PageMaster // layouts of page ranges
+	Overflow        *PageMaster            // layout of pages appended for flowing content
+	Footnotes       *Footnotes             // layout of footnotes
 	Pages           map[string]*PDFPage
 	pages           []*PDFPage
 	Margin          *Margin                // the global margin named "margin"
@@ -102,6 +112,9 @@ type PDF struct {
 	FieldGroupPool  map[string]*FieldGroup `json:"fieldgroups"`
 	Colors          map[string]string
 	colors          map[string]color.SimpleColor
+	Gradients       map[string]*Gradient // named gradients
+	Patterns        map[string]*Pattern  // named tiling patterns
+	paints          map[string]paint
 	DirNames        map[string]string          `json:"dirs"`
 	FileNames       map[string]string          `json:"files"`
 	TimestampFormat string                     `json:"timestamp"`
@@ -115,6 +128,9 @@ type PDF struct {
 	RadioBtnAPs     map[float64]*AP            `json:"-"`
 	HasForm         bool                       `json:"-"`
 	OldFieldIDs     types.StringSet            `json:"-"`
+	Outline         []*OutlineItem             `json:"-"` // rendered bookmarks in page order
+	Targets         map[string]*Target         `json:"-"` // rendered link targets
+	links           types.StringSet            // names of targets referenced by internal links
 	Offline         bool                       `json:"-"`
 	Timeout         int                        `json:"-"`
 	httpClient      *http.Client
@@ -231,13 +247,18 @@ func (pdf *PDF) validateColors() error {
 		pdf.colors[strings.ToLower(n)] = sc
 	}
 
+	// Custom gradients and patterns
+	if err := pdf.validatePaints(); err != nil {
+		return err
+	}
+
 	// Default background color
 	if pdf.BackgroundColor != "" {
-		sc, err := pdf.parseColor(pdf.BackgroundColor)
+		sc, pt, err := pdf.parsePaint(pdf.BackgroundColor)
 		if err != nil {
 			return err
 		}
-		pdf.bgCol = sc
+		pdf.bgCol, pdf.bgPaint = sc, pt
 	}
 	return nil
 }
@@ -255,22 +276,22 @@ func (pdf *PDF) validateFonts() error {
 
 func (pdf *PDF) validateHeader() error {
 	if pdf.Header != nil {
+		pdf.Header.pdf = pdf
 		if err := pdf.Header.validate(); err != nil {
 			return err
 		}
 		pdf.Header.position = types.TopCenter
-		pdf.Header.pdf = pdf
 	}
 	return nil
 }
 
 func (pdf *PDF) validateFooter() error {
 	if pdf.Footer != nil {
+		pdf.Footer.pdf = pdf
 		if err := pdf.Footer.validate(); err != nil {
 			return err
 		}
 		pdf.Footer.position = types.BottomCenter
-		pdf.Footer.pdf = pdf
 	}
 	return nil
 }
@@ -442,6 +463,28 @@ func (pdf *PDF) Validate() error {
 		return err
 	}
 
+	if err := pdf.validateMasters(); err != nil {
+		return err
+	}
+
+	tabs, err := parseTabOrder(pdf.TabOrder)
+	if err != nil {
+		return err
+	}
+	pdf.tabs = tabs
+
+	if pdf.Lang != "" && !types.ValidLanguageTag(pdf.Lang) {
+		return errors.Errorf("pdfcpu: invalid document language: %s", pdf.Lang)
+	}
+
+	if pdf.Footnotes == nil {
+		pdf.Footnotes = &Footnotes{}
+	}
+	pdf.Footnotes.pdf = pdf
+	if err := pdf.Footnotes.validate(); err != nil {
+		return err
+	}
+
 	if pdf.TimestampFormat == "" {
 		pdf.TimestampFormat = pdf.Conf.TimestampFormat
 	}
@@ -753,7 +796,7 @@ func (pdf *PDF) calcTopLevelFonts() {
 
 func (pdf *PDF) calcInheritedPageFonts() {
 	for id, f0 := range pdf.Fonts {
-		for _, page := range pdf.pages {
+		for _, page := range pdf.inheritingPages() {
 			if page == nil {
 				continue
 			}
@@ -766,7 +809,7 @@ func (pdf *PDF) calcInheritedPageFonts() {
 }
 
 func (pdf *PDF) calcInheritedContentFonts() {
-	for _, page := range pdf.pages {
+	for _, page := range pdf.inheritingPages() {
 		if page == nil {
 			continue
 		}
@@ -790,7 +833,7 @@ func (pdf *PDF) calcInheritedFonts() {
 func (pdf *PDF) calcInheritedMargins() {
 	// Calc inherited margins.
 	for id, m0 := range pdf.Margins {
-		for _, page := range pdf.pages {
+		for _, page := range pdf.inheritingPages() {
 			if page == nil {
 				continue
 			}
@@ -800,7 +843,7 @@ func (pdf *PDF) calcInheritedMargins() {
 			}
 		}
 	}
-	for _, page := range pdf.pages {
+	for _, page := range pdf.inheritingPages() {
 		if page == nil {
 			continue
 		}
@@ -818,7 +861,7 @@ func (pdf *PDF) calcInheritedMargins() {
 func (pdf *PDF) calcInheritedBorders() {
 	// Calc inherited borders.
 	for id, b0 := range pdf.Borders {
-		for _, page := range pdf.pages {
+		for _, page := range pdf.inheritingPages() {
 			if page == nil {
 				continue
 			}
@@ -828,7 +871,7 @@ func (pdf *PDF) calcInheritedBorders() {
 			}
 		}
 	}
-	for _, page := range pdf.pages {
+	for _, page := range pdf.inheritingPages() {
 		if page == nil {
 			continue
 		}
@@ -846,7 +889,7 @@ func (pdf *PDF) calcInheritedBorders() {
 func (pdf *PDF) calcInheritedPaddings() {
 	// Calc inherited paddings.
 	for id, p0 := range pdf.Paddings {
-		for _, page := range pdf.pages {
+		for _, page := range pdf.inheritingPages() {
 			if page == nil {
 				continue
 			}
@@ -856,7 +899,7 @@ func (pdf *PDF) calcInheritedPaddings() {
 			}
 		}
 	}
-	for _, page := range pdf.pages {
+	for _, page := range pdf.inheritingPages() {
 		if page == nil {
 			continue
 		}
@@ -874,7 +917,7 @@ func (pdf *PDF) calcInheritedPaddings() {
 func (pdf *PDF) calcInheritedSimpleBoxes() {
 	// Calc inherited SimpleBoxes.
 	for id, sb0 := range pdf.SimpleBoxPool {
-		for _, page := range pdf.pages {
+		for _, page := range pdf.inheritingPages() {
 			if page == nil {
 				continue
 			}
@@ -884,7 +927,7 @@ func (pdf *PDF) calcInheritedSimpleBoxes() {
 			}
 		}
 	}
-	for _, page := range pdf.pages {
+	for _, page := range pdf.inheritingPages() {
 		if page == nil {
 			continue
 		}
@@ -902,7 +945,7 @@ func (pdf *PDF) calcInheritedSimpleBoxes() {
 func (pdf *PDF) calcInheritedTextBoxes() {
 	// Calc inherited TextBoxes.
 	for id, tb0 := range pdf.TextBoxPool {
-		for _, page := range pdf.pages {
+		for _, page := range pdf.inheritingPages() {
 			if page == nil {
 				continue
 			}
@@ -912,7 +955,7 @@ func (pdf *PDF) calcInheritedTextBoxes() {
 			}
 		}
 	}
-	for _, page := range pdf.pages {
+	for _, page := range pdf.inheritingPages() {
 		if page == nil {
 			continue
 		}
@@ -930,7 +973,7 @@ func (pdf *PDF) calcInheritedTextBoxes() {
 func (pdf *PDF) calcInheritedImageBoxes() {
 	// Calc inherited ImageBoxes.
 	for id, ib0 := range pdf.ImageBoxPool {
-		for _, page := range pdf.pages {
+		for _, page := range pdf.inheritingPages() {
 			if page == nil {
 				continue
 			}
@@ -940,7 +983,7 @@ func (pdf *PDF) calcInheritedImageBoxes() {
 			}
 		}
 	}
-	for _, page := range pdf.pages {
+	for _, page := range pdf.inheritingPages() {
 		if page == nil {
 			continue
 		}
@@ -958,7 +1001,7 @@ func (pdf *PDF) calcInheritedImageBoxes() {
 func (pdf *PDF) calcInheritedTables() {
 	// Calc inherited Tables.
 	for id, t0 := range pdf.TablePool {
-		for _, page := range pdf.pages {
+		for _, page := range pdf.inheritingPages() {
 			if page == nil {
 				continue
 			}
@@ -968,7 +1011,7 @@ func (pdf *PDF) calcInheritedTables() {
 			}
 		}
 	}
-	for _, page := range pdf.pages {
+	for _, page := range pdf.inheritingPages() {
 		if page == nil {
 			continue
 		}
@@ -986,7 +1029,7 @@ func (pdf *PDF) calcInheritedTables() {
 func (pdf *PDF) calcInheritedFieldGroups() {
 	// Calc inherited field groups.
 	for id, fg0 := range pdf.FieldGroupPool {
-		for _, page := range pdf.pages {
+		for _, page := range pdf.inheritingPages() {
 			if page == nil {
 				continue
 			}
@@ -996,7 +1039,7 @@ func (pdf *PDF) calcInheritedFieldGroups() {
 			}
 		}
 	}
-	for _, page := range pdf.pages {
+	for _, page := range pdf.inheritingPages() {
 		if page == nil {
 			continue
 		}
@@ -1011,6 +1054,22 @@ func (pdf *PDF) calcInheritedFieldGroups() {
 	}
 }
 
+// inheritingPages returns all pages including the pages of page masters.
+func (pdf *PDF) inheritingPages() []*PDFPage {
+	pp := pdf.pages
+	if len(pdf.Masters) == 0 && pdf.Overflow == nil {
+		return pp
+	}
+	pp = append([]*PDFPage{}, pp...)
+	for _, m := range pdf.Masters {
+		pp = append(pp, m.page)
+	}
+	if pdf.Overflow != nil {
+		pp = append(pp, pdf.Overflow.page)
+	}
+	return pp
+}
+
 func (pdf *PDF) calcInheritedAttrs() {
 	pdf.calcInheritedFonts()
 	pdf.calcInheritedMargins()
@@ -1028,13 +1087,14 @@ func (pdf *PDF) highlightPos(w io.Writer, x, y float64, cBox *types.Rectangle) {
 	draw.DrawHairCross(w, x, y, cBox)
 }
 
-func (pdf *PDF) renderPageBackground(page *PDFPage, w io.Writer) {
-	if page.bgCol == nil {
-		page.bgCol = pdf.bgCol
+func (pdf *PDF) renderPageBackground(p *model.Page, r *types.Rectangle, bgCol *color.SimpleColor, bgPaint paint) error {
+	if bgPaint != nil {
+		return pdf.fillRect(p, r, matrix.IdentMatrix, bgPaint)
 	}
-	if page.bgCol != nil {
-		draw.FillRectNoBorder(w, page.cropBox, *page.bgCol)
+	if bgCol != nil {
+		draw.FillRectNoBorder(p.Buf, r, *bgCol)
 	}
+	return nil
 }
 
 func (pdf *PDF) newModelPageforPDFPage(page *PDFPage) model.Page {
@@ -1048,7 +1108,55 @@ func (pdf *PDF) newModelPageforPDFPage(page *PDFPage) model.Page {
 		cropBox = page.cropBox
 	}
 
-	return model.NewPage(mediaBox, cropBox)
+	p := model.NewPage(mediaBox, cropBox)
+
+	p.Tabs = pdf.tabs
+	if page != nil && page.tabs != "" {
+		p.Tabs = page.tabs
+	}
+
+	return p
+}
+
+func (pdf *PDF) renderBlankPage(p *model.Page, pageNr int, fonts model.FontMap, images model.ImageMap) error {
+	m := pdf.master(pageNr)
+
+	bgCol, bgPaint := pdf.bgCol, pdf.bgPaint
+	if m != nil && (m.page.bgCol != nil || m.page.bgPaint != nil) {
+		bgCol, bgPaint = m.page.bgCol, m.page.bgPaint
+	}
+	if err := pdf.renderPageBackground(p, p.CropBox, bgCol, bgPaint); err != nil {
+		return err
+	}
+
+	return pdf.renderBands(p, p.CropBox, m, pageNr, fonts, images)
+}
+
+// renderBands renders master content, header and footer of a page.
+func (pdf *PDF) renderBands(p *model.Page, cropBox *types.Rectangle, m *PageMaster, pageNr int, fonts model.FontMap, images model.ImageMap) error {
+	if m != nil {
+		if err := m.render(p, pdf.contentRect(cropBox, m), pageNr, fonts, images); err != nil {
+			return err
+		}
+	}
+
+	header, footer := pdf.bands(m)
+
+	// Render page header.
+	if header != nil {
+		if err := header.render(p, pageNr, fonts, images, true); err != nil {
+			return err
+		}
+	}
+
+	// Render page footer.
+	if footer != nil {
+		if err := footer.render(p, pageNr, fonts, images, false); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 // RenderPages renders page content into model.Pages
@@ -1056,6 +1164,10 @@ func (pdf *PDF) RenderPages() ([]*model.Page, model.FontMap, error) {
 
 	pdf.calcInheritedAttrs()
 
+	if err := pdf.paginate(); err != nil {
+		return nil, nil, err
+	}
+
 	pp := []*model.Page{}
 	fontMap := model.FontMap{}
 	imageMap := model.ImageMap{}
@@ -1073,22 +1185,8 @@ func (pdf *PDF) RenderPages() ([]*model.Page, model.FontMap, error) {
 			}
 
 			// Create blank page with optional background color.
-			if pdf.bgCol != nil {
-				draw.FillRectNoBorder(p.Buf, p.CropBox, *pdf.bgCol)
-			}
-
-			// Render page header.
-			if pdf.Header != nil {
-				if err := pdf.Header.render(&p, pageNr, fontMap, imageMap, true); err != nil {
-					return nil, nil, err
-				}
-			}
-
-			// Render page footer.
-			if pdf.Footer != nil {
-				if err := pdf.Footer.render(&p, pageNr, fontMap, imageMap, false); err != nil {
-					return nil, nil, err
-				}
+			if err := pdf.renderBlankPage(&p, pageNr, fontMap, imageMap); err != nil {
+				return nil, nil, err
 			}
 
 			pp = append(pp, &p)
@@ -1096,40 +1194,35 @@ func (pdf *PDF) RenderPages() ([]*model.Page, model.FontMap, error) {
 			continue
 		}
 
-		pdf.renderPageBackground(page, p.Buf)
-
-		var headerHeight, headerDy float64
-		var footerHeight, footerDy float64
-
-		// Render page header.
-		if pdf.Header != nil {
-			if err := pdf.Header.render(&p, pageNr, fontMap, imageMap, true); err != nil {
-				return nil, nil, err
-			}
-			headerHeight = pdf.Header.Height
-			headerDy = float64(pdf.Header.Dy)
+		if page.bgCol == nil && page.bgPaint == nil {
+			page.bgCol, page.bgPaint = pdf.bgCol, pdf.bgPaint
+		}
+		if err := pdf.renderPageBackground(&p, page.cropBox, page.bgCol, page.bgPaint); err != nil {
+			return nil, nil, err
 		}
 
-		// Render page footer.
-		if pdf.Footer != nil {
-			if err := pdf.Footer.render(&p, pageNr, fontMap, imageMap, false); err != nil {
-				return nil, nil, err
-			}
-			footerHeight = pdf.Footer.Height
-			footerDy = float64(pdf.Footer.Dy)
+		if err := pdf.renderBands(&p, page.cropBox, page.master, pageNr, fontMap, imageMap); err != nil {
+			return nil, nil, err
 		}
 
-		// Render page content.
-		r := page.cropBox.CroppedCopy(0)
-		r.LL.Y += footerHeight + footerDy
-		r.UR.Y -= headerHeight + headerDy
-		page.Content.mediaBox = r
+		// Render page content above any footnotes.
+		r := pdf.contentRect(page.cropBox, page.master)
+		page.Content.mediaBox = r.CroppedCopy(0)
+		page.Content.mediaBox.LL.Y += page.footnoteHeight
 		if err := page.Content.render(&p, pageNr, fontMap, imageMap); err != nil {
 			return nil, nil, err
 		}
 
+		if err := page.renderFootnotes(&p, r, pageNr, fontMap); err != nil {
+			return nil, nil, err
+		}
+
 		pp = append(pp, &p)
 	}
 
+	if err := pdf.checkLinks(); err != nil {
+		return nil, nil, err
+	}
+
 	return pp, fontMap, nil
 }
@@ -55,6 +55,7 @@ type RadioButtonGroup struct {
 	RTL             bool
 	Tab             int
 	Locked          bool
+	Required        bool
 	Debug           bool
 	Hide            bool
 }
@@ -1005,6 +1006,9 @@ func (rbg *RadioButtonGroup) prepareDict(p *model.Page, pageNr int, fonts model.
 		// Note: unsupported in Mac Preview
 		ff += FieldReadOnly
 	}
+	if rbg.Required {
+		ff += FieldRequired
+	}
 
 	d := types.Dict(
 		map[string]types.Object{
@@ -30,23 +30,27 @@ import (
 
 // SimpleBox is a positioned rectangular region within content.
 type SimpleBox struct {
-	pdf       *PDF
-	content   *Content
-	Name      string
-	Position  [2]float64 `json:"pos"` // x,y
-	x, y      float64
-	Dx, Dy    float64
-	Anchor    string
-	anchor    types.Anchor
-	anchored  bool
-	Width     float64
-	Height    float64
-	Margin    *Margin
-	Border    *Border
-	FillColor string `json:"fillCol"`
-	fillCol   *color.SimpleColor
-	Rotation  float64 `json:"rot"`
-	Hide      bool
+	pdf           *PDF
+	content       *Content
+	Name          string
+	Position      [2]float64 `json:"pos"` // x,y
+	x, y          float64
+	Dx, Dy        float64
+	Anchor        string
+	anchor        types.Anchor
+	anchored      bool
+	Width         float64
+	Height        float64
+	Margin        *Margin
+	Border        *Border
+	FillColor     string `json:"fillCol"`
+	fillCol       *color.SimpleColor
+	fillPaint     paint
+	Rotation      float64 `json:"rot"`
+	FillOpacity   float64 `json:"fillOpacity"`   // alpha for filling
+	StrokeOpacity float64 `json:"strokeOpacity"` // alpha for the border
+	Opacity       float64 // 0 < opacity < 1 renders the box as isolated transparency group
+	Hide          bool
 }
 
 func (sb *SimpleBox) validate() error {
@@ -58,6 +62,12 @@ func (sb *SimpleBox) validate() error {
 		return errors.New("pdfcpu: invalid box reference $")
 	}
 
+	for _, f := range []float64{sb.FillOpacity, sb.StrokeOpacity, sb.Opacity} {
+		if err := validateOpacity(f); err != nil {
+			return err
+		}
+	}
+
 	if sb.Anchor != "" {
 		if sb.Position[0] != 0 || sb.Position[1] != 0 {
 			return errors.New("pdfcpu: Please supply \"pos\" or \"anchor\"")
@@ -84,11 +94,11 @@ func (sb *SimpleBox) validate() error {
 	}
 
 	if sb.FillColor != "" {
-		sc, err := sb.pdf.parseColor(sb.FillColor)
+		sc, pt, err := sb.pdf.parsePaint(sb.FillColor)
 		if err != nil {
 			return err
 		}
-		sb.fillCol = sc
+		sb.fillCol, sb.fillPaint = sc, pt
 	}
 
 	return nil
@@ -131,14 +141,26 @@ func (sb *SimpleBox) mergeIn(sb0 *SimpleBox) {
 		sb.Border = sb0.Border
 	}
 
-	if sb.fillCol == nil {
-		sb.fillCol = sb0.fillCol
+	if sb.fillCol == nil && sb.fillPaint == nil {
+		sb.fillCol, sb.fillPaint = sb0.fillCol, sb0.fillPaint
 	}
 
 	if sb.Rotation == 0 {
 		sb.Rotation = sb0.Rotation
 	}
 
+	if sb.FillOpacity == 0 {
+		sb.FillOpacity = sb0.FillOpacity
+	}
+
+	if sb.StrokeOpacity == 0 {
+		sb.StrokeOpacity = sb0.StrokeOpacity
+	}
+
+	if sb.Opacity == 0 {
+		sb.Opacity = sb0.Opacity
+	}
+
 	if !sb.Hide {
 		sb.Hide = sb0.Hide
 	}
@@ -261,6 +283,35 @@ func (sb *SimpleBox) calcTransform(mLeft, mBottom, mRight, mTop, bWidth float64)
 	return m, r
 }
 
+func (sb *SimpleBox) renderFillAndBorder(p *model.Page, m matrix.Matrix, r *types.Rectangle, bWidth float64, bCol *color.SimpleColor, bStyle types.LineJoinStyle) error {
+	var bPaint paint
+	if sb.Border != nil && sb.Border.Width >= 0 {
+		bPaint = sb.Border.paint
+	}
+
+	switch {
+	case sb.fillPaint != nil:
+		if err := sb.pdf.fillRect(p, r, m, sb.fillPaint); err != nil {
+			return err
+		}
+		if bCol == nil && bPaint == nil {
+			return nil
+		}
+	case sb.fillCol != nil && bPaint == nil:
+		draw.FillRect(p.Buf, r, bWidth, bCol, *sb.fillCol, &bStyle)
+		return nil
+	case sb.fillCol != nil:
+		draw.FillRectNoBorder(p.Buf, r, *sb.fillCol)
+	}
+
+	if bPaint != nil {
+		return sb.pdf.drawRect(p, r, m, bWidth, bPaint, &bStyle)
+	}
+
+	draw.DrawRect(p.Buf, r, bWidth, bCol, &bStyle)
+	return nil
+}
+
 func (sb *SimpleBox) render(p *model.Page) error {
 
 	bWidth, bCol, bStyle, err := sb.calcBorder()
@@ -277,16 +328,21 @@ func (sb *SimpleBox) render(p *model.Page) error {
 
 	fmt.Fprintf(p.Buf, "q %.5f %.5f %.5f %.5f %.5f %.5f cm ", m[0][0], m[0][1], m[1][0], m[1][1], m[2][0], m[2][1])
 
-	if sb.fillCol != nil {
-		draw.FillRect(p.Buf, r, bWidth, bCol, *sb.fillCol, &bStyle)
-		if sb.pdf.Debug {
-			draw.DrawCircle(p.Buf, r.LL.X, r.LL.Y, 5, color.Black, &color.Red)
+	if sb.FillOpacity > 0 || sb.StrokeOpacity > 0 {
+		ca, CA := 1., 1.
+		if sb.FillOpacity > 0 {
+			ca = sb.FillOpacity
 		}
-		fmt.Fprint(p.Buf, "Q ")
-		return nil
+		if sb.StrokeOpacity > 0 {
+			CA = sb.StrokeOpacity
+		}
+		draw.SetExtGState(p.Buf, p.ExtGState(ca, CA))
+	}
+
+	if err := sb.renderFillAndBorder(p, m, r, bWidth, bCol, bStyle); err != nil {
+		return err
 	}
 
-	draw.DrawRect(p.Buf, r, bWidth, bCol, &bStyle)
 	if sb.pdf.Debug {
 		draw.DrawCircle(p.Buf, r.LL.X, r.LL.Y, 5, color.Black, &color.Red)
 	}
@@ -0,0 +1,186 @@
+/*
+	Copyright 2026 The pdfcpu Authors.
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+package primitives
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Styles are named sets of JSON attributes defined in the top level "styles" section.
+// Any JSON object referencing a style via "style": "$name" receives all style attributes it does not define itself.
+// Nested objects like fonts, borders, margins or paddings are merged attribute by attribute.
+// Styles may reference a style themselves for inheritance.
+type styles struct {
+	defs     map[string]map[string]interface{}
+	resolved map[string]bool
+	busy     map[string]bool
+}
+
+func styleRef(o interface{}) (string, bool) {
+	s, ok := o.(string)
+	if !ok || len(s) < 2 || s[0] != '$' {
+		// Not a style reference, eg. a border style.
+		return "", false
+	}
+	return strings.ToLower(s[1:]), true
+}
+
+// findKey returns the key of m matching k ignoring case like encoding/json does.
+func findKey(m map[string]interface{}, k string) (string, bool) {
+	if _, ok := m[k]; ok {
+		return k, true
+	}
+	for k1 := range m {
+		if strings.EqualFold(k1, k) {
+			return k1, true
+		}
+	}
+	return "", false
+}
+
+func deepCopy(o interface{}) interface{} {
+	switch o := o.(type) {
+	case map[string]interface{}:
+		m := map[string]interface{}{}
+		for k, v := range o {
+			m[k] = deepCopy(v)
+		}
+		return m
+	case []interface{}:
+		a := make([]interface{}, len(o))
+		for i, v := range o {
+			a[i] = deepCopy(v)
+		}
+		return a
+	}
+	return o
+}
+
+// mergeStyle adds all attributes of style missing in m.
+func mergeStyle(m, style map[string]interface{}) {
+	for k, v := range style {
+		k1, ok := findKey(m, k)
+		if !ok {
+			m[k] = deepCopy(v)
+			continue
+		}
+		m1, ok1 := m[k1].(map[string]interface{})
+		s1, ok2 := v.(map[string]interface{})
+		if ok1 && ok2 {
+			mergeStyle(m1, s1)
+		}
+	}
+}
+
+func (st *styles) style(name string) (map[string]interface{}, error) {
+	def, ok := st.defs[name]
+	if !ok {
+		return nil, errors.Errorf("pdfcpu: unknown style %s", name)
+	}
+	if st.resolved[name] {
+		return def, nil
+	}
+	if st.busy[name] {
+		return nil, errors.Errorf("pdfcpu: cyclic style inheritance: %s", name)
+	}
+	st.busy[name] = true
+
+	if err := st.apply(def); err != nil {
+		return nil, err
+	}
+
+	st.resolved[name] = true
+	return def, nil
+}
+
+// apply merges referenced styles into o and all objects nested in o.
+func (st *styles) apply(o interface{}) error {
+	switch o := o.(type) {
+
+	case map[string]interface{}:
+		if k, ok := findKey(o, "style"); ok {
+			if name, ok := styleRef(o[k]); ok {
+				style, err := st.style(name)
+				if err != nil {
+					return err
+				}
+				delete(o, k)
+				mergeStyle(o, style)
+			}
+		}
+		for _, v := range o {
+			if err := st.apply(v); err != nil {
+				return err
+			}
+		}
+
+	case []interface{}:
+		for _, v := range o {
+			if err := st.apply(v); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// ApplyStyles resolves all style references of a JSON document for PDF creation.
+func ApplyStyles(bb []byte) ([]byte, error) {
+	d := json.NewDecoder(bytes.NewReader(bb))
+	d.UseNumber()
+
+	var m map[string]interface{}
+	if err := d.Decode(&m); err != nil {
+		return nil, err
+	}
+
+	k, ok := findKey(m, "styles")
+	if !ok {
+		return bb, nil
+	}
+
+	defs, ok := m[k].(map[string]interface{})
+	if !ok {
+		return nil, errors.New("pdfcpu: \"styles\" must be a JSON object")
+	}
+	delete(m, k)
+
+	st := styles{
+		defs:     map[string]map[string]interface{}{},
+		resolved: map[string]bool{},
+		busy:     map[string]bool{},
+	}
+
+	for name, def := range defs {
+		m1, ok := def.(map[string]interface{})
+		if !ok {
+			return nil, errors.Errorf("pdfcpu: style %s must be a JSON object", name)
+		}
+		st.defs[strings.ToLower(name)] = m1
+	}
+
+	if err := st.apply(m); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(m)
+}
@@ -38,6 +38,7 @@ type TableHeader struct {
 	ColPaddings     []*Padding
 	BackgroundColor string `json:"bgCol"`
 	bgCol           *color.SimpleColor
+	bgPaint         paint
 	Font            *FormFont // defaults to table font
 	RTL             bool
 }
@@ -94,11 +95,11 @@ func (th *TableHeader) validate(pdf *PDF, cols int) error {
 	}
 
 	if th.BackgroundColor != "" {
-		sc, err := pdf.parseColor(th.BackgroundColor)
+		sc, pt, err := pdf.parsePaint(th.BackgroundColor)
 		if err != nil {
 			return err
 		}
-		th.bgCol = sc
+		th.bgCol, th.bgPaint = sc, pt
 	}
 	return nil
 }
@@ -155,11 +156,16 @@ type Table struct {
 	OddColor        string `json:"oddCol"`
 	EvenColor       string `json:"evenCol"`
 	bgCol           *color.SimpleColor
+	bgPaint         paint
 	oddCol          *color.SimpleColor
 	evenCol         *color.SimpleColor
 	RTL             bool
 	Rotation        float64 `json:"rot"`
 	Grid            bool
+	Opacity         float64 // 0 < opacity < 1 renders the table as isolated transparency group
+	Flow            bool    // table starting at pos grows downwards and continues on appended pages
+	cont            bool    // continues rows overflowing the previous page
+	row0            int     // index of the first row of a flowing table rendered on this page
 	Hide            bool
 	Header          *TableHeader
 }
@@ -256,7 +262,8 @@ func (t *Table) validateColumns() error {
 
 func (t *Table) validateValues() error {
 	if t.Values != nil {
-		if len(t.Values) > t.Rows {
+		// Flowing tables grow with their values.
+		if !t.Flow && len(t.Values) > t.Rows {
 			return errors.Errorf("pdfcpu: values for more than %d rows", t.Rows)
 		}
 		for _, vv := range t.Values {
@@ -310,11 +317,11 @@ func (t *Table) validatePadding() error {
 
 func (t *Table) validateBackgroundColor() error {
 	if t.BackgroundColor != "" {
-		sc, err := t.pdf.parseColor(t.BackgroundColor)
+		sc, pt, err := t.pdf.parsePaint(t.BackgroundColor)
 		if err != nil {
 			return err
 		}
-		t.bgCol = sc
+		t.bgCol, t.bgPaint = sc, pt
 	}
 	return nil
 }
@@ -353,6 +360,10 @@ func (t *Table) validateColors() error {
 
 func (t *Table) validate() error {
 
+	if err := validateOpacity(t.Opacity); err != nil {
+		return err
+	}
+
 	t.x = t.Position[0]
 	t.y = t.Position[1]
 
@@ -387,6 +398,10 @@ func (t *Table) validate() error {
 		return errors.New("pdfcpu: line height \"lheight\" missing.")
 	}
 
+	if t.Flow && (t.anchored || t.Rotation != 0) {
+		return errors.New("pdfcpu: Please supply \"pos\" and no rotation for flowing tables")
+	}
+
 	if err := t.validateValues(); err != nil {
 		return err
 	}
@@ -439,6 +454,10 @@ func (t *Table) mergeIn(t0 *Table) {
 
 	t.mergeInAnchor(t0)
 
+	if t.Opacity == 0 {
+		t.Opacity = t0.Opacity
+	}
+
 	if t.Dx == 0 {
 		t.Dx = t0.Dx
 	}
@@ -466,8 +485,8 @@ func (t *Table) mergeIn(t0 *Table) {
 		t.Font = t0.Font
 	}
 
-	if t.bgCol == nil {
-		t.bgCol = t0.bgCol
+	if t.bgCol == nil && t.bgPaint == nil {
+		t.bgCol, t.bgPaint = t0.bgCol, t0.bgPaint
 	}
 
 	if t.oddCol == nil {
@@ -482,6 +501,10 @@ func (t *Table) mergeIn(t0 *Table) {
 		t.Rotation = t0.Rotation
 	}
 
+	if !t.Flow {
+		t.Flow = t0.Flow
+	}
+
 	if !t.Hide {
 		t.Hide = t0.Hide
 	}
@@ -583,6 +606,15 @@ func (t *Table) calcTransform(mLeft, mBottom, mRight, mTop, bWidth float64) (mat
 	h := t.Height() + 2*bWidth
 	if t.anchored {
 		x, y = types.AnchorPosition(t.anchor, r, t.Width, h)
+	} else if t.Flow {
+		x, _ = types.NormalizeCoord(t.x, t.y, r, pdf.origin, false)
+		if x < 0 {
+			x = cBox.Center().X - t.Width/2 - r.LL.X
+		} else if x > 0 {
+			x -= mLeft
+		}
+		// Flowing tables start at pos and grow downwards.
+		y = t.flowTop(r, mBottom) - h
 	} else {
 		x, y = types.NormalizeCoord(t.x, t.y, r, pdf.origin, false)
 		if y < 0 {
@@ -633,7 +665,7 @@ func (t *Table) calcTransform(mLeft, mBottom, mRight, mTop, bWidth float64) (mat
 	return m, r
 }
 
-func (t *Table) renderBackground(p *model.Page, bWidth float64, r *types.Rectangle) {
+func (t *Table) renderBackground(p *model.Page, m matrix.Matrix, bWidth float64, r *types.Rectangle) error {
 	x := r.LL.X + bWidth/2
 	// Render odd,even row background.
 	if t.oddCol != nil || t.evenCol != nil {
@@ -644,8 +676,13 @@ func (t *Table) renderBackground(p *model.Page, bWidth float64, r *types.Rectang
 			w -= 1
 		}
 		for i := 0; i < t.Rows; i++ {
+			k := i
+			if t.Flow {
+				// Count rows top down across pages.
+				k = t.row0 + t.Rows - 1 - i
+			}
 			col := t.evenCol
-			if i%2 > 0 {
+			if k%2 > 0 {
 				col = t.oddCol
 			}
 			if col == nil {
@@ -658,7 +695,7 @@ func (t *Table) renderBackground(p *model.Page, bWidth float64, r *types.Rectang
 	}
 
 	// Render header background.
-	if t.Header != nil && t.Header.bgCol != nil {
+	if t.Header != nil && (t.Header.bgCol != nil || t.Header.bgPaint != nil) {
 		x, w := x, t.Width-2*bWidth
 		h := float64(t.LineHeight)
 		if bWidth == 0 {
@@ -668,10 +705,14 @@ func (t *Table) renderBackground(p *model.Page, bWidth float64, r *types.Rectang
 			h -= .5
 		}
 		y := r.LL.Y + bWidth/2 + float64(t.Rows*t.LineHeight)
-		col := t.Header.bgCol
 		r1 := types.RectForWidthAndHeight(x, y, w, h)
-		draw.FillRect(p.Buf, r1, 0, nil, *col, nil)
+		if t.Header.bgPaint != nil {
+			return t.pdf.fillRect(p, r1, m, t.Header.bgPaint)
+		}
+		draw.FillRect(p.Buf, r1, 0, nil, *t.Header.bgCol, nil)
 	}
+
+	return nil
 }
 
 func (t *Table) prepareColWidths(bWidth float64) []float64 {
@@ -899,11 +940,11 @@ func (t *Table) render(p *model.Page, pageNr int, fonts model.FontMap) error {
 		return err
 	}
 
-	m, r := t.calcTransform(mTop, mRight, mBottom, mLeft, bWidth)
+	m, r := t.calcTransform(mLeft, mBottom, mRight, mTop, bWidth)
 
 	fmt.Fprintf(p.Buf, "q %.5f %.5f %.5f %.5f %.5f %.5f cm ", m[0][0], m[0][1], m[1][0], m[1][1], m[2][0], m[2][1])
 
-	if t.bgCol != nil {
+	if t.bgCol != nil || t.bgPaint != nil {
 		x, w := r.LL.X+bWidth/2, t.Width-2*bWidth
 		if bWidth == 0 {
 			// Reduce artefacts.
@@ -912,14 +953,28 @@ func (t *Table) render(p *model.Page, pageNr int, fonts model.FontMap) error {
 		}
 		y := r.LL.Y + bWidth/2
 		r1 := types.RectForWidthAndHeight(x, y, w, r.Height()-.5)
-		draw.FillRect(p.Buf, r1, 0, bCol, *t.bgCol, &bStyle)
+		if t.bgPaint != nil {
+			if err := t.pdf.fillRect(p, r1, m, t.bgPaint); err != nil {
+				return err
+			}
+		} else {
+			draw.FillRect(p.Buf, r1, 0, bCol, *t.bgCol, &bStyle)
+		}
 	}
 
 	if t.Border != nil {
-		draw.DrawRect(p.Buf, r, bWidth, bCol, &bStyle)
+		if t.Border.paint != nil && t.Border.Width >= 0 {
+			if err := t.pdf.drawRect(p, r, m, bWidth, t.Border.paint, &bStyle); err != nil {
+				return err
+			}
+		} else {
+			draw.DrawRect(p.Buf, r, bWidth, bCol, &bStyle)
+		}
 	}
 
-	t.renderBackground(p, bWidth, r)
+	if err := t.renderBackground(p, m, bWidth, r); err != nil {
+		return err
+	}
 
 	colWidths := t.prepareColWidths(bWidth)
 
@@ -17,6 +17,7 @@
 package primitives
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/color"
@@ -51,7 +52,18 @@ type TextBox struct {
 	horAlign        types.HAlignment
 	RTL             bool
 	Rotation        float64 `json:"rot"`
+	Path            string  // "arc radius [startAngle endAngle]" or "curve x0 y0 x1 y1 x2 y2 x3 y3" relative to pos
+	path            *model.TextPath
+	Opacity         float64 // 0 < opacity < 1 renders the text box as isolated transparency group
+	Flow            bool    // text starting at pos grows downwards and continues on appended pages
+	cont            bool    // continues text overflowing the previous page
 	Hide            bool
+	Floats          []*TextFloat // images the justified text flows around
+	footnotes       []*footnote  // footnotes referenced within Value
+	Url             string       // external link or "#target" for an internal link
+	Bookmark        *Bookmark    // outline entry pointing to this text
+	Target          string       // name of this text as link target
+	Lang            string       // natural language of this text overriding the document language, eg. "de-CH"
 }
 
 func (tb *TextBox) validateAnchor() error {
@@ -128,8 +140,43 @@ func (tb *TextBox) validateHorAlign() error {
 	return nil
 }
 
+func (tb *TextBox) validatePath() error {
+	if tb.Path == "" {
+		return nil
+	}
+	if tb.anchored {
+		return errors.New("pdfcpu: Please supply \"pos\" for text following a path")
+	}
+	tp, err := model.ParseTextPath(tb.Path, types.POINTS)
+	if err != nil {
+		return err
+	}
+	tb.path = tp
+	return nil
+}
+
+func (tb *TextBox) validateFlow() error {
+	if !tb.Flow {
+		return nil
+	}
+	if tb.anchored || tb.path != nil {
+		return errors.New("pdfcpu: Please supply \"pos\" for flowing text")
+	}
+	if tb.Width <= 0 {
+		return errors.New("pdfcpu: Please supply \"width\" for flowing text")
+	}
+	if tb.Rotation != 0 {
+		return errors.New("pdfcpu: flowing text can't be rotated")
+	}
+	return nil
+}
+
 func (tb *TextBox) validate() error {
 
+	if err := validateOpacity(tb.Opacity); err != nil {
+		return err
+	}
+
 	tb.x = tb.Position[0]
 	tb.y = tb.Position[1]
 
@@ -161,7 +208,34 @@ func (tb *TextBox) validate() error {
 		return err
 	}
 
-	return tb.validateHorAlign()
+	if err := tb.validatePath(); err != nil {
+		return err
+	}
+
+	if err := tb.validateFlow(); err != nil {
+		return err
+	}
+
+	if err := tb.validateHorAlign(); err != nil {
+		return err
+	}
+
+	if err := tb.validateFloats(); err != nil {
+		return err
+	}
+
+	if tb.Lang != "" && !types.ValidLanguageTag(tb.Lang) {
+		return errors.Errorf("pdfcpu: invalid text language: %s", tb.Lang)
+	}
+
+	return tb.validateNavigation()
+}
+
+func (tb *TextBox) validateNavigation() error {
+	if tb.Path != "" && (tb.Url != "" || tb.Bookmark != nil || tb.Target != "") {
+		return errors.New("pdfcpu: text on path does not support \"url\", \"bookmark\" or \"target\"")
+	}
+	return validateNavigation(tb.pdf, tb.Bookmark, tb.Target)
 }
 
 func (tb *TextBox) font(name string) *FormFont {
@@ -201,6 +275,10 @@ func (tb *TextBox) mergeIn(tb0 *TextBox) {
 
 	tb.mergeInPos(tb0)
 
+	if tb.Opacity == 0 {
+		tb.Opacity = tb0.Opacity
+	}
+
 	if tb.Value == "" {
 		tb.Value = tb0.Value
 	}
@@ -237,9 +315,29 @@ func (tb *TextBox) mergeIn(tb0 *TextBox) {
 		tb.Rotation = tb0.Rotation
 	}
 
+	if tb.path == nil {
+		tb.path = tb0.path
+	}
+
+	if !tb.Flow {
+		tb.Flow = tb0.Flow
+	}
+
+	if tb.Floats == nil {
+		tb.Floats = tb0.Floats
+	}
+
 	if !tb.Hide {
 		tb.Hide = tb0.Hide
 	}
+
+	if tb.Url == "" {
+		tb.Url = tb0.Url
+	}
+
+	if tb.Lang == "" {
+		tb.Lang = tb0.Lang
+	}
 }
 
 func (tb *TextBox) calcFont() error {
@@ -398,7 +496,20 @@ func (tb *TextBox) calcMargin() (float64, float64, float64, float64, error) {
 	return mTop, mRight, mBottom, mLeft, nil
 }
 
-func (tb *TextBox) render(p *model.Page, pageNr int, fonts model.FontMap) error {
+// renderPath renders the text glyph by glyph along the path originating at pos.
+func (tb *TextBox) renderPath(p *model.Page, td *model.TextDescriptor, r *types.Rectangle) {
+	x, y := types.NormalizeCoord(tb.x, tb.y, tb.content.Box(), tb.pdf.origin, false)
+	if x == -1 {
+		x = r.Width() / 2
+	}
+	if y == -1 {
+		y = r.Height() / 2
+	}
+	td.X, td.Y = r.LL.X+x+td.Dx, r.LL.Y+y+td.Dy
+	model.WriteTextOnPath(tb.pdf.XRefTable, p.Buf, *td, *tb.path)
+}
+
+func (tb *TextBox) render(p *model.Page, pageNr int, fonts model.FontMap, images model.ImageMap) error {
 
 	pdf := tb.pdf
 
@@ -406,6 +517,12 @@ func (tb *TextBox) render(p *model.Page, pageNr int, fonts model.FontMap) error
 		return err
 	}
 
+	if tb.Lang != "" {
+		// Mark the text as span of its own language for screen readers.
+		fmt.Fprintf(p.Buf, "/Span <</Lang (%s)>> BDC ", tb.Lang)
+		defer fmt.Fprint(p.Buf, "EMC ")
+	}
+
 	td, err := tb.prepareTextDescriptor(p, pageNr, fonts)
 	if err != nil {
 		return err
@@ -430,7 +547,13 @@ func (tb *TextBox) render(p *model.Page, pageNr int, fonts model.FontMap) error
 	}
 
 	if tb.anchored {
-		model.WriteMultiLineAnchored(tb.pdf.XRefTable, p.Buf, r, nil, *td, tb.anchor)
+		td.X, td.Y, td.HAlign, td.VAlign = model.AnchorPosAndAlign(tb.anchor, r)
+		_, ql := model.WriteColumnQuad(tb.pdf.XRefTable, p.Buf, r, nil, *td, 0)
+		return tb.navigate(p, pageNr, ql)
+	}
+
+	if tb.path != nil {
+		tb.renderPath(p, td, r)
 		return nil
 	}
 
@@ -446,7 +569,10 @@ func (tb *TextBox) render(p *model.Page, pageNr int, fonts model.FontMap) error
 		}
 	}
 
-	if td.Y == -1 {
+	if tb.Flow || len(tb.Floats) > 0 {
+		// Flowing text and text with floats start at pos and grow downwards.
+		td.Y, td.VAlign = tb.flowTop(r, mBottom), types.AlignTop
+	} else if td.Y == -1 {
 		// Center vertically
 		td.Y = cBox.Center().Y - r.LL.Y
 		td.VAlign = types.AlignMiddle
@@ -458,7 +584,32 @@ func (tb *TextBox) render(p *model.Page, pageNr int, fonts model.FontMap) error
 		r.LL.Y += td.BorderWidth
 	}
 
-	model.WriteColumn(tb.pdf.XRefTable, p.Buf, r, nil, *td, float64(tb.Width))
+	if len(tb.Floats) == 0 {
+		_, ql := model.WriteColumnQuad(tb.pdf.XRefTable, p.Buf, r, nil, *td, float64(tb.Width))
+		return tb.navigate(p, pageNr, ql)
+	}
+
+	// The column text starts inside border and padding.
+	bw := td.BorderWidth
+	w := tb.Width - td.MLeft - td.MRight - 2*bw
+	td.Exclusions = tb.exclusions(w)
 
-	return nil
+	_, ql := model.WriteColumnQuad(tb.pdf.XRefTable, p.Buf, r, nil, *td, float64(tb.Width))
+
+	x := r.LL.X + td.X + td.Dx + td.MLeft + bw
+	y := r.LL.Y + td.Y + td.Dy - td.MTop - bw
+
+	if err := tb.renderFloats(p, x, y, w, pageNr, images); err != nil {
+		return err
+	}
+
+	return tb.navigate(p, pageNr, ql)
+}
+
+// navigate creates the link and records bookmark and target of tb covering ql.
+func (tb *TextBox) navigate(p *model.Page, pageNr int, ql types.QuadLiteral) error {
+	if tb.Url != "" {
+		tb.pdf.createLink(p, pageNr, ql, tb.Url)
+	}
+	return tb.pdf.recordNavigation(pageNr, ql, tb.Bookmark, tb.Value, tb.Target)
 }
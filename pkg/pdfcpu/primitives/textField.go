@@ -51,6 +51,8 @@ type TextField struct {
 	fontID          string
 	Margin          *Margin // applied to content box
 	Border          *Border
+	BorderStyle     string `json:"borderStyle"` // "solid", "dashed", "beveled", "inset", "underline"
+	borderStyle     model.BorderStyle
 	BackgroundColor string             `json:"bgCol"`
 	BgCol           *color.SimpleColor `json:"-"`
 	Alignment       string             `json:"align"` // "Left", "Center", "Right"
@@ -60,6 +62,7 @@ type TextField struct {
 	RTL             bool
 	Tab             int
 	Locked          bool
+	Required        bool
 	Debug           bool
 	Hide            bool
 }
@@ -127,6 +130,11 @@ func (tf *TextField) validateBorder() error {
 			return err
 		}
 	}
+	bs, err := parseFieldBorderStyle(tf.BorderStyle)
+	if err != nil {
+		return err
+	}
+	tf.borderStyle = bs
 	return nil
 }
 
@@ -435,7 +443,11 @@ func (tf *TextField) renderN(xRefTable *model.XRefTable) ([]byte, error) {
 	boWidth, boCol := tf.calcBorder()
 	buf := new(bytes.Buffer)
 
-	tf.renderBackground(buf, bgCol, boCol, boWidth, w, h)
+	if tf.borderStyle == model.BSSolid {
+		tf.renderBackground(buf, bgCol, boCol, boWidth, w, h)
+	} else {
+		tf.renderBackground(buf, bgCol, nil, 0, w, h)
+	}
 
 	f := tf.Font
 
@@ -474,8 +486,12 @@ func (tf *TextField) renderN(xRefTable *model.XRefTable) ([]byte, error) {
 	fmt.Fprint(buf, "EMC ")
 
 	if boCol != nil && boWidth > 0 {
-		fmt.Fprintf(buf, "q %.2f %.2f %.2f RG %.2f w %.2f %.2f %.2f %.2f re s Q ",
-			boCol.R, boCol.G, boCol.B, boWidth-1, boWidth/2, boWidth/2, w-boWidth, h-boWidth)
+		if tf.borderStyle != model.BSSolid {
+			renderFieldBorder(buf, tf.borderStyle, boCol, boWidth, w, h)
+		} else {
+			fmt.Fprintf(buf, "q %.2f %.2f %.2f RG %.2f w %.2f %.2f %.2f %.2f re s Q ",
+				boCol.R, boCol.G, boCol.B, boWidth-1, boWidth/2, boWidth/2, w-boWidth, h-boWidth)
+		}
 	}
 
 	return buf.Bytes(), nil
@@ -577,6 +593,10 @@ func (tf *TextField) prepareFF() FieldFlags {
 		ff += FieldReadOnly
 	}
 
+	if tf.Required {
+		ff += FieldRequired
+	}
+
 	if tf.Comb {
 		ff += FieldComb
 	}
@@ -609,6 +629,9 @@ func (tf *TextField) handleBorderAndMK(d types.Dict) {
 
 	if boWidth > 0 {
 		d["Border"] = types.NewNumberArray(0, 0, boWidth)
+		if tf.borderStyle != model.BSSolid {
+			d["BS"] = fieldBorderStyleDict(boWidth, tf.borderStyle)
+		}
 	}
 }
 
@@ -889,6 +912,43 @@ func calcColsFromMK(ctx *model.Context, d types.Dict) (*color.SimpleColor, *colo
 	return bgCol, boCol, nil
 }
 
+// Quadding returns the inheritable quadding of the field d or nil (see 12.7.3.3 Table 228).
+func Quadding(ctx *model.Context, d types.Dict) *types.HAlignment {
+	// Limit the depth of the field hierarchy in order to survive cycles.
+	for i := 0; d != nil && i < 32; i++ {
+		if q := d.IntEntry("Q"); q != nil {
+			a := types.HAlignment(*q)
+			return &a
+		}
+		d1, err := ctx.DereferenceDict(d["Parent"])
+		if err != nil {
+			return nil
+		}
+		d = d1
+	}
+
+	if ctx.Form != nil {
+		if q := ctx.Form.IntEntry("Q"); q != nil {
+			a := types.HAlignment(*q)
+			return &a
+		}
+	}
+
+	return nil
+}
+
+// horAlign returns the horizontal alignment of value v for the field d.
+// Right to left values align right unless the field defines its quadding.
+func horAlign(ctx *model.Context, d types.Dict, v string) types.HAlignment {
+	if a := Quadding(ctx, d); a != nil && *a <= types.AlignRight {
+		return *a
+	}
+	if types.RightToLeft(v) {
+		return types.AlignRight
+	}
+	return types.AlignLeft
+}
+
 func calcBorderWidth(d types.Dict) int {
 	w := 0
 	if arr := d.ArrayEntry("Border"); len(arr) == 3 {
@@ -941,10 +1001,7 @@ func NewTextField(
 		}
 	}
 
-	tf.HorAlign = types.AlignLeft
-	if q := d.IntEntry("Q"); q != nil {
-		tf.HorAlign = types.HAlignment(*q)
-	}
+	tf.HorAlign = horAlign(ctx, d, v)
 
 	bgCol, boCol, err := calcColsFromMK(ctx, d)
 	if err != nil {
@@ -0,0 +1,62 @@
+/*
+	Copyright 2026 The pdfcpu Authors.
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+package primitives
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/draw"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+func validateOpacity(f float64) error {
+	if f < 0 || f > 1 {
+		return errors.Errorf("pdfcpu: invalid opacity: %.2f (should be between 0 and 1)", f)
+	}
+	return nil
+}
+
+// renderGroup renders into an isolated transparency group painted using opacity
+// so overlapping parts of the group do not shine through each other.
+// opacity 0 (=undefined) and 1 render straight into the page content.
+func (pdf *PDF) renderGroup(p *model.Page, opacity float64, render func() error) error {
+	if opacity == 0 || opacity == 1 {
+		return render()
+	}
+
+	buf := p.Buf
+	p.Buf = new(bytes.Buffer)
+	err := render()
+	content := p.Buf.Bytes()
+	p.Buf = buf
+	if err != nil {
+		return err
+	}
+
+	id, err := p.AddTransparencyGroup(pdf.XRefTable, p.MediaBox, content)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprint(p.Buf, "q ")
+	draw.SetExtGState(p.Buf, p.ExtGState(opacity, opacity))
+	fmt.Fprintf(p.Buf, "/%s Do Q ", id)
+
+	return nil
+}
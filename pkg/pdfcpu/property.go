@@ -17,11 +17,14 @@ limitations under the License.
 package pdfcpu
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
 	"sort"
 
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
 )
 
 // PropertiesList returns a list of document properties as recorded in the document info dict.
@@ -56,7 +59,7 @@ func PropertiesAdd(ctx *model.Context, properties map[string]string) error {
 			return err
 		}
 		d[k] = types.StringLiteral(*s)
-		ctx.Properties[k] = *s
+		ctx.Properties[k] = v
 	}
 
 	return nil
@@ -95,3 +98,38 @@ func PropertiesRemove(ctx *model.Context, properties []string) (bool, error) {
 
 	return removed, nil
 }
+
+// PropertiesJSON represents the document properties of a PDF file for JSON export and import.
+type PropertiesJSON struct {
+	Header     *Header           `json:"header,omitempty"`
+	Properties map[string]string `json:"properties"`
+}
+
+// ExportPropertiesJSON writes the document properties of ctx (originating from source) as JSON to w.
+func ExportPropertiesJSON(ctx *model.Context, source string, w io.Writer) error {
+	h := header(ctx.XRefTable, source)
+	pj := PropertiesJSON{Header: &h, Properties: ctx.Properties}
+	if pj.Properties == nil {
+		pj.Properties = map[string]string{}
+	}
+
+	bb, err := json.MarshalIndent(pj, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(bb)
+	return err
+}
+
+// ParsePropertiesJSON parses a JSON document containing document properties.
+func ParsePropertiesJSON(rd io.Reader) (map[string]string, error) {
+	pj := PropertiesJSON{}
+	if err := json.NewDecoder(rd).Decode(&pj); err != nil {
+		return nil, errors.Wrap(err, "pdfcpu: invalid properties JSON")
+	}
+	if len(pj.Properties) == 0 {
+		return nil, errors.New("pdfcpu: properties JSON: missing \"properties\"")
+	}
+	return pj.Properties, nil
+}
@@ -22,6 +22,7 @@ import (
 	"context"
 	"io"
 	"os"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -41,6 +42,7 @@ const (
 
 var (
 	ErrWrongPassword         = errors.New("pdfcpu: please provide the correct password")
+	ErrOwnerPasswordRequired = errors.New("pdfcpu: please provide the owner password with -opw")
 	ErrCorruptHeader         = errors.New("pdfcpu: no header version available")
 	ErrReferenceDoesNotExist = errors.New("pdfcpu: referenced object does not exist")
 
@@ -68,7 +70,55 @@ func ReadFileWithContext(c context.Context, inFile string, conf *model.Configura
 		f.Close()
 	}()
 
-	return ReadWithContext(c, f, conf)
+	if conf != nil && conf.MemoryMappedRead {
+		r, err := newMmapReader(f)
+		if err == nil {
+			defer r.Close()
+			return readAndLoadWithContext(c, r, conf)
+		}
+		if log.InfoEnabled() {
+			log.Info.Printf("memory mapped read of %s failed, falling back to buffered read: %v\n", inFile, err)
+		}
+	}
+
+	return readAndLoadWithContext(c, f, conf)
+}
+
+// readAndLoadWithContext reads rs and loads all deferred streams since rs gets closed right after.
+func readAndLoadWithContext(c context.Context, rs io.ReadSeeker, conf *model.Configuration) (*model.Context, error) {
+	ctx, err := ReadWithContext(c, rs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := LoadDeferredStreams(ctx); err != nil {
+		return nil, err
+	}
+
+	// Copy through relies on rs.
+	ctx.Read.ObjectDigests = nil
+
+	return ctx, nil
+}
+
+// LoadDeferredStreams loads the encoded content of all streams whose loading has been deferred until writing.
+// Call this before closing ctx.Read.RS while ctx is still in use.
+func LoadDeferredStreams(ctx *model.Context) error {
+	for objNr, entry := range ctx.Table {
+		if entry == nil || entry.Free || entry.Object == nil {
+			continue
+		}
+		sd, ok := entry.Object.(types.StreamDict)
+		if !ok || !sd.Deferred() {
+			continue
+		}
+		if err := sd.Load(); err != nil {
+			return errors.Wrapf(err, "LoadDeferredStreams: obj#%d", objNr)
+		}
+		entry.Object = sd
+	}
+
+	return nil
 }
 
 // Read takes a readSeeker and generates a PDF model context,
@@ -119,6 +169,10 @@ func ReadWithContext(c context.Context, rs io.ReadSeeker, conf *model.Configurat
 		model.ShowRepaired("trailer size")
 	}
 
+	if ctx.CopyThrough || ctx.DryRun {
+		recordObjectDigests(c, ctx)
+	}
+
 	if log.ReadEnabled() {
 		log.Read.Println("Read: end")
 	}
@@ -696,16 +750,16 @@ func parseXRefStream(c context.Context, ctx *model.Context, rd io.Reader, offset
 		log.Read.Printf("parseXRefStream: endInd=%[1]d(%[1]x) streamInd=%[2]d(%[2]x)\n", endInd, streamInd)
 	}
 
-	line := string(buf)
-
 	// We expect a stream and therefore "stream" before "endobj" if "endobj" within buffer.
 	// There is no guarantee that "endobj" is contained in this buffer for large streams!
 	if streamInd < 0 || (endInd > 0 && endInd < streamInd) {
+		putObjectBuf(buf)
 		return nil, errors.New("pdfcpu: parseXRefStream: corrupt pdf file")
 	}
 
 	// Init object parse buf.
-	l := line[:streamInd]
+	l := string(buf[:streamInd])
+	putObjectBuf(buf)
 
 	objNr, genNr, err := model.ParseObjectAttributes(&l)
 	if err != nil {
@@ -1373,6 +1427,8 @@ func bypassXrefSection(c context.Context, ctx *model.Context, offExtra int64, wa
 		log.Read.Printf("bypassXRefSection after %v\n", wasErr)
 	}
 
+	ctx.Read.RepairedXRef = true
+
 	var z int64
 	g := types.FreeHeadGeneration
 	ctx.Table[0] = &model.XRefTableEntry{
@@ -1634,14 +1690,19 @@ func readXRefTable(c context.Context, ctx *model.Context) (err error) {
 }
 
 func growBufBy(buf []byte, size int, rd io.Reader) ([]byte, error) {
-	b := make([]byte, size)
+	n := len(buf)
+	buf = slices.Grow(buf, size)[:n+size]
 
-	if _, err := fillBuffer(rd, b); err != nil {
+	m, err := fillBuffer(rd, buf[n:])
+	if err != nil {
 		return nil, err
 	}
-	//log.Read.Printf("growBufBy: Read %d bytes\n", n)
+	//log.Read.Printf("growBufBy: Read %d bytes\n", m)
+
+	// Zero any remainder since buf may be a reused buffer.
+	clear(buf[n+m:])
 
-	return append(buf, b...), nil
+	return buf, nil
 }
 
 func nextStreamOffset(line string, streamInd int) (off int) {
@@ -1699,6 +1760,7 @@ func lastStreamMarker(streamInd *int, endInd int, line string) {
 }
 
 // Provide a PDF file buffer of sufficient size for parsing an object w/o stream.
+// The buffer is pooled and must be released using putObjectBuf.
 func buffer(c context.Context, rd io.Reader) (buf []byte, endInd int, streamInd int, streamOffset int64, err error) {
 	// process: # gen obj ... obj dict ... {stream ... data ... endstream} ... endobj
 	//                                    streamInd                            endInd
@@ -1709,6 +1771,8 @@ func buffer(c context.Context, rd io.Reader) (buf []byte, endInd int, streamInd
 	endInd, streamInd = -1, -1
 	growSize := defaultBufSize
 
+	buf = getObjectBuf()
+
 	for endInd < 0 && streamInd < 0 {
 		if err := c.Err(); err != nil {
 			return nil, 0, 0, 0, err
@@ -1719,7 +1783,9 @@ func buffer(c context.Context, rd io.Reader) (buf []byte, endInd int, streamInd
 		}
 
 		growSize = min(growSize*2, maximumBufSize)
-		line := string(buf)
+
+		// line is a read only view of buf valid for this iteration.
+		line := byteString(buf)
 
 		endInd, streamInd, err = model.DetectKeywords(line)
 		if err != nil {
@@ -1756,7 +1822,7 @@ func buffer(c context.Context, rd io.Reader) (buf []byte, endInd int, streamInd
 					return nil, 0, 0, 0, err
 				}
 
-				line = string(buf)
+				line = byteString(buf)
 			}
 
 			streamOffset = int64(nextStreamOffset(line, streamInd))
@@ -1952,7 +2018,7 @@ func streamDictForObject(c context.Context, ctx *model.Context, d types.Dict, ob
 }
 
 func dict(ctx *model.Context, d1 types.Dict, objNr, genNr, endInd, streamInd int) (d2 types.Dict, err error) {
-	if ctx.EncKey != nil {
+	if ctx.EncKey != nil && ctx.EncStrings {
 		if _, err := decryptDeepObject(d1, objNr, genNr, ctx.EncKey, ctx.AES4Strings, ctx.E.R); err != nil {
 			return nil, err
 		}
@@ -1969,11 +2035,11 @@ func dict(ctx *model.Context, d1 types.Dict, objNr, genNr, endInd, streamInd int
 }
 
 func object(c context.Context, ctx *model.Context, offset int64, objNr, genNr int) (o types.Object, endInd, streamInd int, streamOffset int64, err error) {
-	var rd io.Reader
-
-	if rd, err = newPositionedReader(ctx.Read.RS, &offset); err != nil {
+	rd, err := pooledPositionedReader(ctx.Read.RS, &offset)
+	if err != nil {
 		return nil, 0, 0, 0, err
 	}
+	defer releaseReader(rd)
 
 	//log.Read.Printf("object: seeked to offset:%d\n", offset)
 
@@ -1988,8 +2054,7 @@ func object(c context.Context, ctx *model.Context, offset int64, objNr, genNr in
 	//log.Read.Printf("streamInd:%d(#%x) streamOffset:%d(#%x) endInd:%d(#%x)\n", streamInd, streamInd, streamOffset, streamOffset, endInd, endInd)
 	//log.Read.Printf("buflen=%d\n%s", len(buf), hex.Dump(buf))
 
-	line := string(buf)
-
+	// Only copy the part of buf needed for parsing since parsed objects may retain substrings.
 	var l string
 
 	if endInd < 0 { // && streamInd >= 0, streamdict
@@ -1999,7 +2064,7 @@ func object(c context.Context, ctx *model.Context, offset int64, objNr, genNr in
 		if log.ReadEnabled() {
 			log.Read.Println("object: big stream, we parse object until stream")
 		}
-		l = line[:streamInd]
+		l = string(buf[:streamInd])
 	} else if streamInd < 0 { // dict
 		// buf: # gen obj ... obj dict ... endobj
 		// implies we detected endobj and no stream.
@@ -2007,7 +2072,7 @@ func object(c context.Context, ctx *model.Context, offset int64, objNr, genNr in
 		if log.ReadEnabled() {
 			log.Read.Println("object: small object w/o stream, parse until endobj")
 		}
-		l = line[:endInd]
+		l = string(buf[:endInd])
 	} else if streamInd < endInd { // streamdict
 		// buf: # gen obj ... obj dict ... stream ... data ... endstream endobj
 		// implies we detected endobj and stream.
@@ -2015,7 +2080,7 @@ func object(c context.Context, ctx *model.Context, offset int64, objNr, genNr in
 		if log.ReadEnabled() {
 			log.Read.Println("object: small stream within buffer, parse until stream")
 		}
-		l = line[:streamInd]
+		l = string(buf[:streamInd])
 	} else { // dict
 		// buf: # gen obj ... obj dict ... endobj # gen obj ... obj dict ... stream
 		// small obj w/o stream, parse until "endobj"
@@ -2023,9 +2088,11 @@ func object(c context.Context, ctx *model.Context, offset int64, objNr, genNr in
 		if log.ReadEnabled() {
 			log.Read.Println("object: small obj w/o stream, parse until endobj")
 		}
-		l = line[:endInd]
+		l = string(buf[:endInd])
 	}
 
+	putObjectBuf(buf)
+
 	// Parse object number and object generation.
 	var objectNr, generationNr *int
 	if objectNr, generationNr, err = model.ParseObjectAttributes(&l); err != nil {
@@ -2071,7 +2138,7 @@ func resolveObject(c context.Context, ctx *model.Context, obj types.Object, offs
 		return streamDictForObject(c, ctx, o, objNr, streamInd, streamOffset, offset)
 
 	case types.Array:
-		if ctx.EncKey != nil {
+		if ctx.EncKey != nil && ctx.EncStrings {
 			if _, err := decryptDeepObject(o, objNr, genNr, ctx.EncKey, ctx.AES4Strings, ctx.E.R); err != nil {
 				return nil, err
 			}
@@ -2079,7 +2146,7 @@ func resolveObject(c context.Context, ctx *model.Context, obj types.Object, offs
 		return o, nil
 
 	case types.StringLiteral:
-		if ctx.EncKey != nil {
+		if ctx.EncKey != nil && ctx.EncStrings {
 			sl, err := decryptStringLiteral(o, objNr, genNr, ctx.EncKey, ctx.AES4Strings, ctx.E.R)
 			if err != nil {
 				return nil, err
@@ -2089,7 +2156,7 @@ func resolveObject(c context.Context, ctx *model.Context, obj types.Object, offs
 		return o, nil
 
 	case types.HexLiteral:
-		if ctx.EncKey != nil {
+		if ctx.EncKey != nil && ctx.EncStrings {
 			hl, err := decryptHexLiteral(o, objNr, genNr, ctx.EncKey, ctx.AES4Strings, ctx.E.R)
 			if err != nil {
 				return nil, err
@@ -2381,11 +2448,13 @@ func saveDecodedStreamContent(ctx *model.Context, sd *types.StreamDict, objNr, g
 	// ctx gets created after XRefStream parsing.
 	// XRefStreams are not encrypted.
 	if ctx != nil && ctx.EncKey != nil {
-		if sd.Raw, err = decryptStream(sd.Raw, objNr, genNr, ctx.EncKey, ctx.AES4Streams, ctx.E.R); err != nil {
-			return err
+		if enc, aes := streamEncryption(ctx, objNr); enc {
+			if sd.Raw, err = decryptStream(sd.Raw, objNr, genNr, ctx.EncKey, aes, ctx.E.R); err != nil {
+				return err
+			}
+			l := int64(len(sd.Raw))
+			sd.StreamLength = &l
 		}
-		l := int64(len(sd.Raw))
-		sd.StreamLength = &l
 	}
 
 	if !decode {
@@ -2594,6 +2663,7 @@ func decodeObjectStreams(c context.Context, ctx *model.Context) error {
 		if err := c.Err(); err != nil {
 			return err
 		}
+		ctx.CurObj = objNr
 		if err := decodeObjectStream(c, ctx, objNr); err != nil {
 			return err
 		}
@@ -2662,10 +2732,71 @@ func loadStreamDict(c context.Context, ctx *model.Context, sd *types.StreamDict,
 
 	ctx.Read.BinaryTotalSize += *sd.StreamLength
 
+	if ctx.EncKey != nil && ctx.EmbeddedFileStreams == nil && embeddedFileCryptDiffers(ctx) {
+		// Decrypt once embedded file streams have been identified.
+		return nil
+	}
+
 	// Decode stream content.
 	return saveDecodedStreamContent(ctx, sd, objNr, genNr, ctx.DecodeAllStreams)
 }
 
+// streamEndsAt returns true if "endstream" follows offset in rs.
+func streamEndsAt(rs io.ReadSeeker, offset int64) (bool, error) {
+	if _, err := rs.Seek(offset, io.SeekStart); err != nil {
+		return false, err
+	}
+
+	buf := make([]byte, 32)
+	n, err := io.ReadFull(rs, buf)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		if err == io.EOF {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return bytes.HasPrefix(bytes.TrimLeft(buf[:n], "\x00\t\n\f\r "), []byte("endstream")), nil
+}
+
+// deferStreamContent returns true if loading the encoded content of sd may be deferred until writing.
+// This applies to image streams of unencrypted files getting split or merged
+// since these are copied through to the output unmodified.
+func deferStreamContent(c context.Context, ctx *model.Context, sd *types.StreamDict) (bool, error) {
+	if ctx.Cmd != model.SPLIT && ctx.Cmd != model.MERGECREATE && ctx.Cmd != model.MERGEAPPEND {
+		return false, nil
+	}
+
+	if ctx.Encrypt != nil || ctx.EncKey != nil || ctx.DecodeAllStreams {
+		return false, nil
+	}
+
+	if st := sd.Subtype(); st == nil || *st != "Image" {
+		return false, nil
+	}
+
+	if sd.StreamLength == nil {
+		if sd.StreamLengthObjNr == nil {
+			return false, nil
+		}
+		l, err := int64Object(c, ctx, *sd.StreamLengthObjNr)
+		if err != nil {
+			if err == ErrReferenceDoesNotExist {
+				return false, nil
+			}
+			return false, err
+		}
+		sd.StreamLength = l
+	}
+
+	if sd.StreamLength == nil || *sd.StreamLength <= 0 {
+		return false, nil
+	}
+
+	// Corrupt stream lengths need fixing while loading.
+	return streamEndsAt(ctx.Read.RS, sd.StreamOffset+*sd.StreamLength)
+}
+
 func updateBinaryTotalSize(ctx *model.Context, o types.Object) {
 	switch o := o.(type) {
 	case types.StreamDict:
@@ -2709,7 +2840,14 @@ func dereferenceAndLoad(c context.Context, ctx *model.Context, objNr int, entry
 	}
 
 	if sd, ok := o.(types.StreamDict); ok {
-		if err = loadStreamDict(c, ctx, &sd, objNr, *entry.Generation, false); err != nil {
+		deferred, err := deferStreamContent(c, ctx, &sd)
+		if err != nil {
+			return err
+		}
+		if deferred {
+			sd.Source = ctx.Read.RS
+			ctx.Read.BinaryTotalSize += *sd.StreamLength
+		} else if err = loadStreamDict(c, ctx, &sd, objNr, *entry.Generation, false); err != nil {
 			return err
 		}
 		entry.Object = sd
@@ -2727,6 +2865,8 @@ func dereferenceObject(c context.Context, ctx *model.Context, objNr int) error {
 		log.Read.Printf("dereferenceObject: begin, dereferencing object %d\n", objNr)
 	}
 
+	ctx.CurObj = objNr
+
 	if objNr > ctx.MaxObjNr {
 		ctx.MaxObjNr = objNr
 	}
@@ -2929,6 +3069,10 @@ func dereferenceXRefTable(c context.Context, ctx *model.Context, conf *model.Con
 		return err
 	}
 
+	if err := decryptDeferredStreams(c, ctx); err != nil {
+		return err
+	}
+
 	// Identify an optional Version entry in the root object/catalog.
 	if err := identifyRootVersion(xRefTable); err != nil {
 		return err
@@ -2941,8 +3085,43 @@ func dereferenceXRefTable(c context.Context, ctx *model.Context, conf *model.Con
 	return nil
 }
 
+// decryptDeferredStreams identifies embedded file streams and decrypts stream dicts
+// whose decryption has been deferred because embedded file streams use a different crypt filter.
+func decryptDeferredStreams(c context.Context, ctx *model.Context) error {
+	if ctx.EncKey == nil {
+		return nil
+	}
+
+	deferred := embeddedFileCryptDiffers(ctx)
+
+	ctx.EmbeddedFileStreams = ctx.EmbeddedFileStreamObjNrs()
+
+	if !deferred {
+		return nil
+	}
+
+	for objNr, entry := range ctx.Table {
+		if entry == nil || entry.Free || entry.Compressed || entry.Generation == nil {
+			continue
+		}
+		sd, ok := entry.Object.(types.StreamDict)
+		if !ok {
+			continue
+		}
+		if err := c.Err(); err != nil {
+			return err
+		}
+		if err := saveDecodedStreamContent(ctx, &sd, objNr, *entry.Generation, ctx.DecodeAllStreams); err != nil {
+			return err
+		}
+		entry.Object = sd
+	}
+
+	return nil
+}
+
 func handleUnencryptedFile(ctx *model.Context) error {
-	if ctx.Cmd == model.DECRYPT || ctx.Cmd == model.SETPERMISSIONS {
+	if ctx.Cmd == model.DECRYPT || ctx.Cmd == model.SETPERMISSIONS || ctx.Cmd == model.UPDATEENCRYPTION {
 		return errors.New("pdfcpu: this file is not encrypted")
 	}
 
@@ -2960,7 +3139,7 @@ func handleUnencryptedFile(ctx *model.Context) error {
 }
 
 func needsOwnerAndUserPassword(cmd model.CommandMode) bool {
-	return cmd == model.CHANGEOPW || cmd == model.CHANGEUPW || cmd == model.SETPERMISSIONS
+	return cmd == model.CHANGEOPW || cmd == model.CHANGEUPW || cmd == model.SETPERMISSIONS || cmd == model.UPDATEENCRYPTION
 }
 
 func handlePermissions(ctx *model.Context) error {
@@ -2979,11 +3158,26 @@ func handlePermissions(ctx *model.Context) error {
 	}
 
 	// Double check minimum permissions for pdfcpu processing.
-	if !hasNeededPermissions(ctx.Cmd, ctx.E) {
-		return errors.New("pdfcpu: operation restricted via pdfcpu's permission bits setting")
+	return neededPermissions(ctx.Cmd, ctx.E)
+}
+
+// lockEmbeddedFiles returns true if no password has been supplied for a file encrypting embedded files only (AuthEvent EFOpen)
+// and the command in progress does not need to decrypt or encrypt embedded file streams.
+func lockEmbeddedFiles(ctx *model.Context) bool {
+	if ctx.EncStrings || ctx.EncStreams || !ctx.EncEmbeddedStreams {
+		return false
 	}
 
-	return nil
+	if ctx.UserPW != "" || ctx.OwnerPW != "" {
+		return false
+	}
+
+	switch ctx.Cmd {
+	case model.DECRYPT, model.UPDATEENCRYPTION, model.EXTRACTATTACHMENTS, model.ADDATTACHMENTS, model.ADDATTACHMENTSPORTFOLIO:
+		return false
+	}
+
+	return true
 }
 
 func setupEncryptionKey(ctx *model.Context, d types.Dict) (err error) {
@@ -3007,7 +3201,7 @@ func setupEncryptionKey(ctx *model.Context, d types.Dict) (err error) {
 	// If the owner password does not match we generally move on if the user password is correct
 	// unless we need to insist on a correct owner password due to the specific command in progress.
 	if !ok && needsOwnerAndUserPassword(ctx.Cmd) {
-		return errors.New("pdfcpu: please provide the owner password with -opw")
+		return ErrOwnerPasswordRequired
 	}
 
 	// Generally the owner password, which is also regarded as the master password or set permissions password
@@ -3028,7 +3222,14 @@ func setupEncryptionKey(ctx *model.Context, d types.Dict) (err error) {
 		return err
 	}
 	if !ok {
-		return ErrWrongPassword
+		if !lockEmbeddedFiles(ctx) {
+			return ErrWrongPassword
+		}
+		// The document opens w/o password, embedded file streams stay encrypted.
+		// All other content is unencrypted, there is no key to derive from an empty password.
+		ctx.EmbeddedFilesLocked = true
+		ctx.EncKey = nil
+		return nil
 	}
 
 	//fmt.Printf("upw ok: %t\n", ok)
@@ -0,0 +1,87 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"bufio"
+	"io"
+	"sync"
+	"unsafe"
+
+	"github.com/pdfcpu/pdfcpu/pkg/log"
+)
+
+// Parsing an object reads a chunk of the file into a buffer which gets discarded once the object is parsed.
+// Pooling these buffers and the positioned readers avoids allocating them for each object read.
+
+var objectBufPool = sync.Pool{
+	New: func() any {
+		b := make([]byte, 0, 4*defaultBufSize)
+		return &b
+	},
+}
+
+var readerPool = sync.Pool{
+	New: func() any {
+		return bufio.NewReader(nil)
+	},
+}
+
+func getObjectBuf() []byte {
+	return (*objectBufPool.Get().(*[]byte))[:0]
+}
+
+func putObjectBuf(buf []byte) {
+	if buf == nil || cap(buf) > maximumBufSize {
+		// Let the GC take care of exceptionally big buffers.
+		return
+	}
+	buf = buf[:0]
+	objectBufPool.Put(&buf)
+}
+
+// pooledPositionedReader returns a pooled buffered reader positioned at offset.
+// The reader must be released using releaseReader.
+func pooledPositionedReader(rs io.ReadSeeker, offset *int64) (*bufio.Reader, error) {
+	if _, err := rs.Seek(*offset, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	if log.ReadEnabled() {
+		log.Read.Printf("pooledPositionedReader: positioned to offset: %d\n", *offset)
+	}
+
+	rd := readerPool.Get().(*bufio.Reader)
+	rd.Reset(rs)
+
+	return rd, nil
+}
+
+func releaseReader(rd *bufio.Reader) {
+	rd.Reset(nil)
+	readerPool.Put(rd)
+}
+
+// byteString returns a string sharing its memory with b.
+// b must not be modified as long as the returned string is in use
+// and the string must not be retained.
+func byteString(b []byte) string {
+	if len(b) == 0 {
+		return ""
+	}
+	return unsafe.String(&b[0], len(b))
+}
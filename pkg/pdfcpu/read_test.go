@@ -202,3 +202,63 @@ func TestReadLargeDictObjectStream(t *testing.T) {
 		t.Errorf("expected stream content %s, got %s", expected, string(d.Content))
 	}
 }
+
+func TestReadFileMemoryMapped(t *testing.T) {
+	inFile := filepath.Join("..", "testdata", "gobook.0.pdf")
+
+	ctx1, err := ReadFile(inFile, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	conf := model.NewDefaultConfiguration()
+	conf.MemoryMappedRead = true
+
+	ctx2, err := ReadFile(inFile, conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if ctx1.PageCount != ctx2.PageCount || *ctx1.Size != *ctx2.Size || ctx1.Read.FileSize != ctx2.Read.FileSize {
+		t.Fatalf("memory mapped read differs: pages %d/%d, size %d/%d", ctx1.PageCount, ctx2.PageCount, *ctx1.Size, *ctx2.Size)
+	}
+}
+
+func benchmarkReadFile(b *testing.B, mmap bool) {
+	inFile := filepath.Join("..", "testdata", "WaldenFull.pdf")
+
+	conf := model.NewDefaultConfiguration()
+	conf.MemoryMappedRead = mmap
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		if _, err := ReadFile(inFile, conf); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkReadFile(b *testing.B) {
+	benchmarkReadFile(b, false)
+}
+
+func BenchmarkReadFileMemoryMapped(b *testing.B) {
+	benchmarkReadFile(b, true)
+}
+
+func TestGrowBufByReusedBuffer(t *testing.T) {
+	buf := getObjectBuf()
+	buf = append(buf, "stale content"...)
+	putObjectBuf(buf)
+
+	buf, err := growBufBy(getObjectBuf(), 8, bytes.NewReader([]byte("1 0 obj")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer putObjectBuf(buf)
+
+	if want := "1 0 obj\x00"; string(buf) != want {
+		t.Fatalf("got %q, want %q", buf, want)
+	}
+}
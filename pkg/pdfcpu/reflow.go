@@ -0,0 +1,419 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"archive/zip"
+	"encoding/hex"
+	"fmt"
+	"html"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// Supported reflow formats.
+const (
+	ReflowHTML = "html"
+	ReflowEPUB = "epub"
+)
+
+// HTML elements for standard structure types (see 14.8.4).
+var reflowElements = map[string]string{
+	"Document": "div", "DocumentFragment": "div", "Part": "div", "Div": "div",
+	"Art": "article", "Sect": "section", "Aside": "aside", "BlockQuote": "blockquote",
+	"P": "p", "H": "h2", "H1": "h1", "H2": "h2", "H3": "h3", "H4": "h4", "H5": "h5", "H6": "h6", "Title": "h1",
+	"LI": "li", "TOC": "ul", "TOCI": "li",
+	"Table": "table", "TR": "tr", "TH": "th", "TD": "td", "THead": "thead", "TBody": "tbody", "TFoot": "tfoot",
+	"Figure": "figure", "Formula": "span", "Span": "span", "Quote": "q", "Code": "code",
+	"Em": "em", "Strong": "strong", "Sub": "sub", "Note": "aside", "FENote": "aside", "Link": "a",
+}
+
+// Heading structure types.
+var reflowHeadings = map[string]bool{
+	"H": true, "H1": true, "H2": true, "H3": true, "H4": true, "H5": true, "H6": true, "Title": true,
+}
+
+// reflowHeading is a heading of a reflowed document used for the EPUB navigation document.
+type reflowHeading struct {
+	id, text string
+}
+
+// reflowWriter renders the content of a document as XHTML compatible markup.
+type reflowWriter struct {
+	sb       strings.Builder
+	headings []reflowHeading
+}
+
+// reflowText returns s without control characters not allowed in XML and with line breaks replaced by spaces.
+func reflowText(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r == '\n' || r == '\r' || r == '\t':
+			return ' '
+		case r < 0x20 || r == 0xFFFE || r == 0xFFFF:
+			return -1
+		}
+		return r
+	}, s)
+}
+
+func (rw *reflowWriter) attr(name, value string) {
+	rw.sb.WriteString(" " + name + "=\"" + html.EscapeString(reflowText(value)) + "\"")
+}
+
+// plainText returns the text of se and its descendants honoring ActualText.
+func plainText(se *StructElem) string {
+	if se.ActualText != "" {
+		return se.ActualText
+	}
+	if se.Type == "" && len(se.Kids) == 0 {
+		return se.Text
+	}
+	var ss []string
+	for _, kid := range se.Kids {
+		if s := strings.TrimSpace(plainText(kid)); s != "" {
+			ss = append(ss, s)
+		}
+	}
+	return strings.Join(ss, " ")
+}
+
+func listElement(se *StructElem) string {
+	switch se.Attrs["ListNumbering"] {
+	case "Decimal", "UpperRoman", "LowerRoman", "UpperAlpha", "LowerAlpha":
+		return "ol"
+	}
+	return "ul"
+}
+
+func (rw *reflowWriter) kids(se *StructElem, depth int) {
+	for _, kid := range se.Kids {
+		rw.elem(kid, se, depth+1)
+	}
+}
+
+func (rw *reflowWriter) elem(se, parent *StructElem, depth int) {
+	if depth > maxStructDepth {
+		return
+	}
+
+	if se.Type == "" {
+		// Content item.
+		rw.sb.WriteString(html.EscapeString(reflowText(se.Text)))
+		return
+	}
+
+	var tag string
+
+	switch se.Type {
+	case "L":
+		tag = listElement(se)
+	case "Lbl":
+		// List labels are rendered by the list element.
+		if parent != nil && parent.Type == "LI" {
+			return
+		}
+		tag = "span"
+	case "LBody", "NonStruct", "Private", "Reference", "Annot", "Form", "Index", "BibEntry":
+		tag = ""
+	case "Caption":
+		tag = "p"
+		if parent != nil {
+			switch parent.Type {
+			case "Table":
+				tag = "caption"
+			case "Figure":
+				tag = "figcaption"
+			}
+		}
+	case "Artifact":
+		return
+	default:
+		tag = reflowElements[se.Type]
+	}
+
+	if tag == "" {
+		if se.ActualText != "" {
+			rw.sb.WriteString(html.EscapeString(reflowText(se.ActualText)))
+			return
+		}
+		rw.kids(se, depth)
+		return
+	}
+
+	rw.sb.WriteString("<" + tag)
+
+	if reflowHeadings[se.Type] {
+		id := "h" + strconv.Itoa(len(rw.headings)+1)
+		rw.attr("id", id)
+		rw.headings = append(rw.headings, reflowHeading{id: id, text: reflowText(plainText(se))})
+	}
+
+	if se.Lang != "" {
+		rw.attr("lang", se.Lang)
+	}
+
+	switch tag {
+	case "a":
+		if se.URI != "" {
+			rw.attr("href", se.URI)
+		}
+	case "td", "th":
+		if s := se.Attrs["RowSpan"]; s != "" && s != "1" {
+			rw.attr("rowspan", s)
+		}
+		if s := se.Attrs["ColSpan"]; s != "" && s != "1" {
+			rw.attr("colspan", s)
+		}
+		if s := se.Attrs["Scope"]; tag == "th" && s != "" {
+			rw.attr("scope", strings.ToLower(s))
+		}
+	}
+
+	if se.Alt != "" {
+		if tag == "figure" || tag == "span" {
+			rw.attr("role", "img")
+		}
+		rw.attr("aria-label", se.Alt)
+	}
+
+	rw.sb.WriteString(">")
+
+	if se.ActualText != "" {
+		rw.sb.WriteString(html.EscapeString(reflowText(se.ActualText)))
+	} else {
+		rw.kids(se, depth)
+	}
+
+	rw.sb.WriteString("</" + tag + ">\n")
+}
+
+// untagged renders the text of all pages of ctx as one section per page and one paragraph per line.
+func (rw *reflowWriter) untagged(ctx *model.Context) error {
+	for i := 1; i <= ctx.PageCount; i++ {
+		s, err := PageText(ctx, i)
+		if err != nil {
+			return err
+		}
+		rw.sb.WriteString("<section id=\"page" + strconv.Itoa(i) + "\">\n")
+		for _, line := range strings.Split(s, "\n") {
+			if line = strings.TrimSpace(reflowText(line)); line != "" {
+				rw.sb.WriteString("<p>" + html.EscapeString(line) + "</p>\n")
+			}
+		}
+		rw.sb.WriteString("</section>\n")
+	}
+	return nil
+}
+
+func newReflowWriter(ctx *model.Context) (*reflowWriter, string, error) {
+	rw := &reflowWriter{}
+
+	root, err := StructTree(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if root == nil {
+		if err := rw.untagged(ctx); err != nil {
+			return nil, "", err
+		}
+		return rw, Language(ctx), nil
+	}
+
+	rw.kids(root, 0)
+
+	return rw, Language(ctx), nil
+}
+
+func documentTitle(ctx *model.Context, source string) string {
+	if ctx.Title != "" {
+		return ctx.Title
+	}
+	return source
+}
+
+func (rw *reflowWriter) document(w io.Writer, title, lang string, xhtml bool) error {
+	var sb strings.Builder
+
+	if xhtml {
+		sb.WriteString("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n<!DOCTYPE html>\n<html xmlns=\"http://www.w3.org/1999/xhtml\"")
+		if lang != "" {
+			sb.WriteString(" xml:lang=\"" + html.EscapeString(reflowText(lang)) + "\"")
+		}
+	} else {
+		sb.WriteString("<!DOCTYPE html>\n<html")
+	}
+	if lang != "" {
+		sb.WriteString(" lang=\"" + html.EscapeString(reflowText(lang)) + "\"")
+	}
+	sb.WriteString(">\n<head>\n<meta charset=\"utf-8\"/>\n<title>" + html.EscapeString(reflowText(title)) + "</title>\n</head>\n<body>\n")
+
+	if _, err := io.WriteString(w, sb.String()); err != nil {
+		return err
+	}
+	if _, err := io.WriteString(w, rw.sb.String()); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "</body>\n</html>\n")
+	return err
+}
+
+// navigation renders the EPUB navigation document listing all headings (see EPUB 3 Packages, 7).
+func (rw *reflowWriter) navigation(w io.Writer, title, lang string) error {
+	var sb strings.Builder
+
+	sb.WriteString("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n<!DOCTYPE html>\n")
+	sb.WriteString("<html xmlns=\"http://www.w3.org/1999/xhtml\" xmlns:epub=\"http://www.idpf.org/2007/ops\"")
+	if lang != "" {
+		sb.WriteString(" xml:lang=\"" + html.EscapeString(reflowText(lang)) + "\" lang=\"" + html.EscapeString(reflowText(lang)) + "\"")
+	}
+	sb.WriteString(">\n<head>\n<meta charset=\"utf-8\"/>\n<title>" + html.EscapeString(reflowText(title)) + "</title>\n</head>\n<body>\n")
+	sb.WriteString("<nav epub:type=\"toc\" id=\"toc\">\n<ol>\n")
+
+	if len(rw.headings) == 0 {
+		sb.WriteString("<li><a href=\"content.xhtml\">" + html.EscapeString(reflowText(title)) + "</a></li>\n")
+	}
+
+	for _, h := range rw.headings {
+		s := strings.TrimSpace(h.text)
+		if s == "" {
+			s = h.id
+		}
+		sb.WriteString("<li><a href=\"content.xhtml#" + h.id + "\">" + html.EscapeString(s) + "</a></li>\n")
+	}
+
+	sb.WriteString("</ol>\n</nav>\n</body>\n</html>\n")
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+func epubIdentifier(ctx *model.Context) string {
+	if len(ctx.ID) > 0 {
+		if s, err := types.StringOrHexLiteral(ctx.ID[0]); err == nil && s != nil && *s != "" {
+			return "urn:pdfcpu:" + hex.EncodeToString([]byte(*s))
+		}
+	}
+	return fmt.Sprintf("urn:pdfcpu:%d", time.Now().UnixNano())
+}
+
+const epubContainer = `<?xml version="1.0" encoding="UTF-8"?>
+<container version="1.0" xmlns="urn:oasis:names:tc:opendocument:xmlns:container">
+<rootfiles>
+<rootfile full-path="OEBPS/content.opf" media-type="application/oebps-package+xml"/>
+</rootfiles>
+</container>
+`
+
+func epubPackage(ctx *model.Context, title, lang string) string {
+	if lang == "" {
+		lang = "en"
+	}
+
+	e := func(s string) string { return html.EscapeString(reflowText(s)) }
+
+	var sb strings.Builder
+	sb.WriteString("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n")
+	sb.WriteString("<package xmlns=\"http://www.idpf.org/2007/opf\" version=\"3.0\" unique-identifier=\"uid\">\n")
+	sb.WriteString("<metadata xmlns:dc=\"http://purl.org/dc/elements/1.1/\">\n")
+	sb.WriteString("<dc:identifier id=\"uid\">" + e(epubIdentifier(ctx)) + "</dc:identifier>\n")
+	sb.WriteString("<dc:title>" + e(title) + "</dc:title>\n")
+	sb.WriteString("<dc:language>" + e(lang) + "</dc:language>\n")
+	if ctx.Author != "" {
+		sb.WriteString("<dc:creator>" + e(ctx.Author) + "</dc:creator>\n")
+	}
+	sb.WriteString("<meta property=\"dcterms:modified\">" + time.Now().UTC().Format("2006-01-02T15:04:05Z") + "</meta>\n")
+	sb.WriteString("</metadata>\n<manifest>\n")
+	sb.WriteString("<item id=\"nav\" href=\"nav.xhtml\" media-type=\"application/xhtml+xml\" properties=\"nav\"/>\n")
+	sb.WriteString("<item id=\"content\" href=\"content.xhtml\" media-type=\"application/xhtml+xml\"/>\n")
+	sb.WriteString("</manifest>\n<spine>\n<itemref idref=\"content\"/>\n</spine>\n</package>\n")
+
+	return sb.String()
+}
+
+func (rw *reflowWriter) epub(ctx *model.Context, w io.Writer, title, lang string) error {
+	zw := zip.NewWriter(w)
+
+	// The mimetype entry must come first and be stored uncompressed (see EPUB 3 OCF, 4.3).
+	fw, err := zw.CreateHeader(&zip.FileHeader{Name: "mimetype", Method: zip.Store})
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(fw, "application/epub+zip"); err != nil {
+		return err
+	}
+
+	entries := []struct {
+		name  string
+		write func(io.Writer) error
+	}{
+		{"META-INF/container.xml", func(w io.Writer) error {
+			_, err := io.WriteString(w, epubContainer)
+			return err
+		}},
+		{"OEBPS/content.opf", func(w io.Writer) error {
+			_, err := io.WriteString(w, epubPackage(ctx, title, lang))
+			return err
+		}},
+		{"OEBPS/nav.xhtml", func(w io.Writer) error {
+			return rw.navigation(w, title, lang)
+		}},
+		{"OEBPS/content.xhtml", func(w io.Writer) error {
+			return rw.document(w, title, lang, true)
+		}},
+	}
+
+	for _, e := range entries {
+		fw, err := zw.Create(e.name)
+		if err != nil {
+			return err
+		}
+		if err := e.write(fw); err != nil {
+			return err
+		}
+	}
+
+	return zw.Close()
+}
+
+// Reflow writes the content of ctx read from source to w as HTML or EPUB.
+// Tagged documents are rendered following their logical structure including headings, lists and tables.
+// For untagged documents each page becomes a section holding one paragraph per line of text.
+func Reflow(ctx *model.Context, w io.Writer, source, format string) error {
+	rw, lang, err := newReflowWriter(ctx)
+	if err != nil {
+		return err
+	}
+
+	title := documentTitle(ctx, source)
+
+	switch format {
+	case ReflowHTML:
+		return rw.document(w, title, lang, false)
+	case ReflowEPUB:
+		return rw.epub(ctx, w, title, lang)
+	}
+
+	return errors.Errorf("pdfcpu: unsupported reflow format: %s", format)
+}
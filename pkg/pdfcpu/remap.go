@@ -0,0 +1,415 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"fmt"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// pageRemap maps page dict object numbers no longer part of the page tree to the page now holding their content.
+// A nil value denotes a page that got dropped.
+type pageRemap struct {
+	pages map[int]*types.IndirectRef
+	// keepView retains the destination view parameters, otherwise the target page gets displayed using /Fit.
+	keepView bool
+	dropped  int
+}
+
+// remapDestArray remaps the explicit destination arr and returns the result or nil if arr points to a dropped page.
+func (m *pageRemap) remapDestArray(arr types.Array) types.Array {
+	if len(arr) == 0 {
+		return arr
+	}
+	ir, ok := arr[0].(types.IndirectRef)
+	if !ok {
+		// Remote destination
+		return arr
+	}
+	ir1, ok := m.pages[ir.ObjectNumber.Value()]
+	if !ok {
+		return arr
+	}
+	if ir1 == nil {
+		m.dropped++
+		return nil
+	}
+	if m.keepView {
+		arr[0] = *ir1
+		return arr
+	}
+	return types.Array{*ir1, types.Name("Fit")}
+}
+
+// remapDest remaps the destination o which is either an explicit destination or a dict containing one under "D".
+// It returns the remapped object and false if o points to a dropped page.
+func (m *pageRemap) remapDest(ctx *model.Context, o types.Object) (types.Object, bool, error) {
+	ir, isIndRef := o.(types.IndirectRef)
+
+	o1, err := ctx.Dereference(o)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var res types.Object
+
+	switch o1 := o1.(type) {
+	case types.Array:
+		arr := m.remapDestArray(o1)
+		if arr == nil {
+			return nil, false, nil
+		}
+		res = arr
+	case types.Dict:
+		arr, err := ctx.DereferenceArray(o1["D"])
+		if err != nil || arr == nil {
+			return o, true, err
+		}
+		if arr = m.remapDestArray(arr); arr == nil {
+			return nil, false, nil
+		}
+		o1["D"] = arr
+		res = o1
+	default:
+		// Named destinations get remapped separately.
+		return o, true, nil
+	}
+
+	if isIndRef {
+		if entry, ok := ctx.FindTableEntryForIndRef(&ir); ok {
+			entry.Object = res
+		}
+		return o, true, nil
+	}
+
+	return res, true, nil
+}
+
+// remapDestOrAction remaps the destination of an outline item or link annotation d.
+func (m *pageRemap) remapDestOrAction(ctx *model.Context, d types.Dict) error {
+	if o, found := d.Find("Dest"); found {
+		o1, ok, err := m.remapDest(ctx, o)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			d.Delete("Dest")
+			return nil
+		}
+		d["Dest"] = o1
+		return nil
+	}
+
+	o, found := d.Find("A")
+	if !found {
+		return nil
+	}
+	act, err := ctx.DereferenceDict(o)
+	if err != nil || act == nil {
+		return err
+	}
+	if s := act.NameEntry("S"); s == nil || *s != "GoTo" {
+		return nil
+	}
+	o1, ok, err := m.remapDest(ctx, act["D"])
+	if err != nil {
+		return err
+	}
+	if !ok {
+		d.Delete("A")
+		return nil
+	}
+	act["D"] = o1
+	return nil
+}
+
+func (m *pageRemap) remapOutlineItems(ctx *model.Context, ir *types.IndirectRef) error {
+	var (
+		d   types.Dict
+		err error
+	)
+
+	for ; ir != nil; ir = d.IndirectRefEntry("Next") {
+		if d, err = ctx.DereferenceDict(*ir); err != nil {
+			return err
+		}
+		if d == nil {
+			return nil
+		}
+		if err := m.remapDestOrAction(ctx, d); err != nil {
+			return err
+		}
+		if err := m.remapOutlineItems(ctx, d.IndirectRefEntry("First")); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *pageRemap) remapNamedDests(ctx *model.Context) error {
+	for k, v := range ctx.Dests {
+		o, ok, err := m.remapDest(ctx, v)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			delete(ctx.Dests, k)
+			continue
+		}
+		ctx.Dests[k] = o
+	}
+
+	n, ok := ctx.Names["Dests"]
+	if !ok {
+		return nil
+	}
+
+	var obsolete []string
+
+	remapValue := func(xRefTable *model.XRefTable, k string, v *types.Object) error {
+		o, ok, err := m.remapDest(ctx, *v)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			// Free the destination only, its page is gone or about to be deleted.
+			if ir, ok := (*v).(types.IndirectRef); ok {
+				if err := ctx.FreeObject(ir.ObjectNumber.Value()); err != nil {
+					return err
+				}
+			}
+			*v = nil
+			obsolete = append(obsolete, k)
+			return nil
+		}
+		*v = o
+		return nil
+	}
+
+	if err := n.Process(ctx.XRefTable, remapValue); err != nil {
+		return err
+	}
+
+	for _, k := range obsolete {
+		empty, _, err := n.Remove(ctx.XRefTable, k)
+		if err != nil {
+			return err
+		}
+		if empty {
+			return removeDestsNameTree(ctx)
+		}
+	}
+
+	return nil
+}
+
+// removeDestsNameTree removes the empty name tree for named destinations.
+func removeDestsNameTree(ctx *model.Context) error {
+	delete(ctx.Names, "Dests")
+
+	o, found := ctx.RootDict.Find("Names")
+	if !found {
+		return nil
+	}
+
+	d, err := ctx.DereferenceDict(o)
+	if err != nil || d == nil {
+		return err
+	}
+
+	// The tree nodes are stale at this point and must not be followed.
+	d.Delete("Dests")
+
+	return nil
+}
+
+func (m *pageRemap) remapLinks(ctx *model.Context) error {
+	irs, err := pageIndRefs(ctx)
+	if err != nil {
+		return err
+	}
+	for _, ir := range irs {
+		d, err := ctx.DereferenceDict(ir)
+		if err != nil {
+			return err
+		}
+		if d == nil {
+			continue
+		}
+		annots, err := ctx.DereferenceArray(d["Annots"])
+		if err != nil {
+			return err
+		}
+		for _, o := range annots {
+			d1, err := ctx.DereferenceDict(o)
+			if err != nil {
+				return err
+			}
+			if st := d1.Subtype(); st == nil || *st != "Link" {
+				continue
+			}
+			if err := m.remapDestOrAction(ctx, d1); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// remapDestinations updates all bookmarks, named destinations and links of ctx according to m.
+// Destinations pointing to dropped pages are removed.
+func remapDestinations(ctx *model.Context, m *pageRemap) error {
+	if len(m.pages) == 0 {
+		return nil
+	}
+
+	if ctx.Outlines != nil {
+		if err := m.remapOutlineItems(ctx, ctx.Outlines.IndirectRefEntry("First")); err != nil {
+			return err
+		}
+	}
+
+	if err := m.remapNamedDests(ctx); err != nil {
+		return err
+	}
+
+	if err := m.remapLinks(ctx); err != nil {
+		return err
+	}
+
+	if m.dropped > 0 {
+		model.ShowWarning(fmt.Sprintf("dropped %d bookmark/link destination(s) pointing to pages not contained in the result", m.dropped))
+	}
+
+	return nil
+}
+
+func collectPageIndRefs(ctx *model.Context, o types.Object, irs *[]types.IndirectRef) error {
+	ir, ok := o.(types.IndirectRef)
+	if !ok {
+		return nil
+	}
+	d, err := ctx.DereferenceDict(ir)
+	if err != nil || d == nil {
+		return err
+	}
+	if t := d.Type(); t != nil && *t == "Page" {
+		*irs = append(*irs, ir)
+		return nil
+	}
+	kids, err := ctx.DereferenceArray(d["Kids"])
+	if err != nil {
+		return err
+	}
+	for _, kid := range kids {
+		if err := collectPageIndRefs(ctx, kid, irs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pageIndRefs returns the indirect references of all page dicts of ctx in page order.
+func pageIndRefs(ctx *model.Context) ([]types.IndirectRef, error) {
+	ir, err := ctx.Pages()
+	if err != nil {
+		return nil, err
+	}
+	irs := []types.IndirectRef{}
+	if err := collectPageIndRefs(ctx, *ir, &irs); err != nil {
+		return nil, err
+	}
+	return irs, nil
+}
+
+// pageObjNrs returns the object numbers of all page dicts of ctx in page order.
+func pageObjNrs(ctx *model.Context) ([]int, error) {
+	irs, err := pageIndRefs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	objNrs := make([]int, len(irs))
+	for i, ir := range irs {
+		objNrs[i] = ir.ObjectNumber.Value()
+	}
+	return objNrs, nil
+}
+
+// pageRemapForSheets returns the page remapping for original pages objNrs rendered onto sheets, n pages per sheet.
+// pageNrs holds the original page numbers in the order of rendering, 0 denoting a blank.
+func pageRemapForSheets(objNrs []int, sheets types.Array, pageNrs []int, n int) *pageRemap {
+	m := &pageRemap{pages: map[int]*types.IndirectRef{}}
+
+	for _, objNr := range objNrs {
+		m.pages[objNr] = nil
+	}
+
+	for i, pageNr := range pageNrs {
+		if pageNr < 1 || pageNr > len(objNrs) || i/n >= len(sheets) {
+			continue
+		}
+		ir, ok := sheets[i/n].(types.IndirectRef)
+		if !ok {
+			continue
+		}
+		objNr := objNrs[pageNr-1]
+		if m.pages[objNr] == nil {
+			m.pages[objNr] = &ir
+		}
+	}
+
+	return m
+}
+
+// pageRemapForAddedPages returns the page remapping for links and named destinations of pages migrated from ctxSrc.
+// Link targets migrated before their page got added are redirected to the added page.
+// Links to pages not contained in pageNrs are dropped.
+func pageRemapForAddedPages(ctxSrc *model.Context, pageNrs []int, migrated, copies map[int]int) (*pageRemap, error) {
+	objNrs, err := pageObjNrs(ctxSrc)
+	if err != nil {
+		return nil, err
+	}
+
+	selected := map[int]bool{}
+	for _, pageNr := range pageNrs {
+		if pageNr > 0 && pageNr <= len(objNrs) {
+			selected[objNrs[pageNr-1]] = true
+		}
+	}
+
+	// Named destinations of pages not migrated got patched to object number 0.
+	m := &pageRemap{pages: map[int]*types.IndirectRef{0: nil}, keepView: true}
+
+	for _, objNr := range objNrs {
+		if !selected[objNr] && migrated[objNr] > 0 {
+			m.pages[migrated[objNr]] = nil
+		}
+	}
+
+	for objNr, srcObjNr := range copies {
+		if !selected[srcObjNr] {
+			m.pages[objNr] = nil
+			continue
+		}
+		m.pages[objNr] = types.NewIndirectRef(migrated[srcObjNr], 0)
+	}
+
+	return m, nil
+}
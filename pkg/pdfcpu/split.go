@@ -0,0 +1,244 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// SplitPart describes a file resulting from splitting a PDF.
+type SplitPart struct {
+	BaseName string // input file name without extension
+	Nr       int    // part number starting with 1
+	From     int    // first page
+	Thru     int    // last page
+	Bookmark string // bookmark title when splitting along bookmarks
+}
+
+// Expand returns pattern with all placeholders replaced:
+//
+//	%basename% ... input file name without extension
+//	%n%        ... part number
+//	%from%     ... first page
+//	%thru%     ... last page
+//	%bookmark% ... bookmark title (splitting along bookmarks only)
+//	%title%    ... title of the input file
+//	%author%   ... author of the input file
+func (p SplitPart) Expand(pattern string, xRefTable *model.XRefTable) string {
+	r := strings.NewReplacer(
+		"%basename%", p.BaseName,
+		"%n%", strconv.Itoa(p.Nr),
+		"%from%", strconv.Itoa(p.From),
+		"%thru%", strconv.Itoa(p.Thru),
+		"%bookmark%", p.Bookmark,
+		"%title%", xRefTable.Title,
+		"%author%", xRefTable.Author,
+	)
+	return r.Replace(pattern)
+}
+
+func copyInfoDict(ctxSrc *model.Context, d types.Dict) error {
+	if ctxSrc.Info == nil {
+		return nil
+	}
+
+	dSrc, err := ctxSrc.DereferenceDict(*ctxSrc.Info)
+	if err != nil || dSrc == nil {
+		return err
+	}
+
+	for k, v := range dSrc {
+		o, err := ctxSrc.Dereference(v)
+		if err != nil {
+			return err
+		}
+		switch o.(type) {
+		case types.Dict, types.Array, types.StreamDict, nil:
+			// Skip anything we would have to deep copy.
+			continue
+		}
+		d[k] = o
+	}
+
+	return nil
+}
+
+func insertInfoString(d types.Dict, k, s string) error {
+	s1, err := types.EscapedUTF16String(s)
+	if err != nil {
+		return err
+	}
+	d[k] = types.StringLiteral(*s1)
+	return nil
+}
+
+// SetSplitPartInfo sets the document information dict of ctxDest representing part p of ctxSrc
+// according to the split configuration of ctxSrc.
+func SetSplitPartInfo(ctxSrc, ctxDest *model.Context, p SplitPart) error {
+	conf := ctxSrc.Configuration
+	if !conf.SplitCopyMetadata && conf.SplitTitle == "" && conf.SplitAuthor == "" {
+		return nil
+	}
+
+	d := types.NewDict()
+
+	if conf.SplitCopyMetadata {
+		if err := copyInfoDict(ctxSrc, d); err != nil {
+			return err
+		}
+	}
+
+	if conf.SplitTitle != "" {
+		if err := insertInfoString(d, "Title", p.Expand(conf.SplitTitle, ctxSrc.XRefTable)); err != nil {
+			return err
+		}
+	}
+
+	if conf.SplitAuthor != "" {
+		if err := insertInfoString(d, "Author", p.Expand(conf.SplitAuthor, ctxSrc.XRefTable)); err != nil {
+			return err
+		}
+	}
+
+	ir, err := ctxDest.IndRefForNewObject(d)
+	if err != nil {
+		return err
+	}
+
+	ctxDest.Info = ir
+
+	return nil
+}
+
+// SplitSection is a page range delimited by bookmarks.
+type SplitSection struct {
+	File  string `json:"file,omitempty"`
+	Nr    string `json:"nr"` // hierarchical number, eg. 02.1
+	Title string `json:"title"`
+	Level int    `json:"level"`
+	From  int    `json:"from"`
+	Thru  int    `json:"thru"`
+}
+
+// SplitManifest maps the files resulting from splitting along bookmarks to the page ranges of the source file.
+type SplitManifest struct {
+	Source   string         `json:"source"`
+	Level    int            `json:"level"`
+	Sections []SplitSection `json:"files"`
+}
+
+func sectionNr(prefix string, i, count int) string {
+	s := fmt.Sprintf("%0*d", len(strconv.Itoa(count)), i+1)
+	if prefix == "" {
+		return s
+	}
+	return prefix + "." + s
+}
+
+func flattenBookmarks(bms []Bookmark, prefix string, level, maxLevel int, ss *[]SplitSection) {
+	for i, bm := range bms {
+		nr := sectionNr(prefix, i, len(bms))
+		*ss = append(*ss, SplitSection{Nr: nr, Title: bm.Title, Level: level, From: bm.PageFrom})
+		if level < maxLevel {
+			flattenBookmarks(bm.Kids, nr, level+1, maxLevel, ss)
+		}
+	}
+}
+
+// BookmarkSections returns the page ranges delimited by the bookmarks of ctx up to level.
+// A section reaches until before the next section starting at a later page.
+// Sections of parent bookmarks are limited to any pages preceding their first kid.
+func BookmarkSections(ctx *model.Context, level int) ([]SplitSection, error) {
+	if level < 1 {
+		return nil, errors.Errorf("pdfcpu: invalid bookmark level: %d", level)
+	}
+
+	bms, err := Bookmarks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(bms) == 0 {
+		return nil, errNoBookmarks
+	}
+
+	var ss []SplitSection
+	flattenBookmarks(bms, "", 1, level, &ss)
+
+	ss1 := []SplitSection{}
+	for i, s := range ss {
+		s.Thru = ctx.PageCount
+		if i+1 < len(ss) {
+			next := ss[i+1].From
+			if next == s.From && ss[i+1].Level > s.Level {
+				// This section has no pages of its own.
+				continue
+			}
+			s.Thru = s.From
+			if next > s.From {
+				s.Thru = next - 1
+			}
+		}
+		if s.From < 1 || s.From > ctx.PageCount {
+			continue
+		}
+		ss1 = append(ss1, s)
+	}
+
+	return ss1, nil
+}
+
+// SanitizedFileName returns s reduced to characters safe for file names.
+func SanitizedFileName(s string) string {
+	var sb strings.Builder
+	sep := false
+	for _, r := range strings.TrimSpace(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' || r == '.' {
+			sb.WriteRune(r)
+			sep = false
+			continue
+		}
+		if !sep && sb.Len() > 0 {
+			sb.WriteRune('_')
+			sep = true
+		}
+	}
+
+	s = strings.Trim(sb.String(), "_.")
+
+	if rr := []rune(s); len(rr) > 64 {
+		s = strings.TrimRight(string(rr[:64]), "_.")
+	}
+
+	return s
+}
+
+// FileName returns the file name for section s consisting of its number and sanitized title.
+func (s SplitSection) FileName() string {
+	t := SanitizedFileName(s.Title)
+	if t == "" {
+		return s.Nr + ".pdf"
+	}
+	return s.Nr + "_" + t + ".pdf"
+}
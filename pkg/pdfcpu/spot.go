@@ -0,0 +1,832 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// Colorant is a colorant of Separation or DeviceN color spaces (see 8.6.6.4 and 8.6.6.5).
+type Colorant struct {
+	Name       string `json:"name"`
+	Alternate  string `json:"alternate"`  // Alternate color space of the first definition.
+	Separation bool   `json:"separation"` // Defined by a Separation color space.
+	DeviceN    bool   `json:"deviceN"`    // Part of a DeviceN color space.
+	Pages      []int  `json:"pages"`      // Pages using the colorant, empty for unused colorants.
+}
+
+// maxColorSpaceDepth limits the recursion into nested objects while looking for color spaces.
+const maxColorSpaceDepth = 32
+
+func reservedColorant(s string) bool {
+	return s == "All" || s == "None"
+}
+
+// separation returns the colorant of a Separation color space array.
+func separation(arr types.Array) (string, bool) {
+	if len(arr) != 4 {
+		return "", false
+	}
+	if n, ok := arr[0].(types.Name); !ok || n != "Separation" {
+		return "", false
+	}
+	n, ok := arr[1].(types.Name)
+	return n.Value(), ok
+}
+
+// deviceN returns the colorant names array of a DeviceN color space array.
+func deviceN(ctx *model.Context, arr types.Array) (types.Array, bool) {
+	if len(arr) != 4 && len(arr) != 5 {
+		return nil, false
+	}
+	if n, ok := arr[0].(types.Name); !ok || n != "DeviceN" {
+		return nil, false
+	}
+	names, err := ctx.DereferenceArray(arr[1])
+	return names, err == nil && names != nil
+}
+
+func alternateName(ctx *model.Context, o types.Object) string {
+	o, err := ctx.Dereference(o)
+	if err != nil {
+		return ""
+	}
+	switch o := o.(type) {
+	case types.Name:
+		return o.Value()
+	case types.Array:
+		if len(o) > 0 {
+			if n, ok := o[0].(types.Name); ok {
+				return n.Value()
+			}
+		}
+	}
+	return ""
+}
+
+// forEachColorSpace calls fn for each Separation and DeviceN color space array contained in o.
+func forEachColorSpace(ctx *model.Context, o types.Object, depth int, fn func(arr types.Array)) {
+	if depth > maxColorSpaceDepth {
+		return
+	}
+
+	switch o := o.(type) {
+
+	case types.Dict:
+		for _, v := range o {
+			forEachColorSpace(ctx, v, depth+1, fn)
+		}
+
+	case types.StreamDict:
+		forEachColorSpace(ctx, o.Dict, depth+1, fn)
+
+	case types.Array:
+		if _, ok := separation(o); ok {
+			fn(o)
+			forEachColorSpace(ctx, o[2], depth+1, fn)
+			return
+		}
+		if _, ok := deviceN(ctx, o); ok {
+			fn(o)
+			for _, v := range o[2:] {
+				forEachColorSpace(ctx, v, depth+1, fn)
+			}
+			return
+		}
+		for _, v := range o {
+			forEachColorSpace(ctx, v, depth+1, fn)
+		}
+	}
+}
+
+// forEachObjectColorSpace calls fn for each Separation and DeviceN color space array of ctx.
+func forEachObjectColorSpace(ctx *model.Context, fn func(arr types.Array)) {
+	objNrs := make([]int, 0, len(ctx.Table))
+	for objNr := range ctx.Table {
+		objNrs = append(objNrs, objNr)
+	}
+	sort.Ints(objNrs)
+
+	for _, objNr := range objNrs {
+		entry := ctx.Table[objNr]
+		if entry == nil || entry.Free || entry.Object == nil {
+			continue
+		}
+		forEachColorSpace(ctx, entry.Object, 0, fn)
+	}
+}
+
+// colorantsOf returns the colorants of color space o.
+func colorantsOf(ctx *model.Context, o types.Object, depth int) []string {
+	if depth > 4 {
+		return nil
+	}
+
+	o, err := ctx.Dereference(o)
+	if err != nil {
+		return nil
+	}
+
+	arr, ok := o.(types.Array)
+	if !ok || len(arr) < 2 {
+		return nil
+	}
+
+	if s, ok := separation(arr); ok {
+		return []string{s}
+	}
+
+	if names, ok := deviceN(ctx, arr); ok {
+		var ss []string
+		for _, o := range names {
+			if n, ok := o.(types.Name); ok {
+				ss = append(ss, n.Value())
+			}
+		}
+		return ss
+	}
+
+	if n, ok := arr[0].(types.Name); ok && (n == "Indexed" || n == "I" || n == "Pattern") {
+		return colorantsOf(ctx, arr[1], depth+1)
+	}
+
+	return nil
+}
+
+// colorSpaceResources returns the ColorSpace resources of res.
+func colorSpaceResources(ctx *model.Context, res types.Dict) types.Dict {
+	if res == nil {
+		return nil
+	}
+	d, err := ctx.DereferenceDict(res["ColorSpace"])
+	if err != nil {
+		return nil
+	}
+	return d
+}
+
+// usedColorSpaces returns the names of color space resources referenced by content bb.
+func usedColorSpaces(bb []byte) map[string]bool {
+	m := map[string]bool{}
+	forEachContentOp(bb, func(op contentOp, oo []interface{}) {
+		switch op {
+		case "cs", "CS":
+			if len(oo) > 0 {
+				if n, ok := oo[len(oo)-1].(contentName); ok {
+					m[string(n)] = true
+				}
+			}
+		case "ID":
+			for i := 0; i+1 < len(oo); i++ {
+				if k, ok := oo[i].(contentName); ok && (k == "CS" || k == "ColorSpace") {
+					if v, ok := oo[i+1].(contentName); ok {
+						m[string(v)] = true
+					}
+				}
+			}
+		}
+	})
+	return m
+}
+
+// pageColorants returns the colorants used by the content bb using resources res.
+func pageColorants(ctx *model.Context, res types.Dict, bb []byte) []string {
+	var ss []string
+
+	csRes := colorSpaceResources(ctx, res)
+	for name := range usedColorSpaces(bb) {
+		if o, found := csRes.Find(name); found {
+			ss = append(ss, colorantsOf(ctx, o, 0)...)
+		}
+	}
+
+	if res == nil {
+		return ss
+	}
+
+	names := map[string]bool{}
+	forEachContentOp(bb, func(op contentOp, oo []interface{}) {
+		if (op == "Do" || op == "sh") && len(oo) > 0 {
+			if n, ok := oo[len(oo)-1].(contentName); ok {
+				names[string(op)+" "+string(n)] = true
+			}
+		}
+	})
+
+	for k := range names {
+		op, name, _ := strings.Cut(k, " ")
+		key := "XObject"
+		if op == "sh" {
+			key = "Shading"
+		}
+		d, err := ctx.DereferenceDict(res[key])
+		if err != nil || d == nil {
+			continue
+		}
+		o, err := ctx.Dereference(d[name])
+		if err != nil {
+			continue
+		}
+		switch o := o.(type) {
+		case types.StreamDict:
+			ss = append(ss, colorantsOf(ctx, o.Dict["ColorSpace"], 0)...)
+		case types.Dict:
+			ss = append(ss, colorantsOf(ctx, o["ColorSpace"], 0)...)
+		}
+	}
+
+	return ss
+}
+
+// Colorants returns the Separation and DeviceN colorants defined in ctx along with the pages using them.
+func Colorants(ctx *model.Context) ([]Colorant, error) {
+	m := map[string]*Colorant{}
+
+	colorant := func(name, alt string) *Colorant {
+		c, ok := m[name]
+		if !ok {
+			c = &Colorant{Name: name, Alternate: alt, Pages: []int{}}
+			m[name] = c
+		}
+		return c
+	}
+
+	forEachObjectColorSpace(ctx, func(arr types.Array) {
+		if s, ok := separation(arr); ok {
+			c := colorant(s, alternateName(ctx, arr[2]))
+			if !c.Separation {
+				c.Separation, c.Alternate = true, alternateName(ctx, arr[2])
+			}
+			return
+		}
+		names, _ := deviceN(ctx, arr)
+		for _, o := range names {
+			if n, ok := o.(types.Name); ok {
+				colorant(n.Value(), alternateName(ctx, arr[2])).DeviceN = true
+			}
+		}
+	})
+
+	pages := map[string]map[int]bool{}
+
+	cw := newContentWalker(ctx, func(pageNr int, res types.Dict, bb []byte) ([]byte, bool, error) {
+		for _, s := range pageColorants(ctx, res, bb) {
+			if pages[s] == nil {
+				pages[s] = map[int]bool{}
+			}
+			pages[s][pageNr] = true
+		}
+		return nil, false, nil
+	}, true)
+
+	if err := cw.walk(nil); err != nil {
+		return nil, err
+	}
+
+	cc := make([]Colorant, 0, len(m))
+	for name, c := range m {
+		for pageNr := range pages[name] {
+			c.Pages = append(c.Pages, pageNr)
+		}
+		sort.Ints(c.Pages)
+		cc = append(cc, *c)
+	}
+
+	sort.Slice(cc, func(i, j int) bool { return cc[i].Name < cc[j].Name })
+
+	return cc, nil
+}
+
+func renameDictKey(ctx *model.Context, o types.Object, from, to string) {
+	d, err := ctx.DereferenceDict(o)
+	if err != nil || d == nil {
+		return
+	}
+	if v, found := d.Find(from); found {
+		d.Delete(from)
+		d.Update(to, v)
+	}
+}
+
+// renameDeviceNColorant renames a colorant of a DeviceN color space array including its attributes (see 8.6.6.5).
+func renameDeviceNColorant(ctx *model.Context, arr, names types.Array, from, to string, sep types.Object) bool {
+	i := -1
+	for j, o := range names {
+		if n, ok := o.(types.Name); ok {
+			if n.Value() == to {
+				// Colorants of a DeviceN color space must be unique.
+				return false
+			}
+			if n.Value() == from {
+				i = j
+			}
+		}
+	}
+	if i < 0 {
+		return false
+	}
+
+	names[i] = types.Name(to)
+
+	if len(arr) < 5 {
+		return true
+	}
+
+	attrs, err := ctx.DereferenceDict(arr[4])
+	if err != nil || attrs == nil {
+		return true
+	}
+
+	if cd, err := ctx.DereferenceDict(attrs["Colorants"]); err == nil && cd != nil {
+		cd.Delete(from)
+		if sep != nil {
+			cd.Update(to, sep)
+		}
+	}
+
+	if mh, err := ctx.DereferenceDict(attrs["MixingHints"]); err == nil && mh != nil {
+		renameDictKey(ctx, mh["Solidities"], from, to)
+		renameDictKey(ctx, mh["DotGain"], from, to)
+		if po, err := ctx.DereferenceArray(mh["PrintingOrder"]); err == nil {
+			for j, o := range po {
+				if n, ok := o.(types.Name); ok && n.Value() == from {
+					po[j] = types.Name(to)
+				}
+			}
+		}
+	}
+
+	return true
+}
+
+func checkColorantNames(from, to string) error {
+	if from == "" || to == "" {
+		return errors.New("pdfcpu: missing colorant name")
+	}
+	if reservedColorant(from) || reservedColorant(to) {
+		return errors.New("pdfcpu: the colorants All and None are reserved")
+	}
+	if from == to {
+		return errors.Errorf("pdfcpu: colorant %s: source and target are identical", from)
+	}
+	return nil
+}
+
+// RenameColorant renames the spot color from to to in all Separation and DeviceN color spaces
+// keeping their appearance on devices lacking the colorant. It returns the number of color spaces changed.
+func RenameColorant(ctx *model.Context, from, to string) (int, error) {
+	if err := checkColorantNames(from, to); err != nil {
+		return 0, err
+	}
+
+	n := 0
+
+	forEachObjectColorSpace(ctx, func(arr types.Array) {
+		if s, ok := separation(arr); ok {
+			if s == from {
+				arr[1] = types.Name(to)
+				n++
+			}
+			return
+		}
+		names, _ := deviceN(ctx, arr)
+		if renameDeviceNColorant(ctx, arr, names, from, to, nil) {
+			n++
+		}
+	})
+
+	if n == 0 {
+		return 0, errors.Errorf("pdfcpu: unknown colorant: %s", from)
+	}
+
+	return n, nil
+}
+
+// RemapColorant maps the spot color from onto the existing spot color to so that both print on the same separation.
+// Separation color spaces for from take over the alternate color space and tint transform of to.
+// DeviceN color spaces already containing to are left untouched.
+// It returns the number of color spaces changed.
+func RemapColorant(ctx *model.Context, from, to string) (int, error) {
+	if err := checkColorantNames(from, to); err != nil {
+		return 0, err
+	}
+
+	var target types.Array
+	forEachObjectColorSpace(ctx, func(arr types.Array) {
+		if s, ok := separation(arr); ok && s == to && target == nil {
+			target = arr
+		}
+	})
+
+	if target == nil {
+		return 0, errors.Errorf("pdfcpu: missing Separation color space for colorant: %s", to)
+	}
+
+	n := 0
+
+	forEachObjectColorSpace(ctx, func(arr types.Array) {
+		if s, ok := separation(arr); ok {
+			if s == from {
+				arr[1], arr[2], arr[3] = types.Name(to), target[2], target[3]
+				n++
+			}
+			return
+		}
+		names, _ := deviceN(ctx, arr)
+		if renameDeviceNColorant(ctx, arr, names, from, to, target) {
+			n++
+		}
+	})
+
+	if n == 0 {
+		return 0, errors.Errorf("pdfcpu: unknown colorant: %s", from)
+	}
+
+	return n, nil
+}
+
+// processBuild is a CMYK process color replacing a spot color at full tint.
+type processBuild [4]float64
+
+func (pb processBuild) tint(t float64) string {
+	t = math.Max(0, math.Min(1, t))
+	return fmt.Sprintf("%s %s %s %s", contentNum(t*pb[0]), contentNum(t*pb[1]), contentNum(t*pb[2]), contentNum(t*pb[3]))
+}
+
+// spotConversion replaces a spot color by a process build in content streams.
+type spotConversion struct {
+	ctx    *model.Context
+	name   string
+	build  processBuild
+	fill   bool   // Fill color space is a converted Separation.
+	stroke bool   // Stroke color space is a converted Separation.
+	stack  []bool // Saved fill and stroke states.
+}
+
+func (sc *spotConversion) converts(res types.Dict, oo []interface{}) bool {
+	if len(oo) == 0 {
+		return false
+	}
+	n, ok := oo[len(oo)-1].(contentName)
+	if !ok {
+		return false
+	}
+	o, found := colorSpaceResources(sc.ctx, res).Find(string(n))
+	if !found {
+		return false
+	}
+	o, err := sc.ctx.Dereference(o)
+	if err != nil {
+		return false
+	}
+	arr, ok := o.(types.Array)
+	if !ok {
+		return false
+	}
+	s, ok := separation(arr)
+	return ok && s == sc.name
+}
+
+func (sc *spotConversion) edit(pageNr int, res types.Dict, bb []byte) ([]byte, bool, error) {
+	sc.fill, sc.stroke, sc.stack = false, false, nil
+
+	bb, changed := editContentOps(bb, func(op contentOp, oo []interface{}, _ []byte) (string, bool) {
+		switch op {
+
+		case "q":
+			sc.stack = append(sc.stack, sc.fill, sc.stroke)
+
+		case "Q":
+			if l := len(sc.stack); l >= 2 {
+				sc.fill, sc.stroke, sc.stack = sc.stack[l-2], sc.stack[l-1], sc.stack[:l-2]
+			}
+
+		case "cs":
+			// The initial color of a Separation color space is full tint.
+			if sc.fill = sc.converts(res, oo); sc.fill {
+				return sc.build.tint(1) + " k", true
+			}
+
+		case "CS":
+			if sc.stroke = sc.converts(res, oo); sc.stroke {
+				return sc.build.tint(1) + " K", true
+			}
+
+		case "sc", "scn":
+			if t, ok := tintOperand(oo); ok && sc.fill {
+				return sc.build.tint(t) + " k", true
+			}
+
+		case "SC", "SCN":
+			if t, ok := tintOperand(oo); ok && sc.stroke {
+				return sc.build.tint(t) + " K", true
+			}
+
+		case "g", "rg", "k":
+			sc.fill = false
+
+		case "G", "RG", "K":
+			sc.stroke = false
+		}
+
+		return "", false
+	})
+
+	return bb, changed, nil
+}
+
+func tintOperand(oo []interface{}) (float64, bool) {
+	if len(oo) != 1 {
+		return 0, false
+	}
+	f, ok := oo[0].(float64)
+	return f, ok
+}
+
+// convertSeparationImage replaces the Separation color space of an image by an Indexed DeviceCMYK color space.
+func convertSeparationImage(ctx *model.Context, d types.Dict, build processBuild) bool {
+	bpc := d.IntEntry("BitsPerComponent")
+	if bpc == nil || *bpc < 1 || *bpc > 8 {
+		return false
+	}
+
+	hival := 1<<uint(*bpc) - 1
+
+	d0, d1 := 0., 1.
+	if arr, err := ctx.DereferenceArray(d["Decode"]); err == nil && len(arr) == 2 {
+		if f, ok := numberValue(arr[0]); ok {
+			d0 = f
+		}
+		if f, ok := numberValue(arr[1]); ok {
+			d1 = f
+		}
+	}
+
+	lookup := make([]byte, 0, 4*(hival+1))
+	for i := 0; i <= hival; i++ {
+		t := math.Max(0, math.Min(1, d0+float64(i)/float64(hival)*(d1-d0)))
+		for _, c := range build {
+			lookup = append(lookup, byte(math.Round(t*c*255)))
+		}
+	}
+
+	d.Update("ColorSpace", types.Array{
+		types.Name("Indexed"),
+		types.Name("DeviceCMYK"),
+		types.Integer(hival),
+		types.NewHexLiteral(lookup),
+	})
+	d.Delete("Decode")
+
+	return true
+}
+
+func numberValue(o types.Object) (float64, bool) {
+	switch o := o.(type) {
+	case types.Integer:
+		return float64(o.Value()), true
+	case types.Float:
+		return o.Value(), true
+	}
+	return 0, false
+}
+
+// convertTintFunction returns a CMYK function for a 1-in, 1-out exponential or stitching function.
+func convertTintFunction(ctx *model.Context, o types.Object, build processBuild, depth int) (types.Object, bool) {
+	if depth > 4 {
+		return nil, false
+	}
+
+	d, err := ctx.DereferenceDict(o)
+	if err != nil || d == nil {
+		return nil, false
+	}
+
+	ft := d.IntEntry("FunctionType")
+	if ft == nil {
+		return nil, false
+	}
+
+	d1 := d.Clone().(types.Dict)
+	d1.Delete("Range")
+
+	switch *ft {
+
+	case 2:
+		for _, k := range []string{"C0", "C1"} {
+			c, def := 0., 0.
+			if k == "C1" {
+				def = 1
+			}
+			c = def
+			if arr, err := ctx.DereferenceArray(d[k]); err == nil && arr != nil {
+				if len(arr) != 1 {
+					return nil, false
+				}
+				f, ok := numberValue(arr[0])
+				if !ok {
+					return nil, false
+				}
+				c = f
+			}
+			d1.Update(k, types.NewNumberArray(c*build[0], c*build[1], c*build[2], c*build[3]))
+		}
+		return d1, true
+
+	case 3:
+		ff, err := ctx.DereferenceArray(d["Functions"])
+		if err != nil || ff == nil {
+			return nil, false
+		}
+		ff1 := make(types.Array, len(ff))
+		for i, f := range ff {
+			f1, ok := convertTintFunction(ctx, f, build, depth+1)
+			if !ok {
+				return nil, false
+			}
+			ff1[i] = f1
+		}
+		d1.Update("Functions", ff1)
+		return d1, true
+	}
+
+	return nil, false
+}
+
+// convertSeparationShading replaces the Separation color space of a shading by DeviceCMYK.
+func convertSeparationShading(ctx *model.Context, d types.Dict, build processBuild) bool {
+	o, found := d.Find("Function")
+	if !found {
+		return false
+	}
+
+	if arr, err := ctx.DereferenceArray(o); err == nil && arr != nil {
+		if len(arr) != 1 {
+			return false
+		}
+		o = arr[0]
+	}
+
+	f, ok := convertTintFunction(ctx, o, build, 0)
+	if !ok {
+		return false
+	}
+
+	d.Update("ColorSpace", types.Name("DeviceCMYK"))
+	d.Update("Function", f)
+
+	return true
+}
+
+// ConvertColorant replaces the spot color name by the CMYK process build cmyk (components 0..1)
+// in page content, form XObjects, tiling patterns, images and shadings using a Separation color space for name.
+// Shadings using sampled or PostScript calculator functions and DeviceN color spaces are left untouched.
+func ConvertColorant(ctx *model.Context, name string, cmyk []float64) error {
+	if name == "" || reservedColorant(name) {
+		return errors.Errorf("pdfcpu: invalid colorant: %s", name)
+	}
+
+	if len(cmyk) != 4 {
+		return errors.New("pdfcpu: process build needs 4 CMYK components")
+	}
+
+	var build processBuild
+	for i, c := range cmyk {
+		if c < 0 || c > 1 {
+			return errors.Errorf("pdfcpu: process build component out of range 0..1: %.2f", c)
+		}
+		build[i] = c
+	}
+
+	sc := &spotConversion{ctx: ctx, name: name, build: build}
+	if err := newContentWalker(ctx, sc.edit, false).walk(nil); err != nil {
+		return err
+	}
+
+	for _, entry := range ctx.Table {
+		if entry == nil || entry.Free || entry.Object == nil {
+			continue
+		}
+
+		var d types.Dict
+		switch o := entry.Object.(type) {
+		case types.Dict:
+			d = o
+		case types.StreamDict:
+			d = o.Dict
+		default:
+			continue
+		}
+
+		cs, found := d.Find("ColorSpace")
+		if !found {
+			continue
+		}
+		cs, err := ctx.Dereference(cs)
+		if err != nil {
+			continue
+		}
+		arr, ok := cs.(types.Array)
+		if !ok {
+			continue
+		}
+		if s, ok := separation(arr); !ok || s != name {
+			continue
+		}
+
+		if st := d.Subtype(); st != nil && *st == "Image" {
+			convertSeparationImage(ctx, d, build)
+			continue
+		}
+
+		if st := d.IntEntry("ShadingType"); st != nil {
+			convertSeparationShading(ctx, d, build)
+		}
+	}
+
+	return nil
+}
+
+func dictPtr(d types.Dict) uintptr {
+	return reflect.ValueOf(d).Pointer()
+}
+
+// RemoveUnusedColorants removes Separation and DeviceN color space resources not referenced by any content stream
+// using them and returns the colorants of the removed color spaces.
+func RemoveUnusedColorants(ctx *model.Context) ([]string, error) {
+	type csResources struct {
+		d    types.Dict
+		used map[string]bool
+	}
+
+	m := map[uintptr]*csResources{}
+
+	cw := newContentWalker(ctx, func(pageNr int, res types.Dict, bb []byte) ([]byte, bool, error) {
+		d := colorSpaceResources(ctx, res)
+		if d == nil {
+			return nil, false, nil
+		}
+		k := dictPtr(d)
+		csr, ok := m[k]
+		if !ok {
+			csr = &csResources{d: d, used: map[string]bool{}}
+			m[k] = csr
+		}
+		for name := range usedColorSpaces(bb) {
+			csr.used[name] = true
+		}
+		return nil, false, nil
+	}, false)
+
+	if err := cw.walk(nil); err != nil {
+		return nil, err
+	}
+
+	removed := map[string]bool{}
+
+	for _, csr := range m {
+		for name, o := range csr.d {
+			if csr.used[name] {
+				continue
+			}
+			ss := colorantsOf(ctx, o, 0)
+			if len(ss) == 0 {
+				continue
+			}
+			csr.d.Delete(name)
+			for _, s := range ss {
+				removed[s] = true
+			}
+		}
+	}
+
+	ss := make([]string, 0, len(removed))
+	for s := range removed {
+		ss = append(ss, s)
+	}
+	sort.Strings(ss)
+
+	return ss, nil
+}
@@ -20,11 +20,13 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"math"
 	"net/url"
 	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 	"unicode/utf16"
 
 	"github.com/pdfcpu/pdfcpu/pkg/filter"
@@ -97,23 +99,31 @@ var wmParamMap = watermarkParamMap{
 	"aligntext":       parseTextHorAlignment,
 	"backgroundcolor": parseBackgroundColor,
 	"bgcolor":         parseBackgroundColor,
+	"blendmode":       parseBlendMode,
 	"border":          parseBorder,
 	"color":           parseFillColor,
 	"diagonal":        parseDiagonal,
 	"fillcolor":       parseFillColor,
 	"fontname":        parseFontName,
+	"id":              parseWatermarkID,
+	"keeprotation":    parseKeepPageRotation,
 	"scriptname":      parseScriptName,
 	"margins":         parseMargins,
 	"mode":            parseRenderMode,
+	"multi":           parsePdfMultiMode,
 	"offset":          parsePositionOffsetWM,
 	"opacity":         parseOpacity,
+	"pagebox":         parsePageBox,
 	"points":          parseFontSize,
 	"position":        parsePositionAnchorWM,
 	"rendermode":      parseRenderMode,
 	"rtl":             parseRightToLeft,
 	"rotation":        parseRotation,
 	"scalefactor":     parseScaleFactorWM,
+	"spacing":         parseTileSpacing,
 	"strokecolor":     parseStrokeColor,
+	"textpath":        parseTextPath,
+	"tile":            parseTile,
 	"url":             parseURL,
 }
 
@@ -277,6 +287,91 @@ func parseRightToLeft(s string, wm *model.Watermark) error {
 	return nil
 }
 
+func parseWatermarkID(s string, wm *model.Watermark) error {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return errors.New("pdfcpu: id, please provide a non empty identifier")
+	}
+	wm.ID = s
+	return nil
+}
+
+func parseKeepPageRotation(s string, wm *model.Watermark) error {
+	switch strings.ToLower(s) {
+	case "on", "true", "t":
+		wm.KeepPageRot = true
+	case "off", "false", "f":
+		wm.KeepPageRot = false
+	default:
+		return errors.New("pdfcpu: keeprotation, please provide one of: on/off true/false t/f")
+	}
+
+	return nil
+}
+
+func parseTextPath(s string, wm *model.Watermark) error {
+	tp, err := model.ParseTextPath(s, wm.InpUnit)
+	if err != nil {
+		return err
+	}
+	wm.TextPath = tp
+	return nil
+}
+
+func parseTile(s string, wm *model.Watermark) error {
+	switch strings.ToLower(s) {
+	case "on", "true", "t":
+		wm.Tile = true
+	case "off", "false", "f":
+		wm.Tile = false
+	default:
+		return errors.New("pdfcpu: tile, please provide one of: on/off true/false t/f")
+	}
+
+	return nil
+}
+
+func parseTileSpacing(s string, wm *model.Watermark) error {
+	d := strings.Split(s, " ")
+	if len(d) < 1 || len(d) > 2 {
+		return errors.Errorf("pdfcpu: illegal tile spacing string: need 1 or 2 numeric values, %s\n", s)
+	}
+
+	f1, err := strconv.ParseFloat(d[0], 64)
+	if err != nil {
+		return err
+	}
+
+	f2 := f1
+	if len(d) == 2 {
+		if f2, err = strconv.ParseFloat(d[1], 64); err != nil {
+			return err
+		}
+	}
+
+	if f1 < 0 || f2 < 0 {
+		return errors.Errorf("pdfcpu: illegal tile spacing: must be >= 0, %s\n", s)
+	}
+
+	wm.TileDx = types.ToUserSpace(f1, wm.InpUnit)
+	wm.TileDy = types.ToUserSpace(f2, wm.InpUnit)
+
+	return nil
+}
+
+func parsePageBox(s string, wm *model.Watermark) error {
+	switch strings.ToLower(s) {
+	case "m", "media":
+		wm.PageBox = "media"
+	case "c", "crop":
+		wm.PageBox = "crop"
+	default:
+		return errors.New("pdfcpu: pagebox, please provide one of: m(edia), c(rop)")
+	}
+
+	return nil
+}
+
 func parseStrokeColor(s string, wm *model.Watermark) error {
 	c, err := color.ParseColor(s)
 	if err != nil {
@@ -357,6 +452,29 @@ func parseOpacity(s string, wm *model.Watermark) error {
 	return nil
 }
 
+func parseBlendMode(s string, wm *model.Watermark) error {
+	bm, ok := model.BlendMode(s)
+	if !ok {
+		return errors.Errorf("pdfcpu: invalid blend mode: %s, please refer to \"pdfcpu stamp\" for valid values", s)
+	}
+	wm.BlendMode = bm
+	return nil
+}
+
+func parsePdfMultiMode(s string, wm *model.Watermark) error {
+	switch strings.ToLower(s) {
+	case "repeat":
+		wm.PdfMultiMode = model.PdfMultiRepeat
+	case "cycle":
+		wm.PdfMultiMode = model.PdfMultiCycle
+	case "once":
+		wm.PdfMultiMode = model.PdfMultiOnce
+	default:
+		return errors.New("pdfcpu: multi, please provide one of: repeat, cycle, once")
+	}
+	return nil
+}
+
 func parseRenderMode(s string, wm *model.Watermark) error {
 	m, err := strconv.Atoi(s)
 	if err != nil {
@@ -960,7 +1078,7 @@ func setupTextDescriptor(wm model.Watermark, timestampFormat string, pageNr, pag
 	td.X, td.Y, td.HAlign, td.VAlign, td.FontKey = x, y, hAlign, vAlign, "F1"
 
 	// Set right to left rendering.
-	td.RTL = wm.RTL
+	td.RTL = wm.RTL || types.RightToLeft(wm.TextString)
 
 	td.Embed = wm.ScriptName == ""
 
@@ -1006,6 +1124,16 @@ func calcFormBoundingBox(xRefTable *model.XRefTable, w io.Writer, timestampForma
 	} else {
 		var td model.TextDescriptor
 		td, unique = setupTextDescriptor(*wm, timestampFormat, pageNr, pageCount)
+		if tp := wm.TextPath; tp != nil {
+			if wm.HAlign == nil {
+				td.HAlign = types.AlignCenter
+			}
+			// Render td along the path into b with the bounding box anchored at the origin.
+			bb := model.TextPathBoundingBox(td, *tp)
+			td.X, td.Y = -bb.LL.X, -bb.LL.Y
+			wm.Bb = model.WriteTextOnPath(xRefTable, w, td, *tp)
+			return unique
+		}
 		// Render td into b and return the bounding box.
 		wm.Bb = model.WriteMultiLine(xRefTable, w, types.RectForDim(wm.Vp.Width(), wm.Vp.Height()), nil, td)
 	}
@@ -1019,9 +1147,10 @@ func createForm(ctx *model.Context, pageNr, pageCount int, wm *model.Watermark,
 	// The forms bounding box is dependent on the page dimensions.
 	bb := wm.Bb
 
-	maxStampPageNr := wm.PdfMultiStartPageNrDest + len(wm.PdfRes) - 1
+	maxStampPageNr := wm.MaxStampPageNr()
+	repeatLast := wm.PdfMultiMode == model.PdfMultiRepeat && pageNr > maxStampPageNr
 
-	if !unique && (cachedForm(*wm) || pageNr > maxStampPageNr) {
+	if !unique && (cachedForm(*wm) || repeatLast) {
 		// Use cached form.
 		ir, ok := wm.FCache[*bb]
 		if ok {
@@ -1067,6 +1196,10 @@ func createForm(ctx *model.Context, pageNr, pageCount int, wm *model.Watermark,
 		sd.Insert("Resources", *ir)
 	}
 
+	if wm.ID != "" {
+		sd.Insert("PieceInfo", pieceInfo(wm.ID))
+	}
+
 	sd.InsertName("Filter", filter.Flate)
 
 	if err = sd.Encode(); err != nil {
@@ -1080,7 +1213,7 @@ func createForm(ctx *model.Context, pageNr, pageCount int, wm *model.Watermark,
 
 	wm.Form = ir
 
-	if cachedForm(*wm) || pageNr >= len(wm.PdfRes) {
+	if cachedForm(*wm) || (wm.PdfMultiMode == model.PdfMultiRepeat && pageNr >= maxStampPageNr) {
 		// Cache form.
 		wm.FCache[*wm.Bb] = ir
 	}
@@ -1088,7 +1221,160 @@ func createForm(ctx *model.Context, pageNr, pageCount int, wm *model.Watermark,
 	return nil
 }
 
-func createExtGStateForStamp(ctx *model.Context, opacity float64) (*types.IndirectRef, error) {
+// tileMatrices returns the transform matrices repeating the form of wm at the configured spacing and angle across the view port.
+func tileMatrices(wm *model.Watermark) []matrix.Matrix {
+	m := wm.CalcTransformMatrix()
+	cos, sin := m[0][0], m[0][1]
+
+	w, h := wm.Bb.Width(), wm.Bb.Height()
+
+	var dy float64
+	if !wm.IsImage() && !wm.IsPDF() {
+		dy = wm.Bb.LL.Y
+	}
+
+	dx1, dy1 := wm.TileDx, wm.TileDy
+	if dx1 == 0 && dy1 == 0 {
+		dx1, dy1 = h, h
+	}
+
+	// Center of the tile anchored on the view port.
+	c := m.Transform(types.Point{X: w / 2, Y: h/2 + dy})
+
+	// Half the diagonal of a tile.
+	r := math.Sqrt(w*w+h*h) / 2
+
+	// Distance of c to the farthest corner of the view port.
+	vp := wm.Vp
+	var d float64
+	for _, p := range []types.Point{vp.LL, vp.UR, {X: vp.LL.X, Y: vp.UR.Y}, {X: vp.UR.X, Y: vp.LL.Y}} {
+		d = math.Max(d, math.Hypot(p.X-c.X, p.Y-c.Y))
+	}
+
+	sx, sy := w+dx1, h+dy1
+	ni, nj := int(math.Ceil((d+r)/sx)), int(math.Ceil((d+r)/sy))
+
+	var mm []matrix.Matrix
+
+	for j := -nj; j <= nj; j++ {
+		for i := -ni; i <= ni; i++ {
+			tx := float64(i)*sx*cos - float64(j)*sy*sin
+			ty := float64(i)*sx*sin + float64(j)*sy*cos
+			x, y := c.X+tx, c.Y+ty
+			if x < vp.LL.X-r || x > vp.UR.X+r || y < vp.LL.Y-r || y > vp.UR.Y+r {
+				// Tile not visible.
+				continue
+			}
+			t := matrix.IdentMatrix
+			t[2][0], t[2][1] = tx, ty
+			mm = append(mm, m.Multiply(t))
+		}
+	}
+
+	return mm
+}
+
+// createTiledForm replaces the form of wm by a form repeating it across the view port.
+func createTiledForm(ctx *model.Context, wm *model.Watermark) error {
+	if ir := wm.TiledForm(); ir != nil {
+		wm.Form = ir
+		return nil
+	}
+
+	var b bytes.Buffer
+	for _, m := range tileMatrices(wm) {
+		fmt.Fprintf(&b, "q %.5f %.5f %.5f %.5f %.5f %.5f cm /Tl0 Do Q ", m[0][0], m[0][1], m[1][0], m[1][1], m[2][0], m[2][1])
+	}
+
+	sd := types.StreamDict{
+		Dict: types.Dict(
+			map[string]types.Object{
+				"Type":    types.Name("XObject"),
+				"Subtype": types.Name("Form"),
+				"BBox":    wm.Vp.Array(),
+				"Matrix":  types.NewNumberArray(1, 0, 0, 1, 0, 0),
+				"OC":      *wm.Ocg,
+				"Resources": types.Dict(
+					map[string]types.Object{
+						"XObject": types.Dict(map[string]types.Object{"Tl0": *wm.Form}),
+					},
+				),
+			},
+		),
+		Content:        b.Bytes(),
+		FilterPipeline: []types.PDFFilter{{Name: filter.Flate, DecodeParms: nil}},
+	}
+
+	if wm.ID != "" {
+		sd.Insert("PieceInfo", pieceInfo(wm.ID))
+	}
+
+	sd.InsertName("Filter", filter.Flate)
+
+	if err := sd.Encode(); err != nil {
+		return err
+	}
+
+	ir, err := ctx.IndRefForNewObject(sd)
+	if err != nil {
+		return err
+	}
+
+	wm.CacheTiledForm(ir)
+	wm.Form = ir
+
+	return nil
+}
+
+// pieceInfo returns the page-piece dict identifying a watermark form by id.
+func pieceInfo(id string) types.Dict {
+	return types.Dict(
+		map[string]types.Object{
+			"pdfcpu": types.Dict(
+				map[string]types.Object{
+					"LastModified": types.StringLiteral(types.DateString(time.Now())),
+					"Private":      types.Dict(map[string]types.Object{"ID": types.StringLiteral(id)}),
+				},
+			),
+		},
+	)
+}
+
+// watermarkID returns the id of the watermark form xoID of resDict.
+func watermarkID(ctx *model.Context, resDict types.Dict, xoID string) string {
+	o, found := resDict.Find("XObject")
+	if !found {
+		return ""
+	}
+	d, err := ctx.DereferenceDict(o)
+	if err != nil || d == nil {
+		return ""
+	}
+	o, found = d.Find(xoID)
+	if !found {
+		return ""
+	}
+	sd, _, err := ctx.DereferenceStreamDict(o)
+	if err != nil || sd == nil {
+		return ""
+	}
+	if d, err = ctx.DereferenceDict(sd.Dict["PieceInfo"]); err != nil || d == nil {
+		return ""
+	}
+	if d, err = ctx.DereferenceDict(d["pdfcpu"]); err != nil || d == nil {
+		return ""
+	}
+	if d, err = ctx.DereferenceDict(d["Private"]); err != nil || d == nil {
+		return ""
+	}
+	id, err := ctx.DereferenceStringOrHexLiteral(d["ID"], model.V10, nil)
+	if err != nil {
+		return ""
+	}
+	return id
+}
+
+func createExtGStateForStamp(ctx *model.Context, opacity float64, blendMode string) (*types.IndirectRef, error) {
 	d := types.Dict(
 		map[string]types.Object{
 			"Type": types.Name("ExtGState"),
@@ -1097,9 +1383,29 @@ func createExtGStateForStamp(ctx *model.Context, opacity float64) (*types.Indire
 		},
 	)
 
+	if blendMode != "" && blendMode != "Normal" {
+		d.InsertName("BM", blendMode)
+	}
+
 	return ctx.IndRefForNewObject(d)
 }
 
+// extGStateCache holds the graphics states shared by watermarks using the same opacity and blend mode.
+type extGStateCache map[string]*types.IndirectRef
+
+func (c extGStateCache) extGState(ctx *model.Context, wm *model.Watermark) (*types.IndirectRef, error) {
+	k := fmt.Sprintf("%.5f %s", wm.Opacity, wm.BlendMode)
+	if ir, ok := c[k]; ok {
+		return ir, nil
+	}
+	ir, err := createExtGStateForStamp(ctx, wm.Opacity, wm.BlendMode)
+	if err != nil {
+		return nil, err
+	}
+	c[k] = ir
+	return ir, nil
+}
+
 func insertPageResourcesForWM(ctx *model.Context, pageDict types.Dict, wm model.Watermark, gsID, xoID string) error {
 	resourceDict := types.Dict(
 		map[string]types.Object{
@@ -1146,11 +1452,18 @@ func updatePageResourcesForWM(ctx *model.Context, resDict types.Dict, wm model.W
 }
 
 func wmContent(wm *model.Watermark, gsID, xoID string) []byte {
-	m := wm.CalcTransformMatrix()
-	p1 := m.Transform(types.Point{X: wm.Bb.LL.X, Y: wm.Bb.LL.Y})
-	p2 := m.Transform(types.Point{X: wm.Bb.UR.X, Y: wm.Bb.LL.Y})
-	p3 := m.Transform(types.Point{X: wm.Bb.UR.X, Y: wm.Bb.UR.Y})
-	p4 := m.Transform(types.Point{X: wm.Bb.LL.X, Y: wm.Bb.UR.Y})
+	m, bb := wm.CalcTransformMatrix(), wm.Bb
+	if wm.Tile {
+		// The tiled form covers the view port.
+		m, bb = matrix.IdentMatrix, wm.Vp
+	}
+	if wm.PageM != nil {
+		m = m.Multiply(*wm.PageM)
+	}
+	p1 := m.Transform(types.Point{X: bb.LL.X, Y: bb.LL.Y})
+	p2 := m.Transform(types.Point{X: bb.UR.X, Y: bb.LL.Y})
+	p3 := m.Transform(types.Point{X: bb.UR.X, Y: bb.UR.Y})
+	p4 := m.Transform(types.Point{X: bb.LL.X, Y: bb.UR.Y})
 	wm.BbTrans = types.QuadLiteral{P1: p1, P2: p2, P3: p3, P4: p4}
 	insertOCG := " /Artifact <</Subtype /Watermark /Type /Pagination >>BDC q %.5f %.5f %.5f %.5f %.5f %.5f cm /%s gs /%s Do Q EMC "
 	var b bytes.Buffer
@@ -1192,7 +1505,7 @@ func patchFirstContentStreamForWatermark(sd *types.StreamDict, gsID, xoID string
 	if wm.OnTop {
 		bb := []byte(" q ")
 		if wm.PageRot != 0 {
-			bb = append(bb, model.ContentBytesForPageRotation(wm.PageRot, wm.Vp.Width(), wm.Vp.Height())...)
+			bb = append(bb, pageRotationBytes(wm)...)
 		}
 		sd.Content = append(bb, sd.Content...)
 		if !isLast {
@@ -1209,7 +1522,7 @@ func patchFirstContentStreamForWatermark(sd *types.StreamDict, gsID, xoID string
 		return sd.Encode()
 	}
 
-	bb := append([]byte(" q "), model.ContentBytesForPageRotation(wm.PageRot, wm.Vp.Width(), wm.Vp.Height())...)
+	bb := append([]byte(" q "), pageRotationBytes(wm)...)
 	sd.Content = append(bb, sd.Content...)
 	if isLast {
 		sd.Content = append(sd.Content, []byte(" Q")...)
@@ -1335,6 +1648,82 @@ func viewPort(a *model.InheritedPageAttrs) *types.Rectangle {
 	return visibleRegion
 }
 
+// internalizedPageRotation returns the matrix internalizing page rotation rot for the visible region r
+// along with the resulting visible region.
+func internalizedPageRotation(rot int, r *types.Rectangle) (matrix.Matrix, *types.Rectangle) {
+	crop := types.NewRectangle(r.LL.X, r.LL.Y, r.UR.X, r.UR.Y)
+	if rot == 90 || rot == 270 {
+		crop.UR.X = crop.LL.X + r.Height()
+		crop.UR.Y = crop.LL.Y + r.Width()
+	}
+
+	m1 := matrix.IdentMatrix
+	m1[2][0], m1[2][1] = -r.LL.X, -r.LL.Y
+	m2 := model.PageRotationMatrix(rot, crop.Width(), crop.Height())
+	m3 := matrix.IdentMatrix
+	m3[2][0], m3[2][1] = r.LL.X, r.LL.Y
+
+	return m1.Multiply(m2).Multiply(m3), crop
+}
+
+func transformRect(m matrix.Matrix, r *types.Rectangle) *types.Rectangle {
+	p1 := m.Transform(r.LL)
+	p2 := m.Transform(r.UR)
+	return types.NewRectangle(math.Min(p1.X, p2.X), math.Min(p1.Y, p2.Y), math.Max(p1.X, p2.X), math.Max(p1.Y, p2.Y))
+}
+
+func pageRotationBytes(wm *model.Watermark) []byte {
+	m := wm.PageRotM
+	return []byte(fmt.Sprintf("%.5f %.5f %.5f %.5f %.5f %.5f cm ", m[0][0], m[0][1], m[1][0], m[1][1], m[2][0], m[2][1]))
+}
+
+// setupViewPort sets the view port for positioning and scaling wm on a page and
+// takes care of page rotation which is either internalized into the page content or retained.
+func setupViewPort(d types.Dict, a *model.InheritedPageAttrs, wm *model.Watermark) {
+	visibleRegion := viewPort(a)
+
+	box := visibleRegion
+	if wm.PageBox == "media" {
+		box = a.MediaBox
+	}
+	wm.Vp = types.NewRectangle(box.LL.X, box.LL.Y, box.UR.X, box.UR.Y)
+
+	wm.PageM = nil
+
+	rot := (a.Rotate%360 + 360) % 360
+	if rot%90 != 0 {
+		rot = 0
+	}
+
+	wm.PageRot = 0
+	if rot == 0 {
+		return
+	}
+
+	if wm.KeepPageRot {
+		// Render upright relative to the displayed page leaving the page untouched.
+		m := model.UprightPageMatrix(rot, wm.Vp)
+		wm.PageM = &m
+		wm.Vp = model.UprightViewPort(rot, wm.Vp)
+		return
+	}
+
+	// Internalize page rotation into content stream.
+	wm.PageRot = rot
+	m, crop := internalizedPageRotation(rot, visibleRegion)
+	wm.PageRotM = m
+	if wm.PageBox == "media" {
+		wm.Vp = transformRect(m, wm.Vp)
+	} else {
+		wm.Vp = types.NewRectangle(crop.LL.X, crop.LL.Y, crop.UR.X, crop.UR.Y)
+	}
+
+	// Reset page rotation in page dict.
+	d.Update("MediaBox", crop.Array())
+	d.Update("CropBox", crop.Array())
+	d.Delete("Rotate")
+}
+
 func handleLink(ctx *model.Context, pageIndRef *types.IndirectRef, d types.Dict, pageNr int, wm model.Watermark) error {
 	if !wm.OnTop || wm.URL == "" {
 		return nil
@@ -1343,7 +1732,7 @@ func handleLink(ctx *model.Context, pageIndRef *types.IndirectRef, d types.Dict,
 	ann := model.NewLinkAnnotation(
 		*wm.BbTrans.EnclosingRectangle(5.0), // rect
 		"",                                  // contents
-		"pdfcpu",                            // id
+		linkAnnotationID(wm.ID),             // id
 		"",                                  // modDate
 		model.AnnNoZoom+model.AnnNoRotate,   // f
 		&color.Red,                          // borderCol
@@ -1365,6 +1754,10 @@ func addPageWatermark(ctx *model.Context, pageNr int, wm model.Watermark) error
 		return errors.Errorf("pdfcpu: invalid page number: %d", pageNr)
 	}
 
+	if wm.SkipPage(pageNr) {
+		return nil
+	}
+
 	if log.DebugEnabled() {
 		log.Debug.Printf("addPageWatermark page:%d\n", pageNr)
 	}
@@ -1373,7 +1766,7 @@ func addPageWatermark(ctx *model.Context, pageNr int, wm model.Watermark) error
 		if log.DebugEnabled() {
 			log.Debug.Println("Updating")
 		}
-		if _, err := removePageWatermark(ctx, pageNr); err != nil {
+		if _, err := removePageWatermark(ctx, pageNr, wm.ID); err != nil {
 			return err
 		}
 	}
@@ -1384,27 +1777,18 @@ func addPageWatermark(ctx *model.Context, pageNr int, wm model.Watermark) error
 		return err
 	}
 
-	// Internalize page rotation into content stream.
-	wm.PageRot = inhPAttrs.Rotate
-
-	wm.Vp = viewPort(inhPAttrs)
-
-	// Reset page rotation in page dict.
-	if wm.PageRot != 0 {
-		if types.IntMemberOf(wm.PageRot, []int{+90, -90, +270, -270}) {
-			w := wm.Vp.Width()
-			wm.Vp.UR.X = wm.Vp.LL.X + wm.Vp.Height()
-			wm.Vp.UR.Y = wm.Vp.LL.Y + w
-		}
-		d.Update("MediaBox", wm.Vp.Array())
-		d.Update("CropBox", wm.Vp.Array())
-		d.Delete("Rotate")
-	}
+	setupViewPort(d, inhPAttrs, &wm)
 
 	if err = createForm(ctx, pageNr, ctx.PageCount, &wm, stampWithBBox); err != nil {
 		return err
 	}
 
+	if wm.Tile {
+		if err = createTiledForm(ctx, &wm); err != nil {
+			return err
+		}
+	}
+
 	if log.DebugEnabled() {
 		log.Debug.Printf("\n%s\n", wm)
 	}
@@ -1440,13 +1824,16 @@ func createResourcesForPageNr(
 	wm *model.Watermark,
 	pageNr int,
 	fm map[string]types.IntSet,
-	ocgIndRef, extGStateIndRef *types.IndirectRef,
-	onTop bool, opacity float64) error {
+	ocgIndRef *types.IndirectRef,
+	gsCache extGStateCache,
+	onTop bool) error {
 
+	var err error
 	wm.Ocg = ocgIndRef
-	wm.ExtGState = extGStateIndRef
 	wm.OnTop = onTop
-	wm.Opacity = opacity
+	if wm.ExtGState, err = gsCache.extGState(ctx, wm); err != nil {
+		return err
+	}
 
 	if wm.IsImage() {
 		return createImageResForWM(ctx, wm)
@@ -1476,13 +1863,13 @@ func createResourcesForPageNr(
 func createResourcesForWMMap(
 	ctx *model.Context,
 	m map[int]*model.Watermark,
-	ocgIndRef, extGStateIndRef *types.IndirectRef,
-	onTop bool,
-	opacity float64) (map[string]types.IntSet, error) {
+	ocgIndRef *types.IndirectRef,
+	onTop bool) (map[string]types.IntSet, error) {
 
 	fm := map[string]types.IntSet{}
+	gsCache := extGStateCache{}
 	for pageNr, wm := range m {
-		if err := createResourcesForPageNr(ctx, wm, pageNr, fm, ocgIndRef, extGStateIndRef, onTop, opacity); err != nil {
+		if err := createResourcesForPageNr(ctx, wm, pageNr, fm, ocgIndRef, gsCache, onTop); err != nil {
 			return nil, err
 		}
 	}
@@ -1493,14 +1880,14 @@ func createResourcesForWMMap(
 func createResourcesForWMSliceMap(
 	ctx *model.Context,
 	m map[int][]*model.Watermark,
-	ocgIndRef, extGStateIndRef *types.IndirectRef,
-	onTop bool,
-	opacity float64) (map[string]types.IntSet, error) {
+	ocgIndRef *types.IndirectRef,
+	onTop bool) (map[string]types.IntSet, error) {
 
 	fm := map[string]types.IntSet{}
+	gsCache := extGStateCache{}
 	for pageNr, wms := range m {
 		for _, wm := range wms {
-			if err := createResourcesForPageNr(ctx, wm, pageNr, fm, ocgIndRef, extGStateIndRef, onTop, opacity); err != nil {
+			if err := createResourcesForPageNr(ctx, wm, pageNr, fm, ocgIndRef, gsCache, onTop); err != nil {
 				return nil, err
 			}
 		}
@@ -1511,13 +1898,9 @@ func createResourcesForWMSliceMap(
 
 // AddWatermarksMap adds watermarks in m to corresponding pages.
 func AddWatermarksMap(ctx *model.Context, m map[int]*model.Watermark) error {
-	var (
-		onTop   bool
-		opacity float64
-	)
+	var onTop bool
 	for _, wm := range m {
 		onTop = wm.OnTop
-		opacity = wm.Opacity
 		break
 	}
 
@@ -1526,12 +1909,7 @@ func AddWatermarksMap(ctx *model.Context, m map[int]*model.Watermark) error {
 		return err
 	}
 
-	extGStateIndRef, err := createExtGStateForStamp(ctx, opacity)
-	if err != nil {
-		return err
-	}
-
-	fm, err := createResourcesForWMMap(ctx, m, ocgIndRef, extGStateIndRef, onTop, opacity)
+	fm, err := createResourcesForWMMap(ctx, m, ocgIndRef, onTop)
 	if err != nil {
 		return err
 	}
@@ -1565,13 +1943,9 @@ func AddWatermarksMap(ctx *model.Context, m map[int]*model.Watermark) error {
 
 // AddWatermarksSliceMap adds watermarks in m to corresponding pages.
 func AddWatermarksSliceMap(ctx *model.Context, m map[int][]*model.Watermark) error {
-	var (
-		onTop   bool
-		opacity float64
-	)
+	var onTop bool
 	for _, wms := range m {
 		onTop = wms[0].OnTop
-		opacity = wms[0].Opacity
 		break
 	}
 
@@ -1580,12 +1954,7 @@ func AddWatermarksSliceMap(ctx *model.Context, m map[int][]*model.Watermark) err
 		return err
 	}
 
-	extGStateIndRef, err := createExtGStateForStamp(ctx, opacity)
-	if err != nil {
-		return err
-	}
-
-	fm, err := createResourcesForWMSliceMap(ctx, m, ocgIndRef, extGStateIndRef, onTop, opacity)
+	fm, err := createResourcesForWMSliceMap(ctx, m, ocgIndRef, onTop)
 	if err != nil {
 		return err
 	}
@@ -1634,7 +2003,7 @@ func AddWatermarks(ctx *model.Context, selectedPages types.IntSet, wm *model.Wat
 		return err
 	}
 
-	if wm.ExtGState, err = createExtGStateForStamp(ctx, wm.Opacity); err != nil {
+	if wm.ExtGState, err = createExtGStateForStamp(ctx, wm.Opacity, wm.BlendMode); err != nil {
 		return err
 	}
 
@@ -1687,28 +2056,54 @@ func removeForms(ctx *model.Context, d types.Dict, ids []string, i int) error {
 	return removeResDictEntry(ctx, d, "XObject", ids, i)
 }
 
-func removeArtifacts(sd *types.StreamDict, i int) (ok bool, extGStates []string, forms []string, err error) {
+// artifactResources returns the names of the extGState and form used by the watermark artifact t.
+func artifactResources(t string) (extGState, form string) {
+	i := strings.Index(t, "/GS")
+	if i > 0 {
+		j := i + 3
+		k := strings.Index(t[j:], " gs")
+		if k > 0 {
+			extGState = "GS" + t[j:j+k]
+		}
+	}
+
+	i = strings.Index(t, "/Fm")
+	if i > 0 {
+		j := i + 3
+		k := strings.Index(t[j:], " Do")
+		if k > 0 {
+			form = "Fm" + t[j:j+k]
+		}
+	}
+
+	return extGState, form
+}
+
+// removeArtifacts removes watermark artifacts from sd.
+// If id is not empty only watermarks with matching id are removed.
+func removeArtifacts(ctx *model.Context, sd *types.StreamDict, resDict types.Dict, id string, i int) (ok bool, extGStates, forms, ids []string, err error) {
 	err = sd.Decode()
 	if err == filter.ErrUnsupportedFilter {
 		if log.InfoEnabled() {
 			log.Info.Printf("unsupported filter: unable to patch content with watermark for page %d\n", i)
 		}
-		return false, nil, nil, nil
+		return false, nil, nil, nil, nil
 	}
 	if err != nil {
-		return false, nil, nil, err
+		return false, nil, nil, nil, err
 	}
 
 	var patched bool
 
 	// Watermarks may begin or end the content stream.
 
-	for {
+	for off := 0; ; {
 		s := string(sd.Content)
-		beg := strings.Index(s, "/Artifact <</Subtype /Watermark /Type /Pagination >>BDC")
+		beg := strings.Index(s[off:], "/Artifact <</Subtype /Watermark /Type /Pagination >>BDC")
 		if beg < 0 {
 			break
 		}
+		beg += off
 
 		end := strings.Index(s[beg:], "EMC")
 		if end < 0 {
@@ -1716,28 +2111,32 @@ func removeArtifacts(sd *types.StreamDict, i int) (ok bool, extGStates []string,
 		}
 
 		// Check for usage of resources.
-		t := s[beg : beg+end]
-
-		i := strings.Index(t, "/GS")
-		if i > 0 {
-			j := i + 3
-			k := strings.Index(t[j:], " gs")
-			if k > 0 {
-				extGStates = append(extGStates, "GS"+t[j:j+k])
-			}
+		gs, fm := artifactResources(s[beg : beg+end])
+
+		wmID := ""
+		if fm != "" {
+			wmID = watermarkID(ctx, resDict, fm)
 		}
 
-		i = strings.Index(t, "/Fm")
-		if i > 0 {
-			j := i + 3
-			k := strings.Index(t[j:], " Do")
-			if k > 0 {
-				forms = append(forms, "Fm"+t[j:j+k])
-			}
+		if id != "" && wmID != id {
+			// Skip watermarks not matching id.
+			off = beg + end + 3
+			continue
+		}
+
+		if gs != "" {
+			extGStates = append(extGStates, gs)
+		}
+		if fm != "" {
+			forms = append(forms, fm)
+		}
+		if wmID != "" {
+			ids = append(ids, wmID)
 		}
 
 		// TODO Remove whitespace until 0x0a
 		sd.Content = append(sd.Content[:beg], sd.Content[beg+end+3:]...)
+		off = beg
 		patched = true
 	}
 
@@ -1745,28 +2144,28 @@ func removeArtifacts(sd *types.StreamDict, i int) (ok bool, extGStates []string,
 		err = sd.Encode()
 	}
 
-	return patched, extGStates, forms, err
+	return patched, extGStates, forms, ids, err
 }
 
-func removeArtifactsFromPage(ctx *model.Context, sd *types.StreamDict, resDict types.Dict, i int) (bool, error) {
+func removeArtifactsFromPage(ctx *model.Context, sd *types.StreamDict, resDict types.Dict, id string, i int) (bool, []string, error) {
 	// Remove watermark artifacts and locate id's
 	// of used extGStates and forms.
-	ok, extGStates, forms, err := removeArtifacts(sd, i)
+	ok, extGStates, forms, ids, err := removeArtifacts(ctx, sd, resDict, id, i)
 	if err != nil {
-		return false, err
+		return false, nil, err
 	}
 	if !ok {
-		return false, nil
+		return false, nil, nil
 	}
 
 	// Remove obsolete extGStates from page resource dict.
 	err = removeExtGStates(ctx, resDict, extGStates, i)
 	if err != nil {
-		return false, err
+		return false, nil, err
 	}
 
 	// Remove obsolete forms from page resource dict.
-	return true, removeForms(ctx, resDict, forms, i)
+	return true, ids, removeForms(ctx, resDict, forms, i)
 }
 
 func locatePageContentAndResourceDict(ctx *model.Context, pageNr int) (types.Object, *types.IndirectRef, types.Dict, error) {
@@ -1794,18 +2193,20 @@ func locatePageContentAndResourceDict(ctx *model.Context, pageNr int) (types.Obj
 	return o, pageDictIndRef, resDict, nil
 }
 
-func removeArtifacts1(ctx *model.Context, o types.Object, entry *model.XRefTableEntry, resDict types.Dict, pageNr int) (bool, error) {
+func removeArtifacts1(ctx *model.Context, o types.Object, entry *model.XRefTableEntry, resDict types.Dict, id string, pageNr int) (bool, []string, error) {
 	found := false
+	var ids []string
 	switch o := o.(type) {
 
 	case types.StreamDict:
-		ok, err := removeArtifactsFromPage(ctx, &o, resDict, pageNr)
+		ok, ids1, err := removeArtifactsFromPage(ctx, &o, resDict, id, pageNr)
 		if err != nil {
-			return false, err
+			return false, nil, err
 		}
 		if !found && ok {
 			found = true
 		}
+		ids = append(ids, ids1...)
 		entry.Object = o
 
 	case types.Array:
@@ -1817,19 +2218,20 @@ func removeArtifacts1(ctx *model.Context, o types.Object, entry *model.XRefTable
 		entry, _ := ctx.FindTableEntry(objNr, genNr)
 		sd, _ := (entry.Object).(types.StreamDict)
 
-		ok, err := removeArtifactsFromPage(ctx, &sd, resDict, pageNr)
+		ok, ids1, err := removeArtifactsFromPage(ctx, &sd, resDict, id, pageNr)
 		if err != nil {
-			return false, err
+			return false, nil, err
 		}
 		if !found && ok {
 			found = true
 			entry.Object = sd
 		}
+		ids = append(ids, ids1...)
 
 		if len(o) > 1 {
 			// Get stream dict for last element.
 			if len(o) == 0 {
-				return false, nil
+				return false, nil, nil
 			}
 			o1 := o[len(o)-1]
 			ir, _ := o1.(types.IndirectRef)
@@ -1838,21 +2240,32 @@ func removeArtifacts1(ctx *model.Context, o types.Object, entry *model.XRefTable
 			entry, _ := ctx.FindTableEntry(objNr, genNr)
 			sd, _ := (entry.Object).(types.StreamDict)
 
-			ok, err = removeArtifactsFromPage(ctx, &sd, resDict, pageNr)
+			ok, ids1, err = removeArtifactsFromPage(ctx, &sd, resDict, id, pageNr)
 			if err != nil {
-				return false, err
+				return false, nil, err
 			}
-			if !found && ok {
+			if ok {
 				found = true
 				entry.Object = sd
 			}
+			ids = append(ids, ids1...)
 		}
 
 	}
-	return found, nil
+	return found, ids, nil
 }
 
-func removePageWatermark(ctx *model.Context, pageNr int) (bool, error) {
+// linkAnnotationID returns the id of the link annotation for a stamp identified by id.
+func linkAnnotationID(id string) string {
+	if id == "" {
+		return "pdfcpu"
+	}
+	return "pdfcpu:" + id
+}
+
+// removePageWatermark removes the watermarks of page pageNr.
+// If id is not empty only watermarks with matching id are removed.
+func removePageWatermark(ctx *model.Context, pageNr int, id string) (bool, error) {
 	o, pageDictIndRef, resDict, err := locatePageContentAndResourceDict(ctx, pageNr)
 	if err != nil {
 		return false, err
@@ -1868,7 +2281,7 @@ func removePageWatermark(ctx *model.Context, pageNr int) (bool, error) {
 		o = entry.Object
 	}
 
-	found, err := removeArtifacts1(ctx, o, entry, resDict, pageNr)
+	found, ids, err := removeArtifacts1(ctx, o, entry, resDict, id, pageNr)
 	if err != nil {
 		return false, err
 	}
@@ -1892,8 +2305,15 @@ func removePageWatermark(ctx *model.Context, pageNr int) (bool, error) {
 		if err != nil {
 			return false, err
 		}
+		annIDs := []string{}
+		if id == "" {
+			annIDs = append(annIDs, linkAnnotationID(""))
+		}
+		for _, id := range ids {
+			annIDs = append(annIDs, linkAnnotationID(id))
+		}
 		objNr := pageDictIndRef.ObjectNumber.Value()
-		if _, err = RemoveAnnotationsFromPageDict(ctx, nil, []string{"pdfcpu"}, nil, d, objNr, pageNr, false); err != nil {
+		if _, err = RemoveAnnotationsFromPageDict(ctx, nil, annIDs, nil, d, objNr, pageNr, false); err != nil {
 			return false, err
 		}
 	}
@@ -1956,7 +2376,7 @@ func detectStampOCG(ctx *model.Context, arr types.Array) error {
 	return errNoWatermark
 }
 
-func removePageWatermarks(ctx *model.Context, selectedPages types.IntSet) error {
+func removePageWatermarks(ctx *model.Context, selectedPages types.IntSet, id string) error {
 	var removed bool
 
 	for k, v := range selectedPages {
@@ -1965,7 +2385,7 @@ func removePageWatermarks(ctx *model.Context, selectedPages types.IntSet) error
 			continue
 		}
 
-		ok, err := removePageWatermark(ctx, k)
+		ok, err := removePageWatermark(ctx, k, id)
 		if err != nil {
 			return err
 		}
@@ -1984,8 +2404,14 @@ func removePageWatermarks(ctx *model.Context, selectedPages types.IntSet) error
 
 // RemoveWatermarks removes watermarks for all pages selected.
 func RemoveWatermarks(ctx *model.Context, selectedPages types.IntSet) error {
+	return RemoveWatermarksByID(ctx, selectedPages, "")
+}
+
+// RemoveWatermarksByID removes watermarks identified by id for all pages selected.
+// If id is empty all watermarks are removed.
+func RemoveWatermarksByID(ctx *model.Context, selectedPages types.IntSet, id string) error {
 	if log.DebugEnabled() {
-		log.Debug.Printf("RemoveWatermarks\n")
+		log.Debug.Printf("RemoveWatermarks id=%s\n", id)
 	}
 
 	arr, err := locateOCGs(ctx)
@@ -1997,7 +2423,7 @@ func RemoveWatermarks(ctx *model.Context, selectedPages types.IntSet) error {
 		return err
 	}
 
-	return removePageWatermarks(ctx, selectedPages)
+	return removePageWatermarks(ctx, selectedPages, id)
 }
 
 func detectArtifacts(sd *types.StreamDict) (bool, error) {
@@ -0,0 +1,356 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+const maxStructDepth = 256
+
+// Structure attributes taken over into StructElem.Attrs (see 14.8.5).
+var structAttrs = map[string]bool{
+	"ListNumbering": true, "RowSpan": true, "ColSpan": true, "Scope": true,
+}
+
+// StructElem is a node of the logical structure tree of a tagged PDF (see 14.7.2).
+// Content items are represented as kids without Type carrying the text of a marked content sequence.
+type StructElem struct {
+	Type       string            // Standard structure type after role mapping, eg. H1, P, L, Table.
+	Alt        string            // Alternate description.
+	ActualText string            // Replacement text for the content of this element.
+	Lang       string            // Natural language.
+	URI        string            // Link target of Link elements.
+	Attrs      map[string]string // Selected list and table attributes, eg. ListNumbering, RowSpan, ColSpan.
+	Page       int               // Page number of content items.
+	Text       string            // Text of content items.
+	Kids       []*StructElem
+}
+
+// structTreeReader resolves the structure tree of a context.
+type structTreeReader struct {
+	ctx     *model.Context
+	roleMap types.Dict
+	pages   map[int]int            // page objNr -> pageNr
+	marked  map[int]map[int]string // pageNr -> MCID -> text
+	visited map[int]bool           // objNrs of processed structure elements
+}
+
+// pageMarkedContent returns the text of all marked content sequences of page pageNr by MCID.
+func pageMarkedContent(ctx *model.Context, pageNr int) (map[int]string, error) {
+	d, _, inhPAttrs, err := ctx.PageDict(pageNr, false)
+	if err != nil || d == nil {
+		return nil, err
+	}
+
+	bb, err := ctx.PageContent(d)
+	if err != nil {
+		if err == model.ErrNoContent {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	te := &textExtractor{ctx: ctx, fonts: map[int]*textFont{}, marked: map[int]*strings.Builder{}}
+
+	if err := te.process(bb, inhPAttrs.Resources, 0); err != nil {
+		return nil, err
+	}
+
+	m := map[int]string{}
+	for mcid, sb := range te.marked {
+		m[mcid] = sb.String()
+	}
+
+	return m, nil
+}
+
+func (r *structTreeReader) pageNr(o types.Object) int {
+	ir, ok := o.(types.IndirectRef)
+	if !ok {
+		return 0
+	}
+
+	if r.pages == nil {
+		r.pages = map[int]int{}
+		for i := 1; i <= r.ctx.PageCount; i++ {
+			if ir, err := r.ctx.PageDictIndRef(i); err == nil && ir != nil {
+				r.pages[ir.ObjectNumber.Value()] = i
+			}
+		}
+	}
+
+	return r.pages[ir.ObjectNumber.Value()]
+}
+
+func (r *structTreeReader) markedContent(pageNr, mcid int) (string, error) {
+	if pageNr == 0 {
+		return "", nil
+	}
+
+	m, ok := r.marked[pageNr]
+	if !ok {
+		var err error
+		if m, err = pageMarkedContent(r.ctx, pageNr); err != nil {
+			return "", err
+		}
+		r.marked[pageNr] = m
+	}
+
+	return m[mcid], nil
+}
+
+// role maps a structure type to a standard structure type using the role map (see 14.7.3).
+func (r *structTreeReader) role(s string) string {
+	for i := 0; i < 10; i++ {
+		n, ok := r.roleMap[s].(types.Name)
+		if !ok || n.Value() == s {
+			break
+		}
+		s = n.Value()
+	}
+	return s
+}
+
+func (r *structTreeReader) text(d types.Dict, key string) string {
+	o, found := d.Find(key)
+	if !found {
+		return ""
+	}
+	s, err := r.ctx.DereferenceText(o)
+	if err != nil {
+		return ""
+	}
+	return s
+}
+
+// attributes collects the structure attributes of interest of d (see 14.7.6).
+func (r *structTreeReader) attributes(d types.Dict) map[string]string {
+	o, err := r.ctx.Dereference(d["A"])
+	if err != nil || o == nil {
+		return nil
+	}
+
+	var oo types.Array
+	switch o := o.(type) {
+	case types.Dict:
+		oo = types.Array{o}
+	case types.Array:
+		oo = o
+	}
+
+	m := map[string]string{}
+
+	for _, o := range oo {
+		d1, err := r.ctx.DereferenceDict(o)
+		if err != nil || d1 == nil {
+			continue
+		}
+		for k, v := range d1 {
+			if !structAttrs[k] {
+				continue
+			}
+			v, err := r.ctx.Dereference(v)
+			if err != nil {
+				continue
+			}
+			switch v := v.(type) {
+			case types.Name:
+				m[k] = v.Value()
+			case types.Integer:
+				m[k] = strconv.Itoa(v.Value())
+			}
+		}
+	}
+
+	if len(m) == 0 {
+		return nil
+	}
+
+	return m
+}
+
+// linkURI returns the URI of the link annotation referenced by an object reference dict (see 14.7.5.3).
+func (r *structTreeReader) linkURI(d types.Dict) string {
+	d1, err := r.ctx.DereferenceDict(d["Obj"])
+	if err != nil || d1 == nil {
+		return ""
+	}
+
+	if st := d1.Subtype(); st == nil || *st != "Link" {
+		return ""
+	}
+
+	d2, err := r.ctx.DereferenceDict(d1["A"])
+	if err != nil || d2 == nil {
+		return ""
+	}
+
+	if s := d2.NameEntry("S"); s == nil || *s != "URI" {
+		return ""
+	}
+
+	s, err := r.ctx.DereferenceStringOrHexLiteral(d2["URI"], model.V10, nil)
+	if err != nil {
+		return ""
+	}
+
+	return s
+}
+
+func (r *structTreeReader) kid(se *StructElem, o types.Object, pageNr, depth int) error {
+	o1, err := r.ctx.Dereference(o)
+	if err != nil || o1 == nil {
+		return err
+	}
+
+	switch o1 := o1.(type) {
+
+	case types.Integer:
+		// Marked content identifier on the page of the parent element.
+		s, err := r.markedContent(pageNr, o1.Value())
+		if err != nil {
+			return err
+		}
+		se.Kids = append(se.Kids, &StructElem{Page: pageNr, Text: s})
+
+	case types.Dict:
+		switch t := o1.Type(); {
+
+		case t != nil && *t == "MCR":
+			if _, ok := o1.Find("Stm"); ok {
+				// Marked content within form XObjects is not resolved.
+				return nil
+			}
+			if pg, ok := o1.Find("Pg"); ok {
+				pageNr = r.pageNr(pg)
+			}
+			mcid := o1.IntEntry("MCID")
+			if mcid == nil {
+				return nil
+			}
+			s, err := r.markedContent(pageNr, *mcid)
+			if err != nil {
+				return err
+			}
+			se.Kids = append(se.Kids, &StructElem{Page: pageNr, Text: s})
+
+		case t != nil && *t == "OBJR":
+			if se.URI == "" {
+				se.URI = r.linkURI(o1)
+			}
+
+		default:
+			if ir, ok := o.(types.IndirectRef); ok {
+				objNr := ir.ObjectNumber.Value()
+				if r.visited[objNr] {
+					return nil
+				}
+				r.visited[objNr] = true
+			}
+			kid, err := r.elem(o1, pageNr, depth+1)
+			if err != nil || kid == nil {
+				return err
+			}
+			se.Kids = append(se.Kids, kid)
+		}
+	}
+
+	return nil
+}
+
+func (r *structTreeReader) elem(d types.Dict, pageNr, depth int) (*StructElem, error) {
+	if depth > maxStructDepth {
+		return nil, nil
+	}
+
+	se := &StructElem{
+		Alt:        r.text(d, "Alt"),
+		ActualText: r.text(d, "ActualText"),
+		Lang:       r.text(d, "Lang"),
+		Attrs:      r.attributes(d),
+	}
+
+	if s := d.NameEntry("S"); s != nil {
+		se.Type = r.role(*s)
+	}
+
+	if pg, ok := d.Find("Pg"); ok {
+		if i := r.pageNr(pg); i > 0 {
+			pageNr = i
+		}
+	}
+
+	o, err := r.ctx.Dereference(d["K"])
+	if err != nil || o == nil {
+		return se, err
+	}
+
+	kids, ok := o.(types.Array)
+	if !ok {
+		kids = types.Array{d["K"]}
+	}
+
+	for _, o := range kids {
+		if err := r.kid(se, o, pageNr, depth); err != nil {
+			return nil, err
+		}
+	}
+
+	return se, nil
+}
+
+// StructTree returns the logical structure tree of ctx or nil if ctx is not tagged (see 14.7).
+// The root element has no Type and holds the top level structure elements.
+func StructTree(ctx *model.Context) (*StructElem, error) {
+	rootDict, err := ctx.Catalog()
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := ctx.DereferenceDict(rootDict["StructTreeRoot"])
+	if err != nil || d == nil {
+		return nil, err
+	}
+
+	if _, ok := d.Find("K"); !ok {
+		return nil, nil
+	}
+
+	r := &structTreeReader{
+		ctx:     ctx,
+		marked:  map[int]map[int]string{},
+		visited: map[int]bool{},
+	}
+
+	if r.roleMap, err = ctx.DereferenceDict(d["RoleMap"]); err != nil {
+		r.roleMap = nil
+	}
+
+	root, err := r.elem(types.Dict{"K": d["K"]}, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	root.Type = ""
+
+	return root, nil
+}
@@ -0,0 +1,455 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// TemplateRegion is a named placeholder region on a page of a template PDF.
+//
+// Regions are either recorded in the page-piece dict of a page
+// or defined by the widget of a form field, in which case Name is the fully qualified field name.
+type TemplateRegion struct {
+	Name   string           `json:"name"`
+	PageNr int              `json:"page"`
+	Rect   *types.Rectangle `json:"-"`
+	Field  bool             `json:"field,omitempty"`
+}
+
+// String returns a one line description of r.
+func (r TemplateRegion) String() string {
+	s := ""
+	if r.Field {
+		s = " (form field)"
+	}
+	return fmt.Sprintf("page %d: %s %s%s", r.PageNr, r.Name, r.Rect.ShortString(), s)
+}
+
+// TemplateFill describes the content replacing a template region.
+// Exactly one of Text, Image or Table is expected.
+type TemplateFill struct {
+	Text     string     `json:"text,omitempty"`
+	Image    string     `json:"image,omitempty"`  // Image file name
+	Table    [][]string `json:"table,omitempty"`  // Table rows
+	Header   []string   `json:"header,omitempty"` // Optional table header
+	Font     string     `json:"font,omitempty"`   // Defaults to Helvetica
+	FontSize int        `json:"size,omitempty"`   // Defaults to 12
+	Color    string     `json:"col,omitempty"`    // Text color
+	Align    string     `json:"align,omitempty"`  // Text alignment: left, center, right
+	Anchor   string     `json:"anchor,omitempty"` // Position within the region, defaults to tl for text and tables and to center for images.
+}
+
+const templateRegionsKey = "Regions"
+
+// templatePieceInfo returns the private data of the pdfcpu page-piece dict of page dict d.
+func templatePieceInfo(ctx *model.Context, d types.Dict) (types.Dict, error) {
+	pi, err := ctx.DereferenceDict(d["PieceInfo"])
+	if err != nil || pi == nil {
+		return nil, err
+	}
+	d1, err := ctx.DereferenceDict(pi["pdfcpu"])
+	if err != nil || d1 == nil {
+		return nil, err
+	}
+	return ctx.DereferenceDict(d1["Private"])
+}
+
+func pieceInfoRegions(ctx *model.Context, d types.Dict, pageNr int) ([]TemplateRegion, error) {
+	priv, err := templatePieceInfo(ctx, d)
+	if err != nil || priv == nil {
+		return nil, err
+	}
+
+	regions, err := ctx.DereferenceDict(priv[templateRegionsKey])
+	if err != nil || regions == nil {
+		return nil, err
+	}
+
+	var rr []TemplateRegion
+
+	for name, o := range regions {
+		a, err := ctx.DereferenceArray(o)
+		if err != nil {
+			return nil, err
+		}
+		if len(a) != 4 {
+			return nil, errors.Errorf("pdfcpu: invalid template region %s on page %d", name, pageNr)
+		}
+		r, err := ctx.RectForArray(a)
+		if err != nil {
+			return nil, err
+		}
+		rr = append(rr, TemplateRegion{Name: name, PageNr: pageNr, Rect: r})
+	}
+
+	return rr, nil
+}
+
+// fullyQualifiedFieldName returns the fully qualified name of the field widget d belongs to.
+func fullyQualifiedFieldName(ctx *model.Context, d types.Dict) (string, error) {
+	var ss []string
+
+	for i := 0; d != nil && i < 32; i++ {
+		if o, found := d.Find("T"); found {
+			s, err := ctx.DereferenceStringOrHexLiteral(o, model.V10, nil)
+			if err != nil {
+				return "", err
+			}
+			ss = append([]string{s}, ss...)
+		}
+		parent, err := ctx.DereferenceDict(d["Parent"])
+		if err != nil {
+			return "", err
+		}
+		d = parent
+	}
+
+	return strings.Join(ss, "."), nil
+}
+
+func fieldRegions(ctx *model.Context, d types.Dict, pageNr int) ([]TemplateRegion, error) {
+	annots, err := ctx.DereferenceArray(d["Annots"])
+	if err != nil || annots == nil {
+		return nil, err
+	}
+
+	var rr []TemplateRegion
+
+	for _, o := range annots {
+		d, err := ctx.DereferenceDict(o)
+		if err != nil {
+			return nil, err
+		}
+		if d == nil {
+			continue
+		}
+		if st := d.NameEntry("Subtype"); st == nil || *st != "Widget" {
+			continue
+		}
+
+		name, err := fullyQualifiedFieldName(ctx, d)
+		if err != nil {
+			return nil, err
+		}
+		if name == "" {
+			continue
+		}
+
+		a, err := ctx.DereferenceArray(d["Rect"])
+		if err != nil || len(a) != 4 {
+			continue
+		}
+		r, err := ctx.RectForArray(a)
+		if err != nil {
+			return nil, err
+		}
+
+		rr = append(rr, TemplateRegion{Name: name, PageNr: pageNr, Rect: r, Field: true})
+	}
+
+	return rr, nil
+}
+
+// TemplateRegions returns all template regions of ctx sorted by page number and name.
+func TemplateRegions(ctx *model.Context) ([]TemplateRegion, error) {
+	var rr []TemplateRegion
+
+	for pageNr := 1; pageNr <= ctx.PageCount; pageNr++ {
+		d, _, _, err := ctx.PageDict(pageNr, false)
+		if err != nil {
+			return nil, err
+		}
+		if d == nil {
+			continue
+		}
+
+		rr1, err := pieceInfoRegions(ctx, d, pageNr)
+		if err != nil {
+			return nil, err
+		}
+		rr = append(rr, rr1...)
+
+		if rr1, err = fieldRegions(ctx, d, pageNr); err != nil {
+			return nil, err
+		}
+		rr = append(rr, rr1...)
+	}
+
+	sort.SliceStable(rr, func(i, j int) bool {
+		if rr[i].PageNr != rr[j].PageNr {
+			return rr[i].PageNr < rr[j].PageNr
+		}
+		return rr[i].Name < rr[j].Name
+	})
+
+	return rr, nil
+}
+
+// ensureTemplateRegions returns the regions dict of the pdfcpu page-piece dict of page dict d, creating it if necessary.
+func ensureTemplateRegions(ctx *model.Context, d types.Dict) (types.Dict, error) {
+	pi, err := ctx.DereferenceDict(d["PieceInfo"])
+	if err != nil {
+		return nil, err
+	}
+	if pi == nil {
+		pi = types.Dict{}
+		d.Update("PieceInfo", pi)
+	}
+
+	d1, err := ctx.DereferenceDict(pi["pdfcpu"])
+	if err != nil {
+		return nil, err
+	}
+	if d1 == nil {
+		d1 = types.Dict{}
+		pi.Update("pdfcpu", d1)
+	}
+
+	now := types.StringLiteral(types.DateString(time.Now()))
+	d1.Update("LastModified", now)
+	d.Update("LastModified", now)
+
+	priv, err := ctx.DereferenceDict(d1["Private"])
+	if err != nil {
+		return nil, err
+	}
+	if priv == nil {
+		priv = types.Dict{}
+		d1.Update("Private", priv)
+	}
+
+	regions, err := ctx.DereferenceDict(priv[templateRegionsKey])
+	if err != nil {
+		return nil, err
+	}
+	if regions == nil {
+		regions = types.Dict{}
+		priv.Update(templateRegionsKey, regions)
+	}
+
+	return regions, nil
+}
+
+// AddTemplateRegions records the template regions rr in the page-piece dicts of their pages.
+// Existing regions with the same name on the same page are replaced.
+func AddTemplateRegions(ctx *model.Context, rr []TemplateRegion) error {
+	for _, r := range rr {
+		if r.Name == "" {
+			return errors.New("pdfcpu: template region: missing name")
+		}
+		if r.Rect == nil || r.Rect.Width() <= 0 || r.Rect.Height() <= 0 {
+			return errors.Errorf("pdfcpu: template region %s: invalid rectangle", r.Name)
+		}
+
+		d, _, _, err := ctx.PageDict(r.PageNr, false)
+		if err != nil {
+			return err
+		}
+		if d == nil {
+			return errors.Errorf("pdfcpu: unknown page number: %d\n", r.PageNr)
+		}
+
+		regions, err := ensureTemplateRegions(ctx, d)
+		if err != nil {
+			return err
+		}
+
+		regions.Update(r.Name, r.Rect.Array())
+	}
+
+	return nil
+}
+
+// RemoveTemplateRegion removes the page-piece based template region r.
+func RemoveTemplateRegion(ctx *model.Context, r TemplateRegion) error {
+	d, _, _, err := ctx.PageDict(r.PageNr, false)
+	if err != nil || d == nil {
+		return err
+	}
+
+	priv, err := templatePieceInfo(ctx, d)
+	if err != nil || priv == nil {
+		return err
+	}
+
+	regions, err := ctx.DereferenceDict(priv[templateRegionsKey])
+	if err != nil || regions == nil {
+		return err
+	}
+
+	regions.Delete(r.Name)
+
+	return nil
+}
+
+func (f TemplateFill) font() map[string]interface{} {
+	name, size := f.Font, f.FontSize
+	if name == "" {
+		name = "Helvetica"
+	}
+	if size <= 0 {
+		size = 12
+	}
+	m := map[string]interface{}{"name": name, "size": size}
+	if f.Color != "" {
+		m["col"] = f.Color
+	}
+	return m
+}
+
+func (f TemplateFill) anchor(def string) string {
+	if f.Anchor != "" {
+		return f.Anchor
+	}
+	return def
+}
+
+func (f TemplateFill) table(r *types.Rectangle) map[string]interface{} {
+	cols := len(f.Header)
+	for _, row := range f.Table {
+		if len(row) > cols {
+			cols = len(row)
+		}
+	}
+
+	values := make([][]string, len(f.Table))
+	for i, row := range f.Table {
+		values[i] = make([]string, cols)
+		copy(values[i], row)
+	}
+
+	font := f.font()
+
+	t := map[string]interface{}{
+		"values":  values,
+		"rows":    len(values),
+		"cols":    cols,
+		"anchor":  f.anchor("tl"),
+		"width":   r.Width(),
+		"lheight": font["size"].(int) + 4,
+		"font":    font,
+		"grid":    true,
+	}
+
+	if len(f.Header) > 0 {
+		header := make([]string, cols)
+		copy(header, f.Header)
+		t["header"] = map[string]interface{}{"values": header}
+	}
+
+	return t
+}
+
+// TemplateFillJSON returns the JSON for rendering f onto a single page the size of region r using create.
+func TemplateFillJSON(f TemplateFill, r *types.Rectangle) ([]byte, error) {
+	content := map[string]interface{}{}
+
+	switch {
+
+	case f.Text != "":
+		text := map[string]interface{}{
+			"value":  f.Text,
+			"anchor": f.anchor("tl"),
+			"width":  r.Width(),
+			"font":   f.font(),
+		}
+		if f.Align != "" {
+			text["align"] = f.Align
+		}
+		content["text"] = []interface{}{text}
+
+	case f.Image != "":
+		content["image"] = []interface{}{map[string]interface{}{
+			"src":    f.Image,
+			"anchor": f.anchor("center"),
+			"width":  r.Width(),
+			"height": r.Height(),
+		}}
+
+	case len(f.Table) > 0:
+		content["table"] = []interface{}{f.table(r)}
+
+	default:
+		return nil, errors.New("pdfcpu: template fill: missing text, image or table")
+	}
+
+	// The crop box of the rendered page matches the region.
+	paper := "A4"
+	if d := types.PaperSize["A4"]; r.Width() > d.Width || r.Height() > d.Height {
+		paper = "A0"
+	}
+
+	m := map[string]interface{}{
+		"paper": paper,
+		"crop":  fmt.Sprintf("[0 0 %.2f %.2f]", r.Width(), r.Height()),
+		"pages": map[string]interface{}{"1": map[string]interface{}{"content": content}},
+	}
+
+	return json.Marshal(m)
+}
+
+// ParseTemplateRegion parses a template region description like "name:logo, rect:10 10 200 80" given in unit for page pageNr.
+func ParseTemplateRegion(s string, pageNr int, unit types.DisplayUnit) (*TemplateRegion, error) {
+	r := &TemplateRegion{PageNr: pageNr}
+
+	for _, s := range strings.Split(s, ",") {
+
+		ss := strings.SplitN(s, ":", 2)
+		if len(ss) != 2 {
+			return nil, errors.New("pdfcpu: Invalid template region description. Please consult pdfcpu help template")
+		}
+
+		k, v := strings.ToLower(strings.TrimSpace(ss[0])), strings.TrimSpace(ss[1])
+
+		switch k {
+		case "name", "n":
+			r.Name = v
+		case "rect", "r":
+			ff := strings.Fields(v)
+			if len(ff) != 4 {
+				return nil, errors.Errorf("pdfcpu: invalid template region rect: %s", v)
+			}
+			var f [4]float64
+			for i, s := range ff {
+				f1, err := strconv.ParseFloat(s, 64)
+				if err != nil {
+					return nil, errors.Errorf("pdfcpu: invalid template region rect: %s", v)
+				}
+				f[i] = types.ToUserSpace(f1, unit)
+			}
+			r.Rect = types.NewRectangle(f[0], f[1], f[2], f[3])
+		default:
+			return nil, errors.Errorf("pdfcpu: unknown template region parameter: %s", k)
+		}
+	}
+
+	if r.Name == "" || r.Rect == nil {
+		return nil, errors.New("pdfcpu: template region: name and rect required")
+	}
+
+	return r, nil
+}
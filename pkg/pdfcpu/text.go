@@ -0,0 +1,974 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"encoding/hex"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/pdfcpu/pdfcpu/pkg/filter"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"golang.org/x/text/encoding/charmap"
+)
+
+// maxFormDepth limits the nesting of form XObjects processed during text extraction.
+const maxFormDepth = 8
+
+type (
+	contentOp   string // content stream operator
+	contentName string // name operand without leading /
+	contentDict map[contentName]interface{}
+)
+
+// contentLexer tokenizes content streams and CMaps (see 7.8.2).
+type contentLexer struct {
+	bb  []byte
+	pos int
+}
+
+func contentWhitespace(c byte) bool {
+	return c == 0x00 || c == 0x09 || c == 0x0A || c == 0x0C || c == 0x0D || c == 0x20
+}
+
+func contentDelimiter(c byte) bool {
+	return strings.IndexByte("()<>[]{}/%", c) >= 0
+}
+
+func (l *contentLexer) skipWhitespaceAndComments() {
+	for l.pos < len(l.bb) {
+		c := l.bb[l.pos]
+		if c == '%' {
+			for l.pos < len(l.bb) && l.bb[l.pos] != 0x0A && l.bb[l.pos] != 0x0D {
+				l.pos++
+			}
+			continue
+		}
+		if !contentWhitespace(c) {
+			return
+		}
+		l.pos++
+	}
+}
+
+func (l *contentLexer) literalString() []byte {
+	l.pos++
+	var (
+		b     []byte
+		depth int
+	)
+	for l.pos < len(l.bb) {
+		c := l.bb[l.pos]
+		l.pos++
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			if depth == 0 {
+				return b
+			}
+			depth--
+		case '\\':
+			if l.pos >= len(l.bb) {
+				return b
+			}
+			c = l.bb[l.pos]
+			l.pos++
+			switch c {
+			case 'n':
+				c = 0x0A
+			case 'r':
+				c = 0x0D
+			case 't':
+				c = 0x09
+			case 'b':
+				c = 0x08
+			case 'f':
+				c = 0x0C
+			case 0x0D:
+				// Line continuation
+				if l.pos < len(l.bb) && l.bb[l.pos] == 0x0A {
+					l.pos++
+				}
+				continue
+			case 0x0A:
+				continue
+			default:
+				if c >= '0' && c <= '7' {
+					v := int(c - '0')
+					for i := 0; i < 2 && l.pos < len(l.bb) && l.bb[l.pos] >= '0' && l.bb[l.pos] <= '7'; i++ {
+						v = v*8 + int(l.bb[l.pos]-'0')
+						l.pos++
+					}
+					c = byte(v)
+				}
+			}
+		}
+		b = append(b, c)
+	}
+	return b
+}
+
+func (l *contentLexer) hexString() []byte {
+	l.pos++
+	var h []byte
+	for l.pos < len(l.bb) && l.bb[l.pos] != '>' {
+		c := l.bb[l.pos]
+		if (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F') {
+			h = append(h, c)
+		}
+		l.pos++
+	}
+	l.pos++
+	if len(h)%2 == 1 {
+		h = append(h, '0')
+	}
+	b := make([]byte, len(h)/2)
+	hex.Decode(b, h)
+	return b
+}
+
+func (l *contentLexer) regular() string {
+	start := l.pos
+	for l.pos < len(l.bb) && !contentWhitespace(l.bb[l.pos]) && !contentDelimiter(l.bb[l.pos]) {
+		l.pos++
+	}
+	return string(l.bb[start:l.pos])
+}
+
+func (l *contentLexer) name() contentName {
+	l.pos++
+	s := l.regular()
+	if strings.IndexByte(s, '#') >= 0 {
+		if s1, err := types.DecodeName(s); err == nil {
+			s = s1
+		}
+	}
+	return contentName(s)
+}
+
+func (l *contentLexer) array() []interface{} {
+	arr := []interface{}{}
+	for {
+		l.skipWhitespaceAndComments()
+		if l.pos >= len(l.bb) {
+			return arr
+		}
+		if l.bb[l.pos] == ']' {
+			l.pos++
+			return arr
+		}
+		o, ok := l.next()
+		if !ok {
+			return arr
+		}
+		arr = append(arr, o)
+	}
+}
+
+func (l *contentLexer) dict() contentDict {
+	d := contentDict{}
+	var key *contentName
+	for {
+		l.skipWhitespaceAndComments()
+		if l.pos+1 >= len(l.bb) {
+			l.pos = len(l.bb)
+			return d
+		}
+		if l.bb[l.pos] == '>' && l.bb[l.pos+1] == '>' {
+			l.pos += 2
+			return d
+		}
+		o, ok := l.next()
+		if !ok {
+			return d
+		}
+		if key != nil {
+			d[*key], key = o, nil
+			continue
+		}
+		if n, ok := o.(contentName); ok {
+			key = &n
+		}
+	}
+}
+
+// next returns the next operand or operator.
+func (l *contentLexer) next() (interface{}, bool) {
+	for {
+		l.skipWhitespaceAndComments()
+		if l.pos >= len(l.bb) {
+			return nil, false
+		}
+
+		switch c := l.bb[l.pos]; c {
+		case '(':
+			return l.literalString(), true
+		case '<':
+			if l.pos+1 < len(l.bb) && l.bb[l.pos+1] == '<' {
+				l.pos += 2
+				return l.dict(), true
+			}
+			return l.hexString(), true
+		case '[':
+			l.pos++
+			return l.array(), true
+		case '/':
+			return l.name(), true
+		case ']', '>', ')', '{', '}':
+			// Skip stray delimiters.
+			l.pos++
+			continue
+		}
+
+		s := l.regular()
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return f, true
+		}
+		return contentOp(s), true
+	}
+}
+
+// contentOperators lists all content stream operators (see A.2).
+var contentOperators = map[contentOp]bool{
+	"b": true, "B": true, "b*": true, "B*": true, "BDC": true, "BI": true, "BMC": true, "BT": true, "BX": true,
+	"c": true, "cm": true, "CS": true, "cs": true, "d": true, "d0": true, "d1": true, "Do": true, "DP": true,
+	"EI": true, "EMC": true, "ET": true, "EX": true, "f": true, "F": true, "f*": true, "G": true, "g": true, "gs": true,
+	"h": true, "i": true, "ID": true, "j": true, "J": true, "K": true, "k": true, "l": true, "m": true, "M": true, "MP": true,
+	"n": true, "q": true, "Q": true, "re": true, "RG": true, "rg": true, "ri": true, "s": true, "S": true,
+	"SC": true, "sc": true, "SCN": true, "scn": true, "sh": true, "T*": true, "Tc": true, "Td": true, "TD": true,
+	"Tf": true, "Tj": true, "TJ": true, "TL": true, "Tm": true, "Tr": true, "Ts": true, "Tw": true, "Tz": true,
+	"v": true, "w": true, "W": true, "W*": true, "y": true, "'": true, "\"": true,
+}
+
+// contentFollows returns true if bb continues with content stream syntax at pos.
+func contentFollows(bb []byte, pos int) bool {
+	l := &contentLexer{bb: bb, pos: pos}
+	for i := 0; i < 8; i++ {
+		l.skipWhitespaceAndComments()
+		if l.pos >= len(bb) {
+			return true
+		}
+		if c := bb[l.pos]; c < 0x21 || c > 0x7E {
+			return false
+		}
+		o, ok := l.next()
+		if !ok {
+			return true
+		}
+		if op, ok := o.(contentOp); ok && op != "true" && op != "false" && op != "null" {
+			return contentOperators[op]
+		}
+	}
+	return true
+}
+
+// inlineImageEI returns the position of the EI operator terminating the inline image data following an ID operator at pos
+// or -1 if there is none.
+// Binary image data may contain "EI" surrounded by white-space, so the first EI followed by valid content wins.
+func inlineImageEI(bb []byte, pos int) int {
+	first := -1
+	for i := pos + 1; i+1 < len(bb); i++ {
+		if bb[i] != 'E' || bb[i+1] != 'I' || !contentWhitespace(bb[i-1]) {
+			continue
+		}
+		if i+2 < len(bb) && !contentWhitespace(bb[i+2]) {
+			continue
+		}
+		if contentFollows(bb, i+2) {
+			return i
+		}
+		if first < 0 {
+			first = i
+		}
+	}
+	return first
+}
+
+// skipInlineImage positions l after the end of the inline image data following an ID operator.
+func (l *contentLexer) skipInlineImage() {
+	if i := inlineImageEI(l.bb, l.pos); i >= 0 {
+		l.pos = i + 2
+		return
+	}
+	l.pos = len(l.bb)
+}
+
+// forEachContentOp calls fn for each operator of content stream bb along with its operands.
+// The operands of ID are the entries of the inline image dict.
+func forEachContentOp(bb []byte, fn func(op contentOp, oo []interface{})) {
+	l := &contentLexer{bb: bb}
+	var oo []interface{}
+
+	for {
+		o, ok := l.next()
+		if !ok {
+			return
+		}
+
+		op, ok := o.(contentOp)
+		if !ok {
+			oo = append(oo, o)
+			continue
+		}
+
+		fn(op, oo)
+
+		if op == "ID" {
+			l.skipInlineImage()
+		}
+
+		oo = oo[:0]
+	}
+}
+
+// toUnicodeCMap maps character codes to Unicode (see 9.10.3).
+type toUnicodeCMap struct {
+	codeLens []int
+	m        map[string]string
+}
+
+func utf16BEToString(b []byte) string {
+	if len(b)%2 == 1 {
+		return string(b)
+	}
+	u := make([]uint16, len(b)/2)
+	for i := range u {
+		u[i] = uint16(b[2*i])<<8 | uint16(b[2*i+1])
+	}
+	return string(utf16.Decode(u))
+}
+
+func (cm *toUnicodeCMap) addCodeLen(n int) {
+	for _, n1 := range cm.codeLens {
+		if n1 == n {
+			return
+		}
+	}
+	cm.codeLens = append(cm.codeLens, n)
+	sort.Ints(cm.codeLens)
+}
+
+func codeValue(b []byte) uint32 {
+	var v uint32
+	for _, c := range b {
+		v = v<<8 | uint32(c)
+	}
+	return v
+}
+
+func codeBytes(v uint32, n int) []byte {
+	b := make([]byte, n)
+	for i := n - 1; i >= 0; i-- {
+		b[i] = byte(v)
+		v >>= 8
+	}
+	return b
+}
+
+func (cm *toUnicodeCMap) addRange(lo, hi []byte, dst interface{}) {
+	if len(lo) != len(hi) || len(lo) == 0 || len(lo) > 4 {
+		return
+	}
+
+	v0, v1 := codeValue(lo), codeValue(hi)
+	if v1 < v0 || v1-v0 > math.MaxUint16 {
+		return
+	}
+
+	switch dst := dst.(type) {
+
+	case []byte:
+		if len(dst) < 2 {
+			return
+		}
+		for v := v0; v <= v1; v++ {
+			d := append([]byte(nil), dst...)
+			i := uint32(d[len(d)-2])<<8 | uint32(d[len(d)-1]) + v - v0
+			d[len(d)-2], d[len(d)-1] = byte(i>>8), byte(i)
+			cm.m[string(codeBytes(v, len(lo)))] = utf16BEToString(d)
+		}
+
+	case []interface{}:
+		for i, o := range dst {
+			if v0+uint32(i) > v1 {
+				break
+			}
+			if d, ok := o.([]byte); ok {
+				cm.m[string(codeBytes(v0+uint32(i), len(lo)))] = utf16BEToString(d)
+			}
+		}
+	}
+}
+
+func parseToUnicodeCMap(bb []byte, twoByte bool) *toUnicodeCMap {
+	cm := &toUnicodeCMap{m: map[string]string{}}
+
+	l := &contentLexer{bb: bb}
+	var (
+		oo   []interface{}
+		mode contentOp
+	)
+
+	for {
+		o, ok := l.next()
+		if !ok {
+			break
+		}
+
+		op, ok := o.(contentOp)
+		if !ok {
+			if mode != "" {
+				oo = append(oo, o)
+			}
+			continue
+		}
+
+		switch op {
+
+		case "begincodespacerange", "beginbfchar", "beginbfrange":
+			mode, oo = op, nil
+
+		case "endcodespacerange":
+			for i := 0; i+1 < len(oo); i += 2 {
+				if b, ok := oo[i].([]byte); ok && len(b) > 0 {
+					cm.addCodeLen(len(b))
+				}
+			}
+			mode = ""
+
+		case "endbfchar":
+			for i := 0; i+1 < len(oo); i += 2 {
+				src, ok1 := oo[i].([]byte)
+				dst, ok2 := oo[i+1].([]byte)
+				if ok1 && ok2 {
+					cm.m[string(src)] = utf16BEToString(dst)
+				}
+			}
+			mode = ""
+
+		case "endbfrange":
+			for i := 0; i+2 < len(oo); i += 3 {
+				lo, ok1 := oo[i].([]byte)
+				hi, ok2 := oo[i+1].([]byte)
+				if ok1 && ok2 {
+					cm.addRange(lo, hi, oo[i+2])
+				}
+			}
+			mode = ""
+		}
+	}
+
+	if len(cm.codeLens) == 0 {
+		for k := range cm.m {
+			cm.addCodeLen(len(k))
+		}
+	}
+
+	if len(cm.codeLens) == 0 {
+		n := 1
+		if twoByte {
+			n = 2
+		}
+		cm.codeLens = []int{n}
+	}
+
+	return cm
+}
+
+func (cm *toUnicodeCMap) decode(b []byte) string {
+	var sb strings.Builder
+	for i := 0; i < len(b); {
+		n := 0
+		for _, n1 := range cm.codeLens {
+			if i+n1 > len(b) {
+				break
+			}
+			if s, ok := cm.m[string(b[i:i+n1])]; ok {
+				sb.WriteString(s)
+				n = n1
+				break
+			}
+		}
+		if n == 0 {
+			n = cm.codeLens[0]
+		}
+		i += n
+	}
+	return sb.String()
+}
+
+var glyphRunes = map[string]rune{
+	"space": ' ', "exclam": '!', "quotedbl": '"', "numbersign": '#', "dollar": '$', "percent": '%',
+	"ampersand": '&', "quotesingle": '\'', "quoteright": '’', "quoteleft": '‘', "parenleft": '(',
+	"parenright": ')', "asterisk": '*', "plus": '+', "comma": ',', "hyphen": '-', "period": '.',
+	"slash": '/', "zero": '0', "one": '1', "two": '2', "three": '3', "four": '4', "five": '5',
+	"six": '6', "seven": '7', "eight": '8', "nine": '9', "colon": ':', "semicolon": ';', "less": '<',
+	"equal": '=', "greater": '>', "question": '?', "at": '@', "bracketleft": '[', "backslash": '\\',
+	"bracketright": ']', "underscore": '_', "braceleft": '{', "bar": '|', "braceright": '}',
+	"endash": '–', "emdash": '—', "bullet": '•', "ellipsis": '…', "quotedblleft": '“',
+	"quotedblright": '”', "Euro": '€', "fi": 'ﬁ', "fl": 'ﬂ', "adieresis": 'ä', "odieresis": 'ö',
+	"udieresis": 'ü', "Adieresis": 'Ä', "Odieresis": 'Ö', "Udieresis": 'Ü', "germandbls": 'ß',
+	"eacute": 'é', "egrave": 'è', "aacute": 'á', "agrave": 'à', "ccedilla": 'ç', "section": '§',
+}
+
+func glyphRune(s string) (rune, bool) {
+	if r, ok := glyphRunes[s]; ok {
+		return r, true
+	}
+	if len(s) == 1 {
+		return rune(s[0]), true
+	}
+	for _, prefix := range []string{"uni", "u"} {
+		if strings.HasPrefix(s, prefix) && len(s)-len(prefix) >= 4 && len(s)-len(prefix) <= 6 {
+			if i, err := strconv.ParseUint(s[len(prefix):], 16, 32); err == nil {
+				return rune(i), true
+			}
+		}
+	}
+	return 0, false
+}
+
+// textFont decodes strings shown using a font.
+type textFont struct {
+	cmap    *toUnicodeCMap
+	twoByte bool
+	enc     *charmap.Charmap
+	diff    map[byte]rune
+	metrics *glyphMetrics
+}
+
+func newTextFont(ctx *model.Context, d types.Dict) *textFont {
+	f := &textFont{enc: charmap.Windows1252}
+
+	if st := d.Subtype(); st != nil && *st == "Type0" {
+		f.twoByte = true
+	}
+
+	if sd, _, err := ctx.DereferenceStreamDict(d["ToUnicode"]); err == nil && sd != nil && sd.Decode() == nil {
+		f.cmap = parseToUnicodeCMap(sd.Content, f.twoByte)
+	}
+
+	f.metrics = newGlyphMetrics(ctx, d, f.twoByte)
+
+	o, err := ctx.Dereference(d["Encoding"])
+	if err != nil {
+		return f
+	}
+
+	var base types.Object = o
+	if d1, ok := o.(types.Dict); ok {
+		base = d1["BaseEncoding"]
+		if arr, err := ctx.DereferenceArray(d1["Differences"]); err == nil {
+			f.diff = differences(arr)
+		}
+	}
+
+	if n, ok := base.(types.Name); ok && n.Value() == "MacRomanEncoding" {
+		f.enc = charmap.Macintosh
+	}
+
+	return f
+}
+
+func differences(arr types.Array) map[byte]rune {
+	m := map[byte]rune{}
+	code := 0
+	for _, o := range arr {
+		switch o := o.(type) {
+		case types.Integer:
+			code = o.Value()
+		case types.Name:
+			if r, ok := glyphRune(o.Value()); ok && code >= 0 && code < 256 {
+				m[byte(code)] = r
+			}
+			code++
+		}
+	}
+	return m
+}
+
+func (f *textFont) decode(b []byte) string {
+	if f == nil {
+		f = &textFont{enc: charmap.Windows1252}
+	}
+
+	if f.cmap != nil {
+		return f.cmap.decode(b)
+	}
+
+	if f.twoByte {
+		// Unknown CID to Unicode mapping.
+		return ""
+	}
+
+	rr := make([]rune, 0, len(b))
+	for _, c := range b {
+		if r, ok := f.diff[c]; ok {
+			rr = append(rr, r)
+			continue
+		}
+		if c < 0x20 {
+			continue
+		}
+		rr = append(rr, f.enc.DecodeByte(c))
+	}
+
+	return string(rr)
+}
+
+// textExtractor collects the text shown by content streams.
+type textExtractor struct {
+	ctx        *model.Context
+	fonts      map[int]*textFont
+	sb         strings.Builder
+	y, leading float64
+	lastY      float64
+	space      bool
+	pos        *textPositions           // Collects glyph positions if not nil.
+	marked     map[int]*strings.Builder // Collects the text of marked content sequences by MCID if not nil.
+	mcids      []int                    // Marked content stack, -1 for sequences without MCID.
+}
+
+// out returns the builder for text shown within the current marked content sequence.
+func (te *textExtractor) out() *strings.Builder {
+	if te.marked == nil || len(te.mcids) == 0 || te.mcids[len(te.mcids)-1] < 0 {
+		return &te.sb
+	}
+	mcid := te.mcids[len(te.mcids)-1]
+	sb, ok := te.marked[mcid]
+	if !ok {
+		sb = &strings.Builder{}
+		te.marked[mcid] = sb
+	}
+	return sb
+}
+
+// beginMarkedContent pushes the MCID of a marked content sequence with properties o (see 14.6).
+// Sequences without MCID inherit the MCID of the enclosing sequence.
+func (te *textExtractor) beginMarkedContent(resources types.Dict, o interface{}) {
+	mcid := -1
+	if len(te.mcids) > 0 {
+		mcid = te.mcids[len(te.mcids)-1]
+	}
+
+	switch o := o.(type) {
+	case contentDict:
+		if f, ok := o["MCID"].(float64); ok {
+			mcid = int(f)
+		}
+	case contentName:
+		if props, err := te.ctx.DereferenceDict(resources["Properties"]); err == nil && props != nil {
+			if d, err := te.ctx.DereferenceDict(props[string(o)]); err == nil && d != nil {
+				if i := d.IntEntry("MCID"); i != nil {
+					mcid = *i
+				}
+			}
+		}
+	}
+
+	te.mcids = append(te.mcids, mcid)
+}
+
+func (te *textExtractor) font(resources types.Dict, name string) *textFont {
+	fonts, err := te.ctx.DereferenceDict(resources["Font"])
+	if err != nil || fonts == nil {
+		return nil
+	}
+
+	o := fonts[name]
+
+	ir, indirect := o.(types.IndirectRef)
+	if indirect {
+		if f, ok := te.fonts[ir.ObjectNumber.Value()]; ok {
+			return f
+		}
+	}
+
+	d, err := te.ctx.DereferenceDict(o)
+	if err != nil || d == nil {
+		return nil
+	}
+
+	f := newTextFont(te.ctx, d)
+	if indirect {
+		te.fonts[ir.ObjectNumber.Value()] = f
+	}
+
+	return f
+}
+
+func (te *textExtractor) show(f *textFont, b []byte) {
+	if te.pos != nil {
+		te.pos.show(f, b)
+	}
+
+	s := f.decode(b)
+	if s == "" {
+		return
+	}
+
+	sb := te.out()
+
+	if sb.Len() > 0 {
+		if math.Abs(te.y-te.lastY) > 0.1 {
+			sb.WriteByte('\n')
+		} else if te.space && !strings.HasSuffix(sb.String(), " ") && !strings.HasPrefix(s, " ") {
+			sb.WriteByte(' ')
+		}
+	}
+
+	sb.WriteString(s)
+	te.lastY, te.space = te.y, false
+}
+
+func (te *textExtractor) nextLine() {
+	if te.leading == 0 {
+		// Force a line break.
+		te.y -= 1
+		return
+	}
+	te.y -= te.leading
+}
+
+func (te *textExtractor) form(resources types.Dict, name string, depth int) error {
+	xobjs, err := te.ctx.DereferenceDict(resources["XObject"])
+	if err != nil || xobjs == nil {
+		return err
+	}
+
+	sd, _, err := te.ctx.DereferenceStreamDict(xobjs[name])
+	if err != nil || sd == nil {
+		return err
+	}
+
+	if st := sd.Subtype(); st == nil || *st != "Form" {
+		return nil
+	}
+
+	if err := sd.Decode(); err != nil {
+		if err == filter.ErrUnsupportedFilter {
+			return nil
+		}
+		return err
+	}
+
+	res, err := te.ctx.DereferenceDict(sd.Dict["Resources"])
+	if err != nil {
+		return err
+	}
+	if res == nil {
+		res = resources
+	}
+
+	te.space = true
+
+	if te.pos != nil {
+		te.pos.beginForm(sd.Dict)
+		defer te.pos.endForm()
+	}
+
+	return te.process(sd.Content, res, depth+1)
+}
+
+func numbers(oo []interface{}, n int) ([]float64, bool) {
+	if len(oo) < n {
+		return nil, false
+	}
+	ff := make([]float64, n)
+	for i, o := range oo[len(oo)-n:] {
+		f, ok := o.(float64)
+		if !ok {
+			return nil, false
+		}
+		ff[i] = f
+	}
+	return ff, true
+}
+
+func (te *textExtractor) showArray(f *textFont, arr []interface{}) {
+	for _, o := range arr {
+		switch o := o.(type) {
+		case []byte:
+			te.show(f, o)
+		case float64:
+			if te.pos != nil {
+				te.pos.kern(o)
+			}
+			if o < -200 {
+				te.space = true
+			}
+		}
+	}
+}
+
+func (te *textExtractor) process(bb []byte, resources types.Dict, depth int) error {
+	l := &contentLexer{bb: bb}
+
+	var (
+		oo []interface{}
+		f  *textFont
+	)
+
+	for {
+		o, ok := l.next()
+		if !ok {
+			return nil
+		}
+
+		op, ok := o.(contentOp)
+		if !ok {
+			oo = append(oo, o)
+			continue
+		}
+
+		if te.pos != nil {
+			te.pos.op(op, oo)
+		}
+
+		switch op {
+
+		case "BT":
+			te.y, te.space = 0, true
+
+		case "Tf":
+			if len(oo) >= 2 {
+				if n, ok := oo[len(oo)-2].(contentName); ok {
+					f = te.font(resources, string(n))
+				}
+			}
+
+		case "TL":
+			if ff, ok := numbers(oo, 1); ok {
+				te.leading = ff[0]
+			}
+
+		case "Td", "TD":
+			if ff, ok := numbers(oo, 2); ok {
+				te.y += ff[1]
+				if ff[1] == 0 && ff[0] != 0 {
+					te.space = true
+				}
+				if op == "TD" {
+					te.leading = -ff[1]
+				}
+			}
+
+		case "Tm":
+			if ff, ok := numbers(oo, 6); ok {
+				te.y, te.space = ff[5], true
+			}
+
+		case "T*":
+			te.nextLine()
+
+		case "Tj", "'", "\"":
+			if op != "Tj" {
+				te.nextLine()
+			}
+			if len(oo) > 0 {
+				if b, ok := oo[len(oo)-1].([]byte); ok {
+					te.show(f, b)
+				}
+			}
+
+		case "TJ":
+			if len(oo) > 0 {
+				if arr, ok := oo[len(oo)-1].([]interface{}); ok {
+					te.showArray(f, arr)
+				}
+			}
+
+		case "Do":
+			if depth < maxFormDepth && len(oo) > 0 {
+				if n, ok := oo[len(oo)-1].(contentName); ok {
+					if err := te.form(resources, string(n), depth); err != nil {
+						return err
+					}
+				}
+			}
+
+		case "ID":
+			l.skipInlineImage()
+
+		case "BMC":
+			te.beginMarkedContent(resources, nil)
+
+		case "BDC":
+			if len(oo) > 0 {
+				te.beginMarkedContent(resources, oo[len(oo)-1])
+			}
+
+		case "EMC":
+			if n := len(te.mcids); n > 0 {
+				te.mcids = te.mcids[:n-1]
+			}
+		}
+
+		oo = oo[:0]
+	}
+}
+
+// PageText returns the text shown on page pageNr of ctx in content stream order.
+// Text lines are separated by newlines.
+func PageText(ctx *model.Context, pageNr int) (string, error) {
+	d, _, inhPAttrs, err := ctx.PageDict(pageNr, false)
+	if err != nil {
+		return "", err
+	}
+	if d == nil {
+		return "", nil
+	}
+
+	bb, err := ctx.PageContent(d)
+	if err != nil {
+		if err == model.ErrNoContent {
+			return "", nil
+		}
+		return "", err
+	}
+
+	te := &textExtractor{ctx: ctx, fonts: map[int]*textFont{}}
+
+	if err := te.process(bb, inhPAttrs.Resources, 0); err != nil {
+		return "", err
+	}
+
+	return te.sb.String(), nil
+}
+
+// PagesMatching returns the numbers of all pages of ctx whose text matches re.
+func PagesMatching(ctx *model.Context, re *regexp.Regexp) ([]int, error) {
+	var pageNrs []int
+
+	for i := 1; i <= ctx.PageCount; i++ {
+		s, err := PageText(ctx, i)
+		if err != nil {
+			return nil, err
+		}
+		if re.MatchString(s) {
+			pageNrs = append(pageNrs, i)
+		}
+	}
+
+	return pageNrs, nil
+}
@@ -0,0 +1,254 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// Metrics of the glyphless font used for invisible text layers.
+const (
+	textLayerFontName  = "GlyphLessFont"
+	textLayerGlyphW    = 500
+	textLayerAscent    = 800
+	textLayerDescent   = -200
+	textLayerFontResID = "OCR"
+)
+
+// OCRWord is a word recognized by an OCR engine.
+type OCRWord struct {
+	Text       string          `json:"text"`
+	Rect       types.Rectangle `json:"rect"`       // Bounding box in user space.
+	Confidence float64         `json:"confidence"` // 0..100 as reported by the OCR engine.
+}
+
+// textLayerToUnicode maps each 2 byte code to the Unicode code point of the same value (see 9.10.3).
+func textLayerToUnicode() string {
+	var sb strings.Builder
+
+	sb.WriteString("/CIDInit /ProcSet findresource begin\n12 dict begin\nbegincmap\n")
+	sb.WriteString("/CIDSystemInfo << /Registry (Adobe) /Ordering (UCS) /Supplement 0 >> def\n")
+	sb.WriteString("/CMapName /Adobe-Identity-UCS def\n/CMapType 2 def\n")
+	sb.WriteString("1 begincodespacerange\n<0000> <FFFF>\nendcodespacerange\n")
+
+	for i := 0; i < 256; i += 100 {
+		n := min(100, 256-i)
+		sb.WriteString(strconv.Itoa(n) + " beginbfrange\n")
+		for j := i; j < i+n; j++ {
+			fmt.Fprintf(&sb, "<%02X00> <%02XFF> <%02X00>\n", j, j, j)
+		}
+		sb.WriteString("endbfrange\n")
+	}
+
+	sb.WriteString("endcmap\nCMapName currentdict /CMap defineresource pop\nend\nend\n")
+
+	return sb.String()
+}
+
+// textLayerFont creates a Type0 font without glyphs using the 2 byte Unicode value as CID.
+// All glyphs share the same width so that words can be stretched onto their bounding boxes.
+func textLayerFont(ctx *model.Context) (*types.IndirectRef, error) {
+	fd := types.Dict{
+		"Type":        types.Name("FontDescriptor"),
+		"FontName":    types.Name(textLayerFontName),
+		"Flags":       types.Integer(5),
+		"FontBBox":    types.NewIntegerArray(0, textLayerDescent, textLayerGlyphW, textLayerAscent),
+		"ItalicAngle": types.Integer(0),
+		"Ascent":      types.Integer(textLayerAscent),
+		"Descent":     types.Integer(textLayerDescent),
+		"CapHeight":   types.Integer(textLayerAscent),
+		"StemV":       types.Integer(80),
+	}
+
+	fdIndRef, err := ctx.IndRefForNewObject(fd)
+	if err != nil {
+		return nil, err
+	}
+
+	cidFont := types.Dict{
+		"Type":     types.Name("Font"),
+		"Subtype":  types.Name("CIDFontType2"),
+		"BaseFont": types.Name(textLayerFontName),
+		"CIDSystemInfo": types.Dict{
+			"Registry":   types.StringLiteral("Adobe"),
+			"Ordering":   types.StringLiteral("Identity"),
+			"Supplement": types.Integer(0),
+		},
+		"FontDescriptor": *fdIndRef,
+		"DW":             types.Integer(textLayerGlyphW),
+		"CIDToGIDMap":    types.Name("Identity"),
+	}
+
+	cidFontIndRef, err := ctx.IndRefForNewObject(cidFont)
+	if err != nil {
+		return nil, err
+	}
+
+	toUnicodeIndRef, err := newContentStreamRef(ctx, textLayerToUnicode())
+	if err != nil {
+		return nil, err
+	}
+
+	d := types.Dict{
+		"Type":            types.Name("Font"),
+		"Subtype":         types.Name("Type0"),
+		"BaseFont":        types.Name(textLayerFontName),
+		"Encoding":        types.Name("Identity-H"),
+		"DescendantFonts": types.Array{*cidFontIndRef},
+		"ToUnicode":       *toUnicodeIndRef,
+	}
+
+	return ctx.IndRefForNewObject(d)
+}
+
+// textLayerHex returns s encoded as 2 byte codes, runes outside the BMP are replaced by U+FFFD.
+func textLayerHex(s string) (string, int) {
+	var sb strings.Builder
+	n := 0
+	for _, r := range s {
+		if r > 0xFFFF || r == utf8.RuneError {
+			r = 0xFFFD
+		}
+		fmt.Fprintf(&sb, "%04X", r)
+		n++
+	}
+	return sb.String(), n
+}
+
+// textLayerContent returns the content stream rendering ww invisibly using font resource id.
+func textLayerContent(ww []OCRWord, id string) string {
+	var sb strings.Builder
+
+	sb.WriteString("BT 3 Tr ")
+
+	for _, w := range ww {
+		s, n := textLayerHex(strings.TrimSpace(w.Text))
+		if n == 0 || w.Rect.Width() <= 0 || w.Rect.Height() <= 0 {
+			continue
+		}
+
+		// Stretch the text onto the bounding box of the word.
+		size := w.Rect.Height() * 1000 / (textLayerAscent - textLayerDescent)
+		tz := 100 * w.Rect.Width() / (float64(n) * textLayerGlyphW / 1000 * size)
+		y := w.Rect.LL.Y - textLayerDescent*size/1000
+
+		fmt.Fprintf(&sb, "/%s %.2f Tf %.2f Tz 1 0 0 1 %.2f %.2f Tm <%s> Tj ", id, size, tz, w.Rect.LL.X, y, s)
+	}
+
+	sb.WriteString("ET")
+
+	return sb.String()
+}
+
+func addTextLayerFontResource(ctx *model.Context, d types.Dict, resDict types.Dict, font types.IndirectRef) (string, error) {
+	if resDict == nil {
+		resDict = types.Dict{}
+	}
+
+	fontDict := types.Dict{}
+	if o, found := resDict.Find("Font"); found {
+		d1, err := ctx.DereferenceDict(o)
+		if err != nil {
+			return "", err
+		}
+		if d1 != nil {
+			fontDict = d1
+		}
+	}
+
+	id := textLayerFontResID + "0"
+	for i := 1; ; i++ {
+		if _, found := fontDict.Find(id); !found {
+			break
+		}
+		id = textLayerFontResID + strconv.Itoa(i)
+	}
+
+	fontDict.Insert(id, font)
+	resDict.Update("Font", fontDict)
+	d.Update("Resources", resDict)
+
+	return id, nil
+}
+
+// AddTextLayers adds invisible text (rendering mode 3) for OCR results to pages of ctx,
+// making scanned pages searchable and their text extractable.
+// layers maps page numbers to the words recognized on the page.
+// Words with a Confidence below minConfidence are skipped.
+func AddTextLayers(ctx *model.Context, layers map[int][]OCRWord, minConfidence float64) error {
+	pageNrs := make([]int, 0, len(layers))
+	for pageNr := range layers {
+		if pageNr < 1 || pageNr > ctx.PageCount {
+			return errors.Errorf("pdfcpu: invalid page number: %d", pageNr)
+		}
+		pageNrs = append(pageNrs, pageNr)
+	}
+	sort.Ints(pageNrs)
+
+	var font *types.IndirectRef
+
+	for _, pageNr := range pageNrs {
+		var ww []OCRWord
+		for _, w := range layers[pageNr] {
+			if w.Confidence >= minConfidence && strings.TrimSpace(w.Text) != "" {
+				ww = append(ww, w)
+			}
+		}
+		if len(ww) == 0 {
+			continue
+		}
+
+		if font == nil {
+			var err error
+			if font, err = textLayerFont(ctx); err != nil {
+				return err
+			}
+		}
+
+		d, _, inhPAttrs, err := ctx.PageDict(pageNr, false)
+		if err != nil {
+			return err
+		}
+		if d == nil {
+			return errors.Errorf("pdfcpu: unknown page number: %d\n", pageNr)
+		}
+
+		id, err := addTextLayerFontResource(ctx, d, inhPAttrs.Resources, *font)
+		if err != nil {
+			return err
+		}
+
+		if err := wrapPageContent(ctx, d, "q ", " Q q "+textLayerContent(ww, id)+" Q"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddTextLayer adds invisible text for the OCR results ww to page pageNr of ctx.
+func AddTextLayer(ctx *model.Context, pageNr int, ww []OCRWord) error {
+	return AddTextLayers(ctx, map[int][]OCRWord{pageNr: ww}, 0)
+}
@@ -0,0 +1,459 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"math"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/font"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/matrix"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// glyphMetrics provides glyph widths in thousandths of text space units (see 9.2.4).
+type glyphMetrics struct {
+	coreFont        string // Standard 14 font lacking /Widths.
+	firstChar       int
+	widths          []float64
+	cidWidths       map[int]float64
+	missingWidth    float64
+	ascent, descent float64
+}
+
+func (gm *glyphMetrics) parseFontDescriptor(ctx *model.Context, d types.Dict) {
+	fd, err := ctx.DereferenceDict(d["FontDescriptor"])
+	if err != nil || fd == nil {
+		return
+	}
+	if w, err := ctx.DereferenceNumber(fd["MissingWidth"]); err == nil && w > 0 {
+		gm.missingWidth = w
+	}
+	if a, err := ctx.DereferenceNumber(fd["Ascent"]); err == nil && a > 0 {
+		gm.ascent = a
+	}
+	if d, err := ctx.DereferenceNumber(fd["Descent"]); err == nil && d < 0 {
+		gm.descent = d
+	}
+}
+
+// parseCIDWidths parses the /W array of a CIDFont (see 9.7.4.3).
+func (gm *glyphMetrics) parseCIDWidths(ctx *model.Context, arr types.Array) {
+	gm.cidWidths = map[int]float64{}
+
+	for i := 0; i+1 < len(arr); {
+		first, err := ctx.DereferenceNumber(arr[i])
+		if err != nil {
+			return
+		}
+
+		o, err := ctx.Dereference(arr[i+1])
+		if err != nil {
+			return
+		}
+
+		if ww, ok := o.(types.Array); ok {
+			for j, w := range ww {
+				if f, err := ctx.DereferenceNumber(w); err == nil {
+					gm.cidWidths[int(first)+j] = f
+				}
+			}
+			i += 2
+			continue
+		}
+
+		if i+2 >= len(arr) {
+			return
+		}
+
+		last, err := ctx.DereferenceNumber(o)
+		if err != nil {
+			return
+		}
+		w, err := ctx.DereferenceNumber(arr[i+2])
+		if err != nil {
+			return
+		}
+		for c := int(first); c <= int(last) && c-int(first) < 0xFFFF; c++ {
+			gm.cidWidths[c] = w
+		}
+		i += 3
+	}
+}
+
+func newGlyphMetrics(ctx *model.Context, d types.Dict, twoByte bool) *glyphMetrics {
+	gm := &glyphMetrics{missingWidth: 500, ascent: 750, descent: -250}
+
+	if twoByte {
+		gm.missingWidth = 1000
+		arr, err := ctx.DereferenceArray(d["DescendantFonts"])
+		if err != nil || len(arr) == 0 {
+			return gm
+		}
+		d1, err := ctx.DereferenceDict(arr[0])
+		if err != nil || d1 == nil {
+			return gm
+		}
+		if dw, err := ctx.DereferenceNumber(d1["DW"]); err == nil {
+			gm.missingWidth = dw
+		}
+		if ww, err := ctx.DereferenceArray(d1["W"]); err == nil && ww != nil {
+			gm.parseCIDWidths(ctx, ww)
+		}
+		gm.parseFontDescriptor(ctx, d1)
+		return gm
+	}
+
+	gm.parseFontDescriptor(ctx, d)
+
+	ww, err := ctx.DereferenceArray(d["Widths"])
+	if err != nil || ww == nil {
+		if bf := d.NameEntry("BaseFont"); bf != nil && font.IsCoreFont(*bf) {
+			gm.coreFont = *bf
+		}
+		return gm
+	}
+
+	if fc, err := ctx.DereferenceNumber(d["FirstChar"]); err == nil {
+		gm.firstChar = int(fc)
+	}
+
+	gm.widths = make([]float64, len(ww))
+	for i, w := range ww {
+		if f, err := ctx.DereferenceNumber(w); err == nil {
+			gm.widths[i] = f
+		}
+	}
+
+	return gm
+}
+
+func (gm *glyphMetrics) width(code uint32) float64 {
+	if gm == nil {
+		return 500
+	}
+
+	if gm.cidWidths != nil {
+		if w, ok := gm.cidWidths[int(code)]; ok {
+			return w
+		}
+		return gm.missingWidth
+	}
+
+	if i := int(code) - gm.firstChar; gm.widths != nil && i >= 0 && i < len(gm.widths) {
+		return gm.widths[i]
+	}
+
+	if gm.coreFont != "" {
+		return float64(font.CharWidth(gm.coreFont, rune(code)))
+	}
+
+	return gm.missingWidth
+}
+
+// codes splits b into the character codes of f.
+func (f *textFont) codes(b []byte) [][]byte {
+	var bb [][]byte
+
+	for i := 0; i < len(b); {
+		n := 1
+		if f != nil && f.twoByte {
+			n = 2
+		}
+		if f != nil && f.cmap != nil {
+			n = f.cmap.codeLens[0]
+			for _, n1 := range f.cmap.codeLens {
+				if i+n1 > len(b) {
+					break
+				}
+				if _, ok := f.cmap.m[string(b[i:i+n1])]; ok {
+					n = n1
+					break
+				}
+			}
+		}
+		if i+n > len(b) {
+			n = len(b) - i
+		}
+		bb = append(bb, b[i:i+n])
+		i += n
+	}
+
+	return bb
+}
+
+// textGlyph is a shown glyph and its bounding box in user space.
+type textGlyph struct {
+	s           string
+	rect        types.Rectangle
+	origin, end types.Point // Baseline from glyph origin to the origin of the next glyph.
+	size        float64     // Effective font size.
+}
+
+// textPositions tracks the graphics and text state needed to position shown glyphs (see 9.3 and 9.4).
+type textPositions struct {
+	ctm      matrix.Matrix
+	stack    []matrix.Matrix
+	tm, tlm  matrix.Matrix
+	fontSize float64
+	tc, tw   float64 // character and word spacing
+	th       float64 // horizontal scaling
+	tl       float64 // leading
+	rise     float64
+	glyphs   []textGlyph
+}
+
+func newTextPositions() *textPositions {
+	return &textPositions{
+		ctm: matrix.IdentMatrix,
+		tm:  matrix.IdentMatrix,
+		tlm: matrix.IdentMatrix,
+		th:  1,
+	}
+}
+
+func matrixFor(ff []float64) matrix.Matrix {
+	return matrix.Matrix{{ff[0], ff[1], 0}, {ff[2], ff[3], 0}, {ff[4], ff[5], 1}}
+}
+
+func (tp *textPositions) translate(tx, ty float64) {
+	tp.tlm = matrix.Matrix{{1, 0, 0}, {0, 1, 0}, {tx, ty, 1}}.Multiply(tp.tlm)
+	tp.tm = tp.tlm
+}
+
+func (tp *textPositions) nextLine() {
+	tp.translate(0, -tp.tl)
+}
+
+func (tp *textPositions) beginForm(d types.Dict) {
+	tp.stack = append(tp.stack, tp.ctm)
+	if arr := d.ArrayEntry("Matrix"); len(arr) == 6 {
+		ff := make([]float64, 6)
+		for i, o := range arr {
+			switch o := o.(type) {
+			case types.Integer:
+				ff[i] = float64(o.Value())
+			case types.Float:
+				ff[i] = o.Value()
+			}
+		}
+		tp.ctm = matrixFor(ff).Multiply(tp.ctm)
+	}
+}
+
+func (tp *textPositions) endForm() {
+	if n := len(tp.stack); n > 0 {
+		tp.ctm, tp.stack = tp.stack[n-1], tp.stack[:n-1]
+	}
+}
+
+// op updates the graphics and text state for op with operands oo.
+func (tp *textPositions) op(op contentOp, oo []interface{}) {
+	switch op {
+
+	case "q":
+		tp.stack = append(tp.stack, tp.ctm)
+
+	case "Q":
+		tp.endForm()
+
+	case "cm":
+		if ff, ok := numbers(oo, 6); ok {
+			tp.ctm = matrixFor(ff).Multiply(tp.ctm)
+		}
+
+	case "BT":
+		tp.tm, tp.tlm = matrix.IdentMatrix, matrix.IdentMatrix
+
+	case "Tf":
+		if ff, ok := numbers(oo, 1); ok {
+			tp.fontSize = ff[0]
+		}
+
+	case "Tc":
+		if ff, ok := numbers(oo, 1); ok {
+			tp.tc = ff[0]
+		}
+
+	case "Tw":
+		if ff, ok := numbers(oo, 1); ok {
+			tp.tw = ff[0]
+		}
+
+	case "Tz":
+		if ff, ok := numbers(oo, 1); ok {
+			tp.th = ff[0] / 100
+		}
+
+	case "TL":
+		if ff, ok := numbers(oo, 1); ok {
+			tp.tl = ff[0]
+		}
+
+	case "Ts":
+		if ff, ok := numbers(oo, 1); ok {
+			tp.rise = ff[0]
+		}
+
+	case "Td", "TD":
+		if ff, ok := numbers(oo, 2); ok {
+			if op == "TD" {
+				tp.tl = -ff[1]
+			}
+			tp.translate(ff[0], ff[1])
+		}
+
+	case "Tm":
+		if ff, ok := numbers(oo, 6); ok {
+			tp.tm = matrixFor(ff)
+			tp.tlm = tp.tm
+		}
+
+	case "T*", "'":
+		tp.nextLine()
+
+	case "\"":
+		if len(oo) >= 3 {
+			if ff, ok := numbers(oo[:len(oo)-1], 2); ok {
+				tp.tw, tp.tc = ff[0], ff[1]
+			}
+		}
+		tp.nextLine()
+	}
+}
+
+// advance moves the text matrix horizontally by tx text space units.
+func (tp *textPositions) advance(tx float64) {
+	tp.tm = matrix.Matrix{{1, 0, 0}, {0, 1, 0}, {tx, 0, 1}}.Multiply(tp.tm)
+}
+
+// kern applies a TJ position adjustment.
+func (tp *textPositions) kern(n float64) {
+	tp.advance(-n / 1000 * tp.fontSize * tp.th)
+}
+
+func (tp *textPositions) glyphRect(w float64, gm *glyphMetrics) types.Rectangle {
+	asc, desc := 0.75, -0.25
+	if gm != nil {
+		asc, desc = gm.ascent/1000, gm.descent/1000
+	}
+
+	m := tp.tm.Multiply(tp.ctm)
+
+	pp := []types.Point{
+		m.Transform(types.Point{X: 0, Y: desc*tp.fontSize + tp.rise}),
+		m.Transform(types.Point{X: w, Y: desc*tp.fontSize + tp.rise}),
+		m.Transform(types.Point{X: w, Y: asc*tp.fontSize + tp.rise}),
+		m.Transform(types.Point{X: 0, Y: asc*tp.fontSize + tp.rise}),
+	}
+
+	r := types.Rectangle{LL: pp[0], UR: pp[0]}
+	for _, p := range pp[1:] {
+		r.LL.X, r.LL.Y = math.Min(r.LL.X, p.X), math.Min(r.LL.Y, p.Y)
+		r.UR.X, r.UR.Y = math.Max(r.UR.X, p.X), math.Max(r.UR.Y, p.Y)
+	}
+
+	return r
+}
+
+func distance(p, q types.Point) float64 {
+	return math.Hypot(q.X-p.X, q.Y-p.Y)
+}
+
+// show records the glyphs of b and advances the text matrix accordingly.
+func (tp *textPositions) show(f *textFont, b []byte) {
+	var gm *glyphMetrics
+	if f != nil {
+		gm = f.metrics
+	}
+
+	for _, code := range f.codes(b) {
+		w0 := gm.width(codeValue(code)) / 1000 * tp.fontSize
+		m := tp.tm.Multiply(tp.ctm)
+		o := m.Transform(types.Point{X: 0, Y: tp.rise})
+		tp.glyphs = append(tp.glyphs, textGlyph{
+			s:      f.decode(code),
+			rect:   tp.glyphRect(w0*tp.th, gm),
+			origin: o,
+			end:    m.Transform(types.Point{X: w0 * tp.th, Y: tp.rise}),
+			size:   distance(o, m.Transform(types.Point{X: 0, Y: tp.rise + tp.fontSize})),
+		})
+
+		tx := w0 + tp.tc
+		if len(code) == 1 && code[0] == ' ' {
+			tx += tp.tw
+		}
+		tp.advance(tx * tp.th)
+	}
+}
+
+// pageGlyphs returns the glyphs shown on page pageNr of ctx in content stream order.
+func pageGlyphs(ctx *model.Context, pageNr int) ([]textGlyph, error) {
+	d, _, inhPAttrs, err := ctx.PageDict(pageNr, false)
+	if err != nil || d == nil {
+		return nil, err
+	}
+
+	bb, err := ctx.PageContent(d)
+	if err != nil {
+		if err == model.ErrNoContent {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	te := &textExtractor{ctx: ctx, fonts: map[int]*textFont{}, pos: newTextPositions()}
+
+	if err := te.process(bb, inhPAttrs.Resources, 0); err != nil {
+		return nil, err
+	}
+
+	return te.pos.glyphs, nil
+}
+
+// textInRects returns the text of all glyphs whose center lies within any of rr.
+func textInRects(gg []textGlyph, rr []types.Rectangle) string {
+	var (
+		sb   strings.Builder
+		prev *textGlyph
+	)
+
+	for i, g := range gg {
+		c := g.rect.Center()
+		in := false
+		for _, r := range rr {
+			if c.X >= r.LL.X && c.X <= r.UR.X && c.Y >= r.LL.Y && c.Y <= r.UR.Y {
+				in = true
+				break
+			}
+		}
+		if !in || g.s == "" {
+			continue
+		}
+		if prev != nil {
+			h := math.Max(prev.rect.Height(), g.rect.Height())
+			if math.Abs(prev.rect.Center().Y-c.Y) > h/2 || g.rect.LL.X-prev.rect.UR.X > h/5 {
+				sb.WriteByte(' ')
+			}
+		}
+		sb.WriteString(g.s)
+		prev = &gg[i]
+	}
+
+	return strings.Join(strings.Fields(sb.String()), " ")
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// Glyph is a shown glyph in user space.
+type Glyph struct {
+	Text    string          `json:"text"`
+	Rect    types.Rectangle `json:"rect"`    // Bounding box from descent to ascent.
+	Origin  types.Point     `json:"origin"`  // Glyph origin on the baseline.
+	Advance float64         `json:"advance"` // Distance to the origin of the next glyph along the baseline.
+}
+
+// Word is a sequence of glyphs on a common baseline not separated by whitespace or gaps.
+type Word struct {
+	Text     string          `json:"text"`
+	Rect     types.Rectangle `json:"rect"`     // Union of all glyph bounding boxes.
+	Baseline [2]types.Point  `json:"baseline"` // From the origin of the first glyph to the end of the last glyph.
+	FontSize float64         `json:"fontSize"` // Effective font size in user space.
+	Glyphs   []Glyph         `json:"glyphs"`
+}
+
+// PageWords are the words of a page together with the page geometry needed to map
+// user space onto the raster images processed by OCR engines.
+type PageWords struct {
+	Page     int              `json:"page"`
+	MediaBox *types.Rectangle `json:"mediaBox,omitempty"`
+	CropBox  *types.Rectangle `json:"cropBox,omitempty"`
+	Rotate   int              `json:"rotate"`
+	Words    []Word           `json:"words"`
+}
+
+// wordBreak returns true if g does not continue the word ending with prev.
+func wordBreak(prev, g textGlyph) bool {
+	size := max(prev.size, g.size)
+	if size == 0 {
+		return true
+	}
+
+	// Baseline direction of prev.
+	dx, dy := prev.end.X-prev.origin.X, prev.end.Y-prev.origin.Y
+	l := distance(prev.origin, prev.end)
+	if l == 0 {
+		dx, dy, l = 1, 0, 1
+	}
+	dx, dy = dx/l, dy/l
+
+	gx, gy := g.origin.X-prev.end.X, g.origin.Y-prev.end.Y
+
+	along := gx*dx + gy*dy
+	across := gx*dy - gy*dx
+
+	return along > size/5 || along < -size/2 || across > size/2 || across < -size/2
+}
+
+func newWord(gg []textGlyph) Word {
+	w := Word{
+		Rect:     gg[0].rect,
+		Baseline: [2]types.Point{gg[0].origin, gg[len(gg)-1].end},
+		FontSize: gg[0].size,
+		Glyphs:   make([]Glyph, len(gg)),
+	}
+
+	var sb strings.Builder
+
+	for i, g := range gg {
+		sb.WriteString(g.s)
+		w.Glyphs[i] = Glyph{Text: g.s, Rect: g.rect, Origin: g.origin, Advance: distance(g.origin, g.end)}
+		w.Rect.LL.X, w.Rect.LL.Y = min(w.Rect.LL.X, g.rect.LL.X), min(w.Rect.LL.Y, g.rect.LL.Y)
+		w.Rect.UR.X, w.Rect.UR.Y = max(w.Rect.UR.X, g.rect.UR.X), max(w.Rect.UR.Y, g.rect.UR.Y)
+	}
+
+	w.Text = sb.String()
+
+	return w
+}
+
+// words groups glyphs into words breaking at whitespace, gaps and baseline changes.
+func words(gg []textGlyph) []Word {
+	var (
+		ww  []Word
+		cur []textGlyph
+	)
+
+	flush := func() {
+		if len(cur) > 0 {
+			ww = append(ww, newWord(cur))
+			cur = nil
+		}
+	}
+
+	for _, g := range gg {
+		if strings.TrimFunc(g.s, unicode.IsSpace) == "" {
+			flush()
+			continue
+		}
+		if len(cur) > 0 && wordBreak(cur[len(cur)-1], g) {
+			flush()
+		}
+		cur = append(cur, g)
+	}
+
+	flush()
+
+	return ww
+}
+
+// Words returns the words shown on page pageNr of ctx in content stream order
+// including glyph bounding boxes, baselines and advances in user space.
+func Words(ctx *model.Context, pageNr int) (*PageWords, error) {
+	d, _, inhPAttrs, err := ctx.PageDict(pageNr, false)
+	if err != nil {
+		return nil, err
+	}
+
+	pw := &PageWords{Page: pageNr, Words: []Word{}}
+
+	if d == nil {
+		return pw, nil
+	}
+
+	pw.MediaBox, pw.CropBox, pw.Rotate = inhPAttrs.MediaBox, inhPAttrs.CropBox, inhPAttrs.Rotate
+	if pw.CropBox == nil {
+		pw.CropBox = pw.MediaBox
+	}
+
+	gg, err := pageGlyphs(ctx, pageNr)
+	if err != nil {
+		return nil, err
+	}
+
+	if ww := words(gg); ww != nil {
+		pw.Words = ww
+	}
+
+	return pw, nil
+}
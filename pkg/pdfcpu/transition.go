@@ -0,0 +1,238 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// ParseTransitionConfig parses a transition command string into an internal structure.
+func ParseTransitionConfig(s string) (*model.Transition, error) {
+	if s == "" {
+		return nil, errors.New("pdfcpu: missing transition configuration string")
+	}
+
+	t := &model.Transition{Style: "R"}
+
+	for _, s := range strings.Split(s, ",") {
+
+		ss1 := strings.Split(s, ":")
+		if len(ss1) != 2 {
+			return nil, errors.New("pdfcpu: Invalid transition configuration string. Please consult pdfcpu help transition")
+		}
+
+		paramPrefix := strings.TrimSpace(ss1[0])
+		paramValueStr := strings.TrimSpace(ss1[1])
+
+		if err := model.TransitionParamMap.Handle(paramPrefix, paramValueStr, t); err != nil {
+			return nil, err
+		}
+	}
+
+	if t.Dimension != "" && t.Style != "Split" && t.Style != "Blinds" {
+		return nil, errors.New("pdfcpu: transition \"dimension\" applies to styles Split and Blinds only")
+	}
+
+	if t.Motion != "" && t.Style != "Split" && t.Style != "Box" && t.Style != "Fly" {
+		return nil, errors.New("pdfcpu: transition \"motion\" applies to styles Split, Box and Fly only")
+	}
+
+	if t.Direction < 0 && t.Style != "Fly" {
+		return nil, errors.New("pdfcpu: transition angle \"none\" applies to style Fly only")
+	}
+
+	return t, nil
+}
+
+func transitionDict(t model.Transition) types.Dict {
+	d := types.Dict(map[string]types.Object{
+		"Type": types.Name("Trans"),
+		"S":    types.Name(t.Style),
+	})
+
+	if t.Duration > 0 {
+		d["D"] = types.Float(t.Duration)
+	}
+
+	if t.Dimension != "" {
+		d["Dm"] = types.Name(t.Dimension)
+	}
+
+	if t.Motion != "" {
+		d["M"] = types.Name(t.Motion)
+	}
+
+	if t.Direction < 0 {
+		d["Di"] = types.Name("None")
+	} else if t.Direction > 0 {
+		d["Di"] = types.Integer(t.Direction)
+	}
+
+	return d
+}
+
+// selectedPageNrs returns the sorted page numbers of selectedPages or all pages if none are selected.
+func selectedPageNrs(ctx *model.Context, selectedPages types.IntSet) []int {
+	var pageNrs []int
+	for i := 1; i <= ctx.PageCount; i++ {
+		if len(selectedPages) == 0 || selectedPages[i] {
+			pageNrs = append(pageNrs, i)
+		}
+	}
+	return pageNrs
+}
+
+// SetTransitions applies t to selected pages of ctx.
+func SetTransitions(ctx *model.Context, selectedPages types.IntSet, t model.Transition) error {
+	if t.NeedsV15() && ctx.XRefTable.Version() < model.V15 {
+		ctx.EnsureVersionForWriting()
+	}
+
+	for _, pageNr := range selectedPageNrs(ctx, selectedPages) {
+		d, _, _, err := ctx.PageDict(pageNr, false)
+		if err != nil {
+			return err
+		}
+		if d == nil {
+			return errors.Errorf("pdfcpu: unknown page number: %d\n", pageNr)
+		}
+
+		d.Update("Trans", transitionDict(t))
+
+		if t.Display > 0 {
+			d.Update("Dur", types.Float(t.Display))
+		} else {
+			d.Delete("Dur")
+		}
+	}
+
+	return nil
+}
+
+// RemoveTransitions removes transitions and display durations from selected pages of ctx.
+// Returns true if at least one page was affected.
+func RemoveTransitions(ctx *model.Context, selectedPages types.IntSet) (bool, error) {
+	var removed bool
+
+	for _, pageNr := range selectedPageNrs(ctx, selectedPages) {
+		d, _, _, err := ctx.PageDict(pageNr, false)
+		if err != nil {
+			return false, err
+		}
+		if d == nil {
+			return false, errors.Errorf("pdfcpu: unknown page number: %d\n", pageNr)
+		}
+
+		for _, k := range []string{"Trans", "Dur"} {
+			if _, found := d.Find(k); found {
+				d.Delete(k)
+				removed = true
+			}
+		}
+	}
+
+	return removed, nil
+}
+
+func describeTransition(ctx *model.Context, d types.Dict) (string, error) {
+	var ss []string
+
+	o, found := d.Find("Trans")
+	if found {
+		td, err := ctx.DereferenceDict(o)
+		if err != nil {
+			return "", err
+		}
+		style := "R"
+		if s := td.NameEntry("S"); s != nil {
+			style = *s
+		}
+		ss = append(ss, "style:"+style)
+		if f := td["D"]; f != nil {
+			if dur, err := ctx.DereferenceNumber(f); err == nil {
+				ss = append(ss, fmt.Sprintf("duration:%.2f", dur))
+			}
+		}
+		if dm := td.NameEntry("Dm"); dm != nil {
+			ss = append(ss, "dimension:"+*dm)
+		}
+		if m := td.NameEntry("M"); m != nil {
+			ss = append(ss, "motion:"+*m)
+		}
+		switch di := td["Di"].(type) {
+		case types.Integer:
+			ss = append(ss, fmt.Sprintf("angle:%d", di.Value()))
+		case types.Name:
+			ss = append(ss, "angle:none")
+		}
+	}
+
+	if o, found := d.Find("Dur"); found {
+		dur, err := ctx.DereferenceNumber(o)
+		if err != nil {
+			return "", err
+		}
+		ss = append(ss, fmt.Sprintf("display:%.2f", dur))
+	}
+
+	return strings.Join(ss, ", "), nil
+}
+
+// ListTransitions returns a list of page transitions and display durations for selected pages of ctx.
+func ListTransitions(ctx *model.Context, selectedPages types.IntSet) ([]string, error) {
+	m := map[int]string{}
+
+	for _, pageNr := range selectedPageNrs(ctx, selectedPages) {
+		d, _, _, err := ctx.PageDict(pageNr, false)
+		if err != nil {
+			return nil, err
+		}
+		if d == nil {
+			continue
+		}
+		s, err := describeTransition(ctx, d)
+		if err != nil {
+			return nil, err
+		}
+		if s != "" {
+			m[pageNr] = s
+		}
+	}
+
+	if len(m) == 0 {
+		return []string{"no transitions available"}, nil
+	}
+
+	pageNrs := make([]int, 0, len(m))
+	for k := range m {
+		pageNrs = append(pageNrs, k)
+	}
+	sort.Ints(pageNrs)
+
+	ss := []string{"Transitions:"}
+	for _, pageNr := range pageNrs {
+		ss = append(ss, fmt.Sprintf("page %d: %s", pageNr, m[pageNr]))
+	}
+
+	return ss, nil
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+// The processing stages of a PDF file in order.
+const (
+	TriageStageXRef     = "xref"     // Locating and parsing the cross reference sections.
+	TriageStageObjects  = "objects"  // Parsing objects incl. object streams and decryption.
+	TriageStageValidate = "validate" // Validation against the PDF specification.
+	TriageStageOptimize = "optimize" // Optimization of the cross reference table.
+	TriageStageWrite    = "write"    // Serialization.
+)
+
+// TriageReport classifies the outcome of processing a PDF file stage by stage.
+type TriageReport struct {
+	Stage     string `json:"stage,omitempty"`   // First failing stage, empty if all stages succeeded.
+	ObjNr     int    `json:"objNr,omitempty"`   // Object being processed at the time of failure, 0 if unknown.
+	Error     string `json:"error,omitempty"`   // Failure cause.
+	Panic     bool   `json:"panic,omitempty"`   // Failure caused by a crash.
+	Version   string `json:"version,omitempty"` // PDF version, if available.
+	PageCount int    `json:"pageCount"`         // Page count, if available.
+}
+
+// OK returns true if all stages succeeded.
+func (r TriageReport) OK() bool {
+	return r.Stage == ""
+}
+
+func (r *TriageReport) fail(ctx *model.Context, stage string, err error, panicked bool) {
+	r.Stage, r.Error, r.Panic = stage, err.Error(), panicked
+	if ctx != nil {
+		r.ObjNr = ctx.CurObj
+	}
+}
+
+// Run executes stage f of processing ctx, recovers from a crash
+// and records any failure along with the current object of ctx.
+// Returns false if stage f failed.
+func (r *TriageReport) Run(ctx *model.Context, stage string, f func() error) (ok bool) {
+	if ctx != nil {
+		ctx.CurObj = 0
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			r.fail(ctx, stage, errors.Errorf("%v", p), true)
+			ok = false
+		}
+	}()
+
+	if err := f(); err != nil {
+		r.fail(ctx, stage, err, false)
+		return false
+	}
+
+	return true
+}
+
+// TriageRead reads rs like Read, but stage by stage, recording the first failure in r.
+// Returns nil if reading fails.
+func TriageRead(rs io.ReadSeeker, conf *model.Configuration, r *TriageReport) *model.Context {
+	c := context.Background()
+
+	ctx, err := model.NewContext(rs, conf)
+	if err != nil {
+		r.fail(nil, TriageStageXRef, err, false)
+		return nil
+	}
+
+	if ctx.Read.FileSize == 0 {
+		r.fail(nil, TriageStageXRef, errors.New("empty file"), false)
+		return nil
+	}
+
+	if !r.Run(ctx, TriageStageXRef, func() error { return readXRefTable(c, ctx) }) {
+		return nil
+	}
+
+	if ctx.HeaderVersion != nil {
+		r.Version = ctx.HeaderVersion.String()
+	}
+
+	if !r.Run(ctx, TriageStageObjects, func() error { return dereferenceXRefTable(c, ctx, conf) }) {
+		return nil
+	}
+
+	if ctx.HeaderVersion != nil {
+		r.Version = ctx.VersionString()
+	}
+
+	if ctx.XRefTable.Size != nil && *ctx.XRefTable.Size != ctx.MaxObjNr+1 {
+		*ctx.XRefTable.Size = ctx.MaxObjNr + 1
+	}
+
+	return ctx
+}
+
+// ListTriageReport returns a formatted triage report.
+func ListTriageReport(r *TriageReport) []string {
+	if r.OK() {
+		ss := []string{"no problems found"}
+		if r.Version != "" {
+			ss = append(ss, fmt.Sprintf("%9s: %s", "version", r.Version), fmt.Sprintf("%9s: %d", "pages", r.PageCount))
+		}
+		return ss
+	}
+
+	ss := []string{fmt.Sprintf("%9s: %s", "stage", r.Stage)}
+
+	if r.ObjNr > 0 {
+		ss = append(ss, fmt.Sprintf("%9s: #%d", "object", r.ObjNr))
+	}
+
+	if r.Version != "" {
+		ss = append(ss, fmt.Sprintf("%9s: %s", "version", r.Version))
+	}
+
+	kind := "error"
+	if r.Panic {
+		kind = "crash"
+	}
+
+	return append(ss, fmt.Sprintf("%9s: %s", kind, r.Error))
+}
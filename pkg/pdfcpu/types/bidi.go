@@ -0,0 +1,370 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+import (
+	"sort"
+
+	"golang.org/x/text/unicode/bidi"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Mirrored glyphs for characters rendered right to left (see UAX #9 L4).
+var bidiMirrors = map[rune]rune{
+	'(': ')', ')': '(',
+	'<': '>', '>': '<',
+	'[': ']', ']': '[',
+	'{': '}', '}': '{',
+	'«': '»', '»': '«',
+	'‹': '›', '›': '‹',
+}
+
+func bidiClasses(rr []rune) []bidi.Class {
+	cc := make([]bidi.Class, len(rr))
+	for i, r := range rr {
+		p, _ := bidi.LookupRune(r)
+		c := p.Class()
+		if c > bidi.AL {
+			// Explicit formatting characters are not supported.
+			c = bidi.BN
+		}
+		cc[i] = c
+	}
+	return cc
+}
+
+func firstStrongRTL(cc []bidi.Class) bool {
+	for _, c := range cc {
+		switch c {
+		case bidi.L:
+			return false
+		case bidi.R, bidi.AL:
+			return true
+		}
+	}
+	return false
+}
+
+// RightToLeft returns true if the first strong character of s implies a right to left paragraph.
+func RightToLeft(s string) bool {
+	return firstStrongRTL(bidiClasses([]rune(s)))
+}
+
+func hasRTL(cc []bidi.Class) bool {
+	for _, c := range cc {
+		if c == bidi.R || c == bidi.AL || c == bidi.AN {
+			return true
+		}
+	}
+	return false
+}
+
+func isNeutral(c bidi.Class) bool {
+	return c == bidi.B || c == bidi.S || c == bidi.WS || c == bidi.ON || c == bidi.BN
+}
+
+// resolveWeak applies the rules W1-W7.
+func resolveWeak(cc []bidi.Class, sos bidi.Class) {
+	// W1
+	prev := sos
+	for i, c := range cc {
+		if c == bidi.NSM {
+			cc[i] = prev
+		}
+		prev = cc[i]
+	}
+
+	// W2, W3
+	strong := sos
+	for i, c := range cc {
+		switch c {
+		case bidi.L, bidi.R, bidi.AL:
+			strong = c
+		case bidi.EN:
+			if strong == bidi.AL {
+				cc[i] = bidi.AN
+			}
+		}
+	}
+	for i, c := range cc {
+		if c == bidi.AL {
+			cc[i] = bidi.R
+		}
+	}
+
+	// W4
+	for i := 1; i < len(cc)-1; i++ {
+		c, c0, c1 := cc[i], cc[i-1], cc[i+1]
+		if c0 != c1 {
+			continue
+		}
+		if (c == bidi.ES || c == bidi.CS) && c0 == bidi.EN {
+			cc[i] = bidi.EN
+		}
+		if c == bidi.CS && c0 == bidi.AN {
+			cc[i] = bidi.AN
+		}
+	}
+
+	// W5
+	for i := 0; i < len(cc); {
+		if cc[i] != bidi.ET {
+			i++
+			continue
+		}
+		j := i
+		for j < len(cc) && cc[j] == bidi.ET {
+			j++
+		}
+		if (i > 0 && cc[i-1] == bidi.EN) || (j < len(cc) && cc[j] == bidi.EN) {
+			for k := i; k < j; k++ {
+				cc[k] = bidi.EN
+			}
+		}
+		i = j
+	}
+
+	// W6
+	for i, c := range cc {
+		if c == bidi.ES || c == bidi.ET || c == bidi.CS {
+			cc[i] = bidi.ON
+		}
+	}
+
+	// W7
+	strong = sos
+	for i, c := range cc {
+		switch c {
+		case bidi.L, bidi.R:
+			strong = c
+		case bidi.EN:
+			if strong == bidi.L {
+				cc[i] = bidi.L
+			}
+		}
+	}
+}
+
+// strongDir returns the direction c counts as for resolving neutrals.
+func strongDir(c bidi.Class) bidi.Class {
+	if c == bidi.L {
+		return bidi.L
+	}
+	// R, EN, AN
+	return bidi.R
+}
+
+// bracketPairs returns the positions of matching bracket pairs sorted by opening bracket (see UAX #9 BD16).
+func bracketPairs(rr []rune, cc []bidi.Class) [][2]int {
+	var (
+		stack []int
+		pairs [][2]int
+	)
+	for i, r := range rr {
+		if cc[i] != bidi.ON {
+			continue
+		}
+		switch r {
+		case '(', '[', '{':
+			stack = append(stack, i)
+		case ')', ']', '}':
+			for j := len(stack) - 1; j >= 0; j-- {
+				if bidiMirrors[rr[stack[j]]] == r {
+					pairs = append(pairs, [2]int{stack[j], i})
+					stack = stack[:j]
+					break
+				}
+			}
+		}
+	}
+	sort.Slice(pairs, func(i, j int) bool { return pairs[i][0] < pairs[j][0] })
+	return pairs
+}
+
+// resolveBrackets applies the rule N0.
+func resolveBrackets(rr []rune, cc []bidi.Class, e bidi.Class) {
+	for _, p := range bracketPairs(rr, cc) {
+		var found, opposite bool
+		for _, c := range cc[p[0]+1 : p[1]] {
+			if isNeutral(c) {
+				continue
+			}
+			if strongDir(c) == e {
+				found = true
+				break
+			}
+			opposite = true
+		}
+
+		c := e
+		switch {
+		case found:
+		case opposite:
+			// Use the context preceding the opening bracket.
+			before := e
+			for i := p[0] - 1; i >= 0; i-- {
+				if !isNeutral(cc[i]) {
+					before = strongDir(cc[i])
+					break
+				}
+			}
+			if before != e {
+				c = before
+			}
+		default:
+			continue
+		}
+
+		cc[p[0]], cc[p[1]] = c, c
+	}
+}
+
+// resolveNeutral applies the rules N1 and N2.
+func resolveNeutral(cc []bidi.Class, e bidi.Class) {
+	for i := 0; i < len(cc); {
+		if !isNeutral(cc[i]) {
+			i++
+			continue
+		}
+		j := i
+		for j < len(cc) && isNeutral(cc[j]) {
+			j++
+		}
+		before, after := e, e
+		if i > 0 {
+			before = strongDir(cc[i-1])
+		}
+		if j < len(cc) {
+			after = strongDir(cc[j])
+		}
+		c := e
+		if before == after {
+			c = before
+		}
+		for k := i; k < j; k++ {
+			cc[k] = c
+		}
+		i = j
+	}
+}
+
+// bidiLevels resolves the embedding levels of rr for paragraph level base.
+func bidiLevels(rr []rune, base int) []int {
+	orig := bidiClasses(rr)
+	cc := make([]bidi.Class, len(orig))
+	copy(cc, orig)
+
+	e := bidi.L
+	if base == 1 {
+		e = bidi.R
+	}
+
+	resolveWeak(cc, e)
+	resolveBrackets(rr, cc, e)
+	resolveNeutral(cc, e)
+
+	// I1, I2
+	levels := make([]int, len(cc))
+	for i, c := range cc {
+		l := base
+		switch {
+		case base == 0 && c == bidi.R:
+			l = 1
+		case base == 0 && (c == bidi.EN || c == bidi.AN):
+			l = 2
+		case base == 1 && c != bidi.R:
+			l = 2
+		}
+		levels[i] = l
+	}
+
+	// L1
+	trailing := true
+	for i := len(orig) - 1; i >= 0; i-- {
+		switch orig[i] {
+		case bidi.S, bidi.B:
+			levels[i] = base
+			trailing = true
+		case bidi.WS, bidi.BN:
+			if trailing {
+				levels[i] = base
+			}
+		default:
+			trailing = false
+		}
+	}
+
+	return levels
+}
+
+// BidiVisual returns s in visual order for rendering left to right.
+// It implements the Unicode Bidirectional Algorithm (UAX #9) for a single line without explicit embeddings,
+// so numbers and latin words embedded in Arabic or Hebrew text keep their reading order.
+// The paragraph direction is right to left if rtl is set or else implied by the first strong character of s.
+func BidiVisual(s string, rtl bool) string {
+	rr := []rune(norm.NFC.String(s))
+	cc := bidiClasses(rr)
+	if !rtl && !hasRTL(cc) {
+		return s
+	}
+
+	base := 0
+	if rtl || firstStrongRTL(cc) {
+		base = 1
+	}
+
+	levels := bidiLevels(rr, base)
+
+	// L2
+	maxLevel, minOdd := 0, 3
+	for _, l := range levels {
+		if l > maxLevel {
+			maxLevel = l
+		}
+		if l%2 == 1 && l < minOdd {
+			minOdd = l
+		}
+	}
+	for l := maxLevel; l >= minOdd; l-- {
+		for i := 0; i < len(rr); {
+			if levels[i] < l {
+				i++
+				continue
+			}
+			j := i
+			for j < len(rr) && levels[j] >= l {
+				j++
+			}
+			for a, b := i, j-1; a < b; a, b = a+1, b-1 {
+				rr[a], rr[b] = rr[b], rr[a]
+				levels[a], levels[b] = levels[b], levels[a]
+			}
+			i = j
+		}
+	}
+
+	// L4
+	for i, r := range rr {
+		if levels[i]%2 == 1 {
+			if m, ok := bidiMirrors[r]; ok {
+				rr[i] = m
+			}
+		}
+	}
+
+	return string(rr)
+}
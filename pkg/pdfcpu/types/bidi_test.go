@@ -0,0 +1,57 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+import "testing"
+
+func TestBidiVisual(t *testing.T) {
+	tests := []struct {
+		input    string
+		rtl      bool
+		expected string
+	}{
+		{"abc def", false, "abc def"},
+		{"abc def", true, "abc def"},
+		{"שלום", false, "םולש"},
+		{"שלום 123 עולם", false, "םלוע 123 םולש"},
+		{"شارع 25", true, "25 عراش"},
+		{"abc שלום def", false, "abc םולש def"},
+		{"abc שלום def", true, "def םולש abc"},
+		{"מחיר (USD) 12.50", false, "12.50 (USD) ריחמ"},
+		{"שלום.", false, ".םולש"},
+	}
+
+	for _, tt := range tests {
+		if got := BidiVisual(tt.input, tt.rtl); got != tt.expected {
+			t.Errorf("BidiVisual(%q, %t): expected %q, got %q", tt.input, tt.rtl, tt.expected, got)
+		}
+	}
+}
+
+func TestRightToLeft(t *testing.T) {
+	for s, expected := range map[string]bool{
+		"":          false,
+		"123":       false,
+		"abc שלום":  false,
+		"123 שלום":  true,
+		"مرحبا abc": true,
+	} {
+		if got := RightToLeft(s); got != expected {
+			t.Errorf("RightToLeft(%q): expected %t, got %t", s, expected, got)
+		}
+	}
+}
@@ -46,6 +46,7 @@ type StreamDict struct {
 	//DCTImage          image.Image
 	IsPageContent bool
 	CSComponents  int
+	Source        io.ReadSeeker // Holds the encoded content of deferred streams at StreamOffset.
 }
 
 // NewStreamDict creates a new PDFStreamDict for given PDFDict, stream offset and length.
@@ -61,9 +62,58 @@ func NewStreamDict(d Dict, streamOffset int64, streamLength *int64, streamLength
 		//nil,
 		false,
 		0,
+		nil,
 	}
 }
 
+// Deferred returns true if sd's encoded content has not been loaded yet and still resides in sd.Source.
+func (sd StreamDict) Deferred() bool {
+	return sd.Raw == nil && sd.Source != nil
+}
+
+// Load reads the encoded content of a deferred stream into sd.Raw.
+func (sd *StreamDict) Load() error {
+	if !sd.Deferred() {
+		return nil
+	}
+
+	if sd.StreamLength == nil {
+		return errors.New("pdfcpu: Load: missing stream length")
+	}
+
+	if _, err := sd.Source.Seek(sd.StreamOffset, io.SeekStart); err != nil {
+		return err
+	}
+
+	raw := make([]byte, *sd.StreamLength)
+	if _, err := io.ReadFull(sd.Source, raw); err != nil {
+		return errors.Wrap(err, "pdfcpu: Load")
+	}
+
+	sd.Raw = raw
+
+	return nil
+}
+
+// CopyRaw writes sd's encoded content to w.
+// The content of deferred streams gets copied through from sd.Source without loading it into memory.
+func (sd StreamDict) CopyRaw(w io.Writer) (int64, error) {
+	if !sd.Deferred() {
+		n, err := w.Write(sd.Raw)
+		return int64(n), err
+	}
+
+	if sd.StreamLength == nil {
+		return 0, errors.New("pdfcpu: CopyRaw: missing stream length")
+	}
+
+	if _, err := sd.Source.Seek(sd.StreamOffset, io.SeekStart); err != nil {
+		return 0, err
+	}
+
+	return io.CopyN(w, sd.Source, *sd.StreamLength)
+}
+
 // Clone returns a clone of sd.
 func (sd StreamDict) Clone() Object {
 	sd1 := sd
@@ -406,6 +456,10 @@ func (sd *StreamDict) DecodeLength(maxLen int64) ([]byte, error) {
 		return sd.Content[:maxLen], nil
 	}
 
+	if err := sd.Load(); err != nil {
+		return nil, err
+	}
+
 	fpl := sd.FilterPipeline
 
 	// No filter or sole filter DTC && !CMYK or JPX - nothing to decode.
@@ -19,6 +19,7 @@ package types
 import (
 	"bytes"
 	"encoding/hex"
+	"regexp"
 	"strconv"
 	"strings"
 	"unicode/utf8"
@@ -27,6 +28,15 @@ import (
 	"golang.org/x/text/unicode/norm"
 )
 
+// languageTag matches language identifiers as defined by BCP 47, eg. "en", "en-US" or "zh-Hant-TW".
+var languageTag = regexp.MustCompile(`^[A-Za-z]{1,8}(-[A-Za-z0-9]{1,8})*$`)
+
+// ValidLanguageTag returns true if s is a valid language identifier.
+// See 14.9.2.2
+func ValidLanguageTag(s string) bool {
+	return languageTag.MatchString(s)
+}
+
 // NewStringSet returns a new StringSet for slice.
 func NewStringSet(slice []string) StringSet {
 	strSet := StringSet{}
@@ -198,3 +198,26 @@ func TestEncodeName(t *testing.T) {
 		}
 	}
 }
+
+func TestValidLanguageTag(t *testing.T) {
+	testcases := []struct {
+		Input    string
+		Expected bool
+	}{
+		{"en", true},
+		{"en-US", true},
+		{"zh-Hant-TW", true},
+		{"de-CH-1901", true},
+		{"", false},
+		{"en_US", false},
+		{"-en", false},
+		{"en-", false},
+		{"english language", false},
+		{"toolonglang", false},
+	}
+	for _, tc := range testcases {
+		if got := ValidLanguageTag(tc.Input); got != tc.Expected {
+			t.Errorf("expected %t for %q, got %t", tc.Expected, tc.Input, got)
+		}
+	}
+}
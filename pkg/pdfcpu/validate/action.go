@@ -850,11 +850,8 @@ func validateActionDict(xRefTable *model.XRefTable, d types.Dict) error {
 	dictName := "actionDict"
 
 	// Type, optional, name
-	allowedTypes := []string{"Action"}
-	if xRefTable.ValidationMode == model.ValidationRelaxed {
-		allowedTypes = []string{"A", "Action"}
-	}
-	_, err := validateNameEntry(xRefTable, d, dictName, "Type", OPTIONAL, model.V10, func(s string) bool { return types.MemberOf(s, allowedTypes) })
+	validate := func(s string) bool { return s == "Action" || s == "A" && xRefTable.Tolerate(model.QuirkActionType) }
+	_, err := validateNameEntry(xRefTable, d, dictName, "Type", OPTIONAL, model.V10, validate)
 	if err != nil {
 		return err
 	}
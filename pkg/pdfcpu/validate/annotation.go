@@ -1172,6 +1172,21 @@ func validateAnnotationDict3D(xRefTable *model.XRefTable, d types.Dict, dictName
 		return err
 	}
 
+	o, err := xRefTable.Dereference(d["3DD"])
+	if err != nil {
+		return err
+	}
+
+	switch o := o.(type) {
+	case types.StreamDict:
+		err = validate3DStreamDict(xRefTable, &o)
+	case types.Dict:
+		err = validate3DReferenceDict(xRefTable, o)
+	}
+	if err != nil {
+		return err
+	}
+
 	// 3DV, optional, various
 	_, err = validateEntry(xRefTable, d, dictName, "3DV", OPTIONAL, model.V16)
 	if err != nil {
@@ -1190,6 +1205,81 @@ func validateAnnotationDict3D(xRefTable *model.XRefTable, d types.Dict, dictName
 	return err
 }
 
+func validate3DStreamDict(xRefTable *model.XRefTable, sd *types.StreamDict) error {
+
+	// see 13.6.3
+
+	dictName := "3DStreamDict"
+
+	// Type, optional, name
+	_, err := validateNameEntry(xRefTable, sd.Dict, dictName, "Type", OPTIONAL, model.V16, func(s string) bool { return s == "3D" })
+	if err != nil {
+		return err
+	}
+
+	// Subtype, required, name
+	subtype, err := validateNameEntry(xRefTable, sd.Dict, dictName, "Subtype", REQUIRED, model.V16, nil)
+	if err != nil {
+		return err
+	}
+	if subtype != nil && *subtype != "U3D" && *subtype != "PRC" && xRefTable.ValidationMode == model.ValidationStrict {
+		return errors.Errorf("pdfcpu: validate3DStreamDict: invalid Subtype: %s", *subtype)
+	}
+
+	// VA, optional, array of 3D view dicts
+	_, err = validateArrayEntry(xRefTable, sd.Dict, dictName, "VA", OPTIONAL, model.V16, nil)
+	if err != nil {
+		return err
+	}
+
+	// DV, optional, various
+	_, err = validateEntry(xRefTable, sd.Dict, dictName, "DV", OPTIONAL, model.V16)
+	if err != nil {
+		return err
+	}
+
+	// Resources, optional, name tree
+	_, err = validateDictEntry(xRefTable, sd.Dict, dictName, "Resources", OPTIONAL, model.V16, nil)
+	if err != nil {
+		return err
+	}
+
+	// OnInstantiate, optional, JavaScript stream
+	_, err = validateStreamDictEntry(xRefTable, sd.Dict, dictName, "OnInstantiate", OPTIONAL, model.V16, nil)
+	if err != nil {
+		return err
+	}
+
+	// AN, optional, 3D animation style dict
+	_, err = validateDictEntry(xRefTable, sd.Dict, dictName, "AN", OPTIONAL, model.V17, nil)
+
+	return err
+}
+
+func validate3DReferenceDict(xRefTable *model.XRefTable, d types.Dict) error {
+
+	// see 13.6.3.3
+
+	dictName := "3DReferenceDict"
+
+	// Writers often leave out the entries below.
+	required := xRefTable.ValidationMode == model.ValidationStrict
+
+	// Type, required, name
+	_, err := validateNameEntry(xRefTable, d, dictName, "Type", required, model.V16, func(s string) bool { return s == "3DRef" })
+	if err != nil {
+		return err
+	}
+
+	// 3DD, required, 3D stream
+	sd, err := validateStreamDictEntry(xRefTable, d, dictName, "3DD", required, model.V16, nil)
+	if err != nil || sd == nil {
+		return err
+	}
+
+	return validate3DStreamDict(xRefTable, sd)
+}
+
 func validateEntryIC(xRefTable *model.XRefTable, d types.Dict, dictName string, required bool, sinceVersion model.Version) error {
 
 	// IC, optional, number array, length:3 [0.0 .. 1.0]
@@ -1281,11 +1371,93 @@ func validateAnnotationDictRedact(xRefTable *model.XRefTable, d types.Dict, dict
 	return err
 }
 
-func validateRichMediaAnnotation(xRefTable *model.XRefTable, d types.Dict, dictName string) error {
-	// TODO See extension level 3.
+func validateRichMediaAssets(xRefTable *model.XRefTable, d types.Dict) error {
+
+	// Name tree node, values are file specs.
+
+	a, err := xRefTable.DereferenceArray(d["Names"])
+	if err != nil {
+		return err
+	}
+	for i := 1; i < len(a); i += 2 {
+		if _, err := validateFileSpecification(xRefTable, a[i]); err != nil {
+			return err
+		}
+	}
+
+	kids, err := xRefTable.DereferenceArray(d["Kids"])
+	if err != nil {
+		return err
+	}
+	for _, o := range kids {
+		d1, err := xRefTable.DereferenceDict(o)
+		if err != nil {
+			return err
+		}
+		if d1 == nil {
+			continue
+		}
+		if err := validateRichMediaAssets(xRefTable, d1); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+func validateRichMediaContentDict(xRefTable *model.XRefTable, d types.Dict) error {
+
+	dictName := "RichMediaContent"
+
+	// Type, optional, name
+	_, err := validateNameEntry(xRefTable, d, dictName, "Type", OPTIONAL, model.V17, func(s string) bool { return s == "RichMediaContent" })
+	if err != nil {
+		return err
+	}
+
+	// Assets, optional, name tree of file specs
+	d1, err := validateDictEntry(xRefTable, d, dictName, "Assets", OPTIONAL, model.V17, nil)
+	if err != nil {
+		return err
+	}
+	if d1 != nil {
+		if err := validateRichMediaAssets(xRefTable, d1); err != nil {
+			return err
+		}
+	}
+
+	// Configurations, optional, array of RichMediaConfiguration dicts
+	_, err = validateArrayEntry(xRefTable, d, dictName, "Configurations", OPTIONAL, model.V17, nil)
+	if err != nil {
+		return err
+	}
+
+	// Views, optional, array of 3D view dicts
+	_, err = validateArrayEntry(xRefTable, d, dictName, "Views", OPTIONAL, model.V17, nil)
+
+	return err
+}
+
+func validateRichMediaAnnotation(xRefTable *model.XRefTable, d types.Dict, dictName string) error {
+
+	// see Adobe Supplement to ISO 32000, extension level 3
+
+	// RichMediaSettings, optional, dict
+	_, err := validateDictEntry(xRefTable, d, dictName, "RichMediaSettings", OPTIONAL, model.V17, nil)
+	if err != nil {
+		return err
+	}
+
+	// RichMediaContent, required, dict
+	required := xRefTable.ValidationMode == model.ValidationStrict
+	d1, err := validateDictEntry(xRefTable, d, dictName, "RichMediaContent", required, model.V17, nil)
+	if err != nil || d1 == nil {
+		return err
+	}
+
+	return validateRichMediaContentDict(xRefTable, d1)
+}
+
 func validateExDataDict(xRefTable *model.XRefTable, d types.Dict) error {
 
 	dictName := "ExData"
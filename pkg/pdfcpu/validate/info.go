@@ -33,7 +33,7 @@ func DocumentProperty(s string) bool {
 
 func validateInfoDictDate(xRefTable *model.XRefTable, name string, o types.Object) (string, error) {
 	s, err := validateDateObject(xRefTable, o, model.V10)
-	if err != nil && xRefTable.ValidationMode == model.ValidationRelaxed {
+	if err != nil && xRefTable.Tolerate(model.QuirkInfoDate) {
 		err = nil
 		model.ShowRepaired(fmt.Sprintf("info dict \"%s\"", name))
 	}
@@ -43,12 +43,11 @@ func validateInfoDictDate(xRefTable *model.XRefTable, name string, o types.Objec
 func validateInfoDictTrapped(xRefTable *model.XRefTable, o types.Object) error {
 	sinceVersion := model.V13
 
-	validate := func(s string) bool { return types.MemberOf(s, []string{"True", "False", "Unknown"}) }
-
-	if xRefTable.ValidationMode == model.ValidationRelaxed {
-		validate = func(s string) bool {
-			return types.MemberOf(s, []string{"True", "False", "Unknown", "true", "false", "unknown"})
+	validate := func(s string) bool {
+		if types.MemberOf(s, []string{"True", "False", "Unknown"}) {
+			return true
 		}
+		return types.MemberOf(s, []string{"true", "false", "unknown"}) && xRefTable.Tolerate(model.QuirkTrapped)
 	}
 
 	_, err := xRefTable.DereferenceName(o, sinceVersion, validate)
@@ -56,8 +55,8 @@ func validateInfoDictTrapped(xRefTable *model.XRefTable, o types.Object) error {
 		return nil
 	}
 
-	if xRefTable.ValidationMode == model.ValidationRelaxed {
-		_, err = xRefTable.DereferenceBoolean(o, sinceVersion)
+	if b, err1 := xRefTable.DereferenceBoolean(o, sinceVersion); err1 == nil && b != nil && xRefTable.Tolerate(model.QuirkTrapped) {
+		return nil
 	}
 
 	return err
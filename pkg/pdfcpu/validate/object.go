@@ -1403,11 +1403,7 @@ func validateStreamDictOrDictEntry(xRefTable *model.XRefTable, d types.Dict, dic
 
 	switch o.(type) {
 
-	case types.StreamDict:
-		// TODO validate 3D stream dict
-
-	case types.Dict:
-		// TODO validate 3D reference dict
+	case types.StreamDict, types.Dict:
 
 	default:
 		return errors.Errorf("pdfcpu: validateStreamDictOrDictEntry: dict=%s entry=%s invalid type", dictName, entryName)
@@ -655,6 +655,10 @@ func validateMeasureDict(xRefTable *model.XRefTable, d types.Dict, sinceVersion
 		return err
 	}
 
+	if *coordSys == "GEO" {
+		return validateGeoMeasureDict(xRefTable, d, sinceVersion)
+	}
+
 	if *coordSys != "RL" {
 		if xRefTable.Version() > sinceVersion {
 			// unknown coord system
@@ -720,6 +724,75 @@ func validateMeasureDict(xRefTable *model.XRefTable, d types.Dict, sinceVersion
 	return nil
 }
 
+func validateGeoCoordSysDict(xRefTable *model.XRefTable, d types.Dict, dictName string, sinceVersion model.Version) error {
+
+	// Type, name, required, GEOGCS or PROJCS
+	_, err := validateNameEntry(xRefTable, d, dictName, "Type", REQUIRED, sinceVersion, func(s string) bool { return s == "GEOGCS" || s == "PROJCS" })
+	if err != nil {
+		return err
+	}
+
+	// EPSG, integer, optional
+	_, err = validateIntegerEntry(xRefTable, d, dictName, "EPSG", OPTIONAL, sinceVersion, nil)
+	if err != nil {
+		return err
+	}
+
+	// WKT, ASCII string, optional
+	_, err = validateStringEntry(xRefTable, d, dictName, "WKT", OPTIONAL, sinceVersion, nil)
+
+	return err
+}
+
+func validateGeoMeasureDict(xRefTable *model.XRefTable, d types.Dict, sinceVersion model.Version) error {
+
+	// see ISO 32000-2 12.10 Geospatial features
+
+	dictName := "geoMeasureDict"
+
+	// Bounds, number array, optional, region of the viewport that shall be georeferenced.
+	_, err := validateNumberArrayEntry(xRefTable, d, dictName, "Bounds", OPTIONAL, sinceVersion, func(a types.Array) bool { return len(a)%2 == 0 })
+	if err != nil {
+		return err
+	}
+
+	// GCS, dict, required, geographic or projected coordinate system.
+	d1, err := validateDictEntry(xRefTable, d, dictName, "GCS", REQUIRED, sinceVersion, nil)
+	if err != nil {
+		return err
+	}
+	if err = validateGeoCoordSysDict(xRefTable, d1, "gcsDict", sinceVersion); err != nil {
+		return err
+	}
+
+	// DCS, dict, optional, display coordinate system.
+	if d1, err = validateDictEntry(xRefTable, d, dictName, "DCS", OPTIONAL, sinceVersion, nil); err != nil {
+		return err
+	}
+	if d1 != nil {
+		if err = validateGeoCoordSysDict(xRefTable, d1, "dcsDict", sinceVersion); err != nil {
+			return err
+		}
+	}
+
+	// PDU, name array, optional, preferred linear, area and angular display units.
+	_, err = validateNameArrayEntry(xRefTable, d, dictName, "PDU", OPTIONAL, sinceVersion, func(a types.Array) bool { return len(a) == 3 })
+	if err != nil {
+		return err
+	}
+
+	// GPTS, number array, required, geographic point pairs (latitude, longitude).
+	gpts, err := validateNumberArrayEntry(xRefTable, d, dictName, "GPTS", REQUIRED, sinceVersion, func(a types.Array) bool { return len(a) > 0 && len(a)%2 == 0 })
+	if err != nil {
+		return err
+	}
+
+	// LPTS, number array, optional, corresponding points in unit square coordinates of Bounds.
+	_, err = validateNumberArrayEntry(xRefTable, d, dictName, "LPTS", OPTIONAL, sinceVersion, func(a types.Array) bool { return len(a) == len(gpts) })
+
+	return err
+}
+
 func validateViewportDict(xRefTable *model.XRefTable, d types.Dict, sinceVersion model.Version) error {
 
 	dictName := "viewportDict"
@@ -171,14 +171,101 @@ func validateRootVersion(xRefTable *model.XRefTable, rootDict types.Dict, requir
 	return err
 }
 
+func validateDeveloperExtensionsDict(xRefTable *model.XRefTable, d types.Dict) error {
+	dictName := "developerExtensionsDict"
+
+	// Type, optional, name
+	_, err := validateNameEntry(xRefTable, d, dictName, "Type", OPTIONAL, model.V10, func(s string) bool { return s == "DeveloperExtensions" })
+	if err != nil {
+		return err
+	}
+
+	required := xRefTable.ValidationMode == model.ValidationStrict
+
+	// BaseVersion, required, name
+	_, err = validateNameEntry(xRefTable, d, dictName, "BaseVersion", required, model.V10, nil)
+	if err != nil {
+		return err
+	}
+
+	// ExtensionLevel, required, integer
+	_, err = validateIntegerEntry(xRefTable, d, dictName, "ExtensionLevel", required, model.V10, nil)
+	if err != nil {
+		return err
+	}
+
+	sinceVersion := model.V20
+	if xRefTable.ValidationMode == model.ValidationRelaxed {
+		sinceVersion = model.V10
+	}
+
+	// URL, optional, string
+	_, err = validateStringEntry(xRefTable, d, dictName, "URL", OPTIONAL, sinceVersion, nil)
+	if err != nil {
+		return err
+	}
+
+	// ExtensionRevision, optional, string
+	_, err = validateStringEntry(xRefTable, d, dictName, "ExtensionRevision", OPTIONAL, sinceVersion, nil)
+
+	return err
+}
+
 func validateExtensions(xRefTable *model.XRefTable, rootDict types.Dict, required bool, sinceVersion model.Version) error {
 	// => 7.12 Extensions Dictionary
 
-	_, err := validateDictEntry(xRefTable, rootDict, "rootDict", "Extensions", required, sinceVersion, nil)
+	d, err := validateDictEntry(xRefTable, rootDict, "rootDict", "Extensions", required, sinceVersion, nil)
+	if err != nil || d == nil {
+		return err
+	}
 
-	// No validation due to lack of documentation.
+	for k, v := range d {
 
-	return err
+		if k == "Type" {
+			continue
+		}
+
+		o, err := xRefTable.Dereference(v)
+		if err != nil {
+			return err
+		}
+
+		switch o := o.(type) {
+
+		case types.Dict:
+			err = validateDeveloperExtensionsDict(xRefTable, o)
+
+		case types.Array:
+			// Multiple extensions per prefix since PDF 2.0
+			if xRefTable.ValidationMode == model.ValidationStrict && xRefTable.Version() < model.V20 {
+				return errors.Errorf("pdfcpu: validateExtensions: %s: array unsupported in version %s", k, xRefTable.VersionString())
+			}
+			for _, o1 := range o {
+				d1, err := xRefTable.DereferenceDict(o1)
+				if err != nil {
+					return err
+				}
+				if d1 == nil {
+					continue
+				}
+				if err = validateDeveloperExtensionsDict(xRefTable, d1); err != nil {
+					return err
+				}
+			}
+
+		default:
+			if xRefTable.ValidationMode == model.ValidationStrict {
+				err = errors.Errorf("pdfcpu: validateExtensions: %s: invalid type", k)
+			}
+
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 func validatePageLabels(xRefTable *model.XRefTable, rootDict types.Dict, required bool, sinceVersion model.Version) error {
@@ -624,12 +711,19 @@ func validatePieceInfo(xRefTable *model.XRefTable, d types.Dict, dictName, entry
 func validatePermissions(xRefTable *model.XRefTable, rootDict types.Dict, required bool, sinceVersion model.Version) error {
 	// => 12.8.4 Permissions
 
-	d, err := validateDictEntry(xRefTable, rootDict, "rootDict", "Permissions", required, sinceVersion, nil)
+	d, err := validateDictEntry(xRefTable, rootDict, "rootDict", "Perms", required, sinceVersion, nil)
 	if err != nil || d == nil {
 		return err
 	}
 
-	return errors.New("pdfcpu: validatePermissions: not supported")
+	// DocMDP: certification signature, UR3: usage rights signature, UR: deprecated usage rights signature.
+	for _, k := range []string{"DocMDP", "UR3", "UR"} {
+		if _, err := validateDictEntry(xRefTable, d, "permsDict", k, OPTIONAL, sinceVersion, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 // TODO implement
@@ -644,6 +738,14 @@ func validateLegal(xRefTable *model.XRefTable, rootDict types.Dict, required boo
 	return errors.New("pdfcpu: validateLegal: not supported")
 }
 
+// requirementTypes lists the requirement types of PDF 2.0 (see 12.11).
+var requirementTypes = []string{
+	"OCInteract", "OCAutoStates", "AcroFormInteract", "Navigation", "Markup", "3DMarkup", "Multimedia",
+	"U3D", "PRC", "Action", "EnableJavaScripts", "Attachment", "AttachmentEditing", "Collection",
+	"CollectionEditing", "DigSigValidation", "DigSig", "DigSigMDP", "RichMedia", "Geospatial2D",
+	"Geospatial3D", "DPartInteract", "SeparationSimulation", "Transitions", "Encryption",
+}
+
 func validateRequirementDict(xRefTable *model.XRefTable, d types.Dict, sinceVersion model.Version) error {
 	dictName := "requirementDict"
 
@@ -654,14 +756,31 @@ func validateRequirementDict(xRefTable *model.XRefTable, d types.Dict, sinceVers
 	}
 
 	// S, required, name
-	_, err = validateNameEntry(xRefTable, d, dictName, "S", REQUIRED, sinceVersion, func(s string) bool { return s == "EnableJavaScripts" })
+	validate := func(s string) bool { return s == "EnableJavaScripts" }
+	if xRefTable.Version() >= model.V20 || xRefTable.ValidationMode == model.ValidationRelaxed {
+		validate = func(s string) bool { return types.MemberOf(s, requirementTypes) }
+	}
+	_, err = validateNameEntry(xRefTable, d, dictName, "S", REQUIRED, sinceVersion, validate)
 	if err != nil {
 		return err
 	}
 
 	// The RH entry (requirement handler dicts) shall not be used in PDF 1.7.
 
-	return nil
+	if xRefTable.Version() < model.V20 && xRefTable.ValidationMode == model.ValidationStrict {
+		return nil
+	}
+
+	// V, optional, name
+	_, err = validateNameEntry(xRefTable, d, dictName, "V", OPTIONAL, model.V10, nil)
+	if err != nil {
+		return err
+	}
+
+	// Penalty, optional, integer
+	_, err = validateIntegerEntry(xRefTable, d, dictName, "Penalty", OPTIONAL, model.V10, func(i int) bool { return i >= 0 && i <= 100 })
+
+	return err
 }
 
 func validateRequirements(xRefTable *model.XRefTable, rootDict types.Dict, required bool, sinceVersion model.Version) error {
@@ -33,6 +33,9 @@ import (
 	"github.com/pkg/errors"
 )
 
+// maxXRefTableOffset is the largest offset a cross reference table entry is able to hold.
+const maxXRefTableOffset = 9999999999
+
 func writeObjects(ctx *model.Context) error {
 	// Write root object(aka the document catalog) and page tree.
 	if err := writeRootObject(ctx); err != nil {
@@ -61,7 +64,15 @@ func writeObjects(ctx *model.Context) error {
 }
 
 // Write generates a PDF file for the cross reference table contained in Context.
-func Write(ctx *model.Context) (err error) {
+// With ctx.DryRun set nothing gets written and a summary of the changes is reported instead.
+func Write(ctx *model.Context) error {
+	if ctx.DryRun {
+		return writeDryRun(ctx)
+	}
+	return write(ctx)
+}
+
+func write(ctx *model.Context) (err error) {
 	// Create a writer for dirname and filename if not already supplied.
 	if ctx.Write.Writer == nil {
 
@@ -94,6 +105,10 @@ func Write(ctx *model.Context) (err error) {
 
 	}
 
+	if copyThroughApplicable(ctx) {
+		return writeCopyThrough(ctx)
+	}
+
 	if err = prepareContextForWriting(ctx); err != nil {
 		return err
 	}
@@ -127,6 +142,11 @@ func Write(ctx *model.Context) (err error) {
 		return err
 	}
 
+	// Unless garbage collection is enabled, also write objects that are not referenced.
+	if err := writeUnreferencedObjects(ctx); err != nil {
+		return err
+	}
+
 	// Mark redundant objects as free.
 	// eg. duplicate resources, compressed objects, linearization dicts..
 	deleteRedundantObjects(ctx)
@@ -156,10 +176,8 @@ func Write(ctx *model.Context) (err error) {
 // WriteIncrement writes a PDF increment..
 func WriteIncrement(ctx *model.Context) error {
 	// Write all modified objects that are part of this increment.
-	for _, i := range ctx.Write.ObjNrs {
-		if err := writeFlatObject(ctx, i); err != nil {
-			return err
-		}
+	if err := writeIncrementObjects(ctx, ctx.Write.ObjNrs, ctx.WriteXRefStream); err != nil {
+		return err
 	}
 
 	if err := writeXRef(ctx); err != nil {
@@ -190,6 +208,10 @@ func writeAdditionalStreams(ctx *model.Context) error {
 }
 
 func ensureFileID(ctx *model.Context) error {
+	if ctx.KeepID && len(ctx.ID) == 2 {
+		return nil
+	}
+
 	fid, err := fileID(ctx)
 	if err != nil {
 		return err
@@ -420,7 +442,7 @@ func writeTrailerDict(ctx *model.Context) error {
 		d.Insert("Info", *xRefTable.Info)
 	}
 
-	if ctx.Encrypt != nil && ctx.EncKey != nil {
+	if writeEncrypted(ctx) {
 		d.Insert("Encrypt", *ctx.Encrypt)
 	}
 
@@ -474,6 +496,9 @@ func writeXRefSubsection(ctx *model.Context, start int, size int) error {
 			if found {
 				off = writeOffset
 			}
+			if off > maxXRefTableOffset {
+				return errors.Errorf("pdfcpu: writeXRefSubsection: offset of obj #%d exceeds xref table limit: %d", i, off)
+			}
 			s = fmt.Sprintf("%010d %05d n%2s", off, *entry.Generation, w.Eol)
 		}
 
@@ -526,6 +551,56 @@ func detectLinearizationObjs(xRefTable *model.XRefTable, entry *model.XRefTableE
 	}
 }
 
+func isUnreferencedObject(ctx *model.Context, objNr int) bool {
+	entry, found := ctx.Find(objNr)
+	if !found || entry.Free || entry.Object == nil || ctx.Write.HasWriteOffset(objNr) {
+		return false
+	}
+
+	if ctx.Read.Linearized && entry.Offset != nil {
+		detectLinearizationObjs(ctx.XRefTable, entry, objNr)
+	}
+
+	if ctx.Optimize.IsDuplicateFontObject(objNr) || ctx.Optimize.IsDuplicateImageObject(objNr) ||
+		ctx.Optimize.IsDuplicateInfoObject(objNr) || ctx.IsLinearizationObject(objNr) ||
+		ctx.Read.IsObjectStreamObject(objNr) || ctx.Read.XRefStreams[objNr] {
+		return false
+	}
+
+	switch o := entry.Object.(type) {
+	case types.Dict:
+		if o.Type() != nil && *o.Type() == "Linearized" {
+			return false
+		}
+	case types.XRefStreamDict:
+		// The xref stream read gets replaced by a new one.
+		return false
+	}
+
+	return true
+}
+
+// writeUnreferencedObjects writes all objects that are not referenced
+// if the gc optimization pass is disabled.
+func writeUnreferencedObjects(ctx *model.Context) error {
+	if ctx.Optimize == nil || ctx.OptimizePass(model.OptimizePassGC) ||
+		ctx.Write.Increment || len(ctx.Write.SelectedPages) > 0 {
+		return nil
+	}
+
+	for i := 1; i < *ctx.Size; i++ {
+		if !isUnreferencedObject(ctx, i) {
+			continue
+		}
+		entry, _ := ctx.Find(i)
+		if _, _, err := writeDeepObject(ctx, *types.NewIndirectRef(i, *entry.Generation)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func deleteRedundantObjects(ctx *model.Context) {
 	if ctx.Optimize == nil {
 		return
@@ -793,7 +868,7 @@ func newXRefStreamDict(ctx *model.Context) *types.XRefStreamDict {
 	if ctx.ID != nil {
 		sd.Insert("ID", ctx.ID)
 	}
-	if ctx.Encrypt != nil && ctx.EncKey != nil {
+	if writeEncrypted(ctx) {
 		sd.Insert("Encrypt", *ctx.Encrypt)
 	}
 	if ctx.Write.Increment {
@@ -891,9 +966,15 @@ func writeXRefStream(ctx *model.Context) error {
 	return nil
 }
 
+// writeEncrypted returns true if the output needs an Encrypt entry.
+// Locked embedded file streams are written as is and still depend on their security handler.
+func writeEncrypted(ctx *model.Context) bool {
+	return ctx.Encrypt != nil && (ctx.EncKey != nil || ctx.EmbeddedFilesLocked)
+}
+
 func writeEncryptDict(ctx *model.Context) error {
 	// Bail out unless we really have to write encrypted.
-	if ctx.Encrypt == nil || ctx.EncKey == nil {
+	if !writeEncrypted(ctx) {
 		return nil
 	}
 
@@ -916,11 +997,16 @@ func setupEncryption(ctx *model.Context) error {
 		return errors.New("pdfcpu: unsupported encryption algorithm (PDF 2.0 assumes AES/256)")
 	}
 
+	if ctx.EncryptAttachmentsOnly && ctx.EncryptKeyLength < 128 {
+		return errors.New("pdfcpu: encrypting attachments only requires a key length of at least 128 bits")
+	}
+
 	d := newEncryptDict(
 		ctx.XRefTable.Version(),
 		ctx.EncryptUsingAES,
 		ctx.EncryptKeyLength,
 		int16(ctx.Permissions),
+		ctx.EncryptAttachmentsOnly,
 	)
 
 	if ctx.E, err = supportedEncryption(ctx, d); err != nil {
@@ -1013,6 +1099,36 @@ func updateEncryption(ctx *model.Context) error {
 	return nil
 }
 
+// replaceEncryption sets up a new security handler using the current passwords.
+func replaceEncryption(ctx *model.Context) error {
+	if ctx.Encrypt == nil {
+		return errors.New("pdfcpu: This file is not encrypted - nothing written.")
+	}
+
+	if !ctx.UpdatePermissions {
+		ctx.Permissions = model.PermissionFlags(ctx.E.P)
+	}
+
+	if err := ctx.FreeObject(ctx.Encrypt.ObjectNumber.Value()); err != nil {
+		return err
+	}
+	ctx.Encrypt = nil
+
+	if err := setupEncryption(ctx); err != nil {
+		return err
+	}
+
+	if log.CLIEnabled() {
+		alg := "RC4"
+		if ctx.EncryptUsingAES {
+			alg = "AES"
+		}
+		log.CLI.Printf("using %s-%d\n", alg, ctx.EncryptKeyLength)
+	}
+
+	return nil
+}
+
 func handleEncryption(ctx *model.Context) error {
 
 	if ctx.Cmd == model.ENCRYPT || ctx.Cmd == model.DECRYPT {
@@ -1037,6 +1153,12 @@ func handleEncryption(ctx *model.Context) error {
 			}
 		}
 
+	} else if ctx.Cmd == model.UPDATEENCRYPTION {
+
+		if err := replaceEncryption(ctx); err != nil {
+			return err
+		}
+
 	} else if ctx.UserPWNew != nil || ctx.OwnerPWNew != nil || ctx.Cmd == model.SETPERMISSIONS {
 
 		if err := updateEncryption(ctx); err != nil {
@@ -1045,6 +1167,11 @@ func handleEncryption(ctx *model.Context) error {
 
 	}
 
+	if ctx.EncKey != nil {
+		// Embedded files may have been added or removed since reading.
+		ctx.EmbeddedFileStreams = ctx.EmbeddedFileStreamObjNrs()
+	}
+
 	// write xrefstream if using xrefstream only.
 	if ctx.Encrypt != nil && ctx.EncKey != nil && !ctx.Read.UsingXRefStreams {
 		ctx.WriteObjectStream = false
@@ -1054,9 +1181,17 @@ func handleEncryption(ctx *model.Context) error {
 	return nil
 }
 
+// xRefTableOverflow returns true if the objects written so far can't be addressed by a cross reference table
+// whose entries are limited to 10 digit offsets.
+func xRefTableOverflow(w *model.WriteContext) bool {
+	// The current write offset is beyond any object offset written.
+	return w.Offset > maxXRefTableOffset
+}
+
 func writeXRef(ctx *model.Context) error {
-	if ctx.WriteXRefStream {
+	if ctx.WriteXRefStream || xRefTableOverflow(ctx.Write) {
 		// Write cross reference stream and generate objectstreams.
+		// Files beyond 10 digit offsets always get a cross reference stream.
 		return writeXRefStream(ctx)
 	}
 
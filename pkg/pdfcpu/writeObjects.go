@@ -294,7 +294,7 @@ func writeStringLiteralObject(ctx *model.Context, objNumber, genNumber int, sl t
 		return nil
 	}
 
-	if ctx.EncKey != nil {
+	if ctx.EncKey != nil && ctx.EncStrings {
 		sl1, err := encryptStringLiteral(sl, objNumber, genNumber, ctx.EncKey, ctx.AES4Strings, ctx.E.R)
 		if err != nil {
 			return err
@@ -316,7 +316,7 @@ func writeHexLiteralObject(ctx *model.Context, objNumber, genNumber int, hl type
 		return nil
 	}
 
-	if ctx.EncKey != nil {
+	if ctx.EncKey != nil && ctx.EncStrings {
 		hl1, err := encryptHexLiteral(hl, objNumber, genNumber, ctx.EncKey, ctx.AES4Strings, ctx.E.R)
 		if err != nil {
 			return err
@@ -364,7 +364,7 @@ func writeDictObject(ctx *model.Context, objNumber, genNumber int, d types.Dict)
 		return nil
 	}
 
-	if ctx.EncKey != nil {
+	if ctx.EncKey != nil && ctx.EncStrings {
 		_, err := encryptDeepObject(d, objNumber, genNumber, ctx.EncKey, ctx.AES4Strings, ctx.E.R)
 		if err != nil {
 			return err
@@ -384,7 +384,7 @@ func writeArrayObject(ctx *model.Context, objNumber, genNumber int, a types.Arra
 		return nil
 	}
 
-	if ctx.EncKey != nil {
+	if ctx.EncKey != nil && ctx.EncStrings {
 		if _, err := encryptDeepObject(a, objNumber, genNumber, ctx.EncKey, ctx.AES4Strings, ctx.E.R); err != nil {
 			return err
 		}
@@ -399,11 +399,11 @@ func writeStream(w *model.WriteContext, sd types.StreamDict) (int64, error) {
 		return 0, errors.Wrapf(err, "writeStream: failed to write raw content")
 	}
 
-	c, err := w.Write(sd.Raw)
+	c, err := sd.CopyRaw(w)
 	if err != nil {
 		return 0, errors.Wrapf(err, "writeStream: failed to write raw content")
 	}
-	if int64(c) != *sd.StreamLength {
+	if c != *sd.StreamLength {
 		return 0, errors.Errorf("writeStream: failed to write raw content: %d bytes written - streamlength:%d", c, *sd.StreamLength)
 	}
 
@@ -485,11 +485,16 @@ func writeStreamDictObject(ctx *model.Context, objNr, genNr int, sd types.Stream
 
 	// Unless the "Identity" crypt filter is used we have to encrypt.
 	isXRefStreamDict := sd.Type() != nil && *sd.Type() == "XRef"
-	if ctx.EncKey != nil &&
+	enc, aes := streamEncryption(ctx, objNr)
+	if ctx.EncKey != nil && enc &&
 		!isXRefStreamDict &&
 		!(len(sd.FilterPipeline) == 1 && sd.FilterPipeline[0].Name == "Crypt") {
 
-		if sd.Raw, err = encryptStream(sd.Raw, objNr, genNr, ctx.EncKey, ctx.AES4Streams, ctx.E.R); err != nil {
+		if err = sd.Load(); err != nil {
+			return err
+		}
+
+		if sd.Raw, err = encryptStream(sd.Raw, objNr, genNr, ctx.EncKey, aes, ctx.E.R); err != nil {
 			return err
 		}
 
@@ -603,7 +608,7 @@ func writeDeepDict(ctx *model.Context, d types.Dict, objNr, genNr int) error {
 }
 
 func writeDeepStreamDict(ctx *model.Context, sd *types.StreamDict, objNr, genNr int) error {
-	if ctx.EncKey != nil {
+	if ctx.EncKey != nil && ctx.EncStrings {
 		if _, err := encryptDeepObject(*sd, objNr, genNr, ctx.EncKey, ctx.AES4Strings, ctx.E.R); err != nil {
 			return err
 		}
@@ -780,6 +785,32 @@ func writeEntry(ctx *model.Context, d types.Dict, dictName, entryName string) (t
 	return o, nil
 }
 
+// writeIncrementObjects writes the objects of an incremental update.
+// If ctx.CompressIncrement is set and the increment is going to use a cross reference stream,
+// non-stream objects get packed into new object streams.
+func writeIncrementObjects(ctx *model.Context, objNrs []int, xRefStream bool) error {
+	compress := ctx.CompressIncrement && xRefStream && ctx.WriteObjectStream
+
+	if compress {
+		// writeToObjectStream assumes an xRefStream to be generated.
+		defer func(b bool) { ctx.WriteXRefStream = b }(ctx.WriteXRefStream)
+		ctx.WriteXRefStream = true
+		ctx.Write.WriteToObjectStream = true
+	}
+
+	for _, objNr := range objNrs {
+		if err := writeFlatObject(ctx, objNr); err != nil {
+			return err
+		}
+	}
+
+	if !compress {
+		return nil
+	}
+
+	return stopObjectStream(ctx)
+}
+
 func writeFlatObject(ctx *model.Context, objNr int) error {
 	e, ok := ctx.FindTableEntryLight(objNr)
 	if !ok {
@@ -17,10 +17,9 @@ limitations under the License.
 package pdfcpu
 
 import (
-	"fmt"
+	"encoding/json"
 	"os"
 	"path/filepath"
-	"strings"
 
 	"github.com/pdfcpu/pdfcpu/pkg/log"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
@@ -77,188 +76,94 @@ func logWriteStats(ctx *model.Context) {
 	log.Stats.Printf("%d original linearization entries: %s", l, str)
 }
 
-func statsHeadLine() *string {
-
-	hl := "name;version;author;creator;producer;src_size (bin|text);src_bin:imgs|fonts|other;dest_size (bin|text);dest_bin:imgs|fonts|other;"
-	hl += "linearized;hybrid;xrefstr;objstr;pages;objs;missing;garbage;"
-	hl += "R_Version;R_Extensions;R_PageLabels;R_Names;R_Dests;R_ViewerPrefs;R_PageLayout;R_PageMode;"
-	hl += "R_Outlines;R_Threads;R_OpenAction;R_AA;R_URI;R_AcroForm;R_Metadata;R_StructTreeRoot;R_MarkInfo;"
-	hl += "R_Lang;R_SpiderInfo;R_OutputIntents;R_PieceInfo;R_OCProperties;R_Perms;R_Legal;R_Requirements;"
-	hl += "R_Collection;R_NeedsRendering;"
-	hl += "P_LastModified;P_Resources;P_MediaBox;P_CropBox;P_BleedBox;P_TrimBox;P_ArtBox;"
-	hl += "P_BoxColorInfo;P_Contents;P_Rotate;P_Group;P_Thumb;P_B;P_Dur;P_Trans;P_Annots;"
-	hl += "P_AA;P_Metadata;P_PieceInfo;P_StructParents;P_ID;P_PZ;P_SeparationInfo;P_Tabs;"
-	hl += "P_TemplateInstantiated;P_PresSteps;P_UserUnit;P_VP;\n"
-
-	return &hl
+// OptimizePassReport represents the savings of an optimization pass.
+type OptimizePassReport struct {
+	Pass       string `json:"pass"`
+	Objects    int    `json:"objects"`
+	BytesSaved int64  `json:"bytesSaved"`
 }
 
-func statsLine(ctx *model.Context) *string {
-
-	xRefTable := ctx.XRefTable
-
-	version := xRefTable.HeaderVersion.String()
-	if xRefTable.RootVersion != nil {
-		version = fmt.Sprintf("%s,%s", version, xRefTable.RootVersion.String())
-	}
+// OptimizeReport represents the outcome of an optimization.
+type OptimizeReport struct {
+	File       string               `json:"file,omitempty"`
+	SizeBefore int64                `json:"sizeBefore"`
+	SizeAfter  int64                `json:"sizeAfter"`
+	Passes     []OptimizePassReport `json:"passes"`
+}
 
-	sourceFileSize := ctx.Read.FileSize
-	sourceBinarySize := ctx.Read.BinaryTotalSize
-	sourceNonBinarySize := sourceFileSize - sourceBinarySize
-
-	sourceSizeStats := fmt.Sprintf("%s (%4.1f%% | %4.1f%%)",
-		types.ByteSize(sourceFileSize),
-		float32(sourceBinarySize)/float32(sourceFileSize)*100,
-		float32(sourceNonBinarySize)/float32(sourceFileSize)*100)
-
-	sourceBinaryImageSize := ctx.Read.BinaryImageSize + ctx.Read.BinaryImageDuplSize
-	sourceBinaryFontSize := ctx.Read.BinaryFontSize + ctx.Read.BinaryFontDuplSize
-	sourceBinaryOtherSize := sourceBinarySize - sourceBinaryImageSize - sourceBinaryFontSize
-
-	sourceBinaryStats := fmt.Sprintf("%4.1f%% | %4.1f%% | %4.1f%%",
-		float32(sourceBinaryImageSize)/float32(sourceBinarySize)*100,
-		float32(sourceBinaryFontSize)/float32(sourceBinarySize)*100,
-		float32(sourceBinaryOtherSize)/float32(sourceBinarySize)*100)
-
-	destFileSize := ctx.Write.FileSize
-	destBinarySize := ctx.Write.BinaryTotalSize
-	destNonBinarySize := destFileSize - destBinarySize
-
-	destSizeStats := fmt.Sprintf("%s (%4.1f%% | %4.1f%%)",
-		types.ByteSize(destFileSize),
-		float32(destBinarySize)/float32(destFileSize)*100,
-		float32(destNonBinarySize)/float32(destFileSize)*100)
-
-	destBinaryImageSize := ctx.Write.BinaryImageSize
-	destBinaryFontSize := ctx.Write.BinaryFontSize
-	destBinaryOtherSize := destBinarySize - destBinaryImageSize - destBinaryFontSize
-
-	destBinaryStats := fmt.Sprintf("%4.1f%% | %4.1f%% | %4.1f%%",
-		float32(destBinaryImageSize)/float32(destBinarySize)*100,
-		float32(destBinaryFontSize)/float32(destBinarySize)*100,
-		float32(destBinaryOtherSize)/float32(destBinarySize)*100)
-
-	var missingObjs string
-	if count, mstr := xRefTable.MissingObjects(); count > 0 {
-		missingObjs = fmt.Sprintf("%d:%s", count, *mstr)
-	}
+func unreferencedObjectStats(ctx *model.Context) (int, int64) {
+	var (
+		objs  int
+		bytes int64
+	)
 
-	var nonreferencedObjs string
-	if len(ctx.Optimize.NonReferencedObjs) > 0 {
-		var s []string
-		for _, o := range ctx.Optimize.NonReferencedObjs {
-			s = append(s, fmt.Sprintf("%d", o))
+	for i := 1; i < *ctx.Size; i++ {
+		entry, found := ctx.Find(i)
+		if !found || entry.Free || ctx.Write.HasWriteOffset(i) {
+			continue
+		}
+		objs++
+		if sd, ok := entry.Object.(types.StreamDict); ok && sd.StreamLength != nil {
+			bytes += *sd.StreamLength
 		}
-		nonreferencedObjs = fmt.Sprintf("%d:%s", len(ctx.Optimize.NonReferencedObjs), strings.Join(s, ","))
 	}
 
-	line := fmt.Sprintf("%s;%s;%s;%s;%s;%s;%s;%s;%s;%v;%v;%v;%v;%d;%d;%s;%s;%v;%v;%v;%v;%v;%v;%v;%v;%v;%v;%v;%v;%v;%v;%v;%v;%v;%v;%v;%v;%v;%v;%v;%v;%v;%v;%v;%v;%v;%v;%v;%v;%v;%v;%v;%v;%v;%v;%v;%v;%v;%v;%v;%v;%v;%v;%v;%v;%v;%v;%v;%v;%v;%v;%v\n",
-		filepath.Base(ctx.Read.FileName),
-		version,
-		xRefTable.Author,
-		xRefTable.Creator,
-		xRefTable.Producer,
-		sourceSizeStats,
-		sourceBinaryStats,
-		destSizeStats,
-		destBinaryStats,
-		ctx.Read.Linearized,
-		ctx.Read.Hybrid,
-		ctx.Read.UsingXRefStreams,
-		ctx.Read.UsingObjectStreams,
-		xRefTable.PageCount,
-		*xRefTable.Size,
-		missingObjs,
-		nonreferencedObjs,
-		xRefTable.Stats.UsesRootAttr(model.RootVersion),
-		xRefTable.Stats.UsesRootAttr(model.RootExtensions),
-		xRefTable.Stats.UsesRootAttr(model.RootPageLabels),
-		xRefTable.Stats.UsesRootAttr(model.RootNames),
-		xRefTable.Stats.UsesRootAttr(model.RootDests),
-		xRefTable.Stats.UsesRootAttr(model.RootViewerPrefs),
-		xRefTable.Stats.UsesRootAttr(model.RootPageLayout),
-		xRefTable.Stats.UsesRootAttr(model.RootPageMode),
-		xRefTable.Stats.UsesRootAttr(model.RootOutlines),
-		xRefTable.Stats.UsesRootAttr(model.RootThreads),
-		xRefTable.Stats.UsesRootAttr(model.RootOpenAction),
-		xRefTable.Stats.UsesRootAttr(model.RootAA),
-		xRefTable.Stats.UsesRootAttr(model.RootURI),
-		xRefTable.Stats.UsesRootAttr(model.RootAcroForm),
-		xRefTable.Stats.UsesRootAttr(model.RootMetadata),
-		xRefTable.Stats.UsesRootAttr(model.RootStructTreeRoot),
-		xRefTable.Stats.UsesRootAttr(model.RootMarkInfo),
-		xRefTable.Stats.UsesRootAttr(model.RootLang),
-		xRefTable.Stats.UsesRootAttr(model.RootSpiderInfo),
-		xRefTable.Stats.UsesRootAttr(model.RootOutputIntents),
-		xRefTable.Stats.UsesRootAttr(model.RootPieceInfo),
-		xRefTable.Stats.UsesRootAttr(model.RootOCProperties),
-		xRefTable.Stats.UsesRootAttr(model.RootPerms),
-		xRefTable.Stats.UsesRootAttr(model.RootLegal),
-		xRefTable.Stats.UsesRootAttr(model.RootRequirements),
-		xRefTable.Stats.UsesRootAttr(model.RootCollection),
-		xRefTable.Stats.UsesRootAttr(model.RootNeedsRendering),
-		xRefTable.Stats.UsesPageAttr(model.PageLastModified),
-		xRefTable.Stats.UsesPageAttr(model.PageResources),
-		xRefTable.Stats.UsesPageAttr(model.PageMediaBox),
-		xRefTable.Stats.UsesPageAttr(model.PageCropBox),
-		xRefTable.Stats.UsesPageAttr(model.PageBleedBox),
-		xRefTable.Stats.UsesPageAttr(model.PageTrimBox),
-		xRefTable.Stats.UsesPageAttr(model.PageArtBox),
-		xRefTable.Stats.UsesPageAttr(model.PageBoxColorInfo),
-		xRefTable.Stats.UsesPageAttr(model.PageContents),
-		xRefTable.Stats.UsesPageAttr(model.PageRotate),
-		xRefTable.Stats.UsesPageAttr(model.PageGroup),
-		xRefTable.Stats.UsesPageAttr(model.PageThumb),
-		xRefTable.Stats.UsesPageAttr(model.PageB),
-		xRefTable.Stats.UsesPageAttr(model.PageDur),
-		xRefTable.Stats.UsesPageAttr(model.PageTrans),
-		xRefTable.Stats.UsesPageAttr(model.PageAnnots),
-		xRefTable.Stats.UsesPageAttr(model.PageAA),
-		xRefTable.Stats.UsesPageAttr(model.PageMetadata),
-		xRefTable.Stats.UsesPageAttr(model.PagePieceInfo),
-		xRefTable.Stats.UsesPageAttr(model.PageStructParents),
-		xRefTable.Stats.UsesPageAttr(model.PageID),
-		xRefTable.Stats.UsesPageAttr(model.PagePZ),
-		xRefTable.Stats.UsesPageAttr(model.PageSeparationInfo),
-		xRefTable.Stats.UsesPageAttr(model.PageTabs),
-		xRefTable.Stats.UsesPageAttr(model.PageTemplateInstantiated),
-		xRefTable.Stats.UsesPageAttr(model.PagePresSteps),
-		xRefTable.Stats.UsesPageAttr(model.PageUserUnit),
-		xRefTable.Stats.UsesPageAttr(model.PageVP))
-
-	return &line
+	return objs, bytes
 }
 
-// AppendStatsFile appends a stats line for this xRefTable to the configured csv file name.
-func AppendStatsFile(ctx *model.Context) error {
-
-	fileName := ctx.StatsFileName
+// NewOptimizeReport returns the optimization report for ctx after it has been written.
+func NewOptimizeReport(ctx *model.Context) *OptimizeReport {
+	r := &OptimizeReport{
+		SizeBefore: ctx.Read.FileSize,
+		SizeAfter:  ctx.Write.FileSize,
+		Passes:     []OptimizePassReport{},
+	}
 
-	// if file does not exist, create file
-	file, err := os.OpenFile(fileName, os.O_APPEND|os.O_WRONLY, 0600)
-	if err != nil {
+	if ctx.Read.FileName != "" {
+		r.File = filepath.Base(ctx.Read.FileName)
+	}
 
-		if os.IsExist(err) {
-			return errors.Errorf("can't open %s\n%s", fileName, err)
-		}
+	if r.SizeAfter == 0 {
+		// Writer based output.
+		r.SizeAfter = ctx.Write.Offset
+	}
 
-		file, err = os.OpenFile(fileName, os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0600)
-		if err != nil {
-			return errors.Errorf("can't create %s\n%s", fileName, err)
+	for _, pass := range model.AllOptimizePasses {
+		if !ctx.OptimizePass(pass) {
+			continue
 		}
 
-		_, err = file.WriteString(*statsHeadLine())
-		if err != nil {
-			return err
+		pr := OptimizePassReport{Pass: pass}
+
+		switch pass {
+		case model.OptimizePassFonts:
+			pr.Objects, pr.BytesSaved = len(ctx.Optimize.DuplicateFonts), ctx.Read.BinaryFontDuplSize
+		case model.OptimizePassImages:
+			pr.Objects, pr.BytesSaved = len(ctx.Optimize.DuplicateImages), ctx.Read.BinaryImageDuplSize
+		case model.OptimizePassGC:
+			pr.Objects, pr.BytesSaved = unreferencedObjectStats(ctx)
+		default:
+			if st, ok := ctx.Optimize.PassStats[pass]; ok {
+				pr.Objects, pr.BytesSaved = st.Objects, st.Bytes
+			}
 		}
 
+		r.Passes = append(r.Passes, pr)
 	}
 
-	defer func() {
-		file.Close()
-	}()
+	return r
+}
 
-	_, err = file.WriteString(*statsLine(ctx))
+// WriteOptimizeReportFile writes the optimization report for ctx to the configured JSON file name.
+func WriteOptimizeReportFile(ctx *model.Context) error {
+	bb, err := json.MarshalIndent(NewOptimizeReport(ctx), "", "\t")
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(ctx.StatsFileName, append(bb, '\n'), 0600); err != nil {
+		return errors.Errorf("can't write %s\n%s", ctx.StatsFileName, err)
+	}
 
-	return err
+	return nil
 }
@@ -0,0 +1,165 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// largeOffset is beyond both 4GB and the 10 digit limit of xref table entries.
+const largeOffset = int64(maxXRefTableOffset) + 1
+
+func TestWriteXRefLargeOffsets(t *testing.T) {
+	msg := "TestWriteXRefLargeOffsets"
+
+	ctx, err := CreateContextWithXRefTable(nil, types.PaperSize["A4"])
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// Ask for a cross reference table and pretend largeOffset bytes have already been written.
+	ctx.WriteXRefStream = false
+	ctx.WriteObjectStream = false
+
+	var buf bytes.Buffer
+	ctx.Write = model.NewWriteContext("\n")
+	ctx.Write.Writer = bufio.NewWriter(&buf)
+	ctx.Write.Offset = largeOffset
+
+	if err := writeObjects(ctx); err != nil {
+		t.Fatalf("%s writeObjects: %v\n", msg, err)
+	}
+
+	if err := writeXRef(ctx); err != nil {
+		t.Fatalf("%s writeXRef: %v\n", msg, err)
+	}
+
+	if err := ctx.Write.Flush(); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	s := buf.String()
+
+	if strings.Contains(s, "\nxref\n") {
+		t.Fatalf("%s: unexpected xref table", msg)
+	}
+
+	// Offsets beyond 4GB need 5 bytes.
+	if !regexp.MustCompile(`/W\s*\[1 5 2\]`).MatchString(s) {
+		t.Fatalf("%s: missing xref stream with 5 byte offsets", msg)
+	}
+
+	if err := writeXRefTable(ctx); err == nil {
+		t.Fatalf("%s: xref table with 11 digit offsets should fail", msg)
+	}
+}
+
+// writeSparsePDF writes a single page PDF whose objects and cross reference stream
+// are located beyond offset, leaving a hole at the beginning of the file.
+func writeSparsePDF(fileName string, offset int64) error {
+	f, err := os.Create(fileName)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if _, err := f.WriteString("%PDF-1.7\n"); err != nil {
+		return err
+	}
+
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return err
+	}
+
+	objs := []string{
+		"<</Type/Catalog/Pages 2 0 R>>",
+		"<</Type/Pages/Kids[3 0 R]/Count 1>>",
+		"<</Type/Page/Parent 2 0 R/MediaBox[0 0 595 842]>>",
+	}
+
+	var (
+		buf  bytes.Buffer
+		offs []int64
+	)
+
+	for i, o := range objs {
+		offs = append(offs, offset+int64(buf.Len()))
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, o)
+	}
+
+	xRefOffset := offset + int64(buf.Len())
+	offs = append(offs, xRefOffset)
+
+	// Entry for the free object 0 followed by 4 entries with 5 byte offsets.
+	entries := []byte{0, 0, 0, 0, 0, 0, 0xff, 0xff}
+	for _, off := range offs {
+		entries = append(entries, 1, byte(off>>32), byte(off>>24), byte(off>>16), byte(off>>8), byte(off), 0, 0)
+	}
+
+	fmt.Fprintf(&buf, "4 0 obj\n<</Type/XRef/Size 5/W[1 5 2]/Root 1 0 R/Length %d>>\nstream\n", len(entries))
+	buf.Write(entries)
+	fmt.Fprintf(&buf, "\nendstream\nendobj\nstartxref\n%d\n%%%%EOF\n", xRefOffset)
+
+	_, err = f.Write(buf.Bytes())
+	return err
+}
+
+func TestReadSparseLargeFile(t *testing.T) {
+	msg := "TestReadSparseLargeFile"
+
+	if testing.Short() {
+		t.Skip("skipping large sparse file test in short mode")
+	}
+
+	fileName := filepath.Join(t.TempDir(), "large.pdf")
+
+	if err := writeSparsePDF(fileName, largeOffset); err != nil {
+		t.Skipf("%s: unable to create sparse file: %v\n", msg, err)
+	}
+
+	ctx, err := ReadFile(fileName, model.NewDefaultConfiguration())
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	for objNr := 1; objNr <= 4; objNr++ {
+		entry, found := ctx.Find(objNr)
+		if !found || entry.Offset == nil || *entry.Offset < largeOffset {
+			t.Fatalf("%s: obj #%d: missing offset beyond %d", msg, objNr, largeOffset)
+		}
+	}
+
+	d, err := ctx.Catalog()
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if ir := d.IndirectRefEntry("Pages"); ir == nil || ir.ObjectNumber.Value() != 2 {
+		t.Fatalf("%s: corrupt catalog: %s", msg, d)
+	}
+}